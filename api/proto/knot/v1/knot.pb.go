@@ -0,0 +1,2125 @@
+// Protobuf definitions for the knot gRPC API served by 'knot serve grpc'.
+//
+// The service mirrors the operations of the public Go SDK (pkg/knot) so that
+// IDE plugins and bots written in other languages get typed clients. IDs are
+// UUID strings, timestamps use google.protobuf.Timestamp. Servers with users
+// or an admin token expect the token as "authorization: Bearer <token>"
+// metadata, and the role of the user on a project decides what it may do.
+//
+// Regenerate the Go code next to this file with 'go generate ./api/...',
+// which needs protoc, protoc-gen-go and protoc-gen-go-grpc.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: knot/v1/knot.proto
+
+package knotv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TaskState int32
+
+const (
+	TaskState_TASK_STATE_UNSPECIFIED      TaskState = 0
+	TaskState_TASK_STATE_PENDING          TaskState = 1
+	TaskState_TASK_STATE_IN_PROGRESS      TaskState = 2
+	TaskState_TASK_STATE_COMPLETED        TaskState = 3
+	TaskState_TASK_STATE_BLOCKED          TaskState = 4
+	TaskState_TASK_STATE_CANCELLED        TaskState = 5
+	TaskState_TASK_STATE_DELETION_PENDING TaskState = 6
+)
+
+// Enum value maps for TaskState.
+var (
+	TaskState_name = map[int32]string{
+		0: "TASK_STATE_UNSPECIFIED",
+		1: "TASK_STATE_PENDING",
+		2: "TASK_STATE_IN_PROGRESS",
+		3: "TASK_STATE_COMPLETED",
+		4: "TASK_STATE_BLOCKED",
+		5: "TASK_STATE_CANCELLED",
+		6: "TASK_STATE_DELETION_PENDING",
+	}
+	TaskState_value = map[string]int32{
+		"TASK_STATE_UNSPECIFIED":      0,
+		"TASK_STATE_PENDING":          1,
+		"TASK_STATE_IN_PROGRESS":      2,
+		"TASK_STATE_COMPLETED":        3,
+		"TASK_STATE_BLOCKED":          4,
+		"TASK_STATE_CANCELLED":        5,
+		"TASK_STATE_DELETION_PENDING": 6,
+	}
+)
+
+func (x TaskState) Enum() *TaskState {
+	p := new(TaskState)
+	*p = x
+	return p
+}
+
+func (x TaskState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskState) Descriptor() protoreflect.EnumDescriptor {
+	return file_knot_v1_knot_proto_enumTypes[0].Descriptor()
+}
+
+func (TaskState) Type() protoreflect.EnumType {
+	return &file_knot_v1_knot_proto_enumTypes[0]
+}
+
+func (x TaskState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskState.Descriptor instead.
+func (TaskState) EnumDescriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{0}
+}
+
+// Values match the knot priorities: 1=high, 2=medium, 3=low
+type TaskPriority int32
+
+const (
+	TaskPriority_TASK_PRIORITY_UNSPECIFIED TaskPriority = 0
+	TaskPriority_TASK_PRIORITY_HIGH        TaskPriority = 1
+	TaskPriority_TASK_PRIORITY_MEDIUM      TaskPriority = 2
+	TaskPriority_TASK_PRIORITY_LOW         TaskPriority = 3
+)
+
+// Enum value maps for TaskPriority.
+var (
+	TaskPriority_name = map[int32]string{
+		0: "TASK_PRIORITY_UNSPECIFIED",
+		1: "TASK_PRIORITY_HIGH",
+		2: "TASK_PRIORITY_MEDIUM",
+		3: "TASK_PRIORITY_LOW",
+	}
+	TaskPriority_value = map[string]int32{
+		"TASK_PRIORITY_UNSPECIFIED": 0,
+		"TASK_PRIORITY_HIGH":        1,
+		"TASK_PRIORITY_MEDIUM":      2,
+		"TASK_PRIORITY_LOW":         3,
+	}
+)
+
+func (x TaskPriority) Enum() *TaskPriority {
+	p := new(TaskPriority)
+	*p = x
+	return p
+}
+
+func (x TaskPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_knot_v1_knot_proto_enumTypes[1].Descriptor()
+}
+
+func (TaskPriority) Type() protoreflect.EnumType {
+	return &file_knot_v1_knot_proto_enumTypes[1]
+}
+
+func (x TaskPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskPriority.Descriptor instead.
+func (TaskPriority) EnumDescriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{1}
+}
+
+type ProjectState int32
+
+const (
+	ProjectState_PROJECT_STATE_UNSPECIFIED      ProjectState = 0
+	ProjectState_PROJECT_STATE_ACTIVE           ProjectState = 1
+	ProjectState_PROJECT_STATE_COMPLETED        ProjectState = 2
+	ProjectState_PROJECT_STATE_ARCHIVED         ProjectState = 3
+	ProjectState_PROJECT_STATE_DELETION_PENDING ProjectState = 4
+)
+
+// Enum value maps for ProjectState.
+var (
+	ProjectState_name = map[int32]string{
+		0: "PROJECT_STATE_UNSPECIFIED",
+		1: "PROJECT_STATE_ACTIVE",
+		2: "PROJECT_STATE_COMPLETED",
+		3: "PROJECT_STATE_ARCHIVED",
+		4: "PROJECT_STATE_DELETION_PENDING",
+	}
+	ProjectState_value = map[string]int32{
+		"PROJECT_STATE_UNSPECIFIED":      0,
+		"PROJECT_STATE_ACTIVE":           1,
+		"PROJECT_STATE_COMPLETED":        2,
+		"PROJECT_STATE_ARCHIVED":         3,
+		"PROJECT_STATE_DELETION_PENDING": 4,
+	}
+)
+
+func (x ProjectState) Enum() *ProjectState {
+	p := new(ProjectState)
+	*p = x
+	return p
+}
+
+func (x ProjectState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProjectState) Descriptor() protoreflect.EnumDescriptor {
+	return file_knot_v1_knot_proto_enumTypes[2].Descriptor()
+}
+
+func (ProjectState) Type() protoreflect.EnumType {
+	return &file_knot_v1_knot_proto_enumTypes[2]
+}
+
+func (x ProjectState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProjectState.Descriptor instead.
+func (ProjectState) EnumDescriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{2}
+}
+
+type ChangeEvent_Kind int32
+
+const (
+	ChangeEvent_KIND_UNSPECIFIED        ChangeEvent_Kind = 0
+	ChangeEvent_KIND_PROJECT_CREATED    ChangeEvent_Kind = 1
+	ChangeEvent_KIND_PROJECT_UPDATED    ChangeEvent_Kind = 2
+	ChangeEvent_KIND_PROJECT_DELETED    ChangeEvent_Kind = 3
+	ChangeEvent_KIND_TASK_CREATED       ChangeEvent_Kind = 4
+	ChangeEvent_KIND_TASK_UPDATED       ChangeEvent_Kind = 5
+	ChangeEvent_KIND_TASK_DELETED       ChangeEvent_Kind = 6
+	ChangeEvent_KIND_DEPENDENCY_ADDED   ChangeEvent_Kind = 7
+	ChangeEvent_KIND_DEPENDENCY_REMOVED ChangeEvent_Kind = 8
+	ChangeEvent_KIND_DEPENDENCY_UPDATED ChangeEvent_Kind = 9
+)
+
+// Enum value maps for ChangeEvent_Kind.
+var (
+	ChangeEvent_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_PROJECT_CREATED",
+		2: "KIND_PROJECT_UPDATED",
+		3: "KIND_PROJECT_DELETED",
+		4: "KIND_TASK_CREATED",
+		5: "KIND_TASK_UPDATED",
+		6: "KIND_TASK_DELETED",
+		7: "KIND_DEPENDENCY_ADDED",
+		8: "KIND_DEPENDENCY_REMOVED",
+		9: "KIND_DEPENDENCY_UPDATED",
+	}
+	ChangeEvent_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED":        0,
+		"KIND_PROJECT_CREATED":    1,
+		"KIND_PROJECT_UPDATED":    2,
+		"KIND_PROJECT_DELETED":    3,
+		"KIND_TASK_CREATED":       4,
+		"KIND_TASK_UPDATED":       5,
+		"KIND_TASK_DELETED":       6,
+		"KIND_DEPENDENCY_ADDED":   7,
+		"KIND_DEPENDENCY_REMOVED": 8,
+		"KIND_DEPENDENCY_UPDATED": 9,
+	}
+)
+
+func (x ChangeEvent_Kind) Enum() *ChangeEvent_Kind {
+	p := new(ChangeEvent_Kind)
+	*p = x
+	return p
+}
+
+func (x ChangeEvent_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeEvent_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_knot_v1_knot_proto_enumTypes[3].Descriptor()
+}
+
+func (ChangeEvent_Kind) Type() protoreflect.EnumType {
+	return &file_knot_v1_knot_proto_enumTypes[3]
+}
+
+func (x ChangeEvent_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeEvent_Kind.Descriptor instead.
+func (ChangeEvent_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{22, 0}
+}
+
+type Project struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	State          ProjectState           `protobuf:"varint,4,opt,name=state,proto3,enum=knot.v1.ProjectState" json:"state,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreatedBy      string                 `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedBy      string                 `protobuf:"bytes,8,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	TotalTasks     int32                  `protobuf:"varint,9,opt,name=total_tasks,json=totalTasks,proto3" json:"total_tasks,omitempty"`
+	CompletedTasks int32                  `protobuf:"varint,10,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
+	// Completed share of the tasks in percent
+	Progress      float64 `protobuf:"fixed64,11,opt,name=progress,proto3" json:"progress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_knot_v1_knot_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Project) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Project) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Project) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Project) GetState() ProjectState {
+	if x != nil {
+		return x.State
+	}
+	return ProjectState_PROJECT_STATE_UNSPECIFIED
+}
+
+func (x *Project) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Project) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Project) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Project) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Project) GetTotalTasks() int32 {
+	if x != nil {
+		return x.TotalTasks
+	}
+	return 0
+}
+
+func (x *Project) GetCompletedTasks() int32 {
+	if x != nil {
+		return x.CompletedTasks
+	}
+	return 0
+}
+
+func (x *Project) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+type ProjectProgress struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId       string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	TotalTasks      int32                  `protobuf:"varint,2,opt,name=total_tasks,json=totalTasks,proto3" json:"total_tasks,omitempty"`
+	CompletedTasks  int32                  `protobuf:"varint,3,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
+	InProgressTasks int32                  `protobuf:"varint,4,opt,name=in_progress_tasks,json=inProgressTasks,proto3" json:"in_progress_tasks,omitempty"`
+	PendingTasks    int32                  `protobuf:"varint,5,opt,name=pending_tasks,json=pendingTasks,proto3" json:"pending_tasks,omitempty"`
+	BlockedTasks    int32                  `protobuf:"varint,6,opt,name=blocked_tasks,json=blockedTasks,proto3" json:"blocked_tasks,omitempty"`
+	CancelledTasks  int32                  `protobuf:"varint,7,opt,name=cancelled_tasks,json=cancelledTasks,proto3" json:"cancelled_tasks,omitempty"`
+	OverallProgress float64                `protobuf:"fixed64,8,opt,name=overall_progress,json=overallProgress,proto3" json:"overall_progress,omitempty"`
+	TasksByDepth    map[int32]int32        `protobuf:"bytes,9,rep,name=tasks_by_depth,json=tasksByDepth,proto3" json:"tasks_by_depth,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProjectProgress) Reset() {
+	*x = ProjectProgress{}
+	mi := &file_knot_v1_knot_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectProgress) ProtoMessage() {}
+
+func (x *ProjectProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectProgress.ProtoReflect.Descriptor instead.
+func (*ProjectProgress) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ProjectProgress) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ProjectProgress) GetTotalTasks() int32 {
+	if x != nil {
+		return x.TotalTasks
+	}
+	return 0
+}
+
+func (x *ProjectProgress) GetCompletedTasks() int32 {
+	if x != nil {
+		return x.CompletedTasks
+	}
+	return 0
+}
+
+func (x *ProjectProgress) GetInProgressTasks() int32 {
+	if x != nil {
+		return x.InProgressTasks
+	}
+	return 0
+}
+
+func (x *ProjectProgress) GetPendingTasks() int32 {
+	if x != nil {
+		return x.PendingTasks
+	}
+	return 0
+}
+
+func (x *ProjectProgress) GetBlockedTasks() int32 {
+	if x != nil {
+		return x.BlockedTasks
+	}
+	return 0
+}
+
+func (x *ProjectProgress) GetCancelledTasks() int32 {
+	if x != nil {
+		return x.CancelledTasks
+	}
+	return 0
+}
+
+func (x *ProjectProgress) GetOverallProgress() float64 {
+	if x != nil {
+		return x.OverallProgress
+	}
+	return 0
+}
+
+func (x *ProjectProgress) GetTasksByDepth() map[int32]int32 {
+	if x != nil {
+		return x.TasksByDepth
+	}
+	return nil
+}
+
+type Task struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId string                 `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Empty for root tasks
+	ParentId    string       `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Title       string       `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Description string       `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	State       TaskState    `protobuf:"varint,6,opt,name=state,proto3,enum=knot.v1.TaskState" json:"state,omitempty"`
+	Priority    TaskPriority `protobuf:"varint,7,opt,name=priority,proto3,enum=knot.v1.TaskPriority" json:"priority,omitempty"`
+	Complexity  int32        `protobuf:"varint,8,opt,name=complexity,proto3" json:"complexity,omitempty"`
+	Depth       int32        `protobuf:"varint,9,opt,name=depth,proto3" json:"depth,omitempty"`
+	// Time estimate in minutes, 0 if not set
+	Estimate     int64                  `protobuf:"varint,10,opt,name=estimate,proto3" json:"estimate,omitempty"`
+	Dependencies []string               `protobuf:"bytes,11,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	Dependents   []string               `protobuf:"bytes,12,rep,name=dependents,proto3" json:"dependents,omitempty"`
+	Tags         []string               `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt    *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreatedBy    string                 `protobuf:"bytes,16,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedBy    string                 `protobuf:"bytes,17,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	// Unset until the task is completed
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_knot_v1_knot_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Task) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *Task) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetState() TaskState {
+	if x != nil {
+		return x.State
+	}
+	return TaskState_TASK_STATE_UNSPECIFIED
+}
+
+func (x *Task) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_UNSPECIFIED
+}
+
+func (x *Task) GetComplexity() int32 {
+	if x != nil {
+		return x.Complexity
+	}
+	return 0
+}
+
+func (x *Task) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *Task) GetEstimate() int64 {
+	if x != nil {
+		return x.Estimate
+	}
+	return 0
+}
+
+func (x *Task) GetDependencies() []string {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *Task) GetDependents() []string {
+	if x != nil {
+		return x.Dependents
+	}
+	return nil
+}
+
+func (x *Task) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Task) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Task) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *Task) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type CreateProjectRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Recorded in the audit trail, defaults to the server's actor. Requests
+	// with a user token are recorded under the user name instead.
+	Actor         string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateProjectRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type GetProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetProjectRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type ListProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{5}
+}
+
+type ListProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_knot_v1_knot_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type DeleteProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectRequest) Reset() {
+	*x = DeleteProjectRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectRequest) ProtoMessage() {}
+
+func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteProjectRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *DeleteProjectRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type DeleteProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectResponse) Reset() {
+	*x = DeleteProjectResponse{}
+	mi := &file_knot_v1_knot_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectResponse) ProtoMessage() {}
+
+func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProjectResponse) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{8}
+}
+
+type GetProjectProgressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectProgressRequest) Reset() {
+	*x = GetProjectProgressRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectProgressRequest) ProtoMessage() {}
+
+func (x *GetProjectProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectProgressRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectProgressRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetProjectProgressRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type CreateTaskRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Creates a subtask when set
+	ParentId    string `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Title       string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// 1-10, 0 uses the default complexity
+	Complexity int32 `protobuf:"varint,5,opt,name=complexity,proto3" json:"complexity,omitempty"`
+	// Unspecified uses medium
+	Priority      TaskPriority `protobuf:"varint,6,opt,name=priority,proto3,enum=knot.v1.TaskPriority" json:"priority,omitempty"`
+	Actor         string       `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateTaskRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetComplexity() int32 {
+	if x != nil {
+		return x.Complexity
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_UNSPECIFIED
+}
+
+func (x *CreateTaskRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type ListTasksRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Only return direct subtasks of this task when set
+	ParentId string `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// Only return tasks in this state when set
+	State         TaskState `protobuf:"varint,3,opt,name=state,proto3,enum=knot.v1.TaskState" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListTasksRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetState() TaskState {
+	if x != nil {
+		return x.State
+	}
+	return TaskState_TASK_STATE_UNSPECIFIED
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_knot_v1_knot_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type UpdateTaskStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	State         TaskState              `protobuf:"varint,2,opt,name=state,proto3,enum=knot.v1.TaskState" json:"state,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskStateRequest) Reset() {
+	*x = UpdateTaskStateRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskStateRequest) ProtoMessage() {}
+
+func (x *UpdateTaskStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskStateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskStateRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateTaskStateRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *UpdateTaskStateRequest) GetState() TaskState {
+	if x != nil {
+		return x.State
+	}
+	return TaskState_TASK_STATE_UNSPECIFIED
+}
+
+func (x *UpdateTaskStateRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *DeleteTaskRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type DeleteTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskResponse) Reset() {
+	*x = DeleteTaskResponse{}
+	mi := &file_knot_v1_knot_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskResponse) ProtoMessage() {}
+
+func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{16}
+}
+
+type AddDependencyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TaskId          string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	DependsOnTaskId string                 `protobuf:"bytes,2,opt,name=depends_on_task_id,json=dependsOnTaskId,proto3" json:"depends_on_task_id,omitempty"`
+	Actor           string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AddDependencyRequest) Reset() {
+	*x = AddDependencyRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDependencyRequest) ProtoMessage() {}
+
+func (x *AddDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDependencyRequest.ProtoReflect.Descriptor instead.
+func (*AddDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AddDependencyRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *AddDependencyRequest) GetDependsOnTaskId() string {
+	if x != nil {
+		return x.DependsOnTaskId
+	}
+	return ""
+}
+
+func (x *AddDependencyRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type RemoveDependencyRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TaskId          string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	DependsOnTaskId string                 `protobuf:"bytes,2,opt,name=depends_on_task_id,json=dependsOnTaskId,proto3" json:"depends_on_task_id,omitempty"`
+	Actor           string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RemoveDependencyRequest) Reset() {
+	*x = RemoveDependencyRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDependencyRequest) ProtoMessage() {}
+
+func (x *RemoveDependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDependencyRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDependencyRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RemoveDependencyRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *RemoveDependencyRequest) GetDependsOnTaskId() string {
+	if x != nil {
+		return x.DependsOnTaskId
+	}
+	return ""
+}
+
+func (x *RemoveDependencyRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type NextTaskRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Selection strategy as accepted by 'knot actionable --strategy'. Empty
+	// uses the configured strategy, or the one recommended for the project.
+	Strategy      string `protobuf:"bytes,2,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NextTaskRequest) Reset() {
+	*x = NextTaskRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextTaskRequest) ProtoMessage() {}
+
+func (x *NextTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextTaskRequest.ProtoReflect.Descriptor instead.
+func (*NextTaskRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *NextTaskRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *NextTaskRequest) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+type NextTaskResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset if no task is actionable
+	Task *Task `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// Why the task was selected, or why none is actionable
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Name of the strategy the task was selected with
+	Strategy      string `protobuf:"bytes,3,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NextTaskResponse) Reset() {
+	*x = NextTaskResponse{}
+	mi := &file_knot_v1_knot_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextTaskResponse) ProtoMessage() {}
+
+func (x *NextTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextTaskResponse.ProtoReflect.Descriptor instead.
+func (*NextTaskResponse) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *NextTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *NextTaskResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *NextTaskResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+type WatchEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only stream events of this project when set, otherwise the events of all
+	// projects the caller may view
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Only stream events with a greater sequence number; pass the seq of the
+	// last received event to resume without gaps
+	SinceSeq      int64 `protobuf:"varint,2,opt,name=since_seq,json=sinceSeq,proto3" json:"since_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	mi := &file_knot_v1_knot_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchEventsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *WatchEventsRequest) GetSinceSeq() int64 {
+	if x != nil {
+		return x.SinceSeq
+	}
+	return 0
+}
+
+type ChangeEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Increases strictly with every recorded change
+	Seq       int64            `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	Kind      ChangeEvent_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=knot.v1.ChangeEvent_Kind" json:"kind,omitempty"`
+	ProjectId string           `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Set for task and dependency events
+	TaskId string `protobuf:"bytes,4,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// Set for dependency events
+	DependsOnTaskId string `protobuf:"bytes,5,opt,name=depends_on_task_id,json=dependsOnTaskId,proto3" json:"depends_on_task_id,omitempty"`
+	// The task after the change, for created and updated task events
+	Task *Task `protobuf:"bytes,6,opt,name=task,proto3" json:"task,omitempty"`
+	// The project after the change, for created and updated project events
+	Project       *Project               `protobuf:"bytes,7,opt,name=project,proto3" json:"project,omitempty"`
+	Actor         string                 `protobuf:"bytes,8,opt,name=actor,proto3" json:"actor,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeEvent) Reset() {
+	*x = ChangeEvent{}
+	mi := &file_knot_v1_knot_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEvent) ProtoMessage() {}
+
+func (x *ChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_knot_v1_knot_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEvent.ProtoReflect.Descriptor instead.
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
+	return file_knot_v1_knot_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ChangeEvent) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetKind() ChangeEvent_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return ChangeEvent_KIND_UNSPECIFIED
+}
+
+func (x *ChangeEvent) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetDependsOnTaskId() string {
+	if x != nil {
+		return x.DependsOnTaskId
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+var File_knot_v1_knot_proto protoreflect.FileDescriptor
+
+const file_knot_v1_knot_proto_rawDesc = "" +
+	"\n" +
+	"\x12knot/v1/knot.proto\x12\aknot.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x98\x03\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12+\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x15.knot.v1.ProjectStateR\x05state\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\b \x01(\tR\tupdatedBy\x12\x1f\n" +
+	"\vtotal_tasks\x18\t \x01(\x05R\n" +
+	"totalTasks\x12'\n" +
+	"\x0fcompleted_tasks\x18\n" +
+	" \x01(\x05R\x0ecompletedTasks\x12\x1a\n" +
+	"\bprogress\x18\v \x01(\x01R\bprogress\"\xd7\x03\n" +
+	"\x0fProjectProgress\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x1f\n" +
+	"\vtotal_tasks\x18\x02 \x01(\x05R\n" +
+	"totalTasks\x12'\n" +
+	"\x0fcompleted_tasks\x18\x03 \x01(\x05R\x0ecompletedTasks\x12*\n" +
+	"\x11in_progress_tasks\x18\x04 \x01(\x05R\x0finProgressTasks\x12#\n" +
+	"\rpending_tasks\x18\x05 \x01(\x05R\fpendingTasks\x12#\n" +
+	"\rblocked_tasks\x18\x06 \x01(\x05R\fblockedTasks\x12'\n" +
+	"\x0fcancelled_tasks\x18\a \x01(\x05R\x0ecancelledTasks\x12)\n" +
+	"\x10overall_progress\x18\b \x01(\x01R\x0foverallProgress\x12P\n" +
+	"\x0etasks_by_depth\x18\t \x03(\v2*.knot.v1.ProjectProgress.TasksByDepthEntryR\ftasksByDepth\x1a?\n" +
+	"\x11TasksByDepthEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x84\x05\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tR\tprojectId\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12(\n" +
+	"\x05state\x18\x06 \x01(\x0e2\x12.knot.v1.TaskStateR\x05state\x121\n" +
+	"\bpriority\x18\a \x01(\x0e2\x15.knot.v1.TaskPriorityR\bpriority\x12\x1e\n" +
+	"\n" +
+	"complexity\x18\b \x01(\x05R\n" +
+	"complexity\x12\x14\n" +
+	"\x05depth\x18\t \x01(\x05R\x05depth\x12\x1a\n" +
+	"\bestimate\x18\n" +
+	" \x01(\x03R\bestimate\x12\"\n" +
+	"\fdependencies\x18\v \x03(\tR\fdependencies\x12\x1e\n" +
+	"\n" +
+	"dependents\x18\f \x03(\tR\n" +
+	"dependents\x12\x12\n" +
+	"\x04tags\x18\r \x03(\tR\x04tags\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x10 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x11 \x01(\tR\tupdatedBy\x12=\n" +
+	"\fcompleted_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"d\n" +
+	"\x14CreateProjectRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\"2\n" +
+	"\x11GetProjectRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\"\x15\n" +
+	"\x13ListProjectsRequest\"D\n" +
+	"\x14ListProjectsResponse\x12,\n" +
+	"\bprojects\x18\x01 \x03(\v2\x10.knot.v1.ProjectR\bprojects\"K\n" +
+	"\x14DeleteProjectRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\"\x17\n" +
+	"\x15DeleteProjectResponse\":\n" +
+	"\x19GetProjectProgressRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\"\xf0\x01\n" +
+	"\x11CreateTaskRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1e\n" +
+	"\n" +
+	"complexity\x18\x05 \x01(\x05R\n" +
+	"complexity\x121\n" +
+	"\bpriority\x18\x06 \x01(\x0e2\x15.knot.v1.TaskPriorityR\bpriority\x12\x14\n" +
+	"\x05actor\x18\a \x01(\tR\x05actor\")\n" +
+	"\x0eGetTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"x\n" +
+	"\x10ListTasksRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\x12(\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x12.knot.v1.TaskStateR\x05state\"8\n" +
+	"\x11ListTasksResponse\x12#\n" +
+	"\x05tasks\x18\x01 \x03(\v2\r.knot.v1.TaskR\x05tasks\"q\n" +
+	"\x16UpdateTaskStateRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12(\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x12.knot.v1.TaskStateR\x05state\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\"B\n" +
+	"\x11DeleteTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\"\x14\n" +
+	"\x12DeleteTaskResponse\"r\n" +
+	"\x14AddDependencyRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12+\n" +
+	"\x12depends_on_task_id\x18\x02 \x01(\tR\x0fdependsOnTaskId\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\"u\n" +
+	"\x17RemoveDependencyRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12+\n" +
+	"\x12depends_on_task_id\x18\x02 \x01(\tR\x0fdependsOnTaskId\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\"L\n" +
+	"\x0fNextTaskRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x1a\n" +
+	"\bstrategy\x18\x02 \x01(\tR\bstrategy\"i\n" +
+	"\x10NextTaskResponse\x12!\n" +
+	"\x04task\x18\x01 \x01(\v2\r.knot.v1.TaskR\x04task\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1a\n" +
+	"\bstrategy\x18\x03 \x01(\tR\bstrategy\"P\n" +
+	"\x12WatchEventsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x1b\n" +
+	"\tsince_seq\x18\x02 \x01(\x03R\bsinceSeq\"\xdc\x04\n" +
+	"\vChangeEvent\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12-\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x19.knot.v1.ChangeEvent.KindR\x04kind\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x03 \x01(\tR\tprojectId\x12\x17\n" +
+	"\atask_id\x18\x04 \x01(\tR\x06taskId\x12+\n" +
+	"\x12depends_on_task_id\x18\x05 \x01(\tR\x0fdependsOnTaskId\x12!\n" +
+	"\x04task\x18\x06 \x01(\v2\r.knot.v1.TaskR\x04task\x12*\n" +
+	"\aproject\x18\a \x01(\v2\x10.knot.v1.ProjectR\aproject\x12\x14\n" +
+	"\x05actor\x18\b \x01(\tR\x05actor\x12;\n" +
+	"\voccurred_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x84\x02\n" +
+	"\x04Kind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14KIND_PROJECT_CREATED\x10\x01\x12\x18\n" +
+	"\x14KIND_PROJECT_UPDATED\x10\x02\x12\x18\n" +
+	"\x14KIND_PROJECT_DELETED\x10\x03\x12\x15\n" +
+	"\x11KIND_TASK_CREATED\x10\x04\x12\x15\n" +
+	"\x11KIND_TASK_UPDATED\x10\x05\x12\x15\n" +
+	"\x11KIND_TASK_DELETED\x10\x06\x12\x19\n" +
+	"\x15KIND_DEPENDENCY_ADDED\x10\a\x12\x1b\n" +
+	"\x17KIND_DEPENDENCY_REMOVED\x10\b\x12\x1b\n" +
+	"\x17KIND_DEPENDENCY_UPDATED\x10\t*\xc8\x01\n" +
+	"\tTaskState\x12\x1a\n" +
+	"\x16TASK_STATE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12TASK_STATE_PENDING\x10\x01\x12\x1a\n" +
+	"\x16TASK_STATE_IN_PROGRESS\x10\x02\x12\x18\n" +
+	"\x14TASK_STATE_COMPLETED\x10\x03\x12\x16\n" +
+	"\x12TASK_STATE_BLOCKED\x10\x04\x12\x18\n" +
+	"\x14TASK_STATE_CANCELLED\x10\x05\x12\x1f\n" +
+	"\x1bTASK_STATE_DELETION_PENDING\x10\x06*v\n" +
+	"\fTaskPriority\x12\x1d\n" +
+	"\x19TASK_PRIORITY_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12TASK_PRIORITY_HIGH\x10\x01\x12\x18\n" +
+	"\x14TASK_PRIORITY_MEDIUM\x10\x02\x12\x15\n" +
+	"\x11TASK_PRIORITY_LOW\x10\x03*\xa4\x01\n" +
+	"\fProjectState\x12\x1d\n" +
+	"\x19PROJECT_STATE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14PROJECT_STATE_ACTIVE\x10\x01\x12\x1b\n" +
+	"\x17PROJECT_STATE_COMPLETED\x10\x02\x12\x1a\n" +
+	"\x16PROJECT_STATE_ARCHIVED\x10\x03\x12\"\n" +
+	"\x1ePROJECT_STATE_DELETION_PENDING\x10\x042\xbf\a\n" +
+	"\vKnotService\x12@\n" +
+	"\rCreateProject\x12\x1d.knot.v1.CreateProjectRequest\x1a\x10.knot.v1.Project\x12:\n" +
+	"\n" +
+	"GetProject\x12\x1a.knot.v1.GetProjectRequest\x1a\x10.knot.v1.Project\x12K\n" +
+	"\fListProjects\x12\x1c.knot.v1.ListProjectsRequest\x1a\x1d.knot.v1.ListProjectsResponse\x12N\n" +
+	"\rDeleteProject\x12\x1d.knot.v1.DeleteProjectRequest\x1a\x1e.knot.v1.DeleteProjectResponse\x12R\n" +
+	"\x12GetProjectProgress\x12\".knot.v1.GetProjectProgressRequest\x1a\x18.knot.v1.ProjectProgress\x127\n" +
+	"\n" +
+	"CreateTask\x12\x1a.knot.v1.CreateTaskRequest\x1a\r.knot.v1.Task\x121\n" +
+	"\aGetTask\x12\x17.knot.v1.GetTaskRequest\x1a\r.knot.v1.Task\x12B\n" +
+	"\tListTasks\x12\x19.knot.v1.ListTasksRequest\x1a\x1a.knot.v1.ListTasksResponse\x12A\n" +
+	"\x0fUpdateTaskState\x12\x1f.knot.v1.UpdateTaskStateRequest\x1a\r.knot.v1.Task\x12E\n" +
+	"\n" +
+	"DeleteTask\x12\x1a.knot.v1.DeleteTaskRequest\x1a\x1b.knot.v1.DeleteTaskResponse\x12=\n" +
+	"\rAddDependency\x12\x1d.knot.v1.AddDependencyRequest\x1a\r.knot.v1.Task\x12C\n" +
+	"\x10RemoveDependency\x12 .knot.v1.RemoveDependencyRequest\x1a\r.knot.v1.Task\x12?\n" +
+	"\bNextTask\x12\x18.knot.v1.NextTaskRequest\x1a\x19.knot.v1.NextTaskResponse\x12B\n" +
+	"\vWatchEvents\x12\x1b.knot.v1.WatchEventsRequest\x1a\x14.knot.v1.ChangeEvent0\x01B6Z4github.com/denkhaus/knot/v2/api/proto/knot/v1;knotv1b\x06proto3"
+
+var (
+	file_knot_v1_knot_proto_rawDescOnce sync.Once
+	file_knot_v1_knot_proto_rawDescData []byte
+)
+
+func file_knot_v1_knot_proto_rawDescGZIP() []byte {
+	file_knot_v1_knot_proto_rawDescOnce.Do(func() {
+		file_knot_v1_knot_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_knot_v1_knot_proto_rawDesc), len(file_knot_v1_knot_proto_rawDesc)))
+	})
+	return file_knot_v1_knot_proto_rawDescData
+}
+
+var file_knot_v1_knot_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_knot_v1_knot_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_knot_v1_knot_proto_goTypes = []any{
+	(TaskState)(0),                    // 0: knot.v1.TaskState
+	(TaskPriority)(0),                 // 1: knot.v1.TaskPriority
+	(ProjectState)(0),                 // 2: knot.v1.ProjectState
+	(ChangeEvent_Kind)(0),             // 3: knot.v1.ChangeEvent.Kind
+	(*Project)(nil),                   // 4: knot.v1.Project
+	(*ProjectProgress)(nil),           // 5: knot.v1.ProjectProgress
+	(*Task)(nil),                      // 6: knot.v1.Task
+	(*CreateProjectRequest)(nil),      // 7: knot.v1.CreateProjectRequest
+	(*GetProjectRequest)(nil),         // 8: knot.v1.GetProjectRequest
+	(*ListProjectsRequest)(nil),       // 9: knot.v1.ListProjectsRequest
+	(*ListProjectsResponse)(nil),      // 10: knot.v1.ListProjectsResponse
+	(*DeleteProjectRequest)(nil),      // 11: knot.v1.DeleteProjectRequest
+	(*DeleteProjectResponse)(nil),     // 12: knot.v1.DeleteProjectResponse
+	(*GetProjectProgressRequest)(nil), // 13: knot.v1.GetProjectProgressRequest
+	(*CreateTaskRequest)(nil),         // 14: knot.v1.CreateTaskRequest
+	(*GetTaskRequest)(nil),            // 15: knot.v1.GetTaskRequest
+	(*ListTasksRequest)(nil),          // 16: knot.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),         // 17: knot.v1.ListTasksResponse
+	(*UpdateTaskStateRequest)(nil),    // 18: knot.v1.UpdateTaskStateRequest
+	(*DeleteTaskRequest)(nil),         // 19: knot.v1.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),        // 20: knot.v1.DeleteTaskResponse
+	(*AddDependencyRequest)(nil),      // 21: knot.v1.AddDependencyRequest
+	(*RemoveDependencyRequest)(nil),   // 22: knot.v1.RemoveDependencyRequest
+	(*NextTaskRequest)(nil),           // 23: knot.v1.NextTaskRequest
+	(*NextTaskResponse)(nil),          // 24: knot.v1.NextTaskResponse
+	(*WatchEventsRequest)(nil),        // 25: knot.v1.WatchEventsRequest
+	(*ChangeEvent)(nil),               // 26: knot.v1.ChangeEvent
+	nil,                               // 27: knot.v1.ProjectProgress.TasksByDepthEntry
+	(*timestamppb.Timestamp)(nil),     // 28: google.protobuf.Timestamp
+}
+var file_knot_v1_knot_proto_depIdxs = []int32{
+	2,  // 0: knot.v1.Project.state:type_name -> knot.v1.ProjectState
+	28, // 1: knot.v1.Project.created_at:type_name -> google.protobuf.Timestamp
+	28, // 2: knot.v1.Project.updated_at:type_name -> google.protobuf.Timestamp
+	27, // 3: knot.v1.ProjectProgress.tasks_by_depth:type_name -> knot.v1.ProjectProgress.TasksByDepthEntry
+	0,  // 4: knot.v1.Task.state:type_name -> knot.v1.TaskState
+	1,  // 5: knot.v1.Task.priority:type_name -> knot.v1.TaskPriority
+	28, // 6: knot.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	28, // 7: knot.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	28, // 8: knot.v1.Task.completed_at:type_name -> google.protobuf.Timestamp
+	4,  // 9: knot.v1.ListProjectsResponse.projects:type_name -> knot.v1.Project
+	1,  // 10: knot.v1.CreateTaskRequest.priority:type_name -> knot.v1.TaskPriority
+	0,  // 11: knot.v1.ListTasksRequest.state:type_name -> knot.v1.TaskState
+	6,  // 12: knot.v1.ListTasksResponse.tasks:type_name -> knot.v1.Task
+	0,  // 13: knot.v1.UpdateTaskStateRequest.state:type_name -> knot.v1.TaskState
+	6,  // 14: knot.v1.NextTaskResponse.task:type_name -> knot.v1.Task
+	3,  // 15: knot.v1.ChangeEvent.kind:type_name -> knot.v1.ChangeEvent.Kind
+	6,  // 16: knot.v1.ChangeEvent.task:type_name -> knot.v1.Task
+	4,  // 17: knot.v1.ChangeEvent.project:type_name -> knot.v1.Project
+	28, // 18: knot.v1.ChangeEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	7,  // 19: knot.v1.KnotService.CreateProject:input_type -> knot.v1.CreateProjectRequest
+	8,  // 20: knot.v1.KnotService.GetProject:input_type -> knot.v1.GetProjectRequest
+	9,  // 21: knot.v1.KnotService.ListProjects:input_type -> knot.v1.ListProjectsRequest
+	11, // 22: knot.v1.KnotService.DeleteProject:input_type -> knot.v1.DeleteProjectRequest
+	13, // 23: knot.v1.KnotService.GetProjectProgress:input_type -> knot.v1.GetProjectProgressRequest
+	14, // 24: knot.v1.KnotService.CreateTask:input_type -> knot.v1.CreateTaskRequest
+	15, // 25: knot.v1.KnotService.GetTask:input_type -> knot.v1.GetTaskRequest
+	16, // 26: knot.v1.KnotService.ListTasks:input_type -> knot.v1.ListTasksRequest
+	18, // 27: knot.v1.KnotService.UpdateTaskState:input_type -> knot.v1.UpdateTaskStateRequest
+	19, // 28: knot.v1.KnotService.DeleteTask:input_type -> knot.v1.DeleteTaskRequest
+	21, // 29: knot.v1.KnotService.AddDependency:input_type -> knot.v1.AddDependencyRequest
+	22, // 30: knot.v1.KnotService.RemoveDependency:input_type -> knot.v1.RemoveDependencyRequest
+	23, // 31: knot.v1.KnotService.NextTask:input_type -> knot.v1.NextTaskRequest
+	25, // 32: knot.v1.KnotService.WatchEvents:input_type -> knot.v1.WatchEventsRequest
+	4,  // 33: knot.v1.KnotService.CreateProject:output_type -> knot.v1.Project
+	4,  // 34: knot.v1.KnotService.GetProject:output_type -> knot.v1.Project
+	10, // 35: knot.v1.KnotService.ListProjects:output_type -> knot.v1.ListProjectsResponse
+	12, // 36: knot.v1.KnotService.DeleteProject:output_type -> knot.v1.DeleteProjectResponse
+	5,  // 37: knot.v1.KnotService.GetProjectProgress:output_type -> knot.v1.ProjectProgress
+	6,  // 38: knot.v1.KnotService.CreateTask:output_type -> knot.v1.Task
+	6,  // 39: knot.v1.KnotService.GetTask:output_type -> knot.v1.Task
+	17, // 40: knot.v1.KnotService.ListTasks:output_type -> knot.v1.ListTasksResponse
+	6,  // 41: knot.v1.KnotService.UpdateTaskState:output_type -> knot.v1.Task
+	20, // 42: knot.v1.KnotService.DeleteTask:output_type -> knot.v1.DeleteTaskResponse
+	6,  // 43: knot.v1.KnotService.AddDependency:output_type -> knot.v1.Task
+	6,  // 44: knot.v1.KnotService.RemoveDependency:output_type -> knot.v1.Task
+	24, // 45: knot.v1.KnotService.NextTask:output_type -> knot.v1.NextTaskResponse
+	26, // 46: knot.v1.KnotService.WatchEvents:output_type -> knot.v1.ChangeEvent
+	33, // [33:47] is the sub-list for method output_type
+	19, // [19:33] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_knot_v1_knot_proto_init() }
+func file_knot_v1_knot_proto_init() {
+	if File_knot_v1_knot_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_knot_v1_knot_proto_rawDesc), len(file_knot_v1_knot_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_knot_v1_knot_proto_goTypes,
+		DependencyIndexes: file_knot_v1_knot_proto_depIdxs,
+		EnumInfos:         file_knot_v1_knot_proto_enumTypes,
+		MessageInfos:      file_knot_v1_knot_proto_msgTypes,
+	}.Build()
+	File_knot_v1_knot_proto = out.File
+	file_knot_v1_knot_proto_goTypes = nil
+	file_knot_v1_knot_proto_depIdxs = nil
+}
@@ -0,0 +1,10 @@
+package main
+
+// Storage drivers register themselves with the repository registry on import.
+// Add a blank import here to make a custom backend, registered with knot.Register
+// from pkg/knot, selectable via KNOT_DATABASE.
+import (
+	_ "github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	_ "github.com/denkhaus/knot/v2/internal/repository/remote"
+	_ "github.com/denkhaus/knot/v2/internal/repository/sqlite"
+)
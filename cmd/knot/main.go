@@ -26,7 +26,7 @@ func main() {
 
 	if err := application.Run(os.Args); err != nil {
 		// Error has already been printed by the Run method
-		// Just exit with error code without additional logging
-		os.Exit(1)
+		// Just exit with the code matching the error class (see 'knot help exit-codes')
+		os.Exit(app.ExitCodeFor(err))
 	}
 }
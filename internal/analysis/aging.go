@@ -0,0 +1,86 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// AgingViolation is a task that has been in its state for longer than the
+// aging policy of its priority allows
+type AgingViolation struct {
+	TaskRef
+	Priority string `json:"priority"`
+	// Since is when the task entered its current state
+	Since time.Time `json:"since"`
+	// AgeDays is the number of whole days the task has been in its state
+	AgeDays    int `json:"age_days"`
+	MaxAgeDays int `json:"max_age_days"`
+}
+
+// AgingPolicyReport is the result of CheckAgingPolicies
+type AgingPolicyReport struct {
+	// Checked is the number of tasks an aging policy applies to
+	Checked    int              `json:"checked"`
+	Violations []AgingViolation `json:"violations"`
+}
+
+// CheckAgingPolicies reports the tasks that have been in their state for
+// longer than the policy matching their priority and state allows. A task
+// entered its state when it was created in it or the change feed last moved
+// it there, blocked tasks with an external reason when the reason was
+// recorded. Without a matching event pending tasks count from their creation,
+// other tasks from their last update. Violations are sorted by age, oldest
+// first.
+func CheckAgingPolicies(tasks []*types.Task, events []*types.ChangeEvent, policies []manager.AgingPolicy, now time.Time) *AgingPolicyReport {
+	maxAge := make(map[string]map[types.TaskState]int, len(policies))
+	for _, policy := range policies {
+		if maxAge[policy.Priority] == nil {
+			maxAge[policy.Priority] = make(map[types.TaskState]int)
+		}
+		maxAge[policy.Priority][policy.TaskState()] = policy.MaxAgeDays
+	}
+	changes := taskStateChanges(events)
+
+	report := &AgingPolicyReport{Violations: []AgingViolation{}}
+	for _, task := range tasks {
+		priority := task.Priority.ToExternalString()
+		days, ok := maxAge[priority][task.State]
+		if !ok {
+			continue
+		}
+		report.Checked++
+
+		since := task.UpdatedAt
+		change, changed := changes[task.ID]
+		switch {
+		case task.State == types.TaskStateBlocked && task.Blocker != nil:
+			since = task.Blocker.BlockedAt
+		case changed && change.state == task.State && change.created:
+			since = task.CreatedAt
+		case changed && change.state == task.State:
+			since = change.at
+		case task.State == types.TaskStatePending:
+			since = task.CreatedAt
+		}
+
+		age := now.Sub(since)
+		if age <= time.Duration(days)*24*time.Hour {
+			continue
+		}
+		report.Violations = append(report.Violations, AgingViolation{
+			TaskRef:    TaskRef{TaskID: task.ID, Title: task.Title, State: task.State},
+			Priority:   priority,
+			Since:      since,
+			AgeDays:    int(age / (24 * time.Hour)),
+			MaxAgeDays: days,
+		})
+	}
+
+	sort.SliceStable(report.Violations, func(i, j int) bool {
+		return report.Violations[i].Since.Before(report.Violations[j].Since)
+	})
+	return report
+}
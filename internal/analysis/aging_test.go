@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAgingPolicies(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.Add(-time.Duration(days) * 24 * time.Hour) }
+	withPriority := func(task *types.Task, priority types.TaskPriority, created time.Time) *types.Task {
+		task.Priority = priority
+		task.CreatedAt, task.UpdatedAt = created, created
+		return task
+	}
+
+	stale := withPriority(newTask("stale", types.TaskStatePending, 3, 0), types.TaskPriorityHigh, daysAgo(5))
+	fresh := withPriority(newTask("fresh", types.TaskStatePending, 3, 0), types.TaskPriorityHigh, daysAgo(2))
+	// Reopened two days ago, so it has only been pending since then
+	reopened := withPriority(newTask("reopened", types.TaskStatePending, 3, 0), types.TaskPriorityHigh, daysAgo(10))
+	started := withPriority(newTask("started", types.TaskStateInProgress, 3, 0), types.TaskPriorityHigh, daysAgo(20))
+	started.UpdatedAt = now
+	vendor := withPriority(newTask("vendor", types.TaskStateBlocked, 3, 0), types.TaskPriorityHigh, daysAgo(20))
+	vendor.Blocker = &types.TaskBlocker{Reason: "waiting for vendor", BlockedBy: "alice", BlockedAt: daysAgo(9)}
+	low := withPriority(newTask("low", types.TaskStatePending, 3, 0), types.TaskPriorityLow, daysAgo(30))
+	done := withPriority(newTask("done", types.TaskStateCompleted, 3, 0), types.TaskPriorityHigh, daysAgo(30))
+	tasks := []*types.Task{stale, fresh, reopened, started, vendor, low, done}
+
+	// Creation events are recorded later for tasks imported with their timestamps
+	created := stateEvent(0, stale, types.TaskStatePending, "alice", now)
+	created.Kind = types.ChangeTaskCreated
+	events := []*types.ChangeEvent{
+		created,
+		stateEvent(1, reopened, types.TaskStateCompleted, "bob", daysAgo(4)),
+		stateEvent(2, reopened, types.TaskStatePending, "bob", daysAgo(2)),
+		stateEvent(3, started, types.TaskStateInProgress, "carol", daysAgo(6)),
+	}
+	policies := []manager.AgingPolicy{
+		{Priority: "high", MaxAgeDays: 3},
+		{Priority: "high", State: "in-progress", MaxAgeDays: 5},
+		{Priority: "high", State: "blocked", MaxAgeDays: 7},
+	}
+
+	report := CheckAgingPolicies(tasks, events, policies, now)
+	assert.Equal(t, 5, report.Checked)
+	require.Len(t, report.Violations, 3)
+
+	var order []string
+	for _, violation := range report.Violations {
+		order = append(order, violation.Title)
+	}
+	assert.Equal(t, []string{"vendor", "started", "stale"}, order)
+
+	assert.Equal(t, "high", report.Violations[0].Priority)
+	assert.Equal(t, 9, report.Violations[0].AgeDays)
+	assert.Equal(t, 7, report.Violations[0].MaxAgeDays)
+	assert.Equal(t, daysAgo(6), report.Violations[1].Since)
+	assert.Equal(t, 5, report.Violations[2].AgeDays)
+	assert.Equal(t, 3, report.Violations[2].MaxAgeDays)
+
+	assert.Empty(t, CheckAgingPolicies(tasks, events, nil, now).Violations)
+}
@@ -0,0 +1,127 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// BlockedAge describes how long a task has been blocked and by whom or what
+type BlockedAge struct {
+	TaskRef
+	// Since is when the task became blocked
+	Since time.Time `json:"since"`
+	// AgeDays is the number of whole days the task has been blocked
+	AgeDays int `json:"age_days"`
+	// BlockedBy is the actor who blocked the task, empty if unknown
+	BlockedBy string `json:"blocked_by,omitempty"`
+	// Reason is the external reason of a blocked task, if any
+	Reason *types.TaskBlocker `json:"reason,omitempty"`
+	// Dependencies lists the open dependencies the task waits for
+	Dependencies []TaskRef `json:"dependencies,omitempty"`
+	// Escalate is set once the task has been blocked for the escalation threshold
+	Escalate bool `json:"escalate"`
+}
+
+// BlockedAgingReport is the result of BlockedAging
+type BlockedAgingReport struct {
+	// EscalateAfterDays is the threshold from which blocked tasks are escalated
+	EscalateAfterDays int          `json:"escalate_after_days"`
+	Escalated         int          `json:"escalated"`
+	Tasks             []BlockedAge `json:"tasks"`
+}
+
+// BlockedAging lists the blocked tasks of a project with the time they have
+// been blocked: tasks in the blocked state, and pending or in-progress tasks
+// waiting for open dependencies. Tasks blocked for an external reason are
+// blocked since the reason was recorded; other blocked tasks since the change
+// feed last moved them into the blocked state; tasks waiting for dependencies
+// since the earliest of their open dependencies was added. Without a matching
+// event the task's last update, or its creation for dependencies, is used.
+// Tasks blocked for escalateAfterDays or longer are marked for escalation.
+// Entries are sorted by age, longest blocked first.
+func BlockedAging(tasks []*types.Task, events []*types.ChangeEvent, now time.Time, escalateAfterDays int) *BlockedAgingReport {
+	taskMap := make(map[uuid.UUID]*types.Task, len(tasks))
+	for _, task := range tasks {
+		taskMap[task.ID] = task
+	}
+	changes := taskStateChanges(events)
+	added := dependencyAddedTimes(events)
+
+	report := &BlockedAgingReport{EscalateAfterDays: escalateAfterDays, Tasks: []BlockedAge{}}
+	for _, task := range tasks {
+		var openDeps []TaskRef
+		for _, depID := range task.Dependencies {
+			if dep, ok := taskMap[depID]; ok && isIncomplete(dep) {
+				openDeps = append(openDeps, TaskRef{TaskID: dep.ID, Title: dep.Title, State: dep.State})
+			}
+		}
+
+		entry := BlockedAge{
+			TaskRef:      TaskRef{TaskID: task.ID, Title: task.Title, State: task.State},
+			Dependencies: openDeps,
+		}
+		switch {
+		case task.State == types.TaskStateBlocked && task.Blocker != nil:
+			entry.Since = task.Blocker.BlockedAt
+			entry.BlockedBy = task.Blocker.BlockedBy
+			entry.Reason = task.Blocker
+		case task.State == types.TaskStateBlocked:
+			entry.Since, entry.BlockedBy = task.UpdatedAt, task.UpdatedBy
+			if change, ok := changes[task.ID]; ok {
+				entry.Since, entry.BlockedBy = change.at, change.actor
+			}
+		case (task.State == types.TaskStatePending || task.State == types.TaskStateInProgress) && len(openDeps) > 0:
+			entry.Since = task.CreatedAt
+			first := true
+			for _, dep := range openDeps {
+				at, ok := added[[2]uuid.UUID{task.ID, dep.TaskID}]
+				if ok && (first || at.Before(entry.Since)) {
+					entry.Since = at
+					first = false
+				}
+			}
+		default:
+			continue
+		}
+
+		if now.After(entry.Since) {
+			entry.AgeDays = int(now.Sub(entry.Since) / (24 * time.Hour))
+		}
+		entry.Escalate = escalateAfterDays > 0 && entry.AgeDays >= escalateAfterDays
+		if entry.Escalate {
+			report.Escalated++
+		}
+		report.Tasks = append(report.Tasks, entry)
+	}
+
+	sort.SliceStable(report.Tasks, func(i, j int) bool {
+		return report.Tasks[i].Since.Before(report.Tasks[j].Since)
+	})
+	return report
+}
+
+// dependencyAddedTimes replays the change feed and returns when each current
+// dependency, keyed by task and dependency ID, was last added
+func dependencyAddedTimes(events []*types.ChangeEvent) map[[2]uuid.UUID]time.Time {
+	sorted := make([]*types.ChangeEvent, len(events))
+	copy(sorted, events)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Seq < sorted[j].Seq })
+
+	added := make(map[[2]uuid.UUID]time.Time)
+	for _, event := range sorted {
+		if event.TaskID == nil || event.DependsOnID == nil {
+			continue
+		}
+		key := [2]uuid.UUID{*event.TaskID, *event.DependsOnID}
+		switch event.Kind {
+		case types.ChangeDependencyAdded:
+			added[key] = event.CreatedAt
+		case types.ChangeDependencyRemoved:
+			delete(added, key)
+		}
+	}
+	return added
+}
@@ -0,0 +1,84 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockedAging(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.Add(-time.Duration(days) * 24 * time.Hour) }
+	dependencyEvent := func(seq int64, kind types.ChangeEventKind, task, dep *types.Task, at time.Time) *types.ChangeEvent {
+		taskID, depID := task.ID, dep.ID
+		return &types.ChangeEvent{Seq: seq, Kind: kind, TaskID: &taskID, DependsOnID: &depID, CreatedAt: at}
+	}
+
+	vendor := newTask("vendor", types.TaskStateBlocked, 3, 0)
+	vendor.Blocker = &types.TaskBlocker{Reason: "waiting for vendor", BlockedBy: "alice", BlockedAt: daysAgo(10)}
+	legal := newTask("legal", types.TaskStateBlocked, 3, 0)
+	legal.UpdatedAt = daysAgo(1)
+	legal.UpdatedBy = "carol"
+	api := newTask("api", types.TaskStateInProgress, 3, 0)
+	done := newTask("done", types.TaskStateCompleted, 3, 0)
+	client := newTask("client", types.TaskStatePending, 3, 0)
+	client.CreatedAt = daysAgo(20)
+	client.Dependencies = []uuid.UUID{api.ID, done.ID}
+	unlogged := newTask("unlogged", types.TaskStatePending, 3, 0)
+	unlogged.CreatedAt = daysAgo(8)
+	unlogged.Dependencies = []uuid.UUID{api.ID}
+	ready := newTask("ready", types.TaskStatePending, 3, 0)
+	ready.Dependencies = []uuid.UUID{done.ID}
+	tasks := []*types.Task{vendor, legal, api, done, client, unlogged, ready}
+
+	events := []*types.ChangeEvent{
+		stateEvent(1, legal, types.TaskStatePending, "carol", daysAgo(6)),
+		stateEvent(2, legal, types.TaskStateBlocked, "bob", daysAgo(4)),
+		// Re-added later, the dependency counts from the last time it was added
+		dependencyEvent(3, types.ChangeDependencyAdded, client, api, daysAgo(9)),
+		dependencyEvent(4, types.ChangeDependencyRemoved, client, api, daysAgo(8)),
+		dependencyEvent(5, types.ChangeDependencyAdded, client, api, daysAgo(3)),
+		stateEvent(6, legal, types.TaskStateBlocked, "dave", daysAgo(1)),
+	}
+
+	report := BlockedAging(tasks, events, now, 7)
+	require.Len(t, report.Tasks, 4)
+	assert.Equal(t, 7, report.EscalateAfterDays)
+	assert.Equal(t, 2, report.Escalated)
+
+	byTitle := make(map[string]BlockedAge)
+	var order []string
+	for _, entry := range report.Tasks {
+		byTitle[entry.Title] = entry
+		order = append(order, entry.Title)
+	}
+	assert.Equal(t, []string{"vendor", "unlogged", "legal", "client"}, order)
+
+	assert.Equal(t, 10, byTitle["vendor"].AgeDays)
+	assert.Equal(t, "alice", byTitle["vendor"].BlockedBy)
+	assert.Same(t, vendor.Blocker, byTitle["vendor"].Reason)
+	assert.True(t, byTitle["vendor"].Escalate)
+
+	assert.Equal(t, 4, byTitle["legal"].AgeDays, "blocked since the state change, not the last update")
+	assert.Equal(t, "bob", byTitle["legal"].BlockedBy)
+	assert.False(t, byTitle["legal"].Escalate)
+
+	assert.Equal(t, 3, byTitle["client"].AgeDays)
+	assert.Equal(t, []TaskRef{{TaskID: api.ID, Title: "api", State: types.TaskStateInProgress}}, byTitle["client"].Dependencies)
+	assert.False(t, byTitle["client"].Escalate)
+
+	assert.Equal(t, 8, byTitle["unlogged"].AgeDays, "falls back to the creation time")
+	assert.True(t, byTitle["unlogged"].Escalate)
+
+	t.Run("threshold 0 disables escalation", func(t *testing.T) {
+		report := BlockedAging(tasks, events, now, 0)
+		assert.Zero(t, report.Escalated)
+		for _, entry := range report.Tasks {
+			assert.False(t, entry.Escalate)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// TargetSubtaskComplexity is the complexity a subtask should ideally have
+// after a breakdown
+const TargetSubtaskComplexity = 3
+
+// BreakdownCandidate is a task that should be broken down into subtasks
+type BreakdownCandidate struct {
+	Task *types.Task `json:"task"`
+	// Dependents is the number of tasks that depend on this task directly
+	Dependents int `json:"dependents"`
+	// SuggestedSubtasks is the number of subtasks needed to bring each of
+	// them down to about TargetSubtaskComplexity
+	SuggestedSubtasks int `json:"suggested_subtasks"`
+	// SubtaskCapacity is the number of tasks that can still be created at the
+	// depth the subtasks would be created at
+	SubtaskCapacity int `json:"subtask_capacity"`
+	// DepthLimitExempt is set if the subtasks may exceed that capacity, see
+	// manager.Config.AllowLargeBreakdowns
+	DepthLimitExempt bool `json:"depth_limit_exempt,omitempty"`
+}
+
+// FindBreakdownCandidates returns the tasks with complexity >= minComplexity
+// that have no subtasks, ordered by potential impact: most dependents first,
+// then highest complexity, then oldest.
+func FindBreakdownCandidates(tasks []*types.Task, minComplexity int, config *manager.Config) []BreakdownCandidate {
+	hasChildren := make(map[uuid.UUID]bool)
+	dependents := make(map[uuid.UUID]int)
+	depthCounts := make(map[int]int)
+	for _, task := range tasks {
+		depthCounts[task.Depth]++
+		if task.ParentID != nil {
+			hasChildren[*task.ParentID] = true
+		}
+		for _, depID := range task.Dependencies {
+			dependents[depID]++
+		}
+	}
+
+	candidates := make([]BreakdownCandidate, 0)
+	for _, task := range tasks {
+		if task.Complexity < minComplexity || hasChildren[task.ID] {
+			continue
+		}
+		candidates = append(candidates, BreakdownCandidate{
+			Task:              task,
+			Dependents:        dependents[task.ID],
+			SuggestedSubtasks: SuggestedSubtaskCount(task.Complexity),
+			SubtaskCapacity:   config.RemainingAt(task.Depth+1, depthCounts[task.Depth+1]),
+			DepthLimitExempt:  task.Depth+1 <= config.MaxDepth && config.ExemptFromDepthLimit(task.Complexity),
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Dependents != b.Dependents {
+			return a.Dependents > b.Dependents
+		}
+		if a.Task.Complexity != b.Task.Complexity {
+			return a.Task.Complexity > b.Task.Complexity
+		}
+		return types.CompareCreation(a.Task, b.Task) < 0
+	})
+	return candidates
+}
+
+// SuggestedSubtaskCount returns how many subtasks a task of the given
+// complexity should be split into, but at least two
+func SuggestedSubtaskCount(complexity int) int {
+	count := (complexity + TargetSubtaskComplexity - 1) / TargetSubtaskComplexity
+	if count < 2 {
+		count = 2
+	}
+	return count
+}
@@ -0,0 +1,39 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindBreakdownCandidates(t *testing.T) {
+	plain := newTask("plain", types.TaskStatePending, 10, 0)
+	impactful := newTask("impactful", types.TaskStatePending, 8, 0)
+	parent := newTask("parent", types.TaskStatePending, 9, 0)
+	child := newTask("child", types.TaskStatePending, 3, 0)
+	child.ParentID = &parent.ID
+	child.Depth = 1
+	dependent := dependsOn(newTask("dependent", types.TaskStatePending, 2, 0), impactful)
+
+	config := manager.DefaultConfig()
+	config.MaxTasksPerDepth = 3
+	candidates := FindBreakdownCandidates([]*types.Task{plain, impactful, parent, child, dependent}, 8, config)
+
+	require.Len(t, candidates, 2)
+	assert.Equal(t, "impactful", candidates[0].Task.Title)
+	assert.Equal(t, 1, candidates[0].Dependents)
+	assert.Equal(t, 3, candidates[0].SuggestedSubtasks)
+	assert.Equal(t, 2, candidates[0].SubtaskCapacity)
+	assert.Equal(t, "plain", candidates[1].Task.Title)
+	assert.Equal(t, 4, candidates[1].SuggestedSubtasks)
+}
+
+func TestSuggestedSubtaskCount(t *testing.T) {
+	assert.Equal(t, 2, SuggestedSubtaskCount(1))
+	assert.Equal(t, 2, SuggestedSubtaskCount(6))
+	assert.Equal(t, 3, SuggestedSubtaskCount(9))
+	assert.Equal(t, 4, SuggestedSubtaskCount(10))
+}
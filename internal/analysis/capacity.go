@@ -0,0 +1,172 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+)
+
+// AgentLoad compares the estimated work assigned to an agent with the agent's
+// capacity within the planning horizon, in minutes
+type AgentLoad struct {
+	AgentID  uuid.UUID `json:"agent_id"`
+	Capacity int64     `json:"capacity"`
+	Assigned int64     `json:"assigned"`
+	// Free is Capacity - Assigned, negative if the agent is over-allocated
+	Free  int64 `json:"free"`
+	Tasks int   `json:"tasks"`
+	// Unestimated counts assigned tasks without an estimate, which are not
+	// included in Assigned
+	Unestimated   int  `json:"unestimated"`
+	Overallocated bool `json:"overallocated"`
+}
+
+// Reassignment suggests moving a pending task to an agent with free capacity
+type Reassignment struct {
+	TaskID   uuid.UUID `json:"task_id"`
+	Title    string    `json:"title"`
+	From     uuid.UUID `json:"from"`
+	To       uuid.UUID `json:"to"`
+	Estimate int64     `json:"estimate"`
+}
+
+// CapacityReport is the result of PlanCapacity
+type CapacityReport struct {
+	Horizon int64        `json:"horizon"`
+	Agents  []*AgentLoad `json:"agents"`
+	// Unassigned sums the estimates of open tasks without an agent
+	Unassigned    int64          `json:"unassigned"`
+	Reassignments []Reassignment `json:"reassignments"`
+}
+
+// PlanCapacity sums the estimates of pending and in-progress tasks per assigned
+// agent and compares them with the agents' configured weekly capacity, scaled
+// to a horizon of working minutes. Tasks with subtasks are skipped, their work
+// is estimated by the subtasks. Agents with a configured capacity are listed
+// even without assigned tasks.
+//
+// For over-allocated agents, pending tasks are suggested for reassignment to the
+// agent with the most free capacity that can take the whole task, lowest
+// priority and largest estimate first. In-progress tasks are never moved.
+func PlanCapacity(tasks []*types.Task, horizon int64, config *manager.Config) *CapacityReport {
+	report := &CapacityReport{
+		Horizon:       horizon,
+		Agents:        []*AgentLoad{},
+		Reassignments: []Reassignment{},
+	}
+
+	hasChildren := make(map[uuid.UUID]bool)
+	for _, task := range tasks {
+		if task.ParentID != nil {
+			hasChildren[*task.ParentID] = true
+		}
+	}
+
+	loads := make(map[uuid.UUID]*AgentLoad)
+	load := func(agentID uuid.UUID) *AgentLoad {
+		if l, ok := loads[agentID]; ok {
+			return l
+		}
+		l := &AgentLoad{
+			AgentID:  agentID,
+			Capacity: config.AgentCapacity(agentID) * horizon / utils.MinutesPerWeek,
+		}
+		loads[agentID] = l
+		report.Agents = append(report.Agents, l)
+		return l
+	}
+	for agentID := range config.AgentCapacities {
+		load(agentID)
+	}
+
+	movable := make(map[uuid.UUID][]*types.Task)
+	for _, task := range tasks {
+		if task.State != types.TaskStatePending && task.State != types.TaskStateInProgress {
+			continue
+		}
+		if hasChildren[task.ID] {
+			continue
+		}
+		if task.AssignedAgent == nil {
+			if task.Estimate != nil {
+				report.Unassigned += *task.Estimate
+			}
+			continue
+		}
+
+		l := load(*task.AssignedAgent)
+		l.Tasks++
+		if task.Estimate == nil {
+			l.Unestimated++
+			continue
+		}
+		l.Assigned += *task.Estimate
+		if task.State == types.TaskStatePending {
+			movable[l.AgentID] = append(movable[l.AgentID], task)
+		}
+	}
+
+	sort.Slice(report.Agents, func(i, j int) bool {
+		return report.Agents[i].AgentID.String() < report.Agents[j].AgentID.String()
+	})
+	// remaining tracks the free capacity left after suggested reassignments
+	remaining := make(map[uuid.UUID]int64, len(report.Agents))
+	for _, l := range report.Agents {
+		l.Free = l.Capacity - l.Assigned
+		l.Overallocated = l.Free < 0
+		remaining[l.AgentID] = l.Free
+	}
+
+	for _, from := range report.Agents {
+		candidates := movable[from.AgentID]
+		sort.SliceStable(candidates, func(i, j int) bool {
+			a, b := candidates[i], candidates[j]
+			if a.Priority != b.Priority {
+				return a.Priority > b.Priority // TaskPriorityLow is the largest value
+			}
+			return *a.Estimate > *b.Estimate
+		})
+		for _, task := range candidates {
+			if remaining[from.AgentID] >= 0 {
+				break
+			}
+			to, ok := mostFreeAgent(report.Agents, remaining, from.AgentID, *task.Estimate)
+			if !ok {
+				continue
+			}
+			remaining[from.AgentID] += *task.Estimate
+			remaining[to] -= *task.Estimate
+			report.Reassignments = append(report.Reassignments, Reassignment{
+				TaskID:   task.ID,
+				Title:    task.Title,
+				From:     from.AgentID,
+				To:       to,
+				Estimate: *task.Estimate,
+			})
+		}
+	}
+
+	return report
+}
+
+// mostFreeAgent returns the agent other than exclude with the most remaining
+// capacity, if it can take minutes of work
+func mostFreeAgent(loads []*AgentLoad, remaining map[uuid.UUID]int64, exclude uuid.UUID, minutes int64) (uuid.UUID, bool) {
+	var (
+		best  uuid.UUID
+		found bool
+	)
+	for _, l := range loads {
+		free := remaining[l.AgentID]
+		if l.AgentID == exclude || free < minutes {
+			continue
+		}
+		if !found || free > remaining[best] {
+			best, found = l.AgentID, true
+		}
+	}
+	return best, found
+}
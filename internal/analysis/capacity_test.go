@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanCapacity(t *testing.T) {
+	busy, small, idle := uuid.New(), uuid.New(), uuid.New()
+	assign := func(task *types.Task, agent uuid.UUID, priority types.TaskPriority) *types.Task {
+		task.AssignedAgent = &agent
+		task.Priority = priority
+		return task
+	}
+
+	started := assign(newTask("started", types.TaskStateInProgress, 5, 1200), busy, types.TaskPriorityHigh)
+	optional := assign(newTask("optional", types.TaskStatePending, 3, 900), busy, types.TaskPriorityLow)
+	regular := assign(newTask("regular", types.TaskStatePending, 3, 600), busy, types.TaskPriorityMedium)
+	unestimated := assign(newTask("unestimated", types.TaskStatePending, 3, 0), busy, types.TaskPriorityMedium)
+	done := assign(newTask("done", types.TaskStateCompleted, 3, 5000), busy, types.TaskPriorityMedium)
+	parent := assign(newTask("parent", types.TaskStatePending, 8, 3000), small, types.TaskPriorityMedium)
+	child := assign(newTask("child", types.TaskStatePending, 3, 300), small, types.TaskPriorityMedium)
+	child.ParentID = &parent.ID
+	unassigned := newTask("unassigned", types.TaskStatePending, 3, 120)
+
+	config := manager.DefaultConfig()
+	config.SetAgentCapacity(small, 600)
+	config.SetAgentCapacity(idle, utils.MinutesPerWeek)
+
+	report := PlanCapacity([]*types.Task{started, optional, regular, unestimated, done, parent, child, unassigned}, utils.MinutesPerWeek, config)
+
+	loads := make(map[uuid.UUID]*AgentLoad)
+	for _, load := range report.Agents {
+		loads[load.AgentID] = load
+	}
+	require.Len(t, loads, 3, "agents with a configured capacity are listed without tasks")
+
+	assert.Equal(t, int64(2700), loads[busy].Assigned)
+	assert.Equal(t, int64(-300), loads[busy].Free)
+	assert.Equal(t, 4, loads[busy].Tasks)
+	assert.Equal(t, 1, loads[busy].Unestimated)
+	assert.True(t, loads[busy].Overallocated)
+
+	assert.Equal(t, int64(300), loads[small].Assigned, "parent estimates are covered by subtasks")
+	assert.Equal(t, int64(600), loads[small].Capacity)
+	assert.False(t, loads[small].Overallocated)
+
+	assert.Equal(t, int64(120), report.Unassigned)
+
+	require.Len(t, report.Reassignments, 1)
+	assert.Equal(t, optional.ID, report.Reassignments[0].TaskID, "lowest priority pending task is moved first")
+	assert.Equal(t, idle, report.Reassignments[0].To)
+
+	t.Run("capacity scales with the horizon", func(t *testing.T) {
+		report := PlanCapacity(nil, 2*utils.MinutesPerWeek, config)
+		for _, load := range report.Agents {
+			if load.AgentID == small {
+				assert.Equal(t, int64(1200), load.Capacity)
+			}
+		}
+	})
+}
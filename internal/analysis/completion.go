@@ -0,0 +1,59 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// CompletionSource tells where a backfilled completion time comes from
+type CompletionSource string
+
+const (
+	// CompletionFromHistory is the time the change feed recorded the task
+	// moving to completed
+	CompletionFromHistory CompletionSource = "history"
+	// CompletionFromUpdatedAt is the last update of the task, used when the
+	// change feed has no record of the completion
+	CompletionFromUpdatedAt CompletionSource = "updated_at"
+)
+
+// CompletionBackfill is the completion time to set on a completed task that
+// has none
+type CompletionBackfill struct {
+	TaskID      uuid.UUID        `json:"task_id"`
+	Title       string           `json:"title"`
+	CompletedAt time.Time        `json:"completed_at"`
+	Source      CompletionSource `json:"source"`
+}
+
+// MissingCompletionTimes returns a backfill for every completed task without
+// a completion time, in the order of tasks. The completion time is taken from
+// the change feed, which records when the task last moved to completed, and
+// falls back to the last update of the task.
+func MissingCompletionTimes(tasks []*types.Task, events []*types.ChangeEvent) []CompletionBackfill {
+	changes := taskStateChanges(events)
+
+	backfills := make([]CompletionBackfill, 0)
+	for _, task := range tasks {
+		if task.State != types.TaskStateCompleted || task.CompletedAt != nil {
+			continue
+		}
+		backfill := CompletionBackfill{
+			TaskID:      task.ID,
+			Title:       task.Title,
+			CompletedAt: task.UpdatedAt,
+			Source:      CompletionFromUpdatedAt,
+		}
+		if change, ok := changes[task.ID]; ok && change.state == types.TaskStateCompleted {
+			backfill.CompletedAt = change.at
+			backfill.Source = CompletionFromHistory
+		}
+		if backfill.CompletedAt.Before(task.CreatedAt) {
+			backfill.CompletedAt = task.CreatedAt
+		}
+		backfills = append(backfills, backfill)
+	}
+	return backfills
+}
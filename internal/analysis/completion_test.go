@@ -0,0 +1,47 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingCompletionTimes(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2026, 10, day, hour, 0, 0, 0, time.UTC) }
+
+	recorded := newTask("recorded", types.TaskStateCompleted, 3, 0)
+	recorded.UpdatedAt = at(12, 9) // edited after completion
+	reopened := newTask("reopened", types.TaskStateCompleted, 3, 0)
+	reopened.UpdatedAt = at(11, 16)
+	unrecorded := newTask("unrecorded", types.TaskStateCompleted, 3, 0)
+	unrecorded.UpdatedAt = at(10, 12)
+	complete := newTask("complete", types.TaskStateCompleted, 3, 0)
+	complete.CompletedAt = ptrTime(at(10, 8))
+	pending := newTask("pending", types.TaskStatePending, 3, 0)
+
+	events := []*types.ChangeEvent{
+		stateEvent(1, recorded, types.TaskStateInProgress, "alice", at(10, 9)),
+		stateEvent(2, recorded, types.TaskStateCompleted, "alice", at(10, 17)),
+		stateEvent(6, recorded, types.TaskStateCompleted, "bob", at(12, 9)),
+		stateEvent(3, reopened, types.TaskStateCompleted, "alice", at(10, 18)),
+		stateEvent(4, reopened, types.TaskStateInProgress, "alice", at(11, 9)),
+		stateEvent(5, reopened, types.TaskStateCompleted, "alice", at(11, 15)),
+	}
+
+	backfills := MissingCompletionTimes([]*types.Task{recorded, reopened, unrecorded, complete, pending}, events)
+	require.Len(t, backfills, 3)
+
+	assert.Equal(t, recorded.ID, backfills[0].TaskID)
+	assert.Equal(t, at(10, 17), backfills[0].CompletedAt, "edits after the completion keep the completion time")
+	assert.Equal(t, CompletionFromHistory, backfills[0].Source)
+
+	assert.Equal(t, at(11, 15), backfills[1].CompletedAt, "the last completion counts")
+	assert.Equal(t, CompletionFromHistory, backfills[1].Source)
+
+	assert.Equal(t, unrecorded.ID, backfills[2].TaskID)
+	assert.Equal(t, at(10, 12), backfills[2].CompletedAt)
+	assert.Equal(t, CompletionFromUpdatedAt, backfills[2].Source)
+}
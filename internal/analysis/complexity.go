@@ -0,0 +1,168 @@
+// Package analysis provides read-only analyses over a project's tasks that
+// back the `knot analyze` commands.
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// ComplexitySource describes which signal a complexity suggestion is based on
+type ComplexitySource string
+
+const (
+	// ComplexitySourceEstimate compares the task's estimate with the project's
+	// calibrated minutes per complexity point
+	ComplexitySourceEstimate ComplexitySource = "estimate"
+	// ComplexitySourceChildren compares a parent task's complexity with the
+	// coordination level the auto-reduce table assigns to its number of subtasks
+	ComplexitySourceChildren ComplexitySource = "children"
+)
+
+const (
+	// MinCalibrationSamples is the number of completed, estimated leaf tasks
+	// needed before estimate-based suggestions are made
+	MinCalibrationSamples = 3
+	// ComplexityTolerance is the largest difference between current and
+	// suggested complexity that is not reported
+	ComplexityTolerance = 1
+)
+
+// ComplexitySuggestion is a recalibrated complexity value for a completed task
+type ComplexitySuggestion struct {
+	TaskID    uuid.UUID        `json:"task_id"`
+	Title     string           `json:"title"`
+	Current   int              `json:"current"`
+	Suggested int              `json:"suggested"`
+	Source    ComplexitySource `json:"source"`
+	Reason    string           `json:"reason"`
+}
+
+// ComplexityReport is the result of SuggestComplexity
+type ComplexityReport struct {
+	// MinutesPerPoint is the median estimate per complexity point over the
+	// calibration samples, or 0 when there are too few samples
+	MinutesPerPoint float64                `json:"minutes_per_point"`
+	Samples         int                    `json:"samples"`
+	Analyzed        int                    `json:"analyzed"`
+	Suggestions     []ComplexitySuggestion `json:"suggestions"`
+}
+
+// SuggestComplexity compares each completed task's complexity with its
+// estimate (leaf tasks) or its number of subtasks (parent tasks) and suggests
+// recalibrated values where they differ by more than ComplexityTolerance.
+func SuggestComplexity(tasks []*types.Task, config *manager.Config) *ComplexityReport {
+	childCounts := make(map[uuid.UUID]int)
+	for _, task := range tasks {
+		if task.ParentID != nil {
+			childCounts[*task.ParentID]++
+		}
+	}
+
+	var completed []*types.Task
+	var ratios []float64
+	for _, task := range tasks {
+		if task.State != types.TaskStateCompleted {
+			continue
+		}
+		completed = append(completed, task)
+		if childCounts[task.ID] == 0 && task.Estimate != nil && *task.Estimate > 0 && task.Complexity > 0 {
+			ratios = append(ratios, float64(*task.Estimate)/float64(task.Complexity))
+		}
+	}
+
+	report := &ComplexityReport{
+		Samples:     len(ratios),
+		Analyzed:    len(completed),
+		Suggestions: []ComplexitySuggestion{},
+	}
+	if len(ratios) >= MinCalibrationSamples {
+		report.MinutesPerPoint = median(ratios)
+	}
+
+	for _, task := range completed {
+		var suggestion *ComplexitySuggestion
+		if count := childCounts[task.ID]; count > 0 {
+			suggestion = suggestFromChildren(task, count, config)
+		} else if report.MinutesPerPoint > 0 && task.Estimate != nil && *task.Estimate > 0 {
+			suggestion = suggestFromEstimate(task, report.MinutesPerPoint)
+		}
+		if suggestion != nil {
+			report.Suggestions = append(report.Suggestions, *suggestion)
+		}
+	}
+
+	sort.SliceStable(report.Suggestions, func(i, j int) bool {
+		return abs(report.Suggestions[i].Suggested-report.Suggestions[i].Current) >
+			abs(report.Suggestions[j].Suggested-report.Suggestions[j].Current)
+	})
+
+	return report
+}
+
+func suggestFromEstimate(task *types.Task, minutesPerPoint float64) *ComplexitySuggestion {
+	suggested := clampComplexity(int(math.Round(float64(*task.Estimate) / minutesPerPoint)))
+	if abs(suggested-task.Complexity) <= ComplexityTolerance {
+		return nil
+	}
+	return &ComplexitySuggestion{
+		TaskID:    task.ID,
+		Title:     task.Title,
+		Current:   task.Complexity,
+		Suggested: suggested,
+		Source:    ComplexitySourceEstimate,
+		Reason: fmt.Sprintf("estimate of %d min at %.0f min per complexity point",
+			*task.Estimate, minutesPerPoint),
+	}
+}
+
+// suggestFromChildren only ever lowers complexity: a parent that was broken
+// down is a coordination task whose own effort shrinks with its subtask count.
+// Relative steps of the reduction table say nothing about the target level and
+// are ignored.
+func suggestFromChildren(task *types.Task, childCount int, config *manager.Config) *ComplexitySuggestion {
+	step, ok := config.ReductionFor(childCount)
+	if !ok || step.Complexity == 0 || task.Complexity-step.Complexity <= ComplexityTolerance {
+		return nil
+	}
+	return &ComplexitySuggestion{
+		TaskID:    task.ID,
+		Title:     task.Title,
+		Current:   task.Complexity,
+		Suggested: step.Complexity,
+		Source:    ComplexitySourceChildren,
+		Reason:    fmt.Sprintf("coordinates %d subtasks", childCount),
+	}
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func clampComplexity(value int) int {
+	if value < 1 {
+		return 1
+	}
+	if value > 10 {
+		return 10
+	}
+	return value
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,72 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTask(title string, state types.TaskState, complexity int, estimate int64) *types.Task {
+	task := &types.Task{ID: uuid.New(), Title: title, State: state, Complexity: complexity}
+	if estimate > 0 {
+		task.Estimate = &estimate
+	}
+	return task
+}
+
+func TestSuggestComplexityFromEstimates(t *testing.T) {
+	tasks := []*types.Task{
+		newTask("a", types.TaskStateCompleted, 2, 60),
+		newTask("b", types.TaskStateCompleted, 4, 120),
+		newTask("c", types.TaskStateCompleted, 3, 90),
+		newTask("underrated", types.TaskStateCompleted, 2, 240),
+		newTask("close enough", types.TaskStateCompleted, 5, 180),
+		newTask("pending", types.TaskStatePending, 1, 300),
+	}
+
+	report := SuggestComplexity(tasks, manager.DefaultConfig())
+	assert.Equal(t, 5, report.Samples)
+	assert.Equal(t, 5, report.Analyzed)
+	assert.InDelta(t, 30.0, report.MinutesPerPoint, 0.001)
+
+	require.Len(t, report.Suggestions, 1)
+	assert.Equal(t, "underrated", report.Suggestions[0].Title)
+	assert.Equal(t, 2, report.Suggestions[0].Current)
+	assert.Equal(t, 8, report.Suggestions[0].Suggested)
+	assert.Equal(t, ComplexitySourceEstimate, report.Suggestions[0].Source)
+}
+
+func TestSuggestComplexityNeedsCalibrationSamples(t *testing.T) {
+	tasks := []*types.Task{
+		newTask("a", types.TaskStateCompleted, 2, 60),
+		newTask("b", types.TaskStateCompleted, 9, 30),
+	}
+
+	report := SuggestComplexity(tasks, manager.DefaultConfig())
+	assert.Zero(t, report.MinutesPerPoint)
+	assert.Empty(t, report.Suggestions)
+}
+
+func TestSuggestComplexityFromChildren(t *testing.T) {
+	parent := newTask("parent", types.TaskStateCompleted, 9, 0)
+	single := newTask("single", types.TaskStateCompleted, 9, 0)
+	tasks := []*types.Task{parent, single}
+	for i := 0; i < 4; i++ {
+		child := newTask("child", types.TaskStateCompleted, 3, 0)
+		child.ParentID = &parent.ID
+		tasks = append(tasks, child)
+	}
+	onlyChild := newTask("only child", types.TaskStatePending, 3, 0)
+	onlyChild.ParentID = &single.ID
+	tasks = append(tasks, onlyChild)
+
+	report := SuggestComplexity(tasks, manager.DefaultConfig())
+	require.Len(t, report.Suggestions, 1)
+	assert.Equal(t, parent.ID, report.Suggestions[0].TaskID)
+	assert.Equal(t, 3, report.Suggestions[0].Suggested)
+	assert.Equal(t, ComplexitySourceChildren, report.Suggestions[0].Source)
+}
@@ -0,0 +1,205 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// TaskRef identifies a task in an analysis report
+type TaskRef struct {
+	TaskID uuid.UUID       `json:"task_id"`
+	Title  string          `json:"title"`
+	State  types.TaskState `json:"state"`
+}
+
+// BlockedTask is a pending task that cannot be started yet
+type BlockedTask struct {
+	TaskRef
+	UnmetDependencies   []TaskRef   `json:"unmet_dependencies"`
+	MissingDependencies []uuid.UUID `json:"missing_dependencies"`
+}
+
+// Unblocker is a task whose completion unblocks pending work. Unblocks is the
+// number of pending tasks that become startable once this task and all
+// unblockers listed before it are completed.
+type Unblocker struct {
+	TaskRef
+	Unblocks int `json:"unblocks"`
+}
+
+// DeadlockReport is the result of ExplainDeadlock
+type DeadlockReport struct {
+	// Deadlocked is true when pending tasks exist but none of them can be
+	// started and no task is in progress
+	Deadlocked bool          `json:"deadlocked"`
+	Pending    int           `json:"pending"`
+	Startable  int           `json:"startable"`
+	InProgress int           `json:"in_progress"`
+	Blocked    []BlockedTask `json:"blocked"`
+	// Unblockers is a small set of tasks, in completion order, whose completion
+	// unblocks as much pending work as possible
+	Unblockers []Unblocker `json:"unblockers"`
+	// Unreachable lists blocked tasks that no sequence of completions can
+	// unblock, because of missing dependencies or dependency cycles
+	Unreachable []TaskRef `json:"unreachable"`
+}
+
+// ExplainDeadlock lists each pending task with its unmet and missing
+// dependencies and computes a greedy, minimal set of tasks whose completion
+// unblocks the most pending work.
+func ExplainDeadlock(tasks []*types.Task) *DeadlockReport {
+	taskMap := make(map[uuid.UUID]*types.Task, len(tasks))
+	done := make(map[uuid.UUID]bool)
+	for _, task := range tasks {
+		taskMap[task.ID] = task
+		if task.State == types.TaskStateCompleted {
+			done[task.ID] = true
+		}
+	}
+
+	report := &DeadlockReport{
+		Blocked:     make([]BlockedTask, 0),
+		Unblockers:  make([]Unblocker, 0),
+		Unreachable: make([]TaskRef, 0),
+	}
+
+	var remaining []*types.Task
+	for _, task := range sortedByTitle(tasks) {
+		switch task.State {
+		case types.TaskStateInProgress:
+			report.InProgress++
+			continue
+		case types.TaskStatePending:
+			report.Pending++
+		default:
+			continue
+		}
+
+		blocked := BlockedTask{
+			TaskRef:             refOf(task),
+			UnmetDependencies:   make([]TaskRef, 0),
+			MissingDependencies: make([]uuid.UUID, 0),
+		}
+		for _, depID := range task.Dependencies {
+			dep, exists := taskMap[depID]
+			switch {
+			case !exists:
+				blocked.MissingDependencies = append(blocked.MissingDependencies, depID)
+			case dep.State != types.TaskStateCompleted:
+				blocked.UnmetDependencies = append(blocked.UnmetDependencies, refOf(dep))
+			}
+		}
+
+		if len(blocked.UnmetDependencies) == 0 && len(blocked.MissingDependencies) == 0 {
+			report.Startable++
+			continue
+		}
+		report.Blocked = append(report.Blocked, blocked)
+		if len(blocked.MissingDependencies) > 0 {
+			report.Unreachable = append(report.Unreachable, blocked.TaskRef)
+		} else {
+			remaining = append(remaining, task)
+		}
+	}
+
+	report.Deadlocked = report.Pending > 0 && report.Startable == 0 && report.InProgress == 0
+
+	// Greedily complete the unmet dependency that unblocks the most pending
+	// tasks, only considering tasks whose own dependencies are already met
+	for len(remaining) > 0 {
+		best, bestUnblocks, bestEdges := (*types.Task)(nil), -1, -1
+		for _, candidate := range unblockCandidates(remaining, taskMap, done) {
+			unblocks, edges := 0, 0
+			for _, task := range remaining {
+				if containsID(task.Dependencies, candidate.ID) {
+					edges++
+				}
+				if dependenciesDone(task, done, candidate.ID) {
+					unblocks++
+				}
+			}
+			if unblocks > bestUnblocks || (unblocks == bestUnblocks && edges > bestEdges) {
+				best, bestUnblocks, bestEdges = candidate, unblocks, edges
+			}
+		}
+		if best == nil {
+			break
+		}
+
+		done[best.ID] = true
+		report.Unblockers = append(report.Unblockers, Unblocker{TaskRef: refOf(best), Unblocks: bestUnblocks})
+
+		still := remaining[:0]
+		for _, task := range remaining {
+			if !dependenciesDone(task, done, uuid.Nil) {
+				still = append(still, task)
+			}
+		}
+		remaining = still
+	}
+
+	for _, task := range remaining {
+		report.Unreachable = append(report.Unreachable, refOf(task))
+	}
+
+	return report
+}
+
+// unblockCandidates returns the unmet dependencies of the remaining tasks that
+// could be completed right now, ordered by title
+func unblockCandidates(remaining []*types.Task, taskMap map[uuid.UUID]*types.Task, done map[uuid.UUID]bool) []*types.Task {
+	seen := make(map[uuid.UUID]bool)
+	var candidates []*types.Task
+	for _, task := range remaining {
+		for _, depID := range task.Dependencies {
+			dep, exists := taskMap[depID]
+			if !exists || done[depID] || seen[depID] {
+				continue
+			}
+			seen[depID] = true
+			if dependenciesDone(dep, done, uuid.Nil) {
+				candidates = append(candidates, dep)
+			}
+		}
+	}
+	return sortedByTitle(candidates)
+}
+
+// dependenciesDone reports whether all dependencies of task are done, treating
+// extra as done as well
+func dependenciesDone(task *types.Task, done map[uuid.UUID]bool, extra uuid.UUID) bool {
+	for _, depID := range task.Dependencies {
+		if !done[depID] && depID != extra {
+			return false
+		}
+	}
+	return true
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func refOf(task *types.Task) TaskRef {
+	return TaskRef{TaskID: task.ID, Title: task.Title, State: task.State}
+}
+
+// sortedByTitle returns a copy of tasks ordered by title and ID, so reports are stable
+func sortedByTitle(tasks []*types.Task) []*types.Task {
+	sorted := make([]*types.Task, len(tasks))
+	copy(sorted, tasks)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Title != sorted[j].Title {
+			return sorted[i].Title < sorted[j].Title
+		}
+		return sorted[i].ID.String() < sorted[j].ID.String()
+	})
+	return sorted
+}
@@ -0,0 +1,62 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dependsOn(task *types.Task, deps ...*types.Task) *types.Task {
+	for _, dep := range deps {
+		task.Dependencies = append(task.Dependencies, dep.ID)
+	}
+	return task
+}
+
+func TestExplainDeadlock(t *testing.T) {
+	blocker := newTask("blocker", types.TaskStateBlocked, 3, 0)
+	other := newTask("other", types.TaskStateCancelled, 3, 0)
+	a := dependsOn(newTask("a", types.TaskStatePending, 3, 0), blocker)
+	b := dependsOn(newTask("b", types.TaskStatePending, 3, 0), blocker, a)
+	c := dependsOn(newTask("c", types.TaskStatePending, 3, 0), other)
+	missing := newTask("missing", types.TaskStatePending, 3, 0)
+	missing.Dependencies = []uuid.UUID{uuid.New()}
+
+	report := ExplainDeadlock([]*types.Task{blocker, other, a, b, c, missing})
+
+	assert.True(t, report.Deadlocked)
+	assert.Equal(t, 4, report.Pending)
+	assert.Equal(t, 0, report.Startable)
+	require.Len(t, report.Blocked, 4)
+	assert.Equal(t, "a", report.Blocked[0].Title)
+	require.Len(t, report.Blocked[0].UnmetDependencies, 1)
+	assert.Equal(t, blocker.ID, report.Blocked[0].UnmetDependencies[0].TaskID)
+	assert.Len(t, report.Blocked[3].MissingDependencies, 1)
+
+	// Completing "blocker" unblocks a, then a unblocks b, then "other" unblocks c
+	require.Len(t, report.Unblockers, 3)
+	assert.Equal(t, "blocker", report.Unblockers[0].Title)
+	assert.Equal(t, 1, report.Unblockers[0].Unblocks)
+	assert.Equal(t, "a", report.Unblockers[1].Title)
+	assert.Equal(t, "other", report.Unblockers[2].Title)
+
+	require.Len(t, report.Unreachable, 1)
+	assert.Equal(t, "missing", report.Unreachable[0].Title)
+}
+
+func TestExplainDeadlockCycle(t *testing.T) {
+	a := newTask("a", types.TaskStatePending, 3, 0)
+	b := dependsOn(newTask("b", types.TaskStatePending, 3, 0), a)
+	dependsOn(a, b)
+	free := newTask("free", types.TaskStatePending, 3, 0)
+
+	report := ExplainDeadlock([]*types.Task{a, b, free})
+
+	assert.False(t, report.Deadlocked)
+	assert.Equal(t, 1, report.Startable)
+	assert.Empty(t, report.Unblockers)
+	assert.Len(t, report.Unreachable, 2)
+}
@@ -0,0 +1,128 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// DigestItem is one task of a digest section
+type DigestItem struct {
+	TaskID uuid.UUID       `json:"task_id"`
+	Title  string          `json:"title"`
+	State  types.TaskState `json:"state"`
+	// At is when the task was completed, created or blocked, nil if unknown
+	At      *time.Time `json:"at,omitempty"`
+	DueDate *time.Time `json:"due_date,omitempty"`
+}
+
+// ProjectDigest summarizes what happened in a project during a period and
+// what is due next
+type ProjectDigest struct {
+	ProjectID    uuid.UUID `json:"project_id"`
+	ProjectTitle string    `json:"project_title"`
+	Since        time.Time `json:"since"`
+	Until        time.Time `json:"until"`
+	// Completed are the tasks completed during the period
+	Completed []DigestItem `json:"completed"`
+	// Created are the tasks created during the period
+	Created []DigestItem `json:"created"`
+	// Blocked are the tasks blocked during the period that are still blocked
+	Blocked []DigestItem `json:"blocked"`
+	// Overdue are the open tasks whose due date has passed
+	Overdue []DigestItem `json:"overdue"`
+	// Upcoming are the open tasks due within the next period, the milestones
+	// ahead
+	Upcoming []DigestItem `json:"upcoming"`
+}
+
+// Empty reports whether the project has nothing to report
+func (d *ProjectDigest) Empty() bool {
+	return len(d.Completed) == 0 && len(d.Created) == 0 && len(d.Blocked) == 0 &&
+		len(d.Overdue) == 0 && len(d.Upcoming) == 0
+}
+
+// BuildProjectDigest summarizes the period from since to until of a project
+// from its tasks and change feed. Upcoming tasks are due within a period of
+// the same length after until. When a task was blocked is taken from the
+// change feed, otherwise from its external blocker or last update.
+func BuildProjectDigest(project *types.Project, tasks []*types.Task, events []*types.ChangeEvent, since, until time.Time) *ProjectDigest {
+	digest := &ProjectDigest{
+		ProjectID:    project.ID,
+		ProjectTitle: project.Title,
+		Since:        since,
+		Until:        until,
+		Completed:    []DigestItem{},
+		Created:      []DigestItem{},
+		Blocked:      []DigestItem{},
+		Overdue:      []DigestItem{},
+		Upcoming:     []DigestItem{},
+	}
+	changes := taskStateChanges(events)
+	within := func(t time.Time) bool { return !t.Before(since) && t.Before(until) }
+	today := time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, until.Location())
+	horizon := until.Add(until.Sub(since))
+
+	for _, task := range tasks {
+		item := DigestItem{TaskID: task.ID, Title: task.Title, State: task.State, DueDate: task.DueDate}
+		at := func(t time.Time) DigestItem {
+			item := item
+			item.At = &t
+			return item
+		}
+
+		if within(task.CreatedAt) {
+			digest.Created = append(digest.Created, at(task.CreatedAt))
+		}
+		switch task.State {
+		case types.TaskStateCompleted:
+			if task.CompletedAt != nil && within(*task.CompletedAt) {
+				digest.Completed = append(digest.Completed, at(*task.CompletedAt))
+			}
+			continue
+		case types.TaskStateCancelled, types.TaskStateDeletionPending:
+			continue
+		case types.TaskStateBlocked:
+			blockedAt := task.UpdatedAt
+			if change, ok := changes[task.ID]; ok {
+				blockedAt = change.at
+			} else if task.Blocker != nil {
+				blockedAt = task.Blocker.BlockedAt
+			}
+			if within(blockedAt) {
+				digest.Blocked = append(digest.Blocked, at(blockedAt))
+			}
+		}
+
+		if task.DueDate == nil {
+			continue
+		}
+		switch {
+		case task.DueDate.Before(today):
+			digest.Overdue = append(digest.Overdue, item)
+		case task.DueDate.Before(horizon):
+			digest.Upcoming = append(digest.Upcoming, item)
+		}
+	}
+
+	for _, items := range [][]DigestItem{digest.Completed, digest.Created, digest.Blocked} {
+		sortDigestItems(items, func(item DigestItem) time.Time { return *item.At })
+	}
+	for _, items := range [][]DigestItem{digest.Overdue, digest.Upcoming} {
+		sortDigestItems(items, func(item DigestItem) time.Time { return *item.DueDate })
+	}
+	return digest
+}
+
+// sortDigestItems orders items by the time key returns, then by title
+func sortDigestItems(items []DigestItem, key func(DigestItem) time.Time) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := key(items[i]), key(items[j])
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return items[i].Title < items[j].Title
+	})
+}
@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildProjectDigest(t *testing.T) {
+	project := &types.Project{ID: uuid.New(), Title: "Launch"}
+	since := time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 7)
+	at := func(day int) time.Time { return time.Date(2026, 10, day, 9, 0, 0, 0, time.UTC) }
+	longAgo := at(1)
+
+	shipped := newTask("shipped", types.TaskStateCompleted, 3, 0)
+	shipped.CreatedAt = longAgo
+	shipped.CompletedAt = ptrTime(at(12))
+	oldDone := newTask("old done", types.TaskStateCompleted, 3, 0)
+	oldDone.CreatedAt = longAgo
+	oldDone.CompletedAt = ptrTime(at(2))
+	fresh := newTask("fresh", types.TaskStatePending, 3, 0)
+	fresh.CreatedAt = at(14)
+	stuck := newTask("stuck", types.TaskStateBlocked, 3, 0)
+	stuck.CreatedAt = longAgo
+	stuck.UpdatedAt = at(15) // a later comment, the feed knows when it was blocked
+	stillStuck := newTask("still stuck", types.TaskStateBlocked, 3, 0)
+	stillStuck.CreatedAt = longAgo
+	stillStuck.Blocker = &types.TaskBlocker{Reason: "vendor", BlockedAt: at(3)}
+	stillStuck.UpdatedAt = at(13)
+	late := newTask("late", types.TaskStateInProgress, 3, 0)
+	late.CreatedAt = longAgo
+	late.DueDate = ptrTime(at(14))
+	beta := newTask("beta", types.TaskStatePending, 3, 0)
+	beta.CreatedAt = longAgo
+	beta.DueDate = ptrTime(at(20))
+	release := newTask("release", types.TaskStatePending, 3, 0)
+	release.CreatedAt = longAgo
+	release.DueDate = ptrTime(at(30))
+	dropped := newTask("dropped", types.TaskStateCancelled, 3, 0)
+	dropped.CreatedAt = at(10)
+	dropped.DueDate = ptrTime(at(11))
+	tasks := []*types.Task{shipped, oldDone, fresh, stuck, stillStuck, late, beta, release, dropped}
+
+	events := []*types.ChangeEvent{
+		stateEvent(1, stuck, types.TaskStateBlocked, "alice", at(11)),
+	}
+
+	digest := BuildProjectDigest(project, tasks, events, since, until)
+	assert.Equal(t, "Launch", digest.ProjectTitle)
+	titles := func(items []DigestItem) []string {
+		var result []string
+		for _, item := range items {
+			result = append(result, item.Title)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"shipped"}, titles(digest.Completed))
+	assert.Equal(t, []string{"dropped", "fresh"}, titles(digest.Created), "created tasks are listed whatever their state")
+	assert.Equal(t, []string{"stuck"}, titles(digest.Blocked), "tasks blocked before the period are left out")
+	require.Len(t, digest.Blocked, 1)
+	assert.Equal(t, at(11), *digest.Blocked[0].At)
+	assert.Equal(t, []string{"late"}, titles(digest.Overdue))
+	assert.Equal(t, []string{"beta"}, titles(digest.Upcoming), "only tasks due within the next period are upcoming")
+	assert.False(t, digest.Empty())
+
+	quiet := BuildProjectDigest(project, []*types.Task{oldDone, release}, nil, since, until)
+	assert.True(t, quiet.Empty())
+}
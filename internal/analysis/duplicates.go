@@ -0,0 +1,153 @@
+package analysis
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// SimilarTask is an existing task whose title is close to a new title
+type SimilarTask struct {
+	Task *types.Task `json:"task"`
+	// Similarity is between 0 (unrelated) and 1 (same title)
+	Similarity float64 `json:"similarity"`
+}
+
+// FindSimilarTasks returns the tasks whose title has a similarity of at least
+// threshold to title, most similar first. Cancelled tasks and tasks pending
+// deletion are ignored.
+func FindSimilarTasks(title string, tasks []*types.Task, threshold float64) []SimilarTask {
+	similar := make([]SimilarTask, 0)
+	for _, task := range tasks {
+		if task.State == types.TaskStateCancelled || task.State == types.TaskStateDeletionPending {
+			continue
+		}
+		if score := TitleSimilarity(title, task.Title); score >= threshold {
+			similar = append(similar, SimilarTask{Task: task, Similarity: score})
+		}
+	}
+
+	sort.SliceStable(similar, func(i, j int) bool {
+		return similar[i].Similarity > similar[j].Similarity
+	})
+	return similar
+}
+
+// TitleSimilarity compares two titles ignoring case, punctuation and extra
+// whitespace. It is the higher of the edit distance similarity, which catches
+// typos, and the word overlap, which catches reordered words. Titles with
+// different numbers, such as "Child 1" and "Child 2", name separate items and
+// have no similarity; spelled-out numbers and ordinals count as numbers.
+func TitleSimilarity(a, b string) float64 {
+	wordsA, wordsB := titleWords(a), titleWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+	if !slices.Equal(titleNumbers(wordsA), titleNumbers(wordsB)) {
+		return 0
+	}
+
+	normalizedA, normalizedB := strings.Join(wordsA, " "), strings.Join(wordsB, " ")
+	if normalizedA == normalizedB {
+		return 1
+	}
+	return max(editSimilarity([]rune(normalizedA), []rune(normalizedB)), wordOverlap(wordsA, wordsB))
+}
+
+// titleWords returns the lower case words of a title, with numbers and
+// ordinals written as digits
+func titleWords(title string) []string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for i, word := range words {
+		if value, ok := numberWords[word]; ok {
+			words[i] = value
+		} else if digits := strings.TrimRight(word, "stndrh"); isDigits(digits) {
+			words[i] = trimZeros(digits) // 7, 07, 1st, 2nd, 3rd, 4th
+		}
+	}
+	return words
+}
+
+// numberWords maps spelled-out numbers and ordinals to digits
+var numberWords = map[string]string{
+	"one": "1", "first": "1",
+	"two": "2", "second": "2",
+	"three": "3", "third": "3",
+	"four": "4", "fourth": "4",
+	"five": "5", "fifth": "5",
+	"six": "6", "sixth": "6",
+	"seven": "7", "seventh": "7",
+	"eight": "8", "eighth": "8",
+	"nine": "9", "ninth": "9",
+	"ten": "10", "tenth": "10",
+}
+
+// titleNumbers returns the distinct numbers in words, including those within
+// words such as "v2", sorted
+func titleNumbers(words []string) []string {
+	numbers := make([]string, 0)
+	for _, word := range words {
+		for _, number := range strings.FieldsFunc(word, func(r rune) bool { return !unicode.IsDigit(r) }) {
+			numbers = append(numbers, trimZeros(number))
+		}
+	}
+	slices.Sort(numbers)
+	return slices.Compact(numbers)
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.TrimLeft(s, "0123456789") == ""
+}
+
+func trimZeros(number string) string {
+	if trimmed := strings.TrimLeft(number, "0"); trimmed != "" {
+		return trimmed
+	}
+	return "0"
+}
+
+// editSimilarity is 1 minus the Levenshtein distance relative to the longer text
+func editSimilarity(a, b []rune) float64 {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return 1 - float64(prev[len(b)])/float64(max(len(a), len(b)))
+}
+
+// wordOverlap is the Dice coefficient of the distinct words of both titles
+func wordOverlap(a, b []string) float64 {
+	setA := make(map[string]bool, len(a))
+	for _, word := range a {
+		setA[word] = true
+	}
+	setB := make(map[string]bool, len(b))
+	shared := 0
+	for _, word := range b {
+		if setB[word] {
+			continue
+		}
+		setB[word] = true
+		if setA[word] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(setA)+len(setB))
+}
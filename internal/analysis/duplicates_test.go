@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTitleSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, TitleSimilarity("Fix login bug", "fix  login bug!"))
+	assert.Greater(t, TitleSimilarity("Fix login bug", "Fix logn bug"), 0.9, "typo")
+	assert.GreaterOrEqual(t, TitleSimilarity("Add tests for login", "Add login tests"), 0.8, "reordered words")
+	assert.Less(t, TitleSimilarity("Fix login bug", "Write release notes"), 0.5)
+	assert.Equal(t, 0.0, TitleSimilarity("", "Fix login bug"))
+	assert.Greater(t, TitleSimilarity("修复登录错误", "修复登录的错误"), 0.8)
+}
+
+func TestTitleSimilarityNumbers(t *testing.T) {
+	// Items of a series are not duplicates of each other
+	for a, b := range map[string]string{
+		"child 1":              "child 2",
+		"Phase 1: design":      "Phase 2: design",
+		"Migrate API v1":       "Migrate API v2",
+		"First review round":   "Second review round",
+		"Release 2.0":          "Release 2.1",
+		"Write chapter 3":      "Write chapter 3 and 4",
+		"Fix issue 1234":       "Fix issue 1243",
+		"Step one: setup":      "Step two: setup",
+		"Deploy to 3rd region": "Deploy to 4th region",
+	} {
+		assert.Equal(t, 0.0, TitleSimilarity(a, b), "%q and %q", a, b)
+	}
+
+	// The same number in different spellings still matches
+	assert.Equal(t, 1.0, TitleSimilarity("Step 2", "step two"))
+	assert.Equal(t, 1.0, TitleSimilarity("2nd review", "Second review"))
+	assert.Equal(t, 1.0, TitleSimilarity("Chapter 07", "chapter 7"))
+	assert.Greater(t, TitleSimilarity("Write chapter 3", "Write chaptr 3"), 0.9, "typo")
+}
+
+func TestFindSimilarTasks(t *testing.T) {
+	exact := newTask("Implement user login", types.TaskStateCompleted, 3, 0)
+	near := newTask("Implement the user login", types.TaskStatePending, 3, 0)
+	cancelled := newTask("Implement user login", types.TaskStateCancelled, 3, 0)
+	unrelated := newTask("Write API documentation", types.TaskStatePending, 3, 0)
+
+	similar := FindSimilarTasks("implement user login", []*types.Task{near, cancelled, unrelated, exact}, 0.8)
+
+	require.Len(t, similar, 2)
+	assert.Equal(t, exact, similar[0].Task)
+	assert.Equal(t, 1.0, similar[0].Similarity)
+	assert.Equal(t, near, similar[1].Task)
+	assert.Empty(t, FindSimilarTasks("implement user login", []*types.Task{unrelated}, 0.8))
+}
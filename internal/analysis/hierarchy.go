@@ -0,0 +1,316 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/plan"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// Hierarchy smells reported by AnalyzeHierarchy
+const (
+	// SmellTooManySiblings flags a level with more than the maximum number of
+	// direct subtasks
+	SmellTooManySiblings = "too-many-siblings"
+	// SmellSingleChildChain flags tasks nested below each other with only one
+	// subtask each
+	SmellSingleChildChain = "single-child-chain"
+	// SmellDepthViolation flags tasks deeper than the maximum depth and depth
+	// levels with more tasks than allowed, e.g. after the limits were lowered
+	SmellDepthViolation = "depth-violation"
+)
+
+// Restructuring operations suggested for hierarchy smells
+const (
+	// RestructureGroup creates a new parent at the level and moves the tasks below it
+	RestructureGroup = "group"
+	// RestructureFlatten moves the tasks of a chain below its top task
+	RestructureFlatten = "flatten"
+	// RestructureMoveUp moves the tasks below a higher ancestor or to the root level
+	RestructureMoveUp = "move-up"
+)
+
+// Restructuring is a suggested change to the task hierarchy
+type Restructuring struct {
+	Kind string `json:"kind"`
+	// GroupTitle is the title of the parent created by RestructureGroup
+	GroupTitle string `json:"group_title,omitempty"`
+	// Parent is the task the tasks (or the new group) end up below, nil for
+	// the root level
+	Parent *TaskRef `json:"parent,omitempty"`
+	// Tasks are moved with their subtasks, in this order
+	Tasks []TaskRef `json:"tasks"`
+}
+
+// HierarchyFinding is a hierarchy smell with the suggested restructuring
+type HierarchyFinding struct {
+	Smell string `json:"smell"`
+	// Parent is the task whose level the smell was found at, nil for the root
+	// level or for smells not bound to a level
+	Parent  *TaskRef  `json:"parent,omitempty"`
+	Tasks   []TaskRef `json:"tasks"`
+	Message string    `json:"message"`
+	// Suggestions is empty if no move fixes the smell within the limits
+	Suggestions []Restructuring `json:"suggestions"`
+}
+
+// AnalyzeHierarchy finds levels with more than maxSiblings tasks (skipped if
+// maxSiblings is 0), chains of two or more single-subtask links and
+// violations of the depth limits of config, and suggests how to restructure
+// the tasks. Levels are reported top-down in sibling order.
+func AnalyzeHierarchy(tasks []*types.Task, maxSiblings int, config *manager.Config) []HierarchyFinding {
+	h := newHierarchy(tasks)
+	findings := []HierarchyFinding{}
+
+	// Depth violations come first, since the other suggestions assume valid depths
+	depthCounts := make(map[int]int)
+	for _, task := range tasks {
+		depthCounts[task.Depth]++
+	}
+	h.walk(func(task *types.Task) {
+		if task.Depth <= config.MaxDepth {
+			return
+		}
+		if parent := h.ref(task.ParentID); parent != nil && h.byID[parent.TaskID].Depth > config.MaxDepth {
+			return // moved along with the violating ancestor
+		}
+		finding := HierarchyFinding{
+			Smell:   SmellDepthViolation,
+			Tasks:   []TaskRef{taskRef(task)},
+			Message: fmt.Sprintf("at depth %d, the maximum depth is %d", task.Depth, config.MaxDepth),
+		}
+		// The subtasks have to fit below the maximum depth as well
+		depth := config.MaxDepth - h.height(task.ID)
+		if depth >= 0 {
+			finding.Suggestions = append(finding.Suggestions, Restructuring{
+				Kind:   RestructureMoveUp,
+				Parent: h.ancestorRef(task, depth-1),
+				Tasks:  []TaskRef{taskRef(task)},
+			})
+		}
+		findings = append(findings, finding)
+	})
+	for depth := 0; depth <= config.MaxDepth; depth++ {
+		if limit := config.MaxTasksAt(depth); limit > 0 && depthCounts[depth] > limit {
+			findings = append(findings, HierarchyFinding{
+				Smell:   SmellDepthViolation,
+				Tasks:   []TaskRef{},
+				Message: fmt.Sprintf("%d tasks at depth %d, the limit is %d", depthCounts[depth], depth, limit),
+			})
+		}
+	}
+
+	if maxSiblings > 0 {
+		h.walkLevels(func(parent *types.Task, siblings []*types.Task) {
+			if len(siblings) <= maxSiblings {
+				return
+			}
+			findings = append(findings, h.tooManySiblings(parent, siblings, maxSiblings, config.MaxDepth))
+		})
+	}
+
+	h.walk(func(task *types.Task) {
+		if len(h.children[task.ID]) != 1 {
+			return
+		}
+		if task.ParentID != nil && len(h.children[*task.ParentID]) == 1 {
+			return // not the top of the chain
+		}
+		chain := []*types.Task{task}
+		for next := task; len(h.children[next.ID]) == 1; {
+			next = h.children[next.ID][0]
+			chain = append(chain, next)
+		}
+		if len(chain) < 3 {
+			return
+		}
+		finding := HierarchyFinding{
+			Smell:   SmellSingleChildChain,
+			Parent:  h.ref(task.ParentID),
+			Message: fmt.Sprintf("%d levels with a single subtask each", len(chain)-1),
+			Suggestions: []Restructuring{{
+				Kind:   RestructureFlatten,
+				Parent: refPtr(chain[0]),
+			}},
+		}
+		for i, link := range chain {
+			finding.Tasks = append(finding.Tasks, taskRef(link))
+			if i >= 2 {
+				finding.Suggestions[0].Tasks = append(finding.Suggestions[0].Tasks, taskRef(link))
+			}
+		}
+		findings = append(findings, finding)
+	})
+
+	return findings
+}
+
+// HierarchyPlan turns the suggestions of the findings into a plan for 'knot
+// plan apply'. New groups are referenced as group-1, group-2 and so on.
+func HierarchyPlan(findings []HierarchyFinding) *plan.Plan {
+	p := &plan.Plan{}
+	groups := 0
+	for _, finding := range findings {
+		for _, suggestion := range finding.Suggestions {
+			parent := ""
+			if suggestion.Parent != nil {
+				parent = suggestion.Parent.TaskID.String()
+			}
+			if suggestion.Kind == RestructureGroup {
+				groups++
+				ref := fmt.Sprintf("group-%d", groups)
+				p.Creates = append(p.Creates, plan.CreateOp{Ref: ref, Title: suggestion.GroupTitle, Parent: parent})
+				parent = ref
+			}
+			for _, task := range suggestion.Tasks {
+				p.Moves = append(p.Moves, plan.MoveOp{ID: task.TaskID.String(), Parent: parent})
+			}
+		}
+	}
+	return p
+}
+
+// tooManySiblings suggests splitting the siblings in order into groups of at
+// most maxSiblings tasks, if the subtasks still fit below maxDepth
+func (h *hierarchy) tooManySiblings(parent *types.Task, siblings []*types.Task, maxSiblings, maxDepth int) HierarchyFinding {
+	finding := HierarchyFinding{
+		Smell:   SmellTooManySiblings,
+		Parent:  refPtr(parent),
+		Message: fmt.Sprintf("%d root tasks, more than %d", len(siblings), maxSiblings),
+	}
+	levelTitle, depth := "Root tasks", 0
+	if parent != nil {
+		levelTitle, depth = parent.Title, parent.Depth+1
+		finding.Message = fmt.Sprintf("%d subtasks, more than %d", len(siblings), maxSiblings)
+	}
+
+	tallest := 0
+	for _, sibling := range siblings {
+		finding.Tasks = append(finding.Tasks, taskRef(sibling))
+		tallest = max(tallest, h.height(sibling.ID))
+	}
+	if depth+1+tallest > maxDepth {
+		finding.Message += ", grouping would exceed the maximum depth"
+		return finding
+	}
+
+	groups := (len(siblings) + maxSiblings - 1) / maxSiblings
+	size := (len(siblings) + groups - 1) / groups
+	for i := 0; i < groups; i++ {
+		chunk := siblings[i*size : min((i+1)*size, len(siblings))]
+		suggestion := Restructuring{
+			Kind:       RestructureGroup,
+			GroupTitle: fmt.Sprintf("%s (part %d of %d)", levelTitle, i+1, groups),
+			Parent:     refPtr(parent),
+		}
+		for _, task := range chunk {
+			suggestion.Tasks = append(suggestion.Tasks, taskRef(task))
+		}
+		finding.Suggestions = append(finding.Suggestions, suggestion)
+	}
+	return finding
+}
+
+// hierarchy indexes tasks by ID and parent
+type hierarchy struct {
+	byID     map[uuid.UUID]*types.Task
+	roots    []*types.Task
+	children map[uuid.UUID][]*types.Task
+	heights  map[uuid.UUID]int
+}
+
+func newHierarchy(tasks []*types.Task) *hierarchy {
+	h := &hierarchy{
+		byID:     make(map[uuid.UUID]*types.Task, len(tasks)),
+		children: make(map[uuid.UUID][]*types.Task),
+		heights:  make(map[uuid.UUID]int),
+	}
+	for _, task := range tasks {
+		h.byID[task.ID] = task
+	}
+	for _, task := range tasks {
+		if task.ParentID == nil || h.byID[*task.ParentID] == nil {
+			h.roots = append(h.roots, task)
+		} else {
+			h.children[*task.ParentID] = append(h.children[*task.ParentID], task)
+		}
+	}
+	types.SortSiblings(h.roots)
+	for _, children := range h.children {
+		types.SortSiblings(children)
+	}
+	return h
+}
+
+// walk visits all tasks depth-first in sibling order
+func (h *hierarchy) walk(visit func(task *types.Task)) {
+	var walk func(tasks []*types.Task)
+	walk = func(tasks []*types.Task) {
+		for _, task := range tasks {
+			visit(task)
+			walk(h.children[task.ID])
+		}
+	}
+	walk(h.roots)
+}
+
+// walkLevels visits the root level and then every task with subtasks,
+// depth-first, with its subtasks
+func (h *hierarchy) walkLevels(visit func(parent *types.Task, children []*types.Task)) {
+	visit(nil, h.roots)
+	h.walk(func(task *types.Task) {
+		if children := h.children[task.ID]; len(children) > 0 {
+			visit(task, children)
+		}
+	})
+}
+
+// height returns the number of levels below the task, 0 for leaf tasks
+func (h *hierarchy) height(id uuid.UUID) int {
+	if height, ok := h.heights[id]; ok {
+		return height
+	}
+	height := 0
+	for _, child := range h.children[id] {
+		height = max(height, h.height(child.ID)+1)
+	}
+	h.heights[id] = height
+	return height
+}
+
+// ancestorRef returns the ancestor of the task at depth, nil if depth is
+// negative (the root level)
+func (h *hierarchy) ancestorRef(task *types.Task, depth int) *TaskRef {
+	for current := task; current.ParentID != nil; {
+		parent := h.byID[*current.ParentID]
+		if parent == nil {
+			break
+		}
+		if parent.Depth <= depth {
+			return refPtr(parent)
+		}
+		current = parent
+	}
+	return nil
+}
+
+func (h *hierarchy) ref(id *uuid.UUID) *TaskRef {
+	if id == nil || h.byID[*id] == nil {
+		return nil
+	}
+	return refPtr(h.byID[*id])
+}
+
+func taskRef(task *types.Task) TaskRef {
+	return TaskRef{TaskID: task.ID, Title: task.Title, State: task.State}
+}
+
+func refPtr(task *types.Task) *TaskRef {
+	if task == nil {
+		return nil
+	}
+	ref := taskRef(task)
+	return &ref
+}
@@ -0,0 +1,78 @@
+package analysis
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/plan"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeHierarchy(t *testing.T) {
+	var tasks []*types.Task
+	add := func(title string, parent *types.Task) *types.Task {
+		task := newTask(title, types.TaskStatePending, 3, 0)
+		task.Position = len(tasks)
+		if parent != nil {
+			task.ParentID = &parent.ID
+			task.Depth = parent.Depth + 1
+		}
+		tasks = append(tasks, task)
+		return task
+	}
+
+	// Five root tasks, one of them the top of a chain A > B > C > D > Deep
+	a := add("A", nil)
+	b := add("B", a)
+	c := add("C", b)
+	d := add("D", c)
+	for i := 1; i <= 4; i++ {
+		add(fmt.Sprintf("Root %d", i), nil)
+	}
+	// A subtree that became too deep after lowering the maximum depth
+	deep := add("Deep", d)
+	add("Deeper", deep)
+	add("Deeper sibling", deep)
+
+	config := manager.DefaultConfig()
+	config.MaxDepth = 3
+	findings := AnalyzeHierarchy(tasks, 3, config)
+	require.Len(t, findings, 3)
+
+	violation := findings[0]
+	assert.Equal(t, SmellDepthViolation, violation.Smell)
+	assert.Equal(t, "Deep", violation.Tasks[0].Title, "only the topmost violating tasks are reported")
+	assert.Equal(t, "B", violation.Suggestions[0].Parent.Title)
+
+	siblings := findings[1]
+	assert.Equal(t, SmellTooManySiblings, siblings.Smell)
+	assert.Nil(t, siblings.Parent)
+	assert.Equal(t, "5 root tasks, more than 3, grouping would exceed the maximum depth", siblings.Message)
+	assert.Empty(t, siblings.Suggestions)
+
+	chain := findings[2]
+	assert.Equal(t, SmellSingleChildChain, chain.Smell)
+	assert.Len(t, chain.Tasks, 5, "the chain ends at Deep, which has two subtasks")
+	require.Len(t, chain.Suggestions, 1)
+	assert.Equal(t, "A", chain.Suggestions[0].Parent.Title)
+	assert.Equal(t, []TaskRef{taskRef(c), taskRef(d), taskRef(deep)}, chain.Suggestions[0].Tasks)
+
+	config.MaxDepth = 10
+	findings = AnalyzeHierarchy(tasks, 3, config)
+	require.Len(t, findings, 2)
+	require.Len(t, findings[0].Suggestions, 2)
+	assert.Equal(t, "Root tasks (part 1 of 2)", findings[0].Suggestions[0].GroupTitle)
+	assert.Len(t, findings[0].Suggestions[0].Tasks, 3)
+	assert.Len(t, findings[0].Suggestions[1].Tasks, 2)
+
+	p := HierarchyPlan(findings)
+	assert.Equal(t, []plan.CreateOp{
+		{Ref: "group-1", Title: "Root tasks (part 1 of 2)"},
+		{Ref: "group-2", Title: "Root tasks (part 2 of 2)"},
+	}, p.Creates)
+	require.Len(t, p.Moves, 8)
+	assert.Equal(t, plan.MoveOp{ID: a.ID.String(), Parent: "group-1"}, p.Moves[0])
+}
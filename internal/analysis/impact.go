@@ -0,0 +1,121 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/denkhaus/knot/v2/internal/selection"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// ImpactEntry is an incomplete task ranked by the work waiting for it
+type ImpactEntry struct {
+	TaskRef
+	// Blocks is the number of incomplete tasks that directly or transitively
+	// depend on this task
+	Blocks int `json:"blocks"`
+	// DirectDependents is the number of incomplete tasks depending directly on this task
+	DirectDependents int `json:"direct_dependents"`
+	// Unblocks is the number of dependents whose dependencies are all met once
+	// this task is completed
+	Unblocks int `json:"unblocks"`
+	// Actionable is true when the task can be worked on right now
+	Actionable bool `json:"actionable"`
+}
+
+// ImpactReport is the result of RankImpact
+type ImpactReport struct {
+	// Incomplete is the number of incomplete tasks that were ranked
+	Incomplete int           `json:"incomplete"`
+	Tasks      []ImpactEntry `json:"tasks"`
+}
+
+// RankImpact ranks incomplete tasks by the number of incomplete tasks that
+// transitively depend on them, then by the number of dependents they unblock
+// immediately. Tasks nothing depends on are left out.
+func RankImpact(tasks []*types.Task) (*ImpactReport, error) {
+	graph, err := selection.NewDependencyAnalyzer(selection.DefaultConfig()).BuildDependencyGraph(tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze dependencies: %w", err)
+	}
+
+	report := &ImpactReport{Tasks: make([]ImpactEntry, 0)}
+	for _, task := range sortedByTitle(tasks) {
+		if !isIncomplete(task) {
+			continue
+		}
+		report.Incomplete++
+
+		node := graph.Nodes[task.ID]
+		entry := ImpactEntry{TaskRef: refOf(task), Actionable: node.IsActionable}
+		for _, depID := range node.Dependents {
+			dependent := graph.Nodes[depID].Task
+			if !isIncomplete(dependent) {
+				continue
+			}
+			entry.DirectDependents++
+			if dependenciesMetWithout(dependent, graph, task.ID) {
+				entry.Unblocks++
+			}
+		}
+		entry.Blocks = countBlocked(graph, task.ID)
+
+		if entry.Blocks > 0 {
+			report.Tasks = append(report.Tasks, entry)
+		}
+	}
+
+	sort.SliceStable(report.Tasks, func(i, j int) bool {
+		a, b := report.Tasks[i], report.Tasks[j]
+		if a.Blocks != b.Blocks {
+			return a.Blocks > b.Blocks
+		}
+		return a.Unblocks > b.Unblocks
+	})
+	return report, nil
+}
+
+// countBlocked counts the incomplete tasks reachable through dependents
+func countBlocked(graph *selection.DependencyGraph, taskID uuid.UUID) int {
+	seen := map[uuid.UUID]bool{taskID: true}
+	queue := []uuid.UUID{taskID}
+	count := 0
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, depID := range graph.Nodes[current].Dependents {
+			if seen[depID] || !isIncomplete(graph.Nodes[depID].Task) {
+				continue
+			}
+			seen[depID] = true
+			count++
+			queue = append(queue, depID)
+		}
+	}
+	return count
+}
+
+// dependenciesMetWithout reports whether all dependencies of task except
+// completedID are completed
+func dependenciesMetWithout(task *types.Task, graph *selection.DependencyGraph, completedID uuid.UUID) bool {
+	for _, depID := range task.Dependencies {
+		if depID == completedID {
+			continue
+		}
+		dep, exists := graph.Nodes[depID]
+		if !exists || dep.Task.State != types.TaskStateCompleted {
+			return false
+		}
+	}
+	return true
+}
+
+func isIncomplete(task *types.Task) bool {
+	switch task.State {
+	case types.TaskStateCompleted, types.TaskStateCancelled, types.TaskStateDeletionPending:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankImpact(t *testing.T) {
+	design := newTask("design", types.TaskStatePending, 3, 0)
+	api := dependsOn(newTask("api", types.TaskStatePending, 3, 0), design)
+	ui := dependsOn(newTask("ui", types.TaskStatePending, 3, 0), design, api)
+	release := dependsOn(newTask("release", types.TaskStatePending, 3, 0), ui)
+	lint := newTask("lint", types.TaskStatePending, 3, 0)
+	chore := dependsOn(newTask("chore", types.TaskStatePending, 3, 0), lint, api)
+	old := newTask("old", types.TaskStateCompleted, 3, 0)
+	cleanup := dependsOn(newTask("cleanup", types.TaskStateCancelled, 3, 0), old)
+
+	report, err := RankImpact([]*types.Task{design, api, ui, release, lint, chore, old, cleanup})
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, report.Incomplete)
+	require.Len(t, report.Tasks, 4)
+
+	names := make([]string, len(report.Tasks))
+	for i, entry := range report.Tasks {
+		names[i] = entry.Title
+	}
+	assert.Equal(t, []string{"design", "api", "ui", "lint"}, names)
+
+	top := report.Tasks[0]
+	assert.Equal(t, 4, top.Blocks)
+	assert.Equal(t, 2, top.DirectDependents)
+	assert.Equal(t, 1, top.Unblocks)
+	assert.True(t, top.Actionable)
+
+	assert.Equal(t, 3, report.Tasks[1].Blocks)
+	assert.Equal(t, 0, report.Tasks[1].Unblocks)
+	assert.False(t, report.Tasks[1].Actionable)
+}
+
+func TestRankImpactCycle(t *testing.T) {
+	a := newTask("a", types.TaskStatePending, 3, 0)
+	b := dependsOn(newTask("b", types.TaskStatePending, 3, 0), a)
+	dependsOn(a, b)
+
+	report, err := RankImpact([]*types.Task{a, b})
+	require.NoError(t, err)
+	require.Len(t, report.Tasks, 2)
+	assert.Equal(t, 1, report.Tasks[0].Blocks)
+	assert.Equal(t, 1, report.Tasks[1].Blocks)
+}
@@ -0,0 +1,219 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// LintSeverity ranks lint findings
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+	LintInfo    LintSeverity = "info"
+)
+
+// Rank orders severities, errors first
+func (s LintSeverity) Rank() int {
+	switch s {
+	case LintError:
+		return 0
+	case LintWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ParseLintSeverity parses the name of a severity
+func ParseLintSeverity(s string) (LintSeverity, error) {
+	switch severity := LintSeverity(s); severity {
+	case LintError, LintWarning, LintInfo:
+		return severity, nil
+	}
+	return "", fmt.Errorf("unknown severity '%s', use error, warning or info", s)
+}
+
+// Lint rules and the severity of their findings
+const (
+	// RuleDeletedTask flags references to tasks deleted according to the change feed (error)
+	RuleDeletedTask = "deleted-task-reference"
+	// RuleUnknownID flags UUIDs that are neither a project nor a task, e.g.
+	// tasks deleted before the change feed existed (info)
+	RuleUnknownID = "unknown-reference"
+	// RuleMissingFile flags file paths that do not exist (warning)
+	RuleMissingFile = "missing-file"
+	// RuleMissingCriteria flags complex open tasks without acceptance criteria (warning)
+	RuleMissingCriteria = "missing-acceptance-criteria"
+)
+
+// LintRules lists the rules in the order they are applied
+var LintRules = []string{RuleDeletedTask, RuleUnknownID, RuleMissingFile, RuleMissingCriteria}
+
+// LintFinding is a problem found in a task description
+type LintFinding struct {
+	TaskRef
+	Rule     string       `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+	Message  string       `json:"message"`
+}
+
+// LintOptions configure LintTasks
+type LintOptions struct {
+	// KnownIDs are the IDs of all projects and tasks of the database, since
+	// descriptions may reference other projects
+	KnownIDs map[uuid.UUID]bool
+	// Events is the change feed of all projects, used to recognize and name
+	// deleted tasks
+	Events []*types.ChangeEvent
+	// PathPatterns restrict the file paths checked by RuleMissingFile to those
+	// matching one of the globs, e.g. "*.go" or "docs/**". All paths are
+	// checked if empty.
+	PathPatterns []string
+	// FileExists reports whether a referenced path exists; RuleMissingFile is
+	// skipped if nil
+	FileExists func(path string) bool
+	// CriteriaComplexity is the complexity from which open tasks need
+	// acceptance criteria; RuleMissingCriteria is skipped if 0
+	CriteriaComplexity int
+	// Disabled are the rules not to apply
+	Disabled map[string]bool
+}
+
+var (
+	uuidPattern = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	// filePathPattern matches relative paths with at least one directory and
+	// an extension, optionally with a line number, e.g. internal/app/app.go:42.
+	// Absolute paths and URLs are not matched.
+	filePathPattern = regexp.MustCompile("(?:^|[\\s(\\[`'\"])((?:\\.{1,2}/)?(?:[\\w.-]+/)+[\\w-][\\w.-]*\\.[A-Za-z0-9]+)(?::\\d+)?")
+	// criteriaHeading recognizes acceptance criteria written into the description
+	criteriaHeading = regexp.MustCompile(`(?i)acceptance criteria|definition of done`)
+)
+
+// LintTasks checks the descriptions of the tasks for references to deleted
+// tasks and missing files, and complex open tasks for acceptance criteria.
+// Findings are sorted by severity, then by task title.
+func LintTasks(tasks []*types.Task, opts LintOptions) []LintFinding {
+	deleted := deletedTaskTitles(opts.Events)
+	enabled := func(rule string) bool { return !opts.Disabled[rule] }
+
+	findings := []LintFinding{}
+	for _, task := range tasks {
+		add := func(rule string, severity LintSeverity, format string, args ...any) {
+			findings = append(findings, LintFinding{
+				TaskRef:  TaskRef{TaskID: task.ID, Title: task.Title, State: task.State},
+				Rule:     rule,
+				Severity: severity,
+				Message:  fmt.Sprintf(format, args...),
+			})
+		}
+
+		seen := make(map[string]bool)
+		for _, match := range uuidPattern.FindAllString(task.Description, -1) {
+			id, err := uuid.Parse(match)
+			if err != nil || seen[id.String()] || id == task.ID || opts.KnownIDs[id] {
+				continue
+			}
+			seen[id.String()] = true
+			if title, ok := deleted[id]; ok {
+				if enabled(RuleDeletedTask) {
+					if title != "" {
+						add(RuleDeletedTask, LintError, "references deleted task '%s' (%s)", title, id)
+					} else {
+						add(RuleDeletedTask, LintError, "references deleted task %s", id)
+					}
+				}
+			} else if enabled(RuleUnknownID) {
+				add(RuleUnknownID, LintInfo, "references %s, which is neither a project nor a task", id)
+			}
+		}
+
+		if opts.FileExists != nil && enabled(RuleMissingFile) {
+			for _, match := range filePathPattern.FindAllStringSubmatch(task.Description, -1) {
+				p := match[1]
+				if seen[p] || !matchesPathPatterns(p, opts.PathPatterns) {
+					continue
+				}
+				seen[p] = true
+				if !opts.FileExists(p) {
+					add(RuleMissingFile, LintWarning, "references %s, which does not exist", p)
+				}
+			}
+		}
+
+		if opts.CriteriaComplexity > 0 && enabled(RuleMissingCriteria) &&
+			task.Complexity >= opts.CriteriaComplexity && isIncomplete(task) &&
+			len(task.AcceptanceCriteria) == 0 && !criteriaHeading.MatchString(task.Description) {
+			add(RuleMissingCriteria, LintWarning, "complexity %d but no acceptance criteria", task.Complexity)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity.Rank() < findings[j].Severity.Rank()
+		}
+		return findings[i].Title < findings[j].Title
+	})
+	return findings
+}
+
+// deletedTaskTitles returns the tasks deleted according to the change feed
+// with their last known title, empty if no snapshot is left
+func deletedTaskTitles(events []*types.ChangeEvent) map[uuid.UUID]string {
+	sorted := make([]*types.ChangeEvent, len(events))
+	copy(sorted, events)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Seq < sorted[j].Seq })
+
+	titles := make(map[uuid.UUID]string)
+	deleted := make(map[uuid.UUID]string)
+	for _, event := range sorted {
+		if event.TaskID == nil {
+			continue
+		}
+		if event.Kind == types.ChangeTaskDeleted {
+			deleted[*event.TaskID] = titles[*event.TaskID]
+			continue
+		}
+		var snapshot struct {
+			Title string `json:"title"`
+		}
+		if len(event.Data) > 0 && json.Unmarshal(event.Data, &snapshot) == nil && snapshot.Title != "" {
+			titles[*event.TaskID] = snapshot.Title
+		}
+	}
+	return deleted
+}
+
+// matchesPathPatterns reports whether p matches one of the globs. Globs
+// without a slash match the file name, globs ending in /** everything below
+// the directory.
+func matchesPathPatterns(p string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	clean := path.Clean(p)
+	for _, pattern := range patterns {
+		if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+			if strings.HasPrefix(clean, path.Clean(dir)+"/") {
+				return true
+			}
+			continue
+		}
+		target := clean
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(clean)
+		}
+		if matched, _ := path.Match(pattern, target); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintTasks(t *testing.T) {
+	project := uuid.New()
+	live := newTask("live", types.TaskStatePending, 3, 0)
+	gone := uuid.New()
+	forgotten := uuid.New()
+	snapshot, _ := json.Marshal(map[string]string{"title": "Old spec"})
+	events := []*types.ChangeEvent{
+		{Seq: 1, Kind: types.ChangeTaskCreated, ProjectID: project, TaskID: &gone, Data: snapshot},
+		{Seq: 2, Kind: types.ChangeTaskDeleted, ProjectID: project, TaskID: &gone},
+	}
+
+	refs := newTask("refs", types.TaskStatePending, 3, 0)
+	refs.Description = "Follows " + live.ID.String() + " and " + gone.String() + ", see " + forgotten.String() +
+		" and " + gone.String() + " again.\nTouches internal/app/app.go:42, `docs/old.md` and https://example.com/a/b.html."
+	complex := newTask("complex", types.TaskStatePending, 8, 0)
+	criteria := newTask("criteria", types.TaskStatePending, 9, 0)
+	criteria.AcceptanceCriteria = []types.AcceptanceCriterion{{Text: "works"}}
+	written := newTask("written", types.TaskStateInProgress, 7, 0)
+	written.Description = "## Acceptance criteria\n- works"
+	done := newTask("done", types.TaskStateCompleted, 10, 0)
+	tasks := []*types.Task{live, refs, complex, criteria, written, done}
+
+	var checked []string
+	opts := LintOptions{
+		KnownIDs: map[uuid.UUID]bool{project: true, live.ID: true},
+		Events:   events,
+		FileExists: func(path string) bool {
+			checked = append(checked, path)
+			return path == "internal/app/app.go"
+		},
+		CriteriaComplexity: 7,
+	}
+	findings := LintTasks(tasks, opts)
+
+	type result struct{ title, rule, message string }
+	var got []result
+	for _, finding := range findings {
+		got = append(got, result{finding.Title, finding.Rule, finding.Message})
+	}
+	assert.Equal(t, []result{
+		{"refs", RuleDeletedTask, "references deleted task 'Old spec' (" + gone.String() + ")"},
+		{"complex", RuleMissingCriteria, "complexity 8 but no acceptance criteria"},
+		{"refs", RuleMissingFile, "references docs/old.md, which does not exist"},
+		{"refs", RuleUnknownID, "references " + forgotten.String() + ", which is neither a project nor a task"},
+	}, got, "findings are sorted by severity, then title")
+	assert.Equal(t, []string{"internal/app/app.go", "docs/old.md"}, checked, "URLs are not checked as files")
+
+	opts.PathPatterns = []string{"internal/**"}
+	opts.Disabled = map[string]bool{RuleUnknownID: true, RuleMissingCriteria: true}
+	findings = LintTasks(tasks, opts)
+	assert.Len(t, findings, 1)
+	assert.Equal(t, LintError, findings[0].Severity)
+}
+
+func TestMatchesPathPatterns(t *testing.T) {
+	assert.True(t, matchesPathPatterns("internal/app/app.go", nil))
+	assert.True(t, matchesPathPatterns("internal/app/app.go", []string{"*.go"}))
+	assert.True(t, matchesPathPatterns("./internal/app/app.go", []string{"internal/**"}))
+	assert.True(t, matchesPathPatterns("docs/guide.md", []string{"docs/*.md"}))
+	assert.False(t, matchesPathPatterns("docs/api/guide.md", []string{"docs/*.md"}))
+	assert.False(t, matchesPathPatterns("internal/app/app.go", []string{"*.md", "docs/**"}))
+}
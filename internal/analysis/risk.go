@@ -0,0 +1,254 @@
+package analysis
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+)
+
+// RiskEntry compares the earliest possible completion of a task with its due
+// date. Durations are working minutes, counted in 8h working days from today.
+type RiskEntry struct {
+	TaskRef
+	DueDate time.Time `json:"due_date"`
+	// Remaining is the estimated work on the critical path to completing the task
+	Remaining int64 `json:"remaining"`
+	// Available is the working time left until the end of the due date
+	Available int64 `json:"available"`
+	// Slack is Available - Remaining, negative if the task cannot be completed in time
+	Slack            int64     `json:"slack"`
+	EarliestComplete time.Time `json:"earliest_complete"`
+	AtRisk           bool      `json:"at_risk"`
+	Overdue          bool      `json:"overdue"`
+	// CriticalPath lists the incomplete tasks that determine Remaining, in the
+	// order they have to be completed, ending with the task itself
+	CriticalPath []TaskRef `json:"critical_path"`
+	// Unestimated counts incomplete tasks on the way to completion without an
+	// estimate; Remaining does not include them
+	Unestimated int `json:"unestimated"`
+}
+
+// RiskReport is the result of AssessDeadlineRisk
+type RiskReport struct {
+	AtRisk int         `json:"at_risk"`
+	Tasks  []RiskEntry `json:"tasks"`
+}
+
+// AssessDeadlineRisk computes, for every incomplete task with a due date, the
+// earliest possible completion: the task's remaining estimate plus the longest
+// chain of remaining estimates through its incomplete dependencies and
+// subtasks. A task starts once its subtasks and finish-to-start dependencies
+// are completed and its start-to-start dependencies have started, each plus
+// the lag of the dependency; the lag of completed dependencies counts as
+// elapsed. Remaining estimates subtract logged effort; tasks with subtasks
+// carry no work of their own, it is estimated by the subtasks. Tasks whose
+// earliest completion falls after their due date are at risk. Entries are
+// sorted by slack, least slack first.
+func AssessDeadlineRisk(tasks []*types.Task, now time.Time) *RiskReport {
+	byID := make(map[uuid.UUID]*types.Task, len(tasks))
+	children := make(map[uuid.UUID][]uuid.UUID)
+	for _, task := range tasks {
+		byID[task.ID] = task
+		if task.ParentID != nil && isIncomplete(task) {
+			children[*task.ParentID] = append(children[*task.ParentID], task.ID)
+		}
+	}
+
+	paths := &criticalPaths{
+		tasks:    byID,
+		children: children,
+		finish:   make(map[uuid.UUID]*pathNode),
+		visiting: make(map[uuid.UUID]bool),
+	}
+
+	report := &RiskReport{Tasks: make([]RiskEntry, 0)}
+	today := startOfDay(now)
+	for _, task := range sortedByTitle(tasks) {
+		if task.DueDate == nil || !isIncomplete(task) {
+			continue
+		}
+
+		node := paths.resolve(task.ID)
+		due := startOfDay(*task.DueDate)
+		entry := RiskEntry{
+			TaskRef:          refOf(task),
+			DueDate:          *task.DueDate,
+			Remaining:        node.minutes,
+			Available:        workingDays(today, due) * utils.MinutesPerDay,
+			EarliestComplete: addWorkingMinutes(today, node.minutes),
+			Overdue:          due.Before(today),
+			CriticalPath:     paths.path(task.ID),
+			Unestimated:      paths.unestimated(task.ID),
+		}
+		entry.Slack = entry.Available - entry.Remaining
+		entry.AtRisk = entry.Slack < 0 || entry.Overdue
+		if entry.AtRisk {
+			report.AtRisk++
+		}
+		report.Tasks = append(report.Tasks, entry)
+	}
+
+	sort.SliceStable(report.Tasks, func(i, j int) bool {
+		a, b := report.Tasks[i], report.Tasks[j]
+		if a.Overdue != b.Overdue {
+			return a.Overdue
+		}
+		if a.Slack != b.Slack {
+			return a.Slack < b.Slack
+		}
+		return a.DueDate.Before(b.DueDate)
+	})
+	return report
+}
+
+// pathNode is the earliest start and finish of a task in working minutes from
+// now and the predecessor on its critical path
+type pathNode struct {
+	start   int64
+	minutes int64
+	prev    *uuid.UUID
+}
+
+// criticalPaths memoizes earliest finishes over the graph of incomplete
+// dependencies and subtasks
+type criticalPaths struct {
+	tasks    map[uuid.UUID]*types.Task
+	children map[uuid.UUID][]uuid.UUID
+	finish   map[uuid.UUID]*pathNode
+	visiting map[uuid.UUID]bool
+}
+
+// predecessors returns the incomplete tasks that must be completed before the task
+func (p *criticalPaths) predecessors(task *types.Task) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, depID := range task.Dependencies {
+		if dep, ok := p.tasks[depID]; ok && isIncomplete(dep) {
+			ids = append(ids, depID)
+		}
+	}
+	return append(ids, p.children[task.ID]...)
+}
+
+func (p *criticalPaths) resolve(id uuid.UUID) *pathNode {
+	if node, ok := p.finish[id]; ok {
+		return node
+	}
+	// A dependency cycle never completes; the cycle is cut where it is detected
+	if p.visiting[id] {
+		return &pathNode{}
+	}
+	p.visiting[id] = true
+	defer delete(p.visiting, id)
+
+	task := p.tasks[id]
+	node := &pathNode{}
+	for _, predID := range p.predecessors(task) {
+		if ready := p.readyAfter(task, predID); node.prev == nil || ready > node.start {
+			node.start = ready
+			node.prev = &predID
+		}
+	}
+	node.minutes = node.start + p.ownWork(task)
+	p.finish[id] = node
+	return node
+}
+
+// readyAfter returns when the predecessor allows the task to start. Subtasks
+// have to be completed; dependencies are constrained by their type and lag.
+func (p *criticalPaths) readyAfter(task *types.Task, predID uuid.UUID) int64 {
+	pred := p.resolve(predID)
+	if !slices.Contains(task.Dependencies, predID) {
+		return pred.minutes
+	}
+	link := task.DependencyLink(predID)
+	if link.Type == types.DependencyStartToStart {
+		return pred.start + link.Lag
+	}
+	return pred.minutes + link.Lag
+}
+
+// ownWork returns the remaining estimate of a task without incomplete subtasks
+func (p *criticalPaths) ownWork(task *types.Task) int64 {
+	if len(p.children[task.ID]) > 0 || task.Estimate == nil {
+		return 0
+	}
+	return max(*task.Estimate-task.ActualEffort(), 0)
+}
+
+// path returns the critical path ending with the task
+func (p *criticalPaths) path(id uuid.UUID) []TaskRef {
+	var path []TaskRef
+	seen := make(map[uuid.UUID]bool)
+	for current := &id; current != nil && !seen[*current]; current = p.resolve(*current).prev {
+		seen[*current] = true
+		path = append(path, refOf(p.tasks[*current]))
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// unestimated counts the incomplete leaf tasks the task waits for, including
+// itself, that have no estimate
+func (p *criticalPaths) unestimated(id uuid.UUID) int {
+	count := 0
+	seen := make(map[uuid.UUID]bool)
+	var walk func(uuid.UUID)
+	walk = func(id uuid.UUID) {
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		task := p.tasks[id]
+		if len(p.children[id]) == 0 && task.Estimate == nil {
+			count++
+		}
+		for _, predID := range p.predecessors(task) {
+			walk(predID)
+		}
+	}
+	walk(id)
+	return count
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+func isWorkingDay(t time.Time) bool {
+	return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+}
+
+// workingDays counts the working days from today through the due date
+func workingDays(today, due time.Time) int64 {
+	var days int64
+	for day := today; !day.After(due); day = day.AddDate(0, 0, 1) {
+		if isWorkingDay(day) {
+			days++
+		}
+	}
+	return days
+}
+
+// addWorkingMinutes returns the working day on which work of the given length,
+// started today, is completed
+func addWorkingMinutes(today time.Time, minutes int64) time.Time {
+	day := today
+	for !isWorkingDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	for minutes > utils.MinutesPerDay {
+		minutes -= utils.MinutesPerDay
+		day = day.AddDate(0, 0, 1)
+		for !isWorkingDay(day) {
+			day = day.AddDate(0, 0, 1)
+		}
+	}
+	return day
+}
@@ -0,0 +1,88 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssessDeadlineRisk(t *testing.T) {
+	// A Monday
+	now := time.Date(2026, 10, 19, 9, 30, 0, 0, time.Local)
+	due := func(task *types.Task, year int, month time.Month, day int) *types.Task {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+		task.DueDate = &date
+		return task
+	}
+
+	design := newTask("design", types.TaskStatePending, 3, 960)
+	build := due(newTask("build", types.TaskStateInProgress, 3, 480), 2026, 10, 20)
+	build.Dependencies = []uuid.UUID{design.ID}
+	build.EffortLog = []types.EffortEntry{{Minutes: 120}}
+	docs := due(newTask("docs", types.TaskStatePending, 2, 240), 2026, 10, 23)
+	release := due(newTask("release", types.TaskStatePending, 5, 3000), 2026, 10, 26)
+	checklist := newTask("checklist", types.TaskStatePending, 2, 480)
+	checklist.ParentID = &release.ID
+	announce := newTask("announce", types.TaskStatePending, 2, 0)
+	announce.ParentID = &release.ID
+	late := due(newTask("late", types.TaskStatePending, 2, 0), 2026, 10, 16)
+	shipped := due(newTask("shipped", types.TaskStateCompleted, 2, 480), 2026, 10, 1)
+
+	report := AssessDeadlineRisk([]*types.Task{design, build, docs, release, checklist, announce, late, shipped}, now)
+
+	titles := make([]string, len(report.Tasks))
+	for i, entry := range report.Tasks {
+		titles[i] = entry.Title
+	}
+	require.Equal(t, []string{"late", "build", "docs", "release"}, titles, "overdue first, then least slack")
+	assert.Equal(t, 2, report.AtRisk)
+
+	assert.True(t, report.Tasks[0].Overdue)
+	assert.True(t, report.Tasks[0].AtRisk)
+
+	b := report.Tasks[1]
+	assert.Equal(t, int64(1320), b.Remaining, "dependency estimate plus remaining own estimate")
+	assert.Equal(t, int64(960), b.Available)
+	assert.Equal(t, int64(-360), b.Slack)
+	assert.True(t, b.AtRisk)
+	assert.Equal(t, time.Date(2026, 10, 21, 0, 0, 0, 0, time.Local), b.EarliestComplete)
+	require.Len(t, b.CriticalPath, 2)
+	assert.Equal(t, design.ID, b.CriticalPath[0].TaskID)
+	assert.Equal(t, build.ID, b.CriticalPath[1].TaskID)
+
+	r := report.Tasks[3]
+	assert.Equal(t, int64(480), r.Remaining, "parent work is estimated by its subtasks")
+	assert.Equal(t, int64(2880), r.Available, "weekends are not working days")
+	assert.Equal(t, 1, r.Unestimated)
+	assert.False(t, r.AtRisk)
+
+	t.Run("dependency types and lag", func(t *testing.T) {
+		spec := newTask("spec", types.TaskStatePending, 2, 480)
+		review := newTask("review", types.TaskStatePending, 2, 240)
+		review.Dependencies = []uuid.UUID{spec.ID}
+		review.DependencyLinks = []types.DependencyLink{{DependsOnID: spec.ID, Type: types.DependencyStartToStart, Lag: 120}}
+		deploy := due(newTask("deploy", types.TaskStatePending, 2, 60), 2026, 10, 30)
+		deploy.Dependencies = []uuid.UUID{review.ID}
+		deploy.DependencyLinks = []types.DependencyLink{{DependsOnID: review.ID, Type: types.DependencyFinishToStart, Lag: 480}}
+
+		report := AssessDeadlineRisk([]*types.Task{spec, review, deploy}, now)
+		require.Len(t, report.Tasks, 1)
+		// review starts 2h after spec starts, deploy a day after review finished
+		assert.Equal(t, int64(120+240+480+60), report.Tasks[0].Remaining)
+		require.Len(t, report.Tasks[0].CriticalPath, 3)
+		assert.Equal(t, spec.ID, report.Tasks[0].CriticalPath[0].TaskID)
+	})
+
+	t.Run("dependency cycles terminate", func(t *testing.T) {
+		a := due(newTask("a", types.TaskStatePending, 2, 60), 2026, 10, 30)
+		b := due(newTask("b", types.TaskStatePending, 2, 60), 2026, 10, 30)
+		a.Dependencies = []uuid.UUID{b.ID}
+		b.Dependencies = []uuid.UUID{a.ID}
+		report := AssessDeadlineRisk([]*types.Task{a, b}, now)
+		assert.Len(t, report.Tasks, 2)
+	})
+}
@@ -0,0 +1,226 @@
+package analysis
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// StandupItem is one task of a standup summary
+type StandupItem struct {
+	TaskID uuid.UUID       `json:"task_id"`
+	Title  string          `json:"title"`
+	State  types.TaskState `json:"state"`
+	// At is when the task was completed or started, nil if unknown
+	At *time.Time `json:"at,omitempty"`
+	// BlockedBy lists the titles of the open dependencies of a blocker
+	BlockedBy []string `json:"blocked_by,omitempty"`
+}
+
+// Standup summarizes the work of one agent since a point in time
+type Standup struct {
+	Agent      string        `json:"agent"`
+	Since      time.Time     `json:"since"`
+	Completed  []StandupItem `json:"completed"`
+	InProgress []StandupItem `json:"in_progress"`
+	Blockers   []StandupItem `json:"blockers"`
+}
+
+// Empty reports whether the agent has nothing to report
+func (s *Standup) Empty() bool {
+	return len(s.Completed) == 0 && len(s.InProgress) == 0 && len(s.Blockers) == 0
+}
+
+// stateChange records who moved a task into its current state and when
+type stateChange struct {
+	state types.TaskState
+	actor string
+	at    time.Time
+	// created is set if the task has been in the state since its creation
+	created bool
+}
+
+// taskStateChanges replays the change feed and returns, per task, the last
+// change that moved the task into a different state
+func taskStateChanges(events []*types.ChangeEvent) map[uuid.UUID]stateChange {
+	sorted := make([]*types.ChangeEvent, len(events))
+	copy(sorted, events)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Seq < sorted[j].Seq })
+
+	states := make(map[uuid.UUID]types.TaskState)
+	changes := make(map[uuid.UUID]stateChange)
+	for _, event := range sorted {
+		if event.TaskID == nil || len(event.Data) == 0 {
+			continue
+		}
+		var snapshot struct {
+			State types.TaskState `json:"state"`
+		}
+		if json.Unmarshal(event.Data, &snapshot) != nil || snapshot.State == "" {
+			continue
+		}
+		if previous, known := states[*event.TaskID]; !known || previous != snapshot.State {
+			changes[*event.TaskID] = stateChange{
+				state: snapshot.State, actor: event.Actor, at: event.CreatedAt,
+				created: !known && event.Kind == types.ChangeTaskCreated,
+			}
+		}
+		states[*event.TaskID] = snapshot.State
+	}
+	return changes
+}
+
+// standupOwner is the agent a task is reported for: the assigned agent if
+// any, otherwise the actor who moved the task into its current state
+type standupOwner struct {
+	assigned string
+	actor    string
+}
+
+func (o standupOwner) key() string {
+	if o.assigned != "" {
+		return o.assigned
+	}
+	return o.actor
+}
+
+func (o standupOwner) matches(agent string) bool {
+	return agent != "" && (agent == o.assigned || agent == o.actor)
+}
+
+// BuildStandups summarizes the work of agents since the given time from the
+// tasks of a project and its change feed. A task belongs to the agent it is
+// assigned to (by agent ID) and to the actor who moved it into its current
+// state; without a change feed the actor who last updated the task is used.
+//
+// Completed lists the tasks completed since the given time, InProgress the
+// tasks in progress and Blockers the open tasks that are blocked or wait for
+// incomplete dependencies. Pending tasks only count as blockers when they are
+// assigned to the agent.
+//
+// With an agent, the result holds the standup of that agent, matched by agent
+// ID or actor name. Without one, every task is reported once, for its
+// assigned agent or otherwise its actor, and the result holds one standup per
+// agent with something to report, sorted by agent.
+func BuildStandups(tasks []*types.Task, events []*types.ChangeEvent, agent string, since time.Time) []*Standup {
+	changes := taskStateChanges(events)
+	taskMap := make(map[uuid.UUID]*types.Task, len(tasks))
+	for _, task := range tasks {
+		taskMap[task.ID] = task
+	}
+
+	standups := make(map[string]*Standup)
+	standupOf := func(name string) *Standup {
+		if standups[name] == nil {
+			standups[name] = &Standup{
+				Agent:      name,
+				Since:      since,
+				Completed:  []StandupItem{},
+				InProgress: []StandupItem{},
+				Blockers:   []StandupItem{},
+			}
+		}
+		return standups[name]
+	}
+	if agent != "" {
+		standupOf(agent)
+	}
+
+	for _, task := range tasks {
+		owner := standupOwner{actor: task.UpdatedBy}
+		if owner.actor == "" {
+			owner.actor = task.CreatedBy
+		}
+		if task.AssignedAgent != nil {
+			owner.assigned = task.AssignedAgent.String()
+		}
+		item := StandupItem{TaskID: task.ID, Title: task.Title, State: task.State}
+		if change, ok := changes[task.ID]; ok {
+			owner.actor = change.actor
+			at := change.at
+			item.At = &at
+		}
+
+		name := owner.key()
+		if agent != "" {
+			if !owner.matches(agent) {
+				continue
+			}
+			name = agent
+		}
+		if name == "" {
+			continue
+		}
+
+		openDeps := openDependencies(task, taskMap)
+		switch task.State {
+		case types.TaskStateCompleted:
+			if task.CompletedAt != nil {
+				item.At = task.CompletedAt
+			}
+			if item.At != nil && !item.At.Before(since) {
+				standupOf(name).Completed = append(standupOf(name).Completed, item)
+			}
+		case types.TaskStateInProgress:
+			if len(openDeps) > 0 {
+				item.BlockedBy = openDeps
+				standupOf(name).Blockers = append(standupOf(name).Blockers, item)
+			} else {
+				standupOf(name).InProgress = append(standupOf(name).InProgress, item)
+			}
+		case types.TaskStateBlocked:
+			item.BlockedBy = openDeps
+			standupOf(name).Blockers = append(standupOf(name).Blockers, item)
+		case types.TaskStatePending:
+			if len(openDeps) > 0 && owner.assigned != "" && (agent == "" || agent == owner.assigned) {
+				item.At = nil
+				item.BlockedBy = openDeps
+				standupOf(name).Blockers = append(standupOf(name).Blockers, item)
+			}
+		}
+	}
+
+	result := make([]*Standup, 0, len(standups))
+	for _, standup := range standups {
+		sortStandupItems(standup.Completed)
+		sortStandupItems(standup.InProgress)
+		sortStandupItems(standup.Blockers)
+		result = append(result, standup)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Agent < result[j].Agent })
+	return result
+}
+
+// openDependencies returns the titles of the dependencies of a task that are
+// not completed or cancelled
+func openDependencies(task *types.Task, taskMap map[uuid.UUID]*types.Task) []string {
+	var titles []string
+	for _, depID := range task.Dependencies {
+		dep, ok := taskMap[depID]
+		if !ok {
+			continue
+		}
+		if dep.State != types.TaskStateCompleted && dep.State != types.TaskStateCancelled {
+			titles = append(titles, dep.Title)
+		}
+	}
+	return titles
+}
+
+// sortStandupItems orders items by time, items without a time last
+func sortStandupItems(items []StandupItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].At, items[j].At
+		switch {
+		case a == nil || b == nil:
+			return a != nil && b == nil
+		case !a.Equal(*b):
+			return a.Before(*b)
+		default:
+			return items[i].Title < items[j].Title
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package analysis
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stateEvent(seq int64, task *types.Task, state types.TaskState, actor string, at time.Time) *types.ChangeEvent {
+	taskID := task.ID
+	data, _ := json.Marshal(map[string]any{"title": task.Title, "state": state})
+	return &types.ChangeEvent{Seq: seq, Kind: types.ChangeTaskUpdated, TaskID: &taskID, Actor: actor, Data: data, CreatedAt: at}
+}
+
+func TestBuildStandups(t *testing.T) {
+	since := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time { return time.Date(2026, 10, day, hour, 0, 0, 0, time.UTC) }
+	agentID := uuid.MustParse("8c0e2f5a-1d2b-4c3d-9e4f-5a6b7c8d9e0f")
+
+	design := newTask("design", types.TaskStateCompleted, 3, 0)
+	design.CompletedAt = ptrTime(at(15, 10))
+	old := newTask("old", types.TaskStateCompleted, 3, 0)
+	old.CompletedAt = ptrTime(at(14, 10))
+	build := newTask("build", types.TaskStateInProgress, 3, 0)
+	build.UpdatedBy = "bob" // review comment by bob after alice started it
+	provision := newTask("provision", types.TaskStatePending, 3, 0)
+	deploy := newTask("deploy", types.TaskStateInProgress, 3, 0)
+	deploy.Dependencies = []uuid.UUID{build.ID, design.ID}
+	docs := newTask("docs", types.TaskStatePending, 3, 0)
+	docs.AssignedAgent = &agentID
+	docs.Dependencies = []uuid.UUID{provision.ID}
+	unowned := newTask("unowned", types.TaskStatePending, 3, 0)
+	unowned.Dependencies = []uuid.UUID{provision.ID}
+	tasks := []*types.Task{design, old, build, provision, deploy, docs, unowned}
+
+	events := []*types.ChangeEvent{
+		stateEvent(1, design, types.TaskStatePending, "lead", at(14, 8)),
+		stateEvent(2, design, types.TaskStateCompleted, "alice", at(15, 10)),
+		stateEvent(3, old, types.TaskStateCompleted, "alice", at(14, 10)),
+		stateEvent(5, build, types.TaskStateInProgress, "alice", at(15, 11)),
+		stateEvent(6, build, types.TaskStateInProgress, "bob", at(15, 12)),
+		stateEvent(4, deploy, types.TaskStateInProgress, "alice", at(15, 9)),
+	}
+
+	standups := BuildStandups(tasks, events, "alice", since)
+	require.Len(t, standups, 1)
+	alice := standups[0]
+	assert.Equal(t, "alice", alice.Agent)
+	require.Len(t, alice.Completed, 1, "tasks completed before --since are left out")
+	assert.Equal(t, "design", alice.Completed[0].Title)
+	require.Len(t, alice.InProgress, 1)
+	assert.Equal(t, "build", alice.InProgress[0].Title, "an update without a state change keeps the actor who started the task")
+	assert.Equal(t, at(15, 11), *alice.InProgress[0].At)
+	require.Len(t, alice.Blockers, 1)
+	assert.Equal(t, "deploy", alice.Blockers[0].Title)
+	assert.Equal(t, []string{"build"}, alice.Blockers[0].BlockedBy)
+
+	byAgentID := BuildStandups(tasks, events, agentID.String(), since)
+	require.Len(t, byAgentID, 1)
+	require.Len(t, byAgentID[0].Blockers, 1)
+	assert.Equal(t, "docs", byAgentID[0].Blockers[0].Title)
+	assert.Equal(t, []string{"provision"}, byAgentID[0].Blockers[0].BlockedBy)
+
+	nobody := BuildStandups(tasks, events, "carol", since)
+	require.Len(t, nobody, 1)
+	assert.True(t, nobody[0].Empty())
+
+	all := BuildStandups(tasks, events, "", since)
+	require.Len(t, all, 2, "agents without anything to report are left out")
+	assert.Equal(t, agentID.String(), all[0].Agent, "standups are sorted by agent")
+	assert.Equal(t, "alice", all[1].Agent)
+}
+
+func TestBuildStandupsWithoutChangeFeed(t *testing.T) {
+	task := newTask("build", types.TaskStateInProgress, 3, 0)
+	task.CreatedBy = "lead"
+	task.UpdatedBy = "bob"
+
+	standups := BuildStandups([]*types.Task{task}, nil, "", time.Now())
+	require.Len(t, standups, 1)
+	assert.Equal(t, "bob", standups[0].Agent)
+	assert.Nil(t, standups[0].InProgress[0].At)
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,384 @@
+package analysis
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/selection"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// MinStrategySamples is the number of finished selections, with at least one
+// good and one poor outcome, needed before weight adjustments are recommended
+const MinStrategySamples = 5
+
+// StrategyFactor compares the weighted factor of a strategy between the
+// selections with good and with poor outcomes
+type StrategyFactor struct {
+	Name string `json:"name"`
+	// Good and Poor are the average rank of the started task's factor value
+	// among the candidates of its selection, from 0 (lowest) to 1 (highest)
+	Good        float64 `json:"good"`
+	Poor        float64 `json:"poor"`
+	Weight      float64 `json:"weight"`
+	Recommended float64 `json:"recommended"`
+}
+
+// StrategySelection is one replayed selection: a task being started, scored
+// against the other actionable tasks of that moment
+type StrategySelection struct {
+	TaskID    uuid.UUID `json:"task_id"`
+	Title     string    `json:"title"`
+	StartedAt time.Time `json:"started_at"`
+	// Rank is the position of the task in the strategy's ranking, 1 for the task
+	// the strategy would have selected
+	Rank       int `json:"rank"`
+	Candidates int `json:"candidates"`
+	// CycleMinutes is the time from start to completion, 0 if not completed
+	CycleMinutes float64 `json:"cycle_minutes,omitempty"`
+	// Reworked is set when the task was reopened after its completion
+	Reworked bool `json:"reworked,omitempty"`
+
+	percentiles map[string]float64
+}
+
+// Completed reports whether the selected task was completed since
+func (s *StrategySelection) Completed() bool {
+	return s.CycleMinutes > 0 || s.Reworked
+}
+
+// StrategyCalibration is the result of CalibrateStrategy
+type StrategyCalibration struct {
+	Strategy string `json:"strategy"`
+	// Weighted is false for strategies with fixed weights, which cannot be calibrated
+	Weighted bool `json:"weighted"`
+	// Selections are the replayed task starts that the strategy could rank
+	Selections []*StrategySelection `json:"selections"`
+	// Agreed counts the selections of the task the strategy ranks first
+	Agreed    int `json:"agreed"`
+	Completed int `json:"completed"`
+	Reworked  int `json:"reworked"`
+	// MedianCycleMinutes separates fast (good) from slow (poor) completions
+	MedianCycleMinutes float64           `json:"median_cycle_minutes"`
+	Factors            []StrategyFactor  `json:"factors"`
+	Current            selection.Weights `json:"current"`
+	// Recommended is nil when there are too few finished selections
+	Recommended *selection.Weights `json:"recommended,omitempty"`
+}
+
+// CalibrateStrategy replays the change feed of a project and scores every task
+// start against the tasks that were actionable at that moment. The outcome of
+// each selection is good when the task was completed without being reopened
+// and no slower than the median, poor otherwise. Factors on which the started
+// tasks of good selections ranked higher than those of poor selections get a
+// higher recommended weight, and vice versa. Recommended weights keep the
+// total of the current weights.
+func CalibrateStrategy(events []*types.ChangeEvent, config *selection.Config) (*StrategyCalibration, error) {
+	calibration := &StrategyCalibration{
+		Strategy:   config.Strategy.String(),
+		Weighted:   (&selection.StrategyFactory{}).UsesWeights(config.Strategy),
+		Selections: make([]*StrategySelection, 0),
+		Factors:    make([]StrategyFactor, 0),
+		Current:    config.Weights,
+	}
+
+	sorted := make([]*types.ChangeEvent, len(events))
+	copy(sorted, events)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Seq < sorted[j].Seq })
+
+	replay := newTaskReplay()
+	open := make(map[uuid.UUID]*StrategySelection)
+	for _, event := range sorted {
+		switch event.Kind {
+		case types.ChangeTaskCreated, types.ChangeTaskUpdated:
+			var snapshot types.Task
+			if event.TaskID == nil || json.Unmarshal(event.Data, &snapshot) != nil {
+				continue
+			}
+			snapshot.ID, snapshot.ProjectID = *event.TaskID, event.ProjectID
+			previous := replay.tasks[snapshot.ID]
+			if snapshot.State == types.TaskStateInProgress && (previous == nil || previous.State != types.TaskStateInProgress) {
+				sel, err := replaySelection(replay.snapshot(), &snapshot, event.CreatedAt, config)
+				if err != nil {
+					return nil, err
+				}
+				if sel != nil {
+					calibration.Selections = append(calibration.Selections, sel)
+					open[snapshot.ID] = sel
+				}
+			}
+			if sel, ok := open[snapshot.ID]; ok && previous != nil {
+				switch {
+				case snapshot.State == types.TaskStateCompleted && previous.State != types.TaskStateCompleted && sel.CycleMinutes == 0:
+					sel.CycleMinutes = math.Max(event.CreatedAt.Sub(sel.StartedAt).Minutes(), 1)
+				case previous.State == types.TaskStateCompleted && snapshot.State != types.TaskStateCompleted:
+					sel.Reworked = true
+				}
+			}
+			replay.apply(&snapshot)
+		case types.ChangeTaskDeleted:
+			if event.TaskID != nil {
+				replay.remove(*event.TaskID)
+				delete(open, *event.TaskID)
+			}
+		case types.ChangeDependencyAdded:
+			if event.TaskID != nil && event.DependsOnID != nil {
+				replay.addDependency(*event.TaskID, *event.DependsOnID)
+			}
+		case types.ChangeDependencyRemoved:
+			if event.TaskID != nil && event.DependsOnID != nil {
+				replay.removeDependency(*event.TaskID, *event.DependsOnID)
+			}
+		}
+	}
+
+	var cycles []float64
+	for _, sel := range calibration.Selections {
+		if sel.Rank == 1 {
+			calibration.Agreed++
+		}
+		if sel.Completed() {
+			calibration.Completed++
+		}
+		if sel.Reworked {
+			calibration.Reworked++
+		} else if sel.CycleMinutes > 0 {
+			cycles = append(cycles, sel.CycleMinutes)
+		}
+	}
+	if len(cycles) > 0 {
+		calibration.MedianCycleMinutes = median(cycles)
+	}
+
+	if calibration.Weighted {
+		calibration.recommend()
+	}
+	return calibration, nil
+}
+
+// recommend compares the factors of good and poor selections and derives the
+// recommended weights
+func (c *StrategyCalibration) recommend() {
+	var good, poor []*StrategySelection
+	for _, sel := range c.Selections {
+		switch {
+		case !sel.Completed():
+		case sel.Reworked || sel.CycleMinutes > c.MedianCycleMinutes:
+			poor = append(poor, sel)
+		default:
+			good = append(good, sel)
+		}
+	}
+
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, sel := range c.Selections {
+		for name := range sel.percentiles {
+			if _, ok := c.Current.WeightOf(name); ok && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	recommended := c.Current
+	total, adjustedTotal := 0.0, 0.0
+	for _, name := range names {
+		weight, _ := c.Current.WeightOf(name)
+		factor := StrategyFactor{
+			Name:        name,
+			Good:        averagePercentile(good, name),
+			Poor:        averagePercentile(poor, name),
+			Weight:      weight,
+			Recommended: weight,
+		}
+		if weight > 0 {
+			// Between half and one and a half times the current weight
+			factor.Recommended = weight * (1 + (factor.Good-factor.Poor)/2)
+		}
+		total += factor.Weight
+		adjustedTotal += factor.Recommended
+		c.Factors = append(c.Factors, factor)
+	}
+
+	if len(good)+len(poor) < MinStrategySamples || len(good) == 0 || len(poor) == 0 || adjustedTotal == 0 {
+		for i := range c.Factors {
+			c.Factors[i].Recommended = c.Factors[i].Weight
+		}
+		return
+	}
+
+	for i := range c.Factors {
+		c.Factors[i].Recommended = math.Round(c.Factors[i].Recommended*total/adjustedTotal*100) / 100
+		recommended.SetWeightOf(c.Factors[i].Name, c.Factors[i].Recommended)
+	}
+	c.Recommended = &recommended
+}
+
+// replaySelection scores the tasks actionable before task was started and
+// returns the selection of task, nil if the strategy could not rank it
+func replaySelection(tasks []*types.Task, started *types.Task, at time.Time, config *selection.Config) (*StrategySelection, error) {
+	projectTasks := make([]*types.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.ProjectID == started.ProjectID {
+			projectTasks = append(projectTasks, task)
+		}
+	}
+
+	report, err := selection.AnalyzeSelection(projectTasks, config)
+	if err != nil {
+		return nil, err
+	}
+	if report.Selected == nil {
+		return nil, nil
+	}
+
+	candidates := append([]*selection.TaskScore{report.Selected}, report.Alternatives...)
+	rank := 0
+	for i, candidate := range candidates {
+		if candidate.Task.ID == started.ID {
+			rank = i + 1
+		}
+	}
+	if rank == 0 {
+		return nil, nil
+	}
+
+	return &StrategySelection{
+		TaskID:      started.ID,
+		Title:       started.Title,
+		StartedAt:   at,
+		Rank:        rank,
+		Candidates:  len(candidates),
+		percentiles: factorPercentiles(candidates, candidates[rank-1]),
+	}, nil
+}
+
+// factorPercentiles returns, per factor, the share of the other candidates
+// whose value of the factor is lower than that of the chosen task. Ties count
+// half, and a lone candidate sits in the middle.
+func factorPercentiles(candidates []*selection.TaskScore, chosen *selection.TaskScore) map[string]float64 {
+	percentiles := make(map[string]float64, len(chosen.Factors))
+	for _, factor := range chosen.Factors {
+		if len(candidates) < 2 {
+			percentiles[factor.Name] = 0.5
+			continue
+		}
+		below := 0.0
+		for _, candidate := range candidates {
+			if candidate == chosen {
+				continue
+			}
+			for _, other := range candidate.Factors {
+				if other.Name != factor.Name {
+					continue
+				}
+				switch {
+				case other.Value < factor.Value:
+					below++
+				case other.Value == factor.Value:
+					below += 0.5
+				}
+			}
+		}
+		percentiles[factor.Name] = below / float64(len(candidates)-1)
+	}
+	return percentiles
+}
+
+// averagePercentile returns the average percentile of a factor over selections
+func averagePercentile(selections []*StrategySelection, name string) float64 {
+	if len(selections) == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, sel := range selections {
+		total += sel.percentiles[name]
+	}
+	return total / float64(len(selections))
+}
+
+// taskReplay rebuilds the tasks of a project from the change feed
+type taskReplay struct {
+	tasks map[uuid.UUID]*types.Task
+	deps  map[uuid.UUID]map[uuid.UUID]bool
+}
+
+func newTaskReplay() *taskReplay {
+	return &taskReplay{
+		tasks: make(map[uuid.UUID]*types.Task),
+		deps:  make(map[uuid.UUID]map[uuid.UUID]bool),
+	}
+}
+
+// apply records a task snapshot. Snapshots without dependencies keep the
+// dependencies known from earlier events.
+func (r *taskReplay) apply(task *types.Task) {
+	r.tasks[task.ID] = task
+	if len(task.Dependencies) == 0 {
+		return
+	}
+	deps := make(map[uuid.UUID]bool, len(task.Dependencies))
+	for _, dep := range task.Dependencies {
+		deps[dep] = true
+	}
+	r.deps[task.ID] = deps
+}
+
+func (r *taskReplay) remove(taskID uuid.UUID) {
+	delete(r.tasks, taskID)
+	delete(r.deps, taskID)
+}
+
+func (r *taskReplay) addDependency(taskID, dependsOnID uuid.UUID) {
+	if r.deps[taskID] == nil {
+		r.deps[taskID] = make(map[uuid.UUID]bool)
+	}
+	r.deps[taskID][dependsOnID] = true
+}
+
+func (r *taskReplay) removeDependency(taskID, dependsOnID uuid.UUID) {
+	delete(r.deps[taskID], dependsOnID)
+}
+
+// snapshot returns copies of the replayed tasks with their dependencies and
+// dependents, ordered by creation time
+func (r *taskReplay) snapshot() []*types.Task {
+	tasks := make([]*types.Task, 0, len(r.tasks))
+	copies := make(map[uuid.UUID]*types.Task, len(r.tasks))
+	for id, task := range r.tasks {
+		clone := *task
+		clone.Dependencies = nil
+		clone.Dependents = nil
+		copies[id] = &clone
+		tasks = append(tasks, &clone)
+	}
+	for id, deps := range r.deps {
+		task, ok := copies[id]
+		if !ok {
+			continue
+		}
+		for dep := range deps {
+			task.Dependencies = append(task.Dependencies, dep)
+			if dependency, ok := copies[dep]; ok {
+				dependency.Dependents = append(dependency.Dependents, id)
+			}
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return types.CompareCreation(tasks[i], tasks[j]) < 0
+	})
+	for _, task := range tasks {
+		sortIDs(task.Dependencies)
+		sortIDs(task.Dependents)
+	}
+	return tasks
+}
+
+func sortIDs(ids []uuid.UUID) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+}
@@ -0,0 +1,90 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/selection"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalibrateStrategy(t *testing.T) {
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	var events []*types.ChangeEvent
+	record := func(kind types.ChangeEventKind, task *types.Task, state types.TaskState, at time.Time) {
+		snapshot := *task
+		snapshot.State = state
+		data, _ := json.Marshal(&snapshot)
+		taskID := task.ID
+		events = append(events, &types.ChangeEvent{
+			Seq: int64(len(events) + 1), Kind: kind, ProjectID: task.ProjectID, TaskID: &taskID, Data: data, CreatedAt: at,
+		})
+	}
+
+	// High priority tasks are completed quickly, low priority tasks slowly or
+	// reopened, so priority should weigh more
+	var high, low []*types.Task
+	for i := 0; i < 4; i++ {
+		for _, priority := range []types.TaskPriority{types.TaskPriorityHigh, types.TaskPriorityLow} {
+			task := newTask(fmt.Sprintf("task %d-%d", i, priority), types.TaskStatePending, 3, 0)
+			task.Priority = priority
+			task.CreatedAt = start
+			record(types.ChangeTaskCreated, task, types.TaskStatePending, start)
+			if priority == types.TaskPriorityHigh {
+				high = append(high, task)
+			} else {
+				low = append(low, task)
+			}
+		}
+	}
+
+	at := start
+	for i := range high {
+		for _, task := range []*types.Task{low[i], high[i]} {
+			at = at.Add(time.Hour)
+			record(types.ChangeTaskUpdated, task, types.TaskStateInProgress, at)
+			duration := 10 * time.Minute
+			if task.Priority == types.TaskPriorityLow {
+				duration = 100 * time.Minute
+			}
+			record(types.ChangeTaskUpdated, task, types.TaskStateCompleted, at.Add(duration))
+		}
+	}
+	record(types.ChangeTaskUpdated, low[0], types.TaskStatePending, at.Add(3*time.Hour))
+
+	config := selection.ConfigForStrategy(selection.StrategyDependencyAware)
+	calibration, err := CalibrateStrategy(events, config)
+	require.NoError(t, err)
+
+	assert.True(t, calibration.Weighted)
+	require.Len(t, calibration.Selections, 8)
+	assert.Equal(t, 8, calibration.Completed)
+	assert.Equal(t, 1, calibration.Reworked)
+	assert.True(t, calibration.Selections[0].Reworked)
+	assert.Equal(t, 8, calibration.Selections[0].Candidates)
+	assert.Equal(t, 1, calibration.Selections[len(calibration.Selections)-1].Candidates)
+	assert.Equal(t, 10.0, calibration.Selections[1].CycleMinutes)
+
+	require.NotNil(t, calibration.Recommended)
+	assert.Greater(t, calibration.Recommended.Priority, config.Weights.Priority)
+	assert.Less(t, calibration.Recommended.DependentCount, config.Weights.DependentCount)
+	assert.NoError(t, (&selection.StrategyFactory{}).ValidateWeights(selection.StrategyDependencyAware, *calibration.Recommended))
+}
+
+func TestCalibrateStrategyFixedWeights(t *testing.T) {
+	task := newTask("task", types.TaskStatePending, 3, 0)
+	data, _ := json.Marshal(task)
+	taskID := task.ID
+	events := []*types.ChangeEvent{{Seq: 1, Kind: types.ChangeTaskCreated, TaskID: &taskID, Data: data}}
+
+	calibration, err := CalibrateStrategy(events, selection.ConfigForStrategy(selection.StrategyPriority))
+	require.NoError(t, err)
+	assert.False(t, calibration.Weighted)
+	assert.Empty(t, calibration.Selections)
+	assert.Empty(t, calibration.Factors)
+	assert.Nil(t, calibration.Recommended)
+}
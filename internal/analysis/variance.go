@@ -0,0 +1,183 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// VarianceOptions controls which tasks BuildVarianceReport compares
+type VarianceOptions struct {
+	// IncludeOpen also compares tasks that are not completed yet
+	IncludeOpen bool
+}
+
+// VarianceEntry compares the logged actual effort with the estimate, in minutes
+type VarianceEntry struct {
+	TaskID   *uuid.UUID `json:"task_id,omitempty"` // Set for task and subtree rows
+	Name     string     `json:"name"`              // Task title or agent
+	Tasks    int        `json:"tasks"`
+	Estimate int64      `json:"estimate"`
+	Actual   int64      `json:"actual"`
+	Variance int64      `json:"variance"` // Actual - Estimate, positive means underestimated
+	Ratio    float64    `json:"ratio"`    // Actual / Estimate
+}
+
+// VarianceReport is the result of BuildVarianceReport
+type VarianceReport struct {
+	Total    VarianceEntry   `json:"total"`
+	Tasks    []VarianceEntry `json:"tasks"`
+	Subtrees []VarianceEntry `json:"subtrees"`
+	Agents   []VarianceEntry `json:"agents"`
+	// Unestimated counts tasks with logged effort but no estimate
+	Unestimated int `json:"unestimated"`
+	// Unlogged counts estimated tasks without logged effort
+	Unlogged int `json:"unlogged"`
+}
+
+// BuildVarianceReport compares the logged actual effort of tasks with their
+// estimates per task, per subtree and per agent. Only tasks with both an
+// estimate and logged effort are compared. Subtree rows sum the compared tasks
+// below (and including) each parent task; agent rows attribute each task's
+// estimate in proportion to the agent's share of the logged effort.
+func BuildVarianceReport(tasks []*types.Task, opts VarianceOptions) *VarianceReport {
+	report := &VarianceReport{
+		Total:    VarianceEntry{Name: "total"},
+		Tasks:    []VarianceEntry{},
+		Subtrees: []VarianceEntry{},
+		Agents:   []VarianceEntry{},
+	}
+
+	byID := make(map[uuid.UUID]*types.Task, len(tasks))
+	hasChildren := make(map[uuid.UUID]bool)
+	for _, task := range tasks {
+		byID[task.ID] = task
+		if task.ParentID != nil {
+			hasChildren[*task.ParentID] = true
+		}
+	}
+
+	subtrees := make(map[uuid.UUID]*VarianceEntry)
+	agents := make(map[string]*agentVariance)
+	for _, task := range tasks {
+		if !varianceCandidate(task, opts) {
+			continue
+		}
+
+		actual := task.ActualEffort()
+		estimated := task.Estimate != nil && *task.Estimate > 0
+		switch {
+		case actual == 0 && estimated:
+			report.Unlogged++
+			continue
+		case actual == 0:
+			continue
+		case !estimated:
+			report.Unestimated++
+			continue
+		}
+
+		estimate := *task.Estimate
+		id := task.ID
+		report.Tasks = append(report.Tasks, newVarianceEntry(&id, task.Title, 1, estimate, actual))
+		report.Total.add(1, estimate, actual)
+
+		// Add the task to its own subtree (if it is a parent) and to all ancestors
+		for current := task; current != nil; current = parentOf(current, byID) {
+			if !hasChildren[current.ID] {
+				continue
+			}
+			entry, ok := subtrees[current.ID]
+			if !ok {
+				parentID := current.ID
+				entry = &VarianceEntry{TaskID: &parentID, Name: current.Title}
+				subtrees[current.ID] = entry
+			}
+			entry.add(1, estimate, actual)
+		}
+
+		for _, entry := range task.EffortLog {
+			agent, ok := agents[entry.Actor]
+			if !ok {
+				agent = &agentVariance{tasks: make(map[uuid.UUID]bool)}
+				agents[entry.Actor] = agent
+			}
+			agent.tasks[task.ID] = true
+			agent.actual += entry.Minutes
+			agent.estimate += float64(estimate) * float64(entry.Minutes) / float64(actual)
+		}
+	}
+
+	for _, entry := range subtrees {
+		report.Subtrees = append(report.Subtrees, *entry)
+	}
+	for name, agent := range agents {
+		report.Agents = append(report.Agents, newVarianceEntry(nil, name, len(agent.tasks), int64(math.Round(agent.estimate)), agent.actual))
+	}
+
+	sortByVariance(report.Tasks)
+	sortByVariance(report.Subtrees)
+	sortByVariance(report.Agents)
+	return report
+}
+
+type agentVariance struct {
+	tasks    map[uuid.UUID]bool
+	estimate float64
+	actual   int64
+}
+
+func varianceCandidate(task *types.Task, opts VarianceOptions) bool {
+	switch task.State {
+	case types.TaskStateCompleted:
+		return true
+	case types.TaskStateCancelled, types.TaskStateDeletionPending:
+		return false
+	default:
+		return opts.IncludeOpen
+	}
+}
+
+func parentOf(task *types.Task, byID map[uuid.UUID]*types.Task) *types.Task {
+	if task.ParentID == nil {
+		return nil
+	}
+	return byID[*task.ParentID]
+}
+
+func newVarianceEntry(taskID *uuid.UUID, name string, tasks int, estimate, actual int64) VarianceEntry {
+	entry := VarianceEntry{TaskID: taskID, Name: name}
+	entry.add(tasks, estimate, actual)
+	return entry
+}
+
+func (e *VarianceEntry) add(tasks int, estimate, actual int64) {
+	e.Tasks += tasks
+	e.Estimate += estimate
+	e.Actual += actual
+	e.Variance = e.Actual - e.Estimate
+	e.Ratio = 0
+	if e.Estimate > 0 {
+		e.Ratio = math.Round(float64(e.Actual)/float64(e.Estimate)*100) / 100
+	}
+}
+
+// sortByVariance orders entries by absolute variance, largest first
+func sortByVariance(entries []VarianceEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		vi, vj := abs64(entries[i].Variance), abs64(entries[j].Variance)
+		if vi != vj {
+			return vi > vj
+		}
+		return entries[i].Name < entries[j].Name
+	})
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,66 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func logEffort(task *types.Task, actor string, minutes int64) *types.Task {
+	task.EffortLog = append(task.EffortLog, types.EffortEntry{Actor: actor, Minutes: minutes})
+	return task
+}
+
+func TestBuildVarianceReport(t *testing.T) {
+	parent := newTask("parent", types.TaskStateCompleted, 4, 0)
+	slow := logEffort(logEffort(newTask("slow", types.TaskStateCompleted, 3, 60), "alice", 60), "bob", 60)
+	slow.ParentID = &parent.ID
+	fast := logEffort(newTask("fast", types.TaskStateCompleted, 3, 120), "bob", 90)
+	fast.ParentID = &parent.ID
+	open := logEffort(newTask("open", types.TaskStateInProgress, 2, 30), "alice", 45)
+	unestimated := logEffort(newTask("unestimated", types.TaskStateCompleted, 2, 0), "alice", 30)
+	unlogged := newTask("unlogged", types.TaskStateCompleted, 2, 60)
+	cancelled := logEffort(newTask("cancelled", types.TaskStateCancelled, 2, 60), "alice", 10)
+
+	tasks := []*types.Task{parent, slow, fast, open, unestimated, unlogged, cancelled}
+	report := BuildVarianceReport(tasks, VarianceOptions{})
+
+	assert.Equal(t, 1, report.Unestimated)
+	assert.Equal(t, 1, report.Unlogged)
+	assert.Equal(t, VarianceEntry{Name: "total", Tasks: 2, Estimate: 180, Actual: 210, Variance: 30, Ratio: 1.17}, report.Total)
+
+	require.Len(t, report.Tasks, 2)
+	assert.Equal(t, "slow", report.Tasks[0].Name)
+	assert.Equal(t, int64(60), report.Tasks[0].Variance)
+	assert.Equal(t, 2.0, report.Tasks[0].Ratio)
+	assert.Equal(t, "fast", report.Tasks[1].Name)
+	assert.Equal(t, int64(-30), report.Tasks[1].Variance)
+
+	require.Len(t, report.Subtrees, 1)
+	assert.Equal(t, parent.ID, *report.Subtrees[0].TaskID)
+	assert.Equal(t, 2, report.Subtrees[0].Tasks)
+	assert.Equal(t, int64(30), report.Subtrees[0].Variance)
+
+	// bob logged half of "slow" (estimate share 30m) and all of "fast" (120m)
+	require.Len(t, report.Agents, 2)
+	agents := map[string]VarianceEntry{}
+	for _, agent := range report.Agents {
+		agents[agent.Name] = agent
+	}
+	assert.Equal(t, VarianceEntry{Name: "alice", Tasks: 1, Estimate: 30, Actual: 60, Variance: 30, Ratio: 2}, agents["alice"])
+	assert.Equal(t, VarianceEntry{Name: "bob", Tasks: 2, Estimate: 150, Actual: 150, Variance: 0, Ratio: 1}, agents["bob"])
+
+	withOpen := BuildVarianceReport(tasks, VarianceOptions{IncludeOpen: true})
+	assert.Len(t, withOpen.Tasks, 3)
+	assert.Equal(t, int64(255), withOpen.Total.Actual)
+}
+
+func TestBuildVarianceReportEmpty(t *testing.T) {
+	report := BuildVarianceReport(nil, VarianceOptions{})
+	assert.Empty(t, report.Tasks)
+	assert.Empty(t, report.Subtrees)
+	assert.Empty(t, report.Agents)
+	assert.Zero(t, report.Total.Ratio)
+}
@@ -1,26 +1,56 @@
 package app
 
 import (
+	stderrors "errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/denkhaus/knot/v2/internal/commands/agent"
+	"github.com/denkhaus/knot/v2/internal/commands/analyze"
+	benchCommands "github.com/denkhaus/knot/v2/internal/commands/bench"
+	changelogCommands "github.com/denkhaus/knot/v2/internal/commands/changelog"
 	configCommands "github.com/denkhaus/knot/v2/internal/commands/config"
 	"github.com/denkhaus/knot/v2/internal/commands/completion"
 	"github.com/denkhaus/knot/v2/internal/commands/dependency"
+	digestCommands "github.com/denkhaus/knot/v2/internal/commands/digest"
+	"github.com/denkhaus/knot/v2/internal/commands/events"
+	filterCommands "github.com/denkhaus/knot/v2/internal/commands/filter"
+	fixturesCommands "github.com/denkhaus/knot/v2/internal/commands/fixtures"
+	maintenanceCommands "github.com/denkhaus/knot/v2/internal/commands/maintenance"
+	policyCommands "github.com/denkhaus/knot/v2/internal/commands/policy"
 	"github.com/denkhaus/knot/v2/internal/commands/health"
+	"github.com/denkhaus/knot/v2/internal/commands/interchange"
+	lintCommands "github.com/denkhaus/knot/v2/internal/commands/lint"
+	notifyCommands "github.com/denkhaus/knot/v2/internal/commands/notify"
+	planCommands "github.com/denkhaus/knot/v2/internal/commands/plan"
 	"github.com/denkhaus/knot/v2/internal/commands/project"
+	"github.com/denkhaus/knot/v2/internal/commands/report"
+	"github.com/denkhaus/knot/v2/internal/commands/serve"
+	sessionCommands "github.com/denkhaus/knot/v2/internal/commands/session"
+	"github.com/denkhaus/knot/v2/internal/commands/simulate"
+	schedulerCommands "github.com/denkhaus/knot/v2/internal/commands/scheduler"
+	snapshotCommands "github.com/denkhaus/knot/v2/internal/commands/snapshot"
+	standupCommands "github.com/denkhaus/knot/v2/internal/commands/standup"
+	"github.com/denkhaus/knot/v2/internal/commands/stats"
+	syncCommands "github.com/denkhaus/knot/v2/internal/commands/sync"
 	"github.com/denkhaus/knot/v2/internal/commands/task"
 	"github.com/denkhaus/knot/v2/internal/commands/template"
+	"github.com/denkhaus/knot/v2/internal/commands/user"
 	validationCommands "github.com/denkhaus/knot/v2/internal/commands/validation"
 	"github.com/denkhaus/knot/v2/internal/errors"
 	"github.com/denkhaus/knot/v2/internal/logger"
 	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/metrics"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/repository"
 	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
-	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/denkhaus/knot/v2/internal/repository/remote"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/templates"
 	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/verify"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
 )
@@ -43,6 +73,12 @@ func SetVersionFromBuild(v, c, d string) {
 type App struct {
 	*cli.App
 	context *shared.AppContext
+
+	// Bounds the running command, see the global --timeout flag
+	deadline *shared.Deadline
+
+	// Set by the global --machine flag
+	machine bool
 }
 
 // isUserInputError checks if an error is due to user input (like missing required flags)
@@ -79,24 +115,38 @@ func isUserInputError(err error) bool {
 	return false
 }
 
+// isPermissionError reports whether the knot server denied the operation or
+// rejected the token, which the user has to fix like an input error
+func isPermissionError(err error) bool {
+	var permErr *remote.PermissionError
+	return stderrors.As(err, &permErr)
+}
+
 // New creates a new CLI application with all dependencies initialized
 func New() (*App, error) {
 	// Initialize logger
 	appLogger := logger.GetLogger()
 
-	// Initialize repository (SQLite with fallback to in-memory)
+	// Initialize repository from the configured storage DSN (SQLite by default)
+	// with fallback to in-memory
 	var repo types.Repository
 	var err error
 
-	repo, err = sqlite.NewRepository("",
-		sqlite.WithLogger(appLogger),
-		sqlite.WithAutoMigrate(true),
-	)
-	if err != nil {
-		appLogger.Warn("Failed to initialize SQLite repository, falling back to in-memory", zap.Error(err))
+	dsn := remote.DSNFromEnv()
+	repo, err = repository.Open(dsn, repository.Options{
+		Logger:      appLogger,
+		AutoMigrate: true,
+		Observer:    metrics.Default,
+	})
+	if err != nil && remote.IsRemote(dsn) {
+		// Never fall back to a local database when a team server is configured
+		return nil, fmt.Errorf("failed to connect to knot server: %w", err)
+	} else if err != nil {
+		appLogger.Warn("Failed to initialize repository, falling back to in-memory", zap.Error(err))
 		repo = inmemory.NewMemoryRepository()
 	} else {
-		appLogger.Info("SQLite repository initialized successfully")
+		driver, _ := repository.ParseDSN(dsn)
+		appLogger.Info("Repository initialized successfully", zap.String("driver", driver))
 
 		// Initialize templates automatically after successful database setup
 		if err := templates.CheckAndSeedIfNeeded(); err != nil {
@@ -106,12 +156,20 @@ func New() (*App, error) {
 		}
 	}
 
+	// Record the latency of every repository operation
+	repo = metrics.InstrumentRepository(repo, metrics.Default)
+
 	// Initialize project manager
 	config := manager.DefaultConfig()
 	projectManager := manager.NewManagerWithRepository(repo, config)
+	if err := projectManager.LoadConfigFromFile(); err != nil {
+		appLogger.Warn("Failed to load configuration, using defaults", zap.Error(err))
+	}
 
 	// Create application context
 	appCtx := shared.NewAppContext(projectManager, appLogger)
+	appCtx.Repository = repo
+	application := &App{context: appCtx}
 
 	// Create CLI app
 	cliApp := &cli.App{
@@ -134,17 +192,52 @@ For new users or LLM agents, run 'knot get-started' for a comprehensive guide to
 				EnvVars: []string{"KNOT_ACTOR", "USER"},
 			},
 			shared.NewLogLevelFlag(),
+			shared.NewNoColorFlag(),
+			shared.NewNoEmojiFlag(),
+			shared.NewMachineFlag(),
+			shared.NewUTCFlag(),
+			shared.NewTimeoutFlag(),
+			shared.NewForceFlag(),
 		},
 		Before: func(c *cli.Context) error {
+			// Configure output theme first, the logger picks up the color setting
+			machine := c.Bool("machine")
+			output.Configure(c.Bool("no-color") || machine, c.Bool("no-emoji") || machine)
+			if machine {
+				application.machine = true
+				appCtx.Output = output.NewJSONWriter(os.Stdout)
+			}
+			if err := configureTime(c, projectManager.GetConfig()); err != nil {
+				return err
+			}
+
 			// Configure logger based on log-level flag
 			logLevel := c.String("log-level")
 			logger.SetLogLevel(logLevel)
 
 			// Update appCtx logger reference after reconfiguration
 			appCtx.Logger = logger.GetLogger()
+			appCtx.Metrics.SetSlowQueryLog(projectManager.GetConfig().SlowQueryThreshold(), appCtx.Logger)
 
 			appCtx.SetActor(c.String("actor"))
 			appCtx.Logger.Info("Knot CLI started", zap.String("version", version))
+
+			// Writes to projects locked by another actor fail unless --force is set
+			c.Context = manager.WithWriter(c.Context, appCtx.GetActor(), c.Bool("force"))
+
+			// Commands inherit c.Context, so every repository call is bound by
+			// the timeout. Interactive commands restart it after user input.
+			application.deadline = nil
+			if timeout := c.Duration("timeout"); timeout > 0 {
+				application.deadline = shared.NewDeadline(c.Context, timeout)
+				c.Context = application.deadline.Context()
+			}
+			return nil
+		},
+		After: func(c *cli.Context) error {
+			if application.deadline != nil {
+				application.deadline.Cancel()
+			}
 			return nil
 		},
 		Commands: []*cli.Command{
@@ -171,7 +264,7 @@ For new users or LLM agents, run 'knot get-started' for a comprehensive guide to
 			},
 			{
 				Name:        "dependency",
-				Aliases:     []string{"dep"},
+				Aliases:     []string{"dep", "deps"},
 				Usage:       "Task dependency management",
 				Subcommands: dependency.Commands(appCtx),
 			},
@@ -202,17 +295,34 @@ For new users or LLM agents, run 'knot get-started' for a comprehensive guide to
 			},
 			{
 				Name:   "blocked",
-				Usage:  "Show tasks blocked by dependencies",
+				Usage:  "Show tasks blocked by dependencies or external reasons",
+				Description: `Lists pending and in-progress tasks waiting for open dependencies and tasks
+blocked for an external reason. With --aging, every blocked task is listed with
+how long it has been blocked, by whom or what, oldest first; tasks blocked for
+the escalation threshold (config key blocked-escalation-days, 7 days by
+default) are marked for escalation.`,
 				Action: task.BlockedAction(appCtx),
 				Flags: []cli.Flag{
 					shared.NewTaskLimitFlag(),
 					shared.NewJSONFlag(),
+					&cli.BoolFlag{
+						Name:  "aging",
+						Usage: "Show how long each task has been blocked and mark long-blocked tasks for escalation",
+					},
+					&cli.IntFlag{
+						Name:  "escalate-after",
+						Usage: "Days after which --aging marks a blocked task for escalation (0 to never escalate, default from config)",
+					},
 				},
 			},
 			task.NewActionableCommand(appCtx),
 			{
-				Name:   "breakdown",
-				Usage:  "Find tasks that need breakdown based on complexity",
+				Name:  "breakdown",
+				Usage: "Find tasks that need breakdown based on complexity",
+				Description: `Lists tasks at or above the complexity threshold that have no subtasks,
+ordered by potential impact (number of dependent tasks), with a suggested
+number of subtasks for each. The threshold defaults to the configured
+complexity_threshold.`,
 				Action: task.BreakdownAction(appCtx),
 				Flags: []cli.Flag{
 					shared.NewTaskLimitFlag(),
@@ -220,26 +330,131 @@ For new users or LLM agents, run 'knot get-started' for a comprehensive guide to
 					shared.NewQuietFlag(),
 					&cli.IntFlag{
 						Name:    "threshold",
-						Aliases: []string{"t"},
-						Usage:   "Complexity threshold for breakdown (default: 8)",
+						Aliases: []string{"t", "min-complexity"},
+						Usage:   "Minimum complexity of tasks to list (default: configured complexity threshold)",
 						Value:   8,
 						EnvVars: []string{"KNOT_COMPLEXITY_THRESHOLD"},
 					},
 				},
 			},
+			{
+				Name:        "import",
+				Usage:       "Import tasks from other formats",
+				Subcommands: interchange.ImportCommands(appCtx),
+			},
+			{
+				Name:        "export",
+				Usage:       "Export tasks to other formats",
+				Subcommands: interchange.ExportCommands(appCtx),
+			},
+			{
+				Name:        "analyze",
+				Usage:       "Analyze project tasks and suggest improvements",
+				Subcommands: analyze.Commands(appCtx),
+			},
+			{
+				Name:        "report",
+				Usage:       "Reports on project effort and estimation",
+				Subcommands: report.Commands(appCtx),
+			},
+			{
+				Name:        "filter",
+				Usage:       "Save and apply named task filter queries",
+				Subcommands: filterCommands.Commands(appCtx),
+			},
+			{
+				Name:        "simulate",
+				Usage:       "Preview the effect of task changes without saving them",
+				Subcommands: simulate.Commands(appCtx),
+			},
+			{
+				Name:        "stats",
+				Usage:       "Runtime statistics for monitoring",
+				Subcommands: stats.Commands(appCtx),
+			},
+			{
+				Name:        "sync",
+				Usage:       "Synchronize knot databases without a server",
+				Subcommands: syncCommands.Commands(appCtx),
+			},
+			{
+				Name:        "scheduler",
+				Usage:       "Apply templates and create recurring tasks on a cron schedule",
+				Subcommands: schedulerCommands.Commands(appCtx),
+			},
+			planCommands.NewPlanCommand(appCtx),
+			planCommands.NewApplyCommand(appCtx),
+			events.NewEventsCommand(appCtx),
+			notifyCommands.NewNotifyCommand(appCtx),
+			standupCommands.NewStandupCommand(appCtx),
+			digestCommands.NewDigestCommand(appCtx),
+			changelogCommands.NewChangelogCommand(appCtx),
+			lintCommands.NewLintCommand(appCtx),
+			serve.NewServeCommand(appCtx),
+			{
+				Name:        "user",
+				Usage:       "Manage the users and roles of a shared knot server",
+				Subcommands: user.Commands(appCtx),
+			},
+			snapshotCommands.NewSnapshotCommand(appCtx),
+			benchCommands.NewBenchCommand(appCtx),
+			{
+				Name:        "fixtures",
+				Usage:       "Load and dump reproducible projects for tests and demos",
+				Subcommands: fixturesCommands.Commands(appCtx),
+			},
+			{
+				Name:        "maintenance",
+				Usage:       "Run periodic housekeeping such as scheduled state changes",
+				Subcommands: maintenanceCommands.Commands(appCtx),
+			},
+			{
+				Name:        "policy",
+				Usage:       "Check project hygiene policies such as maximum task ages",
+				Subcommands: policyCommands.Commands(appCtx),
+			},
+			{
+				Name:        "session",
+				Usage:       "Work in a project of your own per shell or agent",
+				Subcommands: sessionCommands.Commands(appCtx),
+			},
 			{
 				Name:   "get-started",
 				Usage:  "Get started guide for LLM agents with available commands and usage",
 				Action: task.GetStartedAction(appCtx),
 			},
+			{
+				Name:        "agent",
+				Usage:       "Session helpers for LLM agents",
+				Subcommands: agent.Commands(appCtx),
+			},
 			completion.CompletionCommand(appCtx),
+			exitCodesCommand(),
 		},
 	}
 
-	return &App{
-		App:     cliApp,
-		context: appCtx,
-	}, nil
+	addOutputTargets(cliApp.Commands, false, appCtx)
+	instrumentCommands(cliApp.Commands, "", appCtx.Metrics)
+
+	application.App = cliApp
+	return application, nil
+}
+
+// instrumentCommands wraps the actions of cmds and their subcommands so each
+// run is recorded in registry under its full command name, e.g. "task list"
+func instrumentCommands(cmds []*cli.Command, prefix string, registry *metrics.Registry) {
+	for _, cmd := range cmds {
+		name := strings.TrimSpace(prefix + " " + cmd.Name)
+		if action := cmd.Action; action != nil {
+			cmd.Action = func(c *cli.Context) error {
+				start := time.Now()
+				err := action(c)
+				registry.ObserveCommand(name, time.Since(start), err)
+				return err
+			}
+		}
+		instrumentCommands(cmd.Subcommands, name, registry)
+	}
 }
 
 // Run starts the CLI application
@@ -247,18 +462,65 @@ func (a *App) Run(args []string) error {
 	defer logger.Sync()
 
 	if err := a.App.Run(args); err != nil {
-		// For user input errors, print them cleanly without JSON logging
-		if isUserInputError(err) {
+		// Failed checks run under their own timeout, see 'knot task verify'
+		var checkErr *verify.FailedError
+		if a.timedOut() && !stderrors.As(err, &checkErr) {
+			err = &TimeoutError{Timeout: a.deadline.Timeout, Cause: err}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return err
+		}
+
+		var lockedErr *manager.ProjectLockedError
+		if stderrors.As(err, &lockedErr) {
+			err = &errors.EnhancedError{
+				Operation:   "writing to a locked project",
+				Cause:       lockedErr,
+				Suggestion:  "Wait until the lock is released or expires, or override it with the global --force flag",
+				Example:     "knot --force task update-state --id <task-id> --state completed",
+				HelpCommand: "knot project lock --help",
+			}
+		}
+
+		// For user input and permission errors, print them cleanly without JSON logging
+		if isUserInputError(err) || isPermissionError(err) {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			fmt.Fprintf(os.Stderr, "💡 For help getting started with Knot and a list of all commands, run: knot get-started\n")
+			a.printGetStartedHint()
 			return err
 		}
 
 		// For internal errors, use the logger but also suggest the get-started command
 		a.context.Logger.Error("Application error", zap.Error(err))
-		fmt.Fprintf(os.Stderr, "💡 For help getting started with Knot and a list of all commands, run: knot get-started\n")
+		a.printGetStartedHint()
 		return err
 	}
 
 	return nil
 }
+
+// printGetStartedHint points to 'knot get-started' after an error, except in
+// machine mode
+func (a *App) printGetStartedHint() {
+	if a.machine {
+		return
+	}
+	fmt.Fprint(os.Stderr, output.Icon("💡")+"For help getting started with Knot and a list of all commands, run: knot get-started\n")
+}
+
+// timedOut reports whether the command was aborted by the --timeout deadline
+func (a *App) timedOut() bool {
+	return a.deadline != nil && a.deadline.TimedOut()
+}
+
+// configureTime sets how timestamps are displayed from the --utc flag and the
+// configured time zone and format
+func configureTime(c *cli.Context, config *manager.Config) error {
+	location, err := config.TimeLocation()
+	if err != nil {
+		return err
+	}
+	if c.Bool("utc") {
+		location = time.UTC
+	}
+	output.ConfigureTime(location, config.TimeFormat)
+	return nil
+}
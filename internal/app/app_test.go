@@ -6,16 +6,36 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/denkhaus/knot/v2/internal/errors"
 	"github.com/denkhaus/knot/v2/internal/manager"
 	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/denkhaus/knot/v2/internal/repository/remote"
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
 	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/verify"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
 )
 
+// TestMain runs the tests in a temporary working directory, since New opens the .knot workspace of the current directory
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "knot-app-test")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 func TestMainFunction(t *testing.T) {
 	// Test the main function by capturing stdout/stderr
 	originalArgs := os.Args
@@ -238,7 +258,7 @@ func TestAppFlags(t *testing.T) {
 	require.NoError(t, err)
 
 	// Check that expected flags are present
-	expectedFlags := []string{"actor", "log-level"}
+	expectedFlags := []string{"actor", "log-level", "timeout"}
 
 	flagsMap := make(map[string]cli.Flag)
 	for _, flag := range app.App.Flags {
@@ -309,3 +329,133 @@ func TestAppIntegration(t *testing.T) {
 	_, err = projectManager.GetProject(ctxWithBackground, project.ID)
 	assert.Error(t, err)
 }
+
+func TestExitCodeFor(t *testing.T) {
+	taskID := uuid.New()
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "nil error", err: nil, expected: ExitSuccess},
+		{name: "unclassified error", err: fmt.Errorf("something broke"), expected: ExitFailure},
+		{name: "cli flag error", err: fmt.Errorf("flag provided but not defined: -foo"), expected: ExitValidation},
+		{name: "complexity out of range", err: errors.ComplexityOutOfRangeError(11), expected: ExitValidation},
+		{name: "no project selected", err: errors.NoProjectContextError(), expected: ExitValidation},
+		{name: "task not found", err: errors.TaskNotFoundError(taskID), expected: ExitNotFound},
+		{name: "wrapped not found", err: fmt.Errorf("failed to get task: %w", fmt.Errorf("task not found")), expected: ExitNotFound},
+		{name: "repository not found", err: sqlite.NewNotFoundError("task", taskID.String()), expected: ExitNotFound},
+		{name: "invalid transition", err: fmt.Errorf("invalid state transition from 'completed' to 'pending'"), expected: ExitConflict},
+		{name: "circular dependency", err: errors.CircularDependencyError(taskID, taskID), expected: ExitConflict},
+		{name: "permission denied", err: fmt.Errorf("permission denied: user alice has the viewer role on project 1, DeleteTask needs the admin role"), expected: ExitPermission},
+		{name: "rejected token", err: fmt.Errorf("failed to create task: %w", &remote.PermissionError{Message: "knot server at http://localhost:8420 rejected the token"}), expected: ExitPermission},
+		{name: "sync conflict", err: fmt.Errorf("1 unresolved sync conflict(s), run the sync again with --prefer local or --prefer remote"), expected: ExitConflict},
+		{name: "repository constraint", err: sqlite.NewConstraintViolationError("unique title", nil), expected: ExitConflict},
+		{name: "repository connection", err: sqlite.NewConnectionError("failed to open", nil), expected: ExitStorage},
+		{name: "database error", err: errors.DatabaseConnectionError("listing tasks", fmt.Errorf("database is locked")), expected: ExitStorage},
+		{name: "policy violation", err: &errors.EnhancedError{Operation: "checking aging policies", Cause: fmt.Errorf("2 aging policy violation(s)")}, expected: ExitPolicy},
+		{name: "lint findings", err: &errors.EnhancedError{Operation: "linting task descriptions", Cause: fmt.Errorf("3 lint finding(s) of severity error or higher")}, expected: ExitPolicy},
+		{name: "failed task check", err: &errors.EnhancedError{Operation: "verifying task", Cause: &verify.FailedError{Run: types.CheckRun{ExitCode: 2}}}, expected: ExitFailure},
+		{name: "cli exit coder", err: cli.Exit("custom", 7), expected: 7},
+		{name: "timeout", err: &TimeoutError{Timeout: time.Second, Cause: context.DeadlineExceeded}, expected: ExitTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExitCodeFor(tt.err))
+		})
+	}
+}
+
+func TestAppRunPrintsPermissionErrors(t *testing.T) {
+	app, err := New()
+	require.NoError(t, err)
+
+	denied := &remote.PermissionError{Message: "user bob has the viewer role on project 1, CreateTask needs the editor role"}
+	app.App.Commands = append(app.App.Commands, &cli.Command{
+		Name: "denied",
+		Action: func(c *cli.Context) error {
+			return fmt.Errorf("failed to create task: %w", denied)
+		},
+	})
+
+	// Permission errors go to stderr like input errors, not to the logger,
+	// which is off by default
+	old := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	err = app.Run([]string{"knot", "denied"})
+	w.Close()
+	os.Stderr = old
+
+	var stderr bytes.Buffer
+	_, _ = stderr.ReadFrom(r)
+	require.Error(t, err)
+	assert.Equal(t, ExitPermission, ExitCodeFor(err))
+	assert.Contains(t, stderr.String(), "Error: failed to create task: permission denied: user bob has the viewer role")
+}
+
+func TestAppRunTimeout(t *testing.T) {
+	app, err := New()
+	require.NoError(t, err)
+
+	// A command that blocks until its context is done stands in for a hung database call
+	app.App.Commands = append(app.App.Commands, &cli.Command{
+		Name: "hang",
+		Action: func(c *cli.Context) error {
+			<-c.Context.Done()
+			return c.Context.Err()
+		},
+	})
+
+	err = app.Run([]string{"knot", "--timeout", "10ms", "hang"})
+	require.Error(t, err)
+
+	var timeoutErr *TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, 10*time.Millisecond, timeoutErr.Timeout)
+	assert.Contains(t, err.Error(), "timed out after 10ms")
+	assert.Equal(t, ExitTimeout, ExitCodeFor(err))
+
+	// Task checks run under their own timeout, so their failure is no timeout
+	app.App.Commands = append(app.App.Commands, &cli.Command{
+		Name: "slow-check",
+		Action: func(c *cli.Context) error {
+			<-c.Context.Done()
+			return &verify.FailedError{Run: types.CheckRun{ExitCode: 1}}
+		},
+	})
+	err = app.Run([]string{"knot", "--timeout", "10ms", "slow-check"})
+	require.Error(t, err)
+	assert.NotErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, ExitFailure, ExitCodeFor(err))
+
+	// Interactive input does not count: the deadline restarts once the user
+	// answered, and later errors are not mistaken for timeouts
+	app.App.Commands = append(app.App.Commands, &cli.Command{
+		Name: "prompt",
+		Action: func(c *cli.Context) error {
+			time.Sleep(50 * time.Millisecond) // the user takes longer than --timeout
+			shared.RestartTimeout(c)
+			if err := c.Context.Err(); err != nil {
+				return err
+			}
+			return fmt.Errorf("task not found")
+		},
+	})
+	err = app.Run([]string{"knot", "--timeout", "20ms", "prompt"})
+	require.Error(t, err)
+	assert.NotErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, ExitNotFound, ExitCodeFor(err))
+
+	app.App.Commands = append(app.App.Commands, &cli.Command{
+		Name: "prompt-then-hang",
+		Action: func(c *cli.Context) error {
+			shared.RestartTimeout(c)
+			<-c.Context.Done()
+			return c.Context.Err()
+		},
+	})
+	err = app.Run([]string{"knot", "--timeout", "10ms", "prompt-then-hang"})
+	require.ErrorAs(t, err, &timeoutErr)
+}
@@ -0,0 +1,185 @@
+package app
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/denkhaus/knot/v2/internal/verify"
+	"github.com/urfave/cli/v2"
+)
+
+// Exit codes returned by the knot binary. Automation can rely on these values.
+const (
+	ExitSuccess    = 0 // Command completed successfully
+	ExitFailure    = 1 // Unexpected or unclassified error, or a failed task check
+	ExitValidation = 2 // Invalid input: bad flags, values or missing project context
+	ExitNotFound   = 3 // Referenced project, task or other entity does not exist
+	ExitConflict   = 4 // Operation conflicts with current state, e.g. an invalid state transition
+	ExitStorage    = 5 // Database or storage failure
+	ExitTimeout    = 6 // Command exceeded the --timeout limit
+	ExitPermission = 7 // The knot server rejected the token or denied the operation
+	ExitPolicy     = 8 // 'knot policy check' found tasks violating a policy, or 'knot lint' findings
+)
+
+// exitCodesHelp documents the exit codes, shown by 'knot help exit-codes'
+const exitCodesHelp = `knot exits with one of the following codes:
+
+   0  success
+   1  unexpected error, or the check run by 'knot task verify' failed
+   2  validation error (invalid flags or values, no project selected)
+   3  not found (project, task or other referenced entity does not exist)
+   4  conflict (invalid state transition, circular dependency, duplicate entity, project locked,
+      unresolved sync conflict)
+   5  storage error (database cannot be opened, read or written)
+   6  timeout (the command ran longer than --timeout, default 30s)
+   7  permission denied (knot server rejected the token or the user's role)
+   8  policy violation ('knot policy check' found tasks older than their aging policy, or
+      'knot lint' found problems at least as severe as --fail-on)
+
+Example:
+   knot task get --id "$TASK_ID" >/dev/null 2>&1
+   case $? in
+     0) echo "task exists" ;;
+     3) echo "task not found" ;;
+     *) echo "something else went wrong" ;;
+   esac`
+
+// Message fragments used to classify errors that carry no type information
+var (
+	notFoundPatterns = []string{"not found", "does not exist", "no results found"}
+	conflictPatterns = []string{
+		"state transition", "circular dependency", "cycle", "already exists",
+		"marked for deletion", "constraint violation", "cannot block task", "cannot start",
+		"project locked by", "sync conflict",
+	}
+	permissionPatterns = []string{"permission denied", "rejected the token"}
+	policyPatterns     = []string{"policy violation", "lint finding"}
+	storagePatterns    = []string{"database", "sqlite", "transaction", "migration", "connection"}
+)
+
+// TimeoutError reports a command aborted because it exceeded the --timeout limit
+type TimeoutError struct {
+	Timeout time.Duration
+	Cause   error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("command timed out after %s (raise the limit with --timeout or KNOT_TIMEOUT): %v", e.Timeout, e.Cause)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Cause
+}
+
+// ExitCode implements cli.ExitCoder
+func (e *TimeoutError) ExitCode() int {
+	return ExitTimeout
+}
+
+// ExitCodeFor maps an error returned by the application to a process exit code
+func ExitCodeFor(err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+
+	var exitCoder cli.ExitCoder
+	if stderrors.As(err, &exitCoder) {
+		return exitCoder.ExitCode()
+	}
+
+	var checkErr *verify.FailedError
+	if stderrors.As(err, &checkErr) {
+		return ExitFailure
+	}
+
+	if isPermissionError(err) {
+		return ExitPermission
+	}
+
+	var repoErr *sqlite.RepositoryError
+	if stderrors.As(err, &repoErr) {
+		return repositoryExitCode(repoErr)
+	}
+
+	// Enhanced errors are user-facing; classify them by their cause only, since
+	// suggestions and examples may mention unrelated keywords
+	var enhancedErr *errors.EnhancedError
+	if stderrors.As(err, &enhancedErr) {
+		if enhancedErr.Cause != nil {
+			if code := exitCodeFromMessage(enhancedErr.Cause.Error()); code != ExitFailure {
+				return code
+			}
+		}
+		return ExitValidation
+	}
+
+	if code := exitCodeFromMessage(err.Error()); code != ExitFailure {
+		return code
+	}
+
+	if isUserInputError(err) {
+		return ExitValidation
+	}
+
+	return ExitFailure
+}
+
+// repositoryExitCode maps a typed repository error to an exit code
+func repositoryExitCode(err *sqlite.RepositoryError) int {
+	switch err.Type {
+	case sqlite.ErrorTypeNotFound:
+		return ExitNotFound
+	case sqlite.ErrorTypeConstraintViolation, sqlite.ErrorTypeCircularDependency:
+		return ExitConflict
+	case sqlite.ErrorTypeMaxDepthExceeded, sqlite.ErrorTypeMaxTasksExceeded, sqlite.ErrorTypeValidationError:
+		return ExitValidation
+	default:
+		return ExitStorage
+	}
+}
+
+// exitCodeFromMessage classifies an untyped error by its message, returning
+// ExitFailure if nothing matches
+func exitCodeFromMessage(msg string) int {
+	msg = strings.ToLower(msg)
+	switch {
+	case containsAny(msg, permissionPatterns):
+		return ExitPermission
+	case containsAny(msg, policyPatterns):
+		return ExitPolicy
+	case containsAny(msg, notFoundPatterns):
+		return ExitNotFound
+	case containsAny(msg, conflictPatterns):
+		return ExitConflict
+	case containsAny(msg, storagePatterns):
+		return ExitStorage
+	default:
+		return ExitFailure
+	}
+}
+
+func containsAny(s string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.Contains(s, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// exitCodesCommand provides the 'exit-codes' help topic
+func exitCodesCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "exit-codes",
+		Usage:       "Show the exit codes returned by knot",
+		Description: exitCodesHelp,
+		Action: func(c *cli.Context) error {
+			fmt.Fprintln(c.App.Writer, exitCodesHelp)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/urfave/cli/v2"
+)
+
+// addOutputTargets adds --out and --copy to the commands printing JSON, those
+// with a json flag or below a command with one, and wraps their actions so
+// the output can be written to a file or the clipboard. Commands writing files
+// or copying IDs themselves are left alone.
+func addOutputTargets(cmds []*cli.Command, inheritedJSON bool, appCtx *shared.AppContext) {
+	for _, cmd := range cmds {
+		json := inheritedJSON || hasFlag(cmd, "json")
+		if cmd.Action != nil && json && !hasFlag(cmd, "out") && !hasFlag(cmd, "copy") {
+			cmd.Flags = append(cmd.Flags,
+				shared.NewOutFlag(),
+				shared.NewCopyFlag("Copy the JSON output to the clipboard (implies --json)"))
+			cmd.Action = withOutputTargets(cmd.Action, appCtx)
+		}
+		addOutputTargets(cmd.Subcommands, json, appCtx)
+	}
+}
+
+// hasFlag reports whether cmd declares a flag with the given name
+func hasFlag(cmd *cli.Command, name string) bool {
+	for _, flag := range cmd.Flags {
+		for _, flagName := range flag.Names() {
+			if flagName == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withOutputTargets runs action with JSON output enabled when --out or --copy
+// is set, writing what it prints to the file given by --out instead of stdout
+// and putting it on the clipboard for --copy
+func withOutputTargets(action cli.ActionFunc, appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		outPath := c.String("out")
+		if outPath == "" && !c.Bool("copy") {
+			return action(c)
+		}
+		if err := c.Set("json", "true"); err != nil {
+			return err
+		}
+
+		output, err := captureOutput(c, appCtx, action)
+		if err != nil || outPath == "" {
+			// Nothing printed is lost when the command fails
+			if _, writeErr := os.Stdout.Write(output); writeErr != nil && err == nil {
+				return fmt.Errorf("failed to write output: %w", writeErr)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if outPath != "" {
+			if err := os.WriteFile(outPath, output, 0644); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "Wrote %d bytes to %s\n", len(output), outPath)
+		}
+		if c.Bool("copy") {
+			shared.CopyToClipboard(strings.TrimSpace(string(output)), "the output")
+		}
+		return nil
+	}
+}
+
+// captureOutput runs action with the output writer of appCtx and the app
+// writer redirected into a buffer and returns what was written
+func captureOutput(c *cli.Context, appCtx *shared.AppContext, action cli.ActionFunc) ([]byte, error) {
+	var captured bytes.Buffer
+	appWriter, out := c.App.Writer, appCtx.Output
+	c.App.Writer = &captured
+	appCtx.Output = appCtx.Out().Redirect(&captured)
+	defer func() {
+		c.App.Writer, appCtx.Output = appWriter, out
+	}()
+
+	err := action(c)
+	return captured.Bytes(), err
+}
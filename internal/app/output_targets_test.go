@@ -0,0 +1,82 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/clipboard"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestAddOutputTargets(t *testing.T) {
+	var printed bytes.Buffer
+	appCtx := &shared.AppContext{Output: output.NewTextWriter(&printed)}
+	show := func(c *cli.Context) error {
+		if c.Bool("json") {
+			return appCtx.Out().JSON(map[string]bool{"ok": true})
+		}
+		appCtx.Out().Println("ok")
+		return nil
+	}
+	app := &cli.App{
+		Name: "knot",
+		Commands: []*cli.Command{
+			{
+				Name:  "group",
+				Flags: []cli.Flag{shared.NewJSONFlag()},
+				Subcommands: []*cli.Command{
+					{Name: "show", Action: show},
+					{Name: "create", Action: show, Flags: []cli.Flag{shared.NewCopyFlag("Copy the ID")}},
+					{Name: "print", Action: func(c *cli.Context) error {
+						_, err := fmt.Fprintln(c.App.Writer, `["app writer"]`)
+						return err
+					}},
+				},
+			},
+			{Name: "plain", Action: show},
+		},
+	}
+	addOutputTargets(app.Commands, false, appCtx)
+
+	group := app.Commands[0]
+	assert.False(t, hasFlag(group, "out"), "commands without an action are not wrapped")
+	assert.True(t, hasFlag(group.Subcommands[0], "out"), "the json flag is inherited")
+	assert.True(t, hasFlag(group.Subcommands[0], "copy"))
+	assert.False(t, hasFlag(group.Subcommands[1], "out"), "commands copying themselves are left alone")
+	assert.False(t, hasFlag(app.Commands[1], "out"))
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "show.json")
+	require.NoError(t, app.Run([]string{"knot", "group", "show", "--out", outPath}))
+	data, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"ok\": true\n}\n", string(data))
+	assert.Empty(t, printed.String(), "the output writer is restored after the command")
+
+	printPath := filepath.Join(dir, "print.json")
+	require.NoError(t, app.Run([]string{"knot", "group", "print", "--out", printPath}))
+	data, err = os.ReadFile(printPath)
+	require.NoError(t, err)
+	assert.Equal(t, "[\"app writer\"]\n", string(data), "the app writer is captured too")
+
+	require.NoError(t, app.Run([]string{"knot", "group", "show"}))
+	assert.Equal(t, "ok\n", printed.String())
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	clipPath := filepath.Join(dir, "clipboard")
+	t.Setenv(clipboard.EnvCommand, "tee "+clipPath)
+	require.NoError(t, app.Run([]string{"knot", "group", "show", "--copy"}))
+	data, err = os.ReadFile(clipPath)
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"ok\": true\n}", string(data))
+}
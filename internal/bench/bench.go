@@ -0,0 +1,343 @@
+// Package bench measures the performance of representative knot operations on
+// a synthetic project, backing `knot bench`.
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/filter"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/repository"
+	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/denkhaus/knot/v2/internal/selection"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Actor is recorded as the creator and updater of the synthetic project
+const Actor = "knot-bench"
+
+const (
+	// childrenPerRoot is the number of subtasks generated below every root task
+	childrenPerRoot = 9
+	// bulkUpdateSize caps the number of tasks changed by the bulk update
+	bulkUpdateSize = 500
+	// benchFilter is the filter query of the filtered list operation
+	benchFilter = "state:pending,in-progress priority:high complexity:3-8"
+)
+
+// Options configure a benchmark run
+type Options struct {
+	// Tasks is the number of tasks of the synthetic project
+	Tasks int
+	// Runs is the number of times each operation is measured
+	Runs int
+	// Backend is the storage driver, sqlite (a temporary database) or inmemory
+	Backend string
+	// Seed makes the synthetic project reproducible
+	Seed int64
+}
+
+// Operation is the measurement of one operation over all runs
+type Operation struct {
+	Name string `json:"name"`
+	Runs int    `json:"runs"`
+	// Items is the number of tasks the operation returned, scored or changed in
+	// the last run
+	Items int `json:"items"`
+	// Min, Median and Max are the durations of the runs
+	Min    time.Duration `json:"min_ns"`
+	Median time.Duration `json:"median_ns"`
+	Max    time.Duration `json:"max_ns"`
+	// AllocBytes and Allocs are the heap allocations per run
+	AllocBytes uint64 `json:"alloc_bytes"`
+	Allocs     uint64 `json:"allocs"`
+}
+
+// Report is the result of Run
+type Report struct {
+	Backend      string      `json:"backend"`
+	Tasks        int         `json:"tasks"`
+	Dependencies int         `json:"dependencies"`
+	Runs         int         `json:"runs"`
+	GoVersion    string      `json:"go_version"`
+	OS           string      `json:"os"`
+	Arch         string      `json:"arch"`
+	CPUs         int         `json:"cpus"`
+	Operations   []Operation `json:"operations"`
+	// HeapInUse is the heap in use after the last operation
+	HeapInUse uint64        `json:"heap_in_use_bytes"`
+	Total     time.Duration `json:"total_ns"`
+}
+
+// Run generates a synthetic project with opts.Tasks tasks in a fresh
+// repository and measures listing, filtering, actionable task selection,
+// dependency graph building and bulk updates on it. The repository is
+// removed afterwards.
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Tasks < 1 {
+		return nil, fmt.Errorf("number of tasks must be at least 1, got %d", opts.Tasks)
+	}
+	if opts.Runs < 1 {
+		return nil, fmt.Errorf("number of runs must be at least 1, got %d", opts.Runs)
+	}
+
+	repo, cleanup, err := openRepository(opts.Backend)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	config := manager.DefaultConfig()
+	config.MaxTasksPerDepth = opts.Tasks
+	config.MaxTasksPerProject = -1
+	config.AutoReduceComplexity = false
+	pm := manager.NewManagerWithRepository(repo, config)
+
+	report := &Report{
+		Backend:   opts.Backend,
+		Tasks:     opts.Tasks,
+		Runs:      opts.Runs,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+	}
+	started := time.Now()
+
+	var projectID uuid.UUID
+	generate, err := measure("generate project", 1, func() (int, error) {
+		id, dependencies, err := generateProject(ctx, repo, opts.Tasks, rand.New(rand.NewSource(opts.Seed)))
+		projectID = id
+		report.Dependencies = dependencies
+		return opts.Tasks, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.Operations = append(report.Operations, generate)
+
+	query, err := filter.Parse(benchFilter)
+	if err != nil {
+		return nil, err
+	}
+	selectionConfig := selection.ConfigForStrategy(selection.StrategyDependencyAware)
+
+	var pendingIDs []uuid.UUID
+	complexity := 5
+	operations := []struct {
+		name string
+		fn   func() (int, error)
+	}{
+		{"list tasks", func() (int, error) {
+			tasks, err := pm.ListTasksForProject(ctx, projectID)
+			return len(tasks), err
+		}},
+		{"list tasks with filter", func() (int, error) {
+			tasks, err := pm.ListTasksForProject(ctx, projectID)
+			if err != nil {
+				return 0, err
+			}
+			return len(query.Filter(tasks)), nil
+		}},
+		{"list tasks with dependencies", func() (int, error) {
+			tasks, err := pm.ListTasksWithDependencies(ctx, projectID)
+			return len(tasks), err
+		}},
+		{"build dependency graph", func() (int, error) {
+			tasks, err := pm.ListTasksWithDependencies(ctx, projectID)
+			if err != nil {
+				return 0, err
+			}
+			graph, err := selection.NewDependencyAnalyzer(selectionConfig).BuildDependencyGraph(tasks)
+			if err != nil {
+				return 0, err
+			}
+			return graph.TaskCount, nil
+		}},
+		{"select actionable task", func() (int, error) {
+			tasks, err := pm.ListTasksWithDependencies(ctx, projectID)
+			if err != nil {
+				return 0, err
+			}
+			selector, err := selection.NewTaskSelector(selectionConfig.Strategy, selectionConfig)
+			if err != nil {
+				return 0, err
+			}
+			if _, err := selector.SelectNextActionableTask(tasks); err != nil {
+				// A project without actionable tasks is still a valid measurement
+				var selErr *selection.SelectionError
+				if errors.As(err, &selErr) {
+					return 0, nil
+				}
+				return 0, err
+			}
+			return 1 + len(selector.GetLastResult().Alternatives), nil
+		}},
+		{"bulk update", func() (int, error) {
+			if pendingIDs == nil {
+				tasks, err := pm.ListTasksByState(ctx, projectID, types.TaskStatePending)
+				if err != nil {
+					return 0, err
+				}
+				pendingIDs = make([]uuid.UUID, 0, bulkUpdateSize)
+				for _, task := range tasks[:min(bulkUpdateSize, len(tasks))] {
+					pendingIDs = append(pendingIDs, task.ID)
+				}
+			}
+			complexity = 11 - complexity // Alternate between 5 and 6
+			update := complexity
+			err := pm.BulkUpdateTasks(ctx, pendingIDs, types.TaskUpdates{Complexity: &update}, Actor)
+			return len(pendingIDs), err
+		}},
+	}
+
+	for _, op := range operations {
+		result, err := measure(op.name, opts.Runs, op.fn)
+		if err != nil {
+			return nil, err
+		}
+		report.Operations = append(report.Operations, result)
+	}
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	report.HeapInUse = mem.HeapInuse
+	report.Total = time.Since(started)
+	return report, nil
+}
+
+// openRepository opens an empty repository of the backend and returns the
+// function that releases it
+func openRepository(backend string) (types.Repository, func(), error) {
+	opts := repository.Options{Logger: zap.NewNop(), AutoMigrate: true}
+	switch backend {
+	case inmemory.DriverName:
+		repo, err := repository.Open(inmemory.DriverName+"://", opts)
+		return repo, func() {}, err
+	case sqlite.DriverName:
+		dir, err := os.MkdirTemp("", "knot-bench-*")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		repo, err := repository.Open(sqlite.DriverName+"://"+filepath.Join(dir, "bench.db"), opts)
+		if err != nil {
+			os.RemoveAll(dir)
+			return nil, nil, err
+		}
+		return repo, func() {
+			if closer, ok := repo.(io.Closer); ok {
+				closer.Close()
+			}
+			os.RemoveAll(dir)
+		}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown backend '%s', use %s or %s", backend, sqlite.DriverName, inmemory.DriverName)
+	}
+}
+
+// generateProject creates a project of root tasks with childrenPerRoot
+// subtasks each. A third of the tasks depend on an earlier task, which keeps
+// the dependency graph acyclic. Roughly 30% of the tasks are completed and
+// 10% in progress, the others pending, with mixed priorities and complexity.
+func generateProject(ctx context.Context, repo types.Repository, count int, rng *rand.Rand) (uuid.UUID, int, error) {
+	now := time.Now()
+	project := &types.Project{
+		ID:          types.NewID(),
+		Title:       "Benchmark project",
+		Description: fmt.Sprintf("Synthetic project with %d tasks", count),
+		State:       types.ProjectStateActive,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		CreatedBy:   Actor,
+	}
+	if err := repo.CreateProject(ctx, project); err != nil {
+		return uuid.Nil, 0, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	tasks := make([]*types.Task, 0, count)
+	var root *types.Task
+	for i := 0; i < count; i++ {
+		task := &types.Task{
+			ID:          types.NewID(),
+			ProjectID:   project.ID,
+			Title:       fmt.Sprintf("Task %d", i+1),
+			Description: fmt.Sprintf("Synthetic task %d of the benchmark project", i+1),
+			State:       types.TaskStatePending,
+			Priority:    types.TaskPriority(1 + rng.Intn(3)),
+			Complexity:  1 + rng.Intn(10),
+			Position:    i,
+			CreatedAt:   now.Add(time.Duration(i) * time.Millisecond),
+			CreatedBy:   Actor,
+		}
+		task.UpdatedAt = task.CreatedAt
+		if i%(childrenPerRoot+1) == 0 {
+			root = task
+		} else {
+			parentID := root.ID
+			task.ParentID = &parentID
+			task.Depth = 1
+		}
+		switch n := rng.Intn(10); {
+		case n < 3:
+			task.State = types.TaskStateCompleted
+			completedAt := task.CreatedAt
+			task.CompletedAt = &completedAt
+		case n < 4:
+			task.State = types.TaskStateInProgress
+		}
+		if err := repo.CreateTask(ctx, task); err != nil {
+			return uuid.Nil, 0, fmt.Errorf("failed to create task %d: %w", i+1, err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	dependencies := 0
+	for i := 3; i < len(tasks); i += 3 {
+		dependsOn := tasks[rng.Intn(i)]
+		if _, err := repo.AddTaskDependency(ctx, tasks[i].ID, dependsOn.ID); err != nil {
+			return uuid.Nil, 0, fmt.Errorf("failed to add dependency: %w", err)
+		}
+		dependencies++
+	}
+	return project.ID, dependencies, nil
+}
+
+// measure runs fn runs times and records its durations and allocations
+func measure(name string, runs int, fn func() (int, error)) (Operation, error) {
+	op := Operation{Name: name, Runs: runs}
+	durations := make([]time.Duration, 0, runs)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	for i := 0; i < runs; i++ {
+		started := time.Now()
+		items, err := fn()
+		if err != nil {
+			return op, fmt.Errorf("%s failed: %w", name, err)
+		}
+		durations = append(durations, time.Since(started))
+		op.Items = items
+	}
+	runtime.ReadMemStats(&after)
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	op.Min = durations[0]
+	op.Median = durations[len(durations)/2]
+	op.Max = durations[len(durations)-1]
+	op.AllocBytes = (after.TotalAlloc - before.TotalAlloc) / uint64(runs)
+	op.Allocs = (after.Mallocs - before.Mallocs) / uint64(runs)
+	return op, nil
+}
@@ -0,0 +1,42 @@
+package bench
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	for _, backend := range []string{"inmemory", "sqlite"} {
+		t.Run(backend, func(t *testing.T) {
+			report, err := Run(context.Background(), Options{Tasks: 60, Runs: 2, Backend: backend, Seed: 1})
+			require.NoError(t, err)
+
+			assert.Equal(t, 60, report.Tasks)
+			assert.Equal(t, 19, report.Dependencies)
+			names := make([]string, 0, len(report.Operations))
+			for _, op := range report.Operations {
+				names = append(names, op.Name)
+				assert.LessOrEqual(t, op.Min, op.Median)
+				assert.LessOrEqual(t, op.Median, op.Max)
+				assert.Positive(t, op.Allocs)
+			}
+			assert.Equal(t, []string{"generate project", "list tasks", "list tasks with filter", "list tasks with dependencies",
+				"build dependency graph", "select actionable task", "bulk update"}, names)
+			assert.Equal(t, 60, report.Operations[1].Items)
+			assert.Equal(t, 60, report.Operations[3].Items)
+			assert.Less(t, report.Operations[2].Items, 60)
+		})
+	}
+}
+
+func TestRunInvalidOptions(t *testing.T) {
+	_, err := Run(context.Background(), Options{Tasks: 0, Runs: 1, Backend: "inmemory"})
+	assert.ErrorContains(t, err, "number of tasks")
+	_, err = Run(context.Background(), Options{Tasks: 10, Runs: 0, Backend: "inmemory"})
+	assert.ErrorContains(t, err, "number of runs")
+	_, err = Run(context.Background(), Options{Tasks: 10, Runs: 1, Backend: "postgres"})
+	assert.ErrorContains(t, err, "unknown backend")
+}
@@ -0,0 +1,232 @@
+// Package changelog builds release notes from the tasks completed in a
+// project, grouped by the root task of their subtree or by their first tag.
+package changelog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// Ways to group the entries of a changelog
+const (
+	GroupByRoot = "root"
+	GroupByTag  = "tag"
+)
+
+// OtherGroup collects root tasks without subtasks and untagged tasks
+const OtherGroup = "Other"
+
+// fieldPrefix starts the line of a task description that holds the changelog
+// text of the task. Tasks have no custom fields, so the text lives in the
+// description.
+const fieldPrefix = "changelog:"
+
+// Options controls which tasks Build includes and how it groups them
+type Options struct {
+	// Since excludes tasks completed before it, the zero time includes all
+	Since time.Time
+	// SinceLabel names Since in the document, e.g. a snapshot label
+	SinceLabel string
+	// GroupBy is GroupByRoot or GroupByTag
+	GroupBy string
+}
+
+// Entry is one completed task of the changelog
+type Entry struct {
+	TaskID      uuid.UUID  `json:"task_id"`
+	Text        string     `json:"text"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// Group is a section of the changelog
+type Group struct {
+	Title   string  `json:"title"`
+	Entries []Entry `json:"entries"`
+}
+
+// Changelog lists the completed tasks of a project in groups
+type Changelog struct {
+	Project    string     `json:"project"`
+	Since      *time.Time `json:"since,omitempty"`
+	SinceLabel string     `json:"since_label,omitempty"`
+	GroupBy    string     `json:"group_by"`
+	Entries    int        `json:"entries"`
+	Groups     []Group    `json:"groups"`
+}
+
+// ValidateGroupBy checks a --group-by value
+func ValidateGroupBy(groupBy string) error {
+	if groupBy != GroupByRoot && groupBy != GroupByTag {
+		return fmt.Errorf("invalid group-by %q, use root or tag", groupBy)
+	}
+	return nil
+}
+
+// Text returns the changelog text of a task: the rest of a "Changelog:" line
+// in its description, or its title
+func Text(task *types.Task) string {
+	for _, line := range strings.Split(task.Description, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) > len(fieldPrefix) && strings.EqualFold(line[:len(fieldPrefix)], fieldPrefix) {
+			if text := strings.TrimSpace(line[len(fieldPrefix):]); text != "" {
+				return text
+			}
+		}
+	}
+	return task.Title
+}
+
+// Build collects the tasks completed since opts.Since. Grouped by root, each
+// group is a root task with subtasks, in sibling order, and root tasks themselves
+// are only listed when they have no subtasks, in the Other group. Grouped by
+// tag, each task is listed under its first tag, groups sorted by name. Entries
+// are sorted by completion time; Other comes last.
+func Build(project *types.Project, tasks []*types.Task, opts Options) *Changelog {
+	log := &Changelog{
+		Project:    project.Title,
+		SinceLabel: opts.SinceLabel,
+		GroupBy:    opts.GroupBy,
+		Groups:     []Group{},
+	}
+	if !opts.Since.IsZero() {
+		since := opts.Since
+		log.Since = &since
+	}
+
+	byID := make(map[uuid.UUID]*types.Task, len(tasks))
+	hasChildren := make(map[uuid.UUID]bool)
+	for _, task := range tasks {
+		byID[task.ID] = task
+		if task.ParentID != nil {
+			hasChildren[*task.ParentID] = true
+		}
+	}
+
+	groups := make(map[string]*Group)
+	var order []*types.Task // root tasks in sibling order, for GroupByRoot
+	for _, task := range tasks {
+		if task.ParentID == nil && hasChildren[task.ID] {
+			order = append(order, task)
+		}
+	}
+	types.SortSiblings(order)
+
+	for _, task := range tasks {
+		if task.State != types.TaskStateCompleted {
+			continue
+		}
+		if !opts.Since.IsZero() && (task.CompletedAt == nil || task.CompletedAt.Before(opts.Since)) {
+			continue
+		}
+
+		title := OtherGroup
+		switch opts.GroupBy {
+		case GroupByTag:
+			if len(task.Tags) > 0 {
+				title = task.Tags[0]
+			}
+		default:
+			if task.ParentID == nil && hasChildren[task.ID] {
+				continue
+			}
+			if root := rootOf(task, byID); root != task {
+				title = root.ID.String()
+			}
+		}
+
+		group := groups[title]
+		if group == nil {
+			group = &Group{Title: title, Entries: []Entry{}}
+			groups[title] = group
+		}
+		group.Entries = append(group.Entries, Entry{TaskID: task.ID, Text: Text(task), CompletedAt: task.CompletedAt})
+		log.Entries++
+	}
+
+	if opts.GroupBy == GroupByTag {
+		titles := make([]string, 0, len(groups))
+		for title := range groups {
+			if title != OtherGroup {
+				titles = append(titles, title)
+			}
+		}
+		sort.Strings(titles)
+		for _, title := range titles {
+			log.Groups = append(log.Groups, *groups[title])
+		}
+	} else {
+		for _, root := range order {
+			if group := groups[root.ID.String()]; group != nil {
+				group.Title = root.Title
+				log.Groups = append(log.Groups, *group)
+			}
+		}
+	}
+	if other := groups[OtherGroup]; other != nil {
+		log.Groups = append(log.Groups, *other)
+	}
+
+	for _, group := range log.Groups {
+		sortEntries(group.Entries)
+	}
+	return log
+}
+
+// rootOf returns the root ancestor of a task, the task itself for root tasks
+func rootOf(task *types.Task, byID map[uuid.UUID]*types.Task) *types.Task {
+	seen := make(map[uuid.UUID]bool)
+	for task.ParentID != nil && !seen[task.ID] {
+		seen[task.ID] = true
+		parent, ok := byID[*task.ParentID]
+		if !ok {
+			break
+		}
+		task = parent
+	}
+	return task
+}
+
+// sortEntries orders entries by completion time, entries without one first
+func sortEntries(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].CompletedAt, entries[j].CompletedAt
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+		return a.Before(*b)
+	})
+}
+
+// RenderMarkdown writes the changelog as a Markdown document
+func RenderMarkdown(w io.Writer, log *Changelog) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# Changelog: %s\n", log.Project)
+	switch {
+	case log.Since != nil && log.SinceLabel != "":
+		fmt.Fprintf(bw, "\nCompleted since %s (%s).\n", log.SinceLabel, log.Since.Format(time.DateOnly))
+	case log.Since != nil:
+		fmt.Fprintf(bw, "\nCompleted since %s.\n", log.Since.Format(time.DateOnly))
+	}
+	if log.Entries == 0 {
+		fmt.Fprintln(bw, "\nNo completed tasks.")
+	}
+	for _, group := range log.Groups {
+		fmt.Fprintf(bw, "\n## %s\n\n", markdownLine(group.Title))
+		for _, entry := range group.Entries {
+			fmt.Fprintf(bw, "- %s\n", markdownLine(entry.Text))
+		}
+	}
+	return bw.Flush()
+}
+
+// markdownLine keeps text on one line
+func markdownLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,82 @@
+package changelog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func completed(title string, parent *types.Task, day int, tags ...string) *types.Task {
+	at := time.Date(2026, 10, day, 12, 0, 0, 0, time.UTC)
+	task := &types.Task{ID: uuid.New(), Title: title, State: types.TaskStateCompleted, CompletedAt: &at, Tags: tags}
+	if parent != nil {
+		task.ParentID = &parent.ID
+	}
+	return task
+}
+
+func TestBuild(t *testing.T) {
+	project := &types.Project{Title: "Billing"}
+	auth := &types.Task{ID: uuid.New(), Title: "Authentication", State: types.TaskStateInProgress, Position: 2}
+	export := &types.Task{ID: uuid.New(), Title: "Export", State: types.TaskStateCompleted, Position: 1}
+	login := completed("Login form", auth, 12, "feature")
+	login.Description = "Build the form.\nChangelog: Users can sign in with email and password\n"
+	reset := completed("Password reset", auth, 11, "feature")
+	csv := completed("CSV export", export, 13, "feature")
+	nested := completed("Escape quotes", csv, 14, "fix")
+	hotfix := completed("Fix rounding", nil, 13)
+	old := completed("Old work", auth, 1, "feature")
+	open := &types.Task{ID: uuid.New(), Title: "Logout", State: types.TaskStatePending, ParentID: &auth.ID}
+	tasks := []*types.Task{auth, export, login, reset, csv, nested, hotfix, old, open}
+
+	since := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	log := Build(project, tasks, Options{Since: since, SinceLabel: "v1.2.0", GroupBy: GroupByRoot})
+	assert.Equal(t, 5, log.Entries)
+	require.Len(t, log.Groups, 3)
+	assert.Equal(t, "Export", log.Groups[0].Title, "root groups follow the sibling order")
+	assert.Equal(t, []string{"CSV export", "Escape quotes"}, texts(log.Groups[0]))
+	assert.Equal(t, "Authentication", log.Groups[1].Title)
+	assert.Equal(t, []string{"Password reset", "Users can sign in with email and password"}, texts(log.Groups[1]))
+	assert.Equal(t, OtherGroup, log.Groups[2].Title)
+	assert.Equal(t, []string{"Fix rounding"}, texts(log.Groups[2]))
+
+	byTag := Build(project, tasks, Options{GroupBy: GroupByTag})
+	assert.Equal(t, 7, byTag.Entries, "without --since all completed tasks are included")
+	require.Len(t, byTag.Groups, 3)
+	assert.Equal(t, "feature", byTag.Groups[0].Title)
+	assert.Equal(t, "fix", byTag.Groups[1].Title)
+	assert.Equal(t, OtherGroup, byTag.Groups[2].Title)
+	assert.Equal(t, []string{"Export", "Fix rounding"}, texts(byTag.Groups[2]))
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	since := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	log := Build(&types.Project{Title: "Billing"}, []*types.Task{completed("Fix  rounding\nof totals", nil, 12)},
+		Options{Since: since, SinceLabel: "v1.2.0", GroupBy: GroupByRoot})
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderMarkdown(&buf, log))
+	assert.Equal(t, "# Changelog: Billing\n\nCompleted since v1.2.0 (2026-10-10).\n\n## Other\n\n- Fix rounding of totals\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, RenderMarkdown(&buf, Build(&types.Project{Title: "Billing"}, nil, Options{GroupBy: GroupByTag})))
+	assert.Equal(t, "# Changelog: Billing\n\nNo completed tasks.\n", buf.String())
+}
+
+func TestText(t *testing.T) {
+	assert.Equal(t, "Title", Text(&types.Task{Title: "Title", Description: "changelog:\nno text"}))
+	assert.Equal(t, "Faster search", Text(&types.Task{Title: "Title", Description: "  CHANGELOG:  Faster search "}))
+}
+
+func texts(group Group) []string {
+	result := make([]string, len(group.Entries))
+	for i, entry := range group.Entries {
+		result[i] = entry.Text
+	}
+	return result
+}
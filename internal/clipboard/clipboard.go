@@ -0,0 +1,60 @@
+// Package clipboard puts text on the system clipboard with the clipboard tool
+// of the platform: pbcopy on macOS, clip on Windows and wl-copy, xclip or xsel
+// elsewhere. KNOT_CLIPBOARD_CMD overrides the tool, e.g. "tmux load-buffer -".
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// EnvCommand names the environment variable overriding the clipboard tool
+const EnvCommand = "KNOT_CLIPBOARD_CMD"
+
+// ErrUnavailable is returned by Copy when no clipboard tool is installed
+var ErrUnavailable = errors.New("no clipboard tool found, install wl-clipboard, xclip or xsel or set " + EnvCommand)
+
+// commands returns the clipboard tools to try in order, each as its name
+// followed by its arguments
+func commands() [][]string {
+	if custom := strings.Fields(os.Getenv(EnvCommand)); len(custom) > 0 {
+		return [][]string{custom}
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip"}}
+	}
+	tools := [][]string{
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		tools = append([][]string{{"wl-copy"}}, tools...)
+	}
+	return tools
+}
+
+// Copy puts text on the system clipboard using the first installed tool
+func Copy(text string) error {
+	for _, command := range commands() {
+		path, err := exec.LookPath(command[0])
+		if err != nil {
+			continue
+		}
+		// The output is not captured: xclip keeps serving the clipboard in a
+		// background process that would hold the pipes open
+		cmd := exec.Command(path, command[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to copy to the clipboard with %s: %w", command[0], err)
+		}
+		return nil
+	}
+	return ErrUnavailable
+}
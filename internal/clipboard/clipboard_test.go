@@ -0,0 +1,27 @@
+package clipboard
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopy(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test tool needs tee")
+	}
+	path := filepath.Join(t.TempDir(), "clipboard")
+	t.Setenv(EnvCommand, "tee "+path)
+
+	require.NoError(t, Copy("0194b6a2-5c1e-7d3f-9a8b-1c2d3e4f5a6b"))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "0194b6a2-5c1e-7d3f-9a8b-1c2d3e4f5a6b", string(data))
+
+	t.Setenv(EnvCommand, "knot-test-no-such-clipboard-tool")
+	assert.ErrorIs(t, Copy("text"), ErrUnavailable)
+}
@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// Commands returns the agent subcommands
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	commands := []*cli.Command{
+		{
+			Name:  "context",
+			Usage: "Print the session context: project, next task, blockers and recent changes",
+			Description: `Prints the state an agent needs to continue working in one compact
+document: the selected project with its progress, the workflow phase with a
+suggested next command, the next actionable task, tasks in progress, blocked
+tasks with what they wait on and the latest changes from the change feed.
+
+--budget sets an approximate token limit; detail is trimmed step by step
+(descriptions, list lengths, recent changes) until the output fits.
+Pass the returned last_seq to --since on the next call to only see new changes.
+
+Examples:
+  knot agent context
+  knot agent context --json --budget 500
+  knot agent context --since 42`,
+			Action: contextAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewJSONFlag(),
+				&cli.IntFlag{
+					Name:  "budget",
+					Usage: "Approximate maximum output size in tokens (0 = unlimited)",
+				},
+				&cli.IntFlag{
+					Name:  "changes",
+					Usage: "Maximum number of recent changes to include",
+					Value: 10,
+				},
+				&cli.Int64Flag{
+					Name:  "since",
+					Usage: "Only include changes with a sequence number greater than this",
+				},
+			},
+		},
+	}
+	return append(commands, registryCommands(appCtx)...)
+}
+
+func contextAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		budget := c.Int("budget")
+		if budget < 0 {
+			return errors.NewValidationError("invalid budget",
+				fmt.Errorf("--budget must not be negative, got %d", budget))
+		}
+
+		appCtx.Logger.Info("Building agent session context",
+			zap.String("projectID", projectID.String()),
+			zap.Int("budget", budget))
+
+		sc, err := BuildSessionContext(c.Context, appCtx.ProjectManager, projectID, Options{
+			Changes:  c.Int("changes"),
+			SinceSeq: c.Int64("since"),
+		})
+		if err != nil {
+			return err
+		}
+
+		render := RenderMarkdown
+		if c.Bool("json") {
+			render = RenderJSON
+		}
+		return RenderWithinBudget(appCtx.Out(), sc, budget, render)
+	}
+}
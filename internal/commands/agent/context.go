@@ -0,0 +1,432 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+)
+
+// Phase describes where the session stands in the get-started workflow
+type Phase string
+
+const (
+	// PhaseEmpty means the project has no tasks yet
+	PhaseEmpty Phase = "empty"
+	// PhaseWorking means a task is in progress
+	PhaseWorking Phase = "working"
+	// PhaseReady means a pending task can be started
+	PhaseReady Phase = "ready"
+	// PhaseBlocked means open tasks exist but none can be worked on
+	PhaseBlocked Phase = "blocked"
+	// PhaseDone means all tasks are completed or cancelled
+	PhaseDone Phase = "done"
+)
+
+// charsPerToken is the rough ratio used to estimate the token size of the output
+const charsPerToken = 4
+
+// Options controls what the session context contains
+type Options struct {
+	// Changes is the maximum number of recent changes to include
+	Changes int
+	// SinceSeq only includes changes after this change feed sequence number
+	SinceSeq int64
+}
+
+// SessionContext is the compact project state handed to an agent
+type SessionContext struct {
+	Project       ProjectSummary  `json:"project"`
+	Phase         Phase           `json:"phase"`
+	Hint          string          `json:"hint"`
+	NextTask      *TaskSummary    `json:"next_task,omitempty"`
+	InProgress    []TaskSummary   `json:"in_progress,omitempty"`
+	BlockedCount  int             `json:"blocked_count"`
+	Blocked       []BlockedTask   `json:"blocked,omitempty"`
+	RecentChanges []ChangeSummary `json:"recent_changes,omitempty"`
+	LastSeq       int64           `json:"last_seq,omitempty"`
+	// Instructions are the standing instructions of the project and, from the
+	// parent chain down, of the next task; they are never trimmed
+	Instructions []types.Instruction `json:"instructions,omitempty"`
+	// DetailLevel is 0 for the full context and increases with every trimming step
+	DetailLevel int  `json:"detail_level"`
+	Truncated   bool `json:"truncated,omitempty"`
+}
+
+// ProjectSummary holds the project identity and progress counters
+type ProjectSummary struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	Total      int       `json:"total"`
+	Completed  int       `json:"completed"`
+	InProgress int       `json:"in_progress"`
+	Pending    int       `json:"pending"`
+	Progress   float64   `json:"progress"`
+}
+
+// TaskSummary is the compact form of a task
+type TaskSummary struct {
+	ID          uuid.UUID       `json:"id"`
+	Title       string          `json:"title"`
+	State       types.TaskState `json:"state"`
+	Priority    string          `json:"priority,omitempty"`
+	Complexity  int             `json:"complexity,omitempty"`
+	Description string          `json:"description,omitempty"`
+}
+
+// BlockedTask is an open task together with the tasks it waits on
+type BlockedTask struct {
+	TaskSummary
+	WaitingOn []TaskSummary `json:"waiting_on,omitempty"`
+}
+
+// ChangeSummary is the compact form of a change feed event
+type ChangeSummary struct {
+	Seq    int64                 `json:"seq"`
+	Kind   types.ChangeEventKind `json:"kind"`
+	Title  string                `json:"title,omitempty"`
+	State  types.TaskState       `json:"state,omitempty"`
+	Actor  string                `json:"actor,omitempty"`
+	At     time.Time             `json:"at"`
+	TaskID *uuid.UUID            `json:"task_id,omitempty"`
+}
+
+// BuildSessionContext collects the session context of a project
+func BuildSessionContext(ctx context.Context, pm manager.ProjectManager, projectID uuid.UUID, opts Options) (*SessionContext, error) {
+	project, err := pm.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	tasks, err := pm.ListTasksWithDependencies(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	sc := &SessionContext{
+		Project: ProjectSummary{ID: project.ID, Title: project.Title},
+	}
+
+	taskMap := make(map[uuid.UUID]*types.Task, len(tasks))
+	for _, task := range tasks {
+		taskMap[task.ID] = task
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+	})
+
+	open := 0
+	for _, task := range tasks {
+		sc.Project.Total++
+		switch task.State {
+		case types.TaskStateCompleted:
+			sc.Project.Completed++
+			continue
+		case types.TaskStateCancelled, types.TaskStateDeletionPending:
+			continue
+		case types.TaskStateInProgress:
+			sc.Project.InProgress++
+			sc.InProgress = append(sc.InProgress, summarizeTask(task))
+		case types.TaskStatePending:
+			sc.Project.Pending++
+		}
+		open++
+
+		if task.State == types.TaskStateBlocked || !utils.IsTaskReady(task, taskMap) {
+			blocked := BlockedTask{TaskSummary: summarizeTask(task)}
+			for _, depID := range task.Dependencies {
+				dep, exists := taskMap[depID]
+				if !exists {
+					blocked.WaitingOn = append(blocked.WaitingOn, TaskSummary{ID: depID, Title: "unknown task"})
+					continue
+				}
+				if dep.State != types.TaskStateCompleted {
+					blocked.WaitingOn = append(blocked.WaitingOn, TaskSummary{ID: dep.ID, Title: dep.Title, State: dep.State})
+				}
+			}
+			sc.Blocked = append(sc.Blocked, blocked)
+		}
+	}
+	sc.BlockedCount = len(sc.Blocked)
+	if sc.Project.Total > 0 {
+		sc.Project.Progress = float64(sc.Project.Completed) / float64(sc.Project.Total) * 100
+	}
+
+	next, err := pm.FindNextActionableTask(ctx, projectID)
+	if err != nil {
+		next = nil
+	}
+	var ancestors []*types.Task
+	if next != nil {
+		summary := summarizeTask(next)
+		sc.NextTask = &summary
+		if ancestors, err = pm.GetAncestors(ctx, next.ID); err != nil {
+			return nil, fmt.Errorf("failed to get ancestors: %w", err)
+		}
+	}
+	sc.Instructions = types.InheritedInstructions(project, ancestors, next)
+
+	sc.Phase, sc.Hint = derivePhase(sc, open)
+
+	if opts.Changes <= 0 {
+		return sc, nil
+	}
+
+	// The change feed is optional; backends without one simply omit recent changes
+	events, err := pm.ListChangeEvents(ctx, types.ChangeEventFilter{
+		ProjectID:  &projectID,
+		AfterSeq:   opts.SinceSeq,
+		Limit:      opts.Changes,
+		Descending: true,
+	})
+	if err == nil {
+		for i := len(events) - 1; i >= 0; i-- {
+			sc.RecentChanges = append(sc.RecentChanges, summarizeEvent(events[i]))
+		}
+		if len(events) > 0 {
+			sc.LastSeq = events[0].Seq
+		}
+	}
+
+	return sc, nil
+}
+
+// derivePhase determines the workflow phase and the suggested next step
+func derivePhase(sc *SessionContext, open int) (Phase, string) {
+	switch {
+	case sc.Project.Total == 0:
+		return PhaseEmpty, `Create tasks: knot task create --title "..." --complexity 5`
+	case sc.NextTask != nil && sc.NextTask.State == types.TaskStateInProgress:
+		return PhaseWorking, fmt.Sprintf("Continue the next task, then: knot task update-state --id %s --state completed", sc.NextTask.ID)
+	case sc.NextTask != nil:
+		return PhaseReady, fmt.Sprintf("Start the next task: knot task update-state --id %s --state in-progress", sc.NextTask.ID)
+	case open > 0:
+		return PhaseBlocked, "No task can be started: run knot blocked or knot analyze deadlock"
+	default:
+		return PhaseDone, "All tasks are completed or cancelled"
+	}
+}
+
+func summarizeTask(task *types.Task) TaskSummary {
+	return TaskSummary{
+		ID:          task.ID,
+		Title:       task.Title,
+		State:       task.State,
+		Priority:    task.Priority.ToExternalString(),
+		Complexity:  task.Complexity,
+		Description: task.Description,
+	}
+}
+
+func summarizeEvent(event *types.ChangeEvent) ChangeSummary {
+	summary := ChangeSummary{
+		Seq:    event.Seq,
+		Kind:   event.Kind,
+		Actor:  event.Actor,
+		At:     event.CreatedAt.UTC().Truncate(time.Second),
+		TaskID: event.TaskID,
+	}
+	if len(event.Data) > 0 {
+		var snapshot struct {
+			Title string          `json:"title"`
+			State types.TaskState `json:"state"`
+		}
+		if json.Unmarshal(event.Data, &snapshot) == nil {
+			summary.Title = snapshot.Title
+			if event.TaskID != nil {
+				summary.State = snapshot.State
+			}
+		}
+	}
+	return summary
+}
+
+// maxDetailLevel is the most aggressive trimming step
+const maxDetailLevel = 4
+
+// Trimmed returns a copy of the context reduced to the given detail level:
+//
+//	1: descriptions are shortened and only the latest 5 changes are kept
+//	2: task lists drop descriptions and are capped at 5, 3 changes are kept
+//	3: task lists and recent changes are dropped (counts remain)
+//	4: the next task is reduced to its title and state
+func (sc *SessionContext) Trimmed(level int) *SessionContext {
+	trimmed := *sc
+	trimmed.DetailLevel = level
+	if level == 0 {
+		return &trimmed
+	}
+
+	descriptionLimit, listLimit, changeLimit := 280, -1, 5
+	switch {
+	case level >= 3:
+		descriptionLimit, listLimit, changeLimit = 0, 0, 0
+	case level == 2:
+		descriptionLimit, listLimit, changeLimit = 120, 5, 3
+	}
+
+	if sc.NextTask != nil {
+		next := *sc.NextTask
+		next.Description = utils.Truncate(next.Description, descriptionLimit)
+		if level >= maxDetailLevel {
+			next = TaskSummary{ID: next.ID, Title: next.Title, State: next.State}
+		}
+		trimmed.NextTask = &next
+	}
+
+	trimmed.InProgress = trimTasks(sc.InProgress, listLimit, level)
+	trimmed.Blocked = nil
+	for i, blocked := range sc.Blocked {
+		if listLimit >= 0 && i >= listLimit {
+			break
+		}
+		blocked.TaskSummary = trimTasks([]TaskSummary{blocked.TaskSummary}, 1, level)[0]
+		trimmed.Blocked = append(trimmed.Blocked, blocked)
+	}
+
+	if len(sc.RecentChanges) > changeLimit {
+		trimmed.RecentChanges = sc.RecentChanges[len(sc.RecentChanges)-changeLimit:]
+	}
+	if changeLimit == 0 {
+		trimmed.RecentChanges = nil
+	}
+
+	trimmed.Truncated = true
+	return &trimmed
+}
+
+func trimTasks(tasks []TaskSummary, limit, level int) []TaskSummary {
+	if len(tasks) == 0 || limit == 0 {
+		return nil
+	}
+	if limit > 0 && len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+	result := make([]TaskSummary, len(tasks))
+	for i, task := range tasks {
+		if level >= 2 {
+			task.Description = ""
+		} else {
+			task.Description = utils.Truncate(task.Description, 280)
+		}
+		result[i] = task
+	}
+	return result
+}
+
+// RenderFunc renders the session context
+type RenderFunc func(w io.Writer, sc *SessionContext) error
+
+// RenderWithinBudget renders the context at the highest detail level whose
+// estimated size fits the token budget. A budget of 0 disables trimming.
+func RenderWithinBudget(w io.Writer, sc *SessionContext, budget int, render RenderFunc) error {
+	var buf strings.Builder
+	for level := 0; level <= maxDetailLevel; level++ {
+		buf.Reset()
+		if err := render(&buf, sc.Trimmed(level)); err != nil {
+			return err
+		}
+		if budget <= 0 || EstimateTokens(buf.String()) <= budget {
+			break
+		}
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// EstimateTokens returns a rough token count for text
+func EstimateTokens(text string) int {
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+// RenderJSON writes the context as compact single-line JSON
+func RenderJSON(w io.Writer, sc *SessionContext) error {
+	return json.NewEncoder(w).Encode(sc)
+}
+
+// RenderMarkdown writes the context as a compact Markdown document
+func RenderMarkdown(w io.Writer, sc *SessionContext) error {
+	var b strings.Builder
+
+	p := sc.Project
+	fmt.Fprintf(&b, "# %s (%s)\n", p.Title, p.ID)
+	fmt.Fprintf(&b, "Progress: %d/%d completed (%.0f%%), %d in progress, %d pending, %d blocked\n",
+		p.Completed, p.Total, p.Progress, p.InProgress, p.Pending, sc.BlockedCount)
+	fmt.Fprintf(&b, "Phase: %s. %s\n", sc.Phase, sc.Hint)
+
+	if len(sc.Instructions) > 0 {
+		b.WriteString("\n## Instructions\n")
+		for _, instruction := range sc.Instructions {
+			fmt.Fprintf(&b, "From %s '%s':\n%s\n", instruction.Scope, instruction.Title, strings.TrimRight(instruction.Text, "\n"))
+		}
+	}
+
+	if sc.NextTask != nil {
+		b.WriteString("\n## Next task\n")
+		writeTask(&b, *sc.NextTask)
+	}
+
+	if len(sc.InProgress) > 0 {
+		b.WriteString("\n## In progress\n")
+		for _, task := range sc.InProgress {
+			writeTask(&b, task)
+		}
+	}
+
+	if len(sc.Blocked) > 0 {
+		fmt.Fprintf(&b, "\n## Blocked (%d)\n", sc.BlockedCount)
+		for _, blocked := range sc.Blocked {
+			writeTask(&b, blocked.TaskSummary)
+			for _, dep := range blocked.WaitingOn {
+				fmt.Fprintf(&b, "  waits on: %s (%s) [%s]\n", dep.Title, dep.ID, dep.State)
+			}
+		}
+	}
+
+	if len(sc.RecentChanges) > 0 {
+		b.WriteString("\n## Recent changes\n")
+		for _, change := range sc.RecentChanges {
+			fmt.Fprintf(&b, "- #%d %s", change.Seq, change.Kind)
+			if change.Title != "" {
+				fmt.Fprintf(&b, " %q", change.Title)
+			}
+			if change.State != "" {
+				fmt.Fprintf(&b, " [%s]", change.State)
+			}
+			if change.Actor != "" {
+				fmt.Fprintf(&b, " by %s", change.Actor)
+			}
+			fmt.Fprintf(&b, " at %s\n", change.At.Format(time.RFC3339))
+		}
+	}
+
+	if sc.Truncated {
+		fmt.Fprintf(&b, "\n(trimmed to detail level %d to fit the budget)\n", sc.DetailLevel)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeTask(b *strings.Builder, task TaskSummary) {
+	fmt.Fprintf(b, "- %s (%s) [%s", task.Title, task.ID, task.State)
+	if task.Priority != "" {
+		fmt.Fprintf(b, ", %s", task.Priority)
+	}
+	if task.Complexity > 0 {
+		fmt.Fprintf(b, ", complexity %d", task.Complexity)
+	}
+	b.WriteString("]\n")
+	if task.Description != "" {
+		fmt.Fprintf(b, "  %s\n", strings.ReplaceAll(task.Description, "\n", "\n  "))
+	}
+}
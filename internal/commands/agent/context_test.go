@@ -0,0 +1,212 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/testutil"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+func TestBuildSessionContext(t *testing.T) {
+	ctx := context.Background()
+	pm := manager.NewManagerWithRepository(inmemory.NewMemoryRepository(), manager.DefaultConfig())
+
+	project, err := pm.CreateProject(ctx, "Agent Project", "", "test-user")
+	require.NoError(t, err)
+
+	sc, err := BuildSessionContext(ctx, pm, project.ID, Options{Changes: 10})
+	require.NoError(t, err)
+	assert.Equal(t, PhaseEmpty, sc.Phase)
+	assert.Nil(t, sc.NextTask)
+
+	design, err := pm.CreateTask(ctx, project.ID, nil, "Design", "Write the design document", 3, types.TaskPriorityHigh, "test-user")
+	require.NoError(t, err)
+	build, err := pm.CreateTask(ctx, project.ID, nil, "Build", "", 5, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = pm.AddTaskDependency(ctx, build.ID, design.ID, "test-user")
+	require.NoError(t, err)
+
+	sc, err = BuildSessionContext(ctx, pm, project.ID, Options{Changes: 10})
+	require.NoError(t, err)
+	assert.Equal(t, PhaseReady, sc.Phase)
+	require.NotNil(t, sc.NextTask)
+	assert.Equal(t, design.ID, sc.NextTask.ID)
+	assert.Contains(t, sc.Hint, design.ID.String())
+	require.Len(t, sc.Blocked, 1)
+	assert.Equal(t, build.ID, sc.Blocked[0].ID)
+	require.Len(t, sc.Blocked[0].WaitingOn, 1)
+	assert.Equal(t, "Design", sc.Blocked[0].WaitingOn[0].Title)
+	require.Len(t, sc.RecentChanges, 4)
+	assert.Equal(t, types.ChangeProjectCreated, sc.RecentChanges[0].Kind)
+	assert.Equal(t, types.ChangeDependencyAdded, sc.RecentChanges[3].Kind)
+	assert.Equal(t, sc.RecentChanges[3].Seq, sc.LastSeq)
+
+	_, err = pm.UpdateTaskState(ctx, design.ID, types.TaskStateInProgress, "agent")
+	require.NoError(t, err)
+
+	sc, err = BuildSessionContext(ctx, pm, project.ID, Options{Changes: 10, SinceSeq: sc.LastSeq})
+	require.NoError(t, err)
+	assert.Equal(t, PhaseWorking, sc.Phase)
+	require.Len(t, sc.RecentChanges, 1)
+	assert.Equal(t, "Design", sc.RecentChanges[0].Title)
+	assert.Equal(t, types.TaskStateInProgress, sc.RecentChanges[0].State)
+	assert.Equal(t, "agent", sc.RecentChanges[0].Actor)
+
+	for _, step := range []struct {
+		task  *types.Task
+		state types.TaskState
+	}{
+		{design, types.TaskStateCompleted},
+		{build, types.TaskStateInProgress},
+		{build, types.TaskStateCompleted},
+	} {
+		_, err = pm.UpdateTaskState(ctx, step.task.ID, step.state, "agent")
+		require.NoError(t, err)
+	}
+
+	sc, err = BuildSessionContext(ctx, pm, project.ID, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, PhaseDone, sc.Phase)
+	assert.Equal(t, 2, sc.Project.Completed)
+	assert.Empty(t, sc.RecentChanges)
+}
+
+// TestBuildSessionContextSQLite tests the blocked summary against SQLite,
+// which only loads task dependencies when asked to
+func TestBuildSessionContextSQLite(t *testing.T) {
+	ctx := context.Background()
+	pm := testutil.NewTestConfig(t).WithSQLiteDB().SetupTestManager(t)
+	project := testutil.CreateTestProject(t, pm)
+
+	design, err := pm.CreateTask(ctx, project.ID, nil, "Design", "", 3, types.TaskPriorityHigh, "test-user")
+	require.NoError(t, err)
+	build, err := pm.CreateTask(ctx, project.ID, nil, "Build", "", 5, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = pm.AddTaskDependency(ctx, build.ID, design.ID, "test-user")
+	require.NoError(t, err)
+
+	sc, err := BuildSessionContext(ctx, pm, project.ID, Options{})
+	require.NoError(t, err)
+	require.NotNil(t, sc.NextTask)
+	assert.Equal(t, design.ID, sc.NextTask.ID)
+	require.Len(t, sc.Blocked, 1)
+	assert.Equal(t, build.ID, sc.Blocked[0].ID)
+	require.Len(t, sc.Blocked[0].WaitingOn, 1)
+	assert.Equal(t, "Design", sc.Blocked[0].WaitingOn[0].Title)
+}
+
+func TestSessionContextInstructions(t *testing.T) {
+	ctx := context.Background()
+	pm := manager.NewManagerWithRepository(inmemory.NewMemoryRepository(), manager.DefaultConfig())
+
+	project, err := pm.CreateProject(ctx, "Agent Project", "", "test-user")
+	require.NoError(t, err)
+	_, err = pm.UpdateProjectInstructions(ctx, project.ID, "Always write tests", "test-user")
+	require.NoError(t, err)
+
+	sc, err := BuildSessionContext(ctx, pm, project.ID, Options{})
+	require.NoError(t, err)
+	require.Len(t, sc.Instructions, 1)
+	assert.Equal(t, types.InstructionScopeProject, sc.Instructions[0].Scope)
+
+	epic, err := pm.CreateTask(ctx, project.ID, nil, "Backend", "", 5, types.TaskPriorityHigh, "test-user")
+	require.NoError(t, err)
+	_, err = pm.UpdateTaskInstructions(ctx, epic.ID, "Use library X", "test-user")
+	require.NoError(t, err)
+	_, err = pm.CreateTask(ctx, project.ID, &epic.ID, "Endpoint", "", 3, types.TaskPriorityHigh, "test-user")
+	require.NoError(t, err)
+
+	sc, err = BuildSessionContext(ctx, pm, project.ID, Options{})
+	require.NoError(t, err)
+	require.NotNil(t, sc.NextTask)
+	require.Len(t, sc.Instructions, 2)
+	assert.Equal(t, "Use library X", sc.Instructions[1].Text)
+
+	// Instructions survive the most aggressive trimming
+	var out strings.Builder
+	require.NoError(t, RenderMarkdown(&out, sc.Trimmed(maxDetailLevel)))
+	assert.Contains(t, out.String(), "## Instructions\nFrom project 'Agent Project':\nAlways write tests\nFrom task 'Backend':\nUse library X\n")
+}
+
+func TestRenderWithinBudget(t *testing.T) {
+	sc := &SessionContext{
+		Project:  ProjectSummary{Title: "Budget"},
+		Phase:    PhaseReady,
+		Hint:     "Start the next task",
+		NextTask: &TaskSummary{Title: "Next", State: types.TaskStatePending, Description: strings.Repeat("long description ", 40)},
+	}
+	for i := 0; i < 20; i++ {
+		sc.Blocked = append(sc.Blocked, BlockedTask{TaskSummary: TaskSummary{Title: "Blocked task", Description: strings.Repeat("details ", 20)}})
+		sc.RecentChanges = append(sc.RecentChanges, ChangeSummary{Seq: int64(i + 1), Kind: types.ChangeTaskUpdated, Title: "Some task"})
+	}
+	sc.BlockedCount = len(sc.Blocked)
+
+	var full bytes.Buffer
+	require.NoError(t, RenderWithinBudget(&full, sc, 0, RenderMarkdown))
+	assert.NotContains(t, full.String(), "trimmed")
+
+	var trimmed bytes.Buffer
+	require.NoError(t, RenderWithinBudget(&trimmed, sc, 200, RenderMarkdown))
+	assert.LessOrEqual(t, EstimateTokens(trimmed.String()), 200)
+	assert.Contains(t, trimmed.String(), "Next")
+	assert.Contains(t, trimmed.String(), "trimmed to detail level")
+
+	var compact bytes.Buffer
+	require.NoError(t, RenderWithinBudget(&compact, sc, 1, RenderJSON))
+	var decoded SessionContext
+	require.NoError(t, json.Unmarshal(compact.Bytes(), &decoded))
+	assert.Equal(t, maxDetailLevel, decoded.DetailLevel)
+	assert.True(t, decoded.Truncated)
+	assert.Empty(t, decoded.Blocked)
+	assert.Equal(t, 20, decoded.BlockedCount)
+	require.NotNil(t, decoded.NextTask)
+	assert.Empty(t, decoded.NextTask.Description)
+
+	// Trimming must not modify the original context
+	assert.Len(t, sc.Blocked, 20)
+	assert.NotEmpty(t, sc.NextTask.Description)
+}
+
+func TestContextAction(t *testing.T) {
+	ctx := context.Background()
+	pm := manager.NewManagerWithRepository(inmemory.NewMemoryRepository(), manager.DefaultConfig())
+	project, err := pm.CreateProject(ctx, "Action Project", "", "test-user")
+	require.NoError(t, err)
+	require.NoError(t, pm.SetSelectedProject(ctx, project.ID, "test-user"))
+	_, err = pm.CreateTask(ctx, project.ID, nil, "First", "", 2, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	app := &cli.App{}
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.Bool("json", true, "")
+	flagSet.Int("budget", 0, "")
+	flagSet.Int("changes", 10, "")
+	flagSet.Int64("since", 0, "")
+
+	appCtx := &shared.AppContext{ProjectManager: pm, Logger: zap.NewNop(), Output: output.NewTextWriter(&out)}
+	require.NoError(t, contextAction(appCtx)(cli.NewContext(app, flagSet, nil)))
+
+	var decoded SessionContext
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "Action Project", decoded.Project.Title)
+	assert.Equal(t, PhaseReady, decoded.Phase)
+	require.NotNil(t, decoded.NextTask)
+	assert.Equal(t, "First", decoded.NextTask.Title)
+
+	require.NoError(t, flagSet.Set("budget", "-1"))
+	assert.Error(t, contextAction(appCtx)(cli.NewContext(app, flagSet, nil)))
+}
@@ -0,0 +1,188 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// registryCommands returns the commands that manage the agent registry
+func registryCommands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "register",
+			Usage: "Register an agent with its selection preferences",
+			Description: `Registers an agent for 'knot task claim', or updates a registered agent
+with the same name. Claimed tasks are assigned to the agent's ID, generated
+unless given. With --require-tag the agent only claims tasks with one of the
+tags; with --prefer-tag tasks with more of the tags are claimed first.
+Strategy weights can be set in the Agents list of .knot/config.json.
+
+Examples:
+  knot agent register --name backend-bot --require-tag backend
+  knot agent register --name docs-bot --strategy shortest-job-first --prefer-tag docs`,
+			Action: registerAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "name",
+					Aliases:  []string{"n"},
+					Usage:    "Agent name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "id",
+					Usage: "Agent ID claimed tasks are assigned to (default: kept, or generated for new agents)",
+				},
+				&cli.StringFlag{
+					Name:  "strategy",
+					Usage: "Selection strategy of the agent (default: configured or auto-recommended)",
+				},
+				&cli.StringSliceFlag{
+					Name:  "require-tag",
+					Usage: "Only claim tasks with at least one of these tags (repeatable)",
+				},
+				&cli.StringSliceFlag{
+					Name:  "prefer-tag",
+					Usage: "Claim tasks with these tags first (repeatable)",
+				},
+			},
+		},
+		{
+			Name:   "list",
+			Usage:  "List the registered agents",
+			Action: listAction(appCtx),
+			Flags:  []cli.Flag{shared.NewJSONFlag()},
+		},
+		{
+			Name:   "remove",
+			Usage:  "Remove an agent from the registry",
+			Action: removeAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "name",
+					Aliases:  []string{"n"},
+					Usage:    "Agent name",
+					Required: true,
+				},
+			},
+		},
+	}
+}
+
+func registerAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		name := strings.TrimSpace(c.String("name"))
+		if name == "" {
+			return errors.NewValidationError("invalid agent name", fmt.Errorf("--name cannot be empty"))
+		}
+
+		newConfig := *appCtx.ProjectManager.GetConfig()
+		profile := manager.AgentProfile{Name: name, ID: uuid.New()}
+		if existing, ok := newConfig.Agent(name); ok && existing.Name == name {
+			profile = *existing
+		}
+		if c.IsSet("id") {
+			agentID, err := uuid.Parse(c.String("id"))
+			if err != nil {
+				return errors.InvalidUUIDError("id", c.String("id"))
+			}
+			profile.ID = agentID
+		}
+		if c.IsSet("strategy") {
+			profile.Strategy = c.String("strategy")
+		}
+		if c.IsSet("require-tag") {
+			profile.RequiredTags = c.StringSlice("require-tag")
+		}
+		if c.IsSet("prefer-tag") {
+			profile.PreferredTags = c.StringSlice("prefer-tag")
+		}
+
+		newConfig.SetAgent(profile)
+		if err := manager.ValidateAgents(newConfig.Agents); err != nil {
+			return errors.NewValidationError("invalid agent", err)
+		}
+		appCtx.ProjectManager.UpdateConfig(&newConfig)
+		if err := appCtx.ProjectManager.SaveConfigToFile(); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+
+		appCtx.Logger.Info("Registered agent", zap.String("name", name), zap.String("agentID", profile.ID.String()))
+		appCtx.Out().Printf("Registered agent %s (ID: %s)\n", profile.Name, profile.ID)
+		writeProfileDetails(c, &profile)
+		return nil
+	}
+}
+
+func listAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		agents := appCtx.ProjectManager.GetConfig().Agents
+		if c.Bool("json") {
+			if agents == nil {
+				agents = []manager.AgentProfile{}
+			}
+			jsonData, err := json.MarshalIndent(agents, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal agents to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		if len(agents) == 0 {
+			appCtx.Out().Println("No agents registered. Register one with: knot agent register --name <name>")
+			return nil
+		}
+		appCtx.Out().Printf("Registered agents (%d):\n", len(agents))
+		for i := range agents {
+			appCtx.Out().Printf("\n%s (ID: %s)\n", agents[i].Name, agents[i].ID)
+			writeProfileDetails(c, &agents[i])
+		}
+		return nil
+	}
+}
+
+func removeAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		name := c.String("name")
+		newConfig := *appCtx.ProjectManager.GetConfig()
+		if !newConfig.RemoveAgent(name) {
+			return errors.NewValidationError("unknown agent",
+				fmt.Errorf("no agent named '%s' is registered", name))
+		}
+		appCtx.ProjectManager.UpdateConfig(&newConfig)
+		if err := appCtx.ProjectManager.SaveConfigToFile(); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+
+		appCtx.Logger.Info("Removed agent", zap.String("name", name))
+		appCtx.Out().Printf("Removed agent %s\n", name)
+		return nil
+	}
+}
+
+func writeProfileDetails(c *cli.Context, profile *manager.AgentProfile) {
+	strategy := profile.Strategy
+	if strategy == "" {
+		strategy = "default"
+	}
+	fmt.Fprintf(c.App.Writer, "  Strategy: %s\n", strategy)
+	if profile.Weights != nil {
+		w := profile.Weights
+		fmt.Fprintf(c.App.Writer, "  Weights: dependents %.2f, priority %.2f, depth %.2f, critical path %.2f, effort %.2f, value %.2f\n",
+			w.DependentCount, w.Priority, w.DepthFirst, w.CriticalPath, w.Effort, w.Value)
+	}
+	if len(profile.RequiredTags) > 0 {
+		fmt.Fprintf(c.App.Writer, "  Required tags: %s\n", strings.Join(profile.RequiredTags, ", "))
+	}
+	if len(profile.PreferredTags) > 0 {
+		fmt.Fprintf(c.App.Writer, "  Preferred tags: %s\n", strings.Join(profile.PreferredTags, ", "))
+	}
+}
@@ -0,0 +1,662 @@
+package analyze
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/selection"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// Commands returns the project analysis subcommands
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "complexity",
+			Usage: "Suggest recalibrated complexity values for completed tasks",
+			Description: `Compares each completed task's complexity with the effort it actually took.
+Leaf tasks are compared by estimate against the project's median minutes per
+complexity point (needs at least 3 completed, estimated leaf tasks); parent
+tasks are compared against the coordination level the auto-reduce table assigns
+to their number of subtasks. Differences of one point are ignored.`,
+			Action: complexityAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "apply",
+					Usage: "Update the tasks to the suggested complexity values",
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+		{
+			Name:  "deadlock",
+			Usage: "Explain why no pending task can be started",
+			Description: `Lists each pending task with its unmet and missing dependencies and suggests
+a minimal set of tasks, in completion order, whose completion unblocks the
+most pending work. Tasks that no completion can unblock (missing dependencies
+or dependency cycles) are listed separately.`,
+			Action: deadlockAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewJSONFlag(),
+			},
+		},
+		{
+			Name:  "hierarchy",
+			Usage: "Find hierarchy smells and suggest how to restructure the tasks",
+			Description: `Checks the task hierarchy of the project for
+  - levels with more than --max-siblings subtasks, suggesting to group them in
+    order below new parent tasks,
+  - chains of tasks with a single subtask each, suggesting to flatten the chain
+    by moving its tasks below the top task,
+  - tasks deeper than the configured max-depth and depth levels with more tasks
+    than allowed, suggesting to move the tasks up.
+
+--plan-file writes the suggestions as a plan, which can be reviewed, edited
+(e.g. to rename the new groups) and applied:
+
+  knot analyze hierarchy --plan-file restructure.yaml
+  knot plan --file restructure.yaml
+  knot apply --file restructure.yaml`,
+			Action: hierarchyAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:  "max-siblings",
+					Usage: "Maximum number of subtasks per level (0 to disable the check)",
+					Value: 10,
+				},
+				&cli.StringFlag{
+					Name:  "plan-file",
+					Usage: "Write the suggested restructuring to this plan file",
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+		{
+			Name:  "impact",
+			Usage: "Rank incomplete tasks by the downstream work they block",
+			Description: `Ranks incomplete tasks by the number of incomplete tasks that depend on them,
+directly or transitively, so you can see which single completion unblocks the
+most downstream work. Ties are broken by the number of dependents that become
+startable immediately. Tasks nothing depends on are not listed.`,
+			Action: impactAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:    "top",
+					Aliases: []string{"n"},
+					Usage:   "Number of tasks to show (0 for all)",
+					Value:   10,
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+		{
+			Name:  "selection",
+			Usage: "Explain how the next actionable task is selected",
+			Description: `Runs the task selection and reports the selected task with its score, the
+scored alternatives, the open tasks that are not actionable together with the
+reasons, and the full dependency graph. Use --json to feed the analysis into
+dashboards or agents.`,
+			Action: selectionAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "strategy",
+					Aliases: []string{"s"},
+					Usage:   "Selection strategy: dependency-aware, depth-first, priority, creation-order, critical-path, shortest-job-first, highest-value (configured or auto-recommended if not specified)",
+				},
+				&cli.BoolFlag{
+					Name:  "allow-parent-with-subtasks",
+					Usage: "Allow selection of parent tasks even when subtasks exist",
+				},
+				&cli.BoolFlag{
+					Name:  "prefer-pending",
+					Usage: "Prefer pending tasks over in-progress tasks",
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+		{
+			Name:  "strategy",
+			Usage: "Recommend selection weights from past selections and their outcomes",
+			Description: `Replays the change feed of the project and, for every task that was started,
+ranks it against the tasks that were actionable at that moment with the
+selection strategy. A selection has a good outcome when the task was completed
+without being reopened and no slower than the median completion time, and a
+poor outcome otherwise. Factors on which good selections ranked higher than
+poor ones get a higher weight, and vice versa. Weights are only recommended
+for strategies with configurable weights and once at least 5 selections are
+finished, with both good and poor outcomes among them.`,
+			Action: strategyAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "strategy",
+					Aliases: []string{"s"},
+					Usage:   "Selection strategy to calibrate: dependency-aware, shortest-job-first, highest-value (configured or auto-recommended if not specified)",
+				},
+				&cli.BoolFlag{
+					Name:  "apply",
+					Usage: "Write the recommended weights to the configuration",
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+	}
+}
+
+func complexityAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		report := analysis.SuggestComplexity(tasks, appCtx.ProjectManager.GetConfig())
+		appCtx.Logger.Info("Analyzed task complexity",
+			zap.String("projectID", projectID.String()),
+			zap.Int("analyzed", report.Analyzed),
+			zap.Int("suggestions", len(report.Suggestions)))
+
+		applied := 0
+		if c.Bool("apply") {
+			applied, err = applySuggestions(c, appCtx, report.Suggestions)
+			if err != nil {
+				return err
+			}
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal complexity report to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		appCtx.Out().Printf("Analyzed %d completed tasks\n", report.Analyzed)
+		if report.MinutesPerPoint > 0 {
+			appCtx.Out().Printf("Calibration: %.0f min per complexity point (from %d estimated tasks)\n",
+				report.MinutesPerPoint, report.Samples)
+		} else {
+			appCtx.Out().Printf("Calibration: not enough estimated tasks (%d of %d needed), estimate-based suggestions skipped\n",
+				report.Samples, analysis.MinCalibrationSamples)
+		}
+
+		if len(report.Suggestions) == 0 {
+			appCtx.Out().Println("\nNo complexity changes suggested.")
+			return nil
+		}
+
+		appCtx.Out().Printf("\nSuggested complexity changes (%d):\n", len(report.Suggestions))
+		for _, s := range report.Suggestions {
+			appCtx.Out().Printf("  %s (ID: %s)\n", s.Title, s.TaskID)
+			appCtx.Out().Printf("    Complexity: %d -> %d (%s)\n", s.Current, s.Suggested, s.Reason)
+		}
+
+		if c.Bool("apply") {
+			appCtx.Out().Printf("\nApplied %d complexity change(s)\n", applied)
+			appCtx.Out().Printf("  Updated by: %s\n", shared.GetActorFromContext(c))
+		} else {
+			appCtx.Out().Println("\nRun with --apply to update these tasks.")
+		}
+		return nil
+	}
+}
+
+func hierarchyAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		maxSiblings := c.Int("max-siblings")
+		if maxSiblings < 0 {
+			return errors.NewValidationError("invalid --max-siblings", fmt.Errorf("must not be negative, got %d", maxSiblings))
+		}
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		findings := analysis.AnalyzeHierarchy(tasks, maxSiblings, appCtx.ProjectManager.GetConfig())
+		appCtx.Logger.Info("Analyzed task hierarchy",
+			zap.String("projectID", projectID.String()),
+			zap.Int("findings", len(findings)))
+
+		path := c.String("plan-file")
+		operations := 0
+		if path != "" {
+			p := analysis.HierarchyPlan(findings)
+			operations = len(p.Creates) + len(p.Moves)
+			if operations > 0 {
+				data, err := p.Marshal()
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(path, data, 0o644); err != nil {
+					return fmt.Errorf("failed to write plan file: %w", err)
+				}
+			}
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(findings, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal hierarchy findings to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		if len(findings) == 0 {
+			appCtx.Out().Printf("No hierarchy smells found in %d task(s).\n", len(tasks))
+			return nil
+		}
+		appCtx.Out().Printf("Hierarchy smells (%d):\n", len(findings))
+		for _, finding := range findings {
+			level := "root level"
+			if finding.Parent != nil {
+				level = fmt.Sprintf("%s (ID: %s)", finding.Parent.Title, finding.Parent.TaskID)
+			}
+			appCtx.Out().Printf("\n  %s: %s\n", finding.Smell, finding.Message)
+			switch {
+			case finding.Smell == analysis.SmellTooManySiblings:
+				appCtx.Out().Printf("    level: %s\n", level)
+			case len(finding.Tasks) > 0:
+				titles := make([]string, len(finding.Tasks))
+				for i, task := range finding.Tasks {
+					titles[i] = task.Title
+				}
+				appCtx.Out().Printf("    tasks: %s\n", strings.Join(titles, " > "))
+			}
+			for _, suggestion := range finding.Suggestions {
+				appCtx.Out().Printf("    suggestion: %s\n", describeRestructuring(suggestion))
+			}
+		}
+
+		switch {
+		case path == "":
+		case operations == 0:
+			appCtx.Out().Printf("\nNo restructuring to suggest, %s not written.\n", path)
+		default:
+			appCtx.Out().Printf("\nWrote %d operation(s) to %s, preview with 'knot plan --file %s'.\n", operations, path, path)
+		}
+		return nil
+	}
+}
+
+// describeRestructuring summarizes a suggested restructuring in one line
+func describeRestructuring(r analysis.Restructuring) string {
+	target := "the root level"
+	if r.Parent != nil {
+		target = fmt.Sprintf("'%s'", r.Parent.Title)
+	}
+	titles := make([]string, len(r.Tasks))
+	for i, task := range r.Tasks {
+		titles[i] = task.Title
+	}
+	switch r.Kind {
+	case analysis.RestructureGroup:
+		return fmt.Sprintf("group %s below new task '%s' at %s", strings.Join(titles, ", "), r.GroupTitle, target)
+	case analysis.RestructureFlatten:
+		return fmt.Sprintf("flatten by moving %s below %s", strings.Join(titles, ", "), target)
+	default:
+		if r.Parent == nil {
+			return fmt.Sprintf("move %s up to the root level", strings.Join(titles, ", "))
+		}
+		return fmt.Sprintf("move %s up below %s", strings.Join(titles, ", "), target)
+	}
+}
+
+func deadlockAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksWithDependencies(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		report := analysis.ExplainDeadlock(tasks)
+		appCtx.Logger.Info("Analyzed blocked tasks",
+			zap.String("projectID", projectID.String()),
+			zap.Bool("deadlocked", report.Deadlocked),
+			zap.Int("blocked", len(report.Blocked)))
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal deadlock report to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		appCtx.Out().Printf("Pending: %d | Startable: %d | In progress: %d | Blocked: %d\n",
+			report.Pending, report.Startable, report.InProgress, len(report.Blocked))
+		if report.Deadlocked {
+			appCtx.Out().Println("Deadlock: no pending task can be started and nothing is in progress")
+		}
+
+		if len(report.Blocked) == 0 {
+			appCtx.Out().Println("\nNo blocked pending tasks.")
+			return nil
+		}
+
+		appCtx.Out().Printf("\nBlocked tasks (%d):\n", len(report.Blocked))
+		for _, blocked := range report.Blocked {
+			appCtx.Out().Printf("  %s (ID: %s)\n", blocked.Title, blocked.TaskID)
+			for _, dep := range blocked.UnmetDependencies {
+				appCtx.Out().Printf("    waits for: %s (ID: %s, %s)\n", dep.Title, dep.TaskID, dep.State)
+			}
+			for _, depID := range blocked.MissingDependencies {
+				appCtx.Out().Printf("    missing dependency: %s\n", depID)
+			}
+		}
+
+		if len(report.Unblockers) > 0 {
+			appCtx.Out().Printf("\nComplete these tasks in order to unblock the most work:\n")
+			for i, u := range report.Unblockers {
+				appCtx.Out().Printf("  %d. %s (ID: %s, %s) - unblocks %d task(s)\n", i+1, u.Title, u.TaskID, u.State, u.Unblocks)
+			}
+		}
+
+		if len(report.Unreachable) > 0 {
+			appCtx.Out().Printf("\nCannot be unblocked (missing dependencies or cycles):\n")
+			for _, ref := range report.Unreachable {
+				appCtx.Out().Printf("  %s (ID: %s)\n", ref.Title, ref.TaskID)
+			}
+		}
+		return nil
+	}
+}
+
+func impactAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		top := c.Int("top")
+		if top < 0 {
+			return errors.NewValidationError("invalid top value",
+				fmt.Errorf("--top must be 0 or greater, got %d", top))
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksWithDependencies(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		report, err := analysis.RankImpact(tasks)
+		if err != nil {
+			appCtx.Logger.Error("Failed to rank task impact", zap.Error(err))
+			return err
+		}
+		appCtx.Logger.Info("Ranked task impact",
+			zap.String("projectID", projectID.String()),
+			zap.Int("blocking", len(report.Tasks)))
+
+		if top > 0 && len(report.Tasks) > top {
+			report.Tasks = report.Tasks[:top]
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal impact report to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		if len(report.Tasks) == 0 {
+			appCtx.Out().Printf("No incomplete task blocks other work (%d incomplete tasks).\n", report.Incomplete)
+			return nil
+		}
+
+		appCtx.Out().Printf("Tasks blocking the most downstream work (%d incomplete tasks):\n", report.Incomplete)
+		for i, entry := range report.Tasks {
+			appCtx.Out().Printf("  %d. %s (ID: %s, %s)\n", i+1, entry.Title, entry.TaskID, entry.State)
+			appCtx.Out().Printf("     blocks %d task(s), %d directly, %d startable once completed", entry.Blocks, entry.DirectDependents, entry.Unblocks)
+			if !entry.Actionable {
+				appCtx.Out().Print(" - not actionable yet")
+			}
+			appCtx.Out().Println()
+		}
+		return nil
+	}
+}
+
+func selectionAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksWithDependencies(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		strategy := selection.StrategyDependencyAware
+		if c.IsSet("strategy") {
+			strategy = selection.ParseStrategy(c.String("strategy"))
+		} else if configured := appCtx.ProjectManager.GetConfig().SelectionStrategy; configured != "" {
+			strategy = selection.ParseStrategy(configured)
+		} else if recommended, _, err := selection.AnalyzeProjectAndRecommendStrategy(tasks); err == nil {
+			strategy = recommended
+		}
+		config := appCtx.ProjectManager.GetConfig().SelectionConfig(strategy)
+		if c.Bool("allow-parent-with-subtasks") {
+			config.Behavior.AllowParentWithSubtasks = true
+		}
+		if c.Bool("prefer-pending") {
+			config.Behavior.PreferInProgress = false
+		}
+
+		report, err := selection.AnalyzeSelection(tasks, config)
+		if err != nil {
+			appCtx.Logger.Error("Failed to analyze task selection", zap.Error(err))
+			return fmt.Errorf("failed to analyze task selection: %w", err)
+		}
+		appCtx.Logger.Info("Analyzed task selection",
+			zap.String("projectID", projectID.String()),
+			zap.String("strategy", report.Strategy),
+			zap.Int("alternatives", len(report.Alternatives)),
+			zap.Int("notActionable", len(report.NotActionable)))
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal selection analysis to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		appCtx.Out().Printf("Selection analysis (strategy: %s)\n", report.Strategy)
+		appCtx.Out().Printf("Tasks: %d | Actionable: %d | Cycles: %t\n",
+			report.Graph.TaskCount, report.Graph.ActionableCount, report.Graph.HasCycles)
+
+		if report.Selected != nil {
+			appCtx.Out().Printf("\nSelected: %s (ID: %s)\n", report.Selected.Task.Title, report.Selected.Task.ID)
+			appCtx.Out().Printf("  Score: %.2f | Reason: %s\n", report.Selected.Score, report.Reason)
+			if len(report.Selected.Factors) > 0 {
+				appCtx.Out().Printf("  Factors: %s\n", selection.FormatScoreFactors(report.Selected.Factors))
+			}
+		} else if report.Error != nil {
+			appCtx.Out().Printf("\nNo task selected: %s\n", report.Error.Message)
+		}
+
+		if len(report.Alternatives) > 0 {
+			appCtx.Out().Printf("\nAlternatives (%d):\n", len(report.Alternatives))
+			for i, alt := range report.Alternatives {
+				appCtx.Out().Printf("  %d. %s (score: %.2f)\n", i+1, alt.Task.Title, alt.Score)
+				if len(alt.Factors) > 0 {
+					appCtx.Out().Printf("     Factors: %s\n", selection.FormatScoreFactors(alt.Factors))
+				}
+			}
+		}
+
+		if len(report.NotActionable) > 0 {
+			appCtx.Out().Printf("\nNot actionable (%d):\n", len(report.NotActionable))
+			for _, blocked := range report.NotActionable {
+				appCtx.Out().Printf("  %s (ID: %s): %s\n", blocked.Title, blocked.TaskID, strings.Join(blocked.Reasons, ", "))
+			}
+		}
+		return nil
+	}
+}
+
+func strategyAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		strategy := selection.StrategyDependencyAware
+		if c.IsSet("strategy") {
+			var ok bool
+			if strategy, ok = selection.LookupStrategy(c.String("strategy")); !ok {
+				return errors.NewValidationError("invalid strategy",
+					fmt.Errorf("unknown selection strategy '%s'", c.String("strategy")))
+			}
+		} else if configured := appCtx.ProjectManager.GetConfig().SelectionStrategy; configured != "" {
+			strategy = selection.ParseStrategy(configured)
+		} else {
+			tasks, err := appCtx.ProjectManager.ListTasksWithDependencies(c.Context, projectID)
+			if err != nil {
+				appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+				return fmt.Errorf("failed to list tasks: %w", err)
+			}
+			if recommended, _, err := selection.AnalyzeProjectAndRecommendStrategy(tasks); err == nil {
+				strategy = recommended
+			}
+		}
+
+		events, err := appCtx.ProjectManager.ListChangeEvents(c.Context, types.ChangeEventFilter{ProjectID: &projectID})
+		if err != nil {
+			appCtx.Logger.Error("Failed to list change events", zap.Error(err))
+			return fmt.Errorf("failed to list change events: %w", err)
+		}
+
+		calibration, err := analysis.CalibrateStrategy(events, appCtx.ProjectManager.GetConfig().SelectionConfig(strategy))
+		if err != nil {
+			appCtx.Logger.Error("Failed to calibrate selection strategy", zap.Error(err))
+			return fmt.Errorf("failed to calibrate selection strategy: %w", err)
+		}
+		appCtx.Logger.Info("Calibrated selection strategy",
+			zap.String("projectID", projectID.String()),
+			zap.String("strategy", calibration.Strategy),
+			zap.Int("selections", len(calibration.Selections)),
+			zap.Bool("recommended", calibration.Recommended != nil))
+
+		if c.Bool("apply") {
+			if calibration.Recommended == nil {
+				return errors.NewValidationError("no weights to apply",
+					fmt.Errorf("no weights are recommended for the %s strategy", calibration.Strategy))
+			}
+			newConfig := *appCtx.ProjectManager.GetConfig()
+			newConfig.SetStrategyWeights(strategy, *calibration.Recommended)
+			if err := manager.ValidateSelectionWeights(newConfig.SelectionWeights); err != nil {
+				return errors.NewValidationError("invalid recommended weights", err)
+			}
+			appCtx.ProjectManager.UpdateConfig(&newConfig)
+			if err := appCtx.ProjectManager.SaveConfigToFile(); err != nil {
+				return fmt.Errorf("failed to save configuration: %w", err)
+			}
+			appCtx.Logger.Info("Applied calibrated selection weights",
+				zap.String("strategy", calibration.Strategy),
+				zap.String("actor", shared.GetActorFromContext(c)))
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(calibration, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal strategy calibration to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		appCtx.Out().Printf("Strategy calibration (strategy: %s)\n", calibration.Strategy)
+		appCtx.Out().Printf("Selections: %d | Strategy's first choice: %d | Completed: %d | Reopened: %d\n",
+			len(calibration.Selections), calibration.Agreed, calibration.Completed, calibration.Reworked)
+		if calibration.MedianCycleMinutes > 0 {
+			appCtx.Out().Printf("Median time from start to completion: %.0f min\n", calibration.MedianCycleMinutes)
+		}
+
+		if !calibration.Weighted {
+			appCtx.Out().Printf("\nThe %s strategy uses fixed weights and cannot be calibrated.\n", calibration.Strategy)
+			return nil
+		}
+
+		if len(calibration.Factors) > 0 {
+			appCtx.Out().Printf("\nFactors (average rank among candidates, good vs. poor outcomes):\n")
+			for _, factor := range calibration.Factors {
+				appCtx.Out().Printf("  %-16s good %.2f | poor %.2f | weight %.2f -> %.2f\n",
+					factor.Name, factor.Good, factor.Poor, factor.Weight, factor.Recommended)
+			}
+		}
+
+		if calibration.Recommended == nil {
+			appCtx.Out().Printf("\nNot enough finished selections with good and poor outcomes to recommend weights (%d needed).\n",
+				analysis.MinStrategySamples)
+			return nil
+		}
+		if c.Bool("apply") {
+			appCtx.Out().Printf("\nApplied the recommended weights of the %s strategy\n", calibration.Strategy)
+			appCtx.Out().Printf("  Updated by: %s\n", shared.GetActorFromContext(c))
+		} else {
+			appCtx.Out().Println("\nRun with --apply to write the recommended weights to the configuration.")
+		}
+		return nil
+	}
+}
+
+func applySuggestions(c *cli.Context, appCtx *shared.AppContext, suggestions []analysis.ComplexitySuggestion) (int, error) {
+	ctx := c.Context
+	actor := shared.GetActorFromContext(c)
+
+	for i, s := range suggestions {
+		task, err := appCtx.ProjectManager.GetTask(ctx, s.TaskID)
+		if err != nil {
+			return i, fmt.Errorf("failed to get task %s: %w", s.TaskID, err)
+		}
+		if _, err := appCtx.ProjectManager.UpdateTask(ctx, task.ID, task.Title, task.Description, s.Suggested, task.State, actor); err != nil {
+			appCtx.Logger.Error("Failed to update task complexity", zap.String("taskID", task.ID.String()), zap.Error(err))
+			return i, fmt.Errorf("failed to update complexity of task %s after %d change(s): %w", task.ID, i, err)
+		}
+		appCtx.Logger.Info("Recalibrated task complexity",
+			zap.String("taskID", task.ID.String()),
+			zap.Int("from", s.Current),
+			zap.Int("to", s.Suggested),
+			zap.String("actor", actor))
+	}
+	return len(suggestions), nil
+}
@@ -0,0 +1,87 @@
+package analyze
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/testutil"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// setupBlockedProject creates a SQLite backed project in which "Build" waits
+// for "Design". SQLite only loads task dependencies when asked to, so the
+// commands must request them.
+func setupBlockedProject(t *testing.T) (*shared.AppContext, *bytes.Buffer, *types.Task, *types.Task) {
+	ctx := context.Background()
+	pm := testutil.NewTestConfig(t).WithSQLiteDB().SetupTestManager(t)
+	project := testutil.CreateTestProject(t, pm)
+	require.NoError(t, pm.SetSelectedProject(ctx, project.ID, "test-user"))
+
+	design, err := pm.CreateTask(ctx, project.ID, nil, "Design", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	build, err := pm.CreateTask(ctx, project.ID, nil, "Build", "", 3, types.TaskPriorityHigh, "test-user")
+	require.NoError(t, err)
+	_, err = pm.AddTaskDependency(ctx, build.ID, design.ID, "test-user")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	appCtx := &shared.AppContext{ProjectManager: pm, Logger: zap.NewNop(), Output: output.NewTextWriter(&out)}
+	return appCtx, &out, design, build
+}
+
+func runAnalyze(t *testing.T, appCtx *shared.AppContext, args ...string) {
+	app := &cli.App{Name: "analyze", Commands: Commands(appCtx)}
+	require.NoError(t, app.Run(append([]string{"analyze"}, args...)))
+}
+
+func TestDeadlockActionLoadsDependencies(t *testing.T) {
+	appCtx, out, design, build := setupBlockedProject(t)
+	runAnalyze(t, appCtx, "deadlock", "--json")
+
+	var report struct {
+		Startable int `json:"startable"`
+		Blocked   []struct {
+			TaskID            uuid.UUID `json:"task_id"`
+			UnmetDependencies []struct {
+				TaskID uuid.UUID `json:"task_id"`
+			} `json:"unmet_dependencies"`
+		} `json:"blocked"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, 1, report.Startable)
+	require.Len(t, report.Blocked, 1)
+	assert.Equal(t, build.ID, report.Blocked[0].TaskID)
+	require.Len(t, report.Blocked[0].UnmetDependencies, 1)
+	assert.Equal(t, design.ID, report.Blocked[0].UnmetDependencies[0].TaskID)
+}
+
+func TestSelectionActionLoadsDependencies(t *testing.T) {
+	appCtx, out, design, build := setupBlockedProject(t)
+	// Priority selection would pick the high priority "Build" if its
+	// dependency was not loaded
+	runAnalyze(t, appCtx, "selection", "--strategy", "priority", "--json")
+
+	var report struct {
+		Selected struct {
+			Task struct {
+				ID uuid.UUID `json:"id"`
+			} `json:"task"`
+		} `json:"selected"`
+		NotActionable []struct {
+			TaskID uuid.UUID `json:"task_id"`
+		} `json:"not_actionable"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, design.ID, report.Selected.Task.ID)
+	require.Len(t, report.NotActionable, 1)
+	assert.Equal(t, build.ID, report.NotActionable[0].TaskID)
+}
@@ -0,0 +1,177 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/bench"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// NewBenchCommand creates the bench command, which measures representative
+// operations on a synthetic project
+func NewBenchCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "bench",
+		Usage: "Measure the performance of knot operations on a synthetic project",
+		Description: `Generates a synthetic project with --tasks tasks in a temporary database,
+then measures listing tasks (with and without a filter and dependencies),
+building the dependency graph, selecting the next actionable task and a bulk
+update of up to 500 tasks. Each operation runs --runs times; the report shows
+the fastest, median and slowest run with the heap allocations per run.
+
+Your own projects and database are never touched. Use the report to compare
+knot versions on your own hardware, and --cpu-profile or --mem-profile to
+attach profiles to a performance issue (inspect them with 'go tool pprof').`,
+		Action: benchAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "tasks",
+				Usage: "Number of tasks of the synthetic project",
+				Value: 1000,
+			},
+			&cli.IntFlag{
+				Name:  "runs",
+				Usage: "Number of times each operation is measured",
+				Value: 5,
+			},
+			&cli.StringFlag{
+				Name:  "backend",
+				Usage: "Storage backend: sqlite (temporary database file) or inmemory",
+				Value: "sqlite",
+			},
+			&cli.Int64Flag{
+				Name:  "seed",
+				Usage: "Seed of the synthetic project, the same seed generates the same project",
+				Value: 1,
+			},
+			&cli.StringFlag{
+				Name:  "cpu-profile",
+				Usage: "Write a CPU profile of the benchmark to this file",
+			},
+			&cli.StringFlag{
+				Name:  "mem-profile",
+				Usage: "Write a heap profile taken after the benchmark to this file",
+			},
+			shared.NewJSONFlag(),
+		},
+	}
+}
+
+func benchAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		opts := bench.Options{
+			Tasks:   c.Int("tasks"),
+			Runs:    c.Int("runs"),
+			Backend: c.String("backend"),
+			Seed:    c.Int64("seed"),
+		}
+		if opts.Tasks < 1 {
+			return errors.NewValidationError("invalid tasks value", fmt.Errorf("--tasks must be at least 1, got %d", opts.Tasks))
+		}
+		if opts.Runs < 1 {
+			return errors.NewValidationError("invalid runs value", fmt.Errorf("--runs must be at least 1, got %d", opts.Runs))
+		}
+
+		if path := c.String("cpu-profile"); path != "" {
+			file, err := os.Create(path)
+			if err != nil {
+				return fmt.Errorf("failed to create CPU profile: %w", err)
+			}
+			defer file.Close()
+			if err := pprof.StartCPUProfile(file); err != nil {
+				return fmt.Errorf("failed to start CPU profile: %w", err)
+			}
+			defer pprof.StopCPUProfile()
+		}
+
+		if !c.Bool("json") {
+			appCtx.Out().Printf("Benchmarking %d tasks on %s, %d run(s) per operation...\n", opts.Tasks, opts.Backend, opts.Runs)
+		}
+		report, err := bench.Run(c.Context, opts)
+		if err != nil {
+			appCtx.Logger.Error("Benchmark failed", zap.Error(err))
+			return fmt.Errorf("benchmark failed: %w", err)
+		}
+		appCtx.Logger.Info("Ran benchmark",
+			zap.Int("tasks", report.Tasks),
+			zap.String("backend", report.Backend),
+			zap.Duration("total", report.Total))
+
+		if path := c.String("mem-profile"); path != "" {
+			if err := writeHeapProfile(path); err != nil {
+				return err
+			}
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal benchmark report to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		writeReport(appCtx.Out(), report)
+		return nil
+	}
+}
+
+func writeHeapProfile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create heap profile: %w", err)
+	}
+	defer file.Close()
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		return fmt.Errorf("failed to write heap profile: %w", err)
+	}
+	return nil
+}
+
+func writeReport(w io.Writer, report *bench.Report) {
+	fmt.Fprintf(w, "%s/%s, %d CPUs, %s\n", report.OS, report.Arch, report.CPUs, report.GoVersion)
+	fmt.Fprintf(w, "Project: %d tasks, %d dependencies (%s)\n\n", report.Tasks, report.Dependencies, report.Backend)
+	fmt.Fprintf(w, "%-30s %10s %10s %10s %12s %10s %8s\n", "Operation", "Min", "Median", "Max", "Alloc/run", "Allocs", "Tasks")
+	for _, op := range report.Operations {
+		fmt.Fprintf(w, "%-30s %10s %10s %10s %12s %10d %8d\n",
+			op.Name, formatDuration(op.Min), formatDuration(op.Median), formatDuration(op.Max),
+			formatBytes(op.AllocBytes), op.Allocs, op.Items)
+	}
+	fmt.Fprintf(w, "\nHeap in use: %s | Total: %s\n", formatBytes(report.HeapInUse), formatDuration(report.Total))
+}
+
+// formatDuration rounds durations to three significant digits at most
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
+
+func formatBytes(b uint64) string {
+	switch {
+	case b >= 1<<30:
+		return fmt.Sprintf("%.1f GiB", float64(b)/(1<<30))
+	case b >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(b)/(1<<20))
+	case b >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(b)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", b)
+	}
+}
@@ -0,0 +1,161 @@
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/changelog"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/snapshot"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// Output formats of the changelog command
+const (
+	formatMarkdown = "markdown"
+	formatJSON     = "json"
+)
+
+// NewChangelogCommand creates the changelog command, which writes release
+// notes from the completed tasks of the selected project
+func NewChangelogCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "changelog",
+		Usage: "Write release notes from the completed tasks",
+		Description: `Lists the tasks of the selected project completed since --since as a release
+notes document. --since is a snapshot label, so tagging a release with
+'knot snapshot create --label v1.2.0' lets the next changelog start there, or a
+date (YYYY-MM-DD, yesterday, or a duration such as 14d).
+
+Tasks are grouped by the root task of their subtree, or with --group-by tag by
+their first tag. An entry shows the task title, or the text of a line starting
+with "Changelog:" in the task description, e.g.
+
+  Changelog: Invoices can be exported as CSV`,
+		Action: changelogAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Snapshot label or date to start from (default: all completed tasks)",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format: markdown or json",
+				Value: formatMarkdown,
+			},
+			&cli.StringFlag{
+				Name:  "group-by",
+				Usage: "Group tasks by their root task (root) or their first tag (tag)",
+				Value: changelog.GroupByRoot,
+			},
+			&cli.StringFlag{
+				Name:  "out",
+				Usage: "Write to file instead of stdout",
+			},
+		},
+	}
+}
+
+func changelogAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		format := c.String("format")
+		if format != formatMarkdown && format != formatJSON {
+			return errors.NewValidationError("invalid --format", fmt.Errorf("unknown format %q, use markdown or json", format))
+		}
+		if err := changelog.ValidateGroupBy(c.String("group-by")); err != nil {
+			return errors.NewValidationError("invalid --group-by", err)
+		}
+
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+		opts := changelog.Options{GroupBy: c.String("group-by")}
+		if value := c.String("since"); value != "" {
+			if opts.Since, opts.SinceLabel, err = resolveSince(value, projectID, appCtx.ProjectManager.GetCurrentTime()); err != nil {
+				return err
+			}
+		}
+
+		project, err := appCtx.ProjectManager.GetProject(c.Context, projectID)
+		if err != nil {
+			return errors.WrapWithSuggestion(err, "loading project")
+		}
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		log := changelog.Build(project, tasks, opts)
+		appCtx.Logger.Info("Built changelog",
+			zap.String("projectID", projectID.String()),
+			zap.Int("entries", log.Entries))
+
+		outPath := c.String("out")
+		if outPath == "" {
+			return render(appCtx.Out(), log, format)
+		}
+
+		file, err := os.Create(outPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		if err := render(file, log, format); err != nil {
+			return fmt.Errorf("failed to write changelog: %w", err)
+		}
+
+		appCtx.Out().Printf("Wrote %d completed tasks to %s\n", log.Entries, outPath)
+		return nil
+	}
+}
+
+// resolveSince returns the creation time of the snapshot with the given
+// label, or else parses the value as a date
+func resolveSince(value string, projectID uuid.UUID, now time.Time) (time.Time, string, error) {
+	if snapshot.ValidateLabel(value) == nil {
+		store, err := snapshot.NewStore()
+		if err != nil {
+			return time.Time{}, "", err
+		}
+		if store.Exists(value) {
+			snap, err := store.Load(value)
+			if err != nil {
+				return time.Time{}, "", err
+			}
+			if snap.Project.ID != projectID {
+				return time.Time{}, "", errors.NewValidationError("invalid --since",
+					fmt.Errorf("snapshot '%s' belongs to project %s, not the selected project", value, snap.Project.Title))
+			}
+			return snap.CreatedAt, value, nil
+		}
+	}
+
+	since, err := utils.ParseSince(value, now)
+	if err != nil {
+		return time.Time{}, "", errors.NewValidationError("invalid --since",
+			fmt.Errorf("%q is neither a snapshot label (see 'knot snapshot list') nor a date: %w", value, err))
+	}
+	return since, "", nil
+}
+
+func render(w io.Writer, log *changelog.Changelog, format string) error {
+	if format == formatJSON {
+		jsonData, err := json.MarshalIndent(log, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal changelog to JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(jsonData))
+		return err
+	}
+	return changelog.RenderMarkdown(w, log)
+}
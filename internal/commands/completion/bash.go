@@ -2,7 +2,7 @@ package completion
 
 import (
 	_ "embed"
-	"fmt"
+	"io"
 )
 
 //go:embed bash_completion.bash
@@ -16,9 +16,9 @@ func NewBashCompletion() *BashCompletion {
 	return &BashCompletion{}
 }
 
-// Generate generates bash completion script
-func (b *BashCompletion) Generate() error {
-	_, err := fmt.Print(bashCompletionScript)
+// Generate writes the bash completion script to w
+func (b *BashCompletion) Generate(w io.Writer) error {
+	_, err := io.WriteString(w, bashCompletionScript)
 	return err
 }
 
@@ -4,7 +4,8 @@
 
 _knot_completion() {
     local cur prev words cword
-    _init_completion || return
+    # Keep "name=value" of --var together
+    _init_completion -n = || return
 
     # Available commands
     local commands="project task template dependency config health validate ready blocked actionable breakdown get-started completion"
@@ -57,6 +58,26 @@ _knot_completion() {
             _knot_complete_ids task
             return
             ;;
+        --var|-v)
+            # Variable names and choice values of the template given with --name
+            local name="" i
+            for ((i = 1; i < cword; i++)); do
+                if [[ "${words[i]}" == "--name" || "${words[i]}" == "-n" ]]; then
+                    name="${words[i+1]}"
+                fi
+            done
+            if [[ -n "$name" ]]; then
+                local IFS=$'\n'
+                COMPREPLY=($(compgen -W "$(knot template complete-var --name "$name" 2>/dev/null)" -- "$cur"))
+                # Bash replaces only the part after "="
+                if [[ "$cur" == *=* ]]; then
+                    COMPREPLY=("${COMPREPLY[@]#*=}")
+                elif [[ ${#COMPREPLY[@]} -eq 1 && "${COMPREPLY[0]}" == *= ]]; then
+                    compopt -o nospace
+                fi
+            fi
+            return
+            ;;
     esac
 }
 
@@ -2,6 +2,7 @@ package completion
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/urfave/cli/v2"
@@ -54,19 +55,19 @@ func CompletionAction(appCtx *shared.AppContext) cli.ActionFunc {
 		}
 
 		shell := ShellType(args.Get(0))
-		return GenerateCompletion(shell)
+		return GenerateCompletion(appCtx.Out(), shell)
 	}
 }
 
-// GenerateCompletion generates completion script for the specified shell
-func GenerateCompletion(shell ShellType) error {
+// GenerateCompletion writes the completion script for the specified shell to w
+func GenerateCompletion(w io.Writer, shell ShellType) error {
 	switch shell {
 	case ShellBash:
 		completion := NewBashCompletion()
-		return completion.Generate()
+		return completion.Generate(w)
 	case ShellZsh:
 		completion := NewZshCompletion()
-		return completion.Generate()
+		return completion.Generate(w)
 	default:
 		return fmt.Errorf("unsupported shell: %s. Supported shells: bash, zsh", shell)
 	}
@@ -2,7 +2,7 @@ package completion
 
 import (
 	_ "embed"
-	"fmt"
+	"io"
 )
 
 //go:embed zsh_completion.zsh
@@ -16,9 +16,9 @@ func NewZshCompletion() *ZshCompletion {
 	return &ZshCompletion{}
 }
 
-// Generate generates zsh completion script
-func (z *ZshCompletion) Generate() error {
-	_, err := fmt.Print(zshCompletionScript)
+// Generate writes the zsh completion script to w
+func (z *ZshCompletion) Generate(w io.Writer) error {
+	_, err := io.WriteString(w, zshCompletionScript)
 	return err
 }
 
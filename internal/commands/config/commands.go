@@ -2,9 +2,16 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
 
+	"github.com/denkhaus/knot/v2/internal/config"
+	"github.com/denkhaus/knot/v2/internal/errors"
 	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/validation"
 	"github.com/urfave/cli/v2"
 )
 
@@ -24,7 +31,7 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 				&cli.StringFlag{
 					Name:     "key",
 					Aliases:  []string{"k"},
-					Usage:    "Configuration key (complexity-threshold, max-depth, max-tasks-per-depth, max-description-length, auto-reduce-complexity)",
+					Usage:    "Configuration key (complexity-threshold, max-depth, max-tasks-per-depth, max-tasks-at-depth-<n>, allow-large-breakdowns, max-tasks-per-project, max-dependencies-per-task, max-description-length, max-title-length, allow-markup, duplicate-check, duplicate-threshold, progress-weighting, blocked-escalation-days, slow-query-threshold, auto-reduce-complexity, allow-unverified-completion, require-review, priority-inheritance, require-handoff-notes)",
 					Required: true,
 				},
 				&cli.IntFlag{
@@ -40,6 +47,45 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 			Usage:  "Reset configuration to defaults",
 			Action: ResetAction(appCtx),
 		},
+		{
+			Name:  "export",
+			Usage: "Export the configuration to share it with a team or CI",
+			Description: `Writes all settings of .knot/config.json, including saved filters, agent
+capacities, schedules and the complexity reduction table, as YAML, or as JSON
+when the file name ends in .json. Without --file the YAML is printed.`,
+			Action: ExportAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "file",
+					Aliases: []string{"f"},
+					Usage:   "File to write (e.g. knot-config.yaml)",
+				},
+			},
+		},
+		{
+			Name:  "import",
+			Usage: "Import a configuration exported with 'knot config export'",
+			Description: `Applies the settings of an exported YAML or JSON file and saves them to
+.knot/config.json. Settings missing from the file keep their current value,
+or are reset to the defaults with --replace. Unknown settings are rejected.`,
+			Action: ImportAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "file",
+					Aliases:  []string{"f"},
+					Usage:    "File to import",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  "replace",
+					Usage: "Reset settings missing from the file to their defaults",
+				},
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Show which settings would change without saving",
+				},
+			},
+		},
 	}
 }
 
@@ -48,14 +94,91 @@ func ShowAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		config := appCtx.ProjectManager.GetConfig()
 
-		fmt.Println("Current Knot Configuration:")
-		fmt.Println()
-		fmt.Printf("  Complexity Threshold:    %d (tasks >= this need breakdown)\n", config.ComplexityThreshold)
-		fmt.Printf("  Max Depth:               %d (maximum hierarchy levels)\n", config.MaxDepth)
-		fmt.Printf("  Max Tasks Per Depth:     %d (maximum tasks per level)\n", config.MaxTasksPerDepth)
-		fmt.Printf("  Max Description Length:  %d (maximum characters)\n", config.MaxDescriptionLength)
-		fmt.Printf("  Auto Reduce Complexity:  %t (automatically reduce parent complexity when subtasks added)\n", config.AutoReduceComplexity)
-		fmt.Println()
+		appCtx.Out().Println("Current Knot Configuration:")
+		appCtx.Out().Println()
+		appCtx.Out().Printf("  Complexity Threshold:    %d (tasks >= this need breakdown)\n", config.ComplexityThreshold)
+		appCtx.Out().Printf("  Max Depth:               %d (maximum hierarchy levels)\n", config.MaxDepth)
+		appCtx.Out().Printf("  Max Tasks Per Depth:     %d (maximum tasks per level)\n", config.MaxTasksPerDepth)
+		for depth, limit := range config.MaxTasksAtDepth {
+			if limit > 0 {
+				appCtx.Out().Printf("    depth %d:               %d (set with max-tasks-at-depth-%d)\n", depth, limit, depth)
+			}
+		}
+		appCtx.Out().Printf("  Allow Large Breakdowns:  %t (subtasks of tasks with complexity >= %d ignore the per-depth limits)\n", config.AllowLargeBreakdowns, config.ComplexityThreshold)
+		if limit := config.ProjectTaskLimit(); limit > 0 {
+			appCtx.Out().Printf("  Max Tasks Per Project:   %d (creating tasks warns from %d%% on and fails at the limit)\n", limit, manager.LimitWarningPercent)
+		} else {
+			appCtx.Out().Printf("  Max Tasks Per Project:   off (creating tasks warns from %d%% on and fails at the limit)\n", manager.LimitWarningPercent)
+		}
+		if limit := config.DependencyLimit(); limit > 0 {
+			appCtx.Out().Printf("  Max Dependencies Per Task: %d (adding dependencies warns from %d%% on and fails at the limit)\n", limit, manager.LimitWarningPercent)
+		} else {
+			appCtx.Out().Printf("  Max Dependencies Per Task: off (adding dependencies warns from %d%% on and fails at the limit)\n", manager.LimitWarningPercent)
+		}
+		appCtx.Out().Printf("  Max Description Length:  %d (maximum characters)\n", config.MaxDescriptionLength)
+		if validator, err := config.Validator(); err == nil {
+			appCtx.Out().Printf("  Max Title Length:        %d (maximum characters)\n", validator.MaxTitleLength)
+			appCtx.Out().Printf("  Allow Markup:            %t (accept HTML and script-like content in titles and descriptions)\n", validator.AllowHTML)
+		}
+		if len(config.BannedPatterns) > 0 {
+			appCtx.Out().Printf("  Banned Patterns:         %d (titles and descriptions must not match, edit BannedPatterns in .knot/config.json)\n", len(config.BannedPatterns))
+			for _, pattern := range config.BannedPatterns {
+				appCtx.Out().Printf("    %s\n", pattern)
+			}
+		}
+		appCtx.Out().Printf("  Duplicate Check:         %s (when creating a task with a title similar to an existing one: warn, block or off)\n", config.DuplicateCheckMode())
+		appCtx.Out().Printf("  Duplicate Threshold:     %.0f%% (title similarity from which a task counts as a duplicate)\n", config.DuplicateSimilarity()*100)
+		appCtx.Out().Printf("  Progress Weighting:      %s (weighted project progress counts tasks or weighs them by complexity or estimate)\n", config.ProgressWeightingMode())
+		if config.SelectionStrategy != "" {
+			appCtx.Out().Printf("  Selection Strategy:      %s (default of actionable and analyze selection, edit SelectionStrategy in .knot/config.json)\n", config.SelectionStrategy)
+		} else {
+			appCtx.Out().Printf("  Selection Strategy:      auto (recommended from the project structure, edit SelectionStrategy in .knot/config.json)\n")
+		}
+		if len(config.SelectionWeights) > 0 {
+			appCtx.Out().Printf("  Selection Weights:       %d strategies (calibrated with knot analyze strategy --apply)\n", len(config.SelectionWeights))
+		}
+		if days := config.BlockedEscalationThreshold(); days > 0 {
+			appCtx.Out().Printf("  Blocked Escalation:      %d days (knot blocked --aging marks tasks blocked this long for escalation)\n", days)
+		} else {
+			appCtx.Out().Printf("  Blocked Escalation:      off (knot blocked --aging marks tasks blocked this long for escalation)\n")
+		}
+		if threshold := config.SlowQueryThreshold(); threshold > 0 {
+			appCtx.Out().Printf("  Slow Query Threshold:    %s (queries taking this long are logged and listed by knot stats db)\n", threshold)
+		} else {
+			appCtx.Out().Printf("  Slow Query Threshold:    off (queries taking this long are logged and listed by knot stats db)\n")
+		}
+		appCtx.Out().Printf("  Auto Reduce Complexity:  %t (automatically reduce parent complexity when subtasks added)\n", config.AutoReduceComplexity)
+		appCtx.Out().Printf("  Allow Unverified Completion: %t (complete tasks with unverified acceptance criteria)\n", config.AllowUnverifiedCompletion)
+		appCtx.Out().Printf("  Priority Inheritance:    %t (dependencies inherit the priority of their dependents)\n", config.PriorityInheritance)
+		appCtx.Out().Printf("  Require Handoff Notes:   %t (reassigning or releasing an in-progress task needs --handoff)\n", config.RequireHandoffNotes)
+		timeZone, timeFormat := config.TimeZone, config.TimeFormat
+		if timeZone == "" {
+			timeZone = "local"
+		}
+		if timeFormat == "" {
+			timeFormat = output.DefaultTimeFormat
+		}
+		appCtx.Out().Printf("  Time Zone:               %s (displayed timestamps, --utc overrides, edit TimeZone in .knot/config.json)\n", timeZone)
+		appCtx.Out().Printf("  Time Format:             %s (Go layout of displayed timestamps, edit TimeFormat in .knot/config.json)\n", timeFormat)
+		appCtx.Out().Printf("  Creation Limits:         %d (tasks an actor may create per window, edit CreationLimits in .knot/config.json)\n", len(config.CreationLimits))
+		for _, limit := range config.CreationLimits {
+			appCtx.Out().Printf("    %s: %d tasks per %s\n", limit.Actor, limit.MaxTasks, limit.Window())
+		}
+		appCtx.Out().Printf("  Registered Agents:       %d (knot agent list, selection preferences for knot task claim)\n", len(config.Agents))
+		appCtx.Out().Printf("  Review Required Projects: %d (tasks need an approved review to complete)\n", len(config.ReviewRequiredProjects))
+		for _, projectID := range config.ReviewRequiredProjects {
+			appCtx.Out().Printf("    %s\n", projectID)
+		}
+		appCtx.Out().Println()
+		appCtx.Out().Println("  Complexity Reductions (parent complexity by subtask count, edit ComplexityReductions in .knot/config.json):")
+		for _, r := range config.Reductions() {
+			if r.Complexity > 0 {
+				appCtx.Out().Printf("    >= %d subtasks: %d\n", r.MinSubtasks, r.Complexity)
+			} else {
+				appCtx.Out().Printf("    >= %d subtasks: -%d\n", r.MinSubtasks, r.ReduceBy)
+			}
+		}
+		appCtx.Out().Println()
 
 		// Show config file location - TODO: implement GetConfigPath method
 		// configPath, err := config.GetConfigPath()
@@ -94,19 +217,121 @@ func SetAction(appCtx *shared.AppContext) cli.ActionFunc {
 				return fmt.Errorf("max-tasks-per-depth must be at least 1, got %d", value)
 			}
 			newConfig.MaxTasksPerDepth = value
+		case "allow-large-breakdowns":
+			if value != 0 && value != 1 {
+				return fmt.Errorf("allow-large-breakdowns must be 0 (false) or 1 (true), got %d", value)
+			}
+			newConfig.AllowLargeBreakdowns = value == 1
+		case "max-tasks-per-project":
+			if value < 0 {
+				return fmt.Errorf("max-tasks-per-project must be 0 (no limit) or a number of tasks, got %d", value)
+			}
+			newConfig.MaxTasksPerProject = value
+			if value == 0 {
+				newConfig.MaxTasksPerProject = -1
+			}
+		case "max-dependencies-per-task":
+			if value < 0 {
+				return fmt.Errorf("max-dependencies-per-task must be 0 (no limit) or a number of dependencies, got %d", value)
+			}
+			newConfig.MaxDependenciesPerTask = value
+			if value == 0 {
+				newConfig.MaxDependenciesPerTask = -1
+			}
 		case "max-description-length":
 			if value < 1 {
 				return fmt.Errorf("max-description-length must be at least 1, got %d", value)
 			}
 			newConfig.MaxDescriptionLength = value
+		case "max-title-length":
+			if value < 1 {
+				return fmt.Errorf("max-title-length must be at least 1, got %d", value)
+			}
+			newConfig.MaxTitleLength = value
+		case "allow-markup":
+			if value != 0 && value != 1 {
+				return fmt.Errorf("allow-markup must be 0 (false) or 1 (true), got %d", value)
+			}
+			newConfig.Markup = validation.MarkupReject
+			if value == 1 {
+				newConfig.Markup = validation.MarkupAllow
+			}
+		case "duplicate-check":
+			modes := []string{manager.DuplicateCheckOff, manager.DuplicateCheckWarn, manager.DuplicateCheckBlock}
+			if value < 0 || value >= len(modes) {
+				return fmt.Errorf("duplicate-check must be 0 (off), 1 (warn) or 2 (block), got %d", value)
+			}
+			newConfig.DuplicateCheck = modes[value]
+		case "duplicate-threshold":
+			if value < 1 || value > 100 {
+				return fmt.Errorf("duplicate-threshold must be a percentage between 1 and 100, got %d", value)
+			}
+			newConfig.DuplicateThreshold = float64(value) / 100
+		case "progress-weighting":
+			weightings := []string{manager.ProgressWeightingCount, manager.ProgressWeightingComplexity, manager.ProgressWeightingEstimate}
+			if value < 0 || value >= len(weightings) {
+				return fmt.Errorf("progress-weighting must be 0 (count), 1 (complexity) or 2 (estimate), got %d", value)
+			}
+			newConfig.ProgressWeighting = weightings[value]
+		case "blocked-escalation-days":
+			if value < 0 {
+				return fmt.Errorf("blocked-escalation-days must be 0 (never escalate) or a number of days, got %d", value)
+			}
+			newConfig.BlockedEscalationDays = value
+			if value == 0 {
+				newConfig.BlockedEscalationDays = -1
+			}
+		case "slow-query-threshold":
+			if value < 0 {
+				return fmt.Errorf("slow-query-threshold must be 0 (never log slow queries) or a number of milliseconds, got %d", value)
+			}
+			newConfig.SlowQueryThresholdMs = value
+			if value == 0 {
+				newConfig.SlowQueryThresholdMs = -1
+			}
 		case "auto-reduce-complexity":
 			// Convert int to bool: 0 = false, 1 = true
 			if value != 0 && value != 1 {
 				return fmt.Errorf("auto-reduce-complexity must be 0 (false) or 1 (true), got %d", value)
 			}
 			newConfig.AutoReduceComplexity = value == 1
+		case "allow-unverified-completion":
+			if value != 0 && value != 1 {
+				return fmt.Errorf("allow-unverified-completion must be 0 (false) or 1 (true), got %d", value)
+			}
+			newConfig.AllowUnverifiedCompletion = value == 1
+		case "priority-inheritance":
+			if value != 0 && value != 1 {
+				return fmt.Errorf("priority-inheritance must be 0 (false) or 1 (true), got %d", value)
+			}
+			newConfig.PriorityInheritance = value == 1
+		case "require-handoff-notes":
+			if value != 0 && value != 1 {
+				return fmt.Errorf("require-handoff-notes must be 0 (false) or 1 (true), got %d", value)
+			}
+			newConfig.RequireHandoffNotes = value == 1
+		case "require-review":
+			if value != 0 && value != 1 {
+				return fmt.Errorf("require-review must be 0 (false) or 1 (true), got %d", value)
+			}
+			// Applies to the selected project only
+			projectID, err := shared.ResolveProjectID(c, appCtx)
+			if err != nil {
+				return err
+			}
+			newConfig.SetReviewRequired(projectID, value == 1)
 		default:
-			return fmt.Errorf("unknown configuration key: %s. Valid keys: complexity-threshold, max-depth, max-tasks-per-depth, max-description-length, auto-reduce-complexity", key)
+			if depth, ok := depthLimitKey(key); ok {
+				if depth > newConfig.MaxDepth {
+					return fmt.Errorf("%s: depth %d exceeds max-depth %d", key, depth, newConfig.MaxDepth)
+				}
+				if value < 0 {
+					return fmt.Errorf("%s must be 0 (use max-tasks-per-depth) or a number of tasks, got %d", key, value)
+				}
+				newConfig.SetMaxTasksAt(depth, value)
+				break
+			}
+			return fmt.Errorf("unknown configuration key: %s. Valid keys: complexity-threshold, max-depth, max-tasks-per-depth, max-tasks-at-depth-<n>, allow-large-breakdowns, max-tasks-per-project, max-dependencies-per-task, max-description-length, max-title-length, allow-markup, duplicate-check, duplicate-threshold, progress-weighting, blocked-escalation-days, slow-query-threshold, auto-reduce-complexity, allow-unverified-completion, require-review, priority-inheritance, require-handoff-notes", key)
 		}
 
 		// Update and save config
@@ -115,11 +340,24 @@ func SetAction(appCtx *shared.AppContext) cli.ActionFunc {
 			return fmt.Errorf("failed to save configuration: %w", err)
 		}
 
-		fmt.Printf("Configuration updated: %s = %d\n", key, value)
+		appCtx.Out().Printf("Configuration updated: %s = %d\n", key, value)
 		return nil
 	}
 }
 
+// depthLimitKey parses the depth of a max-tasks-at-depth-<n> key
+func depthLimitKey(key string) (int, bool) {
+	suffix, ok := strings.CutPrefix(key, "max-tasks-at-depth-")
+	if !ok {
+		return 0, false
+	}
+	depth, err := strconv.Atoi(suffix)
+	if err != nil || depth < 0 {
+		return 0, false
+	}
+	return depth, true
+}
+
 // ResetAction resets configuration to defaults
 func ResetAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
@@ -132,13 +370,84 @@ func ResetAction(appCtx *shared.AppContext) cli.ActionFunc {
 			return fmt.Errorf("failed to save configuration: %w", err)
 		}
 
-		fmt.Println("Configuration reset to defaults:")
-		fmt.Printf("  Complexity Threshold:    %d\n", defaultConfig.ComplexityThreshold)
-		fmt.Printf("  Max Depth:               %d\n", defaultConfig.MaxDepth)
-		fmt.Printf("  Max Tasks Per Depth:     %d\n", defaultConfig.MaxTasksPerDepth)
-		fmt.Printf("  Max Description Length:  %d\n", defaultConfig.MaxDescriptionLength)
-		fmt.Printf("  Auto Reduce Complexity:  %t\n", defaultConfig.AutoReduceComplexity)
+		appCtx.Out().Println("Configuration reset to defaults:")
+		appCtx.Out().Printf("  Complexity Threshold:    %d\n", defaultConfig.ComplexityThreshold)
+		appCtx.Out().Printf("  Max Depth:               %d\n", defaultConfig.MaxDepth)
+		appCtx.Out().Printf("  Max Tasks Per Depth:     %d\n", defaultConfig.MaxTasksPerDepth)
+		appCtx.Out().Printf("  Max Tasks Per Project:   %d\n", defaultConfig.ProjectTaskLimit())
+		appCtx.Out().Printf("  Max Dependencies Per Task: %d\n", defaultConfig.DependencyLimit())
+		appCtx.Out().Printf("  Max Description Length:  %d\n", defaultConfig.MaxDescriptionLength)
+		appCtx.Out().Printf("  Progress Weighting:      %s\n", defaultConfig.ProgressWeightingMode())
+		appCtx.Out().Printf("  Blocked Escalation:      %d days\n", defaultConfig.BlockedEscalationThreshold())
+		appCtx.Out().Printf("  Slow Query Threshold:    %s\n", defaultConfig.SlowQueryThreshold())
+		appCtx.Out().Printf("  Auto Reduce Complexity:  %t\n", defaultConfig.AutoReduceComplexity)
+		appCtx.Out().Printf("  Allow Unverified Completion: %t\n", defaultConfig.AllowUnverifiedCompletion)
+		appCtx.Out().Printf("  Priority Inheritance:    %t\n", defaultConfig.PriorityInheritance)
+		appCtx.Out().Printf("  Require Handoff Notes:   %t\n", defaultConfig.RequireHandoffNotes)
 
 		return nil
 	}
 }
+
+// ExportAction writes the configuration to a file or stdout
+func ExportAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		path := c.String("file")
+		data, err := config.Export(appCtx.ProjectManager.GetConfig(), config.FormatFor(path))
+		if err != nil {
+			return err
+		}
+
+		if path == "" {
+			appCtx.Out().Print(string(data))
+			return nil
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write configuration: %w", err)
+		}
+		appCtx.Out().Printf("Configuration exported to %s\n", path)
+		return nil
+	}
+}
+
+// ImportAction applies an exported configuration and saves it
+func ImportAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		path := c.String("file")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return errors.NewValidationError("cannot read configuration file", err)
+		}
+
+		current := appCtx.ProjectManager.GetConfig()
+		base := current
+		if c.Bool("replace") {
+			base = manager.DefaultConfig()
+		}
+		imported, err := config.Import(data, base)
+		if err != nil {
+			return errors.NewValidationError("invalid configuration file", fmt.Errorf("%s: %w", path, err))
+		}
+
+		changed, err := config.ChangedSettings(current, imported)
+		if err != nil {
+			return err
+		}
+		if len(changed) == 0 {
+			appCtx.Out().Println("Configuration is already up to date")
+			return nil
+		}
+
+		if c.Bool("dry-run") {
+			appCtx.Out().Printf("Importing %s would change: %s\n", path, strings.Join(changed, ", "))
+			return nil
+		}
+
+		appCtx.ProjectManager.UpdateConfig(imported)
+		if err := appCtx.ProjectManager.SaveConfigToFile(); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+		appCtx.Out().Printf("Imported %s, changed: %s\n", path, strings.Join(changed, ", "))
+		return nil
+	}
+}
@@ -15,6 +15,20 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
+// TestMain runs the tests in a temporary working directory, since the commands save .knot/config.json in the current directory
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "knot-config-test")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 func TestCommands(t *testing.T) {
 	// Create a test app context
 	config := manager.DefaultConfig()
@@ -24,7 +38,7 @@ func TestCommands(t *testing.T) {
 
 	commands := Commands(appCtx)
 
-	assert.Len(t, commands, 3)
+	assert.Len(t, commands, 5)
 
 	commandNames := make(map[string]*cli.Command)
 	for _, cmd := range commands {
@@ -34,6 +48,8 @@ func TestCommands(t *testing.T) {
 	assert.Contains(t, commandNames, "show")
 	assert.Contains(t, commandNames, "set")
 	assert.Contains(t, commandNames, "reset")
+	assert.Contains(t, commandNames, "export")
+	assert.Contains(t, commandNames, "import")
 }
 
 func TestShowAction(t *testing.T) {
@@ -544,7 +560,7 @@ func TestCommandsStructure(t *testing.T) {
 
 	commands := Commands(appCtx)
 
-	assert.Len(t, commands, 3)
+	assert.Len(t, commands, 5)
 
 	// Check each command has the expected structure
 	for _, cmd := range commands {
@@ -559,6 +575,9 @@ func TestCommandsStructure(t *testing.T) {
 		case "reset":
 			assert.Equal(t, "Reset configuration to defaults", cmd.Usage)
 			assert.NotNil(t, cmd.Action)
+		case "export", "import":
+			assert.NotNil(t, cmd.Action)
+			assert.NotEmpty(t, cmd.Flags)
 		default:
 			t.Fatalf("Unexpected command: %s", cmd.Name)
 		}
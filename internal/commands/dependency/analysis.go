@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
@@ -36,28 +37,28 @@ func chainAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.Bool("downstream", downstream))
 
 		// Get the original task
-		task, err := appCtx.ProjectManager.GetTask(context.Background(), taskID)
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get task", zap.Error(err))
 			return fmt.Errorf("failed to get task: %w", err)
 		}
 
-		fmt.Printf("Dependency chain for '%s' (ID: %s):\n\n", task.Title, taskID)
+		appCtx.Out().Printf("Dependency chain for '%s' (ID: %s):\n\n", task.Title, taskID)
 
 		if upstream {
-			fmt.Println("📈 UPSTREAM DEPENDENCIES (what this task depends on):")
-			if err := showUpstreamChain(appCtx.ProjectManager, taskID, 0); err != nil {
+			appCtx.Out().Println(output.Icon("📈") + "UPSTREAM DEPENDENCIES (what this task depends on):")
+			if err := showUpstreamChain(c.Context, appCtx.Out(), appCtx.ProjectManager, taskID, 0); err != nil {
 				return fmt.Errorf("failed to show upstream chain: %w", err)
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 		}
 
 		if downstream {
-			fmt.Println("📉 DOWNSTREAM DEPENDENCIES (what depends on this task):")
-			if err := showDownstreamChain(appCtx.ProjectManager, taskID, 0); err != nil {
+			appCtx.Out().Println(output.Icon("📉") + "DOWNSTREAM DEPENDENCIES (what depends on this task):")
+			if err := showDownstreamChain(c.Context, appCtx.Out(), appCtx.ProjectManager, taskID, 0); err != nil {
 				return fmt.Errorf("failed to show downstream chain: %w", err)
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 		}
 
 		return nil
@@ -65,15 +66,15 @@ func chainAction(appCtx *shared.AppContext) cli.ActionFunc {
 }
 
 // showUpstreamChain recursively shows what a task depends on
-func showUpstreamChain(projectManager manager.ProjectManager, taskID uuid.UUID, depth int) error {
-	dependencies, err := projectManager.GetTaskDependencies(context.Background(), taskID)
+func showUpstreamChain(ctx context.Context, out output.Writer, projectManager manager.ProjectManager, taskID uuid.UUID, depth int) error {
+	dependencies, err := projectManager.GetTaskDependencies(ctx, taskID)
 	if err != nil {
 		return err
 	}
 
 	if len(dependencies) == 0 {
 		if depth == 0 {
-			fmt.Println("  No upstream dependencies")
+			out.Println("  No upstream dependencies")
 		}
 		return nil
 	}
@@ -83,10 +84,10 @@ func showUpstreamChain(projectManager manager.ProjectManager, taskID uuid.UUID,
 		for i := 0; i < depth; i++ {
 			indent += "  "
 		}
-		fmt.Printf("%s  ├─ %s (ID: %s) - %s\n", indent, dep.Title, dep.ID, dep.State)
+		out.Printf("%s  ├─ %s (ID: %s) - %s\n", indent, dep.Title, dep.ID, dep.State)
 
 		// Recursively show dependencies of this dependency
-		if err := showUpstreamChain(projectManager, dep.ID, depth+1); err != nil {
+		if err := showUpstreamChain(ctx, out, projectManager, dep.ID, depth+1); err != nil {
 			return err
 		}
 	}
@@ -95,15 +96,15 @@ func showUpstreamChain(projectManager manager.ProjectManager, taskID uuid.UUID,
 }
 
 // showDownstreamChain recursively shows what depends on a task
-func showDownstreamChain(projectManager manager.ProjectManager, taskID uuid.UUID, depth int) error {
-	dependents, err := projectManager.GetDependentTasks(context.Background(), taskID)
+func showDownstreamChain(ctx context.Context, out output.Writer, projectManager manager.ProjectManager, taskID uuid.UUID, depth int) error {
+	dependents, err := projectManager.GetDependentTasks(ctx, taskID)
 	if err != nil {
 		return err
 	}
 
 	if len(dependents) == 0 {
 		if depth == 0 {
-			fmt.Println("  No downstream dependencies")
+			out.Println("  No downstream dependencies")
 		}
 		return nil
 	}
@@ -113,10 +114,10 @@ func showDownstreamChain(projectManager manager.ProjectManager, taskID uuid.UUID
 		for i := 0; i < depth; i++ {
 			indent += "  "
 		}
-		fmt.Printf("%s  ├─ %s (ID: %s) - %s\n", indent, dep.Title, dep.ID, dep.State)
+		out.Printf("%s  ├─ %s (ID: %s) - %s\n", indent, dep.Title, dep.ID, dep.State)
 
 		// Recursively show dependents of this dependent
-		if err := showDownstreamChain(projectManager, dep.ID, depth+1); err != nil {
+		if err := showDownstreamChain(ctx, out, projectManager, dep.ID, depth+1); err != nil {
 			return err
 		}
 	}
@@ -141,7 +142,7 @@ func cyclesAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.Bool("autoFix", autoFix))
 
 		// Get all tasks in the project
-		tasks, err := appCtx.ProjectManager.ListTasksForProject(context.Background(), projectID)
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get project tasks", zap.Error(err))
 			return fmt.Errorf("failed to get project tasks: %w", err)
@@ -194,25 +195,25 @@ func cyclesAction(appCtx *shared.AppContext) cli.ActionFunc {
 			if err != nil {
 				return fmt.Errorf("failed to marshal JSON result: %w", err)
 			}
-			fmt.Println(string(data))
+			appCtx.Out().Println(string(data))
 			return nil
 		}
 
 		// Text output
 		shared.ShowProjectContextWithSeparator(c, appCtx)
-		fmt.Printf("Circular dependency analysis for project %s:\n\n", projectID)
+		appCtx.Out().Printf("Circular dependency analysis for project %s:\n\n", projectID)
 
 		if len(cycles) == 0 {
-			fmt.Println("✅ No circular dependencies detected!")
-			fmt.Printf("📊 Analyzed %d tasks with %d total dependencies\n",
+			appCtx.Out().Println(output.Icon("✅") + "No circular dependencies detected!")
+			appCtx.Out().Printf("%sAnalyzed %d tasks with %d total dependencies\n", output.Icon("📊"),
 				len(tasks), countTotalDependencies(tasks))
 			return nil
 		}
 
-		fmt.Printf("⚠️  Found %d circular dependency cycle(s):\n\n", len(cycles))
+		appCtx.Out().Printf("%sFound %d circular dependency cycle(s):\n\n", output.Icon("⚠️"), len(cycles))
 
 		for i, cycle := range cycles {
-			fmt.Printf("Cycle %d (%d tasks):\n", i+1, len(cycle))
+			appCtx.Out().Printf("Cycle %d (%d tasks):\n", i+1, len(cycle))
 			for j, taskID := range cycle {
 				// Find task details
 				var task *types.Task
@@ -228,20 +229,20 @@ func cyclesAction(appCtx *shared.AppContext) cli.ActionFunc {
 					if j == len(cycle)-1 {
 						arrow = "└─"
 					}
-					fmt.Printf("  %s %s (ID: %s) [%s]\n", arrow, task.Title, taskID, task.State)
+					appCtx.Out().Printf("  %s %s (ID: %s) [%s]\n", arrow, task.Title, taskID, task.State)
 				} else {
-					fmt.Printf("  ├─ Unknown task (ID: %s)\n", taskID)
+					appCtx.Out().Printf("  ├─ Unknown task (ID: %s)\n", taskID)
 				}
 			}
-			fmt.Printf("  └─ Back to: %s\n\n", cycle[0])
+			appCtx.Out().Printf("  └─ Back to: %s\n\n", cycle[0])
 		}
 
-		fmt.Printf("💡 Recommendations:\n")
-		fmt.Printf("  1. Review the cycles above and remove unnecessary dependencies\n")
-		fmt.Printf("  2. Consider breaking circular dependencies by creating intermediate tasks\n")
-		fmt.Printf("  3. Use 'knot dependency validate' for more detailed analysis\n")
+		appCtx.Out().Advise("%sRecommendations:\n", output.Icon("💡"))
+		appCtx.Out().Advise("  1. Review the cycles above and remove unnecessary dependencies\n")
+		appCtx.Out().Advise("  2. Consider breaking circular dependencies by creating intermediate tasks\n")
+		appCtx.Out().Advise("  3. Use 'knot dependency validate' for more detailed analysis\n")
 		if autoFix {
-			fmt.Printf("  4. Auto-fix requested - this feature is not yet implemented\n")
+			appCtx.Out().Advise("  4. Auto-fix requested - this feature is not yet implemented\n")
 		}
 
 		return nil
@@ -326,13 +327,13 @@ func validateAction(appCtx *shared.AppContext) cli.ActionFunc {
 		appCtx.Logger.Info("Validating dependencies", zap.String("projectID", projectID.String()))
 
 		// Get all tasks in the project
-		tasks, err := appCtx.ProjectManager.ListTasksForProject(context.Background(), projectID)
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get project tasks", zap.Error(err))
 			return fmt.Errorf("failed to get project tasks: %w", err)
 		}
 
-		fmt.Printf("Dependency validation for project %s:\n\n", projectID)
+		appCtx.Out().Printf("Dependency validation for project %s:\n\n", projectID)
 
 		// Create task map for quick lookup
 		taskMap := make(map[uuid.UUID]*types.Task)
@@ -360,30 +361,30 @@ func validateAction(appCtx *shared.AppContext) cli.ActionFunc {
 		cycles := detectCycles(tasks)
 
 		// Report results
-		fmt.Printf("📊 VALIDATION SUMMARY:\n")
-		fmt.Printf("  Total tasks: %d\n", len(tasks))
-		fmt.Printf("  Total dependencies: %d\n", totalDeps)
-		fmt.Printf("  Orphaned dependencies: %d\n", orphanedDeps)
-		fmt.Printf("  Circular dependencies: %d\n", len(cycles))
-		fmt.Println()
+		appCtx.Out().Print(output.Icon("📊") + "VALIDATION SUMMARY:\n")
+		appCtx.Out().Printf("  Total tasks: %d\n", len(tasks))
+		appCtx.Out().Printf("  Total dependencies: %d\n", totalDeps)
+		appCtx.Out().Printf("  Orphaned dependencies: %d\n", orphanedDeps)
+		appCtx.Out().Printf("  Circular dependencies: %d\n", len(cycles))
+		appCtx.Out().Println()
 
 		if len(issues) == 0 && len(cycles) == 0 {
-			fmt.Println("✅ All dependencies are valid!")
+			appCtx.Out().Println(output.Icon("✅") + "All dependencies are valid!")
 			return nil
 		}
 
 		if len(issues) > 0 {
-			fmt.Printf("⚠️  ORPHANED DEPENDENCIES (%d):\n", len(issues))
+			appCtx.Out().Printf("%sORPHANED DEPENDENCIES (%d):\n", output.Icon("⚠️"), len(issues))
 			for i, issue := range issues {
-				fmt.Printf("  %d. %s\n", i+1, issue)
+				appCtx.Out().Printf("  %d. %s\n", i+1, issue)
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 		}
 
 		if len(cycles) > 0 {
-			fmt.Printf("⚠️  CIRCULAR DEPENDENCIES (%d cycles detected)\n", len(cycles))
-			fmt.Println("  Run 'knot dependency cycles' for detailed cycle information")
-			fmt.Println()
+			appCtx.Out().Printf("%sCIRCULAR DEPENDENCIES (%d cycles detected)\n", output.Icon("⚠️"), len(cycles))
+			appCtx.Out().Println("  Run 'knot dependency cycles' for detailed cycle information")
+			appCtx.Out().Println()
 		}
 
 		return nil
@@ -1,11 +1,14 @@
 package dependency
 
 import (
-	"context"
 	"fmt"
+	"slices"
 
 	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
 	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
 	"github.com/google/uuid"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
@@ -30,6 +33,29 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 					Usage:    "Task ID that this task depends on",
 					Required: true,
 				},
+				newTypeFlag(),
+				newLagFlag(),
+			},
+		},
+		{
+			Name:  "set",
+			Usage: "Change the type or lag of a task dependency",
+			Description: `Changes an existing dependency. Only the given flags are changed, e.g.
+'knot dependency set --task-id X --depends-on Y --lag 1d' keeps the type.`,
+			Action: setAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "task-id",
+					Usage:    "Task ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "depends-on",
+					Usage:    "Task ID that this task depends on",
+					Required: true,
+				},
+				newTypeFlag(),
+				newLagFlag(),
 			},
 		},
 		{
@@ -63,6 +89,8 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 		},
 	}
 
+	basicCommands = append(basicCommands, GraphCommands(appCtx)...)
+
 	// Enhanced commands
 	enhancedCommands := EnhancedCommands(appCtx)
 
@@ -88,22 +116,94 @@ func addAction(appCtx *shared.AppContext) cli.ActionFunc {
 			return errors.InvalidUUIDError("depends-on", dependsOnStr)
 		}
 
+		link := types.DependencyLink{DependsOnID: dependsOnID, Type: types.DependencyFinishToStart}
+		if err := applyLinkFlags(c, &link); err != nil {
+			return err
+		}
+
 		actor := c.String("actor")
 
 		appCtx.Logger.Info("Adding task dependency",
 			zap.String("taskID", taskID.String()),
 			zap.String("dependsOnID", dependsOnID.String()),
+			zap.String("type", string(link.Type)),
+			zap.Int64("lag", link.Lag),
 			zap.String("actor", actor))
 
-		_, err = appCtx.ProjectManager.AddTaskDependency(context.Background(), taskID, dependsOnID, actor)
+		task, err := appCtx.ProjectManager.AddTaskDependency(c.Context, taskID, dependsOnID, actor)
 		if err != nil {
 			appCtx.Logger.Error("Failed to add dependency", zap.Error(err))
 			return errors.WrapWithSuggestion(err, "adding task dependency")
 		}
 
+		if !link.IsDefault() {
+			if _, err := appCtx.ProjectManager.SetDependencyLink(c.Context, taskID, link, actor); err != nil {
+				appCtx.Logger.Error("Failed to set dependency type", zap.Error(err))
+				return errors.WrapWithSuggestion(err, "setting dependency type")
+			}
+		}
+
 		appCtx.Logger.Info("Dependency added successfully", zap.String("actor", actor))
-		fmt.Printf("Added dependency: %s now depends on %s\n", taskID, dependsOnID)
-		fmt.Printf("  Added by: %s\n", actor)
+		appCtx.Out().Printf("Added dependency: %s now depends on %s%s\n", taskID, dependsOnID, formatLink(link))
+		appCtx.Out().Printf("  Added by: %s\n", actor)
+
+		// Warn on stderr, adding dependencies fails once the limit is reached
+		if limit := appCtx.ProjectManager.GetConfig().DependencyLimit(); manager.NearLimit(len(task.Dependencies), limit) {
+			fmt.Fprintf(c.App.ErrWriter, "Warning: task '%s' has %d of at most %d dependencies. Consider depending on a parent task grouping the prerequisites.\n",
+				task.Title, len(task.Dependencies), limit)
+		}
+		return nil
+	}
+}
+
+func setAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskIDStr := c.String("task-id")
+		taskID, err := uuid.Parse(taskIDStr)
+		if err != nil {
+			return errors.InvalidUUIDError("task-id", taskIDStr)
+		}
+
+		dependsOnStr := c.String("depends-on")
+		dependsOnID, err := uuid.Parse(dependsOnStr)
+		if err != nil {
+			return errors.InvalidUUIDError("depends-on", dependsOnStr)
+		}
+
+		if !c.IsSet("type") && !c.IsSet("lag") {
+			return errors.NewValidationError("nothing to change, pass --type and/or --lag", nil)
+		}
+
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
+		if err != nil {
+			return errors.TaskNotFoundError(taskID)
+		}
+		if !slices.Contains(task.Dependencies, dependsOnID) {
+			return errors.NewValidationError(fmt.Sprintf("task %s does not depend on %s", taskID, dependsOnID), nil)
+		}
+
+		link := task.DependencyLink(dependsOnID)
+		if err := applyLinkFlags(c, &link); err != nil {
+			return err
+		}
+
+		actor := c.String("actor")
+
+		appCtx.Logger.Info("Updating task dependency",
+			zap.String("taskID", taskID.String()),
+			zap.String("dependsOnID", dependsOnID.String()),
+			zap.String("type", string(link.Type)),
+			zap.Int64("lag", link.Lag),
+			zap.String("actor", actor))
+
+		if _, err := appCtx.ProjectManager.SetDependencyLink(c.Context, taskID, link, actor); err != nil {
+			appCtx.Logger.Error("Failed to update dependency", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "updating task dependency")
+		}
+
+		appCtx.Out().Printf("Updated dependency: %s depends on %s (%s, lag %s)\n",
+			taskID, dependsOnID, link.Type, utils.FormatEstimate(link.Lag))
+		appCtx.Out().Printf("  Updated by: %s\n", actor)
 		return nil
 	}
 }
@@ -129,15 +229,15 @@ func removeAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.String("dependsOnID", dependsOnID.String()),
 			zap.String("actor", actor))
 
-		_, err = appCtx.ProjectManager.RemoveTaskDependency(context.Background(), taskID, dependsOnID, actor)
+		_, err = appCtx.ProjectManager.RemoveTaskDependency(c.Context, taskID, dependsOnID, actor)
 		if err != nil {
 			appCtx.Logger.Error("Failed to remove dependency", zap.Error(err))
 			return fmt.Errorf("failed to remove dependency: %w", err)
 		}
 
 		appCtx.Logger.Info("Dependency removed successfully", zap.String("actor", actor))
-		fmt.Printf("Removed dependency: %s no longer depends on %s\n", taskID, dependsOnID)
-		fmt.Printf("  Removed by: %s\n", actor)
+		appCtx.Out().Printf("Removed dependency: %s no longer depends on %s\n", taskID, dependsOnID)
+		appCtx.Out().Printf("  Removed by: %s\n", actor)
 		return nil
 	}
 }
@@ -152,13 +252,13 @@ func listAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		appCtx.Logger.Info("Listing task dependencies", zap.String("taskID", taskID.String()))
 
-		dependencies, err := appCtx.ProjectManager.GetTaskDependencies(context.Background(), taskID)
+		dependencies, err := appCtx.ProjectManager.GetTaskDependencies(c.Context, taskID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get dependencies", zap.Error(err))
 			return fmt.Errorf("failed to get dependencies: %w", err)
 		}
 
-		dependents, err := appCtx.ProjectManager.GetDependentTasks(context.Background(), taskID)
+		dependents, err := appCtx.ProjectManager.GetDependentTasks(c.Context, taskID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get dependents", zap.Error(err))
 			return fmt.Errorf("failed to get dependents: %w", err)
@@ -168,28 +268,101 @@ func listAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.Int("dependencies", len(dependencies)),
 			zap.Int("dependents", len(dependents)))
 
-		fmt.Printf("Dependencies for task %s:\n\n", taskID)
+		// Dependency types and lags are stored on the dependent task
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to get task", zap.Error(err))
+			return fmt.Errorf("failed to get task: %w", err)
+		}
+		dependentsWithLinks := make(map[uuid.UUID]*types.Task, len(dependents))
+		if len(dependents) > 0 {
+			ids := make([]uuid.UUID, len(dependents))
+			for i, dep := range dependents {
+				ids[i] = dep.ID
+			}
+			loaded, err := appCtx.ProjectManager.GetTasksWithDependencies(c.Context, ids)
+			if err != nil {
+				appCtx.Logger.Error("Failed to load dependents", zap.Error(err))
+				return fmt.Errorf("failed to load dependents: %w", err)
+			}
+			for _, dep := range loaded {
+				dependentsWithLinks[dep.ID] = dep
+			}
+		}
+
+		appCtx.Out().Printf("Dependencies for task %s:\n\n", taskID)
 
 		if len(dependencies) > 0 {
-			fmt.Println("This task depends on:")
+			appCtx.Out().Println("This task depends on:")
 			for _, dep := range dependencies {
-				fmt.Printf("  • %s (ID: %s) - %s\n", dep.Title, dep.ID, dep.State)
+				appCtx.Out().Printf("  • %s (ID: %s) - %s%s\n", dep.Title, dep.ID, dep.State, formatLink(task.DependencyLink(dep.ID)))
 			}
 		} else {
-			fmt.Println("This task has no dependencies.")
+			appCtx.Out().Println("This task has no dependencies.")
 		}
 
-		fmt.Println()
+		appCtx.Out().Println()
 
 		if len(dependents) > 0 {
-			fmt.Println("Tasks that depend on this task:")
+			appCtx.Out().Println("Tasks that depend on this task:")
 			for _, dep := range dependents {
-				fmt.Printf("  • %s (ID: %s) - %s\n", dep.Title, dep.ID, dep.State)
+				suffix := ""
+				if loaded, ok := dependentsWithLinks[dep.ID]; ok {
+					suffix = formatLink(loaded.DependencyLink(taskID))
+				}
+				appCtx.Out().Printf("  • %s (ID: %s) - %s%s\n", dep.Title, dep.ID, dep.State, suffix)
 			}
 		} else {
-			fmt.Println("No tasks depend on this task.")
+			appCtx.Out().Println("No tasks depend on this task.")
 		}
 
 		return nil
 	}
 }
+
+func newTypeFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:  "type",
+		Usage: "Dependency type: finish-to-start (start once the dependency is completed) or start-to-start (start once it has started)",
+		Value: string(types.DependencyFinishToStart),
+	}
+}
+
+func newLagFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:  "lag",
+		Usage: "Wait after the dependency is completed or has started (e.g. 4h, 2d)",
+	}
+}
+
+// applyLinkFlags sets the type and lag of the link from the --type and --lag
+// flags that were given
+func applyLinkFlags(c *cli.Context, link *types.DependencyLink) error {
+	if c.IsSet("type") {
+		link.Type = types.DependencyType(c.String("type"))
+		if !link.Type.IsValid() {
+			return errors.NewValidationError(fmt.Sprintf("invalid --type %q, must be %s or %s",
+				c.String("type"), types.DependencyFinishToStart, types.DependencyStartToStart), nil)
+		}
+	}
+	if c.IsSet("lag") {
+		lag, err := utils.ParseEstimate(c.String("lag"))
+		if err != nil {
+			return errors.NewValidationError("invalid --lag", err)
+		}
+		link.Lag = lag
+	}
+	return nil
+}
+
+// formatLink renders the type and lag of a dependency for listings, or "" for
+// a plain finish-to-start dependency
+func formatLink(link types.DependencyLink) string {
+	if link.IsDefault() {
+		return ""
+	}
+	if link.Lag == 0 {
+		return fmt.Sprintf(" [%s]", link.Type)
+	}
+	return fmt.Sprintf(" [%s, lag %s]", link.Type, utils.FormatEstimate(link.Lag))
+}
@@ -97,9 +97,9 @@ func dependentsAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		var dependents []*types.Task
 		if recursive {
-			dependents, err = getAllTransitiveDependents(appCtx.ProjectManager, taskID)
+			dependents, err = getAllTransitiveDependents(c.Context, appCtx.ProjectManager, taskID)
 		} else {
-			dependents, err = appCtx.ProjectManager.GetDependentTasks(context.Background(), taskID)
+			dependents, err = appCtx.ProjectManager.GetDependentTasks(c.Context, taskID)
 		}
 
 		if err != nil {
@@ -108,16 +108,16 @@ func dependentsAction(appCtx *shared.AppContext) cli.ActionFunc {
 		}
 
 		// Get the original task for context
-		task, err := appCtx.ProjectManager.GetTask(context.Background(), taskID)
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get task", zap.Error(err))
 			return fmt.Errorf("failed to get task: %w", err)
 		}
 
-		fmt.Printf("Tasks that depend on '%s' (ID: %s):\n\n", task.Title, taskID)
+		appCtx.Out().Printf("Tasks that depend on '%s' (ID: %s):\n\n", task.Title, taskID)
 
 		if len(dependents) == 0 {
-			fmt.Println("No tasks depend on this task.")
+			appCtx.Out().Println("No tasks depend on this task.")
 			return nil
 		}
 
@@ -130,25 +130,25 @@ func dependentsAction(appCtx *shared.AppContext) cli.ActionFunc {
 		})
 
 		for i, dep := range dependents {
-			fmt.Printf("%d. %s (ID: %s)\n", i+1, dep.Title, dep.ID)
+			appCtx.Out().Printf("%d. %s (ID: %s)\n", i+1, dep.Title, dep.ID)
 			if dep.Description != "" {
-				fmt.Printf("   %s\n", dep.Description)
+				appCtx.Out().Printf("   %s\n", dep.Description)
 			}
-			fmt.Printf("   State: %s | Complexity: %d\n", dep.State, dep.Complexity)
+			appCtx.Out().Printf("   State: %s | Complexity: %d\n", dep.State, dep.Complexity)
 			if dep.Depth > 0 {
-				fmt.Printf("   Depth: %d", dep.Depth)
+				appCtx.Out().Printf("   Depth: %d", dep.Depth)
 				if dep.ParentID != nil {
-					fmt.Printf(" | Parent: %s", *dep.ParentID)
+					appCtx.Out().Printf(" | Parent: %s", *dep.ParentID)
 				}
-				fmt.Println()
+				appCtx.Out().Println()
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 		}
 
 		if recursive {
-			fmt.Printf("Total: %d transitive dependents\n", len(dependents))
+			appCtx.Out().Printf("Total: %d transitive dependents\n", len(dependents))
 		} else {
-			fmt.Printf("Total: %d direct dependents\n", len(dependents))
+			appCtx.Out().Printf("Total: %d direct dependents\n", len(dependents))
 		}
 
 		return nil
@@ -156,7 +156,7 @@ func dependentsAction(appCtx *shared.AppContext) cli.ActionFunc {
 }
 
 // getAllTransitiveDependents recursively gets all tasks that depend on the given task
-func getAllTransitiveDependents(projectManager manager.ProjectManager, taskID uuid.UUID) ([]*types.Task, error) {
+func getAllTransitiveDependents(ctx context.Context, projectManager manager.ProjectManager, taskID uuid.UUID) ([]*types.Task, error) {
 	visited := make(map[uuid.UUID]bool)
 	var result []*types.Task
 
@@ -167,7 +167,7 @@ func getAllTransitiveDependents(projectManager manager.ProjectManager, taskID uu
 		}
 		visited[id] = true
 
-		dependents, err := projectManager.GetDependentTasks(context.Background(), id)
+		dependents, err := projectManager.GetDependentTasks(ctx, id)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,234 @@
+package dependency
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/interchange"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// GraphCommands returns the commands exporting and importing the dependency
+// graph of a project
+func GraphCommands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "export",
+			Usage: "Export the dependency graph of the project as JSON or DOT",
+			Description: `Writes the dependencies between the tasks of the selected project, without
+the tasks themselves. The JSON document can be edited and applied again with
+'knot dependency import'; DOT renders with Graphviz:
+
+  knot dependency export --format dot | dot -Tsvg > deps.svg`,
+			Action: exportGraphAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "format",
+					Usage: "Output format (json, dot)",
+					Value: "json",
+				},
+				&cli.StringFlag{
+					Name:  "out",
+					Usage: "Write to file instead of stdout",
+				},
+			},
+		},
+		{
+			Name:  "import",
+			Usage: "Apply a dependency graph to the existing tasks of the project",
+			Description: `Reads a JSON document in the format of 'knot dependency export' and adds its
+dependencies to the tasks of the selected project. Only the dependencies are
+required; tasks are referenced by ID, by a unique ID prefix such as the short
+ID of 'knot task list --compact', or by title:
+
+  {"dependencies": [
+    {"task": "Write docs", "depends_on": "Implement API"},
+    {"task": "Release", "depends_on": "01a14384", "type": "start-to-start", "lag": 60}
+  ]}
+
+Every reference is checked before anything changes. Existing dependencies get
+the given type and lag; with --replace the dependencies missing from the file
+are removed.`,
+			Action: importGraphAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "file",
+					Aliases:  []string{"f"},
+					Usage:    "Dependency graph file to import",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  "replace",
+					Usage: "Remove the dependencies of the project that are not in the file",
+				},
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Show what would change without changing anything",
+				},
+			},
+		},
+	}
+}
+
+func exportGraphAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		var render func(io.Writer, *interchange.DependencyGraph) error
+		switch format := c.String("format"); format {
+		case "json":
+			render = interchange.RenderGraphJSON
+		case "dot":
+			render = interchange.RenderGraphDOT
+		default:
+			return errors.NewValidationError("invalid --format", fmt.Errorf("unknown format '%s', use json or dot", format))
+		}
+
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+		tasks, err := appCtx.ProjectManager.ListTasksWithDependencies(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		graph := interchange.BuildDependencyGraph(projectID, tasks)
+
+		appCtx.Logger.Info("Exporting dependency graph",
+			zap.String("format", c.String("format")),
+			zap.String("projectID", projectID.String()),
+			zap.Int("dependencies", len(graph.Dependencies)))
+
+		outPath := c.String("out")
+		if outPath == "" {
+			return render(appCtx.Out(), graph)
+		}
+		file, err := os.Create(outPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+		if err := render(file, graph); err != nil {
+			return fmt.Errorf("failed to write dependency graph: %w", err)
+		}
+		appCtx.Out().Printf("Exported %d dependencies to %s\n", len(graph.Dependencies), outPath)
+		return nil
+	}
+}
+
+func importGraphAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(c.String("file"))
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		defer file.Close()
+		graph, err := interchange.ParseGraph(file)
+		if err != nil {
+			return errors.NewValidationError("invalid dependency graph file", err)
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksWithDependencies(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		edges, err := interchange.ResolveGraph(graph, tasks)
+		if err != nil {
+			return &errors.EnhancedError{
+				Operation:  "importing dependency graph",
+				Cause:      err,
+				Suggestion: "Reference the tasks by their full ID where titles or ID prefixes are ambiguous",
+				Example:    "knot dependency export  # see the IDs and titles",
+			}
+		}
+		changes := interchange.PlanGraphChanges(edges, tasks, c.Bool("replace"))
+
+		if c.Bool("dry-run") {
+			printGraphChanges(appCtx.Out(), changes)
+			appCtx.Out().Println("\nDry run mode - no dependencies were changed.")
+			return nil
+		}
+
+		actor := shared.ResolveActor(c.String("actor"))
+		appCtx.Logger.Info("Importing dependency graph",
+			zap.String("file", c.String("file")),
+			zap.String("projectID", projectID.String()),
+			zap.Int("add", len(changes.Add)),
+			zap.Int("update", len(changes.Update)),
+			zap.Int("remove", len(changes.Remove)),
+			zap.String("actor", actor))
+
+		// Removals go first so replaced edges do not count against the
+		// dependency limit or form cycles with the new ones
+		applied := 0
+		for _, edge := range changes.Remove {
+			if _, err := appCtx.ProjectManager.RemoveTaskDependency(c.Context, edge.Task.ID, edge.DependsOn.ID, actor); err != nil {
+				return graphImportError(applied, edge, "removing", err)
+			}
+			applied++
+		}
+		for _, edge := range changes.Update {
+			if _, err := appCtx.ProjectManager.SetDependencyLink(c.Context, edge.Task.ID, edge.Link, actor); err != nil {
+				return graphImportError(applied, edge, "updating", err)
+			}
+			applied++
+		}
+		for _, edge := range changes.Add {
+			if _, err := appCtx.ProjectManager.AddTaskDependency(c.Context, edge.Task.ID, edge.DependsOn.ID, actor); err != nil {
+				return graphImportError(applied, edge, "adding", err)
+			}
+			if !edge.Link.IsDefault() {
+				if _, err := appCtx.ProjectManager.SetDependencyLink(c.Context, edge.Task.ID, edge.Link, actor); err != nil {
+					return graphImportError(applied, edge, "adding", err)
+				}
+			}
+			applied++
+		}
+
+		appCtx.Out().Printf("Imported dependency graph from %s: %d added, %d updated, %d removed, %d unchanged\n",
+			c.String("file"), len(changes.Add), len(changes.Update), len(changes.Remove), changes.Unchanged)
+		appCtx.Out().Printf("  Changed by: %s\n", actor)
+		return nil
+	}
+}
+
+// graphImportError reports the change a graph import stopped at
+func graphImportError(applied int, edge interchange.ResolvedEdge, verb string, err error) error {
+	return fmt.Errorf("import stopped after %d changes, %s '%s' -> '%s': %w", applied, verb, edge.Task.Title, edge.DependsOn.Title, err)
+}
+
+// printGraphChanges lists the changes of a graph import
+func printGraphChanges(out output.Writer, changes *interchange.GraphChanges) {
+	if changes.IsEmpty() {
+		out.Printf("No changes, all %d dependencies exist.\n", changes.Unchanged)
+		return
+	}
+	sections := []struct {
+		title string
+		edges []interchange.ResolvedEdge
+	}{
+		{"Add", changes.Add},
+		{"Update", changes.Update},
+		{"Remove", changes.Remove},
+	}
+	for _, section := range sections {
+		if len(section.edges) == 0 {
+			continue
+		}
+		out.Printf("%s (%d):\n", section.title, len(section.edges))
+		for _, edge := range section.edges {
+			out.Printf("  %s -> %s%s\n", edge.Task.Title, edge.DependsOn.Title, formatLink(edge.Link))
+		}
+	}
+	out.Printf("Unchanged: %d\n", changes.Unchanged)
+}
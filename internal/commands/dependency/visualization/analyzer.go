@@ -29,7 +29,7 @@ func NewAnalyzer(projectManager manager.ProjectManager, tasks []*types.Task) *An
 }
 
 // AnalyzeTask performs comprehensive task analysis
-func (a *Analyzer) AnalyzeTask(taskID uuid.UUID) (*TaskAnalysisResult, error) {
+func (a *Analyzer) AnalyzeTask(ctx context.Context, taskID uuid.UUID) (*TaskAnalysisResult, error) {
 	task, exists := a.taskMap[taskID]
 	if !exists {
 		return nil, fmt.Errorf("task not found: %s", taskID)
@@ -40,13 +40,13 @@ func (a *Analyzer) AnalyzeTask(taskID uuid.UUID) (*TaskAnalysisResult, error) {
 	}
 
 	// Get upstream dependencies
-	if dependencies, err := a.projectManager.GetTaskDependencies(context.Background(), taskID); err == nil {
+	if dependencies, err := a.projectManager.GetTaskDependencies(ctx, taskID); err == nil {
 		result.UpstreamTasks = dependencies
 		result.Dependencies = a.buildRelationships(task, dependencies, RelationshipDependency)
 	}
 
 	// Get downstream dependents
-	if dependents, err := a.projectManager.GetDependentTasks(context.Background(), taskID); err == nil {
+	if dependents, err := a.projectManager.GetDependentTasks(ctx, taskID); err == nil {
 		result.DownstreamTasks = dependents
 		result.Dependents = a.buildRelationships(task, dependents, RelationshipBlocks)
 	}
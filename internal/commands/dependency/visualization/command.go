@@ -98,7 +98,7 @@ func (f *CommandFactory) createAction(appCtx *shared.AppContext) cli.ActionFunc
 			zap.Int("depth", config.MaxDepth))
 
 		// Execute visualization
-		return f.executeVisualization(appCtx, config)
+		return f.executeVisualization(c.Context, appCtx, config)
 	}
 }
 
@@ -151,26 +151,26 @@ func (f *CommandFactory) validateConfig(config *VisualizationConfig) error {
 }
 
 // executeVisualization executes the visualization based on configuration
-func (f *CommandFactory) executeVisualization(appCtx *shared.AppContext, config *VisualizationConfig) error {
+func (f *CommandFactory) executeVisualization(ctx context.Context, appCtx *shared.AppContext, config *VisualizationConfig) error {
 	// Get project tasks
 	projectID, err := uuid.Parse(config.ProjectID)
 	if err != nil {
 		return fmt.Errorf("invalid project ID: %w", err)
 	}
 
-	tasks, err := appCtx.ProjectManager.ListTasksForProject(context.Background(), projectID)
+	tasks, err := appCtx.ProjectManager.ListTasksForProject(ctx, projectID)
 	if err != nil {
 		return fmt.Errorf("failed to get project tasks: %w", err)
 	}
 
 	// Create components
 	analyzer := NewAnalyzer(appCtx.ProjectManager, tasks)
-	renderer := NewRenderer(config)
+	renderer := NewRenderer(config, appCtx.Out())
 
 	// Execute based on mode
 	switch config.Mode {
 	case ModeTask:
-		return f.executeTaskVisualization(analyzer, renderer, config)
+		return f.executeTaskVisualization(ctx, analyzer, renderer, config)
 	case ModeTree:
 		return f.executeTreeVisualization(analyzer, renderer, config)
 	case ModeGraph:
@@ -183,14 +183,14 @@ func (f *CommandFactory) executeVisualization(appCtx *shared.AppContext, config
 }
 
 // executeTaskVisualization handles task-specific visualization
-func (f *CommandFactory) executeTaskVisualization(analyzer *Analyzer, renderer *Renderer, config *VisualizationConfig) error {
+func (f *CommandFactory) executeTaskVisualization(ctx context.Context, analyzer *Analyzer, renderer *Renderer, config *VisualizationConfig) error {
 	taskID, err := uuid.Parse(config.TaskID)
 	if err != nil {
 		return fmt.Errorf("invalid task ID: %w", err)
 	}
 
 	// Analyze task
-	result, err := analyzer.AnalyzeTask(taskID)
+	result, err := analyzer.AnalyzeTask(ctx, taskID)
 	if err != nil {
 		return err
 	}
@@ -1,24 +1,26 @@
 package visualization
 
 import (
-	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
 
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/types"
 )
 
 // Renderer handles output rendering for different formats
 type Renderer struct {
 	config *VisualizationConfig
+	out    output.Writer
 	output []string
 }
 
 // NewRenderer creates a new renderer
-func NewRenderer(config *VisualizationConfig) *Renderer {
+func NewRenderer(config *VisualizationConfig, out output.Writer) *Renderer {
 	return &Renderer{
 		config: config,
+		out:    out,
 		output: make([]string, 0),
 	}
 }
@@ -139,13 +141,7 @@ func (r *Renderer) RenderJSON(taskResult *TaskAnalysisResult, projectResult *Pro
 		}
 	}
 
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal JSON: %w", err)
-	}
-
-	fmt.Println(string(jsonData))
-	return nil
+	return r.out.JSON(data)
 }
 
 // Private helper methods
@@ -330,7 +326,7 @@ func (r *Renderer) addLine(line string) {
 
 // Render outputs the current visualization
 func (r *Renderer) Render() error {
-	fmt.Println(strings.Join(r.output, "\n"))
+	r.out.Println(strings.Join(r.output, "\n"))
 	return nil
 }
 
@@ -0,0 +1,247 @@
+package digest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/notify"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// periods are the digest periods with their name in the title
+var periods = map[string]struct {
+	name   string
+	months int
+	days   int
+}{
+	"day":   {"Daily", 0, 1},
+	"week":  {"Weekly", 0, 7},
+	"month": {"Monthly", 1, 0},
+}
+
+// NewDigestCommand creates the digest command, which summarizes a period of
+// the projects as Markdown for a file or an email
+func NewDigestCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "digest",
+		Usage: "Summarize the last day, week or month of the projects as Markdown",
+		Description: `Writes a Markdown digest of the selected project, or of all projects with
+--all-projects, for the period ending at midnight today: the tasks completed,
+newly created and newly blocked during the period, the open tasks that are
+overdue and the upcoming milestones, i.e. open tasks due within the next
+period. Projects with nothing to report are left out.
+
+The digest goes to stdout, to a file with --out, or by email with --smtp:
+
+  knot digest --period week --out digest.md
+  knot digest --all-projects --smtp mail.example.com:587 \
+    --from knot@example.com --to team@example.com
+
+The SMTP password is read from $KNOT_SMTP_PASSWORD. Periods end at midnight,
+so consecutive runs cover every task once. Run it from cron, e.g. every
+Monday morning:
+
+  0 8 * * 1 cd /path/to/project && knot digest --all-projects --smtp ...`,
+		Action: digestAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "period",
+				Usage: "Period to summarize (day, week, month)",
+				Value: "week",
+			},
+			&cli.BoolFlag{
+				Name:  "all-projects",
+				Usage: "Summarize all projects instead of the selected project",
+			},
+			&cli.StringFlag{
+				Name:  "out",
+				Usage: "Write to file instead of stdout",
+			},
+			&cli.StringFlag{
+				Name:  "smtp",
+				Usage: "Email the digest through this SMTP server (host:port)",
+			},
+			&cli.StringFlag{
+				Name:  "from",
+				Usage: "Sender address of the email",
+			},
+			&cli.StringSliceFlag{
+				Name:  "to",
+				Usage: "Recipient of the email (can specify multiple)",
+			},
+			&cli.StringFlag{
+				Name:    "smtp-user",
+				Usage:   "User name for the SMTP server",
+				EnvVars: []string{"KNOT_SMTP_USER"},
+			},
+			shared.NewJSONFlag(),
+		},
+	}
+}
+
+func digestAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		period, ok := periods[c.String("period")]
+		if !ok {
+			return errors.NewValidationError("invalid --period", fmt.Errorf("unknown period '%s', use day, week or month", c.String("period")))
+		}
+		var mail *notify.Mail
+		if server := c.String("smtp"); server != "" {
+			if c.IsSet("out") || c.Bool("json") {
+				return errors.NewValidationError("conflicting flags", fmt.Errorf("--smtp cannot be combined with --out or --json"))
+			}
+			mail = &notify.Mail{
+				Server:   server,
+				Username: c.String("smtp-user"),
+				Password: os.Getenv("KNOT_SMTP_PASSWORD"),
+				From:     c.String("from"),
+				To:       c.StringSlice("to"),
+			}
+			if err := mail.Validate(); err != nil {
+				return errors.NewValidationError("invalid email settings", err)
+			}
+		}
+
+		now := time.Now()
+		until := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		since := until.AddDate(0, -period.months, -period.days)
+
+		projects, err := digestProjects(c, appCtx)
+		if err != nil {
+			return err
+		}
+		digests := make([]*analysis.ProjectDigest, 0, len(projects))
+		for _, project := range projects {
+			tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, project.ID)
+			if err != nil {
+				appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+				return errors.WrapWithSuggestion(err, "listing tasks of project")
+			}
+			// The change feed is optional; without it blocked tasks are dated by their last update
+			events, err := appCtx.ProjectManager.ListChangeEvents(c.Context, types.ChangeEventFilter{ProjectID: &project.ID})
+			if err != nil {
+				appCtx.Logger.Warn("Change feed unavailable, dating blocked tasks by their last update", zap.Error(err))
+				events = nil
+			}
+			if digest := analysis.BuildProjectDigest(project, tasks, events, since, until); !digest.Empty() {
+				digests = append(digests, digest)
+			}
+		}
+		appCtx.Logger.Info("Built digest",
+			zap.String("period", c.String("period")),
+			zap.Int("projects", len(projects)),
+			zap.Int("reported", len(digests)))
+
+		if c.Bool("json") {
+			return appCtx.Out().JSON(digests)
+		}
+
+		title := fmt.Sprintf("%s digest %s", period.name, formatPeriod(since, until))
+		var doc strings.Builder
+		writeDigest(&doc, title, digests)
+
+		switch {
+		case mail != nil:
+			mail.Subject = "knot: " + title
+			if len(projects) == 1 {
+				mail.Subject += " – " + projects[0].Title
+			}
+			mail.Body = doc.String()
+			if err := mail.Send(now); err != nil {
+				return &errors.EnhancedError{
+					Operation:  "sending digest",
+					Cause:      err,
+					Suggestion: "Check the SMTP server, --smtp-user and $KNOT_SMTP_PASSWORD, or write the digest to a file with --out",
+				}
+			}
+			appCtx.Out().Printf("Sent digest of %d projects to %s\n", len(digests), strings.Join(mail.To, ", "))
+		case c.String("out") != "":
+			if err := os.WriteFile(c.String("out"), []byte(doc.String()), 0644); err != nil {
+				return fmt.Errorf("failed to write digest: %w", err)
+			}
+			appCtx.Out().Printf("Wrote digest of %d projects to %s\n", len(digests), c.String("out"))
+		default:
+			appCtx.Out().Print(doc.String())
+		}
+		return nil
+	}
+}
+
+// digestProjects returns the selected project, or all projects with
+// --all-projects
+func digestProjects(c *cli.Context, appCtx *shared.AppContext) ([]*types.Project, error) {
+	if c.Bool("all-projects") {
+		projects, err := appCtx.ProjectManager.ListProjects(c.Context)
+		if err != nil {
+			return nil, errors.WrapWithSuggestion(err, "listing projects")
+		}
+		return projects, nil
+	}
+	projectID, err := shared.ResolveProjectID(c, appCtx)
+	if err != nil {
+		return nil, err
+	}
+	project, err := appCtx.ProjectManager.GetProject(c.Context, projectID)
+	if err != nil {
+		return nil, errors.WrapWithSuggestion(err, "loading project")
+	}
+	return []*types.Project{project}, nil
+}
+
+// formatPeriod prints the days of a period ending at midnight until
+func formatPeriod(since, until time.Time) string {
+	last := until.AddDate(0, 0, -1)
+	if !last.After(since) {
+		return since.Format("Mon 2006-01-02")
+	}
+	return since.Format("Mon 2006-01-02") + " to " + last.Format("Mon 2006-01-02")
+}
+
+// writeDigest writes the digests as a Markdown document, one section per
+// project
+func writeDigest(w io.Writer, title string, digests []*analysis.ProjectDigest) {
+	fmt.Fprintf(w, "# %s\n", title)
+	if len(digests) == 0 {
+		fmt.Fprintln(w, "\nNothing to report.")
+		return
+	}
+	for _, digest := range digests {
+		fmt.Fprintf(w, "\n## %s\n", digest.ProjectTitle)
+		writeSection(w, "Completed", digest.Completed, func(item analysis.DigestItem) string {
+			return "completed " + output.Timestamp(*item.At)
+		})
+		writeSection(w, "Created", digest.Created, func(item analysis.DigestItem) string {
+			return string(item.State)
+		})
+		writeSection(w, "Newly blocked", digest.Blocked, func(item analysis.DigestItem) string {
+			return "blocked " + output.Timestamp(*item.At)
+		})
+		writeSection(w, "Overdue", digest.Overdue, func(item analysis.DigestItem) string {
+			return fmt.Sprintf("due %s, %s", item.DueDate.Format(time.DateOnly), item.State)
+		})
+		writeSection(w, "Upcoming milestones", digest.Upcoming, func(item analysis.DigestItem) string {
+			return "due " + item.DueDate.Format("Mon 2006-01-02")
+		})
+	}
+}
+
+// writeSection lists the items of a digest section with a note each, and
+// leaves empty sections out
+func writeSection(w io.Writer, title string, items []analysis.DigestItem, note func(analysis.DigestItem) string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n### %s (%d)\n\n", title, len(items))
+	for _, item := range items {
+		fmt.Fprintf(w, "- %s (%s)\n", item.Title, note(item))
+	}
+}
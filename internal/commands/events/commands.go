@@ -0,0 +1,158 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// eventBatchSize limits how many events are read from the feed per query
+const eventBatchSize = 500
+
+// NewEventsCommand creates the events command, which prints the change feed
+func NewEventsCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "events",
+		Usage: "Print the change feed of project and task mutations as JSON lines",
+		Description: `Prints one JSON object per line for every recorded mutation of projects,
+tasks and dependencies, ordered by sequence number. Each event carries a
+"seq" field; pass the last seen value to --since to resume without gaps.
+
+With --follow the command keeps running and prints new events as they are
+recorded, also by other knot processes using the same database. The global
+--timeout then limits each poll instead of the whole run.
+
+Example:
+  knot events --since 42 --follow | my-mirror-tool`,
+		Action: eventsAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.Int64Flag{
+				Name:  "since",
+				Usage: "Only print events with a sequence number greater than this",
+			},
+			&cli.BoolFlag{
+				Name:    "follow",
+				Aliases: []string{"f"},
+				Usage:   "Keep running and print new events as they are recorded",
+			},
+			&cli.BoolFlag{
+				Name:  "all-projects",
+				Usage: "Print events of all projects instead of the selected project",
+			},
+			&cli.DurationFlag{
+				Name:  "interval",
+				Usage: "Polling interval for --follow",
+				Value: time.Second,
+			},
+		},
+	}
+}
+
+func eventsAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		filter := types.ChangeEventFilter{
+			AfterSeq: c.Int64("since"),
+			Limit:    eventBatchSize,
+		}
+		if !c.Bool("all-projects") {
+			projectID, err := shared.ResolveProjectID(c, appCtx)
+			if err != nil {
+				return err
+			}
+			filter.ProjectID = &projectID
+		}
+
+		interval := c.Duration("interval")
+		if interval <= 0 {
+			return errors.NewValidationError("invalid interval",
+				fmt.Errorf("--interval must be positive, got %s", interval))
+		}
+
+		appCtx.Logger.Info("Reading change events",
+			zap.Int64("since", filter.AfterSeq),
+			zap.Bool("follow", c.Bool("follow")),
+			zap.String("project", projectScope(filter.ProjectID)))
+
+		// Following runs until interrupted, so --timeout bounds each poll instead
+		// of the whole command
+		base := c.Context
+		if c.Bool("follow") {
+			base = context.WithoutCancel(base)
+		}
+		ctx, stop := signal.NotifyContext(base, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		encoder := json.NewEncoder(appCtx.Out())
+		for {
+			lastSeq, err := pollEvents(ctx, c.Duration("timeout"), appCtx, encoder, filter)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return errors.WrapWithSuggestion(err, "reading change events")
+			}
+			filter.AfterSeq = lastSeq
+
+			if !c.Bool("follow") {
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(interval):
+			}
+		}
+	}
+}
+
+// pollEvents runs printEvents bounded by timeout, if one is set
+func pollEvents(ctx context.Context, timeout time.Duration, appCtx *shared.AppContext, encoder *json.Encoder, filter types.ChangeEventFilter) (int64, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return printEvents(ctx, appCtx, encoder, filter)
+}
+
+// printEvents prints all events matching the filter in batches and returns the
+// sequence number of the last printed event
+func printEvents(ctx context.Context, appCtx *shared.AppContext, encoder *json.Encoder, filter types.ChangeEventFilter) (int64, error) {
+	for {
+		events, err := appCtx.ProjectManager.ListChangeEvents(ctx, filter)
+		if err != nil {
+			return filter.AfterSeq, err
+		}
+
+		for _, event := range events {
+			if err := encoder.Encode(event); err != nil {
+				return filter.AfterSeq, fmt.Errorf("failed to write event: %w", err)
+			}
+			filter.AfterSeq = event.Seq
+		}
+
+		if len(events) < filter.Limit {
+			return filter.AfterSeq, nil
+		}
+	}
+}
+
+// projectScope describes the project filter for logging
+func projectScope(projectID *uuid.UUID) string {
+	if projectID == nil {
+		return "all"
+	}
+	return projectID.String()
+}
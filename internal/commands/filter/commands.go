@@ -0,0 +1,201 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/filter"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// savedFilter is a saved filter as shown by the list command
+type savedFilter struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// Commands returns the saved filter subcommands
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "save",
+			Usage: "Save a task filter query under a name",
+			Description: `Saves a filter query in .knot/config.json so it can be passed by name to
+commands accepting --filter, e.g.
+
+  knot filter save --name stale-backend --query "state:pending priority:low tag:backend"
+  knot task reprioritize --filter stale-backend --priority medium
+
+Query terms: state:<state>, priority:<priority>, complexity:<n|min-max>,
+depth-max:<n>, tag:<tag>, search:<word> or plain words. Comma-separated values
+match any of them, different terms must all match.`,
+			Action: saveAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "name",
+					Aliases:  []string{"n"},
+					Usage:    "Filter name",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "query",
+					Aliases:  []string{"q"},
+					Usage:    "Filter query",
+					Required: true,
+				},
+			},
+		},
+		{
+			Name:   "list",
+			Usage:  "List saved filters",
+			Action: listAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewJSONFlag(),
+			},
+		},
+		{
+			Name:   "show",
+			Usage:  "List the tasks of the selected project matching a filter",
+			Action: showAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "filter",
+					Aliases:  []string{"f"},
+					Usage:    "Saved filter name or filter query",
+					Required: true,
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+		{
+			Name:   "delete",
+			Usage:  "Delete a saved filter",
+			Action: deleteAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "name",
+					Aliases:  []string{"n"},
+					Usage:    "Filter name",
+					Required: true,
+				},
+			},
+		},
+	}
+}
+
+func saveAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		name := c.String("name")
+		query := strings.Join(strings.Fields(c.String("query")), " ")
+
+		if err := filter.ValidateName(name); err != nil {
+			return errors.NewValidationError("invalid filter name", err)
+		}
+		if _, err := filter.Parse(query); err != nil {
+			return errors.NewValidationError("invalid filter query", err)
+		}
+
+		newConfig := *appCtx.ProjectManager.GetConfig()
+		newConfig.SetSavedFilter(name, query)
+		appCtx.ProjectManager.UpdateConfig(&newConfig)
+		if err := appCtx.ProjectManager.SaveConfigToFile(); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+
+		appCtx.Logger.Info("Saved filter", zap.String("name", name), zap.String("query", query))
+		appCtx.Out().Printf("Saved filter '%s': %s\n", name, query)
+		return nil
+	}
+}
+
+func listAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		saved := appCtx.ProjectManager.GetConfig().SavedFilters
+		filters := make([]savedFilter, 0, len(saved))
+		for name, query := range saved {
+			filters = append(filters, savedFilter{Name: name, Query: query})
+		}
+		sort.Slice(filters, func(i, j int) bool {
+			return filters[i].Name < filters[j].Name
+		})
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(filters, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal filters to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		if len(filters) == 0 {
+			appCtx.Out().Println("No saved filters. Save one with: knot filter save --name <name> --query <query>")
+			return nil
+		}
+		appCtx.Out().Printf("Saved filters (%d):\n", len(filters))
+		for _, f := range filters {
+			appCtx.Out().Printf("  %s: %s\n", f.Name, f.Query)
+		}
+		return nil
+	}
+}
+
+func showAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		query, err := filter.Resolve(appCtx.ProjectManager.GetConfig().SavedFilters, c.String("filter"))
+		if err != nil {
+			return errors.NewValidationError("invalid filter", err)
+		}
+
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		matched := query.Filter(tasks)
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(matched, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal tasks to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		appCtx.Out().Printf("Filter '%s' matched %d task(s):\n", c.String("filter"), len(matched))
+		for _, task := range matched {
+			appCtx.Out().Printf("  %s (ID: %s, %s, priority: %s, complexity: %d)\n",
+				task.Title, task.ID, task.State, task.Priority.ToExternalString(), task.Complexity)
+		}
+		return nil
+	}
+}
+
+func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		name := c.String("name")
+
+		newConfig := *appCtx.ProjectManager.GetConfig()
+		if !newConfig.DeleteSavedFilter(name) {
+			return errors.NewValidationError("filter not found", fmt.Errorf("no saved filter named '%s'", name))
+		}
+		appCtx.ProjectManager.UpdateConfig(&newConfig)
+		if err := appCtx.ProjectManager.SaveConfigToFile(); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+
+		appCtx.Out().Printf("Deleted filter '%s'\n", name)
+		return nil
+	}
+}
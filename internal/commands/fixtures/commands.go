@@ -0,0 +1,245 @@
+package fixtures
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/fixture"
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// Commands returns the fixtures subcommands
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "load",
+			Usage: "Create reproducible projects from a fixture file",
+			Description: `Creates the projects of a YAML (or JSON) fixture file with their tasks,
+states, dependencies and timestamps, e.g. for demos, benchmarks or to
+reproduce a bug report. Tasks are written as given: states and timestamps
+do not pass through the workflow rules of 'knot task update-state'.
+
+Fixture file format:
+  projects:
+    - title: Demo
+      created_at: 2025-01-06T09:00:00Z   # optional, default: now
+      updated_at: 2025-01-10T17:00:00Z   # optional, default: created_at
+      tasks:
+        - ref: design                    # optional name for parent and depends_on
+          title: Design API
+          state: completed               # pending, in-progress, completed, blocked, cancelled
+          priority: high                 # low, medium, high
+          complexity: 4
+          created_at: 2025-01-06T10:00:00Z
+          completed_at: 2025-01-07T12:00:00Z
+        - title: Implement endpoints
+          parent: design                 # ref or ID
+          depends_on: [design]
+          tags: [backend]
+          estimate: 90                   # minutes
+
+Tasks without created_at are created one second apart after the project, in
+file order. Projects and tasks without an id get IDs derived from the project
+title and task refs, so loading a fixture always creates the same IDs.
+'knot fixtures dump' writes fixtures in this format.
+
+Projects or tasks whose IDs exist already fail the load, unless --on-conflict
+says otherwise:
+  skip       keep existing projects and tasks, create only the new tasks;
+             tasks deleted in this database stay deleted
+  overwrite  replace existing projects and tasks with the fixture; tasks
+             deleted in this database are created again
+  new-ids    import existing projects and tasks as copies with new IDs
+
+--dry-run reports what the load would create, skip or overwrite, with the
+fields that differ, without writing anything.`,
+			Action: loadAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "file",
+					Aliases:  []string{"f"},
+					Usage:    "Fixture file (YAML or JSON)",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  "select",
+					Usage: "Select the first loaded project",
+				},
+				&cli.StringFlag{
+					Name:  "on-conflict",
+					Usage: "What to do with existing IDs (fail, skip, overwrite, new-ids)",
+					Value: string(fixture.ConflictFail),
+				},
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Show what would be loaded without loading it",
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+		{
+			Name:  "dump",
+			Usage: "Capture projects of the current database as a fixture",
+			Description: `Writes projects with their tasks, dependencies and timestamps as a fixture
+that 'knot fixtures load' recreates with the same IDs. All projects are
+dumped unless --project-id is given.`,
+			Action: dumpAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "file",
+					Aliases: []string{"f"},
+					Usage:   "Fixture file to write (default: standard output)",
+				},
+				&cli.StringSliceFlag{
+					Name:  "project-id",
+					Usage: "Dump only this project (repeatable)",
+				},
+			},
+		},
+	}
+}
+
+func loadAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		f, err := fixture.LoadFile(c.String("file"))
+		if err != nil {
+			return errors.NewValidationError("invalid fixture file", err)
+		}
+
+		policy, err := fixture.ParseConflictPolicy(c.String("on-conflict"))
+		if err != nil {
+			return errors.NewValidationError("invalid --on-conflict", err)
+		}
+
+		appCtx.Logger.Info("Loading fixture",
+			zap.String("file", c.String("file")),
+			zap.String("on_conflict", string(policy)))
+		plan, err := fixture.Plan(c.Context, appCtx.Repository, f, appCtx.ProjectManager.GetCurrentTime(), policy)
+		if err != nil {
+			return loadError(appCtx, err)
+		}
+		if c.Bool("dry-run") {
+			if c.Bool("json") {
+				return appCtx.Out().JSON(plan)
+			}
+			printPlan(appCtx, plan)
+			return nil
+		}
+		projects, err := plan.Apply(c.Context, appCtx.Repository)
+		if err != nil {
+			return loadError(appCtx, err)
+		}
+
+		if c.Bool("select") && len(projects) > 0 {
+			actor := shared.GetActorFromContext(c)
+			if err := appCtx.ProjectManager.SetSelectedProject(c.Context, projects[0].ID, actor); err != nil {
+				return fmt.Errorf("failed to select project: %w", err)
+			}
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(projects, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal projects to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+		for _, project := range projects {
+			appCtx.Out().Printf("Loaded project %s (ID: %s) with %d tasks\n",
+				project.Title, project.ID, project.TotalTasks)
+		}
+		return nil
+	}
+}
+
+// loadError maps an error of planning or loading a fixture
+func loadError(appCtx *shared.AppContext, err error) error {
+	// Existing IDs and circular dependencies are mistakes in the fixture
+	if stderrors.Is(err, fixture.ErrProjectExists) || stderrors.Is(err, fixture.ErrTaskExists) {
+		return &errors.EnhancedError{
+			Operation:  "loading fixture",
+			Cause:      err,
+			Suggestion: "Choose what to do with existing IDs with --on-conflict skip, overwrite or new-ids",
+			Example:    "knot fixtures load --file fixture.yaml --on-conflict skip --dry-run",
+		}
+	}
+	if sqlite.IsCircularDependencyError(err) {
+		return errors.NewValidationError("invalid fixture file", err)
+	}
+	appCtx.Logger.Error("Failed to load fixture", zap.Error(err))
+	return errors.WrapWithSuggestion(err, "loading fixture")
+}
+
+// printPlan prints what loading a fixture would do, one line per project and
+// task, and a summary
+func printPlan(appCtx *shared.AppContext, plan *fixture.ImportPlan) {
+	out := appCtx.Out()
+	describe := func(action, title string, id uuid.UUID, originalID *uuid.UUID, reason string, changes []string) string {
+		line := fmt.Sprintf("%-9s %s (ID: %s)", action, title, id)
+		if originalID != nil {
+			line += fmt.Sprintf(", copy of %s", *originalID)
+		}
+		if reason != "" {
+			line += ": " + reason
+		}
+		if len(changes) > 0 {
+			line += " [" + strings.Join(changes, ", ") + "]"
+		}
+		return line
+	}
+	for _, project := range plan.Projects {
+		out.Printf("Project %s\n", describe(project.Action, project.Title, project.ID, project.OriginalID, "", project.Changes))
+		for _, task := range project.Tasks {
+			out.Printf("  %s\n", describe(task.Action, task.Title, task.ID, task.OriginalID, task.Reason, task.Changes))
+		}
+	}
+	out.Printf("\nDry run with --on-conflict %s: %d to create, %d to overwrite, %d unchanged, %d to skip\n",
+		plan.Policy,
+		plan.Count(fixture.ActionCreate),
+		plan.Count(fixture.ActionOverwrite),
+		plan.Count(fixture.ActionUnchanged),
+		plan.Count(fixture.ActionSkip))
+}
+
+func dumpAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		var projectIDs []uuid.UUID
+		for _, value := range c.StringSlice("project-id") {
+			id, err := uuid.Parse(value)
+			if err != nil {
+				return errors.InvalidUUIDError("project-id", value)
+			}
+			projectIDs = append(projectIDs, id)
+		}
+
+		f, err := fixture.Dump(c.Context, appCtx.Repository, projectIDs)
+		if err != nil {
+			appCtx.Logger.Error("Failed to dump fixture", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "dumping fixture")
+		}
+		data, err := f.Marshal()
+		if err != nil {
+			return err
+		}
+
+		path := c.String("file")
+		if path == "" {
+			_, err := appCtx.Out().Write(data)
+			return err
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write fixture file: %w", err)
+		}
+		appCtx.Out().Printf("Dumped %d project(s) to %s\n", len(f.Projects), path)
+		return nil
+	}
+}
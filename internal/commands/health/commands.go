@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/denkhaus/knot/v2/internal/logger"
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
@@ -64,7 +65,7 @@ func checkAction(appCtx *shared.AppContext) cli.ActionFunc {
 		timeout := c.Duration("timeout")
 		jsonOutput := c.Bool("json")
 
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		ctx, cancel := context.WithTimeout(c.Context, timeout)
 		defer cancel()
 
 		logger.Log.Info("Performing database health check", zap.Duration("timeout", timeout))
@@ -82,9 +83,9 @@ func checkAction(appCtx *shared.AppContext) cli.ActionFunc {
 			if err != nil {
 				return fmt.Errorf("failed to marshal health status: %w", err)
 			}
-			fmt.Println(string(jsonData))
+			appCtx.Out().Println(string(jsonData))
 		} else {
-			printHealthStatus(health)
+			printHealthStatus(appCtx.Out(), health)
 		}
 
 		if !health.Healthy {
@@ -99,7 +100,7 @@ func pingAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		timeout := c.Duration("timeout")
 
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		ctx, cancel := context.WithTimeout(c.Context, timeout)
 		defer cancel()
 
 		logger.Log.Info("Pinging database", zap.Duration("timeout", timeout))
@@ -110,17 +111,17 @@ func pingAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		if err != nil {
 			logger.Log.Error("Database ping failed", zap.Error(err), zap.Duration("latency", latency))
-			fmt.Printf("Database ping failed: %v\n", err)
-			fmt.Printf("Latency: %v\n", latency)
+			appCtx.Out().Printf("Database ping failed: %v\n", err)
+			appCtx.Out().Printf("Latency: %v\n", latency)
 			return err
 		}
 
 		logger.Log.Info("Database ping successful", zap.Duration("latency", latency))
-		fmt.Printf("Database ping successful\n")
-		fmt.Printf("Latency: %v\n", latency)
+		appCtx.Out().Printf("Database ping successful\n")
+		appCtx.Out().Printf("Latency: %v\n", latency)
 
 		if latency > time.Millisecond*100 {
-			fmt.Printf("High latency detected (>100ms)\n")
+			appCtx.Out().Printf("High latency detected (>100ms)\n")
 		}
 
 		return nil
@@ -131,7 +132,7 @@ func validateAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		timeout := c.Duration("timeout")
 
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		ctx, cancel := context.WithTimeout(c.Context, timeout)
 		defer cancel()
 
 		logger.Log.Info("Validating database connection", zap.Duration("timeout", timeout))
@@ -139,13 +140,13 @@ func validateAction(appCtx *shared.AppContext) cli.ActionFunc {
 		err := performValidation(ctx, appCtx)
 		if err != nil {
 			logger.Log.Error("Database validation failed", zap.Error(err))
-			fmt.Printf("Database validation failed: %v\n", err)
+			appCtx.Out().Printf("Database validation failed: %v\n", err)
 			return err
 		}
 
 		logger.Log.Info("Database validation successful")
-		fmt.Printf("✅ Database connection validation successful\n")
-		fmt.Printf("   All checks passed\n")
+		appCtx.Out().Print(output.Icon("✅") + "Database connection validation successful\n")
+		appCtx.Out().Printf("   All checks passed\n")
 
 		return nil
 	}
@@ -230,38 +231,38 @@ func performValidation(ctx context.Context, appCtx *shared.AppContext) error {
 }
 
 // printHealthStatus prints health status in human-readable format
-func printHealthStatus(health *HealthStatus) {
-	fmt.Printf("Database Health Status:\n\n")
+func printHealthStatus(out output.Writer, health *HealthStatus) {
+	out.Printf("Database Health Status:\n\n")
 
 	if health.Healthy {
-		fmt.Printf("✅ Status: Healthy\n")
+		out.Print(output.Icon("✅") + "Status: Healthy\n")
 	} else {
-		fmt.Printf("❌ Status: Unhealthy\n")
+		out.Print(output.Icon("❌") + "Status: Unhealthy\n")
 		if health.ErrorMessage != "" {
-			fmt.Printf("   Error: %s\n", health.ErrorMessage)
+			out.Printf("   Error: %s\n", health.ErrorMessage)
 		}
 	}
 
-	fmt.Printf("📊 Connection Details:\n")
-	fmt.Printf("   Active: %v\n", health.ConnectionActive)
-	fmt.Printf("   Latency: %v\n", health.PingLatency)
-	fmt.Printf("   Database: %s\n", health.DatabasePath)
-	fmt.Printf("   Last Checked: %v\n", health.LastChecked.Format(time.RFC3339))
+	out.Print(output.Icon("📊") + "Connection Details:\n")
+	out.Printf("   Active: %v\n", health.ConnectionActive)
+	out.Printf("   Latency: %v\n", health.PingLatency)
+	out.Printf("   Database: %s\n", health.DatabasePath)
+	out.Printf("   Last Checked: %v\n", output.Timestamp(health.LastChecked))
 
 	if health.OpenConnections > 0 {
-		fmt.Printf("🔗 Connection Pool:\n")
-		fmt.Printf("   Open: %d\n", health.OpenConnections)
-		fmt.Printf("   Idle: %d\n", health.IdleConnections)
-		fmt.Printf("   In Use: %d\n", health.InUseConnections)
+		out.Print(output.Icon("🔗") + "Connection Pool:\n")
+		out.Printf("   Open: %d\n", health.OpenConnections)
+		out.Printf("   Idle: %d\n", health.IdleConnections)
+		out.Printf("   In Use: %d\n", health.InUseConnections)
 	}
 
 	if health.WALModeEnabled || health.ForeignKeys {
-		fmt.Printf("⚙️  SQLite Settings:\n")
+		out.Print(output.Icon("⚙️") + "SQLite Settings:\n")
 		if health.WALModeEnabled {
-			fmt.Printf("   WAL Mode: ✅ Enabled\n")
+			out.Print("   WAL Mode: " + output.Icon("✅") + "Enabled\n")
 		}
 		if health.ForeignKeys {
-			fmt.Printf("   Foreign Keys: ✅ Enabled\n")
+			out.Print("   Foreign Keys: " + output.Icon("✅") + "Enabled\n")
 		}
 	}
 }
@@ -0,0 +1,396 @@
+package interchange
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/interchange"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// ImportCommands returns the import subcommands, one per supported format
+func ImportCommands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "markdown",
+			Usage: "Import a nested Markdown bullet/checkbox list as a task hierarchy",
+			Description: `Indentation determines the hierarchy, "- [x]" items are imported as completed
+and indented text below an item becomes its description.`,
+			Action: importAction(appCtx, "markdown", interchange.ParseMarkdown),
+			Flags:  importFlags(),
+		},
+		{
+			Name:    "org",
+			Aliases: []string{"org-mode"},
+			Usage:   "Import Emacs org-mode headings as a task hierarchy",
+			Description: `Heading levels determine the hierarchy. TODO keywords map to states
+(TODO, NEXT/STARTED/DOING, WAITING/BLOCKED, DONE, CANCELLED), [#A]/[#B]/[#C]
+to priorities and :tags: to task tags.`,
+			Action: importAction(appCtx, "org-mode", interchange.ParseOrgMode),
+			Flags:  importFlags(),
+		},
+		{
+			Name:    "todotxt",
+			Aliases: []string{"todo.txt"},
+			Usage:   "Import a todo.txt file as flat tasks",
+			Description: `"x" marks completed tasks, (A)/(B)/(C) map to priorities and
++project / @context words become task tags.`,
+			Action: importAction(appCtx, "todo.txt", interchange.ParseTodoTxt),
+			Flags:  importFlags(),
+		},
+	}
+}
+
+// ExportCommands returns the export subcommands, one per supported format
+func ExportCommands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:   "markdown",
+			Usage:  "Export the project task hierarchy as a nested Markdown list",
+			Action: exportMarkdownAction(appCtx),
+			Flags: append(exportFlags(),
+				numberedFlag(),
+				&cli.BoolFlag{
+					Name:  "checklist",
+					Usage: "Render tasks as checkboxes (- [ ] / - [x])",
+				},
+			),
+		},
+		{
+			Name:    "org",
+			Aliases: []string{"org-mode"},
+			Usage:   "Export the project task hierarchy as org-mode headings",
+			Action:  exportAction(appCtx, "org-mode", interchange.RenderOrgMode),
+			Flags:   append(exportFlags(), numberedFlag()),
+		},
+		{
+			Name:    "todotxt",
+			Aliases: []string{"todo.txt"},
+			Usage:   "Export the project tasks as a flat todo.txt list",
+			Action:  exportAction(appCtx, "todo.txt", interchange.RenderTodoTxt),
+			Flags:   append(exportFlags(), numberedFlag()),
+		},
+		{
+			Name:  "vscode-tasks",
+			Usage: "Export the actionable tasks as JSON for editor integrations",
+			Description: `Writes the pending and in-progress tasks whose dependencies are completed
+as a versioned JSON document. Each task carries its state, priority, blockers
+and command hints (argument lists for "knot") an editor extension can run to
+show, start or complete it.`,
+			Action: exportVSCodeTasksAction(appCtx),
+			Flags: append(exportFlags(),
+				&cli.BoolFlag{
+					Name:  "include-blocked",
+					Usage: "Also include open tasks that are waiting on dependencies",
+				},
+			),
+		},
+		{
+			Name:    "ical",
+			Aliases: []string{"ics"},
+			Usage:   "Export the tasks with due dates as an iCalendar file",
+			Description: `Writes one calendar entry per task with a due date, to import or subscribe
+to in a calendar application. Tasks without an estimate become all-day entries
+on their due date. Tasks with an estimate become entries of the estimated
+working time that end at --end-of-day on the due date. Cancelled tasks are
+left out, completed tasks unless --include-completed is given.
+
+Most calendars only show events (VEVENT); task managers such as Thunderbird
+or Apple Reminders read to-dos (VTODO).`,
+			Action: exportICalAction(appCtx),
+			Flags: append(exportFlags(),
+				&cli.StringFlag{
+					Name:  "component",
+					Usage: "Export tasks as calendar events (event), to-dos (todo) or both",
+					Value: interchange.ICalEvent,
+				},
+				&cli.StringFlag{
+					Name:  "end-of-day",
+					Usage: "Local time (HH:MM) at which tasks with an estimate end on their due date",
+					Value: "17:00",
+				},
+				&cli.BoolFlag{
+					Name:  "include-completed",
+					Usage: "Also export completed tasks",
+				},
+			),
+		},
+	}
+}
+
+func importFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "file",
+			Aliases:  []string{"f"},
+			Usage:    "File to import",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:  "parent-id",
+			Usage: "Import under an existing task instead of as root tasks",
+		},
+		&cli.IntFlag{
+			Name:  "complexity",
+			Usage: "Complexity for imported tasks when the file does not specify one",
+			Value: interchange.DefaultComplexity,
+		},
+		&cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "Show what would be imported without creating tasks",
+		},
+	}
+}
+
+func exportFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "out",
+			Usage: "Write to file instead of stdout",
+		},
+	}
+}
+
+// numberedFlag numbers the exported tasks by their position in the hierarchy
+func numberedFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "numbered",
+		Usage: "Prefix the tasks with their hierarchical number (1, 1.1, 1.1.2)",
+	}
+}
+
+// parseFunc parses an input document into task trees
+type parseFunc func(r io.Reader) ([]*interchange.Node, error)
+
+func importAction(appCtx *shared.AppContext, format string, parse parseFunc) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(c.String("file"))
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		defer file.Close()
+
+		nodes, err := parse(file)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", format, err)
+		}
+		if len(nodes) == 0 {
+			return fmt.Errorf("no tasks found in %s", c.String("file"))
+		}
+
+		opts := interchange.ImportOptions{
+			DefaultComplexity: c.Int("complexity"),
+			Actor:             shared.GetActorFromContext(c),
+		}
+		if parentIDStr := c.String("parent-id"); parentIDStr != "" {
+			parentID, err := uuid.Parse(parentIDStr)
+			if err != nil {
+				return fmt.Errorf("invalid parent ID: %w", err)
+			}
+			opts.ParentID = &parentID
+		}
+
+		if c.Bool("dry-run") {
+			appCtx.Out().Printf("Would import %d tasks from %s:\n\n", interchange.CountNodes(nodes), c.String("file"))
+			printNodes(appCtx.Out(), nodes, 0)
+			appCtx.Out().Println("\nDry run mode - no tasks were created.")
+			return nil
+		}
+
+		appCtx.Logger.Info("Importing tasks",
+			zap.String("format", format),
+			zap.String("file", c.String("file")),
+			zap.String("projectID", projectID.String()),
+			zap.Int("taskCount", interchange.CountNodes(nodes)))
+
+		created, err := interchange.CreateTree(c.Context, appCtx.ProjectManager, projectID, nodes, opts)
+		if err != nil {
+			appCtx.Logger.Error("Failed to import tasks", zap.Error(err), zap.Int("created", len(created)))
+			return fmt.Errorf("import stopped after %d tasks: %w", len(created), err)
+		}
+
+		appCtx.Out().Printf("Successfully imported %d tasks from %s\n", len(created), c.String("file"))
+		appCtx.Out().Printf("  Created by: %s\n", opts.Actor)
+		return nil
+	}
+}
+
+// renderFunc renders task trees in an export format
+type renderFunc func(w io.Writer, nodes []*interchange.Node) error
+
+func exportAction(appCtx *shared.AppContext, format string, render renderFunc) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		return exportTree(c, appCtx, format, render)
+	}
+}
+
+func exportMarkdownAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		opts := interchange.MarkdownOptions{Checklist: c.Bool("checklist")}
+		return exportTree(c, appCtx, "markdown", func(w io.Writer, nodes []*interchange.Node) error {
+			return interchange.RenderMarkdown(w, nodes, opts)
+		})
+	}
+}
+
+// exportTree loads the selected project's task tree and renders it to stdout or --out
+func exportTree(c *cli.Context, appCtx *shared.AppContext, format string, render renderFunc) error {
+	projectID, err := shared.ResolveProjectID(c, appCtx)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+	if err != nil {
+		appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	appCtx.Logger.Info("Exporting tasks",
+		zap.String("format", format),
+		zap.String("projectID", projectID.String()),
+		zap.Int("taskCount", len(tasks)))
+
+	nodes := interchange.BuildTree(tasks)
+	if c.Bool("numbered") {
+		interchange.NumberTree(nodes)
+	}
+
+	outPath := c.String("out")
+	if outPath == "" {
+		return render(appCtx.Out(), nodes)
+	}
+
+	file, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+
+	if err := render(file, nodes); err != nil {
+		return fmt.Errorf("failed to write %s export: %w", format, err)
+	}
+
+	appCtx.Out().Printf("Exported %d tasks to %s\n", len(tasks), outPath)
+	return nil
+}
+
+func exportVSCodeTasksAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		doc := interchange.BuildVSCodeTasks(projectID, tasks, interchange.VSCodeTaskOptions{
+			IncludeBlocked: c.Bool("include-blocked"),
+		})
+
+		appCtx.Logger.Info("Exporting editor tasks",
+			zap.String("projectID", projectID.String()),
+			zap.Int("taskCount", len(doc.Tasks)))
+
+		outPath := c.String("out")
+		if outPath == "" {
+			return interchange.RenderVSCodeTasks(appCtx.Out(), doc)
+		}
+
+		file, err := os.Create(outPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		if err := interchange.RenderVSCodeTasks(file, doc); err != nil {
+			return err
+		}
+
+		appCtx.Out().Printf("Exported %d tasks to %s\n", len(doc.Tasks), outPath)
+		return nil
+	}
+}
+
+func exportICalAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		component, err := interchange.ParseICalComponent(c.String("component"))
+		if err != nil {
+			return errors.NewValidationError("invalid --component", err)
+		}
+		endOfDay, err := time.Parse("15:04", c.String("end-of-day"))
+		if err != nil {
+			return errors.NewValidationError("invalid --end-of-day", fmt.Errorf("expected HH:MM, got %q", c.String("end-of-day")))
+		}
+
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+		project, err := appCtx.ProjectManager.GetProject(c.Context, projectID)
+		if err != nil {
+			return errors.WrapWithSuggestion(err, "loading project")
+		}
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		opts := interchange.ICalOptions{
+			CalendarName:     project.Title,
+			Component:        component,
+			EndOfDay:         time.Duration(endOfDay.Hour())*time.Hour + time.Duration(endOfDay.Minute())*time.Minute,
+			IncludeCompleted: c.Bool("include-completed"),
+			Now:              appCtx.ProjectManager.GetCurrentTime(),
+		}
+		selected := interchange.SelectICalTasks(tasks, opts)
+
+		appCtx.Logger.Info("Exporting calendar",
+			zap.String("projectID", projectID.String()),
+			zap.String("component", component),
+			zap.Int("taskCount", len(selected)))
+
+		outPath := c.String("out")
+		if outPath == "" {
+			return interchange.RenderICal(appCtx.Out(), selected, opts)
+		}
+
+		file, err := os.Create(outPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		if err := interchange.RenderICal(file, selected, opts); err != nil {
+			return fmt.Errorf("failed to write iCalendar export: %w", err)
+		}
+
+		appCtx.Out().Printf("Exported %d tasks with due dates to %s\n", len(selected), outPath)
+		return nil
+	}
+}
+
+func printNodes(out output.Writer, nodes []*interchange.Node, depth int) {
+	for _, node := range nodes {
+		out.Printf("%s- %s [%s]\n", strings.Repeat("  ", depth), node.Title, node.State)
+		printNodes(out, node.Children, depth+1)
+	}
+}
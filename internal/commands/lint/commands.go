@@ -0,0 +1,240 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// projectFindings are the lint findings of one project
+type projectFindings struct {
+	ProjectID uuid.UUID              `json:"project_id"`
+	Title     string                 `json:"title"`
+	Checked   int                    `json:"checked"`
+	Findings  []analysis.LintFinding `json:"findings"`
+}
+
+// lintResult is the outcome of 'knot lint'
+type lintResult struct {
+	Projects []projectFindings             `json:"projects"`
+	Counts   map[analysis.LintSeverity]int `json:"counts"`
+}
+
+// NewLintCommand creates the lint command, which checks task descriptions for
+// dead references and missing acceptance criteria
+func NewLintCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "lint",
+		Usage: "Check task descriptions for dead references and missing acceptance criteria",
+		Description: `Checks the open tasks of the current project, or of all projects with --all,
+with the following rules:
+
+  deleted-task-reference       error    the description mentions the UUID of a
+                                        deleted task
+  unknown-reference            info     the description mentions a UUID that is
+                                        neither a project nor a task
+  missing-file                 warning  the description mentions a relative file
+                                        path, e.g. internal/app/app.go:42, that
+                                        does not exist below --root
+  missing-acceptance-criteria  warning  a task of complexity 7 or more has no
+                                        acceptance criteria, neither added with
+                                        'knot task criteria' nor as a section of
+                                        the description
+
+--paths restricts the file check to paths matching a glob, e.g. "*.go" or
+"docs/**"; --disable turns rules off. The command exits with code 8 if a
+finding is at least as severe as --fail-on, so it can run in CI:
+
+  knot lint --all --paths "*.go" --json > lint.json`,
+		Action: lintAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "Check all projects instead of the current project",
+			},
+			&cli.BoolFlag{
+				Name:  "include-closed",
+				Usage: "Check completed and cancelled tasks too",
+			},
+			&cli.StringSliceFlag{
+				Name:  "paths",
+				Usage: "Only check file references matching this glob (can specify multiple)",
+			},
+			&cli.StringFlag{
+				Name:  "root",
+				Usage: "Directory file references are relative to",
+				Value: ".",
+			},
+			&cli.IntFlag{
+				Name:  "criteria-complexity",
+				Usage: "Complexity from which tasks need acceptance criteria (0 to disable)",
+				Value: 7,
+			},
+			&cli.StringSliceFlag{
+				Name:  "disable",
+				Usage: "Rule to skip (can specify multiple)",
+			},
+			&cli.StringFlag{
+				Name:  "fail-on",
+				Usage: "Lowest severity that fails the command (error, warning, info, none)",
+				Value: string(analysis.LintError),
+			},
+			shared.NewJSONFlag(),
+		},
+	}
+}
+
+func lintAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		failOn := c.String("fail-on")
+		var threshold analysis.LintSeverity
+		if failOn != "none" {
+			severity, err := analysis.ParseLintSeverity(failOn)
+			if err != nil {
+				return errors.NewValidationError("invalid --fail-on", err)
+			}
+			threshold = severity
+		}
+		disabled := make(map[string]bool)
+		for _, rule := range c.StringSlice("disable") {
+			if !slices.Contains(analysis.LintRules, rule) {
+				return errors.NewValidationError("invalid --disable",
+					fmt.Errorf("unknown rule '%s', use one of %s", rule, strings.Join(analysis.LintRules, ", ")))
+			}
+			disabled[rule] = true
+		}
+		for _, pattern := range c.StringSlice("paths") {
+			if _, err := filepath.Match(strings.TrimSuffix(pattern, "/**"), ""); err != nil {
+				return errors.NewValidationError("invalid --paths", fmt.Errorf("glob '%s': %w", pattern, err))
+			}
+		}
+		root := c.String("root")
+
+		// References may point into any project, so all IDs are known
+		all, err := appCtx.ProjectManager.ListProjects(c.Context)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list projects", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "listing projects")
+		}
+		known := make(map[uuid.UUID]bool)
+		tasksByProject := make(map[uuid.UUID][]*types.Task, len(all))
+		for _, project := range all {
+			known[project.ID] = true
+			tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, project.ID)
+			if err != nil {
+				appCtx.Logger.Error("Failed to get project tasks", zap.Error(err))
+				return fmt.Errorf("failed to get project tasks: %w", err)
+			}
+			for _, task := range tasks {
+				known[task.ID] = true
+			}
+			tasksByProject[project.ID] = tasks
+		}
+		// The change feed is optional; without it references to deleted tasks are
+		// reported as unknown
+		events, err := appCtx.ProjectManager.ListChangeEvents(c.Context, types.ChangeEventFilter{})
+		if err != nil {
+			appCtx.Logger.Warn("Change feed unavailable, deleted tasks are not recognized", zap.Error(err))
+			events = nil
+		}
+
+		projects := all
+		if !c.Bool("all") {
+			projectID, err := shared.ResolveProjectID(c, appCtx)
+			if err != nil {
+				return err
+			}
+			project, err := appCtx.ProjectManager.GetProject(c.Context, projectID)
+			if err != nil {
+				return errors.WrapWithSuggestion(err, "getting project")
+			}
+			projects = []*types.Project{project}
+		}
+
+		opts := analysis.LintOptions{
+			KnownIDs:           known,
+			Events:             events,
+			PathPatterns:       c.StringSlice("paths"),
+			CriteriaComplexity: c.Int("criteria-complexity"),
+			Disabled:           disabled,
+			FileExists: func(path string) bool {
+				_, err := os.Stat(filepath.Join(root, filepath.FromSlash(path)))
+				return err == nil
+			},
+		}
+		result := lintResult{Projects: []projectFindings{}, Counts: map[analysis.LintSeverity]int{}}
+		failing := 0
+		for _, project := range projects {
+			var tasks []*types.Task
+			for _, task := range tasksByProject[project.ID] {
+				if c.Bool("include-closed") || (task.State != types.TaskStateCompleted && task.State != types.TaskStateCancelled) {
+					tasks = append(tasks, task)
+				}
+			}
+			findings := analysis.LintTasks(tasks, opts)
+			for _, finding := range findings {
+				result.Counts[finding.Severity]++
+				if threshold != "" && finding.Severity.Rank() <= threshold.Rank() {
+					failing++
+				}
+			}
+			result.Projects = append(result.Projects, projectFindings{
+				ProjectID: project.ID,
+				Title:     project.Title,
+				Checked:   len(tasks),
+				Findings:  findings,
+			})
+		}
+		appCtx.Logger.Info("Linted task descriptions",
+			zap.Int("projects", len(result.Projects)),
+			zap.Int("errors", result.Counts[analysis.LintError]),
+			zap.Int("warnings", result.Counts[analysis.LintWarning]))
+
+		if c.Bool("json") {
+			if err := appCtx.Out().JSON(result); err != nil {
+				return err
+			}
+		} else {
+			printResult(appCtx, result)
+		}
+
+		if failing > 0 {
+			return &errors.EnhancedError{
+				Operation:   "linting task descriptions",
+				Cause:       fmt.Errorf("%d lint finding(s) of severity %s or higher", failing, threshold),
+				Suggestion:  "Fix the listed descriptions, disable rules with --disable or relax --fail-on",
+				HelpCommand: "knot lint --help",
+			}
+		}
+		return nil
+	}
+}
+
+func printResult(appCtx *shared.AppContext, result lintResult) {
+	out := appCtx.Out()
+	for _, project := range result.Projects {
+		if len(project.Findings) == 0 {
+			out.Printf("%s: %d task(s) checked, no findings\n", project.Title, project.Checked)
+			continue
+		}
+		out.Printf("%s: %d finding(s) in %d task(s) checked\n", project.Title, len(project.Findings), project.Checked)
+		for _, finding := range project.Findings {
+			out.Printf("  %-7s %s (ID: %s)\n", finding.Severity, finding.Title, finding.TaskID)
+			out.Printf("          %s: %s\n", finding.Rule, finding.Message)
+		}
+	}
+	if len(result.Projects) > 1 {
+		out.Printf("\n%d error(s), %d warning(s), %d info(s)\n",
+			result.Counts[analysis.LintError], result.Counts[analysis.LintWarning], result.Counts[analysis.LintInfo])
+	}
+}
@@ -0,0 +1,129 @@
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// DefaultInterval is how often 'knot serve' runs maintenance
+const DefaultInterval = time.Minute
+
+// Commands returns the maintenance subcommands
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "run",
+			Usage: "Apply the scheduled state changes that are due",
+			Description: `Applies every state change scheduled with 'knot task schedule-state' whose
+time has come. knot has no daemon: run this from cron or a systemd timer, e.g.
+
+  * * * * * cd /path/to/project && knot maintenance run
+
+'knot serve' runs maintenance itself every minute. Changes that are not a
+valid transition any more are dropped and reported; changes of projects
+locked by another actor are kept and applied by a later run.`,
+			Action: runAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewJSONFlag(),
+			},
+		},
+	}
+}
+
+func runAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		results, err := appCtx.ProjectManager.RunDueTransitions(c.Context)
+		if err != nil {
+			appCtx.Logger.Error("Failed to run maintenance", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "running maintenance")
+		}
+
+		if c.Bool("json") {
+			data, err := json.MarshalIndent(results, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal results to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(data))
+		} else {
+			printResults(c, results)
+		}
+
+		failed := 0
+		for _, result := range results {
+			if result.Error != "" {
+				failed++
+			}
+		}
+		if failed > 0 {
+			return &errors.EnhancedError{
+				Operation:   "applying scheduled state changes",
+				Cause:       fmt.Errorf("%d of %d due state changes failed", failed, len(results)),
+				Suggestion:  "Check the task states; changes of locked projects are retried on the next run",
+				HelpCommand: "knot task scheduled --all",
+			}
+		}
+		return nil
+	}
+}
+
+func printResults(c *cli.Context, results []*manager.TransitionResult) {
+	if len(results) == 0 {
+		fmt.Fprintln(c.App.Writer, "No scheduled state changes are due")
+		return
+	}
+	for _, result := range results {
+		transition := result.Transition
+		switch {
+		case result.Error == "":
+			fmt.Fprintf(c.App.Writer, "Moved task '%s' to %s (scheduled for %s)\n",
+				result.Task.Title, transition.State, output.Timestamp(transition.At))
+		case result.Retry:
+			fmt.Fprintf(c.App.Writer, "Kept change of task %s to %s for the next run: %s\n",
+				transition.TaskID, transition.State, result.Error)
+		default:
+			fmt.Fprintf(c.App.Writer, "Dropped change of task %s to %s: %s\n",
+				transition.TaskID, transition.State, result.Error)
+		}
+	}
+}
+
+// Loop runs maintenance every interval until ctx is done, e.g. while 'knot
+// serve' is running. Failures are logged.
+func Loop(ctx context.Context, appCtx *shared.AppContext, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		results, err := appCtx.ProjectManager.RunDueTransitions(ctx)
+		if err != nil {
+			appCtx.Logger.Error("Failed to run maintenance", zap.Error(err))
+		}
+		for _, result := range results {
+			fields := []zap.Field{
+				zap.String("taskID", result.Transition.TaskID.String()),
+				zap.String("state", string(result.Transition.State)),
+				zap.Time("at", result.Transition.At),
+			}
+			if result.Error != "" {
+				appCtx.Logger.Warn("Scheduled state change failed", append(fields, zap.String("error", result.Error), zap.Bool("retry", result.Retry))...)
+			} else {
+				appCtx.Logger.Info("Applied scheduled state change", fields...)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
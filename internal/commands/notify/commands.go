@@ -0,0 +1,263 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/notify"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// NewNotifyCommand creates the notify command, which delivers notifications to
+// the configured hooks
+func NewNotifyCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "notify",
+		Usage: "Send desktop, Slack or Teams notifications for task events",
+		Description: `Checks the projects for events and sends them to the hooks configured under
+Notifications in .knot/config.json:
+
+  assigned   a task was assigned to an agent (AgentID limits it to one agent)
+  unblocked  all dependencies of a task were completed
+  overdue    an open task passed its due date
+
+Hooks deliver to the desktop (notify-send, or osascript on macOS) or to the
+incoming webhook of a Slack or Teams channel:
+
+  "Notifications": [
+    {"Name": "me", "Channel": "desktop", "Events": ["assigned", "unblocked"],
+     "AgentID": "8c0e2f5a-..."},
+    {"Name": "team", "Channel": "slack", "Events": ["overdue"],
+     "WebhookURL": "https://hooks.slack.com/services/..."}
+  ]
+
+The task states seen by the last run are kept in .knot/notify-state.json, so
+each event is sent once. The first run only records the current state and
+reports overdue tasks. Run it periodically, e.g. from cron:
+
+  */5 * * * * cd /path/to/project && knot notify
+
+Use --test to send a test notification through every hook.`,
+		Action: notifyAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "test",
+				Usage: "Send a test notification through the hooks instead of checking for events",
+			},
+			&cli.StringFlag{
+				Name:  "hook",
+				Usage: "Only use the hook with this name",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Print the notifications without sending them or recording the state",
+			},
+		},
+	}
+}
+
+// hookSender is a configured hook with its sender
+type hookSender struct {
+	hook   manager.NotificationHook
+	sender notify.Sender
+	sent   int
+	errors []string
+}
+
+func notifyAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		hooks := appCtx.ProjectManager.GetConfig().Notifications
+		if name := c.String("hook"); name != "" {
+			hooks = findHook(hooks, name)
+			if len(hooks) == 0 {
+				return errors.NewValidationError("unknown notification hook", fmt.Errorf("hook '%s' not found", name))
+			}
+		}
+		if len(hooks) == 0 {
+			appCtx.Out().Println("No notification hooks configured (add Notifications to .knot/config.json, see 'knot notify --help')")
+			return nil
+		}
+
+		senders := make([]*hookSender, 0, len(hooks))
+		for _, hook := range hooks {
+			sender, err := notify.NewSender(hook.Channel, hook.WebhookURL)
+			if err != nil {
+				return errors.NewValidationError("invalid notification hook", fmt.Errorf("hook '%s': %w", hook.Name, err))
+			}
+			senders = append(senders, &hookSender{hook: hook, sender: sender})
+		}
+
+		if c.Bool("test") {
+			for _, s := range senders {
+				s.send(c, notify.Notification{Event: notify.EventTest})
+			}
+			return report(appCtx.Out(), senders, "test notification")
+		}
+
+		notifications, err := detect(c, appCtx, hooks)
+		if err != nil {
+			return err
+		}
+
+		dryRun := c.Bool("dry-run")
+		for _, n := range notifications {
+			for _, s := range senders {
+				if !wants(s.hook, n) {
+					continue
+				}
+				if dryRun {
+					appCtx.Out().Printf("Would notify %s: %s: %s\n", s.hook.Name, n.Title(), strings.SplitN(n.Message(), "\n", 2)[0])
+					continue
+				}
+				s.send(c, n)
+			}
+		}
+		if dryRun {
+			return nil
+		}
+		return report(appCtx.Out(), senders, "notification")
+	}
+}
+
+// detect finds the events in the projects the hooks watch and records the new
+// state, unless this is a dry run
+func detect(c *cli.Context, appCtx *shared.AppContext, hooks []manager.NotificationHook) ([]notify.Notification, error) {
+	projects, err := watchedProjects(c, appCtx, hooks)
+	if err != nil {
+		return nil, err
+	}
+
+	statePath, err := notify.DefaultStatePath()
+	if err != nil {
+		return nil, err
+	}
+	state, err := notify.LoadState(statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	notifications := make([]notify.Notification, 0)
+	for _, project := range projects {
+		tasks, err := appCtx.ProjectManager.ListTasksWithDependencies(c.Context, project.ID)
+		if err != nil {
+			return nil, errors.WrapWithSuggestion(err, "listing tasks of project")
+		}
+		found, statuses := notify.Detect(project, tasks, state.Projects[project.ID], now)
+		notifications = append(notifications, found...)
+		state.Projects[project.ID] = statuses
+	}
+
+	appCtx.Logger.Info("Detected notification events",
+		zap.Int("projects", len(projects)), zap.Int("events", len(notifications)))
+
+	if !c.Bool("dry-run") {
+		if err := state.Save(statePath); err != nil {
+			return nil, err
+		}
+	}
+	return notifications, nil
+}
+
+// watchedProjects returns all projects if a hook is not limited to one
+// project, otherwise the projects of the hooks
+func watchedProjects(c *cli.Context, appCtx *shared.AppContext, hooks []manager.NotificationHook) ([]*types.Project, error) {
+	ids := make([]uuid.UUID, 0, len(hooks))
+	for _, hook := range hooks {
+		if hook.ProjectID == nil {
+			projects, err := appCtx.ProjectManager.ListProjects(c.Context)
+			if err != nil {
+				return nil, errors.WrapWithSuggestion(err, "listing projects")
+			}
+			return projects, nil
+		}
+		ids = append(ids, *hook.ProjectID)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(ids))
+	projects := make([]*types.Project, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		project, err := appCtx.ProjectManager.GetProject(c.Context, id)
+		if err != nil {
+			return nil, errors.WrapWithSuggestion(err, "loading project of notification hook")
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
+
+// wants reports whether a hook selects a notification
+func wants(hook manager.NotificationHook, n notify.Notification) bool {
+	if hook.ProjectID != nil && *hook.ProjectID != n.ProjectID {
+		return false
+	}
+	if len(hook.Events) > 0 && !contains(hook.Events, n.Event) {
+		return false
+	}
+	if n.Event == notify.EventAssigned && hook.AgentID != nil {
+		return n.Task.AssignedAgent != nil && *n.Task.AssignedAgent == *hook.AgentID
+	}
+	return true
+}
+
+func (s *hookSender) send(c *cli.Context, n notify.Notification) {
+	if err := s.sender.Send(c.Context, n); err != nil {
+		s.errors = append(s.errors, err.Error())
+		return
+	}
+	s.sent++
+}
+
+// report prints what each hook sent and fails if a hook could not deliver
+func report(out output.Writer, senders []*hookSender, what string) error {
+	failed := 0
+	for _, s := range senders {
+		out.Printf("%s (%s): sent %d %s(s)\n", s.hook.Name, s.hook.Channel, s.sent, what)
+		for _, errMsg := range s.errors {
+			out.Printf("  Error: %s\n", errMsg)
+		}
+		if len(s.errors) > 0 {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return &errors.EnhancedError{
+			Operation:   "sending notifications",
+			Cause:       fmt.Errorf("%d of %d notification hooks failed", failed, len(senders)),
+			Suggestion:  "Check the Channel and WebhookURL of the hooks in .knot/config.json; desktop notifications need notify-send (Linux) or osascript (macOS)",
+			HelpCommand: "knot notify --test",
+		}
+	}
+	return nil
+}
+
+func findHook(hooks []manager.NotificationHook, name string) []manager.NotificationHook {
+	for _, hook := range hooks {
+		if hook.Name == name {
+			return []manager.NotificationHook{hook}
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
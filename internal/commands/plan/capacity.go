@@ -0,0 +1,216 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+func newCapacityCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "capacity",
+		Usage: "Compare estimated work assigned to agents with their capacity",
+		Description: `Sums the estimates of pending and in-progress tasks assigned to each agent and
+compares them with the agent's capacity within the horizon. Horizons and
+capacities use working time: 1d is 8h, 1w is 5d.
+
+Agents without a configured capacity can take on one working week of work per
+week. Over-allocated agents get suggestions to move pending tasks to agents
+with free capacity; use --apply to assign the tasks accordingly.`,
+		Action: capacityAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "horizon",
+				Usage: "Planning horizon as working time (e.g. 3d, 2w)",
+				Value: "1w",
+			},
+			&cli.BoolFlag{
+				Name:  "apply",
+				Usage: "Assign tasks as suggested",
+			},
+			shared.NewJSONFlag(),
+		},
+		Subcommands: []*cli.Command{
+			{
+				Name:   "set",
+				Usage:  "Set the weekly capacity of an agent",
+				Action: setCapacityAction(appCtx),
+				Flags: []cli.Flag{
+					agentFlag(),
+					&cli.StringFlag{
+						Name:     "per-week",
+						Usage:    "Estimated work the agent can take on per week (e.g. 20h, 3d)",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "reset",
+				Usage:  "Reset the capacity of an agent to one working week per week",
+				Action: resetCapacityAction(appCtx),
+				Flags:  []cli.Flag{agentFlag()},
+			},
+		},
+	}
+}
+
+func agentFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:     "agent",
+		Aliases:  []string{"a"},
+		Usage:    "Agent ID",
+		Required: true,
+	}
+}
+
+func parseAgentID(c *cli.Context) (uuid.UUID, error) {
+	agentID, err := uuid.Parse(c.String("agent"))
+	if err != nil {
+		return uuid.Nil, errors.InvalidUUIDError("agent", c.String("agent"))
+	}
+	return agentID, nil
+}
+
+func capacityAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		horizon, err := utils.ParseEstimate(c.String("horizon"))
+		if err != nil {
+			return errors.NewValidationError("invalid horizon", fmt.Errorf("invalid horizon: %w", err))
+		}
+		if horizon <= 0 {
+			return errors.NewValidationError("invalid horizon", fmt.Errorf("horizon must be greater than zero"))
+		}
+
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+		if err != nil {
+			return errors.WrapWithSuggestion(err, "listing tasks")
+		}
+
+		report := analysis.PlanCapacity(tasks, horizon, appCtx.ProjectManager.GetConfig())
+
+		if c.Bool("apply") {
+			for _, r := range report.Reassignments {
+				if _, err := appCtx.ProjectManager.AssignTaskToAgent(c.Context, r.TaskID, r.To); err != nil {
+					return errors.WrapWithSuggestion(err, "assigning task")
+				}
+				appCtx.Logger.Info("Reassigned task",
+					zap.String("taskID", r.TaskID.String()),
+					zap.String("from", r.From.String()),
+					zap.String("to", r.To.String()))
+			}
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal capacity report to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		printCapacityReport(appCtx.Out(), report, c.Bool("apply"))
+		return nil
+	}
+}
+
+func printCapacityReport(out output.Writer, report *analysis.CapacityReport, applied bool) {
+	out.Printf("Capacity for the next %s:\n\n", utils.FormatEstimate(report.Horizon))
+
+	if len(report.Agents) == 0 {
+		out.Println("  No open tasks are assigned to agents and no agent capacities are configured.")
+	}
+	for _, load := range report.Agents {
+		out.Printf("  Agent %s\n", load.AgentID)
+		out.Printf("    Capacity: %s, assigned: %s (%d task(s))\n",
+			utils.FormatEstimate(load.Capacity), utils.FormatEstimate(load.Assigned), load.Tasks)
+		if load.Overallocated {
+			out.Printf("    OVER-ALLOCATED by %s\n", utils.FormatEstimate(-load.Free))
+		} else {
+			out.Printf("    Free: %s\n", utils.FormatEstimate(load.Free))
+		}
+		if load.Unestimated > 0 {
+			out.Printf("    Unestimated: %d task(s) not included\n", load.Unestimated)
+		}
+	}
+
+	if report.Unassigned > 0 {
+		out.Printf("\nUnassigned open work: %s\n", utils.FormatEstimate(report.Unassigned))
+	}
+
+	if len(report.Reassignments) == 0 {
+		return
+	}
+	if applied {
+		out.Printf("\nReassigned %d task(s):\n", len(report.Reassignments))
+	} else {
+		out.Printf("\nSuggested reassignments (%d):\n", len(report.Reassignments))
+	}
+	for _, r := range report.Reassignments {
+		out.Printf("  %s (ID: %s, %s)\n    %s -> %s\n",
+			r.Title, r.TaskID, utils.FormatEstimate(r.Estimate), r.From, r.To)
+	}
+	if !applied {
+		out.Println("\nRun 'knot plan capacity --apply' to assign the tasks as suggested.")
+	}
+}
+
+func setCapacityAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		agentID, err := parseAgentID(c)
+		if err != nil {
+			return err
+		}
+		minutes, err := utils.ParseEstimate(c.String("per-week"))
+		if err != nil {
+			return errors.NewValidationError("invalid capacity", fmt.Errorf("invalid capacity: %w", err))
+		}
+
+		newConfig := *appCtx.ProjectManager.GetConfig()
+		newConfig.SetAgentCapacity(agentID, minutes)
+		appCtx.ProjectManager.UpdateConfig(&newConfig)
+		if err := appCtx.ProjectManager.SaveConfigToFile(); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+
+		appCtx.Logger.Info("Set agent capacity", zap.String("agentID", agentID.String()), zap.Int64("minutes", minutes))
+		appCtx.Out().Printf("Set capacity of agent %s to %s per week\n", agentID, utils.FormatEstimate(minutes))
+		return nil
+	}
+}
+
+func resetCapacityAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		agentID, err := parseAgentID(c)
+		if err != nil {
+			return err
+		}
+
+		newConfig := *appCtx.ProjectManager.GetConfig()
+		if !newConfig.ResetAgentCapacity(agentID) {
+			return errors.NewValidationError("no capacity configured",
+				fmt.Errorf("no capacity configured for agent %s", agentID))
+		}
+		appCtx.ProjectManager.UpdateConfig(&newConfig)
+		if err := appCtx.ProjectManager.SaveConfigToFile(); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+
+		appCtx.Logger.Info("Reset agent capacity", zap.String("agentID", agentID.String()))
+		appCtx.Out().Printf("Reset capacity of agent %s to %s per week\n", agentID, utils.FormatEstimate(utils.MinutesPerWeek))
+		return nil
+	}
+}
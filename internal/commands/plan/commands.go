@@ -0,0 +1,227 @@
+package plan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	knoterrors "github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/plan"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// NewPlanCommand creates the plan command, which validates a batch of changes and shows a diff
+func NewPlanCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "plan",
+		Usage: "Validate a declarative batch of changes and show what would change",
+		Description: `Reads a YAML (or JSON) plan file containing task creations, task updates and
+dependency edits, validates the whole batch against the current project
+(constraints, state transitions, dependency cycles) and shows the resulting diff.
+Nothing is written. Use 'knot apply' to apply the plan.
+
+Plan file format:
+  creates:
+    - ref: design              # optional name usable by later operations
+      title: Design API
+      complexity: 4
+      priority: high           # low, medium, high
+      parent: <task-id|ref>    # optional
+  moves:
+    - id: <task-id|ref>        # moved with its subtasks
+      parent: <task-id|ref>    # new parent, omit for the root level
+  updates:
+    - id: <task-id|ref>
+      state: in-progress       # title, description, complexity, priority, state
+  dependencies:
+    remove:
+      - task: <task-id|ref>
+        depends_on: <task-id|ref>
+    add:
+      - task: <task-id|ref>
+        depends_on: design
+
+Use 'knot plan capacity' to compare estimated work with agent capacities.`,
+		Action: planAction(appCtx),
+		// --file is checked in planAction, required flags of a parent command
+		// would also be enforced for its subcommands
+		Flags: []cli.Flag{fileFlag(false), shared.NewJSONFlag()},
+		Subcommands: []*cli.Command{
+			newCapacityCommand(appCtx),
+		},
+	}
+}
+
+// NewApplyCommand creates the apply command, which applies a plan file atomically
+func NewApplyCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "apply",
+		Usage: "Validate and atomically apply a declarative batch of changes",
+		Description: `Validates the plan file exactly like 'knot plan' and applies it only if the
+whole batch is valid. If an operation fails while applying, all changes made so
+far are rolled back.`,
+		Action: applyAction(appCtx),
+		Flags:  planFlags(),
+	}
+}
+
+func planFlags() []cli.Flag {
+	return []cli.Flag{fileFlag(true), shared.NewJSONFlag()}
+}
+
+func fileFlag(required bool) cli.Flag {
+	return &cli.StringFlag{
+		Name:     "file",
+		Aliases:  []string{"f"},
+		Usage:    "Plan file (YAML or JSON)",
+		Required: required,
+	}
+}
+
+func planAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if !c.IsSet("file") {
+			return fmt.Errorf("Required flag \"file\" not set")
+		}
+
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		p, err := plan.LoadFile(c.String("file"))
+		if err != nil {
+			return err
+		}
+
+		actor := shared.GetActorFromContext(c)
+		appCtx.Logger.Info("Previewing plan",
+			zap.String("projectID", projectID.String()),
+			zap.String("file", c.String("file")))
+
+		result, err := plan.Preview(c.Context, appCtx.ProjectManager, projectID, p, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to preview plan", zap.Error(err))
+			return fmt.Errorf("failed to preview plan: %w", err)
+		}
+
+		if c.Bool("json") {
+			if err := outputResultAsJSON(appCtx.Out(), result); err != nil {
+				return err
+			}
+		} else {
+			printResult(appCtx.Out(), result, "Plan")
+		}
+
+		if !result.Valid() {
+			return invalidPlanError(result)
+		}
+
+		if !c.Bool("json") {
+			appCtx.Out().Printf("\nPlan is valid. Run 'knot apply --file %s' to apply it.\n", c.String("file"))
+		}
+		return nil
+	}
+}
+
+func applyAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		p, err := plan.LoadFile(c.String("file"))
+		if err != nil {
+			return err
+		}
+
+		actor := shared.GetActorFromContext(c)
+		appCtx.Logger.Info("Applying plan",
+			zap.String("projectID", projectID.String()),
+			zap.String("file", c.String("file")),
+			zap.String("actor", actor))
+
+		result, err := plan.Apply(c.Context, appCtx.ProjectManager, projectID, p, actor)
+		if errors.Is(err, plan.ErrInvalidPlan) {
+			if c.Bool("json") {
+				_ = outputResultAsJSON(appCtx.Out(), result)
+			} else {
+				printResult(appCtx.Out(), result, "Plan")
+			}
+			return invalidPlanError(result)
+		}
+		if err != nil {
+			appCtx.Logger.Error("Failed to apply plan", zap.Error(err))
+			return err
+		}
+
+		if c.Bool("json") {
+			return outputResultAsJSON(appCtx.Out(), result)
+		}
+
+		printResult(appCtx.Out(), result, "Applied")
+		appCtx.Out().Printf("\nSuccessfully applied %d change(s)\n", len(result.Changes))
+		appCtx.Out().Printf("  Applied by: %s\n", actor)
+		return nil
+	}
+}
+
+func printResult(out output.Writer, result *plan.Result, heading string) {
+	out.Printf("%s: %d change(s)\n\n", heading, len(result.Changes))
+
+	for _, change := range result.Changes {
+		out.Printf("  %s %-18s %s", changeSymbol(change.Kind), change.Kind, change.Title)
+		switch {
+		case change.TaskID != "" && change.Ref != "":
+			out.Printf(" (ID: %s, ref: %s)", change.TaskID, change.Ref)
+		case change.TaskID != "":
+			out.Printf(" (ID: %s)", change.TaskID)
+		case change.Ref != "":
+			out.Printf(" (ref: %s)", change.Ref)
+		}
+		out.Println()
+		if len(change.Details) > 0 {
+			out.Printf("      %s\n", strings.Join(change.Details, ", "))
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		out.Printf("\nValidation errors (%d):\n", len(result.Errors))
+		for _, e := range result.Errors {
+			out.Printf("  - %s\n", e)
+		}
+	}
+}
+
+func changeSymbol(kind plan.ChangeKind) string {
+	switch kind {
+	case plan.ChangeCreate, plan.ChangeAddDependency:
+		return "+"
+	case plan.ChangeRemoveDependency:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+func outputResultAsJSON(out output.Writer, result *plan.Result) error {
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan result to JSON: %w", err)
+	}
+	out.Println(string(jsonData))
+	return nil
+}
+
+func invalidPlanError(result *plan.Result) error {
+	return &knoterrors.EnhancedError{
+		Operation:  "validating plan",
+		Cause:      fmt.Errorf("plan has %d validation error(s); nothing was applied", len(result.Errors)),
+		Suggestion: "Fix the listed operations in the plan file and run 'knot plan' again",
+	}
+}
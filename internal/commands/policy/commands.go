@@ -0,0 +1,167 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// projectReport is the aging policy report of one project
+type projectReport struct {
+	ProjectID uuid.UUID `json:"project_id"`
+	Title     string    `json:"title"`
+	*analysis.AgingPolicyReport
+}
+
+// checkResult is the outcome of 'knot policy check'
+type checkResult struct {
+	Projects   []projectReport `json:"projects"`
+	Violations int             `json:"total_violations"`
+}
+
+// Commands returns the policy subcommands
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "check",
+			Usage: "Report tasks older than the aging policy of their priority",
+			Description: `Checks the open tasks of the current project, or of all projects with --all,
+against the AgingPolicies of .knot/config.json, e.g.
+
+  "AgingPolicies": [
+    {"Priority": "high", "MaxAgeDays": 3},
+    {"Priority": "high", "State": "in-progress", "MaxAgeDays": 5},
+    {"Priority": "medium", "MaxAgeDays": 14}
+  ]
+
+flags high-priority tasks pending for more than 3 days or in progress for more
+than 5 days, and medium-priority tasks pending for more than 14 days. State is
+pending, in-progress or blocked, pending if omitted. A task's age counts from
+when it entered its current state.
+
+The command exits with code 8 if any task violates a policy, so it can gate
+project hygiene in CI:
+
+  knot policy check --all --json > aging.json`,
+			Action: checkAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "all",
+					Usage: "Check all projects instead of the current project",
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+	}
+}
+
+func checkAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		policies := appCtx.ProjectManager.GetConfig().AgingPolicies
+
+		var projects []*types.Project
+		if c.Bool("all") {
+			all, err := appCtx.ProjectManager.ListProjects(c.Context)
+			if err != nil {
+				appCtx.Logger.Error("Failed to list projects", zap.Error(err))
+				return errors.WrapWithSuggestion(err, "listing projects")
+			}
+			projects = all
+		} else {
+			projectID, err := shared.ResolveProjectID(c, appCtx)
+			if err != nil {
+				return err
+			}
+			project, err := appCtx.ProjectManager.GetProject(c.Context, projectID)
+			if err != nil {
+				return errors.WrapWithSuggestion(err, "getting project")
+			}
+			projects = []*types.Project{project}
+		}
+
+		now := appCtx.ProjectManager.GetCurrentTime()
+		result := checkResult{Projects: []projectReport{}}
+		for _, project := range projects {
+			tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, project.ID)
+			if err != nil {
+				appCtx.Logger.Error("Failed to get project tasks", zap.Error(err))
+				return fmt.Errorf("failed to get project tasks: %w", err)
+			}
+			// The change feed is optional; without it ages count from task timestamps
+			projectID := project.ID
+			events, err := appCtx.ProjectManager.ListChangeEvents(c.Context, types.ChangeEventFilter{ProjectID: &projectID})
+			if err != nil {
+				appCtx.Logger.Warn("Change feed unavailable, using task timestamps", zap.Error(err))
+				events = nil
+			}
+
+			report := analysis.CheckAgingPolicies(tasks, events, policies, now)
+			result.Violations += len(report.Violations)
+			result.Projects = append(result.Projects, projectReport{
+				ProjectID:         project.ID,
+				Title:             project.Title,
+				AgingPolicyReport: report,
+			})
+		}
+		appCtx.Logger.Info("Checked aging policies",
+			zap.Int("projects", len(result.Projects)),
+			zap.Int("violations", result.Violations))
+
+		if c.Bool("json") {
+			data, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal policy report to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(data))
+		} else {
+			printResult(c, policies, result)
+		}
+
+		if result.Violations > 0 {
+			return &errors.EnhancedError{
+				Operation:   "checking aging policies",
+				Cause:       fmt.Errorf("%d aging policy violation(s)", result.Violations),
+				Suggestion:  "Start, reprioritize or cancel the listed tasks, or adjust AgingPolicies in .knot/config.json",
+				HelpCommand: "knot policy check --help",
+			}
+		}
+		return nil
+	}
+}
+
+func printResult(c *cli.Context, policies []manager.AgingPolicy, result checkResult) {
+	w := c.App.Writer
+	if len(policies) == 0 {
+		fmt.Fprintln(w, "No aging policies configured (add AgingPolicies to .knot/config.json)")
+		return
+	}
+
+	var rules []string
+	for _, policy := range policies {
+		rules = append(rules, fmt.Sprintf("%s %s > %dd", policy.Priority, policy.TaskState(), policy.MaxAgeDays))
+	}
+	fmt.Fprintf(w, "Aging policies: %s\n\n", strings.Join(rules, ", "))
+
+	for _, project := range result.Projects {
+		if len(project.Violations) == 0 {
+			fmt.Fprintf(w, "%s: %d task(s) checked, no violations\n", project.Title, project.Checked)
+			continue
+		}
+		fmt.Fprintf(w, "%s: %d of %d task(s) violate a policy\n", project.Title, len(project.Violations), project.Checked)
+		for _, violation := range project.Violations {
+			fmt.Fprintf(w, "  %s (ID: %s)\n", violation.Title, violation.TaskID)
+			fmt.Fprintf(w, "    %s priority, %s for %d days (max %d) since %s\n",
+				violation.Priority, violation.State, violation.AgeDays, violation.MaxAgeDays, output.Timestamp(violation.Since))
+		}
+	}
+}
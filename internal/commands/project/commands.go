@@ -1,14 +1,16 @@
 package project
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/placeholder"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/types"
-	"github.com/denkhaus/knot/v2/internal/validation"
 	"github.com/google/uuid"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
@@ -31,8 +33,15 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 				&cli.StringFlag{
 					Name:    "description",
 					Aliases: []string{"d"},
-					Usage:   "Project description",
+					Usage:   "Project description, {{project.title}}, {{date}}, {{actor}} and --var placeholders are expanded",
 				},
+				&cli.StringSliceFlag{
+					Name:    "var",
+					Aliases: []string{"v"},
+					Usage:   "Custom placeholder for the description in format key=value (can specify multiple)",
+				},
+				shared.NewQuietIDFlag(),
+				shared.NewCopyFlag("Copy the ID of the created project to the clipboard"),
 			},
 		},
 		{
@@ -82,6 +91,10 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 					Usage:    "Project ID to select",
 					Required: true,
 				},
+				&cli.BoolFlag{
+					Name:  "session",
+					Usage: "Select the project only in the current session, see 'knot session start'",
+				},
 			},
 		},
 		{
@@ -100,7 +113,72 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 			Name:   "clear-selection",
 			Usage:  "Clear the currently selected project",
 			Action: clearSelectionAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "session",
+					Usage: "Clear only the selection of the current session",
+				},
+			},
 		},
+		{
+			Name:  "lock",
+			Usage: "Lock a project for exclusive writes by the current actor",
+			Description: `Places an advisory lock on the project, e.g. for the duration of an agent
+session. Until the lock is released or expires, writes by any other actor fail
+with "project locked by X until Y" unless they pass the global --force flag.
+Locking again as the owner extends the lock.`,
+			Action: lockAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "id",
+					Usage: "Project ID (default: selected project)",
+				},
+				&cli.StringFlag{
+					Name:  "reason",
+					Usage: "Why the project is locked, shown to other writers",
+				},
+				&cli.DurationFlag{
+					Name:  "ttl",
+					Usage: "How long the lock lasts, e.g. 30m or 4h",
+					Value: manager.DefaultLockTTL,
+				},
+				&cli.BoolFlag{
+					Name:  "force",
+					Usage: "Take over a lock held by another actor",
+				},
+			},
+		},
+		{
+			Name:   "unlock",
+			Usage:  "Release the lock of a project",
+			Action: unlockAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "id",
+					Usage: "Project ID (default: selected project)",
+				},
+				&cli.BoolFlag{
+					Name:  "force",
+					Usage: "Release a lock held by another actor",
+				},
+			},
+		},
+		{
+			Name:  "update-instructions",
+			Usage: "Set the agent instructions of a project",
+			Description: `Instructions are standing guidance for agents working on any task of the
+project, e.g. "always write tests" or "use library X". 'knot task prompt' and
+'knot agent context' list them before the instructions of the parent chain and
+the task itself (see 'knot task update-instructions').`,
+			Action: updateInstructionsAction(appCtx),
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  "id",
+					Usage: "Project ID (default: selected project)",
+				},
+			}, shared.NewInstructionsFlags()...),
+		},
+		newDocCommand(appCtx),
 	}
 }
 
@@ -110,34 +188,55 @@ func createAction(appCtx *shared.AppContext) cli.ActionFunc {
 		description := c.String("description")
 		actor := c.String("actor")
 
-		// Create input validator
-		validator := validation.NewInputValidator()
+		// Create input validator with the configured rules
+		validator, err := appCtx.ProjectManager.GetConfig().Validator()
+		if err != nil {
+			return errors.NewValidationError("invalid validation rules", err)
+		}
 
 		// Validate inputs
 		if err := validator.ValidateProjectTitle(title); err != nil {
 			return errors.NewValidationError("invalid project title", err)
 		}
 
+		// Default to $USER if actor is not provided
+		actor = shared.ResolveActor(actor)
+
+		vars, err := placeholder.ParseVars(c.StringSlice("var"))
+		if err != nil {
+			return errors.NewValidationError("invalid placeholder variable", err)
+		}
+		values := placeholder.New(appCtx.ProjectManager.GetCurrentTime(), actor)
+		values["project.title"] = title
+		description = values.AddVars(vars).Expand(description)
 		if err := validator.ValidateProjectDescription(description); err != nil {
 			return errors.NewValidationError("invalid project description", err)
 		}
 
-		// Default to $USER if actor is not provided
-		actor = shared.ResolveActor(actor)
-
 		appCtx.Logger.Info("Creating project", zap.String("title", title), zap.String("description", description), zap.String("actor", actor))
 
-		project, err := appCtx.ProjectManager.CreateProject(context.Background(), title, description, actor)
+		project, err := appCtx.ProjectManager.CreateProject(c.Context, title, description, actor)
 		if err != nil {
 			appCtx.Logger.Error("Failed to create project", zap.Error(err))
 			return errors.WrapWithSuggestion(err, "creating project")
 		}
 
 		appCtx.Logger.Info("Project created successfully", zap.String("projectID", project.ID.String()), zap.String("title", project.Title), zap.String("actor", actor))
-		fmt.Printf("Created project: %s (ID: %s)\n", project.Title, project.ID)
-		fmt.Printf("  Created by: %s\n", actor)
+		if c.Bool("copy") {
+			shared.CopyToClipboard(project.ID.String(), "the project ID")
+		}
+		out := appCtx.Out()
+		if c.Bool("quiet") {
+			out = output.NewQuietWriter(out)
+		}
+		if printed, err := out.Result(project.ID, project); printed || err != nil {
+			return err
+		}
+
+		appCtx.Out().Printf("Created project: %s (ID: %s)\n", project.Title, project.ID)
+		appCtx.Out().Printf("  Created by: %s\n", actor)
 		if project.Description != "" {
-			fmt.Printf("  Description: %s\n", project.Description)
+			appCtx.Out().Printf("  Description: %s\n", project.Description)
 		}
 		return nil
 	}
@@ -147,7 +246,7 @@ func listAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		appCtx.Logger.Info("Listing projects")
 
-		projects, err := appCtx.ProjectManager.ListProjects(context.Background())
+		projects, err := appCtx.ProjectManager.ListProjects(c.Context)
 		if err != nil {
 			appCtx.Logger.Error("Failed to list projects", zap.Error(err))
 			return errors.WrapWithSuggestion(err, "listing projects")
@@ -157,11 +256,15 @@ func listAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		// Output JSON if requested
 		if c.Bool("json") {
-			jsonData, err := json.MarshalIndent(projects, "", "  ")
+			var data any = projects
+			if weighted := weightedProgresses(c, appCtx, projects); weighted != nil {
+				data = weighted
+			}
+			jsonData, err := json.MarshalIndent(data, "", "  ")
 			if err != nil {
 				return fmt.Errorf("failed to marshal projects to JSON: %w", err)
 			}
-			fmt.Println(string(jsonData))
+			appCtx.Out().Println(string(jsonData))
 			return nil
 		}
 
@@ -169,20 +272,61 @@ func listAction(appCtx *shared.AppContext) cli.ActionFunc {
 			return errors.EmptyResultError("list projects", "current workspace")
 		}
 
-		fmt.Printf("Found %d project(s):\n\n", len(projects))
+		appCtx.Out().Printf("Found %d project(s):\n\n", len(projects))
 		for _, project := range projects {
-			fmt.Printf("• %s (ID: %s)\n", project.Title, project.ID)
+			appCtx.Out().Printf("• %s (ID: %s)\n", project.Title, project.ID)
 			if project.Description != "" {
-				fmt.Printf("  %s\n", project.Description)
+				appCtx.Out().Printf("  %s\n", output.Summary(project.Description))
 			}
-			fmt.Printf("  Progress: %.1f%% (%d/%d tasks completed)\n",
-				project.Progress, project.CompletedTasks, project.TotalTasks)
-			fmt.Println()
+			appCtx.Out().Printf("  Progress: %s\n", formatProgress(c, appCtx, project))
+			appCtx.Out().Println()
 		}
 		return nil
 	}
 }
 
+// projectWithProgress adds the weighted progress to a project in JSON output
+type projectWithProgress struct {
+	*types.Project
+	Weighting        string  `json:"weighting"`
+	WeightedProgress float64 `json:"weighted_progress"`
+}
+
+// weightedProgresses returns the projects with their weighted progress, or nil
+// if progress is not weighted
+func weightedProgresses(c *cli.Context, appCtx *shared.AppContext, projects []*types.Project) []projectWithProgress {
+	config := appCtx.ProjectManager.GetConfig()
+	if config.ProgressWeightingMode() == manager.ProgressWeightingCount {
+		return nil
+	}
+	result := make([]projectWithProgress, 0, len(projects))
+	for _, project := range projects {
+		entry := projectWithProgress{Project: project, Weighting: config.ProgressWeightingMode(), WeightedProgress: project.Progress}
+		if progress, err := appCtx.ProjectManager.GetProjectProgress(c.Context, project.ID); err == nil {
+			entry.WeightedProgress = progress.WeightedProgress
+		} else {
+			appCtx.Logger.Warn("Failed to get project progress", zap.String("projectID", project.ID.String()), zap.Error(err))
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// formatProgress formats the progress of a project, followed by the weighted
+// progress if tasks are weighted by complexity or estimate
+func formatProgress(c *cli.Context, appCtx *shared.AppContext, project *types.Project) string {
+	text := fmt.Sprintf("%.1f%% (%d/%d tasks completed)", project.Progress, project.CompletedTasks, project.TotalTasks)
+	if appCtx.ProjectManager.GetConfig().ProgressWeightingMode() == manager.ProgressWeightingCount || project.TotalTasks == 0 {
+		return text
+	}
+	progress, err := appCtx.ProjectManager.GetProjectProgress(c.Context, project.ID)
+	if err != nil {
+		appCtx.Logger.Warn("Failed to get project progress", zap.String("projectID", project.ID.String()), zap.Error(err))
+		return text
+	}
+	return fmt.Sprintf("%s, %.1f%% weighted by %s", text, progress.WeightedProgress, progress.Weighting)
+}
+
 func getAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		idStr := c.String("id")
@@ -193,21 +337,37 @@ func getAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		appCtx.Logger.Info("Getting project", zap.String("projectID", projectID.String()))
 
-		project, err := appCtx.ProjectManager.GetProject(context.Background(), projectID)
+		project, err := appCtx.ProjectManager.GetProject(c.Context, projectID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get project", zap.Error(err))
 			return fmt.Errorf("failed to get project: %w", err)
 		}
 
-		fmt.Printf("Project: %s\n", project.Title)
-		fmt.Printf("ID: %s\n", project.ID)
+		appCtx.Out().Printf("Project: %s\n", project.Title)
+		appCtx.Out().Printf("ID: %s\n", project.ID)
 		if project.Description != "" {
-			fmt.Printf("Description: %s\n", project.Description)
+			appCtx.Out().Printf("Description: %s\n", project.Description)
+		}
+		if project.Instructions != "" {
+			appCtx.Out().Printf("Instructions: %s\n", project.Instructions)
+		}
+		if project.Document != "" {
+			appCtx.Out().Printf("Document: %d characters (knot project doc show --id %s)\n", len([]rune(project.Document)), project.ID)
+		}
+		appCtx.Out().Printf("Progress: %s\n", formatProgress(c, appCtx, project))
+		appCtx.Out().Printf("Created: %s\n", output.Timestamp(project.CreatedAt))
+		appCtx.Out().Printf("Updated: %s\n", output.Timestamp(project.UpdatedAt))
+
+		lock, err := appCtx.ProjectManager.GetProjectLock(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Warn("Failed to get project lock", zap.Error(err))
+		} else if lock != nil {
+			appCtx.Out().Printf("Locked: by %s until %s", lock.Owner, output.Timestamp(lock.ExpiresAt))
+			if lock.Reason != "" {
+				appCtx.Out().Printf(" (%s)", lock.Reason)
+			}
+			appCtx.Out().Println()
 		}
-		fmt.Printf("Progress: %.1f%% (%d/%d tasks completed)\n",
-			project.Progress, project.CompletedTasks, project.TotalTasks)
-		fmt.Printf("Created: %s\n", project.CreatedAt.Format("2006-01-02 15:04:05"))
-		fmt.Printf("Updated: %s\n", project.UpdatedAt.Format("2006-01-02 15:04:05"))
 
 		return nil
 	}
@@ -230,7 +390,7 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 		dryRun := c.Bool("dry-run")
 
 		// Get project details
-		project, err := appCtx.ProjectManager.GetProject(context.Background(), projectID)
+		project, err := appCtx.ProjectManager.GetProject(c.Context, projectID)
 		if err != nil {
 			return &errors.EnhancedError{
 				Operation:   "retrieving project",
@@ -242,7 +402,7 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 		}
 
 		// Check if project has tasks
-		tasks, err := appCtx.ProjectManager.ListTasksForProject(context.Background(), projectID)
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
 		if err != nil {
 			return &errors.EnhancedError{
 				Operation:   "checking project tasks",
@@ -256,22 +416,22 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 		if project.State == types.ProjectStateDeletionPending {
 			// Second call - actually delete the project
 			if dryRun {
-				fmt.Printf("🔍 DRY RUN: Project would be permanently deleted (no actual changes made)\n")
+				appCtx.Out().Print(output.Icon("🔍") + "DRY RUN: Project would be permanently deleted (no actual changes made)\n")
 				return nil
 			}
 
 			// Show what will be deleted
-			fmt.Printf("🗑️  Final deletion of project:\n")
-			fmt.Printf("  • %s (ID: %s)\n", project.Title, project.ID)
+			appCtx.Out().Print(output.Icon("🗑️") + "Final deletion of project:\n")
+			appCtx.Out().Printf("  • %s (ID: %s)\n", project.Title, project.ID)
 			if project.Description != "" {
-				fmt.Printf("    %s\n", project.Description)
+				appCtx.Out().Printf("    %s\n", project.Description)
 			}
 			if len(tasks) > 0 {
-				fmt.Printf("    ⚠️  This will also delete %d task(s)\n", len(tasks))
+				appCtx.Out().Printf("    %sThis will also delete %d task(s)\n", output.Icon("⚠️"), len(tasks))
 			}
 
 			// Perform deletion
-			err = appCtx.ProjectManager.DeleteProject(context.Background(), projectID)
+			err = appCtx.ProjectManager.DeleteProject(c.Context, projectID)
 			if err != nil {
 				return &errors.EnhancedError{
 					Operation:   "deleting project",
@@ -281,39 +441,39 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 				}
 			}
 
-			fmt.Printf("✅ Project permanently deleted: %s\n", project.Title)
+			appCtx.Out().Printf("%sProject permanently deleted: %s\n", output.Icon("✅"), project.Title)
 			return nil
 		} else {
 			// First call - mark for deletion
 			if dryRun {
-				fmt.Printf("🔍 DRY RUN: Project would be marked for deletion (no actual changes made)\n")
+				appCtx.Out().Print(output.Icon("🔍") + "DRY RUN: Project would be marked for deletion (no actual changes made)\n")
 				return nil
 			}
 
 			// Show what will be marked for deletion
-			fmt.Printf("📋 Project to be marked for deletion:\n")
-			fmt.Printf("  • %s (ID: %s)\n", project.Title, project.ID)
+			appCtx.Out().Print(output.Icon("📋") + "Project to be marked for deletion:\n")
+			appCtx.Out().Printf("  • %s (ID: %s)\n", project.Title, project.ID)
 			if project.Description != "" {
-				fmt.Printf("    %s\n", project.Description)
+				appCtx.Out().Printf("    %s\n", project.Description)
 			}
-			fmt.Printf("    Current State: %s\n", project.State)
-			fmt.Printf("    Progress: %.1f%% (%d/%d tasks)\n", project.Progress, project.CompletedTasks, project.TotalTasks)
+			appCtx.Out().Printf("    Current State: %s\n", project.State)
+			appCtx.Out().Printf("    Progress: %.1f%% (%d/%d tasks)\n", project.Progress, project.CompletedTasks, project.TotalTasks)
 
 			if len(tasks) > 0 {
-				fmt.Printf("\n  ⚠️  This project contains %d task(s):\n", len(tasks))
+				appCtx.Out().Printf("\n  %sThis project contains %d task(s):\n", output.Icon("⚠️"), len(tasks))
 				for i, task := range tasks {
 					if i < 5 { // Show first 5 tasks
-						fmt.Printf("    • %s (%s)\n", task.Title, task.State)
+						appCtx.Out().Printf("    • %s (%s)\n", task.Title, task.State)
 					} else if i == 5 {
-						fmt.Printf("    • ... and %d more task(s)\n", len(tasks)-5)
+						appCtx.Out().Printf("    • ... and %d more task(s)\n", len(tasks)-5)
 						break
 					}
 				}
-				fmt.Printf("    All tasks will be deleted with the project.\n")
+				appCtx.Out().Printf("    All tasks will be deleted with the project.\n")
 			}
 
 			// Mark project for deletion
-			_, err = appCtx.ProjectManager.UpdateProjectState(context.Background(), projectID, types.ProjectStateDeletionPending, appCtx.Actor)
+			_, err = appCtx.ProjectManager.UpdateProjectState(c.Context, projectID, types.ProjectStateDeletionPending, appCtx.Actor)
 			if err != nil {
 				return &errors.EnhancedError{
 					Operation:   "marking project for deletion",
@@ -323,10 +483,10 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 				}
 			}
 
-			fmt.Printf("\n⚠️  Project marked for deletion. To confirm deletion, run the same command again:\n")
-			fmt.Printf("    knot project delete --id %s\n", projectID)
-			fmt.Printf("\n💡 To cancel deletion, change the project state:\n")
-			fmt.Printf("    knot project update-state --id %s --state active\n", projectID)
+			appCtx.Out().Print("\n" + output.Icon("⚠️") + "Project marked for deletion. To confirm deletion, run the same command again:\n")
+			appCtx.Out().Printf("    knot project delete --id %s\n", projectID)
+			appCtx.Out().Advise("\n%sTo cancel deletion, change the project state:\n", output.Icon("💡"))
+			appCtx.Out().Advise("    knot project update-state --id %s --state active\n", projectID)
 
 			return nil
 		}
@@ -343,19 +503,38 @@ func selectAction(appCtx *shared.AppContext) cli.ActionFunc {
 		}
 
 		// Verify project exists
-		project, err := appCtx.ProjectManager.GetProject(context.Background(), projectID)
+		project, err := appCtx.ProjectManager.GetProject(c.Context, projectID)
 		if err != nil {
 			return fmt.Errorf("project not found: %w", err)
 		}
 
-		// Set as selected project
 		actor := appCtx.GetActor()
-		err = appCtx.ProjectManager.SetSelectedProject(context.Background(), projectID, actor)
-		if err != nil {
-			return fmt.Errorf("failed to select project: %w", err)
+		if c.Bool("session") {
+			session, err := requireSession()
+			if err != nil {
+				return err
+			}
+			if err := appCtx.ProjectManager.SetSessionProject(c.Context, session, projectID, actor); err != nil {
+				return fmt.Errorf("failed to select project: %w", err)
+			}
+			appCtx.Out().Printf("Selected project for this session: %s (ID: %s)\n", project.Title, project.ID)
+		} else {
+			// Set as selected project
+			err = appCtx.ProjectManager.SetSelectedProject(c.Context, projectID, actor)
+			if err != nil {
+				return fmt.Errorf("failed to select project: %w", err)
+			}
+			appCtx.Out().Printf("Selected project: %s (ID: %s)\n", project.Title, project.ID)
 		}
 
-		fmt.Printf("Selected project: %s (ID: %s)\n", project.Title, project.ID)
+		if envProjectID := os.Getenv(shared.ProjectEnvVar); envProjectID != "" {
+			appCtx.Out().Printf("Note: %s=%s overrides the selection in this environment\n", shared.ProjectEnvVar, envProjectID)
+		} else if session := shared.Session(); session != "" && !c.Bool("session") {
+			sessionProjectID, err := appCtx.ProjectManager.GetSessionProject(c.Context, session)
+			if err == nil && sessionProjectID != nil {
+				appCtx.Out().Println("Note: the project selected in this session (KNOT_SESSION) takes precedence in this shell")
+			}
+		}
 		return nil
 	}
 }
@@ -363,23 +542,23 @@ func selectAction(appCtx *shared.AppContext) cli.ActionFunc {
 // getSelectedAction shows the currently selected project
 func getSelectedAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
-		selectedProjectID, err := appCtx.ProjectManager.GetSelectedProject(context.Background())
+		selectedProjectID, source, err := shared.ResolveSelection(c, appCtx)
 		if err != nil {
-			return fmt.Errorf("failed to get selected project: %w", err)
+			return err
 		}
 
 		if selectedProjectID == nil {
 			if c.Bool("json") {
-				fmt.Println("null")
+				appCtx.Out().Println("null")
 				return nil
 			}
-			fmt.Println("No project currently selected")
-			fmt.Println("Use 'knot project select --id <project-id>' to select a project")
+			appCtx.Out().Println("No project currently selected")
+			appCtx.Out().Println("Use 'knot project select --id <project-id>' to select a project")
 			return nil
 		}
 
 		// Get project details
-		project, err := appCtx.ProjectManager.GetProject(context.Background(), *selectedProjectID)
+		project, err := appCtx.ProjectManager.GetProject(c.Context, *selectedProjectID)
 		if err != nil {
 			return fmt.Errorf("selected project not found: %w", err)
 		}
@@ -389,17 +568,24 @@ func getSelectedAction(appCtx *shared.AppContext) cli.ActionFunc {
 			if err != nil {
 				return fmt.Errorf("failed to marshal project: %w", err)
 			}
-			fmt.Println(string(projectJSON))
+			appCtx.Out().Println(string(projectJSON))
 			return nil
 		}
 
-		fmt.Printf("Currently selected project:\n\n")
-		fmt.Printf("* %s (ID: %s)\n", project.Title, project.ID)
+		switch source {
+		case shared.SelectionFromEnv:
+			appCtx.Out().Printf("Currently selected project (from %s):\n\n", shared.ProjectEnvVar)
+		case shared.SelectionFromSession:
+			appCtx.Out().Printf("Currently selected project (in this session):\n\n")
+		default:
+			appCtx.Out().Printf("Currently selected project:\n\n")
+		}
+		appCtx.Out().Printf("* %s (ID: %s)\n", project.Title, project.ID)
 		if project.Description != "" {
-			fmt.Printf("  %s\n", project.Description)
+			appCtx.Out().Printf("  %s\n", project.Description)
 		}
-		fmt.Printf("  State: %s | Progress: %.1f%%\n", project.State, project.Progress)
-		fmt.Printf("  Tasks: %d total, %d completed\n", project.TotalTasks, project.CompletedTasks)
+		appCtx.Out().Printf("  State: %s | Progress: %.1f%%\n", project.State, project.Progress)
+		appCtx.Out().Printf("  Tasks: %d total, %d completed\n", project.TotalTasks, project.CompletedTasks)
 		return nil
 	}
 }
@@ -407,25 +593,156 @@ func getSelectedAction(appCtx *shared.AppContext) cli.ActionFunc {
 // clearSelectionAction clears the currently selected project
 func clearSelectionAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
+		if c.Bool("session") {
+			session, err := requireSession()
+			if err != nil {
+				return err
+			}
+			if err := appCtx.ProjectManager.ClearSessionProject(c.Context, session); err != nil {
+				return fmt.Errorf("failed to clear session project: %w", err)
+			}
+			appCtx.Out().Println("Session project selection cleared")
+			return nil
+		}
+
 		// Check if there's a selection to clear
-		hasSelected, err := appCtx.ProjectManager.HasSelectedProject(context.Background())
+		hasSelected, err := appCtx.ProjectManager.HasSelectedProject(c.Context)
 		if err != nil {
 			return fmt.Errorf("failed to check selected project: %w", err)
 		}
 
 		if !hasSelected {
-			fmt.Println("No project currently selected")
+			appCtx.Out().Println("No project currently selected")
 			return nil
 		}
 
 		// Clear the selection
-		err = appCtx.ProjectManager.ClearSelectedProject(context.Background())
+		err = appCtx.ProjectManager.ClearSelectedProject(c.Context)
 		if err != nil {
 			return fmt.Errorf("failed to clear selected project: %w", err)
 		}
 
-		fmt.Println("Project selection cleared")
-		fmt.Println("Use 'knot project select --id <project-id>' to select a project")
+		appCtx.Out().Println("Project selection cleared")
+		appCtx.Out().Println("Use 'knot project select --id <project-id>' to select a project")
+		return nil
+	}
+}
+
+// requireSession returns the token of the current session, or an error
+// explaining how to start one
+func requireSession() (string, error) {
+	session := shared.Session()
+	if session == "" {
+		return "", &errors.EnhancedError{
+			Operation:   "selecting session project",
+			Cause:       fmt.Errorf("%s is not set, this shell has no session", shared.SessionEnvVar),
+			Suggestion:  "Start a session in this shell first",
+			Example:     `eval "$(knot session start)"`,
+			HelpCommand: "knot session start --help",
+		}
+	}
+	return session, nil
+}
+
+// lockAction locks a project for the current actor
+func lockAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := resolveProjectOption(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		actor := appCtx.GetActor()
+		ctx := manager.WithWriter(c.Context, actor, c.Bool("force"))
+		lock, err := appCtx.ProjectManager.LockProject(ctx, projectID, c.String("reason"), c.Duration("ttl"), actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to lock project", zap.Error(err))
+			return err
+		}
+
+		appCtx.Logger.Info("Project locked",
+			zap.String("projectID", projectID.String()),
+			zap.String("owner", lock.Owner),
+			zap.Time("expiresAt", lock.ExpiresAt))
+		appCtx.Out().Printf("Project %s locked by %s until %s\n", projectID, lock.Owner, output.Timestamp(lock.ExpiresAt))
+		appCtx.Out().Printf("Release the lock with: knot --actor %q project unlock --id %s\n", lock.Owner, projectID)
+		return nil
+	}
+}
+
+// unlockAction releases the lock of a project
+func unlockAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := resolveProjectOption(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		actor := appCtx.GetActor()
+		ctx := manager.WithWriter(c.Context, actor, c.Bool("force"))
+		if err := appCtx.ProjectManager.UnlockProject(ctx, projectID, actor); err != nil {
+			appCtx.Logger.Error("Failed to unlock project", zap.Error(err))
+			return err
+		}
+
+		appCtx.Logger.Info("Project unlocked", zap.String("projectID", projectID.String()))
+		appCtx.Out().Printf("Project %s unlocked\n", projectID)
 		return nil
 	}
 }
+
+// updateInstructionsAction sets the agent instructions of a project
+func updateInstructionsAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := resolveProjectOption(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		instructions, err := shared.InstructionsFromFlags(c)
+		if err != nil {
+			return err
+		}
+		validator, err := appCtx.ProjectManager.GetConfig().Validator()
+		if err != nil {
+			return errors.NewValidationError("invalid validation rules", err)
+		}
+		if err := validator.ValidateInstructions(instructions); err != nil {
+			return errors.NewValidationError("invalid instructions", err)
+		}
+
+		actor := appCtx.GetActor()
+		project, err := appCtx.ProjectManager.UpdateProjectInstructions(c.Context, projectID, instructions, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to update project instructions", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "updating project instructions")
+		}
+
+		appCtx.Logger.Info("Project instructions updated",
+			zap.String("projectID", projectID.String()),
+			zap.String("actor", actor))
+		if printed, err := appCtx.Out().Result(project.ID, project); printed || err != nil {
+			return err
+		}
+		if project.Instructions == "" {
+			appCtx.Out().Printf("Removed the instructions of project '%s'\n", project.Title)
+		} else {
+			appCtx.Out().Printf("Updated the instructions of project '%s': \"%s\"\n", project.Title, project.Instructions)
+		}
+		appCtx.Out().Printf("  Updated by: %s\n", actor)
+		return nil
+	}
+}
+
+// resolveProjectOption returns the project given by --id or the selected project
+func resolveProjectOption(c *cli.Context, appCtx *shared.AppContext) (uuid.UUID, error) {
+	idStr := c.String("id")
+	if idStr == "" {
+		return shared.ResolveProjectID(c, appCtx)
+	}
+	projectID, err := uuid.Parse(idStr)
+	if err != nil {
+		return uuid.Nil, errors.InvalidUUIDError("project-id", idStr)
+	}
+	return projectID, nil
+}
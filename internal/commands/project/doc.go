@@ -0,0 +1,241 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// projectDocument is the JSON output of the doc show command
+type projectDocument struct {
+	ProjectID uuid.UUID `json:"project_id"`
+	Title     string    `json:"title"`
+	Document  string    `json:"document"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+}
+
+// newDocCommand creates the command group for the long-form project document
+func newDocCommand(appCtx *shared.AppContext) *cli.Command {
+	idFlag := &cli.StringFlag{
+		Name:  "id",
+		Usage: "Project ID (default: selected project)",
+	}
+	return &cli.Command{
+		Name:  "doc",
+		Usage: "Show or edit the project document",
+		Description: `Every project can carry a long-form document beyond the description limit,
+e.g. a README or charter with the goals of the project, its conventions and
+instructions for agents working on it. The document is stored with the project
+in the repository and exported with it.`,
+		Subcommands: []*cli.Command{
+			{
+				Name:   "show",
+				Usage:  "Print the project document",
+				Action: docShowAction(appCtx),
+				Flags: []cli.Flag{
+					idFlag,
+					shared.NewJSONFlag(),
+				},
+			},
+			{
+				Name:  "edit",
+				Usage: "Edit the project document in $EDITOR",
+				Description: `Opens the project document in $EDITOR (default: nano) and saves it when the
+editor exits. With --file the document is read from a file instead, or from
+standard input with --file -. Saving an empty document removes it. If saving
+fails, the edits are kept in a temporary file whose path is printed.`,
+				Action: docEditAction(appCtx),
+				Flags: []cli.Flag{
+					idFlag,
+					&cli.StringFlag{
+						Name:    "file",
+						Aliases: []string{"f"},
+						Usage:   "Read the document from this file instead of opening an editor ('-' for stdin)",
+					},
+				},
+			},
+		},
+	}
+}
+
+// docShowAction prints the document of a project
+func docShowAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		project, err := getDocProject(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(projectDocument{
+				ProjectID: project.ID,
+				Title:     project.Title,
+				Document:  project.Document,
+				UpdatedBy: project.UpdatedBy,
+			}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal project document to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		if project.Document == "" {
+			appCtx.Out().Printf("Project '%s' has no document.\n", project.Title)
+			appCtx.Out().Printf("Write one with: knot project doc edit --id %s\n", project.ID)
+			return nil
+		}
+
+		appCtx.Out().Print(project.Document)
+		if !strings.HasSuffix(project.Document, "\n") {
+			appCtx.Out().Println()
+		}
+		return nil
+	}
+}
+
+// docEditAction replaces the document of a project with the edited text
+func docEditAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		project, err := getDocProject(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		var document, draft string
+		switch path := c.String("file"); path {
+		case "":
+			document, draft, err = editInEditor(project.Document)
+			// The time spent in the editor does not count against --timeout
+			shared.RestartTimeout(c)
+		case "-":
+			var data []byte
+			data, err = io.ReadAll(os.Stdin)
+			document = string(data)
+		default:
+			var data []byte
+			data, err = os.ReadFile(path)
+			document = string(data)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read project document: %w", err)
+		}
+
+		// The edits are kept in the temporary file until they are saved, so a
+		// failed save does not lose an editor session
+		saved := false
+		if draft != "" {
+			defer func() {
+				if saved {
+					os.Remove(draft)
+					return
+				}
+				fmt.Fprintf(os.Stderr, "Your edits are kept in %s\n", draft)
+			}()
+		}
+
+		if strings.TrimSpace(document) == "" {
+			document = ""
+		}
+		if document == project.Document {
+			saved = true
+			appCtx.Out().Printf("Document of project '%s' unchanged.\n", project.Title)
+			return nil
+		}
+
+		validator, err := appCtx.ProjectManager.GetConfig().Validator()
+		if err != nil {
+			return errors.NewValidationError("invalid validation rules", err)
+		}
+		if err := validator.ValidateProjectDocument(document); err != nil {
+			return errors.NewValidationError("invalid project document", err)
+		}
+
+		actor := appCtx.GetActor()
+		updated, err := appCtx.ProjectManager.UpdateProjectDocument(c.Context, project.ID, document, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to update project document", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "updating project document")
+		}
+		saved = true
+
+		appCtx.Logger.Info("Project document updated",
+			zap.String("projectID", updated.ID.String()),
+			zap.Int("length", len(updated.Document)),
+			zap.String("actor", actor))
+
+		if updated.Document == "" {
+			appCtx.Out().Printf("Removed the document of project '%s'\n", updated.Title)
+		} else {
+			appCtx.Out().Printf("Saved the document of project '%s' (%d lines)\n", updated.Title, strings.Count(strings.TrimRight(updated.Document, "\n"), "\n")+1)
+		}
+		appCtx.Out().Printf("Updated: %s by %s\n", output.Timestamp(updated.UpdatedAt), actor)
+		return nil
+	}
+}
+
+// getDocProject loads the project given by --id or the selected project
+func getDocProject(c *cli.Context, appCtx *shared.AppContext) (*types.Project, error) {
+	projectID, err := resolveProjectOption(c, appCtx)
+	if err != nil {
+		return nil, err
+	}
+	project, err := appCtx.ProjectManager.GetProject(c.Context, projectID)
+	if err != nil {
+		appCtx.Logger.Error("Failed to get project", zap.Error(err))
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return project, nil
+}
+
+// editInEditor opens text in $EDITOR and returns the edited text and the
+// temporary file holding it. The caller removes the file once the text is saved.
+func editInEditor(text string) (string, string, error) {
+	file, err := os.CreateTemp("", "knot-project-doc-*.md")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	// $EDITOR may carry arguments, e.g. "code --wait"
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"nano"} // Default editor
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		os.Remove(file.Name())
+		return "", "", fmt.Errorf("failed to open editor: %w", err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		os.Remove(file.Name())
+		return "", "", fmt.Errorf("failed to read edited document: %w", err)
+	}
+	return string(data), file.Name(), nil
+}
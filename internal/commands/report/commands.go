@@ -0,0 +1,238 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// Commands returns the project report subcommands
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "variance",
+			Usage: "Compare logged actual effort against estimates",
+			Description: `Compares the effort logged with 'knot task log-effort' against the task
+estimates, per task, per subtree and per agent. Only tasks that have both an
+estimate and logged effort are compared; by default only completed tasks are
+included. Agents are credited with the share of a task's estimate that matches
+their share of its logged effort. A ratio above 1 means the work took longer
+than estimated.`,
+			Action: varianceAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "include-open",
+					Usage: "Also compare tasks that are not completed yet",
+				},
+				&cli.IntFlag{
+					Name:    "limit",
+					Aliases: []string{"l"},
+					Usage:   "Maximum number of rows per section (0 for all)",
+					Value:   10,
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+		{
+			Name:  "risk",
+			Usage: "Flag tasks that cannot be completed by their due date",
+			Description: `For every incomplete task with a due date (set with 'knot task due'), computes
+the earliest possible completion: the remaining estimate of the task plus the
+longest chain of remaining estimates through its incomplete dependencies and
+subtasks, worked in 8h days on weekdays starting today. Tasks whose earliest
+completion falls after their due date are at risk. Tasks are sorted by slack,
+the working time left after the remaining work, so slipping work shows first.
+Tasks without an estimate count as no work and are reported as unestimated.`,
+			Action: riskAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "all",
+					Usage: "Also list tasks that are on track",
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+	}
+}
+
+func varianceAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		report := analysis.BuildVarianceReport(tasks, analysis.VarianceOptions{IncludeOpen: c.Bool("include-open")})
+		appCtx.Logger.Info("Built variance report",
+			zap.String("projectID", projectID.String()),
+			zap.Int("tasks", len(report.Tasks)))
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal variance report to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		w := appCtx.Out()
+		if len(report.Tasks) == 0 {
+			fmt.Fprintln(w, "No tasks with both an estimate and logged effort.")
+			fmt.Fprintln(w, "Log effort with: knot task log-effort --id <task-id> --duration 1h30m")
+		} else {
+			fmt.Fprintf(w, "Estimate vs. actual over %d tasks: %s\n", report.Total.Tasks, formatVarianceEntry(report.Total))
+			limit := c.Int("limit")
+			writeVarianceSection(w, "Tasks", report.Tasks, limit)
+			writeVarianceSection(w, "Subtrees", report.Subtrees, limit)
+			writeVarianceSection(w, "Agents", report.Agents, limit)
+		}
+
+		if report.Unestimated > 0 {
+			fmt.Fprintf(w, "\n%d task(s) with logged effort have no estimate\n", report.Unestimated)
+		}
+		if report.Unlogged > 0 {
+			fmt.Fprintf(w, "%d estimated task(s) have no logged effort\n", report.Unlogged)
+		}
+		return nil
+	}
+}
+
+func writeVarianceSection(w io.Writer, title string, entries []analysis.VarianceEntry, limit int) {
+	if len(entries) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s (largest variance first):\n", title)
+	for i, entry := range entries {
+		if limit > 0 && i >= limit {
+			fmt.Fprintf(w, "  ... %d more\n", len(entries)-limit)
+			break
+		}
+		fmt.Fprintf(w, "  %s", entry.Name)
+		if entry.TaskID != nil {
+			fmt.Fprintf(w, " (ID: %s)", *entry.TaskID)
+		}
+		fmt.Fprintf(w, "\n    %s", formatVarianceEntry(entry))
+		if entry.Tasks > 1 || entry.TaskID == nil {
+			fmt.Fprintf(w, " over %d tasks", entry.Tasks)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+func formatVarianceEntry(entry analysis.VarianceEntry) string {
+	sign := "+"
+	variance := entry.Variance
+	if variance < 0 {
+		sign = "-"
+		variance = -variance
+	}
+	return fmt.Sprintf("estimate %s, actual %s, variance %s%s (%.2fx)",
+		utils.FormatEstimate(entry.Estimate), utils.FormatEstimate(entry.Actual), sign, utils.FormatEstimate(variance), entry.Ratio)
+}
+
+func riskAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksWithDependencies(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		report := analysis.AssessDeadlineRisk(tasks, time.Now())
+		appCtx.Logger.Info("Built deadline risk report",
+			zap.String("projectID", projectID.String()),
+			zap.Int("tasks", len(report.Tasks)),
+			zap.Int("atRisk", report.AtRisk))
+
+		withDueDate := len(report.Tasks)
+		if !c.Bool("all") {
+			atRisk := make([]analysis.RiskEntry, 0, report.AtRisk)
+			for _, entry := range report.Tasks {
+				if entry.AtRisk {
+					atRisk = append(atRisk, entry)
+				}
+			}
+			report.Tasks = atRisk
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal risk report to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		w := appCtx.Out()
+		if withDueDate == 0 {
+			fmt.Fprintln(w, "No incomplete tasks have a due date.")
+			fmt.Fprintln(w, "Set due dates with: knot task due --id <task-id> --date YYYY-MM-DD")
+			return nil
+		}
+		if len(report.Tasks) == 0 {
+			fmt.Fprintf(w, "All %d task(s) with a due date are on track.\n", withDueDate)
+			return nil
+		}
+
+		fmt.Fprintf(w, "%d task(s) at risk of missing their due date:\n", report.AtRisk)
+		for _, entry := range report.Tasks {
+			writeRiskEntry(w, entry)
+		}
+		return nil
+	}
+}
+
+func writeRiskEntry(w io.Writer, entry analysis.RiskEntry) {
+	status := "on track"
+	switch {
+	case entry.Overdue:
+		status = "OVERDUE"
+	case entry.AtRisk:
+		status = "AT RISK"
+	}
+
+	fmt.Fprintf(w, "\n  [%s] %s (ID: %s, %s)\n", status, entry.Title, entry.TaskID, entry.State)
+	fmt.Fprintf(w, "    Due %s, earliest completion %s\n",
+		entry.DueDate.Format(time.DateOnly), entry.EarliestComplete.Format(time.DateOnly))
+	fmt.Fprintf(w, "    Remaining %s, available %s, slack %s\n",
+		utils.FormatEstimate(entry.Remaining), utils.FormatEstimate(entry.Available), formatSlack(entry.Slack))
+	if len(entry.CriticalPath) > 1 {
+		titles := make([]string, len(entry.CriticalPath))
+		for i, ref := range entry.CriticalPath {
+			titles[i] = ref.Title
+		}
+		fmt.Fprintf(w, "    Critical path: %s\n", strings.Join(titles, " -> "))
+	}
+	if entry.Unestimated > 0 {
+		fmt.Fprintf(w, "    %d unestimated task(s) not included\n", entry.Unestimated)
+	}
+}
+
+func formatSlack(slack int64) string {
+	if slack < 0 {
+		return "-" + utils.FormatEstimate(-slack)
+	}
+	return utils.FormatEstimate(slack)
+}
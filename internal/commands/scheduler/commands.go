@@ -0,0 +1,301 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/commands/template"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/placeholder"
+	"github.com/denkhaus/knot/v2/internal/scheduler"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// scheduleInfo is a schedule as shown by the list command
+type scheduleInfo struct {
+	Name    string     `json:"name"`
+	Cron    string     `json:"cron"`
+	Action  string     `json:"action"`
+	LastRun *time.Time `json:"last_run,omitempty"`
+	NextRun *time.Time `json:"next_run,omitempty"`
+	Due     bool       `json:"due"`
+}
+
+// runResult is the outcome of one due schedule
+type runResult struct {
+	Schedule   string    `json:"schedule"`
+	Occurrence time.Time `json:"occurrence"`
+	// Created counts the created tasks, or the tasks a dry run would create
+	Created int         `json:"created"`
+	TaskIDs []uuid.UUID `json:"task_ids,omitempty"`
+	Errors  []string    `json:"errors,omitempty"`
+}
+
+// Commands returns the scheduler subcommands
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List the configured schedules with their last and next run",
+			Action: listAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewJSONFlag(),
+			},
+		},
+		{
+			Name:  "run",
+			Usage: "Run the schedules that are due",
+			Description: `Applies the templates and creates the recurring tasks of every schedule in
+.knot/config.json whose latest cron occurrence has not run yet. Intended to be
+invoked periodically by cron or a systemd timer, e.g.
+
+  */15 * * * * cd /path/to/project && knot scheduler run
+
+The occurrence each schedule last ran for is recorded in
+.knot/scheduler-state.json, so repeated invocations do not create duplicates.
+Missed occurrences run once, for the latest occurrence.`,
+			Action: runAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "name",
+					Aliases: []string{"n"},
+					Usage:   "Only run the schedule with this name",
+				},
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Show what is due without creating tasks or recording runs",
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+	}
+}
+
+func listAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		schedules := appCtx.ProjectManager.GetConfig().Schedules
+		state, _, err := loadState()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		infos := make([]scheduleInfo, 0, len(schedules))
+		for _, sch := range schedules {
+			cron, err := scheduler.ParseCron(sch.Cron)
+			if err != nil {
+				return errors.NewValidationError("invalid schedule", fmt.Errorf("schedule '%s': %w", sch.Name, err))
+			}
+			info := scheduleInfo{Name: sch.Name, Cron: sch.Cron, Action: describeAction(sch)}
+			lastRun, ran := state.LastRun[sch.Name]
+			if ran {
+				info.LastRun = &lastRun
+			}
+			_, info.Due = cron.Due(lastRun, now)
+			if next, ok := cron.Next(now); ok {
+				info.NextRun = &next
+			}
+			infos = append(infos, info)
+		}
+
+		if c.Bool("json") {
+			data, err := json.MarshalIndent(infos, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal schedules to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(data))
+			return nil
+		}
+
+		if len(infos) == 0 {
+			appCtx.Out().Println("No schedules configured (add Schedules to .knot/config.json)")
+			return nil
+		}
+		for _, info := range infos {
+			appCtx.Out().Printf("%s  [%s]  %s\n", info.Name, info.Cron, info.Action)
+			lastRun := "never"
+			if info.LastRun != nil {
+				lastRun = output.Timestamp(*info.LastRun)
+			}
+			nextRun := "never"
+			if info.NextRun != nil {
+				nextRun = output.Timestamp(*info.NextRun)
+			}
+			appCtx.Out().Printf("  Last run: %s | Next run: %s", lastRun, nextRun)
+			if info.Due {
+				appCtx.Out().Print(" | due")
+			}
+			appCtx.Out().Println()
+		}
+		return nil
+	}
+}
+
+func runAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		schedules := appCtx.ProjectManager.GetConfig().Schedules
+		if name := c.String("name"); name != "" {
+			schedules = findSchedule(schedules, name)
+			if len(schedules) == 0 {
+				return errors.NewValidationError("unknown schedule", fmt.Errorf("schedule '%s' not found", name))
+			}
+		}
+
+		state, statePath, err := loadState()
+		if err != nil {
+			return err
+		}
+
+		dryRun := c.Bool("dry-run")
+		actor := shared.ResolveActor(c.String("actor"))
+		now := time.Now()
+		results := make([]runResult, 0)
+		failed := 0
+		for _, sch := range schedules {
+			cron, err := scheduler.ParseCron(sch.Cron)
+			if err != nil {
+				return errors.NewValidationError("invalid schedule", fmt.Errorf("schedule '%s': %w", sch.Name, err))
+			}
+			occurrence, due := cron.Due(state.LastRun[sch.Name], now)
+			if !due {
+				continue
+			}
+
+			result := runSchedule(c.Context, appCtx, sch, occurrence, actor, dryRun)
+			results = append(results, result)
+			if len(result.Errors) > 0 {
+				failed++
+			}
+
+			// A partially applied template is recorded too, running it again would
+			// duplicate the tasks that were created
+			if !dryRun && result.Created > 0 {
+				state.LastRun[sch.Name] = occurrence
+				if err := state.Save(statePath); err != nil {
+					return err
+				}
+				appCtx.Logger.Info("Ran schedule", zap.String("schedule", sch.Name),
+					zap.Time("occurrence", occurrence), zap.Int("created", result.Created))
+			}
+		}
+
+		if c.Bool("json") {
+			data, err := json.MarshalIndent(results, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal results to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(data))
+		} else {
+			printResults(appCtx.Out(), results, dryRun)
+		}
+
+		if failed > 0 {
+			return &errors.EnhancedError{
+				Operation:   "running schedules",
+				Cause:       fmt.Errorf("%d of %d due schedules failed", failed, len(results)),
+				Suggestion:  "Check the schedules in .knot/config.json; schedules that created no tasks run again on the next invocation",
+				HelpCommand: "knot scheduler list",
+			}
+		}
+		return nil
+	}
+}
+
+// runSchedule applies the template or creates the task of a schedule for an occurrence
+func runSchedule(ctx context.Context, appCtx *shared.AppContext, sch manager.Schedule, occurrence time.Time, actor string, dryRun bool) runResult {
+	result := runResult{Schedule: sch.Name, Occurrence: occurrence}
+	values := placeholder.New(occurrence, actor)
+
+	if sch.Template != "" {
+		variables := map[string]string{"date": values["date"]}
+		for name, value := range sch.Variables {
+			variables[name] = values.Expand(value)
+		}
+		applied, err := template.ApplyByName(ctx, appCtx, sch.Template, sch.ProjectID, sch.ParentID, variables, dryRun)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			return result
+		}
+		result.Created = len(applied.CreatedTasks)
+		if !dryRun {
+			for _, task := range applied.CreatedTasks {
+				result.TaskIDs = append(result.TaskIDs, task.ID)
+			}
+		}
+		result.Errors = append(result.Errors, applied.Errors...)
+		return result
+	}
+
+	if dryRun {
+		result.Created = 1
+		return result
+	}
+	complexity := sch.Task.Complexity
+	if complexity == 0 {
+		complexity = 1
+	}
+	task, err := appCtx.ProjectManager.CreateTask(ctx, sch.ProjectID, sch.ParentID,
+		values.Expand(sch.Task.Title), values.Expand(sch.Task.Description),
+		complexity, utils.ParsePriority(sch.Task.Priority), actor)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return result
+	}
+	result.Created = 1
+	result.TaskIDs = append(result.TaskIDs, task.ID)
+	return result
+}
+
+func printResults(out output.Writer, results []runResult, dryRun bool) {
+	if len(results) == 0 {
+		out.Println("No schedules are due")
+		return
+	}
+	for _, result := range results {
+		verb := "created"
+		if dryRun {
+			verb = "would create"
+		}
+		out.Printf("%s (%s): %s %d task(s)\n", result.Schedule, output.Timestamp(result.Occurrence), verb, result.Created)
+		for _, errMsg := range result.Errors {
+			out.Printf("  Error: %s\n", errMsg)
+		}
+	}
+}
+
+func loadState() (*scheduler.State, string, error) {
+	path, err := scheduler.DefaultStatePath()
+	if err != nil {
+		return nil, "", err
+	}
+	state, err := scheduler.LoadState(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return state, path, nil
+}
+
+func findSchedule(schedules []manager.Schedule, name string) []manager.Schedule {
+	for _, sch := range schedules {
+		if sch.Name == name {
+			return []manager.Schedule{sch}
+		}
+	}
+	return nil
+}
+
+// describeAction summarizes what a schedule does
+func describeAction(sch manager.Schedule) string {
+	if sch.Template != "" {
+		return fmt.Sprintf("template '%s'", sch.Template)
+	}
+	return fmt.Sprintf("task '%s'", sch.Task.Title)
+}
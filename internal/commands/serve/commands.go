@@ -0,0 +1,189 @@
+package serve
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/commands/maintenance"
+	"github.com/denkhaus/knot/v2/internal/commands/stats"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/metrics"
+	"github.com/denkhaus/knot/v2/internal/repository/remote"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// DefaultAddr is the address 'knot serve' listens on unless --addr is given
+const DefaultAddr = "127.0.0.1:7420"
+
+// shutdownTimeout bounds how long running requests may finish on shutdown
+const shutdownTimeout = 10 * time.Second
+
+// NewServeCommand creates the serve command, which shares the knot database
+// with remote clients over HTTP
+func NewServeCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "Serve the knot database to remote knot clients over HTTP",
+		Description: `Runs a knot server for the configured database (KNOT_DATABASE), so a team
+can work on one central database. Clients use the same CLI with
+KNOT_REMOTE_URL set to the server URL:
+
+  knot user add --name alice --role editor   # prints alice's token
+  knot serve --addr 0.0.0.0:7420
+
+  export KNOT_REMOTE_URL=http://knot.internal:7420
+  export KNOT_REMOTE_TOKEN=<alice's token>
+  knot project list
+
+Once users exist, every request needs the token of a user, and the user's
+role on a project decides what it may do: viewers read, editors create and
+change, admins also delete. --token sets an additional admin token with
+full access. Without users and without --token the server is open to every
+client; restart it after adding the first user. The selected project is kept
+per client. Prometheus metrics are served at /metrics to the admin token and
+to users with the viewer role on all projects, or to everyone with
+--public-metrics. The server runs until interrupted; the global --timeout limits each
+request instead of the whole run. While serving, maintenance such as
+scheduled state changes runs every --maintenance-interval.
+
+'knot serve grpc' serves the same database over the gRPC API instead.`,
+		Action:      serveAction(appCtx),
+		Subcommands: []*cli.Command{newGRPCCommand(appCtx)},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "addr",
+				Usage:   "Address to listen on",
+				Value:   DefaultAddr,
+				EnvVars: []string{"KNOT_SERVE_ADDR"},
+			},
+			&cli.StringFlag{
+				Name:    "token",
+				Usage:   "Admin token with full access to all projects (KNOT_REMOTE_TOKEN on the client)",
+				EnvVars: []string{"KNOT_SERVE_TOKEN"},
+			},
+			&cli.BoolFlag{
+				Name:  "public-metrics",
+				Usage: "Serve /metrics without a token, e.g. for a Prometheus scraper without credentials",
+			},
+			&cli.DurationFlag{
+				Name:  "maintenance-interval",
+				Usage: "How often to run 'knot maintenance run' while serving (0 disables it)",
+				Value: maintenance.DefaultInterval,
+			},
+		},
+	}
+}
+
+func serveAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		auth, users, err := loadAuth(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		timeout := c.Duration("timeout")
+		mux := http.NewServeMux()
+		mux.Handle("/", remote.NewHandler(appCtx.Repository, auth, appCtx.Logger))
+		var metricsHandler http.Handler = metrics.Handler(appCtx.Metrics, func(r *http.Request) (map[types.TaskState]int64, error) {
+			return stats.CountTaskStates(r.Context(), appCtx.ProjectManager)
+		})
+		if !c.Bool("public-metrics") {
+			// The metrics count the tasks of all projects
+			metricsHandler = auth.RequireRole(metricsHandler, types.RoleViewer, "reading metrics")
+		}
+		mux.Handle("/metrics", metricsHandler)
+
+		listener, err := net.Listen("tcp", c.String("addr"))
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", c.String("addr"), err)
+		}
+		server := &http.Server{
+			Handler:           withRequestTimeout(mux, timeout),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		// Serving runs until interrupted, so --timeout bounds each request
+		// instead of the whole command
+		ctx, stop := signal.NotifyContext(context.WithoutCancel(c.Context), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.Serve(listener)
+		}()
+		if interval := c.Duration("maintenance-interval"); interval > 0 {
+			go maintenance.Loop(ctx, appCtx, interval)
+		}
+
+		appCtx.Logger.Info("Knot server started",
+			zap.String("addr", listener.Addr().String()),
+			zap.Bool("adminToken", auth.AdminToken != ""),
+			zap.Int("users", users),
+			zap.Bool("publicMetrics", c.Bool("public-metrics")),
+			zap.Duration("requestTimeout", timeout))
+		appCtx.Out().Printf("Serving knot database on http://%s (Ctrl+C to stop)\n", listener.Addr())
+		if auth.AdminToken == "" && auth.Users == nil {
+			appCtx.Out().Println("Warning: no users and no --token, every client that can reach the server has full access.")
+			appCtx.Out().Println("Add users with 'knot user add' and restart the server to require tokens.")
+		}
+
+		select {
+		case err := <-errCh:
+			return fmt.Errorf("knot server stopped: %w", err)
+		case <-ctx.Done():
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to stop knot server: %w", err)
+		}
+		appCtx.Logger.Info("Knot server stopped")
+		return nil
+	}
+}
+
+// loadAuth checks that the local storage can be served and returns the
+// authentication of the server: the --token admin token, and user tokens
+// once users exist. It also returns the number of users.
+func loadAuth(c *cli.Context, appCtx *shared.AppContext) (*remote.Auth, int, error) {
+	if remote.IsRemote(remote.DSNFromEnv()) {
+		return nil, 0, errors.NewValidationError("cannot serve a remote repository",
+			fmt.Errorf("%s points to another knot server, unset it to serve local storage", remote.URLEnvVar))
+	}
+	if appCtx.Repository == nil {
+		return nil, 0, fmt.Errorf("no repository available to serve")
+	}
+
+	auth := &remote.Auth{AdminToken: c.String("token")}
+	users, err := appCtx.ProjectManager.ListUsers(c.Context)
+	if err != nil && !stderrors.Is(err, types.ErrUsersUnsupported) {
+		return nil, 0, errors.WrapWithSuggestion(err, "loading users")
+	}
+	if len(users) > 0 {
+		auth.Users = appCtx.ProjectManager.AuthenticateUser
+	}
+	return auth, len(users), nil
+}
+
+// withRequestTimeout bounds the context of every request by timeout, if set
+func withRequestTimeout(next http.Handler, timeout time.Duration) http.Handler {
+	if timeout <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
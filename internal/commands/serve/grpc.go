@@ -0,0 +1,131 @@
+package serve
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/denkhaus/knot/v2/internal/commands/maintenance"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/grpcserver"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// DefaultGRPCAddr is the address 'knot serve grpc' listens on unless --addr
+// is given
+const DefaultGRPCAddr = "127.0.0.1:7421"
+
+// newGRPCCommand creates the 'serve grpc' command, which serves the knot gRPC
+// API for typed clients in other languages
+func newGRPCCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "grpc",
+		Usage: "Serve the knot gRPC API for IDE plugins, bots and other typed clients",
+		Description: `Runs a gRPC server for the configured database (KNOT_DATABASE) with the
+KnotService defined in api/proto/knot/v1/knot.proto: projects, tasks,
+dependencies, next task selection and a stream of change events. Generate
+clients for other languages from the proto file.
+
+  knot serve grpc --addr 0.0.0.0:7421
+
+Authentication works as for 'knot serve': once users exist, every call needs
+a user token as "authorization: Bearer <token>" metadata, and the user's role
+on a project decides what it may do. --token sets an additional admin token.
+Changes made with a user token are recorded under the user name. The global
+--timeout limits each call and each poll of a WatchEvents stream.`,
+		Action: grpcAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "addr",
+				Usage:   "Address to listen on",
+				Value:   DefaultGRPCAddr,
+				EnvVars: []string{"KNOT_GRPC_ADDR"},
+			},
+			&cli.StringFlag{
+				Name:    "token",
+				Usage:   "Admin token with full access to all projects",
+				EnvVars: []string{"KNOT_SERVE_TOKEN"},
+			},
+			&cli.DurationFlag{
+				Name:  "poll-interval",
+				Usage: "How often WatchEvents streams check for new events",
+				Value: grpcserver.DefaultPollInterval,
+			},
+			&cli.DurationFlag{
+				Name:  "maintenance-interval",
+				Usage: "How often to run 'knot maintenance run' while serving (0 disables it)",
+				Value: maintenance.DefaultInterval,
+			},
+		},
+	}
+}
+
+func grpcAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		auth, users, err := loadAuth(c, appCtx)
+		if err != nil {
+			return err
+		}
+		if interval := c.Duration("poll-interval"); interval <= 0 {
+			return errors.NewValidationError("invalid poll interval",
+				fmt.Errorf("--poll-interval must be positive, got %s", interval))
+		}
+
+		timeout := c.Duration("timeout")
+		server := grpcserver.New(appCtx.ProjectManager, grpcserver.Options{
+			Auth:         auth,
+			Actor:        appCtx.GetActor(),
+			Timeout:      timeout,
+			PollInterval: c.Duration("poll-interval"),
+			Logger:       appCtx.Logger,
+		})
+
+		listener, err := net.Listen("tcp", c.String("addr"))
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", c.String("addr"), err)
+		}
+
+		// Serving runs until interrupted, so --timeout bounds each call
+		// instead of the whole command
+		ctx, stop := signal.NotifyContext(context.WithoutCancel(c.Context), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.Serve(listener)
+		}()
+		if interval := c.Duration("maintenance-interval"); interval > 0 {
+			go maintenance.Loop(ctx, appCtx, interval)
+		}
+
+		appCtx.Logger.Info("Knot gRPC server started",
+			zap.String("addr", listener.Addr().String()),
+			zap.Bool("adminToken", auth.AdminToken != ""),
+			zap.Int("users", users),
+			zap.Duration("callTimeout", timeout))
+		appCtx.Out().Printf("Serving the knot gRPC API on %s (Ctrl+C to stop)\n", listener.Addr())
+		if auth.AdminToken == "" && auth.Users == nil {
+			appCtx.Out().Println("Warning: no users and no --token, every client that can reach the server has full access.")
+			appCtx.Out().Println("Add users with 'knot user add' and restart the server to require tokens.")
+		}
+
+		select {
+		case err := <-errCh:
+			return fmt.Errorf("knot gRPC server stopped: %w", err)
+		case <-ctx.Done():
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to stop knot gRPC server: %w", err)
+		}
+		appCtx.Logger.Info("Knot gRPC server stopped")
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+)
+
+// Commands returns the session subcommands
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "start",
+			Usage: "Start a session with its own selected project",
+			Description: `Prints a new session token as shell command setting KNOT_SESSION, so
+starting a session in a shell or agent sandbox is:
+
+  eval "$(knot session start)"
+
+Projects selected with 'knot project select --session' apply only to
+processes with the same KNOT_SESSION, so several shells can work in different
+projects of the same database at once. Without a session selection, commands
+use the project selected with 'knot project select'. KNOT_PROJECT_ID takes
+precedence over both.`,
+			Action: startAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "project-id",
+					Usage: "Select this project in the new session",
+				},
+			},
+		},
+		{
+			Name:  "end",
+			Usage: "End the current session and forget its selected project",
+			Description: `Removes the project selection of the session in KNOT_SESSION and prints
+the shell command unsetting it:
+
+  eval "$(knot session end)"`,
+			Action: endAction(appCtx),
+		},
+	}
+}
+
+func startAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		session := types.NewID().String()
+
+		if value := c.String("project-id"); value != "" {
+			projectID, err := uuid.Parse(value)
+			if err != nil {
+				return errors.InvalidUUIDError("project-id", value)
+			}
+			if _, err := appCtx.ProjectManager.GetProject(c.Context, projectID); err != nil {
+				return fmt.Errorf("project not found: %w", err)
+			}
+			if err := appCtx.ProjectManager.SetSessionProject(c.Context, session, projectID, appCtx.GetActor()); err != nil {
+				return fmt.Errorf("failed to select project: %w", err)
+			}
+		}
+
+		appCtx.Out().Printf("export %s=%s\n", shared.SessionEnvVar, session)
+		return nil
+	}
+}
+
+func endAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		session := shared.Session()
+		if session == "" {
+			return &errors.EnhancedError{
+				Operation:   "ending session",
+				Cause:       fmt.Errorf("%s is not set, this shell has no session", shared.SessionEnvVar),
+				Suggestion:  "Sessions are started with 'knot session start'",
+				HelpCommand: "knot session start --help",
+			}
+		}
+
+		if err := appCtx.ProjectManager.ClearSessionProject(c.Context, session); err != nil {
+			return fmt.Errorf("failed to clear session project: %w", err)
+		}
+		appCtx.Out().Printf("unset %s\n", shared.SessionEnvVar)
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package simulate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/simulation"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// Commands returns the simulation subcommands
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "complete",
+			Usage: "Show what completing a task would change, without saving anything",
+			Description: `Completes the task in an in-memory copy of the selected project and reports
+which tasks would become actionable, which parent tasks would be completed
+automatically, and how the project progress and the critical path (the longest
+chain of open tasks linked by dependencies) would change. The same rules as for
+a real completion apply, so acceptance criteria and required reviews are
+checked. Run it for several candidate tasks to decide which one to work on.`,
+			Action: completeAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewTaskIDFlag(),
+				shared.NewJSONFlag(),
+			},
+		},
+	}
+}
+
+func completeAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		taskIDStr := c.String("id")
+		taskID, err := uuid.Parse(taskIDStr)
+		if err != nil {
+			return errors.InvalidUUIDError("task-id", taskIDStr)
+		}
+
+		result, err := simulation.SimulateCompletion(c.Context, appCtx.ProjectManager, projectID, taskID, shared.GetActorFromContext(c))
+		if err != nil {
+			appCtx.Logger.Error("Failed to simulate task completion", zap.String("taskID", taskID.String()), zap.Error(err))
+			return errors.NewValidationError("simulation failed", err)
+		}
+		appCtx.Logger.Info("Simulated task completion",
+			zap.String("taskID", taskID.String()),
+			zap.Int("newlyActionable", len(result.NewlyActionable)))
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal simulation result to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		w := appCtx.Out()
+		fmt.Fprintf(w, "Simulated completion of '%s' (ID: %s, %s) - nothing was saved\n\n", result.Task.Title, result.Task.TaskID, result.Task.State)
+		fmt.Fprintf(w, "Progress:   %d/%d (%.1f%%) -> %d/%d (%.1f%%)\n",
+			result.Before.Completed, result.Before.Total, result.Before.Progress,
+			result.After.Completed, result.After.Total, result.After.Progress)
+		fmt.Fprintf(w, "Actionable: %d -> %d tasks\n", len(result.Before.Actionable), len(result.After.Actionable))
+
+		writeRefs(w, "Would become actionable", result.NewlyActionable)
+		writeRefs(w, "Would be completed automatically", result.AlsoCompleted)
+
+		fmt.Fprintf(w, "\nCritical path: %d -> %d tasks", len(result.Before.CriticalPath), len(result.After.CriticalPath))
+		if result.OnCriticalPath {
+			fmt.Fprint(w, " (task is on the critical path)")
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "  Before: %s\n", formatPath(result.Before.CriticalPath))
+		fmt.Fprintf(w, "  After:  %s\n", formatPath(result.After.CriticalPath))
+		return nil
+	}
+}
+
+func writeRefs(w io.Writer, title string, refs []analysis.TaskRef) {
+	if len(refs) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s (%d):\n", title, len(refs))
+	for _, ref := range refs {
+		fmt.Fprintf(w, "  %s (ID: %s)\n", ref.Title, ref.TaskID)
+	}
+}
+
+func formatPath(path []analysis.TaskRef) string {
+	if len(path) == 0 {
+		return "(no open tasks)"
+	}
+	titles := make([]string, len(path))
+	for i, ref := range path {
+		titles[i] = ref.Title
+	}
+	return strings.Join(titles, " -> ")
+}
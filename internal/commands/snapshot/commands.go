@@ -0,0 +1,258 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/snapshot"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// currentLabel names the live project state in diffs
+const currentLabel = "current"
+
+// NewSnapshotCommand creates the snapshot command with its subcommands
+func NewSnapshotCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "snapshot",
+		Usage: "Capture and compare the state of a project",
+		Description: `Snapshots store the full state of the selected project (all tasks with their
+state, complexity and dependencies) in .knot/snapshots/<label>.json. Comparing
+two snapshots, or a snapshot with the current state, shows which tasks were
+added, removed or changed, e.g. to audit what an agent session changed:
+
+  knot snapshot create --label before-session
+  ... agent works ...
+  knot snapshot diff before-session`,
+		Subcommands: []*cli.Command{
+			{
+				Name:   "create",
+				Usage:  "Capture the current state of the selected project",
+				Action: createAction(appCtx),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "label",
+						Aliases: []string{"l"},
+						Usage:   "Snapshot label (default: current UTC time, e.g. 20260101-120000)",
+					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: "Replace an existing snapshot with the same label",
+					},
+				},
+			},
+			{
+				Name:   "list",
+				Usage:  "List stored snapshots",
+				Action: listAction(appCtx),
+				Flags: []cli.Flag{
+					shared.NewJSONFlag(),
+				},
+			},
+			{
+				Name:      "diff",
+				Usage:     "Show tasks added, removed and changed between two snapshots",
+				ArgsUsage: "<from> [<to>]",
+				Description: `Compares snapshot <from> with snapshot <to>, or with the current state of the
+project when <to> is omitted or "current".`,
+				Action: diffAction(appCtx),
+				Flags: []cli.Flag{
+					shared.NewJSONFlag(),
+				},
+			},
+			{
+				Name:   "delete",
+				Usage:  "Delete a stored snapshot",
+				Action: deleteAction(appCtx),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "label",
+						Aliases:  []string{"l"},
+						Usage:    "Snapshot label",
+						Required: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func createAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		label := c.String("label")
+		if label == "" {
+			label = snapshot.DefaultLabel(appCtx.ProjectManager.GetCurrentTime())
+		}
+		if label == currentLabel {
+			return errors.NewValidationError("invalid snapshot label",
+				fmt.Errorf("snapshot label '%s' is reserved for the current state", currentLabel))
+		}
+		if err := snapshot.ValidateLabel(label); err != nil {
+			return errors.NewValidationError("invalid snapshot label", err)
+		}
+
+		store, err := snapshot.NewStore()
+		if err != nil {
+			return err
+		}
+		if store.Exists(label) && !c.Bool("force") {
+			return errors.NewValidationError("snapshot exists",
+				fmt.Errorf("snapshot '%s' already exists, use --force to replace it", label))
+		}
+
+		actor := shared.GetActorFromContext(c)
+		appCtx.Logger.Info("Creating snapshot",
+			zap.String("projectID", projectID.String()),
+			zap.String("label", label),
+			zap.String("actor", actor))
+
+		snap, err := snapshot.Capture(c.Context, appCtx.ProjectManager, projectID, label, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to capture snapshot", zap.Error(err))
+			return err
+		}
+		if err := store.Save(snap); err != nil {
+			appCtx.Logger.Error("Failed to save snapshot", zap.Error(err))
+			return err
+		}
+
+		appCtx.Out().Printf("Created snapshot '%s' of project \"%s\" (%d tasks)\n", label, snap.Project.Title, len(snap.Tasks))
+		appCtx.Out().Printf("  Compare later with: knot snapshot diff %s\n", label)
+		return nil
+	}
+}
+
+func listAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		store, err := snapshot.NewStore()
+		if err != nil {
+			return err
+		}
+		infos, err := store.List()
+		if err != nil {
+			return err
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(infos, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal snapshots to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		if len(infos) == 0 {
+			appCtx.Out().Println("No snapshots found. Create one with: knot snapshot create --label <label>")
+			return nil
+		}
+
+		appCtx.Out().Printf("Snapshots (%d):\n", len(infos))
+		for _, info := range infos {
+			appCtx.Out().Printf("  %s  %s  %s (%d tasks)", info.Label, output.Timestamp(info.CreatedAt), info.ProjectTitle, info.Tasks)
+			if info.CreatedBy != "" {
+				appCtx.Out().Printf(" by %s", info.CreatedBy)
+			}
+			appCtx.Out().Println()
+		}
+		return nil
+	}
+}
+
+func diffAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.NArg() < 1 || c.NArg() > 2 {
+			return errors.NewValidationError("invalid arguments",
+				fmt.Errorf("expected a snapshot label to compare from and an optional label to compare to, got %d arguments", c.NArg()))
+		}
+
+		store, err := snapshot.NewStore()
+		if err != nil {
+			return err
+		}
+		from, err := store.Load(c.Args().Get(0))
+		if err != nil {
+			return err
+		}
+
+		toLabel := c.Args().Get(1)
+		var to *snapshot.Snapshot
+		if toLabel == "" || toLabel == currentLabel {
+			to, err = snapshot.Capture(c.Context, appCtx.ProjectManager, from.Project.ID, currentLabel, "")
+		} else {
+			to, err = store.Load(toLabel)
+		}
+		if err != nil {
+			return err
+		}
+
+		diff, err := snapshot.Compare(from, to)
+		if err != nil {
+			return err
+		}
+		appCtx.Logger.Info("Compared snapshots",
+			zap.String("from", diff.From),
+			zap.String("to", diff.To),
+			zap.Int("changes", len(diff.Changes)))
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(diff, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal snapshot diff to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		appCtx.Out().Printf("Changes from '%s' to '%s': %d added, %d removed, %d changed\n",
+			diff.From, diff.To, diff.Added, diff.Removed, diff.Changed)
+		if diff.IsEmpty() {
+			appCtx.Out().Println("\nNo differences.")
+			return nil
+		}
+
+		appCtx.Out().Println()
+		for _, change := range diff.Changes {
+			appCtx.Out().Printf("  %s %-8s %s (ID: %s)\n", changeSymbol(change.Kind), change.Kind, change.Title, change.TaskID)
+			for _, detail := range change.Details {
+				appCtx.Out().Printf("      %s\n", detail)
+			}
+		}
+		return nil
+	}
+}
+
+func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		store, err := snapshot.NewStore()
+		if err != nil {
+			return err
+		}
+		label := c.String("label")
+		if err := store.Delete(label); err != nil {
+			return err
+		}
+		appCtx.Out().Printf("Deleted snapshot '%s'\n", label)
+		return nil
+	}
+}
+
+func changeSymbol(kind snapshot.ChangeKind) string {
+	switch kind {
+	case snapshot.ChangeAdded:
+		return "+"
+	case snapshot.ChangeRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
@@ -0,0 +1,158 @@
+package standup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// NewStandupCommand creates the standup command, which summarizes the work of
+// an agent for pasting into a chat
+func NewStandupCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "standup",
+		Usage: "Summarize what an agent completed, is working on and is blocked by",
+		Description: `Prints a daily standup for an agent of the selected project, formatted for
+pasting into a chat: the tasks completed since --since, the tasks in progress
+and the blockers, i.e. open tasks that are blocked or wait for incomplete
+dependencies.
+
+An agent is an actor name (--actor, $KNOT_ACTOR) or the ID of an agent tasks
+are assigned to. Tasks belong to their assigned agent and to the actor who
+moved them into their current state, taken from the change feed. Without
+--agent the standup is for the current actor; --all-agents prints one standup
+per agent for team leads.`,
+		Action: standupAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "agent",
+				Aliases: []string{"a"},
+				Usage:   "Actor name or agent ID (default: the current actor)",
+			},
+			&cli.BoolFlag{
+				Name:  "all-agents",
+				Usage: "Print a standup for every agent with something to report",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Start of the period: today, yesterday, YYYY-MM-DD or a duration such as 36h or 2d",
+				Value: "yesterday",
+			},
+			shared.NewJSONFlag(),
+		},
+	}
+}
+
+func standupAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.IsSet("agent") && c.Bool("all-agents") {
+			return errors.NewValidationError("conflicting flags", fmt.Errorf("--agent and --all-agents cannot be combined"))
+		}
+		since, err := parseSince(c.String("since"), time.Now())
+		if err != nil {
+			return err
+		}
+		agent := ""
+		if !c.Bool("all-agents") {
+			agent = strings.TrimSpace(c.String("agent"))
+			if agent == "" {
+				agent = shared.ResolveActor(appCtx.GetActor())
+			}
+		}
+
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+		tasks, err := appCtx.ProjectManager.ListTasksWithDependencies(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "listing tasks")
+		}
+		// The change feed is optional; without it tasks belong to the actor who last updated them
+		events, err := appCtx.ProjectManager.ListChangeEvents(c.Context, types.ChangeEventFilter{ProjectID: &projectID})
+		if err != nil {
+			appCtx.Logger.Warn("Change feed unavailable, using the last updater of each task", zap.Error(err))
+			events = nil
+		}
+
+		standups := analysis.BuildStandups(tasks, events, agent, since)
+		appCtx.Logger.Info("Built standup",
+			zap.String("projectID", projectID.String()),
+			zap.String("agent", agent),
+			zap.Int("agents", len(standups)))
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(standups, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal standup to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		if len(standups) == 0 {
+			appCtx.Out().Printf("Nothing to report since %s\n", formatSince(since))
+			return nil
+		}
+		for i, standup := range standups {
+			if i > 0 {
+				appCtx.Out().Println()
+			}
+			writeStandup(appCtx.Out(), standup)
+		}
+		return nil
+	}
+}
+
+func parseSince(value string, now time.Time) (time.Time, error) {
+	since, err := utils.ParseSince(value, now)
+	if err != nil {
+		return time.Time{}, errors.NewValidationError("invalid --since value", err)
+	}
+	return since, nil
+}
+
+// writeStandup prints a standup as plain text that reads well in Slack and Teams
+func writeStandup(w io.Writer, standup *analysis.Standup) {
+	fmt.Fprintf(w, "Standup for %s since %s\n", standup.Agent, formatSince(standup.Since))
+	writeSection(w, "Done", standup.Completed)
+	writeSection(w, "In progress", standup.InProgress)
+	writeSection(w, "Blockers", standup.Blockers)
+}
+
+func writeSection(w io.Writer, title string, items []analysis.StandupItem) {
+	fmt.Fprintf(w, "%s:\n", title)
+	if len(items) == 0 {
+		fmt.Fprintln(w, "- nothing")
+		return
+	}
+	for _, item := range items {
+		switch {
+		case len(item.BlockedBy) > 0:
+			fmt.Fprintf(w, "- %s (waiting on %s)\n", item.Title, strings.Join(item.BlockedBy, ", "))
+		case item.State == types.TaskStateBlocked:
+			fmt.Fprintf(w, "- %s (blocked)\n", item.Title)
+		default:
+			fmt.Fprintf(w, "- %s\n", item.Title)
+		}
+	}
+}
+
+// formatSince prints midnight as a plain date
+func formatSince(since time.Time) string {
+	if since.Hour() == 0 && since.Minute() == 0 && since.Second() == 0 {
+		return since.Format("Mon 2006-01-02")
+	}
+	return since.Format("Mon 2006-01-02 15:04")
+}
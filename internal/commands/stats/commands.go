@@ -0,0 +1,141 @@
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/metrics"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// Commands returns the stats subcommands
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "runtime",
+			Usage: "Collect runtime metrics once: process, repository latency and task counts",
+			Description: `Collects the metrics of this knot process in one shot: Go runtime
+statistics, the commands run and the latency of the repository operations they
+issued, and the number of tasks per state across all projects.
+
+Use --prometheus to print the Prometheus text exposition format, e.g. for the
+node_exporter textfile collector:
+  knot stats runtime --prometheus > /var/lib/node_exporter/knot.prom`,
+			Action: runtimeAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewJSONFlag(),
+				&cli.BoolFlag{
+					Name:  "prometheus",
+					Usage: "Output in the Prometheus text exposition format",
+				},
+			},
+		},
+		newDBCommand(appCtx),
+	}
+}
+
+func runtimeAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if c.Bool("json") && c.Bool("prometheus") {
+			return errors.NewValidationError("conflicting output formats",
+				fmt.Errorf("--json and --prometheus cannot be combined"))
+		}
+
+		states, err := CountTaskStates(c.Context, appCtx.ProjectManager)
+		if err != nil {
+			appCtx.Logger.Error("Failed to count tasks", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "counting tasks per state")
+		}
+
+		snapshot := appCtx.Metrics.Snapshot(states)
+		appCtx.Logger.Info("Collected runtime metrics",
+			zap.Int("commands", len(snapshot.Commands)),
+			zap.Int("queries", len(snapshot.Queries)))
+
+		switch {
+		case c.Bool("prometheus"):
+			return metrics.WritePrometheus(appCtx.Out(), snapshot)
+		case c.Bool("json"):
+			jsonData, err := json.MarshalIndent(snapshot, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal runtime metrics to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		default:
+			writeSnapshot(appCtx.Out(), snapshot)
+			return nil
+		}
+	}
+}
+
+// CountTaskStates counts the tasks of all projects per state
+func CountTaskStates(ctx context.Context, pm manager.ProjectManager) (map[types.TaskState]int64, error) {
+	projects, err := pm.ListProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	states := make(map[types.TaskState]int64)
+	for _, project := range projects {
+		tasks, err := pm.ListTasksForProject(ctx, project.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tasks of project %s: %w", project.ID, err)
+		}
+		for _, task := range tasks {
+			states[task.State]++
+		}
+	}
+	return states, nil
+}
+
+func writeSnapshot(w io.Writer, snapshot *metrics.Snapshot) {
+	process := snapshot.Process
+	fmt.Fprintf(w, "Uptime: %s | %s | Goroutines: %d | Heap: %.1f MiB | GC cycles: %d\n",
+		snapshot.Uptime.Round(time.Millisecond), process.GoVersion, process.Goroutines,
+		float64(process.HeapAlloc)/(1<<20), process.GCCycles)
+
+	fmt.Fprintln(w, "\nTasks per state:")
+	if len(snapshot.TaskStates) == 0 {
+		fmt.Fprintln(w, "  (no tasks)")
+	}
+	states := make([]string, 0, len(snapshot.TaskStates))
+	for state := range snapshot.TaskStates {
+		states = append(states, string(state))
+	}
+	sort.Strings(states)
+	for _, state := range states {
+		fmt.Fprintf(w, "  %-18s %d\n", state, snapshot.TaskStates[types.TaskState(state)])
+	}
+
+	writeSummaries(w, "Commands", snapshot.Commands)
+	writeSummaries(w, "Repository operations", snapshot.Queries)
+}
+
+func writeSummaries(w io.Writer, title string, summaries map[string]metrics.Summary) {
+	fmt.Fprintf(w, "\n%s:\n", title)
+	if len(summaries) == 0 {
+		fmt.Fprintln(w, "  (none recorded)")
+		return
+	}
+
+	names := make([]string, 0, len(summaries))
+	for name := range summaries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		summary := summaries[name]
+		fmt.Fprintf(w, "  %-28s count: %d | errors: %d | avg: %.2fms | max: %s\n",
+			name, summary.Count, summary.Errors, summary.AvgMilli, summary.Max.Round(time.Microsecond))
+	}
+}
@@ -0,0 +1,239 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/metrics"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// probe is one of the reads behind the common commands that 'knot stats db'
+// measures
+type probe struct {
+	name string
+	run  func(ctx context.Context, pm manager.ProjectManager, projectID uuid.UUID) error
+}
+
+var probes = []probe{
+	{"list tasks", func(ctx context.Context, pm manager.ProjectManager, projectID uuid.UUID) error {
+		_, err := pm.ListTasksForProject(ctx, projectID)
+		return err
+	}},
+	{"list tasks with dependencies", func(ctx context.Context, pm manager.ProjectManager, projectID uuid.UUID) error {
+		_, err := pm.ListTasksWithDependencies(ctx, projectID)
+		return err
+	}},
+	{"project progress", func(ctx context.Context, pm manager.ProjectManager, projectID uuid.UUID) error {
+		_, err := pm.GetProjectProgress(ctx, projectID)
+		return err
+	}},
+	{"subtree progress", func(ctx context.Context, pm manager.ProjectManager, projectID uuid.UUID) error {
+		_, err := pm.GetSubtreeProgress(ctx, projectID)
+		return err
+	}},
+	{"next actionable task", func(ctx context.Context, pm manager.ProjectManager, projectID uuid.UUID) error {
+		_, err := pm.FindNextActionableTask(ctx, projectID)
+		return err
+	}},
+	{"tasks needing breakdown", func(ctx context.Context, pm manager.ProjectManager, projectID uuid.UUID) error {
+		_, err := pm.FindTasksNeedingBreakdown(ctx, projectID)
+		return err
+	}},
+}
+
+// ProbeResult is the cost of one probe
+type ProbeResult struct {
+	Name       string        `json:"name"`
+	Duration   time.Duration `json:"duration_ns"`
+	Operations int64         `json:"repository_operations"`
+	Statements int64         `json:"sql_statements"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// DBReport is the result of 'knot stats db'
+type DBReport struct {
+	ProjectID uuid.UUID     `json:"project_id"`
+	Tasks     int           `json:"tasks"`
+	Probes    []ProbeResult `json:"probes"`
+	// Statements are the SQL statements the probes ran
+	Statements    map[string]metrics.Summary `json:"sql_statements"`
+	SlowThreshold time.Duration              `json:"slow_query_threshold_ns"`
+	SlowQueries   []metrics.SlowQuery        `json:"slow_queries"`
+}
+
+func newDBCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "db",
+		Usage: "Measure the repository queries behind the common commands",
+		Description: `Runs the reads behind the common commands against the selected project:
+listing its tasks with and without dependencies, its progress and subtree
+rollups, and finding the next actionable task and the tasks needing breakdown.
+Reports how long each took and how many repository operations and SQL
+statements it issued, the statements by total time, and the queries slower than
+the slow query threshold.
+
+A statement count growing with the number of tasks points at queries issued per
+task. Set the threshold in milliseconds, 0 to turn slow query logging off:
+  knot config set --key slow-query-threshold --value 50
+
+Slow queries of every command are logged as warnings, e.g. with --log-level warn.`,
+		Action: dbAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "top",
+				Usage: "Number of statements listed by total time",
+				Value: 10,
+			},
+			shared.NewJSONFlag(),
+		},
+	}
+}
+
+func dbAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		report, err := ProbeDB(c.Context, appCtx.ProjectManager, appCtx.Metrics, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to probe repository", zap.Error(err))
+			return err
+		}
+		appCtx.Logger.Info("Probed repository",
+			zap.String("projectID", projectID.String()),
+			zap.Int("tasks", report.Tasks),
+			zap.Int("slowQueries", len(report.SlowQueries)))
+
+		if c.Bool("json") {
+			return appCtx.Out().JSON(report)
+		}
+		writeDBReport(appCtx.Out(), report, c.Int("top"))
+		appCtx.Out().Advise("\nA statement count growing with the number of tasks points at queries issued per task.\n")
+		return nil
+	}
+}
+
+// ProbeDB runs the probes against a project and reports their cost as
+// recorded in registry, which must observe the repository of pm
+func ProbeDB(ctx context.Context, pm manager.ProjectManager, registry *metrics.Registry, projectID uuid.UUID) (*DBReport, error) {
+	tasks, err := pm.ListTasksForProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	report := &DBReport{ProjectID: projectID, Tasks: len(tasks)}
+	before, probedAt := registry.Snapshot(nil), time.Now()
+	for _, p := range probes {
+		operations, statements := registry.QueryCounts()
+		start := time.Now()
+		err := p.run(ctx, pm, projectID)
+		result := ProbeResult{Name: p.name, Duration: time.Since(start)}
+		afterOperations, afterStatements := registry.QueryCounts()
+		result.Operations = afterOperations - operations
+		result.Statements = afterStatements - statements
+		if err != nil {
+			result.Error = err.Error()
+		}
+		report.Probes = append(report.Probes, result)
+	}
+
+	after := registry.Snapshot(nil)
+	report.Statements = subtractSummaries(after.Statements, before.Statements)
+	report.SlowThreshold = after.SlowThreshold
+	for _, slow := range after.SlowQueries {
+		if !slow.At.Before(probedAt) {
+			report.SlowQueries = append(report.SlowQueries, slow)
+		}
+	}
+	return report, nil
+}
+
+// subtractSummaries returns the observations in after that are not in before,
+// keeping the maximum of after
+func subtractSummaries(after, before map[string]metrics.Summary) map[string]metrics.Summary {
+	result := make(map[string]metrics.Summary)
+	for name, summary := range after {
+		previous := before[name]
+		summary.Count -= previous.Count
+		summary.Errors -= previous.Errors
+		summary.Slow -= previous.Slow
+		summary.Total -= previous.Total
+		if summary.Count == 0 {
+			continue
+		}
+		summary.AvgMilli = float64(summary.Total) / float64(summary.Count) / float64(time.Millisecond)
+		result[name] = summary
+	}
+	return result
+}
+
+func writeDBReport(w io.Writer, report *DBReport, top int) {
+	fmt.Fprintf(w, "Repository probe of project %s (%d tasks):\n\n", report.ProjectID, report.Tasks)
+	fmt.Fprintf(w, "  %-30s %10s  %10s  %10s\n", "Read", "Time", "Operations", "Statements")
+	for _, result := range report.Probes {
+		fmt.Fprintf(w, "  %-30s %10s  %10d  %10d", result.Name,
+			result.Duration.Round(time.Microsecond), result.Operations, result.Statements)
+		if result.Error != "" {
+			fmt.Fprintf(w, "  (failed: %s)", result.Error)
+		}
+		fmt.Fprintln(w)
+	}
+
+	names := make([]string, 0, len(report.Statements))
+	for name := range report.Statements {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := report.Statements[names[i]], report.Statements[names[j]]
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return names[i] < names[j]
+	})
+	if top > 0 && len(names) > top {
+		names = names[:top]
+	}
+
+	fmt.Fprintln(w, "\nSQL statements by total time:")
+	if len(names) == 0 {
+		fmt.Fprintln(w, "  (none recorded, the repository does not run SQL)")
+	}
+	for _, name := range names {
+		summary := report.Statements[name]
+		fmt.Fprintf(w, "  %5dx %10s  avg %.2fms  %s\n", summary.Count,
+			summary.Total.Round(time.Microsecond), summary.AvgMilli, shorten(name, 100))
+	}
+
+	if report.SlowThreshold <= 0 {
+		fmt.Fprintln(w, "\nSlow queries: not logged (knot config set --key slow-query-threshold --value <ms>)")
+		return
+	}
+	fmt.Fprintf(w, "\nSlow queries (%s or longer):\n", report.SlowThreshold)
+	if len(report.SlowQueries) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	}
+	for _, slow := range report.SlowQueries {
+		fmt.Fprintf(w, "  %10s  %-9s  %s\n", slow.Duration.Round(time.Microsecond), slow.Kind, shorten(slow.Name, 100))
+	}
+}
+
+// shorten cuts s to at most max runes, marking the cut with "..."
+func shorten(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-3]) + "..."
+}
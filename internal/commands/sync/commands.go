@@ -0,0 +1,286 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/dbsync"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/repository"
+	"github.com/denkhaus/knot/v2/internal/repository/remote"
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// Commands returns the sync subcommands
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "file",
+			Usage: "Merge this knot database with another one in both directions",
+			Description: `Merges the projects, tasks and dependencies of the current knot database with
+another knot SQLite database, e.g. a copy on a laptop and one on a desktop:
+
+  knot sync file --with /mnt/desktop/project/.knot
+
+Since the previous sync of the two databases, changes made on one side are
+copied to the other and deletions are propagated. Entities changed on both
+sides keep the version with the newer update time. Conflicts that need a
+manual choice - a task deleted on one side and modified on the other, or
+changed on both sides at the same time - are reported and left untouched;
+run again with --prefer local or --prefer remote to resolve them.
+
+The time of the last sync is stored in .knot/sync.json next to both databases
+and only advances once no conflicts or failures remain.`,
+			Action: fileAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "with",
+					Usage:    "Other database: a .knot directory or a database file",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "prefer",
+					Usage: "Resolve all conflicts in favor of this side: local or remote",
+				},
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "Show what would change without writing to either database",
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+	}
+}
+
+func fileAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		prefer, err := dbsync.ParseSide(c.String("prefer"))
+		if err != nil {
+			return errors.NewValidationError("invalid --prefer value", err)
+		}
+
+		localPath, err := localDatabasePath()
+		if err != nil {
+			return err
+		}
+		remotePath, err := remoteDatabasePath(c.String("with"))
+		if err != nil {
+			return err
+		}
+		if localPath == remotePath {
+			return errors.NewValidationError("cannot sync a database with itself",
+				fmt.Errorf("--with points to the current database %s", localPath))
+		}
+
+		localStatePath := filepath.Join(filepath.Dir(localPath), dbsync.StateFile)
+		remoteStatePath := filepath.Join(filepath.Dir(remotePath), dbsync.StateFile)
+		localState, err := dbsync.LoadState(localStatePath)
+		if err != nil {
+			return err
+		}
+		remoteState, err := dbsync.LoadState(remoteStatePath)
+		if err != nil {
+			return err
+		}
+
+		local, err := openDatabase(appCtx, localPath)
+		if err != nil {
+			return err
+		}
+		defer closeDatabase(appCtx, local)
+		remote, err := openDatabase(appCtx, remotePath)
+		if err != nil {
+			return err
+		}
+		defer closeDatabase(appCtx, remote)
+
+		opts := dbsync.Options{
+			LastSync: lastSync(localState.Peers[remotePath], remoteState.Peers[localPath]),
+			Prefer:   prefer,
+			DryRun:   c.Bool("dry-run"),
+		}
+		appCtx.Logger.Info("Syncing databases",
+			zap.String("local", localPath),
+			zap.String("remote", remotePath),
+			zap.Time("lastSync", opts.LastSync),
+			zap.Bool("dryRun", opts.DryRun))
+
+		report, err := dbsync.Sync(c.Context, local, remote, opts)
+		if err != nil {
+			appCtx.Logger.Error("Failed to sync databases", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "syncing databases")
+		}
+
+		if !opts.DryRun && report.Complete() {
+			now := time.Now()
+			localState.Peers[remotePath] = now
+			remoteState.Peers[localPath] = now
+			if err := localState.Save(localStatePath); err != nil {
+				return err
+			}
+			if err := remoteState.Save(remoteStatePath); err != nil {
+				return err
+			}
+		}
+
+		appCtx.Logger.Info("Databases synced",
+			zap.Int("changes", len(report.Changes)),
+			zap.Int("conflicts", len(report.Conflicts)),
+			zap.Int("failures", len(report.Failures)))
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal sync report to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+		} else {
+			writeReport(appCtx.Out(), report, opts.LastSync)
+		}
+
+		if len(report.Failures) > 0 {
+			return fmt.Errorf("%d change(s) could not be applied, run the sync again after fixing the cause", len(report.Failures))
+		}
+		if unresolved := len(report.Unresolved()); unresolved > 0 {
+			return fmt.Errorf("%d unresolved sync conflict(s), run the sync again with --prefer local or --prefer remote", unresolved)
+		}
+		return nil
+	}
+}
+
+// lastSync returns the earlier of the sync times recorded by both databases,
+// so no change is missed if one state file is outdated
+func lastSync(local, remote time.Time) time.Time {
+	switch {
+	case local.IsZero():
+		return remote
+	case remote.IsZero() || local.Before(remote):
+		return local
+	default:
+		return remote
+	}
+}
+
+// localDatabasePath returns the SQLite database the CLI is working on
+func localDatabasePath() (string, error) {
+	dsn := remote.DSNFromEnv()
+	if remote.IsRemote(dsn) {
+		variable := repository.DSNEnvVar
+		if os.Getenv(remote.URLEnvVar) != "" {
+			variable = remote.URLEnvVar
+		}
+		return "", errors.NewValidationError("sync requires a local SQLite database",
+			fmt.Errorf("%s selects the knot server at %s, whose clients share its database without syncing; unset %s to sync the local database", variable, dsn, variable))
+	}
+
+	driver, location := repository.ParseDSN(dsn)
+	if driver != sqlite.DriverName {
+		return "", errors.NewValidationError("sync requires a SQLite database",
+			fmt.Errorf("the %s storage backend cannot be synced, unset %s to use the default database", driver, repository.DSNEnvVar))
+	}
+
+	if location == "" {
+		path, err := sqlite.GetDatabasePath()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate the knot database: %w", err)
+		}
+		location = path
+	}
+	location, _, _ = strings.Cut(location, "?")
+	return filepath.Abs(location)
+}
+
+// remoteDatabasePath resolves --with to an existing database file
+func remoteDatabasePath(with string) (string, error) {
+	path, err := filepath.Abs(with)
+	if err != nil {
+		return "", fmt.Errorf("invalid --with path: %w", err)
+	}
+
+	info, err := os.Stat(path)
+	if err == nil && info.IsDir() {
+		path = filepath.Join(path, sqlite.DatabaseName)
+		info, err = os.Stat(path)
+	}
+	if err != nil {
+		return "", errors.NewValidationError("other database not found",
+			fmt.Errorf("no knot database at %s: %w", path, err))
+	}
+	if info.IsDir() {
+		return "", errors.NewValidationError("other database not found",
+			fmt.Errorf("%s is a directory, not a knot database", path))
+	}
+	return path, nil
+}
+
+func openDatabase(appCtx *shared.AppContext, path string) (types.Repository, error) {
+	repo, err := repository.Open(sqlite.DriverName+"://"+path, repository.Options{
+		Logger:      appCtx.Logger,
+		AutoMigrate: true,
+	})
+	if err != nil {
+		return nil, errors.DatabaseConnectionError("opening "+path, err)
+	}
+	return repo, nil
+}
+
+func closeDatabase(appCtx *shared.AppContext, repo types.Repository) {
+	if closer, ok := repo.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			appCtx.Logger.Warn("Failed to close database", zap.Error(err))
+		}
+	}
+}
+
+func writeReport(w io.Writer, report *dbsync.Report, lastSync time.Time) {
+	if lastSync.IsZero() {
+		fmt.Fprintln(w, "First sync of these databases: merging all projects and tasks, deletions are not propagated")
+	} else {
+		fmt.Fprintf(w, "Changes since the last sync at %s\n", output.Timestamp(lastSync))
+	}
+
+	verb := "Applied"
+	if report.DryRun {
+		verb = "DRY RUN: would apply"
+	}
+	fmt.Fprintf(w, "\n%s %d change(s):\n", verb, len(report.Changes))
+	for _, change := range report.Changes {
+		fmt.Fprintf(w, "  %-6s %-18s %s (ID: %s)\n", change.Target, change.Action, change.Title, change.ID)
+	}
+
+	if len(report.Failures) > 0 {
+		fmt.Fprintf(w, "\nFailed %d change(s):\n", len(report.Failures))
+		for _, change := range report.Failures {
+			fmt.Fprintf(w, "  %-6s %-18s %s (ID: %s): %s\n", change.Target, change.Action, change.Title, change.ID, change.Error)
+		}
+	}
+
+	unresolved := report.Unresolved()
+	if resolved := len(report.Conflicts) - len(unresolved); resolved > 0 {
+		fmt.Fprintf(w, "\nResolved %d conflict(s):\n", resolved)
+		for _, conflict := range report.Conflicts {
+			if conflict.Resolved() {
+				fmt.Fprintf(w, "  %s (ID: %s): %s, %s\n", conflict.Title, conflict.ID, conflict.Reason, conflict.Resolution)
+			}
+		}
+	}
+
+	if len(unresolved) > 0 {
+		fmt.Fprintf(w, "\n%d conflict(s) need a manual choice and were left untouched:\n", len(unresolved))
+		for _, conflict := range unresolved {
+			fmt.Fprintf(w, "  %s (ID: %s): %s\n", conflict.Title, conflict.ID, conflict.Reason)
+		}
+		fmt.Fprintln(w, "\nResolve them by running the sync again with --prefer local or --prefer remote.")
+		fmt.Fprintln(w, "The last sync time is not advanced until all conflicts are resolved.")
+	}
+}
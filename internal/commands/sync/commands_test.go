@@ -0,0 +1,51 @@
+package sync
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/repository"
+	"github.com/denkhaus/knot/v2/internal/repository/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalDatabasePath(t *testing.T) {
+	t.Run("sqlite database", func(t *testing.T) {
+		t.Setenv(remote.URLEnvVar, "")
+		t.Setenv(repository.DSNEnvVar, "sqlite:///tmp/knot/knot.db?_busy_timeout=5000")
+
+		path, err := localDatabasePath()
+		require.NoError(t, err)
+		assert.Equal(t, filepath.FromSlash("/tmp/knot/knot.db"), path)
+	})
+
+	t.Run("other backends name the database variable", func(t *testing.T) {
+		t.Setenv(remote.URLEnvVar, "")
+		t.Setenv(repository.DSNEnvVar, "inmemory://")
+
+		_, err := localDatabasePath()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unset KNOT_DATABASE")
+	})
+
+	t.Run("knot server from the remote URL", func(t *testing.T) {
+		t.Setenv(remote.URLEnvVar, "https://knot.example.com")
+		t.Setenv(repository.DSNEnvVar, "")
+
+		_, err := localDatabasePath()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "KNOT_REMOTE_URL selects the knot server at https://knot.example.com")
+		assert.Contains(t, err.Error(), "unset KNOT_REMOTE_URL")
+		assert.NotContains(t, err.Error(), "KNOT_DATABASE")
+	})
+
+	t.Run("knot server from the database variable", func(t *testing.T) {
+		t.Setenv(remote.URLEnvVar, "")
+		t.Setenv(repository.DSNEnvVar, "http://localhost:8080")
+
+		_, err := localDatabasePath()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unset KNOT_DATABASE")
+	})
+}
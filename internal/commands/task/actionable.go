@@ -1,12 +1,13 @@
 package task
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/selection"
 	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/utils"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
 )
@@ -23,7 +24,7 @@ func ActionableAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.String("projectID", projectID.String()))
 
 		// Get all tasks in the project
-		allTasks, err := appCtx.ProjectManager.ListTasksForProject(context.Background(), projectID)
+		allTasks, err := appCtx.ProjectManager.ListTasksWithDependencies(c.Context, projectID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get project tasks", zap.Error(err))
 			return fmt.Errorf("failed to get project tasks: %w", err)
@@ -38,6 +39,10 @@ func ActionableAction(appCtx *shared.AppContext) cli.ActionFunc {
 			strategyStr := c.String("strategy")
 			strategy = selection.ParseStrategy(strategyStr)
 			strategyReason = fmt.Sprintf("User-selected %s strategy", strategy.String())
+		} else if configured := appCtx.ProjectManager.GetConfig().SelectionStrategy; configured != "" {
+			// Strategy configured as the default in .knot/config.json
+			strategy = selection.ParseStrategy(configured)
+			strategyReason = fmt.Sprintf("Configured %s strategy", strategy.String())
 		} else {
 			// Auto-recommend strategy based on project analysis
 			recommendedStrategy, reason, err := selection.AnalyzeProjectAndRecommendStrategy(allTasks)
@@ -52,8 +57,7 @@ func ActionableAction(appCtx *shared.AppContext) cli.ActionFunc {
 		}
 
 		// Get configuration
-		config := selection.DefaultConfig()
-		config.Strategy = strategy
+		config := appCtx.ProjectManager.GetConfig().SelectionConfig(strategy)
 
 		// Apply configuration overrides from CLI flags
 		if c.Bool("allow-parent-with-subtasks") {
@@ -77,17 +81,18 @@ func ActionableAction(appCtx *shared.AppContext) cli.ActionFunc {
 			if selErr, ok := err.(*selection.SelectionError); ok {
 				switch selErr.Type {
 				case selection.ErrorTypeNoTasks:
-					fmt.Println("No tasks found in project")
+					appCtx.Out().Println("No tasks found in project")
 					return nil
 				case selection.ErrorTypeNoActionable:
-					fmt.Println("No actionable tasks available")
+					appCtx.Out().Println("No actionable tasks available")
 					return nil
 				case selection.ErrorTypeDeadlock:
-					fmt.Printf("No actionable tasks found: %s\n", selErr.Message)
+					appCtx.Out().Printf("No actionable tasks found: %s\n", selErr.Message)
+					appCtx.Out().Println("Run 'knot analyze deadlock' to see which dependencies block the pending tasks")
 					return nil
 				case selection.ErrorTypeCircularDep:
-					fmt.Printf("Circular dependencies detected: %s\n", selErr.Message)
-					fmt.Println("Please resolve the circular dependencies before continuing")
+					appCtx.Out().Printf("Circular dependencies detected: %s\n", selErr.Message)
+					appCtx.Out().Println("Please resolve the circular dependencies before continuing")
 					return nil
 				default:
 					return fmt.Errorf("task selection failed: %w", err)
@@ -118,7 +123,7 @@ func ActionableAction(appCtx *shared.AppContext) cli.ActionFunc {
 			if err != nil {
 				return fmt.Errorf("failed to marshal result to JSON: %w", err)
 			}
-			fmt.Println(string(jsonData))
+			appCtx.Out().Println(string(jsonData))
 			return nil
 		}
 
@@ -126,50 +131,66 @@ func ActionableAction(appCtx *shared.AppContext) cli.ActionFunc {
 		shared.ShowProjectContextWithSeparator(c, appCtx)
 
 		// Output formatted text
-		fmt.Printf("Next actionable task (strategy: %s):\n\n", strategy.String())
-		fmt.Printf("* %s (ID: %s)\n", selectedTask.Title, selectedTask.ID)
+		appCtx.Out().Printf("Next actionable task (strategy: %s):\n\n", strategy.String())
+		appCtx.Out().Printf("* %s (ID: %s)\n", selectedTask.Title, selectedTask.ID)
 
 		if selectedTask.Description != "" {
-			fmt.Printf("  %s\n", selectedTask.Description)
+			appCtx.Out().Printf("  %s\n", selectedTask.Description)
 		}
 
-		fmt.Printf("  State: %s | Complexity: %d | Priority: %d\n",
-			selectedTask.State, selectedTask.Complexity, selectedTask.Priority)
+		appCtx.Out().Printf("  State: %s | Complexity: %d | Priority: %d%s\n",
+			selectedTask.State, selectedTask.Complexity, selectedTask.Priority, utils.EstimateSuffix(selectedTask))
 
 		if selectedTask.Depth > 0 {
-			fmt.Printf("  Depth: %d", selectedTask.Depth)
+			appCtx.Out().Printf("  Depth: %d", selectedTask.Depth)
 			if selectedTask.ParentID != nil {
-				fmt.Printf(" | Parent: %s", *selectedTask.ParentID)
+				appCtx.Out().Printf(" | Parent: %s", *selectedTask.ParentID)
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 		}
 
 		// Show strategy reasoning and selection reasoning
-		fmt.Printf("\nStrategy: %s\n", strategyReason)
-		fmt.Printf("Selection reason: %s\n", result.Reason)
+		appCtx.Out().Printf("\nStrategy: %s\n", strategyReason)
+		appCtx.Out().Printf("Selection reason: %s\n", result.Reason)
 
 		if result.Score.UnblockedTaskCount > 0 {
-			fmt.Printf("Will unblock: %d task(s)\n", result.Score.UnblockedTaskCount)
+			appCtx.Out().Printf("Will unblock: %d task(s)\n", result.Score.UnblockedTaskCount)
 		}
 
 		if result.Score.DependentCount > 0 {
-			fmt.Printf("Dependent tasks: %d\n", result.Score.DependentCount)
+			appCtx.Out().Printf("Dependent tasks: %d\n", result.Score.DependentCount)
 		}
 
-		// Show alternatives if verbose mode and available
+		// Show the score breakdown and alternatives if verbose mode
+		if c.Bool("verbose") {
+			appCtx.Out().Printf("\nScore: %.2f\n", result.Score.Score)
+			printScoreFactors(appCtx.Out(), "  ", result.Score.Factors)
+		}
 		if c.Bool("verbose") && len(result.Alternatives) > 0 {
-			fmt.Printf("\nAlternatives considered:\n")
+			appCtx.Out().Printf("\nAlternatives considered:\n")
 			for i, alt := range result.Alternatives[:min(3, len(result.Alternatives))] {
-				fmt.Printf("  %d. %s (score: %.2f)\n", i+1, alt.Task.Title, alt.Score)
+				appCtx.Out().Printf("  %d. %s (score: %.2f, %+.2f vs. selected)\n", i+1, alt.Task.Title, alt.Score, alt.Score-result.Score.Score)
+				printScoreFactors(appCtx.Out(), "       ", alt.Factors)
 			}
 		}
 
-		fmt.Printf("\nExecution time: %v\n", result.ExecutionTime)
+		appCtx.Out().Printf("\nExecution time: %v\n", result.ExecutionTime)
 
 		return nil
 	}
 }
 
+// printScoreFactors prints the contribution of each weighted factor to a score,
+// one factor per line
+func printScoreFactors(out output.Writer, indent string, factors []selection.ScoreFactor) {
+	for _, factor := range factors {
+		if factor.Weight == 0 {
+			continue
+		}
+		out.Printf("%s%-18s %10.2f x %-8.2f = %+.2f\n", indent, factor.Name+":", factor.Value, factor.Weight, factor.Contribution)
+	}
+}
+
 // NewActionableCommand creates the enhanced actionable command with new flags
 func NewActionableCommand(appCtx *shared.AppContext) *cli.Command {
 	return &cli.Command{
@@ -184,18 +205,24 @@ Available strategies:
   - priority: Focus on high-priority tasks first
   - creation-order: Original knot behavior (oldest first)
   - critical-path: Focus on tasks affecting project timeline
+  - shortest-job-first: Quick wins first, the least remaining effort
+  - highest-value: Best priority to complexity ratio first
+
+Without --strategy the SelectionStrategy of .knot/config.json is used, or a
+strategy is recommended from the project structure.
 
 Examples:
   knot task actionable                           # Use default dependency-aware strategy
   knot task actionable --strategy=depth-first   # Prioritize completing branches
   knot task actionable --strategy=priority      # Focus on high-priority tasks
+  knot task actionable --verbose                # Score breakdown per factor and alternatives
   knot task actionable --verbose --json         # Detailed JSON output`,
 		Action: ActionableAction(appCtx),
 		Flags: []cli.Flag{
 			&cli.StringFlag{
 				Name:    "strategy",
 				Aliases: []string{"s"},
-				Usage:   "Selection strategy: dependency-aware, depth-first, priority, creation-order, critical-path (auto-recommended if not specified)",
+				Usage:   "Selection strategy: dependency-aware, depth-first, priority, creation-order, critical-path, shortest-job-first, highest-value (configured or auto-recommended if not specified)",
 			},
 			&cli.BoolFlag{
 				Name:  "allow-parent-with-subtasks",
@@ -208,7 +235,7 @@ Examples:
 			&cli.BoolFlag{
 				Name:    "verbose",
 				Aliases: []string{"v"},
-				Usage:   "Show detailed selection reasoning and alternatives",
+				Usage:   "Show the contribution of each factor to the score, and alternatives",
 			},
 			&cli.BoolFlag{
 				Name:  "json",
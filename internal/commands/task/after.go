@@ -0,0 +1,46 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/interchange"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+)
+
+// resolveAfter resolves the --after references of a new task to the tasks of
+// the project it will depend on, before the task is created so a typo does
+// not leave a task without its dependencies
+func resolveAfter(c *cli.Context, appCtx *shared.AppContext, projectID uuid.UUID) ([]*types.Task, error) {
+	refs := c.StringSlice("after")
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+	if err != nil {
+		return nil, errors.WrapWithSuggestion(err, "resolving --after tasks")
+	}
+
+	var after []*types.Task
+	seen := make(map[uuid.UUID]bool, len(refs))
+	for _, ref := range refs {
+		task, err := interchange.ResolveTaskRef(ref, tasks)
+		if err != nil {
+			return nil, &errors.EnhancedError{
+				Operation:  "creating task",
+				Cause:      fmt.Errorf("--after %q: %w", ref, err),
+				Suggestion: "Reference an existing task of the project by title, short ID or ID",
+				Example:    "knot task create --title \"Write docs\" --after \"API Design\" --after 9c2e41d7",
+			}
+		}
+		if !seen[task.ID] {
+			seen[task.ID] = true
+			after = append(after, task)
+		}
+	}
+	return after, nil
+}
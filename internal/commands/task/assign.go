@@ -0,0 +1,133 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// NewAssignCommand creates the command that passes a task to another agent
+// with a handoff note
+func NewAssignCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "assign",
+		Usage: "Assign a task to an agent, leaving a handoff note for the next assignee",
+		Description: `Assigns the task to a registered agent (by name) or any agent ID, or removes
+the assignment with --unassign. --handoff records what is done and what is
+left; 'knot task get' and 'knot task claim' show the note to the next
+assignee. With 'knot config set --key require-handoff-notes --value 1' a note
+is required to take a task away from another agent.
+
+Examples:
+  knot task assign --id <task-id> --agent docs-bot --handoff "Outline done, examples left"
+  knot task assign --id <task-id> --unassign --handoff "Stuck on the vendor API, see the blocker"`,
+		Action: assignAction(appCtx),
+		Flags: []cli.Flag{
+			shared.NewTaskIDFlag(),
+			&cli.StringFlag{
+				Name:    "agent",
+				Aliases: []string{"a"},
+				Usage:   "Name of a registered agent or agent ID",
+			},
+			&cli.BoolFlag{
+				Name:  "unassign",
+				Usage: "Remove the assignment instead",
+			},
+			&cli.StringFlag{
+				Name:  "handoff",
+				Usage: "Note for the next assignee: what's done, what's left",
+			},
+		},
+	}
+}
+
+func assignAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskID, err := parseTaskIDFlag(c)
+		if err != nil {
+			return err
+		}
+		if c.IsSet("agent") == c.Bool("unassign") {
+			return errors.NewValidationError("invalid flags", fmt.Errorf("give either --agent or --unassign"))
+		}
+		config := appCtx.ProjectManager.GetConfig()
+		var agentID *uuid.UUID
+		if c.IsSet("agent") {
+			id, err := resolveAgent(config, c.String("agent"))
+			if err != nil {
+				return err
+			}
+			agentID = &id
+		}
+		actor := shared.ResolveActor(c.String("actor"))
+
+		appCtx.Logger.Info("Reassigning task",
+			zap.String("taskID", taskID.String()),
+			zap.String("agent", c.String("agent")),
+			zap.String("actor", actor))
+
+		previous, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
+		if err != nil {
+			return errors.TaskNotFoundError(taskID)
+		}
+		task, err := appCtx.ProjectManager.ReassignTask(c.Context, taskID, agentID, c.String("handoff"), actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to reassign task", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "assigning task")
+		}
+
+		appCtx.Out().Printf("Reassigned task \"%s\": %s -> %s\n", task.Title,
+			agentLabel(config, previous.AssignedAgent), agentLabel(config, task.AssignedAgent))
+		if len(task.Handoffs) > len(previous.Handoffs) {
+			printHandoff(appCtx.Out(), config, task.LatestHandoff(), "  ")
+		}
+		return nil
+	}
+}
+
+// resolveAgent returns the ID of a registered agent by name or ID, or parses
+// the ID of an agent that is not registered
+func resolveAgent(config *manager.Config, nameOrID string) (uuid.UUID, error) {
+	if profile, ok := config.Agent(nameOrID); ok {
+		return profile.ID, nil
+	}
+	id, err := uuid.Parse(nameOrID)
+	if err != nil {
+		return uuid.Nil, &errors.EnhancedError{
+			Operation:   "assign task",
+			Cause:       fmt.Errorf("no agent '%s' is registered", nameOrID),
+			Suggestion:  "Use the name of a registered agent or an agent ID",
+			HelpCommand: "knot agent list",
+		}
+	}
+	return id, nil
+}
+
+// agentLabel names an assignee by its registered name, or by ID if it is not
+// registered
+func agentLabel(config *manager.Config, agentID *uuid.UUID) string {
+	if agentID == nil {
+		return "unassigned"
+	}
+	if profile, ok := config.Agent(agentID.String()); ok {
+		return profile.Name
+	}
+	return agentID.String()
+}
+
+// printHandoff prints a handoff note with who left it and how the task changed hands
+func printHandoff(out output.Writer, config *manager.Config, handoff *types.TaskHandoff, indent string) {
+	change := fmt.Sprintf("%s -> %s", agentLabel(config, handoff.FromAgent), agentLabel(config, handoff.ToAgent))
+	if handoff.FromState != handoff.ToState {
+		change = fmt.Sprintf("%s -> %s", handoff.FromState, handoff.ToState)
+	}
+	out.Printf("%sHandoff note (%s, by %s at %s):\n", indent, change, handoff.By, output.Timestamp(handoff.At))
+	out.Printf("%s  %s\n", indent, handoff.Note)
+}
@@ -1,9 +1,15 @@
 package task
 
 import (
-	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/errors"
+
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/denkhaus/knot/v2/internal/utils"
@@ -12,7 +18,8 @@ import (
 	"go.uber.org/zap"
 )
 
-// BlockedAction shows tasks that are blocked by dependencies
+// BlockedAction shows tasks that are blocked by dependencies or by an
+// external reason
 func BlockedAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		projectID, err := shared.ResolveProjectID(c, appCtx)
@@ -20,10 +27,14 @@ func BlockedAction(appCtx *shared.AppContext) cli.ActionFunc {
 			return err
 		}
 
+		if c.Bool("aging") {
+			return blockedAging(c, appCtx, projectID)
+		}
+
 		appCtx.Logger.Info("Finding blocked tasks", zap.String("projectID", projectID.String()))
 
 		// Get all tasks in the project
-		allTasks, err := appCtx.ProjectManager.ListTasksForProject(context.Background(), projectID)
+		allTasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get project tasks", zap.Error(err))
 			return fmt.Errorf("failed to get project tasks: %w", err)
@@ -35,10 +46,13 @@ func BlockedAction(appCtx *shared.AppContext) cli.ActionFunc {
 			taskMap[task.ID] = task
 		}
 
-		// Find blocked tasks (pending/in-progress with unmet dependencies)
+		// Find blocked tasks (pending/in-progress with unmet dependencies, or
+		// blocked for an external reason)
 		var blockedTasks []*types.Task
 		for _, task := range allTasks {
-			if task.State == types.TaskStatePending || task.State == types.TaskStateInProgress {
+			if task.State == types.TaskStateBlocked && task.Blocker != nil {
+				blockedTasks = append(blockedTasks, task)
+			} else if task.State == types.TaskStatePending || task.State == types.TaskStateInProgress {
 				if !utils.IsTaskReady(task, taskMap) && len(task.Dependencies) > 0 {
 					blockedTasks = append(blockedTasks, task)
 				}
@@ -51,38 +65,145 @@ func BlockedAction(appCtx *shared.AppContext) cli.ActionFunc {
 		shared.ShowProjectContextWithSeparator(c, appCtx)
 
 		if len(blockedTasks) == 0 {
-			fmt.Println("No blocked tasks found. All tasks are either ready, completed, or have no dependencies.")
+			appCtx.Out().Println("No blocked tasks found. All tasks are either ready, completed, or have no dependencies or external blockers.")
 			return nil
 		}
 
 		// Apply limit if specified
 		limit := c.Int("limit")
 		if limit > 0 && len(blockedTasks) > limit {
-			fmt.Printf("Blocked tasks (showing %d of %d):\n\n", limit, len(blockedTasks))
+			appCtx.Out().Printf("Blocked tasks (showing %d of %d):\n\n", limit, len(blockedTasks))
 			blockedTasks = blockedTasks[:limit]
 		} else {
-			fmt.Printf("Blocked tasks (%d):\n\n", len(blockedTasks))
+			appCtx.Out().Printf("Blocked tasks (%d):\n\n", len(blockedTasks))
 		}
 
 		for i, task := range blockedTasks {
-			fmt.Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
+			appCtx.Out().Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
 			if task.Description != "" {
-				fmt.Printf("   %s\n", task.Description)
+				appCtx.Out().Printf("   %s\n", output.Summary(task.Description))
+			}
+			appCtx.Out().Printf("   State: %s | Complexity: %d%s\n", task.State, task.Complexity, utils.EstimateSuffix(task))
+
+			if task.Blocker != nil {
+				appCtx.Out().Printf("   Blocked externally: %s\n", output.Blocker(task.Blocker))
+				appCtx.Out().Printf("     since %s by %s\n", output.Timestamp(task.Blocker.BlockedAt), task.Blocker.BlockedBy)
 			}
-			fmt.Printf("   State: %s | Complexity: %d\n", task.State, task.Complexity)
 
 			// Show blocking dependencies
-			fmt.Printf("   Blocked by %d dependencies:\n", len(task.Dependencies))
+			if len(task.Dependencies) == 0 {
+				appCtx.Out().Println()
+				continue
+			}
+			appCtx.Out().Printf("   Blocked by %d dependencies:\n", len(task.Dependencies))
 			for _, depID := range task.Dependencies {
 				if depTask, exists := taskMap[depID]; exists {
-					fmt.Printf("     -> %s (ID: %s) - %s\n", depTask.Title, depTask.ID, depTask.State)
+					appCtx.Out().Printf("     -> %s (ID: %s) - %s\n", depTask.Title, depTask.ID, depTask.State)
 				} else {
-					fmt.Printf("     -> Unknown task (ID: %s)\n", depID)
+					appCtx.Out().Printf("     -> Unknown task (ID: %s)\n", depID)
 				}
 			}
-			fmt.Println()
+			appCtx.Out().Println()
+		}
+
+		return nil
+	}
+}
+
+// blockedAging lists blocked tasks with how long they have been blocked, by
+// whom or what, and marks long-blocked tasks for escalation
+func blockedAging(c *cli.Context, appCtx *shared.AppContext, projectID uuid.UUID) error {
+	threshold := appCtx.ProjectManager.GetConfig().BlockedEscalationThreshold()
+	if c.IsSet("escalate-after") {
+		threshold = c.Int("escalate-after")
+		if threshold < 0 {
+			return errors.NewValidationError("invalid --escalate-after value",
+				fmt.Errorf("--escalate-after must be 0 (never escalate) or a number of days, got %d", threshold))
+		}
+	}
+
+	tasks, err := appCtx.ProjectManager.ListTasksWithDependencies(c.Context, projectID)
+	if err != nil {
+		appCtx.Logger.Error("Failed to get project tasks", zap.Error(err))
+		return fmt.Errorf("failed to get project tasks: %w", err)
+	}
+	// The change feed is optional; without it ages count from the last update
+	events, err := appCtx.ProjectManager.ListChangeEvents(c.Context, types.ChangeEventFilter{ProjectID: &projectID})
+	if err != nil {
+		appCtx.Logger.Warn("Change feed unavailable, using task timestamps", zap.Error(err))
+		events = nil
+	}
+
+	now := appCtx.ProjectManager.GetCurrentTime()
+	report := analysis.BlockedAging(tasks, events, now, threshold)
+	appCtx.Logger.Info("Built blocked aging report",
+		zap.String("projectID", projectID.String()),
+		zap.Int("tasks", len(report.Tasks)),
+		zap.Int("escalated", report.Escalated))
+
+	if limit := c.Int("limit"); limit > 0 && len(report.Tasks) > limit {
+		report.Tasks = report.Tasks[:limit]
+	}
+
+	if c.Bool("json") {
+		jsonData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal blocked aging report to JSON: %w", err)
 		}
+		appCtx.Out().Println(string(jsonData))
+		return nil
+	}
 
+	w := appCtx.Out()
+	shared.ShowProjectContextWithSeparator(c, appCtx)
+	if len(report.Tasks) == 0 {
+		fmt.Fprintln(w, "No blocked tasks found.")
 		return nil
 	}
+
+	fmt.Fprintf(w, "Blocked tasks by age (%d", len(report.Tasks))
+	if threshold > 0 {
+		fmt.Fprintf(w, ", %d blocked %d+ days need escalation", report.Escalated, threshold)
+	}
+	fmt.Fprintln(w, "):")
+	fmt.Fprintln(w)
+	for i, entry := range report.Tasks {
+		marker := ""
+		if entry.Escalate {
+			marker = " [ESCALATE]"
+		}
+		fmt.Fprintf(w, "%d. %s (ID: %s)%s\n", i+1, entry.Title, entry.TaskID, marker)
+		fmt.Fprintf(w, "   Blocked for %s, since %s", formatAge(now.Sub(entry.Since)), output.Timestamp(entry.Since))
+		if entry.BlockedBy != "" {
+			fmt.Fprintf(w, " by %s", entry.BlockedBy)
+		}
+		fmt.Fprintln(w)
+		if entry.Reason != nil {
+			fmt.Fprintf(w, "   Reason: %s\n", output.Blocker(entry.Reason))
+		}
+		if len(entry.Dependencies) > 0 {
+			titles := make([]string, len(entry.Dependencies))
+			for j, dep := range entry.Dependencies {
+				titles[j] = fmt.Sprintf("%s (%s)", dep.Title, dep.State)
+			}
+			fmt.Fprintf(w, "   Waiting for: %s\n", strings.Join(titles, ", "))
+		}
+	}
+	return nil
+}
+
+// formatAge renders how long a task has been blocked in days and hours
+func formatAge(age time.Duration) string {
+	if age < time.Hour {
+		return "less than an hour"
+	}
+	days := int(age / (24 * time.Hour))
+	hours := int(age/time.Hour) % 24
+	switch {
+	case days == 0:
+		return fmt.Sprintf("%dh", hours)
+	case hours == 0:
+		return fmt.Sprintf("%dd", days)
+	}
+	return fmt.Sprintf("%dd %dh", days, hours)
 }
@@ -1,11 +1,12 @@
 package task
 
 import (
-	"context"
+	"encoding/json"
 	"fmt"
 
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
-	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/urfave/cli/v2"
 	"go.uber.org/zap"
 )
@@ -18,8 +19,11 @@ func BreakdownAction(appCtx *shared.AppContext) cli.ActionFunc {
 			return err
 		}
 
-		// TODO: Get complexity threshold from config (default: 8)
-		complexityThreshold := c.Int("threshold")
+		// The flag wins when given, otherwise the configured threshold applies
+		complexityThreshold := appCtx.ProjectManager.GetConfig().ComplexityThreshold
+		if c.IsSet("threshold") || complexityThreshold == 0 {
+			complexityThreshold = c.Int("threshold")
+		}
 		if complexityThreshold == 0 {
 			complexityThreshold = 8 // Default from original pkg/tools/project
 		}
@@ -29,63 +33,71 @@ func BreakdownAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.Int("threshold", complexityThreshold))
 
 		// Get all tasks in the project
-		allTasks, err := appCtx.ProjectManager.ListTasksForProject(context.Background(), projectID)
+		allTasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get project tasks", zap.Error(err))
 			return fmt.Errorf("failed to get project tasks: %w", err)
 		}
 
-		// Find tasks with complexity >= threshold that have no children
-		var needsBreakdown []*types.Task
-		for _, task := range allTasks {
-			if task.Complexity >= complexityThreshold {
-				// Check if task has children by looking for tasks with this task as parent
-				hasChildren := false
-				for _, otherTask := range allTasks {
-					if otherTask.ParentID != nil && *otherTask.ParentID == task.ID {
-						hasChildren = true
-						break
-					}
-				}
-				if !hasChildren {
-					needsBreakdown = append(needsBreakdown, task)
-				}
-			}
+		// Tasks with complexity >= threshold that have no children, most impactful first
+		candidates := analysis.FindBreakdownCandidates(allTasks, complexityThreshold, appCtx.ProjectManager.GetConfig())
+		total := len(candidates)
+
+		appCtx.Logger.Info("Tasks needing breakdown found", zap.Int("count", total))
+
+		// Apply limit if specified
+		limit := c.Int("limit")
+		if limit > 0 && len(candidates) > limit {
+			candidates = candidates[:limit]
 		}
 
-		appCtx.Logger.Info("Tasks needing breakdown found", zap.Int("count", len(needsBreakdown)))
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(candidates, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal breakdown candidates to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
 
-		if len(needsBreakdown) == 0 {
-			fmt.Printf("No tasks need breakdown (complexity >= %d with no subtasks)\n", complexityThreshold)
+		if total == 0 {
+			appCtx.Out().Printf("No tasks need breakdown (complexity >= %d with no subtasks)\n", complexityThreshold)
 			return nil
 		}
 
-		// Apply limit if specified
-		limit := c.Int("limit")
-		if limit > 0 && len(needsBreakdown) > limit {
-			fmt.Printf("Tasks needing breakdown (showing %d of %d with complexity >= %d):\n\n",
-				limit, len(needsBreakdown), complexityThreshold)
-			needsBreakdown = needsBreakdown[:limit]
+		if len(candidates) < total {
+			appCtx.Out().Printf("Tasks needing breakdown (showing %d of %d with complexity >= %d):\n\n",
+				len(candidates), total, complexityThreshold)
 		} else {
-			fmt.Printf("Tasks needing breakdown (%d tasks with complexity >= %d):\n\n",
-				len(needsBreakdown), complexityThreshold)
+			appCtx.Out().Printf("Tasks needing breakdown (%d tasks with complexity >= %d):\n\n",
+				total, complexityThreshold)
 		}
 
-		for i, task := range needsBreakdown {
-			fmt.Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
+		for i, candidate := range candidates {
+			task := candidate.Task
+			appCtx.Out().Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
 			if task.Description != "" {
-				fmt.Printf("   %s\n", task.Description)
+				appCtx.Out().Printf("   %s\n", output.Summary(task.Description))
 			}
-			fmt.Printf("   State: %s | Complexity: %d (>= %d threshold)\n",
+			appCtx.Out().Printf("   State: %s | Complexity: %d (>= %d threshold)\n",
 				task.State, task.Complexity, complexityThreshold)
+			appCtx.Out().Printf("   Dependents: %d | Suggested subtasks: %d | Capacity at depth %d: %d",
+				candidate.Dependents, candidate.SuggestedSubtasks, task.Depth+1, candidate.SubtaskCapacity)
+			if candidate.DepthLimitExempt {
+				appCtx.Out().Print(" (exempt as a large breakdown)")
+			}
+			appCtx.Out().Println()
+			if !candidate.DepthLimitExempt && candidate.SubtaskCapacity < candidate.SuggestedSubtasks {
+				appCtx.Out().Printf("   Warning: not enough capacity for the suggested subtasks (see 'knot task capacity --parent-id %s')\n", task.ID)
+			}
 			if task.Depth > 0 {
-				fmt.Printf("   Depth: %d", task.Depth)
+				appCtx.Out().Printf("   Depth: %d", task.Depth)
 				if task.ParentID != nil {
-					fmt.Printf(" | Parent: %s", *task.ParentID)
+					appCtx.Out().Printf(" | Parent: %s", *task.ParentID)
 				}
-				fmt.Println()
+				appCtx.Out().Println()
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 		}
 
 		return nil
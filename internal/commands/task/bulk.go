@@ -2,11 +2,14 @@ package task
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/utils"
 
@@ -25,59 +28,72 @@ func BulkUpdateAction(appCtx *shared.AppContext) cli.ActionFunc {
 		}
 
 		// Parse comma-separated task IDs
-		taskIDStrings := strings.Split(taskIDsStr, ",")
-		var taskIDs []uuid.UUID
-		for _, idStr := range taskIDStrings {
-			idStr = strings.TrimSpace(idStr)
-			taskID, err := uuid.Parse(idStr)
-			if err != nil {
-				return fmt.Errorf("invalid task ID '%s': %w", idStr, err)
-			}
-			taskIDs = append(taskIDs, taskID)
+		taskIDs, err := parseTaskIDList(taskIDsStr)
+		if err != nil {
+			return err
 		}
 
-		if len(taskIDs) == 0 {
-			return fmt.Errorf("no valid task IDs provided")
+		updates, err := bulkUpdatesFromFlags(c)
+		if err != nil {
+			return err
 		}
 
-		// Build updates struct
-		var updates types.TaskUpdates
+		return bulkUpdate(c, appCtx, taskIDs, updates)
+	}
+}
 
-		if stateStr := c.String("state"); stateStr != "" {
-			state := types.TaskState(stateStr)
-			updates.State = &state
-		}
+// bulkUpdatesFromFlags builds the updates of a bulk update from the state and
+// complexity flags
+func bulkUpdatesFromFlags(c *cli.Context) (types.TaskUpdates, error) {
+	var updates types.TaskUpdates
 
-		if complexity := c.Int("complexity"); complexity > 0 {
-			updates.Complexity = &complexity
+	if stateStr := c.String("state"); stateStr != "" {
+		if err := errors.ValidateTaskState(stateStr); err != nil {
+			return updates, err
 		}
+		state := types.TaskState(stateStr)
+		updates.State = &state
+	}
 
-		if updates.State == nil && updates.Complexity == nil {
-			return fmt.Errorf("at least one field (state or complexity) must be specified")
-		}
+	if complexity := c.Int("complexity"); complexity > 0 {
+		updates.Complexity = &complexity
+	}
 
-		appCtx.Logger.Info("Bulk updating tasks",
-			zap.Int("taskCount", len(taskIDs)),
-			zap.Any("updates", updates))
+	if updates.State == nil && updates.Complexity == nil {
+		return updates, fmt.Errorf("at least one field (state or complexity) must be specified")
+	}
+	return updates, nil
+}
 
-		actor := shared.GetActorFromContext(c)
+// bulkUpdate applies updates to the given tasks, forcing state transitions
+// when --force-transition is set
+func bulkUpdate(c *cli.Context, appCtx *shared.AppContext, taskIDs []uuid.UUID, updates types.TaskUpdates) error {
+	appCtx.Logger.Info("Bulk updating tasks",
+		zap.Int("taskCount", len(taskIDs)),
+		zap.Any("updates", updates))
 
-		err := appCtx.ProjectManager.BulkUpdateTasks(context.Background(), taskIDs, updates, actor)
-		if err != nil {
-			appCtx.Logger.Error("Failed to bulk update tasks", zap.Error(err))
-			return fmt.Errorf("failed to bulk update tasks: %w", err)
-		}
+	actor := shared.GetActorFromContext(c)
 
-		fmt.Printf("Successfully updated %d tasks\n", len(taskIDs))
-		if updates.State != nil {
-			fmt.Printf("  State: %s\n", *updates.State)
-		}
-		if updates.Complexity != nil {
-			fmt.Printf("  Complexity: %d\n", *updates.Complexity)
-		}
+	ctx := c.Context
+	if c.Bool("force-transition") {
+		ctx = manager.WithForcedTransitions(ctx)
+	}
 
-		return nil
+	err := appCtx.ProjectManager.BulkUpdateTasks(ctx, taskIDs, updates, actor)
+	if err != nil {
+		appCtx.Logger.Error("Failed to bulk update tasks", zap.Error(err))
+		return fmt.Errorf("failed to bulk update tasks: %w", err)
 	}
+
+	appCtx.Out().Printf("Successfully updated %d tasks\n", len(taskIDs))
+	if updates.State != nil {
+		appCtx.Out().Printf("  State: %s\n", *updates.State)
+	}
+	if updates.Complexity != nil {
+		appCtx.Out().Printf("  Complexity: %d\n", *updates.Complexity)
+	}
+
+	return nil
 }
 
 // DuplicateAction creates a copy of a task
@@ -107,18 +123,18 @@ func DuplicateAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.String("taskID", taskID.String()),
 			zap.String("targetProjectID", targetProjectID.String()))
 
-		duplicatedTask, err := appCtx.ProjectManager.DuplicateTask(context.Background(), taskID, targetProjectID)
+		duplicatedTask, err := appCtx.ProjectManager.DuplicateTask(c.Context, taskID, targetProjectID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to duplicate task", zap.Error(err))
 			return fmt.Errorf("failed to duplicate task: %w", err)
 		}
 
-		fmt.Printf("Task duplicated successfully:\n")
-		fmt.Printf("  Original: %s\n", taskID)
-		fmt.Printf("  New: %s (ID: %s)\n", duplicatedTask.Title, duplicatedTask.ID)
-		fmt.Printf("  Target Project: %s\n", targetProjectID)
-		fmt.Printf("  State: %s (reset to pending)\n", duplicatedTask.State)
-		fmt.Printf("  Complexity: %d\n", duplicatedTask.Complexity)
+		appCtx.Out().Printf("Task duplicated successfully:\n")
+		appCtx.Out().Printf("  Original: %s\n", taskID)
+		appCtx.Out().Printf("  New: %s (ID: %s)\n", duplicatedTask.Title, duplicatedTask.ID)
+		appCtx.Out().Printf("  Target Project: %s\n", targetProjectID)
+		appCtx.Out().Printf("  State: %s (reset to pending)\n", duplicatedTask.State)
+		appCtx.Out().Printf("  Complexity: %d\n", duplicatedTask.Complexity)
 
 		return nil
 	}
@@ -143,40 +159,49 @@ func ListByStateAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.String("projectID", projectID.String()),
 			zap.String("state", stateStr))
 
-		tasks, err := appCtx.ProjectManager.ListTasksByState(context.Background(), projectID, state)
+		tasks, err := appCtx.ProjectManager.ListTasksByState(c.Context, projectID, state)
 		if err != nil {
 			appCtx.Logger.Error("Failed to list tasks by state", zap.Error(err))
 			return fmt.Errorf("failed to list tasks by state: %w", err)
 		}
 
 		if len(tasks) == 0 {
-			fmt.Printf("No tasks found with state '%s' in project %s\n", state, projectID)
+			appCtx.Out().Printf("No tasks found with state '%s' in project %s\n", state, projectID)
 			return nil
 		}
 
 		// Check if JSON output is requested
 		if c.Bool("json") {
-			return utils.OutputTasksAsJSON(tasks)
+			return appCtx.Out().JSON(tasks)
 		}
 
-		fmt.Printf("Tasks with state '%s' (%d found):\n\n", state, len(tasks))
+		appCtx.Out().Printf("Tasks with state '%s' (%d found):\n\n", state, len(tasks))
 		for i, task := range tasks {
-			fmt.Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
+			appCtx.Out().Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
 			if task.Description != "" {
-				fmt.Printf("   %s\n", task.Description)
+				appCtx.Out().Printf("   %s\n", output.Summary(task.Description))
 			}
-			fmt.Printf("   Complexity: %d | Depth: %d\n", task.Complexity, task.Depth)
+			appCtx.Out().Printf("   Complexity: %d | Depth: %d\n", task.Complexity, task.Depth)
 			if task.ParentID != nil {
-				fmt.Printf("   Parent: %s\n", *task.ParentID)
+				appCtx.Out().Printf("   Parent: %s\n", *task.ParentID)
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 		}
 
 		return nil
 	}
 }
 
-// BulkCreateAction creates multiple tasks from JSON input
+// bulkCreateFailure records an entry that could not be created
+type bulkCreateFailure struct {
+	index int
+	title string
+	err   error
+}
+
+// BulkCreateAction creates multiple tasks from a YAML or JSON file.
+// Created entries are recorded in a resume file so that a re-run after a failure
+// skips them instead of creating duplicates; the file is removed once all entries exist.
 func BulkCreateAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		projectID, err := shared.ResolveProjectID(c, appCtx)
@@ -195,79 +220,209 @@ func BulkCreateAction(appCtx *shared.AppContext) cli.ActionFunc {
 			return fmt.Errorf("failed to read file: %w", err)
 		}
 
-		// Parse JSON input
-		var taskInputs []struct {
-			Title       string  `json:"title"`
-			Description string  `json:"description"`
-			Complexity  int     `json:"complexity"`
-			ParentID    *string `json:"parent_id,omitempty"`
+		entries, err := parseBulkInput(data)
+		if err != nil {
+			return err
 		}
 
-		if err := json.Unmarshal(data, &taskInputs); err != nil {
-			return fmt.Errorf("failed to parse JSON: %w", err)
+		resumePath := c.String("resume-file")
+		if resumePath == "" {
+			resumePath = inputFile + ".resume"
 		}
-
-		if len(taskInputs) == 0 {
-			return fmt.Errorf("no tasks found in input file")
+		resume, err := loadBulkResumeState(resumePath)
+		if err != nil {
+			return err
 		}
 
 		actor := shared.GetActorFromContext(c)
+		continueOnError := c.Bool("continue-on-error")
+		delay := c.Duration("delay")
 
 		appCtx.Logger.Info("Bulk creating tasks",
-			zap.Int("taskCount", len(taskInputs)),
+			zap.Int("taskCount", len(entries)),
+			zap.Int("alreadyCreated", len(resume.Created)),
 			zap.String("projectID", projectID.String()),
 			zap.String("actor", actor))
 
-		var createdTasks []types.Task
-		for i, input := range taskInputs {
-			// Validate input
-			if input.Title == "" {
-				return fmt.Errorf("task %d: title is required", i+1)
+		total := len(entries)
+		created, skipped := 0, 0
+		var failures []bulkCreateFailure
+
+		// taskIDs holds the task of every created entry, uuid.Nil otherwise
+		taskIDs := make([]uuid.UUID, total)
+
+		for i, entry := range entries {
+			input := entry.input
+			if taskID, done, err := resume.lookup(i, input.Title); err != nil {
+				return err
+			} else if done {
+				taskIDs[i] = taskID
+				skipped++
+				appCtx.Out().Printf("[%d/%d] Skipped (already created): %s\n", i+1, total, input.Title)
+				continue
 			}
-			if input.Complexity <= 0 {
-				input.Complexity = 5 // Default complexity
+
+			if created > 0 && delay > 0 {
+				time.Sleep(delay)
 			}
 
+			var task *types.Task
 			var parentID *uuid.UUID
-			if input.ParentID != nil && *input.ParentID != "" {
-				parsed, err := uuid.Parse(*input.ParentID)
-				if err != nil {
-					return fmt.Errorf("task %d: invalid parent ID '%s': %w", i+1, *input.ParentID, err)
+			var err error
+			if entry.parent >= 0 {
+				if taskIDs[entry.parent] == uuid.Nil {
+					err = fmt.Errorf("parent entry %d was not created", entry.parent+1)
+				} else {
+					parentID = &taskIDs[entry.parent]
+				}
+			}
+			if err == nil {
+				task, err = createBulkTask(c.Context, appCtx, projectID, parentID, input, actor)
+			}
+			if err == nil {
+				if recordErr := resume.record(i, input.Title, task.ID); recordErr != nil {
+					appCtx.Logger.Error("Failed to record bulk-create progress", zap.Error(recordErr))
+					err = recordErr
 				}
-				parentID = &parsed
 			}
-
-			// Create task
-			task, err := appCtx.ProjectManager.CreateTask(
-				context.Background(),
-				projectID,
-				parentID,
-				input.Title,
-				input.Description,
-				input.Complexity,
-				types.TaskPriorityMedium,
-				actor,
-			)
 			if err != nil {
 				appCtx.Logger.Error("Failed to create task", zap.Error(err), zap.Int("taskIndex", i))
-				return fmt.Errorf("failed to create task %d ('%s'): %w", i+1, input.Title, err)
+				appCtx.Out().Printf("[%d/%d] Failed: %s: %v\n", i+1, total, input.Title, err)
+				failures = append(failures, bulkCreateFailure{index: i, title: input.Title, err: err})
+				if !continueOnError {
+					break
+				}
+				continue
 			}
 
-			createdTasks = append(createdTasks, *task)
+			taskIDs[i] = task.ID
+			created++
+			appCtx.Out().Printf("[%d/%d] Created: %s (ID: %s)%s\n", i+1, total, task.Title, task.ID, utils.EstimateSuffix(task))
 		}
 
-		fmt.Printf("Successfully created %d tasks:\n", len(createdTasks))
-		for i, task := range createdTasks {
-			fmt.Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
-			if task.Description != "" {
-				fmt.Printf("   %s\n", task.Description)
+		notAttempted := total - created - skipped - len(failures)
+
+		// Dependencies are linked once the tasks they reference exist. Linking is
+		// idempotent, so a resumed run simply completes the missing edges.
+		linked := 0
+		if len(failures) == 0 || continueOnError {
+			var depFailures []bulkCreateFailure
+			linked, depFailures = linkBulkDependencies(c.Context, appCtx, entries, taskIDs, actor)
+			failures = append(failures, depFailures...)
+		}
+
+		appCtx.Out().Printf("\nBulk create summary: %d created, %d skipped, %d failed, %d not attempted, %d dependencies linked\n",
+			created, skipped, len(failures), notAttempted, linked)
+		appCtx.Out().Printf("  Created by: %s\n", actor)
+		if created > 0 {
+			warnProjectSize(c, appCtx, projectID)
+		}
+
+		if len(failures) == 0 {
+			return resume.remove()
+		}
+
+		appCtx.Out().Printf("\nFailed entries (%d):\n", len(failures))
+		for _, f := range failures {
+			appCtx.Out().Printf("  %d. %s: %v\n", f.index+1, f.title, f.err)
+		}
+		appCtx.Out().Printf("\nProgress saved to %s - fix the input and re-run the same command to resume.\n", resumePath)
+
+		return fmt.Errorf("bulk create failed for %d of %d tasks", len(failures), total)
+	}
+}
+
+// createBulkTask validates a bulk-create entry and creates the task. parentID is
+// the task of the enclosing entry for nested entries, otherwise the entry's parent_id is used.
+func createBulkTask(ctx context.Context, appCtx *shared.AppContext, projectID uuid.UUID, parentID *uuid.UUID, input bulkTaskInput, actor string) (*types.Task, error) {
+	if input.Title == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	if input.Complexity <= 0 {
+		input.Complexity = 5 // Default complexity
+	}
+
+	if parentID == nil && input.ParentID != nil && *input.ParentID != "" {
+		parsed, err := uuid.Parse(*input.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent ID '%s': %w", *input.ParentID, err)
+		}
+		parentID = &parsed
+	}
+
+	return appCtx.ProjectManager.CreateTask(
+		ctx,
+		projectID,
+		parentID,
+		input.Title,
+		input.Description,
+		input.Complexity,
+		types.TaskPriorityMedium,
+		actor,
+	)
+}
+
+// linkBulkDependencies resolves the depends_on refs of all created entries and adds
+// the missing dependencies. It returns the number of added dependencies and the
+// entries whose dependencies could not be linked.
+func linkBulkDependencies(ctx context.Context, appCtx *shared.AppContext, entries []bulkEntry, taskIDs []uuid.UUID, actor string) (int, []bulkCreateFailure) {
+	refs := make(map[string]int)
+	for i, entry := range entries {
+		if entry.input.Ref != "" {
+			refs[entry.input.Ref] = i
+		}
+	}
+
+	linked := 0
+	var failures []bulkCreateFailure
+	for i, entry := range entries {
+		if len(entry.input.DependsOn) == 0 || taskIDs[i] == uuid.Nil {
+			continue
+		}
+
+		task, err := appCtx.ProjectManager.GetTask(ctx, taskIDs[i])
+		if err != nil {
+			failures = append(failures, bulkCreateFailure{index: i, title: entry.input.Title, err: err})
+			continue
+		}
+		existing := make(map[uuid.UUID]bool)
+		for _, depID := range task.Dependencies {
+			existing[depID] = true
+		}
+
+		for _, dep := range entry.input.DependsOn {
+			dep = strings.TrimSpace(dep)
+			depID, err := resolveBulkDependency(dep, refs, taskIDs)
+			if err == nil && !existing[depID] {
+				_, err = appCtx.ProjectManager.AddTaskDependency(ctx, taskIDs[i], depID, actor)
+				if err == nil {
+					existing[depID] = true
+					linked++
+				}
+			}
+			if err != nil {
+				appCtx.Logger.Error("Failed to add dependency", zap.Error(err), zap.Int("taskIndex", i), zap.String("dependsOn", dep))
+				failures = append(failures, bulkCreateFailure{
+					index: i,
+					title: entry.input.Title,
+					err:   fmt.Errorf("dependency '%s': %w", dep, err),
+				})
 			}
-			fmt.Printf("   Complexity: %d | State: %s\n", task.Complexity, task.State)
 		}
-		fmt.Printf("  Created by: %s\n", actor)
+	}
+	return linked, failures
+}
 
-		return nil
+// resolveBulkDependency maps a depends_on value to a task ID. Refs resolve to the
+// task created for the referenced entry, anything else must be a task ID.
+func resolveBulkDependency(dep string, refs map[string]int, taskIDs []uuid.UUID) (uuid.UUID, error) {
+	if index, ok := refs[dep]; ok {
+		if taskIDs[index] == uuid.Nil {
+			return uuid.Nil, fmt.Errorf("entry %d was not created", index+1)
+		}
+		return taskIDs[index], nil
 	}
+	return uuid.Parse(dep)
 }
 
 // BulkDeleteAction deletes multiple tasks with safety checks
@@ -279,84 +434,82 @@ func BulkDeleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 		}
 
 		// Parse comma-separated task IDs
-		taskIDStrings := strings.Split(taskIDsStr, ",")
-		var taskIDs []uuid.UUID
-		for _, idStr := range taskIDStrings {
-			idStr = strings.TrimSpace(idStr)
-			taskID, err := uuid.Parse(idStr)
-			if err != nil {
-				return fmt.Errorf("invalid task ID '%s': %w", idStr, err)
-			}
-			taskIDs = append(taskIDs, taskID)
-		}
-
-		if len(taskIDs) == 0 {
-			return fmt.Errorf("no valid task IDs provided")
+		taskIDs, err := parseTaskIDList(taskIDsStr)
+		if err != nil {
+			return err
 		}
 
-		dryRun := c.Bool("dry-run")
-		force := c.Bool("force")
+		return bulkDelete(c, appCtx, taskIDs)
+	}
+}
 
-		appCtx.Logger.Info("Bulk deleting tasks",
-			zap.Int("taskCount", len(taskIDs)),
-			zap.Bool("dryRun", dryRun),
-			zap.Bool("force", force))
+// bulkDelete deletes the given tasks after showing them and asking for
+// confirmation, unless --force or --dry-run is set
+func bulkDelete(c *cli.Context, appCtx *shared.AppContext, taskIDs []uuid.UUID) error {
+	dryRun := c.Bool("dry-run")
+	force := c.Bool("force")
+
+	appCtx.Logger.Info("Bulk deleting tasks",
+		zap.Int("taskCount", len(taskIDs)),
+		zap.Bool("dryRun", dryRun),
+		zap.Bool("force", force))
+
+	// Get task details for confirmation using optimized batch loading
+	tasksToDelete, err := appCtx.ProjectManager.GetTasksWithDependencies(c.Context, taskIDs)
+	if err != nil {
+		appCtx.Logger.Error("Failed to get tasks", zap.Error(err), zap.Strings("taskIDs", utils.ConvertUUIDsToStrings(taskIDs)))
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
 
-		// Get task details for confirmation using optimized batch loading
-		tasksToDelete, err := appCtx.ProjectManager.GetTasksWithDependencies(context.Background(), taskIDs)
-		if err != nil {
-			appCtx.Logger.Error("Failed to get tasks", zap.Error(err), zap.Strings("taskIDs", utils.ConvertUUIDsToStrings(taskIDs)))
-			return fmt.Errorf("failed to get tasks: %w", err)
+	// Show what will be deleted
+	appCtx.Out().Printf("Tasks to be deleted (%d):\n", len(tasksToDelete))
+	for i, task := range tasksToDelete {
+		appCtx.Out().Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
+		if task.Description != "" {
+			appCtx.Out().Printf("   %s\n", task.Description)
 		}
+		appCtx.Out().Printf("   State: %s | Complexity: %d\n", task.State, task.Complexity)
+	}
 
-		// Show what will be deleted
-		fmt.Printf("Tasks to be deleted (%d):\n", len(tasksToDelete))
-		for i, task := range tasksToDelete {
-			fmt.Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
-			if task.Description != "" {
-				fmt.Printf("   %s\n", task.Description)
-			}
-			fmt.Printf("   State: %s | Complexity: %d\n", task.State, task.Complexity)
-		}
+	if dryRun {
+		appCtx.Out().Println("\nDry run mode - no tasks were actually deleted.")
+		return nil
+	}
 
-		if dryRun {
-			fmt.Println("\nDry run mode - no tasks were actually deleted.")
+	// Confirmation prompt (unless force flag is used)
+	if !force {
+		appCtx.Out().Printf("\nAre you sure you want to delete these %d tasks? (y/N): ", len(tasksToDelete))
+		var response string
+		_, _ = fmt.Scanln(&response)
+		if response != "y" && response != "Y" && response != "yes" && response != "YES" {
+			appCtx.Out().Println("Deletion cancelled.")
 			return nil
 		}
+		// The time spent answering does not count against --timeout
+		shared.RestartTimeout(c)
+	}
 
-		// Confirmation prompt (unless force flag is used)
-		if !force {
-			fmt.Printf("\nAre you sure you want to delete these %d tasks? (y/N): ", len(tasksToDelete))
-			var response string
-			_, _ = fmt.Scanln(&response)
-			if response != "y" && response != "Y" && response != "yes" && response != "YES" {
-				fmt.Println("Deletion cancelled.")
-				return nil
-			}
-		}
-
-		// Get actor for deletion
-		actor := shared.GetActorFromContext(c)
-
-		// Delete tasks
-		var deletedCount int
-		for _, taskID := range taskIDs {
-			err := appCtx.ProjectManager.DeleteTask(context.Background(), taskID, actor)
-			if err != nil {
-				appCtx.Logger.Error("Failed to delete task", zap.Error(err), zap.String("taskID", taskID.String()))
-				fmt.Printf("Failed to delete task %s: %v\n", taskID, err)
-				continue
-			}
-			deletedCount++
-		}
+	// Get actor for deletion
+	actor := shared.GetActorFromContext(c)
 
-		fmt.Printf("Successfully deleted %d out of %d tasks\n", deletedCount, len(taskIDs))
-		if deletedCount < len(taskIDs) {
-			fmt.Printf("Warning: %d tasks could not be deleted (see errors above)\n", len(taskIDs)-deletedCount)
+	// Delete tasks
+	var deletedCount int
+	for _, taskID := range taskIDs {
+		err := appCtx.ProjectManager.DeleteTask(c.Context, taskID, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to delete task", zap.Error(err), zap.String("taskID", taskID.String()))
+			appCtx.Out().Printf("Failed to delete task %s: %v\n", taskID, err)
+			continue
 		}
+		deletedCount++
+	}
 
-		return nil
+	appCtx.Out().Printf("Successfully deleted %d out of %d tasks\n", deletedCount, len(taskIDs))
+	if deletedCount < len(taskIDs) {
+		appCtx.Out().Printf("Warning: %d tasks could not be deleted (see errors above)\n", len(taskIDs)-deletedCount)
 	}
+
+	return nil
 }
 
 // BulkCommands returns bulk operation CLI commands
@@ -380,19 +533,54 @@ func BulkCommands(appCtx *shared.AppContext) []*cli.Command {
 					Name:  "complexity",
 					Usage: "New complexity (1-10)",
 				},
+				&cli.BoolFlag{
+					Name:  "force-transition",
+					Usage: "Allow state changes the state machine forbids, e.g. reopening completed tasks",
+				},
 			},
 		},
 		{
-			Name:   "bulk-create",
-			Usage:  "Create multiple tasks from JSON file",
+			Name:  "bulk-create",
+			Usage: "Create multiple tasks from a YAML or JSON file",
+			Description: `Creates the tasks in file order and prints progress for each entry.
+
+Entries may nest subtasks in a "children" list and name each other with a
+"ref". A "depends_on" list takes refs or existing task IDs; dependencies are
+linked once all tasks exist:
+
+  - ref: api
+    title: Build API
+    children:
+      - title: Design endpoints
+      - ref: impl
+        title: Implement handlers
+  - title: Write docs
+    depends_on: [impl]
+
+Created
+entries are recorded in a resume file (<file>.resume by default) so that
+re-running the command after a failure skips them instead of creating
+duplicates. The resume file is removed once all entries have been created.`,
 			Action: BulkCreateAction(appCtx),
 			Flags: []cli.Flag{
 				&cli.StringFlag{
 					Name:     "file",
 					Aliases:  []string{"f"},
-					Usage:    "JSON file containing task definitions",
+					Usage:    "YAML or JSON file containing task definitions",
 					Required: true,
 				},
+				&cli.BoolFlag{
+					Name:  "continue-on-error",
+					Usage: "Keep creating the remaining tasks when an entry fails",
+				},
+				&cli.StringFlag{
+					Name:  "resume-file",
+					Usage: "File recording already created entries (default: <file>.resume)",
+				},
+				&cli.DurationFlag{
+					Name:  "delay",
+					Usage: "Pause between task creations to limit the write rate (e.g. 100ms)",
+				},
 			},
 		},
 		{
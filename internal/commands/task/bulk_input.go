@@ -0,0 +1,99 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// bulkTaskInput is one entry of a bulk-create file. Entries may nest their
+// subtasks in Children and name each other through Ref, so a whole hierarchy
+// including its dependencies can be described in one file.
+type bulkTaskInput struct {
+	Ref         string          `json:"ref,omitempty" yaml:"ref,omitempty"`
+	Title       string          `json:"title" yaml:"title"`
+	Description string          `json:"description" yaml:"description"`
+	Complexity  int             `json:"complexity" yaml:"complexity"`
+	ParentID    *string         `json:"parent_id,omitempty" yaml:"parent_id,omitempty"`
+	DependsOn   []string        `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	Children    []bulkTaskInput `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// bulkEntry is a flattened bulk-create entry. Parent is the index of the entry
+// it is nested in, or -1 for top-level entries.
+type bulkEntry struct {
+	input  bulkTaskInput
+	parent int
+}
+
+// parseBulkInput parses bulk-create data. YAML is expected, but since YAML is a
+// superset of JSON, the flat JSON format is accepted as well.
+func parseBulkInput(data []byte) ([]bulkEntry, error) {
+	var inputs []bulkTaskInput
+	if err := yaml.Unmarshal(data, &inputs); err != nil {
+		return nil, fmt.Errorf("failed to parse input: %w", err)
+	}
+
+	var entries []bulkEntry
+	flattenBulkInputs(inputs, -1, &entries)
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no tasks found in input file")
+	}
+
+	if err := validateBulkRefs(entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// flattenBulkInputs appends inputs and their children in depth-first order,
+// so parents are always created before their subtasks
+func flattenBulkInputs(inputs []bulkTaskInput, parent int, entries *[]bulkEntry) {
+	for _, input := range inputs {
+		children := input.Children
+		input.Children = nil
+
+		*entries = append(*entries, bulkEntry{input: input, parent: parent})
+		flattenBulkInputs(children, len(*entries)-1, entries)
+	}
+}
+
+// validateBulkRefs checks that refs are unique and that every dependency names
+// a ref from the file or an existing task ID
+func validateBulkRefs(entries []bulkEntry) error {
+	refs := make(map[string]bool)
+	for i, entry := range entries {
+		ref := entry.input.Ref
+		if ref == "" {
+			continue
+		}
+		if _, err := uuid.Parse(ref); err == nil {
+			return fmt.Errorf("entry %d: ref '%s' must not be a UUID", i+1, ref)
+		}
+		if refs[ref] {
+			return fmt.Errorf("entry %d: duplicate ref '%s'", i+1, ref)
+		}
+		refs[ref] = true
+	}
+
+	for i, entry := range entries {
+		if entry.parent >= 0 && entry.input.ParentID != nil && *entry.input.ParentID != "" {
+			return fmt.Errorf("entry %d: nested entries cannot set parent_id", i+1)
+		}
+		for _, dep := range entry.input.DependsOn {
+			dep = strings.TrimSpace(dep)
+			if dep == entry.input.Ref && dep != "" {
+				return fmt.Errorf("entry %d: task cannot depend on itself", i+1)
+			}
+			if refs[dep] {
+				continue
+			}
+			if _, err := uuid.Parse(dep); err != nil {
+				return fmt.Errorf("entry %d: unknown dependency '%s' (expected a ref from the file or a task ID)", i+1, dep)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+)
+
+// bulkResumeState records which entries of a bulk-create file were already
+// created, so re-running after a failure does not create them twice
+type bulkResumeState struct {
+	path    string
+	Created []bulkResumeEntry `json:"created"`
+}
+
+// bulkResumeEntry is one created entry, identified by its position in the input file
+type bulkResumeEntry struct {
+	Index  int       `json:"index"`
+	Title  string    `json:"title"`
+	TaskID uuid.UUID `json:"task_id"`
+}
+
+// loadBulkResumeState reads the resume file at path, or returns an empty state if it does not exist
+func loadBulkResumeState(path string) (*bulkResumeState, error) {
+	state := &bulkResumeState{path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resume file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse resume file %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// lookup returns the task created for the entry at index. It fails if the entry
+// was recorded with a different title, i.e. the input file changed since the last run.
+func (s *bulkResumeState) lookup(index int, title string) (uuid.UUID, bool, error) {
+	for _, entry := range s.Created {
+		if entry.Index != index {
+			continue
+		}
+		if entry.Title != title {
+			return uuid.Nil, false, fmt.Errorf("resume file %s does not match the input: entry %d was '%s', now '%s' (delete the resume file to start over)",
+				s.path, index+1, entry.Title, title)
+		}
+		return entry.TaskID, true, nil
+	}
+	return uuid.Nil, false, nil
+}
+
+// record adds a created entry and saves the resume file immediately
+func (s *bulkResumeState) record(index int, title string, taskID uuid.UUID) error {
+	s.Created = append(s.Created, bulkResumeEntry{Index: index, Title: title, TaskID: taskID})
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal resume file: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write resume file: %w", err)
+	}
+	return nil
+}
+
+// remove deletes the resume file once all entries were created
+func (s *bulkResumeState) remove() error {
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove resume file: %w", err)
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package task
 
 import (
 	"flag"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/denkhaus/knot/v2/internal/shared"
@@ -181,4 +183,178 @@ func TestDuplicateAction(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "task-id is required")
 	})
-}
\ No newline at end of file
+}
+func TestBulkCreateActionResume(t *testing.T) {
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+
+	err := mgr.SetSelectedProject(nil, project.ID, "test-user")
+	require.NoError(t, err)
+
+	appCtx := &shared.AppContext{
+		ProjectManager: mgr,
+		Logger:         config.Logger,
+	}
+
+	inputFile := filepath.Join(t.TempDir(), "tasks.json")
+	runBulkCreate := func(t *testing.T, input string, continueOnError bool) error {
+		require.NoError(t, os.WriteFile(inputFile, []byte(input), 0o644))
+
+		app := &cli.App{}
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		flagSet.String("file", "", "")
+		flagSet.String("resume-file", "", "")
+		flagSet.Bool("continue-on-error", false, "")
+		flagSet.Duration("delay", 0, "")
+		flagSet.String("actor", "", "")
+		_ = flagSet.Set("file", inputFile)
+		_ = flagSet.Set("actor", "bulk-user")
+		if continueOnError {
+			_ = flagSet.Set("continue-on-error", "true")
+		}
+
+		return BulkCreateAction(appCtx)(cli.NewContext(app, flagSet, nil))
+	}
+
+	// The second entry fails, the third is still created with --continue-on-error
+	err = runBulkCreate(t, `[
+		{"title": "First"},
+		{"title": "Second", "parent_id": "not-a-uuid"},
+		{"title": "Third", "complexity": 3}
+	]`, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 3")
+	assert.FileExists(t, inputFile+".resume")
+
+	tasks, err := mgr.ListTasksForProject(nil, project.ID)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2)
+
+	// Re-running with the fixed input only creates the missing entry
+	err = runBulkCreate(t, `[
+		{"title": "First"},
+		{"title": "Second"},
+		{"title": "Third", "complexity": 3}
+	]`, false)
+	require.NoError(t, err)
+	assert.NoFileExists(t, inputFile+".resume")
+
+	tasks, err = mgr.ListTasksForProject(nil, project.ID)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 3)
+}
+
+func TestBulkCreateActionStopsOnError(t *testing.T) {
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+
+	err := mgr.SetSelectedProject(nil, project.ID, "test-user")
+	require.NoError(t, err)
+
+	inputFile := filepath.Join(t.TempDir(), "tasks.json")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`[{"title": ""}, {"title": "Never created"}]`), 0o644))
+
+	app := &cli.App{}
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.String("file", "", "")
+	flagSet.String("resume-file", "", "")
+	flagSet.Bool("continue-on-error", false, "")
+	flagSet.Duration("delay", 0, "")
+	_ = flagSet.Set("file", inputFile)
+
+	appCtx := &shared.AppContext{
+		ProjectManager: mgr,
+		Logger:         config.Logger,
+	}
+
+	err = BulkCreateAction(appCtx)(cli.NewContext(app, flagSet, nil))
+	require.Error(t, err)
+
+	tasks, err := mgr.ListTasksForProject(nil, project.ID)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestBulkCreateActionYAMLHierarchy(t *testing.T) {
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+
+	err := mgr.SetSelectedProject(nil, project.ID, "test-user")
+	require.NoError(t, err)
+
+	inputFile := filepath.Join(t.TempDir(), "tasks.yaml")
+	require.NoError(t, os.WriteFile(inputFile, []byte(`
+- ref: api
+  title: Build API
+  complexity: 6
+  children:
+    - ref: design
+      title: Design endpoints
+    - ref: impl
+      title: Implement handlers
+      depends_on: [design]
+- title: Write docs
+  depends_on: [impl]
+`), 0o644))
+
+	app := &cli.App{}
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.String("file", "", "")
+	flagSet.String("resume-file", "", "")
+	flagSet.Bool("continue-on-error", false, "")
+	flagSet.Duration("delay", 0, "")
+	_ = flagSet.Set("file", inputFile)
+
+	appCtx := &shared.AppContext{
+		ProjectManager: mgr,
+		Logger:         config.Logger,
+	}
+
+	err = BulkCreateAction(appCtx)(cli.NewContext(app, flagSet, nil))
+	require.NoError(t, err)
+
+	tasks, err := mgr.ListTasksForProject(nil, project.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 4)
+
+	byTitle := make(map[string]*types.Task)
+	for _, task := range tasks {
+		byTitle[task.Title] = task
+	}
+
+	api := byTitle["Build API"]
+	require.NotNil(t, api)
+	assert.Nil(t, api.ParentID)
+	require.NotNil(t, byTitle["Design endpoints"].ParentID)
+	assert.Equal(t, api.ID, *byTitle["Design endpoints"].ParentID)
+	require.NotNil(t, byTitle["Implement handlers"].ParentID)
+	assert.Equal(t, api.ID, *byTitle["Implement handlers"].ParentID)
+
+	deps, err := mgr.GetTaskDependencies(nil, byTitle["Implement handlers"].ID)
+	require.NoError(t, err)
+	require.Len(t, deps, 1)
+	assert.Equal(t, byTitle["Design endpoints"].ID, deps[0].ID)
+
+	deps, err = mgr.GetTaskDependencies(nil, byTitle["Write docs"].ID)
+	require.NoError(t, err)
+	require.Len(t, deps, 1)
+	assert.Equal(t, byTitle["Implement handlers"].ID, deps[0].ID)
+}
+
+func TestParseBulkInputValidatesRefs(t *testing.T) {
+	_, err := parseBulkInput([]byte(`[{"title": "A", "ref": "a"}, {"title": "B", "ref": "a"}]`))
+	assert.ErrorContains(t, err, "duplicate ref")
+
+	_, err = parseBulkInput([]byte(`[{"title": "A", "depends_on": ["missing"]}]`))
+	assert.ErrorContains(t, err, "unknown dependency")
+
+	entries, err := parseBulkInput([]byte(`[{"title": "A", "children": [{"title": "B"}]}, {"title": "C"}]`))
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, -1, entries[0].parent)
+	assert.Equal(t, 0, entries[1].parent)
+	assert.Equal(t, -1, entries[2].parent)
+}
@@ -0,0 +1,191 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/verify"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// NewCheckCommands creates the set-check and verify commands
+func NewCheckCommands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "set-check",
+			Usage: "Set the command that verifies a task, such as its acceptance tests",
+			Description: `Associates a shell command with a task that 'knot task verify' runs to check
+the task is done, e.g.
+
+  knot task set-check --id <task-id> --cmd "go test ./pkg/parser" --required
+
+With --required the task can only be completed once the latest run of the
+command passed. Changing the command discards the runs of the previous one.`,
+			Action: setCheckAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewTaskIDFlag(),
+				&cli.StringFlag{
+					Name:  "cmd",
+					Usage: "Shell command that exits with 0 when the task is done",
+				},
+				&cli.BoolFlag{
+					Name:  "required",
+					Usage: "Block completion until the latest run passed",
+				},
+				&cli.BoolFlag{
+					Name:  "clear",
+					Usage: "Remove the check and its runs",
+				},
+			},
+		},
+		{
+			Name:  "verify",
+			Usage: "Run the check command of a task and record the result",
+			Description: `Runs the command set with 'knot task set-check' in the current directory
+and records whether it passed, with its exit code, duration and the end of its
+output, in the task's check history. The command exits non-zero if the check
+fails.
+
+The check runs for at most --check-timeout; the global --timeout only bounds
+reading and updating the task.`,
+			Action: verifyAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewTaskIDFlag(),
+				&cli.DurationFlag{
+					Name:  "check-timeout",
+					Usage: "Maximum duration of the check command",
+					Value: verify.DefaultTimeout,
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+	}
+}
+
+func setCheckAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskID, err := parseTaskIDFlag(c)
+		if err != nil {
+			return err
+		}
+		command := c.String("cmd")
+		if c.Bool("clear") == (command != "") {
+			return &errors.EnhancedError{
+				Operation:  "setting task check",
+				Cause:      fmt.Errorf("exactly one of --cmd and --clear is required"),
+				Suggestion: "Pass the command that verifies the task, or --clear to remove the check",
+				Example:    "knot task set-check --id <task-id> --cmd \"go test ./pkg/parser\" --required",
+			}
+		}
+		actor := shared.ResolveActor(c.String("actor"))
+
+		appCtx.Logger.Info("Setting task check",
+			zap.String("taskID", taskID.String()),
+			zap.String("command", command),
+			zap.String("actor", actor))
+
+		task, err := appCtx.ProjectManager.SetTaskCheck(c.Context, taskID, command, c.Bool("required"), actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to set task check", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "setting task check")
+		}
+
+		if printed, err := appCtx.Out().Result(task.ID, task); printed || err != nil {
+			return err
+		}
+		if task.Check == nil {
+			appCtx.Out().Printf("Removed the check of task \"%s\"\n", task.Title)
+			return nil
+		}
+		appCtx.Out().Printf("Check of task \"%s\" set: %s\n", task.Title, task.Check.Command)
+		if task.Check.Required {
+			appCtx.Out().Println("  Required: the task can only be completed once the check passed")
+		}
+		appCtx.Out().Advise("  Run it with: knot task verify --id %s\n", task.ID)
+		return nil
+	}
+}
+
+func verifyAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskID, err := parseTaskIDFlag(c)
+		if err != nil {
+			return err
+		}
+		actor := shared.ResolveActor(c.String("actor"))
+
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to get task", zap.Error(err))
+			return errors.TaskNotFoundError(taskID)
+		}
+		if task.Check == nil {
+			return &errors.EnhancedError{
+				Operation:  "verifying task",
+				Cause:      fmt.Errorf("task '%s' has no check", task.Title),
+				Suggestion: "Set the command that verifies the task first",
+				Example:    fmt.Sprintf("knot task set-check --id %s --cmd \"go test ./...\"", task.ID),
+			}
+		}
+
+		appCtx.Logger.Info("Running task check",
+			zap.String("taskID", taskID.String()),
+			zap.String("command", task.Check.Command))
+
+		// The check has its own timeout; output is streamed unless JSON is requested
+		var stream io.Writer
+		if !c.Bool("json") {
+			appCtx.Out().Printf("Running check of task \"%s\": %s\n\n", task.Title, task.Check.Command)
+			stream = appCtx.Out()
+		}
+		startedAt := appCtx.ProjectManager.GetCurrentTime()
+		run := verify.Run(context.WithoutCancel(c.Context), task.Check.Command, "", c.Duration("check-timeout"), stream)
+		run.RunBy = actor
+		run.RunAt = startedAt
+
+		ctx := context.WithoutCancel(c.Context)
+		if timeout := c.Duration("timeout"); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		task, err = appCtx.ProjectManager.RecordTaskCheckRun(ctx, taskID, run)
+		if err != nil {
+			appCtx.Logger.Error("Failed to record check run", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "recording check run")
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(struct {
+				TaskID uuid.UUID `json:"task_id"`
+				types.CheckRun
+			}{task.ID, run}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal check run to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+		} else {
+			duration := time.Duration(run.DurationMs) * time.Millisecond
+			appCtx.Out().Printf("\nCheck %s after %s, recorded for task \"%s\"\n", output.CheckRun(&run), duration, task.Title)
+		}
+
+		if !run.Passed {
+			return &errors.EnhancedError{
+				Operation:  "verifying task",
+				Cause:      &verify.FailedError{Run: run},
+				Suggestion: "Fix the task and run the check again",
+				Example:    fmt.Sprintf("knot task verify --id %s", task.ID),
+			}
+		}
+		return nil
+	}
+}
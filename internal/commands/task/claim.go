@@ -0,0 +1,177 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/selection"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// NewClaimCommand creates the command that lets a registered agent claim its next task
+func NewClaimCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "claim",
+		Usage: "Select the next task suited to a registered agent, assign it and start it",
+		Description: `Selects the next actionable task with the preferences of an agent from the
+agent registry (see 'knot agent register'): its strategy and weights, the
+tags it requires and the tags it prefers. Tasks assigned to other agents are
+skipped. The task is assigned to the agent and moved to in-progress; the
+agent's name is recorded as actor unless --actor is given. The latest handoff
+note left on the task is shown, so the agent picks up where others left off.
+
+Examples:
+  knot task claim --agent backend-bot
+  knot task claim --agent backend-bot --dry-run --json`,
+		Action: claimAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "agent",
+				Aliases:  []string{"a"},
+				Usage:    "Name or ID of a registered agent",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:    "strategy",
+				Aliases: []string{"s"},
+				Usage:   "Selection strategy, overriding the agent's strategy",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the task that would be claimed without claiming it",
+			},
+			shared.NewJSONFlag(),
+		},
+	}
+}
+
+func claimAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		config := appCtx.ProjectManager.GetConfig()
+		profile, ok := config.Agent(c.String("agent"))
+		if !ok {
+			return &errors.EnhancedError{
+				Operation:   "claim task",
+				Cause:       fmt.Errorf("no agent '%s' is registered", c.String("agent")),
+				Suggestion:  "Register the agent first, or check the registered agents",
+				Example:     fmt.Sprintf("knot agent register --name %s --require-tag backend", c.String("agent")),
+				HelpCommand: "knot agent list",
+			}
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksWithDependencies(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to get project tasks", zap.Error(err))
+			return fmt.Errorf("failed to get project tasks: %w", err)
+		}
+
+		// The agent's strategy wins over the configured or recommended one
+		strategy := selection.StrategyDependencyAware
+		if configured := config.SelectionStrategy; configured != "" {
+			strategy = selection.ParseStrategy(configured)
+		} else if recommended, _, err := selection.AnalyzeProjectAndRecommendStrategy(tasks); err == nil {
+			strategy = recommended
+		}
+		selectionConfig := profile.SelectionConfig(strategy)
+		if profile.Weights == nil {
+			selectionConfig.Weights = config.StrategyWeights(selectionConfig.Strategy)
+		}
+		if c.IsSet("strategy") {
+			override, ok := selection.LookupStrategy(c.String("strategy"))
+			if !ok {
+				return errors.NewValidationError("invalid strategy",
+					fmt.Errorf("unknown selection strategy '%s'", c.String("strategy")))
+			}
+			selectionConfig.Strategy = override
+			selectionConfig.Weights = config.StrategyWeights(override)
+		}
+		selectionConfig.Behavior.PriorityInheritance = config.PriorityInheritance
+
+		selector, err := selection.NewTaskSelector(selectionConfig.Strategy, selectionConfig)
+		if err != nil {
+			appCtx.Logger.Error("Failed to create task selector", zap.Error(err))
+			return errors.NewValidationError("invalid selection preferences of agent "+profile.Name, err)
+		}
+
+		selected, err := selector.SelectNextActionableTask(tasks)
+		if err != nil {
+			if selErr, ok := err.(*selection.SelectionError); ok && selErr.Type != selection.ErrorTypeCircularDep {
+				appCtx.Out().Printf("No task to claim for agent %s: %s\n", profile.Name, selErr.Message)
+				return nil
+			}
+			return fmt.Errorf("failed to select a task to claim: %w", err)
+		}
+		result := selector.GetLastResult()
+		// The agent's own work in progress is selected first and needs no claim
+		continues := selected.State == types.TaskStateInProgress &&
+			selected.AssignedAgent != nil && *selected.AssignedAgent == profile.ID
+
+		if !c.Bool("dry-run") && !continues {
+			actor := c.String("actor")
+			if actor == "" {
+				actor = profile.Name
+			}
+			if selected.AssignedAgent == nil || *selected.AssignedAgent != profile.ID {
+				if selected, err = appCtx.ProjectManager.AssignTaskToAgent(c.Context, selected.ID, profile.ID); err != nil {
+					appCtx.Logger.Error("Failed to assign task", zap.Error(err))
+					return errors.WrapWithSuggestion(err, "assigning task")
+				}
+			}
+			if selected.State == types.TaskStatePending {
+				if selected, err = appCtx.ProjectManager.UpdateTaskState(c.Context, selected.ID, types.TaskStateInProgress, actor); err != nil {
+					appCtx.Logger.Error("Failed to start task", zap.Error(err))
+					return errors.WrapWithSuggestion(err, "starting task")
+				}
+			}
+			appCtx.Logger.Info("Claimed task",
+				zap.String("agent", profile.Name),
+				zap.String("taskID", selected.ID.String()))
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(map[string]interface{}{
+				"agent":    profile.Name,
+				"agent_id": profile.ID,
+				"task":     selected,
+				"strategy": selectionConfig.Strategy.String(),
+				"reason":   result.Reason,
+				"score":    result.Score.Score,
+				"claimed":  !c.Bool("dry-run") && !continues,
+				"handoff":  selected.LatestHandoff(),
+			}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal result to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		w := appCtx.Out()
+		if continues {
+			fmt.Fprintf(w, "Agent %s continues its task in progress (strategy: %s):\n", profile.Name, selectionConfig.Strategy)
+		} else if c.Bool("dry-run") {
+			fmt.Fprintf(w, "Agent %s would claim (strategy: %s):\n", profile.Name, selectionConfig.Strategy)
+		} else {
+			fmt.Fprintf(w, "Agent %s claimed (strategy: %s):\n", profile.Name, selectionConfig.Strategy)
+		}
+		fmt.Fprintf(w, "* %s (ID: %s)\n", selected.Title, selected.ID)
+		fmt.Fprintf(w, "  State: %s | Complexity: %d | Priority: %d%s\n",
+			selected.State, selected.Complexity, selected.Priority, utils.EstimateSuffix(selected))
+		fmt.Fprintf(w, "Selection reason: %s\n", result.Reason)
+		if handoff := selected.LatestHandoff(); handoff != nil {
+			fmt.Fprintln(w)
+			printHandoff(w, config, handoff, "")
+		}
+		return nil
+	}
+}
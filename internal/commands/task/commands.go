@@ -1,11 +1,17 @@
 package task
 
 import (
-	"context"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/placeholder"
+	"github.com/denkhaus/knot/v2/internal/selection"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/utils"
 
@@ -37,12 +43,17 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 				&cli.StringFlag{
 					Name:    "description",
 					Aliases: []string{"d"},
-					Usage:   "Task description",
+					Usage:   "Task description, {{project.title}}, {{parent.title}}, {{date}}, {{actor}} and --var placeholders are expanded",
 				},
 				&cli.StringFlag{
 					Name:  "parent-id",
 					Usage: "Parent task ID (for subtasks)",
 				},
+				&cli.StringSliceFlag{
+					Name:    "var",
+					Aliases: []string{"v"},
+					Usage:   "Custom placeholder for the description in format key=value (can specify multiple)",
+				},
 				&cli.IntFlag{
 					Name:    "complexity",
 					Aliases: []string{"c"},
@@ -56,6 +67,35 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 					Usage:   "Task priority (low, medium, high)",
 					Value:   "medium",
 				},
+				&cli.StringSliceFlag{
+					Name:  "after",
+					Usage: "Title, short ID or ID of a task the new task depends on (can specify multiple)",
+				},
+				&cli.BoolFlag{
+					Name:  "no-dup-check",
+					Usage: "Create the task even if a task with a similar title exists in the project",
+				},
+				shared.NewQuietIDFlag(),
+				shared.NewCopyFlag("Copy the ID of the created task to the clipboard"),
+			},
+		},
+		{
+			Name:  "id",
+			Usage: "Print the ID of the task with the given title",
+			Description: `Prints only the task's UUID, for use in command substitution:
+
+  TASK=$(knot task id --title "Write docs")
+
+Titles are matched exactly first, then case-insensitively. The command fails
+if no task or more than one task matches.`,
+			Action: idAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "title",
+					Aliases:  []string{"t"},
+					Usage:    "Task title",
+					Required: true,
+				},
 			},
 		},
 		{
@@ -68,6 +108,28 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 				shared.NewTaskIDFlag(),
 			},
 		},
+		NewGetManyCommand(appCtx),
+		NewClaimCommand(appCtx),
+		NewAssignCommand(appCtx),
+		NewScheduleStateCommand(appCtx),
+		NewScheduledCommand(appCtx),
+		NewUnscheduleCommand(appCtx),
+		{
+			Name:  "prompt",
+			Usage: "Print a ready-to-paste agent prompt for a task",
+			Description: `Assembles the context a coding agent needs for a task: the task itself,
+the project, the parent chain, dependencies and the tasks it unblocks,
+acceptance criteria and workflow guidelines.
+
+Acceptance criteria are the task's criteria (see 'knot task criteria'),
+Markdown checklist items ("- [ ] ...") and bullets below an "Acceptance
+criteria" line in the description, followed by the subtasks.`,
+			Action: PromptAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewJSONFlag(),
+				shared.NewTaskIDFlag(),
+			},
+		},
 		{
 			Name:   "list",
 			Usage:  "List tasks with advanced filtering options",
@@ -109,7 +171,15 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 				&cli.IntFlag{
 					Name:    "limit",
 					Aliases: []string{"l"},
-					Usage:   "Maximum number of tasks to show",
+					Usage:   "Maximum number of tasks to show, or the page size with --paginate (default 100)",
+				},
+				&cli.BoolFlag{
+					Name:  "paginate",
+					Usage: "Return the first page of tasks in creation order as {tasks, next_cursor} (requires --json)",
+				},
+				&cli.StringFlag{
+					Name:  "cursor",
+					Usage: "Return the page after the next_cursor of the previous page (implies --paginate)",
 				},
 				&cli.StringFlag{
 					Name:  "sort",
@@ -120,6 +190,18 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 					Name:  "reverse",
 					Usage: "Reverse sort order",
 				},
+				&cli.StringFlag{
+					Name:  "group-by",
+					Usage: "Group tasks into lanes with counts and rollups (state, priority, agent, tag)",
+				},
+				&cli.BoolFlag{
+					Name:  "compact",
+					Usage: "Print one dense line per task (shortid|state|prio|cx|title) to keep large listings small",
+				},
+				&cli.StringFlag{
+					Name:  "fields",
+					Usage: "Comma-separated columns for compact or JSON output (" + strings.Join(listFieldNames(), ", ") + ")",
+				},
 			},
 		},
 		{
@@ -138,6 +220,23 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 					Usage:    "New state (pending, in-progress, completed, blocked, cancelled)",
 					Required: true,
 				},
+				&cli.StringFlag{
+					Name:  "reason",
+					Usage: "External reason the task is blocked, e.g. \"waiting for vendor\" (only with --state blocked)",
+				},
+				&cli.StringFlag{
+					Name:    "ref",
+					Aliases: []string{"reference"},
+					Usage:   "Reference of the external blocker, such as a ticket or URL (requires --reason)",
+				},
+				&cli.BoolFlag{
+					Name:  "force-transition",
+					Usage: "Allow a state change the state machine forbids, e.g. reopening a completed task",
+				},
+				&cli.StringFlag{
+					Name:  "handoff",
+					Usage: "Note for the next assignee when forcing an in-progress task back to pending: what's done, what's left",
+				},
 			},
 		},
 		{
@@ -176,6 +275,22 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 				},
 			},
 		},
+		{
+			Name:  "update-instructions",
+			Usage: "Set the agent instructions of a task and its subtasks",
+			Description: `Instructions are standing guidance for agents, separate from the
+description, e.g. "use the repository pattern". They apply to the task and all
+its subtasks: 'knot task prompt' and 'knot agent context' list the instructions
+of the project, the parent chain and the task, from general to specific.`,
+			Action: updateInstructionsAction(appCtx),
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:     "id",
+					Usage:    "Task ID",
+					Required: true,
+				},
+			}, shared.NewInstructionsFlags()...),
+		},
 		{
 			Name:   "update-priority",
 			Usage:  "Update task priority",
@@ -194,7 +309,146 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 				},
 			},
 		},
+		{
+			Name:   "estimate",
+			Usage:  "Set task time estimate",
+			Action: estimateAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "id",
+					Usage:    "Task ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "duration",
+					Aliases:  []string{"d"},
+					Usage:    "Estimated effort (e.g. 45m, 2h30m, 3d, 1w; a day is 8h, a week 5d)",
+					Required: true,
+				},
+			},
+		},
+		{
+			Name:   "log-effort",
+			Usage:  "Record actual effort spent on a task",
+			Action: logEffortAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewTaskIDFlag(),
+				&cli.StringFlag{
+					Name:     "duration",
+					Aliases:  []string{"d"},
+					Usage:    "Effort spent (e.g. 45m, 1h30m, 2d; a day is 8h, a week 5d)",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    "note",
+					Aliases: []string{"n"},
+					Usage:   "What the effort was spent on",
+				},
+			},
+		},
+		{
+			Name:   "due",
+			Usage:  "Set or clear the due date of a task",
+			Action: dueAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "id",
+					Usage:    "Task ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    "date",
+					Aliases: []string{"d"},
+					Usage:   "Due date (YYYY-MM-DD)",
+				},
+				&cli.BoolFlag{
+					Name:  "clear",
+					Usage: "Remove the due date",
+				},
+			},
+		},
+		{
+			Name:  "set-completed-at",
+			Usage: "Correct the completion time of a completed task",
+			Description: `Sets when a completed task was completed. Burndown, velocity and cycle
+time reports rely on completion times; use this command to correct them, e.g.
+for tasks completed outside of knot. 'knot validate project --fix' backfills
+missing completion times from the change history.`,
+			Action: setCompletedAtAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "id",
+					Usage:    "Task ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "time",
+					Aliases:  []string{"t"},
+					Usage:    "Completion time (RFC 3339, \"YYYY-MM-DD HH:MM\" or YYYY-MM-DD, local time unless a zone is given)",
+					Required: true,
+				},
+			},
+		},
+		{
+			Name:   "keep-complexity",
+			Usage:  "Exclude a task from automatic complexity reduction when subtasks are added",
+			Action: keepComplexityAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "id",
+					Usage:    "Task ID",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  "off",
+					Usage: "Allow automatic complexity reduction again",
+				},
+			},
+		},
+		{
+			Name:  "reorder",
+			Usage: "Move a task before or after one of its siblings",
+			Description: `Sets the intended order of tasks that share a parent (or of the root tasks
+of a project). The order is kept in tree, children, roots and list output.
+New tasks are placed after their existing siblings.`,
+			Action: reorderAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "id",
+					Usage:    "Task ID",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "before",
+					Usage: "Sibling task ID to place the task before",
+				},
+				&cli.StringFlag{
+					Name:  "after",
+					Usage: "Sibling task ID to place the task after",
+				},
+			},
+		},
+		{
+			Name:  "capacity",
+			Usage: "Show how many more tasks can be created at each depth",
+			Description: `Shows the task count and remaining capacity at each depth level given the
+max-tasks-per-depth and max-depth settings. With --parent-id the capacity for
+new subtasks of that parent is highlighted; with allow-large-breakdowns set,
+subtasks of a parent of complexity complexity-threshold or more are not limited
+by the depth level.`,
+			Action: capacityAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "parent-id",
+					Usage: "Show the capacity for subtasks of this task",
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+		NewCriteriaCommand(appCtx),
 	}
+	basicCommands = append(basicCommands, NewReviewCommands(appCtx)...)
+	basicCommands = append(basicCommands, NewCheckCommands(appCtx)...)
 
 	// Hierarchy navigation commands
 	hierarchyCommands := HierarchyCommands(appCtx)
@@ -204,6 +458,7 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 
 	// Bulk operation commands
 	bulkCommands := BulkCommands(appCtx)
+	bulkCommands = append(bulkCommands, NewReprioritizeCommand(appCtx), NewBulkTransitionCommand(appCtx), NewSelectInteractiveCommand(appCtx))
 
 	// Combine all commands
 	allCommands := make([]*cli.Command, 0, len(basicCommands)+len(hierarchyCommands)+len(deletionCommands)+len(bulkCommands))
@@ -228,18 +483,17 @@ func createAction(appCtx *shared.AppContext) cli.ActionFunc {
 		priority := c.String("priority")
 		actor := c.String("actor")
 
-		// Create input validator
-		validator := validation.NewInputValidator()
+		// Create input validator with the configured rules
+		validator, err := appCtx.ProjectManager.GetConfig().Validator()
+		if err != nil {
+			return errors.NewValidationError("invalid validation rules", err)
+		}
 
 		// Validate inputs
 		if err := validator.ValidateTaskTitle(title); err != nil {
 			return errors.NewValidationError("invalid task title", err)
 		}
 
-		if err := validator.ValidateTaskDescription(description); err != nil {
-			return errors.NewValidationError("invalid task description", err)
-		}
-
 		if err := validator.ValidateComplexity(complexity); err != nil {
 			return errors.NewValidationError("invalid complexity", err)
 		}
@@ -266,6 +520,22 @@ func createAction(appCtx *shared.AppContext) cli.ActionFunc {
 			parentID = &parsed
 		}
 
+		description, err = expandTaskDescription(c, appCtx, projectID, parentID, description, actor)
+		if err != nil {
+			return err
+		}
+		if err := validator.ValidateTaskDescription(description); err != nil {
+			return errors.NewValidationError("invalid task description", err)
+		}
+
+		if err := checkDuplicates(c, appCtx, projectID, title); err != nil {
+			return err
+		}
+		after, err := resolveAfter(c, appCtx, projectID)
+		if err != nil {
+			return err
+		}
+
 		appCtx.Logger.Info("Creating task",
 			zap.String("title", title),
 			zap.String("projectID", projectID.String()),
@@ -273,43 +543,102 @@ func createAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.String("priority", priority),
 			zap.String("actor", actor))
 
-		task, err := appCtx.ProjectManager.CreateTask(context.Background(), projectID, parentID, title, description, complexity, utils.ParsePriority(priority), actor)
+		task, err := appCtx.ProjectManager.CreateTask(c.Context, projectID, parentID, title, description, complexity, utils.ParsePriority(priority), actor)
 		if err != nil {
 			appCtx.Logger.Error("Failed to create task", zap.Error(err))
 			return errors.WrapWithSuggestion(err, "creating task")
 		}
 
 		appCtx.Logger.Info("Task created successfully", zap.String("taskID", task.ID.String()), zap.String("actor", actor))
+		warnProjectSize(c, appCtx, projectID)
+
+		for _, dependency := range after {
+			task, err = appCtx.ProjectManager.AddTaskDependency(c.Context, task.ID, dependency.ID, actor)
+			if err != nil {
+				appCtx.Logger.Error("Failed to add dependency", zap.Error(err))
+				return fmt.Errorf("task created, but adding the dependency on '%s' failed: %w", dependency.Title, err)
+			}
+		}
 
-		fmt.Printf("Created task: %s (ID: %s)\n", task.Title, task.ID)
-		fmt.Printf("  Created by: %s\n", actor)
+		if c.Bool("copy") {
+			shared.CopyToClipboard(task.ID.String(), "the task ID")
+		}
+		out := appCtx.Out()
+		if c.Bool("quiet") {
+			out = output.NewQuietWriter(out)
+		}
+		if printed, err := out.Result(task.ID, task); printed || err != nil {
+			return err
+		}
+
+		appCtx.Out().Printf("Created task: %s (ID: %s)\n", task.Title, task.ID)
+		appCtx.Out().Printf("  Created by: %s\n", actor)
 		if task.Description != "" {
-			fmt.Printf("  Description: %s\n", task.Description)
+			appCtx.Out().Printf("  Description: %s\n", task.Description)
 		}
-		fmt.Printf("  Complexity: %d\n", task.Complexity)
+		appCtx.Out().Printf("  Complexity: %d\n", task.Complexity)
 
-		fmt.Printf("  Priority: %s\n", task.Priority.ToExternalString())
-		fmt.Printf("  State: %s\n", task.State)
+		appCtx.Out().Printf("  Priority: %s\n", task.Priority.ToExternalString())
+		appCtx.Out().Printf("  State: %s\n", task.State)
 		if parentID != nil {
-			fmt.Printf("  Parent: %s\n", *parentID)
+			appCtx.Out().Printf("  Parent: %s\n", *parentID)
+		}
+		for _, dependency := range after {
+			appCtx.Out().Printf("  Depends on: %s (ID: %s)\n", dependency.Title, dependency.ID)
 		}
 
 		// Show workflow reminder for task state management
-		fmt.Printf("\nReminder: Set this task to 'in-progress' before starting work:\n")
-		fmt.Printf("  knot task update-state --id %s --state in-progress\n", task.ID)
+		appCtx.Out().Advise("\nReminder: Set this task to 'in-progress' before starting work:\n")
+		appCtx.Out().Advise("  knot task update-state --id %s --state in-progress\n", task.ID)
 
 		// Show breakdown suggestion for high complexity tasks
 		if complexity >= 8 {
-			fmt.Printf("\nNote: This task has high complexity (%d >= 8 threshold).\n", complexity)
-			fmt.Printf("Consider breaking it down into smaller subtasks:\n")
-			fmt.Printf("  knot task create --parent-id %s --title \"Subtask 1\"\n", task.ID)
-			fmt.Printf("  knot breakdown  # to see all tasks needing breakdown\n")
+			appCtx.Out().Advise("\nNote: This task has high complexity (%d >= 8 threshold).\n", complexity)
+			appCtx.Out().Advise("Consider breaking it down into smaller subtasks:\n")
+			appCtx.Out().Advise("  knot task create --parent-id %s --title \"Subtask 1\"\n", task.ID)
+			appCtx.Out().Advise("  knot breakdown  # to see all tasks needing breakdown\n")
 		}
 
 		return nil
 	}
 }
 
+// validateWithConfig runs a check of the validator for the configured rules
+func validateWithConfig(appCtx *shared.AppContext, check func(*validation.InputValidator) error) error {
+	validator, err := appCtx.ProjectManager.GetConfig().Validator()
+	if err != nil {
+		return err
+	}
+	return check(validator)
+}
+
+// expandTaskDescription expands the placeholders of a new task's description.
+// The project and parent are only loaded when the description has placeholders.
+func expandTaskDescription(c *cli.Context, appCtx *shared.AppContext, projectID uuid.UUID, parentID *uuid.UUID, description, actor string) (string, error) {
+	if !strings.Contains(description, "{{") {
+		return description, nil
+	}
+
+	vars, err := placeholder.ParseVars(c.StringSlice("var"))
+	if err != nil {
+		return "", errors.NewValidationError("invalid placeholder variable", err)
+	}
+
+	project, err := appCtx.ProjectManager.GetProject(c.Context, projectID)
+	if err != nil {
+		return "", errors.WrapWithSuggestion(err, "getting project")
+	}
+	values := placeholder.New(appCtx.ProjectManager.GetCurrentTime(), actor).AddProject(project)
+	if parentID != nil {
+		parent, err := appCtx.ProjectManager.GetTask(c.Context, *parentID)
+		if err != nil {
+			return "", errors.WrapWithSuggestion(err, "getting parent task")
+		}
+		values.AddParent(parent)
+	}
+	return values.AddVars(vars).Expand(description), nil
+}
+
 func listAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
 		projectID, err := shared.ResolveProjectID(c, appCtx)
@@ -319,12 +648,47 @@ func listAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		appCtx.Logger.Info("Listing tasks", zap.String("projectID", projectID.String()))
 
-		tasks, err := appCtx.ProjectManager.ListTasksForProject(context.Background(), projectID)
+		var groupBy string
+		if c.IsSet("group-by") {
+			if groupBy, err = parseGroupBy(c.String("group-by")); err != nil {
+				return errors.NewValidationError("invalid --group-by value", err)
+			}
+		}
+
+		// --fields implies the compact format unless JSON is requested
+		var fields []listField
+		if c.Bool("compact") || c.IsSet("fields") {
+			if fields, err = parseListFields(c.String("fields")); err != nil {
+				return errors.NewValidationError("invalid --fields value", err)
+			}
+			if groupBy != "" && c.Bool("json") {
+				return errors.NewValidationError("invalid flag combination",
+					fmt.Errorf("--fields and --compact cannot be combined with --group-by in JSON output"))
+			}
+		}
+
+		if isPaginated(c) {
+			return listPage(c, appCtx, projectID, fields)
+		}
+
+		inheritance := appCtx.ProjectManager.GetConfig().PriorityInheritance
+		listTasks := appCtx.ProjectManager.ListTasksForProject
+		if inheritance {
+			// Priority inheritance follows dependencies, which plain listings do not include
+			listTasks = appCtx.ProjectManager.ListTasksWithDependencies
+		}
+
+		tasks, err := listTasks(c.Context, projectID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
 			return errors.WrapWithSuggestion(err, "listing tasks")
 		}
 
+		var effective map[uuid.UUID]selection.EffectivePriority
+		if inheritance {
+			effective = selection.EffectivePriorities(tasks)
+		}
+
 		// Apply filters
 		filteredTasks := applyTaskFilters(tasks, c)
 
@@ -339,13 +703,29 @@ func listAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.Int("filteredCount", len(finalTasks)))
 
 		if len(finalTasks) == 0 {
-			fmt.Printf("No tasks found matching the specified criteria.\n")
+			appCtx.Out().Printf("No tasks found matching the specified criteria.\n")
 			return nil
 		}
 
+		var groups []*taskGroup
+		if groupBy != "" {
+			groups = groupTasks(finalTasks, groupBy)
+		}
+
 		// Check if JSON output is requested
 		if c.Bool("json") {
-			return utils.OutputTasksAsJSON(finalTasks)
+			if fields != nil {
+				return writeSelectedFieldsJSON(appCtx.Out(), finalTasks, fields)
+			}
+			if groups != nil {
+				return writeGroupsJSON(appCtx.Out(), groupBy, groups)
+			}
+			return appCtx.Out().JSON(finalTasks)
+		}
+
+		if fields != nil {
+			writeCompactList(appCtx.Out(), finalTasks, groupBy, groups, fields)
+			return nil
 		}
 
 		// Show project context indicator
@@ -353,33 +733,84 @@ func listAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		// Show filter summary if filters were applied
 		if hasFiltersApplied(c) {
-			fmt.Printf("Found %d task(s) matching criteria (out of %d total):\n\n", len(finalTasks), len(tasks))
+			appCtx.Out().Printf("Found %d task(s) matching criteria (out of %d total):\n\n", len(finalTasks), len(tasks))
 		} else {
-			fmt.Printf("Found %d task(s):\n\n", len(finalTasks))
+			appCtx.Out().Printf("Found %d task(s):\n\n", len(finalTasks))
 		}
 
-		for _, task := range finalTasks {
-			indent := ""
-			for i := 0; i < task.Depth; i++ {
-				indent += "  "
+		if groups == nil {
+			for _, task := range finalTasks {
+				printListedTask(appCtx.Out(), task, effective, tasks)
 			}
+			return nil
+		}
 
-			// Show parent information for better hierarchy understanding
-			parentInfo := ""
-			if task.ParentID != nil {
-				parentInfo = fmt.Sprintf(" (Parent: %s)", *task.ParentID)
+		for _, group := range groups {
+			appCtx.Out().Printf("== %s %s: %d task(s), %d completed (%.1f%%), complexity %d, estimate %s ==\n\n",
+				groupBy, group.Key, group.Count, group.Completed, group.CompletionPercentage(),
+				group.TotalComplexity, utils.FormatEstimate(group.EstimateMinutes))
+			for _, task := range group.Tasks {
+				printListedTask(appCtx.Out(), task, effective, tasks)
 			}
+		}
+		return nil
+	}
+}
 
-			fmt.Printf("%s* %s (ID: %s)%s\n", indent, task.Title, task.ID, parentInfo)
-			if task.Description != "" {
-				fmt.Printf("%s  %s\n", indent, task.Description)
-			}
+// printListedTask prints one entry of the task list, indented by depth
+func printListedTask(out output.Writer, task *types.Task, effective map[uuid.UUID]selection.EffectivePriority, tasks []*types.Task) {
+	indent := ""
+	for i := 0; i < task.Depth; i++ {
+		indent += "  "
+	}
 
-			fmt.Printf("%s  State: %s | Priority: %s | Complexity: %d | Depth: %d\n", indent, task.State, task.Priority.ToExternalString(), task.Complexity, task.Depth)
-			fmt.Println()
+	// Show parent information for better hierarchy understanding
+	parentInfo := ""
+	if task.ParentID != nil {
+		parentInfo = fmt.Sprintf(" (Parent: %s)", *task.ParentID)
+	}
+
+	out.Printf("%s* %s (ID: %s)%s\n", indent, task.Title, task.ID, parentInfo)
+	if task.Description != "" {
+		out.Printf("%s  %s\n", indent, output.Summary(task.Description))
+	}
+
+	out.Printf("%s  State: %s | Priority: %s%s | Complexity: %d | Depth: %d%s\n", indent, output.State(task.State), output.Priority(task.Priority), effectivePrioritySuffix(task, effective, tasks), task.Complexity, task.Depth, utils.EstimateSuffix(task))
+	if task.Blocker != nil {
+		out.Printf("%s  Blocked: %s\n", indent, output.Blocker(task.Blocker))
+	}
+	out.Println()
+}
+
+// writeGroupsJSON outputs a grouped task listing in JSON format
+func writeGroupsJSON(out output.Writer, groupBy string, groups []*taskGroup) error {
+	jsonData, err := json.MarshalIndent(struct {
+		GroupBy string       `json:"group_by"`
+		Groups  []*taskGroup `json:"groups"`
+	}{GroupBy: groupBy, Groups: groups}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal task groups to JSON: %w", err)
+	}
+	out.Println(string(jsonData))
+	return nil
+}
+
+// effectivePrioritySuffix describes an inherited priority, or returns an empty
+// string if the task keeps its own priority
+func effectivePrioritySuffix(task *types.Task, effective map[uuid.UUID]selection.EffectivePriority, tasks []*types.Task) string {
+	inherited, ok := effective[task.ID]
+	if !ok || inherited.InheritedFrom == nil {
+		return ""
+	}
+
+	source := inherited.InheritedFrom.String()
+	for _, candidate := range tasks {
+		if candidate.ID == *inherited.InheritedFrom {
+			source = fmt.Sprintf("%q", candidate.Title)
+			break
 		}
-		return nil
 	}
+	return fmt.Sprintf(" (effective: %s, inherited from %s)", output.Priority(inherited.Priority), source)
 }
 
 func updateStateAction(appCtx *shared.AppContext) cli.ActionFunc {
@@ -403,6 +834,20 @@ func updateStateAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		newState := types.TaskState(stateStr)
 
+		reason := strings.TrimSpace(c.String("reason"))
+		reference := c.String("ref")
+		if reason != "" && newState != types.TaskStateBlocked {
+			return errors.NewValidationError("--reason can only be given with --state blocked", nil)
+		}
+		if reference != "" && reason == "" {
+			return errors.NewValidationError("--ref requires --reason", nil)
+		}
+		handoff := strings.TrimSpace(c.String("handoff"))
+		if handoff != "" && newState != types.TaskStatePending {
+			return errors.NewValidationError("--handoff can only be given with --state pending",
+				fmt.Errorf("use 'knot task assign --handoff' to pass a task to another agent"))
+		}
+
 		appCtx.Logger.Info("Updating task state",
 			zap.String("taskID", taskID.String()),
 			zap.String("newState", stateStr),
@@ -422,7 +867,7 @@ func updateStateAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.String("actor", actor))
 
 		// Get current task to preserve other fields
-		task, err := appCtx.ProjectManager.GetTask(context.Background(), taskID)
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get task", zap.Error(err))
 			return errors.TaskNotFoundError(taskID)
@@ -434,24 +879,57 @@ func updateStateAction(appCtx *shared.AppContext) cli.ActionFunc {
 				taskID, task.ProjectID, projectID)
 		}
 
-		// Validate state transition
-		validator := validation.NewStateValidator()
-		if err := validator.ValidateTransition(task.State, newState, task); err != nil {
-			// EnhancedError already contains user-friendly formatting
-			// No need to log this as it's a user input validation error
-			return err
+		// An external reason allows blocking a task without dependencies
+		oldState := task.State
+		if reason != "" {
+			task.Blocker = &types.TaskBlocker{Reason: reason, Reference: reference}
+		}
+
+		// Validate state transition, unless it is forced
+		ctx := c.Context
+		if c.Bool("force-transition") {
+			ctx = manager.WithForcedTransitions(ctx)
+			appCtx.Logger.Warn("Forcing task state transition",
+				zap.String("taskID", taskID.String()),
+				zap.String("from", string(oldState)),
+				zap.String("to", stateStr))
+		} else {
+			validator := validation.NewStateValidator()
+			if err := validator.ValidateTransition(oldState, newState, task); err != nil {
+				// EnhancedError already contains user-friendly formatting
+				// No need to log this as it's a user input validation error
+				return err
+			}
 		}
 
 		// Update task state
-		updatedTask, err := appCtx.ProjectManager.UpdateTaskState(context.Background(), taskID, newState, actor)
+		var updatedTask *types.Task
+		if reason != "" {
+			updatedTask, err = appCtx.ProjectManager.BlockTask(ctx, taskID, reason, reference, actor)
+		} else {
+			updatedTask, err = appCtx.ProjectManager.UpdateTaskStateWithHandoff(ctx, taskID, newState, handoff, actor)
+		}
 		if err != nil {
 			appCtx.Logger.Error("Failed to update task state", zap.Error(err))
+			var enhanced *errors.EnhancedError
+			if stderrors.As(err, &enhanced) {
+				return err
+			}
 			return errors.WrapWithSuggestion(err, "updating task state")
 		}
 
 		appCtx.Logger.Info("Task state updated successfully", zap.String("actor", actor))
-		fmt.Printf("Updated task state: %s -> %s\n", task.State, updatedTask.State)
-		fmt.Printf("  Updated by: %s\n", actor)
+		if printed, err := appCtx.Out().Result(updatedTask.ID, updatedTask); printed || err != nil {
+			return err
+		}
+		appCtx.Out().Printf("Updated task state: %s -> %s\n", oldState, updatedTask.State)
+		if updatedTask.Blocker != nil {
+			appCtx.Out().Printf("  Blocked: %s\n", output.Blocker(updatedTask.Blocker))
+		}
+		if handoff != "" {
+			printHandoff(appCtx.Out(), appCtx.ProjectManager.GetConfig(), updatedTask.LatestHandoff(), "  ")
+		}
+		appCtx.Out().Printf("  Updated by: %s\n", actor)
 		return nil
 	}
 }
@@ -469,6 +947,9 @@ func updateTitleAction(appCtx *shared.AppContext) cli.ActionFunc {
 		if newTitle == "" {
 			return fmt.Errorf("title cannot be empty")
 		}
+		if err := validateWithConfig(appCtx, func(v *validation.InputValidator) error { return v.ValidateTaskTitle(newTitle) }); err != nil {
+			return errors.NewValidationError("invalid task title", err)
+		}
 
 		// Default to $USER if actor is not provided
 		actor = shared.ResolveActor(actor)
@@ -479,7 +960,7 @@ func updateTitleAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.String("actor", actor))
 
 		// Get current task to check if it exists and get old title
-		task, err := appCtx.ProjectManager.GetTask(context.Background(), taskID)
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get task", zap.Error(err))
 			return errors.TaskNotFoundError(taskID)
@@ -488,15 +969,18 @@ func updateTitleAction(appCtx *shared.AppContext) cli.ActionFunc {
 		oldTitle := task.Title
 
 		// Update task title
-		updatedTask, err := appCtx.ProjectManager.UpdateTaskTitle(context.Background(), taskID, newTitle, actor)
+		updatedTask, err := appCtx.ProjectManager.UpdateTaskTitle(c.Context, taskID, newTitle, actor)
 		if err != nil {
 			appCtx.Logger.Error("Failed to update task title", zap.Error(err))
 			return errors.WrapWithSuggestion(err, "updating task title")
 		}
 
 		appCtx.Logger.Info("Task title updated successfully", zap.String("actor", actor))
-		fmt.Printf("Updated task title: \"%s\" -> \"%s\"\n", oldTitle, updatedTask.Title)
-		fmt.Printf("  Updated by: %s\n", actor)
+		if printed, err := appCtx.Out().Result(updatedTask.ID, updatedTask); printed || err != nil {
+			return err
+		}
+		appCtx.Out().Printf("Updated task title: \"%s\" -> \"%s\"\n", oldTitle, updatedTask.Title)
+		appCtx.Out().Printf("  Updated by: %s\n", actor)
 		return nil
 	}
 }
@@ -511,6 +995,9 @@ func updateDescriptionAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		newDescription := c.String("description")
 		actor := c.String("actor")
+		if err := validateWithConfig(appCtx, func(v *validation.InputValidator) error { return v.ValidateTaskDescription(newDescription) }); err != nil {
+			return errors.NewValidationError("invalid task description", err)
+		}
 
 		// Default to $USER if actor is not provided
 		actor = shared.ResolveActor(actor)
@@ -521,7 +1008,7 @@ func updateDescriptionAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.String("actor", actor))
 
 		// Get current task to check if it exists and get old description
-		task, err := appCtx.ProjectManager.GetTask(context.Background(), taskID)
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get task", zap.Error(err))
 			return errors.TaskNotFoundError(taskID)
@@ -530,19 +1017,63 @@ func updateDescriptionAction(appCtx *shared.AppContext) cli.ActionFunc {
 		oldDescription := task.Description
 
 		// Update task description
-		updatedTask, err := appCtx.ProjectManager.UpdateTaskDescription(context.Background(), taskID, newDescription, actor)
+		updatedTask, err := appCtx.ProjectManager.UpdateTaskDescription(c.Context, taskID, newDescription, actor)
 		if err != nil {
 			appCtx.Logger.Error("Failed to update task description", zap.Error(err))
 			return errors.WrapWithSuggestion(err, "updating task description")
 		}
 
 		appCtx.Logger.Info("Task description updated successfully", zap.String("actor", actor))
+		if printed, err := appCtx.Out().Result(updatedTask.ID, updatedTask); printed || err != nil {
+			return err
+		}
 		if oldDescription == "" {
-			fmt.Printf("Updated task description: (empty) -> \"%s\"\n", updatedTask.Description)
+			appCtx.Out().Printf("Updated task description: (empty) -> \"%s\"\n", updatedTask.Description)
 		} else {
-			fmt.Printf("Updated task description: \"%s\" -> \"%s\"\n", oldDescription, updatedTask.Description)
+			appCtx.Out().Printf("Updated task description: \"%s\" -> \"%s\"\n", oldDescription, updatedTask.Description)
 		}
-		fmt.Printf("  Updated by: %s\n", actor)
+		appCtx.Out().Printf("  Updated by: %s\n", actor)
+		return nil
+	}
+}
+
+func updateInstructionsAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskIDStr := c.String("id")
+		taskID, err := uuid.Parse(taskIDStr)
+		if err != nil {
+			return errors.InvalidUUIDError("task-id", taskIDStr)
+		}
+
+		instructions, err := shared.InstructionsFromFlags(c)
+		if err != nil {
+			return err
+		}
+		if err := validateWithConfig(appCtx, func(v *validation.InputValidator) error { return v.ValidateInstructions(instructions) }); err != nil {
+			return errors.NewValidationError("invalid instructions", err)
+		}
+
+		actor := shared.ResolveActor(c.String("actor"))
+		appCtx.Logger.Info("Updating task instructions",
+			zap.String("taskID", taskID.String()),
+			zap.Int("length", len(instructions)),
+			zap.String("actor", actor))
+
+		updatedTask, err := appCtx.ProjectManager.UpdateTaskInstructions(c.Context, taskID, instructions, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to update task instructions", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "updating task instructions")
+		}
+
+		if printed, err := appCtx.Out().Result(updatedTask.ID, updatedTask); printed || err != nil {
+			return err
+		}
+		if updatedTask.Instructions == "" {
+			appCtx.Out().Printf("Removed the instructions of task '%s'\n", updatedTask.Title)
+		} else {
+			appCtx.Out().Printf("Updated the instructions of task '%s': \"%s\"\n", updatedTask.Title, updatedTask.Instructions)
+		}
+		appCtx.Out().Printf("  Updated by: %s\n", actor)
 		return nil
 	}
 }
@@ -573,7 +1104,7 @@ func updatePriorityAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.String("actor", actor))
 
 		// Get current task to check if it exists and get old priority
-		task, err := appCtx.ProjectManager.GetTask(context.Background(), taskID)
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get task", zap.Error(err))
 			return errors.TaskNotFoundError(taskID)
@@ -582,15 +1113,361 @@ func updatePriorityAction(appCtx *shared.AppContext) cli.ActionFunc {
 		oldPriority := task.Priority
 
 		// Update task priority using the service method
-		updatedTask, err := appCtx.ProjectManager.UpdateTaskPriority(context.Background(), taskID, utils.ParsePriority(priority), actor)
+		updatedTask, err := appCtx.ProjectManager.UpdateTaskPriority(c.Context, taskID, utils.ParsePriority(priority), actor)
 		if err != nil {
 			appCtx.Logger.Error("Failed to update task priority", zap.Error(err))
 			return errors.WrapWithSuggestion(err, "updating task priority")
 		}
 
 		appCtx.Logger.Info("Task priority updated successfully", zap.String("actor", actor))
-		fmt.Printf("Updated task priority: \"%s\" -> \"%s\"\n", oldPriority.ToExternalString(), updatedTask.Priority.ToExternalString())
-		fmt.Printf("  Updated by: %s\n", actor)
+		if printed, err := appCtx.Out().Result(updatedTask.ID, updatedTask); printed || err != nil {
+			return err
+		}
+		appCtx.Out().Printf("Updated task priority: \"%s\" -> \"%s\"\n", oldPriority.ToExternalString(), updatedTask.Priority.ToExternalString())
+		appCtx.Out().Printf("  Updated by: %s\n", actor)
+		return nil
+	}
+}
+
+func estimateAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskIDStr := c.String("id")
+		taskID, err := uuid.Parse(taskIDStr)
+		if err != nil {
+			return errors.InvalidUUIDError("task-id", taskIDStr)
+		}
+
+		minutes, err := utils.ParseEstimate(c.String("duration"))
+		if err != nil {
+			return errors.NewValidationError("invalid duration", err)
+		}
+
+		actor := shared.ResolveActor(c.String("actor"))
+
+		appCtx.Logger.Info("Updating task estimate",
+			zap.String("taskID", taskID.String()),
+			zap.Int64("minutes", minutes),
+			zap.String("actor", actor))
+
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to get task", zap.Error(err))
+			return errors.TaskNotFoundError(taskID)
+		}
+
+		oldEstimate := utils.FormatEstimatePtr(task.Estimate)
+
+		updatedTask, err := appCtx.ProjectManager.SetTaskEstimate(c.Context, taskID, minutes)
+		if err != nil {
+			appCtx.Logger.Error("Failed to update task estimate", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "updating task estimate")
+		}
+
+		appCtx.Logger.Info("Task estimate updated successfully", zap.String("actor", actor))
+		if printed, err := appCtx.Out().Result(updatedTask.ID, updatedTask); printed || err != nil {
+			return err
+		}
+		appCtx.Out().Printf("Updated task estimate: \"%s\" -> \"%s\" (%d minutes)\n",
+			oldEstimate, utils.FormatEstimatePtr(updatedTask.Estimate), minutes)
+		return nil
+	}
+}
+
+func logEffortAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskID, err := parseTaskIDFlag(c)
+		if err != nil {
+			return err
+		}
+
+		minutes, err := utils.ParseEstimate(c.String("duration"))
+		if err != nil {
+			return errors.NewValidationError("invalid duration", err)
+		}
+
+		actor := shared.ResolveActor(c.String("actor"))
+
+		appCtx.Logger.Info("Logging task effort",
+			zap.String("taskID", taskID.String()),
+			zap.Int64("minutes", minutes),
+			zap.String("actor", actor))
+
+		task, err := appCtx.ProjectManager.LogTaskEffort(c.Context, taskID, minutes, c.String("note"), actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to log task effort", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "logging task effort")
+		}
+
+		appCtx.Out().Printf("Logged %s on task \"%s\" (total: %s, estimate: %s)\n",
+			utils.FormatEstimate(minutes), task.Title, utils.FormatEstimate(task.ActualEffort()), utils.FormatEstimatePtr(task.Estimate))
+		appCtx.Out().Printf("  Logged by: %s\n", actor)
+		return nil
+	}
+}
+
+func dueAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskIDStr := c.String("id")
+		taskID, err := uuid.Parse(taskIDStr)
+		if err != nil {
+			return errors.InvalidUUIDError("task-id", taskIDStr)
+		}
+
+		if c.IsSet("date") == c.Bool("clear") {
+			return errors.NewValidationError("invalid flags", fmt.Errorf("specify exactly one of --date or --clear"))
+		}
+		var due *time.Time
+		if !c.Bool("clear") {
+			date, err := utils.ParseDueDate(c.String("date"))
+			if err != nil {
+				return errors.NewValidationError("invalid due date", err)
+			}
+			due = &date
+		}
+
+		actor := shared.ResolveActor(c.String("actor"))
+
+		appCtx.Logger.Info("Updating task due date",
+			zap.String("taskID", taskID.String()),
+			zap.String("due", utils.FormatDueDate(due)),
+			zap.String("actor", actor))
+
+		task, err := appCtx.ProjectManager.SetTaskDueDate(c.Context, taskID, due, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to update task due date", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "updating task due date")
+		}
+
+		if task.DueDate != nil {
+			appCtx.Out().Printf("Task \"%s\" is due on %s\n", task.Title, utils.FormatDueDate(task.DueDate))
+		} else {
+			appCtx.Out().Printf("Removed due date of task \"%s\"\n", task.Title)
+		}
+		appCtx.Out().Printf("  Updated by: %s\n", actor)
+		return nil
+	}
+}
+
+func setCompletedAtAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskIDStr := c.String("id")
+		taskID, err := uuid.Parse(taskIDStr)
+		if err != nil {
+			return errors.InvalidUUIDError("task-id", taskIDStr)
+		}
+
+		completedAt, err := utils.ParseTimestamp(c.String("time"))
+		if err != nil {
+			return errors.NewValidationError("invalid completion time", err)
+		}
+
+		actor := shared.ResolveActor(c.String("actor"))
+
+		appCtx.Logger.Info("Updating task completion time",
+			zap.String("taskID", taskID.String()),
+			zap.Time("completedAt", completedAt),
+			zap.String("actor", actor))
+
+		task, err := appCtx.ProjectManager.SetTaskCompletedAt(c.Context, taskID, completedAt, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to update task completion time", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "updating task completion time")
+		}
+
+		appCtx.Out().Printf("Task \"%s\" was completed at %s\n", task.Title, output.Timestamp(*task.CompletedAt))
+		appCtx.Out().Printf("  Updated by: %s\n", actor)
+		return nil
+	}
+}
+
+func keepComplexityAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskIDStr := c.String("id")
+		taskID, err := uuid.Parse(taskIDStr)
+		if err != nil {
+			return errors.InvalidUUIDError("task-id", taskIDStr)
+		}
+
+		keep := !c.Bool("off")
+		actor := shared.ResolveActor(c.String("actor"))
+
+		appCtx.Logger.Info("Updating task keep-complexity",
+			zap.String("taskID", taskID.String()),
+			zap.Bool("keep", keep),
+			zap.String("actor", actor))
+
+		task, err := appCtx.ProjectManager.SetTaskKeepComplexity(c.Context, taskID, keep, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to update task keep-complexity", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "updating task keep-complexity")
+		}
+
+		if keep {
+			appCtx.Out().Printf("Task \"%s\" keeps its complexity (%d) when subtasks are added\n", task.Title, task.Complexity)
+		} else {
+			appCtx.Out().Printf("Task \"%s\" complexity is reduced automatically when subtasks are added\n", task.Title)
+		}
+		appCtx.Out().Printf("  Updated by: %s\n", actor)
+		return nil
+	}
+}
+
+func reorderAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskIDStr := c.String("id")
+		taskID, err := uuid.Parse(taskIDStr)
+		if err != nil {
+			return errors.InvalidUUIDError("task-id", taskIDStr)
+		}
+
+		if c.IsSet("before") == c.IsSet("after") {
+			return errors.NewValidationError("invalid flags",
+				fmt.Errorf("exactly one of --before or --after is required"))
+		}
+		flag, after := "before", false
+		if c.IsSet("after") {
+			flag, after = "after", true
+		}
+		siblingID, err := uuid.Parse(c.String(flag))
+		if err != nil {
+			return errors.InvalidUUIDError(flag, c.String(flag))
+		}
+
+		actor := shared.ResolveActor(c.String("actor"))
+
+		appCtx.Logger.Info("Reordering task",
+			zap.String("taskID", taskID.String()),
+			zap.String(flag, siblingID.String()),
+			zap.String("actor", actor))
+
+		siblings, err := appCtx.ProjectManager.ReorderTask(c.Context, taskID, siblingID, after, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to reorder task", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "reordering task")
+		}
+
+		appCtx.Out().Println("New sibling order:")
+		for i, sibling := range siblings {
+			marker := " "
+			if sibling.ID == taskID {
+				marker = "*"
+			}
+			appCtx.Out().Printf("%s %d. %s (ID: %s)\n", marker, i+1, sibling.Title, sibling.ID)
+		}
+		return nil
+	}
+}
+
+func idAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		title := c.String("title")
+		if strings.TrimSpace(title) == "" {
+			return fmt.Errorf("title is required")
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "listing tasks")
+		}
+
+		matches := findTasksByTitle(tasks, title)
+		switch len(matches) {
+		case 0:
+			return fmt.Errorf("no task found with title '%s'", title)
+		case 1:
+			appCtx.Out().Println(matches[0].ID)
+			return nil
+		}
+
+		ids := make([]string, len(matches))
+		for i, task := range matches {
+			ids[i] = task.ID.String()
+		}
+		return fmt.Errorf("%d tasks match title '%s': %s", len(matches), title, strings.Join(ids, ", "))
+	}
+}
+
+// findTasksByTitle returns the tasks with exactly the given title, or the
+// case-insensitive matches if there is no exact match
+func findTasksByTitle(tasks []*types.Task, title string) []*types.Task {
+	var exact, folded []*types.Task
+	for _, task := range tasks {
+		switch {
+		case task.Title == title:
+			exact = append(exact, task)
+		case strings.EqualFold(task.Title, title):
+			folded = append(folded, task)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+	return folded
+}
+
+func capacityAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		var parentID *uuid.UUID
+		if parentIDStr := c.String("parent-id"); parentIDStr != "" {
+			parsed, err := uuid.Parse(parentIDStr)
+			if err != nil {
+				return errors.InvalidUUIDError("parent-id", parentIDStr)
+			}
+			parentID = &parsed
+		}
+
+		capacity, err := appCtx.ProjectManager.GetTaskCapacity(c.Context, projectID, parentID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to get task capacity", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "getting task capacity")
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(capacity, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal task capacity to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		appCtx.Out().Printf("Task capacity (max depth: %d):\n", capacity.MaxDepth)
+		for _, d := range capacity.Depths {
+			marker := " "
+			if d.Depth == capacity.TargetDepth {
+				marker = "*"
+			}
+			appCtx.Out().Printf("%s depth %d: %d/%d tasks, %d remaining\n", marker, d.Depth, d.Count, d.Max, d.Remaining)
+		}
+
+		target := "root tasks"
+		if parentID != nil {
+			target = fmt.Sprintf("subtasks of %s", *parentID)
+		}
+		switch {
+		case capacity.TargetDepth > capacity.MaxDepth:
+			appCtx.Out().Printf("\nNo %s can be created: depth %d exceeds the maximum depth %d\n", target, capacity.TargetDepth, capacity.MaxDepth)
+		case capacity.DepthLimitExempt && capacity.Remaining < 0:
+			appCtx.Out().Printf("\nAny number of %s can be created (depth %d, exempt from the depth limit as a large breakdown)\n", target, capacity.TargetDepth)
+		case capacity.DepthLimitExempt:
+			appCtx.Out().Printf("\n%d more %s can be created (depth %d, exempt from the depth limit as a large breakdown)\n", capacity.Remaining, target, capacity.TargetDepth)
+		default:
+			appCtx.Out().Printf("\n%d more %s can be created (depth %d)\n", capacity.Remaining, target, capacity.TargetDepth)
+		}
+		if capacity.MaxProjectTasks > 0 {
+			appCtx.Out().Printf("Project: %d/%d tasks\n", capacity.ProjectTasks, capacity.MaxProjectTasks)
+		}
 		return nil
 	}
 }
@@ -606,7 +1483,7 @@ func getAction(appCtx *shared.AppContext) cli.ActionFunc {
 		appCtx.Logger.Info("Getting task", zap.String("taskID", taskID.String()))
 
 		// Get the task
-		task, err := appCtx.ProjectManager.GetTask(context.Background(), taskID)
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get task", zap.Error(err))
 			return errors.TaskNotFoundError(taskID)
@@ -614,33 +1491,105 @@ func getAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		// Check if JSON output is requested
 		if c.Bool("json") {
-			return utils.OutputTaskAsJSON(task)
+			return appCtx.Out().JSON(task)
 		}
 
 		// Show project context indicator
 		shared.ShowProjectContextWithSeparator(c, appCtx)
 
 		// Display task details
-		fmt.Printf("Task Details:\n")
-		fmt.Printf("  ID: %s\n", task.ID)
-		fmt.Printf("  Title: %s\n", task.Title)
+		appCtx.Out().Printf("Task Details:\n")
+		appCtx.Out().Printf("  ID: %s\n", task.ID)
+		appCtx.Out().Printf("  Title: %s\n", task.Title)
+		if path := breadcrumb(c, appCtx, task); path != "" {
+			appCtx.Out().Printf("  Path: %s\n", path)
+		}
 		if task.Description != "" {
-			fmt.Printf("  Description: %s\n", task.Description)
+			appCtx.Out().Printf("  Description: %s\n", task.Description)
+		}
+		if task.Instructions != "" {
+			appCtx.Out().Printf("  Instructions: %s\n", task.Instructions)
+		}
+		appCtx.Out().Printf("  State: %s\n", task.State)
+		if task.Blocker != nil {
+			appCtx.Out().Printf("  Blocked: %s\n", output.Blocker(task.Blocker))
+			appCtx.Out().Printf("    Blocked by %s at %s\n", task.Blocker.BlockedBy, output.Timestamp(task.Blocker.BlockedAt))
+		}
+		config := appCtx.ProjectManager.GetConfig()
+		if task.AssignedAgent != nil {
+			appCtx.Out().Printf("  Assigned: %s\n", agentLabel(config, task.AssignedAgent))
+		}
+		if handoff := task.LatestHandoff(); handoff != nil {
+			printHandoff(appCtx.Out(), config, handoff, "  ")
+			if earlier := len(task.Handoffs) - 1; earlier > 0 {
+				appCtx.Out().Printf("    %d earlier handoff note(s), see --json\n", earlier)
+			}
+		}
+		appCtx.Out().Printf("  Priority: %s\n", task.Priority.ToExternalString())
+		appCtx.Out().Printf("  Complexity: %d", task.Complexity)
+		if task.KeepComplexity {
+			appCtx.Out().Print(" (kept, no auto-reduce)")
+		}
+		appCtx.Out().Println()
+		if task.Estimate != nil {
+			appCtx.Out().Printf("  Estimate: %s (%d minutes)\n", utils.FormatEstimate(*task.Estimate), *task.Estimate)
 		}
-		fmt.Printf("  State: %s\n", task.State)
-		fmt.Printf("  Priority: %s\n", task.Priority.ToExternalString())
-		fmt.Printf("  Complexity: %d\n", task.Complexity)
-		fmt.Printf("  Depth: %d\n", task.Depth)
-		fmt.Printf("  Created: %s\n", task.CreatedAt.Format("2006-01-02 15:04:05"))
-		fmt.Printf("  Updated: %s\n", task.UpdatedAt.Format("2006-01-02 15:04:05"))
-		fmt.Printf("  Created By: %s\n", task.CreatedBy)
+		if len(task.EffortLog) > 0 {
+			appCtx.Out().Printf("  Actual Effort: %s (%d entries)\n", utils.FormatEstimate(task.ActualEffort()), len(task.EffortLog))
+		}
+		if task.DueDate != nil {
+			appCtx.Out().Printf("  Due: %s\n", utils.FormatDueDate(task.DueDate))
+		}
+		appCtx.Out().Printf("  Depth: %d\n", task.Depth)
+		if rollup := subtreeProgress(c, appCtx, task.ProjectID)[task.ID]; rollup != nil {
+			appCtx.Out().Printf("  Subtree: %s\n", output.Rollup(rollup))
+		}
+		appCtx.Out().Printf("  Created: %s\n", output.Timestamp(task.CreatedAt))
+		appCtx.Out().Printf("  Updated: %s\n", output.Timestamp(task.UpdatedAt))
+		appCtx.Out().Printf("  Created By: %s\n", task.CreatedBy)
 
 		if task.ParentID != nil {
-			fmt.Printf("  Parent ID: %s\n", *task.ParentID)
+			appCtx.Out().Printf("  Parent ID: %s\n", *task.ParentID)
 		}
 
 		if task.CompletedAt != nil {
-			fmt.Printf("  Completed At: %s\n", task.CompletedAt.Format("2006-01-02 15:04:05"))
+			appCtx.Out().Printf("  Completed At: %s\n", output.Timestamp(*task.CompletedAt))
+		}
+
+		if len(task.AcceptanceCriteria) > 0 {
+			verified := len(task.AcceptanceCriteria) - len(task.UnverifiedCriteria())
+			appCtx.Out().Printf("  Acceptance Criteria (%d/%d verified):\n", verified, len(task.AcceptanceCriteria))
+			for i, criterion := range task.AcceptanceCriteria {
+				mark := " "
+				if criterion.Verified {
+					mark = "x"
+				}
+				appCtx.Out().Printf("    %d. [%s] %s\n", i+1, mark, criterion.Text)
+			}
+		}
+
+		if task.Check != nil {
+			required := ""
+			if task.Check.Required {
+				required = " (required)"
+			}
+			appCtx.Out().Printf("  Check: %s%s\n", task.Check.Command, required)
+			if run := task.Check.LastRun(); run != nil {
+				appCtx.Out().Printf("    Last run %s by %s at %s\n", output.CheckRun(run), run.RunBy, output.Timestamp(run.RunAt))
+			} else {
+				appCtx.Out().Printf("    Not run yet: knot task verify --id %s\n", task.ID)
+			}
+		}
+
+		if task.Review != nil {
+			appCtx.Out().Printf("  Review: %s (requested by %s at %s)\n", task.Review.Status, task.Review.RequestedBy,
+				output.Timestamp(task.Review.RequestedAt))
+			if task.Review.ReviewedAt != nil {
+				appCtx.Out().Printf("    Reviewed by %s at %s\n", task.Review.Reviewer, output.Timestamp(*task.Review.ReviewedAt))
+			}
+			if task.Review.Comment != "" {
+				appCtx.Out().Printf("    Comment: %s\n", task.Review.Comment)
+			}
 		}
 
 		return nil
@@ -735,17 +1684,17 @@ func applyTaskSorting(tasks []*types.Task, c *cli.Context) []*types.Task {
 		case "depth":
 			less = sorted[i].Depth < sorted[j].Depth
 		case "hierarchy":
-			// Hierarchical sort: first by depth, then by creation time within each level
+			// Hierarchical sort: first by depth, then in sibling order within each level
 			if sorted[i].Depth != sorted[j].Depth {
 				less = sorted[i].Depth < sorted[j].Depth
 			} else {
-				less = sorted[i].ID.String() < sorted[j].ID.String()
+				less = types.CompareSiblings(sorted[i], sorted[j]) < 0
 			}
 		case "created":
 			fallthrough
 		default:
-			// Default sort by creation time (using ID as proxy since tasks are created sequentially)
-			less = sorted[i].ID.String() < sorted[j].ID.String()
+			// Default sort by creation time
+			less = types.CompareCreation(sorted[i], sorted[j]) < 0
 		}
 
 		if reverse {
@@ -1,13 +1,17 @@
 package task
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"flag"
 	"testing"
 
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/testutil"
 	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli/v2"
@@ -393,3 +397,146 @@ func TestTaskWorkflow(t *testing.T) {
 		testutil.AssertTaskState(t, mgr, tasks[0].ID, types.TaskStateInProgress)
 	})
 }
+
+func TestTaskIDAction(t *testing.T) {
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+
+	err := mgr.SetSelectedProject(nil, project.ID, "test-user")
+	require.NoError(t, err)
+
+	_, err = mgr.CreateTask(context.Background(), project.ID, nil, "Write docs", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = mgr.CreateTask(context.Background(), project.ID, nil, "Duplicate", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = mgr.CreateTask(context.Background(), project.ID, nil, "duplicate", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = mgr.CreateTask(context.Background(), project.ID, nil, "Twice", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = mgr.CreateTask(context.Background(), project.ID, nil, "Twice", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	appCtx := &shared.AppContext{
+		ProjectManager: mgr,
+		Logger:         config.Logger,
+	}
+
+	runID := func(title string) error {
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		flagSet.String("title", "", "")
+		_ = flagSet.Set("title", title)
+		return idAction(appCtx)(cli.NewContext(&cli.App{}, flagSet, nil))
+	}
+
+	assert.NoError(t, runID("Write docs"))
+	assert.NoError(t, runID("WRITE DOCS"))
+	assert.NoError(t, runID("Duplicate"), "exact match wins over case-insensitive matches")
+	assert.ErrorContains(t, runID("Twice"), "2 tasks match")
+	assert.ErrorContains(t, runID("Missing"), "no task found")
+}
+
+func TestCreateActionOutput(t *testing.T) {
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+	require.NoError(t, mgr.SetSelectedProject(nil, project.ID, "test-user"))
+
+	var buf bytes.Buffer
+	appCtx := &shared.AppContext{
+		ProjectManager: mgr,
+		Logger:         config.Logger,
+	}
+
+	runCreate := func(title string, quiet bool) *types.Task {
+		buf.Reset()
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		flagSet.String("title", "", "")
+		flagSet.Int("complexity", 3, "")
+		flagSet.String("priority", "medium", "")
+		flagSet.Bool("quiet", false, "")
+		_ = flagSet.Set("title", title)
+		if quiet {
+			_ = flagSet.Set("quiet", "true")
+		}
+		require.NoError(t, createAction(appCtx)(cli.NewContext(&cli.App{}, flagSet, nil)))
+		tasks, err := mgr.ListTasksForProject(context.Background(), project.ID)
+		require.NoError(t, err)
+		for _, task := range tasks {
+			if task.Title == title {
+				return task
+			}
+		}
+		t.Fatalf("task %q was not created", title)
+		return nil
+	}
+
+	appCtx.Output = output.NewTextWriter(&buf)
+	runCreate("Text", false)
+	assert.Contains(t, buf.String(), "Created task: Text")
+	assert.Contains(t, buf.String(), "knot task update-state --id")
+
+	task := runCreate("Quiet", true)
+	assert.Equal(t, task.ID.String()+"\n", buf.String())
+
+	appCtx.Output = output.NewJSONWriter(&buf)
+	task = runCreate("Machine", false)
+	var printed types.Task
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &printed))
+	assert.Equal(t, task.ID, printed.ID)
+	assert.Equal(t, "Machine", printed.Title)
+}
+
+func TestCreateActionAfter(t *testing.T) {
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+	require.NoError(t, mgr.SetSelectedProject(nil, project.ID, "test-user"))
+	design, err := mgr.CreateTask(context.Background(), project.ID, nil, "API Design", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	database, err := mgr.CreateTask(context.Background(), project.ID, nil, "Database Setup", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	appCtx := &shared.AppContext{
+		ProjectManager: mgr,
+		Logger:         config.Logger,
+		Output:         output.NewTextWriter(&buf),
+	}
+	runCreate := func(title string, after ...string) error {
+		flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+		flagSet.String("title", "", "")
+		flagSet.Int("complexity", 3, "")
+		flagSet.String("priority", "medium", "")
+		afterFlag := cli.NewStringSlice()
+		flagSet.Var(afterFlag, "after", "")
+		_ = flagSet.Set("title", title)
+		for _, ref := range after {
+			_ = flagSet.Set("after", ref)
+		}
+		return createAction(appCtx)(cli.NewContext(&cli.App{}, flagSet, nil))
+	}
+
+	require.NoError(t, runCreate("Implement API", "API Design", database.ID.String()[:23], "api design"))
+	assert.Contains(t, buf.String(), "Depends on: API Design")
+	assert.Contains(t, buf.String(), "Depends on: Database Setup")
+
+	tasks, err := mgr.ListTasksForProject(context.Background(), project.ID)
+	require.NoError(t, err)
+	var created *types.Task
+	for _, task := range tasks {
+		if task.Title == "Implement API" {
+			created = task
+		}
+	}
+	require.NotNil(t, created)
+	assert.ElementsMatch(t, []uuid.UUID{design.ID, database.ID}, created.Dependencies)
+
+	// An unknown reference fails before the task is created
+	err = runCreate("Deploy", "Missing Task")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no task with title or ID 'Missing Task'")
+	tasks, err = mgr.ListTasksForProject(context.Background(), project.ID)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 3)
+}
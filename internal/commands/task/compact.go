@@ -0,0 +1,161 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+)
+
+// compactTitleLimit caps task titles in compact listings
+const compactTitleLimit = 60
+
+// defaultCompactFields are the columns of `knot task list --compact`
+var defaultCompactFields = []string{"shortid", "state", "prio", "cx", "title"}
+
+// listField is a column of a compact or field-selected task listing. Text
+// renders the column for compact lines, Value for JSON output.
+type listField struct {
+	Name  string
+	Text  func(task *types.Task) string
+	Value func(task *types.Task) any
+}
+
+// listFields lists the selectable columns in the order of `--fields` help
+var listFields = []listField{
+	{"id", func(t *types.Task) string { return t.ID.String() }, func(t *types.Task) any { return t.ID }},
+	{"shortid", func(t *types.Task) string { return types.ShortID(t.ID) }, func(t *types.Task) any { return types.ShortID(t.ID) }},
+	{"parent", func(t *types.Task) string {
+		if t.ParentID == nil {
+			return "-"
+		}
+		return types.ShortID(*t.ParentID)
+	}, func(t *types.Task) any { return t.ParentID }},
+	{"state", func(t *types.Task) string { return string(t.State) }, func(t *types.Task) any { return t.State }},
+	{"prio", func(t *types.Task) string { return t.Priority.ToExternalString() }, func(t *types.Task) any { return t.Priority.ToExternalString() }},
+	{"cx", func(t *types.Task) string { return fmt.Sprint(t.Complexity) }, func(t *types.Task) any { return t.Complexity }},
+	{"depth", func(t *types.Task) string { return fmt.Sprint(t.Depth) }, func(t *types.Task) any { return t.Depth }},
+	{"estimate", func(t *types.Task) string { return utils.FormatEstimatePtr(t.Estimate) }, func(t *types.Task) any { return t.Estimate }},
+	{"due", func(t *types.Task) string { return utils.FormatDueDate(t.DueDate) }, func(t *types.Task) any { return t.DueDate }},
+	{"agent", func(t *types.Task) string {
+		if t.AssignedAgent == nil {
+			return "-"
+		}
+		return types.ShortID(*t.AssignedAgent)
+	}, func(t *types.Task) any { return t.AssignedAgent }},
+	{"tags", func(t *types.Task) string { return strings.Join(t.Tags, ",") }, func(t *types.Task) any { return t.Tags }},
+	{"title", func(t *types.Task) string { return utils.Truncate(t.Title, compactTitleLimit) }, func(t *types.Task) any { return t.Title }},
+}
+
+// listFieldNames returns the names of all selectable columns
+func listFieldNames() []string {
+	names := make([]string, len(listFields))
+	for i, field := range listFields {
+		names[i] = field.Name
+	}
+	return names
+}
+
+// parseListFields resolves a comma-separated --fields value, in the given
+// order. An empty value selects the default compact columns.
+func parseListFields(value string) ([]listField, error) {
+	names := defaultCompactFields
+	if strings.TrimSpace(value) != "" {
+		names = strings.Split(value, ",")
+	}
+
+	fields := make([]listField, 0, len(names))
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		field, ok := lookupListField(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q, use any of %s", name, strings.Join(listFieldNames(), ", "))
+		}
+		seen[name] = true
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields given, use any of %s", strings.Join(listFieldNames(), ", "))
+	}
+	return fields, nil
+}
+
+func lookupListField(name string) (listField, bool) {
+	for _, field := range listFields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return listField{}, false
+}
+
+// writeCompactHeader writes the column names of a compact listing
+func writeCompactHeader(w io.Writer, fields []listField) {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	fmt.Fprintf(w, "#%s\n", strings.Join(names, "|"))
+}
+
+// writeCompactTask writes a task as one line of '|'-separated columns.
+// Separators and line breaks inside values are replaced so that every
+// task stays on a single line.
+func writeCompactTask(w io.Writer, task *types.Task, fields []listField) {
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		values[i] = compactValueReplacer.Replace(field.Text(task))
+	}
+	fmt.Fprintln(w, strings.Join(values, "|"))
+}
+
+// writeCompactList writes a compact listing, with a header line per lane
+// when the tasks are grouped
+func writeCompactList(w io.Writer, tasks []*types.Task, groupBy string, groups []*taskGroup, fields []listField) {
+	writeCompactHeader(w, fields)
+	if groups == nil {
+		for _, task := range tasks {
+			writeCompactTask(w, task, fields)
+		}
+		return
+	}
+	for _, group := range groups {
+		fmt.Fprintf(w, "## %s %s: %d task(s), %d completed\n", groupBy, group.Key, group.Count, group.Completed)
+		for _, task := range group.Tasks {
+			writeCompactTask(w, task, fields)
+		}
+	}
+}
+
+// compactValueReplacer keeps values from splitting compact lines
+var compactValueReplacer = strings.NewReplacer("|", "/", "\r\n", " ", "\n", " ", "\r", " ")
+
+// selectTaskFields reduces tasks to the selected fields for JSON output
+func selectTaskFields(tasks []*types.Task, fields []listField) []map[string]any {
+	result := make([]map[string]any, len(tasks))
+	for i, task := range tasks {
+		entry := make(map[string]any, len(fields))
+		for _, field := range fields {
+			entry[field.Name] = field.Value(task)
+		}
+		result[i] = entry
+	}
+	return result
+}
+
+// writeSelectedFieldsJSON outputs tasks reduced to the selected fields in JSON format
+func writeSelectedFieldsJSON(w io.Writer, tasks []*types.Task, fields []listField) error {
+	jsonData, err := json.Marshal(selectTaskFields(tasks, fields))
+	if err != nil {
+		return fmt.Errorf("failed to marshal tasks to JSON: %w", err)
+	}
+	fmt.Fprintln(w, string(jsonData))
+	return nil
+}
@@ -0,0 +1,84 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListFields(t *testing.T) {
+	fields, err := parseListFields("")
+	require.NoError(t, err)
+	assert.Equal(t, defaultCompactFields, fieldNames(fields))
+
+	fields, err = parseListFields(" Title, id,title ,,cx")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"title", "id", "cx"}, fieldNames(fields))
+
+	_, err = parseListFields("title,owner")
+	assert.ErrorContains(t, err, `unknown field "owner"`)
+
+	_, err = parseListFields(" , ")
+	assert.Error(t, err)
+}
+
+func TestWriteCompactList(t *testing.T) {
+	parentID := uuid.MustParse("2185605d-5805-44d2-868a-f536afc798fd")
+	tasks := []*types.Task{
+		{ID: parentID, Title: "Build | ship\nit", State: types.TaskStateInProgress, Priority: types.TaskPriorityHigh, Complexity: 5},
+		{ID: uuid.MustParse("8f72ab6b-1fbf-495f-839c-921ed1c4a0b2"), ParentID: &parentID, Title: strings.Repeat("x", 100),
+			State: types.TaskStatePending, Priority: types.TaskPriorityLow, Complexity: 3, Depth: 1},
+	}
+
+	fields, err := parseListFields("")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	writeCompactList(&buf, tasks, "", nil, fields)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "#shortid|state|prio|cx|title", lines[0])
+	assert.Equal(t, "afc798fd|in-progress|high|5|Build / ship it", lines[1])
+	assert.Equal(t, "d1c4a0b2|pending|low|3|"+strings.Repeat("x", compactTitleLimit-1)+"…", lines[2])
+
+	fields, err = parseListFields("shortid,parent")
+	require.NoError(t, err)
+	buf.Reset()
+	writeCompactList(&buf, tasks, "state", groupTasks(tasks, "state"), fields)
+	assert.Equal(t, "#shortid|parent\n"+
+		"## state in-progress: 1 task(s), 0 completed\nafc798fd|-\n"+
+		"## state pending: 1 task(s), 0 completed\nd1c4a0b2|afc798fd\n", buf.String())
+}
+
+func TestWriteSelectedFieldsJSON(t *testing.T) {
+	task := &types.Task{ID: uuid.New(), Title: "Task", State: types.TaskStatePending, Priority: types.TaskPriorityMedium, Complexity: 4}
+	fields, err := parseListFields("id,prio,cx,title")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeSelectedFieldsJSON(&buf, []*types.Task{task}, fields))
+
+	var result []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	require.Len(t, result, 1)
+	assert.Equal(t, map[string]any{
+		"id":    task.ID.String(),
+		"prio":  "medium",
+		"cx":    float64(4),
+		"title": "Task",
+	}, result[0])
+}
+
+func fieldNames(fields []listField) []string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	return names
+}
@@ -0,0 +1,224 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// NewCriteriaCommand creates the task criteria command with its subcommands
+func NewCriteriaCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "criteria",
+		Usage: "Manage acceptance criteria (definition of done) of a task",
+		Description: `Acceptance criteria define when a task is done. Each criterion is verified
+separately; a task cannot be completed while criteria are unverified unless
+the allow-unverified-completion setting is enabled.`,
+		Subcommands: []*cli.Command{
+			{
+				Name:   "add",
+				Usage:  "Add an acceptance criterion to a task",
+				Action: criteriaAddAction(appCtx),
+				Flags: []cli.Flag{
+					shared.NewTaskIDFlag(),
+					&cli.StringFlag{
+						Name:     "text",
+						Aliases:  []string{"t"},
+						Usage:    "Criterion text",
+						Required: true,
+					},
+				},
+			},
+			{
+				Name:   "list",
+				Usage:  "List the acceptance criteria of a task",
+				Action: criteriaListAction(appCtx),
+				Flags: []cli.Flag{
+					shared.NewTaskIDFlag(),
+					shared.NewJSONFlag(),
+				},
+			},
+			{
+				Name:   "verify",
+				Usage:  "Mark an acceptance criterion as verified",
+				Action: criteriaVerifyAction(appCtx),
+				Flags: []cli.Flag{
+					shared.NewTaskIDFlag(),
+					criterionNumberFlag(),
+					&cli.BoolFlag{
+						Name:  "undo",
+						Usage: "Mark the criterion as unverified again",
+					},
+				},
+			},
+			{
+				Name:   "remove",
+				Usage:  "Remove an acceptance criterion",
+				Action: criteriaRemoveAction(appCtx),
+				Flags: []cli.Flag{
+					shared.NewTaskIDFlag(),
+					criterionNumberFlag(),
+				},
+			},
+		},
+	}
+}
+
+func criteriaAddAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskID, err := parseTaskIDFlag(c)
+		if err != nil {
+			return err
+		}
+		actor := shared.ResolveActor(c.String("actor"))
+
+		appCtx.Logger.Info("Adding acceptance criterion",
+			zap.String("taskID", taskID.String()),
+			zap.String("actor", actor))
+
+		task, err := appCtx.ProjectManager.AddAcceptanceCriterion(c.Context, taskID, c.String("text"), actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to add acceptance criterion", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "adding acceptance criterion")
+		}
+
+		appCtx.Out().Printf("Added acceptance criterion %d to task \"%s\"\n", len(task.AcceptanceCriteria), task.Title)
+		appCtx.Out().Printf("  Updated by: %s\n", actor)
+		return nil
+	}
+}
+
+func criteriaListAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskID, err := parseTaskIDFlag(c)
+		if err != nil {
+			return err
+		}
+
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to get task", zap.Error(err))
+			return errors.TaskNotFoundError(taskID)
+		}
+
+		if c.Bool("json") {
+			criteria := task.AcceptanceCriteria
+			if criteria == nil {
+				criteria = []types.AcceptanceCriterion{}
+			}
+			jsonData, err := json.MarshalIndent(criteria, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal criteria to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		if len(task.AcceptanceCriteria) == 0 {
+			appCtx.Out().Printf("Task \"%s\" has no acceptance criteria\n", task.Title)
+			return nil
+		}
+
+		verified := len(task.AcceptanceCriteria) - len(task.UnverifiedCriteria())
+		appCtx.Out().Printf("Acceptance criteria for \"%s\" (%d/%d verified):\n", task.Title, verified, len(task.AcceptanceCriteria))
+		for i, criterion := range task.AcceptanceCriteria {
+			mark := " "
+			if criterion.Verified {
+				mark = "x"
+			}
+			appCtx.Out().Printf("  %d. [%s] %s", i+1, mark, criterion.Text)
+			if criterion.Verified && criterion.VerifiedBy != "" {
+				appCtx.Out().Printf(" (verified by %s)", criterion.VerifiedBy)
+			}
+			appCtx.Out().Println()
+		}
+		return nil
+	}
+}
+
+func criteriaVerifyAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskID, err := parseTaskIDFlag(c)
+		if err != nil {
+			return err
+		}
+		number := c.Int("number")
+		verified := !c.Bool("undo")
+		actor := shared.ResolveActor(c.String("actor"))
+
+		appCtx.Logger.Info("Updating acceptance criterion",
+			zap.String("taskID", taskID.String()),
+			zap.Int("number", number),
+			zap.Bool("verified", verified),
+			zap.String("actor", actor))
+
+		task, err := appCtx.ProjectManager.SetAcceptanceCriterionVerified(c.Context, taskID, number-1, verified, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to update acceptance criterion", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "verifying acceptance criterion")
+		}
+
+		status := "verified"
+		if !verified {
+			status = "unverified"
+		}
+		appCtx.Out().Printf("Acceptance criterion %d of task \"%s\" marked as %s: %s\n",
+			number, task.Title, status, task.AcceptanceCriteria[number-1].Text)
+		if remaining := len(task.UnverifiedCriteria()); remaining > 0 {
+			appCtx.Out().Printf("  %d criteria still unverified\n", remaining)
+		} else {
+			appCtx.Out().Println("  All acceptance criteria are verified")
+		}
+		return nil
+	}
+}
+
+func criteriaRemoveAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskID, err := parseTaskIDFlag(c)
+		if err != nil {
+			return err
+		}
+		number := c.Int("number")
+		actor := shared.ResolveActor(c.String("actor"))
+
+		appCtx.Logger.Info("Removing acceptance criterion",
+			zap.String("taskID", taskID.String()),
+			zap.Int("number", number),
+			zap.String("actor", actor))
+
+		task, err := appCtx.ProjectManager.RemoveAcceptanceCriterion(c.Context, taskID, number-1, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to remove acceptance criterion", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "removing acceptance criterion")
+		}
+
+		appCtx.Out().Printf("Removed acceptance criterion %d from task \"%s\" (%d remaining)\n",
+			number, task.Title, len(task.AcceptanceCriteria))
+		return nil
+	}
+}
+
+func criterionNumberFlag() cli.Flag {
+	return &cli.IntFlag{
+		Name:     "number",
+		Aliases:  []string{"n"},
+		Usage:    "Criterion number as shown by 'knot task criteria list'",
+		Required: true,
+	}
+}
+
+func parseTaskIDFlag(c *cli.Context) (uuid.UUID, error) {
+	taskIDStr := c.String("id")
+	taskID, err := uuid.Parse(taskIDStr)
+	if err != nil {
+		return uuid.Nil, errors.InvalidUUIDError("task-id", taskIDStr)
+	}
+	return taskID, nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
 
 	"github.com/denkhaus/knot/v2/internal/errors"
@@ -20,7 +21,7 @@ func DeletionCommands(appCtx *shared.AppContext) []*cli.Command {
 	return []*cli.Command{
 		{
 			Name:   "delete",
-			Usage:  "Delete a task with two-step confirmation. Use --all to delete task and all descendants",
+			Usage:  "Delete a task with two-step confirmation. Use --all or --children to handle descendants",
 			Action: deleteAction(appCtx),
 			Flags: []cli.Flag{
 				&cli.StringFlag{
@@ -38,6 +39,14 @@ func DeletionCommands(appCtx *shared.AppContext) []*cli.Command {
 					Usage: "Delete task and all descendants recursively",
 					Value: false,
 				},
+				&cli.StringFlag{
+					Name:  "children",
+					Usage: "What happens to child tasks: promote (to the parent of the deleted task), reparent-to (the task given by --parent-id) or delete",
+				},
+				&cli.StringFlag{
+					Name:  "parent-id",
+					Usage: "New parent task ID for --children reparent-to",
+				},
 			},
 		},
 	}
@@ -55,25 +64,33 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 		dryRun := c.Bool("dry-run")
 		deleteAll := c.Bool("all")
 
+		policy, newParentID, err := parseChildPolicyFlags(c)
+		if err != nil {
+			return err
+		}
+		if policy == manager.ChildPolicyDelete {
+			deleteAll = true
+		}
+
 		// Get task details
-		task, err := appCtx.ProjectManager.GetTask(context.Background(), taskID)
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
 		if err != nil {
 			return errors.TaskNotFoundError(taskID)
 		}
 
 		// Check if task has children
-		children, err := appCtx.ProjectManager.GetChildTasks(context.Background(), taskID)
+		children, err := appCtx.ProjectManager.GetChildTasks(c.Context, taskID)
 		if err != nil {
 			return errors.WrapWithSuggestion(err, "checking child tasks")
 		}
 
 		// Handle task with children based on --all flag
-		if len(children) > 0 && !deleteAll {
+		if len(children) > 0 && !deleteAll && policy == "" {
 			return &errors.EnhancedError{
 				Operation:   "deleting task",
 				Cause:       fmt.Errorf("task has %d child task(s)", len(children)),
-				Suggestion:  "Either delete all child tasks first, or use the --all flag to delete the entire hierarchy",
-				Example:     fmt.Sprintf("knot task delete --id %s --all", taskID),
+				Suggestion:  "Choose a child policy: --children promote moves them up one level, --children reparent-to --parent-id <id> moves them below another task, --all deletes the entire hierarchy",
+				Example:     fmt.Sprintf("knot task delete --id %s --children promote", taskID),
 				HelpCommand: "knot task children --task-id " + taskID.String(),
 			}
 		}
@@ -81,7 +98,7 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 		// If --all flag is used, get all descendants for subtree deletion
 		var descendants []*types.Task
 		if deleteAll {
-			descendants, err = getTaskDescendants(appCtx.ProjectManager, taskID)
+			descendants, err = getTaskDescendants(c.Context, appCtx.ProjectManager, taskID)
 			if err != nil {
 				return errors.WrapWithSuggestion(err, "getting task descendants")
 			}
@@ -93,47 +110,52 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 			if dryRun {
 				if deleteAll {
 					totalTasks := 1 + len(descendants)
-					fmt.Printf("DRY RUN: Task subtree would be permanently deleted (%d tasks, no actual changes made)\n", totalTasks)
+					appCtx.Out().Printf("DRY RUN: Task subtree would be permanently deleted (%d tasks, no actual changes made)\n", totalTasks)
 				} else {
-					fmt.Printf("DRY RUN: Task would be permanently deleted (no actual changes made)\n")
+					appCtx.Out().Printf("DRY RUN: Task would be permanently deleted (no actual changes made)\n")
 				}
 				return nil
 			}
 
 			// Show what will be deleted
 			if deleteAll {
-				fmt.Printf("Final deletion of task subtree:\n")
-				fmt.Printf("  %s (ID: %s) [ROOT]\n", task.Title, task.ID)
+				appCtx.Out().Printf("Final deletion of task subtree:\n")
+				appCtx.Out().Printf("  %s (ID: %s) [ROOT]\n", task.Title, task.ID)
 
 				if len(descendants) > 0 {
-					fmt.Printf("  └── %d descendant task(s):\n", len(descendants))
+					appCtx.Out().Printf("  └── %d descendant task(s):\n", len(descendants))
 					for _, desc := range descendants {
 						indent := strings.Repeat("  ", desc.Depth-task.Depth+1)
-						fmt.Printf("  %s├─ %s (ID: %s)\n", indent, desc.Title, desc.ID)
+						appCtx.Out().Printf("  %s├─ %s (ID: %s)\n", indent, desc.Title, desc.ID)
 					}
 				}
 
 				totalTasks := 1 + len(descendants)
-				fmt.Printf("\nTotal tasks to delete: %d\n", totalTasks)
+				appCtx.Out().Printf("\nTotal tasks to delete: %d\n", totalTasks)
 
 				// Perform subtree deletion
-				err = appCtx.ProjectManager.DeleteTaskSubtree(context.Background(), taskID, appCtx.Actor)
+				err = appCtx.ProjectManager.DeleteTaskSubtree(c.Context, taskID, appCtx.Actor)
 				if err != nil {
 					appCtx.Logger.Error("Failed to delete task subtree", zap.Error(err))
 					return errors.WrapWithSuggestion(err, "deleting task subtree")
 				}
 
 				appCtx.Logger.Info("Task subtree deleted successfully", zap.Int("totalDeleted", totalTasks))
-				fmt.Printf("Task subtree permanently deleted: %d task(s) removed\n", totalTasks)
+				appCtx.Out().Printf("Task subtree permanently deleted: %d task(s) removed\n", totalTasks)
 			} else {
-				fmt.Printf("Final deletion of task:\n")
-				fmt.Printf("  • %s (ID: %s)\n", task.Title, task.ID)
+				appCtx.Out().Printf("Final deletion of task:\n")
+				appCtx.Out().Printf("  • %s (ID: %s)\n", task.Title, task.ID)
 				if task.Description != "" {
-					fmt.Printf("    %s\n", task.Description)
+					appCtx.Out().Printf("    %s\n", task.Description)
 				}
 
-				// Perform single task deletion
-				err = appCtx.ProjectManager.DeleteTask(context.Background(), taskID, appCtx.Actor)
+				// Perform single task deletion, moving the children first
+				if len(children) > 0 {
+					printChildPolicy(appCtx.Out(), children, policy, newParentID)
+					err = appCtx.ProjectManager.DeleteTaskWithChildren(c.Context, taskID, policy, newParentID, appCtx.Actor)
+				} else {
+					err = appCtx.ProjectManager.DeleteTask(c.Context, taskID, appCtx.Actor)
+				}
 				if err != nil {
 					return &errors.EnhancedError{
 						Operation:   "deleting task",
@@ -143,7 +165,7 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 					}
 				}
 
-				fmt.Printf("Task permanently deleted: %s\n", task.Title)
+				appCtx.Out().Printf("Task permanently deleted: %s\n", task.Title)
 			}
 			return nil
 		} else {
@@ -151,80 +173,85 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 			if dryRun {
 				if deleteAll {
 					totalTasks := 1 + len(descendants)
-					fmt.Printf("DRY RUN: Task subtree would be marked for deletion (%d tasks, no actual changes made)\n", totalTasks)
+					appCtx.Out().Printf("DRY RUN: Task subtree would be marked for deletion (%d tasks, no actual changes made)\n", totalTasks)
 				} else {
-					fmt.Printf("DRY RUN: Task would be marked for deletion (no actual changes made)\n")
+					appCtx.Out().Printf("DRY RUN: Task would be marked for deletion (no actual changes made)\n")
 				}
 				return nil
 			}
 
 			// Show what will be marked for deletion
 			if deleteAll {
-				fmt.Printf("Task subtree to be marked for deletion:\n")
-				fmt.Printf("  %s (ID: %s) [ROOT]\n", task.Title, task.ID)
+				appCtx.Out().Printf("Task subtree to be marked for deletion:\n")
+				appCtx.Out().Printf("  %s (ID: %s) [ROOT]\n", task.Title, task.ID)
 				if task.Description != "" {
-					fmt.Printf("    %s\n", task.Description)
+					appCtx.Out().Printf("    %s\n", task.Description)
 				}
-				fmt.Printf("    Current State: %s | Complexity: %d\n", task.State, task.Complexity)
+				appCtx.Out().Printf("    Current State: %s | Complexity: %d\n", task.State, task.Complexity)
 
 				if len(descendants) > 0 {
-					fmt.Printf("  └── %d descendant task(s):\n", len(descendants))
+					appCtx.Out().Printf("  └── %d descendant task(s):\n", len(descendants))
 					for _, desc := range descendants {
 						indent := strings.Repeat("  ", desc.Depth-task.Depth+1)
-						fmt.Printf("  %s├─ %s (ID: %s) - State: %s\n", indent, desc.Title, desc.ID, desc.State)
+						appCtx.Out().Printf("  %s├─ %s (ID: %s) - State: %s\n", indent, desc.Title, desc.ID, desc.State)
 					}
 				}
 
 				totalTasks := 1 + len(descendants)
-				fmt.Printf("\nTotal tasks to mark for deletion: %d\n", totalTasks)
+				appCtx.Out().Printf("\nTotal tasks to mark for deletion: %d\n", totalTasks)
 
 				// Check for dependencies on any task in the subtree
-				err = checkSubtreeDependencies(appCtx, task, descendants)
+				err = checkSubtreeDependencies(c.Context, appCtx, task, descendants)
 				if err != nil {
 					return err
 				}
 
-				fmt.Printf("\nTask subtree marked for deletion. To confirm deletion, run the same command again:\n")
-				fmt.Printf("    knot task delete --id %s --all\n", taskID)
+				appCtx.Out().Printf("\nTask subtree marked for deletion. To confirm deletion, run the same command again:\n")
+				appCtx.Out().Printf("    knot task delete --id %s --all\n", taskID)
 			} else {
-				fmt.Printf("Task to be marked for deletion:\n")
-				fmt.Printf("  • %s (ID: %s)\n", task.Title, task.ID)
+				appCtx.Out().Printf("Task to be marked for deletion:\n")
+				appCtx.Out().Printf("  • %s (ID: %s)\n", task.Title, task.ID)
 				if task.Description != "" {
-					fmt.Printf("    %s\n", task.Description)
+					appCtx.Out().Printf("    %s\n", task.Description)
 				}
-				fmt.Printf("    Current State: %s | Complexity: %d\n", task.State, task.Complexity)
+				appCtx.Out().Printf("    Current State: %s | Complexity: %d\n", task.State, task.Complexity)
 
 				// Check for dependencies
-				dependencies, err := appCtx.ProjectManager.GetTaskDependencies(context.Background(), taskID)
+				dependencies, err := appCtx.ProjectManager.GetTaskDependencies(c.Context, taskID)
 				if err == nil && len(dependencies) > 0 {
-					fmt.Printf("\n  This task depends on %d other task(s):\n", len(dependencies))
+					appCtx.Out().Printf("\n  This task depends on %d other task(s):\n", len(dependencies))
 					for _, dep := range dependencies {
-						fmt.Printf("    • %s (ID: %s)\n", dep.Title, dep.ID)
+						appCtx.Out().Printf("    • %s (ID: %s)\n", dep.Title, dep.ID)
 					}
 				}
 
-				dependents, err := appCtx.ProjectManager.GetDependentTasks(context.Background(), taskID)
+				dependents, err := appCtx.ProjectManager.GetDependentTasks(c.Context, taskID)
 				if err == nil && len(dependents) > 0 {
-					fmt.Printf("\n  %d task(s) depend on this task:\n", len(dependents))
+					appCtx.Out().Printf("\n  %d task(s) depend on this task:\n", len(dependents))
 					for _, dep := range dependents {
-						fmt.Printf("    • %s (ID: %s)\n", dep.Title, dep.ID)
+						appCtx.Out().Printf("    • %s (ID: %s)\n", dep.Title, dep.ID)
 					}
-					fmt.Printf("    These dependencies will be removed.\n")
+					appCtx.Out().Printf("    These dependencies will be removed.\n")
+				}
+
+				if len(children) > 0 {
+					appCtx.Out().Println()
+					printChildPolicy(appCtx.Out(), children, policy, newParentID)
 				}
 
-				fmt.Printf("\nTask marked for deletion. To confirm deletion, run the same command again:\n")
-				fmt.Printf("    knot task delete --id %s\n", taskID)
+				appCtx.Out().Printf("\nTask marked for deletion. To confirm deletion, run the same command again:\n")
+				appCtx.Out().Printf("    knot task delete --id %s%s\n", taskID, childPolicyArgs(c))
 			}
 
-			fmt.Printf("\nTo cancel deletion, change the task state:\n")
-			fmt.Printf("    knot task update-state --id %s --state pending\n", taskID)
+			appCtx.Out().Advise("\nTo cancel deletion, change the task state:\n")
+			appCtx.Out().Advise("    knot task update-state --id %s --state pending\n", taskID)
 
 			if deleteAll {
-				fmt.Printf("\nNote: Only the root task is marked as deletion-pending. All descendants will be deleted when confirmed.\n")
+				appCtx.Out().Printf("\nNote: Only the root task is marked as deletion-pending. All descendants will be deleted when confirmed.\n")
 			}
 
 			// Mark root task for deletion (triggers subtree deletion if --all was used)
-			_, err = appCtx.ProjectManager.UpdateTask(context.Background(), task.ID, task.Title, task.Description, task.Complexity, types.TaskStateDeletionPending, appCtx.Actor)
+			_, err = appCtx.ProjectManager.UpdateTask(c.Context, task.ID, task.Title, task.Description, task.Complexity, types.TaskStateDeletionPending, appCtx.Actor)
 			if err != nil {
 				return &errors.EnhancedError{
 					Operation:   "marking task for deletion",
@@ -239,6 +266,69 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 	}
 }
 
+// parseChildPolicyFlags reads --children and --parent-id
+func parseChildPolicyFlags(c *cli.Context) (manager.ChildPolicy, *uuid.UUID, error) {
+	if !c.IsSet("children") {
+		if c.IsSet("parent-id") {
+			return "", nil, errors.NewValidationError("--parent-id requires --children reparent-to",
+				fmt.Errorf("--parent-id is only used with --children reparent-to"))
+		}
+		return "", nil, nil
+	}
+
+	policy, err := manager.ParseChildPolicy(c.String("children"))
+	if err != nil {
+		return "", nil, errors.NewValidationError("invalid child policy", err)
+	}
+	if c.Bool("all") && policy != manager.ChildPolicyDelete {
+		return "", nil, errors.NewValidationError("conflicting child policies",
+			fmt.Errorf("--all deletes all descendants and cannot be combined with --children %s", policy))
+	}
+
+	if policy != manager.ChildPolicyReparent {
+		if c.IsSet("parent-id") {
+			return "", nil, errors.NewValidationError("--parent-id requires --children reparent-to",
+				fmt.Errorf("--parent-id is not used with --children %s", policy))
+		}
+		return policy, nil, nil
+	}
+
+	parentIDStr := c.String("parent-id")
+	if parentIDStr == "" {
+		return "", nil, errors.NewValidationError("missing new parent",
+			fmt.Errorf("--children reparent-to requires --parent-id"))
+	}
+	parentID, err := uuid.Parse(parentIDStr)
+	if err != nil {
+		return "", nil, errors.InvalidUUIDError("parent-id", parentIDStr)
+	}
+	return policy, &parentID, nil
+}
+
+// childPolicyArgs repeats the child policy flags for the confirmation command
+func childPolicyArgs(c *cli.Context) string {
+	args := ""
+	if c.IsSet("children") {
+		args += " --children " + c.String("children")
+	}
+	if c.IsSet("parent-id") {
+		args += " --parent-id " + c.String("parent-id")
+	}
+	return args
+}
+
+// printChildPolicy describes what happens to the children of a deleted task
+func printChildPolicy(out output.Writer, children []*types.Task, policy manager.ChildPolicy, newParentID *uuid.UUID) {
+	switch policy {
+	case manager.ChildPolicyPromote:
+		out.Printf("  %d child task(s) will be promoted to the parent of this task:\n", len(children))
+	case manager.ChildPolicyReparent:
+		out.Printf("  %d child task(s) will be moved below task %s:\n", len(children), newParentID)
+	}
+	for _, child := range children {
+		out.Printf("    • %s (ID: %s)\n", child.Title, child.ID)
+	}
+}
 
 // confirmDeletion prompts user for confirmation
 // Currently unused but kept for potential future use
@@ -254,7 +344,7 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 // }
 
 // getTaskDescendants recursively gets all descendants of a task (renamed to avoid conflict)
-func getTaskDescendants(projectManager manager.ProjectManager, taskID uuid.UUID) ([]*types.Task, error) {
+func getTaskDescendants(ctx context.Context, projectManager manager.ProjectManager, taskID uuid.UUID) ([]*types.Task, error) {
 	var result []*types.Task
 	visited := make(map[uuid.UUID]bool)
 
@@ -265,7 +355,7 @@ func getTaskDescendants(projectManager manager.ProjectManager, taskID uuid.UUID)
 		}
 		visited[id] = true
 
-		children, err := projectManager.GetChildTasks(context.Background(), id)
+		children, err := projectManager.GetChildTasks(ctx, id)
 		if err != nil {
 			return err
 		}
@@ -288,15 +378,15 @@ func getTaskDescendants(projectManager manager.ProjectManager, taskID uuid.UUID)
 }
 
 // checkSubtreeDependencies checks for external dependencies on tasks in the subtree
-func checkSubtreeDependencies(appCtx *shared.AppContext, rootTask *types.Task, descendants []*types.Task) error {
+func checkSubtreeDependencies(ctx context.Context, appCtx *shared.AppContext, rootTask *types.Task, descendants []*types.Task) error {
 	allTasks := append([]*types.Task{rootTask}, descendants...)
 
 	// Check dependencies for root task
-	dependencies, err := appCtx.ProjectManager.GetTaskDependencies(context.Background(), rootTask.ID)
+	dependencies, err := appCtx.ProjectManager.GetTaskDependencies(ctx, rootTask.ID)
 	if err == nil && len(dependencies) > 0 {
-		fmt.Printf("\n  Root task depends on %d other task(s):\n", len(dependencies))
+		appCtx.Out().Printf("\n  Root task depends on %d other task(s):\n", len(dependencies))
 		for _, dep := range dependencies {
-			fmt.Printf("    • %s (ID: %s)\n", dep.Title, dep.ID)
+			appCtx.Out().Printf("    • %s (ID: %s)\n", dep.Title, dep.ID)
 		}
 	}
 
@@ -308,7 +398,7 @@ func checkSubtreeDependencies(appCtx *shared.AppContext, rootTask *types.Task, d
 	}
 
 	for _, task := range allTasks {
-		dependents, err := appCtx.ProjectManager.GetDependentTasks(context.Background(), task.ID)
+		dependents, err := appCtx.ProjectManager.GetDependentTasks(ctx, task.ID)
 		if err != nil {
 			continue
 		}
@@ -322,11 +412,11 @@ func checkSubtreeDependencies(appCtx *shared.AppContext, rootTask *types.Task, d
 	}
 
 	if len(externalDependents) > 0 {
-		fmt.Printf("\n  %d external task(s) depend on tasks in this subtree:\n", len(externalDependents))
+		appCtx.Out().Printf("\n  %d external task(s) depend on tasks in this subtree:\n", len(externalDependents))
 		for _, dep := range externalDependents {
-			fmt.Printf("    • %s (ID: %s)\n", dep.Title, dep.ID)
+			appCtx.Out().Printf("    • %s (ID: %s)\n", dep.Title, dep.ID)
 		}
-		fmt.Printf("    These dependencies will be removed when the subtree is deleted.\n")
+		appCtx.Out().Printf("    These dependencies will be removed when the subtree is deleted.\n")
 	}
 
 	return nil
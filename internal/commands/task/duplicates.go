@@ -0,0 +1,62 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+)
+
+// maxDuplicateCandidates is the number of similar tasks listed by the duplicate check
+const maxDuplicateCandidates = 5
+
+// checkDuplicates looks for tasks in the project with a title similar to the
+// title of a new task. Depending on the DuplicateCheck setting it warns on
+// stderr, so --quiet output stays usable, or refuses to create the task.
+func checkDuplicates(c *cli.Context, appCtx *shared.AppContext, projectID uuid.UUID, title string) error {
+	config := appCtx.ProjectManager.GetConfig()
+	mode := config.DuplicateCheckMode()
+	if mode == manager.DuplicateCheckOff || c.Bool("no-dup-check") {
+		return nil
+	}
+
+	tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+	if err != nil {
+		return errors.WrapWithSuggestion(err, "checking for similar tasks in project")
+	}
+	similar := analysis.FindSimilarTasks(title, tasks, config.DuplicateSimilarity())
+	if len(similar) == 0 {
+		return nil
+	}
+
+	if mode == manager.DuplicateCheckBlock {
+		return &errors.EnhancedError{
+			Operation:  "creating task",
+			Cause:      fmt.Errorf("a task with a similar title already exists in the project:\n%s", strings.TrimSuffix(formatSimilarTasks(similar), "\n")),
+			Suggestion: "Continue with the existing task, or pass --no-dup-check to create the task anyway",
+			Example:    fmt.Sprintf("knot task create --title %q --no-dup-check", title),
+		}
+	}
+
+	fmt.Fprintf(c.App.ErrWriter, "Warning: a task with a similar title already exists in the project:\n%s", formatSimilarTasks(similar))
+	fmt.Fprintln(c.App.ErrWriter, "Pass --no-dup-check to skip this check.")
+	return nil
+}
+
+// formatSimilarTasks lists the most similar tasks, one per line
+func formatSimilarTasks(similar []analysis.SimilarTask) string {
+	var b strings.Builder
+	for i, match := range similar {
+		if i == maxDuplicateCandidates {
+			fmt.Fprintf(&b, "  ... and %d more\n", len(similar)-i)
+			break
+		}
+		fmt.Fprintf(&b, "  - %s (ID: %s, %s, %.0f%% similar)\n", match.Task.Title, match.Task.ID, match.Task.State, match.Similarity*100)
+	}
+	return b.String()
+}
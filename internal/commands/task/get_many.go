@@ -0,0 +1,110 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// NewGetManyCommand creates the task get-many command, which loads several
+// tasks in one invocation
+func NewGetManyCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "get-many",
+		Usage: "Get several tasks by ID in one call",
+		Description: `Loads the given tasks with their dependencies in a single batch query, in
+the order of --ids. Agents resolving several referenced tasks can use it
+instead of one 'knot task get' per ID.`,
+		Action: getManyAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "ids",
+				Aliases:  []string{"task-ids"},
+				Usage:    "Comma-separated list of task IDs",
+				Required: true,
+			},
+			shared.NewJSONFlag(),
+		},
+	}
+}
+
+func getManyAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskIDs, err := parseTaskIDList(c.String("ids"))
+		if err != nil {
+			return errors.NewValidationError("invalid --ids", err)
+		}
+
+		appCtx.Logger.Info("Getting tasks", zap.Int("taskCount", len(taskIDs)))
+
+		tasks, err := appCtx.ProjectManager.GetTasksWithDependencies(c.Context, taskIDs)
+		if err != nil || len(tasks) != len(taskIDs) {
+			// The batch loader does not name missing tasks, so look for the first one
+			for _, taskID := range taskIDs {
+				if _, getErr := appCtx.ProjectManager.GetTask(c.Context, taskID); getErr != nil {
+					return errors.TaskNotFoundError(taskID)
+				}
+			}
+			if err != nil {
+				appCtx.Logger.Error("Failed to get tasks", zap.Error(err))
+				return errors.WrapWithSuggestion(err, "loading tasks")
+			}
+		}
+
+		// Restore the order of --ids, the batch loader returns tasks in any order
+		byID := make(map[uuid.UUID]*types.Task, len(tasks))
+		for _, task := range tasks {
+			byID[task.ID] = task
+		}
+		ordered := make([]*types.Task, 0, len(taskIDs))
+		for _, taskID := range taskIDs {
+			ordered = append(ordered, byID[taskID])
+		}
+
+		if c.Bool("json") {
+			return appCtx.Out().JSON(ordered)
+		}
+
+		for i, task := range ordered {
+			appCtx.Out().Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
+			appCtx.Out().Printf("   State: %s | Priority: %s | Complexity: %d | Depth: %d%s\n",
+				task.State, task.Priority.ToExternalString(), task.Complexity, task.Depth, utils.EstimateSuffix(task))
+			if len(task.Dependencies) > 0 {
+				appCtx.Out().Printf("   Dependencies: %s\n", strings.Join(utils.ConvertUUIDsToStrings(task.Dependencies), ", "))
+			}
+		}
+		return nil
+	}
+}
+
+// parseTaskIDList parses a comma-separated list of task IDs, skipping
+// duplicates
+func parseTaskIDList(value string) ([]uuid.UUID, error) {
+	var taskIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, idStr := range strings.Split(value, ",") {
+		idStr = strings.TrimSpace(idStr)
+		if idStr == "" {
+			continue
+		}
+		taskID, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task ID '%s': %w", idStr, err)
+		}
+		if !seen[taskID] {
+			seen[taskID] = true
+			taskIDs = append(taskIDs, taskID)
+		}
+	}
+	if len(taskIDs) == 0 {
+		return nil, fmt.Errorf("no valid task IDs provided")
+	}
+	return taskIDs, nil
+}
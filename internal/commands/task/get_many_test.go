@@ -0,0 +1,23 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaskIDList(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	ids, err := parseTaskIDList(a.String() + ", " + b.String() + "," + a.String() + ",")
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{a, b}, ids, "duplicates and empty entries are skipped")
+
+	_, err = parseTaskIDList(a.String() + ",nope")
+	assert.ErrorContains(t, err, "invalid task ID 'nope'")
+
+	_, err = parseTaskIDList(" , ")
+	assert.ErrorContains(t, err, "no valid task IDs provided")
+}
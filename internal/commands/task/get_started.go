@@ -25,7 +25,7 @@ func GetStartedAction(appCtx *shared.AppContext) cli.ActionFunc {
 			return fmt.Errorf("failed to read get-started content: %w", err)
 		}
 
-		fmt.Print(string(content))
+		appCtx.Out().Print(string(content))
 		return nil
 	}
 }
@@ -0,0 +1,141 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// groupByFields lists the fields tasks can be grouped by in listings
+var groupByFields = []string{"state", "priority", "agent", "tag"}
+
+// Lane names for tasks without a value in the grouped field
+const (
+	unassignedLane = "unassigned"
+	untaggedLane   = "untagged"
+)
+
+// laneOrder lists known lanes in review order; other lanes follow sorted by name
+var laneOrder = map[string][]string{
+	"state": {
+		string(types.TaskStateInProgress),
+		string(types.TaskStateBlocked),
+		string(types.TaskStatePending),
+		string(types.TaskStateCompleted),
+		string(types.TaskStateCancelled),
+		string(types.TaskStateDeletionPending),
+	},
+	"priority": {"high", "medium", "low"},
+}
+
+// taskGroup is one lane of a grouped task listing with its rollups
+type taskGroup struct {
+	Key             string        `json:"key"`
+	Count           int           `json:"count"`
+	Completed       int           `json:"completed"`
+	TotalComplexity int           `json:"total_complexity"`
+	EstimateMinutes int64         `json:"estimate_minutes"`
+	Tasks           []*types.Task `json:"tasks"`
+}
+
+// CompletionPercentage returns the share of completed tasks in the group
+func (g *taskGroup) CompletionPercentage() float64 {
+	if g.Count == 0 {
+		return 0
+	}
+	return float64(g.Completed) / float64(g.Count) * 100
+}
+
+func (g *taskGroup) add(task *types.Task) {
+	g.Tasks = append(g.Tasks, task)
+	g.Count++
+	if task.State == types.TaskStateCompleted {
+		g.Completed++
+	}
+	g.TotalComplexity += task.Complexity
+	if task.Estimate != nil {
+		g.EstimateMinutes += *task.Estimate
+	}
+}
+
+// parseGroupBy validates a --group-by value
+func parseGroupBy(field string) (string, error) {
+	if name, ok := strings.CutPrefix(field, "custom:"); ok {
+		return "", fmt.Errorf("cannot group by custom field %q: tasks have no custom fields, group by one of %s",
+			name, strings.Join(groupByFields, ", "))
+	}
+	for _, valid := range groupByFields {
+		if field == valid {
+			return field, nil
+		}
+	}
+	return "", fmt.Errorf("invalid group-by field %q, use one of %s", field, strings.Join(groupByFields, ", "))
+}
+
+// laneKeys returns the lanes a task belongs to. Tasks with several tags
+// appear in the lane of each tag.
+func laneKeys(task *types.Task, field string) []string {
+	switch field {
+	case "state":
+		return []string{string(task.State)}
+	case "priority":
+		return []string{task.Priority.ToExternalString()}
+	case "agent":
+		if task.AssignedAgent == nil {
+			return []string{unassignedLane}
+		}
+		return []string{task.AssignedAgent.String()}
+	case "tag":
+		if len(task.Tags) == 0 {
+			return []string{untaggedLane}
+		}
+		return task.Tags
+	}
+	return nil
+}
+
+// groupTasks splits tasks into lanes by field, keeping the order of tasks
+// within each lane
+func groupTasks(tasks []*types.Task, field string) []*taskGroup {
+	groups := make(map[string]*taskGroup)
+	for _, task := range tasks {
+		for _, key := range laneKeys(task, field) {
+			group, ok := groups[key]
+			if !ok {
+				group = &taskGroup{Key: key, Tasks: []*types.Task{}}
+				groups[key] = group
+			}
+			group.add(task)
+		}
+	}
+
+	rank := make(map[string]int)
+	for i, key := range laneOrder[field] {
+		rank[key] = i + 1
+	}
+	lanes := make([]*taskGroup, 0, len(groups))
+	for _, group := range groups {
+		lanes = append(lanes, group)
+	}
+	sort.Slice(lanes, func(i, j int) bool {
+		a, b := lanes[i].Key, lanes[j].Key
+		// Lanes without a value come last
+		if emptyA, emptyB := isEmptyLane(a), isEmptyLane(b); emptyA != emptyB {
+			return emptyB
+		}
+		if rank[a] != rank[b] {
+			if rank[a] == 0 || rank[b] == 0 {
+				return rank[b] == 0
+			}
+			return rank[a] < rank[b]
+		}
+		return a < b
+	})
+	return lanes
+}
+
+func isEmptyLane(key string) bool {
+	return key == unassignedLane || key == untaggedLane
+}
@@ -0,0 +1,75 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupTasks(t *testing.T) {
+	agent := uuid.New()
+	estimate := int64(90)
+	tasks := []*types.Task{
+		{Title: "design", State: types.TaskStateCompleted, Priority: types.TaskPriorityHigh, Complexity: 3, Tags: []string{"backend", "api"}, AssignedAgent: &agent, Estimate: &estimate},
+		{Title: "build", State: types.TaskStateInProgress, Priority: types.TaskPriorityMedium, Complexity: 5, Tags: []string{"backend"}},
+		{Title: "docs", State: types.TaskStatePending, Priority: types.TaskPriorityLow, Complexity: 2},
+		{Title: "review", State: types.TaskStatePending, Priority: types.TaskPriorityHigh, Complexity: 1, AssignedAgent: &agent},
+	}
+
+	keys := func(groups []*taskGroup) []string {
+		result := make([]string, len(groups))
+		for i, group := range groups {
+			result[i] = group.Key
+		}
+		return result
+	}
+	titles := func(group *taskGroup) []string {
+		result := make([]string, len(group.Tasks))
+		for i, task := range group.Tasks {
+			result[i] = task.Title
+		}
+		return result
+	}
+
+	t.Run("state lanes follow review order", func(t *testing.T) {
+		groups := groupTasks(tasks, "state")
+		assert.Equal(t, []string{"in-progress", "pending", "completed"}, keys(groups))
+		assert.Equal(t, []string{"docs", "review"}, titles(groups[1]))
+		assert.Equal(t, 2, groups[1].Count)
+		assert.Equal(t, 3, groups[1].TotalComplexity)
+	})
+
+	t.Run("priority lanes", func(t *testing.T) {
+		groups := groupTasks(tasks, "priority")
+		assert.Equal(t, []string{"high", "medium", "low"}, keys(groups))
+		assert.Equal(t, 1, groups[0].Completed)
+		assert.InDelta(t, 50.0, groups[0].CompletionPercentage(), 0.01)
+		assert.Equal(t, int64(90), groups[0].EstimateMinutes)
+	})
+
+	t.Run("unassigned tasks come last", func(t *testing.T) {
+		groups := groupTasks(tasks, "agent")
+		assert.Equal(t, []string{agent.String(), unassignedLane}, keys(groups))
+	})
+
+	t.Run("tasks appear in the lane of each tag", func(t *testing.T) {
+		groups := groupTasks(tasks, "tag")
+		assert.Equal(t, []string{"api", "backend", untaggedLane}, keys(groups))
+		assert.Equal(t, []string{"design", "build"}, titles(groups[1]))
+	})
+}
+
+func TestParseGroupBy(t *testing.T) {
+	field, err := parseGroupBy("tag")
+	require.NoError(t, err)
+	assert.Equal(t, "tag", field)
+
+	_, err = parseGroupBy("owner")
+	assert.ErrorContains(t, err, "invalid group-by field")
+
+	_, err = parseGroupBy("custom:team")
+	assert.ErrorContains(t, err, "no custom fields")
+}
@@ -1,11 +1,15 @@
 package task
 
 import (
-	"context"
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/utils"
 
 	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
@@ -23,6 +27,7 @@ func HierarchyCommands(appCtx *shared.AppContext) []*cli.Command {
 			Flags: []cli.Flag{
 				&cli.StringFlag{
 					Name:     "task-id",
+					Aliases:  []string{"id"},
 					Usage:    "Parent task ID",
 					Required: true,
 				},
@@ -31,6 +36,11 @@ func HierarchyCommands(appCtx *shared.AppContext) []*cli.Command {
 					Usage: "Show all descendants (children of children)",
 					Value: false,
 				},
+				&cli.IntFlag{
+					Name:  "depth",
+					Usage: "Maximum levels below the task to show with --recursive (0 = no limit)",
+					Value: 0,
+				},
 			},
 		},
 		{
@@ -45,6 +55,20 @@ func HierarchyCommands(appCtx *shared.AppContext) []*cli.Command {
 				},
 			},
 		},
+		{
+			Name:   "path",
+			Usage:  "Show the chain of parent tasks from the project down to a task",
+			Action: PathAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "id",
+					Aliases:  []string{"task-id"},
+					Usage:    "Task ID",
+					Required: true,
+				},
+				shared.NewJSONFlag(),
+			},
+		},
 		{
 			Name:   "roots",
 			Usage:  "Get root tasks of a project",
@@ -73,6 +97,10 @@ func HierarchyCommands(appCtx *shared.AppContext) []*cli.Command {
 					Name:  "root-task-id",
 					Usage: "Show tree starting from specific task",
 				},
+				&cli.BoolFlag{
+					Name:  "numbered",
+					Usage: "Number the tasks by their position in the tree (1, 1.1, 1.1.2)",
+				},
 			},
 		},
 	}
@@ -88,13 +116,21 @@ func ChildrenAction(appCtx *shared.AppContext) cli.ActionFunc {
 		}
 
 		recursive := c.Bool("recursive")
+		depth := c.Int("depth")
+		if depth < 0 {
+			return errors.NewValidationError("invalid --depth", fmt.Errorf("depth must not be negative, got %d", depth))
+		}
+		if c.IsSet("depth") && !recursive {
+			return errors.NewValidationError("invalid --depth", fmt.Errorf("--depth requires --recursive"))
+		}
 
 		appCtx.Logger.Info("Getting child tasks",
 			zap.String("taskID", taskID.String()),
-			zap.Bool("recursive", recursive))
+			zap.Bool("recursive", recursive),
+			zap.Int("depth", depth))
 
 		// Get the parent task for context
-		parentTask, err := appCtx.ProjectManager.GetTask(context.Background(), taskID)
+		parentTask, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get parent task", zap.Error(err))
 			return fmt.Errorf("failed to get parent task: %w", err)
@@ -102,9 +138,9 @@ func ChildrenAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		var children []*types.Task
 		if recursive {
-			children, err = getAllDescendants(appCtx.ProjectManager, taskID)
+			children, err = appCtx.ProjectManager.GetDescendants(c.Context, taskID, depth)
 		} else {
-			children, err = appCtx.ProjectManager.GetChildTasks(context.Background(), taskID)
+			children, err = appCtx.ProjectManager.GetChildTasks(c.Context, taskID)
 		}
 
 		if err != nil {
@@ -112,19 +148,24 @@ func ChildrenAction(appCtx *shared.AppContext) cli.ActionFunc {
 			return fmt.Errorf("failed to get child tasks: %w", err)
 		}
 
-		fmt.Printf("Children of '%s' (ID: %s):\n\n", parentTask.Title, taskID)
+		rollups := subtreeProgress(c, appCtx, parentTask.ProjectID)
+		appCtx.Out().Printf("Children of '%s' (ID: %s):\n", parentTask.Title, taskID)
+		if rollup := rollups[taskID]; rollup != nil {
+			appCtx.Out().Printf("Subtree: %s\n", output.Rollup(rollup))
+		}
+		appCtx.Out().Println()
 
 		if len(children) == 0 {
-			fmt.Println("No child tasks found.")
+			appCtx.Out().Println("No child tasks found.")
 			return nil
 		}
 
-		// Sort by depth first, then by title
-		sort.Slice(children, func(i, j int) bool {
+		// Sort by depth first, then in sibling order
+		sort.SliceStable(children, func(i, j int) bool {
 			if children[i].Depth != children[j].Depth {
 				return children[i].Depth < children[j].Depth
 			}
-			return children[i].Title < children[j].Title
+			return types.CompareSiblings(children[i], children[j]) < 0
 		})
 
 		for i, child := range children {
@@ -137,19 +178,23 @@ func ChildrenAction(appCtx *shared.AppContext) cli.ActionFunc {
 				}
 			}
 
-			fmt.Printf("%s%d. %s (ID: %s)\n", indent, i+1, child.Title, child.ID)
+			appCtx.Out().Printf("%s%d. %s (ID: %s)\n", indent, i+1, child.Title, child.ID)
 			if child.Description != "" {
-				fmt.Printf("%s   %s\n", indent, child.Description)
+				appCtx.Out().Printf("%s   %s\n", indent, output.Summary(child.Description))
+			}
+			appCtx.Out().Printf("%s   State: %s | Complexity: %d | Depth: %d%s",
+				indent, child.State, child.Complexity, child.Depth, utils.EstimateSuffix(child))
+			if rollup := rollups[child.ID]; rollup != nil {
+				appCtx.Out().Printf(" | Subtree: %s", output.Rollup(rollup))
 			}
-			fmt.Printf("%s   State: %s | Complexity: %d | Depth: %d\n",
-				indent, child.State, child.Complexity, child.Depth)
-			fmt.Println()
+			appCtx.Out().Println()
+			appCtx.Out().Println()
 		}
 
 		if recursive {
-			fmt.Printf("Total: %d descendants\n", len(children))
+			appCtx.Out().Printf("Total: %d descendants\n", len(children))
 		} else {
-			fmt.Printf("Total: %d direct children\n", len(children))
+			appCtx.Out().Printf("Total: %d direct children\n", len(children))
 		}
 
 		return nil
@@ -168,41 +213,91 @@ func ParentAction(appCtx *shared.AppContext) cli.ActionFunc {
 		appCtx.Logger.Info("Getting parent task", zap.String("taskID", taskID.String()))
 
 		// Get the child task first
-		childTask, err := appCtx.ProjectManager.GetTask(context.Background(), taskID)
+		childTask, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get task", zap.Error(err))
 			return fmt.Errorf("failed to get task: %w", err)
 		}
 
-		fmt.Printf("Parent of '%s' (ID: %s):\n\n", childTask.Title, taskID)
+		appCtx.Out().Printf("Parent of '%s' (ID: %s):\n\n", childTask.Title, taskID)
 
 		if childTask.ParentID == nil {
-			fmt.Println("This is a root task (no parent).")
+			appCtx.Out().Println("This is a root task (no parent).")
 			return nil
 		}
 
-		parentTask, err := appCtx.ProjectManager.GetParentTask(context.Background(), taskID)
+		parentTask, err := appCtx.ProjectManager.GetParentTask(c.Context, taskID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get parent task", zap.Error(err))
 			return fmt.Errorf("failed to get parent task: %w", err)
 		}
 
 		if parentTask == nil {
-			fmt.Println("Parent task not found (orphaned task).")
+			appCtx.Out().Println("Parent task not found (orphaned task).")
 			return nil
 		}
 
-		fmt.Printf("* %s (ID: %s)\n", parentTask.Title, parentTask.ID)
+		appCtx.Out().Printf("* %s (ID: %s)\n", parentTask.Title, parentTask.ID)
 		if parentTask.Description != "" {
-			fmt.Printf("  %s\n", parentTask.Description)
+			appCtx.Out().Printf("  %s\n", parentTask.Description)
 		}
-		fmt.Printf("  State: %s | Complexity: %d | Depth: %d\n",
-			parentTask.State, parentTask.Complexity, parentTask.Depth)
+		appCtx.Out().Printf("  State: %s | Complexity: %d | Depth: %d%s\n",
+			parentTask.State, parentTask.Complexity, parentTask.Depth, utils.EstimateSuffix(parentTask))
 
 		return nil
 	}
 }
 
+// TaskPath is the JSON output of the path command
+type TaskPath struct {
+	Project *types.Project `json:"project"`
+	Path    []*types.Task  `json:"path"` // Root task first, the task itself last
+}
+
+// PathAction prints the ancestors of a task, from the project down to the task
+func PathAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskIDStr := c.String("id")
+		taskID, err := uuid.Parse(taskIDStr)
+		if err != nil {
+			return errors.InvalidUUIDError("task-id", taskIDStr)
+		}
+
+		appCtx.Logger.Info("Getting task path", zap.String("taskID", taskID.String()))
+
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to get task", zap.Error(err))
+			return errors.TaskNotFoundError(taskID)
+		}
+		ancestors, err := appCtx.ProjectManager.GetAncestors(c.Context, taskID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to get ancestors", zap.Error(err))
+			return fmt.Errorf("failed to get ancestors: %w", err)
+		}
+		project, err := appCtx.ProjectManager.GetProject(c.Context, task.ProjectID)
+		if err != nil {
+			return errors.WrapWithSuggestion(err, "loading project")
+		}
+		path := append(ancestors, task)
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(TaskPath{Project: project, Path: path}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal task path to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		appCtx.Out().Printf("Project: %s (ID: %s)\n", project.Title, project.ID)
+		for i, step := range path {
+			appCtx.Out().Printf("%s+- %s (ID: %s) - %s\n", strings.Repeat("   ", i), step.Title, step.ID, output.State(step.State))
+		}
+		return nil
+	}
+}
+
 // RootsAction gets root tasks of a project
 func RootsAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
@@ -217,39 +312,36 @@ func RootsAction(appCtx *shared.AppContext) cli.ActionFunc {
 			zap.String("projectID", projectID.String()),
 			zap.Int("limit", limit))
 
-		rootTasks, err := appCtx.ProjectManager.GetRootTasks(context.Background(), projectID)
+		rootTasks, err := appCtx.ProjectManager.GetRootTasks(c.Context, projectID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get root tasks", zap.Error(err))
 			return fmt.Errorf("failed to get root tasks: %w", err)
 		}
 
-		fmt.Printf("Root tasks for project %s:\n\n", projectID)
+		appCtx.Out().Printf("Root tasks for project %s:\n\n", projectID)
 
 		if len(rootTasks) == 0 {
-			fmt.Println("No root tasks found.")
+			appCtx.Out().Println("No root tasks found.")
 			return nil
 		}
 
-		// Sort by title for consistent output
-		sort.Slice(rootTasks, func(i, j int) bool {
-			return rootTasks[i].Title < rootTasks[j].Title
-		})
+		types.SortSiblings(rootTasks)
 
 		// Apply limit if specified
 		if limit > 0 && len(rootTasks) > limit {
-			fmt.Printf("Root tasks (showing %d of %d):\n\n", limit, len(rootTasks))
+			appCtx.Out().Printf("Root tasks (showing %d of %d):\n\n", limit, len(rootTasks))
 			rootTasks = rootTasks[:limit]
 		} else {
-			fmt.Printf("Root tasks (%d total):\n\n", len(rootTasks))
+			appCtx.Out().Printf("Root tasks (%d total):\n\n", len(rootTasks))
 		}
 
 		for i, task := range rootTasks {
-			fmt.Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
+			appCtx.Out().Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
 			if task.Description != "" {
-				fmt.Printf("   %s\n", task.Description)
+				appCtx.Out().Printf("   %s\n", output.Summary(task.Description))
 			}
-			fmt.Printf("   State: %s | Complexity: %d\n", task.State, task.Complexity)
-			fmt.Println()
+			appCtx.Out().Printf("   State: %s | Complexity: %d%s\n", task.State, task.Complexity, utils.EstimateSuffix(task))
+			appCtx.Out().Println()
 		}
 
 		return nil
@@ -4,9 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"sort"
+	"strconv"
 
 	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
@@ -18,9 +19,52 @@ import (
 // TreeNode represents a task node in JSON tree format
 type TreeNode struct {
 	*types.Task
+	// Subtree is the rollup progress of the descendants, nil for leaf tasks
+	Subtree *types.SubtreeProgress `json:"subtree,omitempty"`
+	// Number is the position in the tree such as "1.2.1", set with --numbered
+	Number   string      `json:"number,omitempty"`
 	Children []*TreeNode `json:"children,omitempty"`
 }
 
+// childNumber returns the number of the i-th child of a task numbered
+// parent, or "" if the tree is not numbered
+func childNumber(parent string, i int) string {
+	if parent == "" {
+		return ""
+	}
+	return parent + "." + strconv.Itoa(i+1)
+}
+
+// breadcrumb returns the path from the project to a nested task, or "" for
+// root tasks. Like the rollup it is supplementary, so failures are only logged.
+func breadcrumb(c *cli.Context, appCtx *shared.AppContext, task *types.Task) string {
+	if task.ParentID == nil {
+		return ""
+	}
+	ancestors, err := appCtx.ProjectManager.GetAncestors(c.Context, task.ID)
+	if err != nil {
+		appCtx.Logger.Warn("Failed to get ancestors", zap.String("taskID", task.ID.String()), zap.Error(err))
+		return ""
+	}
+	project, err := appCtx.ProjectManager.GetProject(c.Context, task.ProjectID)
+	if err != nil {
+		appCtx.Logger.Warn("Failed to get project", zap.String("projectID", task.ProjectID.String()), zap.Error(err))
+		return ""
+	}
+	return output.Breadcrumb(project.Title, append(ancestors, task))
+}
+
+// subtreeProgress returns the rollup progress of the tasks of a project. The
+// rollup is supplementary, so failures are only logged.
+func subtreeProgress(c *cli.Context, appCtx *shared.AppContext, projectID uuid.UUID) map[uuid.UUID]*types.SubtreeProgress {
+	progress, err := appCtx.ProjectManager.GetSubtreeProgress(c.Context, projectID)
+	if err != nil {
+		appCtx.Logger.Warn("Failed to get subtree progress", zap.String("projectID", projectID.String()), zap.Error(err))
+		return nil
+	}
+	return progress
+}
+
 // TreeAction shows task hierarchy as a tree
 func TreeAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
@@ -47,7 +91,7 @@ func TreeAction(appCtx *shared.AppContext) cli.ActionFunc {
 			}
 
 			// Use batch loading for consistency with other optimizations
-			tasks, err := appCtx.ProjectManager.GetTasksWithDependencies(context.Background(), []uuid.UUID{rootTaskID})
+			tasks, err := appCtx.ProjectManager.GetTasksWithDependencies(c.Context, []uuid.UUID{rootTaskID})
 			if err != nil {
 				return fmt.Errorf("failed to get root task: %w", err)
 			}
@@ -55,10 +99,10 @@ func TreeAction(appCtx *shared.AppContext) cli.ActionFunc {
 				return fmt.Errorf("root task not found")
 			}
 			startingTasks = tasks
-			fmt.Printf("Task tree starting from '%s':\n\n", tasks[0].Title)
+			appCtx.Out().Printf("Task tree starting from '%s':\n\n", tasks[0].Title)
 		} else {
 			// Start from project roots
-			roots, err := appCtx.ProjectManager.GetRootTasks(context.Background(), projectID)
+			roots, err := appCtx.ProjectManager.GetRootTasks(c.Context, projectID)
 			if err != nil {
 				return fmt.Errorf("failed to get root tasks: %w", err)
 			}
@@ -66,29 +110,34 @@ func TreeAction(appCtx *shared.AppContext) cli.ActionFunc {
 		}
 
 		if len(startingTasks) == 0 {
-			fmt.Println("No tasks found.")
+			appCtx.Out().Println("No tasks found.")
 			return nil
 		}
 
-		// Sort starting tasks
-		sort.Slice(startingTasks, func(i, j int) bool {
-			return startingTasks[i].Title < startingTasks[j].Title
-		})
+		types.SortSiblings(startingTasks)
+		numbered := c.Bool("numbered")
+		rootNumber := func(i int) string {
+			if !numbered {
+				return ""
+			}
+			return strconv.Itoa(i + 1)
+		}
+		rollups := subtreeProgress(c, appCtx, startingTasks[0].ProjectID)
 
 		// Show headers for non-JSON mode (skip if quiet)
 		if !c.Bool("json") && !c.Bool("quiet") {
 			if rootTaskIDStr != "" {
-				fmt.Printf("Task tree starting from '%s':\n\n", startingTasks[0].Title)
+				appCtx.Out().Printf("Task tree starting from '%s':\n\n", startingTasks[0].Title)
 			} else {
-				fmt.Printf("Task tree for project %s:\n\n", projectID)
+				appCtx.Out().Printf("Task tree for project %s:\n\n", projectID)
 			}
 		}
 
 		// Output JSON if requested
 		if c.Bool("json") {
 			var treeNodes []*TreeNode
-			for _, task := range startingTasks {
-				treeNode, err := buildTreeJSON(appCtx.ProjectManager, task, 0, maxDepth)
+			for i, task := range startingTasks {
+				treeNode, err := buildTreeJSON(c.Context, appCtx.ProjectManager, rollups, task, rootNumber(i), 0, maxDepth)
 				if err != nil {
 					return fmt.Errorf("failed to build JSON tree: %w", err)
 				}
@@ -99,12 +148,12 @@ func TreeAction(appCtx *shared.AppContext) cli.ActionFunc {
 			if err != nil {
 				return fmt.Errorf("failed to marshal tree to JSON: %w", err)
 			}
-			fmt.Println(string(jsonData))
+			appCtx.Out().Println(string(jsonData))
 			return nil
 		}
 
-		for _, task := range startingTasks {
-			if err := printTaskTree(appCtx.ProjectManager, task, 0, maxDepth, ""); err != nil {
+		for i, task := range startingTasks {
+			if err := printTaskTree(c.Context, appCtx.Out(), appCtx.ProjectManager, rollups, task, rootNumber(i), 0, maxDepth, ""); err != nil {
 				return fmt.Errorf("failed to print task tree: %w", err)
 			}
 		}
@@ -114,31 +163,30 @@ func TreeAction(appCtx *shared.AppContext) cli.ActionFunc {
 }
 
 // buildTreeJSON recursively builds a JSON tree structure
-func buildTreeJSON(projectManager manager.ProjectManager, task *types.Task, currentDepth, maxDepth int) (*TreeNode, error) {
+func buildTreeJSON(ctx context.Context, projectManager manager.ProjectManager, rollups map[uuid.UUID]*types.SubtreeProgress, task *types.Task, number string, currentDepth, maxDepth int) (*TreeNode, error) {
 	// Check depth limit
 	if maxDepth > 0 && currentDepth >= maxDepth {
-		return &TreeNode{Task: task, Children: []*TreeNode{}}, nil
+		return &TreeNode{Task: task, Subtree: rollups[task.ID], Number: number, Children: []*TreeNode{}}, nil
 	}
 
 	node := &TreeNode{
 		Task:     task,
+		Subtree:  rollups[task.ID],
+		Number:   number,
 		Children: []*TreeNode{},
 	}
 
 	// Get children
-	children, err := projectManager.GetChildTasks(context.Background(), task.ID)
+	children, err := projectManager.GetChildTasks(ctx, task.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Sort children
-	sort.Slice(children, func(i, j int) bool {
-		return children[i].Title < children[j].Title
-	})
+	types.SortSiblings(children)
 
 	// Build child nodes
-	for _, child := range children {
-		childNode, err := buildTreeJSON(projectManager, child, currentDepth+1, maxDepth)
+	for i, child := range children {
+		childNode, err := buildTreeJSON(ctx, projectManager, rollups, child, childNumber(number, i), currentDepth+1, maxDepth)
 		if err != nil {
 			return nil, err
 		}
@@ -148,60 +196,35 @@ func buildTreeJSON(projectManager manager.ProjectManager, task *types.Task, curr
 	return node, nil
 }
 
-// getAllDescendants recursively gets all descendants of a task
-func getAllDescendants(projectManager manager.ProjectManager, taskID uuid.UUID) ([]*types.Task, error) {
-	var result []*types.Task
-	visited := make(map[uuid.UUID]bool)
-
-	var collectDescendants func(uuid.UUID) error
-	collectDescendants = func(id uuid.UUID) error {
-		if visited[id] {
-			return nil
-		}
-		visited[id] = true
-
-		children, err := projectManager.GetChildTasks(context.Background(), id)
-		if err != nil {
-			return err
-		}
-
-		for _, child := range children {
-			result = append(result, child)
-			if err := collectDescendants(child.ID); err != nil {
-				return err
-			}
-		}
-
-		return nil
-	}
-
-	if err := collectDescendants(taskID); err != nil {
-		return nil, err
-	}
-
-	return result, nil
-}
-
-// printTaskTree recursively prints a task and its children as a tree
-func printTaskTree(projectManager manager.ProjectManager, task *types.Task, currentDepth, maxDepth int, prefix string) error {
+// printTaskTree recursively prints a task and its children as a tree, with
+// the tasks numbered if number is set
+func printTaskTree(ctx context.Context, out output.Writer, projectManager manager.ProjectManager, rollups map[uuid.UUID]*types.SubtreeProgress, task *types.Task, number string, currentDepth, maxDepth int, prefix string) error {
 	// Check depth limit
 	if maxDepth > 0 && currentDepth >= maxDepth {
 		return nil
 	}
 
-	// Print current task
-	fmt.Printf("%s+- %s (ID: %s) - %s\n", prefix, task.Title, task.ID, task.State)
+	// Print current task, with the progress of its subtree
+	title := task.Title
+	if number != "" {
+		title = number + " " + title
+	}
+	out.Printf("%s+- %s (ID: %s) - %s", prefix, title, task.ID, output.State(task.State))
+	if task.Blocker != nil {
+		out.Printf(": %s", output.Blocker(task.Blocker))
+	}
+	if rollup := rollups[task.ID]; rollup != nil {
+		out.Printf(" [%s]", output.Rollup(rollup))
+	}
+	out.Println()
 
 	// Get children
-	children, err := projectManager.GetChildTasks(context.Background(), task.ID)
+	children, err := projectManager.GetChildTasks(ctx, task.ID)
 	if err != nil {
 		return err
 	}
 
-	// Sort children
-	sort.Slice(children, func(i, j int) bool {
-		return children[i].Title < children[j].Title
-	})
+	types.SortSiblings(children)
 
 	// Print children
 	for i, child := range children {
@@ -212,7 +235,7 @@ func printTaskTree(projectManager manager.ProjectManager, task *types.Task, curr
 			childPrefix += "|  "
 		}
 
-		if err := printTaskTree(projectManager, child, currentDepth+1, maxDepth, childPrefix); err != nil {
+		if err := printTaskTree(ctx, out, projectManager, rollups, child, childNumber(number, i), currentDepth+1, maxDepth, childPrefix); err != nil {
 			return err
 		}
 	}
@@ -1,9 +1,11 @@
 package task
 
 import (
+	"bytes"
 	"flag"
 	"testing"
 
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/testutil"
 	"github.com/denkhaus/knot/v2/internal/types"
@@ -124,4 +126,60 @@ func TestRootsAction(t *testing.T) {
 		_, err = mgr.GetTask(nil, root2.ID)
 		assert.NoError(t, err)
 	})
-}
\ No newline at end of file
+}
+func TestTreeActionNumbered(t *testing.T) {
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+	require.NoError(t, mgr.SetSelectedProject(nil, project.ID, "test-user"))
+
+	backend, err := mgr.CreateTask(nil, project.ID, nil, "Backend", "", 4, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = mgr.CreateTask(nil, project.ID, &backend.ID, "Schema", "", 2, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = mgr.CreateTask(nil, project.ID, &backend.ID, "API", "", 2, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = mgr.CreateTask(nil, project.ID, nil, "Release", "", 2, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	appCtx := &shared.AppContext{
+		ProjectManager: mgr,
+		Logger:         config.Logger,
+		Output:         output.NewTextWriter(&buf),
+	}
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.Bool("numbered", true, "")
+	flagSet.Bool("json", false, "")
+	require.NoError(t, TreeAction(appCtx)(cli.NewContext(&cli.App{}, flagSet, nil)))
+
+	tree := buf.String()
+	assert.Contains(t, tree, "+- 1 Backend")
+	assert.Contains(t, tree, "+- 1.1 Schema")
+	assert.Contains(t, tree, "+- 1.2 API")
+	assert.Contains(t, tree, "+- 2 Release")
+}
+
+func TestTreeActionShowsBlocker(t *testing.T) {
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+	require.NoError(t, mgr.SetSelectedProject(nil, project.ID, "test-user"))
+
+	task, err := mgr.CreateTask(nil, project.ID, nil, "Integrate payments", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = mgr.BlockTask(nil, task.ID, "waiting for vendor", "SUP-123", "test-user")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	appCtx := &shared.AppContext{
+		ProjectManager: mgr,
+		Logger:         config.Logger,
+		Output:         output.NewTextWriter(&buf),
+	}
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.Bool("json", false, "")
+	require.NoError(t, TreeAction(appCtx)(cli.NewContext(&cli.App{}, flagSet, nil)))
+
+	assert.Contains(t, buf.String(), "blocked: waiting for vendor (ref: SUP-123)")
+}
@@ -0,0 +1,28 @@
+package task
+
+import (
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// warnProjectSize warns on stderr, so --quiet output stays usable, when the
+// project approaches its task limit. Creating tasks fails once it is reached.
+func warnProjectSize(c *cli.Context, appCtx *shared.AppContext, projectID uuid.UUID) {
+	capacity, err := appCtx.ProjectManager.GetTaskCapacity(c.Context, projectID, nil)
+	if err != nil {
+		appCtx.Logger.Warn("Failed to check project size", zap.Error(err))
+		return
+	}
+	if !manager.NearLimit(capacity.ProjectTasks, capacity.MaxProjectTasks) {
+		return
+	}
+
+	fmt.Fprintf(c.App.ErrWriter, "Warning: the project has %d of at most %d tasks; creating tasks fails once the limit is reached.\n",
+		capacity.ProjectTasks, capacity.MaxProjectTasks)
+	fmt.Fprintln(c.App.ErrWriter, "Plan with fewer, coarser tasks, or raise the limit with: knot config set --key max-tasks-per-project --value <n>")
+}
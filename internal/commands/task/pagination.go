@@ -0,0 +1,122 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+)
+
+// isPaginated reports whether task list should return one page of tasks
+func isPaginated(c *cli.Context) bool {
+	return c.Bool("paginate") || c.IsSet("cursor")
+}
+
+// listPage prints one page of the filtered task list as a JSON envelope
+// {"tasks": [...], "next_cursor": "..."}. Filters run in the repository and
+// pages follow creation order, so a consumer walks a large project by passing
+// next_cursor back as --cursor until it is empty.
+func listPage(c *cli.Context, appCtx *shared.AppContext, projectID uuid.UUID, fields []listField) error {
+	if !c.Bool("json") {
+		return errors.NewValidationError("invalid flag combination",
+			fmt.Errorf("--paginate and --cursor require --json"))
+	}
+	for _, name := range []string{"sort", "reverse", "group-by"} {
+		if c.IsSet(name) {
+			return errors.NewValidationError("invalid flag combination",
+				fmt.Errorf("--%s cannot be combined with pagination, pages are in creation order", name))
+		}
+	}
+
+	filter, err := buildTaskFilter(c)
+	if err != nil {
+		return err
+	}
+	page := types.PageRequest{Cursor: c.String("cursor"), Limit: c.Int("limit")}
+	if page.Cursor != "" {
+		if _, err := types.DecodePageCursor(page.Cursor); err != nil {
+			return errors.NewValidationError("invalid --cursor value", err)
+		}
+	}
+	if page.Limit > types.MaxPageSize {
+		return errors.NewValidationError("invalid --limit value",
+			fmt.Errorf("a page holds at most %d tasks", types.MaxPageSize))
+	}
+
+	result, err := appCtx.ProjectManager.ListTasksPage(c.Context, projectID, filter, page)
+	if err != nil {
+		return errors.WrapWithSuggestion(err, "listing tasks")
+	}
+	return writeTaskPage(appCtx.Out(), result, fields)
+}
+
+// buildTaskFilter turns the task list filter flags into a repository filter
+func buildTaskFilter(c *cli.Context) (types.TaskFilter, error) {
+	var filter types.TaskFilter
+
+	if state := c.String("state"); state != "" {
+		if err := errors.ValidateTaskState(state); err != nil {
+			return filter, err
+		}
+		taskState := types.TaskState(state)
+		filter.State = &taskState
+	}
+	if priority := c.String("priority"); priority != "" {
+		var taskPriority types.TaskPriority
+		switch priority {
+		case "high":
+			taskPriority = types.TaskPriorityHigh
+		case "medium":
+			taskPriority = types.TaskPriorityMedium
+		case "low":
+			taskPriority = types.TaskPriorityLow
+		default:
+			return filter, errors.NewValidationError("invalid --priority value",
+				fmt.Errorf("unknown priority %q, use low, medium or high", priority))
+		}
+		filter.Priority = &taskPriority
+	}
+
+	minComplexity, maxComplexity := c.Int("complexity-min"), c.Int("complexity-max")
+	if complexity := c.Int("complexity"); complexity > 0 {
+		minComplexity, maxComplexity = complexity, complexity
+	}
+	if minComplexity > 0 {
+		filter.MinComplexity = &minComplexity
+	}
+	if maxComplexity > 0 {
+		filter.MaxComplexity = &maxComplexity
+	}
+	if c.IsSet("depth-max") {
+		maxDepth := c.Int("depth-max")
+		filter.MaxDepth = &maxDepth
+	}
+	filter.Search = c.String("search")
+
+	return filter, nil
+}
+
+// writeTaskPage writes a page as JSON, with only the selected fields per task
+// if fields is set
+func writeTaskPage(w io.Writer, page *types.TaskPage, fields []listField) error {
+	var data []byte
+	var err error
+	if fields != nil {
+		data, err = json.MarshalIndent(struct {
+			Tasks      []map[string]any `json:"tasks"`
+			NextCursor string           `json:"next_cursor,omitempty"`
+		}{selectTaskFields(page.Tasks, fields), page.NextCursor}, "", "  ")
+	} else {
+		data, err = json.MarshalIndent(page, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal tasks to JSON: %w", err)
+	}
+	fmt.Fprintln(w, string(data))
+	return nil
+}
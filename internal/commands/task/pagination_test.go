@@ -0,0 +1,35 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTaskPage(t *testing.T) {
+	task := &types.Task{ID: uuid.New(), Title: "Task", State: types.TaskStatePending, Priority: types.TaskPriorityMedium, Complexity: 4}
+	page := &types.TaskPage{Tasks: []*types.Task{task}, NextCursor: types.NewPageCursor(task).Encode()}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeTaskPage(&buf, page, nil))
+	var full types.TaskPage
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &full))
+	require.Len(t, full.Tasks, 1)
+	assert.Equal(t, task.ID, full.Tasks[0].ID)
+	assert.Equal(t, page.NextCursor, full.NextCursor)
+
+	fields, err := parseListFields("shortid,title")
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, writeTaskPage(&buf, &types.TaskPage{Tasks: []*types.Task{task}}, fields))
+	var selected map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &selected))
+	assert.Equal(t, map[string]any{
+		"tasks": []any{map[string]any{"shortid": types.ShortID(task.ID), "title": "Task"}},
+	}, selected)
+}
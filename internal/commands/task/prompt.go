@@ -0,0 +1,297 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+var (
+	checklistItemPattern     = regexp.MustCompile(`^\s*[-*]\s+\[([ xX])\]\s+(.+)$`)
+	bulletItemPattern        = regexp.MustCompile(`^\s*[-*]\s+(.+)$`)
+	acceptanceHeadingPattern = regexp.MustCompile(`(?i)^\s*(#+\s*)?acceptance criteria:?\s*$`)
+)
+
+const (
+	// promptSummaryLimit caps the description summaries of related tasks
+	promptSummaryLimit = 200
+	// promptParentDescriptionLimit caps the parent description in the prompt
+	promptParentDescriptionLimit = 500
+)
+
+// TaskPrompt is the context assembled for handing a task to a coding agent
+type TaskPrompt struct {
+	Task               *types.Task       `json:"task"`
+	Project            *types.Project    `json:"project"`
+	Ancestors          []*types.Task     `json:"ancestors,omitempty"` // Root first, direct parent last
+	Dependencies       []*types.Task     `json:"dependencies,omitempty"`
+	Dependents         []*types.Task     `json:"dependents,omitempty"`
+	Subtasks           []*types.Task     `json:"subtasks,omitempty"`
+	AcceptanceCriteria []PromptCriterion `json:"acceptance_criteria,omitempty"`
+	// Instructions are inherited from the project and the parent chain, most
+	// general first, followed by the task's own
+	Instructions []types.Instruction `json:"instructions,omitempty"`
+	// ComplexityThreshold is the configured complexity at which tasks should be broken down
+	ComplexityThreshold int `json:"complexity_threshold"`
+	// RequiresReview is set when the project needs an approved review before completion
+	RequiresReview bool `json:"requires_review,omitempty"`
+}
+
+// PromptCriterion is one acceptance criterion of a task
+type PromptCriterion struct {
+	Text   string `json:"text"`
+	Done   bool   `json:"done"`
+	Source string `json:"source"` // "criteria", "description" or "subtask"
+}
+
+// PromptAction prints a ready-to-paste agent prompt for a task
+func PromptAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskIDStr := c.String("id")
+		taskID, err := uuid.Parse(taskIDStr)
+		if err != nil {
+			return errors.InvalidUUIDError("task-id", taskIDStr)
+		}
+
+		appCtx.Logger.Info("Building task prompt", zap.String("taskID", taskID.String()))
+
+		prompt, err := BuildTaskPrompt(c.Context, appCtx.ProjectManager, taskID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to build task prompt", zap.Error(err))
+			return err
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(prompt, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal prompt to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		return RenderTaskPrompt(appCtx.Out(), prompt)
+	}
+}
+
+// BuildTaskPrompt collects the task, its project, parent chain, dependencies,
+// dependents and subtasks and collects the acceptance criteria
+func BuildTaskPrompt(ctx context.Context, pm manager.ProjectManager, taskID uuid.UUID) (*TaskPrompt, error) {
+	task, err := pm.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, errors.TaskNotFoundError(taskID)
+	}
+
+	project, err := pm.GetProject(ctx, task.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	prompt := &TaskPrompt{
+		Task:                task,
+		Project:             project,
+		ComplexityThreshold: pm.GetConfig().ComplexityThreshold,
+		RequiresReview:      pm.GetConfig().RequiresReview(task.ProjectID),
+	}
+
+	if prompt.Ancestors, err = pm.GetAncestors(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to get ancestors: %w", err)
+	}
+	prompt.Instructions = types.InheritedInstructions(project, prompt.Ancestors, task)
+
+	if prompt.Dependencies, err = pm.GetTaskDependencies(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	if prompt.Dependents, err = pm.GetDependentTasks(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to get dependent tasks: %w", err)
+	}
+	if prompt.Subtasks, err = pm.GetChildTasks(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+
+	for _, criterion := range task.AcceptanceCriteria {
+		prompt.AcceptanceCriteria = append(prompt.AcceptanceCriteria, PromptCriterion{
+			Text:   criterion.Text,
+			Done:   criterion.Verified,
+			Source: "criteria",
+		})
+	}
+	prompt.AcceptanceCriteria = append(prompt.AcceptanceCriteria, ExtractAcceptanceCriteria(task.Description)...)
+	for _, subtask := range prompt.Subtasks {
+		if subtask.State == types.TaskStateCancelled {
+			continue
+		}
+		prompt.AcceptanceCriteria = append(prompt.AcceptanceCriteria, PromptCriterion{
+			Text:   subtask.Title,
+			Done:   subtask.State == types.TaskStateCompleted,
+			Source: "subtask",
+		})
+	}
+
+	return prompt, nil
+}
+
+// ExtractAcceptanceCriteria returns the Markdown checklist items of a description
+// ("- [ ] item", "- [x] item") and the bullets below an "Acceptance criteria" line
+func ExtractAcceptanceCriteria(description string) []PromptCriterion {
+	var criteria []PromptCriterion
+	inSection := false
+
+	for _, line := range strings.Split(description, "\n") {
+		if acceptanceHeadingPattern.MatchString(line) {
+			inSection = true
+			continue
+		}
+
+		if match := checklistItemPattern.FindStringSubmatch(line); match != nil {
+			criteria = append(criteria, PromptCriterion{
+				Text:   strings.TrimSpace(match[2]),
+				Done:   match[1] != " ",
+				Source: "description",
+			})
+			continue
+		}
+
+		if !inSection {
+			continue
+		}
+		if match := bulletItemPattern.FindStringSubmatch(line); match != nil {
+			criteria = append(criteria, PromptCriterion{Text: strings.TrimSpace(match[1]), Source: "description"})
+			continue
+		}
+		// Any other non-empty line, e.g. the next heading, ends the section
+		if strings.TrimSpace(line) != "" {
+			inSection = false
+		}
+	}
+
+	return criteria
+}
+
+// RenderTaskPrompt writes the prompt as Markdown
+func RenderTaskPrompt(w io.Writer, prompt *TaskPrompt) error {
+	var b strings.Builder
+	task := prompt.Task
+
+	fmt.Fprintf(&b, "# Task: %s\n\n", task.Title)
+	fmt.Fprintf(&b, "- ID: %s\n", task.ID)
+	fmt.Fprintf(&b, "- State: %s\n", task.State)
+	fmt.Fprintf(&b, "- Priority: %s\n", task.Priority.ToExternalString())
+	fmt.Fprintf(&b, "- Complexity: %d/10\n", task.Complexity)
+	if task.Estimate != nil {
+		fmt.Fprintf(&b, "- Estimate: %s\n", utils.FormatEstimate(*task.Estimate))
+	}
+	if task.DueDate != nil {
+		fmt.Fprintf(&b, "- Due: %s\n", utils.FormatDueDate(task.DueDate))
+	}
+	if len(task.Tags) > 0 {
+		fmt.Fprintf(&b, "- Tags: %s\n", strings.Join(task.Tags, ", "))
+	}
+
+	b.WriteString("\n## Project\n\n")
+	b.WriteString(prompt.Project.Title + "\n")
+	if prompt.Project.Description != "" {
+		b.WriteString("\n" + prompt.Project.Description + "\n")
+	}
+
+	if len(prompt.Ancestors) > 0 {
+		b.WriteString("\n## Parent context\n\n")
+		b.WriteString("This task is part of:\n")
+		for i, ancestor := range prompt.Ancestors {
+			fmt.Fprintf(&b, "%s- %s [%s]\n", strings.Repeat("  ", i), ancestor.Title, ancestor.State)
+		}
+		parent := prompt.Ancestors[len(prompt.Ancestors)-1]
+		if parent.Description != "" {
+			fmt.Fprintf(&b, "\nParent description:\n%s\n", utils.Truncate(parent.Description, promptParentDescriptionLimit))
+		}
+	}
+
+	if len(prompt.Instructions) > 0 {
+		b.WriteString("\n## Instructions\n\n")
+		writeInstructions(&b, prompt.Instructions)
+	}
+
+	b.WriteString("\n## Description\n\n")
+	if task.Description != "" {
+		b.WriteString(task.Description + "\n")
+	} else {
+		b.WriteString("(no description)\n")
+	}
+
+	if len(prompt.Dependencies) > 0 {
+		b.WriteString("\n## Dependencies\n\n")
+		b.WriteString("This task builds on:\n")
+		writePromptTaskList(&b, prompt.Dependencies)
+	}
+
+	if len(prompt.Dependents) > 0 {
+		b.WriteString("\n## Unblocks\n\n")
+		b.WriteString("Completing this task unblocks:\n")
+		writePromptTaskList(&b, prompt.Dependents)
+	}
+
+	if len(prompt.AcceptanceCriteria) > 0 {
+		b.WriteString("\n## Acceptance criteria\n\n")
+		for _, criterion := range prompt.AcceptanceCriteria {
+			mark := " "
+			if criterion.Done {
+				mark = "x"
+			}
+			fmt.Fprintf(&b, "- [%s] %s\n", mark, criterion.Text)
+		}
+	}
+
+	b.WriteString("\n## Guidelines\n\n")
+	if prompt.ComplexityThreshold > 0 && task.Complexity >= prompt.ComplexityThreshold && len(prompt.Subtasks) == 0 {
+		fmt.Fprintf(&b, "- Complexity %d reaches the breakdown threshold of %d: split the task into subtasks first\n  (knot task create --parent-id %s --title \"...\")\n",
+			task.Complexity, prompt.ComplexityThreshold, task.ID)
+	}
+	if task.State == types.TaskStatePending {
+		fmt.Fprintf(&b, "- Before starting: knot task update-state --id %s --state in-progress\n", task.ID)
+	}
+	if prompt.RequiresReview && !task.IsApproved() {
+		fmt.Fprintf(&b, "- When done: knot task request-review --id %s and wait for approval by another actor\n", task.ID)
+	} else {
+		fmt.Fprintf(&b, "- When done: knot task update-state --id %s --state completed\n", task.ID)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writePromptTaskList(b *strings.Builder, tasks []*types.Task) {
+	for _, task := range tasks {
+		fmt.Fprintf(b, "- %s [%s]", task.Title, task.State)
+		if summary := firstLine(task.Description); summary != "" {
+			fmt.Fprintf(b, ": %s", utils.Truncate(summary, promptSummaryLimit))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// writeInstructions lists instructions with the project or task they come from
+func writeInstructions(b *strings.Builder, instructions []types.Instruction) {
+	for _, instruction := range instructions {
+		fmt.Fprintf(b, "From %s '%s':\n%s\n", instruction.Scope, instruction.Title, strings.TrimRight(instruction.Text, "\n"))
+	}
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return strings.TrimSpace(s[:i])
+	}
+	return s
+}
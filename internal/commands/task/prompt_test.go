@@ -0,0 +1,85 @@
+package task
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/testutil"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestExtractAcceptanceCriteria(t *testing.T) {
+	description := `Implement the login form.
+
+- [x] Form renders
+- [ ] Errors are shown inline
+
+Acceptance criteria:
+- Password field is masked
+* Submit is disabled while loading
+
+Notes:
+- not a criterion`
+
+	criteria := ExtractAcceptanceCriteria(description)
+	require.Len(t, criteria, 4)
+	assert.Equal(t, PromptCriterion{Text: "Form renders", Done: true, Source: "description"}, criteria[0])
+	assert.Equal(t, PromptCriterion{Text: "Errors are shown inline", Source: "description"}, criteria[1])
+	assert.Equal(t, "Password field is masked", criteria[2].Text)
+	assert.Equal(t, "Submit is disabled while loading", criteria[3].Text)
+
+	assert.Empty(t, ExtractAcceptanceCriteria("No criteria here\n- just a bullet"))
+}
+
+func TestPromptAction(t *testing.T) {
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+
+	epic, err := mgr.CreateTask(nil, project.ID, nil, "Authentication", "User accounts and sessions", 6, types.TaskPriorityHigh, "test-user")
+	require.NoError(t, err)
+	schema, err := mgr.CreateTask(nil, project.ID, &epic.ID, "User schema", "Create the users table\nwith indexes", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	login, err := mgr.CreateTask(nil, project.ID, &epic.ID, "Login endpoint", "POST /login\n\n- [ ] Returns a session token", 4, types.TaskPriorityHigh, "test-user")
+	require.NoError(t, err)
+	_, err = mgr.AddTaskDependency(nil, login.ID, schema.ID, "test-user")
+	require.NoError(t, err)
+	_, err = mgr.CreateTask(nil, project.ID, &login.ID, "Rate limiting", "", 2, types.TaskPriorityLow, "test-user")
+	require.NoError(t, err)
+
+	_, err = mgr.UpdateProjectInstructions(nil, project.ID, "Always write tests", "test-user")
+	require.NoError(t, err)
+	_, err = mgr.UpdateTaskInstructions(nil, epic.ID, "Use bcrypt for passwords", "test-user")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	app := &cli.App{}
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.Bool("json", false, "")
+	flagSet.String("id", login.ID.String(), "")
+
+	appCtx := &shared.AppContext{ProjectManager: mgr, Logger: config.Logger, Output: output.NewTextWriter(&out)}
+	require.NoError(t, PromptAction(appCtx)(cli.NewContext(app, flagSet, nil)))
+
+	prompt := out.String()
+	assert.Contains(t, prompt, "# Task: Login endpoint")
+	assert.Contains(t, prompt, "## Project\n\n"+project.Title)
+	assert.Contains(t, prompt, "- Authentication [pending]")
+	assert.Contains(t, prompt, "Parent description:\nUser accounts and sessions")
+	assert.Contains(t, prompt, "- User schema [pending]: Create the users table\n")
+	assert.Contains(t, prompt, "- [ ] Returns a session token")
+	assert.Contains(t, prompt, "- [ ] Rate limiting")
+	assert.Contains(t, prompt, "--state in-progress")
+	assert.Contains(t, prompt, "## Instructions\n\nFrom project '"+project.Title+"':\nAlways write tests\nFrom task 'Authentication':\nUse bcrypt for passwords\n")
+
+	t.Run("invalid id", func(t *testing.T) {
+		require.NoError(t, flagSet.Set("id", "not-a-uuid"))
+		assert.Error(t, PromptAction(appCtx)(cli.NewContext(app, flagSet, nil)))
+	})
+}
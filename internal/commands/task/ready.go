@@ -1,9 +1,9 @@
 package task
 
 import (
-	"context"
 	"fmt"
 
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/denkhaus/knot/v2/internal/utils"
@@ -23,7 +23,7 @@ func ReadyAction(appCtx *shared.AppContext) cli.ActionFunc {
 		appCtx.Logger.Info("Finding ready tasks", zap.String("projectID", projectID.String()))
 
 		// Get all tasks in the project
-		allTasks, err := appCtx.ProjectManager.ListTasksForProject(context.Background(), projectID)
+		allTasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
 		if err != nil {
 			appCtx.Logger.Error("Failed to get project tasks", zap.Error(err))
 			return fmt.Errorf("failed to get project tasks: %w", err)
@@ -49,10 +49,10 @@ func ReadyAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		if len(readyTasks) == 0 {
 			if c.Bool("json") {
-				fmt.Println("[]")
+				appCtx.Out().Println("[]")
 				return nil
 			}
-			fmt.Println("No ready tasks found. All tasks are either completed, blocked, or cancelled.")
+			appCtx.Out().Println("No ready tasks found. All tasks are either completed, blocked, or cancelled.")
 			return nil
 		}
 
@@ -64,32 +64,32 @@ func ReadyAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		// Check if JSON output is requested
 		if c.Bool("json") {
-			return utils.OutputTasksAsJSON(readyTasks)
+			return appCtx.Out().JSON(readyTasks)
 		}
 
 		// Show project context indicator
 		shared.ShowProjectContextWithSeparator(c, appCtx)
 
 		if limit > 0 && len(readyTasks) == limit {
-			fmt.Printf("Ready work (showing %d of %d tasks with no blockers):\n\n", limit, len(readyTasks))
+			appCtx.Out().Printf("Ready work (showing %d of %d tasks with no blockers):\n\n", limit, len(readyTasks))
 		} else {
-			fmt.Printf("Ready work (%d tasks with no blockers):\n\n", len(readyTasks))
+			appCtx.Out().Printf("Ready work (%d tasks with no blockers):\n\n", len(readyTasks))
 		}
 
 		for i, task := range readyTasks {
-			fmt.Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
+			appCtx.Out().Printf("%d. %s (ID: %s)\n", i+1, task.Title, task.ID)
 			if task.Description != "" {
-				fmt.Printf("   %s\n", task.Description)
+				appCtx.Out().Printf("   %s\n", output.Summary(task.Description))
 			}
-			fmt.Printf("   State: %s | Complexity: %d\n", task.State, task.Complexity)
+			appCtx.Out().Printf("   State: %s | Complexity: %d%s\n", task.State, task.Complexity, utils.EstimateSuffix(task))
 			if task.Depth > 0 {
-				fmt.Printf("   Depth: %d", task.Depth)
+				appCtx.Out().Printf("   Depth: %d", task.Depth)
 				if task.ParentID != nil {
-					fmt.Printf(" | Parent: %s", *task.ParentID)
+					appCtx.Out().Printf(" | Parent: %s", *task.ParentID)
 				}
-				fmt.Println()
+				appCtx.Out().Println()
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 		}
 
 		return nil
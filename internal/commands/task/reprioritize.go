@@ -0,0 +1,168 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/filter"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/denkhaus/knot/v2/internal/validation"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// reprioritizeChange is a single priority change of the reprioritize command
+type reprioritizeChange struct {
+	TaskID uuid.UUID `json:"task_id"`
+	Title  string    `json:"title"`
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+}
+
+// reprioritizeResult is the JSON output of the reprioritize command
+type reprioritizeResult struct {
+	Filter    string               `json:"filter"`
+	Matched   int                  `json:"matched"`
+	Unchanged int                  `json:"unchanged"`
+	DryRun    bool                 `json:"dry_run"`
+	Changes   []reprioritizeChange `json:"changes"`
+}
+
+// NewReprioritizeCommand creates the command changing the priority of all tasks matching a filter
+func NewReprioritizeCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "reprioritize",
+		Usage: "Set the priority of all tasks matching a filter",
+		Description: `Sets the priority of all tasks in the selected project that match a saved
+filter (see 'knot filter save') or a filter query, e.g.
+
+  knot task reprioritize --filter "state:pending tag:backend" --priority high --dry-run
+
+Query terms: state:<state>, priority:<priority>, complexity:<n|min-max>,
+depth-max:<n>, tag:<tag>, search:<word> or plain words. Comma-separated values
+match any of them. Either all matching tasks are updated or none.`,
+		Action: reprioritizeAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "filter",
+				Aliases:  []string{"f"},
+				Usage:    "Saved filter name or filter query",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "priority",
+				Aliases:  []string{"p"},
+				Usage:    "New task priority (low, medium, high)",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the changes without applying them",
+			},
+			shared.NewJSONFlag(),
+		},
+	}
+}
+
+func reprioritizeAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		priorityStr := c.String("priority")
+		if err := validation.NewInputValidator().ValidateTaskPriority(priorityStr); err != nil {
+			return errors.NewValidationError("invalid priority", err)
+		}
+		priority := utils.ParsePriority(priorityStr)
+
+		query, err := filter.Resolve(appCtx.ProjectManager.GetConfig().SavedFilters, c.String("filter"))
+		if err != nil {
+			return errors.NewValidationError("invalid filter", err)
+		}
+
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		matched := query.Filter(tasks)
+		result := reprioritizeResult{
+			Filter:  c.String("filter"),
+			Matched: len(matched),
+			DryRun:  c.Bool("dry-run"),
+			Changes: make([]reprioritizeChange, 0),
+		}
+		var taskIDs []uuid.UUID
+		for _, task := range matched {
+			if task.Priority == priority {
+				result.Unchanged++
+				continue
+			}
+			taskIDs = append(taskIDs, task.ID)
+			result.Changes = append(result.Changes, reprioritizeChange{
+				TaskID: task.ID,
+				Title:  task.Title,
+				From:   task.Priority.ToExternalString(),
+				To:     priorityStr,
+			})
+		}
+
+		actor := shared.GetActorFromContext(c)
+		if !result.DryRun && len(taskIDs) > 0 {
+			appCtx.Logger.Info("Reprioritizing tasks",
+				zap.String("filter", result.Filter),
+				zap.Int("taskCount", len(taskIDs)),
+				zap.String("priority", priorityStr),
+				zap.String("actor", actor))
+
+			if _, err := appCtx.ProjectManager.ReprioritizeTasks(c.Context, taskIDs, priority, actor); err != nil {
+				appCtx.Logger.Error("Failed to reprioritize tasks", zap.Error(err))
+				return errors.WrapWithSuggestion(err, "reprioritizing tasks")
+			}
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal reprioritize result to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+			return nil
+		}
+
+		printReprioritizeResult(appCtx.Out(), result, priority, actor)
+		return nil
+	}
+}
+
+func printReprioritizeResult(out output.Writer, result reprioritizeResult, priority types.TaskPriority, actor string) {
+	out.Printf("Filter '%s' matched %d task(s), %d already %s\n",
+		result.Filter, result.Matched, result.Unchanged, priority.ToExternalString())
+	if len(result.Changes) == 0 {
+		out.Println("No priorities to change.")
+		return
+	}
+
+	if result.DryRun {
+		out.Printf("\nWould change the priority of %d task(s):\n", len(result.Changes))
+	} else {
+		out.Printf("\nChanged the priority of %d task(s):\n", len(result.Changes))
+	}
+	for _, change := range result.Changes {
+		out.Printf("  %s (ID: %s): %s -> %s\n", change.Title, change.TaskID, change.From, change.To)
+	}
+
+	if result.DryRun {
+		out.Println("\nRun without --dry-run to apply these changes.")
+	} else {
+		out.Printf("  Updated by: %s\n", actor)
+	}
+}
@@ -0,0 +1,116 @@
+package task
+
+import (
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// NewReviewCommands creates the request-review, approve and reject commands
+func NewReviewCommands(appCtx *shared.AppContext) []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:  "request-review",
+			Usage: "Request a review of a task before it is completed",
+			Description: `Records a review request. In projects that require review (see
+'knot config set --key require-review') a task can only be completed after a
+different actor approved it with 'knot task approve'.`,
+			Action: requestReviewAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewTaskIDFlag(),
+			},
+		},
+		{
+			Name:   "approve",
+			Usage:  "Approve the requested review of a task",
+			Action: reviewDecisionAction(appCtx, types.ReviewStatusApproved),
+			Flags:  reviewDecisionFlags(),
+		},
+		{
+			Name:   "reject",
+			Usage:  "Reject the requested review of a task and send it back for more work",
+			Action: reviewDecisionAction(appCtx, types.ReviewStatusRejected),
+			Flags:  reviewDecisionFlags(),
+		},
+	}
+}
+
+func requestReviewAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskID, err := parseTaskIDFlag(c)
+		if err != nil {
+			return err
+		}
+		actor := shared.ResolveActor(c.String("actor"))
+
+		appCtx.Logger.Info("Requesting task review",
+			zap.String("taskID", taskID.String()),
+			zap.String("actor", actor))
+
+		task, err := appCtx.ProjectManager.RequestTaskReview(c.Context, taskID, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to request review", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "requesting review")
+		}
+
+		appCtx.Out().Printf("Review requested for task \"%s\"\n", task.Title)
+		appCtx.Out().Printf("  Requested by: %s\n", actor)
+		appCtx.Out().Printf("  A different actor can approve it: knot task approve --id %s --actor <reviewer>\n", task.ID)
+		return nil
+	}
+}
+
+func reviewDecisionAction(appCtx *shared.AppContext, status types.ReviewStatus) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskID, err := parseTaskIDFlag(c)
+		if err != nil {
+			return err
+		}
+		reviewer := c.String("actor")
+		if reviewer == "" {
+			reviewer = appCtx.GetActor()
+		}
+		reviewer = shared.ResolveActor(reviewer)
+
+		appCtx.Logger.Info("Recording review decision",
+			zap.String("taskID", taskID.String()),
+			zap.String("status", string(status)),
+			zap.String("reviewer", reviewer))
+
+		decide := appCtx.ProjectManager.ApproveTask
+		if status == types.ReviewStatusRejected {
+			decide = appCtx.ProjectManager.RejectTask
+		}
+		task, err := decide(c.Context, taskID, reviewer, c.String("comment"))
+		if err != nil {
+			appCtx.Logger.Error("Failed to record review decision", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "reviewing task")
+		}
+
+		appCtx.Out().Printf("Task \"%s\" %s by %s\n", task.Title, status, reviewer)
+		if task.Review.Comment != "" {
+			appCtx.Out().Printf("  Comment: %s\n", task.Review.Comment)
+		}
+		if status == types.ReviewStatusApproved {
+			appCtx.Out().Printf("  Complete it with: knot task update-state --id %s --state completed\n", task.ID)
+		}
+		return nil
+	}
+}
+
+func reviewDecisionFlags() []cli.Flag {
+	return []cli.Flag{
+		shared.NewTaskIDFlag(),
+		&cli.StringFlag{
+			Name:  "actor",
+			Usage: "Reviewer, must differ from the actor who requested the review (default: global --actor)",
+		},
+		&cli.StringFlag{
+			Name:    "comment",
+			Aliases: []string{"m"},
+			Usage:   "Review comment",
+		},
+	}
+}
@@ -0,0 +1,195 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// NewScheduleStateCommand creates the command that schedules a state change of a task
+func NewScheduleStateCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "schedule-state",
+		Usage: "Schedule a state change of a task for a later time",
+		Description: `Stores a state change that 'knot maintenance run' (or 'knot serve', which
+runs maintenance every minute) applies once its time has come, e.g. to start
+work at a sprint boundary:
+
+  knot task schedule-state --id <task-id> --state in-progress --at 2024-07-01T09:00
+
+The change is applied as the actor who scheduled it and must be a valid
+transition at that time; otherwise it is dropped and reported by the run.
+Use 'knot task scheduled' to list pending changes and 'knot task unschedule'
+to cancel one.`,
+		Action: scheduleStateAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "id",
+				Usage:    "Task ID",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "state",
+				Aliases:  []string{"s"},
+				Usage:    "New state (pending, in-progress, completed, blocked, cancelled)",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "at",
+				Usage:    "When to change the state: RFC 3339, YYYY-MM-DDTHH:MM or YYYY-MM-DD (local time)",
+				Required: true,
+			},
+			shared.NewJSONFlag(),
+		},
+	}
+}
+
+// NewScheduledCommand creates the command listing pending scheduled state changes
+func NewScheduledCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:   "scheduled",
+		Usage:  "List the pending scheduled state changes of the project",
+		Action: scheduledAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "List the scheduled state changes of all projects",
+			},
+			shared.NewJSONFlag(),
+		},
+	}
+}
+
+// NewUnscheduleCommand creates the command cancelling a scheduled state change
+func NewUnscheduleCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:   "unschedule",
+		Usage:  "Cancel a scheduled state change",
+		Action: unscheduleAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "id",
+				Usage:    "ID of the scheduled state change, see 'knot task scheduled'",
+				Required: true,
+			},
+		},
+	}
+}
+
+func scheduleStateAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		taskIDStr := c.String("id")
+		taskID, err := uuid.Parse(taskIDStr)
+		if err != nil {
+			return errors.InvalidUUIDError("task-id", taskIDStr)
+		}
+		stateStr := c.String("state")
+		if err := errors.ValidateTaskState(stateStr); err != nil {
+			return err
+		}
+		at, err := utils.ParseTimestamp(c.String("at"))
+		if err != nil {
+			return errors.NewValidationError("invalid --at", err)
+		}
+
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
+		if err != nil {
+			return errors.TaskNotFoundError(taskID)
+		}
+		if task.ProjectID != projectID {
+			return fmt.Errorf("task %s belongs to project %s, but current project is %s",
+				taskID, task.ProjectID, projectID)
+		}
+
+		actor := shared.ResolveActor(c.String("actor"))
+		transition, err := appCtx.ProjectManager.ScheduleTaskState(c.Context, taskID, types.TaskState(stateStr), at, actor)
+		if err != nil {
+			appCtx.Logger.Error("Failed to schedule task state", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "scheduling task state")
+		}
+
+		if c.Bool("json") {
+			data, err := json.MarshalIndent(transition, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal scheduled state change to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(data))
+			return nil
+		}
+		appCtx.Out().Printf("Scheduled task '%s' to move to %s at %s (ID: %s)\n",
+			task.Title, transition.State, output.Timestamp(transition.At), transition.ID)
+		if transition.Due(appCtx.ProjectManager.GetCurrentTime()) {
+			appCtx.Out().Println("The time has already come; the change is applied by the next 'knot maintenance run'")
+		}
+		return nil
+	}
+}
+
+func scheduledAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		var projectID *uuid.UUID
+		if !c.Bool("all") {
+			id, err := shared.ResolveProjectID(c, appCtx)
+			if err != nil {
+				return err
+			}
+			projectID = &id
+		}
+
+		transitions, err := appCtx.ProjectManager.ListScheduledTransitions(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list scheduled state changes", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "listing scheduled state changes")
+		}
+
+		if c.Bool("json") {
+			data, err := json.MarshalIndent(transitions, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal scheduled state changes to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(data))
+			return nil
+		}
+
+		if len(transitions) == 0 {
+			appCtx.Out().Println("No scheduled state changes")
+			return nil
+		}
+		for _, transition := range transitions {
+			title := transition.TaskID.String()
+			if task, err := appCtx.ProjectManager.GetTask(c.Context, transition.TaskID); err == nil {
+				title = task.Title
+			}
+			appCtx.Out().Printf("%s  %s -> %s  by %s (ID: %s)\n",
+				output.Timestamp(transition.At), title, transition.State, transition.CreatedBy, transition.ID)
+		}
+		return nil
+	}
+}
+
+func unscheduleAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		idStr := c.String("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return errors.InvalidUUIDError("id", idStr)
+		}
+		if err := appCtx.ProjectManager.CancelScheduledTransition(c.Context, id); err != nil {
+			return errors.WrapWithSuggestion(err, "cancelling scheduled state change")
+		}
+		appCtx.Out().Printf("Cancelled scheduled state change %s\n", id)
+		return nil
+	}
+}
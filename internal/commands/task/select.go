@@ -0,0 +1,239 @@
+package task
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/filter"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// NewSelectInteractiveCommand creates the command selecting tasks from a list
+// with checkboxes and passing them to a bulk operation
+func NewSelectInteractiveCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "select-interactive",
+		Usage: "Select tasks from a list and run a bulk operation on them",
+		Description: `Lists the tasks of the selected project, optionally narrowed by a saved
+filter or filter query (see 'knot task reprioritize'), and lets you tick them by
+number, e.g. "1,3-5". The selected tasks are passed to --then:
+
+  knot task select-interactive --filter "state:in-progress" --then bulk-update --state completed
+  knot task select-interactive --filter "tag:spike" --then bulk-delete
+
+The flags of the bulk operation are given to this command. Without --then the
+IDs of the selected tasks are printed comma-separated, ready for --task-ids.
+The list and prompt are written to stderr.`,
+		Action: selectInteractiveAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "filter",
+				Aliases: []string{"f"},
+				Usage:   "Saved filter name or filter query narrowing the listed tasks",
+			},
+			&cli.StringFlag{
+				Name:  "then",
+				Usage: "Bulk operation run on the selected tasks (bulk-update, bulk-delete)",
+			},
+			&cli.StringFlag{
+				Name:  "state",
+				Usage: "bulk-update: new state (pending, in-progress, completed, blocked, cancelled)",
+			},
+			&cli.IntFlag{
+				Name:  "complexity",
+				Usage: "bulk-update: new complexity (1-10)",
+			},
+			&cli.BoolFlag{
+				Name:  "force-transition",
+				Usage: "bulk-update: allow state changes the state machine forbids",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "bulk-delete: show what would be deleted without deleting",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "bulk-delete: skip the confirmation prompt",
+			},
+		},
+	}
+}
+
+func selectInteractiveAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		// Check the operation before the user spends time selecting tasks
+		then := c.String("then")
+		var updates types.TaskUpdates
+		switch then {
+		case "", "bulk-delete":
+		case "bulk-update":
+			var err error
+			if updates, err = bulkUpdatesFromFlags(c); err != nil {
+				return err
+			}
+		default:
+			return &errors.EnhancedError{
+				Operation:  "selecting tasks",
+				Cause:      fmt.Errorf("unknown bulk operation '%s'", then),
+				Suggestion: "Use bulk-update or bulk-delete, or leave out --then to print the selected task IDs",
+				Example:    "knot task select-interactive --then bulk-update --state completed",
+			}
+		}
+
+		if !output.IsTerminal(os.Stdin) {
+			return &errors.EnhancedError{
+				Operation:  "selecting tasks",
+				Cause:      fmt.Errorf("interactive selection needs a terminal"),
+				Suggestion: "Pass the task IDs to the bulk command directly",
+				Example:    "knot task bulk-update --task-ids <id1>,<id2> --state completed",
+			}
+		}
+
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+		if value := c.String("filter"); value != "" {
+			query, err := filter.Resolve(appCtx.ProjectManager.GetConfig().SavedFilters, value)
+			if err != nil {
+				return errors.NewValidationError("invalid filter", err)
+			}
+			tasks = query.Filter(tasks)
+		}
+		if len(tasks) == 0 {
+			fmt.Fprintln(os.Stderr, "No tasks to select")
+			return nil
+		}
+
+		selected, ok := selectTasks(os.Stdin, os.Stderr, tasks)
+		// The time spent selecting does not count against --timeout
+		shared.RestartTimeout(c)
+		if !ok {
+			fmt.Fprintln(os.Stderr, "Selection cancelled.")
+			return nil
+		}
+		if len(selected) == 0 {
+			fmt.Fprintln(os.Stderr, "No tasks selected.")
+			return nil
+		}
+
+		taskIDs := make([]uuid.UUID, len(selected))
+		for i, task := range selected {
+			taskIDs[i] = task.ID
+		}
+		appCtx.Logger.Info("Tasks selected",
+			zap.Int("taskCount", len(taskIDs)),
+			zap.String("then", then))
+
+		switch then {
+		case "bulk-update":
+			return bulkUpdate(c, appCtx, taskIDs, updates)
+		case "bulk-delete":
+			return bulkDelete(c, appCtx, taskIDs)
+		}
+		ids := make([]string, len(taskIDs))
+		for i, id := range taskIDs {
+			ids[i] = id.String()
+		}
+		appCtx.Out().Println(strings.Join(ids, ","))
+		return nil
+	}
+}
+
+// selectTasks lists tasks with checkboxes on out and toggles them with the
+// numbers and ranges read from in until an empty line confirms the selection.
+// It returns the selected tasks in list order, and false if the selection
+// was cancelled with "q" or the input ended.
+func selectTasks(in io.Reader, out io.Writer, tasks []*types.Task) ([]*types.Task, bool) {
+	reader := bufio.NewReader(in)
+	checked := make([]bool, len(tasks))
+	width := len(strconv.Itoa(len(tasks)))
+
+	for {
+		for i, task := range tasks {
+			box := "[ ]"
+			if checked[i] {
+				box = "[x]"
+			}
+			fmt.Fprintf(out, "%s %*d. %s (%s, %s)\n", box, width, i+1, task.Title,
+				task.State, task.Priority.ToExternalString())
+		}
+		fmt.Fprint(out, "Toggle tasks by number or range (e.g. 1,3-5), a = all, n = none, enter = done, q = cancel: ")
+
+		line, err := reader.ReadString('\n')
+		answer := strings.ToLower(strings.TrimSpace(line))
+		if err != nil && answer == "" {
+			fmt.Fprintln(out)
+			return nil, false
+		}
+
+		switch answer {
+		case "":
+			var selected []*types.Task
+			for i, task := range tasks {
+				if checked[i] {
+					selected = append(selected, task)
+				}
+			}
+			return selected, true
+		case "q":
+			return nil, false
+		case "a", "n":
+			for i := range checked {
+				checked[i] = answer == "a"
+			}
+		default:
+			indexes, perr := parseSelection(answer, len(tasks))
+			if perr != nil {
+				fmt.Fprintf(out, "  %v\n", perr)
+				continue
+			}
+			for _, i := range indexes {
+				checked[i] = !checked[i]
+			}
+		}
+		fmt.Fprintln(out)
+	}
+}
+
+// parseSelection parses comma or space separated numbers and ranges such as
+// "1,3-5" of a list with n entries into zero-based indexes
+func parseSelection(input string, n int) ([]int, error) {
+	var indexes []int
+	for _, part := range strings.FieldsFunc(input, func(r rune) bool { return r == ',' || r == ' ' }) {
+		from, to, isRange := strings.Cut(part, "-")
+		first, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a number or range", part)
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(to); err != nil {
+				return nil, fmt.Errorf("'%s' is not a number or range", part)
+			}
+		}
+		if first < 1 || last > n || first > last {
+			return nil, fmt.Errorf("'%s' is outside of 1-%d", part, n)
+		}
+		for i := first; i <= last; i++ {
+			indexes = append(indexes, i-1)
+		}
+	}
+	return indexes, nil
+}
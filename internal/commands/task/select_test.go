@@ -0,0 +1,50 @@
+package task
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelection(t *testing.T) {
+	indexes, err := parseSelection("1,3-5 7", 8)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 2, 3, 4, 6}, indexes)
+
+	for _, input := range []string{"0", "9", "5-3", "x", "2-y"} {
+		_, err := parseSelection(input, 8)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestSelectTasks(t *testing.T) {
+	tasks := []*types.Task{
+		{Title: "First", State: types.TaskStatePending, Priority: types.TaskPriorityHigh},
+		{Title: "Second", State: types.TaskStateInProgress, Priority: types.TaskPriorityMedium},
+		{Title: "Third", State: types.TaskStatePending, Priority: types.TaskPriorityLow},
+	}
+
+	var out bytes.Buffer
+	selected, ok := selectTasks(strings.NewReader("1-3\n2\nbogus\n\n"), &out, tasks)
+	require.True(t, ok)
+	require.Len(t, selected, 2)
+	assert.Equal(t, "First", selected[0].Title)
+	assert.Equal(t, "Third", selected[1].Title)
+	assert.Contains(t, out.String(), "[x] 2. Second (in-progress, medium)")
+	assert.Contains(t, out.String(), "'bogus' is not a number or range")
+
+	selected, ok = selectTasks(strings.NewReader("a\nn\n\n"), &out, tasks)
+	assert.True(t, ok)
+	assert.Empty(t, selected)
+
+	_, ok = selectTasks(strings.NewReader("1\nq\n"), &out, tasks)
+	assert.False(t, ok)
+
+	// Input ending before the selection is confirmed cancels it
+	_, ok = selectTasks(strings.NewReader("1\n"), &out, tasks)
+	assert.False(t, ok)
+}
@@ -0,0 +1,201 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/filter"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// transitionEntry is a task of the bulk-transition command, with the reason
+// why it is skipped if it is
+type transitionEntry struct {
+	TaskID uuid.UUID       `json:"task_id"`
+	Title  string          `json:"title"`
+	From   types.TaskState `json:"from"`
+	Reason string          `json:"reason,omitempty"`
+}
+
+// transitionResult is the JSON output of the bulk-transition command
+type transitionResult struct {
+	Filter    string            `json:"filter"`
+	State     types.TaskState   `json:"state"`
+	Matched   int               `json:"matched"`
+	Unchanged int               `json:"unchanged"`
+	DryRun    bool              `json:"dry_run"`
+	Applied   bool              `json:"applied"`
+	Valid     []transitionEntry `json:"valid"`
+	Skipped   []transitionEntry `json:"skipped"`
+}
+
+// NewBulkTransitionCommand creates the command moving all tasks matching a filter to a state
+func NewBulkTransitionCommand(appCtx *shared.AppContext) *cli.Command {
+	return &cli.Command{
+		Name:  "bulk-transition",
+		Usage: "Move all tasks matching a filter to a state, skipping invalid transitions",
+		Description: `Moves all tasks in the selected project that match a saved filter (see
+'knot filter save') or a filter query to a state, e.g.
+
+  knot task bulk-transition --filter "state:in-progress tag:backend" --state completed --dry-run
+
+Every transition is validated first, with the same rules as 'knot task
+update-state': the state machine, verified acceptance criteria and required
+reviews. Tasks that cannot make the transition are reported with the reason and
+skipped; the valid transitions are applied together, either all or none. With
+--strict nothing is applied when any task would be skipped.`,
+		Action: bulkTransitionAction(appCtx),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "filter",
+				Aliases:  []string{"f"},
+				Usage:    "Saved filter name or filter query",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "state",
+				Aliases:  []string{"s"},
+				Usage:    "New state (pending, in-progress, completed, blocked, cancelled)",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "strict",
+				Usage: "Apply nothing if any matching task cannot make the transition",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Show the validation report without applying the transitions",
+			},
+			shared.NewJSONFlag(),
+		},
+	}
+}
+
+func bulkTransitionAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		stateStr := c.String("state")
+		if err := errors.ValidateTaskState(stateStr); err != nil {
+			return err
+		}
+		state := types.TaskState(stateStr)
+
+		query, err := filter.Resolve(appCtx.ProjectManager.GetConfig().SavedFilters, c.String("filter"))
+		if err != nil {
+			return errors.NewValidationError("invalid filter", err)
+		}
+
+		projectID, err := shared.ResolveProjectID(c, appCtx)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list tasks", zap.Error(err))
+			return fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		matched := query.Filter(tasks)
+		result := transitionResult{
+			Filter:  c.String("filter"),
+			State:   state,
+			Matched: len(matched),
+			DryRun:  c.Bool("dry-run"),
+			Valid:   make([]transitionEntry, 0),
+			Skipped: make([]transitionEntry, 0),
+		}
+		var taskIDs []uuid.UUID
+		for _, task := range matched {
+			if task.State == state {
+				result.Unchanged++
+				continue
+			}
+			entry := transitionEntry{TaskID: task.ID, Title: task.Title, From: task.State}
+			if err := appCtx.ProjectManager.CheckStateTransition(task, state); err != nil {
+				entry.Reason = err.Error()
+				result.Skipped = append(result.Skipped, entry)
+				continue
+			}
+			taskIDs = append(taskIDs, task.ID)
+			result.Valid = append(result.Valid, entry)
+		}
+
+		blockedByStrict := c.Bool("strict") && len(result.Skipped) > 0
+		actor := shared.GetActorFromContext(c)
+		if !result.DryRun && !blockedByStrict && len(taskIDs) > 0 {
+			appCtx.Logger.Info("Transitioning tasks",
+				zap.String("filter", result.Filter),
+				zap.Int("taskCount", len(taskIDs)),
+				zap.Int("skipped", len(result.Skipped)),
+				zap.String("state", stateStr),
+				zap.String("actor", actor))
+
+			if _, err := appCtx.ProjectManager.TransitionTasks(c.Context, taskIDs, state, actor); err != nil {
+				appCtx.Logger.Error("Failed to transition tasks", zap.Error(err))
+				return errors.WrapWithSuggestion(err, "transitioning tasks")
+			}
+			result.Applied = true
+		}
+
+		if c.Bool("json") {
+			jsonData, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal bulk transition result to JSON: %w", err)
+			}
+			appCtx.Out().Println(string(jsonData))
+		} else {
+			printTransitionResult(appCtx.Out(), result, actor)
+		}
+
+		if blockedByStrict {
+			return &errors.EnhancedError{
+				Operation:   "bulk transition",
+				Cause:       fmt.Errorf("%d task(s) cannot be moved to '%s', nothing was applied (--strict)", len(result.Skipped), state),
+				Suggestion:  "Narrow the filter to the tasks that can make the transition, or run without --strict to skip the others",
+				Example:     fmt.Sprintf("knot task bulk-transition --filter \"%s\" --state %s", result.Filter, state),
+				HelpCommand: "knot task bulk-transition --help",
+			}
+		}
+		return nil
+	}
+}
+
+func printTransitionResult(out output.Writer, result transitionResult, actor string) {
+	out.Printf("Filter '%s' matched %d task(s), %d already %s\n",
+		result.Filter, result.Matched, result.Unchanged, result.State)
+
+	if len(result.Skipped) > 0 {
+		out.Printf("\nSkipped %d task(s):\n", len(result.Skipped))
+		for _, entry := range result.Skipped {
+			out.Printf("  %s (ID: %s, %s): %s\n", entry.Title, entry.TaskID, entry.From, entry.Reason)
+		}
+	}
+
+	if len(result.Valid) == 0 {
+		out.Println("\nNo tasks to transition.")
+		return
+	}
+
+	switch {
+	case result.Applied:
+		out.Printf("\nMoved %d task(s) to %s:\n", len(result.Valid), result.State)
+	default:
+		out.Printf("\nWould move %d task(s) to %s:\n", len(result.Valid), result.State)
+	}
+	for _, entry := range result.Valid {
+		out.Printf("  %s (ID: %s): %s -> %s\n", entry.Title, entry.TaskID, entry.From, result.State)
+	}
+
+	switch {
+	case result.Applied:
+		out.Printf("  Updated by: %s\n", actor)
+	case result.DryRun:
+		out.Println("\nRun without --dry-run to apply these transitions.")
+	}
+}
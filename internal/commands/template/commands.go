@@ -7,9 +7,13 @@ import (
 	"os/exec"
 	"strings"
 
+	knoterrors "github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/placeholder"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/templates"
 	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
 	"github.com/google/uuid"
 	"github.com/urfave/cli/v2"
 )
@@ -86,10 +90,18 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 					Aliases: []string{"v"},
 					Usage:   "Template variables in format key=value (can specify multiple)",
 				},
+				&cli.StringFlag{
+					Name:  "iterations-file",
+					Usage: "YAML or JSON list of variable maps; applies the template once per entry",
+				},
 				&cli.BoolFlag{
 					Name:  "dry-run",
 					Usage: "Preview what tasks would be created without actually creating them",
 				},
+				&cli.BoolFlag{
+					Name:  "no-prompt",
+					Usage: "Fail instead of prompting for missing required variables",
+				},
 				&cli.BoolFlag{
 					Name:    "json",
 					Aliases: []string{"j"},
@@ -97,6 +109,20 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 				},
 			},
 		},
+		{
+			Name:   "complete-var",
+			Usage:  "Print --var completions of a template for shell completion",
+			Hidden: true,
+			Action: completeVarAction(appCtx),
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "name",
+					Aliases:  []string{"n"},
+					Usage:    "Template name",
+					Required: true,
+				},
+			},
+		},
 		{
 			Name:   "create",
 			Usage:  "Create a new template from file",
@@ -187,28 +213,28 @@ func listAction(appCtx *shared.AppContext) cli.ActionFunc {
 		filtered := applyTemplateFilters(templates, c)
 
 		if len(filtered) == 0 {
-			fmt.Println("No templates found matching the criteria.")
+			appCtx.Out().Println("No templates found matching the criteria.")
 			return nil
 		}
 
 		// Check if JSON output is requested
 		if c.Bool("json") {
-			return outputTemplatesAsJSON(filtered)
+			return outputTemplatesAsJSON(appCtx.Out(), filtered)
 		}
 
-		fmt.Printf("Found %d template(s):\n\n", len(filtered))
+		appCtx.Out().Printf("Found %d template(s):\n\n", len(filtered))
 		for _, template := range filtered {
-			fmt.Printf("* %s\n", template.Name)
-			fmt.Printf("  Category: %s\n", template.Category)
+			appCtx.Out().Printf("* %s\n", template.Name)
+			appCtx.Out().Printf("  Category: %s\n", template.Category)
 			if len(template.Tags) > 0 {
-				fmt.Printf("  Tags: %s\n", strings.Join(template.Tags, ", "))
+				appCtx.Out().Printf("  Tags: %s\n", strings.Join(template.Tags, ", "))
 			}
-			fmt.Printf("  Description: %s\n", template.Description)
-			fmt.Printf("  Tasks: %d\n", len(template.Tasks))
+			appCtx.Out().Printf("  Description: %s\n", template.Description)
+			appCtx.Out().Printf("  Tasks: %d\n", len(template.Tasks))
 			if len(template.Variables) > 0 {
-				fmt.Printf("  Variables: %d\n", len(template.Variables))
+				appCtx.Out().Printf("  Variables: %d\n", len(template.Variables))
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 		}
 
 		return nil
@@ -227,53 +253,56 @@ func showAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		// Check if JSON output is requested
 		if c.Bool("json") {
-			return outputTemplateAsJSON(template)
+			return outputTemplateAsJSON(appCtx.Out(), template)
 		}
 
-		fmt.Printf("Template: %s\n", template.Name)
-		fmt.Printf("Category: %s\n", template.Category)
+		appCtx.Out().Printf("Template: %s\n", template.Name)
+		appCtx.Out().Printf("Category: %s\n", template.Category)
 		if len(template.Tags) > 0 {
-			fmt.Printf("Tags: %s\n", strings.Join(template.Tags, ", "))
+			appCtx.Out().Printf("Tags: %s\n", strings.Join(template.Tags, ", "))
 		}
-		fmt.Printf("Description: %s\n\n", template.Description)
+		appCtx.Out().Printf("Description: %s\n\n", template.Description)
 
 		if len(template.Variables) > 0 {
-			fmt.Println("Variables:")
+			appCtx.Out().Println("Variables:")
 			for _, variable := range template.Variables {
-				fmt.Printf("  %s (%s)", variable.Name, variable.Type)
+				appCtx.Out().Printf("  %s (%s)", variable.Name, variable.Type)
 				if variable.Required {
-					fmt.Print(" [required]")
+					appCtx.Out().Print(" [required]")
 				}
 				if variable.DefaultValue != nil {
-					fmt.Printf(" [default: %s]", *variable.DefaultValue)
+					appCtx.Out().Printf(" [default: %s]", *variable.DefaultValue)
 				}
-				fmt.Printf("\n    %s\n", variable.Description)
+				appCtx.Out().Printf("\n    %s\n", variable.Description)
 				if variable.Type == types.VarTypeChoice && len(variable.Options) > 0 {
-					fmt.Printf("    Options: %s\n", strings.Join(variable.Options, ", "))
+					appCtx.Out().Printf("    Options: %s\n", strings.Join(variable.Options, ", "))
+				}
+				if variable.Pattern != "" || variable.Min != nil || variable.Max != nil {
+					appCtx.Out().Printf("    Accepts: %s\n", variable.Hint())
 				}
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 		}
 
-		fmt.Printf("Tasks (%d):\n", len(template.Tasks))
+		appCtx.Out().Printf("Tasks (%d):\n", len(template.Tasks))
 		for i, task := range template.Tasks {
 			indent := ""
 			if task.ParentID != nil {
 				indent = "  "
 			}
-			fmt.Printf("%s%d. %s (ID: %s)\n", indent, i+1, task.Title, task.ID)
-			fmt.Printf("%s   Complexity: %d", indent, task.Complexity)
+			appCtx.Out().Printf("%s%d. %s (ID: %s)\n", indent, i+1, task.Title, task.ID)
+			appCtx.Out().Printf("%s   Complexity: %d", indent, task.Complexity)
 			if task.Estimate != nil {
-				fmt.Printf(" | Estimate: %d min", *task.Estimate)
+				appCtx.Out().Printf(" | Estimate: %s", utils.FormatEstimate(*task.Estimate))
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 			if task.Description != "" {
-				fmt.Printf("%s   %s\n", indent, task.Description)
+				appCtx.Out().Printf("%s   %s\n", indent, task.Description)
 			}
 			if len(task.Dependencies) > 0 {
-				fmt.Printf("%s   Dependencies: %s\n", indent, strings.Join(task.Dependencies, ", "))
+				appCtx.Out().Printf("%s   Dependencies: %s\n", indent, strings.Join(task.Dependencies, ", "))
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 		}
 
 		return nil
@@ -295,18 +324,9 @@ func applyAction(appCtx *shared.AppContext) cli.ActionFunc {
 		}
 
 		// Parse variables
-		variables := make(map[string]string)
-		for _, varStr := range c.StringSlice("var") {
-			parts := strings.SplitN(varStr, "=", 2)
-			if len(parts) != 2 {
-				return fmt.Errorf("invalid variable format: %s (expected key=value)", varStr)
-			}
-			variables[parts[0]] = parts[1]
-		}
-
-		// Validate required variables
-		if err := validateTemplateVariables(template, variables); err != nil {
-			return fmt.Errorf("variable validation failed: %w", err)
+		variables, err := placeholder.ParseVars(c.StringSlice("var"))
+		if err != nil {
+			return knoterrors.NewValidationError("invalid template variable", err)
 		}
 
 		// Parse parent ID if provided
@@ -319,23 +339,45 @@ func applyAction(appCtx *shared.AppContext) cli.ActionFunc {
 			parentID = &parsed
 		}
 
+		// Apply once per row of an iterations file
+		if c.IsSet("iterations-file") {
+			return applyIterations(c, appCtx, template, projectID, parentID, variables)
+		}
+
+		// Ask for missing required variables when running in a terminal
+		if missing := missingVariables(template, variables); len(missing) > 0 {
+			if c.Bool("no-prompt") || !output.IsTerminal(os.Stdin) {
+				return missingVariablesError(template, missing)
+			}
+			if err := promptVariables(os.Stdin, os.Stderr, missing, variables); err != nil {
+				return knoterrors.NewValidationError("invalid template variable", err)
+			}
+			// The time spent answering does not count against --timeout
+			shared.RestartTimeout(c)
+		}
+
+		// Validate required variables
+		if err := validateTemplateVariables(template, variables); err != nil {
+			return knoterrors.NewValidationError("invalid template variable", err)
+		}
+
 		dryRun := c.Bool("dry-run")
 
 		// Apply template
-		result, err := applyTemplate(appCtx, template, projectID, parentID, variables, dryRun)
+		result, err := applyTemplate(c.Context, appCtx, template, projectID, parentID, variables, dryRun)
 		if err != nil {
-			return fmt.Errorf("failed to apply template: %w", err)
+			return knoterrors.WrapWithSuggestion(err, "applying template")
 		}
 
 		// Check if JSON output is requested
 		if c.Bool("json") {
-			return outputApplyResultAsJSON(result)
+			return outputApplyResultAsJSON(appCtx.Out(), result)
 		}
 
 		if dryRun {
-			fmt.Printf("Template '%s' would create %d tasks:\n\n", template.Name, len(result.CreatedTasks))
+			appCtx.Out().Printf("Template '%s' would create %d tasks:\n\n", template.Name, len(result.CreatedTasks))
 		} else {
-			fmt.Printf("Template '%s' successfully created %d tasks:\n\n", template.Name, len(result.CreatedTasks))
+			appCtx.Out().Printf("Template '%s' successfully created %d tasks:\n\n", template.Name, len(result.CreatedTasks))
 		}
 
 		for i, task := range result.CreatedTasks {
@@ -343,18 +385,18 @@ func applyAction(appCtx *shared.AppContext) cli.ActionFunc {
 			for j := 0; j < task.Depth; j++ {
 				indent += "  "
 			}
-			fmt.Printf("%s%d. %s (ID: %s)\n", indent, i+1, task.Title, task.ID)
-			fmt.Printf("%s   Complexity: %d | State: %s\n", indent, task.Complexity, task.State)
+			appCtx.Out().Printf("%s%d. %s (ID: %s)\n", indent, i+1, task.Title, task.ID)
+			appCtx.Out().Printf("%s   Complexity: %d | State: %s\n", indent, task.Complexity, task.State)
 			if task.Description != "" {
-				fmt.Printf("%s   %s\n", indent, task.Description)
+				appCtx.Out().Printf("%s   %s\n", indent, task.Description)
 			}
-			fmt.Println()
+			appCtx.Out().Println()
 		}
 
 		if len(result.Errors) > 0 {
-			fmt.Println("Errors:")
+			appCtx.Out().Println("Errors:")
 			for _, errMsg := range result.Errors {
-				fmt.Printf("  - %s\n", errMsg)
+				appCtx.Out().Printf("  - %s\n", errMsg)
 			}
 		}
 
@@ -362,6 +404,37 @@ func applyAction(appCtx *shared.AppContext) cli.ActionFunc {
 	}
 }
 
+// missingVariablesError explains how to pass the missing required variables
+func missingVariablesError(template *types.TaskTemplate, missing []types.Variable) error {
+	names := make([]string, len(missing))
+	flags := make([]string, len(missing))
+	for i, variable := range missing {
+		names[i] = variable.Name
+		flags[i] = fmt.Sprintf("--var %s=<%s>", variable.Name, variable.Hint())
+	}
+	return &knoterrors.EnhancedError{
+		Operation:   "applying template",
+		Cause:       fmt.Errorf("required variable(s) not provided: %s", strings.Join(names, ", ")),
+		Suggestion:  "Pass the variables with --var, or run the command in a terminal to be prompted for them",
+		Example:     fmt.Sprintf("knot template apply --name %q %s", template.Name, strings.Join(flags, " ")),
+		HelpCommand: fmt.Sprintf("knot template show --name %q", template.Name),
+	}
+}
+
+// completeVarAction prints the --var candidates of a template, one per line
+func completeVarAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		template, err := findTemplateByName(c.String("name"))
+		if err != nil {
+			return nil // No completions for unknown templates
+		}
+		for _, candidate := range variableCompletions(template) {
+			appCtx.Out().Println(candidate)
+		}
+		return nil
+	}
+}
+
 // createAction creates a new template from file
 func createAction(appCtx *shared.AppContext) cli.ActionFunc {
 	return func(c *cli.Context) error {
@@ -378,8 +451,8 @@ func createAction(appCtx *shared.AppContext) cli.ActionFunc {
 		}
 
 		// TODO: Save to database when template repository is implemented
-		fmt.Printf("Template '%s' loaded and validated successfully.\n", template.Name)
-		fmt.Printf("Note: Template persistence not yet implemented.\n")
+		appCtx.Out().Printf("Template '%s' loaded and validated successfully.\n", template.Name)
+		appCtx.Out().Printf("Note: Template persistence not yet implemented.\n")
 
 		return nil
 	}
@@ -399,9 +472,9 @@ func validateAction(appCtx *shared.AppContext) cli.ActionFunc {
 			return fmt.Errorf("template validation failed: %w", err)
 		}
 
-		fmt.Printf("Template '%s' is valid.\n", template.Name)
-		fmt.Printf("  Tasks: %d\n", len(template.Tasks))
-		fmt.Printf("  Variables: %d\n", len(template.Variables))
+		appCtx.Out().Printf("Template '%s' is valid.\n", template.Name)
+		appCtx.Out().Printf("  Tasks: %d\n", len(template.Tasks))
+		appCtx.Out().Printf("  Variables: %d\n", len(template.Variables))
 
 		return nil
 	}
@@ -439,23 +512,23 @@ func infoAction(appCtx *shared.AppContext) cli.ActionFunc {
 			if err != nil {
 				return fmt.Errorf("failed to marshal info to JSON: %w", err)
 			}
-			fmt.Println(string(data))
+			appCtx.Out().Println(string(data))
 			return nil
 		}
 
-		fmt.Printf("Template: %s\n", template.Name)
-		fmt.Printf("Source: %s\n", source)
+		appCtx.Out().Printf("Template: %s\n", template.Name)
+		appCtx.Out().Printf("Source: %s\n", source)
 		if filePath != "" {
-			fmt.Printf("File Path: %s\n", filePath)
+			appCtx.Out().Printf("File Path: %s\n", filePath)
 		}
-		fmt.Printf("Category: %s\n", template.Category)
+		appCtx.Out().Printf("Category: %s\n", template.Category)
 		if len(template.Tags) > 0 {
-			fmt.Printf("Tags: %s\n", strings.Join(template.Tags, ", "))
+			appCtx.Out().Printf("Tags: %s\n", strings.Join(template.Tags, ", "))
 		}
-		fmt.Printf("Description: %s\n", template.Description)
-		fmt.Printf("Tasks: %d\n", len(template.Tasks))
-		fmt.Printf("Variables: %d\n", len(template.Variables))
-		fmt.Printf("Built-in: %t\n", template.IsBuiltIn)
+		appCtx.Out().Printf("Description: %s\n", template.Description)
+		appCtx.Out().Printf("Tasks: %d\n", len(template.Tasks))
+		appCtx.Out().Printf("Variables: %d\n", len(template.Variables))
+		appCtx.Out().Printf("Built-in: %t\n", template.IsBuiltIn)
 
 		return nil
 	}
@@ -499,8 +572,8 @@ func editAction(appCtx *shared.AppContext) cli.ActionFunc {
 			return fmt.Errorf("failed to open editor: %w", err)
 		}
 
-		fmt.Printf("Template \"%s\" edited successfully.\n", templateName)
-		fmt.Printf("Use \"knot template validate --file %s\" to validate changes.\n", filePath)
+		appCtx.Out().Printf("Template \"%s\" edited successfully.\n", templateName)
+		appCtx.Out().Printf("Use \"knot template validate --file %s\" to validate changes.\n", filePath)
 
 		return nil
 	}
@@ -525,11 +598,11 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		// Confirmation prompt (unless force flag is used)
 		if !force {
-			fmt.Printf("Are you sure you want to delete user template \"%s\"? (y/N): ", templateName)
+			appCtx.Out().Printf("Are you sure you want to delete user template \"%s\"? (y/N): ", templateName)
 			var response string
 			_, _ = fmt.Scanln(&response)
 			if response != "y" && response != "Y" && response != "yes" && response != "YES" {
-				fmt.Println("Deletion cancelled.")
+				appCtx.Out().Println("Deletion cancelled.")
 				return nil
 			}
 		}
@@ -539,14 +612,14 @@ func deleteAction(appCtx *shared.AppContext) cli.ActionFunc {
 			return fmt.Errorf("failed to delete template: %w", err)
 		}
 
-		fmt.Printf("User template \"%s\" deleted successfully.\n", templateName)
+		appCtx.Out().Printf("User template \"%s\" deleted successfully.\n", templateName)
 
 		// Check if built-in version exists
 		builtInTemplates, err := loadBuiltInTemplates()
 		if err == nil {
 			for _, builtIn := range builtInTemplates {
 				if strings.EqualFold(builtIn.Name, templateName) {
-					fmt.Printf("Note: Built-in template \"%s\" is still available.\n", templateName)
+					appCtx.Out().Printf("Note: Built-in template \"%s\" is still available.\n", templateName)
 					break
 				}
 			}
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"strings"
 
+	knoterrors "github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/placeholder"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/templates"
 	"github.com/denkhaus/knot/v2/internal/types"
@@ -180,8 +183,8 @@ func validateTemplate(template *types.TaskTemplate) error {
 		}
 		varNames[variable.Name] = true
 
-		if variable.Type == types.VarTypeChoice && len(variable.Options) == 0 {
-			return fmt.Errorf("choice variable %s must have options", variable.Name)
+		if err := variable.Check(); err != nil {
+			return err
 		}
 	}
 
@@ -189,27 +192,18 @@ func validateTemplate(template *types.TaskTemplate) error {
 }
 
 // validateTemplateVariables validates that required variables are provided
+// and that provided values match the variable types
 func validateTemplateVariables(template *types.TaskTemplate, variables map[string]string) error {
 	for _, variable := range template.Variables {
 		value, provided := variables[variable.Name]
 
-		if variable.Required && !provided {
+		if variable.Required && !provided && variable.DefaultValue == nil {
 			return fmt.Errorf("required variable '%s' not provided", variable.Name)
 		}
 
 		if provided {
-			// Validate choice variables
-			if variable.Type == types.VarTypeChoice {
-				validOption := false
-				for _, option := range variable.Options {
-					if value == option {
-						validOption = true
-						break
-					}
-				}
-				if !validOption {
-					return fmt.Errorf("variable '%s' must be one of: %s", variable.Name, strings.Join(variable.Options, ", "))
-				}
+			if err := variable.Validate(value); err != nil {
+				return err
 			}
 		}
 	}
@@ -217,8 +211,21 @@ func validateTemplateVariables(template *types.TaskTemplate, variables map[strin
 	return nil
 }
 
+// ApplyByName applies the template with the given name, like `knot template
+// apply` without prompting for missing variables
+func ApplyByName(ctx context.Context, appCtx *shared.AppContext, name string, projectID uuid.UUID, parentID *uuid.UUID, variables map[string]string, dryRun bool) (*types.TemplateApplyResult, error) {
+	template, err := findTemplateByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTemplateVariables(template, variables); err != nil {
+		return nil, err
+	}
+	return applyTemplate(ctx, appCtx, template, projectID, parentID, variables, dryRun)
+}
+
 // applyTemplate applies a template to create tasks
-func applyTemplate(appCtx *shared.AppContext, template *types.TaskTemplate, projectID uuid.UUID, parentID *uuid.UUID, variables map[string]string, dryRun bool) (*types.TemplateApplyResult, error) {
+func applyTemplate(ctx context.Context, appCtx *shared.AppContext, template *types.TaskTemplate, projectID uuid.UUID, parentID *uuid.UUID, variables map[string]string, dryRun bool) (*types.TemplateApplyResult, error) {
 	result := &types.TemplateApplyResult{
 		Success: true,
 	}
@@ -234,6 +241,21 @@ func applyTemplate(appCtx *shared.AppContext, template *types.TaskTemplate, proj
 		}
 	}
 
+	// Placeholders: template variables, the project and the parent task
+	project, err := appCtx.ProjectManager.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, knoterrors.WrapWithSuggestion(err, "getting project")
+	}
+	values := placeholder.New(appCtx.ProjectManager.GetCurrentTime(), appCtx.GetActor()).AddProject(project)
+	if parentID != nil {
+		parent, err := appCtx.ProjectManager.GetTask(ctx, *parentID)
+		if err != nil {
+			return nil, knoterrors.WrapWithSuggestion(err, "getting parent task")
+		}
+		values.AddParent(parent)
+	}
+	values.AddVars(finalVariables)
+
 	// Create task ID mapping for dependencies
 	taskIDMap := make(map[string]uuid.UUID)
 
@@ -247,8 +269,8 @@ func applyTemplate(appCtx *shared.AppContext, template *types.TaskTemplate, proj
 		task := &types.Task{
 			ID:          uuid.New(),
 			ProjectID:   projectID,
-			Title:       substituteVariables(taskSpec.Title, finalVariables),
-			Description: substituteVariables(taskSpec.Description, finalVariables),
+			Title:       values.Expand(taskSpec.Title),
+			Description: values.Expand(taskSpec.Description),
 			State:       types.TaskStatePending,
 			Complexity:  taskSpec.Complexity,
 			Estimate:    taskSpec.Estimate,
@@ -269,7 +291,7 @@ func applyTemplate(appCtx *shared.AppContext, template *types.TaskTemplate, proj
 		if !dryRun {
 			actor := appCtx.GetActor()
 			createdTask, err := appCtx.ProjectManager.CreateTask(
-				context.Background(),
+				ctx,
 				projectID,
 				task.ParentID,
 				task.Title,
@@ -283,6 +305,8 @@ func applyTemplate(appCtx *shared.AppContext, template *types.TaskTemplate, proj
 				result.Success = false
 				continue
 			}
+			// Subtasks refer to the stored task, not the placeholder ID
+			taskIDMap[taskSpec.ID] = createdTask.ID
 			result.CreatedTasks = append(result.CreatedTasks, createdTask)
 		} else {
 			result.CreatedTasks = append(result.CreatedTasks, task)
@@ -324,40 +348,30 @@ func shouldSkipTask(taskSpec types.TaskSpec, variables map[string]string) bool {
 	return false
 }
 
-// substituteVariables replaces template variables in text
-func substituteVariables(text string, variables map[string]string) string {
-	result := text
-	for name, value := range variables {
-		placeholder := fmt.Sprintf("{{%s}}", name)
-		result = strings.ReplaceAll(result, placeholder, value)
-	}
-	return result
-}
-
 // Output functions
-func outputTemplatesAsJSON(templates []*types.TaskTemplate) error {
+func outputTemplatesAsJSON(out output.Writer, templates []*types.TaskTemplate) error {
 	data, err := json.MarshalIndent(templates, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal templates to JSON: %w", err)
 	}
-	fmt.Println(string(data))
+	out.Println(string(data))
 	return nil
 }
 
-func outputTemplateAsJSON(template *types.TaskTemplate) error {
+func outputTemplateAsJSON(out output.Writer, template *types.TaskTemplate) error {
 	data, err := json.MarshalIndent(template, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal template to JSON: %w", err)
 	}
-	fmt.Println(string(data))
+	out.Println(string(data))
 	return nil
 }
 
-func outputApplyResultAsJSON(result *types.TemplateApplyResult) error {
+func outputApplyResultAsJSON(out output.Writer, result *types.TemplateApplyResult) error {
 	data, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal result to JSON: %w", err)
 	}
-	fmt.Println(string(data))
+	out.Println(string(data))
 	return nil
 }
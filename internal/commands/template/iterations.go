@@ -0,0 +1,166 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	knoterrors "github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// iterationResult is the outcome of applying a template for one row of an
+// iterations file
+type iterationResult struct {
+	Iteration int                        `json:"iteration"`
+	Variables map[string]string          `json:"variables"`
+	Result    *types.TemplateApplyResult `json:"result"`
+}
+
+// loadIterations reads an iterations file: a YAML or JSON list with one map of
+// variable values per iteration
+func loadIterations(path string) ([]map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read iterations file: %w", err)
+	}
+
+	var rows []map[string]any
+	if err := yaml.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("failed to parse iterations file %s: expected a list of variable maps: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("iterations file %s contains no iterations", path)
+	}
+
+	iterations := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		iterations[i] = make(map[string]string, len(row))
+		for name, value := range row {
+			switch value.(type) {
+			case map[string]any, []any:
+				return nil, fmt.Errorf("iteration %d: variable '%s' must be a scalar value", i+1, name)
+			case nil:
+				continue
+			}
+			iterations[i][name] = fmt.Sprint(value)
+		}
+	}
+	return iterations, nil
+}
+
+// iterationVariables merges the values of one iteration over the values given
+// with --var
+func iterationVariables(base, row map[string]string) map[string]string {
+	variables := make(map[string]string, len(base)+len(row))
+	for name, value := range base {
+		variables[name] = value
+	}
+	for name, value := range row {
+		variables[name] = value
+	}
+	return variables
+}
+
+// applyIterations applies the template once per row of the iterations file.
+// All rows are validated before any task is created.
+func applyIterations(c *cli.Context, appCtx *shared.AppContext, template *types.TaskTemplate, projectID uuid.UUID, parentID *uuid.UUID, base map[string]string) error {
+	rows, err := loadIterations(c.String("iterations-file"))
+	if err != nil {
+		return knoterrors.NewValidationError("invalid iterations file", err)
+	}
+
+	results := make([]iterationResult, len(rows))
+	for i, row := range rows {
+		variables := iterationVariables(base, row)
+		if missing := missingVariables(template, variables); len(missing) > 0 {
+			names := make([]string, len(missing))
+			for j, variable := range missing {
+				names[j] = variable.Name
+			}
+			return knoterrors.NewValidationError("invalid iteration",
+				fmt.Errorf("iteration %d: required variable(s) not provided: %s", i+1, strings.Join(names, ", ")))
+		}
+		if err := validateTemplateVariables(template, variables); err != nil {
+			return knoterrors.NewValidationError("invalid iteration", fmt.Errorf("iteration %d: %w", i+1, err))
+		}
+		results[i] = iterationResult{Iteration: i + 1, Variables: row}
+	}
+
+	dryRun := c.Bool("dry-run")
+	for i := range results {
+		variables := iterationVariables(base, results[i].Variables)
+		result, err := applyTemplate(c.Context, appCtx, template, projectID, parentID, variables, dryRun)
+		if err != nil {
+			return knoterrors.WrapWithSuggestion(err, "applying template")
+		}
+		results[i].Result = result
+	}
+
+	if c.Bool("json") {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal result to JSON: %w", err)
+		}
+		appCtx.Out().Println(string(data))
+		return nil
+	}
+
+	printIterationReport(appCtx.Out(), template, results, dryRun)
+	return nil
+}
+
+// printIterationReport prints the tasks of every iteration followed by totals
+func printIterationReport(out output.Writer, template *types.TaskTemplate, results []iterationResult, dryRun bool) {
+	total, failed := 0, 0
+	for _, iteration := range results {
+		total += len(iteration.Result.CreatedTasks)
+		if len(iteration.Result.Errors) > 0 {
+			failed++
+		}
+	}
+
+	verb := "successfully created"
+	if dryRun {
+		verb = "would create"
+	}
+	out.Printf("Template '%s' %s %d tasks in %d iterations:\n\n", template.Name, verb, total, len(results))
+
+	for _, iteration := range results {
+		out.Printf("Iteration %d (%s): %d tasks\n", iteration.Iteration, formatIterationVariables(iteration.Variables), len(iteration.Result.CreatedTasks))
+		for _, task := range iteration.Result.CreatedTasks {
+			out.Printf("  %s- %s (ID: %s)\n", strings.Repeat("  ", task.Depth), task.Title, task.ID)
+		}
+		for _, errMsg := range iteration.Result.Errors {
+			out.Printf("  Error: %s\n", errMsg)
+		}
+		out.Println()
+	}
+
+	if failed > 0 {
+		out.Printf("%d of %d iterations had errors\n", failed, len(results))
+	}
+}
+
+// formatIterationVariables renders the values of an iteration as sorted
+// name=value pairs
+func formatIterationVariables(variables map[string]string) string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + variables[name]
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,53 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadIterations(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	rows, err := loadIterations(write("sprints.yaml", `
+- sprint: 1
+  goal: Onboarding
+- sprint: 2
+  goal: Billing
+  hotfix: true
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"sprint": "1", "goal": "Onboarding"},
+		{"sprint": "2", "goal": "Billing", "hotfix": "true"},
+	}, rows)
+
+	rows, err = loadIterations(write("sprints.json", `[{"sprint": 3}]`))
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"sprint": "3"}}, rows)
+
+	_, err = loadIterations(write("empty.yaml", "[]"))
+	assert.ErrorContains(t, err, "no iterations")
+
+	_, err = loadIterations(write("nested.yaml", "- sprint: {number: 1}"))
+	assert.ErrorContains(t, err, "iteration 1: variable 'sprint' must be a scalar value")
+
+	_, err = loadIterations(write("map.yaml", "sprint: 1"))
+	assert.ErrorContains(t, err, "expected a list of variable maps")
+}
+
+func TestIterationVariables(t *testing.T) {
+	base := map[string]string{"team": "core", "sprint": "0"}
+	variables := iterationVariables(base, map[string]string{"sprint": "4"})
+	assert.Equal(t, map[string]string{"team": "core", "sprint": "4"}, variables)
+	assert.Equal(t, "0", base["sprint"], "the --var values are not modified")
+	assert.Equal(t, "sprint=4, team=core", formatIterationVariables(variables))
+}
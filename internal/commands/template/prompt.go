@@ -0,0 +1,111 @@
+package template
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// missingVariables returns the required variables that have neither a value
+// nor a default
+func missingVariables(template *types.TaskTemplate, variables map[string]string) []types.Variable {
+	var missing []types.Variable
+	for _, variable := range template.Variables {
+		if _, provided := variables[variable.Name]; !provided && variable.Required && variable.DefaultValue == nil {
+			missing = append(missing, variable)
+		}
+	}
+	return missing
+}
+
+// promptVariables asks for each variable on out and reads the answers from
+// in, asking again until the answer is valid. Choices can be answered with
+// their number or value, bools with y/n.
+func promptVariables(in io.Reader, out io.Writer, missing []types.Variable, variables map[string]string) error {
+	reader := bufio.NewReader(in)
+	for _, variable := range missing {
+		fmt.Fprintf(out, "%s", variable.Name)
+		if variable.Description != "" {
+			fmt.Fprintf(out, " - %s", variable.Description)
+		}
+		fmt.Fprintln(out)
+		if variable.Type == types.VarTypeChoice {
+			for i, option := range variable.Options {
+				fmt.Fprintf(out, "  %d) %s\n", i+1, option)
+			}
+		}
+
+		for {
+			fmt.Fprintf(out, "%s (%s): ", variable.Name, variable.Hint())
+			line, err := reader.ReadString('\n')
+			answer := normalizeAnswer(variable, strings.TrimSpace(line))
+			if answer == "" {
+				if err != nil {
+					fmt.Fprintln(out)
+					return fmt.Errorf("input ended before required variable '%s' was entered", variable.Name)
+				}
+				fmt.Fprintln(out, "  a value is required")
+				continue
+			}
+			if verr := variable.Validate(answer); verr != nil {
+				if err != nil {
+					fmt.Fprintln(out)
+					return verr
+				}
+				fmt.Fprintf(out, "  %v\n", verr)
+				continue
+			}
+			variables[variable.Name] = answer
+			break
+		}
+	}
+	return nil
+}
+
+// normalizeAnswer maps option numbers and case-insensitive option names to the
+// option, and y/n to yes/no
+func normalizeAnswer(variable types.Variable, answer string) string {
+	switch variable.Type {
+	case types.VarTypeChoice:
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(variable.Options) {
+			return variable.Options[n-1]
+		}
+		for _, option := range variable.Options {
+			if strings.EqualFold(option, answer) {
+				return option
+			}
+		}
+	case types.VarTypeBool:
+		switch strings.ToLower(answer) {
+		case "y":
+			return "yes"
+		case "n":
+			return "no"
+		}
+		return strings.ToLower(answer)
+	}
+	return answer
+}
+
+// variableCompletions returns "name=value" candidates for --var: every
+// variable name, and every option of choice and bool variables
+func variableCompletions(template *types.TaskTemplate) []string {
+	var candidates []string
+	for _, variable := range template.Variables {
+		switch variable.Type {
+		case types.VarTypeChoice:
+			for _, option := range variable.Options {
+				candidates = append(candidates, variable.Name+"="+option)
+			}
+		case types.VarTypeBool:
+			candidates = append(candidates, variable.Name+"=true", variable.Name+"=false")
+		default:
+			candidates = append(candidates, variable.Name+"=")
+		}
+	}
+	return candidates
+}
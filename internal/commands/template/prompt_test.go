@@ -0,0 +1,60 @@
+package template
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptVariables(t *testing.T) {
+	one, forty := 1, 40
+	defaultHours := "4"
+	template := &types.TaskTemplate{
+		Name: "Bug Fix",
+		Variables: []types.Variable{
+			{Name: "bug_id", Type: types.VarTypeString, Required: true, Pattern: `^BUG-\d+$`},
+			{Name: "priority", Type: types.VarTypeChoice, Required: true, Options: []string{"Low", "High"}},
+			{Name: "regression", Type: types.VarTypeBool, Required: true},
+			{Name: "hours", Type: types.VarTypeInt, Required: true, Min: &one, Max: &forty, DefaultValue: &defaultHours},
+			{Name: "notes", Type: types.VarTypeString},
+		},
+	}
+
+	variables := map[string]string{"regression": "no"}
+	missing := missingVariables(template, variables)
+	require.Len(t, missing, 2, "given, defaulted and optional variables are not asked for")
+
+	var out bytes.Buffer
+	input := strings.NewReader("\n42\nBUG-42\nmedium\n2\n")
+	require.NoError(t, promptVariables(input, &out, missing, variables))
+
+	assert.Equal(t, "BUG-42", variables["bug_id"])
+	assert.Equal(t, "High", variables["priority"], "choices can be answered by number")
+	assert.Contains(t, out.String(), "a value is required")
+	assert.Contains(t, out.String(), "must match")
+	assert.Contains(t, out.String(), "2) High")
+
+	t.Run("input ends", func(t *testing.T) {
+		err := promptVariables(strings.NewReader(""), &out, missing, map[string]string{})
+		assert.ErrorContains(t, err, "input ended before required variable 'bug_id'")
+	})
+
+	t.Run("answers are normalized", func(t *testing.T) {
+		choice := types.Variable{Type: types.VarTypeChoice, Options: []string{"Low", "High"}}
+		assert.Equal(t, "Low", normalizeAnswer(choice, "low"))
+		assert.Equal(t, "yes", normalizeAnswer(types.Variable{Type: types.VarTypeBool}, "Y"))
+	})
+}
+
+func TestVariableCompletions(t *testing.T) {
+	template := &types.TaskTemplate{Variables: []types.Variable{
+		{Name: "priority", Type: types.VarTypeChoice, Options: []string{"Low", "High"}},
+		{Name: "api", Type: types.VarTypeBool},
+		{Name: "name", Type: types.VarTypeString},
+	}}
+	assert.Equal(t, []string{"priority=Low", "priority=High", "api=true", "api=false", "name="}, variableCompletions(template))
+}
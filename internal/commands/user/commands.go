@@ -0,0 +1,278 @@
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/shared"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// Commands returns the user management commands for 'knot serve'
+func Commands(appCtx *shared.AppContext) []*cli.Command {
+	nameFlag := &cli.StringFlag{
+		Name:     "name",
+		Usage:    "User name",
+		Required: true,
+	}
+
+	return []*cli.Command{
+		{
+			Name:  "add",
+			Usage: "Add a user and print the user's token",
+			Description: `Adds a user of the knot server and prints the token the user sets as
+KNOT_REMOTE_TOKEN. The token is shown only once; use rotate-token if it is lost.
+
+--role applies to all projects: viewers read, editors create and change,
+admins also delete. Roles on single projects are set with 'knot user grant'.`,
+			Action: addAction(appCtx),
+			Flags: []cli.Flag{
+				nameFlag,
+				&cli.StringFlag{
+					Name:  "role",
+					Usage: "Role on all projects: viewer, editor, admin or none",
+					Value: string(types.RoleViewer),
+				},
+				shared.NewJSONFlag(),
+			},
+		},
+		{
+			Name:   "list",
+			Usage:  "List users and their roles",
+			Action: listAction(appCtx),
+			Flags: []cli.Flag{
+				shared.NewJSONFlag(),
+			},
+		},
+		{
+			Name:   "grant",
+			Usage:  "Set the role of a user on one project, or on all projects without --project-id",
+			Action: grantAction(appCtx),
+			Flags: []cli.Flag{
+				nameFlag,
+				&cli.StringFlag{
+					Name:     "role",
+					Usage:    "Role: viewer, editor, admin or none",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  "project-id",
+					Usage: "Project the role applies to (default: all projects)",
+				},
+			},
+		},
+		{
+			Name:   "revoke",
+			Usage:  "Remove the role of a user on a project, so the role on all projects applies",
+			Action: revokeAction(appCtx),
+			Flags: []cli.Flag{
+				nameFlag,
+				&cli.StringFlag{
+					Name:     "project-id",
+					Usage:    "Project ID",
+					Required: true,
+				},
+			},
+		},
+		{
+			Name:   "rotate-token",
+			Usage:  "Replace the token of a user and print the new one",
+			Action: rotateTokenAction(appCtx),
+			Flags: []cli.Flag{
+				nameFlag,
+			},
+		},
+		{
+			Name:   "remove",
+			Usage:  "Remove a user, whose token stops working immediately",
+			Action: removeAction(appCtx),
+			Flags: []cli.Flag{
+				nameFlag,
+			},
+		},
+	}
+}
+
+func parseRole(name string) (types.Role, error) {
+	role, err := types.ParseRole(name)
+	if err != nil {
+		return types.RoleNone, errors.NewValidationError("invalid --role value", err)
+	}
+	return role, nil
+}
+
+func parseProjectID(c *cli.Context) (*uuid.UUID, error) {
+	if !c.IsSet("project-id") {
+		return nil, nil
+	}
+	projectID, err := uuid.Parse(c.String("project-id"))
+	if err != nil {
+		return nil, errors.InvalidUUIDError("project-id", c.String("project-id"))
+	}
+	return &projectID, nil
+}
+
+func addAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		role, err := parseRole(c.String("role"))
+		if err != nil {
+			return err
+		}
+
+		user, token, err := appCtx.ProjectManager.CreateUser(c.Context, c.String("name"), role)
+		if err != nil {
+			appCtx.Logger.Error("Failed to add user", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "adding user")
+		}
+		appCtx.Logger.Info("User added", zap.String("name", user.Name), zap.String("role", string(role)))
+
+		if c.Bool("json") {
+			return writeJSON(appCtx.Out(), struct {
+				*types.User
+				Token string `json:"token"`
+			}{User: user, Token: token})
+		}
+
+		appCtx.Out().Printf("Added user %s with %s on all projects\n", user.Name, describeRole(role))
+		appCtx.Out().Printf("Token (shown only once): %s\n", token)
+		appCtx.Out().Println("The user sets it as KNOT_REMOTE_TOKEN to work with 'knot serve'.")
+		return nil
+	}
+}
+
+func listAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		users, err := appCtx.ProjectManager.ListUsers(c.Context)
+		if err != nil {
+			appCtx.Logger.Error("Failed to list users", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "listing users")
+		}
+
+		if c.Bool("json") {
+			if users == nil {
+				users = []*types.User{}
+			}
+			return writeJSON(appCtx.Out(), users)
+		}
+
+		if len(users) == 0 {
+			appCtx.Out().Println("No users. Add one with: knot user add --name <name> --role <role>")
+			return nil
+		}
+		for _, user := range users {
+			appCtx.Out().Printf("%s: %s on all projects\n", user.Name, describeRole(user.Role))
+
+			projectIDs := make([]uuid.UUID, 0, len(user.ProjectRoles))
+			for projectID := range user.ProjectRoles {
+				projectIDs = append(projectIDs, projectID)
+			}
+			sort.Slice(projectIDs, func(i, j int) bool { return projectIDs[i].String() < projectIDs[j].String() })
+			for _, projectID := range projectIDs {
+				appCtx.Out().Printf("  %s on %s\n", describeRole(user.ProjectRoles[projectID]), projectLabel(c, appCtx, projectID))
+			}
+		}
+		return nil
+	}
+}
+
+func grantAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		role, err := parseRole(c.String("role"))
+		if err != nil {
+			return err
+		}
+		projectID, err := parseProjectID(c)
+		if err != nil {
+			return err
+		}
+
+		user, err := appCtx.ProjectManager.SetUserRole(c.Context, c.String("name"), projectID, role)
+		if err != nil {
+			appCtx.Logger.Error("Failed to set user role", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "setting user role")
+		}
+
+		scope := "all projects"
+		if projectID != nil {
+			scope = projectLabel(c, appCtx, *projectID)
+		}
+		appCtx.Out().Printf("User %s now has %s on %s\n", user.Name, describeRole(role), scope)
+		return nil
+	}
+}
+
+func revokeAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		projectID, err := parseProjectID(c)
+		if err != nil {
+			return err
+		}
+
+		user, err := appCtx.ProjectManager.RevokeUserProjectRole(c.Context, c.String("name"), *projectID)
+		if err != nil {
+			appCtx.Logger.Error("Failed to revoke user role", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "revoking user role")
+		}
+
+		appCtx.Out().Printf("User %s now has %s on %s (the role on all projects)\n",
+			user.Name, describeRole(user.Role), projectLabel(c, appCtx, *projectID))
+		return nil
+	}
+}
+
+func rotateTokenAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		token, err := appCtx.ProjectManager.RotateUserToken(c.Context, c.String("name"))
+		if err != nil {
+			appCtx.Logger.Error("Failed to rotate user token", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "rotating user token")
+		}
+
+		appCtx.Out().Printf("New token of %s (shown only once): %s\n", c.String("name"), token)
+		appCtx.Out().Println("The old token no longer works.")
+		return nil
+	}
+}
+
+func removeAction(appCtx *shared.AppContext) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		if err := appCtx.ProjectManager.DeleteUser(c.Context, c.String("name")); err != nil {
+			appCtx.Logger.Error("Failed to remove user", zap.Error(err))
+			return errors.WrapWithSuggestion(err, "removing user")
+		}
+
+		appCtx.Out().Printf("Removed user %s\n", c.String("name"))
+		return nil
+	}
+}
+
+func describeRole(role types.Role) string {
+	if role == types.RoleNone {
+		return "no access"
+	}
+	return string(role) + " access"
+}
+
+// projectLabel names a project by title and ID, or by ID if it cannot be read
+func projectLabel(c *cli.Context, appCtx *shared.AppContext, projectID uuid.UUID) string {
+	project, err := appCtx.ProjectManager.GetProject(c.Context, projectID)
+	if err != nil {
+		return fmt.Sprintf("project %s", projectID)
+	}
+	return fmt.Sprintf("%s (ID: %s)", project.Title, projectID)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	jsonData, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal users to JSON: %w", err)
+	}
+	fmt.Fprintln(w, string(jsonData))
+	return nil
+}
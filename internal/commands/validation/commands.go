@@ -1,10 +1,11 @@
 package validation
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/shared"
 	"github.com/denkhaus/knot/v2/internal/types"
 	internalValidation "github.com/denkhaus/knot/v2/internal/validation"
@@ -56,12 +57,12 @@ func Commands(appCtx *shared.AppContext) []*cli.Command {
 		},
 		{
 			Name:   "project",
-			Usage:  "Validate all task states in a project",
+			Usage:  "Validate all task states and completion times in a project",
 			Action: projectAction(appCtx),
 			Flags: []cli.Flag{
 				&cli.BoolFlag{
 					Name:  "fix",
-					Usage: "Attempt to fix invalid states automatically",
+					Usage: "Attempt to fix invalid states and backfill missing completion times automatically",
 					Value: false,
 				},
 			},
@@ -77,19 +78,19 @@ func statesAction(appCtx *shared.AppContext) cli.ActionFunc {
 		validator := internalValidation.NewStateValidator()
 
 		if showMatrix {
-			fmt.Println("Task State Transition Matrix:")
+			appCtx.Out().Println("Task State Transition Matrix:")
 			matrix := validator.GetStateTransitionMatrix()
 
 			for state, transitions := range matrix {
-				fmt.Printf("* %s:\n", strings.ToUpper(state))
+				appCtx.Out().Printf("* %s:\n", strings.ToUpper(state))
 				if len(transitions) == 0 {
-					fmt.Printf("   (no valid transitions)\n")
+					appCtx.Out().Printf("   (no valid transitions)\n")
 				} else {
 					for _, target := range transitions {
-						fmt.Printf("   ✅ → %s\n", target)
+						appCtx.Out().Printf("   %s→ %s\n", output.Icon("✅"), target)
 					}
 				}
-				fmt.Println()
+				appCtx.Out().Println()
 			}
 			return nil
 		}
@@ -102,26 +103,26 @@ func statesAction(appCtx *shared.AppContext) cli.ActionFunc {
 			matrix := validator.GetStateTransitionMatrix()
 			transitions := matrix[fromState]
 
-			fmt.Printf("Valid transitions from '%s':\n\n", strings.ToUpper(fromState))
+			appCtx.Out().Printf("Valid transitions from '%s':\n\n", strings.ToUpper(fromState))
 			if len(transitions) == 0 {
-				fmt.Printf("   (no valid transitions)\n")
+				appCtx.Out().Printf("   (no valid transitions)\n")
 			} else {
 				for _, target := range transitions {
-					fmt.Printf("   ✅ %s → %s\n", fromState, target)
+					appCtx.Out().Printf("   %s%s → %s\n", output.Icon("✅"), fromState, target)
 				}
 			}
 			return nil
 		}
 
 		// Show all valid states
-		fmt.Println("Valid Task States:")
+		appCtx.Out().Println("Valid Task States:")
 		states := validator.GetAllValidStates()
 		for _, state := range states {
-			fmt.Printf("   * %s\n", strings.ToUpper(string(state)))
+			appCtx.Out().Printf("   * %s\n", strings.ToUpper(string(state)))
 		}
 
-		fmt.Println("\nUse --matrix to see all valid transitions")
-		fmt.Println("Use --from <state> to see transitions from a specific state")
+		appCtx.Out().Println("\nUse --matrix to see all valid transitions")
+		appCtx.Out().Println("Use --from <state> to see transitions from a specific state")
 
 		return nil
 	}
@@ -139,7 +140,7 @@ func transitionAction(appCtx *shared.AppContext) cli.ActionFunc {
 		lenient := c.Bool("lenient")
 
 		// Get task
-		task, err := appCtx.ProjectManager.GetTask(context.Background(), taskID)
+		task, err := appCtx.ProjectManager.GetTask(c.Context, taskID)
 		if err != nil {
 			return fmt.Errorf("failed to get task: %w", err)
 		}
@@ -153,36 +154,36 @@ func transitionAction(appCtx *shared.AppContext) cli.ActionFunc {
 
 		targetState := types.TaskState(toState)
 
-		fmt.Printf("Validating transition for task '%s':\n", task.Title)
-		fmt.Printf("  Current State: %s\n", strings.ToUpper(string(task.State)))
-		fmt.Printf("  Target State:  %s\n", strings.ToUpper(string(targetState)))
-		fmt.Println()
+		appCtx.Out().Printf("Validating transition for task '%s':\n", task.Title)
+		appCtx.Out().Printf("  Current State: %s\n", strings.ToUpper(string(task.State)))
+		appCtx.Out().Printf("  Target State:  %s\n", strings.ToUpper(string(targetState)))
+		appCtx.Out().Println()
 
 		if lenient {
 			err, warnings := validator.ValidateTransitionLenient(task.State, targetState, task)
 			if err != nil {
-				fmt.Printf("Transition INVALID: %v\n", err)
+				appCtx.Out().Printf("Transition INVALID: %v\n", err)
 				return err
 			}
 
-			fmt.Printf("Transition VALID\n")
+			appCtx.Out().Printf("Transition VALID\n")
 			if len(warnings) > 0 {
-				fmt.Printf("\nWarnings:\n")
+				appCtx.Out().Printf("\nWarnings:\n")
 				for _, warning := range warnings {
-					fmt.Printf("   %s\n", warning)
+					appCtx.Out().Printf("   %s\n", warning)
 				}
 			}
 		} else {
 			err := validator.ValidateTransition(task.State, targetState, task)
 			if err != nil {
-				fmt.Printf("Transition INVALID: %v\n", err)
+				appCtx.Out().Printf("Transition INVALID: %v\n", err)
 				return err
 			}
-			fmt.Printf("Transition VALID\n")
+			appCtx.Out().Printf("Transition VALID\n")
 		}
 
-		fmt.Printf("\nTo apply this transition:\n")
-		fmt.Printf("   knot task update-state --id %s --state %s\n", taskID, toState)
+		appCtx.Out().Printf("\nTo apply this transition:\n")
+		appCtx.Out().Printf("   knot task update-state --id %s --state %s\n", taskID, toState)
 
 		return nil
 	}
@@ -200,7 +201,7 @@ func projectAction(appCtx *shared.AppContext) cli.ActionFunc {
 		appCtx.Logger.Info("Validating project task states", zap.String("projectID", projectID.String()))
 
 		// Get all tasks
-		tasks, err := appCtx.ProjectManager.ListTasksForProject(context.Background(), projectID)
+		tasks, err := appCtx.ProjectManager.ListTasksForProject(c.Context, projectID)
 		if err != nil {
 			return fmt.Errorf("failed to get project tasks: %w", err)
 		}
@@ -209,7 +210,7 @@ func projectAction(appCtx *shared.AppContext) cli.ActionFunc {
 		var issues []string
 		var fixedCount int
 
-		fmt.Printf("Validating %d tasks in project %s:\n\n", len(tasks), projectID)
+		appCtx.Out().Printf("Validating %d tasks in project %s:\n\n", len(tasks), projectID)
 
 		for _, task := range tasks {
 			// Check if state is valid
@@ -223,11 +224,11 @@ func projectAction(appCtx *shared.AppContext) cli.ActionFunc {
 						zap.String("taskID", task.ID.String()),
 						zap.String("invalidState", string(task.State)))
 
-					_, err := appCtx.ProjectManager.UpdateTaskState(context.Background(), task.ID, types.TaskStatePending, appCtx.Actor)
+					_, err := appCtx.ProjectManager.UpdateTaskState(c.Context, task.ID, types.TaskStatePending, appCtx.Actor)
 					if err != nil {
-						fmt.Printf("Failed to fix task %s: %v\n", task.ID, err)
+						appCtx.Out().Printf("Failed to fix task %s: %v\n", task.ID, err)
 					} else {
-						fmt.Printf("Fixed task '%s': %s → pending\n", task.Title, task.State)
+						appCtx.Out().Printf("Fixed task '%s': %s → pending\n", task.Title, task.State)
 						fixedCount++
 					}
 				}
@@ -237,25 +238,53 @@ func projectAction(appCtx *shared.AppContext) cli.ActionFunc {
 			// e.g., check if blocked tasks have dependencies, etc.
 		}
 
-		fmt.Printf("\n📊 Validation Summary:\n")
-		fmt.Printf("   Total Tasks: %d\n", len(tasks))
-		fmt.Printf("   Issues Found: %d\n", len(issues))
+		// Completed tasks need a completion time for burndown and velocity
+		// reports; backfill it from the change history where possible
+		events, err := appCtx.ProjectManager.ListChangeEvents(c.Context, types.ChangeEventFilter{ProjectID: &projectID})
+		if err != nil {
+			appCtx.Logger.Warn("Change history unavailable, completion times fall back to the last update", zap.Error(err))
+			events = nil
+		}
+		for _, backfill := range analysis.MissingCompletionTimes(tasks, events) {
+			issue := fmt.Sprintf("Completed task '%s' has no completion time", backfill.Title)
+			issues = append(issues, issue)
+
+			if fix {
+				appCtx.Logger.Info("Backfilling completion time",
+					zap.String("taskID", backfill.TaskID.String()),
+					zap.Time("completedAt", backfill.CompletedAt),
+					zap.String("source", string(backfill.Source)))
+
+				_, err := appCtx.ProjectManager.SetTaskCompletedAt(c.Context, backfill.TaskID, backfill.CompletedAt, appCtx.Actor)
+				if err != nil {
+					appCtx.Out().Printf("Failed to fix task %s: %v\n", backfill.TaskID, err)
+				} else {
+					appCtx.Out().Printf("Fixed task '%s': completed at %s (from %s)\n",
+						backfill.Title, output.Timestamp(backfill.CompletedAt), backfill.Source)
+					fixedCount++
+				}
+			}
+		}
+
+		appCtx.Out().Print("\n" + output.Icon("📊") + "Validation Summary:\n")
+		appCtx.Out().Printf("   Total Tasks: %d\n", len(tasks))
+		appCtx.Out().Printf("   Issues Found: %d\n", len(issues))
 
 		if fix && fixedCount > 0 {
-			fmt.Printf("   Issues Fixed: %d\n", fixedCount)
+			appCtx.Out().Printf("   Issues Fixed: %d\n", fixedCount)
 		}
 
 		if len(issues) > 0 {
-			fmt.Printf("\nIssues Found:\n")
+			appCtx.Out().Printf("\nIssues Found:\n")
 			for i, issue := range issues {
-				fmt.Printf("   %d. %s\n", i+1, issue)
+				appCtx.Out().Printf("   %d. %s\n", i+1, issue)
 			}
 
 			if !fix {
-				fmt.Printf("\nUse --fix to automatically repair invalid states\n")
+				appCtx.Out().Printf("\nUse --fix to automatically repair invalid states and completion times\n")
 			}
 		} else {
-			fmt.Printf("\nAll task states are valid!\n")
+			appCtx.Out().Printf("\nAll task states are valid!\n")
 		}
 
 		return nil
@@ -27,8 +27,8 @@ func GetConfigPath() (string, error) {
 
 // ValidateConfig checks if the configuration values are valid
 func ValidateConfig(c *manager.Config) error {
-	if c.MaxTasksPerDepth < 1 {
-		return fmt.Errorf("max_tasks_per_depth must be at least 1, got %d", c.MaxTasksPerDepth)
+	if err := manager.ValidateDepthLimits(c); err != nil {
+		return err
 	}
 	if c.ComplexityThreshold < 1 || c.ComplexityThreshold > 10 {
 		return fmt.Errorf("complexity_threshold must be between 1 and 10, got %d", c.ComplexityThreshold)
@@ -39,5 +39,38 @@ func ValidateConfig(c *manager.Config) error {
 	if c.MaxDescriptionLength < 1 {
 		return fmt.Errorf("max_description_length must be at least 1, got %d", c.MaxDescriptionLength)
 	}
-	return nil
+	if _, err := c.Validator(); err != nil {
+		return err
+	}
+	if err := manager.ValidateDuplicateCheck(c); err != nil {
+		return err
+	}
+	if err := manager.ValidateProgressWeighting(c.ProgressWeighting); err != nil {
+		return err
+	}
+	if err := manager.ValidateSelectionStrategy(c.SelectionStrategy); err != nil {
+		return err
+	}
+	if err := manager.ValidateSelectionWeights(c.SelectionWeights); err != nil {
+		return err
+	}
+	if err := manager.ValidateAgents(c.Agents); err != nil {
+		return err
+	}
+	if err := manager.ValidateComplexityReductions(c.ComplexityReductions); err != nil {
+		return err
+	}
+	if err := manager.ValidateSchedules(c.Schedules); err != nil {
+		return err
+	}
+	if err := manager.ValidateAgingPolicies(c.AgingPolicies); err != nil {
+		return err
+	}
+	if err := manager.ValidateCreationLimits(c.CreationLimits); err != nil {
+		return err
+	}
+	if _, err := c.TimeLocation(); err != nil {
+		return err
+	}
+	return manager.ValidateNotifications(c.Notifications)
 }
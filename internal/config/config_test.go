@@ -160,6 +160,30 @@ func TestValidateConfig(t *testing.T) {
 			expectError: true,
 			errorMsg:    "max_description_length must be at least 1",
 		},
+		{
+			name: "invalid DuplicateCheck",
+			config: &manager.Config{
+				MaxTasksPerDepth:     5,
+				ComplexityThreshold:  3,
+				MaxDepth:             10,
+				MaxDescriptionLength: 200,
+				DuplicateCheck:       "reject",
+			},
+			expectError: true,
+			errorMsg:    "duplicate_check must be warn, block or off",
+		},
+		{
+			name: "invalid DuplicateThreshold",
+			config: &manager.Config{
+				MaxTasksPerDepth:     5,
+				ComplexityThreshold:  3,
+				MaxDepth:             10,
+				MaxDescriptionLength: 200,
+				DuplicateThreshold:   1.5,
+			},
+			expectError: true,
+			errorMsg:    "duplicate_threshold must be between 0 and 1",
+		},
 		{
 			name: "multiple invalid values",
 			config: &manager.Config{
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"gopkg.in/yaml.v3"
+)
+
+// exportHeader is written at the top of YAML exports
+const exportHeader = "# knot configuration, import with: knot config import --file <this file>\n"
+
+// Export encodes the configuration for sharing, as JSON when format is "json"
+// and as YAML otherwise. The keys are the same as in .knot/config.json.
+func Export(c *manager.Config, format string) ([]byte, error) {
+	values, err := toMap(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal configuration: %w", err)
+		}
+		return append(data, '\n'), nil
+	}
+
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	return append([]byte(exportHeader), data...), nil
+}
+
+// Import decodes an exported configuration in YAML or JSON and applies it on
+// top of base: settings missing from the data keep their value in base. The
+// result is validated; base is not modified.
+func Import(data []byte, base *manager.Config) (*manager.Config, error) {
+	var values map[string]any
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("the configuration file contains no settings")
+	}
+
+	// Round trip through JSON so the file uses the keys of config.json
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+
+	imported, err := copyConfig(base)
+	if err != nil {
+		return nil, err
+	}
+	// Maps and lists in the file replace those of base instead of being merged
+	for key := range values {
+		if field := reflect.ValueOf(imported).Elem().FieldByName(key); field.IsValid() {
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(imported); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %s", strings.TrimPrefix(err.Error(), "json: "))
+	}
+	if err := ValidateConfig(imported); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return imported, nil
+}
+
+// ChangedSettings returns the names of the settings that differ between two
+// configurations, sorted
+func ChangedSettings(old, updated *manager.Config) ([]string, error) {
+	oldValues, err := toMap(old)
+	if err != nil {
+		return nil, err
+	}
+	newValues, err := toMap(updated)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for key, value := range newValues {
+		if !reflect.DeepEqual(oldValues[key], value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range oldValues {
+		if _, ok := newValues[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// FormatFor returns the export format for a file name: json for .json files,
+// yaml otherwise
+func FormatFor(path string) string {
+	if strings.HasSuffix(strings.ToLower(path), ".json") {
+		return "json"
+	}
+	return "yaml"
+}
+
+// toMap converts the configuration to its config.json representation
+func toMap(c *manager.Config) (map[string]any, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	return values, nil
+}
+
+// copyConfig returns a deep copy, the maps of a configuration are shared
+// between copies of the struct
+func copyConfig(c *manager.Config) (*manager.Config, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy configuration: %w", err)
+	}
+	var copied manager.Config
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, fmt.Errorf("failed to copy configuration: %w", err)
+	}
+	return &copied, nil
+}
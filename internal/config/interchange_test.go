@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImport(t *testing.T) {
+	agentID := uuid.New()
+	source := manager.DefaultConfig()
+	source.ComplexityThreshold = 6
+	source.PriorityInheritance = true
+	source.SetSavedFilter("stale", "state:pending priority:low")
+	source.SetAgentCapacity(agentID, 1200)
+
+	for _, format := range []string{"yaml", "json"} {
+		t.Run(format, func(t *testing.T) {
+			data, err := Export(source, format)
+			require.NoError(t, err)
+
+			imported, err := Import(data, manager.DefaultConfig())
+			require.NoError(t, err)
+			assert.Equal(t, source, imported)
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	base := manager.DefaultConfig()
+	base.SetSavedFilter("mine", "tag:mine")
+	base.SetSavedFilter("stale", "state:pending")
+
+	imported, err := Import([]byte(`
+ComplexityThreshold: 7
+SavedFilters:
+  stale: "state:pending priority:low"
+`), base)
+	require.NoError(t, err)
+	assert.Equal(t, 7, imported.ComplexityThreshold)
+	assert.Equal(t, base.MaxDepth, imported.MaxDepth, "missing settings keep their value")
+	assert.Equal(t, map[string]string{"stale": "state:pending priority:low"}, imported.SavedFilters, "maps are replaced, not merged")
+	assert.Equal(t, 8, base.ComplexityThreshold, "base is not modified")
+	assert.Len(t, base.SavedFilters, 2)
+
+	changed, err := ChangedSettings(base, imported)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ComplexityThreshold", "SavedFilters"}, changed)
+
+	_, err = Import([]byte("ComplexityTreshold: 7"), base)
+	assert.ErrorContains(t, err, "unknown field")
+
+	_, err = Import([]byte("ComplexityThreshold: 12"), base)
+	assert.ErrorContains(t, err, "complexity_threshold must be between 1 and 10")
+
+	_, err = Import([]byte(""), base)
+	assert.ErrorContains(t, err, "no settings")
+}
+
+func TestFormatFor(t *testing.T) {
+	assert.Equal(t, "json", FormatFor("knot-config.JSON"))
+	assert.Equal(t, "yaml", FormatFor("knot-config.yaml"))
+	assert.Equal(t, "yaml", FormatFor(""))
+}
@@ -0,0 +1,762 @@
+// Package dbsync merges two knot databases in both directions, e.g. the
+// databases of a laptop and a desktop that are edited offline.
+//
+// Changes are detected relative to the time of the previous sync: a project
+// or task updated on one side only since then is copied to the other side,
+// one updated on both sides is resolved by the newer UpdatedAt. Deleted
+// projects and tasks and added or removed dependencies are read from the
+// change feed. Conflicts that cannot be decided that way, a deletion on one
+// side and a modification on the other or equal timestamps, are reported and
+// left untouched unless a side is preferred.
+package dbsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// Side names one of the two synchronized databases
+type Side string
+
+const (
+	Local  Side = "local"
+	Remote Side = "remote"
+)
+
+// ParseSide parses a side name, the empty string is returned as is
+func ParseSide(name string) (Side, error) {
+	switch side := Side(name); side {
+	case "", Local, Remote:
+		return side, nil
+	default:
+		return "", fmt.Errorf("unknown side %q, use local or remote", name)
+	}
+}
+
+// Options control a sync
+type Options struct {
+	// LastSync is the time of the previous sync, zero for the first sync.
+	// Deletions and removed dependencies are only propagated with a LastSync.
+	LastSync time.Time
+	// Prefer resolves every conflict in favor of this side when set
+	Prefer Side
+	// DryRun plans the changes without writing them
+	DryRun bool
+}
+
+// Change is a write to one of the databases
+type Change struct {
+	Target Side      `json:"target"`
+	Action string    `json:"action"`
+	ID     uuid.UUID `json:"id"`
+	Title  string    `json:"title"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// Conflict is a project, task or dependency changed on both sides
+type Conflict struct {
+	ID     uuid.UUID `json:"id"`
+	Title  string    `json:"title"`
+	Reason string    `json:"reason"`
+	// Resolution describes which version was kept, empty if the conflict
+	// needs a manual choice
+	Resolution string `json:"resolution,omitempty"`
+}
+
+// Resolved reports whether the conflict was resolved automatically
+func (c Conflict) Resolved() bool {
+	return c.Resolution != ""
+}
+
+// Report is the outcome of a sync
+type Report struct {
+	DryRun    bool       `json:"dry_run,omitempty"`
+	Changes   []Change   `json:"changes"`
+	Conflicts []Conflict `json:"conflicts"`
+	Failures  []Change   `json:"failures,omitempty"`
+}
+
+// Unresolved returns the conflicts needing a manual choice
+func (r *Report) Unresolved() []Conflict {
+	unresolved := make([]Conflict, 0)
+	for _, conflict := range r.Conflicts {
+		if !conflict.Resolved() {
+			unresolved = append(unresolved, conflict)
+		}
+	}
+	return unresolved
+}
+
+// Complete reports whether both databases are in sync after the report was applied
+func (r *Report) Complete() bool {
+	return len(r.Failures) == 0 && len(r.Unresolved()) == 0
+}
+
+// Sync merges the projects, tasks and dependencies of local and remote
+func Sync(ctx context.Context, local, remote types.Repository, opts Options) (*Report, error) {
+	s := &syncer{
+		opts:   opts,
+		report: &Report{DryRun: opts.DryRun, Changes: []Change{}, Conflicts: []Conflict{}},
+	}
+
+	var err error
+	if s.local, err = loadSide(ctx, Local, local, opts.LastSync); err != nil {
+		return nil, fmt.Errorf("failed to read local database: %w", err)
+	}
+	if s.remote, err = loadSide(ctx, Remote, remote, opts.LastSync); err != nil {
+		return nil, fmt.Errorf("failed to read remote database: %w", err)
+	}
+
+	s.planProjects()
+	s.planTasks()
+	s.planDependencies()
+
+	for _, phase := range s.phases {
+		for _, op := range phase {
+			if opts.DryRun {
+				s.report.Changes = append(s.report.Changes, op.change)
+				continue
+			}
+			if err := op.apply(ctx); err != nil {
+				op.change.Error = err.Error()
+				s.report.Failures = append(s.report.Failures, op.change)
+				continue
+			}
+			s.report.Changes = append(s.report.Changes, op.change)
+		}
+	}
+	return s.report, nil
+}
+
+type dependency struct {
+	taskID, dependsOnID uuid.UUID
+}
+
+// side is the state of one database and its changes since the last sync
+type side struct {
+	name            Side
+	repo            types.Repository
+	projects        map[uuid.UUID]*types.Project
+	tasks           map[uuid.UUID]*types.Task
+	dependencies    map[dependency]bool
+	deletedProjects map[uuid.UUID]bool
+	deletedTasks    map[uuid.UUID]bool
+	addedDeps       map[dependency]bool
+	removedDeps     map[dependency]bool
+}
+
+func loadSide(ctx context.Context, name Side, repo types.Repository, since time.Time) (*side, error) {
+	s := &side{
+		name:            name,
+		repo:            repo,
+		projects:        make(map[uuid.UUID]*types.Project),
+		tasks:           make(map[uuid.UUID]*types.Task),
+		dependencies:    make(map[dependency]bool),
+		deletedProjects: make(map[uuid.UUID]bool),
+		deletedTasks:    make(map[uuid.UUID]bool),
+		addedDeps:       make(map[dependency]bool),
+		removedDeps:     make(map[dependency]bool),
+	}
+
+	projects, err := repo.ListProjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	for _, project := range projects {
+		// Copy, repositories may hand out their live instances
+		copied := *project
+		s.projects[project.ID] = &copied
+
+		tasks, err := repo.GetTasksByProject(ctx, project.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tasks of project %s: %w", project.ID, err)
+		}
+		ids := make([]uuid.UUID, len(tasks))
+		for i, task := range tasks {
+			ids[i] = task.ID
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		tasks, err = repo.GetTasksWithDependencies(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load dependencies of project %s: %w", project.ID, err)
+		}
+		for _, task := range tasks {
+			copied := *task
+			copied.Dependencies = append([]uuid.UUID(nil), task.Dependencies...)
+			copied.Dependents = nil
+			s.tasks[task.ID] = &copied
+			for _, dependsOnID := range task.Dependencies {
+				s.dependencies[dependency{task.ID, dependsOnID}] = true
+			}
+		}
+	}
+
+	feed, ok := repo.(types.ChangeFeed)
+	if since.IsZero() || !ok {
+		return s, nil
+	}
+	events, err := feed.ListChangeEvents(ctx, types.ChangeEventFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read change feed: %w", err)
+	}
+	for _, event := range events {
+		if !event.CreatedAt.After(since) {
+			continue
+		}
+		switch event.Kind {
+		case types.ChangeProjectDeleted:
+			s.deletedProjects[event.ProjectID] = true
+		case types.ChangeTaskDeleted:
+			s.deletedTasks[*event.TaskID] = true
+		case types.ChangeDependencyAdded:
+			dep := dependency{*event.TaskID, *event.DependsOnID}
+			s.addedDeps[dep] = true
+			delete(s.removedDeps, dep)
+		case types.ChangeDependencyRemoved:
+			dep := dependency{*event.TaskID, *event.DependsOnID}
+			s.removedDeps[dep] = true
+			delete(s.addedDeps, dep)
+		}
+	}
+	return s, nil
+}
+
+// Writes are applied in phases, so parents exist before their children and
+// projects are only deleted after all other writes
+const (
+	phaseProjects = iota
+	phaseTasks
+	phaseRemoveDependencies
+	phaseAddDependencies
+	phaseDeleteTasks
+	phaseDeleteProjects
+	phaseCount
+)
+
+type operation struct {
+	change Change
+	apply  func(ctx context.Context) error
+}
+
+type syncer struct {
+	opts          Options
+	local, remote *side
+	report        *Report
+	phases        [phaseCount][]operation
+	// skipped projects are deleted or in conflict, their tasks are not synced
+	skipped map[uuid.UUID]bool
+	// exists holds the tasks of each side after the sync
+	exists map[Side]map[uuid.UUID]bool
+}
+
+func (s *syncer) side(name Side) *side {
+	if name == Local {
+		return s.local
+	}
+	return s.remote
+}
+
+func (s *syncer) other(sd *side) *side {
+	if sd == s.local {
+		return s.remote
+	}
+	return s.local
+}
+
+func (s *syncer) plan(phase int, target *side, action string, id uuid.UUID, title string, apply func(ctx context.Context) error) {
+	s.phases[phase] = append(s.phases[phase], operation{
+		change: Change{Target: target.name, Action: action, ID: id, Title: title},
+		apply:  apply,
+	})
+}
+
+func (s *syncer) conflict(id uuid.UUID, title, reason, resolution string) {
+	s.report.Conflicts = append(s.report.Conflicts, Conflict{ID: id, Title: title, Reason: reason, Resolution: resolution})
+}
+
+func (s *syncer) changedSinceLastSync(updatedAt time.Time) bool {
+	return !s.opts.LastSync.IsZero() && updatedAt.After(s.opts.LastSync)
+}
+
+// newer decides which version of an entity that differs between the sides
+// is kept. It returns false if the conflict needs a manual choice.
+func (s *syncer) newer(id uuid.UUID, title string, local, remote time.Time) (Side, bool) {
+	localChanged, remoteChanged := s.changedSinceLastSync(local), s.changedSinceLastSync(remote)
+	switch {
+	case localChanged && !remoteChanged:
+		return Local, true
+	case remoteChanged && !localChanged:
+		return Remote, true
+	}
+
+	// Changed on both sides, or no previous sync to compare with
+	reason := "changed on both sides"
+	if s.opts.LastSync.IsZero() {
+		reason = "differs between the databases"
+	}
+	switch {
+	case s.opts.Prefer != "":
+		s.conflict(id, title, reason, fmt.Sprintf("kept %s version (preferred)", s.opts.Prefer))
+		return s.opts.Prefer, true
+	case local.After(remote):
+		s.conflict(id, title, reason, "kept newer local version")
+		return Local, true
+	case remote.After(local):
+		s.conflict(id, title, reason, "kept newer remote version")
+		return Remote, true
+	default:
+		s.conflict(id, title, reason+" at the same time", "")
+		return "", false
+	}
+}
+
+func (s *syncer) planProjects() {
+	s.skipped = make(map[uuid.UUID]bool)
+
+	ids := make(map[uuid.UUID]*types.Project)
+	for id, project := range s.local.projects {
+		ids[id] = project
+	}
+	for id, project := range s.remote.projects {
+		ids[id] = project
+	}
+	for _, id := range sortedProjectIDs(ids) {
+		local, remote := s.local.projects[id], s.remote.projects[id]
+		switch {
+		case local != nil && remote != nil:
+			if projectsEqual(local, remote) {
+				continue
+			}
+			winner, ok := s.newer(id, local.Title, local.UpdatedAt, remote.UpdatedAt)
+			if !ok {
+				continue
+			}
+			src := s.side(winner)
+			dst := s.other(src)
+			// Keep the task counters of the target, they are derived from its tasks
+			updated := *dst.projects[id]
+			updated.Title = src.projects[id].Title
+			updated.Description = src.projects[id].Description
+			updated.State = src.projects[id].State
+			updated.UpdatedAt = src.projects[id].UpdatedAt
+			updated.UpdatedBy = src.projects[id].UpdatedBy
+			s.plan(phaseProjects, dst, "update project", id, updated.Title, func(ctx context.Context) error {
+				return dst.repo.UpdateProject(ctx, &updated)
+			})
+		case local != nil:
+			s.planSingleProject(s.local, local)
+		default:
+			s.planSingleProject(s.remote, remote)
+		}
+	}
+}
+
+// planSingleProject handles a project that exists on src only
+func (s *syncer) planSingleProject(src *side, project *types.Project) {
+	dst := s.other(src)
+	if !dst.deletedProjects[project.ID] {
+		s.planProjectCreate(dst, project)
+		return
+	}
+
+	modified := s.changedSinceLastSync(project.UpdatedAt)
+	for _, task := range src.tasks {
+		if task.ProjectID == project.ID && s.changedSinceLastSync(task.UpdatedAt) {
+			modified = true
+		}
+	}
+
+	if modified {
+		reason := fmt.Sprintf("deleted on %s, modified on %s", dst.name, src.name)
+		switch s.opts.Prefer {
+		case src.name:
+			s.conflict(project.ID, project.Title, reason, fmt.Sprintf("kept %s project (preferred)", src.name))
+			s.planProjectCreate(dst, project)
+			return
+		case dst.name:
+			s.conflict(project.ID, project.Title, reason, fmt.Sprintf("deleted project (%s preferred)", dst.name))
+		default:
+			s.conflict(project.ID, project.Title, reason, "")
+			s.skipped[project.ID] = true
+			return
+		}
+	}
+
+	s.skipped[project.ID] = true
+	s.plan(phaseDeleteProjects, src, "delete project", project.ID, project.Title, func(ctx context.Context) error {
+		return src.repo.DeleteProject(ctx, project.ID)
+	})
+}
+
+// planProjectCreate creates a project on dst, its tasks follow in the task phase
+func (s *syncer) planProjectCreate(dst *side, project *types.Project) {
+	created := *project
+	created.TotalTasks, created.CompletedTasks, created.Progress = 0, 0, 0
+	s.plan(phaseProjects, dst, "create project", project.ID, project.Title, func(ctx context.Context) error {
+		return dst.repo.CreateProject(ctx, &created)
+	})
+}
+
+// taskDecision is the planned outcome for one task
+type taskDecision struct {
+	// copyTo is the side receiving the version of the other side, empty if none
+	copyTo Side
+	// deleteFrom is the side the task is deleted from, empty if none
+	deleteFrom Side
+}
+
+func (s *syncer) planTasks() {
+	decisions := make(map[uuid.UUID]*taskDecision)
+	ids := make(map[uuid.UUID]*types.Task)
+	for id, task := range s.local.tasks {
+		ids[id] = task
+	}
+	for id, task := range s.remote.tasks {
+		ids[id] = task
+	}
+
+	for id, task := range ids {
+		if s.skipped[task.ProjectID] {
+			continue
+		}
+		local, remote := s.local.tasks[id], s.remote.tasks[id]
+		switch {
+		case local != nil && remote != nil:
+			if tasksEqual(local, remote) {
+				continue
+			}
+			if winner, ok := s.newer(id, local.Title, local.UpdatedAt, remote.UpdatedAt); ok {
+				decisions[id] = &taskDecision{copyTo: s.other(s.side(winner)).name}
+			}
+		case local != nil:
+			if decision := s.decideSingleTask(s.local, local); decision != nil {
+				decisions[id] = decision
+			}
+		default:
+			if decision := s.decideSingleTask(s.remote, remote); decision != nil {
+				decisions[id] = decision
+			}
+		}
+	}
+
+	s.cancelBrokenDecisions(decisions)
+
+	// Apply parents before their children and delete children first
+	writes := make([]uuid.UUID, 0, len(decisions))
+	deletes := make([]uuid.UUID, 0, len(decisions))
+	for id, decision := range decisions {
+		if decision.copyTo != "" {
+			writes = append(writes, id)
+		}
+		if decision.deleteFrom != "" {
+			deletes = append(deletes, id)
+		}
+	}
+	sort.Slice(writes, func(i, j int) bool {
+		return s.source(decisions[writes[i]]).tasks[writes[i]].Depth < s.source(decisions[writes[j]]).tasks[writes[j]].Depth
+	})
+	sort.Slice(deletes, func(i, j int) bool {
+		return s.side(decisions[deletes[i]].deleteFrom).tasks[deletes[i]].Depth > s.side(decisions[deletes[j]].deleteFrom).tasks[deletes[j]].Depth
+	})
+
+	for _, id := range writes {
+		s.planTaskWrite(s.source(decisions[id]), id)
+	}
+	for _, id := range deletes {
+		src := s.side(decisions[id].deleteFrom)
+		task := src.tasks[id]
+		s.plan(phaseDeleteTasks, src, "delete task", id, task.Title, func(ctx context.Context) error {
+			return src.repo.DeleteTask(ctx, id)
+		})
+	}
+
+	s.exists = map[Side]map[uuid.UUID]bool{Local: {}, Remote: {}}
+	for _, sd := range []*side{s.local, s.remote} {
+		for id, task := range sd.tasks {
+			if !s.skipped[task.ProjectID] {
+				s.exists[sd.name][id] = true
+			}
+		}
+	}
+	for id, decision := range decisions {
+		if decision.copyTo != "" {
+			s.exists[decision.copyTo][id] = true
+		}
+		if decision.deleteFrom != "" {
+			delete(s.exists[decision.deleteFrom], id)
+		}
+	}
+}
+
+// source returns the side whose version of the task is copied
+func (s *syncer) source(decision *taskDecision) *side {
+	return s.other(s.side(decision.copyTo))
+}
+
+// decideSingleTask handles a task that exists on src only
+func (s *syncer) decideSingleTask(src *side, task *types.Task) *taskDecision {
+	dst := s.other(src)
+	if !dst.deletedTasks[task.ID] {
+		return &taskDecision{copyTo: dst.name}
+	}
+	if !s.changedSinceLastSync(task.UpdatedAt) {
+		return &taskDecision{deleteFrom: src.name}
+	}
+
+	reason := fmt.Sprintf("deleted on %s, modified on %s", dst.name, src.name)
+	switch s.opts.Prefer {
+	case src.name:
+		s.conflict(task.ID, task.Title, reason, fmt.Sprintf("kept %s task (preferred)", src.name))
+		return &taskDecision{copyTo: dst.name}
+	case dst.name:
+		s.conflict(task.ID, task.Title, reason, fmt.Sprintf("deleted task (%s preferred)", dst.name))
+		return &taskDecision{deleteFrom: src.name}
+	default:
+		s.conflict(task.ID, task.Title, reason, "")
+		return nil
+	}
+}
+
+// cancelBrokenDecisions drops deletions of tasks that keep subtasks and
+// copies of tasks whose parent will not exist on the target, reporting both
+// as conflicts. Cancelling one decision can break others, so this repeats
+// until nothing changes.
+func (s *syncer) cancelBrokenDecisions(decisions map[uuid.UUID]*taskDecision) {
+	for changed := true; changed; {
+		changed = false
+		for id, decision := range decisions {
+			if decision.deleteFrom != "" {
+				src := s.side(decision.deleteFrom)
+				for _, child := range src.tasks {
+					if child.ParentID == nil || *child.ParentID != id {
+						continue
+					}
+					if d := decisions[child.ID]; d == nil || d.deleteFrom != src.name {
+						s.conflict(id, src.tasks[id].Title,
+							fmt.Sprintf("deleted on %s, but has new subtasks on %s", s.other(src).name, src.name), "")
+						delete(decisions, id)
+						changed = true
+						break
+					}
+				}
+				continue
+			}
+
+			task := s.source(decision).tasks[id]
+			if task.ParentID == nil {
+				continue
+			}
+			dst := s.side(decision.copyTo)
+			parent := decisions[*task.ParentID]
+			_, onTarget := dst.tasks[*task.ParentID]
+			parentKept := (onTarget && (parent == nil || parent.deleteFrom != dst.name)) ||
+				(parent != nil && parent.copyTo == dst.name)
+			if !parentKept {
+				s.conflict(id, task.Title, fmt.Sprintf("parent task was deleted on %s", dst.name), "")
+				delete(decisions, id)
+				changed = true
+			}
+		}
+	}
+}
+
+// planTaskWrite copies the version of a task on src to the other side
+func (s *syncer) planTaskWrite(src *side, id uuid.UUID) {
+	dst := s.other(src)
+	task := *src.tasks[id]
+	task.Dependencies = nil
+
+	current, exists := dst.tasks[id]
+	if !exists {
+		s.plan(phaseTasks, dst, "create task", id, task.Title, func(ctx context.Context) error {
+			return dst.repo.CreateTask(ctx, &task)
+		})
+		return
+	}
+
+	if !sameParent(current.ParentID, task.ParentID) {
+		s.plan(phaseTasks, dst, "move task", id, task.Title, func(ctx context.Context) error {
+			return dst.repo.MoveTask(ctx, id, task.ParentID)
+		})
+	}
+	moved := *current
+	moved.ParentID = task.ParentID
+	if !tasksEqual(&moved, &task) {
+		s.plan(phaseTasks, dst, "update task", id, task.Title, func(ctx context.Context) error {
+			return dst.repo.UpdateTask(ctx, &task)
+		})
+	}
+}
+
+func (s *syncer) planDependencies() {
+	deps := make(map[dependency]bool)
+	for dep := range s.local.dependencies {
+		deps[dep] = true
+	}
+	for dep := range s.remote.dependencies {
+		deps[dep] = true
+	}
+
+	sorted := make([]dependency, 0, len(deps))
+	for dep := range deps {
+		sorted = append(sorted, dep)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].taskID != sorted[j].taskID {
+			return sorted[i].taskID.String() < sorted[j].taskID.String()
+		}
+		return sorted[i].dependsOnID.String() < sorted[j].dependsOnID.String()
+	})
+
+	for _, dep := range sorted {
+		// Dependencies of deleted or conflicting tasks are left alone
+		if !s.existsOnBothSides(dep.taskID) || !s.existsOnBothSides(dep.dependsOnID) {
+			continue
+		}
+		onLocal, onRemote := s.local.dependencies[dep], s.remote.dependencies[dep]
+		switch {
+		case onLocal && !onRemote:
+			s.planDependency(s.local, dep)
+		case onRemote && !onLocal:
+			s.planDependency(s.remote, dep)
+		}
+	}
+}
+
+// planDependency handles a dependency that exists on src only: it was either
+// removed on the other side since the last sync or is new on src
+func (s *syncer) planDependency(src *side, dep dependency) {
+	dst := s.other(src)
+	title := s.taskTitle(dep.taskID)
+	if dst.removedDeps[dep] && !src.addedDeps[dep] {
+		s.plan(phaseRemoveDependencies, src, "remove dependency", dep.taskID, title, func(ctx context.Context) error {
+			_, err := src.repo.RemoveTaskDependency(ctx, dep.taskID, dep.dependsOnID)
+			return err
+		})
+		return
+	}
+	s.plan(phaseAddDependencies, dst, "add dependency", dep.taskID, title, func(ctx context.Context) error {
+		if _, err := dst.repo.AddTaskDependency(ctx, dep.taskID, dep.dependsOnID); err != nil {
+			return err
+		}
+		link := src.tasks[dep.taskID].DependencyLink(dep.dependsOnID)
+		if link.IsDefault() {
+			return nil
+		}
+		_, err := dst.repo.SetDependencyLink(ctx, dep.taskID, link)
+		return err
+	})
+}
+
+func (s *syncer) existsOnBothSides(id uuid.UUID) bool {
+	return s.exists[Local][id] && s.exists[Remote][id]
+}
+
+func (s *syncer) taskTitle(id uuid.UUID) string {
+	if task, ok := s.local.tasks[id]; ok {
+		return task.Title
+	}
+	if task, ok := s.remote.tasks[id]; ok {
+		return task.Title
+	}
+	return ""
+}
+
+func projectsEqual(a, b *types.Project) bool {
+	return a.Title == b.Title && a.Description == b.Description && a.State == b.State
+}
+
+// tasksEqual compares the synced content of two task versions, ignoring
+// timestamps, dependencies and values the repositories derive themselves
+func tasksEqual(a, b *types.Task) bool {
+	return reflect.DeepEqual(syncedContent(a), syncedContent(b))
+}
+
+func syncedContent(task *types.Task) map[string]any {
+	copied := *task
+	copied.Depth = 0
+	copied.Dependencies, copied.Dependents = nil, nil
+	copied.DependencyLinks = nil // Synced with the dependencies
+	copied.CreatedAt, copied.UpdatedAt = time.Time{}, time.Time{}
+	copied.CompletedAt = nil
+	copied.UpdatedBy = ""
+
+	// Compare the JSON form, so nil and empty slices are equal
+	data, _ := json.Marshal(&copied)
+	content := make(map[string]any)
+	_ = json.Unmarshal(data, &content)
+	return content
+}
+
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func sortedProjectIDs(projects map[uuid.UUID]*types.Project) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(projects))
+	for id := range projects {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return projects[ids[i]].CreatedAt.Before(projects[ids[j]].CreatedAt)
+	})
+	return ids
+}
+
+// StateFile is the name of the file next to a database recording when it
+// was last synced with which other database
+const StateFile = "sync.json"
+
+// State records the previous syncs of a database
+type State struct {
+	// Peers maps the absolute path of the other database to the last sync
+	Peers map[string]time.Time `json:"peers"`
+}
+
+// LoadState reads a state file, a missing file is an empty state
+func LoadState(path string) (*State, error) {
+	state := &State{Peers: make(map[string]time.Time)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state %s: %w", path, err)
+	}
+	if state.Peers == nil {
+		state.Peers = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// Save writes the state file
+func (s *State) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create sync state directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package dbsync
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTask(t *testing.T, repo types.Repository, projectID uuid.UUID, parent *types.Task, title string) *types.Task {
+	t.Helper()
+	task := &types.Task{
+		ID:         uuid.New(),
+		ProjectID:  projectID,
+		Title:      title,
+		State:      types.TaskStatePending,
+		Priority:   types.TaskPriorityMedium,
+		Complexity: 3,
+	}
+	if parent != nil {
+		task.ParentID = &parent.ID
+		task.Depth = parent.Depth + 1
+	}
+	require.NoError(t, repo.CreateTask(context.Background(), task))
+	return task
+}
+
+func getTask(t *testing.T, repo types.Repository, id uuid.UUID) *types.Task {
+	t.Helper()
+	task, err := repo.GetTask(context.Background(), id)
+	require.NoError(t, err)
+	copied := *task
+	return &copied
+}
+
+func updateTitle(t *testing.T, repo types.Repository, id uuid.UUID, title string) {
+	t.Helper()
+	task := getTask(t, repo, id)
+	task.Title = title
+	require.NoError(t, repo.UpdateTask(context.Background(), task))
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	local, remote := inmemory.NewMemoryRepository(), inmemory.NewMemoryRepository()
+
+	// Both databases start from the same project, edited independently
+	project := &types.Project{ID: uuid.New(), Title: "Shared", State: types.ProjectStateActive}
+	require.NoError(t, local.CreateProject(ctx, &types.Project{ID: project.ID, Title: "Shared", State: types.ProjectStateActive}))
+	parent := newTask(t, local, project.ID, nil, "Parent")
+	child := newTask(t, local, project.ID, parent, "Child")
+	other := newTask(t, local, project.ID, nil, "Other")
+	_, err := local.AddTaskDependency(ctx, child.ID, other.ID)
+	require.NoError(t, err)
+
+	remoteProject := &types.Project{ID: uuid.New(), Title: "Desktop only", State: types.ProjectStateActive}
+	require.NoError(t, remote.CreateProject(ctx, remoteProject))
+	remoteTask := newTask(t, remote, remoteProject.ID, nil, "Desktop task")
+
+	t.Run("first sync copies everything both ways", func(t *testing.T) {
+		report, err := Sync(ctx, local, remote, Options{})
+		require.NoError(t, err)
+		assert.True(t, report.Complete())
+		assert.Empty(t, report.Conflicts)
+
+		assert.Equal(t, "Child", getTask(t, remote, child.ID).Title)
+		assert.Equal(t, 1, getTask(t, remote, child.ID).Depth)
+		assert.Equal(t, "Desktop task", getTask(t, local, remoteTask.ID).Title)
+
+		deps, err := remote.GetTaskDependencies(ctx, child.ID)
+		require.NoError(t, err)
+		require.Len(t, deps, 1)
+		assert.Equal(t, other.ID, deps[0].ID)
+
+		again, err := Sync(ctx, local, remote, Options{})
+		require.NoError(t, err)
+		assert.Empty(t, again.Changes, "a second sync must find nothing to do")
+	})
+
+	lastSync := time.Now()
+
+	t.Run("changes since the last sync are propagated", func(t *testing.T) {
+		updateTitle(t, local, child.ID, "Child (laptop)")
+		require.NoError(t, remote.DeleteTask(ctx, remoteTask.ID))
+		_, err := remote.RemoveTaskDependency(ctx, child.ID, other.ID)
+		require.NoError(t, err)
+		_, err = local.AddTaskDependency(ctx, parent.ID, other.ID)
+		require.NoError(t, err)
+		added := newTask(t, remote, project.ID, parent, "Added on desktop")
+
+		dryRun, err := Sync(ctx, local, remote, Options{LastSync: lastSync, DryRun: true})
+		require.NoError(t, err)
+		assert.NotEmpty(t, dryRun.Changes)
+		assert.Equal(t, "Child", getTask(t, remote, child.ID).Title, "a dry run must not write")
+
+		report, err := Sync(ctx, local, remote, Options{LastSync: lastSync})
+		require.NoError(t, err)
+		assert.True(t, report.Complete(), "unexpected report: %+v", report)
+		assert.Len(t, report.Changes, len(dryRun.Changes))
+
+		assert.Equal(t, "Child (laptop)", getTask(t, remote, child.ID).Title)
+		assert.Equal(t, "Added on desktop", getTask(t, local, added.ID).Title)
+		_, err = local.GetTask(ctx, remoteTask.ID)
+		assert.Error(t, err, "task deleted on remote must be deleted locally")
+
+		for _, repo := range []types.Repository{local, remote} {
+			deps, err := repo.GetTaskDependencies(ctx, child.ID)
+			require.NoError(t, err)
+			assert.Empty(t, deps)
+			deps, err = repo.GetTaskDependencies(ctx, parent.ID)
+			require.NoError(t, err)
+			assert.Len(t, deps, 1)
+		}
+	})
+
+	lastSync = time.Now()
+
+	t.Run("newer version wins when both sides changed", func(t *testing.T) {
+		updateTitle(t, local, other.ID, "Other (laptop)")
+		updateTitle(t, remote, other.ID, "Other (desktop)")
+
+		report, err := Sync(ctx, local, remote, Options{LastSync: lastSync})
+		require.NoError(t, err)
+		require.Len(t, report.Conflicts, 1)
+		assert.Equal(t, "kept newer remote version", report.Conflicts[0].Resolution)
+		assert.Equal(t, "Other (desktop)", getTask(t, local, other.ID).Title)
+	})
+
+	lastSync = time.Now()
+
+	t.Run("delete against modify needs a manual choice", func(t *testing.T) {
+		require.NoError(t, local.DeleteTask(ctx, child.ID))
+		updateTitle(t, remote, child.ID, "Child (desktop)")
+
+		report, err := Sync(ctx, local, remote, Options{LastSync: lastSync})
+		require.NoError(t, err)
+		assert.False(t, report.Complete())
+		unresolved := report.Unresolved()
+		require.Len(t, unresolved, 1)
+		assert.Equal(t, child.ID, unresolved[0].ID)
+		assert.Contains(t, unresolved[0].Reason, "deleted on local, modified on remote")
+
+		report, err = Sync(ctx, local, remote, Options{LastSync: lastSync, Prefer: Remote})
+		require.NoError(t, err)
+		assert.True(t, report.Complete())
+		assert.Equal(t, "Child (desktop)", getTask(t, local, child.ID).Title)
+	})
+}
+
+func TestSyncKeepsTasksWithNewSubtasks(t *testing.T) {
+	ctx := context.Background()
+	local, remote := inmemory.NewMemoryRepository(), inmemory.NewMemoryRepository()
+	project := &types.Project{ID: uuid.New(), Title: "Shared"}
+	require.NoError(t, local.CreateProject(ctx, project))
+	parent := newTask(t, local, project.ID, nil, "Parent")
+
+	_, err := Sync(ctx, local, remote, Options{})
+	require.NoError(t, err)
+	lastSync := time.Now()
+
+	// Deleted on the laptop while the desktop adds a subtask
+	require.NoError(t, local.DeleteTask(ctx, parent.ID))
+	subtask := newTask(t, remote, project.ID, getTask(t, remote, parent.ID), "New subtask")
+
+	report, err := Sync(ctx, local, remote, Options{LastSync: lastSync})
+	require.NoError(t, err)
+	assert.Empty(t, report.Failures)
+	assert.Len(t, report.Unresolved(), 2)
+
+	_, err = remote.GetTask(ctx, parent.ID)
+	assert.NoError(t, err, "the parent of a new subtask must not be deleted")
+	_, err = local.GetTask(ctx, subtask.ID)
+	assert.Error(t, err, "a subtask cannot be copied without its parent")
+}
+
+func TestState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".knot", StateFile)
+
+	state, err := LoadState(path)
+	require.NoError(t, err)
+	assert.Empty(t, state.Peers)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	state.Peers["/home/user/project/.knot/knot.db"] = now
+	require.NoError(t, state.Save(path))
+
+	loaded, err := LoadState(path)
+	require.NoError(t, err)
+	assert.True(t, now.Equal(loaded.Peers["/home/user/project/.knot/knot.db"]))
+}
+
+func TestParseSide(t *testing.T) {
+	for _, name := range []string{"", "local", "remote"} {
+		side, err := ParseSide(name)
+		require.NoError(t, err)
+		assert.Equal(t, Side(name), side)
+	}
+	_, err := ParseSide("both")
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package errors
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -191,6 +192,65 @@ func TooManyTasksError(currentCount, maxAllowed int, depth int) *EnhancedError {
 	}
 }
 
+// ProjectTaskLimitError creates an enhanced error for projects that reached
+// their task limit
+func ProjectTaskLimitError(count, limit int) *EnhancedError {
+	return &EnhancedError{
+		Operation:   "creating task",
+		Cause:       fmt.Errorf("project task limit reached: %d/%d tasks", count, limit),
+		Suggestion:  "Plan with fewer, coarser tasks, delete or archive tasks that are no longer needed, or raise the limit if the project really needs more",
+		Example:     fmt.Sprintf("knot config set --key max-tasks-per-project --value %d", limit*2),
+		HelpCommand: "knot task capacity  # see remaining capacity",
+	}
+}
+
+// DependencyLimitError creates an enhanced error for tasks that reached their
+// dependency limit
+func DependencyLimitError(title string, count, limit int) *EnhancedError {
+	return &EnhancedError{
+		Operation:   "adding task dependency",
+		Cause:       fmt.Errorf("task '%s' already has %d of at most %d dependencies", title, count, limit),
+		Suggestion:  "Depend on a parent task grouping the prerequisites instead of each of them, or raise the limit",
+		Example:     fmt.Sprintf("knot config set --key max-dependencies-per-task --value %d", limit*2),
+		HelpCommand: "knot dependency list --task-id <task-id>  # see the dependencies",
+	}
+}
+
+// CreationLimitError creates an enhanced error for actors that created as many
+// tasks as a creation limit allows
+func CreationLimitError(actor, pattern string, count, limit int, window time.Duration) *EnhancedError {
+	return &EnhancedError{
+		Operation:   "creating task",
+		Cause:       fmt.Errorf("creation limit reached: '%s' created %d tasks in the last %s, at most %d allowed for '%s'", actor, count, window, limit, pattern),
+		Suggestion:  "Check whether the agent is stuck in a loop creating tasks. Otherwise wait, or raise MaxTasks of the CreationLimits in .knot/config.json",
+		Example:     fmt.Sprintf(`"CreationLimits": [{"Actor": "%s", "MaxTasks": %d, "WindowMinutes": %d}]`, pattern, limit*2, int(window.Minutes())),
+		HelpCommand: "knot config show  # see the creation limits",
+	}
+}
+
+// MaxDepthExceededError creates an enhanced error for tasks nested too deeply
+func MaxDepthExceededError(depth, maxDepth int) *EnhancedError {
+	return &EnhancedError{
+		Operation:   "creating task",
+		Cause:       fmt.Errorf("maximum depth of %d exceeded: task would be at depth %d", maxDepth, depth),
+		Suggestion:  fmt.Sprintf("Create the task below a parent at depth %d or less, or increase max-depth", maxDepth-1),
+		Example:     "knot config set --key max-depth --value 8",
+		HelpCommand: "knot task capacity --parent-id <parent-id>  # see remaining capacity",
+	}
+}
+
+// HandoffNoteRequiredError creates an enhanced error for tasks changing hands
+// without the handoff note the configuration requires
+func HandoffNoteRequiredError(title string) *EnhancedError {
+	return &EnhancedError{
+		Operation:   "handing off task",
+		Cause:       fmt.Errorf("handoff note required: task '%s' changes hands without a note", title),
+		Suggestion:  "Leave a note on what is done and what is left for the next assignee, or turn the requirement off",
+		Example:     `knot task assign --id <task-id> --agent <agent> --handoff "API done, error handling tests left"`,
+		HelpCommand: "knot config set --key require-handoff-notes --value 0",
+	}
+}
+
 // NewValidationError creates an enhanced error for validation failures
 func NewValidationError(message string, cause error) *EnhancedError {
 	return &EnhancedError{
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/google/uuid"
 	"github.com/urfave/cli/v2"
 )
@@ -169,7 +170,7 @@ func FormatSuggestionList(title string, suggestions []string) string {
 	}
 
 	var parts []string
-	parts = append(parts, fmt.Sprintf("💡 %s:", title))
+	parts = append(parts, fmt.Sprintf("%s%s:", output.Icon("💡"), title))
 
 	for i, suggestion := range suggestions {
 		parts = append(parts, fmt.Sprintf("   %d. %s", i+1, suggestion))
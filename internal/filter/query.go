@@ -0,0 +1,233 @@
+// Package filter parses task filter queries, such as
+// "state:pending priority:low complexity:5-8 tag:backend login", and matches
+// tasks against them. Queries can be saved by name in the configuration.
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/denkhaus/knot/v2/internal/validation"
+)
+
+// Query is a parsed filter query. Terms with different keys must all match,
+// comma-separated values of a key match any of them.
+type Query struct {
+	States        []types.TaskState
+	Priorities    []types.TaskPriority
+	ComplexityMin int // 0 means no lower bound
+	ComplexityMax int // 0 means no upper bound
+	DepthMax      int // -1 means no limit
+	Tags          []string
+	Search        []string // Words that must all appear in title or description
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+var validStates = []types.TaskState{
+	types.TaskStatePending,
+	types.TaskStateInProgress,
+	types.TaskStateCompleted,
+	types.TaskStateBlocked,
+	types.TaskStateCancelled,
+	types.TaskStateDeletionPending,
+}
+
+// Parse parses a filter query. Terms are separated by whitespace and have the
+// form key:value with the keys state, priority, complexity (N or MIN-MAX),
+// depth-max, tag and search; words without a key are search terms.
+func Parse(query string) (*Query, error) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("filter query is empty")
+	}
+
+	q := &Query{DepthMax: -1}
+	for _, term := range terms {
+		key, value, hasKey := strings.Cut(term, ":")
+		if !hasKey {
+			q.Search = append(q.Search, strings.ToLower(term))
+			continue
+		}
+		if value == "" {
+			return nil, fmt.Errorf("filter term '%s' has no value", term)
+		}
+
+		var err error
+		switch strings.ToLower(key) {
+		case "state":
+			err = q.parseStates(value)
+		case "priority":
+			err = q.parsePriorities(value)
+		case "complexity":
+			err = q.parseComplexity(value)
+		case "depth-max":
+			q.DepthMax, err = strconv.Atoi(value)
+			if err == nil && q.DepthMax < 0 {
+				err = fmt.Errorf("depth-max must be 0 or greater, got %d", q.DepthMax)
+			}
+		case "tag":
+			for _, tag := range strings.Split(value, ",") {
+				q.Tags = append(q.Tags, strings.ToLower(tag))
+			}
+		case "search":
+			q.Search = append(q.Search, strings.ToLower(value))
+		default:
+			err = fmt.Errorf("unknown filter key '%s' (use state, priority, complexity, depth-max, tag or search)", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter term '%s': %w", term, err)
+		}
+	}
+	return q, nil
+}
+
+func (q *Query) parseStates(value string) error {
+	for _, name := range strings.Split(value, ",") {
+		state := types.TaskState(name)
+		valid := false
+		for _, candidate := range validStates {
+			if state == candidate {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("unknown state %q", name)
+		}
+		q.States = append(q.States, state)
+	}
+	return nil
+}
+
+func (q *Query) parsePriorities(value string) error {
+	validator := validation.NewInputValidator()
+	for _, name := range strings.Split(value, ",") {
+		if err := validator.ValidateTaskPriority(name); err != nil {
+			return err
+		}
+		q.Priorities = append(q.Priorities, utils.ParsePriority(name))
+	}
+	return nil
+}
+
+func (q *Query) parseComplexity(value string) error {
+	minStr, maxStr, isRange := strings.Cut(value, "-")
+	if !isRange {
+		maxStr = minStr
+	}
+
+	validator := validation.NewInputValidator()
+	bounds := make([]int, 2)
+	for i, s := range []string{minStr, maxStr} {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("complexity must be a number or a range like 5-8, got %q", value)
+		}
+		if err := validator.ValidateComplexity(n); err != nil {
+			return err
+		}
+		bounds[i] = n
+	}
+	if bounds[0] > bounds[1] {
+		return fmt.Errorf("complexity range %q is reversed", value)
+	}
+	q.ComplexityMin, q.ComplexityMax = bounds[0], bounds[1]
+	return nil
+}
+
+// Match reports whether the task matches all terms of the query
+func (q *Query) Match(task *types.Task) bool {
+	if len(q.States) > 0 && !containsState(q.States, task.State) {
+		return false
+	}
+	if len(q.Priorities) > 0 && !containsPriority(q.Priorities, task.Priority) {
+		return false
+	}
+	if q.ComplexityMin > 0 && task.Complexity < q.ComplexityMin {
+		return false
+	}
+	if q.ComplexityMax > 0 && task.Complexity > q.ComplexityMax {
+		return false
+	}
+	if q.DepthMax >= 0 && task.Depth > q.DepthMax {
+		return false
+	}
+	if len(q.Tags) > 0 && !hasAnyTag(task, q.Tags) {
+		return false
+	}
+
+	text := strings.ToLower(task.Title + "\n" + task.Description)
+	for _, word := range q.Search {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the tasks matching the query, keeping their order
+func (q *Query) Filter(tasks []*types.Task) []*types.Task {
+	matched := make([]*types.Task, 0)
+	for _, task := range tasks {
+		if q.Match(task) {
+			matched = append(matched, task)
+		}
+	}
+	return matched
+}
+
+func containsState(states []types.TaskState, state types.TaskState) bool {
+	for _, candidate := range states {
+		if candidate == state {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPriority(priorities []types.TaskPriority, priority types.TaskPriority) bool {
+	for _, candidate := range priorities {
+		if candidate == priority {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyTag(task *types.Task, tags []string) bool {
+	for _, tag := range task.Tags {
+		for _, wanted := range tags {
+			if strings.EqualFold(tag, wanted) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Resolve parses the saved filter with the given name, or value itself as a
+// query if no filter of that name is saved
+func Resolve(saved map[string]string, value string) (*Query, error) {
+	if query, ok := saved[value]; ok {
+		q, err := Parse(query)
+		if err != nil {
+			return nil, fmt.Errorf("saved filter '%s': %w", value, err)
+		}
+		return q, nil
+	}
+	return Parse(value)
+}
+
+// ValidateName checks that a name can be used for a saved filter. Names cannot
+// contain ':' or whitespace, so they are never mistaken for query terms.
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid filter name '%s': use letters, digits, '.', '_' and '-' only", name)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	q, err := Parse("state:pending,blocked priority:low complexity:5-8 depth-max:2 tag:Backend login")
+	require.NoError(t, err)
+	assert.Equal(t, []types.TaskState{types.TaskStatePending, types.TaskStateBlocked}, q.States)
+	assert.Equal(t, []types.TaskPriority{types.TaskPriorityLow}, q.Priorities)
+	assert.Equal(t, 5, q.ComplexityMin)
+	assert.Equal(t, 8, q.ComplexityMax)
+	assert.Equal(t, 2, q.DepthMax)
+	assert.Equal(t, []string{"backend"}, q.Tags)
+	assert.Equal(t, []string{"login"}, q.Search)
+
+	invalid := []string{
+		"",
+		"state:done",
+		"priority:urgent",
+		"complexity:11",
+		"complexity:8-5",
+		"depth-max:-1",
+		"owner:me",
+		"state:",
+	}
+	for _, query := range invalid {
+		_, err := Parse(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	task := &types.Task{
+		Title:       "Fix login flow",
+		Description: "Session cookies expire too early",
+		State:       types.TaskStatePending,
+		Priority:    types.TaskPriorityLow,
+		Complexity:  6,
+		Depth:       1,
+		Tags:        []string{"backend"},
+	}
+
+	matching := []string{
+		"state:pending",
+		"priority:low,medium",
+		"complexity:6",
+		"complexity:5-8 depth-max:1",
+		"tag:BACKEND",
+		"login cookies",
+		"search:FLOW",
+	}
+	for _, query := range matching {
+		q, err := Parse(query)
+		require.NoError(t, err, query)
+		assert.True(t, q.Match(task), query)
+	}
+
+	notMatching := []string{
+		"state:completed",
+		"priority:high",
+		"complexity:1-5",
+		"depth-max:0",
+		"tag:frontend",
+		"login signup",
+	}
+	for _, query := range notMatching {
+		q, err := Parse(query)
+		require.NoError(t, err, query)
+		assert.False(t, q.Match(task), query)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	saved := map[string]string{"stale": "state:pending priority:low", "broken": "state:nope"}
+
+	q, err := Resolve(saved, "stale")
+	require.NoError(t, err)
+	assert.Equal(t, []types.TaskPriority{types.TaskPriorityLow}, q.Priorities)
+
+	q, err = Resolve(saved, "login")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"login"}, q.Search)
+
+	_, err = Resolve(saved, "broken")
+	assert.ErrorContains(t, err, "saved filter 'broken'")
+
+	assert.NoError(t, ValidateName("stale-backend"))
+	assert.Error(t, ValidateName("state:pending"))
+	assert.Error(t, ValidateName("two words"))
+}
@@ -0,0 +1,509 @@
+package fixture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// ErrTaskExists is returned by Load for a task ID that already exists
+var ErrTaskExists = errors.New("task already exists")
+
+// ConflictPolicy decides what importing a fixture does with projects and
+// tasks whose IDs exist in the repository already, e.g. when a dump is loaded
+// into the database it was taken from
+type ConflictPolicy string
+
+const (
+	// ConflictFail rejects the fixture, nothing is written
+	ConflictFail ConflictPolicy = "fail"
+	// ConflictSkip keeps existing projects and tasks as they are and only
+	// creates the new tasks. Tasks deleted in the repository stay deleted.
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictOverwrite replaces existing projects and tasks, including the
+	// parent and dependencies of tasks, with the fixture. Tasks deleted in the
+	// repository are created again.
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	// ConflictNewIDs imports existing projects and tasks as copies with new IDs
+	ConflictNewIDs ConflictPolicy = "new-ids"
+)
+
+// ParseConflictPolicy parses the name of a conflict policy
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch policy := ConflictPolicy(s); policy {
+	case ConflictFail, ConflictSkip, ConflictOverwrite, ConflictNewIDs:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown conflict policy %q (must be fail, skip, overwrite or new-ids)", s)
+}
+
+// Actions an import plan takes for a project or task
+const (
+	ActionCreate    = "create"
+	ActionSkip      = "skip"
+	ActionOverwrite = "overwrite"
+	ActionUnchanged = "unchanged"
+)
+
+// ImportPlan is what importing a fixture changes in a repository, decided
+// before anything is written so it can be reviewed first
+type ImportPlan struct {
+	Policy   ConflictPolicy `json:"policy"`
+	Projects []*ProjectPlan `json:"projects"`
+}
+
+// ProjectPlan is the import of one project of the fixture
+type ProjectPlan struct {
+	ID uuid.UUID `json:"id"`
+	// OriginalID is the ID in the fixture if the project gets a new one
+	OriginalID *uuid.UUID `json:"original_id,omitempty"`
+	Title      string     `json:"title"`
+	Action     string     `json:"action"`
+	// Changes are the fields of an existing project that differ
+	Changes []string    `json:"changes,omitempty"`
+	Tasks   []*TaskPlan `json:"tasks"`
+
+	resolved *resolved
+}
+
+// TaskPlan is the import of one task of the fixture
+type TaskPlan struct {
+	ID uuid.UUID `json:"id"`
+	// OriginalID is the ID in the fixture if the task gets a new one
+	OriginalID *uuid.UUID `json:"original_id,omitempty"`
+	Title      string     `json:"title"`
+	Action     string     `json:"action"`
+	// Reason explains why a task is skipped or gets a new ID
+	Reason string `json:"reason,omitempty"`
+	// Changes are the fields of an existing task that differ
+	Changes []string `json:"changes,omitempty"`
+
+	task     *types.Task
+	existing *types.Task
+}
+
+// Count returns the number of tasks of the plan with the given action
+func (p *ImportPlan) Count(action string) int {
+	count := 0
+	for _, project := range p.Projects {
+		for _, task := range project.Tasks {
+			if task.Action == action {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Plan decides per project and task of the fixture what importing it with
+// policy does. Tasks deleted in the repository are recognized from its change
+// feed, if it has one. With ConflictFail, existing projects and tasks are
+// reported as ErrProjectExists and ErrTaskExists.
+func Plan(ctx context.Context, repo types.Repository, f *Fixture, now time.Time, policy ConflictPolicy) (*ImportPlan, error) {
+	plan := &ImportPlan{Policy: policy, Projects: make([]*ProjectPlan, 0, len(f.Projects))}
+	for i := range f.Projects {
+		r, err := f.Projects[i].resolve(now)
+		if err != nil {
+			return nil, fmt.Errorf("projects[%d]: %w", i, err)
+		}
+		projectPlan, err := planProject(ctx, repo, r, policy)
+		if err != nil {
+			return nil, fmt.Errorf("projects[%d]: %w", i, err)
+		}
+		plan.Projects = append(plan.Projects, projectPlan)
+	}
+	return plan, nil
+}
+
+func planProject(ctx context.Context, repo types.Repository, r *resolved, policy ConflictPolicy) (*ProjectPlan, error) {
+	existing := make(map[uuid.UUID]*types.Task)
+	for _, task := range r.tasks {
+		current, err := repo.GetTask(ctx, task.ID)
+		if sqlite.IsNotFoundError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up task %s: %w", task.ID, err)
+		}
+		existing[task.ID] = current
+	}
+	deleted, err := deletedTasks(ctx, repo, r.project.ID, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ProjectPlan{ID: r.project.ID, Title: r.project.Title, Action: ActionCreate, resolved: r}
+	existingProject, err := repo.GetProject(ctx, r.project.ID)
+	if err != nil && !sqlite.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to look up project: %w", err)
+	}
+	projectExists := err == nil
+	switch {
+	case !projectExists:
+	case policy == ConflictFail:
+		return nil, fmt.Errorf("%w: %s (%s)", ErrProjectExists, r.project.Title, r.project.ID)
+	case policy == ConflictSkip:
+		plan.Action = ActionSkip
+	case policy == ConflictOverwrite:
+		plan.Changes = projectChanges(existingProject, r.project)
+		plan.Action = ActionOverwrite
+		if len(plan.Changes) == 0 {
+			plan.Action = ActionUnchanged
+		}
+	case policy == ConflictNewIDs:
+		newIDs(r, existing, deleted, true)
+		original := plan.ID
+		plan.ID, plan.OriginalID = r.project.ID, &original
+	}
+	if policy == ConflictNewIDs && plan.OriginalID == nil {
+		newIDs(r, existing, deleted, false)
+	}
+
+	// Tasks end up in the project if they are created or exist in it already
+	available := make(map[uuid.UUID]bool, len(r.tasks))
+	for _, task := range r.tasks {
+		taskPlan := &TaskPlan{ID: task.ID, Title: task.Title, Action: ActionCreate, task: task}
+		if original, renamed := r.renamed[task.ID]; renamed {
+			taskPlan.OriginalID = &original
+			taskPlan.Reason = r.renamedReason[task.ID]
+		}
+		current, exists := existing[task.ID]
+		parentMissing := task.ParentID != nil && !available[*task.ParentID]
+		switch {
+		case exists && policy == ConflictFail:
+			return nil, fmt.Errorf("%w: %s (%s)", ErrTaskExists, task.Title, task.ID)
+		case exists && current.ProjectID != r.project.ID:
+			// Moving tasks between projects is not an import
+			taskPlan.Action, taskPlan.Reason = ActionSkip, "exists in another project"
+		case exists && policy == ConflictSkip:
+			taskPlan.Action, taskPlan.Reason = ActionSkip, "exists"
+			taskPlan.existing = current
+		case exists:
+			taskPlan.existing = current
+			taskPlan.Changes = taskChanges(current, task, r.dependencies(task.ID))
+			taskPlan.Action = ActionOverwrite
+			if len(taskPlan.Changes) == 0 {
+				taskPlan.Action = ActionUnchanged
+			}
+			if parentMissing {
+				taskPlan.Action, taskPlan.Reason = ActionSkip, "its parent is skipped"
+			}
+		case deleted[task.ID] && policy == ConflictSkip:
+			taskPlan.Action, taskPlan.Reason = ActionSkip, "deleted in this database"
+		case parentMissing:
+			taskPlan.Action, taskPlan.Reason = ActionSkip, "its parent is skipped"
+		case deleted[task.ID]:
+			taskPlan.Reason = "deleted in this database, created again"
+		}
+		if taskPlan.Action != ActionSkip || taskPlan.existing != nil {
+			available[task.ID] = true
+		}
+		plan.Tasks = append(plan.Tasks, taskPlan)
+	}
+	return plan, nil
+}
+
+// deletedTasks returns the IDs of tasks of a project the change feed of repo
+// records as deleted and that do not exist again
+func deletedTasks(ctx context.Context, repo types.Repository, projectID uuid.UUID, existing map[uuid.UUID]*types.Task) (map[uuid.UUID]bool, error) {
+	deleted := make(map[uuid.UUID]bool)
+	feed, ok := repo.(types.ChangeFeed)
+	if !ok {
+		return deleted, nil
+	}
+	events, err := feed.ListChangeEvents(ctx, types.ChangeEventFilter{ProjectID: &projectID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read change feed: %w", err)
+	}
+	for _, event := range events {
+		if event.Kind == types.ChangeTaskDeleted && event.TaskID != nil && existing[*event.TaskID] == nil {
+			deleted[*event.TaskID] = true
+		}
+	}
+	return deleted, nil
+}
+
+// newIDs gives the project, if all is set, and the tasks whose IDs exist or
+// were deleted new IDs, and updates the references to them
+func newIDs(r *resolved, existing map[uuid.UUID]*types.Task, deleted map[uuid.UUID]bool, all bool) {
+	r.renamed = make(map[uuid.UUID]uuid.UUID)
+	r.renamedReason = make(map[uuid.UUID]string)
+	mapping := make(map[uuid.UUID]uuid.UUID)
+	if all {
+		r.project.ID = types.NewID()
+	}
+	for _, task := range r.tasks {
+		reason := ""
+		switch {
+		case existing[task.ID] != nil:
+			reason = "ID exists"
+		case deleted[task.ID]:
+			reason = "ID deleted in this database"
+		default:
+			continue
+		}
+		id := types.NewID()
+		mapping[task.ID] = id
+		r.renamed[id] = task.ID
+		r.renamedReason[id] = reason
+	}
+
+	remap := func(id uuid.UUID) uuid.UUID {
+		if mapped, ok := mapping[id]; ok {
+			return mapped
+		}
+		return id
+	}
+	for _, task := range r.tasks {
+		task.ID = remap(task.ID)
+		task.ProjectID = r.project.ID
+		if task.ParentID != nil {
+			parentID := remap(*task.ParentID)
+			task.ParentID = &parentID
+		}
+	}
+	for i, edge := range r.edges {
+		r.edges[i] = [2]uuid.UUID{remap(edge[0]), remap(edge[1])}
+	}
+}
+
+// dependencies returns the IDs of the tasks a task of the fixture depends on
+func (r *resolved) dependencies(taskID uuid.UUID) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, edge := range r.edges {
+		if edge[0] == taskID {
+			ids = append(ids, edge[1])
+		}
+	}
+	return ids
+}
+
+// projectChanges lists the fields of an existing project the fixture changes
+func projectChanges(current, imported *types.Project) []string {
+	var changes []string
+	if current.Title != imported.Title {
+		changes = append(changes, "title")
+	}
+	if current.Description != imported.Description {
+		changes = append(changes, "description")
+	}
+	if current.State != imported.State {
+		changes = append(changes, "state")
+	}
+	return changes
+}
+
+// taskChanges lists the fields of an existing task the fixture changes
+func taskChanges(current, imported *types.Task, dependsOn []uuid.UUID) []string {
+	var changes []string
+	add := func(changed bool, field string) {
+		if changed {
+			changes = append(changes, field)
+		}
+	}
+	add(current.Title != imported.Title, "title")
+	add(current.Description != imported.Description, "description")
+	add(current.State != imported.State, "state")
+	add(current.Priority != imported.Priority, "priority")
+	add(current.Complexity != imported.Complexity, "complexity")
+	add(!equalPointers(current.ParentID, imported.ParentID), "parent")
+	add(!sameIDs(current.Dependencies, dependsOn), "dependencies")
+	add(!slices.Equal(current.Tags, imported.Tags), "tags")
+	add(!equalPointers(current.Estimate, imported.Estimate), "estimate")
+	add(!equalTimePointers(current.DueDate, imported.DueDate), "due date")
+	return changes
+}
+
+// Apply writes the plan to repo and returns the imported projects. The plan
+// is applied in one transaction of the storage backend, so either all projects
+// are imported or none. Backends without transactions delete a created project
+// again if it fails to load; changes to existing projects are not rolled back.
+func (p *ImportPlan) Apply(ctx context.Context, repo types.Repository) ([]*types.Project, error) {
+	transactor, ok := repo.(types.Transactor)
+	if !ok {
+		return p.apply(ctx, repo, true)
+	}
+
+	var projects []*types.Project
+	ran := false
+	err := transactor.InTransaction(ctx, func(ctx context.Context) error {
+		ran = true
+		var err error
+		projects, err = p.apply(ctx, repo, false)
+		return err
+	})
+	if !ran && errors.Is(err, types.ErrTransactionsUnsupported) {
+		return p.apply(ctx, repo, true)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// apply writes the projects of the plan one after another. With cleanup a
+// created project that fails to load is deleted again.
+func (p *ImportPlan) apply(ctx context.Context, repo types.Repository, cleanup bool) ([]*types.Project, error) {
+	projects := make([]*types.Project, 0, len(p.Projects))
+	for i, projectPlan := range p.Projects {
+		var project *types.Project
+		var err error
+		if projectPlan.Action == ActionCreate {
+			project, err = load(ctx, repo, projectPlan.loadable())
+			if err != nil && project != nil && cleanup {
+				if deleteErr := repo.DeleteProject(ctx, project.ID); deleteErr != nil {
+					return projects, fmt.Errorf("projects[%d]: %w (failed to remove the partial project: %v)", i, err, deleteErr)
+				}
+			}
+		} else {
+			project, err = projectPlan.merge(ctx, repo)
+		}
+		if err != nil {
+			return projects, fmt.Errorf("projects[%d]: %w", i, err)
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
+
+// loadable returns the resolved project without the skipped tasks and their
+// dependencies
+func (p *ProjectPlan) loadable() *resolved {
+	r := &resolved{project: p.resolved.project}
+	created := make(map[uuid.UUID]bool, len(p.Tasks))
+	for _, task := range p.Tasks {
+		if task.Action == ActionCreate {
+			r.tasks = append(r.tasks, task.task)
+			created[task.ID] = true
+		}
+	}
+	for _, edge := range p.resolved.edges {
+		if created[edge[0]] && created[edge[1]] {
+			r.edges = append(r.edges, edge)
+		}
+	}
+	return r
+}
+
+// merge imports the project into the existing project of the same ID
+func (p *ProjectPlan) merge(ctx context.Context, repo types.Repository) (*types.Project, error) {
+	if p.Action == ActionOverwrite {
+		project, err := repo.GetProject(ctx, p.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project: %w", err)
+		}
+		project.Title = p.resolved.project.Title
+		project.Description = p.resolved.project.Description
+		project.State = p.resolved.project.State
+		if err := repo.UpdateProject(ctx, project); err != nil {
+			return nil, fmt.Errorf("failed to update project: %w", err)
+		}
+	}
+
+	// Tasks are created parents first, before tasks are moved below them
+	inProject := make(map[uuid.UUID]bool, len(p.Tasks))
+	for _, task := range p.Tasks {
+		if task.Action == ActionCreate {
+			if err := repo.CreateTask(ctx, task.task); err != nil {
+				return nil, fmt.Errorf("failed to create task %q: %w", task.Title, err)
+			}
+		}
+		if task.Action != ActionSkip || task.existing != nil {
+			inProject[task.ID] = true
+		}
+	}
+	for _, task := range p.Tasks {
+		if task.Action == ActionOverwrite {
+			if err := overwriteTask(ctx, repo, task); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Dependencies are set for the created and overwritten tasks only, to
+	// tasks that are part of the project
+	for _, task := range p.Tasks {
+		if task.Action != ActionCreate && task.Action != ActionOverwrite {
+			continue
+		}
+		wanted := p.resolved.dependencies(task.ID)
+		var current []uuid.UUID
+		if task.existing != nil {
+			current = task.existing.Dependencies
+		}
+		for _, dependsOn := range current {
+			if !slices.Contains(wanted, dependsOn) {
+				if _, err := repo.RemoveTaskDependency(ctx, task.ID, dependsOn); err != nil {
+					return nil, fmt.Errorf("failed to remove dependency %s -> %s: %w", task.ID, dependsOn, err)
+				}
+			}
+		}
+		for _, dependsOn := range wanted {
+			if inProject[dependsOn] && !slices.Contains(current, dependsOn) {
+				if _, err := repo.AddTaskDependency(ctx, task.ID, dependsOn); err != nil {
+					return nil, fmt.Errorf("failed to add dependency %s -> %s: %w", task.ID, dependsOn, err)
+				}
+			}
+		}
+	}
+
+	project, err := repo.GetProject(ctx, p.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload project: %w", err)
+	}
+	return project, nil
+}
+
+// overwriteTask replaces the fields and parent of an existing task with the
+// ones of the fixture
+func overwriteTask(ctx context.Context, repo types.Repository, plan *TaskPlan) error {
+	task, err := repo.GetTask(ctx, plan.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get task %q: %w", plan.Title, err)
+	}
+	imported := plan.task
+	task.Title = imported.Title
+	task.Description = imported.Description
+	task.State = imported.State
+	task.Priority = imported.Priority
+	task.Complexity = imported.Complexity
+	task.Tags = imported.Tags
+	task.Estimate = imported.Estimate
+	task.DueDate = imported.DueDate
+	if err := repo.UpdateTask(ctx, task); err != nil {
+		return fmt.Errorf("failed to overwrite task %q: %w", plan.Title, err)
+	}
+	if !equalPointers(task.ParentID, imported.ParentID) {
+		if err := repo.MoveTask(ctx, task.ID, imported.ParentID); err != nil {
+			return fmt.Errorf("failed to move task %q: %w", plan.Title, err)
+		}
+	}
+	return nil
+}
+
+func equalPointers[T comparable](a, b *T) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func equalTimePointers(a, b *time.Time) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && a.Equal(*b))
+}
+
+// sameIDs reports whether a and b hold the same IDs in any order
+func sameIDs(a, b []uuid.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, id := range a {
+		if !slices.Contains(b, id) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package fixture
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/testutil"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// planActions returns the action and reason of every task of a plan by title
+func planActions(plan *ImportPlan) map[string]string {
+	actions := make(map[string]string)
+	for _, project := range plan.Projects {
+		for _, task := range project.Tasks {
+			actions[task.Title] = task.Action
+			if task.Reason != "" {
+				actions[task.Title] += ": " + task.Reason
+			}
+		}
+	}
+	return actions
+}
+
+func TestImportConflictPolicies(t *testing.T) {
+	backends := map[string]*testutil.TestConfig{
+		"inmemory": testutil.NewTestConfig(t),
+		"sqlite":   testutil.NewTestConfig(t).WithSQLiteDB(),
+	}
+	for name, config := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := config.SetupTestRepository(t)
+			f, err := Parse([]byte(demoFixture))
+			require.NoError(t, err)
+			projects, err := Load(ctx, repo, f, time.Now())
+			require.NoError(t, err)
+			projectID := projects[0].ID
+			dump, err := Dump(ctx, repo, nil)
+			require.NoError(t, err)
+
+			// The database moves on after the dump: a task is renamed, one is
+			// deleted and one is added
+			byTitle := func() map[string]*types.Task {
+				tasks, err := repo.GetTasksByProject(ctx, projectID)
+				require.NoError(t, err)
+				result := make(map[string]*types.Task)
+				for _, task := range tasks {
+					loaded, err := repo.GetTask(ctx, task.ID)
+					require.NoError(t, err)
+					result[task.Title] = loaded
+				}
+				return result
+			}
+			tasks := byTitle()
+			design := tasks["Design API"]
+			design.Title = "Design API v2"
+			require.NoError(t, repo.UpdateTask(ctx, design))
+			require.NoError(t, repo.DeleteTask(ctx, tasks["Write docs"].ID))
+			local := &types.Task{ID: types.NewID(), ProjectID: projectID, Title: "Local only", State: types.TaskStatePending,
+				Priority: types.TaskPriorityMedium, Complexity: 2, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+			require.NoError(t, repo.CreateTask(ctx, local))
+
+			_, err = Plan(ctx, repo, dump, time.Now(), ConflictFail)
+			assert.ErrorIs(t, err, ErrProjectExists)
+
+			skip, err := Plan(ctx, repo, dump, time.Now(), ConflictSkip)
+			require.NoError(t, err)
+			assert.Equal(t, ActionSkip, skip.Projects[0].Action)
+			assert.Equal(t, map[string]string{
+				"Design API":          "skip: exists",
+				"Implement endpoints": "skip: exists",
+				"Write docs":          "skip: deleted in this database",
+			}, planActions(skip))
+			_, err = skip.Apply(ctx, repo)
+			require.NoError(t, err)
+			assert.Contains(t, byTitle(), "Design API v2")
+			assert.NotContains(t, byTitle(), "Write docs", "skipping keeps deleted tasks deleted")
+
+			overwrite, err := Plan(ctx, repo, dump, time.Now(), ConflictOverwrite)
+			require.NoError(t, err)
+			assert.Equal(t, ActionUnchanged, overwrite.Projects[0].Action)
+			assert.Equal(t, map[string]string{
+				"Design API":          "overwrite",
+				"Implement endpoints": "overwrite",
+				"Write docs":          "create: deleted in this database, created again",
+			}, planActions(overwrite))
+			assert.Equal(t, []string{"title"}, overwrite.Projects[0].Tasks[0].Changes)
+			assert.Equal(t, []string{"dependencies"}, overwrite.Projects[0].Tasks[1].Changes)
+			_, err = overwrite.Apply(ctx, repo)
+			require.NoError(t, err)
+			tasks = byTitle()
+			require.Contains(t, tasks, "Design API")
+			require.Contains(t, tasks, "Write docs")
+			assert.Equal(t, []uuid.UUID{tasks["Write docs"].ID}, tasks["Implement endpoints"].Dependencies)
+			assert.Contains(t, tasks, "Local only", "tasks missing from the fixture are kept")
+
+			again, err := Plan(ctx, repo, dump, time.Now(), ConflictOverwrite)
+			require.NoError(t, err)
+			assert.Equal(t, 3, again.Count(ActionUnchanged), "overwriting twice changes nothing")
+
+			copied, err := Plan(ctx, repo, dump, time.Now(), ConflictNewIDs)
+			require.NoError(t, err)
+			require.NotNil(t, copied.Projects[0].OriginalID)
+			assert.Equal(t, projectID, *copied.Projects[0].OriginalID)
+			assert.Equal(t, 3, copied.Count(ActionCreate))
+			imported, err := copied.Apply(ctx, repo)
+			require.NoError(t, err)
+			require.Len(t, imported, 1)
+			assert.NotEqual(t, projectID, imported[0].ID)
+			assert.Equal(t, 3, imported[0].TotalTasks)
+
+			copies, err := repo.GetTasksByProject(ctx, imported[0].ID)
+			require.NoError(t, err)
+			for _, task := range copies {
+				loaded, err := repo.GetTask(ctx, task.ID)
+				require.NoError(t, err)
+				if loaded.Title == "Implement endpoints" {
+					require.NotNil(t, loaded.ParentID)
+					parent, err := repo.GetTask(ctx, *loaded.ParentID)
+					require.NoError(t, err)
+					assert.Equal(t, imported[0].ID, parent.ProjectID, "references point to the copies")
+					require.Len(t, loaded.Dependencies, 1)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,479 @@
+// Package fixture loads and dumps reproducible knot databases for tests,
+// demos, benchmarks and bug reports.
+//
+// A fixture file lists projects with their tasks, states, dependencies and
+// timestamps. Loading it writes directly to the repository, so tasks keep the
+// given states and timestamps instead of passing through the workflow rules
+// of the manager. IDs that are not given are derived from the project title
+// and task refs, so loading the same fixture always creates the same IDs.
+package fixture
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// Namespace derives the IDs of fixture projects without an explicit ID
+var Namespace = uuid.MustParse("5f0c6f1e-2a4b-4d0e-9c3a-6b1d7e2f8a90")
+
+// ErrProjectExists is returned by Load for a project that already exists
+var ErrProjectExists = errors.New("project already exists")
+
+// Fixture is the content of a fixture file
+type Fixture struct {
+	Projects []Project `yaml:"projects" json:"projects"`
+}
+
+// Project describes a project and its tasks
+type Project struct {
+	ID          string     `yaml:"id,omitempty" json:"id,omitempty"`
+	Title       string     `yaml:"title" json:"title"`
+	Description string     `yaml:"description,omitempty" json:"description,omitempty"`
+	State       string     `yaml:"state,omitempty" json:"state,omitempty"`
+	CreatedBy   string     `yaml:"created_by,omitempty" json:"created_by,omitempty"`
+	CreatedAt   *time.Time `yaml:"created_at,omitempty" json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `yaml:"updated_at,omitempty" json:"updated_at,omitempty"`
+	Tasks       []Task     `yaml:"tasks,omitempty" json:"tasks,omitempty"`
+}
+
+// Task describes a task. Parent and DependsOn name other tasks of the same
+// project by ref or ID.
+type Task struct {
+	Ref         string     `yaml:"ref,omitempty" json:"ref,omitempty"`
+	ID          string     `yaml:"id,omitempty" json:"id,omitempty"`
+	Title       string     `yaml:"title" json:"title"`
+	Description string     `yaml:"description,omitempty" json:"description,omitempty"`
+	State       string     `yaml:"state,omitempty" json:"state,omitempty"`
+	Priority    string     `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Complexity  int        `yaml:"complexity,omitempty" json:"complexity,omitempty"`
+	Parent      string     `yaml:"parent,omitempty" json:"parent,omitempty"`
+	DependsOn   []string   `yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
+	Tags        []string   `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Estimate    *int64     `yaml:"estimate,omitempty" json:"estimate,omitempty"` // Minutes
+	DueDate     *time.Time `yaml:"due_date,omitempty" json:"due_date,omitempty"`
+	CreatedBy   string     `yaml:"created_by,omitempty" json:"created_by,omitempty"`
+	CreatedAt   *time.Time `yaml:"created_at,omitempty" json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `yaml:"updated_at,omitempty" json:"updated_at,omitempty"`
+	CompletedAt *time.Time `yaml:"completed_at,omitempty" json:"completed_at,omitempty"`
+}
+
+// defaultComplexity is used for tasks without a complexity
+const defaultComplexity = 5
+
+// LoadFile reads and parses a fixture file. YAML is expected, but since YAML
+// is a superset of JSON, JSON fixture files are accepted as well.
+func LoadFile(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses fixture data and performs structural validation
+func Parse(data []byte) (*Fixture, error) {
+	var f Fixture
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture: %w", err)
+	}
+	if len(f.Projects) == 0 {
+		return nil, fmt.Errorf("fixture contains no projects")
+	}
+	for i := range f.Projects {
+		if _, err := f.Projects[i].resolve(time.Time{}); err != nil {
+			return nil, fmt.Errorf("projects[%d]: %w", i, err)
+		}
+	}
+	return &f, nil
+}
+
+// Marshal encodes a fixture as YAML
+func (f *Fixture) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	return data, nil
+}
+
+// resolved is a fixture project turned into domain objects
+type resolved struct {
+	project *types.Project
+	tasks   []*types.Task // Parents come before their children
+	edges   [][2]uuid.UUID
+	// renamed maps the new IDs of tasks imported with new IDs to the ones of
+	// the fixture, with the reason
+	renamed       map[uuid.UUID]uuid.UUID
+	renamedReason map[uuid.UUID]string
+}
+
+// resolve validates the project and builds its domain objects. Missing
+// timestamps default to now for the project and to one second apart from the
+// project creation, in file order, for tasks.
+func (p *Project) resolve(now time.Time) (*resolved, error) {
+	if strings.TrimSpace(p.Title) == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	projectID, err := parseID(p.ID, func() uuid.UUID {
+		return uuid.NewSHA1(Namespace, []byte("project/"+p.Title))
+	})
+	if err != nil {
+		return nil, err
+	}
+	state := types.ProjectState(p.State)
+	switch state {
+	case "":
+		state = types.ProjectStateActive
+	case types.ProjectStateActive, types.ProjectStateCompleted, types.ProjectStateArchived, types.ProjectStateDeletionPending:
+	default:
+		return nil, fmt.Errorf("invalid state %q (must be active, completed, archived or deletion-pending)", p.State)
+	}
+	createdAt := timeOr(p.CreatedAt, now)
+
+	result := &resolved{project: &types.Project{
+		ID:          projectID,
+		Title:       p.Title,
+		Description: p.Description,
+		State:       state,
+		CreatedBy:   p.CreatedBy,
+		CreatedAt:   createdAt,
+		UpdatedAt:   timeOr(p.UpdatedAt, createdAt),
+	}}
+
+	byRef := make(map[string]*types.Task, len(p.Tasks))
+	tasks := make([]*types.Task, len(p.Tasks))
+	for i, t := range p.Tasks {
+		task, err := t.resolve(projectID, i, createdAt.Add(time.Duration(i)*time.Second))
+		if err != nil {
+			return nil, fmt.Errorf("tasks[%d]: %w", i, err)
+		}
+		for _, key := range []string{t.Ref, task.ID.String()} {
+			if key == "" {
+				continue
+			}
+			if _, exists := byRef[key]; exists {
+				return nil, fmt.Errorf("tasks[%d]: duplicate ref or ID %q", i, key)
+			}
+			byRef[key] = task
+		}
+		tasks[i] = task
+	}
+
+	lookup := func(i int, field, ref string) (*types.Task, error) {
+		task, exists := byRef[ref]
+		if !exists {
+			return nil, fmt.Errorf("tasks[%d]: %s %q is not a task of the project", i, field, ref)
+		}
+		return task, nil
+	}
+	for i, t := range p.Tasks {
+		if t.Parent != "" {
+			parent, err := lookup(i, "parent", t.Parent)
+			if err != nil {
+				return nil, err
+			}
+			tasks[i].ParentID = &parent.ID
+		}
+		for _, ref := range t.DependsOn {
+			dependency, err := lookup(i, "depends_on", ref)
+			if err != nil {
+				return nil, err
+			}
+			if dependency == tasks[i] {
+				return nil, fmt.Errorf("tasks[%d]: task cannot depend on itself", i)
+			}
+			result.edges = append(result.edges, [2]uuid.UUID{tasks[i].ID, dependency.ID})
+		}
+	}
+
+	result.tasks, err = parentsFirst(tasks)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// resolve validates the task and builds its domain object
+func (t *Task) resolve(projectID uuid.UUID, index int, defaultCreatedAt time.Time) (*types.Task, error) {
+	if strings.TrimSpace(t.Title) == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	id, err := parseID(t.ID, func() uuid.UUID {
+		name := t.Ref
+		if name == "" {
+			name = fmt.Sprintf("#%d", index)
+		}
+		return uuid.NewSHA1(projectID, []byte("task/"+name))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state := types.TaskState(t.State)
+	if state == "" {
+		state = types.TaskStatePending
+	} else if err := validateTaskState(state); err != nil {
+		return nil, err
+	}
+	priority, err := parsePriority(t.Priority)
+	if err != nil {
+		return nil, err
+	}
+	complexity := t.Complexity
+	if complexity == 0 {
+		complexity = defaultComplexity
+	}
+	if complexity < 1 || complexity > 10 {
+		return nil, fmt.Errorf("complexity must be between 1 and 10, got %d", complexity)
+	}
+
+	createdAt := timeOr(t.CreatedAt, defaultCreatedAt)
+	updatedAt := timeOr(t.UpdatedAt, createdAt)
+	var completedAt *time.Time
+	if state == types.TaskStateCompleted {
+		completed := timeOr(t.CompletedAt, updatedAt)
+		completedAt = &completed
+	}
+
+	return &types.Task{
+		ID:          id,
+		ProjectID:   projectID,
+		Title:       t.Title,
+		Description: t.Description,
+		State:       state,
+		Priority:    priority,
+		Complexity:  complexity,
+		Tags:        t.Tags,
+		Estimate:    t.Estimate,
+		DueDate:     t.DueDate,
+		CreatedBy:   t.CreatedBy,
+		UpdatedBy:   t.CreatedBy,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+		CompletedAt: completedAt,
+	}, nil
+}
+
+// Load creates the projects of the fixture in repo and returns them. Projects
+// and tasks that already exist are rejected before anything is written, see
+// Plan for other conflict policies. If a project fails to load, nothing is
+// imported, see ImportPlan.Apply.
+func Load(ctx context.Context, repo types.Repository, f *Fixture, now time.Time) ([]*types.Project, error) {
+	plan, err := Plan(ctx, repo, f, now, ConflictFail)
+	if err != nil {
+		return nil, err
+	}
+	return plan.Apply(ctx, repo)
+}
+
+// load writes one resolved project. It returns the project once it exists,
+// also on errors, so the caller can remove it.
+func load(ctx context.Context, repo types.Repository, r *resolved) (*types.Project, error) {
+	updatedAt := r.project.UpdatedAt
+	if err := repo.CreateProject(ctx, r.project); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	for _, task := range r.tasks {
+		if err := repo.CreateTask(ctx, task); err != nil {
+			return r.project, fmt.Errorf("failed to create task %q: %w", task.Title, err)
+		}
+	}
+	for _, edge := range r.edges {
+		if _, err := repo.AddTaskDependency(ctx, edge[0], edge[1]); err != nil {
+			return r.project, fmt.Errorf("failed to add dependency %s -> %s: %w", edge[0], edge[1], err)
+		}
+	}
+
+	// Creating tasks updates the project metrics and with them its update time
+	project, err := repo.GetProject(ctx, r.project.ID)
+	if err != nil {
+		return r.project, fmt.Errorf("failed to reload project: %w", err)
+	}
+	project.UpdatedAt = updatedAt
+	if err := repo.UpdateProject(ctx, project); err != nil {
+		return project, fmt.Errorf("failed to restore project update time: %w", err)
+	}
+	return project, nil
+}
+
+// Dump captures projects of repo as a fixture, all projects if projectIDs is
+// empty. Tasks keep their IDs, so loading the dump recreates the same data.
+func Dump(ctx context.Context, repo types.Repository, projectIDs []uuid.UUID) (*Fixture, error) {
+	var projects []*types.Project
+	if len(projectIDs) == 0 {
+		var err error
+		if projects, err = repo.ListProjects(ctx); err != nil {
+			return nil, fmt.Errorf("failed to list projects: %w", err)
+		}
+	}
+	for _, id := range projectIDs {
+		project, err := repo.GetProject(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project %s: %w", id, err)
+		}
+		projects = append(projects, project)
+	}
+
+	f := &Fixture{Projects: make([]Project, 0, len(projects))}
+	for _, project := range projects {
+		dumped, err := dumpProject(ctx, repo, project)
+		if err != nil {
+			return nil, err
+		}
+		f.Projects = append(f.Projects, dumped)
+	}
+	return f, nil
+}
+
+func dumpProject(ctx context.Context, repo types.Repository, project *types.Project) (Project, error) {
+	listed, err := repo.GetTasksByProject(ctx, project.ID)
+	if err != nil {
+		return Project{}, fmt.Errorf("failed to list tasks of project %s: %w", project.ID, err)
+	}
+	ids := make([]uuid.UUID, len(listed))
+	for i, task := range listed {
+		ids[i] = task.ID
+	}
+	// Listed tasks may lack their dependencies, depending on the storage
+	tasks, err := repo.GetTasksWithDependencies(ctx, ids)
+	if err != nil {
+		return Project{}, fmt.Errorf("failed to load tasks of project %s: %w", project.ID, err)
+	}
+	slices.SortFunc(tasks, types.CompareCreation)
+	tasks, err = parentsFirst(tasks)
+	if err != nil {
+		return Project{}, err
+	}
+
+	dumped := Project{
+		ID:          project.ID.String(),
+		Title:       project.Title,
+		Description: project.Description,
+		State:       string(project.State),
+		CreatedBy:   project.CreatedBy,
+		CreatedAt:   utcPointer(project.CreatedAt),
+		UpdatedAt:   utcPointer(project.UpdatedAt),
+		Tasks:       make([]Task, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		t := Task{
+			ID:          task.ID.String(),
+			Title:       task.Title,
+			Description: task.Description,
+			State:       string(task.State),
+			Priority:    task.Priority.ToExternalString(),
+			Complexity:  task.Complexity,
+			Tags:        task.Tags,
+			Estimate:    task.Estimate,
+			DueDate:     task.DueDate,
+			CreatedBy:   task.CreatedBy,
+			CreatedAt:   utcPointer(task.CreatedAt),
+			UpdatedAt:   utcPointer(task.UpdatedAt),
+		}
+		if task.ParentID != nil {
+			t.Parent = task.ParentID.String()
+		}
+		for _, dependsOn := range task.Dependencies {
+			t.DependsOn = append(t.DependsOn, dependsOn.String())
+		}
+		if task.CompletedAt != nil {
+			t.CompletedAt = utcPointer(*task.CompletedAt)
+		}
+		dumped.Tasks = append(dumped.Tasks, t)
+	}
+	return dumped, nil
+}
+
+// parentsFirst orders tasks so every parent comes before its children,
+// keeping the order of tasks otherwise. Parent cycles are rejected.
+func parentsFirst(tasks []*types.Task) ([]*types.Task, error) {
+	byID := make(map[uuid.UUID]*types.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	ordered := make([]*types.Task, 0, len(tasks))
+	placed := make(map[uuid.UUID]bool, len(tasks))
+	var place func(task *types.Task, visiting map[uuid.UUID]bool) error
+	place = func(task *types.Task, visiting map[uuid.UUID]bool) error {
+		if placed[task.ID] {
+			return nil
+		}
+		if visiting[task.ID] {
+			return fmt.Errorf("task %q is its own ancestor", task.Title)
+		}
+		visiting[task.ID] = true
+		if task.ParentID != nil {
+			if parent, exists := byID[*task.ParentID]; exists {
+				if err := place(parent, visiting); err != nil {
+					return err
+				}
+			}
+		}
+		placed[task.ID] = true
+		ordered = append(ordered, task)
+		return nil
+	}
+	for _, task := range tasks {
+		if err := place(task, make(map[uuid.UUID]bool)); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// parseID parses id, or derives one if it is empty
+func parseID(id string, derive func() uuid.UUID) (uuid.UUID, error) {
+	if id == "" {
+		return derive(), nil
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid id %q: %w", id, err)
+	}
+	return parsed, nil
+}
+
+func validateTaskState(state types.TaskState) error {
+	switch state {
+	case types.TaskStatePending, types.TaskStateInProgress, types.TaskStateCompleted,
+		types.TaskStateBlocked, types.TaskStateCancelled, types.TaskStateDeletionPending:
+		return nil
+	}
+	return fmt.Errorf("invalid state %q (must be pending, in-progress, completed, blocked, cancelled or deletion-pending)", state)
+}
+
+func parsePriority(priority string) (types.TaskPriority, error) {
+	switch priority {
+	case "", "medium":
+		return types.TaskPriorityMedium, nil
+	case "high":
+		return types.TaskPriorityHigh, nil
+	case "low":
+		return types.TaskPriorityLow, nil
+	default:
+		return 0, fmt.Errorf("invalid priority %q (must be low, medium or high)", priority)
+	}
+}
+
+func timeOr(t *time.Time, fallback time.Time) time.Time {
+	if t == nil {
+		return fallback.UTC()
+	}
+	return t.UTC()
+}
+
+func utcPointer(t time.Time) *time.Time {
+	utc := t.UTC()
+	return &utc
+}
@@ -0,0 +1,155 @@
+package fixture
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/testutil"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const demoFixture = `
+projects:
+  - title: Demo
+    created_at: 2025-01-06T09:00:00Z
+    updated_at: 2025-01-10T17:00:00Z
+    tasks:
+      - ref: design
+        title: Design API
+        state: completed
+        priority: high
+        complexity: 4
+        updated_at: 2025-01-07T12:00:00Z
+      - ref: endpoints
+        title: Implement endpoints
+        state: in-progress
+        parent: design
+        depends_on: [docs]
+        tags: [backend]
+      - ref: docs
+        title: Write docs
+        estimate: 90
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"no projects", "projects: []", "no projects"},
+		{"missing title", "projects: [{tasks: [{title: A}]}]", "title is required"},
+		{"unknown parent", "projects: [{title: P, tasks: [{title: A, parent: nope}]}]", `parent "nope"`},
+		{"self dependency", "projects: [{title: P, tasks: [{ref: a, title: A, depends_on: [a]}]}]", "itself"},
+		{"duplicate ref", "projects: [{title: P, tasks: [{ref: a, title: A}, {ref: a, title: B}]}]", "duplicate"},
+		{"invalid state", "projects: [{title: P, tasks: [{title: A, state: done}]}]", "invalid state"},
+		{"invalid priority", "projects: [{title: P, tasks: [{title: A, priority: urgent}]}]", "invalid priority"},
+		{"parent cycle", "projects: [{title: P, tasks: [{ref: a, title: A, parent: b}, {ref: b, title: B, parent: a}]}]", "own ancestor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoadAndDump(t *testing.T) {
+	backends := map[string]*testutil.TestConfig{
+		"inmemory": testutil.NewTestConfig(t),
+		"sqlite":   testutil.NewTestConfig(t).WithSQLiteDB(),
+	}
+	for name, config := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := config.SetupTestRepository(t)
+			f, err := Parse([]byte(demoFixture))
+			require.NoError(t, err)
+
+			projects, err := Load(ctx, repo, f, time.Now())
+			require.NoError(t, err)
+			require.Len(t, projects, 1)
+			project := projects[0]
+			assert.Equal(t, 3, project.TotalTasks)
+			assert.Equal(t, 1, project.CompletedTasks)
+			assert.True(t, project.UpdatedAt.Equal(time.Date(2025, 1, 10, 17, 0, 0, 0, time.UTC)))
+
+			tasks, err := repo.GetTasksByProject(ctx, project.ID)
+			require.NoError(t, err)
+			require.Len(t, tasks, 3)
+			design, endpoints, docs := tasks[0], tasks[1], tasks[2]
+			assert.Equal(t, "Design API", design.Title)
+			assert.Equal(t, types.TaskPriorityHigh, design.Priority)
+			require.NotNil(t, design.CompletedAt)
+			assert.True(t, design.CompletedAt.Equal(time.Date(2025, 1, 7, 12, 0, 0, 0, time.UTC)))
+			assert.Equal(t, &design.ID, endpoints.ParentID)
+			assert.Equal(t, 1, endpoints.Depth)
+			assert.True(t, docs.CreatedAt.Equal(time.Date(2025, 1, 6, 9, 0, 2, 0, time.UTC)))
+
+			loaded, err := repo.GetTask(ctx, endpoints.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []uuid.UUID{docs.ID}, loaded.Dependencies)
+
+			t.Run("IDs are reproducible", func(t *testing.T) {
+				again, err := Parse([]byte(demoFixture))
+				require.NoError(t, err)
+				_, err = Load(ctx, repo, again, time.Now())
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "already exists")
+			})
+
+			t.Run("a dump loads into the same data", func(t *testing.T) {
+				dumped, err := Dump(ctx, repo, nil)
+				require.NoError(t, err)
+				data, err := dumped.Marshal()
+				require.NoError(t, err)
+
+				other := testutil.NewTestConfig(t).SetupTestRepository(t)
+				reparsed, err := Parse(data)
+				require.NoError(t, err)
+				_, err = Load(ctx, other, reparsed, time.Now())
+				require.NoError(t, err)
+
+				redumped, err := Dump(ctx, other, nil)
+				require.NoError(t, err)
+				assert.Equal(t, dumped, redumped)
+			})
+		})
+	}
+}
+
+func TestLoadRemovesPartialProject(t *testing.T) {
+	backends := map[string]*testutil.TestConfig{
+		"inmemory": testutil.NewTestConfig(t),
+		"sqlite":   testutil.NewTestConfig(t).WithSQLiteDB(),
+	}
+	for name, config := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := config.SetupTestRepository(t)
+			f, err := Parse([]byte(`
+projects:
+  - title: Valid
+    tasks:
+      - {ref: a, title: A}
+  - title: Cyclic
+    tasks:
+      - {ref: a, title: A, depends_on: [b]}
+      - {ref: b, title: B, depends_on: [a]}
+`))
+			require.NoError(t, err)
+
+			_, err = Load(ctx, repo, f, time.Now())
+			require.Error(t, err)
+
+			projects, err := repo.ListProjects(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, projects, "the projects loaded before the failure are removed too")
+		})
+	}
+}
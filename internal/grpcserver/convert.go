@@ -0,0 +1,197 @@
+package grpcserver
+
+import (
+	"encoding/json"
+	"time"
+
+	knotv1 "github.com/denkhaus/knot/v2/api/proto/knot/v1"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var taskStates = map[types.TaskState]knotv1.TaskState{
+	types.TaskStatePending:         knotv1.TaskState_TASK_STATE_PENDING,
+	types.TaskStateInProgress:      knotv1.TaskState_TASK_STATE_IN_PROGRESS,
+	types.TaskStateCompleted:       knotv1.TaskState_TASK_STATE_COMPLETED,
+	types.TaskStateBlocked:         knotv1.TaskState_TASK_STATE_BLOCKED,
+	types.TaskStateCancelled:       knotv1.TaskState_TASK_STATE_CANCELLED,
+	types.TaskStateDeletionPending: knotv1.TaskState_TASK_STATE_DELETION_PENDING,
+}
+
+var projectStates = map[types.ProjectState]knotv1.ProjectState{
+	types.ProjectStateActive:          knotv1.ProjectState_PROJECT_STATE_ACTIVE,
+	types.ProjectStateCompleted:       knotv1.ProjectState_PROJECT_STATE_COMPLETED,
+	types.ProjectStateArchived:        knotv1.ProjectState_PROJECT_STATE_ARCHIVED,
+	types.ProjectStateDeletionPending: knotv1.ProjectState_PROJECT_STATE_DELETION_PENDING,
+}
+
+var eventKinds = map[types.ChangeEventKind]knotv1.ChangeEvent_Kind{
+	types.ChangeProjectCreated:    knotv1.ChangeEvent_KIND_PROJECT_CREATED,
+	types.ChangeProjectUpdated:    knotv1.ChangeEvent_KIND_PROJECT_UPDATED,
+	types.ChangeProjectDeleted:    knotv1.ChangeEvent_KIND_PROJECT_DELETED,
+	types.ChangeTaskCreated:       knotv1.ChangeEvent_KIND_TASK_CREATED,
+	types.ChangeTaskUpdated:       knotv1.ChangeEvent_KIND_TASK_UPDATED,
+	types.ChangeTaskDeleted:       knotv1.ChangeEvent_KIND_TASK_DELETED,
+	types.ChangeDependencyAdded:   knotv1.ChangeEvent_KIND_DEPENDENCY_ADDED,
+	types.ChangeDependencyRemoved: knotv1.ChangeEvent_KIND_DEPENDENCY_REMOVED,
+	types.ChangeDependencyUpdated: knotv1.ChangeEvent_KIND_DEPENDENCY_UPDATED,
+}
+
+// fromTaskState converts a requested state, rejecting unspecified states
+func fromTaskState(state knotv1.TaskState) (types.TaskState, error) {
+	for taskState, value := range taskStates {
+		if value == state {
+			return taskState, nil
+		}
+	}
+	return "", status.Errorf(codes.InvalidArgument, "invalid task state %s", state)
+}
+
+// parseID parses the UUID of a request field
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid %s %q: %v", field, value, err)
+	}
+	return id, nil
+}
+
+// parseOptionalID parses the UUID of a request field that may be empty
+func parseOptionalID(field, value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := parseID(field, value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func idStrings(ids []uuid.UUID) []string {
+	if len(ids) == 0 {
+		return nil
+	}
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+	return strs
+}
+
+func toProject(project *types.Project) *knotv1.Project {
+	return &knotv1.Project{
+		Id:             project.ID.String(),
+		Title:          project.Title,
+		Description:    project.Description,
+		State:          projectStates[project.State],
+		CreatedAt:      timestamp(project.CreatedAt),
+		UpdatedAt:      timestamp(project.UpdatedAt),
+		CreatedBy:      project.CreatedBy,
+		UpdatedBy:      project.UpdatedBy,
+		TotalTasks:     int32(project.TotalTasks),
+		CompletedTasks: int32(project.CompletedTasks),
+		Progress:       project.Progress,
+	}
+}
+
+func toProgress(progress *types.ProjectProgress) *knotv1.ProjectProgress {
+	byDepth := make(map[int32]int32, len(progress.TasksByDepth))
+	for depth, count := range progress.TasksByDepth {
+		byDepth[int32(depth)] = int32(count)
+	}
+	return &knotv1.ProjectProgress{
+		ProjectId:       progress.ProjectID.String(),
+		TotalTasks:      int32(progress.TotalTasks),
+		CompletedTasks:  int32(progress.CompletedTasks),
+		InProgressTasks: int32(progress.InProgressTasks),
+		PendingTasks:    int32(progress.PendingTasks),
+		BlockedTasks:    int32(progress.BlockedTasks),
+		CancelledTasks:  int32(progress.CancelledTasks),
+		OverallProgress: progress.OverallProgress,
+		TasksByDepth:    byDepth,
+	}
+}
+
+func toTask(task *types.Task) *knotv1.Task {
+	converted := &knotv1.Task{
+		Id:           task.ID.String(),
+		ProjectId:    task.ProjectID.String(),
+		Title:        task.Title,
+		Description:  task.Description,
+		State:        taskStates[task.State],
+		Priority:     knotv1.TaskPriority(task.Priority),
+		Complexity:   int32(task.Complexity),
+		Depth:        int32(task.Depth),
+		Dependencies: idStrings(task.Dependencies),
+		Dependents:   idStrings(task.Dependents),
+		Tags:         task.Tags,
+		CreatedAt:    timestamp(task.CreatedAt),
+		UpdatedAt:    timestamp(task.UpdatedAt),
+		CreatedBy:    task.CreatedBy,
+		UpdatedBy:    task.UpdatedBy,
+	}
+	if task.ParentID != nil {
+		converted.ParentId = task.ParentID.String()
+	}
+	if task.Estimate != nil {
+		converted.Estimate = *task.Estimate
+	}
+	if task.CompletedAt != nil {
+		converted.CompletedAt = timestamp(*task.CompletedAt)
+	}
+	return converted
+}
+
+func toTasks(tasks []*types.Task) []*knotv1.Task {
+	converted := make([]*knotv1.Task, len(tasks))
+	for i, task := range tasks {
+		converted[i] = toTask(task)
+	}
+	return converted
+}
+
+// toEvent converts a change event, including the project or task snapshot it
+// carries
+func toEvent(event *types.ChangeEvent) *knotv1.ChangeEvent {
+	converted := &knotv1.ChangeEvent{
+		Seq:        event.Seq,
+		Kind:       eventKinds[event.Kind],
+		ProjectId:  event.ProjectID.String(),
+		Actor:      event.Actor,
+		OccurredAt: timestamp(event.CreatedAt),
+	}
+	if event.TaskID != nil {
+		converted.TaskId = event.TaskID.String()
+	}
+	if event.DependsOnID != nil {
+		converted.DependsOnTaskId = event.DependsOnID.String()
+	}
+	if len(event.Data) == 0 {
+		return converted
+	}
+
+	switch event.Kind {
+	case types.ChangeTaskCreated, types.ChangeTaskUpdated:
+		var task types.Task
+		if json.Unmarshal(event.Data, &task) == nil {
+			converted.Task = toTask(&task)
+		}
+	case types.ChangeProjectCreated, types.ChangeProjectUpdated:
+		var project types.Project
+		if json.Unmarshal(event.Data, &project) == nil {
+			converted.Project = toProject(&project)
+		}
+	}
+	return converted
+}
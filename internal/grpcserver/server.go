@@ -0,0 +1,170 @@
+// Package grpcserver serves the knot gRPC API defined in api/proto/knot/v1
+// on top of the project manager
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	knotv1 "github.com/denkhaus/knot/v2/api/proto/knot/v1"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/repository/remote"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultPollInterval is how often WatchEvents checks the change feed for new
+// events
+const DefaultPollInterval = time.Second
+
+// Options configure a gRPC server
+type Options struct {
+	// Auth decides who may call the API; nil serves every client with full
+	// access, like the HTTP server without users and token
+	Auth *remote.Auth
+	// Actor records changes of clients that send no actor and use no user
+	// token
+	Actor string
+	// Timeout bounds each call and each poll of WatchEvents, 0 for no limit
+	Timeout time.Duration
+	// PollInterval is how often WatchEvents polls the change feed,
+	// DefaultPollInterval if 0
+	PollInterval time.Duration
+	Logger       *zap.Logger
+}
+
+// Server is a gRPC server for the knot API
+type Server struct {
+	grpc     *grpc.Server
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// New creates a gRPC server serving pm
+func New(pm manager.ProjectManager, opts Options) *Server {
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+
+	s := &Server{done: make(chan struct{})}
+	svc := &service{
+		pm:           pm,
+		actor:        opts.Actor,
+		timeout:      opts.Timeout,
+		pollInterval: opts.PollInterval,
+		logger:       opts.Logger,
+		done:         s.done,
+	}
+	interceptors := &interceptors{auth: opts.Auth, timeout: opts.Timeout, logger: opts.Logger}
+	s.grpc = grpc.NewServer(
+		grpc.UnaryInterceptor(interceptors.unary),
+		grpc.StreamInterceptor(interceptors.stream),
+	)
+	knotv1.RegisterKnotServiceServer(s.grpc, svc)
+	return s
+}
+
+// Serve accepts connections on listener until Shutdown is called
+func (s *Server) Serve(listener net.Listener) error {
+	return s.grpc.Serve(listener)
+}
+
+// Shutdown ends running event streams and waits for running calls to finish,
+// closing the remaining connections once ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.done) })
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		return ctx.Err()
+	}
+}
+
+type callerContextKey struct{}
+
+// callerFrom returns the authenticated caller of a call
+func callerFrom(ctx context.Context) *remote.Caller {
+	caller, _ := ctx.Value(callerContextKey{}).(*remote.Caller)
+	if caller == nil {
+		return &remote.Caller{}
+	}
+	return caller
+}
+
+// interceptors authenticate every call and bound unary calls by the timeout
+type interceptors struct {
+	auth    *remote.Auth
+	timeout time.Duration
+	logger  *zap.Logger
+}
+
+func (i *interceptors) authenticate(ctx context.Context) (context.Context, error) {
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authorization = values[0]
+		}
+	}
+	caller, err := i.auth.Authenticate(ctx, authorization)
+	if err != nil {
+		i.logger.Error("Failed to authenticate call", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to authenticate call")
+	}
+	if caller == nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or missing token")
+	}
+	return context.WithValue(ctx, callerContextKey{}, caller), nil
+}
+
+func (i *interceptors) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := i.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if i.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, i.timeout)
+		defer cancel()
+	}
+	resp, err := handler(ctx, req)
+	if err != nil {
+		i.logger.Debug("gRPC call failed", zap.String("method", info.FullMethod), zap.Error(err))
+	}
+	return resp, err
+}
+
+func (i *interceptors) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := i.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	// Streams run until the client cancels, so the timeout bounds each poll
+	// of the change feed instead
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticatedStream carries the caller in the context of a stream
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
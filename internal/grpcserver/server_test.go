@@ -0,0 +1,202 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	knotv1 "github.com/denkhaus/knot/v2/api/proto/knot/v1"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/repository/remote"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func setupServer(t *testing.T, pm manager.ProjectManager, auth *remote.Auth) knotv1.KnotServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := New(pm, Options{Auth: auth, Actor: "server", PollInterval: 10 * time.Millisecond})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return knotv1.NewKnotServiceClient(conn)
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func TestServer(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := setupServer(t, manager.NewManager(manager.DefaultConfig()), nil)
+
+	project, err := client.CreateProject(ctx, &knotv1.CreateProjectRequest{Title: "Plugin", Actor: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, knotv1.ProjectState_PROJECT_STATE_ACTIVE, project.State)
+	assert.Equal(t, "alice", project.CreatedBy)
+
+	first, err := client.CreateTask(ctx, &knotv1.CreateTaskRequest{ProjectId: project.Id, Title: "First"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, first.Complexity, "complexity defaults like the CLI")
+	assert.Equal(t, knotv1.TaskPriority_TASK_PRIORITY_MEDIUM, first.Priority)
+	second, err := client.CreateTask(ctx, &knotv1.CreateTaskRequest{ProjectId: project.Id, Title: "Second", Complexity: 2})
+	require.NoError(t, err)
+
+	second, err = client.AddDependency(ctx, &knotv1.AddDependencyRequest{TaskId: second.Id, DependsOnTaskId: first.Id})
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.Id}, second.Dependencies)
+
+	next, err := client.NextTask(ctx, &knotv1.NextTaskRequest{ProjectId: project.Id, Strategy: "creation-order"})
+	require.NoError(t, err)
+	require.NotNil(t, next.Task)
+	assert.Equal(t, first.Id, next.Task.Id, "the blocked task must not be selected")
+	assert.Equal(t, "creation-order", next.Strategy)
+
+	_, err = client.NextTask(ctx, &knotv1.NextTaskRequest{ProjectId: project.Id, Strategy: "no-such-strategy"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	updated, err := client.UpdateTaskState(ctx, &knotv1.UpdateTaskStateRequest{TaskId: first.Id, State: knotv1.TaskState_TASK_STATE_IN_PROGRESS})
+	require.NoError(t, err)
+	assert.Equal(t, knotv1.TaskState_TASK_STATE_IN_PROGRESS, updated.State)
+
+	pending, err := client.ListTasks(ctx, &knotv1.ListTasksRequest{ProjectId: project.Id, State: knotv1.TaskState_TASK_STATE_PENDING})
+	require.NoError(t, err)
+	require.Len(t, pending.Tasks, 1)
+	assert.Equal(t, second.Id, pending.Tasks[0].Id)
+
+	progress, err := client.GetProjectProgress(ctx, &knotv1.GetProjectProgressRequest{ProjectId: project.Id})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, progress.TotalTasks)
+	assert.EqualValues(t, 1, progress.InProgressTasks)
+
+	t.Run("errors map to status codes", func(t *testing.T) {
+		_, err := client.GetTask(ctx, &knotv1.GetTaskRequest{TaskId: "not-a-uuid"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		_, err = client.GetTask(ctx, &knotv1.GetTaskRequest{TaskId: "01890000-0000-7000-8000-000000000000"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = client.UpdateTaskState(ctx, &knotv1.UpdateTaskStateRequest{TaskId: first.Id})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("watch events streams the change feed", func(t *testing.T) {
+		watchCtx, stopWatch := context.WithCancel(ctx)
+		defer stopWatch()
+		stream, err := client.WatchEvents(watchCtx, &knotv1.WatchEventsRequest{ProjectId: project.Id})
+		require.NoError(t, err)
+
+		event, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, knotv1.ChangeEvent_KIND_PROJECT_CREATED, event.Kind)
+		assert.Equal(t, "Plugin", event.Project.GetTitle())
+		lastSeq := event.Seq
+
+		// The recorded history comes first, then changes made while watching
+		_, err = client.CreateTask(ctx, &knotv1.CreateTaskRequest{ProjectId: project.Id, Title: "Live"})
+		require.NoError(t, err)
+		for {
+			event, err = stream.Recv()
+			require.NoError(t, err)
+			assert.Greater(t, event.Seq, lastSeq)
+			lastSeq = event.Seq
+			if event.Kind == knotv1.ChangeEvent_KIND_TASK_CREATED && event.Task.GetTitle() == "Live" {
+				break
+			}
+		}
+	})
+}
+
+func TestServerAuth(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	pm := manager.NewManager(manager.DefaultConfig())
+	project, err := pm.CreateProject(ctx, "Shared", "", "admin")
+	require.NoError(t, err)
+	other, err := pm.CreateProject(ctx, "Private", "", "admin")
+	require.NoError(t, err)
+	task, err := pm.CreateTask(ctx, project.ID, nil, "Task", "", 3, types.TaskPriorityMedium, "admin")
+	require.NoError(t, err)
+
+	_, viewerToken, err := pm.CreateUser(ctx, "victor", types.RoleNone)
+	require.NoError(t, err)
+	_, err = pm.SetUserRole(ctx, "victor", &project.ID, types.RoleViewer)
+	require.NoError(t, err)
+	_, editorToken, err := pm.CreateUser(ctx, "erin", types.RoleNone)
+	require.NoError(t, err)
+	_, err = pm.SetUserRole(ctx, "erin", &project.ID, types.RoleEditor)
+	require.NoError(t, err)
+
+	client := setupServer(t, pm, &remote.Auth{AdminToken: "secret", Users: pm.AuthenticateUser})
+
+	_, err = client.ListProjects(ctx, &knotv1.ListProjectsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.ListProjects(withToken(ctx, "wrong"), &knotv1.ListProjectsRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	projects, err := client.ListProjects(withToken(ctx, viewerToken), &knotv1.ListProjectsRequest{})
+	require.NoError(t, err)
+	require.Len(t, projects.Projects, 1, "viewers only see their projects")
+	assert.Equal(t, project.ID.String(), projects.Projects[0].Id)
+
+	_, err = client.GetProject(withToken(ctx, viewerToken), &knotv1.GetProjectRequest{ProjectId: other.ID.String()})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.UpdateTaskState(withToken(ctx, viewerToken), &knotv1.UpdateTaskStateRequest{
+		TaskId: task.ID.String(), State: knotv1.TaskState_TASK_STATE_IN_PROGRESS,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	created, err := client.CreateTask(withToken(ctx, editorToken), &knotv1.CreateTaskRequest{
+		ProjectId: project.ID.String(), Title: "By erin", Actor: "mallory",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "erin", created.CreatedBy, "user tokens act as their user")
+
+	_, err = client.DeleteTask(withToken(ctx, editorToken), &knotv1.DeleteTaskRequest{TaskId: created.Id})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "deleting needs the admin role")
+	_, err = client.DeleteTask(withToken(ctx, "secret"), &knotv1.DeleteTaskRequest{TaskId: created.Id})
+	require.NoError(t, err)
+
+	t.Run("event streams skip projects the user may not view", func(t *testing.T) {
+		watchCtx, stopWatch := context.WithCancel(withToken(ctx, viewerToken))
+		defer stopWatch()
+		stream, err := client.WatchEvents(watchCtx, &knotv1.WatchEventsRequest{})
+		require.NoError(t, err)
+
+		_, err = pm.CreateTask(ctx, other.ID, nil, "Hidden", "", 3, types.TaskPriorityMedium, "admin")
+		require.NoError(t, err)
+		_, err = pm.CreateTask(ctx, project.ID, nil, "Visible", "", 3, types.TaskPriorityMedium, "admin")
+		require.NoError(t, err)
+		for {
+			event, err := stream.Recv()
+			require.NoError(t, err)
+			assert.Equal(t, project.ID.String(), event.ProjectId)
+			if event.Task.GetTitle() == "Visible" {
+				break
+			}
+		}
+
+		denied, err := client.WatchEvents(watchCtx, &knotv1.WatchEventsRequest{ProjectId: other.ID.String()})
+		require.NoError(t, err)
+		_, err = denied.Recv()
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+}
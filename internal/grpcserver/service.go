@@ -0,0 +1,491 @@
+package grpcserver
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+	"time"
+
+	knotv1 "github.com/denkhaus/knot/v2/api/proto/knot/v1"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/repository/remote"
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/denkhaus/knot/v2/internal/selection"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultComplexity is used for new tasks that do not set a complexity, as in
+// 'knot task create' and the Go SDK
+const defaultComplexity = 5
+
+// eventBatchSize limits how many events are read from the feed per query
+const eventBatchSize = 500
+
+// service implements the KnotService RPCs with the project manager. Every
+// call checks the role of the caller like the HTTP server: viewers read,
+// editors create and change, admins also delete.
+type service struct {
+	knotv1.UnimplementedKnotServiceServer
+
+	pm           manager.ProjectManager
+	actor        string
+	timeout      time.Duration
+	pollInterval time.Duration
+	logger       *zap.Logger
+	// done is closed when the server shuts down, ending event streams
+	done <-chan struct{}
+}
+
+// writer returns the actor a change is recorded under and ctx with the actor
+// as writer, so writes to projects locked by another actor are rejected.
+// Callers with a user token always act as their user.
+func (s *service) writer(ctx context.Context, requested string) (context.Context, string) {
+	fallback := requested
+	if fallback == "" {
+		fallback = s.actor
+	}
+	actor := callerFrom(ctx).Actor(fallback)
+	return manager.WithWriter(ctx, actor, false), actor
+}
+
+// requireRole checks that the caller has role on the project
+func requireRole(ctx context.Context, projectID uuid.UUID, role types.Role, operation string) error {
+	if err := callerFrom(ctx).Require(&projectID, role, operation); err != nil {
+		return toStatus(err)
+	}
+	return nil
+}
+
+// loadTask returns the task a request field refers to, if the caller has role
+// on its project
+func (s *service) loadTask(ctx context.Context, field, value string, role types.Role, operation string) (*types.Task, error) {
+	id, err := parseID(field, value)
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.pm.GetTask(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := requireRole(ctx, task.ProjectID, role, operation); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (s *service) CreateProject(ctx context.Context, req *knotv1.CreateProjectRequest) (*knotv1.Project, error) {
+	if err := callerFrom(ctx).Require(nil, types.RoleEditor, "CreateProject"); err != nil {
+		return nil, toStatus(err)
+	}
+	ctx, actor := s.writer(ctx, req.GetActor())
+	project, err := s.pm.CreateProject(ctx, req.GetTitle(), req.GetDescription(), actor)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProject(project), nil
+}
+
+func (s *service) GetProject(ctx context.Context, req *knotv1.GetProjectRequest) (*knotv1.Project, error) {
+	projectID, err := parseID("project_id", req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, projectID, types.RoleViewer, "GetProject"); err != nil {
+		return nil, err
+	}
+	project, err := s.pm.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProject(project), nil
+}
+
+func (s *service) ListProjects(ctx context.Context, _ *knotv1.ListProjectsRequest) (*knotv1.ListProjectsResponse, error) {
+	projects, err := s.pm.ListProjects(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	caller := callerFrom(ctx)
+	resp := &knotv1.ListProjectsResponse{}
+	for _, project := range projects {
+		if caller.CanView(project.ID) {
+			resp.Projects = append(resp.Projects, toProject(project))
+		}
+	}
+	return resp, nil
+}
+
+func (s *service) DeleteProject(ctx context.Context, req *knotv1.DeleteProjectRequest) (*knotv1.DeleteProjectResponse, error) {
+	projectID, err := parseID("project_id", req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, projectID, types.RoleAdmin, "DeleteProject"); err != nil {
+		return nil, err
+	}
+	ctx, _ = s.writer(ctx, req.GetActor())
+	if err := s.pm.DeleteProject(ctx, projectID); err != nil {
+		return nil, toStatus(err)
+	}
+	return &knotv1.DeleteProjectResponse{}, nil
+}
+
+func (s *service) GetProjectProgress(ctx context.Context, req *knotv1.GetProjectProgressRequest) (*knotv1.ProjectProgress, error) {
+	projectID, err := parseID("project_id", req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, projectID, types.RoleViewer, "GetProjectProgress"); err != nil {
+		return nil, err
+	}
+	progress, err := s.pm.GetProjectProgress(ctx, projectID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProgress(progress), nil
+}
+
+func (s *service) CreateTask(ctx context.Context, req *knotv1.CreateTaskRequest) (*knotv1.Task, error) {
+	projectID, err := parseID("project_id", req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := parseOptionalID("parent_id", req.GetParentId())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, projectID, types.RoleEditor, "CreateTask"); err != nil {
+		return nil, err
+	}
+
+	complexity := int(req.GetComplexity())
+	if complexity == 0 {
+		complexity = defaultComplexity
+	}
+	priority := types.TaskPriority(req.GetPriority())
+	if priority == 0 {
+		priority = types.TaskPriorityMedium
+	}
+	ctx, actor := s.writer(ctx, req.GetActor())
+	task, err := s.pm.CreateTask(ctx, projectID, parentID, req.GetTitle(), req.GetDescription(), complexity, priority, actor)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTask(task), nil
+}
+
+func (s *service) GetTask(ctx context.Context, req *knotv1.GetTaskRequest) (*knotv1.Task, error) {
+	task, err := s.loadTask(ctx, "task_id", req.GetTaskId(), types.RoleViewer, "GetTask")
+	if err != nil {
+		return nil, err
+	}
+	return toTask(task), nil
+}
+
+func (s *service) ListTasks(ctx context.Context, req *knotv1.ListTasksRequest) (*knotv1.ListTasksResponse, error) {
+	var tasks []*types.Task
+	if req.GetParentId() != "" {
+		parent, err := s.loadTask(ctx, "parent_id", req.GetParentId(), types.RoleViewer, "ListTasks")
+		if err != nil {
+			return nil, err
+		}
+		if req.GetProjectId() != "" && req.GetProjectId() != parent.ProjectID.String() {
+			return nil, status.Errorf(codes.InvalidArgument, "task %s is not in project %s", parent.ID, req.GetProjectId())
+		}
+		if tasks, err = s.pm.GetChildTasks(ctx, parent.ID); err != nil {
+			return nil, toStatus(err)
+		}
+	} else {
+		projectID, err := parseID("project_id", req.GetProjectId())
+		if err != nil {
+			return nil, err
+		}
+		if err := requireRole(ctx, projectID, types.RoleViewer, "ListTasks"); err != nil {
+			return nil, err
+		}
+		if tasks, err = s.pm.ListTasksForProject(ctx, projectID); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+
+	if req.GetState() != knotv1.TaskState_TASK_STATE_UNSPECIFIED {
+		state, err := fromTaskState(req.GetState())
+		if err != nil {
+			return nil, err
+		}
+		filtered := tasks[:0]
+		for _, task := range tasks {
+			if task.State == state {
+				filtered = append(filtered, task)
+			}
+		}
+		tasks = filtered
+	}
+	return &knotv1.ListTasksResponse{Tasks: toTasks(tasks)}, nil
+}
+
+func (s *service) UpdateTaskState(ctx context.Context, req *knotv1.UpdateTaskStateRequest) (*knotv1.Task, error) {
+	state, err := fromTaskState(req.GetState())
+	if err != nil {
+		return nil, err
+	}
+	task, err := s.loadTask(ctx, "task_id", req.GetTaskId(), types.RoleEditor, "UpdateTaskState")
+	if err != nil {
+		return nil, err
+	}
+	ctx, actor := s.writer(ctx, req.GetActor())
+	updated, err := s.pm.UpdateTaskState(ctx, task.ID, state, actor)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTask(updated), nil
+}
+
+func (s *service) DeleteTask(ctx context.Context, req *knotv1.DeleteTaskRequest) (*knotv1.DeleteTaskResponse, error) {
+	task, err := s.loadTask(ctx, "task_id", req.GetTaskId(), types.RoleAdmin, "DeleteTask")
+	if err != nil {
+		return nil, err
+	}
+	ctx, actor := s.writer(ctx, req.GetActor())
+	if err := s.pm.DeleteTask(ctx, task.ID, actor); err != nil {
+		return nil, toStatus(err)
+	}
+	return &knotv1.DeleteTaskResponse{}, nil
+}
+
+func (s *service) AddDependency(ctx context.Context, req *knotv1.AddDependencyRequest) (*knotv1.Task, error) {
+	task, err := s.loadTask(ctx, "task_id", req.GetTaskId(), types.RoleEditor, "AddDependency")
+	if err != nil {
+		return nil, err
+	}
+	// The prerequisite may live in another project, which the caller must be
+	// allowed to change as well
+	dependsOn, err := s.loadTask(ctx, "depends_on_task_id", req.GetDependsOnTaskId(), types.RoleEditor, "AddDependency")
+	if err != nil {
+		return nil, err
+	}
+	ctx, actor := s.writer(ctx, req.GetActor())
+	updated, err := s.pm.AddTaskDependency(ctx, task.ID, dependsOn.ID, actor)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTask(updated), nil
+}
+
+func (s *service) RemoveDependency(ctx context.Context, req *knotv1.RemoveDependencyRequest) (*knotv1.Task, error) {
+	task, err := s.loadTask(ctx, "task_id", req.GetTaskId(), types.RoleEditor, "RemoveDependency")
+	if err != nil {
+		return nil, err
+	}
+	dependsOnID, err := parseID("depends_on_task_id", req.GetDependsOnTaskId())
+	if err != nil {
+		return nil, err
+	}
+	ctx, actor := s.writer(ctx, req.GetActor())
+	updated, err := s.pm.RemoveTaskDependency(ctx, task.ID, dependsOnID, actor)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toTask(updated), nil
+}
+
+func (s *service) NextTask(ctx context.Context, req *knotv1.NextTaskRequest) (*knotv1.NextTaskResponse, error) {
+	projectID, err := parseID("project_id", req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, projectID, types.RoleViewer, "NextTask"); err != nil {
+		return nil, err
+	}
+	tasks, err := s.pm.ListTasksWithDependencies(ctx, projectID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	strategy, err := s.strategy(req.GetStrategy(), tasks)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := selection.NewTaskSelector(strategy, s.pm.GetConfig().SelectionConfig(strategy))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create task selector: %v", err)
+	}
+
+	resp := &knotv1.NextTaskResponse{Strategy: strategy.String()}
+	task, err := selector.SelectNextActionableTask(tasks)
+	if err != nil {
+		var selErr *selection.SelectionError
+		if !stderrors.As(err, &selErr) {
+			return nil, toStatus(err)
+		}
+		switch selErr.Type {
+		case selection.ErrorTypeNoTasks, selection.ErrorTypeNoActionable, selection.ErrorTypeDeadlock:
+			resp.Reason = selErr.Message
+			return resp, nil
+		case selection.ErrorTypeCircularDep:
+			return nil, status.Errorf(codes.FailedPrecondition, "circular dependencies detected: %s", selErr.Message)
+		default:
+			return nil, status.Errorf(codes.Internal, "task selection failed: %v", err)
+		}
+	}
+
+	resp.Task = toTask(task)
+	if result := selector.GetLastResult(); result != nil {
+		resp.Reason = result.Reason
+	}
+	return resp, nil
+}
+
+// strategy returns the requested selection strategy, or like 'knot
+// actionable' the configured one or the one recommended for the tasks
+func (s *service) strategy(name string, tasks []*types.Task) (selection.Strategy, error) {
+	if name != "" {
+		strategy, ok := selection.LookupStrategy(name)
+		if !ok {
+			return 0, status.Errorf(codes.InvalidArgument, "unknown selection strategy %q", name)
+		}
+		return strategy, nil
+	}
+	if configured := s.pm.GetConfig().SelectionStrategy; configured != "" {
+		return selection.ParseStrategy(configured), nil
+	}
+	strategy, _, err := selection.AnalyzeProjectAndRecommendStrategy(tasks)
+	if err != nil {
+		s.logger.Warn("Failed to recommend a selection strategy, using dependency-aware", zap.Error(err))
+		return selection.StrategyDependencyAware, nil
+	}
+	return strategy, nil
+}
+
+func (s *service) WatchEvents(req *knotv1.WatchEventsRequest, stream knotv1.KnotService_WatchEventsServer) error {
+	ctx := stream.Context()
+	filter := types.ChangeEventFilter{AfterSeq: req.GetSinceSeq(), Limit: eventBatchSize}
+	projectID, err := parseOptionalID("project_id", req.GetProjectId())
+	if err != nil {
+		return err
+	}
+	if projectID != nil {
+		if err := requireRole(ctx, *projectID, types.RoleViewer, "WatchEvents"); err != nil {
+			return err
+		}
+		filter.ProjectID = projectID
+	}
+
+	for {
+		if err := s.sendEvents(ctx, stream, &filter); err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.done:
+			return status.Error(codes.Unavailable, "knot server is shutting down")
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+// sendEvents sends all events matching the filter that the caller may view,
+// advancing the filter past them
+func (s *service) sendEvents(ctx context.Context, stream knotv1.KnotService_WatchEventsServer, filter *types.ChangeEventFilter) error {
+	caller := callerFrom(ctx)
+	for {
+		events, err := s.pollEvents(ctx, *filter)
+		if err != nil {
+			return toStatus(err)
+		}
+		for _, event := range events {
+			filter.AfterSeq = event.Seq
+			if !caller.CanView(event.ProjectID) {
+				continue
+			}
+			if err := stream.Send(toEvent(event)); err != nil {
+				return err
+			}
+		}
+		if len(events) < filter.Limit {
+			return nil
+		}
+	}
+}
+
+// pollEvents reads one batch of events, bounded by the timeout if one is set
+func (s *service) pollEvents(ctx context.Context, filter types.ChangeEventFilter) ([]*types.ChangeEvent, error) {
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+	return s.pm.ListChangeEvents(ctx, filter)
+}
+
+// Message fragments used to classify errors that carry no type information,
+// as for the exit codes of the CLI
+var (
+	notFoundPatterns = []string{"not found", "does not exist"}
+	conflictPatterns = []string{
+		"state transition", "circular dependency", "cycle", "already exists",
+		"marked for deletion", "cannot block task", "cannot start", "project locked by",
+	}
+)
+
+// toStatus maps an error of the manager to a gRPC status
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var permErr *remote.PermissionError
+	if stderrors.As(err, &permErr) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if stderrors.Is(err, context.DeadlineExceeded) || stderrors.Is(err, context.Canceled) {
+		return status.FromContextError(err).Err()
+	}
+
+	var repoErr *sqlite.RepositoryError
+	if stderrors.As(err, &repoErr) {
+		switch repoErr.Type {
+		case sqlite.ErrorTypeNotFound:
+			return status.Error(codes.NotFound, err.Error())
+		case sqlite.ErrorTypeConstraintViolation, sqlite.ErrorTypeCircularDependency:
+			return status.Error(codes.FailedPrecondition, err.Error())
+		case sqlite.ErrorTypeMaxDepthExceeded, sqlite.ErrorTypeMaxTasksExceeded, sqlite.ErrorTypeValidationError:
+			return status.Error(codes.InvalidArgument, err.Error())
+		default:
+			return status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case containsAny(msg, notFoundPatterns):
+		return status.Error(codes.NotFound, err.Error())
+	case containsAny(msg, conflictPatterns):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	var enhancedErr *errors.EnhancedError
+	if stderrors.As(err, &enhancedErr) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+func containsAny(s string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.Contains(s, pattern) {
+			return true
+		}
+	}
+	return false
+}
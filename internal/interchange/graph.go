@@ -0,0 +1,322 @@
+package interchange
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// GraphVersion is the schema version of the dependency graph document. It is
+// increased whenever a field is removed or changes its meaning.
+const GraphVersion = 1
+
+// minIDPartLength is the number of characters from which a task reference is
+// matched as the prefix or suffix of a task ID
+const minIDPartLength = 4
+
+// DependencyGraph is the dependency structure of a project without its tasks,
+// so it can be edited in or generated by other tools and applied to the
+// existing tasks of a project
+type DependencyGraph struct {
+	Version   int       `json:"version"`
+	ProjectID uuid.UUID `json:"project_id"`
+	// Tasks names the tasks referenced by the dependencies, for reading them
+	Tasks        []GraphTask `json:"tasks,omitempty"`
+	Dependencies []GraphEdge `json:"dependencies"`
+}
+
+// GraphTask names a task of the dependency graph
+type GraphTask struct {
+	ID    uuid.UUID `json:"id"`
+	Title string    `json:"title"`
+}
+
+// GraphEdge makes Task depend on DependsOn. Both reference a task by ID, by a
+// unique ID prefix or suffix such as the short ID of compact listings, or by
+// title.
+type GraphEdge struct {
+	Task      string               `json:"task"`
+	DependsOn string               `json:"depends_on"`
+	Type      types.DependencyType `json:"type,omitempty"` // finish-to-start if empty
+	Lag       int64                `json:"lag,omitempty"`  // minutes
+}
+
+// BuildDependencyGraph collects the dependencies between the given tasks of a
+// project, referencing the tasks by ID
+func BuildDependencyGraph(projectID uuid.UUID, tasks []*types.Task) *DependencyGraph {
+	graph := &DependencyGraph{Version: GraphVersion, ProjectID: projectID, Dependencies: []GraphEdge{}}
+	taskMap := make(map[uuid.UUID]*types.Task, len(tasks))
+	for _, task := range tasks {
+		taskMap[task.ID] = task
+	}
+
+	referenced := make(map[uuid.UUID]bool)
+	for _, task := range tasks {
+		for _, depID := range task.Dependencies {
+			if _, ok := taskMap[depID]; !ok {
+				continue
+			}
+			link := task.DependencyLink(depID)
+			edge := GraphEdge{Task: task.ID.String(), DependsOn: depID.String(), Lag: link.Lag}
+			if link.Type != types.DependencyFinishToStart {
+				edge.Type = link.Type
+			}
+			graph.Dependencies = append(graph.Dependencies, edge)
+			referenced[task.ID] = true
+			referenced[depID] = true
+		}
+	}
+
+	for _, task := range tasks {
+		if referenced[task.ID] {
+			graph.Tasks = append(graph.Tasks, GraphTask{ID: task.ID, Title: task.Title})
+		}
+	}
+	return graph
+}
+
+// RenderGraphJSON writes the dependency graph as indented JSON
+func RenderGraphJSON(w io.Writer, graph *DependencyGraph) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(graph)
+}
+
+// RenderGraphDOT writes the dependency graph in the Graphviz DOT language,
+// with an arrow from every dependency to the task depending on it. Edges
+// other than finish-to-start without lag are labeled.
+func RenderGraphDOT(w io.Writer, graph *DependencyGraph) error {
+	var b strings.Builder
+	b.WriteString("digraph dependencies {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, task := range graph.Tasks {
+		fmt.Fprintf(&b, "  %s [label=%s];\n", dotQuote(task.ID.String()), dotQuote(task.Title))
+	}
+	for _, edge := range graph.Dependencies {
+		fmt.Fprintf(&b, "  %s -> %s", dotQuote(edge.DependsOn), dotQuote(edge.Task))
+		if label := edgeLabel(edge); label != "" {
+			fmt.Fprintf(&b, " [label=%s]", dotQuote(label))
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// edgeLabel describes the type and lag of an edge, empty for a plain
+// finish-to-start edge
+func edgeLabel(edge GraphEdge) string {
+	var parts []string
+	if edge.Type != "" && edge.Type != types.DependencyFinishToStart {
+		parts = append(parts, string(edge.Type))
+	}
+	if edge.Lag > 0 {
+		parts = append(parts, fmt.Sprintf("+%dm", edge.Lag))
+	}
+	return strings.Join(parts, " ")
+}
+
+// dotQuote quotes s as a DOT identifier
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+// ParseGraph reads a dependency graph document as written by RenderGraphJSON.
+// Only the dependencies are required, unknown fields are rejected.
+func ParseGraph(r io.Reader) (*DependencyGraph, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	var graph DependencyGraph
+	if err := decoder.Decode(&graph); err != nil {
+		return nil, fmt.Errorf("invalid dependency graph: %w", err)
+	}
+	if graph.Version > GraphVersion {
+		return nil, fmt.Errorf("dependency graph version %d is newer than the supported version %d", graph.Version, GraphVersion)
+	}
+
+	var errs []error
+	for i, edge := range graph.Dependencies {
+		switch {
+		case strings.TrimSpace(edge.Task) == "":
+			errs = append(errs, fmt.Errorf("dependencies[%d]: task is required", i))
+		case strings.TrimSpace(edge.DependsOn) == "":
+			errs = append(errs, fmt.Errorf("dependencies[%d]: depends_on is required", i))
+		case edge.Type != "" && !edge.Type.IsValid():
+			errs = append(errs, fmt.Errorf("dependencies[%d]: invalid type %q, must be %s or %s", i, edge.Type, types.DependencyFinishToStart, types.DependencyStartToStart))
+		case edge.Lag < 0:
+			errs = append(errs, fmt.Errorf("dependencies[%d]: lag must not be negative, got %d", i, edge.Lag))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, stderrors.Join(errs...)
+	}
+	return &graph, nil
+}
+
+// ResolvedEdge is a dependency of a graph resolved to the tasks of a project
+type ResolvedEdge struct {
+	Task      *types.Task
+	DependsOn *types.Task
+	Link      types.DependencyLink
+}
+
+// ResolveGraph resolves the task references of the dependencies to tasks.
+// It reports every reference that matches no task or more than one.
+func ResolveGraph(graph *DependencyGraph, tasks []*types.Task) ([]ResolvedEdge, error) {
+	var edges []ResolvedEdge
+	var errs []error
+	for i, edge := range graph.Dependencies {
+		task, err := ResolveTaskRef(edge.Task, tasks)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("dependencies[%d]: task: %w", i, err))
+		}
+		dependsOn, depErr := ResolveTaskRef(edge.DependsOn, tasks)
+		if depErr != nil {
+			errs = append(errs, fmt.Errorf("dependencies[%d]: depends_on: %w", i, depErr))
+		}
+		if err != nil || depErr != nil {
+			continue
+		}
+		if task.ID == dependsOn.ID {
+			errs = append(errs, fmt.Errorf("dependencies[%d]: task '%s' cannot depend on itself", i, task.Title))
+			continue
+		}
+
+		link := types.DependencyLink{DependsOnID: dependsOn.ID, Type: edge.Type, Lag: edge.Lag}
+		if link.Type == "" {
+			link.Type = types.DependencyFinishToStart
+		}
+		edges = append(edges, ResolvedEdge{Task: task, DependsOn: dependsOn, Link: link})
+	}
+	if len(errs) > 0 {
+		return nil, stderrors.Join(errs...)
+	}
+	return edges, nil
+}
+
+// ResolveTaskRef finds the task a reference names: by ID, by exact title, by
+// ID prefix or suffix such as a short ID, or by title ignoring case, in this
+// order. A reference matching several tasks is rejected with their IDs.
+func ResolveTaskRef(ref string, tasks []*types.Task) (*types.Task, error) {
+	ref = strings.TrimSpace(ref)
+	if id, err := uuid.Parse(ref); err == nil {
+		for _, task := range tasks {
+			if task.ID == id {
+				return task, nil
+			}
+		}
+		return nil, fmt.Errorf("no task with ID %s in the project", id)
+	}
+
+	matchers := []func(*types.Task) bool{
+		func(task *types.Task) bool { return task.Title == ref },
+		func(task *types.Task) bool {
+			id, part := task.ID.String(), strings.ToLower(ref)
+			return len(part) >= minIDPartLength && (strings.HasPrefix(id, part) || strings.HasSuffix(id, part))
+		},
+		func(task *types.Task) bool { return strings.EqualFold(task.Title, ref) },
+	}
+	for _, matches := range matchers {
+		var found []*types.Task
+		for _, task := range tasks {
+			if matches(task) {
+				found = append(found, task)
+			}
+		}
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], nil
+		default:
+			ids := make([]string, len(found))
+			for i, task := range found {
+				ids[i] = task.ID.String()
+			}
+			return nil, fmt.Errorf("'%s' matches %d tasks, use one of their IDs: %s", ref, len(found), strings.Join(ids, ", "))
+		}
+	}
+	return nil, fmt.Errorf("no task with title or ID '%s' in the project", ref)
+}
+
+// GraphChanges are the changes applying a dependency graph makes to a project
+type GraphChanges struct {
+	// Add are the dependencies that do not exist yet
+	Add []ResolvedEdge
+	// Update are existing dependencies whose type or lag changes
+	Update []ResolvedEdge
+	// Remove are the existing dependencies missing from the graph, only
+	// collected when replacing the dependencies of the project
+	Remove []ResolvedEdge
+	// Unchanged is the number of dependencies that already exist as given
+	Unchanged int
+}
+
+// IsEmpty reports whether applying the graph changes nothing
+func (c *GraphChanges) IsEmpty() bool {
+	return len(c.Add) == 0 && len(c.Update) == 0 && len(c.Remove) == 0
+}
+
+// PlanGraphChanges compares resolved dependencies with the existing ones of
+// the tasks. With replace the existing dependencies between the tasks that
+// are not in edges are removed. An edge given twice is applied once, with the
+// type and lag given last.
+func PlanGraphChanges(edges []ResolvedEdge, tasks []*types.Task, replace bool) *GraphChanges {
+	type key struct{ task, dependsOn uuid.UUID }
+	wanted := make(map[key]int, len(edges))
+	var unique []ResolvedEdge
+	for _, edge := range edges {
+		k := key{edge.Task.ID, edge.DependsOn.ID}
+		if i, ok := wanted[k]; ok {
+			unique[i] = edge
+			continue
+		}
+		wanted[k] = len(unique)
+		unique = append(unique, edge)
+	}
+
+	changes := &GraphChanges{}
+	for _, edge := range unique {
+		task := edge.Task
+		exists := false
+		for _, depID := range task.Dependencies {
+			if depID == edge.DependsOn.ID {
+				exists = true
+				break
+			}
+		}
+		switch {
+		case !exists:
+			changes.Add = append(changes.Add, edge)
+		case task.DependencyLink(edge.DependsOn.ID) != edge.Link:
+			changes.Update = append(changes.Update, edge)
+		default:
+			changes.Unchanged++
+		}
+	}
+
+	if replace {
+		taskMap := make(map[uuid.UUID]*types.Task, len(tasks))
+		for _, task := range tasks {
+			taskMap[task.ID] = task
+		}
+		for _, task := range tasks {
+			for _, depID := range task.Dependencies {
+				dependsOn, ok := taskMap[depID]
+				if _, keep := wanted[key{task.ID, depID}]; keep || !ok {
+					continue
+				}
+				changes.Remove = append(changes.Remove, ResolvedEdge{Task: task, DependsOn: dependsOn, Link: task.DependencyLink(depID)})
+			}
+		}
+	}
+	return changes
+}
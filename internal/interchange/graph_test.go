@@ -0,0 +1,148 @@
+package interchange
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// graphTasks returns tasks A, B and C where B depends on A and C on B with a
+// start-to-start link
+func graphTasks() []*types.Task {
+	newTask := func(id, title string) *types.Task {
+		return &types.Task{ID: uuid.MustParse(id), Title: title}
+	}
+	a := newTask("01a14384-0000-7000-8000-00000000000a", "Design API")
+	b := newTask("01a14384-0000-7000-8000-00000000000b", "Implement API")
+	c := newTask("01a14385-0000-7000-8000-00000000000c", "Write docs")
+	b.Dependencies = []uuid.UUID{a.ID}
+	c.Dependencies = []uuid.UUID{b.ID}
+	c.DependencyLinks = []types.DependencyLink{{DependsOnID: b.ID, Type: types.DependencyStartToStart, Lag: 30}}
+	return []*types.Task{a, b, c}
+}
+
+func TestDependencyGraphRoundTrip(t *testing.T) {
+	tasks := graphTasks()
+	graph := BuildDependencyGraph(uuid.New(), tasks)
+	require.Len(t, graph.Dependencies, 2)
+	assert.Len(t, graph.Tasks, 3)
+	assert.Equal(t, GraphEdge{Task: tasks[1].ID.String(), DependsOn: tasks[0].ID.String()}, graph.Dependencies[0])
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderGraphJSON(&buf, graph))
+	parsed, err := ParseGraph(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, graph, parsed)
+
+	edges, err := ResolveGraph(parsed, tasks)
+	require.NoError(t, err)
+	changes := PlanGraphChanges(edges, tasks, true)
+	assert.True(t, changes.IsEmpty())
+	assert.Equal(t, 2, changes.Unchanged)
+}
+
+func TestRenderGraphDOT(t *testing.T) {
+	tasks := graphTasks()
+	tasks[0].Title = `Design "v2" API`
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderGraphDOT(&buf, BuildDependencyGraph(uuid.New(), tasks)))
+	dot := buf.String()
+	assert.True(t, strings.HasPrefix(dot, "digraph dependencies {\n"))
+	assert.Contains(t, dot, `[label="Design \"v2\" API"]`)
+	assert.Contains(t, dot, `"`+tasks[0].ID.String()+`" -> "`+tasks[1].ID.String()+`";`)
+	assert.Contains(t, dot, `"`+tasks[1].ID.String()+`" -> "`+tasks[2].ID.String()+`" [label="start-to-start +30m"];`)
+}
+
+func TestParseGraphRejectsInvalidEdges(t *testing.T) {
+	_, err := ParseGraph(strings.NewReader(`{"dependencies": [
+		{"task": "", "depends_on": "A"},
+		{"task": "B", "depends_on": "A", "type": "finish-to-finish"},
+		{"task": "B", "depends_on": "A", "lag": -5}
+	]}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dependencies[0]: task is required")
+	assert.Contains(t, err.Error(), "dependencies[1]: invalid type")
+	assert.Contains(t, err.Error(), "dependencies[2]: lag must not be negative")
+
+	_, err = ParseGraph(strings.NewReader(`{"edges": []}`))
+	assert.ErrorContains(t, err, "unknown field")
+	_, err = ParseGraph(strings.NewReader(`{"version": 2, "dependencies": []}`))
+	assert.ErrorContains(t, err, "newer than the supported version")
+}
+
+func TestResolveGraphReferences(t *testing.T) {
+	tasks := graphTasks()
+	graph := &DependencyGraph{Dependencies: []GraphEdge{
+		{Task: "Write docs", DependsOn: "Design API"},              // titles
+		{Task: "write DOCS", DependsOn: "01a14384-0000-7000-8000"}, // title ignoring case, ambiguous prefix
+		{Task: "01a14385", DependsOn: "Missing"},                   // unique prefix, unknown title
+		{Task: "Design API", DependsOn: "design api"},              // itself
+	}}
+
+	_, err := ResolveGraph(graph, tasks)
+	require.Error(t, err)
+	msg := err.Error()
+	assert.NotContains(t, msg, "dependencies[0]")
+	assert.Contains(t, msg, "dependencies[1]: depends_on: '01a14384-0000-7000-8000' matches 2 tasks")
+	assert.NotContains(t, msg, "dependencies[2]: task")
+	assert.Contains(t, msg, "dependencies[2]: depends_on: no task with title or ID 'Missing'")
+	assert.Contains(t, msg, "dependencies[3]: task 'Design API' cannot depend on itself")
+
+	graph.Dependencies = graph.Dependencies[:1]
+	edges, err := ResolveGraph(graph, tasks)
+	require.NoError(t, err)
+	require.Len(t, edges, 1)
+	assert.Equal(t, tasks[2], edges[0].Task)
+	assert.Equal(t, types.DependencyLink{DependsOnID: tasks[0].ID, Type: types.DependencyFinishToStart}, edges[0].Link)
+}
+
+func TestResolveTaskRefByShortID(t *testing.T) {
+	// Tasks created in the same millisecond share the leading characters of
+	// their IDs, so the short IDs are taken from the random tail
+	base := types.NewID()
+	var tasks []*types.Task
+	for i := 0; i < 5; i++ {
+		id := base
+		random := uuid.New()
+		copy(id[8:], random[8:])
+		tasks = append(tasks, &types.Task{ID: id, Title: "child"})
+	}
+
+	for _, task := range tasks {
+		found, err := ResolveTaskRef(types.ShortID(task.ID), tasks)
+		require.NoError(t, err)
+		assert.Equal(t, task, found)
+	}
+	_, err := ResolveTaskRef(base.String()[:8], tasks)
+	assert.ErrorContains(t, err, "matches 5 tasks")
+}
+
+func TestPlanGraphChanges(t *testing.T) {
+	tasks := graphTasks()
+	a, b, c := tasks[0], tasks[1], tasks[2]
+	edges := []ResolvedEdge{
+		{Task: c, DependsOn: a, Link: types.DependencyLink{DependsOnID: a.ID, Type: types.DependencyFinishToStart}},
+		{Task: c, DependsOn: b, Link: types.DependencyLink{DependsOnID: b.ID, Type: types.DependencyStartToStart, Lag: 10}},
+		// Given twice, the last one counts
+		{Task: c, DependsOn: b, Link: types.DependencyLink{DependsOnID: b.ID, Type: types.DependencyFinishToStart}},
+	}
+
+	changes := PlanGraphChanges(edges, tasks, false)
+	require.Len(t, changes.Add, 1)
+	assert.Equal(t, a, changes.Add[0].DependsOn)
+	require.Len(t, changes.Update, 1)
+	assert.True(t, changes.Update[0].Link.IsDefault())
+	assert.Empty(t, changes.Remove)
+	assert.Equal(t, 0, changes.Unchanged)
+
+	changes = PlanGraphChanges(edges, tasks, true)
+	require.Len(t, changes.Remove, 1)
+	assert.Equal(t, b, changes.Remove[0].Task)
+	assert.Equal(t, a, changes.Remove[0].DependsOn)
+}
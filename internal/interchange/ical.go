@@ -0,0 +1,247 @@
+package interchange
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// iCalendar components a task is exported as
+const (
+	ICalEvent = "event"
+	ICalTodo  = "todo"
+	ICalBoth  = "both"
+)
+
+// DefaultICalEndOfDay is the time of day a timed entry ends on its due date
+const DefaultICalEndOfDay = 17 * time.Hour
+
+// ICalOptions controls the iCalendar export
+type ICalOptions struct {
+	// CalendarName is shown by calendar applications, usually the project title
+	CalendarName string
+	// Component is ICalEvent, ICalTodo or ICalBoth
+	Component string
+	// EndOfDay is the time of day, as offset from midnight, at which tasks with
+	// an estimate end on their due date
+	EndOfDay time.Duration
+	// IncludeCompleted also exports completed tasks
+	IncludeCompleted bool
+	// Now is written as the time stamp of the entries
+	Now time.Time
+}
+
+// ParseICalComponent checks the name of an iCalendar component option
+func ParseICalComponent(name string) (string, error) {
+	switch name {
+	case ICalEvent, ICalTodo, ICalBoth:
+		return name, nil
+	default:
+		return "", fmt.Errorf("unknown component %q, use event, todo or both", name)
+	}
+}
+
+// SelectICalTasks returns the tasks with a due date that belong in a calendar,
+// ordered by due date. Cancelled tasks and tasks pending deletion are left
+// out, completed tasks unless opts.IncludeCompleted is set.
+func SelectICalTasks(tasks []*types.Task, opts ICalOptions) []*types.Task {
+	selected := make([]*types.Task, 0)
+	for _, task := range tasks {
+		if task.DueDate == nil {
+			continue
+		}
+		switch task.State {
+		case types.TaskStateCancelled, types.TaskStateDeletionPending:
+			continue
+		case types.TaskStateCompleted:
+			if !opts.IncludeCompleted {
+				continue
+			}
+		}
+		selected = append(selected, task)
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].DueDate.Before(*selected[j].DueDate)
+	})
+	return selected
+}
+
+// RenderICal writes tasks as an iCalendar (RFC 5545) document. A task without
+// an estimate becomes an all-day entry on its due date. A task with an
+// estimate becomes an entry of the estimated duration that ends at
+// opts.EndOfDay on the due date; estimates are working time, so "1d" lasts
+// eight hours.
+func RenderICal(w io.Writer, tasks []*types.Task, opts ICalOptions) error {
+	bw := bufio.NewWriter(w)
+	ical := &icalWriter{w: bw}
+
+	ical.line("BEGIN:VCALENDAR")
+	ical.line("VERSION:2.0")
+	ical.line("PRODID:-//knot//task export//EN")
+	ical.line("CALSCALE:GREGORIAN")
+	if opts.CalendarName != "" {
+		ical.line("X-WR-CALNAME:" + icalText(opts.CalendarName))
+	}
+
+	stamp := opts.Now.UTC().Format(icalDateTime)
+	for _, task := range tasks {
+		if task.DueDate == nil {
+			continue
+		}
+		if opts.Component == ICalEvent || opts.Component == ICalBoth {
+			ical.event(task, stamp, opts.EndOfDay)
+		}
+		if opts.Component == ICalTodo || opts.Component == ICalBoth {
+			ical.todo(task, stamp, opts.EndOfDay)
+		}
+	}
+
+	ical.line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+const (
+	icalDate     = "20060102"
+	icalDateTime = "20060102T150405Z"
+	// icalLineLimit is the maximum length of a content line in octets
+	icalLineLimit = 75
+)
+
+type icalWriter struct {
+	w *bufio.Writer
+}
+
+func (ical *icalWriter) event(task *types.Task, stamp string, endOfDay time.Duration) {
+	ical.line("BEGIN:VEVENT")
+	ical.line(fmt.Sprintf("UID:%s-event@knot", task.ID))
+	ical.line("DTSTAMP:" + stamp)
+	if start, duration, ok := estimatedSlot(task, endOfDay); ok {
+		ical.line("DTSTART:" + start.UTC().Format(icalDateTime))
+		ical.line("DURATION:" + icalDuration(duration))
+	} else {
+		ical.line("DTSTART;VALUE=DATE:" + task.DueDate.Format(icalDate))
+		ical.line("DTEND;VALUE=DATE:" + task.DueDate.AddDate(0, 0, 1).Format(icalDate))
+		ical.line("TRANSP:TRANSPARENT")
+	}
+	ical.details(task)
+	ical.line("END:VEVENT")
+}
+
+func (ical *icalWriter) todo(task *types.Task, stamp string, endOfDay time.Duration) {
+	ical.line("BEGIN:VTODO")
+	ical.line(fmt.Sprintf("UID:%s-todo@knot", task.ID))
+	ical.line("DTSTAMP:" + stamp)
+	// A to-do has either a DUE or a DTSTART with a DURATION
+	if start, duration, ok := estimatedSlot(task, endOfDay); ok {
+		ical.line("DTSTART:" + start.UTC().Format(icalDateTime))
+		ical.line("DURATION:" + icalDuration(duration))
+	} else {
+		ical.line("DUE;VALUE=DATE:" + task.DueDate.Format(icalDate))
+	}
+	ical.line("STATUS:" + icalTodoStatus(task.State))
+	if task.State == types.TaskStateCompleted && task.CompletedAt != nil {
+		ical.line("COMPLETED:" + task.CompletedAt.UTC().Format(icalDateTime))
+	}
+	ical.details(task)
+	ical.line("END:VTODO")
+}
+
+// details writes the properties shared by events and to-dos
+func (ical *icalWriter) details(task *types.Task) {
+	ical.line("SUMMARY:" + icalText(task.Title))
+	if task.Description != "" {
+		ical.line("DESCRIPTION:" + icalText(task.Description))
+	}
+	ical.line(fmt.Sprintf("PRIORITY:%d", icalPriority(task.Priority)))
+	if len(task.Tags) > 0 {
+		tags := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			tags[i] = icalText(tag)
+		}
+		ical.line("CATEGORIES:" + strings.Join(tags, ","))
+	}
+}
+
+// line writes a content line, folded after 75 octets without splitting
+// UTF-8 sequences
+func (ical *icalWriter) line(s string) {
+	limit := icalLineLimit
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		ical.w.WriteString(s[:cut])
+		ical.w.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space
+		limit = icalLineLimit - 1
+	}
+	ical.w.WriteString(s)
+	ical.w.WriteString("\r\n")
+}
+
+// estimatedSlot returns the start and duration of a task with an estimate
+func estimatedSlot(task *types.Task, endOfDay time.Duration) (time.Time, time.Duration, bool) {
+	if task.Estimate == nil || *task.Estimate <= 0 {
+		return time.Time{}, 0, false
+	}
+	due := task.DueDate
+	end := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, due.Location()).Add(endOfDay)
+	duration := time.Duration(*task.Estimate) * time.Minute
+	return end.Add(-duration), duration, true
+}
+
+// icalDuration formats a duration as e.g. PT2H30M
+func icalDuration(d time.Duration) string {
+	minutes := int64(d / time.Minute)
+	s := "PT"
+	if hours := minutes / 60; hours > 0 {
+		s += fmt.Sprintf("%dH", hours)
+	}
+	if minutes%60 > 0 || minutes < 60 {
+		s += fmt.Sprintf("%dM", minutes%60)
+	}
+	return s
+}
+
+// icalText escapes a TEXT value
+func icalText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+func icalTodoStatus(state types.TaskState) string {
+	switch state {
+	case types.TaskStateInProgress:
+		return "IN-PROCESS"
+	case types.TaskStateCompleted:
+		return "COMPLETED"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// icalPriority maps task priorities to the iCalendar scale, 1 being highest
+func icalPriority(priority types.TaskPriority) int {
+	switch priority {
+	case types.TaskPriorityHigh:
+		return 1
+	case types.TaskPriorityLow:
+		return 9
+	default:
+		return 5
+	}
+}
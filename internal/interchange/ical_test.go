@@ -0,0 +1,101 @@
+package interchange
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectICalTasks(t *testing.T) {
+	due := func(day int) *time.Time {
+		d := time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	later := &types.Task{Title: "Later", State: types.TaskStatePending, DueDate: due(20)}
+	sooner := &types.Task{Title: "Sooner", State: types.TaskStateInProgress, DueDate: due(18)}
+	done := &types.Task{Title: "Done", State: types.TaskStateCompleted, DueDate: due(16)}
+	cancelled := &types.Task{Title: "Cancelled", State: types.TaskStateCancelled, DueDate: due(17)}
+	undated := &types.Task{Title: "Undated", State: types.TaskStatePending}
+	tasks := []*types.Task{later, sooner, done, cancelled, undated}
+
+	assert.Equal(t, []*types.Task{sooner, later}, SelectICalTasks(tasks, ICalOptions{}))
+	assert.Equal(t, []*types.Task{done, sooner, later}, SelectICalTasks(tasks, ICalOptions{IncludeCompleted: true}))
+}
+
+func TestRenderICal(t *testing.T) {
+	due := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	estimate := int64(150)
+	timed := &types.Task{
+		ID:          uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		Title:       "Release, finally; really",
+		Description: "Line one\nLine two",
+		State:       types.TaskStateInProgress,
+		Priority:    types.TaskPriorityHigh,
+		DueDate:     &due,
+		Estimate:    &estimate,
+		Tags:        []string{"release", "q4"},
+	}
+	allDay := &types.Task{
+		ID:       uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+		Title:    "Send invoices",
+		State:    types.TaskStatePending,
+		Priority: types.TaskPriorityLow,
+		DueDate:  &due,
+	}
+
+	var buf bytes.Buffer
+	err := RenderICal(&buf, []*types.Task{timed, allDay}, ICalOptions{
+		CalendarName: "Billing",
+		Component:    ICalBoth,
+		EndOfDay:     DefaultICalEndOfDay,
+		Now:          time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	out := buf.String()
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Contains(t, out, "X-WR-CALNAME:Billing\r\n")
+	assert.Equal(t, 2, strings.Count(out, "BEGIN:VEVENT"))
+	assert.Equal(t, 2, strings.Count(out, "BEGIN:VTODO"))
+
+	// The estimate ends at the end of the working day on the due date
+	assert.Contains(t, out, "UID:11111111-1111-1111-1111-111111111111-event@knot\r\nDTSTAMP:20261016T120000Z\r\nDTSTART:20261020T143000Z\r\nDURATION:PT2H30M\r\n")
+	assert.Contains(t, out, "SUMMARY:Release\\, finally\\; really\r\n")
+	assert.Contains(t, out, "DESCRIPTION:Line one\\nLine two\r\n")
+	assert.Contains(t, out, "PRIORITY:1\r\nCATEGORIES:release,q4\r\n")
+	assert.Contains(t, out, "STATUS:IN-PROCESS\r\n")
+
+	// Without an estimate the task is an all-day entry
+	assert.Contains(t, out, "DTSTART;VALUE=DATE:20261020\r\nDTEND;VALUE=DATE:20261021\r\n")
+	assert.Contains(t, out, "DUE;VALUE=DATE:20261020\r\nSTATUS:NEEDS-ACTION\r\n")
+	assert.Contains(t, out, "PRIORITY:9\r\n")
+}
+
+func TestICalLineFolding(t *testing.T) {
+	var buf bytes.Buffer
+	ical := &icalWriter{w: bufio.NewWriter(&buf)}
+	ical.line("SUMMARY:" + strings.Repeat("ü", 60))
+	require.NoError(t, ical.w.Flush())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), icalLineLimit)
+	}
+	assert.True(t, strings.HasPrefix(lines[1], " "))
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("ü", 60), lines[0]+lines[1][1:])
+}
+
+func TestICalDuration(t *testing.T) {
+	assert.Equal(t, "PT45M", icalDuration(45*time.Minute))
+	assert.Equal(t, "PT2H", icalDuration(2*time.Hour))
+	assert.Equal(t, "PT24H30M", icalDuration(24*time.Hour+30*time.Minute))
+}
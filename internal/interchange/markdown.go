@@ -0,0 +1,143 @@
+package interchange
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// listItemPattern matches bullet ("-", "*", "+") and ordered ("1." / "1)") list
+// items with an optional checkbox.
+var listItemPattern = regexp.MustCompile(`^([-*+]|\d+[.)])\s+(?:\[([ xX])\]\s+)?(.*)$`)
+
+// tabWidth is the number of columns a tab counts for when measuring indentation
+const tabWidth = 4
+
+type markdownEntry struct {
+	indent int
+	node   *Node
+}
+
+// ParseMarkdown parses nested Markdown bullet and checkbox lists into task trees.
+// Indentation determines nesting, "[x]" marks a task as completed, and indented
+// plain text lines below an item become its description. Everything outside of
+// lists (headings, paragraphs) is ignored.
+func ParseMarkdown(r io.Reader) ([]*Node, error) {
+	var roots []*Node
+	var stack []markdownEntry
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indent, content := splitIndent(line)
+		match := listItemPattern.FindStringSubmatch(content)
+		if match == nil {
+			// Continuation text belongs to the innermost item it is indented under
+			for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+			if len(stack) > 0 {
+				node := stack[len(stack)-1].node
+				if node.Description != "" {
+					node.Description += "\n"
+				}
+				node.Description += content
+			}
+			continue
+		}
+
+		title := strings.TrimSpace(match[3])
+		if title == "" {
+			return nil, fmt.Errorf("line %d: list item has no title", lineNo)
+		}
+
+		node := &Node{Title: title, State: types.TaskStatePending}
+		if match[2] == "x" || match[2] == "X" {
+			node.State = types.TaskStateCompleted
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, node)
+		} else {
+			parent := stack[len(stack)-1].node
+			parent.Children = append(parent.Children, node)
+		}
+		stack = append(stack, markdownEntry{indent: indent, node: node})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read markdown: %w", err)
+	}
+
+	return roots, nil
+}
+
+// splitIndent returns the indentation width of a line and its remaining content
+func splitIndent(line string) (int, string) {
+	indent := 0
+	for i, r := range line {
+		switch r {
+		case ' ':
+			indent++
+		case '\t':
+			indent += tabWidth
+		default:
+			return indent, line[i:]
+		}
+	}
+	return indent, ""
+}
+
+// MarkdownOptions controls Markdown rendering
+type MarkdownOptions struct {
+	Checklist bool // Render "- [ ]" / "- [x]" checkboxes instead of plain bullets
+}
+
+// RenderMarkdown writes task trees as a nested Markdown list
+func RenderMarkdown(w io.Writer, nodes []*Node, opts MarkdownOptions) error {
+	bw := bufio.NewWriter(w)
+	for _, node := range nodes {
+		renderMarkdownNode(bw, node, 0, opts)
+	}
+	return bw.Flush()
+}
+
+func renderMarkdownNode(w *bufio.Writer, node *Node, depth int, opts MarkdownOptions) {
+	indent := strings.Repeat("  ", depth)
+
+	marker := "-"
+	if opts.Checklist {
+		if node.State == types.TaskStateCompleted {
+			marker = "- [x]"
+		} else {
+			marker = "- [ ]"
+		}
+	}
+	fmt.Fprintf(w, "%s%s %s\n", indent, marker, node.Heading())
+
+	if node.Description != "" {
+		for _, line := range strings.Split(node.Description, "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			fmt.Fprintf(w, "%s  %s\n", indent, strings.TrimSpace(line))
+		}
+	}
+
+	for _, child := range node.Children {
+		renderMarkdownNode(w, child, depth+1, opts)
+	}
+}
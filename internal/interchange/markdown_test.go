@@ -0,0 +1,117 @@
+package interchange
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/testutil"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleMarkdown = `# Release plan
+
+Some intro text that is ignored.
+
+- [ ] Backend
+  - [x] Design schema
+    Tables for users and sessions
+  - [ ] Implement API
+	* Write handlers
+- [x] Setup CI
+1. Plain ordered item
+`
+
+func TestParseMarkdown(t *testing.T) {
+	nodes, err := ParseMarkdown(strings.NewReader(sampleMarkdown))
+	require.NoError(t, err)
+	require.Len(t, nodes, 3)
+
+	backend := nodes[0]
+	assert.Equal(t, "Backend", backend.Title)
+	assert.Equal(t, types.TaskStatePending, backend.State)
+	require.Len(t, backend.Children, 2)
+
+	design := backend.Children[0]
+	assert.Equal(t, "Design schema", design.Title)
+	assert.Equal(t, types.TaskStateCompleted, design.State)
+	assert.Equal(t, "Tables for users and sessions", design.Description)
+
+	impl := backend.Children[1]
+	require.Len(t, impl.Children, 1)
+	assert.Equal(t, "Write handlers", impl.Children[0].Title)
+
+	assert.Equal(t, types.TaskStateCompleted, nodes[1].State)
+	assert.Equal(t, "Plain ordered item", nodes[2].Title)
+	assert.Equal(t, 6, CountNodes(nodes))
+}
+
+func TestRenderMarkdownRoundTrip(t *testing.T) {
+	nodes, err := ParseMarkdown(strings.NewReader(sampleMarkdown))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderMarkdown(&buf, nodes, MarkdownOptions{Checklist: true}))
+	assert.Contains(t, buf.String(), "- [ ] Backend\n  - [x] Design schema\n    Tables for users and sessions\n")
+
+	reparsed, err := ParseMarkdown(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, nodes, reparsed)
+
+	buf.Reset()
+	require.NoError(t, RenderMarkdown(&buf, nodes, MarkdownOptions{}))
+	assert.NotContains(t, buf.String(), "[x]")
+}
+
+func TestCreateTree(t *testing.T) {
+	ctx := context.Background()
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+
+	nodes, err := ParseMarkdown(strings.NewReader(sampleMarkdown))
+	require.NoError(t, err)
+
+	created, err := CreateTree(ctx, mgr, project.ID, nodes, ImportOptions{Actor: "test-user"})
+	require.NoError(t, err)
+	require.Len(t, created, 6)
+
+	byTitle := make(map[string]*types.Task)
+	tasks, err := mgr.ListTasksForProject(ctx, project.ID)
+	require.NoError(t, err)
+	for _, task := range tasks {
+		byTitle[task.Title] = task
+	}
+
+	assert.Equal(t, types.TaskStateCompleted, byTitle["Design schema"].State)
+	assert.Equal(t, types.TaskStateCompleted, byTitle["Setup CI"].State)
+	assert.Equal(t, types.TaskStatePending, byTitle["Write handlers"].State)
+	assert.Equal(t, 2, byTitle["Write handlers"].Depth)
+	require.NotNil(t, byTitle["Design schema"].ParentID)
+	assert.Equal(t, byTitle["Backend"].ID, *byTitle["Design schema"].ParentID)
+
+	roots := BuildTree(tasks)
+	assert.Equal(t, 6, CountNodes(roots))
+}
+
+func TestNumberTree(t *testing.T) {
+	nodes, err := ParseMarkdown(strings.NewReader(sampleMarkdown))
+	require.NoError(t, err)
+	NumberTree(nodes)
+
+	assert.Equal(t, "1", nodes[0].Number)
+	assert.Equal(t, "1.2.1", nodes[0].Children[1].Children[0].Number)
+	assert.Equal(t, "3", nodes[2].Number)
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderMarkdown(&buf, nodes, MarkdownOptions{}))
+	assert.Contains(t, buf.String(), "- 1 Backend\n  - 1.1 Design schema\n")
+	assert.Contains(t, buf.String(), "    - 1.2.1 Write handlers\n")
+
+	buf.Reset()
+	require.NoError(t, RenderOrgMode(&buf, nodes))
+	assert.Contains(t, buf.String(), "** DONE 1.1 Design schema\n")
+}
@@ -0,0 +1,220 @@
+package interchange
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+var (
+	orgHeadingPattern  = regexp.MustCompile(`^(\*+)\s+(.*)$`)
+	orgPriorityPattern = regexp.MustCompile(`^\[#([A-Za-z])\]\s*`)
+	orgTagsPattern     = regexp.MustCompile(`\s+(:[\w@#%:]+:)\s*$`)
+)
+
+// orgKeywordStates maps org-mode TODO keywords to task states
+var orgKeywordStates = map[string]types.TaskState{
+	"TODO":      types.TaskStatePending,
+	"NEXT":      types.TaskStateInProgress,
+	"STARTED":   types.TaskStateInProgress,
+	"DOING":     types.TaskStateInProgress,
+	"WAITING":   types.TaskStateBlocked,
+	"BLOCKED":   types.TaskStateBlocked,
+	"DONE":      types.TaskStateCompleted,
+	"CANCELLED": types.TaskStateCancelled,
+	"CANCELED":  types.TaskStateCancelled,
+}
+
+// orgStateKeywords maps task states to the keywords used when exporting
+var orgStateKeywords = map[types.TaskState]string{
+	types.TaskStatePending:         "TODO",
+	types.TaskStateInProgress:      "STARTED",
+	types.TaskStateBlocked:         "WAITING",
+	types.TaskStateCompleted:       "DONE",
+	types.TaskStateCancelled:       "CANCELLED",
+	types.TaskStateDeletionPending: "CANCELLED",
+}
+
+// orgTodoHeader declares the exported keywords so org-mode recognizes them
+const orgTodoHeader = "#+TODO: TODO STARTED WAITING | DONE CANCELLED"
+
+// ParseOrgMode parses Emacs org-mode headings into task trees. Heading levels
+// determine nesting, TODO keywords map to states, [#A]/[#B]/[#C] cookies map to
+// priorities and trailing :tags: become task tags. Body text below a heading
+// becomes its description; property drawers and planning lines are skipped.
+func ParseOrgMode(r io.Reader) ([]*Node, error) {
+	var roots []*Node
+	var stack []*Node // stack[i] is the current node at heading level i+1
+	inDrawer := false
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		match := orgHeadingPattern.FindStringSubmatch(line)
+		if match == nil {
+			if len(stack) == 0 {
+				continue // Preamble such as #+TITLE lines
+			}
+			trimmed := strings.TrimSpace(line)
+			switch {
+			case trimmed == "":
+				continue
+			case strings.HasPrefix(trimmed, ":") && strings.HasSuffix(trimmed, ":") && !inDrawer:
+				inDrawer = trimmed != ":END:"
+				continue
+			case inDrawer:
+				inDrawer = trimmed != ":END:"
+				continue
+			case isOrgPlanningLine(trimmed):
+				continue
+			}
+			node := stack[len(stack)-1]
+			if node.Description != "" {
+				node.Description += "\n"
+			}
+			node.Description += trimmed
+			continue
+		}
+
+		inDrawer = false
+		level := len(match[1])
+		node, err := parseOrgHeading(match[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		if level > len(stack)+1 {
+			// Skipped levels attach to the deepest open heading
+			level = len(stack) + 1
+		}
+		stack = stack[:level-1]
+		if len(stack) == 0 {
+			roots = append(roots, node)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, node)
+		}
+		stack = append(stack, node)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read org-mode file: %w", err)
+	}
+
+	return roots, nil
+}
+
+func parseOrgHeading(text string) (*Node, error) {
+	node := &Node{State: types.TaskStatePending}
+
+	keyword, rest, _ := strings.Cut(text, " ")
+	if state, ok := orgKeywordStates[keyword]; ok {
+		node.State = state
+		text = strings.TrimSpace(rest)
+	}
+
+	if match := orgPriorityPattern.FindStringSubmatch(text); match != nil {
+		node.Priority = priorityFromLetter(match[1])
+		text = text[len(match[0]):]
+	}
+
+	if match := orgTagsPattern.FindStringSubmatchIndex(text); match != nil {
+		tags := text[match[2]:match[3]]
+		text = text[:match[0]]
+		for _, tag := range strings.Split(strings.Trim(tags, ":"), ":") {
+			if tag != "" {
+				node.Tags = append(node.Tags, tag)
+			}
+		}
+	}
+
+	node.Title = strings.TrimSpace(text)
+	if node.Title == "" {
+		return nil, fmt.Errorf("heading has no title")
+	}
+
+	return node, nil
+}
+
+func isOrgPlanningLine(line string) bool {
+	for _, prefix := range []string{"SCHEDULED:", "DEADLINE:", "CLOSED:"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderOrgMode writes task trees as org-mode headings
+func RenderOrgMode(w io.Writer, nodes []*Node) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, orgTodoHeader)
+	fmt.Fprintln(bw)
+	for _, node := range nodes {
+		renderOrgNode(bw, node, 1)
+	}
+	return bw.Flush()
+}
+
+func renderOrgNode(w *bufio.Writer, node *Node, level int) {
+	keyword, ok := orgStateKeywords[node.State]
+	if !ok {
+		keyword = "TODO"
+	}
+
+	heading := fmt.Sprintf("%s %s", strings.Repeat("*", level), keyword)
+	if node.Priority != 0 && node.Priority != types.TaskPriorityMedium {
+		heading += fmt.Sprintf(" [#%s]", priorityLetter(node.Priority))
+	}
+	heading += " " + node.Heading()
+	if len(node.Tags) > 0 {
+		heading += " :" + strings.Join(node.Tags, ":") + ":"
+	}
+	fmt.Fprintln(w, heading)
+
+	if node.Description != "" {
+		indent := strings.Repeat(" ", level+1)
+		for _, line := range strings.Split(node.Description, "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			fmt.Fprintf(w, "%s%s\n", indent, strings.TrimSpace(line))
+		}
+	}
+
+	for _, child := range node.Children {
+		renderOrgNode(w, child, level+1)
+	}
+}
+
+// priorityFromLetter maps A/B/C priority letters (used by org-mode and todo.txt)
+// to task priorities. Letters after C are treated as low.
+func priorityFromLetter(letter string) types.TaskPriority {
+	switch strings.ToUpper(letter) {
+	case "A":
+		return types.TaskPriorityHigh
+	case "B":
+		return types.TaskPriorityMedium
+	default:
+		return types.TaskPriorityLow
+	}
+}
+
+// priorityLetter maps a task priority to an A/B/C priority letter
+func priorityLetter(priority types.TaskPriority) string {
+	switch priority {
+	case types.TaskPriorityHigh:
+		return "A"
+	case types.TaskPriorityLow:
+		return "C"
+	default:
+		return "B"
+	}
+}
@@ -0,0 +1,66 @@
+package interchange
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleOrg = `#+TITLE: Roadmap
+
+* TODO [#A] Launch website :web:release:
+  Public launch of the marketing site
+  :PROPERTIES:
+  :ID: abc
+  :END:
+** DONE Write copy
+   CLOSED: [2024-01-02 Tue 10:00]
+** STARTED Build pages :frontend:
+**** WAITING Review by legal
+* Plain heading
+* CANCELLED [#C] Old idea
+`
+
+func TestParseOrgMode(t *testing.T) {
+	nodes, err := ParseOrgMode(strings.NewReader(sampleOrg))
+	require.NoError(t, err)
+	require.Len(t, nodes, 3)
+
+	launch := nodes[0]
+	assert.Equal(t, "Launch website", launch.Title)
+	assert.Equal(t, types.TaskStatePending, launch.State)
+	assert.Equal(t, types.TaskPriorityHigh, launch.Priority)
+	assert.Equal(t, []string{"web", "release"}, launch.Tags)
+	assert.Equal(t, "Public launch of the marketing site", launch.Description)
+	require.Len(t, launch.Children, 2)
+
+	assert.Equal(t, types.TaskStateCompleted, launch.Children[0].State)
+	assert.Empty(t, launch.Children[0].Description)
+
+	build := launch.Children[1]
+	assert.Equal(t, types.TaskStateInProgress, build.State)
+	require.Len(t, build.Children, 1, "skipped heading levels attach to the deepest open heading")
+	assert.Equal(t, types.TaskStateBlocked, build.Children[0].State)
+
+	assert.Equal(t, types.TaskStatePending, nodes[1].State)
+	assert.Equal(t, types.TaskStateCancelled, nodes[2].State)
+	assert.Equal(t, types.TaskPriorityLow, nodes[2].Priority)
+}
+
+func TestRenderOrgModeRoundTrip(t *testing.T) {
+	nodes, err := ParseOrgMode(strings.NewReader(sampleOrg))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderOrgMode(&buf, nodes))
+	assert.True(t, strings.HasPrefix(buf.String(), orgTodoHeader))
+	assert.Contains(t, buf.String(), "* TODO [#A] Launch website :web:release:\n")
+
+	reparsed, err := ParseOrgMode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, nodes, reparsed)
+}
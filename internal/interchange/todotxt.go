@@ -0,0 +1,120 @@
+package interchange
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+var (
+	todoTxtPriorityPattern = regexp.MustCompile(`^\(([A-Z])\)\s+`)
+	todoTxtDatePattern     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}\s+`)
+)
+
+// ParseTodoTxt parses a todo.txt file into flat task nodes. A leading "x" marks
+// a task as completed, "(A)".."(C)" (or a pri:X key) map to priorities, and
+// +project / @context words become tags ("project" and "@context"). Dates are
+// skipped; other key:value pairs are kept in the title.
+func ParseTodoTxt(r io.Reader) ([]*Node, error) {
+	var nodes []*Node
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		node, err := parseTodoTxtLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		nodes = append(nodes, node)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read todo.txt file: %w", err)
+	}
+
+	return nodes, nil
+}
+
+func parseTodoTxtLine(line string) (*Node, error) {
+	node := &Node{State: types.TaskStatePending}
+
+	if strings.HasPrefix(line, "x ") {
+		node.State = types.TaskStateCompleted
+		line = strings.TrimSpace(line[2:])
+	}
+
+	if match := todoTxtPriorityPattern.FindStringSubmatch(line); match != nil {
+		node.Priority = priorityFromLetter(match[1])
+		line = line[len(match[0]):]
+	}
+
+	// Completion and creation dates
+	for i := 0; i < 2; i++ {
+		if match := todoTxtDatePattern.FindString(line); match != "" {
+			line = line[len(match):]
+		}
+	}
+
+	var words []string
+	for _, word := range strings.Fields(line) {
+		switch {
+		case len(word) > 1 && word[0] == '+':
+			node.Tags = append(node.Tags, word[1:])
+		case len(word) > 1 && word[0] == '@':
+			node.Tags = append(node.Tags, word)
+		case strings.HasPrefix(word, "pri:") && len(word) == 5:
+			node.Priority = priorityFromLetter(word[4:])
+		default:
+			words = append(words, word)
+		}
+	}
+
+	node.Title = strings.Join(words, " ")
+	if node.Title == "" {
+		return nil, fmt.Errorf("task has no title")
+	}
+
+	return node, nil
+}
+
+// RenderTodoTxt writes task trees as a flat todo.txt list. The hierarchy is
+// flattened in document order since todo.txt has no notion of subtasks.
+// Medium priority is written without a priority marker.
+func RenderTodoTxt(w io.Writer, nodes []*Node) error {
+	bw := bufio.NewWriter(w)
+	renderTodoTxtNodes(bw, nodes)
+	return bw.Flush()
+}
+
+func renderTodoTxtNodes(w *bufio.Writer, nodes []*Node) {
+	for _, node := range nodes {
+		var parts []string
+		if node.State == types.TaskStateCompleted {
+			parts = append(parts, "x")
+		}
+		if node.Priority == types.TaskPriorityHigh || node.Priority == types.TaskPriorityLow {
+			parts = append(parts, fmt.Sprintf("(%s)", priorityLetter(node.Priority)))
+		}
+		parts = append(parts, node.Heading())
+		for _, tag := range node.Tags {
+			if strings.HasPrefix(tag, "@") {
+				parts = append(parts, tag)
+			} else {
+				parts = append(parts, "+"+tag)
+			}
+		}
+		fmt.Fprintln(w, strings.Join(parts, " "))
+
+		renderTodoTxtNodes(w, node.Children)
+	}
+}
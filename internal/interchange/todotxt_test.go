@@ -0,0 +1,57 @@
+package interchange
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTodoTxt(t *testing.T) {
+	input := `(A) 2024-01-01 Call the bank +finance @phone
+x 2024-01-03 2024-01-01 Pay invoices +finance pri:C
+Plain task due:2024-02-01
+`
+	nodes, err := ParseTodoTxt(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, nodes, 3)
+
+	assert.Equal(t, "Call the bank", nodes[0].Title)
+	assert.Equal(t, types.TaskPriorityHigh, nodes[0].Priority)
+	assert.Equal(t, []string{"finance", "@phone"}, nodes[0].Tags)
+	assert.Equal(t, types.TaskStatePending, nodes[0].State)
+
+	assert.Equal(t, "Pay invoices", nodes[1].Title)
+	assert.Equal(t, types.TaskStateCompleted, nodes[1].State)
+	assert.Equal(t, types.TaskPriorityLow, nodes[1].Priority)
+
+	assert.Equal(t, "Plain task due:2024-02-01", nodes[2].Title)
+	assert.Equal(t, types.TaskPriority(0), nodes[2].Priority)
+}
+
+func TestRenderTodoTxt(t *testing.T) {
+	nodes := []*Node{
+		{
+			Title:    "Parent",
+			State:    types.TaskStatePending,
+			Priority: types.TaskPriorityHigh,
+			Tags:     []string{"proj", "@home"},
+			Children: []*Node{
+				{Title: "Child", State: types.TaskStateCompleted, Priority: types.TaskPriorityMedium},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderTodoTxt(&buf, nodes))
+	assert.Equal(t, "(A) Parent +proj @home\nx Child\n", buf.String())
+
+	reparsed, err := ParseTodoTxt(&buf)
+	require.NoError(t, err)
+	require.Len(t, reparsed, 2)
+	assert.Equal(t, nodes[0].Tags, reparsed[0].Tags)
+	assert.Equal(t, types.TaskStateCompleted, reparsed[1].State)
+}
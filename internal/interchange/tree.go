@@ -0,0 +1,210 @@
+// Package interchange converts knot task hierarchies to and from external
+// plain-text formats such as Markdown checklists, Emacs org-mode and todo.txt.
+//
+// Importers parse a document into a format-neutral tree of Nodes, which is
+// then created in a project through the project manager. Exporters build the
+// same tree from the tasks of a project and render it.
+package interchange
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// DefaultComplexity is used for imported tasks whose source format carries no complexity
+const DefaultComplexity = 5
+
+// Node is a format-neutral task tree node used by importers and exporters
+type Node struct {
+	Title       string
+	Description string
+	State       types.TaskState
+	Priority    types.TaskPriority // 0 means medium
+	Complexity  int                // 0 means "use the import default"
+	Tags        []string
+	Children    []*Node
+	// Number is the position in the tree such as "1.2.1", set by NumberTree
+	// and rendered before the title
+	Number string
+}
+
+// Heading returns the title, preceded by the number if the tree is numbered
+func (n *Node) Heading() string {
+	if n.Number == "" {
+		return n.Title
+	}
+	return n.Number + " " + n.Title
+}
+
+// CountNodes returns the total number of nodes in the given trees
+func CountNodes(nodes []*Node) int {
+	count := 0
+	for _, node := range nodes {
+		count += 1 + CountNodes(node.Children)
+	}
+	return count
+}
+
+// BuildTree arranges project tasks into a tree with the siblings in their
+// intended order, as shown by 'knot task tree'. Tasks whose parent is not part
+// of the list become roots.
+func BuildTree(tasks []*types.Task) []*Node {
+	sorted := make([]*types.Task, len(tasks))
+	copy(sorted, tasks)
+	types.SortSiblings(sorted)
+
+	nodes := make(map[uuid.UUID]*Node, len(sorted))
+	for _, task := range sorted {
+		nodes[task.ID] = &Node{
+			Title:       task.Title,
+			Description: task.Description,
+			State:       task.State,
+			Priority:    task.Priority,
+			Complexity:  task.Complexity,
+			Tags:        task.Tags,
+		}
+	}
+
+	var roots []*Node
+	for _, task := range sorted {
+		node := nodes[task.ID]
+		if task.ParentID != nil {
+			if parent, ok := nodes[*task.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	return roots
+}
+
+// NumberTree numbers the nodes by their position in the trees, 1, 1.1, 1.1.2
+// and so on, so exported documents read like a structured plan
+func NumberTree(nodes []*Node) {
+	numberNodes(nodes, "")
+}
+
+func numberNodes(nodes []*Node, prefix string) {
+	for i, node := range nodes {
+		node.Number = prefix + strconv.Itoa(i+1)
+		numberNodes(node.Children, node.Number+".")
+	}
+}
+
+// ImportOptions controls how an imported tree is created in a project
+type ImportOptions struct {
+	ParentID          *uuid.UUID // Optional existing task to import under
+	DefaultComplexity int
+	Actor             string
+}
+
+// CreateTree creates the given trees in a project and returns the created tasks
+// in document order. Task states are applied after all tasks exist, children
+// before parents, so that automatic parent state evaluation sees final child states.
+func CreateTree(ctx context.Context, pm manager.ProjectManager, projectID uuid.UUID, nodes []*Node, opts ImportOptions) ([]*types.Task, error) {
+	if opts.DefaultComplexity <= 0 {
+		opts.DefaultComplexity = DefaultComplexity
+	}
+
+	importer := &treeImporter{pm: pm, projectID: projectID, opts: opts}
+	for _, node := range nodes {
+		if err := importer.create(ctx, node, opts.ParentID); err != nil {
+			return importer.created, err
+		}
+	}
+
+	for i := len(importer.pending) - 1; i >= 0; i-- {
+		if err := importer.applyState(ctx, importer.pending[i]); err != nil {
+			return importer.created, err
+		}
+	}
+
+	return importer.created, nil
+}
+
+type pendingState struct {
+	taskID uuid.UUID
+	title  string
+	state  types.TaskState
+}
+
+type treeImporter struct {
+	pm        manager.ProjectManager
+	projectID uuid.UUID
+	opts      ImportOptions
+	created   []*types.Task
+	pending   []pendingState
+}
+
+func (ti *treeImporter) create(ctx context.Context, node *Node, parentID *uuid.UUID) error {
+	complexity := node.Complexity
+	if complexity <= 0 {
+		complexity = ti.opts.DefaultComplexity
+	}
+
+	priority := node.Priority
+	if priority == 0 {
+		priority = types.TaskPriorityMedium
+	}
+
+	task, err := ti.pm.CreateTask(ctx, ti.projectID, parentID, node.Title, node.Description, complexity, priority, ti.opts.Actor)
+	if err != nil {
+		return fmt.Errorf("failed to create task '%s': %w", node.Title, err)
+	}
+
+	if len(node.Tags) > 0 {
+		if task, err = ti.pm.SetTaskTags(ctx, task.ID, node.Tags, ti.opts.Actor); err != nil {
+			return fmt.Errorf("failed to set tags of '%s': %w", node.Title, err)
+		}
+	}
+	ti.created = append(ti.created, task)
+
+	if node.State != "" && node.State != types.TaskStatePending {
+		ti.pending = append(ti.pending, pendingState{taskID: task.ID, title: task.Title, state: node.State})
+	}
+
+	for _, child := range node.Children {
+		if err := ti.create(ctx, child, &task.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyState moves a freshly created (pending) task to its target state along valid transitions
+func (ti *treeImporter) applyState(ctx context.Context, p pendingState) error {
+	var path []types.TaskState
+	switch p.state {
+	case types.TaskStateCompleted:
+		path = []types.TaskState{types.TaskStateInProgress, types.TaskStateCompleted}
+	default:
+		path = []types.TaskState{p.state}
+	}
+
+	for _, state := range path {
+		task, err := ti.pm.GetTask(ctx, p.taskID)
+		if err != nil {
+			return err
+		}
+		if task.State == p.state {
+			// Already reached, e.g. through automatic parent state evaluation
+			return nil
+		}
+		if task.State == state {
+			continue
+		}
+		if _, err := ti.pm.UpdateTaskState(ctx, p.taskID, state, ti.opts.Actor); err != nil {
+			return fmt.Errorf("failed to set state of '%s' to %s: %w", p.title, p.state, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package interchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+)
+
+// VSCodeTasksVersion is the schema version of the editor task document. It is
+// increased whenever a field is removed or changes its meaning.
+const VSCodeTasksVersion = 1
+
+// VSCodeTaskDocument is the JSON document consumed by editor integrations
+type VSCodeTaskDocument struct {
+	Version     int          `json:"version"`
+	ProjectID   uuid.UUID    `json:"project_id"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	Tasks       []VSCodeTask `json:"tasks"`
+}
+
+// VSCodeTask is one task entry of the editor task document
+type VSCodeTask struct {
+	ID          uuid.UUID       `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	State       types.TaskState `json:"state"`
+	Priority    string          `json:"priority"`
+	Complexity  int             `json:"complexity"`
+	ParentID    *uuid.UUID      `json:"parent_id,omitempty"`
+	Depth       int             `json:"depth"`
+	Ready       bool            `json:"ready"`
+	BlockedBy   []uuid.UUID     `json:"blocked_by,omitempty"`
+	Commands    []VSCodeCommand `json:"commands"`
+}
+
+// VSCodeCommand is a knot command line an editor can offer for a task
+type VSCodeCommand struct {
+	Label   string   `json:"label"`
+	Command string   `json:"command"`
+	Args    []string `json:"args"`
+}
+
+// VSCodeTaskOptions controls which tasks are included in the document
+type VSCodeTaskOptions struct {
+	// IncludeBlocked adds open tasks that are waiting on dependencies
+	IncludeBlocked bool
+}
+
+// BuildVSCodeTasks selects the actionable tasks of a project, i.e. pending or
+// in-progress tasks whose dependencies are completed. Tasks are ordered with
+// in-progress work first, then by priority and creation time.
+func BuildVSCodeTasks(projectID uuid.UUID, tasks []*types.Task, opts VSCodeTaskOptions) *VSCodeTaskDocument {
+	taskMap := make(map[uuid.UUID]*types.Task, len(tasks))
+	for _, task := range tasks {
+		taskMap[task.ID] = task
+	}
+
+	selected := make([]*types.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.State != types.TaskStatePending && task.State != types.TaskStateInProgress {
+			continue
+		}
+		if opts.IncludeBlocked || utils.IsTaskReady(task, taskMap) {
+			selected = append(selected, task)
+		}
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		a, b := selected[i], selected[j]
+		if (a.State == types.TaskStateInProgress) != (b.State == types.TaskStateInProgress) {
+			return a.State == types.TaskStateInProgress
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return types.CompareCreation(a, b) < 0
+	})
+
+	doc := &VSCodeTaskDocument{
+		Version:     VSCodeTasksVersion,
+		ProjectID:   projectID,
+		GeneratedAt: time.Now().UTC(),
+		Tasks:       make([]VSCodeTask, 0, len(selected)),
+	}
+	for _, task := range selected {
+		doc.Tasks = append(doc.Tasks, newVSCodeTask(task, taskMap))
+	}
+	return doc
+}
+
+// RenderVSCodeTasks writes the document as indented JSON
+func RenderVSCodeTasks(w io.Writer, doc *VSCodeTaskDocument) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode editor tasks: %w", err)
+	}
+	return nil
+}
+
+func newVSCodeTask(task *types.Task, taskMap map[uuid.UUID]*types.Task) VSCodeTask {
+	entry := VSCodeTask{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		State:       task.State,
+		Priority:    task.Priority.ToExternalString(),
+		Complexity:  task.Complexity,
+		ParentID:    task.ParentID,
+		Depth:       task.Depth,
+	}
+
+	for _, depID := range task.Dependencies {
+		if dep, ok := taskMap[depID]; !ok || dep.State != types.TaskStateCompleted {
+			entry.BlockedBy = append(entry.BlockedBy, depID)
+		}
+	}
+	entry.Ready = len(entry.BlockedBy) == 0
+
+	id := task.ID.String()
+	entry.Commands = append(entry.Commands, VSCodeCommand{
+		Label:   "Show details",
+		Command: "knot",
+		Args:    []string{"task", "get", "--id", id},
+	})
+	if task.State == types.TaskStatePending && entry.Ready {
+		entry.Commands = append(entry.Commands, VSCodeCommand{
+			Label:   "Start",
+			Command: "knot",
+			Args:    []string{"task", "update-state", "--id", id, "--state", string(types.TaskStateInProgress)},
+		})
+	}
+	if entry.Ready {
+		entry.Commands = append(entry.Commands, VSCodeCommand{
+			Label:   "Complete",
+			Command: "knot",
+			Args:    []string{"task", "update-state", "--id", id, "--state", string(types.TaskStateCompleted)},
+		})
+	}
+	return entry
+}
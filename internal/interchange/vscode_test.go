@@ -0,0 +1,86 @@
+package interchange
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildVSCodeTasks(t *testing.T) {
+	projectID := uuid.New()
+	now := time.Now()
+	newTask := func(title string, state types.TaskState, priority types.TaskPriority, age time.Duration) *types.Task {
+		return &types.Task{
+			ID:        uuid.New(),
+			ProjectID: projectID,
+			Title:     title,
+			State:     state,
+			Priority:  priority,
+			CreatedAt: now.Add(-age),
+		}
+	}
+
+	done := newTask("Done", types.TaskStateCompleted, types.TaskPriorityHigh, 5*time.Hour)
+	open := newTask("Open", types.TaskStatePending, types.TaskPriorityMedium, 4*time.Hour)
+	urgent := newTask("Urgent", types.TaskStatePending, types.TaskPriorityHigh, 1*time.Hour)
+	active := newTask("Active", types.TaskStateInProgress, types.TaskPriorityLow, 3*time.Hour)
+	waiting := newTask("Waiting", types.TaskStatePending, types.TaskPriorityHigh, 2*time.Hour)
+	waiting.Dependencies = []uuid.UUID{done.ID, open.ID}
+	unblocked := newTask("Unblocked", types.TaskStatePending, types.TaskPriorityLow, 2*time.Hour)
+	unblocked.Dependencies = []uuid.UUID{done.ID}
+
+	tasks := []*types.Task{done, open, urgent, active, waiting, unblocked}
+
+	doc := BuildVSCodeTasks(projectID, tasks, VSCodeTaskOptions{})
+	assert.Equal(t, VSCodeTasksVersion, doc.Version)
+	assert.Equal(t, projectID, doc.ProjectID)
+
+	var titles []string
+	for _, task := range doc.Tasks {
+		titles = append(titles, task.Title)
+		assert.True(t, task.Ready)
+	}
+	assert.Equal(t, []string{"Active", "Urgent", "Open", "Unblocked"}, titles)
+
+	// In-progress tasks offer no start command
+	require.Len(t, doc.Tasks[0].Commands, 2)
+	assert.Equal(t, "Complete", doc.Tasks[0].Commands[1].Label)
+	require.Len(t, doc.Tasks[1].Commands, 3)
+	assert.Equal(t, []string{"task", "update-state", "--id", urgent.ID.String(), "--state", "in-progress"},
+		doc.Tasks[1].Commands[1].Args)
+	assert.Equal(t, "high", doc.Tasks[1].Priority)
+
+	withBlocked := BuildVSCodeTasks(projectID, tasks, VSCodeTaskOptions{IncludeBlocked: true})
+	require.Len(t, withBlocked.Tasks, 5)
+	blocked := withBlocked.Tasks[1]
+	assert.Equal(t, "Waiting", blocked.Title)
+	assert.False(t, blocked.Ready)
+	assert.Equal(t, []uuid.UUID{open.ID}, blocked.BlockedBy)
+	require.Len(t, blocked.Commands, 1)
+	assert.Equal(t, "Show details", blocked.Commands[0].Label)
+}
+
+func TestRenderVSCodeTasks(t *testing.T) {
+	projectID := uuid.New()
+	task := &types.Task{ID: uuid.New(), ProjectID: projectID, Title: "Write docs", State: types.TaskStatePending, Priority: types.TaskPriorityMedium}
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderVSCodeTasks(&buf, BuildVSCodeTasks(projectID, []*types.Task{task}, VSCodeTaskOptions{})))
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.EqualValues(t, 1, decoded["version"])
+	entries := decoded["tasks"].([]any)
+	require.Len(t, entries, 1)
+	entry := entries[0].(map[string]any)
+	assert.Equal(t, "Write docs", entry["title"])
+	assert.Equal(t, "medium", entry["priority"])
+	assert.Equal(t, true, entry["ready"])
+	assert.NotContains(t, entry, "blocked_by")
+}
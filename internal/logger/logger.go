@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"github.com/denkhaus/knot/v2/internal/output"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -35,7 +36,10 @@ func SetLogLevel(logLevel string) {
 		config.EncoderConfig.TimeKey = ""                                   // Remove timestamp for cleaner CLI output
 		config.EncoderConfig.CallerKey = ""                                 // Remove caller info for cleaner CLI output
 		config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder // Colored level names
-		config.OutputPaths = []string{"stderr"}                             // Send to stderr to not interfere with CLI output
+		if !output.ColorEnabled() {
+			config.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+		}
+		config.OutputPaths = []string{"stderr"} // Send to stderr to not interfere with CLI output
 
 		// Set log level
 		switch logLevel {
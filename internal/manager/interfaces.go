@@ -24,9 +24,14 @@ package manager
 
 import (
 	"context"
+	"fmt"
+	"path"
 	"time"
 
+	"github.com/denkhaus/knot/v2/internal/selection"
 	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/denkhaus/knot/v2/internal/validation"
 	"github.com/google/uuid"
 )
 
@@ -47,47 +52,141 @@ type ProjectManager interface {
 	GetProject(ctx context.Context, projectID uuid.UUID) (*types.Project, error)
 	UpdateProject(ctx context.Context, projectID uuid.UUID, title, description string, actor string) (*types.Project, error)
 	UpdateProjectDescription(ctx context.Context, projectID uuid.UUID, description string, actor string) (*types.Project, error)
+	// UpdateProjectInstructions sets the agent instructions inherited by all
+	// tasks of the project, empty instructions remove them
+	UpdateProjectInstructions(ctx context.Context, projectID uuid.UUID, instructions string, actor string) (*types.Project, error)
+	// UpdateProjectDocument replaces the long-form project document, an empty
+	// document removes it
+	UpdateProjectDocument(ctx context.Context, projectID uuid.UUID, document string, actor string) (*types.Project, error)
 	UpdateProjectState(ctx context.Context, projectID uuid.UUID, state types.ProjectState, actor string) (*types.Project, error)
 	DeleteProject(ctx context.Context, projectID uuid.UUID) error
 	ListProjects(ctx context.Context) ([]*types.Project, error)
 
 	// Task operations
+	//
+	// Every operation changing the state of a task (UpdateTask, UpdateTaskState,
+	// BlockTask, BulkUpdateTasks, TransitionTasks) rejects transitions that
+	// CheckStateTransition rejects. A context created with WithForcedTransitions
+	// skips the state machine check; the completion requirements still apply.
 	CreateTask(ctx context.Context, projectID uuid.UUID, parentID *uuid.UUID, title, description string, complexity int, priority types.TaskPriority, actor string) (*types.Task, error)
 	GetTask(ctx context.Context, taskID uuid.UUID) (*types.Task, error)
 	GetTasksWithDependencies(ctx context.Context, taskIDs []uuid.UUID) ([]*types.Task, error)
 	UpdateTask(ctx context.Context, taskID uuid.UUID, title, description string, complexity int, state types.TaskState, actor string) (*types.Task, error)
 	UpdateTaskDescription(ctx context.Context, taskID uuid.UUID, description string, actor string) (*types.Task, error)
+	// UpdateTaskInstructions sets the agent instructions of a task, inherited
+	// by its subtasks; empty instructions remove them
+	UpdateTaskInstructions(ctx context.Context, taskID uuid.UUID, instructions string, actor string) (*types.Task, error)
 	UpdateTaskTitle(ctx context.Context, taskID uuid.UUID, title string, actor string) (*types.Task, error)
 	UpdateTaskPriority(ctx context.Context, taskID uuid.UUID, priority types.TaskPriority, actor string) (*types.Task, error)
 	UpdateTaskState(ctx context.Context, taskID uuid.UUID, state types.TaskState, actor string) (*types.Task, error)
+	// UpdateTaskStateWithHandoff is UpdateTaskState with a handoff note, which
+	// is recorded when an in-progress task is forced back to pending
+	UpdateTaskStateWithHandoff(ctx context.Context, taskID uuid.UUID, state types.TaskState, note string, actor string) (*types.Task, error)
+	// ScheduleTaskState schedules a state change of a task that RunDueTransitions
+	// applies once at is reached
+	ScheduleTaskState(ctx context.Context, taskID uuid.UUID, state types.TaskState, at time.Time, actor string) (*types.ScheduledTransition, error)
+	ListScheduledTransitions(ctx context.Context, projectID *uuid.UUID) ([]*types.ScheduledTransition, error)
+	CancelScheduledTransition(ctx context.Context, id uuid.UUID) error
+	RunDueTransitions(ctx context.Context) ([]*TransitionResult, error)
+	// BlockTask sets a task to blocked because of an external reason, with an
+	// optional reference such as a ticket or URL
+	BlockTask(ctx context.Context, taskID uuid.UUID, reason, reference string, actor string) (*types.Task, error)
 	DeleteTask(ctx context.Context, taskID uuid.UUID, actor string) error
 	DeleteTaskSubtree(ctx context.Context, taskID uuid.UUID, actor string) error
+	DeleteTaskWithChildren(ctx context.Context, taskID uuid.UUID, policy ChildPolicy, newParentID *uuid.UUID, actor string) error
 
 	// Task queries and analysis
 	GetParentTask(ctx context.Context, taskID uuid.UUID) (*types.Task, error)
 	GetChildTasks(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error)
+	// GetDescendants returns the descendants of a task up to maxDepth levels
+	// below it, or all of them if maxDepth is 0
+	GetDescendants(ctx context.Context, taskID uuid.UUID, maxDepth int) ([]*types.Task, error)
+	// GetAncestors returns the ancestors of a task, the root task first and
+	// the direct parent last
+	GetAncestors(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error)
 	GetRootTasks(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error)
 	ListTasksForProject(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error)
+	ListTasksWithDependencies(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error)
+	// ListTasksPage returns one page of the project's tasks matching filter in
+	// creation order, continuing after page.Cursor
+	ListTasksPage(ctx context.Context, projectID uuid.UUID, filter types.TaskFilter, page types.PageRequest) (*types.TaskPage, error)
 	FindNextActionableTask(ctx context.Context, projectID uuid.UUID) (*types.Task, error)
 	FindTasksNeedingBreakdown(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error)
 	GetProjectProgress(ctx context.Context, projectID uuid.UUID) (*types.ProjectProgress, error)
+	// GetSubtreeProgress returns the rollup progress of every task of a project
+	// that has descendants, keyed by task ID
+	GetSubtreeProgress(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]*types.SubtreeProgress, error)
 	ListTasksByState(ctx context.Context, projectID uuid.UUID, state types.TaskState) ([]*types.Task, error)
 	BulkUpdateTasks(ctx context.Context, taskIDs []uuid.UUID, updates types.TaskUpdates, actor string) error
+	ReprioritizeTasks(ctx context.Context, taskIDs []uuid.UUID, priority types.TaskPriority, actor string) ([]*types.Task, error)
+	// CheckStateTransition reports why a task cannot move to the given state, nil if it can
+	CheckStateTransition(task *types.Task, state types.TaskState) error
+	// TransitionTasks moves all given tasks to a state, or none if any transition is invalid
+	TransitionTasks(ctx context.Context, taskIDs []uuid.UUID, state types.TaskState, actor string) ([]*types.Task, error)
 	DuplicateTask(ctx context.Context, taskID uuid.UUID, newProjectID uuid.UUID) (*types.Task, error)
 	SetTaskEstimate(ctx context.Context, taskID uuid.UUID, estimate int64) (*types.Task, error)
+	LogTaskEffort(ctx context.Context, taskID uuid.UUID, minutes int64, note string, actor string) (*types.Task, error)
+	SetTaskTags(ctx context.Context, taskID uuid.UUID, tags []string, actor string) (*types.Task, error)
+	SetTaskKeepComplexity(ctx context.Context, taskID uuid.UUID, keep bool, actor string) (*types.Task, error)
+	SetTaskDueDate(ctx context.Context, taskID uuid.UUID, due *time.Time, actor string) (*types.Task, error)
+	// SetTaskCompletedAt corrects the completion time of a completed task
+	SetTaskCompletedAt(ctx context.Context, taskID uuid.UUID, completedAt time.Time, actor string) (*types.Task, error)
+	ReorderTask(ctx context.Context, taskID, siblingID uuid.UUID, after bool, actor string) ([]*types.Task, error)
+	// MoveTask moves a task with its subtasks below parentID, or to the root
+	// level if parentID is nil, after its new siblings
+	MoveTask(ctx context.Context, taskID uuid.UUID, parentID *uuid.UUID, actor string) (*types.Task, error)
+	AddAcceptanceCriterion(ctx context.Context, taskID uuid.UUID, text string, actor string) (*types.Task, error)
+	SetAcceptanceCriterionVerified(ctx context.Context, taskID uuid.UUID, index int, verified bool, actor string) (*types.Task, error)
+	RemoveAcceptanceCriterion(ctx context.Context, taskID uuid.UUID, index int, actor string) (*types.Task, error)
+	RequestTaskReview(ctx context.Context, taskID uuid.UUID, actor string) (*types.Task, error)
+	ApproveTask(ctx context.Context, taskID uuid.UUID, reviewer, comment string) (*types.Task, error)
+	RejectTask(ctx context.Context, taskID uuid.UUID, reviewer, comment string) (*types.Task, error)
+	// SetTaskCheck sets the command that verifies a task, or removes it if command is empty
+	SetTaskCheck(ctx context.Context, taskID uuid.UUID, command string, required bool, actor string) (*types.Task, error)
+	// RecordTaskCheckRun records the outcome of a run of the task's check command
+	RecordTaskCheckRun(ctx context.Context, taskID uuid.UUID, run types.CheckRun) (*types.Task, error)
+	GetTaskCapacity(ctx context.Context, projectID uuid.UUID, parentID *uuid.UUID) (*TaskCapacity, error)
 
 	// Agent assignment management
 	AssignTaskToAgent(ctx context.Context, taskID uuid.UUID, agentID uuid.UUID) (*types.Task, error)
 	UnassignTaskFromAgent(ctx context.Context, taskID uuid.UUID) (*types.Task, error)
+	// ReassignTask assigns a task to an agent, or unassigns it if agentID is
+	// nil, recording the handoff note for the next assignee
+	ReassignTask(ctx context.Context, taskID uuid.UUID, agentID *uuid.UUID, note string, actor string) (*types.Task, error)
 	ListTasksByAgent(ctx context.Context, projectID uuid.UUID, agentID uuid.UUID) ([]*types.Task, error)
 	ListUnassignedTasks(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error)
 
 	// Dependency management
 	AddTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID, actor string) (*types.Task, error)
+	// SetDependencyLink changes the type and lag of an existing dependency
+	SetDependencyLink(ctx context.Context, taskID uuid.UUID, link types.DependencyLink, actor string) (*types.Task, error)
 	RemoveTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID, actor string) (*types.Task, error)
 	GetTaskDependencies(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error)
 	GetDependentTasks(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error)
 
+	// Project locks
+	LockProject(ctx context.Context, projectID uuid.UUID, reason string, ttl time.Duration, actor string) (*types.ProjectLock, error)
+	UnlockProject(ctx context.Context, projectID uuid.UUID, actor string) error
+	GetProjectLock(ctx context.Context, projectID uuid.UUID) (*types.ProjectLock, error)
+
+	// Users of a shared deployment ('knot serve')
+	CreateUser(ctx context.Context, name string, role types.Role) (*types.User, string, error)
+	ListUsers(ctx context.Context) ([]*types.User, error)
+	SetUserRole(ctx context.Context, name string, projectID *uuid.UUID, role types.Role) (*types.User, error)
+	RevokeUserProjectRole(ctx context.Context, name string, projectID uuid.UUID) (*types.User, error)
+	RotateUserToken(ctx context.Context, name string) (string, error)
+	DeleteUser(ctx context.Context, name string) error
+	AuthenticateUser(ctx context.Context, token string) (*types.User, error)
+
+	// Change feed
+	ListChangeEvents(ctx context.Context, filter types.ChangeEventFilter) ([]*types.ChangeEvent, error)
+
+	// InTransaction runs fn so that the changes made with the context passed
+	// to fn are applied together if it returns nil, and none of them
+	// otherwise. It returns types.ErrTransactionsUnsupported without calling
+	// fn if the storage backend has no transactions.
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
 	// Configuration
 	GetConfig() *Config
 	UpdateConfig(config *Config)
@@ -99,6 +198,9 @@ type ProjectManager interface {
 	SetSelectedProject(ctx context.Context, projectID uuid.UUID, actor string) error
 	ClearSelectedProject(ctx context.Context) error
 	HasSelectedProject(ctx context.Context) (bool, error)
+	GetSessionProject(ctx context.Context, session string) (*uuid.UUID, error)
+	SetSessionProject(ctx context.Context, session string, projectID uuid.UUID, actor string) error
+	ClearSessionProject(ctx context.Context, session string) error
 
 	// Utility methods
 	GetCurrentTime() time.Time
@@ -116,6 +218,687 @@ type Config struct {
 	MaxDepth             int  // Maximum allowed depth
 	MaxDescriptionLength int  // Maximum length for descriptions
 	AutoReduceComplexity bool // Automatically reduce parent task complexity when subtasks are added
+
+	// MaxTasksAtDepth overrides MaxTasksPerDepth per depth level: entry d is the
+	// maximum number of tasks at depth d, 0 to use MaxTasksPerDepth. Deeper
+	// levels than listed use MaxTasksPerDepth.
+	MaxTasksAtDepth []int `json:",omitempty"`
+
+	// AllowLargeBreakdowns exempts subtasks of tasks with a complexity of at least
+	// ComplexityThreshold from the per-depth limits, so a full level does not
+	// block breaking down a large task. The highest complexity the parent had
+	// counts, since AutoReduceComplexity lowers it once it has subtasks.
+	AllowLargeBreakdowns bool `json:",omitempty"`
+
+	// MaxTitleLength is the maximum length of task and project titles, 200 if 0
+	MaxTitleLength int `json:",omitempty"`
+
+	// Markup is "reject" (the default) to reject HTML tags and script-like content
+	// in titles and descriptions, or "allow" to accept them
+	Markup string `json:",omitempty"`
+
+	// BannedPatterns are regular expressions that titles and descriptions must not match
+	BannedPatterns []string `json:",omitempty"`
+
+	// DuplicateCheck decides what 'knot task create' does when a task with a very
+	// similar title exists in the project: "warn" (the default), "block" or "off"
+	DuplicateCheck string `json:",omitempty"`
+
+	// DuplicateThreshold is the title similarity, between 0 and 1, from which a task
+	// counts as a possible duplicate. DefaultDuplicateThreshold if 0.
+	DuplicateThreshold float64 `json:",omitempty"`
+
+	// ProgressWeighting weights tasks in the weighted project progress: "count"
+	// (the default, every task counts the same), "complexity" or "estimate"
+	ProgressWeighting string `json:",omitempty"`
+
+	// SelectionStrategy is the strategy 'knot actionable' and 'knot analyze selection'
+	// use when no --strategy is given, see package selection. Recommended from the
+	// project structure if empty.
+	SelectionStrategy string `json:",omitempty"`
+
+	// SelectionWeights replace the default weights of the named selection
+	// strategies, e.g. as calibrated by 'knot analyze strategy --apply'
+	SelectionWeights map[string]selection.Weights `json:",omitempty"`
+
+	// BlockedEscalationDays is the number of days after which 'knot blocked --aging'
+	// marks a blocked task for escalation. DefaultBlockedEscalationDays if 0,
+	// negative to never escalate.
+	BlockedEscalationDays int `json:",omitempty"`
+
+	// SlowQueryThresholdMs is the duration in milliseconds from which repository
+	// operations and SQL statements are logged as slow and listed by 'knot stats db'.
+	// DefaultSlowQueryThresholdMs if 0, negative to never log slow queries.
+	SlowQueryThresholdMs int `json:",omitempty"`
+
+	// MaxTasksPerProject is the soft limit of tasks in a project: creating tasks
+	// warns from LimitWarningPercent of it on and fails once it is reached.
+	// DefaultMaxTasksPerProject if 0, negative for no limit.
+	MaxTasksPerProject int `json:",omitempty"`
+
+	// MaxDependenciesPerTask is the soft limit of dependencies of a task, warned
+	// about and enforced like MaxTasksPerProject. DefaultMaxDependenciesPerTask
+	// if 0, negative for no limit.
+	MaxDependenciesPerTask int `json:",omitempty"`
+
+	// CreationLimits limit how many tasks actors may create within a time
+	// window, protecting the database from agents stuck in a loop
+	CreationLimits []CreationLimit `json:",omitempty"`
+
+	// AgingPolicies are the maximum ages of open tasks by priority that
+	// 'knot policy check' reports violations of
+	AgingPolicies []AgingPolicy `json:",omitempty"`
+
+	// ReviewRequiredProjects lists the projects whose tasks need an approved review
+	// by a different actor before they can be completed.
+	ReviewRequiredProjects []uuid.UUID `json:",omitempty"`
+
+	// AllowUnverifiedCompletion lets tasks be completed while acceptance criteria are
+	// unverified. By default completion is blocked until all criteria are verified.
+	AllowUnverifiedCompletion bool `json:",omitempty"`
+
+	// PriorityInheritance lets incomplete dependencies inherit the priority of the
+	// tasks depending on them in task selection and listings.
+	PriorityInheritance bool `json:",omitempty"`
+
+	// RequireHandoffNotes makes a handoff note mandatory when a task is
+	// reassigned from one agent to another, or goes back from in-progress to
+	// pending, so the next assignee learns what is done and what is left.
+	RequireHandoffNotes bool `json:",omitempty"`
+
+	// ComplexityReductions maps subtask counts to parent complexity for AutoReduceComplexity.
+	// Empty uses DefaultComplexityReductions.
+	ComplexityReductions []ComplexityReduction `json:",omitempty"`
+
+	// SavedFilters maps filter names to task filter queries, see package filter
+	SavedFilters map[string]string `json:",omitempty"`
+
+	// AgentCapacities maps agent IDs to the minutes of estimated work the agent
+	// can take on per working week. Other agents can take on one working week.
+	AgentCapacities map[uuid.UUID]int64 `json:",omitempty"`
+
+	// Agents is the agent registry: the agents 'knot task claim' picks work for,
+	// with their selection preferences
+	Agents []AgentProfile `json:",omitempty"`
+
+	// Schedules apply templates or create recurring tasks when `knot scheduler run`
+	// finds them due, see package scheduler
+	Schedules []Schedule `json:",omitempty"`
+
+	// Notifications are the hooks `knot notify` delivers events to, see package notify
+	Notifications []NotificationHook `json:",omitempty"`
+
+	// TimeZone is the IANA time zone (e.g. "Europe/Berlin" or "UTC") timestamps
+	// are displayed in, local time if empty. The global --utc flag overrides it.
+	TimeZone string `json:",omitempty"`
+
+	// TimeFormat is the Go time layout of displayed timestamps, e.g.
+	// "2006-01-02 15:04 MST". output.DefaultTimeFormat if empty.
+	TimeFormat string `json:",omitempty"`
+}
+
+// AgentProfile registers an agent by name with the work it should pick up
+type AgentProfile struct {
+	Name string
+	// ID is the agent ID claimed tasks are assigned to
+	ID uuid.UUID
+	// Strategy is the selection strategy of the agent, the configured or
+	// recommended one if empty
+	Strategy string `json:",omitempty"`
+	// Weights replace the default weights of the strategy
+	Weights *selection.Weights `json:",omitempty"`
+	// RequiredTags limit the agent to tasks with at least one of these tags
+	RequiredTags []string `json:",omitempty"`
+	// PreferredTags select tasks with more of these tags first
+	PreferredTags []string `json:",omitempty"`
+}
+
+// SelectionConfig returns the selection configuration for the agent, using
+// the given strategy unless the agent has its own
+func (p *AgentProfile) SelectionConfig(strategy selection.Strategy) *selection.Config {
+	if p.Strategy != "" {
+		strategy = selection.ParseStrategy(p.Strategy)
+	}
+	config := selection.ConfigForStrategy(strategy)
+	if p.Weights != nil {
+		config.Weights = *p.Weights
+	}
+	agentID := p.ID
+	config.Agent = selection.AgentPreferences{
+		AgentID:       &agentID,
+		RequiredTags:  p.RequiredTags,
+		PreferredTags: p.PreferredTags,
+	}
+	return config
+}
+
+// NotificationHook delivers selected events to the desktop or to a Slack or
+// Teams incoming webhook
+type NotificationHook struct {
+	Name string
+	// Channel is desktop, slack or teams
+	Channel string
+	// WebhookURL is the incoming webhook of a slack or teams channel
+	WebhookURL string `json:",omitempty"`
+	// Events are assigned, unblocked and overdue, all of them if empty
+	Events []string `json:",omitempty"`
+	// AgentID limits assigned events to tasks assigned to this agent
+	AgentID *uuid.UUID `json:",omitempty"`
+	// ProjectID limits the hook to one project
+	ProjectID *uuid.UUID `json:",omitempty"`
+}
+
+// Schedule applies a template, or creates a task, whenever its cron expression
+// is due. Placeholders in titles, descriptions and template variables are
+// expanded, with {{date}} being the date of the occurrence.
+type Schedule struct {
+	Name      string
+	Cron      string
+	ProjectID uuid.UUID
+	// ParentID is the parent of the created root tasks, if any
+	ParentID *uuid.UUID `json:",omitempty"`
+
+	// Template is the name of the template to apply with Variables
+	Template  string            `json:",omitempty"`
+	Variables map[string]string `json:",omitempty"`
+
+	// Task is the recurring task to create, used when Template is empty
+	Task *ScheduledTask `json:",omitempty"`
+}
+
+// ScheduledTask is the task a schedule creates
+type ScheduledTask struct {
+	Title       string
+	Description string `json:",omitempty"`
+	// Complexity defaults to 1
+	Complexity int `json:",omitempty"`
+	// Priority is low, medium or high, medium by default
+	Priority string `json:",omitempty"`
+}
+
+// CreationLimit limits how many tasks actors matching a pattern may create
+// within a time window, e.g. 50 per hour for "agent-*". Every actor matching
+// the pattern is counted on their own.
+type CreationLimit struct {
+	// Actor is a pattern of actor names as matched by path.Match, e.g. "agent-*"
+	Actor    string
+	MaxTasks int
+	// WindowMinutes is the length of the window in minutes, 60 if 0
+	WindowMinutes int `json:",omitempty"`
+}
+
+// Window returns the length of the window the tasks are counted in
+func (l *CreationLimit) Window() time.Duration {
+	if l.WindowMinutes == 0 {
+		return time.Hour
+	}
+	return time.Duration(l.WindowMinutes) * time.Minute
+}
+
+// Matches reports whether the limit applies to actor
+func (l *CreationLimit) Matches(actor string) bool {
+	matched, err := path.Match(l.Actor, actor)
+	return err == nil && matched
+}
+
+// AgingPolicy flags tasks of a priority that have been in a state for longer
+// than MaxAgeDays, e.g. high-priority tasks pending for more than 3 days
+type AgingPolicy struct {
+	// Priority is low, medium or high
+	Priority string
+	// State is pending, in-progress or blocked, pending if empty
+	State      string `json:",omitempty"`
+	MaxAgeDays int
+}
+
+// TaskState returns the state the policy applies to
+func (p *AgingPolicy) TaskState() types.TaskState {
+	if p.State == "" {
+		return types.TaskStatePending
+	}
+	return types.TaskState(p.State)
+}
+
+// ComplexityReduction is one step of the auto-reduce table. Once a parent has at
+// least MinSubtasks subtasks its complexity is set to Complexity, or lowered by
+// ReduceBy when Complexity is 0.
+type ComplexityReduction struct {
+	MinSubtasks int
+	Complexity  int `json:",omitempty"`
+	ReduceBy    int `json:",omitempty"`
+}
+
+// DefaultComplexityReductions returns the default auto-reduce table: high complexity
+// tasks become coordination tasks as they are broken down.
+func DefaultComplexityReductions() []ComplexityReduction {
+	return []ComplexityReduction{
+		{MinSubtasks: 1, ReduceBy: 2},   // First subtask: e.g. 9 -> 7
+		{MinSubtasks: 2, Complexity: 4}, // 2-3 subtasks: coordination level
+		{MinSubtasks: 4, Complexity: 3}, // 4-5 subtasks: oversight level
+		{MinSubtasks: 6, Complexity: 2}, // Many subtasks: minimal coordination
+	}
+}
+
+// Validator returns the input validator for the configured validation rules
+func (c *Config) Validator() (*validation.InputValidator, error) {
+	return validation.NewInputValidatorWithRules(validation.Rules{
+		MaxTitleLength:       c.MaxTitleLength,
+		MaxDescriptionLength: c.MaxDescriptionLength,
+		Markup:               c.Markup,
+		BannedPatterns:       c.BannedPatterns,
+	})
+}
+
+// Duplicate check modes of Config.DuplicateCheck
+const (
+	DuplicateCheckWarn  = "warn"
+	DuplicateCheckBlock = "block"
+	DuplicateCheckOff   = "off"
+)
+
+// DefaultDuplicateThreshold is the title similarity from which a task counts as
+// a possible duplicate
+const DefaultDuplicateThreshold = 0.8
+
+// DuplicateCheckMode returns the configured duplicate check mode, warn if unset
+func (c *Config) DuplicateCheckMode() string {
+	if c.DuplicateCheck == "" {
+		return DuplicateCheckWarn
+	}
+	return c.DuplicateCheck
+}
+
+// Progress weightings of Config.ProgressWeighting
+const (
+	ProgressWeightingCount      = "count"
+	ProgressWeightingComplexity = "complexity"
+	ProgressWeightingEstimate   = "estimate"
+)
+
+// ProgressWeightingMode returns the configured progress weighting, count if unset
+func (c *Config) ProgressWeightingMode() string {
+	if c.ProgressWeighting == "" {
+		return ProgressWeightingCount
+	}
+	return c.ProgressWeighting
+}
+
+// TimeLocation returns the configured display time zone, local time if unset
+func (c *Config) TimeLocation() (*time.Location, error) {
+	if c.TimeZone == "" {
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("time_zone '%s' is not a known time zone: %w", c.TimeZone, err)
+	}
+	return location, nil
+}
+
+// DuplicateSimilarity returns the configured duplicate threshold, or the default
+func (c *Config) DuplicateSimilarity() float64 {
+	if c.DuplicateThreshold == 0 {
+		return DefaultDuplicateThreshold
+	}
+	return c.DuplicateThreshold
+}
+
+// DefaultBlockedEscalationDays is the number of days after which a blocked task
+// is marked for escalation
+const DefaultBlockedEscalationDays = 7
+
+// BlockedEscalationThreshold returns the number of days after which a blocked
+// task is marked for escalation, 0 if blocked tasks are never escalated
+func (c *Config) BlockedEscalationThreshold() int {
+	switch {
+	case c.BlockedEscalationDays == 0:
+		return DefaultBlockedEscalationDays
+	case c.BlockedEscalationDays < 0:
+		return 0
+	}
+	return c.BlockedEscalationDays
+}
+
+// DefaultSlowQueryThresholdMs is the duration in milliseconds from which a
+// query is logged as slow
+const DefaultSlowQueryThresholdMs = 200
+
+// SlowQueryThreshold returns the duration from which a query is logged as
+// slow, 0 if slow queries are never logged
+func (c *Config) SlowQueryThreshold() time.Duration {
+	switch {
+	case c.SlowQueryThresholdMs == 0:
+		return DefaultSlowQueryThresholdMs * time.Millisecond
+	case c.SlowQueryThresholdMs < 0:
+		return 0
+	}
+	return time.Duration(c.SlowQueryThresholdMs) * time.Millisecond
+}
+
+// DefaultMaxTasksPerProject is the number of tasks a project can have
+const DefaultMaxTasksPerProject = 500
+
+// DefaultMaxDependenciesPerTask is the number of dependencies a task can have
+const DefaultMaxDependenciesPerTask = 20
+
+// LimitWarningPercent is the share of a soft limit in percent from which
+// approaching it is warned about
+const LimitWarningPercent = 80
+
+// ProjectTaskLimit returns the number of tasks a project can have, 0 if the
+// number is not limited
+func (c *Config) ProjectTaskLimit() int {
+	switch {
+	case c.MaxTasksPerProject == 0:
+		return DefaultMaxTasksPerProject
+	case c.MaxTasksPerProject < 0:
+		return 0
+	}
+	return c.MaxTasksPerProject
+}
+
+// DependencyLimit returns the number of dependencies a task can have, 0 if
+// the number is not limited
+func (c *Config) DependencyLimit() int {
+	switch {
+	case c.MaxDependenciesPerTask == 0:
+		return DefaultMaxDependenciesPerTask
+	case c.MaxDependenciesPerTask < 0:
+		return 0
+	}
+	return c.MaxDependenciesPerTask
+}
+
+// NearLimit reports whether count has reached LimitWarningPercent of limit,
+// a limit of 0 is never near
+func NearLimit(count, limit int) bool {
+	return limit > 0 && count*100 >= limit*LimitWarningPercent
+}
+
+// StrategyWeights returns the configured weights of a selection strategy, or
+// its default weights if none are configured
+func (c *Config) StrategyWeights(strategy selection.Strategy) selection.Weights {
+	if weights, ok := c.SelectionWeights[strategy.String()]; ok {
+		return weights
+	}
+	return (&selection.StrategyFactory{}).GetDefaultWeightsForStrategy(strategy)
+}
+
+// SetStrategyWeights sets the weights of a selection strategy
+func (c *Config) SetStrategyWeights(strategy selection.Strategy, weights selection.Weights) {
+	all := make(map[string]selection.Weights, len(c.SelectionWeights)+1)
+	for name, w := range c.SelectionWeights {
+		all[name] = w
+	}
+	all[strategy.String()] = weights
+	c.SelectionWeights = all
+}
+
+// SelectionConfig returns the selection configuration for a strategy with the
+// configured weights and priority inheritance
+func (c *Config) SelectionConfig(strategy selection.Strategy) *selection.Config {
+	config := selection.ConfigForStrategy(strategy)
+	config.Weights = c.StrategyWeights(strategy)
+	config.Behavior.PriorityInheritance = c.PriorityInheritance
+	return config
+}
+
+// RequiresReview reports whether tasks of the project need an approved review to complete
+func (c *Config) RequiresReview(projectID uuid.UUID) bool {
+	for _, id := range c.ReviewRequiredProjects {
+		if id == projectID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetReviewRequired enables or disables the review requirement for a project
+func (c *Config) SetReviewRequired(projectID uuid.UUID, required bool) {
+	projects := make([]uuid.UUID, 0, len(c.ReviewRequiredProjects)+1)
+	for _, id := range c.ReviewRequiredProjects {
+		if id != projectID {
+			projects = append(projects, id)
+		}
+	}
+	if required {
+		projects = append(projects, projectID)
+	}
+	c.ReviewRequiredProjects = projects
+}
+
+// SetMaxTasksAt sets the maximum number of tasks at depth, or removes the
+// depth's own limit if limit is 0 so MaxTasksPerDepth applies again
+func (c *Config) SetMaxTasksAt(depth, limit int) {
+	limits := make([]int, max(len(c.MaxTasksAtDepth), depth+1))
+	copy(limits, c.MaxTasksAtDepth)
+	limits[depth] = limit
+	for len(limits) > 0 && limits[len(limits)-1] == 0 {
+		limits = limits[:len(limits)-1]
+	}
+	if len(limits) == 0 {
+		limits = nil
+	}
+	c.MaxTasksAtDepth = limits
+}
+
+// SetSavedFilter saves a task filter query under a name, replacing a filter with the same name
+func (c *Config) SetSavedFilter(name, query string) {
+	filters := make(map[string]string, len(c.SavedFilters)+1)
+	for existing, q := range c.SavedFilters {
+		filters[existing] = q
+	}
+	filters[name] = query
+	c.SavedFilters = filters
+}
+
+// DeleteSavedFilter removes a saved filter and reports whether it existed
+func (c *Config) DeleteSavedFilter(name string) bool {
+	if _, ok := c.SavedFilters[name]; !ok {
+		return false
+	}
+	filters := make(map[string]string, len(c.SavedFilters))
+	for existing, q := range c.SavedFilters {
+		if existing != name {
+			filters[existing] = q
+		}
+	}
+	c.SavedFilters = filters
+	return true
+}
+
+// Agent returns the registered agent with the given name or ID
+func (c *Config) Agent(nameOrID string) (*AgentProfile, bool) {
+	for i := range c.Agents {
+		if c.Agents[i].Name == nameOrID || c.Agents[i].ID.String() == nameOrID {
+			profile := c.Agents[i]
+			return &profile, true
+		}
+	}
+	return nil, false
+}
+
+// SetAgent registers an agent, replacing a registered agent with the same name
+func (c *Config) SetAgent(profile AgentProfile) {
+	agents := make([]AgentProfile, 0, len(c.Agents)+1)
+	replaced := false
+	for _, existing := range c.Agents {
+		if existing.Name == profile.Name {
+			existing = profile
+			replaced = true
+		}
+		agents = append(agents, existing)
+	}
+	if !replaced {
+		agents = append(agents, profile)
+	}
+	c.Agents = agents
+}
+
+// RemoveAgent removes a registered agent and reports whether it was registered
+func (c *Config) RemoveAgent(name string) bool {
+	agents := make([]AgentProfile, 0, len(c.Agents))
+	for _, existing := range c.Agents {
+		if existing.Name != name {
+			agents = append(agents, existing)
+		}
+	}
+	if len(agents) == len(c.Agents) {
+		return false
+	}
+	c.Agents = agents
+	return true
+}
+
+// AgentCapacity returns the minutes of estimated work an agent can take on per working week
+func (c *Config) AgentCapacity(agentID uuid.UUID) int64 {
+	if minutes, ok := c.AgentCapacities[agentID]; ok {
+		return minutes
+	}
+	return utils.MinutesPerWeek
+}
+
+// SetAgentCapacity sets the minutes of estimated work an agent can take on per working week
+func (c *Config) SetAgentCapacity(agentID uuid.UUID, minutes int64) {
+	capacities := make(map[uuid.UUID]int64, len(c.AgentCapacities)+1)
+	for existing, m := range c.AgentCapacities {
+		capacities[existing] = m
+	}
+	capacities[agentID] = minutes
+	c.AgentCapacities = capacities
+}
+
+// ResetAgentCapacity removes the configured capacity of an agent and reports
+// whether one was set
+func (c *Config) ResetAgentCapacity(agentID uuid.UUID) bool {
+	if _, ok := c.AgentCapacities[agentID]; !ok {
+		return false
+	}
+	capacities := make(map[uuid.UUID]int64, len(c.AgentCapacities))
+	for existing, m := range c.AgentCapacities {
+		if existing != agentID {
+			capacities[existing] = m
+		}
+	}
+	c.AgentCapacities = capacities
+	return true
+}
+
+// Reductions returns the configured auto-reduce table, or the default table if none is set
+func (c *Config) Reductions() []ComplexityReduction {
+	if len(c.ComplexityReductions) == 0 {
+		return DefaultComplexityReductions()
+	}
+	return c.ComplexityReductions
+}
+
+// ReductionFor returns the auto-reduce step that applies to a parent with the given
+// number of subtasks, and false if no step applies.
+func (c *Config) ReductionFor(subtasks int) (ComplexityReduction, bool) {
+	var step ComplexityReduction
+	found := false
+	for _, r := range c.Reductions() {
+		if subtasks >= r.MinSubtasks && (!found || r.MinSubtasks > step.MinSubtasks) {
+			step, found = r, true
+		}
+	}
+	return step, found
+}
+
+// ReducedComplexity returns the parent complexity the auto-reduce table yields for the
+// given number of subtasks, and false if no step applies.
+func (c *Config) ReducedComplexity(current, subtasks int) (int, bool) {
+	step, ok := c.ReductionFor(subtasks)
+	if !ok {
+		return current, false
+	}
+
+	reduced := step.Complexity
+	if reduced == 0 {
+		reduced = current - step.ReduceBy
+	}
+	if reduced < 1 {
+		reduced = 1
+	}
+	return reduced, true
+}
+
+// MaxTasksAt returns the maximum number of tasks at depth, from
+// MaxTasksAtDepth if it sets one, otherwise MaxTasksPerDepth
+func (c *Config) MaxTasksAt(depth int) int {
+	if depth >= 0 && depth < len(c.MaxTasksAtDepth) && c.MaxTasksAtDepth[depth] > 0 {
+		return c.MaxTasksAtDepth[depth]
+	}
+	return c.MaxTasksPerDepth
+}
+
+// ExemptFromDepthLimit reports whether subtasks of a parent whose complexity
+// reached peakComplexity may be created beyond the per-depth limits
+func (c *Config) ExemptFromDepthLimit(peakComplexity int) bool {
+	return c.AllowLargeBreakdowns && peakComplexity >= c.ComplexityThreshold
+}
+
+// RemainingAt returns how many more tasks can be created at depth when count
+// tasks already exist there, or 0 if depth exceeds MaxDepth
+func (c *Config) RemainingAt(depth, count int) int {
+	limit := c.MaxTasksAt(depth)
+	if depth > c.MaxDepth || count >= limit {
+		return 0
+	}
+	return limit - count
+}
+
+// DepthCapacity is the task count and remaining capacity at one depth level
+type DepthCapacity struct {
+	Depth     int `json:"depth"`
+	Count     int `json:"count"`
+	Max       int `json:"max"`
+	Remaining int `json:"remaining"`
+}
+
+// TaskCapacity describes how many tasks can still be created in a project,
+// either as root tasks or below a given parent
+type TaskCapacity struct {
+	ProjectID uuid.UUID  `json:"project_id"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+	// TargetDepth is the depth a new task would be created at
+	TargetDepth int `json:"target_depth"`
+	MaxDepth    int `json:"max_depth"`
+	// Remaining is the number of tasks that can still be created at TargetDepth,
+	// at most the number the project can still take. It is -1 if the number is
+	// not limited, i.e. the parent is DepthLimitExempt and the project has no
+	// task limit.
+	Remaining int `json:"remaining"`
+	// DepthLimitExempt is set if subtasks of the parent may exceed the limit of
+	// TargetDepth, see Config.AllowLargeBreakdowns
+	DepthLimitExempt bool            `json:"depth_limit_exempt,omitempty"`
+	Depths           []DepthCapacity `json:"depths"`
+	// ProjectTasks is the number of tasks of the project and MaxProjectTasks
+	// its limit, 0 if the number is not limited
+	ProjectTasks    int `json:"project_tasks"`
+	MaxProjectTasks int `json:"max_project_tasks"`
+}
+
+// ChildPolicy decides what happens to the children of a deleted task
+type ChildPolicy string
+
+const (
+	// ChildPolicyPromote moves the children up to the parent of the deleted task
+	ChildPolicyPromote ChildPolicy = "promote"
+	// ChildPolicyReparent moves the children below another task
+	ChildPolicyReparent ChildPolicy = "reparent-to"
+	// ChildPolicyDelete deletes the children together with the task
+	ChildPolicyDelete ChildPolicy = "delete"
+)
+
+// ParseChildPolicy parses the name of a child policy
+func ParseChildPolicy(name string) (ChildPolicy, error) {
+	switch policy := ChildPolicy(name); policy {
+	case ChildPolicyPromote, ChildPolicyReparent, ChildPolicyDelete:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown child policy %q, use promote, reparent-to or delete", name)
+	}
 }
 
 // DefaultConfig returns a sensible default configuration
@@ -126,5 +909,6 @@ func DefaultConfig() *Config {
 		MaxDepth:             5,
 		MaxDescriptionLength: 2000, // Default maximum description length
 		AutoReduceComplexity: true, // Enable auto-reduce by default
+		ComplexityReductions: DefaultComplexityReductions(),
 	}
 }
@@ -0,0 +1,251 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// DefaultLockTTL is how long a project lock lasts unless a TTL is given
+const DefaultLockTTL = 2 * time.Hour
+
+// ProjectLockedError reports a write to a project locked by another actor
+type ProjectLockedError struct {
+	Lock *types.ProjectLock
+}
+
+func (e *ProjectLockedError) Error() string {
+	msg := fmt.Sprintf("project locked by %s until %s", e.Lock.Owner, e.Lock.ExpiresAt.Local().Format(time.RFC3339))
+	if e.Lock.Reason != "" {
+		msg += fmt.Sprintf(" (%s)", e.Lock.Reason)
+	}
+	return msg
+}
+
+type writerContextKey struct{}
+
+// writer identifies who performs the repository writes of a context
+type writer struct {
+	actor       string
+	ignoreLocks bool
+}
+
+// WithWriter attaches the acting writer to ctx. Writes to a project locked by
+// another actor fail with ProjectLockedError unless ignoreLocks is set.
+func WithWriter(ctx context.Context, actor string, ignoreLocks bool) context.Context {
+	return context.WithValue(ctx, writerContextKey{}, writer{actor: actor, ignoreLocks: ignoreLocks})
+}
+
+func writerFrom(ctx context.Context) writer {
+	if ctx == nil {
+		return writer{}
+	}
+	w, _ := ctx.Value(writerContextKey{}).(writer)
+	return w
+}
+
+// LockProject places an advisory lock on a project for actor. Locking a
+// project again as its owner extends the lock; taking over the lock of
+// another actor requires a context created with ignoreLocks.
+func (s *service) LockProject(ctx context.Context, projectID uuid.UUID, reason string, ttl time.Duration, actor string) (*types.ProjectLock, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("lock duration must be positive, got %s", ttl)
+	}
+	if _, err := s.repo.GetProject(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	now := s.GetCurrentTime()
+	current, err := s.GetProjectLock(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.Owner != actor && !writerFrom(ctx).ignoreLocks {
+		return nil, &ProjectLockedError{Lock: current}
+	}
+
+	lock := &types.ProjectLock{
+		ProjectID:  projectID,
+		Owner:      actor,
+		Reason:     reason,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(ttl),
+	}
+	if err := s.repo.SaveProjectLock(ctx, lock); err != nil {
+		return nil, fmt.Errorf("failed to lock project: %w", err)
+	}
+	return lock, nil
+}
+
+// UnlockProject releases the lock of a project. Releasing the lock of
+// another actor requires a context created with ignoreLocks.
+func (s *service) UnlockProject(ctx context.Context, projectID uuid.UUID, actor string) error {
+	current, err := s.GetProjectLock(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return fmt.Errorf("project %s is not locked", projectID)
+	}
+	if current.Owner != actor && !writerFrom(ctx).ignoreLocks {
+		return &ProjectLockedError{Lock: current}
+	}
+
+	if err := s.repo.DeleteProjectLock(ctx, projectID); err != nil {
+		return fmt.Errorf("failed to unlock project: %w", err)
+	}
+	return nil
+}
+
+// GetProjectLock returns the active lock of a project, or nil if the project
+// is not locked or its lock has expired
+func (s *service) GetProjectLock(ctx context.Context, projectID uuid.UUID) (*types.ProjectLock, error) {
+	lock, err := s.repo.GetProjectLock(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project lock: %w", err)
+	}
+	if lock == nil || lock.Expired(s.GetCurrentTime()) {
+		return nil, nil
+	}
+	return lock, nil
+}
+
+// lockGuard rejects writes to projects locked by an actor other than the
+// writer of the context. Reads are passed through unchanged.
+type lockGuard struct {
+	types.Repository
+	clock types.Clock
+}
+
+// guardProjectLocks wraps repo so its writes honor project locks, which
+// expire by clock
+func guardProjectLocks(repo types.Repository, clock types.Clock) types.Repository {
+	return &lockGuard{Repository: repo, clock: clock}
+}
+
+func (g *lockGuard) checkProject(ctx context.Context, projectID uuid.UUID) error {
+	w := writerFrom(ctx)
+	if w.ignoreLocks {
+		return nil
+	}
+
+	lock, err := g.Repository.GetProjectLock(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to check project lock: %w", err)
+	}
+	if lock == nil || lock.Expired(g.clock.Now()) || lock.Owner == w.actor {
+		return nil
+	}
+	return &ProjectLockedError{Lock: lock}
+}
+
+func (g *lockGuard) checkTask(ctx context.Context, taskID uuid.UUID) error {
+	if writerFrom(ctx).ignoreLocks {
+		return nil
+	}
+
+	task, err := g.Repository.GetTask(ctx, taskID)
+	if err != nil {
+		// Let the write itself report the missing task
+		return nil
+	}
+	return g.checkProject(ctx, task.ProjectID)
+}
+
+// ListChangeEvents forwards to the change feed of the wrapped repository
+func (g *lockGuard) ListChangeEvents(ctx context.Context, filter types.ChangeEventFilter) ([]*types.ChangeEvent, error) {
+	feed, ok := g.Repository.(types.ChangeFeed)
+	if !ok {
+		return nil, fmt.Errorf("the storage backend does not record change events")
+	}
+	return feed.ListChangeEvents(ctx, filter)
+}
+
+// InTransaction forwards to the transactions of the wrapped repository
+func (g *lockGuard) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	transactor, ok := g.Repository.(types.Transactor)
+	if !ok {
+		return types.ErrTransactionsUnsupported
+	}
+	return transactor.InTransaction(ctx, fn)
+}
+
+func (g *lockGuard) UpdateProject(ctx context.Context, project *types.Project) error {
+	if err := g.checkProject(ctx, project.ID); err != nil {
+		return err
+	}
+	return g.Repository.UpdateProject(ctx, project)
+}
+
+func (g *lockGuard) DeleteProject(ctx context.Context, id uuid.UUID) error {
+	if err := g.checkProject(ctx, id); err != nil {
+		return err
+	}
+	return g.Repository.DeleteProject(ctx, id)
+}
+
+func (g *lockGuard) CreateTask(ctx context.Context, task *types.Task) error {
+	if err := g.checkProject(ctx, task.ProjectID); err != nil {
+		return err
+	}
+	return g.Repository.CreateTask(ctx, task)
+}
+
+func (g *lockGuard) UpdateTask(ctx context.Context, task *types.Task) error {
+	if err := g.checkProject(ctx, task.ProjectID); err != nil {
+		return err
+	}
+	return g.Repository.UpdateTask(ctx, task)
+}
+
+func (g *lockGuard) DeleteTask(ctx context.Context, id uuid.UUID) error {
+	if err := g.checkTask(ctx, id); err != nil {
+		return err
+	}
+	return g.Repository.DeleteTask(ctx, id)
+}
+
+func (g *lockGuard) DeleteTaskSubtree(ctx context.Context, taskID uuid.UUID) error {
+	if err := g.checkTask(ctx, taskID); err != nil {
+		return err
+	}
+	return g.Repository.DeleteTaskSubtree(ctx, taskID)
+}
+
+func (g *lockGuard) MoveTask(ctx context.Context, taskID uuid.UUID, parentID *uuid.UUID) error {
+	if err := g.checkTask(ctx, taskID); err != nil {
+		return err
+	}
+	return g.Repository.MoveTask(ctx, taskID, parentID)
+}
+
+func (g *lockGuard) AddTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID) (*types.Task, error) {
+	if err := g.checkTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return g.Repository.AddTaskDependency(ctx, taskID, dependsOnTaskID)
+}
+
+func (g *lockGuard) SetDependencyLink(ctx context.Context, taskID uuid.UUID, link types.DependencyLink) (*types.Task, error) {
+	if err := g.checkTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return g.Repository.SetDependencyLink(ctx, taskID, link)
+}
+
+func (g *lockGuard) RemoveTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID) (*types.Task, error) {
+	if err := g.checkTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return g.Repository.RemoveTaskDependency(ctx, taskID, dependsOnTaskID)
+}
+
+func (g *lockGuard) SaveScheduledTransition(ctx context.Context, transition *types.ScheduledTransition) error {
+	if err := g.checkProject(ctx, transition.ProjectID); err != nil {
+		return err
+	}
+	return g.Repository.SaveScheduledTransition(ctx, transition)
+}
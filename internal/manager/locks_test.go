@@ -0,0 +1,135 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProjectLocks tests that locked projects reject writes by other actors
+func TestProjectLocks(t *testing.T) {
+	repos := map[string]func(t *testing.T) (types.Repository, func()){
+		"inmemory": func(t *testing.T) (types.Repository, func()) {
+			return inmemory.NewMemoryRepository(), func() {}
+		},
+		"sqlite": setupSQLiteTestRepository,
+	}
+
+	for name, setup := range repos {
+		t.Run(name, func(t *testing.T) {
+			repo, cleanup := setup(t)
+			defer cleanup()
+			service := NewManagerWithRepository(repo, DefaultConfig())
+
+			agent := WithWriter(context.Background(), "agent", false)
+			human := WithWriter(context.Background(), "human", false)
+			forced := WithWriter(context.Background(), "human", true)
+
+			project, err := service.CreateProject(agent, "Locked", "", "agent")
+			require.NoError(t, err)
+			task, err := service.CreateTask(agent, project.ID, nil, "Task", "", 3, types.TaskPriorityMedium, "agent")
+			require.NoError(t, err)
+
+			lock, err := service.LockProject(agent, project.ID, "agent session", time.Hour, "agent")
+			require.NoError(t, err)
+			assert.Equal(t, "agent", lock.Owner)
+
+			current, err := service.GetProjectLock(agent, project.ID)
+			require.NoError(t, err)
+			require.NotNil(t, current)
+			assert.Equal(t, "agent session", current.Reason)
+
+			t.Run("owner can write", func(t *testing.T) {
+				_, err := service.UpdateTaskState(agent, task.ID, types.TaskStateInProgress, "agent")
+				assert.NoError(t, err)
+			})
+
+			t.Run("other actors are rejected", func(t *testing.T) {
+				_, err := service.UpdateTaskTitle(human, task.ID, "Renamed", "human")
+				var lockedErr *ProjectLockedError
+				require.True(t, errors.As(err, &lockedErr), "expected ProjectLockedError, got %v", err)
+				assert.Contains(t, err.Error(), "project locked by agent until")
+				assert.Contains(t, err.Error(), "(agent session)")
+
+				_, err = service.CreateTask(human, project.ID, nil, "Other", "", 3, types.TaskPriorityMedium, "human")
+				assert.True(t, errors.As(err, &lockedErr))
+				assert.True(t, errors.As(service.DeleteTask(human, task.ID, "human"), &lockedErr))
+
+				_, err = service.LockProject(human, project.ID, "", time.Hour, "human")
+				assert.True(t, errors.As(err, &lockedErr))
+				assert.True(t, errors.As(service.UnlockProject(human, project.ID, "human"), &lockedErr))
+
+				// Reads are not affected
+				_, err = service.GetTask(human, task.ID)
+				assert.NoError(t, err)
+			})
+
+			t.Run("force overrides the lock", func(t *testing.T) {
+				updated, err := service.UpdateTaskTitle(forced, task.ID, "Forced", "human")
+				require.NoError(t, err)
+				assert.Equal(t, "Forced", updated.Title)
+			})
+
+			t.Run("unlock", func(t *testing.T) {
+				require.NoError(t, service.UnlockProject(agent, project.ID, "agent"))
+				assert.Error(t, service.UnlockProject(agent, project.ID, "agent"), "unlocking twice must fail")
+
+				_, err := service.UpdateTaskTitle(human, task.ID, "Unlocked", "human")
+				assert.NoError(t, err)
+			})
+
+			t.Run("expired locks are ignored", func(t *testing.T) {
+				require.NoError(t, repo.SaveProjectLock(agent, &types.ProjectLock{
+					ProjectID:  project.ID,
+					Owner:      "agent",
+					AcquiredAt: time.Now().Add(-2 * time.Hour),
+					ExpiresAt:  time.Now().Add(-time.Hour),
+				}))
+
+				current, err := service.GetProjectLock(human, project.ID)
+				require.NoError(t, err)
+				assert.Nil(t, current)
+
+				_, err = service.UpdateTaskTitle(human, task.ID, "After expiry", "human")
+				assert.NoError(t, err)
+
+				_, err = service.LockProject(human, project.ID, "", time.Hour, "human")
+				assert.NoError(t, err, "an expired lock can be taken over")
+			})
+		})
+	}
+}
+
+type testClock struct{ now time.Time }
+
+func (c *testClock) Now() time.Time { return c.now }
+
+// TestProjectLockExpiryFollowsRepositoryClock tests that locks expire by the
+// clock of a repository that has one
+func TestProjectLockExpiryFollowsRepositoryClock(t *testing.T) {
+	clock := &testClock{now: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)}
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(inmemory.WithClock(clock)), DefaultConfig())
+	agent := WithWriter(context.Background(), "agent", false)
+	human := WithWriter(context.Background(), "human", false)
+
+	project, err := service.CreateProject(agent, "Locked", "", "agent")
+	require.NoError(t, err)
+	lock, err := service.LockProject(agent, project.ID, "agent session", time.Hour, "agent")
+	require.NoError(t, err)
+	assert.Equal(t, clock.now.Add(time.Hour), lock.ExpiresAt)
+
+	_, err = service.CreateTask(human, project.ID, nil, "Blocked", "", 3, types.TaskPriorityMedium, "human")
+	var locked *ProjectLockedError
+	require.ErrorAs(t, err, &locked)
+
+	clock.now = clock.now.Add(2 * time.Hour)
+	task, err := service.CreateTask(human, project.ID, nil, "Allowed", "", 3, types.TaskPriorityMedium, "human")
+	require.NoError(t, err)
+	assert.Equal(t, clock.now, task.CreatedAt.UTC())
+}
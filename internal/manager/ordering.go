@@ -0,0 +1,151 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	knoterrors "github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// siblingTasks returns the tasks sharing a parent, or the root tasks of the
+// project if parentID is nil, in sibling order
+func (s *service) siblingTasks(ctx context.Context, projectID uuid.UUID, parentID *uuid.UUID) ([]*types.Task, error) {
+	var (
+		siblings []*types.Task
+		err      error
+	)
+	if parentID == nil {
+		siblings, err = s.repo.GetRootTasks(ctx, projectID)
+	} else {
+		siblings, err = s.repo.GetTasksByParent(ctx, *parentID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sibling tasks: %w", err)
+	}
+	types.SortSiblings(siblings)
+	return siblings, nil
+}
+
+// nextSiblingPosition returns the position that places a new task after all
+// of its siblings
+func (s *service) nextSiblingPosition(ctx context.Context, projectID uuid.UUID, parentID *uuid.UUID) (int, error) {
+	siblings, err := s.siblingTasks(ctx, projectID, parentID)
+	if err != nil {
+		return 0, err
+	}
+	if len(siblings) == 0 {
+		return 0, nil
+	}
+	return siblings[len(siblings)-1].Position + 1, nil
+}
+
+// ReorderTask moves a task directly before, or after if after is set, one of
+// its siblings and returns the siblings in their new order. Siblings are
+// renumbered from 0, so only tasks whose position changes are updated.
+func (s *service) ReorderTask(ctx context.Context, taskID, siblingID uuid.UUID, after bool, actor string) ([]*types.Task, error) {
+	if taskID == siblingID {
+		return nil, fmt.Errorf("cannot order task %s relative to itself", taskID)
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	sibling, err := s.repo.GetTask(ctx, siblingID)
+	if err != nil {
+		return nil, fmt.Errorf("sibling task not found: %w", err)
+	}
+	if task.ProjectID != sibling.ProjectID || !sameParent(task.ParentID, sibling.ParentID) {
+		return nil, fmt.Errorf("task %s and task %s are not siblings: only tasks with the same parent can be reordered", taskID, siblingID)
+	}
+
+	siblings, err := s.siblingTasks(ctx, task.ProjectID, task.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	siblings = slices.DeleteFunc(siblings, func(t *types.Task) bool { return t.ID == taskID })
+	index := slices.IndexFunc(siblings, func(t *types.Task) bool { return t.ID == siblingID })
+	if index < 0 {
+		return nil, fmt.Errorf("sibling task %s not found among the children of its parent", siblingID)
+	}
+	if after {
+		index++
+	}
+	siblings = slices.Insert(siblings, index, task)
+
+	for position, t := range siblings {
+		if t.Position == position {
+			continue
+		}
+		t.Position = position
+		t.UpdatedBy = actor
+		t.UpdatedAt = s.GetCurrentTime()
+		if err := s.repo.UpdateTask(ctx, t); err != nil {
+			return nil, fmt.Errorf("failed to update position of task %s: %w", t.ID, err)
+		}
+	}
+
+	return siblings, nil
+}
+
+// MoveTask moves a task with its subtasks below parentID, or to the root level
+// if parentID is nil, placing it after its new siblings. The moved subtree
+// must stay within MaxDepth.
+func (s *service) MoveTask(ctx context.Context, taskID uuid.UUID, parentID *uuid.UUID, actor string) (*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if sameParent(task.ParentID, parentID) {
+		return task, nil
+	}
+
+	depth, err := s.validateParentAndCalculateDepth(ctx, parentID, task.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	descendants, err := s.GetDescendants(ctx, taskID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtasks: %w", err)
+	}
+	deepest := depth
+	for _, descendant := range append(descendants, task) {
+		if parentID != nil && descendant.ID == *parentID {
+			return nil, fmt.Errorf("task %s cannot be moved below itself or one of its subtasks", taskID)
+		}
+		deepest = max(deepest, descendant.Depth-task.Depth+depth)
+	}
+	if deepest > s.config.MaxDepth {
+		return nil, knoterrors.MaxDepthExceededError(deepest, s.config.MaxDepth)
+	}
+
+	position, err := s.nextSiblingPosition(ctx, task.ProjectID, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.MoveTask(ctx, taskID, parentID); err != nil {
+		return nil, fmt.Errorf("failed to move task: %w", err)
+	}
+	moved, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	moved.Position = position
+	moved.UpdatedBy = actor
+	moved.UpdatedAt = s.GetCurrentTime()
+	if err := s.repo.UpdateTask(ctx, moved); err != nil {
+		return nil, fmt.Errorf("failed to update position of task %s: %w", taskID, err)
+	}
+	return s.repo.GetTask(ctx, taskID)
+}
+
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
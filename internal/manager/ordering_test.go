@@ -0,0 +1,92 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReorderTask tests the explicit order of sibling tasks
+func TestReorderTask(t *testing.T) {
+	repos := map[string]func(t *testing.T) (types.Repository, func()){
+		"inmemory": func(t *testing.T) (types.Repository, func()) {
+			return inmemory.NewMemoryRepository(), func() {}
+		},
+		"sqlite": setupSQLiteTestRepository,
+	}
+
+	titles := func(tasks []*types.Task) []string {
+		result := make([]string, len(tasks))
+		for i, task := range tasks {
+			result[i] = task.Title
+		}
+		return result
+	}
+
+	for name, setup := range repos {
+		t.Run(name, func(t *testing.T) {
+			repo, cleanup := setup(t)
+			defer cleanup()
+			ctx := context.Background()
+			service := NewManagerWithRepository(repo, DefaultConfig())
+
+			project, err := service.CreateProject(ctx, "Ordering", "", "test-user")
+			require.NoError(t, err)
+
+			create := func(title string, parent *types.Task) *types.Task {
+				var parentID *uuid.UUID
+				if parent != nil {
+					parentID = &parent.ID
+				}
+				task, err := service.CreateTask(ctx, project.ID, parentID, title, "", 3, types.TaskPriorityMedium, "test-user")
+				require.NoError(t, err)
+				return task
+			}
+
+			a, b, c := create("A", nil), create("B", nil), create("C", nil)
+			assert.Equal(t, []int{0, 1, 2}, []int{a.Position, b.Position, c.Position}, "new tasks are placed after their siblings")
+
+			siblings, err := service.ReorderTask(ctx, c.ID, a.ID, false, "planner")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"C", "A", "B"}, titles(siblings))
+
+			roots, err := service.GetRootTasks(ctx, project.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"C", "A", "B"}, titles(roots))
+
+			siblings, err = service.ReorderTask(ctx, a.ID, b.ID, true, "planner")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"C", "B", "A"}, titles(siblings))
+
+			moved, err := service.GetTask(ctx, a.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "planner", moved.UpdatedBy)
+
+			create("D", nil)
+			roots, err = service.GetRootTasks(ctx, project.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"C", "B", "A", "D"}, titles(roots))
+
+			t.Run("children keep their order", func(t *testing.T) {
+				first, second := create("first", a), create("second", a)
+				_, err := service.ReorderTask(ctx, second.ID, first.ID, false, "planner")
+				require.NoError(t, err)
+
+				children, err := service.GetChildTasks(ctx, a.ID)
+				require.NoError(t, err)
+				assert.Equal(t, []string{"second", "first"}, titles(children))
+
+				_, err = service.ReorderTask(ctx, first.ID, b.ID, false, "planner")
+				assert.ErrorContains(t, err, "not siblings")
+			})
+
+			_, err = service.ReorderTask(ctx, a.ID, a.ID, false, "planner")
+			assert.ErrorContains(t, err, "relative to itself")
+		})
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProjectSelection(t *testing.T) {
@@ -176,3 +179,64 @@ func TestProjectSelectionWithDeletedProject(t *testing.T) {
 		t.Errorf("Expected selection to be automatically cleared when project is deleted, but got %v", selectedID)
 	}
 }
+
+// TestSessionProjectSelection tests that sessions select projects independently
+// of each other and of the shared selection
+func TestSessionProjectSelection(t *testing.T) {
+	repos := map[string]func(t *testing.T) (types.Repository, func()){
+		"inmemory": func(t *testing.T) (types.Repository, func()) {
+			return inmemory.NewMemoryRepository(), func() {}
+		},
+		"sqlite": setupSQLiteTestRepository,
+	}
+
+	for name, setup := range repos {
+		t.Run(name, func(t *testing.T) {
+			repo, cleanup := setup(t)
+			defer cleanup()
+			manager := NewManagerWithRepository(repo, DefaultConfig())
+			ctx := context.Background()
+
+			first, err := manager.CreateProject(ctx, "First", "", "test-actor")
+			require.NoError(t, err)
+			second, err := manager.CreateProject(ctx, "Second", "", "test-actor")
+			require.NoError(t, err)
+
+			require.NoError(t, manager.SetSelectedProject(ctx, first.ID, "test-actor"))
+			require.NoError(t, manager.SetSessionProject(ctx, "a", first.ID, "test-actor"))
+			require.NoError(t, manager.SetSessionProject(ctx, "b", second.ID, "test-actor"))
+			assert.Error(t, manager.SetSessionProject(ctx, "c", uuid.New(), "test-actor"))
+
+			selected, err := manager.GetSessionProject(ctx, "b")
+			require.NoError(t, err)
+			require.NotNil(t, selected)
+			assert.Equal(t, second.ID, *selected)
+
+			// Selecting again replaces the selection of the session only
+			require.NoError(t, manager.SetSessionProject(ctx, "a", second.ID, "test-actor"))
+			selected, err = manager.GetSessionProject(ctx, "a")
+			require.NoError(t, err)
+			require.NotNil(t, selected)
+			assert.Equal(t, second.ID, *selected)
+			shared, err := manager.GetSelectedProject(ctx)
+			require.NoError(t, err)
+			require.NotNil(t, shared)
+			assert.Equal(t, first.ID, *shared)
+
+			selected, err = manager.GetSessionProject(ctx, "unknown")
+			require.NoError(t, err)
+			assert.Nil(t, selected)
+
+			require.NoError(t, manager.ClearSessionProject(ctx, "a"))
+			selected, err = manager.GetSessionProject(ctx, "a")
+			require.NoError(t, err)
+			assert.Nil(t, selected)
+
+			// Deleting a project clears the sessions it is selected in
+			require.NoError(t, manager.DeleteProject(ctx, second.ID))
+			selected, err = manager.GetSessionProject(ctx, "b")
+			require.NoError(t, err)
+			assert.Nil(t, selected)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/metrics"
+	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConstantQueries checks that breakdown and agent listings issue the same
+// number of repository operations however many tasks a project has
+func TestConstantQueries(t *testing.T) {
+	repos := map[string]func(t *testing.T) (types.Repository, func()){
+		"inmemory": func(t *testing.T) (types.Repository, func()) {
+			return inmemory.NewMemoryRepository(), func() {}
+		},
+		"sqlite": setupSQLiteTestRepository,
+	}
+
+	for name, setup := range repos {
+		t.Run(name, func(t *testing.T) {
+			repo, cleanup := setup(t)
+			defer cleanup()
+			ctx := context.Background()
+			registry := metrics.NewRegistry()
+			service := NewManagerWithRepository(metrics.InstrumentRepository(repo, registry), DefaultConfig())
+
+			project, err := service.CreateProject(ctx, "Queries", "", "test-user")
+			require.NoError(t, err)
+			agentID := uuid.New()
+
+			// operations counts the repository operations of run
+			operations := func(run func() error) int64 {
+				before, _ := registry.QueryCounts()
+				require.NoError(t, run())
+				after, _ := registry.QueryCounts()
+				return after - before
+			}
+
+			var breakdownOps, agentOps, unassignedOps []int64
+			for round := 1; round <= 2; round++ {
+				// Every round adds five complex tasks with a child assigned to
+				// the agent and five complex tasks without children
+				for i := 0; i < 10; i++ {
+					task, err := service.CreateTask(ctx, project.ID, nil, fmt.Sprintf("Complex %d.%d", round, i), "", 9, types.TaskPriorityMedium, "test-user")
+					require.NoError(t, err)
+					if i%2 == 0 {
+						_, err = service.AssignTaskToAgent(ctx, task.ID, agentID)
+						require.NoError(t, err)
+						_, err = service.CreateTask(ctx, project.ID, &task.ID, fmt.Sprintf("Child %d.%d", round, i), "", 2, types.TaskPriorityMedium, "test-user")
+						require.NoError(t, err)
+					}
+				}
+
+				var tasks []*types.Task
+				breakdownOps = append(breakdownOps, operations(func() (err error) {
+					tasks, err = service.FindTasksNeedingBreakdown(ctx, project.ID)
+					return err
+				}))
+				assert.Len(t, tasks, 5*round)
+				for _, task := range tasks {
+					assert.Nil(t, task.AssignedAgent, "tasks with children are not candidates")
+				}
+
+				agentOps = append(agentOps, operations(func() (err error) {
+					tasks, err = service.ListTasksByAgent(ctx, project.ID, agentID)
+					return err
+				}))
+				assert.Len(t, tasks, 5*round)
+
+				unassignedOps = append(unassignedOps, operations(func() (err error) {
+					tasks, err = service.ListUnassignedTasks(ctx, project.ID)
+					return err
+				}))
+				assert.Len(t, tasks, 10*round)
+			}
+
+			assert.Equal(t, breakdownOps[0], breakdownOps[1], "breakdown queries grow with the tasks")
+			assert.Equal(t, agentOps[0], agentOps[1], "agent listing queries grow with the tasks")
+			assert.Equal(t, unassignedOps[0], unassignedOps[1], "unassigned listing queries grow with the tasks")
+			assert.LessOrEqual(t, breakdownOps[0], int64(3))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/google/uuid"
+)
+
+// NewSandbox creates a project manager backed by an in-memory copy of the
+// project, including task dependencies and the configuration of pm. Changes
+// made through the sandbox are never written to the real repository.
+func NewSandbox(ctx context.Context, pm ProjectManager, projectID uuid.UUID) (ProjectManager, error) {
+	project, err := pm.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	tasks, err := pm.ListTasksWithDependencies(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project tasks: %w", err)
+	}
+
+	repo := inmemory.NewMemoryRepository()
+	projectCopy := *project
+	if err := repo.CreateProject(ctx, &projectCopy); err != nil {
+		return nil, fmt.Errorf("failed to prepare sandbox: %w", err)
+	}
+
+	for _, task := range tasks {
+		taskCopy := *task
+		taskCopy.Dependencies = nil
+		taskCopy.Dependents = nil
+		if err := repo.CreateTask(ctx, &taskCopy); err != nil {
+			return nil, fmt.Errorf("failed to prepare sandbox: %w", err)
+		}
+	}
+
+	for _, task := range tasks {
+		for _, depID := range task.Dependencies {
+			if _, err := repo.AddTaskDependency(ctx, task.ID, depID); err != nil {
+				return nil, fmt.Errorf("failed to prepare sandbox: %w", err)
+			}
+		}
+		for _, link := range task.DependencyLinks {
+			if _, err := repo.SetDependencyLink(ctx, task.ID, link); err != nil {
+				return nil, fmt.Errorf("failed to prepare sandbox: %w", err)
+			}
+		}
+	}
+
+	config := *pm.GetConfig()
+	return NewManagerWithRepository(repo, &config), nil
+}
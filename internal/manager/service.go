@@ -6,18 +6,27 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	knoterrors "github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/logger"
+	"github.com/denkhaus/knot/v2/internal/notify"
+	"github.com/denkhaus/knot/v2/internal/scheduler"
+	"github.com/denkhaus/knot/v2/internal/selection"
 	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // service provides business logic for project task management
 type service struct {
 	repo   types.Repository
 	config *Config
+	clock  types.Clock
 }
 
 // newService creates a new task management service
@@ -25,9 +34,16 @@ func newService(repo types.Repository, config *Config) *service {
 	if config == nil {
 		config = DefaultConfig()
 	}
+	// A repository with its own clock, such as the in-memory repository
+	// with a fake clock in tests, also sets the time of the manager
+	clock, ok := repo.(types.Clock)
+	if !ok {
+		clock = types.SystemClock{}
+	}
 	return &service{
-		repo:   repo,
+		repo:   guardProjectLocks(repo, clock),
 		config: config,
+		clock:  clock,
 	}
 }
 
@@ -42,14 +58,14 @@ func (s *service) CreateProject(ctx context.Context, title, description, actor s
 	}
 
 	project := &types.Project{
-		ID:          uuid.New(),
+		ID:          types.NewID(),
 		Title:       title,
 		Description: description,
 		State:       types.ProjectStateActive, // Set initial state to active
 		CreatedBy:   actor,
 		UpdatedBy:   actor,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		CreatedAt:   s.GetCurrentTime(),
+		UpdatedAt:   s.GetCurrentTime(),
 	}
 
 	if err := s.repo.CreateProject(ctx, project); err != nil {
@@ -85,9 +101,12 @@ func (s *service) UpdateProject(ctx context.Context, projectID uuid.UUID, title,
 }
 
 func (s *service) UpdateProjectDescription(ctx context.Context, projectID uuid.UUID, description string, actor string) (*types.Project, error) {
-	// Validate description length
-	if len(description) > s.config.MaxDescriptionLength {
-		return nil, fmt.Errorf("description cannot exceed %d characters", s.config.MaxDescriptionLength)
+	validator, err := s.config.Validator()
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateProjectDescription(description); err != nil {
+		return nil, err
 	}
 
 	project, err := s.repo.GetProject(ctx, projectID)
@@ -105,6 +124,52 @@ func (s *service) UpdateProjectDescription(ctx context.Context, projectID uuid.U
 	return s.repo.GetProject(ctx, projectID)
 }
 
+func (s *service) UpdateProjectDocument(ctx context.Context, projectID uuid.UUID, document string, actor string) (*types.Project, error) {
+	validator, err := s.config.Validator()
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.ValidateProjectDocument(document); err != nil {
+		return nil, err
+	}
+
+	project, err := s.repo.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	project.Document = document
+	project.UpdatedBy = actor
+	project.UpdatedAt = s.GetCurrentTime()
+
+	if err := s.repo.UpdateProject(ctx, project); err != nil {
+		return nil, fmt.Errorf("failed to update project document: %w", err)
+	}
+
+	return s.repo.GetProject(ctx, projectID)
+}
+
+func (s *service) UpdateProjectInstructions(ctx context.Context, projectID uuid.UUID, instructions string, actor string) (*types.Project, error) {
+	if err := s.validateInstructions(instructions); err != nil {
+		return nil, err
+	}
+
+	project, err := s.repo.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	project.Instructions = instructions
+	project.UpdatedBy = actor
+	project.UpdatedAt = s.GetCurrentTime()
+
+	if err := s.repo.UpdateProject(ctx, project); err != nil {
+		return nil, fmt.Errorf("failed to update project instructions: %w", err)
+	}
+
+	return s.repo.GetProject(ctx, projectID)
+}
+
 func (s *service) UpdateProjectState(ctx context.Context, projectID uuid.UUID, state types.ProjectState, actor string) (*types.Project, error) {
 	project, err := s.repo.GetProject(ctx, projectID)
 	if err != nil {
@@ -118,7 +183,7 @@ func (s *service) UpdateProjectState(ctx context.Context, projectID uuid.UUID, s
 
 	project.State = state
 	project.UpdatedBy = actor
-	project.UpdatedAt = time.Now()
+	project.UpdatedAt = s.GetCurrentTime()
 
 	if err := s.repo.UpdateProject(ctx, project); err != nil {
 		return nil, fmt.Errorf("failed to update project state: %w", err)
@@ -159,18 +224,30 @@ func (s *service) CreateTask(ctx context.Context, projectID uuid.UUID, parentID
 	}
 
 	// Validate depth and task count constraints
-	if err := s.validateTaskConstraints(ctx, projectID, depth); err != nil {
+	if err := s.validateTaskConstraints(ctx, projectID, parentID, depth); err != nil {
+		return nil, err
+	}
+
+	// Guard against actors mass-creating tasks
+	if err := s.checkCreationLimits(ctx, actor); err != nil {
+		return nil, err
+	}
+
+	// New tasks are placed after their siblings
+	position, err := s.nextSiblingPosition(ctx, projectID, parentID)
+	if err != nil {
 		return nil, err
 	}
 
 	// Create the task
 	task := s.buildNewTask(projectID, parentID, title, description, complexity, priority, depth, actor)
+	task.Position = position
 	if err := s.repo.CreateTask(ctx, task); err != nil {
 		return nil, fmt.Errorf("failed to create task: %w", err)
 	}
 
 	// Handle parent complexity reduction
-	s.handleParentComplexityReduction(ctx, parentID)
+	s.handleParentComplexityReduction(ctx, parentID, actor)
 
 	return s.repo.GetTask(ctx, task.ID)
 }
@@ -201,30 +278,182 @@ func (s *service) validateParentAndCalculateDepth(ctx context.Context, parentID
 	return parentTask.Depth + 1, nil
 }
 
-// validateTaskConstraints validates depth and task count constraints
-func (s *service) validateTaskConstraints(ctx context.Context, projectID uuid.UUID, depth int) error {
+// validateTaskConstraints validates depth and task count constraints for a new
+// task below parentID at depth
+func (s *service) validateTaskConstraints(ctx context.Context, projectID uuid.UUID, parentID *uuid.UUID, depth int) error {
 	// Check depth constraints
 	if depth > s.config.MaxDepth {
-		return fmt.Errorf("maximum depth of %d exceeded", s.config.MaxDepth)
+		return knoterrors.MaxDepthExceededError(depth, s.config.MaxDepth)
 	}
 
 	// Check task count constraints for this depth
-	counts, err := s.repo.GetTaskCountByDepth(ctx, projectID, depth)
+	counts, err := s.repo.GetTaskCountByDepth(ctx, projectID, s.config.MaxDepth)
 	if err != nil {
 		return fmt.Errorf("failed to check task count constraints: %w", err)
 	}
 
-	if counts[depth] >= s.config.MaxTasksPerDepth {
-		return knoterrors.TooManyTasksError(counts[depth], s.config.MaxTasksPerDepth, depth)
+	if limit := s.config.MaxTasksAt(depth); counts[depth] >= limit {
+		exempt, err := s.exemptFromDepthLimit(ctx, parentID)
+		if err != nil {
+			return err
+		}
+		if !exempt {
+			tooMany := knoterrors.TooManyTasksError(counts[depth], limit, depth)
+			if hint := s.capacityHint(counts, depth); hint != "" {
+				tooMany.Suggestion = hint + ". " + tooMany.Suggestion
+			}
+			if parentID != nil && !s.config.AllowLargeBreakdowns {
+				tooMany.Suggestion += fmt.Sprintf(". Subtasks of tasks with complexity %d or more can exceed the limit with 'knot config set --key allow-large-breakdowns --value 1'", s.config.ComplexityThreshold)
+			}
+			return tooMany
+		}
+	}
+
+	if limit := s.config.ProjectTaskLimit(); limit > 0 {
+		if total := sumCounts(counts); total >= limit {
+			return knoterrors.ProjectTaskLimitError(total, limit)
+		}
+	}
+
+	return nil
+}
+
+// exemptFromDepthLimit reports whether subtasks of parentID may exceed the
+// per-depth limits, see Config.AllowLargeBreakdowns
+func (s *service) exemptFromDepthLimit(ctx context.Context, parentID *uuid.UUID) (bool, error) {
+	if !s.config.AllowLargeBreakdowns || parentID == nil {
+		return false, nil
+	}
+	parent, err := s.repo.GetTask(ctx, *parentID)
+	if err != nil {
+		return false, fmt.Errorf("parent task not found: %w", err)
+	}
+	return s.config.ExemptFromDepthLimit(s.peakComplexity(ctx, parent)), nil
+}
+
+// peakComplexity returns the highest complexity task had according to the
+// change feed, or its current complexity if the feed is unavailable
+func (s *service) peakComplexity(ctx context.Context, task *types.Task) int {
+	peak := task.Complexity
+	events, err := s.ListChangeEvents(ctx, types.ChangeEventFilter{ProjectID: &task.ProjectID})
+	if err != nil {
+		return peak
+	}
+	for _, event := range events {
+		if event.TaskID == nil || *event.TaskID != task.ID || len(event.Data) == 0 {
+			continue
+		}
+		var snapshot struct {
+			Complexity int `json:"complexity"`
+		}
+		if json.Unmarshal(event.Data, &snapshot) == nil && snapshot.Complexity > peak {
+			peak = snapshot.Complexity
+		}
 	}
+	return peak
+}
 
+// checkCreationLimits rejects a new task of actor if the actor created as many
+// tasks as one of the matching creation limits allows within its window
+func (s *service) checkCreationLimits(ctx context.Context, actor string) error {
+	for _, limit := range s.config.CreationLimits {
+		if !limit.Matches(actor) {
+			continue
+		}
+		since := s.GetCurrentTime().Add(-limit.Window())
+		created, err := s.repo.ListTasks(ctx, types.TaskFilter{CreatedBy: actor, CreatedSince: &since})
+		if err != nil {
+			return fmt.Errorf("failed to check creation limits: %w", err)
+		}
+		if len(created) >= limit.MaxTasks {
+			return knoterrors.CreationLimitError(actor, limit.Actor, len(created), limit.MaxTasks, limit.Window())
+		}
+	}
 	return nil
 }
 
+// sumCounts returns the number of tasks over all depth levels
+func sumCounts(counts map[int]int) int {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	return total
+}
+
+// capacityHint names the depth levels that still have room when depth is full
+func (s *service) capacityHint(counts map[int]int, depth int) string {
+	var free []string
+	for d := 0; d <= s.config.MaxDepth; d++ {
+		if remaining := s.config.RemainingAt(d, counts[d]); d != depth && remaining > 0 {
+			free = append(free, fmt.Sprintf("depth %d has %d free", d, remaining))
+		}
+	}
+	if len(free) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Depth %d is full; %s", depth, strings.Join(free, ", "))
+}
+
+// GetTaskCapacity reports the remaining task capacity per depth level and at the
+// depth a new task would be created at: the root level, or below parentID if given
+func (s *service) GetTaskCapacity(ctx context.Context, projectID uuid.UUID, parentID *uuid.UUID) (*TaskCapacity, error) {
+	if err := s.validateProjectExists(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	depth, err := s.validateParentAndCalculateDepth(ctx, parentID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.repo.GetTaskCountByDepth(ctx, projectID, s.config.MaxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tasks by depth: %w", err)
+	}
+
+	exempt, err := s.exemptFromDepthLimit(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	capacity := &TaskCapacity{
+		ProjectID:        projectID,
+		ParentID:         parentID,
+		TargetDepth:      depth,
+		MaxDepth:         s.config.MaxDepth,
+		Remaining:        s.config.RemainingAt(depth, counts[depth]),
+		DepthLimitExempt: exempt && depth <= s.config.MaxDepth,
+		Depths:           make([]DepthCapacity, 0, s.config.MaxDepth+1),
+
+		ProjectTasks:    sumCounts(counts),
+		MaxProjectTasks: s.config.ProjectTaskLimit(),
+	}
+	if capacity.DepthLimitExempt {
+		capacity.Remaining = -1
+	}
+	if capacity.MaxProjectTasks > 0 {
+		projectRemaining := max(capacity.MaxProjectTasks-capacity.ProjectTasks, 0)
+		if capacity.Remaining < 0 || projectRemaining < capacity.Remaining {
+			capacity.Remaining = projectRemaining
+		}
+	}
+	for d := 0; d <= s.config.MaxDepth; d++ {
+		capacity.Depths = append(capacity.Depths, DepthCapacity{
+			Depth:     d,
+			Count:     counts[d],
+			Max:       s.config.MaxTasksAt(d),
+			Remaining: s.config.RemainingAt(d, counts[d]),
+		})
+	}
+
+	return capacity, nil
+}
+
 // buildNewTask creates a new task instance with the given parameters
 func (s *service) buildNewTask(projectID uuid.UUID, parentID *uuid.UUID, title, description string, complexity int, priority types.TaskPriority, depth int, actor string) *types.Task {
 	return &types.Task{
-		ID:          uuid.New(),
+		ID:          types.NewID(),
 		ProjectID:   projectID,
 		ParentID:    parentID,
 		Title:       title,
@@ -241,15 +470,17 @@ func (s *service) buildNewTask(projectID uuid.UUID, parentID *uuid.UUID, title,
 }
 
 // handleParentComplexityReduction automatically reduces parent complexity if enabled
-func (s *service) handleParentComplexityReduction(ctx context.Context, parentID *uuid.UUID) {
+func (s *service) handleParentComplexityReduction(ctx context.Context, parentID *uuid.UUID, actor string) {
 	if !s.config.AutoReduceComplexity || parentID == nil {
 		return
 	}
 
-	if err := s.autoReduceParentComplexity(ctx, *parentID); err != nil {
+	if err := s.autoReduceParentComplexity(ctx, *parentID, actor); err != nil {
 		// Log error but don't fail the task creation
 		// The task was successfully created, complexity reduction is a bonus feature
-		fmt.Printf("Warning: Failed to auto-reduce parent complexity: %v\n", err)
+		logger.Log.Warn("Failed to auto-reduce parent complexity",
+			zap.String("parentID", parentID.String()),
+			zap.Error(err))
 	}
 }
 
@@ -262,26 +493,78 @@ func (s *service) UpdateTaskState(ctx context.Context, taskID uuid.UUID, state t
 	if err != nil {
 		return nil, err
 	}
+	return s.setTaskState(ctx, task, state, actor)
+}
 
-	// Validate state transition
-	if !isValidTaskStateTransition(task.State, state) {
-		return nil, fmt.Errorf("invalid state transition from '%s' to '%s'", task.State, state)
+// UpdateTaskStateWithHandoff changes the state of a task like UpdateTaskState.
+// When an in-progress task goes back to pending the note is recorded as a
+// handoff, and required if RequireHandoffNotes is set; other transitions take
+// no note.
+func (s *service) UpdateTaskStateWithHandoff(ctx context.Context, taskID uuid.UUID, state types.TaskState, note string, actor string) (*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
 	}
 
-	oldState := task.State
-	task.State = state
-	task.UpdatedBy = actor
-	task.UpdatedAt = time.Now()
+	note = strings.TrimSpace(note)
+	if task.State != types.TaskStateInProgress || state != types.TaskStatePending {
+		if note != "" {
+			return nil, fmt.Errorf("handoff notes are recorded when an in-progress task goes back to pending, not from %s to %s", task.State, state)
+		}
+		return s.setTaskState(ctx, task, state, actor)
+	}
+	if note == "" {
+		if s.config.RequireHandoffNotes {
+			return nil, knoterrors.HandoffNoteRequiredError(task.Title)
+		}
+		return s.setTaskState(ctx, task, state, actor)
+	}
 
-	// Set completion timestamp if transitioning to completed
-	if state == types.TaskStateCompleted && task.CompletedAt == nil {
-		now := time.Now()
-		task.CompletedAt = &now
-	} else if state != types.TaskStateCompleted && task.CompletedAt != nil {
-		// Clear completion timestamp if moving away from completed
-		task.CompletedAt = nil
+	task.Handoffs = append(task.Handoffs, types.TaskHandoff{
+		Note:      note,
+		FromAgent: task.AssignedAgent,
+		ToAgent:   task.AssignedAgent,
+		FromState: task.State,
+		ToState:   state,
+		By:        actor,
+		At:        s.GetCurrentTime(),
+	})
+	return s.setTaskState(ctx, task, state, actor)
+}
+
+// BlockTask sets a task to blocked because of the given external reason, or
+// replaces the reason of a task that is already blocked
+func (s *service) BlockTask(ctx context.Context, taskID uuid.UUID, reason, reference string, actor string) (*types.Task, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("block reason cannot be empty")
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Blocker = &types.TaskBlocker{
+		Reason:    reason,
+		Reference: strings.TrimSpace(reference),
+		BlockedBy: actor,
+		BlockedAt: s.GetCurrentTime(),
+	}
+	return s.setTaskState(ctx, task, types.TaskStateBlocked, actor)
+}
+
+// setTaskState moves a loaded task to the given state and re-evaluates its parent
+func (s *service) setTaskState(ctx context.Context, task *types.Task, state types.TaskState, actor string) (*types.Task, error) {
+	taskID := task.ID
+
+	if err := s.checkTransition(ctx, task, state); err != nil {
+		return nil, err
 	}
 
+	oldState := task.State
+	applyTaskState(task, state, actor, s.GetCurrentTime())
+
 	if err := s.repo.UpdateTask(ctx, task); err != nil {
 		return nil, fmt.Errorf("failed to update task state: %w", err)
 	}
@@ -297,6 +580,65 @@ func (s *service) UpdateTaskState(ctx context.Context, taskID uuid.UUID, state t
 	return s.repo.GetTask(ctx, taskID)
 }
 
+// CheckStateTransition reports why a task cannot move to the given state, nil
+// if it can: the transition must be allowed by the state machine, and a task
+// can only be completed once its acceptance criteria and review allow it
+func (s *service) CheckStateTransition(task *types.Task, state types.TaskState) error {
+	if !isValidTaskStateTransition(task.State, state) {
+		return fmt.Errorf("invalid state transition from '%s' to '%s'", task.State, state)
+	}
+	return s.checkCompletion(task, state)
+}
+
+type forceTransitionsContextKey struct{}
+
+// WithForcedTransitions marks the state changes made with ctx as forced: they
+// skip the state machine check, e.g. to reopen a completed task. The
+// requirements for completing a task (verified acceptance criteria, approved
+// reviews) still apply.
+func WithForcedTransitions(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceTransitionsContextKey{}, true)
+}
+
+func transitionsForced(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	forced, _ := ctx.Value(forceTransitionsContextKey{}).(bool)
+	return forced
+}
+
+// checkTransition is CheckStateTransition for the state-changing paths of the
+// service, honoring WithForcedTransitions
+func (s *service) checkTransition(ctx context.Context, task *types.Task, state types.TaskState) error {
+	if transitionsForced(ctx) {
+		return s.checkCompletion(task, state)
+	}
+	return s.CheckStateTransition(task, state)
+}
+
+// applyTaskState sets the state of a task together with the fields that
+// depend on it
+func applyTaskState(task *types.Task, state types.TaskState, actor string, now time.Time) {
+	task.State = state
+	task.UpdatedBy = actor
+	task.UpdatedAt = now
+
+	// The external blocker only applies while the task is blocked
+	if state != types.TaskStateBlocked {
+		task.Blocker = nil
+	}
+
+	// Set completion timestamp if transitioning to completed
+	if state == types.TaskStateCompleted && task.CompletedAt == nil {
+		completedAt := now
+		task.CompletedAt = &completedAt
+	} else if state != types.TaskStateCompleted && task.CompletedAt != nil {
+		// Clear completion timestamp if moving away from completed
+		task.CompletedAt = nil
+	}
+}
+
 // evaluateAndUpdateParentTask calculates the appropriate state for a parent task based on its children and updates it
 func (s *service) evaluateAndUpdateParentTask(ctx context.Context, parentID uuid.UUID, actor string) error {
 	// Get the parent task
@@ -319,6 +661,11 @@ func (s *service) evaluateAndUpdateParentTask(ctx context.Context, parentID uuid
 	// Calculate the appropriate parent state based on children
 	newState := s.calculateParentTaskState(children, parentTask.State)
 
+	// A parent with unverified acceptance criteria or a missing approval stays open
+	if s.checkCompletion(parentTask, newState) != nil {
+		return nil
+	}
+
 	// Only update if the state should change
 	if newState != parentTask.State {
 		// Validate the state transition
@@ -329,11 +676,14 @@ func (s *service) evaluateAndUpdateParentTask(ctx context.Context, parentID uuid
 		// Update parent task state
 		parentTask.State = newState
 		parentTask.UpdatedBy = actor
-		parentTask.UpdatedAt = time.Now()
+		parentTask.UpdatedAt = s.GetCurrentTime()
+		if newState != types.TaskStateBlocked {
+			parentTask.Blocker = nil
+		}
 
 		// Handle completion timestamp
 		if newState == types.TaskStateCompleted && parentTask.CompletedAt == nil {
-			now := time.Now()
+			now := s.GetCurrentTime()
 			parentTask.CompletedAt = &now
 		} else if newState != types.TaskStateCompleted && parentTask.CompletedAt != nil {
 			parentTask.CompletedAt = nil
@@ -408,12 +758,14 @@ func (s *service) UpdateTask(ctx context.Context, taskID uuid.UUID, title, descr
 		return nil, err
 	}
 
+	if err := s.checkTransition(ctx, task, state); err != nil {
+		return nil, err
+	}
+
 	task.Title = title
 	task.Description = description
 	task.Complexity = complexity
-	task.State = state
-	task.UpdatedBy = actor
-	task.UpdatedAt = time.Now()
+	applyTaskState(task, state, actor, s.GetCurrentTime())
 
 	if err := s.repo.UpdateTask(ctx, task); err != nil {
 		return nil, fmt.Errorf("failed to update task: %w", err)
@@ -424,9 +776,8 @@ func (s *service) UpdateTask(ctx context.Context, taskID uuid.UUID, title, descr
 }
 
 func (s *service) UpdateTaskDescription(ctx context.Context, taskID uuid.UUID, description string, actor string) (*types.Task, error) {
-	// Validate description length
-	if len(description) > s.config.MaxDescriptionLength {
-		return nil, fmt.Errorf("description cannot exceed %d characters", s.config.MaxDescriptionLength)
+	if err := s.validateTaskDescription(description); err != nil {
+		return nil, err
 	}
 
 	task, err := s.repo.GetTask(ctx, taskID)
@@ -445,13 +796,29 @@ func (s *service) UpdateTaskDescription(ctx context.Context, taskID uuid.UUID, d
 	return task, nil
 }
 
-func (s *service) UpdateTaskTitle(ctx context.Context, taskID uuid.UUID, title string, actor string) (*types.Task, error) {
-	// Validate title
-	if title == "" {
-		return nil, fmt.Errorf("title cannot be empty")
+func (s *service) UpdateTaskInstructions(ctx context.Context, taskID uuid.UUID, instructions string, actor string) (*types.Task, error) {
+	if err := s.validateInstructions(instructions); err != nil {
+		return nil, err
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Instructions = instructions
+	task.UpdatedBy = actor
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task instructions: %w", err)
 	}
-	if len(title) > 200 {
-		return nil, fmt.Errorf("title cannot exceed 200 characters")
+
+	return s.repo.GetTask(ctx, taskID)
+}
+
+func (s *service) UpdateTaskTitle(ctx context.Context, taskID uuid.UUID, title string, actor string) (*types.Task, error) {
+	if err := s.validateTaskTitle(title); err != nil {
+		return nil, err
 	}
 
 	task, err := s.repo.GetTask(ctx, taskID)
@@ -477,7 +844,7 @@ func (s *service) UpdateTaskPriority(ctx context.Context, taskID uuid.UUID, prio
 
 	task.Priority = priority
 	task.UpdatedBy = actor
-	task.UpdatedAt = time.Now()
+	task.UpdatedAt = s.GetCurrentTime()
 
 	if err := s.repo.UpdateTask(ctx, task); err != nil {
 		return nil, fmt.Errorf("failed to update task priority: %w", err)
@@ -494,6 +861,99 @@ func (s *service) DeleteTaskSubtree(ctx context.Context, taskID uuid.UUID, actor
 	return s.repo.DeleteTaskSubtree(ctx, taskID)
 }
 
+// DeleteTaskWithChildren deletes a task and applies policy to its children.
+// Promoted and reparented children keep their subtrees, whose depths are
+// recalculated. newParentID is only used by ChildPolicyReparent.
+func (s *service) DeleteTaskWithChildren(ctx context.Context, taskID uuid.UUID, policy ChildPolicy, newParentID *uuid.UUID, actor string) error {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("task not found: %w", err)
+	}
+
+	var target *uuid.UUID
+	switch policy {
+	case ChildPolicyDelete:
+		return s.DeleteTaskSubtree(ctx, taskID, actor)
+	case ChildPolicyPromote:
+		target = task.ParentID
+	case ChildPolicyReparent:
+		if newParentID == nil {
+			return fmt.Errorf("reparenting children requires a new parent task")
+		}
+		target = newParentID
+	default:
+		return fmt.Errorf("unknown child policy %q", policy)
+	}
+
+	tasks, err := s.repo.GetTasksByProject(ctx, task.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to list project tasks: %w", err)
+	}
+	children := make(map[uuid.UUID][]*types.Task)
+	byID := make(map[uuid.UUID]*types.Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+		if t.ParentID != nil {
+			children[*t.ParentID] = append(children[*t.ParentID], t)
+		}
+	}
+
+	targetDepth := 0
+	if target != nil {
+		parent, exists := byID[*target]
+		if !exists {
+			return fmt.Errorf("new parent task %s not found in the project of the deleted task", *target)
+		}
+		for ancestor := parent; ancestor != nil; ancestor = parentTask(byID, ancestor) {
+			if ancestor.ID == taskID {
+				return fmt.Errorf("new parent task %s must not be the deleted task or one of its descendants", *target)
+			}
+		}
+		targetDepth = parent.Depth + 1
+	}
+
+	// Moving deeper must keep every descendant within the depth limit
+	for _, child := range children[taskID] {
+		deepest := subtreeDepth(children, child) - child.Depth + targetDepth
+		if deepest > s.config.MaxDepth {
+			return knoterrors.MaxDepthExceededError(deepest, s.config.MaxDepth)
+		}
+	}
+
+	// The children keep their order and are placed after the tasks below
+	// the new parent. Either all of them move and the task is deleted, or
+	// nothing changes.
+	moving := children[taskID]
+	types.SortSiblings(moving)
+	return s.atomically(ctx, func(ctx context.Context) error {
+		for _, child := range moving {
+			if _, err := s.MoveTask(ctx, child.ID, target, actor); err != nil {
+				return fmt.Errorf("failed to move child task %s: %w", child.ID, err)
+			}
+		}
+		return s.repo.DeleteTask(ctx, taskID)
+	})
+}
+
+// parentTask returns the parent of task, or nil for root tasks
+func parentTask(byID map[uuid.UUID]*types.Task, task *types.Task) *types.Task {
+	if task.ParentID == nil {
+		return nil
+	}
+	return byID[*task.ParentID]
+}
+
+// subtreeDepth returns the depth of the deepest task in the subtree of task
+func subtreeDepth(children map[uuid.UUID][]*types.Task, task *types.Task) int {
+	deepest := task.Depth
+	for _, child := range children[task.ID] {
+		if depth := subtreeDepth(children, child); depth > deepest {
+			deepest = depth
+		}
+	}
+	return deepest
+}
+
 // Task queries and analysis
 
 func (s *service) GetParentTask(ctx context.Context, taskID uuid.UUID) (*types.Task, error) {
@@ -515,6 +975,40 @@ func (s *service) GetChildTasks(ctx context.Context, taskID uuid.UUID) ([]*types
 	return s.repo.GetTasksByParent(ctx, taskID)
 }
 
+func (s *service) GetDescendants(ctx context.Context, taskID uuid.UUID, maxDepth int) ([]*types.Task, error) {
+	if maxDepth < 0 {
+		return nil, fmt.Errorf("max depth must not be negative, got %d", maxDepth)
+	}
+	if _, err := s.repo.GetTask(ctx, taskID); err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+	return s.repo.GetDescendants(ctx, taskID, maxDepth)
+}
+
+func (s *service) GetAncestors(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	var ancestors []*types.Task
+	seen := map[uuid.UUID]bool{task.ID: true}
+	for parentID := task.ParentID; parentID != nil; {
+		if seen[*parentID] {
+			return nil, fmt.Errorf("task hierarchy of %s contains a cycle at task %s", taskID, *parentID)
+		}
+		seen[*parentID] = true
+
+		parent, err := s.repo.GetTask(ctx, *parentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent task %s: %w", *parentID, err)
+		}
+		ancestors = append([]*types.Task{parent}, ancestors...)
+		parentID = parent.ParentID
+	}
+	return ancestors, nil
+}
+
 func (s *service) GetRootTasks(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error) {
 	return s.repo.GetRootTasks(ctx, projectID)
 }
@@ -529,12 +1023,58 @@ func (s *service) ListTasksForProject(ctx context.Context, projectID uuid.UUID)
 	return s.repo.GetTasksByProject(ctx, projectID)
 }
 
-// BulkUpdateTasks updates multiple tasks with the same updates
-func (s *service) BulkUpdateTasks(ctx context.Context, taskIDs []uuid.UUID, updates types.TaskUpdates, actor string) error {
-	if len(taskIDs) == 0 {
-		return nil // Nothing to update
-	}
-
+// ListTasksPage returns one page of a project's tasks. The repository pages
+// with a keyset cursor, so large projects are never loaded as a whole.
+func (s *service) ListTasksPage(ctx context.Context, projectID uuid.UUID, filter types.TaskFilter, page types.PageRequest) (*types.TaskPage, error) {
+	// Validate project exists
+	if _, err := s.repo.GetProject(ctx, projectID); err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+	if page.Limit > types.MaxPageSize {
+		return nil, fmt.Errorf("page size %d exceeds the maximum of %d", page.Limit, types.MaxPageSize)
+	}
+
+	filter.ProjectID = &projectID
+	return s.repo.ListTasksPage(ctx, filter, page)
+}
+
+// ListTasksWithDependencies returns all tasks in a project, in the order of
+// ListTasksForProject, with their dependencies and dependents loaded
+func (s *service) ListTasksWithDependencies(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error) {
+	tasks, err := s.ListTasksForProject(ctx, projectID)
+	if err != nil || len(tasks) == 0 {
+		return tasks, err
+	}
+
+	taskIDs := make([]uuid.UUID, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+	loaded, err := s.repo.GetTasksWithDependencies(ctx, taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task dependencies: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*types.Task, len(loaded))
+	for _, task := range loaded {
+		byID[task.ID] = task
+	}
+	for i, task := range tasks {
+		if withDeps, ok := byID[task.ID]; ok {
+			tasks[i] = withDeps
+		}
+	}
+	return tasks, nil
+}
+
+// BulkUpdateTasks updates multiple tasks with the same updates. A state change
+// is checked for every task before anything is written, so an invalid
+// transition of one task leaves all tasks unchanged.
+func (s *service) BulkUpdateTasks(ctx context.Context, taskIDs []uuid.UUID, updates types.TaskUpdates, actor string) error {
+	if len(taskIDs) == 0 {
+		return nil // Nothing to update
+	}
+
 	// Validate updates
 	if updates.State == nil && updates.Complexity == nil {
 		return fmt.Errorf("at least one field must be specified for update")
@@ -545,34 +1085,39 @@ func (s *service) BulkUpdateTasks(ctx context.Context, taskIDs []uuid.UUID, upda
 		return fmt.Errorf("complexity is %d but must be between 1 and 10", *updates.Complexity)
 	}
 
-	// Track parent tasks that need re-evaluation
-	parentTasksToEvaluate := make(map[uuid.UUID]bool)
-
-	// Update each task
+	tasks := make([]*types.Task, 0, len(taskIDs))
 	for _, taskID := range taskIDs {
 		task, err := s.repo.GetTask(ctx, taskID)
 		if err != nil {
 			return fmt.Errorf("failed to get task %s: %w", taskID, err)
 		}
+		if updates.State != nil {
+			if err := s.checkTransition(ctx, task, *updates.State); err != nil {
+				return fmt.Errorf("task %s: %w", taskID, err)
+			}
+		}
+		tasks = append(tasks, task)
+	}
 
+	// Track parent tasks that need re-evaluation
+	parentTasksToEvaluate := make(map[uuid.UUID]bool)
+
+	// Update each task
+	for _, task := range tasks {
+		taskID := task.ID
 		oldState := task.State
 
 		// Apply updates
+		now := s.GetCurrentTime()
 		if updates.State != nil {
-			task.State = *updates.State
-			if task.State == types.TaskStateCompleted && task.CompletedAt == nil {
-				now := time.Now()
-				task.CompletedAt = &now
-			} else if task.State != types.TaskStateCompleted && task.CompletedAt != nil {
-				task.CompletedAt = nil
-			}
+			applyTaskState(task, *updates.State, actor, now)
 		}
 		if updates.Complexity != nil {
 			task.Complexity = *updates.Complexity
 		}
 
 		task.UpdatedBy = actor
-		task.UpdatedAt = time.Now()
+		task.UpdatedAt = now
 
 		if err := s.repo.UpdateTask(ctx, task); err != nil {
 			return fmt.Errorf("failed to update task %s: %w", taskID, err)
@@ -595,6 +1140,101 @@ func (s *service) BulkUpdateTasks(ctx context.Context, taskIDs []uuid.UUID, upda
 	return nil
 }
 
+// ReprioritizeTasks sets the priority of all given tasks in one transaction of
+// the repository, so either all tasks are changed or none.
+func (s *service) ReprioritizeTasks(ctx context.Context, taskIDs []uuid.UUID, priority types.TaskPriority, actor string) ([]*types.Task, error) {
+	if priority < types.TaskPriorityHigh || priority > types.TaskPriorityLow {
+		return nil, fmt.Errorf("invalid priority %d", priority)
+	}
+
+	var updated []*types.Task
+	err := s.atomically(ctx, func(ctx context.Context) error {
+		// All tasks are loaded before anything is written
+		tasks := make([]types.Task, len(taskIDs))
+		for i, taskID := range taskIDs {
+			task, err := s.repo.GetTask(ctx, taskID)
+			if err != nil {
+				return fmt.Errorf("failed to get task %s: %w", taskID, err)
+			}
+			// Keep a copy, repositories may hand out their live instances
+			tasks[i] = *task
+		}
+
+		updated = make([]*types.Task, 0, len(tasks))
+		now := s.GetCurrentTime()
+		for i := range tasks {
+			task := &tasks[i]
+			task.Priority = priority
+			task.UpdatedBy = actor
+			task.UpdatedAt = now
+
+			if err := s.repo.UpdateTask(ctx, task); err != nil {
+				return fmt.Errorf("failed to update task %s: %w", task.ID, err)
+			}
+			updated = append(updated, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// TransitionTasks moves all given tasks to a state in one transaction of the
+// repository. Every transition is checked with CheckStateTransition before
+// anything is written, so either all tasks are moved or none. The parents of
+// the moved tasks are re-evaluated afterwards.
+func (s *service) TransitionTasks(ctx context.Context, taskIDs []uuid.UUID, state types.TaskState, actor string) ([]*types.Task, error) {
+	var updated []*types.Task
+	parentsToEvaluate := make(map[uuid.UUID]bool)
+	err := s.atomically(ctx, func(ctx context.Context) error {
+		tasks := make([]types.Task, len(taskIDs))
+		for i, taskID := range taskIDs {
+			task, err := s.repo.GetTask(ctx, taskID)
+			if err != nil {
+				return fmt.Errorf("failed to get task %s: %w", taskID, err)
+			}
+			if err := s.checkTransition(ctx, task, state); err != nil {
+				return fmt.Errorf("task %s: %w", taskID, err)
+			}
+			// Keep a copy, repositories may hand out their live instances
+			tasks[i] = *task
+		}
+
+		updated = make([]*types.Task, 0, len(tasks))
+		now := s.GetCurrentTime()
+		for i := range tasks {
+			task := &tasks[i]
+			oldState := task.State
+			applyTaskState(task, state, actor, now)
+
+			if err := s.repo.UpdateTask(ctx, task); err != nil {
+				return fmt.Errorf("failed to update task %s: %w", task.ID, err)
+			}
+			updated = append(updated, task)
+			if task.ParentID != nil && oldState != state {
+				parentsToEvaluate[*task.ParentID] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for parentID := range parentsToEvaluate {
+		if err := s.evaluateAndUpdateParentTask(ctx, parentID, actor); err != nil {
+			// Log error but don't fail the transition
+			logger.Log.Warn("Failed to evaluate parent task",
+				zap.String("parentID", parentID.String()),
+				zap.Error(err))
+		}
+	}
+
+	return updated, nil
+}
+
 // DuplicateTask creates a copy of a task in a new project
 func (s *service) DuplicateTask(ctx context.Context, taskID uuid.UUID, newProjectID uuid.UUID) (*types.Task, error) {
 	// Validate source task exists
@@ -608,49 +1248,411 @@ func (s *service) DuplicateTask(ctx context.Context, taskID uuid.UUID, newProjec
 		return nil, fmt.Errorf("target project not found: %w", err)
 	}
 
-	// Create a copy of the task
-	newTask := &types.Task{
-		ID:          uuid.New(),
-		ProjectID:   newProjectID,
-		ParentID:    originalTask.ParentID, // This will be nil for the duplicated task
-		Title:       originalTask.Title,
-		Description: originalTask.Description,
-		State:       types.TaskStatePending, // Reset state to pending
-		Complexity:  originalTask.Complexity,
-		Depth:       0, // Reset depth to 0 as it's now a root task
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-		CompletedAt: nil, // Reset completion status
+	// Create a copy of the task
+	newTask := &types.Task{
+		ID:           types.NewID(),
+		ProjectID:    newProjectID,
+		ParentID:     originalTask.ParentID, // This will be nil for the duplicated task
+		Title:        originalTask.Title,
+		Description:  originalTask.Description,
+		Instructions: originalTask.Instructions,
+		State:        types.TaskStatePending, // Reset state to pending
+		Complexity:   originalTask.Complexity,
+		Depth:        0, // Reset depth to 0 as it's now a root task
+		CreatedAt:    s.GetCurrentTime(),
+		UpdatedAt:    s.GetCurrentTime(),
+		CompletedAt:  nil, // Reset completion status
+	}
+
+	// Save the new task
+	if err := s.repo.CreateTask(ctx, newTask); err != nil {
+		return nil, fmt.Errorf("failed to create duplicated task: %w", err)
+	}
+
+	return s.repo.GetTask(ctx, newTask.ID)
+}
+
+// SetTaskEstimate sets the time estimate for a task
+func (s *service) SetTaskEstimate(ctx context.Context, taskID uuid.UUID, estimate int64) (*types.Task, error) {
+	// Validate estimate
+	if estimate < 0 {
+		return nil, errors.New("estimate must be non-negative")
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	task.Estimate = &estimate
+	task.UpdatedAt = s.GetCurrentTime()
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task estimate: %w", err)
+	}
+
+	// Return the updated task directly (avoiding redundant GetTask call)
+	return task, nil
+}
+
+// LogTaskEffort records minutes of actual effort spent on a task by actor
+func (s *service) LogTaskEffort(ctx context.Context, taskID uuid.UUID, minutes int64, note string, actor string) (*types.Task, error) {
+	if minutes <= 0 {
+		return nil, errors.New("effort must be positive")
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.GetCurrentTime()
+	task.EffortLog = append(task.EffortLog, types.EffortEntry{
+		Minutes:  minutes,
+		Actor:    actor,
+		LoggedAt: now,
+		Note:     strings.TrimSpace(note),
+	})
+	task.UpdatedBy = actor
+	task.UpdatedAt = now
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to log task effort: %w", err)
+	}
+
+	return task, nil
+}
+
+// SetTaskKeepComplexity excludes a task from (or re-includes it in) automatic
+// complexity reduction when subtasks are added
+func (s *service) SetTaskKeepComplexity(ctx context.Context, taskID uuid.UUID, keep bool, actor string) (*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	task.KeepComplexity = keep
+	task.UpdatedBy = actor
+	task.UpdatedAt = s.GetCurrentTime()
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	return task, nil
+}
+
+// SetTaskDueDate sets the due date of a task, a nil due date clears it
+func (s *service) SetTaskDueDate(ctx context.Context, taskID uuid.UUID, due *time.Time, actor string) (*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	task.DueDate = due
+	task.UpdatedBy = actor
+	task.UpdatedAt = s.GetCurrentTime()
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task due date: %w", err)
+	}
+
+	return task, nil
+}
+
+// SetTaskCompletedAt corrects the completion time of a completed task, e.g.
+// for tasks completed before knot recorded completion times. The time can
+// neither precede the creation of the task nor lie in the future.
+func (s *service) SetTaskCompletedAt(ctx context.Context, taskID uuid.UUID, completedAt time.Time, actor string) (*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.State != types.TaskStateCompleted {
+		return nil, fmt.Errorf("task '%s' is %s, only completed tasks have a completion time", task.Title, task.State)
+	}
+	now := s.GetCurrentTime()
+	if completedAt.After(now) {
+		return nil, fmt.Errorf("completion time %s lies in the future", completedAt.Format(time.RFC3339))
+	}
+	if completedAt.Before(task.CreatedAt) {
+		return nil, fmt.Errorf("completion time %s precedes the creation of the task at %s",
+			completedAt.Format(time.RFC3339), task.CreatedAt.Format(time.RFC3339))
+	}
+
+	task.CompletedAt = &completedAt
+	task.UpdatedBy = actor
+	task.UpdatedAt = now
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update task completion time: %w", err)
+	}
+
+	return task, nil
+}
+
+// AddAcceptanceCriterion appends an unverified acceptance criterion to a task
+func (s *service) AddAcceptanceCriterion(ctx context.Context, taskID uuid.UUID, text string, actor string) (*types.Task, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, errors.New("acceptance criterion text cannot be empty")
+	}
+
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	task.AcceptanceCriteria = append(task.AcceptanceCriteria, types.AcceptanceCriterion{Text: text})
+	task.UpdatedBy = actor
+	task.UpdatedAt = s.GetCurrentTime()
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to add acceptance criterion: %w", err)
+	}
+
+	return task, nil
+}
+
+// SetAcceptanceCriterionVerified marks the acceptance criterion at index (0-based)
+// as verified or unverified
+func (s *service) SetAcceptanceCriterionVerified(ctx context.Context, taskID uuid.UUID, index int, verified bool, actor string) (*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCriterionIndex(task, index); err != nil {
+		return nil, err
+	}
+
+	criterion := &task.AcceptanceCriteria[index]
+	criterion.Verified = verified
+	if verified {
+		now := s.GetCurrentTime()
+		criterion.VerifiedBy = actor
+		criterion.VerifiedAt = &now
+	} else {
+		criterion.VerifiedBy = ""
+		criterion.VerifiedAt = nil
+	}
+	task.UpdatedBy = actor
+	task.UpdatedAt = s.GetCurrentTime()
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to update acceptance criterion: %w", err)
+	}
+
+	return task, nil
+}
+
+// RemoveAcceptanceCriterion removes the acceptance criterion at index (0-based)
+func (s *service) RemoveAcceptanceCriterion(ctx context.Context, taskID uuid.UUID, index int, actor string) (*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCriterionIndex(task, index); err != nil {
+		return nil, err
+	}
+
+	task.AcceptanceCriteria = append(task.AcceptanceCriteria[:index], task.AcceptanceCriteria[index+1:]...)
+	task.UpdatedBy = actor
+	task.UpdatedAt = s.GetCurrentTime()
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to remove acceptance criterion: %w", err)
+	}
+
+	return task, nil
+}
+
+func checkCriterionIndex(task *types.Task, index int) error {
+	if index < 0 || index >= len(task.AcceptanceCriteria) {
+		return fmt.Errorf("acceptance criterion %d not found: task has %d criteria", index+1, len(task.AcceptanceCriteria))
+	}
+	return nil
+}
+
+// checkCompletion refuses the transition to completed while the task has
+// unverified acceptance criteria, unless AllowUnverifiedCompletion is set,
+// while its required check has not passed, or while its project requires
+// review and the task is not approved
+func (s *service) checkCompletion(task *types.Task, state types.TaskState) error {
+	if state != types.TaskStateCompleted || task.State == types.TaskStateCompleted {
+		return nil
+	}
+	if unverified := task.UnverifiedCriteria(); len(unverified) > 0 && !s.config.AllowUnverifiedCompletion {
+		return fmt.Errorf("cannot complete task '%s': %d of %d acceptance criteria are not verified (first: %q)",
+			task.Title, len(unverified), len(task.AcceptanceCriteria), unverified[0].Text)
+	}
+	if task.Check != nil && task.Check.Required && !task.Check.Passed() {
+		if task.Check.LastRun() == nil {
+			return fmt.Errorf("cannot complete task '%s': its required check has not run yet", task.Title)
+		}
+		return fmt.Errorf("cannot complete task '%s': its required check did not pass in the last run", task.Title)
+	}
+	if s.config.RequiresReview(task.ProjectID) && !task.IsApproved() {
+		if task.Review != nil && task.Review.Status == types.ReviewStatusRequested {
+			return fmt.Errorf("cannot complete task '%s': review requested by %s is not approved yet", task.Title, task.Review.RequestedBy)
+		}
+		return fmt.Errorf("cannot complete task '%s': the project requires an approved review", task.Title)
+	}
+	return nil
+}
+
+// RequestTaskReview asks for a review of the task, replacing any previous review
+func (s *service) RequestTaskReview(ctx context.Context, taskID uuid.UUID, actor string) (*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch task.State {
+	case types.TaskStateCompleted, types.TaskStateCancelled, types.TaskStateDeletionPending:
+		return nil, fmt.Errorf("cannot request review for task '%s' in state '%s'", task.Title, task.State)
+	}
+
+	now := s.GetCurrentTime()
+	task.Review = &types.TaskReview{
+		Status:      types.ReviewStatusRequested,
+		RequestedBy: actor,
+		RequestedAt: now,
+	}
+	task.UpdatedBy = actor
+	task.UpdatedAt = now
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to request review: %w", err)
+	}
+
+	return task, nil
+}
+
+// ApproveTask approves the requested review of a task. The reviewer must differ
+// from the actor who requested the review.
+func (s *service) ApproveTask(ctx context.Context, taskID uuid.UUID, reviewer, comment string) (*types.Task, error) {
+	return s.decideReview(ctx, taskID, types.ReviewStatusApproved, reviewer, comment)
+}
+
+// RejectTask rejects the requested review of a task, sending it back for more work
+func (s *service) RejectTask(ctx context.Context, taskID uuid.UUID, reviewer, comment string) (*types.Task, error) {
+	return s.decideReview(ctx, taskID, types.ReviewStatusRejected, reviewer, comment)
+}
+
+func (s *service) decideReview(ctx context.Context, taskID uuid.UUID, status types.ReviewStatus, reviewer, comment string) (*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Review == nil || task.Review.Status != types.ReviewStatusRequested {
+		return nil, fmt.Errorf("task '%s' has no pending review request", task.Title)
+	}
+	if reviewer == task.Review.RequestedBy {
+		return nil, fmt.Errorf("task '%s' must be reviewed by a different actor than %s, who requested the review",
+			task.Title, task.Review.RequestedBy)
+	}
+
+	now := s.GetCurrentTime()
+	review := *task.Review
+	review.Status = status
+	review.Reviewer = reviewer
+	review.ReviewedAt = &now
+	review.Comment = strings.TrimSpace(comment)
+	task.Review = &review
+	task.UpdatedBy = reviewer
+	task.UpdatedAt = now
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to record review: %w", err)
+	}
+
+	return task, nil
+}
+
+// SetTaskCheck sets the command that verifies a task. Runs of a previous
+// command are discarded when the command changes. An empty command removes
+// the check.
+func (s *service) SetTaskCheck(ctx context.Context, taskID uuid.UUID, command string, required bool, actor string) (*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.GetCurrentTime()
+	command = strings.TrimSpace(command)
+	switch {
+	case command == "":
+		task.Check = nil
+	case task.Check != nil && task.Check.Command == command:
+		task.Check.Required = required
+	default:
+		task.Check = &types.TaskCheck{Command: command, Required: required, SetBy: actor, SetAt: now}
+	}
+	task.UpdatedBy = actor
+	task.UpdatedAt = now
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to set task check: %w", err)
+	}
+
+	return task, nil
+}
+
+// RecordTaskCheckRun appends a run to the check of a task, keeping the latest
+// types.MaxCheckRuns runs
+func (s *service) RecordTaskCheckRun(ctx context.Context, taskID uuid.UUID, run types.CheckRun) (*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Check == nil {
+		return nil, fmt.Errorf("task '%s' has no check, set one with 'knot task set-check'", task.Title)
+	}
+
+	runs := append(task.Check.Runs, run)
+	if len(runs) > types.MaxCheckRuns {
+		runs = runs[len(runs)-types.MaxCheckRuns:]
 	}
+	task.Check.Runs = runs
+	task.UpdatedBy = run.RunBy
+	task.UpdatedAt = s.GetCurrentTime()
 
-	// Save the new task
-	if err := s.repo.CreateTask(ctx, newTask); err != nil {
-		return nil, fmt.Errorf("failed to create duplicated task: %w", err)
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to record check run: %w", err)
 	}
 
-	return s.repo.GetTask(ctx, newTask.ID)
+	return task, nil
 }
 
-// SetTaskEstimate sets the time estimate for a task
-func (s *service) SetTaskEstimate(ctx context.Context, taskID uuid.UUID, estimate int64) (*types.Task, error) {
-	// Validate estimate
-	if estimate < 0 {
-		return nil, errors.New("estimate must be non-negative")
-	}
-
+// SetTaskTags replaces the tags of a task. Tags are trimmed and deduplicated,
+// empty tags are dropped.
+func (s *service) SetTaskTags(ctx context.Context, taskID uuid.UUID, tags []string, actor string) (*types.Task, error) {
 	task, err := s.repo.GetTask(ctx, taskID)
 	if err != nil {
 		return nil, err
 	}
 
-	task.Estimate = &estimate
-	task.UpdatedAt = time.Now()
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	task.Tags = normalized
+	task.UpdatedBy = actor
+	task.UpdatedAt = s.GetCurrentTime()
 
 	if err := s.repo.UpdateTask(ctx, task); err != nil {
-		return nil, fmt.Errorf("failed to update task estimate: %w", err)
+		return nil, fmt.Errorf("failed to update task tags: %w", err)
 	}
 
-	// Return the updated task directly (avoiding redundant GetTask call)
 	return task, nil
 }
 
@@ -664,7 +1666,7 @@ func (s *service) AssignTaskToAgent(ctx context.Context, taskID uuid.UUID, agent
 	}
 
 	task.AssignedAgent = &agentID
-	task.UpdatedAt = time.Now()
+	task.UpdatedAt = s.GetCurrentTime()
 
 	if err := s.repo.UpdateTask(ctx, task); err != nil {
 		return nil, fmt.Errorf("failed to assign task to agent: %w", err)
@@ -682,7 +1684,7 @@ func (s *service) UnassignTaskFromAgent(ctx context.Context, taskID uuid.UUID) (
 	}
 
 	task.AssignedAgent = nil
-	task.UpdatedAt = time.Now()
+	task.UpdatedAt = s.GetCurrentTime()
 
 	if err := s.repo.UpdateTask(ctx, task); err != nil {
 		return nil, fmt.Errorf("failed to unassign task from agent: %w", err)
@@ -692,6 +1694,49 @@ func (s *service) UnassignTaskFromAgent(ctx context.Context, taskID uuid.UUID) (
 	return task, nil
 }
 
+// ReassignTask assigns a task to an agent, or unassigns it if agentID is nil.
+// A note is recorded as handoff for the next assignee; it is required when the
+// task was assigned to another agent and RequireHandoffNotes is set.
+func (s *service) ReassignTask(ctx context.Context, taskID uuid.UUID, agentID *uuid.UUID, note string, actor string) (*types.Task, error) {
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task not found: %w", err)
+	}
+
+	switch {
+	case agentID == nil && task.AssignedAgent == nil:
+		return nil, fmt.Errorf("task '%s' is not assigned to an agent", task.Title)
+	case agentID != nil && task.AssignedAgent != nil && *agentID == *task.AssignedAgent:
+		return nil, fmt.Errorf("task '%s' is already assigned to agent %s", task.Title, agentID)
+	}
+
+	note = strings.TrimSpace(note)
+	if note == "" && task.AssignedAgent != nil && s.config.RequireHandoffNotes {
+		return nil, knoterrors.HandoffNoteRequiredError(task.Title)
+	}
+
+	now := s.GetCurrentTime()
+	if note != "" {
+		task.Handoffs = append(task.Handoffs, types.TaskHandoff{
+			Note:      note,
+			FromAgent: task.AssignedAgent,
+			ToAgent:   agentID,
+			FromState: task.State,
+			ToState:   task.State,
+			By:        actor,
+			At:        now,
+		})
+	}
+	task.AssignedAgent = agentID
+	task.UpdatedBy = actor
+	task.UpdatedAt = now
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to reassign task: %w", err)
+	}
+	return task, nil
+}
+
 // ListTasksByAgent returns all tasks assigned to a specific agent in a project
 func (s *service) ListTasksByAgent(ctx context.Context, projectID uuid.UUID, agentID uuid.UUID) ([]*types.Task, error) {
 	// Validate project exists
@@ -699,21 +1744,12 @@ func (s *service) ListTasksByAgent(ctx context.Context, projectID uuid.UUID, age
 		return nil, fmt.Errorf("project not found: %w", err)
 	}
 
-	// Get all tasks in the project
-	allTasks, err := s.repo.GetTasksByProject(ctx, projectID)
+	// The repository filters on the assigned agent index
+	tasks, err := s.repo.ListTasks(ctx, types.TaskFilter{ProjectID: &projectID, AssignedAgent: &agentID})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project tasks: %w", err)
-	}
-
-	// Filter tasks assigned to the specific agent
-	var assignedTasks []*types.Task
-	for _, task := range allTasks {
-		if task.AssignedAgent != nil && *task.AssignedAgent == agentID {
-			assignedTasks = append(assignedTasks, task)
-		}
+		return nil, fmt.Errorf("failed to get agent tasks: %w", err)
 	}
-
-	return assignedTasks, nil
+	return tasks, nil
 }
 
 // ListUnassignedTasks returns all tasks that have no agent assigned in a project
@@ -723,21 +1759,11 @@ func (s *service) ListUnassignedTasks(ctx context.Context, projectID uuid.UUID)
 		return nil, fmt.Errorf("project not found: %w", err)
 	}
 
-	// Get all tasks in the project
-	allTasks, err := s.repo.GetTasksByProject(ctx, projectID)
+	tasks, err := s.repo.ListTasks(ctx, types.TaskFilter{ProjectID: &projectID, Unassigned: true})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project tasks: %w", err)
-	}
-
-	// Filter tasks with no agent assignment
-	var unassignedTasks []*types.Task
-	for _, task := range allTasks {
-		if task.AssignedAgent == nil {
-			unassignedTasks = append(unassignedTasks, task)
-		}
+		return nil, fmt.Errorf("failed to get unassigned tasks: %w", err)
 	}
-
-	return unassignedTasks, nil
+	return tasks, nil
 }
 
 func (s *service) FindNextActionableTask(ctx context.Context, projectID uuid.UUID) (*types.Task, error) {
@@ -820,23 +1846,22 @@ func (s *service) FindTasksNeedingBreakdown(ctx context.Context, projectID uuid.
 		return nil, fmt.Errorf("project not found: %w", err)
 	}
 
-	// Find tasks with complexity above threshold that have no children
-	tasks, err := s.repo.GetTasksByProject(ctx, projectID)
+	// Find tasks with complexity above threshold that have no children, with
+	// one query for the candidates and one for the child counts of all tasks
+	threshold := s.config.ComplexityThreshold
+	tasks, err := s.repo.ListTasks(ctx, types.TaskFilter{ProjectID: &projectID, MinComplexity: &threshold})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get project tasks: %w", err)
 	}
+	childCounts, err := s.repo.GetChildCounts(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check task children: %w", err)
+	}
 
 	var needsBreakdown []*types.Task
 	for _, task := range tasks {
-		if task.Complexity >= s.config.ComplexityThreshold {
-			// Check if task has children
-			children, err := s.repo.GetTasksByParent(ctx, task.ID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to check task children: %w", err)
-			}
-			if len(children) == 0 {
-				needsBreakdown = append(needsBreakdown, task)
-			}
+		if childCounts[task.ID] == 0 {
+			needsBreakdown = append(needsBreakdown, task)
 		}
 	}
 
@@ -849,7 +1874,79 @@ func (s *service) GetProjectProgress(ctx context.Context, projectID uuid.UUID) (
 		return nil, fmt.Errorf("project not found: %w", err)
 	}
 
-	return s.repo.GetProjectProgress(ctx, projectID)
+	progress, err := s.repo.GetProjectProgress(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress.Weighting = s.config.ProgressWeightingMode()
+	progress.WeightedProgress = progress.OverallProgress
+	if progress.Weighting == ProgressWeightingCount || progress.TotalTasks == 0 {
+		return progress, nil
+	}
+	tasks, err := s.repo.GetTasksByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks for weighted progress: %w", err)
+	}
+	progress.WeightedProgress = WeightedProgress(tasks, progress.Weighting)
+	return progress, nil
+}
+
+// GetSubtreeProgress returns the rollup progress of every task of a project
+// that has descendants, weighted like the project progress
+func (s *service) GetSubtreeProgress(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]*types.SubtreeProgress, error) {
+	counts, err := s.repo.GetSubtreeCounts(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count subtrees: %w", err)
+	}
+
+	weighting := s.config.ProgressWeightingMode()
+	progress := make(map[uuid.UUID]*types.SubtreeProgress, len(counts))
+	for taskID, c := range counts {
+		progress[taskID] = &types.SubtreeProgress{
+			Descendants:      c.Descendants,
+			Completed:        c.Completed,
+			Weighting:        weighting,
+			WeightedProgress: weightedShare(c, weighting),
+		}
+	}
+	return progress, nil
+}
+
+// WeightedProgress returns the completed share of the total task weight in
+// percent. With the complexity weighting a task weighs its complexity (at least
+// 1), with the estimate weighting its estimate in minutes; tasks without an
+// estimate weigh the average estimate of the others, or 1 if no task has one.
+// Like the raw progress, cancelled tasks count as not completed.
+func WeightedProgress(tasks []*types.Task, weighting string) float64 {
+	var counts types.SubtreeCounts
+	for _, task := range tasks {
+		counts.Add(task)
+	}
+	return weightedShare(&counts, weighting)
+}
+
+// weightedShare returns the completed share of the weight of the counted tasks
+// in percent, see WeightedProgress
+func weightedShare(c *types.SubtreeCounts, weighting string) float64 {
+	var total, completed float64
+	switch weighting {
+	case ProgressWeightingComplexity:
+		total, completed = float64(c.Complexity), float64(c.CompletedComplexity)
+	case ProgressWeightingEstimate:
+		average := 1.0
+		if c.Estimated > 0 {
+			average = float64(c.Estimate) / float64(c.Estimated)
+		}
+		total = float64(c.Estimate) + float64(c.Descendants-c.Estimated)*average
+		completed = float64(c.CompletedEstimate) + float64(c.Completed-c.CompletedEstimated)*average
+	default:
+		total, completed = float64(c.Descendants), float64(c.Completed)
+	}
+	if total == 0 {
+		return 0
+	}
+	return completed / total * 100
 }
 
 func (s *service) ListTasksByState(ctx context.Context, projectID uuid.UUID, state types.TaskState) ([]*types.Task, error) {
@@ -866,28 +1963,25 @@ func (s *service) ListTasksByState(ctx context.Context, projectID uuid.UUID, sta
 
 // Validation helpers
 
+// The service validates with the same configured validator as the CLI
+
 func (s *service) validateProjectInput(title, description string) error {
-	if title == "" {
-		return errors.New("title cannot be empty")
-	}
-	if len(title) > 200 {
-		return errors.New("title cannot exceed 200 characters")
+	validator, err := s.config.Validator()
+	if err != nil {
+		return err
 	}
-	if len(description) > s.config.MaxDescriptionLength {
-		return fmt.Errorf("description cannot exceed %d characters", s.config.MaxDescriptionLength)
+	if err := validator.ValidateProjectTitle(title); err != nil {
+		return err
 	}
-	return nil
+	return validator.ValidateProjectDescription(description)
 }
 
 func (s *service) validateTaskInput(title, description string, complexity int) error {
-	if title == "" {
-		return errors.New("title cannot be empty")
-	}
-	if len(title) > 200 {
-		return errors.New("title cannot exceed 200 characters")
+	if err := s.validateTaskTitle(title); err != nil {
+		return err
 	}
-	if len(description) > s.config.MaxDescriptionLength {
-		return fmt.Errorf("description cannot exceed %d characters", s.config.MaxDescriptionLength)
+	if err := s.validateTaskDescription(description); err != nil {
+		return err
 	}
 	if complexity < 1 || complexity > 10 {
 		return errors.New("complexity must be between 1 and 10")
@@ -895,6 +1989,30 @@ func (s *service) validateTaskInput(title, description string, complexity int) e
 	return nil
 }
 
+func (s *service) validateTaskTitle(title string) error {
+	validator, err := s.config.Validator()
+	if err != nil {
+		return err
+	}
+	return validator.ValidateTaskTitle(title)
+}
+
+func (s *service) validateTaskDescription(description string) error {
+	validator, err := s.config.Validator()
+	if err != nil {
+		return err
+	}
+	return validator.ValidateTaskDescription(description)
+}
+
+func (s *service) validateInstructions(instructions string) error {
+	validator, err := s.config.Validator()
+	if err != nil {
+		return err
+	}
+	return validator.ValidateInstructions(instructions)
+}
+
 // Config management
 
 func (s *service) GetConfig() *Config {
@@ -973,9 +2091,29 @@ func (s *service) SaveConfigToFile() error {
 
 // AddTaskDependency adds a dependency between tasks
 func (s *service) AddTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID, actor string) (*types.Task, error) {
+	if limit := s.config.DependencyLimit(); limit > 0 {
+		task, err := s.repo.GetTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if len(task.Dependencies) >= limit && !slices.Contains(task.Dependencies, dependsOnTaskID) {
+			return nil, knoterrors.DependencyLimitError(task.Title, len(task.Dependencies), limit)
+		}
+	}
 	return s.repo.AddTaskDependency(ctx, taskID, dependsOnTaskID)
 }
 
+// SetDependencyLink changes the type and lag of an existing dependency
+func (s *service) SetDependencyLink(ctx context.Context, taskID uuid.UUID, link types.DependencyLink, actor string) (*types.Task, error) {
+	if !link.Type.IsValid() {
+		return nil, fmt.Errorf("invalid dependency type %q, must be %s or %s", link.Type, types.DependencyFinishToStart, types.DependencyStartToStart)
+	}
+	if link.Lag < 0 {
+		return nil, fmt.Errorf("dependency lag must not be negative, got %d", link.Lag)
+	}
+	return s.repo.SetDependencyLink(ctx, taskID, link)
+}
+
 // RemoveTaskDependency removes a dependency between tasks
 func (s *service) RemoveTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID, actor string) (*types.Task, error) {
 	return s.repo.RemoveTaskDependency(ctx, taskID, dependsOnTaskID)
@@ -991,6 +2129,38 @@ func (s *service) GetDependentTasks(ctx context.Context, taskID uuid.UUID) ([]*t
 	return s.repo.GetDependentTasks(ctx, taskID)
 }
 
+// ListChangeEvents returns change feed events if the repository records them
+func (s *service) ListChangeEvents(ctx context.Context, filter types.ChangeEventFilter) ([]*types.ChangeEvent, error) {
+	feed, ok := s.repo.(types.ChangeFeed)
+	if !ok {
+		return nil, fmt.Errorf("the storage backend does not record change events")
+	}
+	return feed.ListChangeEvents(ctx, filter)
+}
+
+// InTransaction runs fn in a transaction of the repository
+func (s *service) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	transactor, ok := s.repo.(types.Transactor)
+	if !ok {
+		return types.ErrTransactionsUnsupported
+	}
+	return transactor.InTransaction(ctx, fn)
+}
+
+// atomically runs fn in a transaction of the repository, or directly if the
+// storage backend has no transactions
+func (s *service) atomically(ctx context.Context, fn func(ctx context.Context) error) error {
+	ran := false
+	err := s.InTransaction(ctx, func(ctx context.Context) error {
+		ran = true
+		return fn(ctx)
+	})
+	if !ran && errors.Is(err, types.ErrTransactionsUnsupported) {
+		return fn(ctx)
+	}
+	return err
+}
+
 // Helper functions for config file management
 
 // getConfigPath returns the path to the knot configuration file
@@ -1008,8 +2178,8 @@ func getConfigPath() (string, error) {
 
 // validateConfig checks if the configuration values are valid
 func validateConfig(c *Config) error {
-	if c.MaxTasksPerDepth < 1 {
-		return fmt.Errorf("max_tasks_per_depth must be at least 1, got %d", c.MaxTasksPerDepth)
+	if err := ValidateDepthLimits(c); err != nil {
+		return err
 	}
 	if c.ComplexityThreshold < 1 || c.ComplexityThreshold > 10 {
 		return fmt.Errorf("complexity_threshold must be between 1 and 10, got %d", c.ComplexityThreshold)
@@ -1020,17 +2190,276 @@ func validateConfig(c *Config) error {
 	if c.MaxDescriptionLength < 1 {
 		return fmt.Errorf("max_description_length must be at least 1, got %d", c.MaxDescriptionLength)
 	}
+	if _, err := c.Validator(); err != nil {
+		return err
+	}
+	if err := ValidateDuplicateCheck(c); err != nil {
+		return err
+	}
+	if err := ValidateProgressWeighting(c.ProgressWeighting); err != nil {
+		return err
+	}
+	if err := ValidateSelectionStrategy(c.SelectionStrategy); err != nil {
+		return err
+	}
+	if err := ValidateSelectionWeights(c.SelectionWeights); err != nil {
+		return err
+	}
+	if err := ValidateAgents(c.Agents); err != nil {
+		return err
+	}
+	if err := ValidateComplexityReductions(c.ComplexityReductions); err != nil {
+		return err
+	}
+	if err := ValidateSchedules(c.Schedules); err != nil {
+		return err
+	}
+	if err := ValidateAgingPolicies(c.AgingPolicies); err != nil {
+		return err
+	}
+	if err := ValidateCreationLimits(c.CreationLimits); err != nil {
+		return err
+	}
+	if _, err := c.TimeLocation(); err != nil {
+		return err
+	}
+	return ValidateNotifications(c.Notifications)
+}
+
+// ValidateDepthLimits checks MaxTasksPerDepth and MaxTasksAtDepth
+func ValidateDepthLimits(c *Config) error {
+	if c.MaxTasksPerDepth < 1 {
+		return fmt.Errorf("max_tasks_per_depth must be at least 1, got %d", c.MaxTasksPerDepth)
+	}
+	for depth, limit := range c.MaxTasksAtDepth {
+		if limit < 0 {
+			return fmt.Errorf("MaxTasksAtDepth[%d] must be 0 (use max_tasks_per_depth) or at least 1, got %d", depth, limit)
+		}
+	}
+	return nil
+}
+
+// ValidateDuplicateCheck checks the duplicate check mode and threshold
+func ValidateDuplicateCheck(c *Config) error {
+	switch c.DuplicateCheck {
+	case "", DuplicateCheckWarn, DuplicateCheckBlock, DuplicateCheckOff:
+	default:
+		return fmt.Errorf("duplicate_check must be warn, block or off, got '%s'", c.DuplicateCheck)
+	}
+	if c.DuplicateThreshold < 0 || c.DuplicateThreshold > 1 {
+		return fmt.Errorf("duplicate_threshold must be between 0 and 1, got %g", c.DuplicateThreshold)
+	}
+	return nil
+}
+
+// ValidateProgressWeighting checks the progress weighting
+func ValidateProgressWeighting(weighting string) error {
+	switch weighting {
+	case "", ProgressWeightingCount, ProgressWeightingComplexity, ProgressWeightingEstimate:
+		return nil
+	default:
+		return fmt.Errorf("progress_weighting must be count, complexity or estimate, got '%s'", weighting)
+	}
+}
+
+// ValidateSelectionStrategy checks the default selection strategy
+func ValidateSelectionStrategy(strategy string) error {
+	if strategy == "" {
+		return nil
+	}
+	if _, ok := selection.LookupStrategy(strategy); !ok {
+		var names []string
+		for _, s := range (&selection.StrategyFactory{}).GetAvailableStrategies() {
+			names = append(names, s.String())
+		}
+		return fmt.Errorf("selection_strategy must be one of %s, got '%s'", strings.Join(names, ", "), strategy)
+	}
+	return nil
+}
+
+// ValidateSelectionWeights checks the configured weights of the selection strategies
+func ValidateSelectionWeights(weights map[string]selection.Weights) error {
+	for name, w := range weights {
+		strategy, ok := selection.LookupStrategy(name)
+		if !ok {
+			return fmt.Errorf("selection_weights: unknown selection strategy '%s'", name)
+		}
+		if err := (&selection.StrategyFactory{}).ValidateWeights(strategy, w); err != nil {
+			return fmt.Errorf("selection_weights: invalid weights of %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ValidateAgents checks the agent registry
+func ValidateAgents(agents []AgentProfile) error {
+	names := make(map[string]bool, len(agents))
+	for i, agent := range agents {
+		if strings.TrimSpace(agent.Name) == "" {
+			return fmt.Errorf("agents[%d]: name is required", i)
+		}
+		if names[agent.Name] {
+			return fmt.Errorf("agents[%d]: duplicate agent name '%s'", i, agent.Name)
+		}
+		names[agent.Name] = true
+		if agent.ID == uuid.Nil {
+			return fmt.Errorf("agent '%s': id is required", agent.Name)
+		}
+		if err := ValidateSelectionStrategy(agent.Strategy); err != nil {
+			return fmt.Errorf("agent '%s': %w", agent.Name, err)
+		}
+		if agent.Weights != nil {
+			strategy := selection.ParseStrategy(agent.Strategy)
+			if err := (&selection.StrategyFactory{}).ValidateWeights(strategy, *agent.Weights); err != nil {
+				return fmt.Errorf("agent '%s': invalid weights: %w", agent.Name, err)
+			}
+		}
+	}
 	return nil
 }
 
-// autoReduceParentComplexity reduces parent task complexity when subtasks are added
-func (s *service) autoReduceParentComplexity(ctx context.Context, parentID uuid.UUID) error {
+// ValidateSchedules checks the scheduler configuration
+func ValidateSchedules(schedules []Schedule) error {
+	names := make(map[string]bool, len(schedules))
+	for i, sch := range schedules {
+		if sch.Name == "" {
+			return fmt.Errorf("schedules[%d]: name is required", i)
+		}
+		if names[sch.Name] {
+			return fmt.Errorf("schedules[%d]: duplicate schedule name '%s'", i, sch.Name)
+		}
+		names[sch.Name] = true
+
+		if _, err := scheduler.ParseCron(sch.Cron); err != nil {
+			return fmt.Errorf("schedule '%s': %w", sch.Name, err)
+		}
+		if sch.ProjectID == uuid.Nil {
+			return fmt.Errorf("schedule '%s': project_id is required", sch.Name)
+		}
+		if (sch.Template == "") == (sch.Task == nil) {
+			return fmt.Errorf("schedule '%s': exactly one of template and task must be set", sch.Name)
+		}
+		if task := sch.Task; task != nil {
+			if task.Title == "" {
+				return fmt.Errorf("schedule '%s': task title is required", sch.Name)
+			}
+			if task.Complexity < 0 || task.Complexity > 10 {
+				return fmt.Errorf("schedule '%s': task complexity must be between 1 and 10, got %d", sch.Name, task.Complexity)
+			}
+			switch task.Priority {
+			case "", "low", "medium", "high":
+			default:
+				return fmt.Errorf("schedule '%s': task priority must be low, medium or high, got '%s'", sch.Name, task.Priority)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateAgingPolicies checks the aging policies
+func ValidateAgingPolicies(policies []AgingPolicy) error {
+	seen := make(map[string]bool, len(policies))
+	for i, policy := range policies {
+		switch policy.Priority {
+		case "low", "medium", "high":
+		default:
+			return fmt.Errorf("aging_policies[%d]: priority must be low, medium or high, got '%s'", i, policy.Priority)
+		}
+		state := policy.TaskState()
+		switch state {
+		case types.TaskStatePending, types.TaskStateInProgress, types.TaskStateBlocked:
+		default:
+			return fmt.Errorf("aging_policies[%d]: state must be pending, in-progress or blocked, got '%s'", i, policy.State)
+		}
+		if policy.MaxAgeDays < 1 {
+			return fmt.Errorf("aging_policies[%d]: max_age_days must be at least 1, got %d", i, policy.MaxAgeDays)
+		}
+		key := policy.Priority + "/" + string(state)
+		if seen[key] {
+			return fmt.Errorf("aging_policies[%d]: duplicate policy for %s %s tasks", i, policy.Priority, state)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// ValidateCreationLimits checks the task creation limits
+func ValidateCreationLimits(limits []CreationLimit) error {
+	for i, limit := range limits {
+		if limit.Actor == "" {
+			return fmt.Errorf("creation_limits[%d]: actor pattern is required", i)
+		}
+		if _, err := path.Match(limit.Actor, ""); err != nil {
+			return fmt.Errorf("creation_limits[%d]: invalid actor pattern '%s': %w", i, limit.Actor, err)
+		}
+		if limit.MaxTasks < 1 {
+			return fmt.Errorf("creation_limits[%d]: max_tasks must be at least 1, got %d", i, limit.MaxTasks)
+		}
+		if limit.WindowMinutes < 0 {
+			return fmt.Errorf("creation_limits[%d]: window_minutes must not be negative, got %d", i, limit.WindowMinutes)
+		}
+	}
+	return nil
+}
+
+// ValidateNotifications checks the notification hooks
+func ValidateNotifications(hooks []NotificationHook) error {
+	names := make(map[string]bool, len(hooks))
+	for i, hook := range hooks {
+		if hook.Name == "" {
+			return fmt.Errorf("notifications[%d]: name is required", i)
+		}
+		if names[hook.Name] {
+			return fmt.Errorf("notifications[%d]: duplicate hook name '%s'", i, hook.Name)
+		}
+		names[hook.Name] = true
+
+		if _, err := notify.NewSender(hook.Channel, hook.WebhookURL); err != nil {
+			return fmt.Errorf("notification hook '%s': %w", hook.Name, err)
+		}
+		for _, event := range hook.Events {
+			if err := notify.ValidateEvent(event); err != nil {
+				return fmt.Errorf("notification hook '%s': %w", hook.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateComplexityReductions checks the auto-reduce table
+func ValidateComplexityReductions(reductions []ComplexityReduction) error {
+	for i, r := range reductions {
+		if r.MinSubtasks < 1 {
+			return fmt.Errorf("complexity_reductions[%d]: min_subtasks must be at least 1, got %d", i, r.MinSubtasks)
+		}
+		if r.Complexity < 0 || r.Complexity > 10 {
+			return fmt.Errorf("complexity_reductions[%d]: complexity must be between 1 and 10, got %d", i, r.Complexity)
+		}
+		if r.ReduceBy < 0 {
+			return fmt.Errorf("complexity_reductions[%d]: reduce_by must not be negative, got %d", i, r.ReduceBy)
+		}
+		if (r.Complexity == 0) == (r.ReduceBy == 0) {
+			return fmt.Errorf("complexity_reductions[%d]: exactly one of complexity and reduce_by must be set", i)
+		}
+	}
+	return nil
+}
+
+// autoReduceParentComplexity reduces parent task complexity when subtasks are added.
+// The complexity threshold decides whether a task is reduced when it is first broken
+// down; afterwards the parent is a coordination task and further subtasks keep lowering
+// it along the reduction table. Complexity is never raised.
+func (s *service) autoReduceParentComplexity(ctx context.Context, parentID uuid.UUID, actor string) error {
 	// Get parent task
 	parentTask, err := s.repo.GetTask(ctx, parentID)
 	if err != nil {
 		return fmt.Errorf("failed to get parent task: %w", err)
 	}
 
+	if parentTask.KeepComplexity {
+		return nil // Opted out of auto-reduce
+	}
+
 	// Only reduce if parent complexity is above threshold
 	if parentTask.Complexity < s.config.ComplexityThreshold {
 		return nil // No need to reduce
@@ -1041,42 +2470,30 @@ func (s *service) autoReduceParentComplexity(ctx context.Context, parentID uuid.
 	if err != nil {
 		return fmt.Errorf("failed to get child tasks: %w", err)
 	}
-
-	// Calculate new complexity based on number of children
-	// Logic: High complexity tasks become coordination tasks when broken down
-	var newComplexity int
 	childCount := len(children)
 
-	switch {
-	case childCount == 1:
-		// First subtask: reduce by 2 (e.g., 9 -> 7)
-		newComplexity = parentTask.Complexity - 2
-	case childCount <= 3:
-		// 2-3 subtasks: reduce to coordination level (complexity 4-5)
-		newComplexity = 4
-	case childCount <= 5:
-		// 4-5 subtasks: well-broken down, reduce to oversight level (complexity 3)
-		newComplexity = 3
-	default:
-		// Many subtasks: very well broken down, minimal coordination (complexity 2)
-		newComplexity = 2
+	oldComplexity := parentTask.Complexity
+	newComplexity, ok := s.config.ReducedComplexity(oldComplexity, childCount)
+	if !ok || newComplexity == oldComplexity {
+		return nil
 	}
 
-	// Ensure complexity doesn't go below 1
-	if newComplexity < 1 {
-		newComplexity = 1
+	// Updating the task records the reduction in the change feed, attributed
+	// to the actor who added the subtask
+	parentTask.Complexity = newComplexity
+	parentTask.UpdatedBy = actor
+	parentTask.UpdatedAt = s.GetCurrentTime()
+	if err := s.repo.UpdateTask(ctx, parentTask); err != nil {
+		return fmt.Errorf("failed to update parent task complexity: %w", err)
 	}
 
-	// Only update if complexity actually changed
-	if newComplexity != parentTask.Complexity {
-		parentTask.Complexity = newComplexity
-		if err := s.repo.UpdateTask(ctx, parentTask); err != nil {
-			return fmt.Errorf("failed to update parent task complexity: %w", err)
-		}
-
-		fmt.Printf("Auto-reduced parent task complexity: %s (ID: %s) %d -> %d (based on %d subtasks)\n",
-			parentTask.Title, parentTask.ID, parentTask.Complexity+2, newComplexity, childCount)
-	}
+	logger.Log.Info("Auto-reduced parent task complexity",
+		zap.String("taskID", parentTask.ID.String()),
+		zap.String("title", parentTask.Title),
+		zap.Int("from", oldComplexity),
+		zap.Int("to", newComplexity),
+		zap.Int("subtasks", childCount),
+		zap.String("actor", actor))
 
 	return nil
 }
@@ -1213,7 +2630,22 @@ func (s *service) HasSelectedProject(ctx context.Context) (bool, error) {
 	return s.repo.HasSelectedProject(ctx)
 }
 
-// GetCurrentTime returns the current time
+// GetSessionProject retrieves the project selected in a session
+func (s *service) GetSessionProject(ctx context.Context, session string) (*uuid.UUID, error) {
+	return s.repo.GetSessionProject(ctx, session)
+}
+
+// SetSessionProject selects a project for one session
+func (s *service) SetSessionProject(ctx context.Context, session string, projectID uuid.UUID, actor string) error {
+	return s.repo.SetSessionProject(ctx, session, projectID, actor)
+}
+
+// ClearSessionProject removes the project selected in a session
+func (s *service) ClearSessionProject(ctx context.Context, session string) error {
+	return s.repo.ClearSessionProject(ctx, session)
+}
+
+// GetCurrentTime returns the current time of the service clock
 func (s *service) GetCurrentTime() time.Time {
-	return time.Now()
+	return s.clock.Now()
 }
@@ -2,14 +2,20 @@ package manager
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	knoterrors "github.com/denkhaus/knot/v2/internal/errors"
 	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/denkhaus/knot/v2/internal/selection"
 	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/validation"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -211,6 +217,21 @@ func TestTaskManagement(t *testing.T) {
 		assert.False(t, updated.UpdatedAt.IsZero())
 	})
 
+	t.Run("Set task tags", func(t *testing.T) {
+		task, err := service.CreateTask(ctx, project.ID, nil, "Tag Test", "Tagged task", 3, types.TaskPriorityMedium, "test-user")
+		require.NoError(t, err)
+
+		// Tags are trimmed, deduplicated and empty tags dropped
+		updated, err := service.SetTaskTags(ctx, task.ID, []string{" backend ", "api", "backend", ""}, "tagger")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend", "api"}, updated.Tags)
+		assert.Equal(t, "tagger", updated.UpdatedBy)
+
+		cleared, err := service.SetTaskTags(ctx, task.ID, nil, "tagger")
+		require.NoError(t, err)
+		assert.Empty(t, cleared.Tags)
+	})
+
 	t.Run("Delete task", func(t *testing.T) {
 		// Create a task
 		task, err := service.CreateTask(ctx, project.ID, nil, "To Delete", "Will be deleted", 3, types.TaskPriorityMedium, "test-user")
@@ -278,6 +299,28 @@ func TestTaskDependencies(t *testing.T) {
 		assert.NotNil(t, updatedD)
 	})
 
+	t.Run("Set dependency link", func(t *testing.T) {
+		taskG, err := service.CreateTask(ctx, project.ID, nil, "Task G", "Seventh task", 3, types.TaskPriorityMedium, "test-user")
+		require.NoError(t, err)
+		taskH, err := service.CreateTask(ctx, project.ID, nil, "Task H", "Eighth task", 4, types.TaskPriorityMedium, "test-user")
+		require.NoError(t, err)
+
+		_, err = service.AddTaskDependency(ctx, taskH.ID, taskG.ID, "test-user")
+		require.NoError(t, err)
+
+		link := types.DependencyLink{DependsOnID: taskG.ID, Type: types.DependencyStartToStart, Lag: 60}
+		updatedH, err := service.SetDependencyLink(ctx, taskH.ID, link, "test-user")
+		require.NoError(t, err)
+		assert.Equal(t, link, updatedH.DependencyLink(taskG.ID))
+
+		_, err = service.SetDependencyLink(ctx, taskH.ID, types.DependencyLink{DependsOnID: taskG.ID, Type: "finish-to-finish"}, "test-user")
+		assert.ErrorContains(t, err, "invalid dependency type")
+		_, err = service.SetDependencyLink(ctx, taskH.ID, types.DependencyLink{DependsOnID: taskG.ID, Type: types.DependencyFinishToStart, Lag: -1}, "test-user")
+		assert.ErrorContains(t, err, "must not be negative")
+		_, err = service.SetDependencyLink(ctx, taskG.ID, link, "test-user")
+		assert.Error(t, err, "missing dependencies cannot be changed")
+	})
+
 	t.Run("Prevent circular dependencies", func(t *testing.T) {
 		// Create two tasks
 		taskE, err := service.CreateTask(ctx, project.ID, nil, "Task E", "Fifth task", 3, types.TaskPriorityMedium, "test-user")
@@ -297,6 +340,105 @@ func TestTaskDependencies(t *testing.T) {
 	})
 }
 
+// TestAutoReduceComplexity tests parent complexity reduction when subtasks are added
+func TestAutoReduceComplexity(t *testing.T) {
+	repo := inmemory.NewMemoryRepository()
+	service := NewManagerWithRepository(repo, DefaultConfig())
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Reduce Project", "Auto-reduce tests", "test-user")
+	require.NoError(t, err)
+
+	addSubtasks := func(t *testing.T, parentID uuid.UUID, count int) {
+		for i := 0; i < count; i++ {
+			_, err := service.CreateTask(ctx, project.ID, &parentID, fmt.Sprintf("Subtask %d", i), "", 3, types.TaskPriorityMedium, "splitter")
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("Reduces along the table", func(t *testing.T) {
+		parent, err := service.CreateTask(ctx, project.ID, nil, "Big Task", "", 9, types.TaskPriorityMedium, "test-user")
+		require.NoError(t, err)
+
+		addSubtasks(t, parent.ID, 1)
+		reduced, err := service.GetTask(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 7, reduced.Complexity)
+		assert.Equal(t, "splitter", reduced.UpdatedBy)
+
+		// The reduction is recorded in the change feed like other field changes
+		events, err := service.ListChangeEvents(ctx, types.ChangeEventFilter{ProjectID: &project.ID, Descending: true})
+		require.NoError(t, err)
+		var recorded *types.ChangeEvent
+		for _, event := range events {
+			if event.Kind == types.ChangeTaskUpdated && *event.TaskID == parent.ID {
+				recorded = event
+				break
+			}
+		}
+		require.NotNil(t, recorded)
+		assert.Equal(t, "splitter", recorded.Actor)
+		var snapshot types.Task
+		require.NoError(t, json.Unmarshal(recorded.Data, &snapshot))
+		assert.Equal(t, 7, snapshot.Complexity)
+
+		// Once below the threshold, further subtasks leave the parent alone
+		addSubtasks(t, parent.ID, 3)
+		reduced, err = service.GetTask(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 7, reduced.Complexity)
+	})
+
+	t.Run("Below threshold is left alone", func(t *testing.T) {
+		parent, err := service.CreateTask(ctx, project.ID, nil, "Small Task", "", 5, types.TaskPriorityMedium, "test-user")
+		require.NoError(t, err)
+
+		addSubtasks(t, parent.ID, 3)
+		unchanged, err := service.GetTask(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, unchanged.Complexity)
+	})
+
+	t.Run("Keep complexity opts out", func(t *testing.T) {
+		parent, err := service.CreateTask(ctx, project.ID, nil, "Kept Task", "", 9, types.TaskPriorityMedium, "test-user")
+		require.NoError(t, err)
+		_, err = service.SetTaskKeepComplexity(ctx, parent.ID, true, "test-user")
+		require.NoError(t, err)
+
+		addSubtasks(t, parent.ID, 4)
+		kept, err := service.GetTask(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 9, kept.Complexity)
+		assert.True(t, kept.KeepComplexity)
+	})
+
+	t.Run("Configured table", func(t *testing.T) {
+		config := DefaultConfig()
+		config.ComplexityReductions = []ComplexityReduction{{MinSubtasks: 2, Complexity: 5}}
+		service.UpdateConfig(config)
+		defer service.UpdateConfig(DefaultConfig())
+
+		parent, err := service.CreateTask(ctx, project.ID, nil, "Custom Task", "", 9, types.TaskPriorityMedium, "test-user")
+		require.NoError(t, err)
+
+		addSubtasks(t, parent.ID, 1)
+		task, err := service.GetTask(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 9, task.Complexity, "no step applies to a single subtask")
+
+		addSubtasks(t, parent.ID, 1)
+		task, err = service.GetTask(ctx, parent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, task.Complexity)
+	})
+
+	t.Run("Invalid table is rejected", func(t *testing.T) {
+		config := DefaultConfig()
+		config.ComplexityReductions = []ComplexityReduction{{MinSubtasks: 2, Complexity: 5, ReduceBy: 1}}
+		assert.Error(t, validateConfig(config))
+	})
+}
+
 // TestValidationRules tests business logic validation
 func TestValidationRules(t *testing.T) {
 	repo := inmemory.NewMemoryRepository()
@@ -345,6 +487,30 @@ func TestValidationRules(t *testing.T) {
 		_, err := service.CreateTask(ctx, project.ID, nil, "Long Desc Task", longDesc, 3, types.TaskPriorityMedium, "test-user")
 		assert.Error(t, err, "Should reject overly long description")
 	})
+
+	t.Run("Configured rules", func(t *testing.T) {
+		_, err := service.CreateTask(ctx, project.ID, nil, "Fix <b>login</b>", "", 3, types.TaskPriorityMedium, "test-user")
+		assert.ErrorContains(t, err, "HTML tags", "markup is rejected by default")
+
+		rules := DefaultConfig()
+		rules.MaxTitleLength = 10
+		rules.Markup = "allow"
+		rules.BannedPatterns = []string{`(?i)\bpassword\b`}
+		service.UpdateConfig(rules)
+		defer service.UpdateConfig(config)
+
+		task, err := service.CreateTask(ctx, project.ID, nil, "<b>Fix</b>", "", 3, types.TaskPriorityMedium, "test-user")
+		require.NoError(t, err)
+
+		_, err = service.UpdateTaskTitle(ctx, task.ID, "Rename the task", "test-user")
+		assert.ErrorContains(t, err, "title too long: 15 characters (max: 10)")
+
+		_, err = service.UpdateTaskDescription(ctx, task.ID, "The Password is hunter2", "test-user")
+		assert.ErrorContains(t, err, "banned pattern")
+
+		_, err = service.UpdateProjectDescription(ctx, project.ID, "no password here", "test-user")
+		assert.ErrorContains(t, err, "banned pattern")
+	})
 }
 
 // TestErrorHandling tests error scenarios
@@ -515,4 +681,1247 @@ func TestManagerWithSQLite(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, types.TaskStateInProgress, updated.State)
 	})
+
+	t.Run("project document", func(t *testing.T) {
+		ctx := context.Background()
+		repo, cleanup := setupSQLiteTestRepository(t)
+		defer cleanup()
+
+		service := NewManagerWithRepository(repo, DefaultConfig())
+		project, err := service.CreateProject(ctx, "Document Test", "Short description", "creator")
+		require.NoError(t, err)
+		assert.Empty(t, project.Document)
+
+		document := "# Goals\n\n" + strings.Repeat("Keep the tasks small.\n", 200)
+		updated, err := service.UpdateProjectDocument(ctx, project.ID, document, "writer")
+		require.NoError(t, err)
+		assert.Equal(t, document, updated.Document)
+		assert.Equal(t, "Short description", updated.Description)
+
+		_, err = service.UpdateProjectDocument(ctx, project.ID, strings.Repeat("x", validation.MaxProjectDocumentLength+1), "writer")
+		assert.ErrorContains(t, err, "project document too long")
+
+		// Updating the project keeps the document
+		_, err = service.UpdateProjectDescription(ctx, project.ID, "New description", "writer")
+		require.NoError(t, err)
+		retrieved, err := service.GetProject(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, document, retrieved.Document)
+
+		cleared, err := service.UpdateProjectDocument(ctx, project.ID, "", "writer")
+		require.NoError(t, err)
+		assert.Empty(t, cleared.Document)
+	})
+
+	t.Run("instructions", func(t *testing.T) {
+		ctx := context.Background()
+		repo, cleanup := setupSQLiteTestRepository(t)
+		defer cleanup()
+
+		service := NewManagerWithRepository(repo, DefaultConfig())
+		project, err := service.CreateProject(ctx, "Instructions Test", "", "creator")
+		require.NoError(t, err)
+		task, err := service.CreateTask(ctx, project.ID, nil, "Task", "Description", 3, types.TaskPriorityMedium, "creator")
+		require.NoError(t, err)
+
+		updatedProject, err := service.UpdateProjectInstructions(ctx, project.ID, "Always write tests", "writer")
+		require.NoError(t, err)
+		assert.Equal(t, "Always write tests", updatedProject.Instructions)
+
+		updatedTask, err := service.UpdateTaskInstructions(ctx, task.ID, "Use library X", "writer")
+		require.NoError(t, err)
+		assert.Equal(t, "Use library X", updatedTask.Instructions)
+		assert.Equal(t, "Description", updatedTask.Description)
+
+		// Other task updates keep the instructions
+		_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateInProgress, "writer")
+		require.NoError(t, err)
+		retrieved, err := service.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Use library X", retrieved.Instructions)
+
+		_, err = service.UpdateTaskInstructions(ctx, task.ID, strings.Repeat("x", DefaultConfig().MaxDescriptionLength+1), "writer")
+		assert.ErrorContains(t, err, "instructions too long")
+
+		cleared, err := service.UpdateTaskInstructions(ctx, task.ID, "", "writer")
+		require.NoError(t, err)
+		assert.Empty(t, cleared.Instructions)
+	})
+}
+
+// TestGetTaskCapacity tests the remaining capacity report and the hints in limit errors
+func TestGetTaskCapacity(t *testing.T) {
+	repo := inmemory.NewMemoryRepository()
+	config := DefaultConfig()
+	config.MaxTasksPerDepth = 2
+	config.MaxDepth = 1
+	service := NewManagerWithRepository(repo, config)
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Capacity Test", "Project for capacity tests", "test-user")
+	require.NoError(t, err)
+
+	root, err := service.CreateTask(ctx, project.ID, nil, "Root", "", 5, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	capacity, err := service.GetTaskCapacity(ctx, project.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, capacity.TargetDepth)
+	assert.Equal(t, 1, capacity.Remaining)
+	require.Len(t, capacity.Depths, 2)
+	assert.Equal(t, 1, capacity.Depths[0].Count)
+	assert.Equal(t, 2, capacity.Depths[1].Remaining)
+
+	capacity, err = service.GetTaskCapacity(ctx, project.ID, &root.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, capacity.TargetDepth)
+	assert.Equal(t, 2, capacity.Remaining)
+
+	_, err = service.CreateTask(ctx, project.ID, nil, "Second Root", "", 5, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = service.CreateTask(ctx, project.ID, nil, "Third Root", "", 5, types.TaskPriorityMedium, "test-user")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "depth 1 has 2 free")
+
+	child, err := service.CreateTask(ctx, project.ID, &root.ID, "Child", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	capacity, err = service.GetTaskCapacity(ctx, project.ID, &child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, capacity.TargetDepth)
+	assert.Equal(t, 0, capacity.Remaining)
+
+	_, err = service.CreateTask(ctx, project.ID, &child.ID, "Too deep", "", 3, types.TaskPriorityMedium, "test-user")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maximum depth of 1 exceeded")
+}
+
+// TestDepthLimitOverrides tests per-depth limits and the exemption of large breakdowns
+func TestDepthLimitOverrides(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxTasksPerDepth = 2
+	config.MaxTasksAtDepth = []int{3, 0, 1}
+	config.ComplexityThreshold = 8
+	config.MaxTasksPerProject = -1
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), config)
+	ctx := context.Background()
+	project, err := service.CreateProject(ctx, "Depth Limits", "", "test-user")
+	require.NoError(t, err)
+	create := func(parentID *uuid.UUID, title string, complexity int) (*types.Task, error) {
+		return service.CreateTask(ctx, project.ID, parentID, title, "", complexity, types.TaskPriorityMedium, "test-user")
+	}
+
+	assert.Equal(t, 3, config.MaxTasksAt(0))
+	assert.Equal(t, 2, config.MaxTasksAt(1), "0 falls back to MaxTasksPerDepth")
+	assert.Equal(t, 1, config.MaxTasksAt(2))
+	assert.Equal(t, 2, config.MaxTasksAt(3), "deeper levels use MaxTasksPerDepth")
+
+	large, err := create(nil, "Large", 9)
+	require.NoError(t, err)
+	small, err := create(nil, "Small", 5)
+	require.NoError(t, err)
+	_, err = create(nil, "Third root", 3)
+	require.NoError(t, err, "depth 0 allows 3 tasks")
+	_, err = create(nil, "Fourth root", 3)
+	assert.ErrorContains(t, err, "3/3 at depth 0")
+
+	for i := 0; i < 2; i++ {
+		_, err = create(&large.ID, fmt.Sprintf("Part %d", i), 3)
+		require.NoError(t, err)
+	}
+	_, err = create(&large.ID, "Part 2", 3)
+	assert.ErrorContains(t, err, "2/2 at depth 1", "without AllowLargeBreakdowns the limit applies")
+
+	config.AllowLargeBreakdowns = true
+	// The auto-reduced parent still counts with the complexity it had
+	reduced, err := service.GetTask(ctx, large.ID)
+	require.NoError(t, err)
+	require.Less(t, reduced.Complexity, config.ComplexityThreshold)
+	for i := 2; i < 5; i++ {
+		_, err = create(&large.ID, fmt.Sprintf("Part %d", i), 3)
+		require.NoError(t, err)
+	}
+	_, err = create(&small.ID, "Small part", 3)
+	assert.ErrorContains(t, err, "5/2 at depth 1", "parents below the threshold stay limited")
+
+	capacity, err := service.GetTaskCapacity(ctx, large.ProjectID, &large.ID)
+	require.NoError(t, err)
+	assert.True(t, capacity.DepthLimitExempt)
+	assert.Equal(t, -1, capacity.Remaining)
+	assert.Equal(t, 2, capacity.Depths[1].Max)
+	assert.Equal(t, 1, capacity.Depths[2].Max)
+
+	capacity, err = service.GetTaskCapacity(ctx, large.ProjectID, &small.ID)
+	require.NoError(t, err)
+	assert.False(t, capacity.DepthLimitExempt)
+	assert.Equal(t, 0, capacity.Remaining)
+
+	assert.ErrorContains(t, ValidateDepthLimits(&Config{MaxTasksPerDepth: 1, MaxTasksAtDepth: []int{1, -1}}), "MaxTasksAtDepth[1]")
+	config.SetMaxTasksAt(2, 0)
+	assert.Equal(t, []int{3}, config.MaxTasksAtDepth)
+	config.SetMaxTasksAt(0, 0)
+	assert.Nil(t, config.MaxTasksAtDepth)
+}
+
+// TestSoftLimits tests the project task and dependency limits
+func TestSoftLimits(t *testing.T) {
+	config := DefaultConfig()
+	config.MaxTasksPerProject = 5
+	config.MaxDependenciesPerTask = 2
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), config)
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Limits Test", "", "test-user")
+	require.NoError(t, err)
+
+	var tasks []*types.Task
+	for i := 0; i < 5; i++ {
+		task, err := service.CreateTask(ctx, project.ID, nil, fmt.Sprintf("Task %d", i), "", 3, types.TaskPriorityMedium, "test-user")
+		require.NoError(t, err)
+		tasks = append(tasks, task)
+
+		capacity, err := service.GetTaskCapacity(ctx, project.ID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, i+1, capacity.ProjectTasks)
+		assert.Equal(t, 4-i, capacity.Remaining)
+		assert.Equal(t, i >= 3, NearLimit(capacity.ProjectTasks, capacity.MaxProjectTasks), "warned from 80%% on")
+	}
+	_, err = service.CreateTask(ctx, project.ID, &tasks[0].ID, "Sixth", "", 3, types.TaskPriorityMedium, "test-user")
+	assert.ErrorContains(t, err, "project task limit reached: 5/5 tasks")
+
+	_, err = service.AddTaskDependency(ctx, tasks[0].ID, tasks[1].ID, "test-user")
+	require.NoError(t, err)
+	task, err := service.AddTaskDependency(ctx, tasks[0].ID, tasks[2].ID, "test-user")
+	require.NoError(t, err)
+	assert.True(t, NearLimit(len(task.Dependencies), config.DependencyLimit()))
+	_, err = service.AddTaskDependency(ctx, tasks[0].ID, tasks[3].ID, "test-user")
+	assert.ErrorContains(t, err, "already has 2 of at most 2 dependencies")
+
+	config.MaxTasksPerProject = -1
+	config.MaxDependenciesPerTask = -1
+	_, err = service.CreateTask(ctx, project.ID, nil, "Unlimited", "", 3, types.TaskPriorityMedium, "test-user")
+	assert.NoError(t, err)
+	_, err = service.AddTaskDependency(ctx, tasks[0].ID, tasks[3].ID, "test-user")
+	assert.NoError(t, err)
+}
+
+// TestCreationLimits tests that actors matching a creation limit cannot
+// create more tasks within its window
+func TestCreationLimits(t *testing.T) {
+	repos := map[string]func(t *testing.T) (types.Repository, func()){
+		"inmemory": func(t *testing.T) (types.Repository, func()) {
+			return inmemory.NewMemoryRepository(), func() {}
+		},
+		"sqlite": setupSQLiteTestRepository,
+	}
+
+	for name, setup := range repos {
+		t.Run(name, func(t *testing.T) {
+			repo, cleanup := setup(t)
+			defer cleanup()
+			config := DefaultConfig()
+			config.CreationLimits = []CreationLimit{{Actor: "agent-*", MaxTasks: 3, WindowMinutes: 30}}
+			clock := &testClock{now: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)}
+			service := NewManagerWithRepository(struct {
+				types.Repository
+				*testClock
+			}{repo, clock}, config)
+			ctx := context.Background()
+
+			project, err := service.CreateProject(ctx, "Creation Limits", "", "test-user")
+			require.NoError(t, err)
+			create := func(title, actor string) error {
+				_, err := service.CreateTask(ctx, project.ID, nil, title, "", 3, types.TaskPriorityMedium, actor)
+				return err
+			}
+
+			for i := 0; i < 3; i++ {
+				require.NoError(t, create(fmt.Sprintf("Agent task %d", i), "agent-1"))
+			}
+			err = create("One too many", "agent-1")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "'agent-1' created 3 tasks in the last 30m0s, at most 3 allowed for 'agent-*'")
+
+			// Every matching actor is counted on their own, others are not limited
+			assert.NoError(t, create("Other agent", "agent-2"))
+			for i := 0; i < 4; i++ {
+				assert.NoError(t, create(fmt.Sprintf("Human task %d", i), "alice"))
+			}
+
+			// Tasks created before the window do not count
+			clock.now = clock.now.Add(31 * time.Minute)
+			assert.NoError(t, create("Next window", "agent-1"))
+		})
+	}
+}
+
+func TestValidateCreationLimits(t *testing.T) {
+	assert.NoError(t, ValidateCreationLimits([]CreationLimit{{Actor: "agent-*", MaxTasks: 50}}))
+	assert.ErrorContains(t, ValidateCreationLimits([]CreationLimit{{MaxTasks: 50}}), "actor pattern is required")
+	assert.ErrorContains(t, ValidateCreationLimits([]CreationLimit{{Actor: "agent-[", MaxTasks: 50}}), "invalid actor pattern")
+	assert.ErrorContains(t, ValidateCreationLimits([]CreationLimit{{Actor: "agent-*"}}), "max_tasks must be at least 1")
+	assert.ErrorContains(t, ValidateCreationLimits([]CreationLimit{{Actor: "agent-*", MaxTasks: 5, WindowMinutes: -1}}), "window_minutes must not be negative")
+}
+
+func TestListChangeEvents(t *testing.T) {
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), DefaultConfig())
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Events Test", "Project for change feed tests", "test-user")
+	require.NoError(t, err)
+	first, err := service.CreateTask(ctx, project.ID, nil, "First", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	second, err := service.CreateTask(ctx, project.ID, nil, "Second", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = service.AddTaskDependency(ctx, second.ID, first.ID, "test-user")
+	require.NoError(t, err)
+	_, err = service.UpdateTaskState(ctx, first.ID, types.TaskStateInProgress, "agent")
+	require.NoError(t, err)
+
+	events, err := service.ListChangeEvents(ctx, types.ChangeEventFilter{ProjectID: &project.ID})
+	require.NoError(t, err)
+
+	var kinds []types.ChangeEventKind
+	for _, event := range events {
+		kinds = append(kinds, event.Kind)
+	}
+	assert.Equal(t, []types.ChangeEventKind{
+		types.ChangeProjectCreated,
+		types.ChangeTaskCreated,
+		types.ChangeTaskCreated,
+		types.ChangeDependencyAdded,
+		types.ChangeTaskUpdated,
+	}, kinds)
+	assert.Equal(t, "agent", events[4].Actor)
+
+	resumed, err := service.ListChangeEvents(ctx, types.ChangeEventFilter{AfterSeq: events[2].Seq})
+	require.NoError(t, err)
+	require.Len(t, resumed, 2)
+	assert.Equal(t, events[3].Seq, resumed[0].Seq)
+}
+
+func TestAcceptanceCriteria(t *testing.T) {
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), DefaultConfig())
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Criteria Test", "Project for acceptance criteria tests", "test-user")
+	require.NoError(t, err)
+	parent, err := service.CreateTask(ctx, project.ID, nil, "Parent", "", 5, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	child, err := service.CreateTask(ctx, project.ID, &parent.ID, "Child", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	_, err = service.AddAcceptanceCriterion(ctx, parent.ID, "Docs updated", "test-user")
+	require.NoError(t, err)
+	_, err = service.AddAcceptanceCriterion(ctx, child.ID, "Tests pass", "test-user")
+	require.NoError(t, err)
+	task, err := service.AddAcceptanceCriterion(ctx, child.ID, "Obsolete", "test-user")
+	require.NoError(t, err)
+	require.Len(t, task.AcceptanceCriteria, 2)
+	assert.Len(t, task.UnverifiedCriteria(), 2)
+
+	_, err = service.AddAcceptanceCriterion(ctx, child.ID, "  ", "test-user")
+	assert.Error(t, err)
+	_, err = service.SetAcceptanceCriterionVerified(ctx, child.ID, 5, true, "test-user")
+	assert.Error(t, err)
+
+	task, err = service.RemoveAcceptanceCriterion(ctx, child.ID, 1, "test-user")
+	require.NoError(t, err)
+	require.Len(t, task.AcceptanceCriteria, 1)
+	assert.Equal(t, "Tests pass", task.AcceptanceCriteria[0].Text)
+
+	// Completion is refused while criteria are unverified
+	_, err = service.UpdateTaskState(ctx, child.ID, types.TaskStateInProgress, "agent")
+	require.NoError(t, err)
+	_, err = service.UpdateTaskState(ctx, child.ID, types.TaskStateCompleted, "agent")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Tests pass")
+
+	task, err = service.SetAcceptanceCriterionVerified(ctx, child.ID, 0, true, "reviewer")
+	require.NoError(t, err)
+	assert.True(t, task.AcceptanceCriteria[0].Verified)
+	assert.Equal(t, "reviewer", task.AcceptanceCriteria[0].VerifiedBy)
+	assert.NotNil(t, task.AcceptanceCriteria[0].VerifiedAt)
+	assert.Empty(t, task.UnverifiedCriteria())
+
+	_, err = service.UpdateTaskState(ctx, child.ID, types.TaskStateCompleted, "agent")
+	require.NoError(t, err)
+
+	// The parent is not auto-completed while its own criteria are unverified
+	parentTask, err := service.GetTask(ctx, parent.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, types.TaskStateCompleted, parentTask.State)
+
+	// The check can be disabled in the configuration
+	config := DefaultConfig()
+	config.AllowUnverifiedCompletion = true
+	service.UpdateConfig(config)
+	_, err = service.UpdateTaskState(ctx, parent.ID, types.TaskStateCompleted, "agent")
+	assert.NoError(t, err)
+}
+
+func TestBlockTask(t *testing.T) {
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), DefaultConfig())
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Block Test", "Project for external blockers", "test-user")
+	require.NoError(t, err)
+	task, err := service.CreateTask(ctx, project.ID, nil, "Integrate API", "", 3, types.TaskPriorityMedium, "dev")
+	require.NoError(t, err)
+
+	_, err = service.BlockTask(ctx, task.ID, "  ", "", "dev")
+	assert.ErrorContains(t, err, "reason cannot be empty")
+
+	blocked, err := service.BlockTask(ctx, task.ID, "waiting for vendor", "SUP-123", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskStateBlocked, blocked.State)
+	require.NotNil(t, blocked.Blocker)
+	assert.Equal(t, "waiting for vendor", blocked.Blocker.Reason)
+	assert.Equal(t, "SUP-123", blocked.Blocker.Reference)
+	assert.Equal(t, "dev", blocked.Blocker.BlockedBy)
+
+	// Blocking again replaces the reason
+	blocked, err = service.BlockTask(ctx, task.ID, "waiting for legal", "", "lead")
+	require.NoError(t, err)
+	assert.Equal(t, "waiting for legal", blocked.Blocker.Reason)
+	assert.Empty(t, blocked.Blocker.Reference)
+
+	// Leaving the blocked state clears the blocker
+	resumed, err := service.UpdateTaskState(ctx, task.ID, types.TaskStateInProgress, "dev")
+	require.NoError(t, err)
+	assert.Nil(t, resumed.Blocker)
+
+	_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateCompleted, "dev")
+	require.NoError(t, err)
+	_, err = service.BlockTask(ctx, task.ID, "waiting for vendor", "", "dev")
+	assert.ErrorContains(t, err, "invalid state transition")
+}
+
+func TestTaskCheck(t *testing.T) {
+	repos := map[string]func(t *testing.T) (types.Repository, func()){
+		"inmemory": func(t *testing.T) (types.Repository, func()) {
+			return inmemory.NewMemoryRepository(), func() {}
+		},
+		"sqlite": setupSQLiteTestRepository,
+	}
+
+	for name, setup := range repos {
+		t.Run(name, func(t *testing.T) {
+			repo, cleanup := setup(t)
+			defer cleanup()
+			service := NewManagerWithRepository(repo, DefaultConfig())
+			ctx := context.Background()
+
+			project, err := service.CreateProject(ctx, "Check Test", "", "lead")
+			require.NoError(t, err)
+			task, err := service.CreateTask(ctx, project.ID, nil, "Parser", "", 3, types.TaskPriorityMedium, "lead")
+			require.NoError(t, err)
+
+			_, err = service.RecordTaskCheckRun(ctx, task.ID, types.CheckRun{Passed: true})
+			assert.ErrorContains(t, err, "has no check")
+
+			checked, err := service.SetTaskCheck(ctx, task.ID, " go test ./pkg/parser ", true, "lead")
+			require.NoError(t, err)
+			require.NotNil(t, checked.Check)
+			assert.Equal(t, "go test ./pkg/parser", checked.Check.Command)
+			assert.Equal(t, "lead", checked.Check.SetBy)
+
+			_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateInProgress, "dev")
+			require.NoError(t, err)
+			_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateCompleted, "dev")
+			assert.ErrorContains(t, err, "required check has not run yet")
+
+			for i := 0; i < types.MaxCheckRuns+2; i++ {
+				_, err = service.RecordTaskCheckRun(ctx, task.ID, types.CheckRun{ExitCode: 1, Output: fmt.Sprintf("run %d", i), RunBy: "dev"})
+				require.NoError(t, err)
+			}
+			_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateCompleted, "dev")
+			assert.ErrorContains(t, err, "required check did not pass")
+
+			passed, err := service.RecordTaskCheckRun(ctx, task.ID, types.CheckRun{Passed: true, RunBy: "dev"})
+			require.NoError(t, err)
+			require.Len(t, passed.Check.Runs, types.MaxCheckRuns)
+			assert.Equal(t, "run 3", passed.Check.Runs[0].Output)
+			assert.True(t, passed.Check.Passed())
+			assert.Equal(t, "dev", passed.UpdatedBy)
+
+			// Keeping the command keeps its runs, a new command starts over
+			optional, err := service.SetTaskCheck(ctx, task.ID, "go test ./pkg/parser", false, "lead")
+			require.NoError(t, err)
+			assert.False(t, optional.Check.Required)
+			assert.Len(t, optional.Check.Runs, types.MaxCheckRuns)
+			changed, err := service.SetTaskCheck(ctx, task.ID, "go test ./...", true, "lead")
+			require.NoError(t, err)
+			assert.Empty(t, changed.Check.Runs)
+			_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateCompleted, "dev")
+			assert.ErrorContains(t, err, "required check has not run yet")
+
+			removed, err := service.SetTaskCheck(ctx, task.ID, "", false, "lead")
+			require.NoError(t, err)
+			assert.Nil(t, removed.Check)
+			_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateCompleted, "dev")
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTaskReview(t *testing.T) {
+	config := DefaultConfig()
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), config)
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Review Test", "Project for review workflow tests", "test-user")
+	require.NoError(t, err)
+	other, err := service.CreateProject(ctx, "No Review", "Project without review", "test-user")
+	require.NoError(t, err)
+	config.SetReviewRequired(project.ID, true)
+	assert.True(t, config.RequiresReview(project.ID))
+	assert.False(t, config.RequiresReview(other.ID))
+
+	task, err := service.CreateTask(ctx, project.ID, nil, "Reviewed", "", 3, types.TaskPriorityMedium, "dev")
+	require.NoError(t, err)
+	_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateInProgress, "dev")
+	require.NoError(t, err)
+
+	// Completion needs an approved review
+	_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateCompleted, "dev")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires an approved review")
+
+	_, err = service.ApproveTask(ctx, task.ID, "reviewer", "")
+	assert.Error(t, err, "approval without a review request")
+
+	reviewed, err := service.RequestTaskReview(ctx, task.ID, "dev")
+	require.NoError(t, err)
+	require.NotNil(t, reviewed.Review)
+	assert.Equal(t, types.ReviewStatusRequested, reviewed.Review.Status)
+	assert.Equal(t, "dev", reviewed.Review.RequestedBy)
+
+	_, err = service.ApproveTask(ctx, task.ID, "dev", "")
+	assert.Error(t, err, "the requester cannot approve")
+
+	reviewed, err = service.RejectTask(ctx, task.ID, "reviewer", "needs tests")
+	require.NoError(t, err)
+	assert.Equal(t, types.ReviewStatusRejected, reviewed.Review.Status)
+	assert.Equal(t, "needs tests", reviewed.Review.Comment)
+	_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateCompleted, "dev")
+	assert.Error(t, err)
+
+	_, err = service.RequestTaskReview(ctx, task.ID, "dev")
+	require.NoError(t, err)
+	reviewed, err = service.ApproveTask(ctx, task.ID, "reviewer", "")
+	require.NoError(t, err)
+	assert.True(t, reviewed.IsApproved())
+	assert.Equal(t, "reviewer", reviewed.Review.Reviewer)
+	require.NotNil(t, reviewed.Review.ReviewedAt)
+
+	_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateCompleted, "dev")
+	require.NoError(t, err)
+	_, err = service.RequestTaskReview(ctx, task.ID, "dev")
+	assert.Error(t, err, "completed tasks cannot be reviewed again")
+
+	// Projects without the requirement complete as before
+	free, err := service.CreateTask(ctx, other.ID, nil, "Free", "", 3, types.TaskPriorityMedium, "dev")
+	require.NoError(t, err)
+	_, err = service.UpdateTaskState(ctx, free.ID, types.TaskStateInProgress, "dev")
+	require.NoError(t, err)
+	_, err = service.UpdateTaskState(ctx, free.ID, types.TaskStateCompleted, "dev")
+	assert.NoError(t, err)
+
+	config.SetReviewRequired(project.ID, false)
+	assert.Empty(t, config.ReviewRequiredProjects)
+}
+
+func TestLogTaskEffort(t *testing.T) {
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), DefaultConfig())
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Effort Test", "Project for effort tests", "test-user")
+	require.NoError(t, err)
+	task, err := service.CreateTask(ctx, project.ID, nil, "Effort", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	_, err = service.LogTaskEffort(ctx, task.ID, 0, "", "agent")
+	assert.Error(t, err)
+
+	_, err = service.LogTaskEffort(ctx, task.ID, 90, " pairing ", "agent")
+	require.NoError(t, err)
+	updated, err := service.LogTaskEffort(ctx, task.ID, 30, "", "reviewer")
+	require.NoError(t, err)
+
+	require.Len(t, updated.EffortLog, 2)
+	assert.Equal(t, "pairing", updated.EffortLog[0].Note)
+	assert.Equal(t, "reviewer", updated.EffortLog[1].Actor)
+	assert.False(t, updated.EffortLog[1].LoggedAt.IsZero())
+	assert.Equal(t, int64(120), updated.ActualEffort())
+}
+
+func TestReprioritizeTasks(t *testing.T) {
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), DefaultConfig())
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Priority Test", "Project for reprioritize tests", "test-user")
+	require.NoError(t, err)
+	a, err := service.CreateTask(ctx, project.ID, nil, "A", "", 3, types.TaskPriorityLow, "test-user")
+	require.NoError(t, err)
+	b, err := service.CreateTask(ctx, project.ID, nil, "B", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	// A missing task fails before anything is written
+	_, err = service.ReprioritizeTasks(ctx, []uuid.UUID{a.ID, uuid.New()}, types.TaskPriorityHigh, "planner")
+	assert.Error(t, err)
+	unchanged, err := service.GetTask(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskPriorityLow, unchanged.Priority)
+
+	_, err = service.ReprioritizeTasks(ctx, []uuid.UUID{a.ID}, types.TaskPriority(7), "planner")
+	assert.Error(t, err)
+
+	updated, err := service.ReprioritizeTasks(ctx, []uuid.UUID{a.ID, b.ID}, types.TaskPriorityHigh, "planner")
+	require.NoError(t, err)
+	require.Len(t, updated, 2)
+	for _, id := range []uuid.UUID{a.ID, b.ID} {
+		task, err := service.GetTask(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, types.TaskPriorityHigh, task.Priority)
+		assert.Equal(t, "planner", task.UpdatedBy)
+	}
+}
+
+func TestTransitionTasks(t *testing.T) {
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), DefaultConfig())
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Transition Test", "Project for bulk transitions", "test-user")
+	require.NoError(t, err)
+	parent, err := service.CreateTask(ctx, project.ID, nil, "Parent", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	a, err := service.CreateTask(ctx, project.ID, &parent.ID, "A", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	b, err := service.CreateTask(ctx, project.ID, &parent.ID, "B", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	criteria, err := service.CreateTask(ctx, project.ID, nil, "Criteria", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = service.AddAcceptanceCriterion(ctx, criteria.ID, "Docs updated", "test-user")
+	require.NoError(t, err)
+	_, err = service.UpdateTaskState(ctx, criteria.ID, types.TaskStateInProgress, "test-user")
+	require.NoError(t, err)
+
+	pending, err := service.GetTask(ctx, a.ID)
+	require.NoError(t, err)
+	assert.ErrorContains(t, service.CheckStateTransition(pending, types.TaskStateCompleted), "invalid state transition from 'pending' to 'completed'")
+	assert.NoError(t, service.CheckStateTransition(pending, types.TaskStateInProgress))
+	inProgress, err := service.GetTask(ctx, criteria.ID)
+	require.NoError(t, err)
+	assert.ErrorContains(t, service.CheckStateTransition(inProgress, types.TaskStateCompleted), "acceptance criteria are not verified")
+
+	// One invalid transition fails before anything is written
+	_, err = service.TransitionTasks(ctx, []uuid.UUID{a.ID, criteria.ID}, types.TaskStateCompleted, "planner")
+	assert.Error(t, err)
+	unchanged, err := service.GetTask(ctx, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskStatePending, unchanged.State)
+
+	updated, err := service.TransitionTasks(ctx, []uuid.UUID{a.ID, b.ID}, types.TaskStateInProgress, "planner")
+	require.NoError(t, err)
+	require.Len(t, updated, 2)
+	updated, err = service.TransitionTasks(ctx, []uuid.UUID{a.ID, b.ID}, types.TaskStateCompleted, "planner")
+	require.NoError(t, err)
+	for _, task := range updated {
+		assert.Equal(t, types.TaskStateCompleted, task.State)
+		assert.NotNil(t, task.CompletedAt)
+		assert.Equal(t, "planner", task.UpdatedBy)
+	}
+
+	// The parent follows its children
+	parentAfter, err := service.GetTask(ctx, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskStateCompleted, parentAfter.State)
+}
+
+// TestStateTransitionEnforcement tests that every state-changing path checks
+// the state machine unless the transition is forced
+func TestStateTransitionEnforcement(t *testing.T) {
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), DefaultConfig())
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Enforcement Test", "Project for transition checks", "test-user")
+	require.NoError(t, err)
+	a, err := service.CreateTask(ctx, project.ID, nil, "A", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	b, err := service.CreateTask(ctx, project.ID, nil, "B", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = service.UpdateTaskState(ctx, b.ID, types.TaskStateInProgress, "test-user")
+	require.NoError(t, err)
+
+	// pending -> completed skips in-progress
+	completed := types.TaskStateCompleted
+	err = service.BulkUpdateTasks(ctx, []uuid.UUID{b.ID, a.ID}, types.TaskUpdates{State: &completed}, "test-user")
+	assert.ErrorContains(t, err, "invalid state transition from 'pending' to 'completed'")
+	unchanged, err := service.GetTask(ctx, b.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskStateInProgress, unchanged.State, "no task is updated when one transition is invalid")
+
+	_, err = service.UpdateTask(ctx, a.ID, "A", "", 3, types.TaskStateCompleted, "test-user")
+	assert.ErrorContains(t, err, "invalid state transition")
+
+	// Completed tasks can only be reopened by force
+	require.NoError(t, service.BulkUpdateTasks(ctx, []uuid.UUID{b.ID}, types.TaskUpdates{State: &completed}, "test-user"))
+	_, err = service.UpdateTaskState(ctx, b.ID, types.TaskStateInProgress, "test-user")
+	assert.Error(t, err)
+
+	forced := WithForcedTransitions(ctx)
+	reopened, err := service.UpdateTaskState(forced, b.ID, types.TaskStateInProgress, "test-user")
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskStateInProgress, reopened.State)
+	assert.Nil(t, reopened.CompletedAt)
+
+	updated, err := service.UpdateTask(forced, a.ID, "A", "", 3, types.TaskStateCompleted, "test-user")
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskStateCompleted, updated.State)
+	assert.NotNil(t, updated.CompletedAt)
+
+	// Forcing skips the state machine only, not the completion requirements
+	_, err = service.AddAcceptanceCriterion(ctx, b.ID, "Docs updated", "test-user")
+	require.NoError(t, err)
+	err = service.BulkUpdateTasks(forced, []uuid.UUID{b.ID}, types.TaskUpdates{State: &completed}, "test-user")
+	assert.ErrorContains(t, err, "acceptance criteria are not verified")
+}
+
+func TestSetTaskCompletedAt(t *testing.T) {
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), DefaultConfig())
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Completion Test", "Project for completion times", "test-user")
+	require.NoError(t, err)
+	task, err := service.CreateTask(ctx, project.ID, nil, "Task", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	_, err = service.SetTaskCompletedAt(ctx, task.ID, time.Now(), "admin")
+	assert.ErrorContains(t, err, "only completed tasks have a completion time")
+
+	_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateInProgress, "test-user")
+	require.NoError(t, err)
+	_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateCompleted, "test-user")
+	require.NoError(t, err)
+
+	_, err = service.SetTaskCompletedAt(ctx, task.ID, time.Now().Add(time.Hour), "admin")
+	assert.ErrorContains(t, err, "lies in the future")
+	_, err = service.SetTaskCompletedAt(ctx, task.ID, task.CreatedAt.Add(-time.Minute), "admin")
+	assert.ErrorContains(t, err, "precedes the creation")
+
+	completedAt := task.CreatedAt
+	updated, err := service.SetTaskCompletedAt(ctx, task.ID, completedAt, "admin")
+	require.NoError(t, err)
+	require.NotNil(t, updated.CompletedAt)
+	assert.True(t, completedAt.Equal(*updated.CompletedAt))
+	assert.Equal(t, "admin", updated.UpdatedBy)
+}
+
+// TestDeleteTaskWithChildren tests the child policies for deleting a parent task
+func TestDeleteTaskWithChildren(t *testing.T) {
+	repos := map[string]func(t *testing.T) (types.Repository, func()){
+		"inmemory": func(t *testing.T) (types.Repository, func()) {
+			return inmemory.NewMemoryRepository(), func() {}
+		},
+		"sqlite": setupSQLiteTestRepository,
+	}
+
+	for name, setup := range repos {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			repo, cleanup := setup(t)
+			defer cleanup()
+			service := NewManagerWithRepository(repo, DefaultConfig())
+
+			project, err := service.CreateProject(ctx, "Child Policy", "Deleting parents", "test-user")
+			require.NoError(t, err)
+
+			// root -> parent -> child -> grandchild, plus a sibling root
+			newTask := func(parentID *uuid.UUID, title string) *types.Task {
+				task, err := service.CreateTask(ctx, project.ID, parentID, title, "", 3, types.TaskPriorityMedium, "test-user")
+				require.NoError(t, err)
+				return task
+			}
+			root := newTask(nil, "Root")
+			parent := newTask(&root.ID, "Parent")
+			child := newTask(&parent.ID, "Child")
+			grandchild := newTask(&child.ID, "Grandchild")
+			sibling := newTask(nil, "Sibling")
+
+			t.Run("Promote", func(t *testing.T) {
+				require.NoError(t, service.DeleteTaskWithChildren(ctx, parent.ID, ChildPolicyPromote, nil, "test-user"))
+
+				_, err := service.GetTask(ctx, parent.ID)
+				assert.Error(t, err)
+
+				moved, err := service.GetTask(ctx, child.ID)
+				require.NoError(t, err)
+				require.NotNil(t, moved.ParentID)
+				assert.Equal(t, root.ID, *moved.ParentID)
+				assert.Equal(t, 1, moved.Depth)
+
+				movedGrandchild, err := service.GetTask(ctx, grandchild.ID)
+				require.NoError(t, err)
+				assert.Equal(t, 2, movedGrandchild.Depth)
+			})
+
+			t.Run("Reparent", func(t *testing.T) {
+				require.NoError(t, service.DeleteTaskWithChildren(ctx, root.ID, ChildPolicyReparent, &sibling.ID, "test-user"))
+
+				moved, err := service.GetTask(ctx, child.ID)
+				require.NoError(t, err)
+				require.NotNil(t, moved.ParentID)
+				assert.Equal(t, sibling.ID, *moved.ParentID)
+				assert.Equal(t, 1, moved.Depth)
+
+				children, err := service.GetChildTasks(ctx, sibling.ID)
+				require.NoError(t, err)
+				assert.Len(t, children, 1)
+			})
+
+			t.Run("Reparent into own subtree is rejected", func(t *testing.T) {
+				err := service.DeleteTaskWithChildren(ctx, child.ID, ChildPolicyReparent, &grandchild.ID, "test-user")
+				assert.Error(t, err)
+
+				err = service.DeleteTaskWithChildren(ctx, child.ID, ChildPolicyReparent, nil, "test-user")
+				assert.Error(t, err)
+
+				_, err = service.GetTask(ctx, child.ID)
+				assert.NoError(t, err, "rejected policies must not delete the task")
+			})
+
+			t.Run("Promote to root", func(t *testing.T) {
+				require.NoError(t, service.DeleteTaskWithChildren(ctx, sibling.ID, ChildPolicyPromote, nil, "test-user"))
+
+				moved, err := service.GetTask(ctx, child.ID)
+				require.NoError(t, err)
+				assert.Nil(t, moved.ParentID)
+				assert.Equal(t, 0, moved.Depth)
+
+				movedGrandchild, err := service.GetTask(ctx, grandchild.ID)
+				require.NoError(t, err)
+				assert.Equal(t, 1, movedGrandchild.Depth)
+			})
+
+			t.Run("Delete", func(t *testing.T) {
+				require.NoError(t, service.DeleteTaskWithChildren(ctx, child.ID, ChildPolicyDelete, nil, "test-user"))
+
+				tasks, err := service.ListTasksForProject(ctx, project.ID)
+				require.NoError(t, err)
+				assert.Empty(t, tasks)
+			})
+		})
+	}
+}
+
+// failingMoveRepository fails to move one task and forwards the transactions
+// of the wrapped repository
+type failingMoveRepository struct {
+	types.Repository
+	failTaskID uuid.UUID
+}
+
+func (r *failingMoveRepository) MoveTask(ctx context.Context, taskID uuid.UUID, parentID *uuid.UUID) error {
+	if taskID == r.failTaskID {
+		return fmt.Errorf("move of task %s failed", taskID)
+	}
+	return r.Repository.MoveTask(ctx, taskID, parentID)
+}
+
+func (r *failingMoveRepository) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.Repository.(types.Transactor).InTransaction(ctx, fn)
+}
+
+// TestDeleteTaskWithChildrenOrder tests that moved children keep their order
+// after the tasks below the new parent, and that a failed move changes nothing
+func TestDeleteTaskWithChildrenOrder(t *testing.T) {
+	repos := map[string]func(t *testing.T) (types.Repository, func()){
+		"inmemory": func(t *testing.T) (types.Repository, func()) {
+			return inmemory.NewMemoryRepository(), func() {}
+		},
+		"sqlite": setupSQLiteTestRepository,
+	}
+
+	for name, setup := range repos {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			repo, cleanup := setup(t)
+			defer cleanup()
+			failing := &failingMoveRepository{Repository: repo}
+			service := NewManagerWithRepository(failing, DefaultConfig())
+
+			project, err := service.CreateProject(ctx, "Child Order", "Deleting parents", "test-user")
+			require.NoError(t, err)
+			newTask := func(parentID *uuid.UUID, title string) *types.Task {
+				task, err := service.CreateTask(ctx, project.ID, parentID, title, "", 3, types.TaskPriorityMedium, "test-user")
+				require.NoError(t, err)
+				return task
+			}
+			titles := func(parentID uuid.UUID) []string {
+				children, err := service.GetChildTasks(ctx, parentID)
+				require.NoError(t, err)
+				types.SortSiblings(children)
+				result := make([]string, 0, len(children))
+				for i, child := range children {
+					if i > 0 {
+						assert.Greater(t, child.Position, children[i-1].Position, "sibling positions must be distinct")
+					}
+					result = append(result, child.Title)
+				}
+				return result
+			}
+
+			// root -> first, middle, last; middle -> child 1, child 2, child 3
+			root := newTask(nil, "Root")
+			newTask(&root.ID, "First")
+			middle := newTask(&root.ID, "Middle")
+			newTask(&root.ID, "Last")
+			newTask(&middle.ID, "Child 1")
+			second := newTask(&middle.ID, "Child 2")
+			newTask(&middle.ID, "Child 3")
+
+			failing.failTaskID = second.ID
+			err = service.DeleteTaskWithChildren(ctx, middle.ID, ChildPolicyPromote, nil, "test-user")
+			require.Error(t, err)
+			assert.Equal(t, []string{"First", "Middle", "Last"}, titles(root.ID))
+			assert.Equal(t, []string{"Child 1", "Child 2", "Child 3"}, titles(middle.ID))
+
+			failing.failTaskID = uuid.Nil
+			require.NoError(t, service.DeleteTaskWithChildren(ctx, middle.ID, ChildPolicyPromote, nil, "test-user"))
+			assert.Equal(t, []string{"First", "Last", "Child 1", "Child 2", "Child 3"}, titles(root.ID))
+		})
+	}
+}
+
+// TestParseChildPolicy tests parsing the child policy names
+func TestParseChildPolicy(t *testing.T) {
+	for _, name := range []string{"promote", "reparent-to", "delete"} {
+		policy, err := ParseChildPolicy(name)
+		require.NoError(t, err)
+		assert.Equal(t, ChildPolicy(name), policy)
+	}
+
+	_, err := ParseChildPolicy("orphan")
+	assert.Error(t, err)
+}
+
+func TestValidateSchedules(t *testing.T) {
+	projectID := uuid.New()
+	valid := []Schedule{
+		{Name: "standup", Cron: "0 9 * * mon-fri", ProjectID: projectID, Task: &ScheduledTask{Title: "Standup {{date}}"}},
+		{Name: "sprint", Cron: "@weekly", ProjectID: projectID, Template: "Sprint", Variables: map[string]string{"goal": "tbd"}},
+	}
+	require.NoError(t, ValidateSchedules(valid))
+
+	for msg, schedule := range map[string]Schedule{
+		"name is required":                     {Cron: "@daily", ProjectID: projectID, Template: "Sprint"},
+		"invalid cron expression":              {Name: "x", Cron: "daily", ProjectID: projectID, Template: "Sprint"},
+		"project_id is required":               {Name: "x", Cron: "@daily", Template: "Sprint"},
+		"exactly one of template and task":     {Name: "x", Cron: "@daily", ProjectID: projectID},
+		"task title is required":               {Name: "x", Cron: "@daily", ProjectID: projectID, Task: &ScheduledTask{}},
+		"priority must be low, medium or high": {Name: "x", Cron: "@daily", ProjectID: projectID, Task: &ScheduledTask{Title: "t", Priority: "urgent"}},
+	} {
+		assert.ErrorContains(t, ValidateSchedules([]Schedule{schedule}), msg)
+	}
+
+	assert.ErrorContains(t, ValidateSchedules(append(valid, valid[0])), "duplicate schedule name 'standup'")
+}
+
+func TestValidateAgingPolicies(t *testing.T) {
+	valid := []AgingPolicy{
+		{Priority: "high", MaxAgeDays: 3},
+		{Priority: "high", State: "in-progress", MaxAgeDays: 5},
+	}
+	require.NoError(t, ValidateAgingPolicies(valid))
+	assert.Equal(t, types.TaskStatePending, valid[0].TaskState())
+
+	for msg, policy := range map[string]AgingPolicy{
+		"priority must be low, medium or high":          {Priority: "urgent", MaxAgeDays: 3},
+		"state must be pending, in-progress or blocked": {Priority: "low", State: "completed", MaxAgeDays: 3},
+		"max_age_days must be at least 1, got 0":        {Priority: "low"},
+	} {
+		assert.ErrorContains(t, ValidateAgingPolicies([]AgingPolicy{policy}), msg)
+	}
+
+	assert.ErrorContains(t, ValidateAgingPolicies(append(valid, valid[0])), "duplicate policy for high pending tasks")
+}
+
+func TestValidateNotifications(t *testing.T) {
+	valid := []NotificationHook{
+		{Name: "me", Channel: "desktop", Events: []string{"assigned", "overdue"}},
+		{Name: "team", Channel: "slack", WebhookURL: "https://hooks.slack.com/services/T0/B0/x"},
+	}
+	require.NoError(t, ValidateNotifications(valid))
+
+	for msg, hook := range map[string]NotificationHook{
+		"name is required":          {Channel: "desktop"},
+		"unknown channel 'email'":   {Name: "x", Channel: "email"},
+		"incoming webhook":          {Name: "x", Channel: "teams"},
+		"unknown event 'completed'": {Name: "x", Channel: "desktop", Events: []string{"completed"}},
+	} {
+		assert.ErrorContains(t, ValidateNotifications([]NotificationHook{hook}), msg)
+	}
+
+	assert.ErrorContains(t, ValidateNotifications(append(valid, valid[0])), "duplicate hook name 'me'")
+}
+
+func TestWeightedProjectProgress(t *testing.T) {
+	config := DefaultConfig()
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), config)
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Progress Test", "Project for weighted progress", "test-user")
+	require.NoError(t, err)
+	big, err := service.CreateTask(ctx, project.ID, nil, "Big", "", 9, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = service.CreateTask(ctx, project.ID, nil, "Small", "", 1, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = service.UpdateTaskState(ctx, big.ID, types.TaskStateInProgress, "test-user")
+	require.NoError(t, err)
+	_, err = service.UpdateTaskState(ctx, big.ID, types.TaskStateCompleted, "test-user")
+	require.NoError(t, err)
+
+	progress, err := service.GetProjectProgress(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ProgressWeightingCount, progress.Weighting)
+	assert.InDelta(t, 50.0, progress.WeightedProgress, 0.01)
+
+	config.ProgressWeighting = ProgressWeightingComplexity
+	progress, err = service.GetProjectProgress(ctx, project.ID)
+	require.NoError(t, err)
+	assert.InDelta(t, 50.0, progress.OverallProgress, 0.01, "raw progress still counts tasks")
+	assert.InDelta(t, 90.0, progress.WeightedProgress, 0.01)
+}
+
+func TestGetSubtreeProgress(t *testing.T) {
+	config := DefaultConfig()
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), config)
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Subtree Test", "Project for subtree progress", "test-user")
+	require.NoError(t, err)
+	parent, err := service.CreateTask(ctx, project.ID, nil, "Parent", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	done, err := service.CreateTask(ctx, project.ID, &parent.ID, "Done", "", 6, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = service.CreateTask(ctx, project.ID, &parent.ID, "Open", "", 2, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = service.UpdateTaskState(ctx, done.ID, types.TaskStateInProgress, "test-user")
+	require.NoError(t, err)
+	_, err = service.UpdateTaskState(ctx, done.ID, types.TaskStateCompleted, "test-user")
+	require.NoError(t, err)
+
+	progress, err := service.GetSubtreeProgress(ctx, project.ID)
+	require.NoError(t, err)
+	require.Len(t, progress, 1, "leaf tasks have no rollup")
+	rollup := progress[parent.ID]
+	require.NotNil(t, rollup)
+	assert.Equal(t, 2, rollup.Descendants)
+	assert.Equal(t, 1, rollup.Completed)
+	assert.InDelta(t, 50.0, rollup.WeightedProgress, 0.01)
+
+	config.ProgressWeighting = ProgressWeightingComplexity
+	progress, err = service.GetSubtreeProgress(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ProgressWeightingComplexity, progress[parent.ID].Weighting)
+	assert.InDelta(t, 75.0, progress[parent.ID].WeightedProgress, 0.01)
+}
+
+func TestGetDescendants(t *testing.T) {
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), DefaultConfig())
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Descendants Test", "Project for descendants", "test-user")
+	require.NoError(t, err)
+	root, err := service.CreateTask(ctx, project.ID, nil, "Root", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	child, err := service.CreateTask(ctx, project.ID, &root.ID, "Child", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = service.CreateTask(ctx, project.ID, &root.ID, "Sibling", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	_, err = service.CreateTask(ctx, project.ID, &child.ID, "Grandchild", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	all, err := service.GetDescendants(ctx, root.ID, 0)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, []string{"Child", "Sibling", "Grandchild"}, []string{all[0].Title, all[1].Title, all[2].Title})
+
+	direct, err := service.GetDescendants(ctx, root.ID, 1)
+	require.NoError(t, err)
+	assert.Len(t, direct, 2)
+
+	_, err = service.GetDescendants(ctx, root.ID, -1)
+	assert.Error(t, err)
+	_, err = service.GetDescendants(ctx, uuid.New(), 0)
+	assert.Error(t, err)
+}
+
+func TestGetAncestors(t *testing.T) {
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), DefaultConfig())
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, "Ancestors Test", "Project for ancestors", "test-user")
+	require.NoError(t, err)
+	root, err := service.CreateTask(ctx, project.ID, nil, "Root", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	child, err := service.CreateTask(ctx, project.ID, &root.ID, "Child", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	grandchild, err := service.CreateTask(ctx, project.ID, &child.ID, "Grandchild", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	ancestors, err := service.GetAncestors(ctx, grandchild.ID)
+	require.NoError(t, err)
+	require.Len(t, ancestors, 2)
+	assert.Equal(t, root.ID, ancestors[0].ID, "the root task comes first")
+	assert.Equal(t, child.ID, ancestors[1].ID)
+
+	ancestors, err = service.GetAncestors(ctx, root.ID)
+	require.NoError(t, err)
+	assert.Empty(t, ancestors)
+
+	_, err = service.GetAncestors(ctx, uuid.New())
+	assert.Error(t, err)
+}
+
+func TestWeightedProgress(t *testing.T) {
+	estimate := func(minutes int64) *int64 { return &minutes }
+	tasks := []*types.Task{
+		{State: types.TaskStateCompleted, Complexity: 3, Estimate: estimate(60)},
+		{State: types.TaskStateInProgress, Complexity: 0},
+		{State: types.TaskStateCancelled, Complexity: 6, Estimate: estimate(120)},
+	}
+
+	assert.InDelta(t, 33.33, WeightedProgress(tasks, ProgressWeightingCount), 0.01)
+	assert.InDelta(t, 30.0, WeightedProgress(tasks, ProgressWeightingComplexity), 0.01, "complexity 0 weighs 1")
+	// The unestimated task weighs the average estimate of 90 minutes
+	assert.InDelta(t, 22.22, WeightedProgress(tasks, ProgressWeightingEstimate), 0.01)
+	assert.Zero(t, WeightedProgress(nil, ProgressWeightingEstimate))
+
+	assert.NoError(t, ValidateProgressWeighting(""))
+	assert.ErrorContains(t, ValidateProgressWeighting("effort"), "progress_weighting must be count, complexity or estimate")
+}
+
+func TestValidateSelectionStrategy(t *testing.T) {
+	assert.NoError(t, ValidateSelectionStrategy(""))
+	assert.NoError(t, ValidateSelectionStrategy("shortest-job-first"))
+	assert.NoError(t, ValidateSelectionStrategy("highest-value"))
+	assert.ErrorContains(t, ValidateSelectionStrategy("fastest"), "selection_strategy must be one of creation-order")
+}
+
+func TestSelectionWeights(t *testing.T) {
+	config := DefaultConfig()
+	defaults := (&selection.StrategyFactory{}).GetDefaultWeightsForStrategy(selection.StrategyDependencyAware)
+	assert.Equal(t, defaults, config.StrategyWeights(selection.StrategyDependencyAware))
+
+	calibrated := selection.Weights{DependentCount: 0.3, Priority: 0.4, DepthFirst: 0.2, CriticalPath: 0.1}
+	config.SetStrategyWeights(selection.StrategyDependencyAware, calibrated)
+	config.PriorityInheritance = true
+	require.NoError(t, ValidateSelectionWeights(config.SelectionWeights))
+
+	selectionConfig := config.SelectionConfig(selection.StrategyDependencyAware)
+	assert.Equal(t, calibrated, selectionConfig.Weights)
+	assert.True(t, selectionConfig.Behavior.PriorityInheritance)
+	assert.Equal(t, (&selection.StrategyFactory{}).GetDefaultWeightsForStrategy(selection.StrategyHighestValue),
+		config.SelectionConfig(selection.StrategyHighestValue).Weights)
+
+	assert.ErrorContains(t, ValidateSelectionWeights(map[string]selection.Weights{"fastest": calibrated}), "unknown selection strategy")
+	assert.ErrorContains(t, ValidateSelectionWeights(map[string]selection.Weights{"dependency-aware": {Priority: 3}}), "invalid weights of dependency-aware")
+}
+
+func TestAgentRegistry(t *testing.T) {
+	config := DefaultConfig()
+	backendID := uuid.New()
+	config.SetAgent(AgentProfile{Name: "backend-bot", ID: backendID, RequiredTags: []string{"backend"}})
+	config.SetAgent(AgentProfile{Name: "docs-bot", ID: uuid.New(), Strategy: "shortest-job-first"})
+	require.NoError(t, ValidateAgents(config.Agents))
+
+	byName, ok := config.Agent("backend-bot")
+	require.True(t, ok)
+	byID, ok := config.Agent(backendID.String())
+	require.True(t, ok)
+	assert.Equal(t, byName, byID)
+
+	selectionConfig := byName.SelectionConfig(selection.StrategyPriority)
+	assert.Equal(t, selection.StrategyPriority, selectionConfig.Strategy)
+	assert.Equal(t, &backendID, selectionConfig.Agent.AgentID)
+	assert.Equal(t, []string{"backend"}, selectionConfig.Agent.RequiredTags)
+
+	docs, _ := config.Agent("docs-bot")
+	selectionConfig = docs.SelectionConfig(selection.StrategyPriority)
+	assert.Equal(t, selection.StrategyShortestJobFirst, selectionConfig.Strategy)
+	assert.Positive(t, selectionConfig.Weights.Effort)
+
+	// Registering a known name replaces the agent
+	config.SetAgent(AgentProfile{Name: "backend-bot", ID: backendID, PreferredTags: []string{"db"}})
+	require.Len(t, config.Agents, 2)
+	updated, _ := config.Agent("backend-bot")
+	assert.Empty(t, updated.RequiredTags)
+
+	assert.True(t, config.RemoveAgent("docs-bot"))
+	assert.False(t, config.RemoveAgent("docs-bot"))
+	_, ok = config.Agent("docs-bot")
+	assert.False(t, ok)
+
+	assert.ErrorContains(t, ValidateAgents([]AgentProfile{{Name: "a", ID: backendID}, {Name: "a", ID: backendID}}), "duplicate agent name")
+	assert.ErrorContains(t, ValidateAgents([]AgentProfile{{Name: "a"}}), "id is required")
+	assert.ErrorContains(t, ValidateAgents([]AgentProfile{{Name: "a", ID: backendID, Strategy: "fastest"}}), "selection_strategy must be")
+	assert.ErrorContains(t, ValidateAgents([]AgentProfile{{Name: "a", ID: backendID, Strategy: "highest-value",
+		Weights: &selection.Weights{DependentCount: 1}}}), "invalid weights")
+}
+
+func TestHandoffNotes(t *testing.T) {
+	config := DefaultConfig()
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), config)
+	ctx := context.Background()
+	project, err := service.CreateProject(ctx, "Handoffs", "", "test-user")
+	require.NoError(t, err)
+	task, err := service.CreateTask(ctx, project.ID, nil, "Write API", "", 5, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	first, second := uuid.New(), uuid.New()
+
+	// The first assignment takes no note
+	task, err = service.ReassignTask(ctx, task.ID, &first, "", "lead")
+	require.NoError(t, err)
+	assert.Empty(t, task.Handoffs)
+	_, err = service.ReassignTask(ctx, task.ID, &first, "", "lead")
+	assert.Error(t, err, "already assigned")
+	_, err = service.UpdateTaskState(ctx, task.ID, types.TaskStateInProgress, "first-agent")
+	require.NoError(t, err)
+
+	config.RequireHandoffNotes = true
+	_, err = service.ReassignTask(ctx, task.ID, &second, " ", "lead")
+	var enhanced *knoterrors.EnhancedError
+	require.ErrorAs(t, err, &enhanced)
+	assert.Contains(t, enhanced.Error(), "handoff note required")
+	_, err = service.UpdateTaskStateWithHandoff(ctx, task.ID, types.TaskStatePending, "", "first-agent")
+	require.ErrorAs(t, err, &enhanced)
+
+	task, err = service.ReassignTask(ctx, task.ID, &second, "Endpoints done, tests left", "lead")
+	require.NoError(t, err)
+	require.Len(t, task.Handoffs, 1)
+	handoff := task.Handoffs[0]
+	assert.Equal(t, "Endpoints done, tests left", handoff.Note)
+	assert.Equal(t, &first, handoff.FromAgent)
+	assert.Equal(t, &second, handoff.ToAgent)
+	assert.Equal(t, types.TaskStateInProgress, handoff.ToState)
+	assert.Equal(t, "lead", handoff.By)
+
+	_, err = service.UpdateTaskStateWithHandoff(ctx, task.ID, types.TaskStateCompleted, "note", "second-agent")
+	assert.Error(t, err, "notes are only taken when going back to pending")
+	_, err = service.UpdateTaskStateWithHandoff(ctx, task.ID, types.TaskStatePending, "Error path tests flaky", "second-agent")
+	assert.Error(t, err, "releasing a started task is a forced transition")
+	task, err = service.UpdateTaskStateWithHandoff(WithForcedTransitions(ctx), task.ID, types.TaskStatePending, "Error path tests flaky", "second-agent")
+	require.NoError(t, err)
+	assert.Equal(t, types.TaskStatePending, task.State)
+
+	stored, err := service.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Handoffs, 2)
+	latest := stored.LatestHandoff()
+	assert.Equal(t, "Error path tests flaky", latest.Note)
+	assert.Equal(t, types.TaskStateInProgress, latest.FromState)
+	assert.Equal(t, types.TaskStatePending, latest.ToState)
+	assert.Equal(t, &second, stored.AssignedAgent, "releasing keeps the assignment")
 }
@@ -0,0 +1,98 @@
+package manager
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// TransitionResult is the outcome of applying one due scheduled transition
+type TransitionResult struct {
+	Transition *types.ScheduledTransition `json:"transition"`
+	Task       *types.Task                `json:"task,omitempty"`
+	Error      string                     `json:"error,omitempty"`
+	// Retry is set when the transition was kept to be applied again on the
+	// next run, because its project is locked by another actor
+	Retry bool `json:"retry,omitempty"`
+}
+
+// ScheduleTaskState schedules a task to move to state at the given time.
+// Whether the transition is allowed is checked when it is applied.
+func (s *service) ScheduleTaskState(ctx context.Context, taskID uuid.UUID, state types.TaskState, at time.Time, actor string) (*types.ScheduledTransition, error) {
+	if state == "" {
+		return nil, fmt.Errorf("state cannot be empty")
+	}
+	if at.IsZero() {
+		return nil, fmt.Errorf("time of the state change cannot be empty")
+	}
+	task, err := s.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	transition := &types.ScheduledTransition{
+		ID:        types.NewID(),
+		TaskID:    task.ID,
+		ProjectID: task.ProjectID,
+		State:     state,
+		At:        at,
+		CreatedBy: actor,
+		CreatedAt: s.GetCurrentTime(),
+	}
+	if err := s.repo.SaveScheduledTransition(ctx, transition); err != nil {
+		return nil, fmt.Errorf("failed to schedule state change: %w", err)
+	}
+	return transition, nil
+}
+
+// ListScheduledTransitions returns the pending scheduled transitions of a
+// project, or of all projects if projectID is nil, ordered by their time
+func (s *service) ListScheduledTransitions(ctx context.Context, projectID *uuid.UUID) ([]*types.ScheduledTransition, error) {
+	return s.repo.ListScheduledTransitions(ctx, projectID)
+}
+
+// CancelScheduledTransition removes a pending scheduled transition
+func (s *service) CancelScheduledTransition(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteScheduledTransition(ctx, id)
+}
+
+// RunDueTransitions applies every scheduled transition whose time has come,
+// in the order of their times, as the actor who scheduled it. Applied and
+// rejected transitions are removed; transitions of projects locked by
+// another actor are kept and applied by a later run.
+func (s *service) RunDueTransitions(ctx context.Context) ([]*TransitionResult, error) {
+	transitions, err := s.repo.ListScheduledTransitions(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled transitions: %w", err)
+	}
+
+	now := s.GetCurrentTime()
+	results := make([]*TransitionResult, 0)
+	for _, transition := range transitions {
+		if !transition.Due(now) {
+			break
+		}
+
+		result := &TransitionResult{Transition: transition}
+		writerCtx := WithWriter(ctx, transition.CreatedBy, writerFrom(ctx).ignoreLocks)
+		result.Task, err = s.UpdateTaskState(writerCtx, transition.TaskID, transition.State, transition.CreatedBy)
+		if err != nil {
+			result.Error = err.Error()
+			var lockedErr *ProjectLockedError
+			result.Retry = stderrors.As(err, &lockedErr)
+		}
+		results = append(results, result)
+
+		if result.Retry {
+			continue
+		}
+		if err := s.repo.DeleteScheduledTransition(ctx, transition.ID); err != nil {
+			return results, fmt.Errorf("failed to remove applied transition %s: %w", transition.ID, err)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,114 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestScheduledTransitions tests that due state changes are applied once and
+// invalid ones are dropped
+func TestScheduledTransitions(t *testing.T) {
+	repos := map[string]func(t *testing.T) (types.Repository, func()){
+		"inmemory": func(t *testing.T) (types.Repository, func()) {
+			return inmemory.NewMemoryRepository(), func() {}
+		},
+		"sqlite": setupSQLiteTestRepository,
+	}
+
+	for name, setup := range repos {
+		t.Run(name, func(t *testing.T) {
+			repo, cleanup := setup(t)
+			defer cleanup()
+			service := NewManagerWithRepository(repo, DefaultConfig())
+			ctx := context.Background()
+			now := service.GetCurrentTime()
+
+			project, err := service.CreateProject(ctx, "Sprint", "", "planner")
+			require.NoError(t, err)
+			start, err := service.CreateTask(ctx, project.ID, nil, "Start", "", 3, types.TaskPriorityMedium, "planner")
+			require.NoError(t, err)
+			cancelled, err := service.CreateTask(ctx, project.ID, nil, "Cancelled", "", 3, types.TaskPriorityMedium, "planner")
+			require.NoError(t, err)
+			_, err = service.UpdateTaskState(ctx, cancelled.ID, types.TaskStateCancelled, "planner")
+			require.NoError(t, err)
+			later, err := service.CreateTask(ctx, project.ID, nil, "Later", "", 3, types.TaskPriorityMedium, "planner")
+			require.NoError(t, err)
+
+			due, err := service.ScheduleTaskState(ctx, start.ID, types.TaskStateInProgress, now.Add(-time.Minute), "planner")
+			require.NoError(t, err)
+			assert.Equal(t, project.ID, due.ProjectID)
+			_, err = service.ScheduleTaskState(ctx, cancelled.ID, types.TaskStateInProgress, now.Add(-time.Minute), "planner")
+			require.NoError(t, err)
+			pending, err := service.ScheduleTaskState(ctx, later.ID, types.TaskStateInProgress, now.Add(time.Hour), "planner")
+			require.NoError(t, err)
+
+			transitions, err := service.ListScheduledTransitions(ctx, &project.ID)
+			require.NoError(t, err)
+			require.Len(t, transitions, 3)
+			assert.Equal(t, pending.ID, transitions[2].ID, "transitions are ordered by time")
+
+			results, err := service.RunDueTransitions(ctx)
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+			for _, result := range results {
+				if result.Transition.ID == due.ID {
+					assert.Empty(t, result.Error)
+					require.NotNil(t, result.Task)
+					assert.Equal(t, types.TaskStateInProgress, result.Task.State)
+					assert.Equal(t, "planner", result.Task.UpdatedBy)
+				} else {
+					assert.Contains(t, result.Error, "invalid state transition")
+					assert.False(t, result.Retry)
+				}
+			}
+
+			// Applied and rejected transitions are removed, so a second run does nothing
+			transitions, err = service.ListScheduledTransitions(ctx, nil)
+			require.NoError(t, err)
+			require.Len(t, transitions, 1)
+			assert.Equal(t, pending.ID, transitions[0].ID)
+			results, err = service.RunDueTransitions(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, results)
+
+			require.NoError(t, service.CancelScheduledTransition(ctx, pending.ID))
+			assert.Error(t, service.CancelScheduledTransition(ctx, pending.ID))
+		})
+	}
+}
+
+// TestScheduledTransitionsOfLockedProjects tests that transitions of projects
+// locked by another actor are kept for a later run
+func TestScheduledTransitionsOfLockedProjects(t *testing.T) {
+	service := NewManagerWithRepository(inmemory.NewMemoryRepository(), DefaultConfig())
+	ctx := context.Background()
+	agent := WithWriter(ctx, "agent", false)
+
+	project, err := service.CreateProject(ctx, "Locked", "", "planner")
+	require.NoError(t, err)
+	task, err := service.CreateTask(ctx, project.ID, nil, "Start", "", 3, types.TaskPriorityMedium, "planner")
+	require.NoError(t, err)
+	transition, err := service.ScheduleTaskState(ctx, task.ID, types.TaskStateInProgress, service.GetCurrentTime(), "planner")
+	require.NoError(t, err)
+	_, err = service.LockProject(agent, project.ID, "", time.Hour, "agent")
+	require.NoError(t, err)
+
+	results, err := service.RunDueTransitions(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Retry)
+	assert.Contains(t, results[0].Error, "project locked by agent")
+
+	require.NoError(t, service.UnlockProject(agent, project.ID, "agent"))
+	results, err = service.RunDueTransitions(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, transition.ID, results[0].Transition.ID)
+	assert.Empty(t, results[0].Error)
+}
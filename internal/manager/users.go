@@ -0,0 +1,212 @@
+package manager
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// TokenPrefix starts every user token, which makes leaked tokens easy to find
+const TokenPrefix = "knot_"
+
+var userNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._@-]{0,63}$`)
+
+// HashToken returns the hash under which a token is stored
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func newToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return TokenPrefix + hex.EncodeToString(secret), nil
+}
+
+// users returns the user store of the repository
+func (s *service) users() (types.UserStore, error) {
+	repo := s.repo
+	if guard, ok := repo.(*lockGuard); ok {
+		repo = guard.Repository
+	}
+	store, ok := repo.(types.UserStore)
+	if !ok {
+		return nil, types.ErrUsersUnsupported
+	}
+	return store, nil
+}
+
+func (s *service) getUser(ctx context.Context, store types.UserStore, name string) (*types.User, error) {
+	user, err := store.GetUser(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user %s not found", name)
+	}
+	return user, nil
+}
+
+// CreateUser adds a user with a role on all projects and returns the user's
+// token. Only the hash of the token is stored.
+func (s *service) CreateUser(ctx context.Context, name string, role types.Role) (*types.User, string, error) {
+	if !userNamePattern.MatchString(name) {
+		return nil, "", fmt.Errorf("invalid user name %q: use up to 64 letters, digits and . _ @ -", name)
+	}
+	store, err := s.users()
+	if err != nil {
+		return nil, "", err
+	}
+
+	existing, err := store.GetUser(ctx, name)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get user: %w", err)
+	}
+	if existing != nil {
+		return nil, "", fmt.Errorf("user %s already exists", name)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, "", err
+	}
+	now := s.GetCurrentTime()
+	user := &types.User{
+		Name:         name,
+		Role:         role,
+		ProjectRoles: make(map[uuid.UUID]types.Role),
+		TokenHash:    HashToken(token),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := store.SaveUser(ctx, user); err != nil {
+		return nil, "", fmt.Errorf("failed to create user: %w", err)
+	}
+	return user, token, nil
+}
+
+// ListUsers returns all users sorted by name
+func (s *service) ListUsers(ctx context.Context) ([]*types.User, error) {
+	store, err := s.users()
+	if err != nil {
+		return nil, err
+	}
+	users, err := store.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, nil
+}
+
+// SetUserRole sets the role of a user on one project, or on all projects
+// without a project role if projectID is nil
+func (s *service) SetUserRole(ctx context.Context, name string, projectID *uuid.UUID, role types.Role) (*types.User, error) {
+	store, err := s.users()
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.getUser(ctx, store, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if projectID == nil {
+		user.Role = role
+	} else {
+		if _, err := s.repo.GetProject(ctx, *projectID); err != nil {
+			return nil, fmt.Errorf("project not found: %w", err)
+		}
+		user.ProjectRoles[*projectID] = role
+	}
+	user.UpdatedAt = s.GetCurrentTime()
+	if err := store.SaveUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	return user, nil
+}
+
+// RevokeUserProjectRole removes the role of a user on a project, so the
+// user's role on all projects applies again
+func (s *service) RevokeUserProjectRole(ctx context.Context, name string, projectID uuid.UUID) (*types.User, error) {
+	store, err := s.users()
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.getUser(ctx, store, name)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := user.ProjectRoles[projectID]; !ok {
+		return nil, fmt.Errorf("user %s has no role on project %s", name, projectID)
+	}
+
+	delete(user.ProjectRoles, projectID)
+	user.UpdatedAt = s.GetCurrentTime()
+	if err := store.SaveUser(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	return user, nil
+}
+
+// RotateUserToken replaces the token of a user and returns the new one. The
+// old token stops working immediately.
+func (s *service) RotateUserToken(ctx context.Context, name string) (string, error) {
+	store, err := s.users()
+	if err != nil {
+		return "", err
+	}
+	user, err := s.getUser(ctx, store, name)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	user.TokenHash = HashToken(token)
+	user.UpdatedAt = s.GetCurrentTime()
+	if err := store.SaveUser(ctx, user); err != nil {
+		return "", fmt.Errorf("failed to update user: %w", err)
+	}
+	return token, nil
+}
+
+// DeleteUser removes a user, whose token stops working immediately
+func (s *service) DeleteUser(ctx context.Context, name string) error {
+	store, err := s.users()
+	if err != nil {
+		return err
+	}
+	if _, err := s.getUser(ctx, store, name); err != nil {
+		return err
+	}
+	if err := store.DeleteUser(ctx, name); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+	return nil
+}
+
+// AuthenticateUser returns the user owning token, or nil if no user does
+func (s *service) AuthenticateUser(ctx context.Context, token string) (*types.User, error) {
+	if token == "" {
+		return nil, nil
+	}
+	store, err := s.users()
+	if err != nil {
+		return nil, err
+	}
+	user, err := store.GetUserByTokenHash(ctx, HashToken(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+	return user, nil
+}
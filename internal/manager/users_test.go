@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/metrics"
+	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUsers tests user management and token authentication
+func TestUsers(t *testing.T) {
+	repos := map[string]func(t *testing.T) (types.Repository, func()){
+		"inmemory": func(t *testing.T) (types.Repository, func()) {
+			return inmemory.NewMemoryRepository(), func() {}
+		},
+		"sqlite": setupSQLiteTestRepository,
+	}
+
+	for name, setup := range repos {
+		t.Run(name, func(t *testing.T) {
+			repo, cleanup := setup(t)
+			defer cleanup()
+			ctx := context.Background()
+			// The CLI instruments its repository, which must keep the user store
+			service := NewManagerWithRepository(metrics.InstrumentRepository(repo, metrics.NewRegistry()), DefaultConfig())
+
+			project, err := service.CreateProject(ctx, "Shared", "", "admin")
+			require.NoError(t, err)
+
+			user, token, err := service.CreateUser(ctx, "alice", types.RoleViewer)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(token, TokenPrefix))
+			assert.Equal(t, HashToken(token), user.TokenHash, "only the hash of the token is stored")
+
+			_, _, err = service.CreateUser(ctx, "alice", types.RoleEditor)
+			assert.ErrorContains(t, err, "already exists")
+			_, _, err = service.CreateUser(ctx, "no spaces", types.RoleEditor)
+			assert.ErrorContains(t, err, "invalid user name")
+
+			authenticated, err := service.AuthenticateUser(ctx, token)
+			require.NoError(t, err)
+			require.NotNil(t, authenticated)
+			assert.Equal(t, "alice", authenticated.Name)
+
+			unknown, err := service.AuthenticateUser(ctx, "knot_invalid")
+			require.NoError(t, err)
+			assert.Nil(t, unknown)
+
+			t.Run("project roles override the role on all projects", func(t *testing.T) {
+				user, err := service.SetUserRole(ctx, "alice", &project.ID, types.RoleAdmin)
+				require.NoError(t, err)
+				assert.Equal(t, types.RoleAdmin, user.RoleFor(project.ID))
+				assert.Equal(t, types.RoleViewer, user.RoleFor(uuid.New()))
+
+				users, err := service.ListUsers(ctx)
+				require.NoError(t, err)
+				require.Len(t, users, 1)
+				assert.Equal(t, types.RoleAdmin, users[0].ProjectRoles[project.ID])
+
+				_, err = service.SetUserRole(ctx, "alice", ptrUUID(uuid.New()), types.RoleAdmin)
+				assert.ErrorContains(t, err, "project not found")
+
+				user, err = service.RevokeUserProjectRole(ctx, "alice", project.ID)
+				require.NoError(t, err)
+				assert.Equal(t, types.RoleViewer, user.RoleFor(project.ID))
+
+				_, err = service.RevokeUserProjectRole(ctx, "alice", project.ID)
+				assert.ErrorContains(t, err, "has no role on project")
+			})
+
+			t.Run("rotating replaces the token", func(t *testing.T) {
+				rotated, err := service.RotateUserToken(ctx, "alice")
+				require.NoError(t, err)
+				assert.NotEqual(t, token, rotated)
+
+				old, err := service.AuthenticateUser(ctx, token)
+				require.NoError(t, err)
+				assert.Nil(t, old)
+				current, err := service.AuthenticateUser(ctx, rotated)
+				require.NoError(t, err)
+				assert.NotNil(t, current)
+				token = rotated
+			})
+
+			t.Run("deleted users cannot authenticate", func(t *testing.T) {
+				require.NoError(t, service.DeleteUser(ctx, "alice"))
+				deleted, err := service.AuthenticateUser(ctx, token)
+				require.NoError(t, err)
+				assert.Nil(t, deleted)
+				assert.ErrorContains(t, service.DeleteUser(ctx, "alice"), "not found")
+			})
+		})
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	for name, expected := range map[string]types.Role{
+		"viewer": types.RoleViewer,
+		"editor": types.RoleEditor,
+		"admin":  types.RoleAdmin,
+		"none":   types.RoleNone,
+	} {
+		role, err := types.ParseRole(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, role)
+	}
+	_, err := types.ParseRole("owner")
+	assert.Error(t, err)
+
+	assert.True(t, types.RoleAdmin.Allows(types.RoleEditor))
+	assert.True(t, types.RoleEditor.Allows(types.RoleEditor))
+	assert.False(t, types.RoleViewer.Allows(types.RoleEditor))
+	assert.False(t, types.RoleNone.Allows(types.RoleViewer))
+}
+
+func ptrUUID(id uuid.UUID) *uuid.UUID {
+	return &id
+}
@@ -0,0 +1,236 @@
+// Package metrics collects runtime metrics of a knot process: command counts
+// and durations, repository query latency, the SQL statements run by the
+// database driver and task counts per state. Queries slower than a threshold
+// are logged and kept. The collected values are available as a JSON friendly
+// snapshot and in the Prometheus text exposition format.
+package metrics
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"go.uber.org/zap"
+)
+
+// Default is the registry used by the knot application
+var Default = NewRegistry()
+
+// Registry records command and repository query observations. It is safe for
+// concurrent use.
+type Registry struct {
+	mu         sync.Mutex
+	startedAt  time.Time
+	commands   map[string]*Summary
+	queries    map[string]*Summary
+	statements map[string]*Summary
+
+	slowThreshold time.Duration
+	logger        *zap.Logger
+	slow          []SlowQuery
+}
+
+// MaxSlowQueries is the number of most recent slow queries a registry keeps
+const MaxSlowQueries = 50
+
+// Kinds of slow queries
+const (
+	KindOperation = "operation"
+	KindStatement = "statement"
+)
+
+// SlowQuery is a repository operation or SQL statement that took at least the
+// slow query threshold
+type SlowQuery struct {
+	Kind     string        `json:"kind"`
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration_ns"`
+	Failed   bool          `json:"failed,omitempty"`
+	At       time.Time     `json:"at"`
+}
+
+// Summary aggregates the observations of one command or query
+type Summary struct {
+	Count    int64         `json:"count"`
+	Errors   int64         `json:"errors"`
+	Slow     int64         `json:"slow,omitempty"`
+	Total    time.Duration `json:"total_ns"`
+	Max      time.Duration `json:"max_ns"`
+	AvgMilli float64       `json:"avg_ms"`
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		startedAt:  time.Now(),
+		commands:   make(map[string]*Summary),
+		queries:    make(map[string]*Summary),
+		statements: make(map[string]*Summary),
+		logger:     zap.NewNop(),
+	}
+}
+
+// SetSlowQueryLog logs repository operations and SQL statements taking at
+// least threshold as warnings to logger and keeps the most recent of them.
+// A threshold of 0 turns slow query logging off.
+func (r *Registry) SetSlowQueryLog(threshold time.Duration, logger *zap.Logger) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	r.slowThreshold = threshold
+	r.logger = logger
+}
+
+// ObserveCommand records one run of a command, e.g. "task list"
+func (r *Registry) ObserveCommand(name string, duration time.Duration, err error) {
+	r.observe(r.commands, "", name, duration, err)
+}
+
+// ObserveQuery records one repository operation, e.g. "GetTask"
+func (r *Registry) ObserveQuery(operation string, duration time.Duration, err error) {
+	r.observe(r.queries, KindOperation, operation, duration, err)
+}
+
+// ObserveStatement records one SQL statement run by a database driver
+func (r *Registry) ObserveStatement(statement string, duration time.Duration, err error) {
+	r.observe(r.statements, KindStatement, statement, duration, err)
+}
+
+// observe adds an observation to summaries. Queries of a kind are checked
+// against the slow query threshold, commands have no kind.
+func (r *Registry) observe(summaries map[string]*Summary, kind, name string, duration time.Duration, err error) {
+	r.mu.Lock()
+	summary, exists := summaries[name]
+	if !exists {
+		summary = &Summary{}
+		summaries[name] = summary
+	}
+	summary.Count++
+	if err != nil {
+		summary.Errors++
+	}
+	summary.Total += duration
+	if duration > summary.Max {
+		summary.Max = duration
+	}
+
+	slow := kind != "" && r.slowThreshold > 0 && duration >= r.slowThreshold
+	if slow {
+		summary.Slow++
+		r.slow = append(r.slow, SlowQuery{Kind: kind, Name: name, Duration: duration, Failed: err != nil, At: time.Now()})
+		if len(r.slow) > MaxSlowQueries {
+			r.slow = r.slow[len(r.slow)-MaxSlowQueries:]
+		}
+	}
+	logger, threshold := r.logger, r.slowThreshold
+	r.mu.Unlock()
+
+	// Logged without holding the lock, the logger may be slow itself
+	if slow {
+		logger.Warn("Slow query",
+			zap.String("kind", kind),
+			zap.String("name", name),
+			zap.Duration("duration", duration),
+			zap.Duration("threshold", threshold),
+			zap.Error(err))
+	}
+}
+
+// QueryCounts returns the number of repository operations and SQL statements
+// observed so far
+func (r *Registry) QueryCounts() (operations, statements int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, summary := range r.queries {
+		operations += summary.Count
+	}
+	for _, summary := range r.statements {
+		statements += summary.Count
+	}
+	return operations, statements
+}
+
+// Snapshot is a point in time copy of the collected metrics
+type Snapshot struct {
+	StartedAt  time.Time                 `json:"started_at"`
+	Uptime     time.Duration             `json:"uptime_ns"`
+	Process    ProcessStats              `json:"process"`
+	Commands   map[string]Summary        `json:"commands"`
+	Queries    map[string]Summary        `json:"repository_queries"`
+	Statements map[string]Summary        `json:"sql_statements,omitempty"`
+	TaskStates map[types.TaskState]int64 `json:"task_states,omitempty"`
+
+	// SlowThreshold is the slow query threshold, 0 if slow queries are not logged
+	SlowThreshold time.Duration `json:"slow_query_threshold_ns,omitempty"`
+	// SlowQueries are the most recent slow queries, oldest first
+	SlowQueries []SlowQuery `json:"slow_queries,omitempty"`
+}
+
+// ProcessStats describes the Go runtime of the process
+type ProcessStats struct {
+	GoVersion  string `json:"go_version"`
+	Goroutines int    `json:"goroutines"`
+	HeapAlloc  uint64 `json:"heap_alloc_bytes"`
+	TotalAlloc uint64 `json:"total_alloc_bytes"`
+	GCCycles   uint32 `json:"gc_cycles"`
+	NumCPU     int    `json:"num_cpu"`
+	MaxProcs   int    `json:"gomaxprocs"`
+}
+
+// Snapshot copies the collected metrics. taskStates may be nil if task
+// counts were not collected.
+func (r *Registry) Snapshot(taskStates map[types.TaskState]int64) *Snapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	return &Snapshot{
+		StartedAt: r.startedAt,
+		Uptime:    time.Since(r.startedAt),
+		Process: ProcessStats{
+			GoVersion:  runtime.Version(),
+			Goroutines: runtime.NumGoroutine(),
+			HeapAlloc:  mem.HeapAlloc,
+			TotalAlloc: mem.TotalAlloc,
+			GCCycles:   mem.NumGC,
+			NumCPU:     runtime.NumCPU(),
+			MaxProcs:   runtime.GOMAXPROCS(0),
+		},
+		Commands:      copySummaries(r.commands),
+		Queries:       copySummaries(r.queries),
+		Statements:    copySummaries(r.statements),
+		TaskStates:    taskStates,
+		SlowThreshold: r.slowThreshold,
+		SlowQueries:   append([]SlowQuery(nil), r.slow...),
+	}
+}
+
+func copySummaries(summaries map[string]*Summary) map[string]Summary {
+	copied := make(map[string]Summary, len(summaries))
+	for name, summary := range summaries {
+		s := *summary
+		if s.Count > 0 {
+			s.AvgMilli = float64(s.Total) / float64(s.Count) / float64(time.Millisecond)
+		}
+		copied[name] = s
+	}
+	return copied
+}
+
+// sortedNames returns the keys of summaries in alphabetical order
+func sortedNames(summaries map[string]Summary) []string {
+	names := make([]string, 0, len(summaries))
+	for name := range summaries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRegistrySnapshot(t *testing.T) {
+	registry := NewRegistry()
+	registry.ObserveCommand("task list", 10*time.Millisecond, nil)
+	registry.ObserveCommand("task list", 30*time.Millisecond, fmt.Errorf("failed"))
+	registry.ObserveQuery("GetTask", time.Millisecond, nil)
+
+	snapshot := registry.Snapshot(nil)
+
+	list := snapshot.Commands["task list"]
+	assert.Equal(t, int64(2), list.Count)
+	assert.Equal(t, int64(1), list.Errors)
+	assert.Equal(t, 40*time.Millisecond, list.Total)
+	assert.Equal(t, 30*time.Millisecond, list.Max)
+	assert.InDelta(t, 20.0, list.AvgMilli, 0.001)
+	assert.Equal(t, int64(1), snapshot.Queries["GetTask"].Count)
+	assert.Nil(t, snapshot.TaskStates)
+
+	// Snapshots are copies
+	registry.ObserveCommand("task list", time.Millisecond, nil)
+	assert.Equal(t, int64(2), snapshot.Commands["task list"].Count)
+}
+
+func TestWritePrometheus(t *testing.T) {
+	registry := NewRegistry()
+	registry.ObserveCommand("task list", 1500*time.Millisecond, nil)
+	registry.ObserveQuery("ListProjects", 2*time.Millisecond, fmt.Errorf("locked"))
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePrometheus(&buf, registry.Snapshot(map[types.TaskState]int64{
+		types.TaskStatePending:   3,
+		types.TaskStateCompleted: 1,
+	})))
+
+	out := buf.String()
+	assert.Contains(t, out, "# TYPE knot_command_total counter\n")
+	assert.Contains(t, out, `knot_command_total{command="task list"} 1`)
+	assert.Contains(t, out, `knot_command_duration_seconds_sum{command="task list"} 1.5`)
+	assert.Contains(t, out, `knot_repository_query_errors_total{operation="ListProjects"} 1`)
+	assert.Contains(t, out, `knot_tasks{state="completed"} 1`)
+	assert.Contains(t, out, `knot_tasks{state="pending"} 3`)
+}
+
+func TestHandler(t *testing.T) {
+	registry := NewRegistry()
+	handler := Handler(registry, func(*http.Request) (map[types.TaskState]int64, error) {
+		return map[types.TaskState]int64{types.TaskStateBlocked: 2}, nil
+	})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, PrometheusContentType, recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), `knot_tasks{state="blocked"} 2`)
+
+	failing := Handler(registry, func(*http.Request) (map[types.TaskState]int64, error) {
+		return nil, fmt.Errorf("database is locked")
+	})
+	recorder = httptest.NewRecorder()
+	failing.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
+
+func TestInstrumentRepository(t *testing.T) {
+	registry := NewRegistry()
+	repo := InstrumentRepository(inmemory.NewMemoryRepository(), registry)
+	ctx := context.Background()
+
+	project := &types.Project{ID: uuid.New(), Title: "Metrics"}
+	require.NoError(t, repo.CreateProject(ctx, project))
+	_, err := repo.GetProject(ctx, project.ID)
+	require.NoError(t, err)
+	_, err = repo.GetProject(ctx, uuid.New())
+	require.Error(t, err)
+
+	// The change feed of the wrapped repository stays available
+	feed, ok := repo.(types.ChangeFeed)
+	require.True(t, ok)
+	_, err = feed.ListChangeEvents(ctx, types.ChangeEventFilter{Limit: 10})
+	require.NoError(t, err)
+
+	snapshot := registry.Snapshot(nil)
+	assert.Equal(t, int64(1), snapshot.Queries["CreateProject"].Count)
+	assert.Equal(t, int64(2), snapshot.Queries["GetProject"].Count)
+	assert.Equal(t, int64(1), snapshot.Queries["GetProject"].Errors)
+	assert.Equal(t, int64(1), snapshot.Queries["ListChangeEvents"].Count)
+}
+
+func TestSlowQueryLog(t *testing.T) {
+	registry := NewRegistry()
+	registry.ObserveQuery("GetTask", time.Second, nil)
+	assert.Empty(t, registry.Snapshot(nil).SlowQueries, "slow queries are not logged by default")
+
+	core, logs := observer.New(zap.WarnLevel)
+	registry.SetSlowQueryLog(100*time.Millisecond, zap.New(core))
+	registry.ObserveCommand("task list", time.Second, nil)
+	registry.ObserveQuery("GetTask", 50*time.Millisecond, nil)
+	registry.ObserveQuery("ListTasks", 150*time.Millisecond, fmt.Errorf("locked"))
+	registry.ObserveStatement("SELECT 1", 100*time.Millisecond, nil)
+
+	snapshot := registry.Snapshot(nil)
+	assert.Equal(t, 100*time.Millisecond, snapshot.SlowThreshold)
+	require.Len(t, snapshot.SlowQueries, 2, "commands are not queries")
+	assert.Equal(t, KindOperation, snapshot.SlowQueries[0].Kind)
+	assert.Equal(t, "ListTasks", snapshot.SlowQueries[0].Name)
+	assert.True(t, snapshot.SlowQueries[0].Failed)
+	assert.Equal(t, KindStatement, snapshot.SlowQueries[1].Kind)
+	assert.Equal(t, int64(1), snapshot.Queries["ListTasks"].Slow)
+	assert.Equal(t, int64(0), snapshot.Queries["GetTask"].Slow)
+	assert.Equal(t, int64(1), snapshot.Statements["SELECT 1"].Count)
+	assert.Equal(t, 2, logs.FilterMessage("Slow query").Len())
+
+	operations, statements := registry.QueryCounts()
+	assert.Equal(t, int64(3), operations)
+	assert.Equal(t, int64(1), statements)
+
+	// Only the most recent slow queries are kept
+	for i := 0; i < MaxSlowQueries+5; i++ {
+		registry.ObserveStatement(fmt.Sprintf("SELECT %d", i), time.Second, nil)
+	}
+	slow := registry.Snapshot(nil).SlowQueries
+	assert.Len(t, slow, MaxSlowQueries)
+	assert.Equal(t, fmt.Sprintf("SELECT %d", MaxSlowQueries+4), slow[len(slow)-1].Name)
+}
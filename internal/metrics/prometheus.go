@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// PrometheusContentType is the content type of the text exposition format
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// WritePrometheus writes the snapshot in the Prometheus text exposition format
+func WritePrometheus(w io.Writer, snapshot *Snapshot) error {
+	out := bufio.NewWriter(w)
+
+	writeHeader(out, "knot_uptime_seconds", "gauge", "Seconds since the knot process started")
+	fmt.Fprintf(out, "knot_uptime_seconds %s\n", seconds(snapshot.Uptime))
+
+	writeHeader(out, "knot_goroutines", "gauge", "Number of goroutines of the knot process")
+	fmt.Fprintf(out, "knot_goroutines %d\n", snapshot.Process.Goroutines)
+
+	writeHeader(out, "knot_heap_alloc_bytes", "gauge", "Bytes of allocated heap objects")
+	fmt.Fprintf(out, "knot_heap_alloc_bytes %d\n", snapshot.Process.HeapAlloc)
+
+	writeSummaries(out, "knot_command", "command", "Knot commands", snapshot.Commands)
+	writeSummaries(out, "knot_repository_query", "operation", "Repository operations", snapshot.Queries)
+
+	if snapshot.TaskStates != nil {
+		writeHeader(out, "knot_tasks", "gauge", "Number of tasks per state")
+		states := make([]string, 0, len(snapshot.TaskStates))
+		for state := range snapshot.TaskStates {
+			states = append(states, string(state))
+		}
+		sort.Strings(states)
+		for _, state := range states {
+			fmt.Fprintf(out, "knot_tasks{state=%q} %d\n", state, snapshot.TaskStates[types.TaskState(state)])
+		}
+	}
+
+	return out.Flush()
+}
+
+// Handler serves the metrics of registry in the Prometheus text format.
+// taskStates is called on every scrape to count tasks per state; it may be
+// nil to omit task counts.
+func Handler(registry *Registry, taskStates func(r *http.Request) (map[types.TaskState]int64, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var states map[types.TaskState]int64
+		if taskStates != nil {
+			var err error
+			if states, err = taskStates(r); err != nil {
+				http.Error(w, fmt.Sprintf("failed to count tasks: %v", err), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", PrometheusContentType)
+		_ = WritePrometheus(w, registry.Snapshot(states))
+	})
+}
+
+func writeSummaries(out *bufio.Writer, prefix, label, subject string, summaries map[string]Summary) {
+	names := sortedNames(summaries)
+
+	writeHeader(out, prefix+"_total", "counter", subject+" run")
+	for _, name := range names {
+		fmt.Fprintf(out, "%s_total{%s=%q} %d\n", prefix, label, name, summaries[name].Count)
+	}
+
+	writeHeader(out, prefix+"_errors_total", "counter", subject+" that returned an error")
+	for _, name := range names {
+		fmt.Fprintf(out, "%s_errors_total{%s=%q} %d\n", prefix, label, name, summaries[name].Errors)
+	}
+
+	writeHeader(out, prefix+"_duration_seconds_sum", "counter", "Total duration of "+strings.ToLower(subject))
+	for _, name := range names {
+		fmt.Fprintf(out, "%s_duration_seconds_sum{%s=%q} %s\n", prefix, label, name, seconds(summaries[name].Total))
+	}
+
+	writeHeader(out, prefix+"_duration_seconds_max", "gauge", "Longest duration of "+strings.ToLower(subject))
+	for _, name := range names {
+		fmt.Fprintf(out, "%s_duration_seconds_max{%s=%q} %s\n", prefix, label, name, seconds(summaries[name].Max))
+	}
+}
+
+func writeHeader(out *bufio.Writer, name, kind, help string) {
+	fmt.Fprintf(out, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%g", d.Seconds())
+}
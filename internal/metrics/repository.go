@@ -0,0 +1,403 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// InstrumentRepository wraps repo so the latency of every operation is
+// recorded in registry. The change feed of repo stays available.
+func InstrumentRepository(repo types.Repository, registry *Registry) types.Repository {
+	instrumented := &instrumentedRepository{repo: repo, registry: registry}
+	if feed, ok := repo.(types.ChangeFeed); ok {
+		return &instrumentedFeedRepository{instrumentedRepository: instrumented, feed: feed}
+	}
+	return instrumented
+}
+
+// instrumentedRepository records the latency of each repository operation
+type instrumentedRepository struct {
+	repo     types.Repository
+	registry *Registry
+}
+
+func (r *instrumentedRepository) observe(operation string, start time.Time, err error) {
+	r.registry.ObserveQuery(operation, time.Since(start), err)
+}
+
+// instrumentedFeedRepository additionally instruments the change feed
+type instrumentedFeedRepository struct {
+	*instrumentedRepository
+	feed types.ChangeFeed
+}
+
+func (r *instrumentedFeedRepository) ListChangeEvents(ctx context.Context, filter types.ChangeEventFilter) ([]*types.ChangeEvent, error) {
+	start := time.Now()
+	events, err := r.feed.ListChangeEvents(ctx, filter)
+	r.observe("ListChangeEvents", start, err)
+	return events, err
+}
+
+func (r *instrumentedRepository) CreateProject(ctx context.Context, project *types.Project) error {
+	start := time.Now()
+	err := r.repo.CreateProject(ctx, project)
+	r.observe("CreateProject", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) GetProject(ctx context.Context, id uuid.UUID) (*types.Project, error) {
+	start := time.Now()
+	result, err := r.repo.GetProject(ctx, id)
+	r.observe("GetProject", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) UpdateProject(ctx context.Context, project *types.Project) error {
+	start := time.Now()
+	err := r.repo.UpdateProject(ctx, project)
+	r.observe("UpdateProject", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) DeleteProject(ctx context.Context, id uuid.UUID) error {
+	start := time.Now()
+	err := r.repo.DeleteProject(ctx, id)
+	r.observe("DeleteProject", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) ListProjects(ctx context.Context) ([]*types.Project, error) {
+	start := time.Now()
+	result, err := r.repo.ListProjects(ctx)
+	r.observe("ListProjects", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) CreateTask(ctx context.Context, task *types.Task) error {
+	start := time.Now()
+	err := r.repo.CreateTask(ctx, task)
+	r.observe("CreateTask", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) GetTask(ctx context.Context, id uuid.UUID) (*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.GetTask(ctx, id)
+	r.observe("GetTask", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetTasksWithDependencies(ctx context.Context, taskIDs []uuid.UUID) ([]*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.GetTasksWithDependencies(ctx, taskIDs)
+	r.observe("GetTasksWithDependencies", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) UpdateTask(ctx context.Context, task *types.Task) error {
+	start := time.Now()
+	err := r.repo.UpdateTask(ctx, task)
+	r.observe("UpdateTask", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) DeleteTask(ctx context.Context, id uuid.UUID) error {
+	start := time.Now()
+	err := r.repo.DeleteTask(ctx, id)
+	r.observe("DeleteTask", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) ListTasks(ctx context.Context, filter types.TaskFilter) ([]*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.ListTasks(ctx, filter)
+	r.observe("ListTasks", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) ListTasksPage(ctx context.Context, filter types.TaskFilter, page types.PageRequest) (*types.TaskPage, error) {
+	start := time.Now()
+	result, err := r.repo.ListTasksPage(ctx, filter, page)
+	r.observe("ListTasksPage", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetTasksByProject(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.GetTasksByProject(ctx, projectID)
+	r.observe("GetTasksByProject", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetTasksByParent(ctx context.Context, parentID uuid.UUID) ([]*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.GetTasksByParent(ctx, parentID)
+	r.observe("GetTasksByParent", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetDescendants(ctx context.Context, taskID uuid.UUID, maxDepth int) ([]*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.GetDescendants(ctx, taskID, maxDepth)
+	r.observe("GetDescendants", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetRootTasks(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.GetRootTasks(ctx, projectID)
+	r.observe("GetRootTasks", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetParentTask(ctx context.Context, taskID uuid.UUID) (*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.GetParentTask(ctx, taskID)
+	r.observe("GetParentTask", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) DeleteTaskSubtree(ctx context.Context, taskID uuid.UUID) error {
+	start := time.Now()
+	err := r.repo.DeleteTaskSubtree(ctx, taskID)
+	r.observe("DeleteTaskSubtree", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) MoveTask(ctx context.Context, taskID uuid.UUID, parentID *uuid.UUID) error {
+	start := time.Now()
+	err := r.repo.MoveTask(ctx, taskID, parentID)
+	r.observe("MoveTask", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) AddTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID) (*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.AddTaskDependency(ctx, taskID, dependsOnTaskID)
+	r.observe("AddTaskDependency", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) SetDependencyLink(ctx context.Context, taskID uuid.UUID, link types.DependencyLink) (*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.SetDependencyLink(ctx, taskID, link)
+	r.observe("SetDependencyLink", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) RemoveTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID) (*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.RemoveTaskDependency(ctx, taskID, dependsOnTaskID)
+	r.observe("RemoveTaskDependency", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetTaskDependencies(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.GetTaskDependencies(ctx, taskID)
+	r.observe("GetTaskDependencies", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetDependentTasks(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error) {
+	start := time.Now()
+	result, err := r.repo.GetDependentTasks(ctx, taskID)
+	r.observe("GetDependentTasks", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetProjectProgress(ctx context.Context, projectID uuid.UUID) (*types.ProjectProgress, error) {
+	start := time.Now()
+	result, err := r.repo.GetProjectProgress(ctx, projectID)
+	r.observe("GetProjectProgress", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetTaskCountByDepth(ctx context.Context, projectID uuid.UUID, maxDepth int) (map[int]int, error) {
+	start := time.Now()
+	result, err := r.repo.GetTaskCountByDepth(ctx, projectID, maxDepth)
+	r.observe("GetTaskCountByDepth", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetSubtreeCounts(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]*types.SubtreeCounts, error) {
+	start := time.Now()
+	result, err := r.repo.GetSubtreeCounts(ctx, projectID)
+	r.observe("GetSubtreeCounts", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetChildCounts(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]int, error) {
+	start := time.Now()
+	result, err := r.repo.GetChildCounts(ctx, projectID)
+	r.observe("GetChildCounts", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetProjectLock(ctx context.Context, projectID uuid.UUID) (*types.ProjectLock, error) {
+	start := time.Now()
+	result, err := r.repo.GetProjectLock(ctx, projectID)
+	r.observe("GetProjectLock", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) SaveProjectLock(ctx context.Context, lock *types.ProjectLock) error {
+	start := time.Now()
+	err := r.repo.SaveProjectLock(ctx, lock)
+	r.observe("SaveProjectLock", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) DeleteProjectLock(ctx context.Context, projectID uuid.UUID) error {
+	start := time.Now()
+	err := r.repo.DeleteProjectLock(ctx, projectID)
+	r.observe("DeleteProjectLock", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) SaveScheduledTransition(ctx context.Context, transition *types.ScheduledTransition) error {
+	start := time.Now()
+	err := r.repo.SaveScheduledTransition(ctx, transition)
+	r.observe("SaveScheduledTransition", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) ListScheduledTransitions(ctx context.Context, projectID *uuid.UUID) ([]*types.ScheduledTransition, error) {
+	start := time.Now()
+	result, err := r.repo.ListScheduledTransitions(ctx, projectID)
+	r.observe("ListScheduledTransitions", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) DeleteScheduledTransition(ctx context.Context, id uuid.UUID) error {
+	start := time.Now()
+	err := r.repo.DeleteScheduledTransition(ctx, id)
+	r.observe("DeleteScheduledTransition", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) GetSelectedProject(ctx context.Context) (*uuid.UUID, error) {
+	start := time.Now()
+	result, err := r.repo.GetSelectedProject(ctx)
+	r.observe("GetSelectedProject", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) SetSelectedProject(ctx context.Context, projectID uuid.UUID, actor string) error {
+	start := time.Now()
+	err := r.repo.SetSelectedProject(ctx, projectID, actor)
+	r.observe("SetSelectedProject", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) ClearSelectedProject(ctx context.Context) error {
+	start := time.Now()
+	err := r.repo.ClearSelectedProject(ctx)
+	r.observe("ClearSelectedProject", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) HasSelectedProject(ctx context.Context) (bool, error) {
+	start := time.Now()
+	result, err := r.repo.HasSelectedProject(ctx)
+	r.observe("HasSelectedProject", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) GetSessionProject(ctx context.Context, session string) (*uuid.UUID, error) {
+	start := time.Now()
+	result, err := r.repo.GetSessionProject(ctx, session)
+	r.observe("GetSessionProject", start, err)
+	return result, err
+}
+
+func (r *instrumentedRepository) SetSessionProject(ctx context.Context, session string, projectID uuid.UUID, actor string) error {
+	start := time.Now()
+	err := r.repo.SetSessionProject(ctx, session, projectID, actor)
+	r.observe("SetSessionProject", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) ClearSessionProject(ctx context.Context, session string) error {
+	start := time.Now()
+	err := r.repo.ClearSessionProject(ctx, session)
+	r.observe("ClearSessionProject", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	transactor, ok := r.repo.(types.Transactor)
+	if !ok {
+		return types.ErrTransactionsUnsupported
+	}
+	start := time.Now()
+	err := transactor.InTransaction(ctx, fn)
+	r.observe("InTransaction", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) userStore() (types.UserStore, error) {
+	store, ok := r.repo.(types.UserStore)
+	if !ok {
+		return nil, types.ErrUsersUnsupported
+	}
+	return store, nil
+}
+
+func (r *instrumentedRepository) ListUsers(ctx context.Context) ([]*types.User, error) {
+	store, err := r.userStore()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	users, err := store.ListUsers(ctx)
+	r.observe("ListUsers", start, err)
+	return users, err
+}
+
+func (r *instrumentedRepository) GetUser(ctx context.Context, name string) (*types.User, error) {
+	store, err := r.userStore()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	user, err := store.GetUser(ctx, name)
+	r.observe("GetUser", start, err)
+	return user, err
+}
+
+func (r *instrumentedRepository) GetUserByTokenHash(ctx context.Context, tokenHash string) (*types.User, error) {
+	store, err := r.userStore()
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	user, err := store.GetUserByTokenHash(ctx, tokenHash)
+	r.observe("GetUserByTokenHash", start, err)
+	return user, err
+}
+
+func (r *instrumentedRepository) SaveUser(ctx context.Context, user *types.User) error {
+	store, err := r.userStore()
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = store.SaveUser(ctx, user)
+	r.observe("SaveUser", start, err)
+	return err
+}
+
+func (r *instrumentedRepository) DeleteUser(ctx context.Context, name string) error {
+	store, err := r.userStore()
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = store.DeleteUser(ctx, name)
+	r.observe("DeleteUser", start, err)
+	return err
+}
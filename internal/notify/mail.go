@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Mail is a plain text email and the SMTP server to send it through
+type Mail struct {
+	// Server is the host:port of the SMTP server
+	Server string
+	// Username and Password authenticate with PLAIN auth if a username is set.
+	// The server must offer TLS then, see smtp.PlainAuth.
+	Username string
+	Password string
+	From     string
+	To       []string
+	Subject  string
+	Body     string
+}
+
+// sendMail is smtp.SendMail, replaced in tests
+var sendMail = smtp.SendMail
+
+// Validate reports a mail that cannot be sent
+func (m *Mail) Validate() error {
+	if _, _, err := net.SplitHostPort(m.Server); err != nil {
+		return fmt.Errorf("SMTP server must be host:port, got '%s'", m.Server)
+	}
+	if m.From == "" {
+		return fmt.Errorf("a sender address is required")
+	}
+	if len(m.To) == 0 {
+		return fmt.Errorf("at least one recipient is required")
+	}
+	return nil
+}
+
+// Send delivers the mail. The connection is upgraded with STARTTLS when the
+// server offers it.
+func (m *Mail) Send(now time.Time) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if m.Username != "" {
+		host, _, _ := net.SplitHostPort(m.Server)
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+	if err := sendMail(m.Server, auth, m.From, m.To, m.message(now)); err != nil {
+		return fmt.Errorf("failed to send mail through %s: %w", m.Server, err)
+	}
+	return nil
+}
+
+// message returns the mail with its headers as sent to the server
+func (m *Mail) message(now time.Time) []byte {
+	var b strings.Builder
+	header := func(name, value string) {
+		fmt.Fprintf(&b, "%s: %s\r\n", name, value)
+	}
+	header("From", m.From)
+	header("To", strings.Join(m.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "8bit")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
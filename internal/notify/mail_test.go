@@ -0,0 +1,54 @@
+package notify
+
+import (
+	"net/smtp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMailSend(t *testing.T) {
+	var gotServer, gotFrom string
+	var gotTo []string
+	var gotAuth smtp.Auth
+	var gotMsg []byte
+	original := sendMail
+	sendMail = func(server string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		gotServer, gotAuth, gotFrom, gotTo, gotMsg = server, auth, from, to, msg
+		return nil
+	}
+	t.Cleanup(func() { sendMail = original })
+
+	mail := &Mail{
+		Server:   "mail.example.com:587",
+		Username: "knot",
+		Password: "secret",
+		From:     "knot@example.com",
+		To:       []string{"lead@example.com", "team@example.com"},
+		Subject:  "Weekly digest – Launch",
+		Body:     "# Weekly digest\n\n- shipped\n",
+	}
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	require.NoError(t, mail.Send(now))
+
+	assert.Equal(t, "mail.example.com:587", gotServer)
+	assert.NotNil(t, gotAuth)
+	assert.Equal(t, "knot@example.com", gotFrom)
+	assert.Equal(t, mail.To, gotTo)
+	msg := string(gotMsg)
+	assert.Contains(t, msg, "To: lead@example.com, team@example.com\r\n")
+	assert.Contains(t, msg, "Subject: =?utf-8?q?Weekly_digest_=E2=80=93_Launch?=\r\n")
+	assert.Contains(t, msg, "Date: Fri, 16 Oct 2026 08:00:00 +0000\r\n")
+	assert.Contains(t, msg, "\r\n\r\n# Weekly digest\r\n\r\n- shipped\r\n")
+}
+
+func TestMailValidate(t *testing.T) {
+	mail := &Mail{Server: "localhost", From: "knot@example.com", To: []string{"lead@example.com"}}
+	assert.ErrorContains(t, mail.Validate(), "host:port")
+	mail.Server = "localhost:25"
+	assert.NoError(t, mail.Validate())
+	mail.To = nil
+	assert.ErrorContains(t, mail.Validate(), "recipient")
+}
@@ -0,0 +1,195 @@
+// Package notify detects events worth a notification, such as a task being
+// assigned to an agent, a task whose dependencies have all been completed or a
+// task past its due date, and delivers them to the desktop or to Slack and
+// Microsoft Teams webhooks.
+//
+// Events are found by comparing the tasks of a project with the snapshot taken
+// by the previous run, which is kept in a state file. The first run for a
+// project only takes the snapshot, apart from overdue tasks.
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+)
+
+// Events a notification hook can select
+const (
+	// EventAssigned is sent when a task is assigned to an agent
+	EventAssigned = "assigned"
+	// EventUnblocked is sent when the last open dependency of a task is completed
+	EventUnblocked = "unblocked"
+	// EventOverdue is sent once when an open task passes its due date
+	EventOverdue = "overdue"
+)
+
+// EventTest is sent by `knot notify --test` to check a hook
+const EventTest = "test"
+
+// Events lists all events in the order they are documented
+var Events = []string{EventAssigned, EventUnblocked, EventOverdue}
+
+// ValidateEvent checks the name of an event
+func ValidateEvent(name string) error {
+	for _, event := range Events {
+		if name == event {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown event '%s', use assigned, unblocked or overdue", name)
+}
+
+// Notification is one event about a task
+type Notification struct {
+	Event        string      `json:"event"`
+	ProjectID    uuid.UUID   `json:"project_id"`
+	ProjectTitle string      `json:"project_title"`
+	Task         *types.Task `json:"task"`
+}
+
+// Title is the short headline of the notification
+func (n Notification) Title() string {
+	switch n.Event {
+	case EventAssigned:
+		return "Task assigned"
+	case EventUnblocked:
+		return "Task unblocked"
+	case EventOverdue:
+		return "Task overdue"
+	case EventTest:
+		return "Test notification"
+	default:
+		return "knot"
+	}
+}
+
+// Message describes the event in one or two lines
+func (n Notification) Message() string {
+	if n.Event == EventTest || n.Task == nil {
+		return "knot notifications are working"
+	}
+	task := n.Task
+	var detail string
+	switch n.Event {
+	case EventAssigned:
+		detail = fmt.Sprintf("assigned to agent %s", task.AssignedAgent)
+	case EventUnblocked:
+		detail = "all dependencies are completed, ready to start"
+	case EventOverdue:
+		detail = fmt.Sprintf("was due on %s", utils.FormatDueDate(task.DueDate))
+	}
+	return fmt.Sprintf("%s (%s): %s\nknot task get --id %s", task.Title, n.ProjectTitle, detail, task.ID)
+}
+
+// TaskStatus is the part of a task the detection compares between runs
+type TaskStatus struct {
+	AssignedAgent *uuid.UUID `json:"assigned_agent,omitempty"`
+	Unblocked     bool       `json:"unblocked,omitempty"`
+	Overdue       bool       `json:"overdue,omitempty"`
+}
+
+// Detect compares the tasks of a project with the statuses of the previous
+// run and returns the notifications and the statuses to keep for the next
+// run. A nil previous map is the first run: only overdue tasks are reported.
+func Detect(project *types.Project, tasks []*types.Task, previous map[uuid.UUID]TaskStatus, now time.Time) ([]Notification, map[uuid.UUID]TaskStatus) {
+	taskMap := make(map[uuid.UUID]*types.Task, len(tasks))
+	for _, task := range tasks {
+		taskMap[task.ID] = task
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	notifications := make([]Notification, 0)
+	notify := func(event string, task *types.Task) {
+		notifications = append(notifications, Notification{
+			Event: event, ProjectID: project.ID, ProjectTitle: project.Title, Task: task,
+		})
+	}
+
+	current := make(map[uuid.UUID]TaskStatus, len(tasks))
+	for _, task := range tasks {
+		open := task.State != types.TaskStateCompleted && task.State != types.TaskStateCancelled &&
+			task.State != types.TaskStateDeletionPending
+		status := TaskStatus{
+			AssignedAgent: task.AssignedAgent,
+			Unblocked:     open && len(task.Dependencies) > 0 && utils.IsTaskReady(task, taskMap),
+			Overdue:       open && task.DueDate != nil && task.DueDate.Before(today),
+		}
+		current[task.ID] = status
+
+		before, known := previous[task.ID]
+		if status.Overdue && !before.Overdue {
+			notify(EventOverdue, task)
+		}
+		if previous == nil {
+			continue
+		}
+		if open && status.AssignedAgent != nil && (before.AssignedAgent == nil || *before.AssignedAgent != *status.AssignedAgent) {
+			notify(EventAssigned, task)
+		}
+		// A new task that starts out unblocked is no news
+		if status.Unblocked && known && !before.Unblocked {
+			notify(EventUnblocked, task)
+		}
+	}
+	return notifications, current
+}
+
+// State keeps the task statuses of the last run per project
+type State struct {
+	Projects map[uuid.UUID]map[uuid.UUID]TaskStatus `json:"projects"`
+}
+
+// DefaultStatePath returns the state file of the .knot directory in the
+// current working directory
+func DefaultStatePath() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current working directory: %w", err)
+	}
+	return filepath.Join(cwd, ".knot", "notify-state.json"), nil
+}
+
+// LoadState reads the state file, a missing file is an empty state
+func LoadState(path string) (*State, error) {
+	state := &State{Projects: make(map[uuid.UUID]map[uuid.UUID]TaskStatus)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notify state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse notify state %s: %w", path, err)
+	}
+	if state.Projects == nil {
+		state.Projects = make(map[uuid.UUID]map[uuid.UUID]TaskStatus)
+	}
+	return state, nil
+}
+
+// Save writes the state file atomically
+func (s *State) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal notify state: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write notify state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write notify state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	project := &types.Project{ID: uuid.New(), Title: "Billing"}
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	yesterday := now.AddDate(0, 0, -1)
+	agent := uuid.New()
+
+	dep := &types.Task{ID: uuid.New(), Title: "Design", State: types.TaskStateInProgress}
+	waiting := &types.Task{ID: uuid.New(), Title: "Build", State: types.TaskStatePending, Dependencies: []uuid.UUID{dep.ID}}
+	late := &types.Task{ID: uuid.New(), Title: "Invoice", State: types.TaskStatePending, DueDate: &yesterday}
+	tasks := []*types.Task{dep, waiting, late}
+
+	// The first run only reports overdue tasks
+	notifications, statuses := Detect(project, tasks, nil, now)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, EventOverdue, notifications[0].Event)
+	assert.Equal(t, late, notifications[0].Task)
+	assert.Equal(t, "Billing", notifications[0].ProjectTitle)
+
+	// Nothing changed
+	notifications, statuses = Detect(project, tasks, statuses, now)
+	assert.Empty(t, notifications, "overdue tasks are reported once")
+
+	dep.State = types.TaskStateCompleted
+	late.AssignedAgent = &agent
+	notifications, statuses = Detect(project, tasks, statuses, now)
+	require.Len(t, notifications, 2)
+	events := map[string]*types.Task{}
+	for _, n := range notifications {
+		events[n.Event] = n.Task
+	}
+	assert.Equal(t, waiting, events[EventUnblocked])
+	assert.Equal(t, late, events[EventAssigned])
+
+	// Completing the overdue task resets it, reopening it reports it again
+	late.State = types.TaskStateCompleted
+	_, statuses = Detect(project, tasks, statuses, now)
+	late.State = types.TaskStatePending
+	notifications, _ = Detect(project, tasks, statuses, now)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, EventOverdue, notifications[0].Event, "a task that becomes overdue again is reported again")
+}
+
+func TestNotificationMessage(t *testing.T) {
+	due := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	task := &types.Task{ID: uuid.New(), Title: "Invoice", DueDate: &due}
+	n := Notification{Event: EventOverdue, ProjectTitle: "Billing", Task: task}
+
+	assert.Equal(t, "Task overdue", n.Title())
+	assert.Contains(t, n.Message(), "Invoice (Billing): was due on 2026-10-15")
+	assert.Contains(t, n.Message(), "knot task get --id "+task.ID.String())
+}
+
+func TestWebhookSender(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	sender, err := NewSender(ChannelSlack, server.URL)
+	require.NoError(t, err)
+	task := &types.Task{ID: uuid.New(), Title: "Build"}
+	require.NoError(t, sender.Send(context.Background(), Notification{Event: EventUnblocked, ProjectTitle: "Billing", Task: task}))
+	assert.Contains(t, received["text"], "*Task unblocked*\nBuild (Billing)")
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer failing.Close()
+	sender, err = NewSender(ChannelTeams, failing.URL)
+	require.NoError(t, err)
+	err = sender.Send(context.Background(), Notification{Event: EventUnblocked, Task: task})
+	assert.ErrorContains(t, err, "403 Forbidden: invalid_token")
+
+	_, err = NewSender(ChannelSlack, "")
+	assert.ErrorContains(t, err, "incoming webhook")
+	_, err = NewSender("email", "")
+	assert.ErrorContains(t, err, "unknown channel")
+}
+
+func TestDesktopSender(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("desktop notifications are not supported on Windows")
+	}
+	var command []string
+	sender := &desktopSender{run: func(ctx context.Context, name string, args ...string) error {
+		command = append([]string{name}, args...)
+		return nil
+	}}
+	task := &types.Task{ID: uuid.New(), Title: "Build"}
+	require.NoError(t, sender.Send(context.Background(), Notification{Event: EventAssigned, Task: task}))
+	require.NotEmpty(t, command)
+	assert.Contains(t, []string{"notify-send", "osascript"}, command[0])
+}
+
+func TestState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".knot", "notify-state.json")
+
+	state, err := LoadState(path)
+	require.NoError(t, err)
+	assert.Empty(t, state.Projects)
+
+	projectID, taskID := uuid.New(), uuid.New()
+	state.Projects[projectID] = map[uuid.UUID]TaskStatus{taskID: {Overdue: true}}
+	require.NoError(t, state.Save(path))
+
+	loaded, err := LoadState(path)
+	require.NoError(t, err)
+	assert.True(t, loaded.Projects[projectID][taskID].Overdue)
+}
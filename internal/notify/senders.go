@@ -0,0 +1,112 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Channels a notification hook can deliver to
+const (
+	ChannelDesktop = "desktop"
+	ChannelSlack   = "slack"
+	ChannelTeams   = "teams"
+)
+
+// webhookTimeout bounds a single webhook request
+const webhookTimeout = 10 * time.Second
+
+// Sender delivers notifications
+type Sender interface {
+	Send(ctx context.Context, n Notification) error
+}
+
+// NewSender returns the sender for a channel. Slack and Teams need the URL
+// of an incoming webhook.
+func NewSender(channel, webhookURL string) (Sender, error) {
+	switch channel {
+	case ChannelDesktop:
+		return &desktopSender{run: runCommand}, nil
+	case ChannelSlack, ChannelTeams:
+		if !strings.HasPrefix(webhookURL, "https://") && !strings.HasPrefix(webhookURL, "http://") {
+			return nil, fmt.Errorf("%s needs the http(s) URL of an incoming webhook", channel)
+		}
+		return &webhookSender{url: webhookURL, client: &http.Client{Timeout: webhookTimeout}}, nil
+	default:
+		return nil, fmt.Errorf("unknown channel '%s', use desktop, slack or teams", channel)
+	}
+}
+
+// desktopSender shows notifications with notify-send on Linux and BSD and
+// with osascript on macOS
+type desktopSender struct {
+	run func(ctx context.Context, name string, args ...string) error
+}
+
+func (s *desktopSender) Send(ctx context.Context, n Notification) error {
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(n.Message()), appleScriptString("knot: "+n.Title()))
+		return s.run(ctx, "osascript", "-e", script)
+	case "windows":
+		return fmt.Errorf("desktop notifications are not supported on Windows, use a slack or teams webhook")
+	default:
+		return s.run(ctx, "notify-send", "--app-name=knot", "knot: "+n.Title(), n.Message())
+	}
+}
+
+func runCommand(ctx context.Context, name string, args ...string) error {
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			return fmt.Errorf("%s failed: %w: %s", name, err, msg)
+		}
+		return fmt.Errorf("%s failed: %w", name, err)
+	}
+	return nil
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// webhookSender posts notifications to a Slack or Teams incoming webhook.
+// Both accept a JSON object with a text field.
+type webhookSender struct {
+	url    string
+	client *http.Client
+}
+
+func (s *webhookSender) Send(ctx context.Context, n Notification) error {
+	body, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", n.Title(), n.Message()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+// Package output provides the formatting theme shared by all commands.
+//
+// Commands render emoji through Icon and task states and priorities through
+// State and Priority, so a single Configure call at startup decides whether
+// the CLI prints ANSI colors and emoji. Colors are only used when stdout is a
+// terminal and NO_COLOR (https://no-color.org) is not set.
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+)
+
+// Theme controls how text output is decorated
+type Theme struct {
+	Color bool
+	Emoji bool
+}
+
+// ANSI color codes used by the theme
+const (
+	reset   = "\033[0m"
+	bold    = "\033[1m"
+	red     = "\033[31m"
+	green   = "\033[32m"
+	yellow  = "\033[33m"
+	blue    = "\033[34m"
+	magenta = "\033[35m"
+	cyan    = "\033[36m"
+	gray    = "\033[90m"
+)
+
+// theme is the process-wide theme. Colors stay off until Configure decides
+// otherwise, so library callers and tests get plain text.
+var theme = Theme{Color: false, Emoji: true}
+
+// Configure sets the theme from the --no-color and --no-emoji flags, the
+// NO_COLOR environment variable and whether stdout is a terminal
+func Configure(noColor, noEmoji bool) {
+	theme = Theme{
+		Color: !noColor && os.Getenv("NO_COLOR") == "" && IsTerminal(os.Stdout),
+		Emoji: !noEmoji,
+	}
+}
+
+// SetTheme replaces the current theme
+func SetTheme(t Theme) {
+	theme = t
+}
+
+// CurrentTheme returns the current theme
+func CurrentTheme() Theme {
+	return theme
+}
+
+// ColorEnabled reports whether ANSI colors are enabled
+func ColorEnabled() bool {
+	return theme.Color
+}
+
+// Icon returns the emoji followed by a space, or an empty string when emoji
+// are disabled
+func Icon(emoji string) string {
+	if !theme.Emoji {
+		return ""
+	}
+	return emoji + " "
+}
+
+// Symbol returns the emoji, or the plain text fallback when emoji are disabled
+func Symbol(emoji, plain string) string {
+	if !theme.Emoji {
+		return plain
+	}
+	return emoji
+}
+
+// Bold renders s in bold
+func Bold(s string) string {
+	return paint(bold, s)
+}
+
+// Success renders s in the success color
+func Success(s string) string {
+	return paint(green, s)
+}
+
+// Warning renders s in the warning color
+func Warning(s string) string {
+	return paint(yellow, s)
+}
+
+// Failure renders s in the error color
+func Failure(s string) string {
+	return paint(red, s)
+}
+
+// State renders a task state in its color
+func State(state types.TaskState) string {
+	switch state {
+	case types.TaskStatePending:
+		return paint(yellow, string(state))
+	case types.TaskStateInProgress:
+		return paint(cyan, string(state))
+	case types.TaskStateCompleted:
+		return paint(green, string(state))
+	case types.TaskStateBlocked:
+		return paint(red, string(state))
+	case types.TaskStateCancelled:
+		return paint(gray, string(state))
+	case types.TaskStateDeletionPending:
+		return paint(magenta, string(state))
+	default:
+		return string(state)
+	}
+}
+
+// Priority renders a task priority in its color
+func Priority(priority types.TaskPriority) string {
+	text := priority.ToExternalString()
+	switch priority {
+	case types.TaskPriorityHigh:
+		return paint(red, text)
+	case types.TaskPriorityMedium:
+		return paint(blue, text)
+	case types.TaskPriorityLow:
+		return paint(gray, text)
+	default:
+		return text
+	}
+}
+
+// SummaryWidth is the number of terminal columns a description may take in
+// list output
+const SummaryWidth = 100
+
+// Summary shortens a description for list output: the first line, cut to
+// SummaryWidth columns. Text that was left out is marked with "…".
+func Summary(description string) string {
+	line, rest, _ := strings.Cut(strings.TrimSpace(description), "\n")
+	line = strings.TrimSpace(line)
+	if strings.TrimSpace(rest) != "" {
+		line += " …"
+	}
+	return utils.TruncateWidth(line, SummaryWidth)
+}
+
+// Rollup formats the progress of a task's descendants, e.g. "3/5 completed, 60%",
+// naming the weighting unless tasks are counted
+func Rollup(progress *types.SubtreeProgress) string {
+	text := fmt.Sprintf("%d/%d completed, %.0f%%", progress.Completed, progress.Descendants, progress.WeightedProgress)
+	if progress.Weighting != "" && progress.Weighting != "count" {
+		text += " by " + progress.Weighting
+	}
+	return text
+}
+
+// Breadcrumb returns the compact path from a project to a task, e.g.
+// "Billing > Authentication > Login form"
+func Breadcrumb(project string, path []*types.Task) string {
+	parts := make([]string, 0, len(path)+1)
+	parts = append(parts, project)
+	for _, task := range path {
+		parts = append(parts, task.Title)
+	}
+	return strings.Join(parts, " > ")
+}
+
+// Blocker formats the external reason of a blocked task, e.g.
+// "waiting for vendor (ref: SUP-123)"
+func Blocker(blocker *types.TaskBlocker) string {
+	if blocker.Reference == "" {
+		return blocker.Reason
+	}
+	return fmt.Sprintf("%s (ref: %s)", blocker.Reason, blocker.Reference)
+}
+
+// CheckRun formats the outcome of a check run, e.g. "passed" or
+// "failed (exit code 1)"
+func CheckRun(run *types.CheckRun) string {
+	switch {
+	case run.Passed:
+		return Success("passed")
+	case run.ExitCode < 0:
+		return Failure("did not complete")
+	default:
+		return Failure(fmt.Sprintf("failed (exit code %d)", run.ExitCode))
+	}
+}
+
+func paint(code, s string) string {
+	if !theme.Color || s == "" {
+		return s
+	}
+	return code + s + reset
+}
+
+// IsTerminal reports whether f is a terminal
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	// The null device is a character device too
+	if null, err := os.Stat(os.DevNull); err == nil && os.SameFile(info, null) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func withTheme(t *testing.T, th Theme) {
+	t.Helper()
+	previous := CurrentTheme()
+	SetTheme(th)
+	t.Cleanup(func() { SetTheme(previous) })
+}
+
+func TestIcon(t *testing.T) {
+	withTheme(t, Theme{Emoji: true})
+	assert.Equal(t, "✅ ", Icon("✅"))
+	assert.Equal(t, "✓", Symbol("✓", "OK"))
+
+	withTheme(t, Theme{Emoji: false})
+	assert.Equal(t, "", Icon("✅"))
+	assert.Equal(t, "OK", Symbol("✓", "OK"))
+}
+
+func TestStateAndPriorityColors(t *testing.T) {
+	withTheme(t, Theme{Color: false})
+	assert.Equal(t, "completed", State(types.TaskStateCompleted))
+	assert.Equal(t, "high", Priority(types.TaskPriorityHigh))
+	assert.Equal(t, "note", Warning("note"))
+
+	withTheme(t, Theme{Color: true})
+	assert.Equal(t, green+"completed"+reset, State(types.TaskStateCompleted))
+	assert.Equal(t, red+"blocked"+reset, State(types.TaskStateBlocked))
+	assert.Equal(t, red+"high"+reset, Priority(types.TaskPriorityHigh))
+	assert.Equal(t, "unknown", State(types.TaskState("unknown")))
+}
+
+func TestConfigure(t *testing.T) {
+	withTheme(t, CurrentTheme())
+
+	t.Setenv("NO_COLOR", "1")
+	Configure(false, false)
+	assert.False(t, ColorEnabled())
+	assert.True(t, CurrentTheme().Emoji)
+
+	t.Setenv("NO_COLOR", "")
+	Configure(true, true)
+	assert.False(t, ColorEnabled())
+	assert.False(t, CurrentTheme().Emoji)
+}
+
+func TestSummary(t *testing.T) {
+	assert.Equal(t, "Fix the login form", Summary("Fix the login form"))
+	assert.Equal(t, "Fix the login form …", Summary("Fix the login form\n\nSteps to reproduce: ..."))
+	assert.Equal(t, "", Summary(""))
+
+	long := Summary(strings.Repeat("修复登录错误", 20))
+	assert.True(t, strings.HasSuffix(long, "…"))
+	assert.LessOrEqual(t, utils.DisplayWidth(long), SummaryWidth)
+}
+
+func TestBreadcrumb(t *testing.T) {
+	path := []*types.Task{{Title: "Authentication"}, {Title: "Login form"}}
+	assert.Equal(t, "Billing > Authentication > Login form", Breadcrumb("Billing", path))
+	assert.Equal(t, "Billing", Breadcrumb("Billing", nil))
+}
+
+func TestBlocker(t *testing.T) {
+	assert.Equal(t, "waiting for vendor", Blocker(&types.TaskBlocker{Reason: "waiting for vendor"}))
+	assert.Equal(t, "waiting for vendor (ref: SUP-123)", Blocker(&types.TaskBlocker{Reason: "waiting for vendor", Reference: "SUP-123"}))
+}
+
+func TestCheckRun(t *testing.T) {
+	assert.Equal(t, "passed", CheckRun(&types.CheckRun{Passed: true}))
+	assert.Equal(t, "failed (exit code 2)", CheckRun(&types.CheckRun{ExitCode: 2}))
+	assert.Equal(t, "did not complete", CheckRun(&types.CheckRun{ExitCode: -1}))
+}
+
+func TestRollup(t *testing.T) {
+	assert.Equal(t, "3/5 completed, 60%", Rollup(&types.SubtreeProgress{Descendants: 5, Completed: 3, Weighting: "count", WeightedProgress: 60}))
+	assert.Equal(t, "3/5 completed, 72% by complexity", Rollup(&types.SubtreeProgress{Descendants: 5, Completed: 3, Weighting: "complexity", WeightedProgress: 72.4}))
+}
+
+func TestTimestamp(t *testing.T) {
+	previous, layout := clock.location, clock.layout
+	t.Cleanup(func() { ConfigureTime(previous, layout) })
+
+	at := time.Date(2026, 10, 16, 22, 30, 0, 0, time.UTC)
+	ConfigureTime(time.UTC, "")
+	assert.Equal(t, "2026-10-16 22:30:00", Timestamp(at))
+
+	berlin := time.FixedZone("CEST", 2*60*60)
+	ConfigureTime(berlin, time.RFC3339)
+	assert.Equal(t, "2026-10-17T00:30:00+02:00", Timestamp(at))
+	assert.Equal(t, berlin, TimeLocation())
+
+	ConfigureTime(nil, "")
+	assert.Equal(t, time.Local, TimeLocation())
+}
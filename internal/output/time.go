@@ -0,0 +1,37 @@
+package output
+
+import "time"
+
+// DefaultTimeFormat is the layout of displayed timestamps unless configured
+const DefaultTimeFormat = "2006-01-02 15:04:05"
+
+// clock decides how timestamps are displayed. Timestamps are stored in UTC
+// and shown in local time until ConfigureTime decides otherwise.
+var clock = struct {
+	location *time.Location
+	layout   string
+}{time.Local, DefaultTimeFormat}
+
+// ConfigureTime sets the time zone and the layout (see package time) of
+// displayed timestamps. A nil location shows local time, an empty layout
+// DefaultTimeFormat.
+func ConfigureTime(location *time.Location, layout string) {
+	if location == nil {
+		location = time.Local
+	}
+	if layout == "" {
+		layout = DefaultTimeFormat
+	}
+	clock.location = location
+	clock.layout = layout
+}
+
+// TimeLocation returns the time zone timestamps are displayed in
+func TimeLocation() *time.Location {
+	return clock.location
+}
+
+// Timestamp formats t in the configured time zone and layout
+func Timestamp(t time.Time) string {
+	return t.In(clock.location).Format(clock.layout)
+}
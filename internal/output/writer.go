@@ -0,0 +1,130 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Writer is where commands print their output. It is injected through the
+// application context, so commands print the same way in every output mode
+// and tests can capture what a command prints:
+//
+//   - NewTextWriter prints everything for humans
+//   - NewJSONWriter is used in machine mode (--machine): it drops advisory
+//     text and prints the items changed by create and update commands as JSON
+//   - NewQuietWriter is used by --quiet: it prints only the ID of the item
+//     changed by a command, e.g. for ID=$(knot task create ... --quiet)
+type Writer interface {
+	// Write writes raw output, such as rendered reports, in every mode
+	io.Writer
+	// Print, Printf and Println print human-readable text
+	Print(a ...any)
+	Printf(format string, a ...any)
+	Println(a ...any)
+	// Advise prints advisory text, such as reminders and suggested next
+	// commands
+	Advise(format string, a ...any)
+	// JSON prints v as indented JSON
+	JSON(v any) error
+	// Result prints the item with the given ID that a create or update
+	// command changed and reports whether it did. If not, the command prints
+	// its human-readable confirmation.
+	Result(id fmt.Stringer, v any) (bool, error)
+	// Redirect returns a writer of the same kind printing to w
+	Redirect(w io.Writer) Writer
+}
+
+// textWriter prints everything
+type textWriter struct {
+	w io.Writer
+}
+
+// NewTextWriter returns the writer of the default output mode
+func NewTextWriter(w io.Writer) Writer {
+	return &textWriter{w: w}
+}
+
+func (t *textWriter) Write(p []byte) (int, error) {
+	return t.w.Write(p)
+}
+
+func (t *textWriter) Print(a ...any) {
+	fmt.Fprint(t.w, a...)
+}
+
+func (t *textWriter) Printf(format string, a ...any) {
+	fmt.Fprintf(t.w, format, a...)
+}
+
+func (t *textWriter) Println(a ...any) {
+	fmt.Fprintln(t.w, a...)
+}
+
+func (t *textWriter) Advise(format string, a ...any) {
+	fmt.Fprintf(t.w, format, a...)
+}
+
+func (t *textWriter) JSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal output to JSON: %w", err)
+	}
+	_, err = fmt.Fprintln(t.w, string(data))
+	return err
+}
+
+func (t *textWriter) Result(id fmt.Stringer, v any) (bool, error) {
+	return false, nil
+}
+
+func (t *textWriter) Redirect(w io.Writer) Writer {
+	return NewTextWriter(w)
+}
+
+// jsonWriter drops advisory text and prints results as JSON
+type jsonWriter struct {
+	textWriter
+}
+
+// NewJSONWriter returns the writer of machine mode
+func NewJSONWriter(w io.Writer) Writer {
+	return &jsonWriter{textWriter{w: w}}
+}
+
+func (j *jsonWriter) Advise(format string, a ...any) {}
+
+func (j *jsonWriter) Result(id fmt.Stringer, v any) (bool, error) {
+	return true, j.JSON(v)
+}
+
+func (j *jsonWriter) Redirect(w io.Writer) Writer {
+	return NewJSONWriter(w)
+}
+
+// quietWriter prints only JSON, raw output and the IDs of results
+type quietWriter struct {
+	textWriter
+}
+
+// NewQuietWriter returns the writer of commands run with --quiet
+func NewQuietWriter(w io.Writer) Writer {
+	return &quietWriter{textWriter{w: w}}
+}
+
+func (q *quietWriter) Print(a ...any) {}
+
+func (q *quietWriter) Printf(format string, a ...any) {}
+
+func (q *quietWriter) Println(a ...any) {}
+
+func (q *quietWriter) Advise(format string, a ...any) {}
+
+func (q *quietWriter) Result(id fmt.Stringer, v any) (bool, error) {
+	_, err := fmt.Fprintln(q.w, id)
+	return true, err
+}
+
+func (q *quietWriter) Redirect(w io.Writer) Writer {
+	return NewQuietWriter(w)
+}
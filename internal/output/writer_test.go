@@ -0,0 +1,52 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriters(t *testing.T) {
+	id := uuid.MustParse("0194b6a2-5c1e-7d3f-9a8b-1c2d3e4f5a6b")
+	result := struct {
+		ID uuid.UUID `json:"id"`
+	}{id}
+
+	printTo := func(w Writer) {
+		w.Printf("Created %s\n", "task")
+		w.Advise("Reminder: %s\n", "start it")
+	}
+
+	var buf bytes.Buffer
+	text := NewTextWriter(&buf)
+	printed, err := text.Result(id, result)
+	require.NoError(t, err)
+	assert.False(t, printed)
+	printTo(text)
+	assert.Equal(t, "Created task\nReminder: start it\n", buf.String())
+
+	buf.Reset()
+	machine := NewJSONWriter(&buf)
+	printed, err = machine.Result(id, result)
+	require.NoError(t, err)
+	assert.True(t, printed)
+	printTo(machine)
+	assert.Equal(t, "{\n  \"id\": \"0194b6a2-5c1e-7d3f-9a8b-1c2d3e4f5a6b\"\n}\nCreated task\n", buf.String())
+
+	buf.Reset()
+	quiet := NewQuietWriter(&buf)
+	printed, err = quiet.Result(id, result)
+	require.NoError(t, err)
+	assert.True(t, printed)
+	printTo(quiet)
+	require.NoError(t, quiet.JSON([]int{1}))
+	assert.Equal(t, id.String()+"\n[\n  1\n]\n", buf.String())
+
+	var redirected bytes.Buffer
+	machine.Redirect(&redirected).Advise("hidden")
+	machine.Redirect(&redirected).Println("shown")
+	assert.Equal(t, "shown\n", redirected.String())
+}
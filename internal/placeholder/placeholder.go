@@ -0,0 +1,107 @@
+// Package placeholder expands {{name}} placeholders in task and project
+// descriptions and in template tasks.
+//
+// Available names are date (today as YYYY-MM-DD), actor, project.title,
+// project.id, project.description, parent.title and parent.id when a project
+// or parent task is known, plus custom variables. Placeholders with unknown
+// names are left unchanged. A backslash escapes a placeholder: \{{date}} is
+// kept as the literal text {{date}}.
+package placeholder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// Values maps placeholder names to their values
+type Values map[string]string
+
+// New returns the values that are always available
+func New(now time.Time, actor string) Values {
+	return Values{
+		"date":  now.Format(time.DateOnly),
+		"actor": actor,
+	}
+}
+
+// AddProject adds the project.* values
+func (v Values) AddProject(project *types.Project) Values {
+	if project != nil {
+		v["project.title"] = project.Title
+		v["project.id"] = project.ID.String()
+		v["project.description"] = project.Description
+	}
+	return v
+}
+
+// AddParent adds the parent.* values
+func (v Values) AddParent(parent *types.Task) Values {
+	if parent != nil {
+		v["parent.title"] = parent.Title
+		v["parent.id"] = parent.ID.String()
+	}
+	return v
+}
+
+// AddVars adds custom variables, overriding values with the same name
+func (v Values) AddVars(vars map[string]string) Values {
+	for name, value := range vars {
+		v[name] = value
+	}
+	return v
+}
+
+// Expand replaces the placeholders in text. Whitespace inside the braces is
+// ignored, {{ date }} is the same as {{date}}.
+func (v Values) Expand(text string) string {
+	if !strings.Contains(text, "{{") {
+		return text
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.Index(text, "{{")
+		if start < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+
+		if start > 0 && text[start-1] == '\\' {
+			b.WriteString(text[:start-1])
+			b.WriteString("{{")
+			text = text[start+2:]
+			continue
+		}
+
+		end := strings.Index(text[start+2:], "}}")
+		if end < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		end += start + 2
+
+		b.WriteString(text[:start])
+		if value, ok := v[strings.TrimSpace(text[start+2:end])]; ok {
+			b.WriteString(value)
+		} else {
+			b.WriteString(text[start : end+2])
+		}
+		text = text[end+2:]
+	}
+}
+
+// ParseVars parses name=value pairs as given with --var
+func ParseVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid variable format: %s (expected key=value)", pair)
+		}
+		vars[parts[0]] = parts[1]
+	}
+	return vars, nil
+}
@@ -0,0 +1,48 @@
+package placeholder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpand(t *testing.T) {
+	project := &types.Project{ID: uuid.New(), Title: "Billing"}
+	parent := &types.Task{ID: uuid.New(), Title: "Invoices"}
+	values := New(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC), "alice").
+		AddProject(project).
+		AddParent(parent).
+		AddVars(map[string]string{"ticket": "BUG-7", "actor": "bob"})
+
+	tests := []struct {
+		text, want string
+	}{
+		{"no placeholders", "no placeholders"},
+		{"{{project.title}}: {{ticket}} ({{date}})", "Billing: BUG-7 (2026-10-16)"},
+		{"Part of {{ parent.title }}", "Part of Invoices"},
+		{"by {{actor}}", "by bob"},
+		{"{{unknown}} stays", "{{unknown}} stays"},
+		{`write \{{date}} literally`, "write {{date}} literally"},
+		{`\{{date}} vs {{date}}`, "{{date}} vs 2026-10-16"},
+		{"unclosed {{date", "unclosed {{date"},
+		{"{{project.id}}", project.ID.String()},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, values.Expand(tt.text), tt.text)
+	}
+}
+
+func TestParseVars(t *testing.T) {
+	vars, err := ParseVars([]string{"ticket=BUG-7", "note=a=b", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"ticket": "BUG-7", "note": "a=b", "empty": ""}, vars)
+
+	_, err = ParseVars([]string{"ticket"})
+	assert.ErrorContains(t, err, "expected key=value")
+	_, err = ParseVars([]string{"=x"})
+	assert.Error(t, err)
+}
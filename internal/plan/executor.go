@@ -0,0 +1,580 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// ChangeKind identifies the type of a planned change
+type ChangeKind string
+
+const (
+	ChangeCreate           ChangeKind = "create"
+	ChangeUpdate           ChangeKind = "update"
+	ChangeMove             ChangeKind = "move"
+	ChangeAddDependency    ChangeKind = "add-dependency"
+	ChangeRemoveDependency ChangeKind = "remove-dependency"
+)
+
+// Change is a single entry of the plan diff
+type Change struct {
+	Kind    ChangeKind `json:"kind"`
+	TaskID  string     `json:"task_id,omitempty"` // Empty for creates during preview
+	Ref     string     `json:"ref,omitempty"`
+	Title   string     `json:"title"`
+	Details []string   `json:"details,omitempty"`
+}
+
+// Result holds the outcome of previewing or applying a plan
+type Result struct {
+	Changes []Change `json:"changes"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// Valid reports whether the plan passed validation
+func (r *Result) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// ErrInvalidPlan is returned by Apply when the plan fails validation
+var ErrInvalidPlan = errors.New("plan validation failed")
+
+// Preview validates the whole plan against an in-memory copy of the project
+// and returns the resulting diff. Nothing is written to the real repository.
+func Preview(ctx context.Context, pm manager.ProjectManager, projectID uuid.UUID, p *Plan, actor string) (*Result, error) {
+	sandbox, err := manager.NewSandbox(ctx, pm, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	exec := newExecutor(sandbox, projectID, actor)
+	exec.preview = true
+	exec.run(ctx, p)
+
+	return exec.result, nil
+}
+
+// Apply validates the plan and, if it is valid, applies it to the project.
+// The plan is applied in one transaction of the storage backend, so it is
+// applied either completely or not at all. Backends without transactions
+// undo the applied operations one by one when an operation fails, which is
+// best effort: if undoing fails, the error reports it.
+func Apply(ctx context.Context, pm manager.ProjectManager, projectID uuid.UUID, p *Plan, actor string) (*Result, error) {
+	preview, err := Preview(ctx, pm, projectID, p, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !preview.Valid() {
+		return preview, ErrInvalidPlan
+	}
+
+	exec := newExecutor(pm, projectID, actor)
+	var runErr error
+	ran := false
+	err = pm.InTransaction(ctx, func(ctx context.Context) error {
+		ran = true
+		runErr = exec.run(ctx, p)
+		return runErr
+	})
+	if !ran && errors.Is(err, types.ErrTransactionsUnsupported) {
+		if err := exec.run(ctx, p); err != nil {
+			if rollbackErr := exec.rollback(ctx); rollbackErr != nil {
+				return exec.result, fmt.Errorf("apply failed: %w (rollback incomplete: %v)", err, rollbackErr)
+			}
+			return exec.result, fmt.Errorf("apply failed, all changes were rolled back: %w", err)
+		}
+		return exec.result, nil
+	}
+	if err != nil {
+		if err != runErr {
+			// The transaction failed to commit or to roll back
+			return exec.result, fmt.Errorf("apply failed: %w", err)
+		}
+		return exec.result, fmt.Errorf("apply failed, all changes were rolled back: %w", err)
+	}
+
+	return exec.result, nil
+}
+
+// executor runs plan operations against a project manager
+type executor struct {
+	pm        manager.ProjectManager
+	projectID uuid.UUID
+	actor     string
+	preview   bool
+
+	refs   map[string]uuid.UUID
+	undo   []func(ctx context.Context) error
+	result *Result
+}
+
+func newExecutor(pm manager.ProjectManager, projectID uuid.UUID, actor string) *executor {
+	return &executor{
+		pm:        pm,
+		projectID: projectID,
+		actor:     actor,
+		refs:      make(map[string]uuid.UUID),
+		result:    &Result{Changes: make([]Change, 0)},
+	}
+}
+
+// run executes all plan operations in order. In preview mode every failure is
+// recorded and execution continues, so that all problems are reported at once.
+// Otherwise execution stops at the first failure.
+func (e *executor) run(ctx context.Context, p *Plan) error {
+	steps := make([]func() error, 0)
+	for i := range p.Creates {
+		op := p.Creates[i]
+		steps = append(steps, func() error {
+			return wrapStep(fmt.Sprintf("creates[%d]", i), e.create(ctx, op))
+		})
+	}
+	for i := range p.Moves {
+		op := p.Moves[i]
+		steps = append(steps, func() error {
+			return wrapStep(fmt.Sprintf("moves[%d]", i), e.move(ctx, op))
+		})
+	}
+	for i := range p.Updates {
+		op := p.Updates[i]
+		steps = append(steps, func() error {
+			return wrapStep(fmt.Sprintf("updates[%d]", i), e.update(ctx, op))
+		})
+	}
+	for i := range p.Dependencies.Remove {
+		edit := p.Dependencies.Remove[i]
+		steps = append(steps, func() error {
+			return wrapStep(fmt.Sprintf("dependencies.remove[%d]", i), e.removeDependency(ctx, edit))
+		})
+	}
+	for i := range p.Dependencies.Add {
+		edit := p.Dependencies.Add[i]
+		steps = append(steps, func() error {
+			return wrapStep(fmt.Sprintf("dependencies.add[%d]", i), e.addDependency(ctx, edit))
+		})
+	}
+
+	for _, step := range steps {
+		if err := step(); err != nil {
+			if !e.preview {
+				return err
+			}
+			e.result.Errors = append(e.result.Errors, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func wrapStep(name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
+
+// rollback undoes all applied operations in reverse order
+func (e *executor) rollback(ctx context.Context) error {
+	var errs []error
+	for i := len(e.undo) - 1; i >= 0; i-- {
+		if err := e.undo[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	e.undo = nil
+	return errors.Join(errs...)
+}
+
+// resolve turns a plan reference (ref name or task ID) into a task of the project
+func (e *executor) resolve(ctx context.Context, ref string) (*types.Task, error) {
+	taskID, ok := e.refs[ref]
+	if !ok {
+		parsed, err := uuid.Parse(ref)
+		if err != nil {
+			return nil, fmt.Errorf("unknown task reference '%s'", ref)
+		}
+		taskID = parsed
+	}
+
+	task, err := e.pm.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("task '%s' not found", ref)
+	}
+	if task.ProjectID != e.projectID {
+		return nil, fmt.Errorf("task '%s' belongs to a different project", ref)
+	}
+
+	return task, nil
+}
+
+// snapshot captures a task and its parent so they can be restored on rollback
+func (e *executor) snapshot(ctx context.Context, taskID uuid.UUID) func(ctx context.Context) error {
+	var saved []types.Task
+	if task, err := e.pm.GetTask(ctx, taskID); err == nil {
+		saved = append(saved, *task)
+		if task.ParentID != nil {
+			if parent, err := e.pm.GetTask(ctx, *task.ParentID); err == nil {
+				saved = append(saved, *parent)
+			}
+		}
+	}
+
+	return func(ctx context.Context) error {
+		// Restoring a state may go against the state machine, e.g. back to pending
+		ctx = manager.WithForcedTransitions(ctx)
+		var errs []error
+		for _, task := range saved {
+			if _, err := e.pm.UpdateTask(ctx, task.ID, task.Title, task.Description, task.Complexity, task.State, e.actor); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if _, err := e.pm.UpdateTaskPriority(ctx, task.ID, task.Priority, e.actor); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+func (e *executor) create(ctx context.Context, op CreateOp) error {
+	complexity := op.Complexity
+	if complexity <= 0 {
+		complexity = 5 // Default complexity, consistent with bulk-create
+	}
+
+	priority, err := parsePriority(op.Priority)
+	if err != nil {
+		return err
+	}
+
+	var parentID *uuid.UUID
+	var restoreParent func(ctx context.Context) error
+	details := []string{fmt.Sprintf("complexity: %d", complexity), fmt.Sprintf("priority: %s", priority.ToExternalString())}
+	if op.Parent != "" {
+		parent, err := e.resolve(ctx, op.Parent)
+		if err != nil {
+			return fmt.Errorf("parent: %w", err)
+		}
+		parentID = &parent.ID
+		restoreParent = e.snapshot(ctx, parent.ID)
+		details = append(details, fmt.Sprintf("parent: %s", parent.Title))
+	}
+
+	task, err := e.pm.CreateTask(ctx, e.projectID, parentID, op.Title, op.Description, complexity, priority, e.actor)
+	if err != nil {
+		return err
+	}
+
+	if op.Ref != "" {
+		e.refs[op.Ref] = task.ID
+	}
+
+	e.undo = append(e.undo, func(ctx context.Context) error {
+		if err := e.pm.DeleteTask(ctx, task.ID, e.actor); err != nil {
+			return err
+		}
+		if restoreParent != nil {
+			return restoreParent(ctx)
+		}
+		return nil
+	})
+
+	change := Change{Kind: ChangeCreate, Ref: op.Ref, Title: task.Title, Details: details}
+	if !e.preview {
+		change.TaskID = task.ID.String()
+	}
+	e.result.Changes = append(e.result.Changes, change)
+
+	return nil
+}
+
+func (e *executor) update(ctx context.Context, op UpdateOp) error {
+	task, err := e.resolve(ctx, op.ID)
+	if err != nil {
+		return err
+	}
+
+	before := *task
+	e.undo = append(e.undo, e.snapshot(ctx, task.ID))
+
+	var details []string
+	if op.Title != nil && *op.Title != task.Title {
+		if task, err = e.pm.UpdateTaskTitle(ctx, task.ID, *op.Title, e.actor); err != nil {
+			return err
+		}
+		details = append(details, fmt.Sprintf("title: %q -> %q", before.Title, task.Title))
+	}
+	if op.Description != nil && *op.Description != task.Description {
+		if task, err = e.pm.UpdateTaskDescription(ctx, task.ID, *op.Description, e.actor); err != nil {
+			return err
+		}
+		details = append(details, "description changed")
+	}
+	if op.Complexity != nil && *op.Complexity != task.Complexity {
+		if task, err = e.pm.UpdateTask(ctx, task.ID, task.Title, task.Description, *op.Complexity, task.State, e.actor); err != nil {
+			return err
+		}
+		details = append(details, fmt.Sprintf("complexity: %d -> %d", before.Complexity, task.Complexity))
+	}
+	if op.Priority != nil {
+		priority, err := parsePriority(*op.Priority)
+		if err != nil {
+			return err
+		}
+		if priority != task.Priority {
+			if task, err = e.pm.UpdateTaskPriority(ctx, task.ID, priority, e.actor); err != nil {
+				return err
+			}
+			details = append(details, fmt.Sprintf("priority: %s -> %s", before.Priority.ToExternalString(), task.Priority.ToExternalString()))
+		}
+	}
+	if op.State != nil && types.TaskState(*op.State) != task.State {
+		if task, err = e.pm.UpdateTaskState(ctx, task.ID, types.TaskState(*op.State), e.actor); err != nil {
+			return err
+		}
+		details = append(details, fmt.Sprintf("state: %s -> %s", before.State, task.State))
+	}
+
+	if len(details) == 0 {
+		details = append(details, "no effective changes")
+	}
+
+	e.result.Changes = append(e.result.Changes, Change{
+		Kind:    ChangeUpdate,
+		TaskID:  e.changeTaskID(op.ID, task),
+		Ref:     refName(op.ID),
+		Title:   task.Title,
+		Details: details,
+	})
+
+	return nil
+}
+
+func (e *executor) move(ctx context.Context, op MoveOp) error {
+	task, err := e.resolve(ctx, op.ID)
+	if err != nil {
+		return err
+	}
+
+	var parentID *uuid.UUID
+	to := "root level"
+	if op.Parent != "" {
+		parent, err := e.resolve(ctx, op.Parent)
+		if err != nil {
+			return fmt.Errorf("parent: %w", err)
+		}
+		parentID = &parent.ID
+		to = parent.Title
+	}
+	from := "root level"
+	if task.ParentID != nil {
+		if parent, err := e.pm.GetTask(ctx, *task.ParentID); err == nil {
+			from = parent.Title
+		}
+	}
+
+	// Remember the next sibling to restore the position on rollback
+	siblings, err := e.siblings(ctx, task.ParentID)
+	if err != nil {
+		return err
+	}
+	var next *uuid.UUID
+	for i, sibling := range siblings {
+		if sibling.ID == task.ID && i+1 < len(siblings) {
+			next = &siblings[i+1].ID
+		}
+	}
+	previousParent := task.ParentID
+
+	moved, err := e.pm.MoveTask(ctx, task.ID, parentID, e.actor)
+	if err != nil {
+		return err
+	}
+	e.undo = append(e.undo, func(ctx context.Context) error {
+		if _, err := e.pm.MoveTask(ctx, task.ID, previousParent, e.actor); err != nil {
+			return err
+		}
+		if next != nil {
+			_, err := e.pm.ReorderTask(ctx, task.ID, *next, false, e.actor)
+			return err
+		}
+		return nil
+	})
+
+	e.result.Changes = append(e.result.Changes, Change{
+		Kind:    ChangeMove,
+		TaskID:  e.changeTaskID(op.ID, moved),
+		Ref:     refName(op.ID),
+		Title:   moved.Title,
+		Details: []string{fmt.Sprintf("parent: %s -> %s", from, to), fmt.Sprintf("depth: %d -> %d", task.Depth, moved.Depth)},
+	})
+	return nil
+}
+
+// siblings returns the children of parentID, or the root tasks of the project
+// if parentID is nil, in sibling order
+func (e *executor) siblings(ctx context.Context, parentID *uuid.UUID) ([]*types.Task, error) {
+	var (
+		siblings []*types.Task
+		err      error
+	)
+	if parentID == nil {
+		siblings, err = e.pm.GetRootTasks(ctx, e.projectID)
+	} else {
+		siblings, err = e.pm.GetChildTasks(ctx, *parentID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sibling tasks: %w", err)
+	}
+	types.SortSiblings(siblings)
+	return siblings, nil
+}
+
+func (e *executor) removeDependency(ctx context.Context, edit DependencyEdit) error {
+	task, dependsOn, err := e.resolveEdit(ctx, edit)
+	if err != nil {
+		return err
+	}
+
+	dependencies, err := e.pm.GetTaskDependencies(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if !containsTask(dependencies, dependsOn.ID) {
+		return fmt.Errorf("'%s' does not depend on '%s'", task.Title, dependsOn.Title)
+	}
+
+	if _, err := e.pm.RemoveTaskDependency(ctx, task.ID, dependsOn.ID, e.actor); err != nil {
+		return err
+	}
+
+	e.undo = append(e.undo, func(ctx context.Context) error {
+		_, err := e.pm.AddTaskDependency(ctx, task.ID, dependsOn.ID, e.actor)
+		return err
+	})
+
+	e.result.Changes = append(e.result.Changes, e.dependencyChange(ChangeRemoveDependency, edit, task, dependsOn))
+	return nil
+}
+
+func (e *executor) addDependency(ctx context.Context, edit DependencyEdit) error {
+	task, dependsOn, err := e.resolveEdit(ctx, edit)
+	if err != nil {
+		return err
+	}
+
+	cycle, err := e.reachable(ctx, dependsOn.ID, task.ID)
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return fmt.Errorf("dependency '%s' -> '%s' would create a cycle", task.Title, dependsOn.Title)
+	}
+
+	if _, err := e.pm.AddTaskDependency(ctx, task.ID, dependsOn.ID, e.actor); err != nil {
+		return err
+	}
+
+	e.undo = append(e.undo, func(ctx context.Context) error {
+		_, err := e.pm.RemoveTaskDependency(ctx, task.ID, dependsOn.ID, e.actor)
+		return err
+	})
+
+	e.result.Changes = append(e.result.Changes, e.dependencyChange(ChangeAddDependency, edit, task, dependsOn))
+	return nil
+}
+
+func (e *executor) resolveEdit(ctx context.Context, edit DependencyEdit) (*types.Task, *types.Task, error) {
+	task, err := e.resolve(ctx, edit.Task)
+	if err != nil {
+		return nil, nil, err
+	}
+	dependsOn, err := e.resolve(ctx, edit.DependsOn)
+	if err != nil {
+		return nil, nil, err
+	}
+	return task, dependsOn, nil
+}
+
+// reachable reports whether target can be reached from start by following dependencies
+func (e *executor) reachable(ctx context.Context, start, target uuid.UUID) (bool, error) {
+	visited := make(map[uuid.UUID]bool)
+	stack := []uuid.UUID{start}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if current == target {
+			return true, nil
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		dependencies, err := e.pm.GetTaskDependencies(ctx, current)
+		if err != nil {
+			return false, fmt.Errorf("failed to check for dependency cycles: %w", err)
+		}
+		for _, dep := range dependencies {
+			stack = append(stack, dep.ID)
+		}
+	}
+
+	return false, nil
+}
+
+func (e *executor) dependencyChange(kind ChangeKind, edit DependencyEdit, task, dependsOn *types.Task) Change {
+	return Change{
+		Kind:    kind,
+		TaskID:  e.changeTaskID(edit.Task, task),
+		Ref:     refName(edit.Task),
+		Title:   task.Title,
+		Details: []string{fmt.Sprintf("depends on: %s", dependsOn.Title)},
+	}
+}
+
+// changeTaskID returns the task ID to report for a change. Tasks created by the
+// plan only have sandbox IDs during preview, so those are not reported.
+func (e *executor) changeTaskID(ref string, task *types.Task) string {
+	if e.preview && refName(ref) != "" {
+		return ""
+	}
+	return task.ID.String()
+}
+
+// refName returns the reference if it is a symbolic plan ref rather than a task ID
+func refName(ref string) string {
+	if _, err := uuid.Parse(ref); err == nil {
+		return ""
+	}
+	return ref
+}
+
+func containsTask(tasks []*types.Task, taskID uuid.UUID) bool {
+	for _, task := range tasks {
+		if task.ID == taskID {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePriority(priority string) (types.TaskPriority, error) {
+	switch priority {
+	case "", "medium":
+		return types.TaskPriorityMedium, nil
+	case "high":
+		return types.TaskPriorityHigh, nil
+	case "low":
+		return types.TaskPriorityLow, nil
+	default:
+		return 0, fmt.Errorf("invalid priority '%s' (must be low, medium or high)", priority)
+	}
+}
@@ -0,0 +1,170 @@
+// Package plan implements the declarative plan/apply workflow for batch changes.
+//
+// A plan file describes a batch of task creations, task updates and dependency
+// edits. Before anything is written the whole batch is replayed against an
+// in-memory copy of the project, so that constraint violations, invalid state
+// transitions and dependency cycles are reported up front. Applying a plan
+// replays the same operations against the real project manager and rolls back
+// the already applied steps if any operation fails.
+package plan
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// Plan is a declarative batch of changes for a single project.
+// Operations are executed in the order creates, moves, updates, dependency
+// removals and dependency additions.
+type Plan struct {
+	Creates      []CreateOp    `yaml:"creates,omitempty" json:"creates,omitempty"`
+	Moves        []MoveOp      `yaml:"moves,omitempty" json:"moves,omitempty"`
+	Updates      []UpdateOp    `yaml:"updates,omitempty" json:"updates,omitempty"`
+	Dependencies DependencyOps `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
+}
+
+// CreateOp describes a task to create. Ref is an optional symbolic name that
+// later operations in the same plan can use instead of a task ID.
+type CreateOp struct {
+	Ref         string `yaml:"ref,omitempty" json:"ref,omitempty"`
+	Title       string `yaml:"title" json:"title"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Complexity  int    `yaml:"complexity,omitempty" json:"complexity,omitempty"`
+	Priority    string `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Parent      string `yaml:"parent,omitempty" json:"parent,omitempty"`
+}
+
+// MoveOp moves an existing task (or one created earlier in the plan) with its
+// subtasks below another task, or to the root level if Parent is empty
+type MoveOp struct {
+	ID     string `yaml:"id" json:"id"`
+	Parent string `yaml:"parent,omitempty" json:"parent,omitempty"`
+}
+
+// UpdateOp describes changes to an existing task (or one created earlier in the plan).
+// Only the fields that are set are changed.
+type UpdateOp struct {
+	ID          string  `yaml:"id" json:"id"`
+	Title       *string `yaml:"title,omitempty" json:"title,omitempty"`
+	Description *string `yaml:"description,omitempty" json:"description,omitempty"`
+	Complexity  *int    `yaml:"complexity,omitempty" json:"complexity,omitempty"`
+	Priority    *string `yaml:"priority,omitempty" json:"priority,omitempty"`
+	State       *string `yaml:"state,omitempty" json:"state,omitempty"`
+}
+
+// DependencyOps groups dependency edits
+type DependencyOps struct {
+	Add    []DependencyEdit `yaml:"add,omitempty" json:"add,omitempty"`
+	Remove []DependencyEdit `yaml:"remove,omitempty" json:"remove,omitempty"`
+}
+
+// DependencyEdit describes a single "task depends on depends_on" edge
+type DependencyEdit struct {
+	Task      string `yaml:"task" json:"task"`
+	DependsOn string `yaml:"depends_on" json:"depends_on"`
+}
+
+// LoadFile reads and parses a plan file. YAML is expected, but since YAML is a
+// superset of JSON, JSON plan files are accepted as well.
+func LoadFile(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan file: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse parses plan data and performs structural validation
+func Parse(data []byte) (*Plan, error) {
+	var p Plan
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+
+	if err := p.validateStructure(); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Marshal encodes a plan as YAML
+func (p *Plan) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plan: %w", err)
+	}
+	return data, nil
+}
+
+// IsEmpty reports whether the plan contains no operations
+func (p *Plan) IsEmpty() bool {
+	return len(p.Creates) == 0 && len(p.Moves) == 0 && len(p.Updates) == 0 &&
+		len(p.Dependencies.Add) == 0 && len(p.Dependencies.Remove) == 0
+}
+
+// validateStructure checks the plan for problems that do not need project state
+func (p *Plan) validateStructure() error {
+	if p.IsEmpty() {
+		return fmt.Errorf("plan contains no operations")
+	}
+
+	refs := make(map[string]bool)
+	for i, op := range p.Creates {
+		if strings.TrimSpace(op.Title) == "" {
+			return fmt.Errorf("creates[%d]: title is required", i)
+		}
+		if op.Ref == "" {
+			continue
+		}
+		if _, err := uuid.Parse(op.Ref); err == nil {
+			return fmt.Errorf("creates[%d]: ref '%s' must not be a UUID", i, op.Ref)
+		}
+		if refs[op.Ref] {
+			return fmt.Errorf("creates[%d]: duplicate ref '%s'", i, op.Ref)
+		}
+		refs[op.Ref] = true
+	}
+
+	for i, op := range p.Moves {
+		if op.ID == "" {
+			return fmt.Errorf("moves[%d]: id is required", i)
+		}
+		if op.ID == op.Parent {
+			return fmt.Errorf("moves[%d]: task cannot be moved below itself", i)
+		}
+	}
+
+	for i, op := range p.Updates {
+		if op.ID == "" {
+			return fmt.Errorf("updates[%d]: id is required", i)
+		}
+		if op.Title == nil && op.Description == nil && op.Complexity == nil && op.Priority == nil && op.State == nil {
+			return fmt.Errorf("updates[%d]: at least one field must be changed", i)
+		}
+	}
+
+	if err := validateEdits("add", p.Dependencies.Add); err != nil {
+		return err
+	}
+	return validateEdits("remove", p.Dependencies.Remove)
+}
+
+// validateEdits checks that dependency edits name two distinct tasks
+func validateEdits(section string, edits []DependencyEdit) error {
+	for i, edit := range edits {
+		if edit.Task == "" || edit.DependsOn == "" {
+			return fmt.Errorf("dependencies.%s[%d]: task and depends_on are required", section, i)
+		}
+		if edit.Task == edit.DependsOn {
+			return fmt.Errorf("dependencies.%s[%d]: task cannot depend on itself", section, i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,289 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/testutil"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("valid plan", func(t *testing.T) {
+		p, err := Parse([]byte(`
+creates:
+  - ref: design
+    title: Design API
+    complexity: 3
+  - title: Implement API
+    parent: design
+dependencies:
+  add:
+    - task: design
+      depends_on: 6f1c1d2e-2f4a-4c59-9d8e-8f2a3b4c5d6e
+`))
+		require.NoError(t, err)
+		assert.Len(t, p.Creates, 2)
+		assert.Equal(t, "design", p.Creates[1].Parent)
+		assert.Len(t, p.Dependencies.Add, 1)
+	})
+
+	t.Run("rejects structural problems", func(t *testing.T) {
+		cases := map[string]string{
+			"empty plan":      `creates: []`,
+			"missing title":   "creates:\n  - ref: a\n",
+			"duplicate ref":   "creates:\n  - {ref: a, title: A}\n  - {ref: a, title: B}\n",
+			"empty update":    "updates:\n  - id: a\n",
+			"self dependency": "dependencies:\n  add:\n    - {task: a, depends_on: a}\n",
+		}
+		for name, data := range cases {
+			_, err := Parse([]byte(data))
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+func TestPreviewAndApply(t *testing.T) {
+	ctx := context.Background()
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+
+	existing, err := mgr.CreateTask(ctx, project.ID, nil, "Existing Task", "", 4, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	valid := &Plan{
+		Creates: []CreateOp{
+			{Ref: "design", Title: "Design API", Complexity: 3, Priority: "high"},
+			{Ref: "impl", Title: "Implement API", Parent: "design"},
+		},
+		Updates: []UpdateOp{
+			{ID: existing.ID.String(), State: stringPtr("in-progress")},
+		},
+		Dependencies: DependencyOps{
+			Add: []DependencyEdit{{Task: "impl", DependsOn: existing.ID.String()}},
+		},
+	}
+
+	t.Run("preview does not write", func(t *testing.T) {
+		result, err := Preview(ctx, mgr, project.ID, valid, "test-user")
+		require.NoError(t, err)
+		assert.True(t, result.Valid(), result.Errors)
+		assert.Len(t, result.Changes, 4)
+
+		tasks, err := mgr.ListTasksForProject(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		assert.Equal(t, types.TaskStatePending, tasks[0].State)
+	})
+
+	t.Run("preview reports all errors", func(t *testing.T) {
+		invalid := &Plan{
+			Creates: []CreateOp{{Title: "Bad", Complexity: 11}},
+			Updates: []UpdateOp{{ID: existing.ID.String(), State: stringPtr("completed")}},
+			Dependencies: DependencyOps{
+				Add: []DependencyEdit{{Task: "missing", DependsOn: existing.ID.String()}},
+			},
+		}
+
+		result, err := Preview(ctx, mgr, project.ID, invalid, "test-user")
+		require.NoError(t, err)
+		assert.False(t, result.Valid())
+		assert.Len(t, result.Errors, 3)
+	})
+
+	t.Run("preview detects cycles", func(t *testing.T) {
+		cyclic := &Plan{
+			Creates: []CreateOp{{Ref: "a", Title: "A"}, {Ref: "b", Title: "B"}},
+			Dependencies: DependencyOps{
+				Add: []DependencyEdit{{Task: "a", DependsOn: "b"}, {Task: "b", DependsOn: "a"}},
+			},
+		}
+
+		result, err := Preview(ctx, mgr, project.ID, cyclic, "test-user")
+		require.NoError(t, err)
+		require.Len(t, result.Errors, 1)
+		assert.Contains(t, result.Errors[0], "cycle")
+	})
+
+	t.Run("apply rejects invalid plan without changes", func(t *testing.T) {
+		invalid := &Plan{
+			Creates: []CreateOp{{Title: "Should not exist"}},
+			Updates: []UpdateOp{{ID: existing.ID.String(), State: stringPtr("completed")}},
+		}
+
+		_, err := Apply(ctx, mgr, project.ID, invalid, "test-user")
+		assert.ErrorIs(t, err, ErrInvalidPlan)
+
+		tasks, err := mgr.ListTasksForProject(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 1)
+	})
+
+	t.Run("apply writes all changes", func(t *testing.T) {
+		result, err := Apply(ctx, mgr, project.ID, valid, "test-user")
+		require.NoError(t, err)
+		assert.Len(t, result.Changes, 4)
+
+		tasks, err := mgr.ListTasksForProject(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 3)
+
+		updated, err := mgr.GetTask(ctx, existing.ID)
+		require.NoError(t, err)
+		assert.Equal(t, types.TaskStateInProgress, updated.State)
+
+		implID, err := uuid.Parse(result.Changes[1].TaskID)
+		require.NoError(t, err)
+		deps, err := mgr.GetTaskDependencies(ctx, implID)
+		require.NoError(t, err)
+		require.Len(t, deps, 1)
+		assert.Equal(t, existing.ID, deps[0].ID)
+	})
+}
+
+func TestExecutorRollback(t *testing.T) {
+	ctx := context.Background()
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+
+	existing, err := mgr.CreateTask(ctx, project.ID, nil, "Existing Task", "", 4, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	// The second update fails against the real manager, so everything before it must be undone
+	p := &Plan{
+		Creates: []CreateOp{{Title: "Temporary"}},
+		Updates: []UpdateOp{
+			{ID: existing.ID.String(), Title: stringPtr("Renamed"), State: stringPtr("in-progress")},
+			{ID: existing.ID.String(), State: stringPtr("pending")},
+		},
+	}
+
+	exec := newExecutor(mgr, project.ID, "test-user")
+	require.Error(t, exec.run(ctx, p))
+	require.NoError(t, exec.rollback(ctx))
+
+	tasks, err := mgr.ListTasksForProject(ctx, project.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Existing Task", tasks[0].Title)
+	assert.Equal(t, types.TaskStatePending, tasks[0].State)
+}
+
+// failingManager fails adding dependencies, which the sandbox of the preview
+// does not see, and optionally reports that it has no transactions
+type failingManager struct {
+	manager.ProjectManager
+	noTransactions bool
+}
+
+func (m *failingManager) AddTaskDependency(ctx context.Context, taskID, dependsOnTaskID uuid.UUID, actor string) (*types.Task, error) {
+	return nil, errors.New("dependency storage failed")
+}
+
+func (m *failingManager) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.noTransactions {
+		return types.ErrTransactionsUnsupported
+	}
+	return m.ProjectManager.InTransaction(ctx, fn)
+}
+
+func TestApplyIsAtomic(t *testing.T) {
+	cases := map[string]struct {
+		sqlite         bool
+		noTransactions bool
+	}{
+		"inmemory transaction": {},
+		"sqlite transaction":   {sqlite: true},
+		"without transactions": {noTransactions: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			config := testutil.NewTestConfig(t)
+			if tc.sqlite {
+				config.WithSQLiteDB()
+			}
+			mgr := config.SetupTestManager(t)
+			project := testutil.CreateTestProject(t, mgr)
+
+			existing, err := mgr.CreateTask(ctx, project.ID, nil, "Existing Task", "", 4, types.TaskPriorityMedium, "test-user")
+			require.NoError(t, err)
+
+			p := &Plan{
+				Creates: []CreateOp{{Ref: "new", Title: "New Task"}},
+				Updates: []UpdateOp{{ID: existing.ID.String(), Title: stringPtr("Renamed")}},
+				Dependencies: DependencyOps{
+					Add: []DependencyEdit{{Task: "new", DependsOn: existing.ID.String()}},
+				},
+			}
+			_, err = Apply(ctx, &failingManager{ProjectManager: mgr, noTransactions: tc.noTransactions}, project.ID, p, "test-user")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "all changes were rolled back")
+			assert.Contains(t, err.Error(), "dependency storage failed")
+
+			tasks, err := mgr.ListTasksForProject(ctx, project.ID)
+			require.NoError(t, err)
+			require.Len(t, tasks, 1)
+			assert.Equal(t, "Existing Task", tasks[0].Title)
+		})
+	}
+}
+
+func TestMoves(t *testing.T) {
+	ctx := context.Background()
+	config := testutil.NewTestConfig(t)
+	mgr := config.SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+
+	first, err := mgr.CreateTask(ctx, project.ID, nil, "First", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	second, err := mgr.CreateTask(ctx, project.ID, nil, "Second", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	third, err := mgr.CreateTask(ctx, project.ID, nil, "Third", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	child, err := mgr.CreateTask(ctx, project.ID, &second.ID, "Child", "", 2, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	p := &Plan{
+		Creates: []CreateOp{{Ref: "group", Title: "Group"}},
+		Moves: []MoveOp{
+			{ID: second.ID.String(), Parent: "group"},
+			{ID: first.ID.String(), Parent: "group"},
+		},
+	}
+	result, err := Apply(ctx, mgr, project.ID, p, "test-user")
+	require.NoError(t, err)
+	require.Len(t, result.Changes, 3)
+	assert.Equal(t, ChangeMove, result.Changes[1].Kind)
+	assert.Contains(t, result.Changes[1].Details, "parent: root level -> Group")
+
+	moved, err := mgr.GetTask(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, moved.Depth, "subtasks move along")
+	roots, err := mgr.GetRootTasks(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Len(t, roots, 2)
+
+	// Moving a task below its own subtask fails and is rolled back
+	exec := newExecutor(mgr, project.ID, "test-user")
+	require.Error(t, exec.run(ctx, &Plan{Moves: []MoveOp{
+		{ID: third.ID.String(), Parent: second.ID.String()},
+		{ID: second.ID.String(), Parent: child.ID.String()},
+	}}))
+	require.NoError(t, exec.rollback(ctx))
+	restored, err := mgr.GetTask(ctx, third.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.ParentID)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
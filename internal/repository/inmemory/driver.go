@@ -0,0 +1,18 @@
+package inmemory
+
+import (
+	"github.com/denkhaus/knot/v2/internal/repository"
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// DriverName is the storage driver name of the in-memory repository
+const DriverName = "inmemory"
+
+func init() {
+	repository.Register(DriverName, func(_ string, opts repository.Options) (types.Repository, error) {
+		if opts.Clock != nil {
+			return NewMemoryRepository(WithClock(opts.Clock)), nil
+		}
+		return NewMemoryRepository(), nil
+	})
+}
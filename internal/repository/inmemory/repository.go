@@ -3,34 +3,82 @@ package inmemory
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
 	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
 )
 
-// simpleMemoryRepository implements Repository interface with simple in-memory storage
+// simpleMemoryRepository implements Repository interface with simple in-memory storage.
+// It behaves like the SQLite repository, down to its typed errors, so tests
+// against it hold for the real storage. Stored objects are owned by the
+// repository: writes store copies and reads return copies.
 type simpleMemoryRepository struct {
 	mu                sync.RWMutex
+	clock             types.Clock
 	projects          map[uuid.UUID]*types.Project
-	tasks             map[uuid.UUID]*types.Task
+	tasks             map[uuid.UUID]*types.Task // Stored without dependency fields
 	tasksByProject    map[uuid.UUID][]uuid.UUID
 	tasksByParent     map[uuid.UUID][]uuid.UUID
-	taskDependencies  map[uuid.UUID][]uuid.UUID // taskID -> list of dependency taskIDs
-	selectedProjectID *uuid.UUID                // Currently selected project
+	taskDependencies  map[uuid.UUID][]types.DependencyLink // taskID -> dependency edges in creation order
+	taskDependents    map[uuid.UUID][]uuid.UUID            // taskID -> tasks depending on it
+	selectedProjectID *uuid.UUID                           // Currently selected project
+	sessionProjects   map[string]uuid.UUID                 // session -> selected project
+	events            []*types.ChangeEvent                 // Change feed, ordered by Seq
+	lastSeq           int64
+	locks             map[uuid.UUID]types.ProjectLock
+	transitions       map[uuid.UUID]types.ScheduledTransition
+	users             map[string]*types.User
+}
+
+// Option configures an in-memory repository
+type Option func(*simpleMemoryRepository)
+
+// WithClock sets the clock for timestamps the repository records. The
+// manager working on the repository uses the same clock, so a fake clock
+// makes time-dependent behavior such as lock expiry testable.
+func WithClock(clock types.Clock) Option {
+	return func(r *simpleMemoryRepository) {
+		r.clock = clock
+	}
 }
 
 // NewMemoryRepository creates a new in-memory repository
-func NewMemoryRepository() types.Repository {
-	return &simpleMemoryRepository{
+func NewMemoryRepository(opts ...Option) types.Repository {
+	r := &simpleMemoryRepository{
+		clock:             types.SystemClock{},
 		projects:          make(map[uuid.UUID]*types.Project),
 		tasks:             make(map[uuid.UUID]*types.Task),
 		tasksByProject:    make(map[uuid.UUID][]uuid.UUID),
 		tasksByParent:     make(map[uuid.UUID][]uuid.UUID),
-		taskDependencies:  make(map[uuid.UUID][]uuid.UUID),
+		taskDependencies:  make(map[uuid.UUID][]types.DependencyLink),
+		taskDependents:    make(map[uuid.UUID][]uuid.UUID),
 		selectedProjectID: nil,
+		sessionProjects:   make(map[string]uuid.UUID),
+		locks:             make(map[uuid.UUID]types.ProjectLock),
+		transitions:       make(map[uuid.UUID]types.ScheduledTransition),
+		users:             make(map[string]*types.User),
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r
+}
+
+// Now returns the current time of the repository clock, which makes the
+// repository a types.Clock for the manager
+func (r *simpleMemoryRepository) Now() time.Time {
+	return r.clock.Now()
+}
+
+// now returns the current time for stored timestamps
+func (r *simpleMemoryRepository) now() time.Time {
+	return r.clock.Now().UTC()
 }
 
 // Project operations
@@ -39,12 +87,23 @@ func (r *simpleMemoryRepository) CreateProject(ctx context.Context, project *typ
 	defer r.mu.Unlock()
 
 	if project.ID == uuid.Nil {
-		project.ID = uuid.New()
+		project.ID = types.NewID()
+	}
+	if _, exists := r.projects[project.ID]; exists {
+		return sqlite.NewConstraintViolationError("project already exists", nil)
+	}
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = r.now()
+	}
+	if project.UpdatedAt.IsZero() {
+		project.UpdatedAt = project.CreatedAt
 	}
-	project.CreatedAt = time.Now()
-	project.UpdatedAt = time.Now()
 
-	r.projects[project.ID] = project
+	stored := *project
+	stored.CreatedAt = stored.CreatedAt.UTC()
+	stored.UpdatedAt = stored.UpdatedAt.UTC()
+	r.projects[project.ID] = &stored
+	r.appendEvent(types.NewProjectEvent(types.ChangeProjectCreated, project))
 	return nil
 }
 
@@ -54,39 +113,65 @@ func (r *simpleMemoryRepository) GetProject(ctx context.Context, id uuid.UUID) (
 
 	project, exists := r.projects[id]
 	if !exists {
-		return nil, fmt.Errorf("project not found")
+		return nil, sqlite.NewNotFoundError("project", id.String())
 	}
-	return project, nil
+	copied := *project
+	return &copied, nil
 }
 
 func (r *simpleMemoryRepository) UpdateProject(ctx context.Context, project *types.Project) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.projects[project.ID]; !exists {
-		return fmt.Errorf("project not found")
+	existing, exists := r.projects[project.ID]
+	if !exists {
+		return sqlite.NewNotFoundError("project", project.ID.String())
 	}
 
-	project.UpdatedAt = time.Now()
-	r.projects[project.ID] = project
+	if project.UpdatedAt.IsZero() {
+		project.UpdatedAt = r.now()
+	}
+	stored := *project
+	stored.CreatedAt = existing.CreatedAt
+	stored.UpdatedAt = stored.UpdatedAt.UTC()
+	r.projects[project.ID] = &stored
+	r.appendEvent(types.NewProjectEvent(types.ChangeProjectUpdated, project))
 	return nil
 }
 
+// DeleteProject deletes a project with all its tasks, their dependencies and
+// the project lock
 func (r *simpleMemoryRepository) DeleteProject(ctx context.Context, id uuid.UUID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.projects[id]; !exists {
-		return fmt.Errorf("project not found")
+	project, exists := r.projects[id]
+	if !exists {
+		return sqlite.NewNotFoundError("project", id.String())
 	}
 
 	// Clear project selection if this project is currently selected
 	if r.selectedProjectID != nil && *r.selectedProjectID == id {
 		r.selectedProjectID = nil
 	}
+	for session, projectID := range r.sessionProjects {
+		if projectID == id {
+			delete(r.sessionProjects, session)
+		}
+	}
 
+	for _, taskID := range slices.Clone(r.tasksByProject[id]) {
+		r.removeTask(taskID)
+	}
 	delete(r.projects, id)
 	delete(r.tasksByProject, id)
+	delete(r.locks, id)
+	for transitionID, transition := range r.transitions {
+		if transition.ProjectID == id {
+			delete(r.transitions, transitionID)
+		}
+	}
+	r.appendEvent(types.NewProjectEvent(types.ChangeProjectDeleted, project))
 	return nil
 }
 
@@ -96,32 +181,84 @@ func (r *simpleMemoryRepository) ListProjects(ctx context.Context) ([]*types.Pro
 
 	projects := make([]*types.Project, 0, len(r.projects))
 	for _, project := range r.projects {
-		projects = append(projects, project)
+		copied := *project
+		projects = append(projects, &copied)
 	}
+	slices.SortFunc(projects, func(a, b *types.Project) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return slices.Compare(a.ID[:], b.ID[:])
+	})
 	return projects, nil
 }
 
 // Task operations
+
+// CreateTask creates a task below an existing parent of the same project and
+// its dependencies, rejecting circular ones
 func (r *simpleMemoryRepository) CreateTask(ctx context.Context, task *types.Task) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if _, exists := r.projects[task.ProjectID]; !exists {
+		return sqlite.NewNotFoundError("project", task.ProjectID.String())
+	}
 	if task.ID == uuid.Nil {
-		task.ID = uuid.New()
+		task.ID = types.NewID()
+	}
+	if _, exists := r.tasks[task.ID]; exists {
+		return sqlite.NewConstraintViolationError("task already exists", nil)
 	}
-	task.CreatedAt = time.Now()
-	task.UpdatedAt = time.Now()
 
-	r.tasks[task.ID] = task
+	if task.ParentID != nil {
+		parent, exists := r.tasks[*task.ParentID]
+		if !exists {
+			return sqlite.NewNotFoundError("parent task", task.ParentID.String())
+		}
+		if parent.ProjectID != task.ProjectID {
+			return sqlite.NewConstraintViolationError("parent task must be in the same project", nil)
+		}
+		task.Depth = parent.Depth + 1
+	} else {
+		task.Depth = 0
+	}
 
-	// Add to project tasks
-	r.tasksByProject[task.ProjectID] = append(r.tasksByProject[task.ProjectID], task.ID)
+	for i, depID := range task.Dependencies {
+		dependency, exists := r.tasks[depID]
+		if !exists {
+			return sqlite.NewNotFoundError("dependency task", depID.String())
+		}
+		if dependency.ProjectID != task.ProjectID {
+			return sqlite.NewConstraintViolationError("dependency task must be in the same project", nil)
+		}
+		if slices.Contains(task.Dependencies[:i], depID) {
+			return sqlite.NewConstraintViolationError("dependency already exists", nil)
+		}
+		if err := r.checkCircularDependency(task.ID, depID); err != nil {
+			return err
+		}
+	}
 
-	// Add to parent tasks if applicable
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = r.now()
+	}
+	if task.UpdatedAt.IsZero() {
+		task.UpdatedAt = task.CreatedAt
+	}
+	normalizeCompletedAt(task)
+
+	r.tasks[task.ID] = storedTask(task)
+	r.tasksByProject[task.ProjectID] = append(r.tasksByProject[task.ProjectID], task.ID)
 	if task.ParentID != nil {
 		r.tasksByParent[*task.ParentID] = append(r.tasksByParent[*task.ParentID], task.ID)
 	}
+	for _, depID := range task.Dependencies {
+		r.addDependency(task.ID, types.DependencyLink{DependsOnID: depID, Type: types.DependencyFinishToStart})
+	}
+	r.updateProjectMetrics(task.ProjectID)
 
+	r.appendEvent(types.NewTaskEvent(types.ChangeTaskCreated, task))
 	return nil
 }
 
@@ -131,9 +268,9 @@ func (r *simpleMemoryRepository) GetTask(ctx context.Context, id uuid.UUID) (*ty
 
 	task, exists := r.tasks[id]
 	if !exists {
-		return nil, fmt.Errorf("task not found")
+		return nil, sqlite.NewNotFoundError("task", id.String())
 	}
-	return task, nil
+	return r.load(task), nil
 }
 
 func (r *simpleMemoryRepository) GetTasksWithDependencies(ctx context.Context, taskIDs []uuid.UUID) ([]*types.Task, error) {
@@ -144,59 +281,92 @@ func (r *simpleMemoryRepository) GetTasksWithDependencies(ctx context.Context, t
 	for _, id := range taskIDs {
 		task, exists := r.tasks[id]
 		if exists {
-			tasks = append(tasks, task)
+			tasks = append(tasks, r.load(task))
 		}
 	}
 	return tasks, nil
 }
 
+// UpdateTask updates a task. Its project, parent, depth and creation time
+// cannot change, and the completion time follows its state.
 func (r *simpleMemoryRepository) UpdateTask(ctx context.Context, task *types.Task) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.tasks[task.ID]; !exists {
-		return fmt.Errorf("task not found")
+	existing, exists := r.tasks[task.ID]
+	if !exists {
+		return sqlite.NewNotFoundError("task", task.ID.String())
+	}
+
+	task.CreatedAt = existing.CreatedAt
+	task.ProjectID = existing.ProjectID
+	task.ParentID = copyPointer(existing.ParentID)
+	task.Depth = existing.Depth
+	task.UpdatedAt = r.now()
+	if task.State == types.TaskStateCompleted && existing.State != types.TaskStateCompleted {
+		completedAt := task.UpdatedAt
+		task.CompletedAt = &completedAt
+	} else if task.State != types.TaskStateCompleted {
+		task.CompletedAt = nil
 	}
+	normalizeCompletedAt(task)
 
-	task.UpdatedAt = time.Now()
-	r.tasks[task.ID] = task
+	r.tasks[task.ID] = storedTask(task)
+	if task.State != existing.State {
+		r.updateProjectMetrics(task.ProjectID)
+	}
+	r.appendEvent(types.NewTaskEvent(types.ChangeTaskUpdated, task))
 	return nil
 }
 
+// DeleteTask deletes a task without children and its dependencies in both
+// directions
 func (r *simpleMemoryRepository) DeleteTask(ctx context.Context, id uuid.UUID) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	task, exists := r.tasks[id]
 	if !exists {
-		return fmt.Errorf("task not found")
+		return sqlite.NewNotFoundError("task", id.String())
+	}
+	if len(r.tasksByParent[id]) > 0 {
+		return sqlite.NewConstraintViolationError("cannot delete task with children", nil)
 	}
 
-	delete(r.tasks, id)
+	r.removeTask(id)
+	r.updateProjectMetrics(task.ProjectID)
+	r.appendEvent(types.NewTaskEvent(types.ChangeTaskDeleted, task))
+	return nil
+}
 
-	// Remove from project tasks
-	if projectTasks, exists := r.tasksByProject[task.ProjectID]; exists {
-		for i, taskID := range projectTasks {
-			if taskID == id {
-				r.tasksByProject[task.ProjectID] = append(projectTasks[:i], projectTasks[i+1:]...)
-				break
-			}
-		}
-	}
+// removeTask removes a task from all indexes together with its dependencies.
+// The caller must hold the write lock.
+func (r *simpleMemoryRepository) removeTask(id uuid.UUID) {
+	task := r.tasks[id]
+	delete(r.tasks, id)
 
-	// Remove from parent tasks if applicable
+	r.tasksByProject[task.ProjectID] = slices.DeleteFunc(r.tasksByProject[task.ProjectID], func(taskID uuid.UUID) bool {
+		return taskID == id
+	})
 	if task.ParentID != nil {
-		if parentTasks, exists := r.tasksByParent[*task.ParentID]; exists {
-			for i, taskID := range parentTasks {
-				if taskID == id {
-					r.tasksByParent[*task.ParentID] = append(parentTasks[:i], parentTasks[i+1:]...)
-					break
-				}
-			}
-		}
+		r.tasksByParent[*task.ParentID] = slices.DeleteFunc(r.tasksByParent[*task.ParentID], func(taskID uuid.UUID) bool {
+			return taskID == id
+		})
 	}
+	delete(r.tasksByParent, id)
 
-	return nil
+	for _, link := range r.taskDependencies[id] {
+		r.taskDependents[link.DependsOnID] = slices.DeleteFunc(r.taskDependents[link.DependsOnID], func(taskID uuid.UUID) bool {
+			return taskID == id
+		})
+	}
+	for _, dependentID := range r.taskDependents[id] {
+		r.taskDependencies[dependentID] = slices.DeleteFunc(r.taskDependencies[dependentID], func(link types.DependencyLink) bool {
+			return link.DependsOnID == id
+		})
+	}
+	delete(r.taskDependencies, id)
+	delete(r.taskDependents, id)
 }
 
 // Task queries
@@ -207,46 +377,57 @@ func (r *simpleMemoryRepository) ListTasks(ctx context.Context, filter types.Tas
 	var tasks []*types.Task
 	for _, task := range r.tasks {
 		if r.matchesFilter(task, filter) {
-			tasks = append(tasks, task)
+			tasks = append(tasks, r.load(task))
 		}
 	}
+	// Map iteration is random, list in creation order like the SQL repositories
+	slices.SortFunc(tasks, types.CompareCreation)
 	return tasks, nil
 }
 
+// ListTasksPage sorts the matching tasks in memory; the keyset cursor keeps
+// pages stable like in the SQL repositories
+func (r *simpleMemoryRepository) ListTasksPage(ctx context.Context, filter types.TaskFilter, page types.PageRequest) (*types.TaskPage, error) {
+	tasks, err := r.ListTasks(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return types.PaginateTasks(tasks, page)
+}
+
 func (r *simpleMemoryRepository) GetTasksByProject(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	taskIDs, exists := r.tasksByProject[projectID]
-	if !exists {
-		return []*types.Task{}, nil
-	}
-
-	tasks := make([]*types.Task, 0, len(taskIDs))
-	for _, taskID := range taskIDs {
-		if task, exists := r.tasks[taskID]; exists {
-			tasks = append(tasks, task)
-		}
-	}
-	return tasks, nil
+	return r.loadAll(r.tasksByProject[projectID], types.CompareCreation), nil
 }
 
 func (r *simpleMemoryRepository) GetTasksByParent(ctx context.Context, parentID uuid.UUID) ([]*types.Task, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	taskIDs, exists := r.tasksByParent[parentID]
-	if !exists {
-		return []*types.Task{}, nil
-	}
+	return r.loadAll(r.tasksByParent[parentID], types.CompareSiblings), nil
+}
 
-	tasks := make([]*types.Task, 0, len(taskIDs))
-	for _, taskID := range taskIDs {
-		if task, exists := r.tasks[taskID]; exists {
-			tasks = append(tasks, task)
+func (r *simpleMemoryRepository) GetDescendants(ctx context.Context, taskID uuid.UUID, maxDepth int) ([]*types.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var descendants []*types.Task
+	level := []uuid.UUID{taskID}
+	for depth := 1; len(level) > 0 && (maxDepth == 0 || depth <= maxDepth); depth++ {
+		var children []*types.Task
+		for _, parentID := range level {
+			children = append(children, r.loadAll(r.tasksByParent[parentID], types.CompareSiblings)...)
 		}
+
+		level = level[:0]
+		for _, child := range children {
+			level = append(level, child.ID)
+		}
+		descendants = append(descendants, children...)
 	}
-	return tasks, nil
+	return descendants, nil
 }
 
 func (r *simpleMemoryRepository) GetRootTasks(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error) {
@@ -261,6 +442,7 @@ func (r *simpleMemoryRepository) GetRootTasks(ctx context.Context, projectID uui
 			rootTasks = append(rootTasks, task)
 		}
 	}
+	types.SortSiblings(rootTasks)
 	return rootTasks, nil
 }
 
@@ -271,125 +453,238 @@ func (r *simpleMemoryRepository) GetParentTask(ctx context.Context, taskID uuid.
 	}
 
 	if task.ParentID == nil {
-		return nil, fmt.Errorf("task has no parent")
+		return nil, sqlite.NewNotFoundError("parent task", "nil")
 	}
 
 	return r.GetTask(ctx, *task.ParentID)
 }
 
 // Hierarchy operations
+
+// DeleteTaskSubtree deletes a task and all its descendants at once
 func (r *simpleMemoryRepository) DeleteTaskSubtree(ctx context.Context, taskID uuid.UUID) error {
-	// Get all child tasks recursively
-	childTasks, err := r.GetTasksByParent(ctx, taskID)
-	if err != nil {
-		return err
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, exists := r.tasks[taskID]
+	if !exists {
+		return sqlite.NewNotFoundError("task", taskID.String())
 	}
 
-	// Delete all children first
-	for _, child := range childTasks {
-		if err := r.DeleteTaskSubtree(ctx, child.ID); err != nil {
-			return err
+	// Children go first, so every task is deleted after its descendants
+	subtree := []uuid.UUID{taskID}
+	for i := 0; i < len(subtree); i++ {
+		subtree = append(subtree, r.tasksByParent[subtree[i]]...)
+	}
+	for i := len(subtree) - 1; i >= 0; i-- {
+		deleted := r.tasks[subtree[i]]
+		r.removeTask(subtree[i])
+		r.appendEvent(types.NewTaskEvent(types.ChangeTaskDeleted, deleted))
+	}
+	r.updateProjectMetrics(task.ProjectID)
+	return nil
+}
+
+// MoveTask moves a task below parentID, or to the root level if parentID is nil,
+// and recalculates the depth of the task and its descendants
+func (r *simpleMemoryRepository) MoveTask(ctx context.Context, taskID uuid.UUID, parentID *uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, exists := r.tasks[taskID]
+	if !exists {
+		return sqlite.NewNotFoundError("task", taskID.String())
+	}
+
+	depth := 0
+	if parentID != nil {
+		parent, exists := r.tasks[*parentID]
+		if !exists {
+			return sqlite.NewNotFoundError("parent task", parentID.String())
+		}
+		if parent.ProjectID != task.ProjectID {
+			return sqlite.NewConstraintViolationError("parent task must be in the same project", nil)
+		}
+		for ancestor := parent; ancestor != nil; ancestor = r.parentOf(ancestor) {
+			if ancestor.ID == taskID {
+				return sqlite.NewConstraintViolationError("a task cannot be moved below itself or its descendants", nil)
+			}
 		}
+		depth = parent.Depth + 1
 	}
 
-	// Delete the task itself
-	return r.DeleteTask(ctx, taskID)
+	if task.ParentID != nil {
+		r.tasksByParent[*task.ParentID] = slices.DeleteFunc(r.tasksByParent[*task.ParentID], func(id uuid.UUID) bool {
+			return id == taskID
+		})
+	}
+	if parentID != nil {
+		newParentID := *parentID
+		task.ParentID = &newParentID
+		r.tasksByParent[newParentID] = append(r.tasksByParent[newParentID], taskID)
+	} else {
+		task.ParentID = nil
+	}
+
+	shift := depth - task.Depth
+	now := r.now()
+	queue := []uuid.UUID{taskID}
+	for len(queue) > 0 {
+		current := r.tasks[queue[0]]
+		queue = append(queue[1:], r.tasksByParent[current.ID]...)
+		current.Depth += shift
+		current.UpdatedAt = now
+		r.appendEvent(types.NewTaskEvent(types.ChangeTaskUpdated, r.load(current)))
+	}
+	return nil
+}
+
+// parentOf returns the parent of task, or nil for root tasks. The caller must
+// hold the lock.
+func (r *simpleMemoryRepository) parentOf(task *types.Task) *types.Task {
+	if task.ParentID == nil {
+		return nil
+	}
+	return r.tasks[*task.ParentID]
 }
 
 // Dependency management
+
+// AddTaskDependency adds a finish-to-start dependency between two tasks of
+// the same project, rejecting duplicates and circular dependencies
 func (r *simpleMemoryRepository) AddTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID) (*types.Task, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// Verify both tasks exist
 	task, exists := r.tasks[taskID]
 	if !exists {
-		return nil, fmt.Errorf("task not found")
+		return nil, sqlite.NewNotFoundError("task", taskID.String())
 	}
-
-	if _, exists := r.tasks[dependsOnTaskID]; !exists {
-		return nil, fmt.Errorf("dependency task not found")
+	dependsOnTask, exists := r.tasks[dependsOnTaskID]
+	if !exists {
+		return nil, sqlite.NewNotFoundError("depends on task", dependsOnTaskID.String())
 	}
-
-	// Add dependency
-	deps := r.taskDependencies[taskID]
-	for _, dep := range deps {
-		if dep == dependsOnTaskID {
-			return task, nil // Already exists
-		}
+	if task.ProjectID != dependsOnTask.ProjectID {
+		return nil, sqlite.NewConstraintViolationError("tasks must be in the same project", nil)
 	}
+	if r.dependencyIndex(taskID, dependsOnTaskID) >= 0 {
+		return nil, sqlite.NewConstraintViolationError("dependency already exists", nil)
+	}
+	if err := r.checkCircularDependency(taskID, dependsOnTaskID); err != nil {
+		return nil, err
+	}
+
+	r.addDependency(taskID, types.DependencyLink{DependsOnID: dependsOnTaskID, Type: types.DependencyFinishToStart})
+	r.appendEvent(types.NewDependencyEvent(types.ChangeDependencyAdded, task.ProjectID, taskID, dependsOnTaskID))
+	return r.load(task), nil
+}
 
-	r.taskDependencies[taskID] = append(deps, dependsOnTaskID)
+// SetDependencyLink changes the type and lag of an existing dependency
+func (r *simpleMemoryRepository) SetDependencyLink(ctx context.Context, taskID uuid.UUID, link types.DependencyLink) (*types.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	// Update task dependencies slice
-	task.Dependencies = r.taskDependencies[taskID]
-	r.tasks[taskID] = task
+	task, exists := r.tasks[taskID]
+	if !exists {
+		return nil, sqlite.NewNotFoundError("task", taskID.String())
+	}
+	i := r.dependencyIndex(taskID, link.DependsOnID)
+	if i < 0 {
+		return nil, sqlite.NewNotFoundError("task dependency", fmt.Sprintf("%s -> %s", taskID, link.DependsOnID))
+	}
 
-	return task, nil
+	r.taskDependencies[taskID][i] = link
+	r.appendEvent(types.NewDependencyEvent(types.ChangeDependencyUpdated, task.ProjectID, taskID, link.DependsOnID))
+	return r.load(task), nil
 }
 
+// RemoveTaskDependency removes an existing dependency between two tasks
 func (r *simpleMemoryRepository) RemoveTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID) (*types.Task, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	task, exists := r.tasks[taskID]
 	if !exists {
-		return nil, fmt.Errorf("task not found")
+		return nil, sqlite.NewNotFoundError("task", taskID.String())
 	}
-
-	deps := r.taskDependencies[taskID]
-	for i, dep := range deps {
-		if dep == dependsOnTaskID {
-			r.taskDependencies[taskID] = append(deps[:i], deps[i+1:]...)
-			break
-		}
+	i := r.dependencyIndex(taskID, dependsOnTaskID)
+	if i < 0 {
+		return nil, sqlite.NewNotFoundError("task dependency", fmt.Sprintf("%s -> %s", taskID, dependsOnTaskID))
 	}
 
-	// Update task dependencies slice
-	task.Dependencies = r.taskDependencies[taskID]
-	r.tasks[taskID] = task
-
-	return task, nil
+	r.taskDependencies[taskID] = slices.Delete(r.taskDependencies[taskID], i, i+1)
+	r.taskDependents[dependsOnTaskID] = slices.DeleteFunc(r.taskDependents[dependsOnTaskID], func(id uuid.UUID) bool {
+		return id == taskID
+	})
+	r.appendEvent(types.NewDependencyEvent(types.ChangeDependencyRemoved, task.ProjectID, taskID, dependsOnTaskID))
+	return r.load(task), nil
 }
 
 func (r *simpleMemoryRepository) GetTaskDependencies(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	deps := r.taskDependencies[taskID]
-	tasks := make([]*types.Task, 0, len(deps))
-
-	for _, depID := range deps {
-		if task, exists := r.tasks[depID]; exists {
-			tasks = append(tasks, task)
-		}
+	links := r.taskDependencies[taskID]
+	ids := make([]uuid.UUID, len(links))
+	for i, link := range links {
+		ids[i] = link.DependsOnID
 	}
-
-	return tasks, nil
+	return r.loadAll(ids, types.CompareCreation), nil
 }
 
 func (r *simpleMemoryRepository) GetDependentTasks(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var dependents []*types.Task
+	return r.loadAll(r.taskDependents[taskID], types.CompareCreation), nil
+}
 
-	for otherTaskID, deps := range r.taskDependencies {
-		for _, depID := range deps {
-			if depID == taskID {
-				if task, exists := r.tasks[otherTaskID]; exists {
-					dependents = append(dependents, task)
-				}
-				break
-			}
+// addDependency records a dependency edge in both directions. The caller
+// must hold the write lock.
+func (r *simpleMemoryRepository) addDependency(taskID uuid.UUID, link types.DependencyLink) {
+	r.taskDependencies[taskID] = append(r.taskDependencies[taskID], link)
+	r.taskDependents[link.DependsOnID] = append(r.taskDependents[link.DependsOnID], taskID)
+}
+
+// dependencyIndex returns the index of the edge from taskID to dependsOnID,
+// or -1 if there is none. The caller must hold the lock.
+func (r *simpleMemoryRepository) dependencyIndex(taskID, dependsOnID uuid.UUID) int {
+	return slices.IndexFunc(r.taskDependencies[taskID], func(link types.DependencyLink) bool {
+		return link.DependsOnID == dependsOnID
+	})
+}
+
+// checkCircularDependency returns a circular dependency error if taskID
+// depending on dependsOnID would close a cycle. The caller must hold the lock.
+func (r *simpleMemoryRepository) checkCircularDependency(taskID, dependsOnID uuid.UUID) error {
+	visited := make(map[uuid.UUID]bool)
+	stack := []uuid.UUID{dependsOnID}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if current == taskID {
+			return sqlite.NewCircularDependencyError(fmt.Sprintf("circular dependency detected: task %s", taskID))
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		for _, link := range r.taskDependencies[current] {
+			stack = append(stack, link.DependsOnID)
 		}
 	}
-
-	return dependents, nil
+	return nil
 }
 
 // Metrics and analysis
 func (r *simpleMemoryRepository) GetProjectProgress(ctx context.Context, projectID uuid.UUID) (*types.ProjectProgress, error) {
+	r.mu.RLock()
+	_, exists := r.projects[projectID]
+	r.mu.RUnlock()
+	if !exists {
+		return nil, sqlite.NewNotFoundError("project", projectID.String())
+	}
+
 	tasks, err := r.GetTasksByProject(ctx, projectID)
 	if err != nil {
 		return nil, err
@@ -397,7 +692,6 @@ func (r *simpleMemoryRepository) GetProjectProgress(ctx context.Context, project
 
 	progress := &types.ProjectProgress{
 		ProjectID:    projectID,
-		TotalTasks:   len(tasks),
 		TasksByDepth: make(map[int]int),
 	}
 
@@ -415,7 +709,11 @@ func (r *simpleMemoryRepository) GetProjectProgress(ctx context.Context, project
 			progress.BlockedTasks++
 		case types.TaskStateCancelled:
 			progress.CancelledTasks++
+		default:
+			// Like SQLite, the totals only count the five progress states
+			continue
 		}
+		progress.TotalTasks++
 	}
 
 	if progress.TotalTasks > 0 {
@@ -425,6 +723,27 @@ func (r *simpleMemoryRepository) GetProjectProgress(ctx context.Context, project
 	return progress, nil
 }
 
+// updateProjectMetrics recalculates the task counts and progress of a
+// project. The caller must hold the write lock.
+func (r *simpleMemoryRepository) updateProjectMetrics(projectID uuid.UUID) {
+	project, exists := r.projects[projectID]
+	if !exists {
+		return
+	}
+
+	project.TotalTasks = len(r.tasksByProject[projectID])
+	project.CompletedTasks = 0
+	for _, taskID := range r.tasksByProject[projectID] {
+		if r.tasks[taskID].State == types.TaskStateCompleted {
+			project.CompletedTasks++
+		}
+	}
+	project.Progress = 0
+	if project.TotalTasks > 0 {
+		project.Progress = float64(project.CompletedTasks) / float64(project.TotalTasks) * 100
+	}
+}
+
 func (r *simpleMemoryRepository) GetTaskCountByDepth(ctx context.Context, projectID uuid.UUID, maxDepth int) (map[int]int, error) {
 	tasks, err := r.GetTasksByProject(ctx, projectID)
 	if err != nil {
@@ -441,6 +760,27 @@ func (r *simpleMemoryRepository) GetTaskCountByDepth(ctx context.Context, projec
 	return counts, nil
 }
 
+func (r *simpleMemoryRepository) GetSubtreeCounts(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]*types.SubtreeCounts, error) {
+	tasks, err := r.GetTasksByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return types.CountSubtrees(tasks), nil
+}
+
+func (r *simpleMemoryRepository) GetChildCounts(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[uuid.UUID]int)
+	for _, taskID := range r.tasksByProject[projectID] {
+		if children := len(r.tasksByParent[taskID]); children > 0 {
+			counts[taskID] = children
+		}
+	}
+	return counts, nil
+}
+
 // Project context management methods
 
 // GetSelectedProject retrieves the currently selected project ID
@@ -488,7 +828,223 @@ func (r *simpleMemoryRepository) HasSelectedProject(ctx context.Context) (bool,
 	return r.selectedProjectID != nil, nil
 }
 
-// Helper function to match tasks against filter
+// GetSessionProject retrieves the project selected in a session
+func (r *simpleMemoryRepository) GetSessionProject(ctx context.Context, session string) (*uuid.UUID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	projectID, ok := r.sessionProjects[session]
+	if !ok {
+		return nil, nil
+	}
+	return &projectID, nil
+}
+
+// SetSessionProject selects a project for one session
+func (r *simpleMemoryRepository) SetSessionProject(ctx context.Context, session string, projectID uuid.UUID, actor string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.projects[projectID]; !exists {
+		return fmt.Errorf("project with ID %s does not exist", projectID)
+	}
+
+	r.sessionProjects[session] = projectID
+	return nil
+}
+
+// ClearSessionProject removes the selection of a session
+func (r *simpleMemoryRepository) ClearSessionProject(ctx context.Context, session string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sessionProjects, session)
+	return nil
+}
+
+// Project lock methods
+
+// GetProjectLock returns a copy of the lock of a project, or nil if it is not locked
+func (r *simpleMemoryRepository) GetProjectLock(ctx context.Context, projectID uuid.UUID) (*types.ProjectLock, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	lock, exists := r.locks[projectID]
+	if !exists {
+		return nil, nil
+	}
+	return &lock, nil
+}
+
+// SaveProjectLock creates or replaces the lock of a project
+func (r *simpleMemoryRepository) SaveProjectLock(ctx context.Context, lock *types.ProjectLock) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.locks[lock.ProjectID] = *lock
+	return nil
+}
+
+// DeleteProjectLock removes the lock of a project, if any
+func (r *simpleMemoryRepository) DeleteProjectLock(ctx context.Context, projectID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.locks, projectID)
+	return nil
+}
+
+// Scheduled transition methods
+
+// SaveScheduledTransition creates or replaces a scheduled transition
+func (r *simpleMemoryRepository) SaveScheduledTransition(ctx context.Context, transition *types.ScheduledTransition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.tasks[transition.TaskID]; !exists {
+		return sqlite.NewNotFoundError("task", transition.TaskID.String())
+	}
+	r.transitions[transition.ID] = *transition
+	return nil
+}
+
+// ListScheduledTransitions returns copies of the scheduled transitions of a
+// project, or of all projects if projectID is nil, ordered by their time
+func (r *simpleMemoryRepository) ListScheduledTransitions(ctx context.Context, projectID *uuid.UUID) ([]*types.ScheduledTransition, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	transitions := make([]*types.ScheduledTransition, 0)
+	for _, transition := range r.transitions {
+		if projectID != nil && transition.ProjectID != *projectID {
+			continue
+		}
+		transitions = append(transitions, &transition)
+	}
+	sort.Slice(transitions, func(i, j int) bool {
+		if !transitions[i].At.Equal(transitions[j].At) {
+			return transitions[i].At.Before(transitions[j].At)
+		}
+		return transitions[i].ID.String() < transitions[j].ID.String()
+	})
+	return transitions, nil
+}
+
+// DeleteScheduledTransition removes a scheduled transition
+func (r *simpleMemoryRepository) DeleteScheduledTransition(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.transitions[id]; !exists {
+		return sqlite.NewNotFoundError("scheduled transition", id.String())
+	}
+	delete(r.transitions, id)
+	return nil
+}
+
+// User methods
+
+// ListUsers returns copies of all users sorted by name
+func (r *simpleMemoryRepository) ListUsers(ctx context.Context) ([]*types.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]*types.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, copyUser(user))
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	return users, nil
+}
+
+// GetUser returns a copy of the user with the given name, or nil if there is none
+func (r *simpleMemoryRepository) GetUser(ctx context.Context, name string) (*types.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, exists := r.users[name]
+	if !exists {
+		return nil, nil
+	}
+	return copyUser(user), nil
+}
+
+// GetUserByTokenHash returns a copy of the user owning a token, or nil if there is none
+func (r *simpleMemoryRepository) GetUserByTokenHash(ctx context.Context, tokenHash string) (*types.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.TokenHash == tokenHash {
+			return copyUser(user), nil
+		}
+	}
+	return nil, nil
+}
+
+// SaveUser creates or replaces a user
+func (r *simpleMemoryRepository) SaveUser(ctx context.Context, user *types.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for name, existing := range r.users {
+		if name != user.Name && existing.TokenHash == user.TokenHash {
+			return fmt.Errorf("token of user %s already exists", user.Name)
+		}
+	}
+	r.users[user.Name] = copyUser(user)
+	return nil
+}
+
+// DeleteUser removes a user, if it exists
+func (r *simpleMemoryRepository) DeleteUser(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.users, name)
+	return nil
+}
+
+func copyUser(user *types.User) *types.User {
+	copied := *user
+	copied.ProjectRoles = make(map[uuid.UUID]types.Role, len(user.ProjectRoles))
+	for projectID, role := range user.ProjectRoles {
+		copied.ProjectRoles[projectID] = role
+	}
+	return &copied
+}
+
+// appendEvent adds an event to the change feed. The caller must hold the write lock.
+func (r *simpleMemoryRepository) appendEvent(event *types.ChangeEvent) {
+	r.lastSeq++
+	event.Seq = r.lastSeq
+	event.CreatedAt = r.now()
+	r.events = append(r.events, event)
+}
+
+// ListChangeEvents returns the recorded change events matching the filter
+func (r *simpleMemoryRepository) ListChangeEvents(ctx context.Context, filter types.ChangeEventFilter) ([]*types.ChangeEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := make([]*types.ChangeEvent, 0)
+	for i := range r.events {
+		event := r.events[i]
+		if filter.Descending {
+			event = r.events[len(r.events)-1-i]
+		}
+		if !types.MatchesChangeEventFilter(event, filter) {
+			continue
+		}
+		copied := *event
+		events = append(events, &copied)
+		if filter.Limit > 0 && len(events) >= filter.Limit {
+			break
+		}
+	}
+	return events, nil
+}
+
 func (r *simpleMemoryRepository) matchesFilter(task *types.Task, filter types.TaskFilter) bool {
 	if filter.ProjectID != nil && task.ProjectID != *filter.ProjectID {
 		return false
@@ -513,5 +1069,126 @@ func (r *simpleMemoryRepository) matchesFilter(task *types.Task, filter types.Ta
 	if filter.MaxComplexity != nil && task.Complexity > *filter.MaxComplexity {
 		return false
 	}
+	if filter.Priority != nil && task.Priority != *filter.Priority {
+		return false
+	}
+	if filter.AssignedAgent != nil && (task.AssignedAgent == nil || *task.AssignedAgent != *filter.AssignedAgent) {
+		return false
+	}
+	if filter.Unassigned && task.AssignedAgent != nil {
+		return false
+	}
+	if filter.Search != "" {
+		search := strings.ToLower(filter.Search)
+		if !strings.Contains(strings.ToLower(task.Title), search) &&
+			!strings.Contains(strings.ToLower(task.Description), search) {
+			return false
+		}
+	}
+	if filter.CreatedBy != "" && task.CreatedBy != filter.CreatedBy {
+		return false
+	}
+	if filter.CreatedSince != nil && task.CreatedAt.Before(*filter.CreatedSince) {
+		return false
+	}
 	return true
 }
+
+// load returns a copy of a stored task with its dependency fields. The caller
+// must hold the lock.
+func (r *simpleMemoryRepository) load(task *types.Task) *types.Task {
+	loaded := copyTask(task)
+	loaded.Dependencies = make([]uuid.UUID, 0, len(r.taskDependencies[task.ID]))
+	loaded.DependencyLinks = nil
+	for _, link := range r.taskDependencies[task.ID] {
+		loaded.Dependencies = append(loaded.Dependencies, link.DependsOnID)
+		// Only links that differ from a plain finish-to-start edge are listed
+		if !link.IsDefault() {
+			loaded.DependencyLinks = append(loaded.DependencyLinks, link)
+		}
+	}
+	loaded.Dependents = slices.Clone(r.taskDependents[task.ID])
+	if loaded.Dependents == nil {
+		loaded.Dependents = make([]uuid.UUID, 0)
+	}
+	return loaded
+}
+
+// loadAll loads the existing tasks among ids sorted by compare. The caller
+// must hold the lock.
+func (r *simpleMemoryRepository) loadAll(ids []uuid.UUID, compare func(a, b *types.Task) int) []*types.Task {
+	tasks := make([]*types.Task, 0, len(ids))
+	for _, id := range ids {
+		if task, exists := r.tasks[id]; exists {
+			tasks = append(tasks, r.load(task))
+		}
+	}
+	slices.SortFunc(tasks, compare)
+	return tasks
+}
+
+// storedTask returns the copy of a task the repository keeps. Dependency
+// fields are derived from the dependency edges on load.
+func storedTask(task *types.Task) *types.Task {
+	stored := copyTask(task)
+	stored.CreatedAt = stored.CreatedAt.UTC()
+	stored.UpdatedAt = stored.UpdatedAt.UTC()
+	stored.Dependencies = nil
+	stored.Dependents = nil
+	stored.DependencyLinks = nil
+	return stored
+}
+
+// copyTask returns a deep copy of a task, so callers and the repository never
+// share mutable state
+func copyTask(task *types.Task) *types.Task {
+	copied := *task
+	copied.ParentID = copyPointer(task.ParentID)
+	copied.Estimate = copyPointer(task.Estimate)
+	copied.AssignedAgent = copyPointer(task.AssignedAgent)
+	copied.CompletedAt = copyPointer(task.CompletedAt)
+	copied.DueDate = copyPointer(task.DueDate)
+	copied.Dependencies = slices.Clone(task.Dependencies)
+	copied.Dependents = slices.Clone(task.Dependents)
+	copied.Tags = slices.Clone(task.Tags)
+	copied.EffortLog = slices.Clone(task.EffortLog)
+	copied.DependencyLinks = slices.Clone(task.DependencyLinks)
+	copied.AcceptanceCriteria = slices.Clone(task.AcceptanceCriteria)
+	for i := range copied.AcceptanceCriteria {
+		copied.AcceptanceCriteria[i].VerifiedAt = copyPointer(task.AcceptanceCriteria[i].VerifiedAt)
+	}
+	if task.Review != nil {
+		review := *task.Review
+		review.ReviewedAt = copyPointer(task.Review.ReviewedAt)
+		copied.Review = &review
+	}
+	copied.Blocker = copyPointer(task.Blocker)
+	if task.Check != nil {
+		check := *task.Check
+		check.Runs = slices.Clone(task.Check.Runs)
+		copied.Check = &check
+	}
+	copied.Handoffs = slices.Clone(task.Handoffs)
+	for i := range copied.Handoffs {
+		copied.Handoffs[i].FromAgent = copyPointer(task.Handoffs[i].FromAgent)
+		copied.Handoffs[i].ToAgent = copyPointer(task.Handoffs[i].ToAgent)
+	}
+	return &copied
+}
+
+// copyPointer returns a pointer to a copy of *p, or nil if p is nil
+func copyPointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	copied := *p
+	return &copied
+}
+
+// normalizeCompletedAt stores the completion time in UTC like all timestamps
+func normalizeCompletedAt(task *types.Task) {
+	if task.CompletedAt != nil {
+		completedAt := task.CompletedAt.UTC()
+		task.CompletedAt = &completedAt
+	}
+}
@@ -0,0 +1,238 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedClock struct{ now time.Time }
+
+func (c *fixedClock) Now() time.Time { return c.now }
+
+func newTestProject(t *testing.T, repo types.Repository) *types.Project {
+	t.Helper()
+	project := &types.Project{Title: "Project", State: types.ProjectStateActive}
+	require.NoError(t, repo.CreateProject(context.Background(), project))
+	return project
+}
+
+func newTestTask(t *testing.T, repo types.Repository, projectID uuid.UUID, parentID *uuid.UUID, title string) *types.Task {
+	t.Helper()
+	task := &types.Task{ProjectID: projectID, ParentID: parentID, Title: title, State: types.TaskStatePending}
+	require.NoError(t, repo.CreateTask(context.Background(), task))
+	return task
+}
+
+func TestMemoryRepositoryParity(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("missing entities are typed not found errors", func(t *testing.T) {
+		repo := NewMemoryRepository()
+		project := newTestProject(t, repo)
+		root := newTestTask(t, repo, project.ID, nil, "Root")
+
+		_, err := repo.GetTask(ctx, uuid.New())
+		assert.True(t, sqlite.IsNotFoundError(err))
+		_, err = repo.GetProject(ctx, uuid.New())
+		assert.True(t, sqlite.IsNotFoundError(err))
+		_, err = repo.GetParentTask(ctx, root.ID)
+		assert.True(t, sqlite.IsNotFoundError(err))
+		_, err = repo.GetProjectProgress(ctx, uuid.New())
+		assert.True(t, sqlite.IsNotFoundError(err))
+		_, err = repo.RemoveTaskDependency(ctx, root.ID, uuid.New())
+		assert.True(t, sqlite.IsNotFoundError(err))
+
+		err = repo.CreateTask(ctx, &types.Task{ProjectID: uuid.New(), Title: "Orphan"})
+		assert.True(t, sqlite.IsNotFoundError(err))
+		missingParent := uuid.New()
+		err = repo.CreateTask(ctx, &types.Task{ProjectID: project.ID, ParentID: &missingParent, Title: "Orphan"})
+		assert.True(t, sqlite.IsNotFoundError(err))
+	})
+
+	t.Run("constraints are enforced", func(t *testing.T) {
+		repo := NewMemoryRepository()
+		project := newTestProject(t, repo)
+		other := newTestProject(t, repo)
+		parent := newTestTask(t, repo, project.ID, nil, "Parent")
+		child := newTestTask(t, repo, project.ID, &parent.ID, "Child")
+		foreign := newTestTask(t, repo, other.ID, nil, "Foreign")
+
+		assert.Equal(t, 1, child.Depth)
+		assert.True(t, sqlite.IsConstraintViolationError(repo.CreateProject(ctx, &types.Project{ID: project.ID})))
+		assert.True(t, sqlite.IsConstraintViolationError(repo.DeleteTask(ctx, parent.ID)))
+		assert.True(t, sqlite.IsConstraintViolationError(
+			repo.CreateTask(ctx, &types.Task{ProjectID: other.ID, ParentID: &parent.ID, Title: "Cross"})))
+
+		_, err := repo.AddTaskDependency(ctx, child.ID, foreign.ID)
+		assert.True(t, sqlite.IsConstraintViolationError(err))
+		_, err = repo.AddTaskDependency(ctx, child.ID, parent.ID)
+		require.NoError(t, err)
+		_, err = repo.AddTaskDependency(ctx, child.ID, parent.ID)
+		assert.True(t, sqlite.IsConstraintViolationError(err))
+	})
+
+	t.Run("circular dependencies are rejected", func(t *testing.T) {
+		repo := NewMemoryRepository()
+		project := newTestProject(t, repo)
+		a := newTestTask(t, repo, project.ID, nil, "A")
+		b := newTestTask(t, repo, project.ID, nil, "B")
+		c := newTestTask(t, repo, project.ID, nil, "C")
+
+		_, err := repo.AddTaskDependency(ctx, a.ID, b.ID)
+		require.NoError(t, err)
+		_, err = repo.AddTaskDependency(ctx, b.ID, c.ID)
+		require.NoError(t, err)
+
+		_, err = repo.AddTaskDependency(ctx, c.ID, a.ID)
+		assert.True(t, sqlite.IsCircularDependencyError(err))
+		_, err = repo.AddTaskDependency(ctx, a.ID, a.ID)
+		assert.True(t, sqlite.IsCircularDependencyError(err))
+
+		loaded, err := repo.GetTask(ctx, b.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{c.ID}, loaded.Dependencies)
+		assert.Equal(t, []uuid.UUID{a.ID}, loaded.Dependents)
+	})
+
+	t.Run("deleting a task removes its dependencies", func(t *testing.T) {
+		repo := NewMemoryRepository()
+		project := newTestProject(t, repo)
+		a := newTestTask(t, repo, project.ID, nil, "A")
+		b := newTestTask(t, repo, project.ID, nil, "B")
+		_, err := repo.AddTaskDependency(ctx, a.ID, b.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.DeleteTask(ctx, b.ID))
+		loaded, err := repo.GetTask(ctx, a.ID)
+		require.NoError(t, err)
+		assert.Empty(t, loaded.Dependencies)
+	})
+
+	t.Run("deleting a project cascades and clears the selection", func(t *testing.T) {
+		repo := NewMemoryRepository()
+		project := newTestProject(t, repo)
+		parent := newTestTask(t, repo, project.ID, nil, "Parent")
+		newTestTask(t, repo, project.ID, &parent.ID, "Child")
+		require.NoError(t, repo.SetSelectedProject(ctx, project.ID, "tester"))
+		require.NoError(t, repo.SaveProjectLock(ctx, &types.ProjectLock{ProjectID: project.ID, Owner: "tester"}))
+
+		require.NoError(t, repo.DeleteProject(ctx, project.ID))
+
+		_, err := repo.GetTask(ctx, parent.ID)
+		assert.True(t, sqlite.IsNotFoundError(err))
+		tasks, err := repo.ListTasks(ctx, types.TaskFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+		selected, err := repo.GetSelectedProject(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, selected)
+		lock, err := repo.GetProjectLock(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Nil(t, lock)
+		assert.Error(t, repo.SetSelectedProject(ctx, project.ID, "tester"))
+	})
+
+	t.Run("stored tasks are not shared with callers", func(t *testing.T) {
+		repo := NewMemoryRepository()
+		project := newTestProject(t, repo)
+		task := newTestTask(t, repo, project.ID, nil, "Original")
+
+		task.Title = "Changed by the caller"
+		loaded, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Original", loaded.Title)
+
+		loaded.Tags = append(loaded.Tags, "mutated")
+		reloaded, err := repo.GetTask(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Empty(t, reloaded.Tags)
+	})
+
+	t.Run("updates keep the hierarchy and track completion and metrics", func(t *testing.T) {
+		repo := NewMemoryRepository()
+		project := newTestProject(t, repo)
+		parent := newTestTask(t, repo, project.ID, nil, "Parent")
+		child := newTestTask(t, repo, project.ID, &parent.ID, "Child")
+
+		update := *child
+		update.ParentID = nil
+		update.Depth = 0
+		update.State = types.TaskStateCompleted
+		require.NoError(t, repo.UpdateTask(ctx, &update))
+
+		loaded, err := repo.GetTask(ctx, child.ID)
+		require.NoError(t, err)
+		assert.Equal(t, &parent.ID, loaded.ParentID)
+		assert.Equal(t, 1, loaded.Depth)
+		assert.NotNil(t, loaded.CompletedAt)
+
+		stored, err := repo.GetProject(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stored.TotalTasks)
+		assert.Equal(t, 1, stored.CompletedTasks)
+		assert.Equal(t, 50.0, stored.Progress)
+	})
+}
+
+func TestMemoryRepositoryClock(t *testing.T) {
+	ctx := context.Background()
+	clock := &fixedClock{now: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)}
+	repo := NewMemoryRepository(WithClock(clock))
+
+	project := newTestProject(t, repo)
+	task := newTestTask(t, repo, project.ID, nil, "Task")
+	assert.Equal(t, clock.now, project.CreatedAt)
+	assert.Equal(t, clock.now, task.CreatedAt)
+
+	clock.now = clock.now.Add(time.Hour)
+	require.NoError(t, repo.UpdateTask(ctx, task))
+	assert.Equal(t, clock.now, task.UpdatedAt)
+
+	events, err := repo.(types.ChangeFeed).ListChangeEvents(ctx, types.ChangeEventFilter{})
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	assert.Equal(t, clock.now, events[len(events)-1].CreatedAt)
+
+	// The manager takes its time from a repository with a clock
+	repoClock, ok := repo.(types.Clock)
+	require.True(t, ok)
+	assert.Equal(t, clock.now, repoClock.Now())
+}
+
+func TestMemoryRepositoryTransactions(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	project := newTestProject(t, repo)
+	parent := newTestTask(t, repo, project.ID, nil, "Parent")
+	task := newTestTask(t, repo, project.ID, nil, "Task")
+	events, err := repo.(types.ChangeFeed).ListChangeEvents(ctx, types.ChangeEventFilter{})
+	require.NoError(t, err)
+
+	failure := errors.New("step failed")
+	err = repo.(types.Transactor).InTransaction(ctx, func(ctx context.Context) error {
+		newTestTask(t, repo, project.ID, &parent.ID, "Rolled back")
+		if err := repo.MoveTask(ctx, task.ID, &parent.ID); err != nil {
+			return err
+		}
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+
+	children, err := repo.GetTasksByParent(ctx, parent.ID)
+	require.NoError(t, err)
+	assert.Empty(t, children, "the changes of a failed transaction must be rolled back")
+	after, err := repo.(types.ChangeFeed).ListChangeEvents(ctx, types.ChangeEventFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, events, after)
+	stored, err := repo.GetProject(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.TotalTasks)
+}
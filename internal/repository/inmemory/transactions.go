@@ -0,0 +1,108 @@
+package inmemory
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// memoryState is a copy of the contents of the repository
+type memoryState struct {
+	projects          map[uuid.UUID]*types.Project
+	tasks             map[uuid.UUID]*types.Task
+	tasksByProject    map[uuid.UUID][]uuid.UUID
+	tasksByParent     map[uuid.UUID][]uuid.UUID
+	taskDependencies  map[uuid.UUID][]types.DependencyLink
+	taskDependents    map[uuid.UUID][]uuid.UUID
+	selectedProjectID *uuid.UUID
+	sessionProjects   map[string]uuid.UUID
+	events            []*types.ChangeEvent
+	lastSeq           int64
+	locks             map[uuid.UUID]types.ProjectLock
+	transitions       map[uuid.UUID]types.ScheduledTransition
+	users             map[string]*types.User
+}
+
+type inTransactionContextKey struct{}
+
+// InTransaction implements types.Transactor: if fn fails, the repository is
+// restored to its contents before the call, change events included. The
+// repository has no isolation, so writes other goroutines make while fn runs
+// are rolled back with it. Calls with a context that already runs in a
+// transaction join it.
+func (r *simpleMemoryRepository) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(inTransactionContextKey{}) == r {
+		return fn(ctx)
+	}
+
+	r.mu.RLock()
+	saved := r.snapshot()
+	r.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, inTransactionContextKey{}, r)); err != nil {
+		r.mu.Lock()
+		r.restore(saved)
+		r.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// snapshot copies the contents of the repository. The caller holds the lock.
+func (r *simpleMemoryRepository) snapshot() *memoryState {
+	state := &memoryState{
+		projects:          make(map[uuid.UUID]*types.Project, len(r.projects)),
+		tasks:             make(map[uuid.UUID]*types.Task, len(r.tasks)),
+		tasksByProject:    cloneSliceMap(r.tasksByProject),
+		tasksByParent:     cloneSliceMap(r.tasksByParent),
+		taskDependencies:  cloneSliceMap(r.taskDependencies),
+		taskDependents:    cloneSliceMap(r.taskDependents),
+		selectedProjectID: copyPointer(r.selectedProjectID),
+		sessionProjects:   maps.Clone(r.sessionProjects),
+		events:            slices.Clone(r.events),
+		lastSeq:           r.lastSeq,
+		locks:             maps.Clone(r.locks),
+		transitions:       maps.Clone(r.transitions),
+		users:             make(map[string]*types.User, len(r.users)),
+	}
+	for id, project := range r.projects {
+		copied := *project
+		state.projects[id] = &copied
+	}
+	for id, task := range r.tasks {
+		state.tasks[id] = copyTask(task)
+	}
+	for name, user := range r.users {
+		state.users[name] = copyUser(user)
+	}
+	return state
+}
+
+// restore replaces the contents of the repository with a snapshot. The
+// caller holds the lock.
+func (r *simpleMemoryRepository) restore(state *memoryState) {
+	r.projects = state.projects
+	r.tasks = state.tasks
+	r.tasksByProject = state.tasksByProject
+	r.tasksByParent = state.tasksByParent
+	r.taskDependencies = state.taskDependencies
+	r.taskDependents = state.taskDependents
+	r.selectedProjectID = state.selectedProjectID
+	r.sessionProjects = state.sessionProjects
+	r.events = state.events
+	r.lastSeq = state.lastSeq
+	r.locks = state.locks
+	r.transitions = state.transitions
+	r.users = state.users
+}
+
+func cloneSliceMap[K comparable, V any](m map[K][]V) map[K][]V {
+	cloned := make(map[K][]V, len(m))
+	for key, values := range m {
+		cloned[key] = slices.Clone(values)
+	}
+	return cloned
+}
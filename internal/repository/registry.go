@@ -0,0 +1,131 @@
+// Package repository provides the storage driver registry.
+//
+// Storage backends register an OpenFunc under a driver name from an init
+// function, similar to database/sql drivers. The application opens its
+// repository from a DSN-like string such as:
+//
+//	sqlite:///home/user/.knot/knot.db
+//	sqlite://                 (default database location)
+//	inmemory://
+//	/path/to/knot.db          (plain paths use the sqlite driver)
+//
+// A new backend only has to be imported for its side effects to become
+// available, without changes to the application setup:
+//
+//	import _ "example.com/knot-rest-backend"
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"go.uber.org/zap"
+)
+
+// DefaultDriver is used when the DSN does not name a driver
+const DefaultDriver = "sqlite"
+
+// DSNEnvVar is the environment variable holding the storage DSN
+const DSNEnvVar = "KNOT_DATABASE"
+
+// Options are the driver independent settings passed to every driver
+type Options struct {
+	Logger      *zap.Logger
+	AutoMigrate bool
+	// Clock sets the time of drivers that support it, the system clock if nil
+	Clock types.Clock
+	// Observer is told about every statement of drivers that run SQL, if set
+	Observer StatementObserver
+}
+
+// StatementObserver records the statements a driver runs against its
+// database, such as metrics.Registry
+type StatementObserver interface {
+	ObserveStatement(statement string, duration time.Duration, err error)
+}
+
+// OpenFunc opens a repository. location is the part of the DSN after the
+// driver prefix and may be empty to request the driver's default location.
+type OpenFunc func(location string, opts Options) (types.Repository, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = make(map[string]OpenFunc)
+)
+
+// Register makes a storage driver available under the given name.
+// It panics if open is nil or the name is already registered.
+func Register(name string, open OpenFunc) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+
+	if open == nil {
+		panic("repository: Register open func is nil")
+	}
+	if _, dup := drivers[name]; dup {
+		panic("repository: Register called twice for driver " + name)
+	}
+	drivers[name] = open
+}
+
+// Drivers returns the sorted names of the registered drivers
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseDSN splits a DSN into driver name and location. A DSN without a
+// "driver://" prefix is either a bare driver name or a sqlite database path.
+func ParseDSN(dsn string) (driver, location string) {
+	dsn = strings.TrimSpace(dsn)
+	if dsn == "" {
+		return DefaultDriver, ""
+	}
+
+	if name, rest, ok := strings.Cut(dsn, "://"); ok {
+		return name, rest
+	}
+
+	driversMu.RLock()
+	_, registered := drivers[strings.TrimSuffix(dsn, ":")]
+	driversMu.RUnlock()
+	if registered {
+		return strings.TrimSuffix(dsn, ":"), ""
+	}
+
+	return DefaultDriver, dsn
+}
+
+// Open opens the repository described by dsn using the registered driver
+func Open(dsn string, opts Options) (types.Repository, error) {
+	driver, location := ParseDSN(dsn)
+
+	driversMu.RLock()
+	open, ok := drivers[driver]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown storage driver %q (registered drivers: %s)",
+			driver, strings.Join(Drivers(), ", "))
+	}
+
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+
+	repo, err := open(location, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s repository: %w", driver, err)
+	}
+	return repo, nil
+}
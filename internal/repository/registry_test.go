@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterAndOpen(t *testing.T) {
+	var gotLocation string
+	var gotOpts Options
+	Register("test-open", func(location string, opts Options) (types.Repository, error) {
+		gotLocation, gotOpts = location, opts
+		return nil, nil
+	})
+	Register("test-fail", func(string, Options) (types.Repository, error) {
+		return nil, fmt.Errorf("backend unavailable")
+	})
+
+	assert.Contains(t, Drivers(), "test-open")
+
+	_, err := Open("test-open://server:8080/knot", Options{AutoMigrate: true})
+	require.NoError(t, err)
+	assert.Equal(t, "server:8080/knot", gotLocation)
+	assert.True(t, gotOpts.AutoMigrate)
+	assert.NotNil(t, gotOpts.Logger, "a nop logger should be supplied")
+
+	_, err = Open("test-fail://", Options{})
+	assert.ErrorContains(t, err, "failed to open test-fail repository: backend unavailable")
+
+	_, err = Open("postgres://localhost/knot", Options{})
+	assert.ErrorContains(t, err, `unknown storage driver "postgres"`)
+
+	assert.Panics(t, func() {
+		Register("test-open", func(string, Options) (types.Repository, error) { return nil, nil })
+	})
+	assert.Panics(t, func() { Register("test-nil", nil) })
+}
+
+func TestParseDSN(t *testing.T) {
+	Register("test-bare", func(string, Options) (types.Repository, error) { return nil, nil })
+
+	tests := []struct {
+		dsn      string
+		driver   string
+		location string
+	}{
+		{dsn: "", driver: DefaultDriver, location: ""},
+		{dsn: "  ", driver: DefaultDriver, location: ""},
+		{dsn: "sqlite:///tmp/knot.db", driver: "sqlite", location: "/tmp/knot.db"},
+		{dsn: "sqlite://", driver: "sqlite", location: ""},
+		{dsn: "test-bare", driver: "test-bare", location: ""},
+		{dsn: "test-bare:", driver: "test-bare", location: ""},
+		{dsn: "/var/lib/knot/knot.db", driver: DefaultDriver, location: "/var/lib/knot/knot.db"},
+		{dsn: "rest://knot.example.com/api", driver: "rest", location: "knot.example.com/api"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsn, func(t *testing.T) {
+			driver, location := ParseDSN(tt.dsn)
+			assert.Equal(t, tt.driver, driver)
+			assert.Equal(t, tt.location, location)
+		})
+	}
+}
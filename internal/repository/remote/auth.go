@@ -0,0 +1,362 @@
+package remote
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// Auth configures who may use a knot server. A server without an admin
+// token and without user authentication is open to every client.
+type Auth struct {
+	// AdminToken grants the admin role on all projects, if set
+	AdminToken string
+	// Users returns the user owning a token, or nil if no user does. User
+	// tokens are rejected if Users is nil.
+	Users func(ctx context.Context, token string) (*types.User, error)
+}
+
+func (a *Auth) open() bool {
+	return a == nil || (a.AdminToken == "" && a.Users == nil)
+}
+
+// Caller is the authenticated client of an operation; a nil user has the
+// admin role on all projects
+type Caller struct {
+	user *types.User
+}
+
+// Name describes the caller in messages
+func (c *Caller) Name() string {
+	if c.user == nil {
+		return "admin token"
+	}
+	return "user " + c.user.Name
+}
+
+// Actor returns the name changes by the caller are recorded under, or
+// fallback for the admin token
+func (c *Caller) Actor(fallback string) string {
+	if c.user == nil {
+		return fallback
+	}
+	return c.user.Name
+}
+
+func (c *Caller) roleFor(projectID uuid.UUID) types.Role {
+	if c.user == nil {
+		return types.RoleAdmin
+	}
+	return c.user.RoleFor(projectID)
+}
+
+func (c *Caller) globalRole() types.Role {
+	if c.user == nil {
+		return types.RoleAdmin
+	}
+	return c.user.Role
+}
+
+// Require checks that the caller has at least role on the project, or on all
+// projects if projectID is nil, and returns a PermissionError naming the
+// operation otherwise
+func (c *Caller) Require(projectID *uuid.UUID, role types.Role, operation string) error {
+	if projectID == nil {
+		if !c.globalRole().Allows(role) {
+			return &PermissionError{Message: fmt.Sprintf("%s needs the %s role on all projects for %s", c.Name(), role, operation)}
+		}
+		return nil
+	}
+	if have := c.roleFor(*projectID); !have.Allows(role) {
+		return &PermissionError{Message: fmt.Sprintf("%s has %s on project %s, %s needs the %s role",
+			c.Name(), describeRole(have), *projectID, operation, role)}
+	}
+	return nil
+}
+
+// CanView reports whether the caller may read the project
+func (c *Caller) CanView(projectID uuid.UUID) bool {
+	return c.roleFor(projectID).Allows(types.RoleViewer)
+}
+
+// PermissionError reports an operation the caller's role does not allow, or a
+// token the server rejected
+type PermissionError struct {
+	Message string
+}
+
+func (e *PermissionError) Error() string {
+	return "permission denied: " + e.Message
+}
+
+// authenticate returns the caller of a request, or nil if the token is invalid
+func (a *Auth) authenticate(r *http.Request) (*Caller, error) {
+	return a.Authenticate(r.Context(), r.Header.Get("Authorization"))
+}
+
+// Authenticate returns the caller owning the bearer token of an Authorization
+// header value, or nil if the token is invalid. Servers other than the HTTP
+// handler, such as the gRPC server, use it with Caller.Require.
+func (a *Auth) Authenticate(ctx context.Context, authorization string) (*Caller, error) {
+	if a.open() {
+		return &Caller{}, nil
+	}
+
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || token == "" {
+		return nil, nil
+	}
+	if a.AdminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.AdminToken)) == 1 {
+		return &Caller{}, nil
+	}
+	if a.Users == nil {
+		return nil, nil
+	}
+
+	user, err := a.Users(ctx, token)
+	if err != nil || user == nil {
+		return nil, err
+	}
+	return &Caller{user: user}, nil
+}
+
+// RequireRole wraps next so that it only serves callers with at least role on
+// all projects, e.g. the metrics endpoint of the server, which counts the
+// tasks of every project
+func (a *Auth) RequireRole(next http.Handler, role types.Role, operation string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller, err := a.authenticate(r)
+		if err != nil {
+			http.Error(w, "failed to authenticate request", http.StatusInternalServerError)
+			return
+		}
+		if caller == nil {
+			http.Error(w, "invalid or missing token", http.StatusUnauthorized)
+			return
+		}
+		if err := caller.Require(nil, role, operation); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// access describes the role an operation requires and the project it acts on
+type access struct {
+	role types.Role
+	// project returns the project the operation acts on. Operations without
+	// one require the role on all projects, unless their results are filtered.
+	project func(ctx context.Context, repo types.Repository, p *params) *uuid.UUID
+	// linked returns the project of a second task the operation ties the first
+	// one to, such as a new parent or a prerequisite, which requires the role
+	// as well
+	linked func(ctx context.Context, repo types.Repository, p *params) *uuid.UUID
+	// filtered operations return only the results the caller may view
+	filtered bool
+}
+
+func byID(_ context.Context, _ types.Repository, p *params) *uuid.UUID {
+	return p.ID
+}
+
+func byProjectID(_ context.Context, _ types.Repository, p *params) *uuid.UUID {
+	return p.ProjectID
+}
+
+// byStoredTask resolves the project of the task an id refers to. A missing
+// task is left to the operation to report.
+func byStoredTask(id func(p *params) *uuid.UUID) func(context.Context, types.Repository, *params) *uuid.UUID {
+	return func(ctx context.Context, repo types.Repository, p *params) *uuid.UUID {
+		taskID := id(p)
+		if taskID == nil {
+			return nil
+		}
+		task, err := repo.GetTask(ctx, *taskID)
+		if err != nil {
+			return nil
+		}
+		return &task.ProjectID
+	}
+}
+
+var (
+	byTaskID      = byStoredTask(func(p *params) *uuid.UUID { return p.ID })
+	byParentID    = byStoredTask(func(p *params) *uuid.UUID { return p.ParentID })
+	byDependsOnID = byStoredTask(func(p *params) *uuid.UUID { return p.DependsOnID })
+	byLinkedTask  = byStoredTask(func(p *params) *uuid.UUID {
+		if p.Link == nil {
+			return nil
+		}
+		return &p.Link.DependsOnID
+	})
+	byTask = byStoredTask(func(p *params) *uuid.UUID {
+		if p.Task == nil {
+			return nil
+		}
+		return &p.Task.ID
+	})
+	byTransitionTask = byStoredTask(func(p *params) *uuid.UUID {
+		if p.Transition == nil {
+			return nil
+		}
+		return &p.Transition.TaskID
+	})
+)
+
+// byStoredTransition resolves the project of the scheduled transition the id
+// refers to. A missing transition is left to the operation to report.
+func byStoredTransition(ctx context.Context, repo types.Repository, p *params) *uuid.UUID {
+	if p.ID == nil {
+		return nil
+	}
+	transitions, err := repo.ListScheduledTransitions(ctx, nil)
+	if err != nil {
+		return nil
+	}
+	for _, transition := range transitions {
+		if transition.ID == *p.ID {
+			return &transition.ProjectID
+		}
+	}
+	return nil
+}
+
+// accessRules lists the required role of every operation. Viewers read,
+// editors additionally create and change, admins additionally delete.
+var accessRules = map[string]access{
+	"CreateProject": {role: types.RoleEditor},
+	"GetProject":    {role: types.RoleViewer, project: byID},
+	"UpdateProject": {role: types.RoleEditor, project: func(_ context.Context, _ types.Repository, p *params) *uuid.UUID {
+		if p.Project == nil {
+			return nil
+		}
+		return &p.Project.ID
+	}},
+	"DeleteProject": {role: types.RoleAdmin, project: byID},
+	"ListProjects":  {role: types.RoleViewer, filtered: true},
+	"CreateTask": {role: types.RoleEditor, project: func(_ context.Context, _ types.Repository, p *params) *uuid.UUID {
+		if p.Task == nil {
+			return nil
+		}
+		return &p.Task.ProjectID
+	}},
+	"GetTask":                  {role: types.RoleViewer, project: byTaskID},
+	"GetTasksWithDependencies": {role: types.RoleViewer, filtered: true},
+	"UpdateTask":               {role: types.RoleEditor, project: byTask},
+	"DeleteTask":               {role: types.RoleAdmin, project: byTaskID},
+	"ListTasks":                {role: types.RoleViewer, filtered: true},
+	"ListTasksPage":            {role: types.RoleViewer, filtered: true},
+	"GetTasksByProject":        {role: types.RoleViewer, project: byProjectID},
+	"GetTasksByParent":         {role: types.RoleViewer, project: byParentID},
+	"GetDescendants":           {role: types.RoleViewer, project: byTaskID},
+	"GetRootTasks":             {role: types.RoleViewer, project: byProjectID},
+	"GetParentTask":            {role: types.RoleViewer, project: byTaskID},
+	"DeleteTaskSubtree":        {role: types.RoleAdmin, project: byTaskID},
+	"MoveTask":                 {role: types.RoleEditor, project: byTaskID, linked: byParentID},
+	"AddTaskDependency":        {role: types.RoleEditor, project: byTaskID, linked: byDependsOnID},
+	"SetDependencyLink":        {role: types.RoleEditor, project: byTaskID, linked: byLinkedTask},
+	"RemoveTaskDependency":     {role: types.RoleEditor, project: byTaskID},
+	"GetTaskDependencies":      {role: types.RoleViewer, project: byTaskID},
+	"GetDependentTasks":        {role: types.RoleViewer, project: byTaskID},
+	"GetProjectProgress":       {role: types.RoleViewer, project: byProjectID},
+	"GetTaskCountByDepth":      {role: types.RoleViewer, project: byProjectID},
+	"GetSubtreeCounts":         {role: types.RoleViewer, project: byProjectID},
+	"GetChildCounts":           {role: types.RoleViewer, project: byProjectID},
+	"GetProjectLock":           {role: types.RoleViewer, project: byProjectID},
+	"SaveProjectLock": {role: types.RoleEditor, project: func(_ context.Context, _ types.Repository, p *params) *uuid.UUID {
+		if p.Lock == nil {
+			return nil
+		}
+		return &p.Lock.ProjectID
+	}},
+	"DeleteProjectLock":         {role: types.RoleEditor, project: byProjectID},
+	"SaveScheduledTransition":   {role: types.RoleEditor, project: byTransitionTask},
+	"ListScheduledTransitions":  {role: types.RoleViewer, filtered: true},
+	"DeleteScheduledTransition": {role: types.RoleEditor, project: byStoredTransition},
+	"ListChangeEvents":          {role: types.RoleViewer, filtered: true},
+}
+
+// authorize checks that caller may run an operation
+func (c *Caller) authorize(ctx context.Context, repo types.Repository, name string, p *params) error {
+	if c.user == nil {
+		return nil
+	}
+	rule, ok := accessRules[name]
+	if !ok {
+		return &PermissionError{Message: fmt.Sprintf("operation %s is not available", name)}
+	}
+	if rule.filtered {
+		return nil
+	}
+
+	if rule.project == nil {
+		return c.Require(nil, rule.role, name)
+	}
+
+	// Missing parameters and tasks are reported by the operation
+	resolvers := []func(context.Context, types.Repository, *params) *uuid.UUID{rule.project, rule.linked}
+	for _, resolve := range resolvers {
+		if resolve == nil {
+			continue
+		}
+		projectID := resolve(ctx, repo, p)
+		if projectID == nil {
+			continue
+		}
+		if err := c.Require(projectID, rule.role, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func describeRole(role types.Role) string {
+	if role == types.RoleNone {
+		return "no role"
+	}
+	return "the " + string(role) + " role"
+}
+
+// filter drops the results of list operations the caller may not view
+func (c *Caller) filter(result any) any {
+	if c.user == nil {
+		return result
+	}
+	canView := c.CanView
+
+	switch items := result.(type) {
+	case []*types.Project:
+		return keep(items, func(project *types.Project) bool { return canView(project.ID) })
+	case []*types.Task:
+		return keep(items, func(task *types.Task) bool { return canView(task.ProjectID) })
+	case []*types.ChangeEvent:
+		return keep(items, func(event *types.ChangeEvent) bool { return canView(event.ProjectID) })
+	case []*types.ScheduledTransition:
+		return keep(items, func(transition *types.ScheduledTransition) bool { return canView(transition.ProjectID) })
+	case *types.TaskPage:
+		// The cursor stays valid, so a page may come out short but the next
+		// one continues where it ended
+		return &types.TaskPage{
+			Tasks:      keep(items.Tasks, func(task *types.Task) bool { return canView(task.ProjectID) }),
+			NextCursor: items.NextCursor,
+		}
+	default:
+		return result
+	}
+}
+
+func keep[T any](items []T, ok func(T) bool) []T {
+	kept := make([]T, 0, len(items))
+	for _, item := range items {
+		if ok(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
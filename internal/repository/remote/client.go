@@ -0,0 +1,377 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Client is a repository backed by a knot server. The selected project is
+// kept on the client, so team members sharing a server select projects
+// independently.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	selection  *selectionStore
+	logger     *zap.Logger
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithToken sets the bearer token sent with every request
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient replaces the default HTTP client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithLogger sets the logger of the client
+func WithLogger(logger *zap.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithSelectionFile stores the selected project in path instead of the
+// .knot directory of the working directory
+func WithSelectionFile(path string) Option {
+	return func(c *Client) {
+		c.selection.path = path
+	}
+}
+
+// NewClient creates a repository talking to the knot server at baseURL.
+// Requests are bound by the context of each call.
+func NewClient(baseURL string, opts ...Option) (*Client, error) {
+	baseURL = strings.TrimRight(baseURL, "/")
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		return nil, fmt.Errorf("invalid knot server URL %q: must start with http:// or https://", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    baseURL,
+		httpClient: http.DefaultClient,
+		selection:  &selectionStore{server: baseURL},
+		logger:     zap.NewNop(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Ensure Client implements the repository and its change feed
+var (
+	_ types.Repository = (*Client)(nil)
+	_ types.ChangeFeed = (*Client)(nil)
+)
+
+// call runs a repository operation on the server and decodes its result
+// into result, which may be nil for operations without one
+func (c *Client) call(ctx context.Context, operation string, p *params, result any) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", operation, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+RepositoryPath+operation, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", operation, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	c.logger.Debug("Calling knot server", zap.String("operation", operation))
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("connection to knot server at %s failed: %w", c.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return &PermissionError{Message: fmt.Sprintf("knot server at %s rejected the token, set %s to the token of the server", c.baseURL, TokenEnvVar)}
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &PermissionError{Message: strings.TrimPrefix(strings.TrimSpace(string(msg)), "permission denied: ")}
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("knot server at %s rejected %s: %s (HTTP %d)",
+			c.baseURL, operation, strings.TrimSpace(string(msg)), resp.StatusCode)
+	}
+
+	var envelope response
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("invalid %s response from knot server: %w", operation, err)
+	}
+	if envelope.Error != nil {
+		return envelope.Error.err()
+	}
+	if result == nil || len(envelope.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(envelope.Result, result); err != nil {
+		return fmt.Errorf("invalid %s result from knot server: %w", operation, err)
+	}
+	return nil
+}
+
+func (c *Client) CreateProject(ctx context.Context, project *types.Project) error {
+	return c.call(ctx, "CreateProject", &params{Project: project}, project)
+}
+
+func (c *Client) GetProject(ctx context.Context, id uuid.UUID) (*types.Project, error) {
+	var project *types.Project
+	err := c.call(ctx, "GetProject", &params{ID: &id}, &project)
+	return project, err
+}
+
+func (c *Client) UpdateProject(ctx context.Context, project *types.Project) error {
+	return c.call(ctx, "UpdateProject", &params{Project: project}, project)
+}
+
+func (c *Client) DeleteProject(ctx context.Context, id uuid.UUID) error {
+	return c.call(ctx, "DeleteProject", &params{ID: &id}, nil)
+}
+
+func (c *Client) ListProjects(ctx context.Context) ([]*types.Project, error) {
+	var projects []*types.Project
+	err := c.call(ctx, "ListProjects", &params{}, &projects)
+	return projects, err
+}
+
+func (c *Client) CreateTask(ctx context.Context, task *types.Task) error {
+	return c.call(ctx, "CreateTask", &params{Task: task}, task)
+}
+
+func (c *Client) GetTask(ctx context.Context, id uuid.UUID) (*types.Task, error) {
+	var task *types.Task
+	err := c.call(ctx, "GetTask", &params{ID: &id}, &task)
+	return task, err
+}
+
+func (c *Client) GetTasksWithDependencies(ctx context.Context, taskIDs []uuid.UUID) ([]*types.Task, error) {
+	var tasks []*types.Task
+	err := c.call(ctx, "GetTasksWithDependencies", &params{IDs: taskIDs}, &tasks)
+	return tasks, err
+}
+
+func (c *Client) UpdateTask(ctx context.Context, task *types.Task) error {
+	return c.call(ctx, "UpdateTask", &params{Task: task}, task)
+}
+
+func (c *Client) DeleteTask(ctx context.Context, id uuid.UUID) error {
+	return c.call(ctx, "DeleteTask", &params{ID: &id}, nil)
+}
+
+func (c *Client) ListTasks(ctx context.Context, filter types.TaskFilter) ([]*types.Task, error) {
+	var tasks []*types.Task
+	err := c.call(ctx, "ListTasks", &params{TaskFilter: &filter}, &tasks)
+	return tasks, err
+}
+
+func (c *Client) ListTasksPage(ctx context.Context, filter types.TaskFilter, page types.PageRequest) (*types.TaskPage, error) {
+	var result types.TaskPage
+	if err := c.call(ctx, "ListTasksPage", &params{TaskFilter: &filter, Page: &page}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) GetTasksByProject(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error) {
+	var tasks []*types.Task
+	err := c.call(ctx, "GetTasksByProject", &params{ProjectID: &projectID}, &tasks)
+	return tasks, err
+}
+
+func (c *Client) GetTasksByParent(ctx context.Context, parentID uuid.UUID) ([]*types.Task, error) {
+	var tasks []*types.Task
+	err := c.call(ctx, "GetTasksByParent", &params{ParentID: &parentID}, &tasks)
+	return tasks, err
+}
+
+func (c *Client) GetDescendants(ctx context.Context, taskID uuid.UUID, maxDepth int) ([]*types.Task, error) {
+	var tasks []*types.Task
+	err := c.call(ctx, "GetDescendants", &params{ID: &taskID, MaxDepth: maxDepth}, &tasks)
+	return tasks, err
+}
+
+func (c *Client) GetRootTasks(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error) {
+	var tasks []*types.Task
+	err := c.call(ctx, "GetRootTasks", &params{ProjectID: &projectID}, &tasks)
+	return tasks, err
+}
+
+func (c *Client) GetParentTask(ctx context.Context, taskID uuid.UUID) (*types.Task, error) {
+	var task *types.Task
+	err := c.call(ctx, "GetParentTask", &params{ID: &taskID}, &task)
+	return task, err
+}
+
+func (c *Client) DeleteTaskSubtree(ctx context.Context, taskID uuid.UUID) error {
+	return c.call(ctx, "DeleteTaskSubtree", &params{ID: &taskID}, nil)
+}
+
+func (c *Client) MoveTask(ctx context.Context, taskID uuid.UUID, parentID *uuid.UUID) error {
+	return c.call(ctx, "MoveTask", &params{ID: &taskID, ParentID: parentID}, nil)
+}
+
+func (c *Client) AddTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID) (*types.Task, error) {
+	var task *types.Task
+	err := c.call(ctx, "AddTaskDependency", &params{ID: &taskID, DependsOnID: &dependsOnTaskID}, &task)
+	return task, err
+}
+
+func (c *Client) SetDependencyLink(ctx context.Context, taskID uuid.UUID, link types.DependencyLink) (*types.Task, error) {
+	var task *types.Task
+	err := c.call(ctx, "SetDependencyLink", &params{ID: &taskID, Link: &link}, &task)
+	return task, err
+}
+
+func (c *Client) RemoveTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID) (*types.Task, error) {
+	var task *types.Task
+	err := c.call(ctx, "RemoveTaskDependency", &params{ID: &taskID, DependsOnID: &dependsOnTaskID}, &task)
+	return task, err
+}
+
+func (c *Client) GetTaskDependencies(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error) {
+	var tasks []*types.Task
+	err := c.call(ctx, "GetTaskDependencies", &params{ID: &taskID}, &tasks)
+	return tasks, err
+}
+
+func (c *Client) GetDependentTasks(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error) {
+	var tasks []*types.Task
+	err := c.call(ctx, "GetDependentTasks", &params{ID: &taskID}, &tasks)
+	return tasks, err
+}
+
+func (c *Client) GetProjectProgress(ctx context.Context, projectID uuid.UUID) (*types.ProjectProgress, error) {
+	var progress *types.ProjectProgress
+	err := c.call(ctx, "GetProjectProgress", &params{ProjectID: &projectID}, &progress)
+	return progress, err
+}
+
+func (c *Client) GetTaskCountByDepth(ctx context.Context, projectID uuid.UUID, maxDepth int) (map[int]int, error) {
+	var counts map[int]int
+	err := c.call(ctx, "GetTaskCountByDepth", &params{ProjectID: &projectID, MaxDepth: maxDepth}, &counts)
+	return counts, err
+}
+
+func (c *Client) GetSubtreeCounts(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]*types.SubtreeCounts, error) {
+	var counts map[uuid.UUID]*types.SubtreeCounts
+	err := c.call(ctx, "GetSubtreeCounts", &params{ProjectID: &projectID}, &counts)
+	return counts, err
+}
+
+func (c *Client) GetChildCounts(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]int, error) {
+	var counts map[uuid.UUID]int
+	err := c.call(ctx, "GetChildCounts", &params{ProjectID: &projectID}, &counts)
+	return counts, err
+}
+
+func (c *Client) GetProjectLock(ctx context.Context, projectID uuid.UUID) (*types.ProjectLock, error) {
+	var lock *types.ProjectLock
+	err := c.call(ctx, "GetProjectLock", &params{ProjectID: &projectID}, &lock)
+	return lock, err
+}
+
+func (c *Client) SaveProjectLock(ctx context.Context, lock *types.ProjectLock) error {
+	return c.call(ctx, "SaveProjectLock", &params{Lock: lock}, nil)
+}
+
+func (c *Client) DeleteProjectLock(ctx context.Context, projectID uuid.UUID) error {
+	return c.call(ctx, "DeleteProjectLock", &params{ProjectID: &projectID}, nil)
+}
+
+// ListChangeEvents reads the change feed of the server
+func (c *Client) ListChangeEvents(ctx context.Context, filter types.ChangeEventFilter) ([]*types.ChangeEvent, error) {
+	var events []*types.ChangeEvent
+	err := c.call(ctx, "ListChangeEvents", &params{EventFilter: &filter}, &events)
+	return events, err
+}
+
+// SaveScheduledTransition creates or replaces a scheduled transition on the server
+func (c *Client) SaveScheduledTransition(ctx context.Context, transition *types.ScheduledTransition) error {
+	return c.call(ctx, "SaveScheduledTransition", &params{Transition: transition}, nil)
+}
+
+// ListScheduledTransitions returns the scheduled transitions of a project, or of all projects if projectID is nil
+func (c *Client) ListScheduledTransitions(ctx context.Context, projectID *uuid.UUID) ([]*types.ScheduledTransition, error) {
+	var transitions []*types.ScheduledTransition
+	err := c.call(ctx, "ListScheduledTransitions", &params{ProjectID: projectID}, &transitions)
+	return transitions, err
+}
+
+// DeleteScheduledTransition removes a scheduled transition on the server
+func (c *Client) DeleteScheduledTransition(ctx context.Context, id uuid.UUID) error {
+	return c.call(ctx, "DeleteScheduledTransition", &params{ID: &id}, nil)
+}
+
+// GetSelectedProject returns the project selected on this client
+func (c *Client) GetSelectedProject(ctx context.Context) (*uuid.UUID, error) {
+	return c.selection.get("")
+}
+
+// SetSelectedProject selects a project of the server on this client
+func (c *Client) SetSelectedProject(ctx context.Context, projectID uuid.UUID, actor string) error {
+	if _, err := c.GetProject(ctx, projectID); err != nil {
+		return fmt.Errorf("failed to verify project exists: %w", err)
+	}
+	return c.selection.set("", &projectID)
+}
+
+// ClearSelectedProject clears the project selected on this client
+func (c *Client) ClearSelectedProject(ctx context.Context) error {
+	return c.selection.set("", nil)
+}
+
+// HasSelectedProject reports whether a project is selected on this client
+func (c *Client) HasSelectedProject(ctx context.Context) (bool, error) {
+	selected, err := c.selection.get("")
+	return selected != nil, err
+}
+
+// GetSessionProject returns the project selected in a session on this client
+func (c *Client) GetSessionProject(ctx context.Context, session string) (*uuid.UUID, error) {
+	return c.selection.get(session)
+}
+
+// SetSessionProject selects a project of the server for a session on this client
+func (c *Client) SetSessionProject(ctx context.Context, session string, projectID uuid.UUID, actor string) error {
+	if _, err := c.GetProject(ctx, projectID); err != nil {
+		return fmt.Errorf("failed to verify project exists: %w", err)
+	}
+	return c.selection.set(session, &projectID)
+}
+
+// ClearSessionProject clears the project selected in a session on this client
+func (c *Client) ClearSessionProject(ctx context.Context, session string) error {
+	return c.selection.set(session, nil)
+}
+
+// Close releases idle connections to the server
+func (c *Client) Close() error {
+	c.httpClient.CloseIdleConnections()
+	return nil
+}
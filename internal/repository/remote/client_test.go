@@ -0,0 +1,328 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setupServer(t *testing.T, auth *Auth) (*httptest.Server, types.Repository) {
+	t.Helper()
+	repo, err := sqlite.NewRepository(filepath.Join(t.TempDir(), "server.db"),
+		sqlite.WithAutoMigrate(true),
+		sqlite.WithLogger(zap.NewNop()),
+	)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(NewHandler(repo, auth, zap.NewNop()))
+	t.Cleanup(server.Close)
+	return server, repo
+}
+
+func newTestClient(t *testing.T, url string, opts ...Option) *Client {
+	t.Helper()
+	opts = append([]Option{WithSelectionFile(filepath.Join(t.TempDir(), SelectionFile))}, opts...)
+	client, err := NewClient(url, opts...)
+	require.NoError(t, err)
+	return client
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	server, serverRepo := setupServer(t, nil)
+	client := newTestClient(t, server.URL)
+
+	// The manager works against the server exactly as against a local database
+	pm := manager.NewManagerWithRepository(client, manager.DefaultConfig())
+	project, err := pm.CreateProject(ctx, "Team project", "Shared", "alice")
+	require.NoError(t, err)
+	assert.False(t, project.CreatedAt.IsZero(), "stored fields must be returned to the client")
+
+	parent, err := pm.CreateTask(ctx, project.ID, nil, "Parent", "", 5, types.TaskPriorityHigh, "alice")
+	require.NoError(t, err)
+	child, err := pm.CreateTask(ctx, project.ID, &parent.ID, "Child", "", 3, types.TaskPriorityMedium, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 1, child.Depth)
+
+	_, err = pm.AddTaskDependency(ctx, parent.ID, child.ID, "alice")
+	require.NoError(t, err)
+
+	stored, err := serverRepo.GetTask(ctx, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{child.ID}, stored.Dependencies)
+
+	children, err := client.GetTasksByParent(ctx, parent.ID)
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	assert.Equal(t, "Child", children[0].Title)
+
+	parentOfChild, err := client.GetParentTask(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, parent.ID, parentOfChild.ID)
+
+	events, err := client.ListChangeEvents(ctx, types.ChangeEventFilter{ProjectID: &project.ID})
+	require.NoError(t, err)
+	assert.NotEmpty(t, events)
+
+	t.Run("repository errors keep their type", func(t *testing.T) {
+		_, err := client.GetTask(ctx, uuid.New())
+		var repoErr *sqlite.RepositoryError
+		require.True(t, errors.As(err, &repoErr), "got %T: %v", err, err)
+		assert.Equal(t, sqlite.ErrorTypeNotFound, repoErr.Type)
+	})
+
+	t.Run("task listings are paginated", func(t *testing.T) {
+		first, err := client.ListTasksPage(ctx, types.TaskFilter{ProjectID: &project.ID}, types.PageRequest{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, first.Tasks, 1)
+		assert.Equal(t, "Parent", first.Tasks[0].Title)
+		require.NotEmpty(t, first.NextCursor)
+
+		second, err := client.ListTasksPage(ctx, types.TaskFilter{ProjectID: &project.ID},
+			types.PageRequest{Cursor: first.NextCursor, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, second.Tasks, 1)
+		assert.Equal(t, "Child", second.Tasks[0].Title)
+		assert.Empty(t, second.NextCursor)
+	})
+
+	t.Run("selected project is client state", func(t *testing.T) {
+		other := newTestClient(t, server.URL)
+		require.NoError(t, client.SetSelectedProject(ctx, project.ID, "alice"))
+
+		selected, err := client.GetSelectedProject(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, selected)
+		assert.Equal(t, project.ID, *selected)
+
+		has, err := other.HasSelectedProject(ctx)
+		require.NoError(t, err)
+		assert.False(t, has)
+
+		assert.Error(t, client.SetSelectedProject(ctx, uuid.New(), "alice"))
+		require.NoError(t, client.ClearSelectedProject(ctx))
+		has, err = client.HasSelectedProject(ctx)
+		require.NoError(t, err)
+		assert.False(t, has)
+	})
+
+	t.Run("scheduled transitions are stored on the server", func(t *testing.T) {
+		transition, err := pm.ScheduleTaskState(ctx, child.ID, types.TaskStateInProgress, time.Now().Add(time.Hour), "alice")
+		require.NoError(t, err)
+
+		stored, err := serverRepo.ListScheduledTransitions(ctx, &project.ID)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.Equal(t, transition.ID, stored[0].ID)
+		assert.Equal(t, child.ID, stored[0].TaskID)
+
+		require.NoError(t, pm.CancelScheduledTransition(ctx, transition.ID))
+		var repoErr *sqlite.RepositoryError
+		err = client.DeleteScheduledTransition(ctx, transition.ID)
+		require.True(t, errors.As(err, &repoErr), "got %T: %v", err, err)
+		assert.Equal(t, sqlite.ErrorTypeNotFound, repoErr.Type)
+	})
+
+	t.Run("session selections are separate", func(t *testing.T) {
+		require.NoError(t, client.SetSessionProject(ctx, "shell-1", project.ID, "alice"))
+
+		selected, err := client.GetSessionProject(ctx, "shell-1")
+		require.NoError(t, err)
+		require.NotNil(t, selected)
+		assert.Equal(t, project.ID, *selected)
+
+		selected, err = client.GetSessionProject(ctx, "shell-2")
+		require.NoError(t, err)
+		assert.Nil(t, selected)
+		has, err := client.HasSelectedProject(ctx)
+		require.NoError(t, err)
+		assert.False(t, has)
+
+		require.NoError(t, client.ClearSessionProject(ctx, "shell-1"))
+		selected, err = client.GetSessionProject(ctx, "shell-1")
+		require.NoError(t, err)
+		assert.Nil(t, selected)
+	})
+}
+
+func TestClientToken(t *testing.T) {
+	ctx := context.Background()
+	server, _ := setupServer(t, &Auth{AdminToken: "secret"})
+
+	_, err := newTestClient(t, server.URL).ListProjects(ctx)
+	assert.ErrorContains(t, err, TokenEnvVar)
+
+	_, err = newTestClient(t, server.URL, WithToken("secret")).ListProjects(ctx)
+	assert.NoError(t, err)
+}
+
+func TestAuthRequireRole(t *testing.T) {
+	users := map[string]*types.User{
+		"viewer": {Name: "victor", Role: types.RoleViewer},
+		"scoped": {Name: "erin", ProjectRoles: map[uuid.UUID]types.Role{uuid.New(): types.RoleAdmin}},
+	}
+	auth := &Auth{AdminToken: "secret", Users: func(_ context.Context, token string) (*types.User, error) {
+		return users[token], nil
+	}}
+	handler := auth.RequireRole(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), types.RoleViewer, "reading metrics")
+
+	for token, want := range map[string]int{
+		"":       http.StatusUnauthorized,
+		"wrong":  http.StatusUnauthorized,
+		"scoped": http.StatusForbidden,
+		"viewer": http.StatusOK,
+		"secret": http.StatusOK,
+	} {
+		request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		assert.Equal(t, want, recorder.Code, "token %q", token)
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient("knot.internal:7420")
+	assert.Error(t, err)
+
+	client, err := NewClient("https://knot.internal:7420/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://knot.internal:7420", client.baseURL)
+
+	assert.True(t, IsRemote("https://knot.internal:7420"))
+	assert.False(t, IsRemote("sqlite:///tmp/knot.db"))
+}
+
+func TestClientRoles(t *testing.T) {
+	ctx := context.Background()
+	var server *httptest.Server
+	var serverRepo types.Repository
+	var pm manager.ProjectManager
+	server, serverRepo = setupServer(t, &Auth{
+		AdminToken: "admin",
+		Users: func(ctx context.Context, token string) (*types.User, error) {
+			return pm.AuthenticateUser(ctx, token)
+		},
+	})
+	pm = manager.NewManagerWithRepository(serverRepo, manager.DefaultConfig())
+
+	admin := manager.NewManagerWithRepository(newTestClient(t, server.URL, WithToken("admin")), manager.DefaultConfig())
+	shared, err := admin.CreateProject(ctx, "Shared", "", "admin")
+	require.NoError(t, err)
+	private, err := admin.CreateProject(ctx, "Private", "", "admin")
+	require.NoError(t, err)
+	task, err := admin.CreateTask(ctx, shared.ID, nil, "Task", "", 3, types.TaskPriorityMedium, "admin")
+	require.NoError(t, err)
+
+	// Editor on the shared project, no access to the private one
+	_, token, err := pm.CreateUser(ctx, "alice", types.RoleNone)
+	require.NoError(t, err)
+	_, err = pm.SetUserRole(ctx, "alice", &shared.ID, types.RoleEditor)
+	require.NoError(t, err)
+	alice := manager.NewManagerWithRepository(newTestClient(t, server.URL, WithToken(token)), manager.DefaultConfig())
+
+	projects, err := alice.ListProjects(ctx)
+	require.NoError(t, err)
+	require.Len(t, projects, 1, "projects without a role must be hidden")
+	assert.Equal(t, shared.ID, projects[0].ID)
+
+	_, err = alice.GetProject(ctx, private.ID)
+	var denied *PermissionError
+	require.ErrorAs(t, err, &denied)
+	assert.Contains(t, err.Error(), "permission denied")
+	assert.NotContains(t, err.Error(), "permission denied: permission denied")
+
+	_, err = alice.CreateTask(ctx, shared.ID, nil, "By alice", "", 3, types.TaskPriorityMedium, "alice")
+	require.NoError(t, err)
+	_, err = alice.CreateTask(ctx, private.ID, nil, "By alice", "", 3, types.TaskPriorityMedium, "alice")
+	assert.ErrorContains(t, err, "permission denied")
+
+	_, err = alice.CreateProject(ctx, "Own project", "", "alice")
+	assert.ErrorContains(t, err, "permission denied", "creating projects needs the editor role on all projects")
+
+	assert.ErrorContains(t, alice.DeleteTask(ctx, task.ID, "alice"), "permission denied", "editors must not delete")
+
+	_, err = pm.SetUserRole(ctx, "alice", &shared.ID, types.RoleAdmin)
+	require.NoError(t, err)
+	assert.NoError(t, alice.DeleteTask(ctx, task.ID, "alice"))
+
+	t.Run("viewer reads only", func(t *testing.T) {
+		_, token, err := pm.CreateUser(ctx, "bob", types.RoleViewer)
+		require.NoError(t, err)
+		bob := manager.NewManagerWithRepository(newTestClient(t, server.URL, WithToken(token)), manager.DefaultConfig())
+
+		projects, err := bob.ListProjects(ctx)
+		require.NoError(t, err)
+		assert.Len(t, projects, 2)
+		_, err = bob.UpdateProjectDescription(ctx, shared.ID, "changed", "bob")
+		assert.ErrorContains(t, err, "permission denied")
+	})
+
+	t.Run("cross-project links need the role on both projects", func(t *testing.T) {
+		viewed, err := admin.CreateProject(ctx, "Viewed", "", "admin")
+		require.NoError(t, err)
+		_, err = pm.SetUserRole(ctx, "alice", &viewed.ID, types.RoleViewer)
+		require.NoError(t, err)
+		own, err := admin.CreateTask(ctx, shared.ID, nil, "Own", "", 3, types.TaskPriorityMedium, "admin")
+		require.NoError(t, err)
+		readOnly, err := admin.CreateTask(ctx, viewed.ID, nil, "Read only", "", 3, types.TaskPriorityMedium, "admin")
+		require.NoError(t, err)
+		hidden, err := admin.CreateTask(ctx, private.ID, nil, "Hidden", "", 3, types.TaskPriorityMedium, "admin")
+		require.NoError(t, err)
+
+		aliceRepo := newTestClient(t, server.URL, WithToken(token))
+		for _, other := range []*types.Task{readOnly, hidden} {
+			var denied *PermissionError
+			_, err = aliceRepo.AddTaskDependency(ctx, own.ID, other.ID)
+			require.ErrorAs(t, err, &denied, "depending on %s", other.Title)
+			assert.Contains(t, err.Error(), other.ProjectID.String())
+			_, err = aliceRepo.SetDependencyLink(ctx, own.ID, types.DependencyLink{DependsOnID: other.ID, Type: types.DependencyFinishToStart})
+			require.ErrorAs(t, err, &denied, "linking to %s", other.Title)
+			err = aliceRepo.MoveTask(ctx, own.ID, &other.ID)
+			require.ErrorAs(t, err, &denied, "moving below %s", other.Title)
+		}
+
+		stored, err := serverRepo.GetTask(ctx, own.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.ParentID)
+		assert.Empty(t, stored.Dependencies)
+
+		// Within the project the editor role is enough
+		sibling, err := admin.CreateTask(ctx, shared.ID, nil, "Sibling", "", 3, types.TaskPriorityMedium, "admin")
+		require.NoError(t, err)
+		_, err = aliceRepo.AddTaskDependency(ctx, own.ID, sibling.ID)
+		require.NoError(t, err)
+		require.NoError(t, aliceRepo.MoveTask(ctx, own.ID, &sibling.ID))
+	})
+
+	t.Run("rotated and deleted tokens stop working", func(t *testing.T) {
+		newToken, err := pm.RotateUserToken(ctx, "alice")
+		require.NoError(t, err)
+		_, err = alice.ListProjects(ctx)
+		assert.ErrorContains(t, err, TokenEnvVar)
+
+		rotated := newTestClient(t, server.URL, WithToken(newToken))
+		_, err = rotated.ListProjects(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, pm.DeleteUser(ctx, "alice"))
+		_, err = rotated.ListProjects(ctx)
+		assert.ErrorContains(t, err, TokenEnvVar)
+	})
+}
@@ -0,0 +1,34 @@
+package remote
+
+import (
+	"os"
+
+	"github.com/denkhaus/knot/v2/internal/repository"
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// DSNFromEnv returns the storage DSN of the CLI: the knot server URL if
+// KNOT_REMOTE_URL is set, otherwise KNOT_DATABASE
+func DSNFromEnv() string {
+	if url := os.Getenv(URLEnvVar); url != "" {
+		return url
+	}
+	return os.Getenv(repository.DSNEnvVar)
+}
+
+// IsRemote reports whether dsn refers to a knot server
+func IsRemote(dsn string) bool {
+	driver, _ := repository.ParseDSN(dsn)
+	return driver == "http" || driver == "https"
+}
+
+func init() {
+	for _, scheme := range []string{"http", "https"} {
+		repository.Register(scheme, func(location string, opts repository.Options) (types.Repository, error) {
+			return NewClient(scheme+"://"+location,
+				WithToken(os.Getenv(TokenEnvVar)),
+				WithLogger(opts.Logger),
+			)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Package remote provides a repository that talks to a knot server over HTTP.
+//
+// 'knot serve' exposes its repository with NewHandler. Every repository method
+// is a POST request to /v1/repository/<Method> carrying the method arguments
+// as JSON params; the response holds either the JSON result or an error:
+//
+//	POST /v1/repository/GetTask  {"id": "8c0e..."}
+//	200  {"result": {"id": "8c0e...", "title": "..."}}
+//	200  {"error": {"message": "task with ID 8c0e... not found", "type": 0}}
+//
+// The client registers the "http" and "https" storage drivers, so the CLI
+// works against a server by setting KNOT_REMOTE_URL (or KNOT_DATABASE) to the
+// server URL, e.g. http://knot.internal:7420.
+package remote
+
+import (
+	"encoding/json"
+
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+const (
+	// URLEnvVar is the environment variable holding the knot server URL
+	URLEnvVar = "KNOT_REMOTE_URL"
+	// TokenEnvVar is the environment variable holding the bearer token sent
+	// to the knot server
+	TokenEnvVar = "KNOT_REMOTE_TOKEN"
+
+	// RepositoryPath is the path prefix of the repository operations
+	RepositoryPath = "/v1/repository/"
+	// HealthPath answers GET requests with 200 while the server is running
+	HealthPath = "/v1/health"
+)
+
+// params carries the arguments of a repository operation. Each operation
+// uses the subset of fields matching its method signature.
+type params struct {
+	ID          *uuid.UUID                 `json:"id,omitempty"`
+	IDs         []uuid.UUID                `json:"ids,omitempty"`
+	ProjectID   *uuid.UUID                 `json:"project_id,omitempty"`
+	ParentID    *uuid.UUID                 `json:"parent_id,omitempty"`
+	DependsOnID *uuid.UUID                 `json:"depends_on_id,omitempty"`
+	MaxDepth    int                        `json:"max_depth,omitempty"`
+	Actor       string                     `json:"actor,omitempty"`
+	Project     *types.Project             `json:"project,omitempty"`
+	Task        *types.Task                `json:"task,omitempty"`
+	Lock        *types.ProjectLock         `json:"lock,omitempty"`
+	Transition  *types.ScheduledTransition `json:"transition,omitempty"`
+	Link        *types.DependencyLink      `json:"link,omitempty"`
+	TaskFilter  *types.TaskFilter          `json:"task_filter,omitempty"`
+	Page        *types.PageRequest         `json:"page,omitempty"`
+	EventFilter *types.ChangeEventFilter   `json:"event_filter,omitempty"`
+}
+
+// response is the envelope of every operation result
+type response struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *wireError      `json:"error,omitempty"`
+}
+
+// wireError transports a repository error. Type is set for typed SQLite
+// repository errors so the client can restore them, e.g. for exit codes.
+type wireError struct {
+	Message string            `json:"message"`
+	Type    *sqlite.ErrorType `json:"type,omitempty"`
+}
+
+func (e *wireError) err() error {
+	if e.Type != nil {
+		return &sqlite.RepositoryError{Type: *e.Type, Message: e.Message}
+	}
+	return &ServerError{Message: e.Message}
+}
+
+// ServerError is an untyped error returned by the knot server
+type ServerError struct {
+	Message string
+}
+
+func (e *ServerError) Error() string {
+	return e.Message
+}
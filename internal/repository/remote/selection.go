@@ -0,0 +1,113 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/google/uuid"
+)
+
+// SelectionFile stores the projects selected per knot server in the .knot
+// directory of the working directory
+const SelectionFile = "remote.json"
+
+// selectionStore persists the selected project of one server in a JSON file
+// mapping server URLs to project IDs. Session selections are stored under the
+// server URL followed by "#" and the session token.
+type selectionStore struct {
+	mu     sync.Mutex
+	server string
+	path   string
+}
+
+func (s *selectionStore) file() (string, error) {
+	if s.path != "" {
+		return s.path, nil
+	}
+	dir, err := sqlite.GetProjectDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, SelectionFile), nil
+}
+
+func (s *selectionStore) load() (string, map[string]uuid.UUID, error) {
+	path, err := s.file()
+	if err != nil {
+		return "", nil, err
+	}
+
+	selected := make(map[string]uuid.UUID)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return path, selected, nil
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read selected project: %w", err)
+	}
+	if err := json.Unmarshal(data, &selected); err != nil {
+		return "", nil, fmt.Errorf("failed to parse selected project file %s: %w", path, err)
+	}
+	return path, selected, nil
+}
+
+// key returns the file entry of the selection of a session, or of the shared
+// selection if session is empty
+func (s *selectionStore) key(session string) string {
+	if session == "" {
+		return s.server
+	}
+	return s.server + "#" + session
+}
+
+func (s *selectionStore) get(session string) (*uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, selected, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	id, ok := selected[s.key(session)]
+	if !ok {
+		return nil, nil
+	}
+	return &id, nil
+}
+
+// set stores projectID as selected project of a session, or of the shared
+// selection if session is empty, or clears the selection if projectID is nil
+func (s *selectionStore) set(session string, projectID *uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, selected, err := s.load()
+	if err != nil {
+		return err
+	}
+	key := s.key(session)
+	if projectID == nil {
+		if _, ok := selected[key]; !ok {
+			return nil
+		}
+		delete(selected, key)
+	} else {
+		selected[key] = *projectID
+	}
+
+	data, err := json.MarshalIndent(selected, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal selected project: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create project directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write selected project: %w", err)
+	}
+	return nil
+}
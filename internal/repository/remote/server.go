@@ -0,0 +1,330 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"go.uber.org/zap"
+)
+
+// maxRequestSize bounds the body of a single operation request
+const maxRequestSize = 16 << 20
+
+// operation runs one repository method with the decoded params
+type operation func(ctx context.Context, repo types.Repository, p *params) (any, error)
+
+var operations = map[string]operation{
+	"CreateProject": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.Project == nil {
+			return nil, errMissing("project")
+		}
+		// The repository fills in fields such as timestamps, so return the
+		// stored project for the client to pick them up
+		err := repo.CreateProject(ctx, p.Project)
+		return p.Project, err
+	},
+	"GetProject": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil {
+			return nil, errMissing("id")
+		}
+		return repo.GetProject(ctx, *p.ID)
+	},
+	"UpdateProject": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.Project == nil {
+			return nil, errMissing("project")
+		}
+		err := repo.UpdateProject(ctx, p.Project)
+		return p.Project, err
+	},
+	"DeleteProject": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil {
+			return nil, errMissing("id")
+		}
+		return nil, repo.DeleteProject(ctx, *p.ID)
+	},
+	"ListProjects": func(ctx context.Context, repo types.Repository, _ *params) (any, error) {
+		return repo.ListProjects(ctx)
+	},
+	"CreateTask": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.Task == nil {
+			return nil, errMissing("task")
+		}
+		err := repo.CreateTask(ctx, p.Task)
+		return p.Task, err
+	},
+	"GetTask": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil {
+			return nil, errMissing("id")
+		}
+		return repo.GetTask(ctx, *p.ID)
+	},
+	"GetTasksWithDependencies": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		return repo.GetTasksWithDependencies(ctx, p.IDs)
+	},
+	"UpdateTask": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.Task == nil {
+			return nil, errMissing("task")
+		}
+		err := repo.UpdateTask(ctx, p.Task)
+		return p.Task, err
+	},
+	"DeleteTask": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil {
+			return nil, errMissing("id")
+		}
+		return nil, repo.DeleteTask(ctx, *p.ID)
+	},
+	"ListTasks": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		var filter types.TaskFilter
+		if p.TaskFilter != nil {
+			filter = *p.TaskFilter
+		}
+		return repo.ListTasks(ctx, filter)
+	},
+	"ListTasksPage": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		var filter types.TaskFilter
+		if p.TaskFilter != nil {
+			filter = *p.TaskFilter
+		}
+		var page types.PageRequest
+		if p.Page != nil {
+			page = *p.Page
+		}
+		if page.Limit > types.MaxPageSize {
+			page.Limit = types.MaxPageSize
+		}
+		return repo.ListTasksPage(ctx, filter, page)
+	},
+	"GetTasksByProject": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ProjectID == nil {
+			return nil, errMissing("project_id")
+		}
+		return repo.GetTasksByProject(ctx, *p.ProjectID)
+	},
+	"GetTasksByParent": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ParentID == nil {
+			return nil, errMissing("parent_id")
+		}
+		return repo.GetTasksByParent(ctx, *p.ParentID)
+	},
+	"GetDescendants": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil {
+			return nil, errMissing("id")
+		}
+		return repo.GetDescendants(ctx, *p.ID, p.MaxDepth)
+	},
+	"GetRootTasks": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ProjectID == nil {
+			return nil, errMissing("project_id")
+		}
+		return repo.GetRootTasks(ctx, *p.ProjectID)
+	},
+	"GetParentTask": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil {
+			return nil, errMissing("id")
+		}
+		return repo.GetParentTask(ctx, *p.ID)
+	},
+	"DeleteTaskSubtree": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil {
+			return nil, errMissing("id")
+		}
+		return nil, repo.DeleteTaskSubtree(ctx, *p.ID)
+	},
+	"MoveTask": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil {
+			return nil, errMissing("id")
+		}
+		return nil, repo.MoveTask(ctx, *p.ID, p.ParentID)
+	},
+	"AddTaskDependency": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil || p.DependsOnID == nil {
+			return nil, errMissing("id and depends_on_id")
+		}
+		return repo.AddTaskDependency(ctx, *p.ID, *p.DependsOnID)
+	},
+	"SetDependencyLink": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil || p.Link == nil {
+			return nil, errMissing("id and link")
+		}
+		return repo.SetDependencyLink(ctx, *p.ID, *p.Link)
+	},
+	"RemoveTaskDependency": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil || p.DependsOnID == nil {
+			return nil, errMissing("id and depends_on_id")
+		}
+		return repo.RemoveTaskDependency(ctx, *p.ID, *p.DependsOnID)
+	},
+	"GetTaskDependencies": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil {
+			return nil, errMissing("id")
+		}
+		return repo.GetTaskDependencies(ctx, *p.ID)
+	},
+	"GetDependentTasks": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil {
+			return nil, errMissing("id")
+		}
+		return repo.GetDependentTasks(ctx, *p.ID)
+	},
+	"GetProjectProgress": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ProjectID == nil {
+			return nil, errMissing("project_id")
+		}
+		return repo.GetProjectProgress(ctx, *p.ProjectID)
+	},
+	"GetTaskCountByDepth": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ProjectID == nil {
+			return nil, errMissing("project_id")
+		}
+		return repo.GetTaskCountByDepth(ctx, *p.ProjectID, p.MaxDepth)
+	},
+	"GetSubtreeCounts": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ProjectID == nil {
+			return nil, errMissing("project_id")
+		}
+		return repo.GetSubtreeCounts(ctx, *p.ProjectID)
+	},
+	"GetChildCounts": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ProjectID == nil {
+			return nil, errMissing("project_id")
+		}
+		return repo.GetChildCounts(ctx, *p.ProjectID)
+	},
+	"GetProjectLock": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ProjectID == nil {
+			return nil, errMissing("project_id")
+		}
+		return repo.GetProjectLock(ctx, *p.ProjectID)
+	},
+	"SaveProjectLock": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.Lock == nil {
+			return nil, errMissing("lock")
+		}
+		return nil, repo.SaveProjectLock(ctx, p.Lock)
+	},
+	"DeleteProjectLock": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ProjectID == nil {
+			return nil, errMissing("project_id")
+		}
+		return nil, repo.DeleteProjectLock(ctx, *p.ProjectID)
+	},
+	"SaveScheduledTransition": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.Transition == nil {
+			return nil, errMissing("transition")
+		}
+		return nil, repo.SaveScheduledTransition(ctx, p.Transition)
+	},
+	"ListScheduledTransitions": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		return repo.ListScheduledTransitions(ctx, p.ProjectID)
+	},
+	"DeleteScheduledTransition": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		if p.ID == nil {
+			return nil, errMissing("id")
+		}
+		return nil, repo.DeleteScheduledTransition(ctx, *p.ID)
+	},
+	"ListChangeEvents": func(ctx context.Context, repo types.Repository, p *params) (any, error) {
+		feed, ok := repo.(types.ChangeFeed)
+		if !ok {
+			return nil, fmt.Errorf("the storage backend of the server does not record change events")
+		}
+		var filter types.ChangeEventFilter
+		if p.EventFilter != nil {
+			filter = *p.EventFilter
+		}
+		return feed.ListChangeEvents(ctx, filter)
+	},
+}
+
+func errMissing(field string) error {
+	return sqlite.NewValidationError(fmt.Sprintf("missing %s parameter", field), nil)
+}
+
+// NewHandler serves repo to remote clients. Requests must carry a bearer
+// token accepted by auth, unless auth is nil or open, and the roles of the
+// authenticated user must allow the operation. The selected project is
+// client state and is not served.
+func NewHandler(repo types.Repository, auth *Auth, logger *zap.Logger) http.Handler {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(HealthPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc(RepositoryPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		name := strings.TrimPrefix(r.URL.Path, RepositoryPath)
+		op, ok := operations[name]
+		if !ok {
+			http.Error(w, fmt.Sprintf("unknown repository operation %q", name), http.StatusNotFound)
+			return
+		}
+
+		client, err := auth.authenticate(r)
+		if err != nil {
+			logger.Error("Failed to authenticate request", zap.Error(err))
+			http.Error(w, "failed to authenticate request", http.StatusInternalServerError)
+			return
+		}
+		if client == nil {
+			http.Error(w, "invalid or missing token", http.StatusUnauthorized)
+			return
+		}
+
+		var p params
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&p); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		if err := client.authorize(r.Context(), repo, name, &p); err != nil {
+			logger.Info("Remote operation denied", zap.String("operation", name), zap.Error(err))
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		result, err := op(r.Context(), repo, &p)
+		if err != nil {
+			logger.Debug("Remote operation failed", zap.String("operation", name), zap.Error(err))
+			writeResponse(w, logger, &response{Error: toWireError(err)})
+			return
+		}
+
+		data, err := json.Marshal(client.filter(result))
+		if err != nil {
+			logger.Error("Failed to encode operation result", zap.String("operation", name), zap.Error(err))
+			http.Error(w, "failed to encode result", http.StatusInternalServerError)
+			return
+		}
+		writeResponse(w, logger, &response{Result: data})
+	})
+	return mux
+}
+
+func toWireError(err error) *wireError {
+	wire := &wireError{Message: err.Error()}
+	var repoErr *sqlite.RepositoryError
+	if errors.As(err, &repoErr) {
+		wire.Type = &repoErr.Type
+	}
+	return wire
+}
+
+func writeResponse(w http.ResponseWriter, logger *zap.Logger, resp *response) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logger.Warn("Failed to write response", zap.Error(err))
+	}
+}
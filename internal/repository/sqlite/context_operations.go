@@ -16,7 +16,7 @@ func (r *sqliteRepository) GetSelectedProject(ctx context.Context) (*uuid.UUID,
 	r.logger.Debug("Getting selected project from database")
 
 	// Query the singleton project context record
-	pc, err := r.client.ProjectContext.Query().
+	pc, err := r.entClient(ctx).ProjectContext.Query().
 		Where(projectcontext.IDEQ(1)).
 		Only(ctx)
 	if err != nil {
@@ -28,6 +28,12 @@ func (r *sqliteRepository) GetSelectedProject(ctx context.Context) (*uuid.UUID,
 		return nil, fmt.Errorf("failed to get selected project: %w", err)
 	}
 
+	// Deleting the selected project clears the reference
+	if pc.SelectedProjectID == uuid.Nil {
+		r.logger.Debug("Selected project was deleted")
+		return nil, nil
+	}
+
 	r.logger.Debug("Retrieved selected project", zap.String("projectID", pc.SelectedProjectID.String()))
 	return &pc.SelectedProjectID, nil
 }
@@ -39,7 +45,7 @@ func (r *sqliteRepository) SetSelectedProject(ctx context.Context, projectID uui
 		zap.String("actor", actor))
 
 	// First verify the project exists
-	exists, err := r.client.Project.Query().
+	exists, err := r.entClient(ctx).Project.Query().
 		Where(project.IDEQ(projectID)).
 		Exist(ctx)
 	if err != nil {
@@ -52,7 +58,7 @@ func (r *sqliteRepository) SetSelectedProject(ctx context.Context, projectID uui
 
 	// Use upsert pattern - try to update existing record, create if not exists
 	// Try to update existing record first
-	updated, err := r.client.ProjectContext.Update().
+	updated, err := r.entClient(ctx).ProjectContext.Update().
 		Where(projectcontext.IDEQ(1)).
 		SetSelectedProjectID(projectID).
 		SetUpdatedBy(actor).
@@ -63,7 +69,7 @@ func (r *sqliteRepository) SetSelectedProject(ctx context.Context, projectID uui
 	if err != nil || updated == 0 {
 		// Create new record if update failed or no record exists
 		r.logger.Debug("Creating new project context record")
-		err = r.client.ProjectContext.Create().
+		err = r.entClient(ctx).ProjectContext.Create().
 			SetID(1).
 			SetSelectedProjectID(projectID).
 			SetUpdatedBy(actor).
@@ -87,7 +93,7 @@ func (r *sqliteRepository) ClearSelectedProject(ctx context.Context) error {
 	r.logger.Debug("Clearing selected project from database")
 
 	// Delete the singleton record
-	_, err := r.client.ProjectContext.Delete().
+	_, err := r.entClient(ctx).ProjectContext.Delete().
 		Where(projectcontext.IDEQ(1)).
 		Exec(ctx)
 	if err != nil {
@@ -103,7 +109,7 @@ func (r *sqliteRepository) ClearSelectedProject(ctx context.Context) error {
 func (r *sqliteRepository) HasSelectedProject(ctx context.Context) (bool, error) {
 	r.logger.Debug("Checking if project is selected")
 
-	exists, err := r.client.ProjectContext.Query().
+	exists, err := r.entClient(ctx).ProjectContext.Query().
 		Where(projectcontext.IDEQ(1)).
 		Exist(ctx)
 	if err != nil {
@@ -70,6 +70,39 @@ func (r *sqliteRepository) AddTaskDependency(ctx context.Context, taskID uuid.UU
 		result, err = r.getTaskInTx(ctx, tx, taskID)
 		return err
 	})
+	if err == nil {
+		r.recordEvents(ctx, types.NewDependencyEvent(types.ChangeDependencyAdded, result.ProjectID, taskID, dependsOnTaskID))
+	}
+	return result, err
+}
+
+// SetDependencyLink changes the type and lag of an existing dependency using ent
+func (r *sqliteRepository) SetDependencyLink(ctx context.Context, taskID uuid.UUID, link types.DependencyLink) (*types.Task, error) {
+	var result *types.Task
+	err := r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+		updatedCount, err := tx.TaskDependency.Update().
+			Where(
+				taskdependency.TaskID(taskID),
+				taskdependency.DependsOnTaskID(link.DependsOnID),
+			).
+			SetDependencyType(taskdependency.DependencyType(link.Type)).
+			SetLag(link.Lag).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update task dependency: %w", err)
+		}
+
+		if updatedCount == 0 {
+			return NewNotFoundError("task dependency", fmt.Sprintf("%s -> %s", taskID, link.DependsOnID))
+		}
+
+		// Return updated task
+		result, err = r.getTaskInTx(ctx, tx, taskID)
+		return err
+	})
+	if err == nil {
+		r.recordEvents(ctx, types.NewDependencyEvent(types.ChangeDependencyUpdated, result.ProjectID, taskID, link.DependsOnID))
+	}
 	return result, err
 }
 
@@ -105,13 +138,16 @@ func (r *sqliteRepository) RemoveTaskDependency(ctx context.Context, taskID uuid
 		result, err = r.getTaskInTx(ctx, tx, taskID)
 		return err
 	})
+	if err == nil {
+		r.recordEvents(ctx, types.NewDependencyEvent(types.ChangeDependencyRemoved, result.ProjectID, taskID, dependsOnTaskID))
+	}
 	return result, err
 }
 
 // GetTaskDependencies retrieves all tasks that the given task depends on using ent
 func (r *sqliteRepository) GetTaskDependencies(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error) {
 	// Get dependency task IDs
-	dependencyTaskIDs, err := r.client.TaskDependency.Query().
+	dependencyTaskIDs, err := r.entClient(ctx).TaskDependency.Query().
 		Where(taskdependency.TaskID(taskID)).
 		Select(taskdependency.FieldDependsOnTaskID).
 		All(ctx)
@@ -130,7 +166,7 @@ func (r *sqliteRepository) GetTaskDependencies(ctx context.Context, taskID uuid.
 	}
 
 	// Get the actual tasks
-	entTasks, err := r.client.Task.Query().
+	entTasks, err := r.entClient(ctx).Task.Query().
 		Where(task.IDIn(ids...)).
 		Order(ent.Asc(task.FieldCreatedAt)).
 		All(ctx)
@@ -149,7 +185,7 @@ func (r *sqliteRepository) GetTaskDependencies(ctx context.Context, taskID uuid.
 // GetDependentTasks retrieves all tasks that depend on the given task using ent
 func (r *sqliteRepository) GetDependentTasks(ctx context.Context, taskID uuid.UUID) ([]*types.Task, error) {
 	// Get dependent task IDs
-	dependentTaskIDs, err := r.client.TaskDependency.Query().
+	dependentTaskIDs, err := r.entClient(ctx).TaskDependency.Query().
 		Where(taskdependency.DependsOnTaskID(taskID)).
 		Select(taskdependency.FieldTaskID).
 		All(ctx)
@@ -168,7 +204,7 @@ func (r *sqliteRepository) GetDependentTasks(ctx context.Context, taskID uuid.UU
 	}
 
 	// Get the actual tasks
-	entTasks, err := r.client.Task.Query().
+	entTasks, err := r.entClient(ctx).Task.Query().
 		Where(task.IDIn(ids...)).
 		Order(ent.Asc(task.FieldCreatedAt)).
 		All(ctx)
@@ -239,6 +275,7 @@ func (r *sqliteRepository) getTaskInTx(ctx context.Context, tx *ent.Tx, taskID u
 		return nil, fmt.Errorf("failed to load dependencies in transaction: %w", err)
 	}
 	task.Dependencies = entTaskDependenciesToTaskIDs(dependencies)
+	task.DependencyLinks = entTaskDependencyLinks(dependencies)
 
 	// Load dependents
 	dependents, err := tx.TaskDependency.Query().
@@ -0,0 +1,19 @@
+package sqlite
+
+import (
+	"github.com/denkhaus/knot/v2/internal/repository"
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// DriverName is the storage driver name of the SQLite repository
+const DriverName = "sqlite"
+
+func init() {
+	repository.Register(DriverName, func(location string, opts repository.Options) (types.Repository, error) {
+		return NewRepository(location,
+			WithLogger(opts.Logger),
+			WithAutoMigrate(opts.AutoMigrate),
+			WithStatementObserver(opts.Observer),
+		)
+	})
+}
@@ -13,6 +13,7 @@ var (
 		{Name: "id", Type: field.TypeUUID, Unique: true},
 		{Name: "title", Type: field.TypeString, Size: 200},
 		{Name: "description", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "instructions", Type: field.TypeString, Nullable: true, Size: 2147483647},
 		{Name: "state", Type: field.TypeEnum, Enums: []string{"active", "completed", "archived", "deletion-pending"}, Default: "active"},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
@@ -21,6 +22,7 @@ var (
 		{Name: "progress", Type: field.TypeFloat64, Default: 0},
 		{Name: "created_by", Type: field.TypeString, Nullable: true},
 		{Name: "updated_by", Type: field.TypeString, Nullable: true},
+		{Name: "document", Type: field.TypeString, Nullable: true, Size: 2147483647},
 	}
 	// ProjectsTable holds the schema information for the "projects" table.
 	ProjectsTable = &schema.Table{
@@ -31,7 +33,7 @@ var (
 			{
 				Name:    "project_created_at",
 				Unique:  false,
-				Columns: []*schema.Column{ProjectsColumns[4]},
+				Columns: []*schema.Column{ProjectsColumns[5]},
 			},
 			{
 				Name:    "project_title",
@@ -41,12 +43,12 @@ var (
 			{
 				Name:    "project_progress",
 				Unique:  false,
-				Columns: []*schema.Column{ProjectsColumns[8]},
+				Columns: []*schema.Column{ProjectsColumns[9]},
 			},
 			{
 				Name:    "project_state",
 				Unique:  false,
-				Columns: []*schema.Column{ProjectsColumns[3]},
+				Columns: []*schema.Column{ProjectsColumns[4]},
 			},
 		},
 	}
@@ -76,6 +78,7 @@ var (
 		{Name: "id", Type: field.TypeUUID, Unique: true},
 		{Name: "title", Type: field.TypeString, Size: 200},
 		{Name: "description", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "instructions", Type: field.TypeString, Nullable: true, Size: 2147483647},
 		{Name: "state", Type: field.TypeEnum, Enums: []string{"pending", "in-progress", "completed", "blocked", "cancelled", "deletion-pending"}, Default: "pending"},
 		{Name: "priority", Type: field.TypeEnum, Enums: []string{"low", "medium", "high"}, Default: "medium"},
 		{Name: "complexity", Type: field.TypeInt},
@@ -85,6 +88,18 @@ var (
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 		{Name: "completed_at", Type: field.TypeTime, Nullable: true},
+		{Name: "tags", Type: field.TypeJSON, Nullable: true},
+		{Name: "acceptance_criteria", Type: field.TypeJSON, Nullable: true},
+		{Name: "effort_log", Type: field.TypeJSON, Nullable: true},
+		{Name: "review", Type: field.TypeJSON, Nullable: true},
+		{Name: "keep_complexity", Type: field.TypeBool, Default: false},
+		{Name: "created_by", Type: field.TypeString, Nullable: true},
+		{Name: "updated_by", Type: field.TypeString, Nullable: true},
+		{Name: "position", Type: field.TypeInt, Default: 0},
+		{Name: "due_date", Type: field.TypeTime, Nullable: true},
+		{Name: "blocker", Type: field.TypeJSON, Nullable: true},
+		{Name: "check", Type: field.TypeJSON, Nullable: true},
+		{Name: "handoffs", Type: field.TypeJSON, Nullable: true},
 		{Name: "project_id", Type: field.TypeUUID},
 		{Name: "parent_id", Type: field.TypeUUID, Nullable: true},
 	}
@@ -96,97 +111,107 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "tasks_projects_tasks",
-				Columns:    []*schema.Column{TasksColumns[12]},
+				Columns:    []*schema.Column{TasksColumns[25]},
 				RefColumns: []*schema.Column{ProjectsColumns[0]},
-				OnDelete:   schema.NoAction,
+				OnDelete:   schema.Cascade,
 			},
 			{
 				Symbol:     "tasks_tasks_children",
-				Columns:    []*schema.Column{TasksColumns[13]},
+				Columns:    []*schema.Column{TasksColumns[26]},
 				RefColumns: []*schema.Column{TasksColumns[0]},
-				OnDelete:   schema.SetNull,
+				OnDelete:   schema.Cascade,
 			},
 		},
 		Indexes: []*schema.Index{
 			{
 				Name:    "task_project_id",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[12]},
+				Columns: []*schema.Column{TasksColumns[25]},
 			},
 			{
 				Name:    "task_parent_id",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[13]},
+				Columns: []*schema.Column{TasksColumns[26]},
 			},
 			{
 				Name:    "task_state",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[3]},
+				Columns: []*schema.Column{TasksColumns[4]},
 			},
 			{
 				Name:    "task_priority",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[4]},
+				Columns: []*schema.Column{TasksColumns[5]},
 			},
 			{
 				Name:    "task_assigned_agent",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[8]},
+				Columns: []*schema.Column{TasksColumns[9]},
 			},
 			{
 				Name:    "task_complexity",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[5]},
+				Columns: []*schema.Column{TasksColumns[6]},
 			},
 			{
 				Name:    "task_depth",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[6]},
+				Columns: []*schema.Column{TasksColumns[7]},
 			},
 			{
 				Name:    "task_created_at",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[9]},
+				Columns: []*schema.Column{TasksColumns[10]},
 			},
 			{
 				Name:    "task_project_id_state",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[12], TasksColumns[3]},
+				Columns: []*schema.Column{TasksColumns[25], TasksColumns[4]},
 			},
 			{
 				Name:    "task_project_id_priority",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[12], TasksColumns[4]},
+				Columns: []*schema.Column{TasksColumns[25], TasksColumns[5]},
 			},
 			{
 				Name:    "task_project_id_assigned_agent",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[12], TasksColumns[8]},
+				Columns: []*schema.Column{TasksColumns[25], TasksColumns[9]},
 			},
 			{
 				Name:    "task_project_id_parent_id",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[12], TasksColumns[13]},
+				Columns: []*schema.Column{TasksColumns[25], TasksColumns[26]},
+			},
+			{
+				Name:    "task_parent_id_position",
+				Unique:  false,
+				Columns: []*schema.Column{TasksColumns[26], TasksColumns[20]},
 			},
 			{
 				Name:    "task_project_id_depth",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[12], TasksColumns[6]},
+				Columns: []*schema.Column{TasksColumns[25], TasksColumns[7]},
+			},
+			{
+				Name:    "task_project_id_created_at_id",
+				Unique:  false,
+				Columns: []*schema.Column{TasksColumns[25], TasksColumns[10], TasksColumns[0]},
 			},
 			{
 				Name:    "task_state_complexity",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[3], TasksColumns[5]},
+				Columns: []*schema.Column{TasksColumns[4], TasksColumns[6]},
 			},
 			{
 				Name:    "task_priority_state",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[4], TasksColumns[3]},
+				Columns: []*schema.Column{TasksColumns[5], TasksColumns[4]},
 			},
 			{
 				Name:    "task_priority_complexity",
 				Unique:  false,
-				Columns: []*schema.Column{TasksColumns[4], TasksColumns[5]},
+				Columns: []*schema.Column{TasksColumns[5], TasksColumns[6]},
 			},
 		},
 	}
@@ -194,6 +219,8 @@ var (
 	TaskDependenciesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID, Unique: true},
 		{Name: "created_at", Type: field.TypeTime},
+		{Name: "dependency_type", Type: field.TypeEnum, Enums: []string{"finish-to-start", "start-to-start"}, Default: "finish-to-start"},
+		{Name: "lag", Type: field.TypeInt64, Default: 0},
 		{Name: "task_id", Type: field.TypeUUID},
 		{Name: "depends_on_task_id", Type: field.TypeUUID},
 	}
@@ -205,37 +232,37 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "task_dependencies_tasks_task",
-				Columns:    []*schema.Column{TaskDependenciesColumns[2]},
+				Columns:    []*schema.Column{TaskDependenciesColumns[4]},
 				RefColumns: []*schema.Column{TasksColumns[0]},
-				OnDelete:   schema.NoAction,
+				OnDelete:   schema.Cascade,
 			},
 			{
 				Symbol:     "task_dependencies_tasks_depends_on_task",
-				Columns:    []*schema.Column{TaskDependenciesColumns[3]},
+				Columns:    []*schema.Column{TaskDependenciesColumns[5]},
 				RefColumns: []*schema.Column{TasksColumns[0]},
-				OnDelete:   schema.NoAction,
+				OnDelete:   schema.Cascade,
 			},
 		},
 		Indexes: []*schema.Index{
 			{
 				Name:    "taskdependency_task_id",
 				Unique:  false,
-				Columns: []*schema.Column{TaskDependenciesColumns[2]},
+				Columns: []*schema.Column{TaskDependenciesColumns[4]},
 			},
 			{
 				Name:    "taskdependency_depends_on_task_id",
 				Unique:  false,
-				Columns: []*schema.Column{TaskDependenciesColumns[3]},
+				Columns: []*schema.Column{TaskDependenciesColumns[5]},
 			},
 			{
 				Name:    "taskdependency_task_id_depends_on_task_id",
 				Unique:  true,
-				Columns: []*schema.Column{TaskDependenciesColumns[2], TaskDependenciesColumns[3]},
+				Columns: []*schema.Column{TaskDependenciesColumns[4], TaskDependenciesColumns[5]},
 			},
 			{
 				Name:    "taskdependency_depends_on_task_id_task_id",
 				Unique:  false,
-				Columns: []*schema.Column{TaskDependenciesColumns[3], TaskDependenciesColumns[2]},
+				Columns: []*schema.Column{TaskDependenciesColumns[5], TaskDependenciesColumns[4]},
 			},
 			{
 				Name:    "taskdependency_created_at",
@@ -16,6 +16,7 @@ import (
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/projectcontext"
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/task"
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/taskdependency"
+	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
 )
 
@@ -42,6 +43,7 @@ type ProjectMutation struct {
 	id                 *uuid.UUID
 	title              *string
 	description        *string
+	instructions       *string
 	state              *project.State
 	created_at         *time.Time
 	updated_at         *time.Time
@@ -53,6 +55,7 @@ type ProjectMutation struct {
 	addprogress        *float64
 	created_by         *string
 	updated_by         *string
+	document           *string
 	clearedFields      map[string]struct{}
 	tasks              map[uuid.UUID]struct{}
 	removedtasks       map[uuid.UUID]struct{}
@@ -251,6 +254,55 @@ func (m *ProjectMutation) ResetDescription() {
 	delete(m.clearedFields, project.FieldDescription)
 }
 
+// SetInstructions sets the "instructions" field.
+func (m *ProjectMutation) SetInstructions(s string) {
+	m.instructions = &s
+}
+
+// Instructions returns the value of the "instructions" field in the mutation.
+func (m *ProjectMutation) Instructions() (r string, exists bool) {
+	v := m.instructions
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldInstructions returns the old "instructions" field's value of the Project entity.
+// If the Project object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProjectMutation) OldInstructions(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldInstructions is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldInstructions requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldInstructions: %w", err)
+	}
+	return oldValue.Instructions, nil
+}
+
+// ClearInstructions clears the value of the "instructions" field.
+func (m *ProjectMutation) ClearInstructions() {
+	m.instructions = nil
+	m.clearedFields[project.FieldInstructions] = struct{}{}
+}
+
+// InstructionsCleared returns if the "instructions" field was cleared in this mutation.
+func (m *ProjectMutation) InstructionsCleared() bool {
+	_, ok := m.clearedFields[project.FieldInstructions]
+	return ok
+}
+
+// ResetInstructions resets all changes to the "instructions" field.
+func (m *ProjectMutation) ResetInstructions() {
+	m.instructions = nil
+	delete(m.clearedFields, project.FieldInstructions)
+}
+
 // SetState sets the "state" field.
 func (m *ProjectMutation) SetState(pr project.State) {
 	m.state = &pr
@@ -625,6 +677,55 @@ func (m *ProjectMutation) ResetUpdatedBy() {
 	delete(m.clearedFields, project.FieldUpdatedBy)
 }
 
+// SetDocument sets the "document" field.
+func (m *ProjectMutation) SetDocument(s string) {
+	m.document = &s
+}
+
+// Document returns the value of the "document" field in the mutation.
+func (m *ProjectMutation) Document() (r string, exists bool) {
+	v := m.document
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDocument returns the old "document" field's value of the Project entity.
+// If the Project object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ProjectMutation) OldDocument(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDocument is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDocument requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDocument: %w", err)
+	}
+	return oldValue.Document, nil
+}
+
+// ClearDocument clears the value of the "document" field.
+func (m *ProjectMutation) ClearDocument() {
+	m.document = nil
+	m.clearedFields[project.FieldDocument] = struct{}{}
+}
+
+// DocumentCleared returns if the "document" field was cleared in this mutation.
+func (m *ProjectMutation) DocumentCleared() bool {
+	_, ok := m.clearedFields[project.FieldDocument]
+	return ok
+}
+
+// ResetDocument resets all changes to the "document" field.
+func (m *ProjectMutation) ResetDocument() {
+	m.document = nil
+	delete(m.clearedFields, project.FieldDocument)
+}
+
 // AddTaskIDs adds the "tasks" edge to the Task entity by ids.
 func (m *ProjectMutation) AddTaskIDs(ids ...uuid.UUID) {
 	if m.tasks == nil {
@@ -713,13 +814,16 @@ func (m *ProjectMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *ProjectMutation) Fields() []string {
-	fields := make([]string, 0, 10)
+	fields := make([]string, 0, 12)
 	if m.title != nil {
 		fields = append(fields, project.FieldTitle)
 	}
 	if m.description != nil {
 		fields = append(fields, project.FieldDescription)
 	}
+	if m.instructions != nil {
+		fields = append(fields, project.FieldInstructions)
+	}
 	if m.state != nil {
 		fields = append(fields, project.FieldState)
 	}
@@ -744,6 +848,9 @@ func (m *ProjectMutation) Fields() []string {
 	if m.updated_by != nil {
 		fields = append(fields, project.FieldUpdatedBy)
 	}
+	if m.document != nil {
+		fields = append(fields, project.FieldDocument)
+	}
 	return fields
 }
 
@@ -756,6 +863,8 @@ func (m *ProjectMutation) Field(name string) (ent.Value, bool) {
 		return m.Title()
 	case project.FieldDescription:
 		return m.Description()
+	case project.FieldInstructions:
+		return m.Instructions()
 	case project.FieldState:
 		return m.State()
 	case project.FieldCreatedAt:
@@ -772,6 +881,8 @@ func (m *ProjectMutation) Field(name string) (ent.Value, bool) {
 		return m.CreatedBy()
 	case project.FieldUpdatedBy:
 		return m.UpdatedBy()
+	case project.FieldDocument:
+		return m.Document()
 	}
 	return nil, false
 }
@@ -785,6 +896,8 @@ func (m *ProjectMutation) OldField(ctx context.Context, name string) (ent.Value,
 		return m.OldTitle(ctx)
 	case project.FieldDescription:
 		return m.OldDescription(ctx)
+	case project.FieldInstructions:
+		return m.OldInstructions(ctx)
 	case project.FieldState:
 		return m.OldState(ctx)
 	case project.FieldCreatedAt:
@@ -801,6 +914,8 @@ func (m *ProjectMutation) OldField(ctx context.Context, name string) (ent.Value,
 		return m.OldCreatedBy(ctx)
 	case project.FieldUpdatedBy:
 		return m.OldUpdatedBy(ctx)
+	case project.FieldDocument:
+		return m.OldDocument(ctx)
 	}
 	return nil, fmt.Errorf("unknown Project field %s", name)
 }
@@ -824,6 +939,13 @@ func (m *ProjectMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetDescription(v)
 		return nil
+	case project.FieldInstructions:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetInstructions(v)
+		return nil
 	case project.FieldState:
 		v, ok := value.(project.State)
 		if !ok {
@@ -880,6 +1002,13 @@ func (m *ProjectMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetUpdatedBy(v)
 		return nil
+	case project.FieldDocument:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDocument(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Project field %s", name)
 }
@@ -952,12 +1081,18 @@ func (m *ProjectMutation) ClearedFields() []string {
 	if m.FieldCleared(project.FieldDescription) {
 		fields = append(fields, project.FieldDescription)
 	}
+	if m.FieldCleared(project.FieldInstructions) {
+		fields = append(fields, project.FieldInstructions)
+	}
 	if m.FieldCleared(project.FieldCreatedBy) {
 		fields = append(fields, project.FieldCreatedBy)
 	}
 	if m.FieldCleared(project.FieldUpdatedBy) {
 		fields = append(fields, project.FieldUpdatedBy)
 	}
+	if m.FieldCleared(project.FieldDocument) {
+		fields = append(fields, project.FieldDocument)
+	}
 	return fields
 }
 
@@ -975,12 +1110,18 @@ func (m *ProjectMutation) ClearField(name string) error {
 	case project.FieldDescription:
 		m.ClearDescription()
 		return nil
+	case project.FieldInstructions:
+		m.ClearInstructions()
+		return nil
 	case project.FieldCreatedBy:
 		m.ClearCreatedBy()
 		return nil
 	case project.FieldUpdatedBy:
 		m.ClearUpdatedBy()
 		return nil
+	case project.FieldDocument:
+		m.ClearDocument()
+		return nil
 	}
 	return fmt.Errorf("unknown Project nullable field %s", name)
 }
@@ -995,6 +1136,9 @@ func (m *ProjectMutation) ResetField(name string) error {
 	case project.FieldDescription:
 		m.ResetDescription()
 		return nil
+	case project.FieldInstructions:
+		m.ResetInstructions()
+		return nil
 	case project.FieldState:
 		m.ResetState()
 		return nil
@@ -1019,6 +1163,9 @@ func (m *ProjectMutation) ResetField(name string) error {
 	case project.FieldUpdatedBy:
 		m.ResetUpdatedBy()
 		return nil
+	case project.FieldDocument:
+		m.ResetDocument()
+		return nil
 	}
 	return fmt.Errorf("unknown Project field %s", name)
 }
@@ -1626,34 +1773,52 @@ func (m *ProjectContextMutation) ResetEdge(name string) error {
 // TaskMutation represents an operation that mutates the Task nodes in the graph.
 type TaskMutation struct {
 	config
-	op              Op
-	typ             string
-	id              *uuid.UUID
-	title           *string
-	description     *string
-	state           *task.State
-	priority        *task.Priority
-	complexity      *int
-	addcomplexity   *int
-	depth           *int
-	adddepth        *int
-	estimate        *int64
-	addestimate     *int64
-	assigned_agent  *uuid.UUID
-	created_at      *time.Time
-	updated_at      *time.Time
-	completed_at    *time.Time
-	clearedFields   map[string]struct{}
-	project         *uuid.UUID
-	clearedproject  bool
-	parent          *uuid.UUID
-	clearedparent   bool
-	children        map[uuid.UUID]struct{}
-	removedchildren map[uuid.UUID]struct{}
-	clearedchildren bool
-	done            bool
-	oldValue        func(context.Context) (*Task, error)
-	predicates      []predicate.Task
+	op                        Op
+	typ                       string
+	id                        *uuid.UUID
+	title                     *string
+	description               *string
+	instructions              *string
+	state                     *task.State
+	priority                  *task.Priority
+	complexity                *int
+	addcomplexity             *int
+	depth                     *int
+	adddepth                  *int
+	estimate                  *int64
+	addestimate               *int64
+	assigned_agent            *uuid.UUID
+	created_at                *time.Time
+	updated_at                *time.Time
+	completed_at              *time.Time
+	tags                      *[]string
+	appendtags                []string
+	acceptance_criteria       *[]types.AcceptanceCriterion
+	appendacceptance_criteria []types.AcceptanceCriterion
+	effort_log                *[]types.EffortEntry
+	appendeffort_log          []types.EffortEntry
+	review                    **types.TaskReview
+	keep_complexity           *bool
+	created_by                *string
+	updated_by                *string
+	position                  *int
+	addposition               *int
+	due_date                  *time.Time
+	blocker                   **types.TaskBlocker
+	check                     **types.TaskCheck
+	handoffs                  *[]types.TaskHandoff
+	appendhandoffs            []types.TaskHandoff
+	clearedFields             map[string]struct{}
+	project                   *uuid.UUID
+	clearedproject            bool
+	parent                    *uuid.UUID
+	clearedparent             bool
+	children                  map[uuid.UUID]struct{}
+	removedchildren           map[uuid.UUID]struct{}
+	clearedchildren           bool
+	done                      bool
+	oldValue                  func(context.Context) (*Task, error)
+	predicates                []predicate.Task
 }
 
 var _ ent.Mutation = (*TaskMutation)(nil)
@@ -1930,6 +2095,55 @@ func (m *TaskMutation) ResetDescription() {
 	delete(m.clearedFields, task.FieldDescription)
 }
 
+// SetInstructions sets the "instructions" field.
+func (m *TaskMutation) SetInstructions(s string) {
+	m.instructions = &s
+}
+
+// Instructions returns the value of the "instructions" field in the mutation.
+func (m *TaskMutation) Instructions() (r string, exists bool) {
+	v := m.instructions
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldInstructions returns the old "instructions" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldInstructions(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldInstructions is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldInstructions requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldInstructions: %w", err)
+	}
+	return oldValue.Instructions, nil
+}
+
+// ClearInstructions clears the value of the "instructions" field.
+func (m *TaskMutation) ClearInstructions() {
+	m.instructions = nil
+	m.clearedFields[task.FieldInstructions] = struct{}{}
+}
+
+// InstructionsCleared returns if the "instructions" field was cleared in this mutation.
+func (m *TaskMutation) InstructionsCleared() bool {
+	_, ok := m.clearedFields[task.FieldInstructions]
+	return ok
+}
+
+// ResetInstructions resets all changes to the "instructions" field.
+func (m *TaskMutation) ResetInstructions() {
+	m.instructions = nil
+	delete(m.clearedFields, task.FieldInstructions)
+}
+
 // SetState sets the "state" field.
 func (m *TaskMutation) SetState(t task.State) {
 	m.state = &t
@@ -2354,161 +2568,810 @@ func (m *TaskMutation) ResetCompletedAt() {
 	delete(m.clearedFields, task.FieldCompletedAt)
 }
 
-// ClearProject clears the "project" edge to the Project entity.
-func (m *TaskMutation) ClearProject() {
-	m.clearedproject = true
-	m.clearedFields[task.FieldProjectID] = struct{}{}
+// SetTags sets the "tags" field.
+func (m *TaskMutation) SetTags(s []string) {
+	m.tags = &s
+	m.appendtags = nil
 }
 
-// ProjectCleared reports if the "project" edge to the Project entity was cleared.
-func (m *TaskMutation) ProjectCleared() bool {
-	return m.clearedproject
+// Tags returns the value of the "tags" field in the mutation.
+func (m *TaskMutation) Tags() (r []string, exists bool) {
+	v := m.tags
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ProjectIDs returns the "project" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ProjectID instead. It exists only for internal usage by the builders.
-func (m *TaskMutation) ProjectIDs() (ids []uuid.UUID) {
-	if id := m.project; id != nil {
-		ids = append(ids, *id)
+// OldTags returns the old "tags" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldTags(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTags is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTags requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTags: %w", err)
+	}
+	return oldValue.Tags, nil
 }
 
-// ResetProject resets all changes to the "project" edge.
-func (m *TaskMutation) ResetProject() {
-	m.project = nil
-	m.clearedproject = false
+// AppendTags adds s to the "tags" field.
+func (m *TaskMutation) AppendTags(s []string) {
+	m.appendtags = append(m.appendtags, s...)
 }
 
-// ClearParent clears the "parent" edge to the Task entity.
-func (m *TaskMutation) ClearParent() {
-	m.clearedparent = true
-	m.clearedFields[task.FieldParentID] = struct{}{}
+// AppendedTags returns the list of values that were appended to the "tags" field in this mutation.
+func (m *TaskMutation) AppendedTags() ([]string, bool) {
+	if len(m.appendtags) == 0 {
+		return nil, false
+	}
+	return m.appendtags, true
 }
 
-// ParentCleared reports if the "parent" edge to the Task entity was cleared.
-func (m *TaskMutation) ParentCleared() bool {
-	return m.ParentIDCleared() || m.clearedparent
+// ClearTags clears the value of the "tags" field.
+func (m *TaskMutation) ClearTags() {
+	m.tags = nil
+	m.appendtags = nil
+	m.clearedFields[task.FieldTags] = struct{}{}
 }
 
-// ParentIDs returns the "parent" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ParentID instead. It exists only for internal usage by the builders.
-func (m *TaskMutation) ParentIDs() (ids []uuid.UUID) {
-	if id := m.parent; id != nil {
-		ids = append(ids, *id)
-	}
-	return
+// TagsCleared returns if the "tags" field was cleared in this mutation.
+func (m *TaskMutation) TagsCleared() bool {
+	_, ok := m.clearedFields[task.FieldTags]
+	return ok
 }
 
-// ResetParent resets all changes to the "parent" edge.
-func (m *TaskMutation) ResetParent() {
-	m.parent = nil
-	m.clearedparent = false
+// ResetTags resets all changes to the "tags" field.
+func (m *TaskMutation) ResetTags() {
+	m.tags = nil
+	m.appendtags = nil
+	delete(m.clearedFields, task.FieldTags)
 }
 
-// AddChildIDs adds the "children" edge to the Task entity by ids.
-func (m *TaskMutation) AddChildIDs(ids ...uuid.UUID) {
-	if m.children == nil {
-		m.children = make(map[uuid.UUID]struct{})
-	}
-	for i := range ids {
-		m.children[ids[i]] = struct{}{}
+// SetAcceptanceCriteria sets the "acceptance_criteria" field.
+func (m *TaskMutation) SetAcceptanceCriteria(tc []types.AcceptanceCriterion) {
+	m.acceptance_criteria = &tc
+	m.appendacceptance_criteria = nil
+}
+
+// AcceptanceCriteria returns the value of the "acceptance_criteria" field in the mutation.
+func (m *TaskMutation) AcceptanceCriteria() (r []types.AcceptanceCriterion, exists bool) {
+	v := m.acceptance_criteria
+	if v == nil {
+		return
 	}
+	return *v, true
 }
 
-// ClearChildren clears the "children" edge to the Task entity.
-func (m *TaskMutation) ClearChildren() {
-	m.clearedchildren = true
+// OldAcceptanceCriteria returns the old "acceptance_criteria" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldAcceptanceCriteria(ctx context.Context) (v []types.AcceptanceCriterion, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAcceptanceCriteria is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAcceptanceCriteria requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAcceptanceCriteria: %w", err)
+	}
+	return oldValue.AcceptanceCriteria, nil
 }
 
-// ChildrenCleared reports if the "children" edge to the Task entity was cleared.
-func (m *TaskMutation) ChildrenCleared() bool {
-	return m.clearedchildren
+// AppendAcceptanceCriteria adds tc to the "acceptance_criteria" field.
+func (m *TaskMutation) AppendAcceptanceCriteria(tc []types.AcceptanceCriterion) {
+	m.appendacceptance_criteria = append(m.appendacceptance_criteria, tc...)
 }
 
-// RemoveChildIDs removes the "children" edge to the Task entity by IDs.
-func (m *TaskMutation) RemoveChildIDs(ids ...uuid.UUID) {
-	if m.removedchildren == nil {
-		m.removedchildren = make(map[uuid.UUID]struct{})
-	}
-	for i := range ids {
-		delete(m.children, ids[i])
-		m.removedchildren[ids[i]] = struct{}{}
+// AppendedAcceptanceCriteria returns the list of values that were appended to the "acceptance_criteria" field in this mutation.
+func (m *TaskMutation) AppendedAcceptanceCriteria() ([]types.AcceptanceCriterion, bool) {
+	if len(m.appendacceptance_criteria) == 0 {
+		return nil, false
 	}
+	return m.appendacceptance_criteria, true
 }
 
-// RemovedChildren returns the removed IDs of the "children" edge to the Task entity.
-func (m *TaskMutation) RemovedChildrenIDs() (ids []uuid.UUID) {
-	for id := range m.removedchildren {
-		ids = append(ids, id)
-	}
-	return
+// ClearAcceptanceCriteria clears the value of the "acceptance_criteria" field.
+func (m *TaskMutation) ClearAcceptanceCriteria() {
+	m.acceptance_criteria = nil
+	m.appendacceptance_criteria = nil
+	m.clearedFields[task.FieldAcceptanceCriteria] = struct{}{}
 }
 
-// ChildrenIDs returns the "children" edge IDs in the mutation.
-func (m *TaskMutation) ChildrenIDs() (ids []uuid.UUID) {
-	for id := range m.children {
-		ids = append(ids, id)
+// AcceptanceCriteriaCleared returns if the "acceptance_criteria" field was cleared in this mutation.
+func (m *TaskMutation) AcceptanceCriteriaCleared() bool {
+	_, ok := m.clearedFields[task.FieldAcceptanceCriteria]
+	return ok
+}
+
+// ResetAcceptanceCriteria resets all changes to the "acceptance_criteria" field.
+func (m *TaskMutation) ResetAcceptanceCriteria() {
+	m.acceptance_criteria = nil
+	m.appendacceptance_criteria = nil
+	delete(m.clearedFields, task.FieldAcceptanceCriteria)
+}
+
+// SetEffortLog sets the "effort_log" field.
+func (m *TaskMutation) SetEffortLog(te []types.EffortEntry) {
+	m.effort_log = &te
+	m.appendeffort_log = nil
+}
+
+// EffortLog returns the value of the "effort_log" field in the mutation.
+func (m *TaskMutation) EffortLog() (r []types.EffortEntry, exists bool) {
+	v := m.effort_log
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// ResetChildren resets all changes to the "children" edge.
-func (m *TaskMutation) ResetChildren() {
-	m.children = nil
-	m.clearedchildren = false
-	m.removedchildren = nil
+// OldEffortLog returns the old "effort_log" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldEffortLog(ctx context.Context) (v []types.EffortEntry, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEffortLog is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEffortLog requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEffortLog: %w", err)
+	}
+	return oldValue.EffortLog, nil
 }
 
-// Where appends a list predicates to the TaskMutation builder.
-func (m *TaskMutation) Where(ps ...predicate.Task) {
-	m.predicates = append(m.predicates, ps...)
+// AppendEffortLog adds te to the "effort_log" field.
+func (m *TaskMutation) AppendEffortLog(te []types.EffortEntry) {
+	m.appendeffort_log = append(m.appendeffort_log, te...)
 }
 
-// WhereP appends storage-level predicates to the TaskMutation builder. Using this method,
-// users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *TaskMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Task, len(ps))
-	for i := range ps {
-		p[i] = ps[i]
+// AppendedEffortLog returns the list of values that were appended to the "effort_log" field in this mutation.
+func (m *TaskMutation) AppendedEffortLog() ([]types.EffortEntry, bool) {
+	if len(m.appendeffort_log) == 0 {
+		return nil, false
 	}
-	m.Where(p...)
+	return m.appendeffort_log, true
 }
 
-// Op returns the operation name.
-func (m *TaskMutation) Op() Op {
-	return m.op
+// ClearEffortLog clears the value of the "effort_log" field.
+func (m *TaskMutation) ClearEffortLog() {
+	m.effort_log = nil
+	m.appendeffort_log = nil
+	m.clearedFields[task.FieldEffortLog] = struct{}{}
 }
 
-// SetOp allows setting the mutation operation.
-func (m *TaskMutation) SetOp(op Op) {
-	m.op = op
+// EffortLogCleared returns if the "effort_log" field was cleared in this mutation.
+func (m *TaskMutation) EffortLogCleared() bool {
+	_, ok := m.clearedFields[task.FieldEffortLog]
+	return ok
 }
 
-// Type returns the node type of this mutation (Task).
-func (m *TaskMutation) Type() string {
-	return m.typ
+// ResetEffortLog resets all changes to the "effort_log" field.
+func (m *TaskMutation) ResetEffortLog() {
+	m.effort_log = nil
+	m.appendeffort_log = nil
+	delete(m.clearedFields, task.FieldEffortLog)
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *TaskMutation) Fields() []string {
-	fields := make([]string, 0, 13)
-	if m.project != nil {
-		fields = append(fields, task.FieldProjectID)
+// SetReview sets the "review" field.
+func (m *TaskMutation) SetReview(tr *types.TaskReview) {
+	m.review = &tr
+}
+
+// Review returns the value of the "review" field in the mutation.
+func (m *TaskMutation) Review() (r *types.TaskReview, exists bool) {
+	v := m.review
+	if v == nil {
+		return
 	}
-	if m.parent != nil {
-		fields = append(fields, task.FieldParentID)
+	return *v, true
+}
+
+// OldReview returns the old "review" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldReview(ctx context.Context) (v *types.TaskReview, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldReview is only allowed on UpdateOne operations")
 	}
-	if m.title != nil {
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldReview requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldReview: %w", err)
+	}
+	return oldValue.Review, nil
+}
+
+// ClearReview clears the value of the "review" field.
+func (m *TaskMutation) ClearReview() {
+	m.review = nil
+	m.clearedFields[task.FieldReview] = struct{}{}
+}
+
+// ReviewCleared returns if the "review" field was cleared in this mutation.
+func (m *TaskMutation) ReviewCleared() bool {
+	_, ok := m.clearedFields[task.FieldReview]
+	return ok
+}
+
+// ResetReview resets all changes to the "review" field.
+func (m *TaskMutation) ResetReview() {
+	m.review = nil
+	delete(m.clearedFields, task.FieldReview)
+}
+
+// SetKeepComplexity sets the "keep_complexity" field.
+func (m *TaskMutation) SetKeepComplexity(b bool) {
+	m.keep_complexity = &b
+}
+
+// KeepComplexity returns the value of the "keep_complexity" field in the mutation.
+func (m *TaskMutation) KeepComplexity() (r bool, exists bool) {
+	v := m.keep_complexity
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldKeepComplexity returns the old "keep_complexity" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldKeepComplexity(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldKeepComplexity is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldKeepComplexity requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldKeepComplexity: %w", err)
+	}
+	return oldValue.KeepComplexity, nil
+}
+
+// ResetKeepComplexity resets all changes to the "keep_complexity" field.
+func (m *TaskMutation) ResetKeepComplexity() {
+	m.keep_complexity = nil
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (m *TaskMutation) SetCreatedBy(s string) {
+	m.created_by = &s
+}
+
+// CreatedBy returns the value of the "created_by" field in the mutation.
+func (m *TaskMutation) CreatedBy() (r string, exists bool) {
+	v := m.created_by
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedBy returns the old "created_by" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldCreatedBy(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedBy: %w", err)
+	}
+	return oldValue.CreatedBy, nil
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (m *TaskMutation) ClearCreatedBy() {
+	m.created_by = nil
+	m.clearedFields[task.FieldCreatedBy] = struct{}{}
+}
+
+// CreatedByCleared returns if the "created_by" field was cleared in this mutation.
+func (m *TaskMutation) CreatedByCleared() bool {
+	_, ok := m.clearedFields[task.FieldCreatedBy]
+	return ok
+}
+
+// ResetCreatedBy resets all changes to the "created_by" field.
+func (m *TaskMutation) ResetCreatedBy() {
+	m.created_by = nil
+	delete(m.clearedFields, task.FieldCreatedBy)
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (m *TaskMutation) SetUpdatedBy(s string) {
+	m.updated_by = &s
+}
+
+// UpdatedBy returns the value of the "updated_by" field in the mutation.
+func (m *TaskMutation) UpdatedBy() (r string, exists bool) {
+	v := m.updated_by
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedBy returns the old "updated_by" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldUpdatedBy(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedBy: %w", err)
+	}
+	return oldValue.UpdatedBy, nil
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (m *TaskMutation) ClearUpdatedBy() {
+	m.updated_by = nil
+	m.clearedFields[task.FieldUpdatedBy] = struct{}{}
+}
+
+// UpdatedByCleared returns if the "updated_by" field was cleared in this mutation.
+func (m *TaskMutation) UpdatedByCleared() bool {
+	_, ok := m.clearedFields[task.FieldUpdatedBy]
+	return ok
+}
+
+// ResetUpdatedBy resets all changes to the "updated_by" field.
+func (m *TaskMutation) ResetUpdatedBy() {
+	m.updated_by = nil
+	delete(m.clearedFields, task.FieldUpdatedBy)
+}
+
+// SetPosition sets the "position" field.
+func (m *TaskMutation) SetPosition(i int) {
+	m.position = &i
+	m.addposition = nil
+}
+
+// Position returns the value of the "position" field in the mutation.
+func (m *TaskMutation) Position() (r int, exists bool) {
+	v := m.position
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPosition returns the old "position" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldPosition(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPosition is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPosition requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPosition: %w", err)
+	}
+	return oldValue.Position, nil
+}
+
+// AddPosition adds i to the "position" field.
+func (m *TaskMutation) AddPosition(i int) {
+	if m.addposition != nil {
+		*m.addposition += i
+	} else {
+		m.addposition = &i
+	}
+}
+
+// AddedPosition returns the value that was added to the "position" field in this mutation.
+func (m *TaskMutation) AddedPosition() (r int, exists bool) {
+	v := m.addposition
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetPosition resets all changes to the "position" field.
+func (m *TaskMutation) ResetPosition() {
+	m.position = nil
+	m.addposition = nil
+}
+
+// SetDueDate sets the "due_date" field.
+func (m *TaskMutation) SetDueDate(t time.Time) {
+	m.due_date = &t
+}
+
+// DueDate returns the value of the "due_date" field in the mutation.
+func (m *TaskMutation) DueDate() (r time.Time, exists bool) {
+	v := m.due_date
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDueDate returns the old "due_date" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldDueDate(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDueDate is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDueDate requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDueDate: %w", err)
+	}
+	return oldValue.DueDate, nil
+}
+
+// ClearDueDate clears the value of the "due_date" field.
+func (m *TaskMutation) ClearDueDate() {
+	m.due_date = nil
+	m.clearedFields[task.FieldDueDate] = struct{}{}
+}
+
+// DueDateCleared returns if the "due_date" field was cleared in this mutation.
+func (m *TaskMutation) DueDateCleared() bool {
+	_, ok := m.clearedFields[task.FieldDueDate]
+	return ok
+}
+
+// ResetDueDate resets all changes to the "due_date" field.
+func (m *TaskMutation) ResetDueDate() {
+	m.due_date = nil
+	delete(m.clearedFields, task.FieldDueDate)
+}
+
+// SetBlocker sets the "blocker" field.
+func (m *TaskMutation) SetBlocker(tb *types.TaskBlocker) {
+	m.blocker = &tb
+}
+
+// Blocker returns the value of the "blocker" field in the mutation.
+func (m *TaskMutation) Blocker() (r *types.TaskBlocker, exists bool) {
+	v := m.blocker
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldBlocker returns the old "blocker" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldBlocker(ctx context.Context) (v *types.TaskBlocker, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBlocker is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBlocker requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBlocker: %w", err)
+	}
+	return oldValue.Blocker, nil
+}
+
+// ClearBlocker clears the value of the "blocker" field.
+func (m *TaskMutation) ClearBlocker() {
+	m.blocker = nil
+	m.clearedFields[task.FieldBlocker] = struct{}{}
+}
+
+// BlockerCleared returns if the "blocker" field was cleared in this mutation.
+func (m *TaskMutation) BlockerCleared() bool {
+	_, ok := m.clearedFields[task.FieldBlocker]
+	return ok
+}
+
+// ResetBlocker resets all changes to the "blocker" field.
+func (m *TaskMutation) ResetBlocker() {
+	m.blocker = nil
+	delete(m.clearedFields, task.FieldBlocker)
+}
+
+// SetCheck sets the "check" field.
+func (m *TaskMutation) SetCheck(tc *types.TaskCheck) {
+	m.check = &tc
+}
+
+// Check returns the value of the "check" field in the mutation.
+func (m *TaskMutation) Check() (r *types.TaskCheck, exists bool) {
+	v := m.check
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCheck returns the old "check" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldCheck(ctx context.Context) (v *types.TaskCheck, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCheck is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCheck requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCheck: %w", err)
+	}
+	return oldValue.Check, nil
+}
+
+// ClearCheck clears the value of the "check" field.
+func (m *TaskMutation) ClearCheck() {
+	m.check = nil
+	m.clearedFields[task.FieldCheck] = struct{}{}
+}
+
+// CheckCleared returns if the "check" field was cleared in this mutation.
+func (m *TaskMutation) CheckCleared() bool {
+	_, ok := m.clearedFields[task.FieldCheck]
+	return ok
+}
+
+// ResetCheck resets all changes to the "check" field.
+func (m *TaskMutation) ResetCheck() {
+	m.check = nil
+	delete(m.clearedFields, task.FieldCheck)
+}
+
+// SetHandoffs sets the "handoffs" field.
+func (m *TaskMutation) SetHandoffs(th []types.TaskHandoff) {
+	m.handoffs = &th
+	m.appendhandoffs = nil
+}
+
+// Handoffs returns the value of the "handoffs" field in the mutation.
+func (m *TaskMutation) Handoffs() (r []types.TaskHandoff, exists bool) {
+	v := m.handoffs
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldHandoffs returns the old "handoffs" field's value of the Task entity.
+// If the Task object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskMutation) OldHandoffs(ctx context.Context) (v []types.TaskHandoff, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHandoffs is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHandoffs requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHandoffs: %w", err)
+	}
+	return oldValue.Handoffs, nil
+}
+
+// AppendHandoffs adds th to the "handoffs" field.
+func (m *TaskMutation) AppendHandoffs(th []types.TaskHandoff) {
+	m.appendhandoffs = append(m.appendhandoffs, th...)
+}
+
+// AppendedHandoffs returns the list of values that were appended to the "handoffs" field in this mutation.
+func (m *TaskMutation) AppendedHandoffs() ([]types.TaskHandoff, bool) {
+	if len(m.appendhandoffs) == 0 {
+		return nil, false
+	}
+	return m.appendhandoffs, true
+}
+
+// ClearHandoffs clears the value of the "handoffs" field.
+func (m *TaskMutation) ClearHandoffs() {
+	m.handoffs = nil
+	m.appendhandoffs = nil
+	m.clearedFields[task.FieldHandoffs] = struct{}{}
+}
+
+// HandoffsCleared returns if the "handoffs" field was cleared in this mutation.
+func (m *TaskMutation) HandoffsCleared() bool {
+	_, ok := m.clearedFields[task.FieldHandoffs]
+	return ok
+}
+
+// ResetHandoffs resets all changes to the "handoffs" field.
+func (m *TaskMutation) ResetHandoffs() {
+	m.handoffs = nil
+	m.appendhandoffs = nil
+	delete(m.clearedFields, task.FieldHandoffs)
+}
+
+// ClearProject clears the "project" edge to the Project entity.
+func (m *TaskMutation) ClearProject() {
+	m.clearedproject = true
+	m.clearedFields[task.FieldProjectID] = struct{}{}
+}
+
+// ProjectCleared reports if the "project" edge to the Project entity was cleared.
+func (m *TaskMutation) ProjectCleared() bool {
+	return m.clearedproject
+}
+
+// ProjectIDs returns the "project" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ProjectID instead. It exists only for internal usage by the builders.
+func (m *TaskMutation) ProjectIDs() (ids []uuid.UUID) {
+	if id := m.project; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetProject resets all changes to the "project" edge.
+func (m *TaskMutation) ResetProject() {
+	m.project = nil
+	m.clearedproject = false
+}
+
+// ClearParent clears the "parent" edge to the Task entity.
+func (m *TaskMutation) ClearParent() {
+	m.clearedparent = true
+	m.clearedFields[task.FieldParentID] = struct{}{}
+}
+
+// ParentCleared reports if the "parent" edge to the Task entity was cleared.
+func (m *TaskMutation) ParentCleared() bool {
+	return m.ParentIDCleared() || m.clearedparent
+}
+
+// ParentIDs returns the "parent" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ParentID instead. It exists only for internal usage by the builders.
+func (m *TaskMutation) ParentIDs() (ids []uuid.UUID) {
+	if id := m.parent; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetParent resets all changes to the "parent" edge.
+func (m *TaskMutation) ResetParent() {
+	m.parent = nil
+	m.clearedparent = false
+}
+
+// AddChildIDs adds the "children" edge to the Task entity by ids.
+func (m *TaskMutation) AddChildIDs(ids ...uuid.UUID) {
+	if m.children == nil {
+		m.children = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		m.children[ids[i]] = struct{}{}
+	}
+}
+
+// ClearChildren clears the "children" edge to the Task entity.
+func (m *TaskMutation) ClearChildren() {
+	m.clearedchildren = true
+}
+
+// ChildrenCleared reports if the "children" edge to the Task entity was cleared.
+func (m *TaskMutation) ChildrenCleared() bool {
+	return m.clearedchildren
+}
+
+// RemoveChildIDs removes the "children" edge to the Task entity by IDs.
+func (m *TaskMutation) RemoveChildIDs(ids ...uuid.UUID) {
+	if m.removedchildren == nil {
+		m.removedchildren = make(map[uuid.UUID]struct{})
+	}
+	for i := range ids {
+		delete(m.children, ids[i])
+		m.removedchildren[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedChildren returns the removed IDs of the "children" edge to the Task entity.
+func (m *TaskMutation) RemovedChildrenIDs() (ids []uuid.UUID) {
+	for id := range m.removedchildren {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ChildrenIDs returns the "children" edge IDs in the mutation.
+func (m *TaskMutation) ChildrenIDs() (ids []uuid.UUID) {
+	for id := range m.children {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetChildren resets all changes to the "children" edge.
+func (m *TaskMutation) ResetChildren() {
+	m.children = nil
+	m.clearedchildren = false
+	m.removedchildren = nil
+}
+
+// Where appends a list predicates to the TaskMutation builder.
+func (m *TaskMutation) Where(ps ...predicate.Task) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the TaskMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *TaskMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Task, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *TaskMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *TaskMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Task).
+func (m *TaskMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *TaskMutation) Fields() []string {
+	fields := make([]string, 0, 26)
+	if m.project != nil {
+		fields = append(fields, task.FieldProjectID)
+	}
+	if m.parent != nil {
+		fields = append(fields, task.FieldParentID)
+	}
+	if m.title != nil {
 		fields = append(fields, task.FieldTitle)
 	}
 	if m.description != nil {
 		fields = append(fields, task.FieldDescription)
 	}
+	if m.instructions != nil {
+		fields = append(fields, task.FieldInstructions)
+	}
 	if m.state != nil {
 		fields = append(fields, task.FieldState)
 	}
@@ -2536,6 +3399,42 @@ func (m *TaskMutation) Fields() []string {
 	if m.completed_at != nil {
 		fields = append(fields, task.FieldCompletedAt)
 	}
+	if m.tags != nil {
+		fields = append(fields, task.FieldTags)
+	}
+	if m.acceptance_criteria != nil {
+		fields = append(fields, task.FieldAcceptanceCriteria)
+	}
+	if m.effort_log != nil {
+		fields = append(fields, task.FieldEffortLog)
+	}
+	if m.review != nil {
+		fields = append(fields, task.FieldReview)
+	}
+	if m.keep_complexity != nil {
+		fields = append(fields, task.FieldKeepComplexity)
+	}
+	if m.created_by != nil {
+		fields = append(fields, task.FieldCreatedBy)
+	}
+	if m.updated_by != nil {
+		fields = append(fields, task.FieldUpdatedBy)
+	}
+	if m.position != nil {
+		fields = append(fields, task.FieldPosition)
+	}
+	if m.due_date != nil {
+		fields = append(fields, task.FieldDueDate)
+	}
+	if m.blocker != nil {
+		fields = append(fields, task.FieldBlocker)
+	}
+	if m.check != nil {
+		fields = append(fields, task.FieldCheck)
+	}
+	if m.handoffs != nil {
+		fields = append(fields, task.FieldHandoffs)
+	}
 	return fields
 }
 
@@ -2552,6 +3451,8 @@ func (m *TaskMutation) Field(name string) (ent.Value, bool) {
 		return m.Title()
 	case task.FieldDescription:
 		return m.Description()
+	case task.FieldInstructions:
+		return m.Instructions()
 	case task.FieldState:
 		return m.State()
 	case task.FieldPriority:
@@ -2570,6 +3471,30 @@ func (m *TaskMutation) Field(name string) (ent.Value, bool) {
 		return m.UpdatedAt()
 	case task.FieldCompletedAt:
 		return m.CompletedAt()
+	case task.FieldTags:
+		return m.Tags()
+	case task.FieldAcceptanceCriteria:
+		return m.AcceptanceCriteria()
+	case task.FieldEffortLog:
+		return m.EffortLog()
+	case task.FieldReview:
+		return m.Review()
+	case task.FieldKeepComplexity:
+		return m.KeepComplexity()
+	case task.FieldCreatedBy:
+		return m.CreatedBy()
+	case task.FieldUpdatedBy:
+		return m.UpdatedBy()
+	case task.FieldPosition:
+		return m.Position()
+	case task.FieldDueDate:
+		return m.DueDate()
+	case task.FieldBlocker:
+		return m.Blocker()
+	case task.FieldCheck:
+		return m.Check()
+	case task.FieldHandoffs:
+		return m.Handoffs()
 	}
 	return nil, false
 }
@@ -2587,6 +3512,8 @@ func (m *TaskMutation) OldField(ctx context.Context, name string) (ent.Value, er
 		return m.OldTitle(ctx)
 	case task.FieldDescription:
 		return m.OldDescription(ctx)
+	case task.FieldInstructions:
+		return m.OldInstructions(ctx)
 	case task.FieldState:
 		return m.OldState(ctx)
 	case task.FieldPriority:
@@ -2605,6 +3532,30 @@ func (m *TaskMutation) OldField(ctx context.Context, name string) (ent.Value, er
 		return m.OldUpdatedAt(ctx)
 	case task.FieldCompletedAt:
 		return m.OldCompletedAt(ctx)
+	case task.FieldTags:
+		return m.OldTags(ctx)
+	case task.FieldAcceptanceCriteria:
+		return m.OldAcceptanceCriteria(ctx)
+	case task.FieldEffortLog:
+		return m.OldEffortLog(ctx)
+	case task.FieldReview:
+		return m.OldReview(ctx)
+	case task.FieldKeepComplexity:
+		return m.OldKeepComplexity(ctx)
+	case task.FieldCreatedBy:
+		return m.OldCreatedBy(ctx)
+	case task.FieldUpdatedBy:
+		return m.OldUpdatedBy(ctx)
+	case task.FieldPosition:
+		return m.OldPosition(ctx)
+	case task.FieldDueDate:
+		return m.OldDueDate(ctx)
+	case task.FieldBlocker:
+		return m.OldBlocker(ctx)
+	case task.FieldCheck:
+		return m.OldCheck(ctx)
+	case task.FieldHandoffs:
+		return m.OldHandoffs(ctx)
 	}
 	return nil, fmt.Errorf("unknown Task field %s", name)
 }
@@ -2642,6 +3593,13 @@ func (m *TaskMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetDescription(v)
 		return nil
+	case task.FieldInstructions:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetInstructions(v)
+		return nil
 	case task.FieldState:
 		v, ok := value.(task.State)
 		if !ok {
@@ -2705,6 +3663,90 @@ func (m *TaskMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetCompletedAt(v)
 		return nil
+	case task.FieldTags:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTags(v)
+		return nil
+	case task.FieldAcceptanceCriteria:
+		v, ok := value.([]types.AcceptanceCriterion)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAcceptanceCriteria(v)
+		return nil
+	case task.FieldEffortLog:
+		v, ok := value.([]types.EffortEntry)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEffortLog(v)
+		return nil
+	case task.FieldReview:
+		v, ok := value.(*types.TaskReview)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetReview(v)
+		return nil
+	case task.FieldKeepComplexity:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetKeepComplexity(v)
+		return nil
+	case task.FieldCreatedBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedBy(v)
+		return nil
+	case task.FieldUpdatedBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedBy(v)
+		return nil
+	case task.FieldPosition:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPosition(v)
+		return nil
+	case task.FieldDueDate:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDueDate(v)
+		return nil
+	case task.FieldBlocker:
+		v, ok := value.(*types.TaskBlocker)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBlocker(v)
+		return nil
+	case task.FieldCheck:
+		v, ok := value.(*types.TaskCheck)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCheck(v)
+		return nil
+	case task.FieldHandoffs:
+		v, ok := value.([]types.TaskHandoff)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHandoffs(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Task field %s", name)
 }
@@ -2722,6 +3764,9 @@ func (m *TaskMutation) AddedFields() []string {
 	if m.addestimate != nil {
 		fields = append(fields, task.FieldEstimate)
 	}
+	if m.addposition != nil {
+		fields = append(fields, task.FieldPosition)
+	}
 	return fields
 }
 
@@ -2736,6 +3781,8 @@ func (m *TaskMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedDepth()
 	case task.FieldEstimate:
 		return m.AddedEstimate()
+	case task.FieldPosition:
+		return m.AddedPosition()
 	}
 	return nil, false
 }
@@ -2766,6 +3813,13 @@ func (m *TaskMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddEstimate(v)
 		return nil
+	case task.FieldPosition:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPosition(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Task numeric field %s", name)
 }
@@ -2780,6 +3834,9 @@ func (m *TaskMutation) ClearedFields() []string {
 	if m.FieldCleared(task.FieldDescription) {
 		fields = append(fields, task.FieldDescription)
 	}
+	if m.FieldCleared(task.FieldInstructions) {
+		fields = append(fields, task.FieldInstructions)
+	}
 	if m.FieldCleared(task.FieldEstimate) {
 		fields = append(fields, task.FieldEstimate)
 	}
@@ -2789,6 +3846,36 @@ func (m *TaskMutation) ClearedFields() []string {
 	if m.FieldCleared(task.FieldCompletedAt) {
 		fields = append(fields, task.FieldCompletedAt)
 	}
+	if m.FieldCleared(task.FieldTags) {
+		fields = append(fields, task.FieldTags)
+	}
+	if m.FieldCleared(task.FieldAcceptanceCriteria) {
+		fields = append(fields, task.FieldAcceptanceCriteria)
+	}
+	if m.FieldCleared(task.FieldEffortLog) {
+		fields = append(fields, task.FieldEffortLog)
+	}
+	if m.FieldCleared(task.FieldReview) {
+		fields = append(fields, task.FieldReview)
+	}
+	if m.FieldCleared(task.FieldCreatedBy) {
+		fields = append(fields, task.FieldCreatedBy)
+	}
+	if m.FieldCleared(task.FieldUpdatedBy) {
+		fields = append(fields, task.FieldUpdatedBy)
+	}
+	if m.FieldCleared(task.FieldDueDate) {
+		fields = append(fields, task.FieldDueDate)
+	}
+	if m.FieldCleared(task.FieldBlocker) {
+		fields = append(fields, task.FieldBlocker)
+	}
+	if m.FieldCleared(task.FieldCheck) {
+		fields = append(fields, task.FieldCheck)
+	}
+	if m.FieldCleared(task.FieldHandoffs) {
+		fields = append(fields, task.FieldHandoffs)
+	}
 	return fields
 }
 
@@ -2809,6 +3896,9 @@ func (m *TaskMutation) ClearField(name string) error {
 	case task.FieldDescription:
 		m.ClearDescription()
 		return nil
+	case task.FieldInstructions:
+		m.ClearInstructions()
+		return nil
 	case task.FieldEstimate:
 		m.ClearEstimate()
 		return nil
@@ -2818,6 +3908,36 @@ func (m *TaskMutation) ClearField(name string) error {
 	case task.FieldCompletedAt:
 		m.ClearCompletedAt()
 		return nil
+	case task.FieldTags:
+		m.ClearTags()
+		return nil
+	case task.FieldAcceptanceCriteria:
+		m.ClearAcceptanceCriteria()
+		return nil
+	case task.FieldEffortLog:
+		m.ClearEffortLog()
+		return nil
+	case task.FieldReview:
+		m.ClearReview()
+		return nil
+	case task.FieldCreatedBy:
+		m.ClearCreatedBy()
+		return nil
+	case task.FieldUpdatedBy:
+		m.ClearUpdatedBy()
+		return nil
+	case task.FieldDueDate:
+		m.ClearDueDate()
+		return nil
+	case task.FieldBlocker:
+		m.ClearBlocker()
+		return nil
+	case task.FieldCheck:
+		m.ClearCheck()
+		return nil
+	case task.FieldHandoffs:
+		m.ClearHandoffs()
+		return nil
 	}
 	return fmt.Errorf("unknown Task nullable field %s", name)
 }
@@ -2838,6 +3958,9 @@ func (m *TaskMutation) ResetField(name string) error {
 	case task.FieldDescription:
 		m.ResetDescription()
 		return nil
+	case task.FieldInstructions:
+		m.ResetInstructions()
+		return nil
 	case task.FieldState:
 		m.ResetState()
 		return nil
@@ -2865,6 +3988,42 @@ func (m *TaskMutation) ResetField(name string) error {
 	case task.FieldCompletedAt:
 		m.ResetCompletedAt()
 		return nil
+	case task.FieldTags:
+		m.ResetTags()
+		return nil
+	case task.FieldAcceptanceCriteria:
+		m.ResetAcceptanceCriteria()
+		return nil
+	case task.FieldEffortLog:
+		m.ResetEffortLog()
+		return nil
+	case task.FieldReview:
+		m.ResetReview()
+		return nil
+	case task.FieldKeepComplexity:
+		m.ResetKeepComplexity()
+		return nil
+	case task.FieldCreatedBy:
+		m.ResetCreatedBy()
+		return nil
+	case task.FieldUpdatedBy:
+		m.ResetUpdatedBy()
+		return nil
+	case task.FieldPosition:
+		m.ResetPosition()
+		return nil
+	case task.FieldDueDate:
+		m.ResetDueDate()
+		return nil
+	case task.FieldBlocker:
+		m.ResetBlocker()
+		return nil
+	case task.FieldCheck:
+		m.ResetCheck()
+		return nil
+	case task.FieldHandoffs:
+		m.ResetHandoffs()
+		return nil
 	}
 	return fmt.Errorf("unknown Task field %s", name)
 }
@@ -2996,6 +4155,9 @@ type TaskDependencyMutation struct {
 	typ                    string
 	id                     *uuid.UUID
 	created_at             *time.Time
+	dependency_type        *taskdependency.DependencyType
+	lag                    *int64
+	addlag                 *int64
 	clearedFields          map[string]struct{}
 	task                   *uuid.UUID
 	clearedtask            bool
@@ -3218,6 +4380,98 @@ func (m *TaskDependencyMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
+// SetDependencyType sets the "dependency_type" field.
+func (m *TaskDependencyMutation) SetDependencyType(tt taskdependency.DependencyType) {
+	m.dependency_type = &tt
+}
+
+// DependencyType returns the value of the "dependency_type" field in the mutation.
+func (m *TaskDependencyMutation) DependencyType() (r taskdependency.DependencyType, exists bool) {
+	v := m.dependency_type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDependencyType returns the old "dependency_type" field's value of the TaskDependency entity.
+// If the TaskDependency object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskDependencyMutation) OldDependencyType(ctx context.Context) (v taskdependency.DependencyType, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDependencyType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDependencyType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDependencyType: %w", err)
+	}
+	return oldValue.DependencyType, nil
+}
+
+// ResetDependencyType resets all changes to the "dependency_type" field.
+func (m *TaskDependencyMutation) ResetDependencyType() {
+	m.dependency_type = nil
+}
+
+// SetLag sets the "lag" field.
+func (m *TaskDependencyMutation) SetLag(i int64) {
+	m.lag = &i
+	m.addlag = nil
+}
+
+// Lag returns the value of the "lag" field in the mutation.
+func (m *TaskDependencyMutation) Lag() (r int64, exists bool) {
+	v := m.lag
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLag returns the old "lag" field's value of the TaskDependency entity.
+// If the TaskDependency object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *TaskDependencyMutation) OldLag(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLag is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLag requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLag: %w", err)
+	}
+	return oldValue.Lag, nil
+}
+
+// AddLag adds i to the "lag" field.
+func (m *TaskDependencyMutation) AddLag(i int64) {
+	if m.addlag != nil {
+		*m.addlag += i
+	} else {
+		m.addlag = &i
+	}
+}
+
+// AddedLag returns the value that was added to the "lag" field in this mutation.
+func (m *TaskDependencyMutation) AddedLag() (r int64, exists bool) {
+	v := m.addlag
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetLag resets all changes to the "lag" field.
+func (m *TaskDependencyMutation) ResetLag() {
+	m.lag = nil
+	m.addlag = nil
+}
+
 // ClearTask clears the "task" edge to the Task entity.
 func (m *TaskDependencyMutation) ClearTask() {
 	m.clearedtask = true
@@ -3306,7 +4560,7 @@ func (m *TaskDependencyMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *TaskDependencyMutation) Fields() []string {
-	fields := make([]string, 0, 3)
+	fields := make([]string, 0, 5)
 	if m.task != nil {
 		fields = append(fields, taskdependency.FieldTaskID)
 	}
@@ -3316,6 +4570,12 @@ func (m *TaskDependencyMutation) Fields() []string {
 	if m.created_at != nil {
 		fields = append(fields, taskdependency.FieldCreatedAt)
 	}
+	if m.dependency_type != nil {
+		fields = append(fields, taskdependency.FieldDependencyType)
+	}
+	if m.lag != nil {
+		fields = append(fields, taskdependency.FieldLag)
+	}
 	return fields
 }
 
@@ -3330,6 +4590,10 @@ func (m *TaskDependencyMutation) Field(name string) (ent.Value, bool) {
 		return m.DependsOnTaskID()
 	case taskdependency.FieldCreatedAt:
 		return m.CreatedAt()
+	case taskdependency.FieldDependencyType:
+		return m.DependencyType()
+	case taskdependency.FieldLag:
+		return m.Lag()
 	}
 	return nil, false
 }
@@ -3345,6 +4609,10 @@ func (m *TaskDependencyMutation) OldField(ctx context.Context, name string) (ent
 		return m.OldDependsOnTaskID(ctx)
 	case taskdependency.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
+	case taskdependency.FieldDependencyType:
+		return m.OldDependencyType(ctx)
+	case taskdependency.FieldLag:
+		return m.OldLag(ctx)
 	}
 	return nil, fmt.Errorf("unknown TaskDependency field %s", name)
 }
@@ -3375,6 +4643,20 @@ func (m *TaskDependencyMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetCreatedAt(v)
 		return nil
+	case taskdependency.FieldDependencyType:
+		v, ok := value.(taskdependency.DependencyType)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDependencyType(v)
+		return nil
+	case taskdependency.FieldLag:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLag(v)
+		return nil
 	}
 	return fmt.Errorf("unknown TaskDependency field %s", name)
 }
@@ -3382,13 +4664,21 @@ func (m *TaskDependencyMutation) SetField(name string, value ent.Value) error {
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
 func (m *TaskDependencyMutation) AddedFields() []string {
-	return nil
+	var fields []string
+	if m.addlag != nil {
+		fields = append(fields, taskdependency.FieldLag)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
 func (m *TaskDependencyMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case taskdependency.FieldLag:
+		return m.AddedLag()
+	}
 	return nil, false
 }
 
@@ -3397,6 +4687,13 @@ func (m *TaskDependencyMutation) AddedField(name string) (ent.Value, bool) {
 // type.
 func (m *TaskDependencyMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case taskdependency.FieldLag:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddLag(v)
+		return nil
 	}
 	return fmt.Errorf("unknown TaskDependency numeric field %s", name)
 }
@@ -3433,6 +4730,12 @@ func (m *TaskDependencyMutation) ResetField(name string) error {
 	case taskdependency.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
+	case taskdependency.FieldDependencyType:
+		m.ResetDependencyType()
+		return nil
+	case taskdependency.FieldLag:
+		m.ResetLag()
+		return nil
 	}
 	return fmt.Errorf("unknown TaskDependency field %s", name)
 }
@@ -22,6 +22,8 @@ type Project struct {
 	Title string `json:"title,omitempty"`
 	// Description holds the value of the "description" field.
 	Description string `json:"description,omitempty"`
+	// Instructions holds the value of the "instructions" field.
+	Instructions string `json:"instructions,omitempty"`
 	// State holds the value of the "state" field.
 	State project.State `json:"state,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
@@ -38,6 +40,8 @@ type Project struct {
 	CreatedBy string `json:"created_by,omitempty"`
 	// UpdatedBy holds the value of the "updated_by" field.
 	UpdatedBy string `json:"updated_by,omitempty"`
+	// Document holds the value of the "document" field.
+	Document string `json:"document,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the ProjectQuery when eager-loading is set.
 	Edges        ProjectEdges `json:"edges"`
@@ -71,7 +75,7 @@ func (*Project) scanValues(columns []string) ([]any, error) {
 			values[i] = new(sql.NullFloat64)
 		case project.FieldTotalTasks, project.FieldCompletedTasks:
 			values[i] = new(sql.NullInt64)
-		case project.FieldTitle, project.FieldDescription, project.FieldState, project.FieldCreatedBy, project.FieldUpdatedBy:
+		case project.FieldTitle, project.FieldDescription, project.FieldInstructions, project.FieldState, project.FieldCreatedBy, project.FieldUpdatedBy, project.FieldDocument:
 			values[i] = new(sql.NullString)
 		case project.FieldCreatedAt, project.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
@@ -110,6 +114,12 @@ func (_m *Project) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Description = value.String
 			}
+		case project.FieldInstructions:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field instructions", values[i])
+			} else if value.Valid {
+				_m.Instructions = value.String
+			}
 		case project.FieldState:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field state", values[i])
@@ -158,6 +168,12 @@ func (_m *Project) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.UpdatedBy = value.String
 			}
+		case project.FieldDocument:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field document", values[i])
+			} else if value.Valid {
+				_m.Document = value.String
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -205,6 +221,9 @@ func (_m *Project) String() string {
 	builder.WriteString("description=")
 	builder.WriteString(_m.Description)
 	builder.WriteString(", ")
+	builder.WriteString("instructions=")
+	builder.WriteString(_m.Instructions)
+	builder.WriteString(", ")
 	builder.WriteString("state=")
 	builder.WriteString(fmt.Sprintf("%v", _m.State))
 	builder.WriteString(", ")
@@ -228,6 +247,9 @@ func (_m *Project) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("updated_by=")
 	builder.WriteString(_m.UpdatedBy)
+	builder.WriteString(", ")
+	builder.WriteString("document=")
+	builder.WriteString(_m.Document)
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -66,6 +66,11 @@ func Description(v string) predicate.Project {
 	return predicate.Project(sql.FieldEQ(FieldDescription, v))
 }
 
+// Instructions applies equality check predicate on the "instructions" field. It's identical to InstructionsEQ.
+func Instructions(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldInstructions, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.Project {
 	return predicate.Project(sql.FieldEQ(FieldCreatedAt, v))
@@ -101,6 +106,11 @@ func UpdatedBy(v string) predicate.Project {
 	return predicate.Project(sql.FieldEQ(FieldUpdatedBy, v))
 }
 
+// Document applies equality check predicate on the "document" field. It's identical to DocumentEQ.
+func Document(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldDocument, v))
+}
+
 // TitleEQ applies the EQ predicate on the "title" field.
 func TitleEQ(v string) predicate.Project {
 	return predicate.Project(sql.FieldEQ(FieldTitle, v))
@@ -241,6 +251,81 @@ func DescriptionContainsFold(v string) predicate.Project {
 	return predicate.Project(sql.FieldContainsFold(FieldDescription, v))
 }
 
+// InstructionsEQ applies the EQ predicate on the "instructions" field.
+func InstructionsEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldInstructions, v))
+}
+
+// InstructionsNEQ applies the NEQ predicate on the "instructions" field.
+func InstructionsNEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldNEQ(FieldInstructions, v))
+}
+
+// InstructionsIn applies the In predicate on the "instructions" field.
+func InstructionsIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldIn(FieldInstructions, vs...))
+}
+
+// InstructionsNotIn applies the NotIn predicate on the "instructions" field.
+func InstructionsNotIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldNotIn(FieldInstructions, vs...))
+}
+
+// InstructionsGT applies the GT predicate on the "instructions" field.
+func InstructionsGT(v string) predicate.Project {
+	return predicate.Project(sql.FieldGT(FieldInstructions, v))
+}
+
+// InstructionsGTE applies the GTE predicate on the "instructions" field.
+func InstructionsGTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldGTE(FieldInstructions, v))
+}
+
+// InstructionsLT applies the LT predicate on the "instructions" field.
+func InstructionsLT(v string) predicate.Project {
+	return predicate.Project(sql.FieldLT(FieldInstructions, v))
+}
+
+// InstructionsLTE applies the LTE predicate on the "instructions" field.
+func InstructionsLTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldLTE(FieldInstructions, v))
+}
+
+// InstructionsContains applies the Contains predicate on the "instructions" field.
+func InstructionsContains(v string) predicate.Project {
+	return predicate.Project(sql.FieldContains(FieldInstructions, v))
+}
+
+// InstructionsHasPrefix applies the HasPrefix predicate on the "instructions" field.
+func InstructionsHasPrefix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasPrefix(FieldInstructions, v))
+}
+
+// InstructionsHasSuffix applies the HasSuffix predicate on the "instructions" field.
+func InstructionsHasSuffix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasSuffix(FieldInstructions, v))
+}
+
+// InstructionsIsNil applies the IsNil predicate on the "instructions" field.
+func InstructionsIsNil() predicate.Project {
+	return predicate.Project(sql.FieldIsNull(FieldInstructions))
+}
+
+// InstructionsNotNil applies the NotNil predicate on the "instructions" field.
+func InstructionsNotNil() predicate.Project {
+	return predicate.Project(sql.FieldNotNull(FieldInstructions))
+}
+
+// InstructionsEqualFold applies the EqualFold predicate on the "instructions" field.
+func InstructionsEqualFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldEqualFold(FieldInstructions, v))
+}
+
+// InstructionsContainsFold applies the ContainsFold predicate on the "instructions" field.
+func InstructionsContainsFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldContainsFold(FieldInstructions, v))
+}
+
 // StateEQ applies the EQ predicate on the "state" field.
 func StateEQ(v State) predicate.Project {
 	return predicate.Project(sql.FieldEQ(FieldState, v))
@@ -611,6 +696,81 @@ func UpdatedByContainsFold(v string) predicate.Project {
 	return predicate.Project(sql.FieldContainsFold(FieldUpdatedBy, v))
 }
 
+// DocumentEQ applies the EQ predicate on the "document" field.
+func DocumentEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldEQ(FieldDocument, v))
+}
+
+// DocumentNEQ applies the NEQ predicate on the "document" field.
+func DocumentNEQ(v string) predicate.Project {
+	return predicate.Project(sql.FieldNEQ(FieldDocument, v))
+}
+
+// DocumentIn applies the In predicate on the "document" field.
+func DocumentIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldIn(FieldDocument, vs...))
+}
+
+// DocumentNotIn applies the NotIn predicate on the "document" field.
+func DocumentNotIn(vs ...string) predicate.Project {
+	return predicate.Project(sql.FieldNotIn(FieldDocument, vs...))
+}
+
+// DocumentGT applies the GT predicate on the "document" field.
+func DocumentGT(v string) predicate.Project {
+	return predicate.Project(sql.FieldGT(FieldDocument, v))
+}
+
+// DocumentGTE applies the GTE predicate on the "document" field.
+func DocumentGTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldGTE(FieldDocument, v))
+}
+
+// DocumentLT applies the LT predicate on the "document" field.
+func DocumentLT(v string) predicate.Project {
+	return predicate.Project(sql.FieldLT(FieldDocument, v))
+}
+
+// DocumentLTE applies the LTE predicate on the "document" field.
+func DocumentLTE(v string) predicate.Project {
+	return predicate.Project(sql.FieldLTE(FieldDocument, v))
+}
+
+// DocumentContains applies the Contains predicate on the "document" field.
+func DocumentContains(v string) predicate.Project {
+	return predicate.Project(sql.FieldContains(FieldDocument, v))
+}
+
+// DocumentHasPrefix applies the HasPrefix predicate on the "document" field.
+func DocumentHasPrefix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasPrefix(FieldDocument, v))
+}
+
+// DocumentHasSuffix applies the HasSuffix predicate on the "document" field.
+func DocumentHasSuffix(v string) predicate.Project {
+	return predicate.Project(sql.FieldHasSuffix(FieldDocument, v))
+}
+
+// DocumentIsNil applies the IsNil predicate on the "document" field.
+func DocumentIsNil() predicate.Project {
+	return predicate.Project(sql.FieldIsNull(FieldDocument))
+}
+
+// DocumentNotNil applies the NotNil predicate on the "document" field.
+func DocumentNotNil() predicate.Project {
+	return predicate.Project(sql.FieldNotNull(FieldDocument))
+}
+
+// DocumentEqualFold applies the EqualFold predicate on the "document" field.
+func DocumentEqualFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldEqualFold(FieldDocument, v))
+}
+
+// DocumentContainsFold applies the ContainsFold predicate on the "document" field.
+func DocumentContainsFold(v string) predicate.Project {
+	return predicate.Project(sql.FieldContainsFold(FieldDocument, v))
+}
+
 // HasTasks applies the HasEdge predicate on the "tasks" edge.
 func HasTasks() predicate.Project {
 	return predicate.Project(func(s *sql.Selector) {
@@ -42,6 +42,20 @@ func (_c *ProjectCreate) SetNillableDescription(v *string) *ProjectCreate {
 	return _c
 }
 
+// SetInstructions sets the "instructions" field.
+func (_c *ProjectCreate) SetInstructions(v string) *ProjectCreate {
+	_c.mutation.SetInstructions(v)
+	return _c
+}
+
+// SetNillableInstructions sets the "instructions" field if the given value is not nil.
+func (_c *ProjectCreate) SetNillableInstructions(v *string) *ProjectCreate {
+	if v != nil {
+		_c.SetInstructions(*v)
+	}
+	return _c
+}
+
 // SetState sets the "state" field.
 func (_c *ProjectCreate) SetState(v project.State) *ProjectCreate {
 	_c.mutation.SetState(v)
@@ -154,6 +168,20 @@ func (_c *ProjectCreate) SetNillableUpdatedBy(v *string) *ProjectCreate {
 	return _c
 }
 
+// SetDocument sets the "document" field.
+func (_c *ProjectCreate) SetDocument(v string) *ProjectCreate {
+	_c.mutation.SetDocument(v)
+	return _c
+}
+
+// SetNillableDocument sets the "document" field if the given value is not nil.
+func (_c *ProjectCreate) SetNillableDocument(v *string) *ProjectCreate {
+	if v != nil {
+		_c.SetDocument(*v)
+	}
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *ProjectCreate) SetID(v uuid.UUID) *ProjectCreate {
 	_c.mutation.SetID(v)
@@ -339,6 +367,10 @@ func (_c *ProjectCreate) createSpec() (*Project, *sqlgraph.CreateSpec) {
 		_spec.SetField(project.FieldDescription, field.TypeString, value)
 		_node.Description = value
 	}
+	if value, ok := _c.mutation.Instructions(); ok {
+		_spec.SetField(project.FieldInstructions, field.TypeString, value)
+		_node.Instructions = value
+	}
 	if value, ok := _c.mutation.State(); ok {
 		_spec.SetField(project.FieldState, field.TypeEnum, value)
 		_node.State = value
@@ -371,6 +403,10 @@ func (_c *ProjectCreate) createSpec() (*Project, *sqlgraph.CreateSpec) {
 		_spec.SetField(project.FieldUpdatedBy, field.TypeString, value)
 		_node.UpdatedBy = value
 	}
+	if value, ok := _c.mutation.Document(); ok {
+		_spec.SetField(project.FieldDocument, field.TypeString, value)
+		_node.Document = value
+	}
 	if nodes := _c.mutation.TasksIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
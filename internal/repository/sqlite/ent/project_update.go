@@ -64,6 +64,26 @@ func (_u *ProjectUpdate) ClearDescription() *ProjectUpdate {
 	return _u
 }
 
+// SetInstructions sets the "instructions" field.
+func (_u *ProjectUpdate) SetInstructions(v string) *ProjectUpdate {
+	_u.mutation.SetInstructions(v)
+	return _u
+}
+
+// SetNillableInstructions sets the "instructions" field if the given value is not nil.
+func (_u *ProjectUpdate) SetNillableInstructions(v *string) *ProjectUpdate {
+	if v != nil {
+		_u.SetInstructions(*v)
+	}
+	return _u
+}
+
+// ClearInstructions clears the value of the "instructions" field.
+func (_u *ProjectUpdate) ClearInstructions() *ProjectUpdate {
+	_u.mutation.ClearInstructions()
+	return _u
+}
+
 // SetState sets the "state" field.
 func (_u *ProjectUpdate) SetState(v project.State) *ProjectUpdate {
 	_u.mutation.SetState(v)
@@ -187,6 +207,26 @@ func (_u *ProjectUpdate) ClearUpdatedBy() *ProjectUpdate {
 	return _u
 }
 
+// SetDocument sets the "document" field.
+func (_u *ProjectUpdate) SetDocument(v string) *ProjectUpdate {
+	_u.mutation.SetDocument(v)
+	return _u
+}
+
+// SetNillableDocument sets the "document" field if the given value is not nil.
+func (_u *ProjectUpdate) SetNillableDocument(v *string) *ProjectUpdate {
+	if v != nil {
+		_u.SetDocument(*v)
+	}
+	return _u
+}
+
+// ClearDocument clears the value of the "document" field.
+func (_u *ProjectUpdate) ClearDocument() *ProjectUpdate {
+	_u.mutation.ClearDocument()
+	return _u
+}
+
 // AddTaskIDs adds the "tasks" edge to the Task entity by IDs.
 func (_u *ProjectUpdate) AddTaskIDs(ids ...uuid.UUID) *ProjectUpdate {
 	_u.mutation.AddTaskIDs(ids...)
@@ -315,6 +355,12 @@ func (_u *ProjectUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.DescriptionCleared() {
 		_spec.ClearField(project.FieldDescription, field.TypeString)
 	}
+	if value, ok := _u.mutation.Instructions(); ok {
+		_spec.SetField(project.FieldInstructions, field.TypeString, value)
+	}
+	if _u.mutation.InstructionsCleared() {
+		_spec.ClearField(project.FieldInstructions, field.TypeString)
+	}
 	if value, ok := _u.mutation.State(); ok {
 		_spec.SetField(project.FieldState, field.TypeEnum, value)
 	}
@@ -351,6 +397,12 @@ func (_u *ProjectUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.UpdatedByCleared() {
 		_spec.ClearField(project.FieldUpdatedBy, field.TypeString)
 	}
+	if value, ok := _u.mutation.Document(); ok {
+		_spec.SetField(project.FieldDocument, field.TypeString, value)
+	}
+	if _u.mutation.DocumentCleared() {
+		_spec.ClearField(project.FieldDocument, field.TypeString)
+	}
 	if _u.mutation.TasksCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -450,6 +502,26 @@ func (_u *ProjectUpdateOne) ClearDescription() *ProjectUpdateOne {
 	return _u
 }
 
+// SetInstructions sets the "instructions" field.
+func (_u *ProjectUpdateOne) SetInstructions(v string) *ProjectUpdateOne {
+	_u.mutation.SetInstructions(v)
+	return _u
+}
+
+// SetNillableInstructions sets the "instructions" field if the given value is not nil.
+func (_u *ProjectUpdateOne) SetNillableInstructions(v *string) *ProjectUpdateOne {
+	if v != nil {
+		_u.SetInstructions(*v)
+	}
+	return _u
+}
+
+// ClearInstructions clears the value of the "instructions" field.
+func (_u *ProjectUpdateOne) ClearInstructions() *ProjectUpdateOne {
+	_u.mutation.ClearInstructions()
+	return _u
+}
+
 // SetState sets the "state" field.
 func (_u *ProjectUpdateOne) SetState(v project.State) *ProjectUpdateOne {
 	_u.mutation.SetState(v)
@@ -573,6 +645,26 @@ func (_u *ProjectUpdateOne) ClearUpdatedBy() *ProjectUpdateOne {
 	return _u
 }
 
+// SetDocument sets the "document" field.
+func (_u *ProjectUpdateOne) SetDocument(v string) *ProjectUpdateOne {
+	_u.mutation.SetDocument(v)
+	return _u
+}
+
+// SetNillableDocument sets the "document" field if the given value is not nil.
+func (_u *ProjectUpdateOne) SetNillableDocument(v *string) *ProjectUpdateOne {
+	if v != nil {
+		_u.SetDocument(*v)
+	}
+	return _u
+}
+
+// ClearDocument clears the value of the "document" field.
+func (_u *ProjectUpdateOne) ClearDocument() *ProjectUpdateOne {
+	_u.mutation.ClearDocument()
+	return _u
+}
+
 // AddTaskIDs adds the "tasks" edge to the Task entity by IDs.
 func (_u *ProjectUpdateOne) AddTaskIDs(ids ...uuid.UUID) *ProjectUpdateOne {
 	_u.mutation.AddTaskIDs(ids...)
@@ -731,6 +823,12 @@ func (_u *ProjectUpdateOne) sqlSave(ctx context.Context) (_node *Project, err er
 	if _u.mutation.DescriptionCleared() {
 		_spec.ClearField(project.FieldDescription, field.TypeString)
 	}
+	if value, ok := _u.mutation.Instructions(); ok {
+		_spec.SetField(project.FieldInstructions, field.TypeString, value)
+	}
+	if _u.mutation.InstructionsCleared() {
+		_spec.ClearField(project.FieldInstructions, field.TypeString)
+	}
 	if value, ok := _u.mutation.State(); ok {
 		_spec.SetField(project.FieldState, field.TypeEnum, value)
 	}
@@ -767,6 +865,12 @@ func (_u *ProjectUpdateOne) sqlSave(ctx context.Context) (_node *Project, err er
 	if _u.mutation.UpdatedByCleared() {
 		_spec.ClearField(project.FieldUpdatedBy, field.TypeString)
 	}
+	if value, ok := _u.mutation.Document(); ok {
+		_spec.SetField(project.FieldDocument, field.TypeString, value)
+	}
+	if _u.mutation.DocumentCleared() {
+		_spec.ClearField(project.FieldDocument, field.TypeString)
+	}
 	if _u.mutation.TasksCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
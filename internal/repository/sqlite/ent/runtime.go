@@ -38,29 +38,29 @@ func init() {
 		}
 	}()
 	// projectDescCreatedAt is the schema descriptor for created_at field.
-	projectDescCreatedAt := projectFields[4].Descriptor()
+	projectDescCreatedAt := projectFields[5].Descriptor()
 	// project.DefaultCreatedAt holds the default value on creation for the created_at field.
 	project.DefaultCreatedAt = projectDescCreatedAt.Default.(func() time.Time)
 	// projectDescUpdatedAt is the schema descriptor for updated_at field.
-	projectDescUpdatedAt := projectFields[5].Descriptor()
+	projectDescUpdatedAt := projectFields[6].Descriptor()
 	// project.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	project.DefaultUpdatedAt = projectDescUpdatedAt.Default.(func() time.Time)
 	// project.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
 	project.UpdateDefaultUpdatedAt = projectDescUpdatedAt.UpdateDefault.(func() time.Time)
 	// projectDescTotalTasks is the schema descriptor for total_tasks field.
-	projectDescTotalTasks := projectFields[6].Descriptor()
+	projectDescTotalTasks := projectFields[7].Descriptor()
 	// project.DefaultTotalTasks holds the default value on creation for the total_tasks field.
 	project.DefaultTotalTasks = projectDescTotalTasks.Default.(int)
 	// project.TotalTasksValidator is a validator for the "total_tasks" field. It is called by the builders before save.
 	project.TotalTasksValidator = projectDescTotalTasks.Validators[0].(func(int) error)
 	// projectDescCompletedTasks is the schema descriptor for completed_tasks field.
-	projectDescCompletedTasks := projectFields[7].Descriptor()
+	projectDescCompletedTasks := projectFields[8].Descriptor()
 	// project.DefaultCompletedTasks holds the default value on creation for the completed_tasks field.
 	project.DefaultCompletedTasks = projectDescCompletedTasks.Default.(int)
 	// project.CompletedTasksValidator is a validator for the "completed_tasks" field. It is called by the builders before save.
 	project.CompletedTasksValidator = projectDescCompletedTasks.Validators[0].(func(int) error)
 	// projectDescProgress is the schema descriptor for progress field.
-	projectDescProgress := projectFields[8].Descriptor()
+	projectDescProgress := projectFields[9].Descriptor()
 	// project.DefaultProgress holds the default value on creation for the progress field.
 	project.DefaultProgress = projectDescProgress.Default.(float64)
 	// project.ProgressValidator is a validator for the "progress" field. It is called by the builders before save.
@@ -116,7 +116,7 @@ func init() {
 		}
 	}()
 	// taskDescComplexity is the schema descriptor for complexity field.
-	taskDescComplexity := taskFields[7].Descriptor()
+	taskDescComplexity := taskFields[8].Descriptor()
 	// task.ComplexityValidator is a validator for the "complexity" field. It is called by the builders before save.
 	task.ComplexityValidator = func() func(int) error {
 		validators := taskDescComplexity.Validators
@@ -134,21 +134,29 @@ func init() {
 		}
 	}()
 	// taskDescDepth is the schema descriptor for depth field.
-	taskDescDepth := taskFields[8].Descriptor()
+	taskDescDepth := taskFields[9].Descriptor()
 	// task.DefaultDepth holds the default value on creation for the depth field.
 	task.DefaultDepth = taskDescDepth.Default.(int)
 	// task.DepthValidator is a validator for the "depth" field. It is called by the builders before save.
 	task.DepthValidator = taskDescDepth.Validators[0].(func(int) error)
 	// taskDescCreatedAt is the schema descriptor for created_at field.
-	taskDescCreatedAt := taskFields[11].Descriptor()
+	taskDescCreatedAt := taskFields[12].Descriptor()
 	// task.DefaultCreatedAt holds the default value on creation for the created_at field.
 	task.DefaultCreatedAt = taskDescCreatedAt.Default.(func() time.Time)
 	// taskDescUpdatedAt is the schema descriptor for updated_at field.
-	taskDescUpdatedAt := taskFields[12].Descriptor()
+	taskDescUpdatedAt := taskFields[13].Descriptor()
 	// task.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	task.DefaultUpdatedAt = taskDescUpdatedAt.Default.(func() time.Time)
 	// task.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
 	task.UpdateDefaultUpdatedAt = taskDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// taskDescKeepComplexity is the schema descriptor for keep_complexity field.
+	taskDescKeepComplexity := taskFields[19].Descriptor()
+	// task.DefaultKeepComplexity holds the default value on creation for the keep_complexity field.
+	task.DefaultKeepComplexity = taskDescKeepComplexity.Default.(bool)
+	// taskDescPosition is the schema descriptor for position field.
+	taskDescPosition := taskFields[22].Descriptor()
+	// task.DefaultPosition holds the default value on creation for the position field.
+	task.DefaultPosition = taskDescPosition.Default.(int)
 	// taskDescID is the schema descriptor for id field.
 	taskDescID := taskFields[0].Descriptor()
 	// task.DefaultID holds the default value on creation for the id field.
@@ -159,6 +167,10 @@ func init() {
 	taskdependencyDescCreatedAt := taskdependencyFields[3].Descriptor()
 	// taskdependency.DefaultCreatedAt holds the default value on creation for the created_at field.
 	taskdependency.DefaultCreatedAt = taskdependencyDescCreatedAt.Default.(func() time.Time)
+	// taskdependencyDescLag is the schema descriptor for lag field.
+	taskdependencyDescLag := taskdependencyFields[5].Descriptor()
+	// taskdependency.DefaultLag holds the default value on creation for the lag field.
+	taskdependency.DefaultLag = taskdependencyDescLag.Default.(int64)
 	// taskdependencyDescID is the schema descriptor for id field.
 	taskdependencyDescID := taskdependencyFields[0].Descriptor()
 	// taskdependency.DefaultID holds the default value on creation for the id field.
@@ -4,9 +4,11 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
+	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
 )
 
@@ -19,7 +21,7 @@ type Project struct {
 func (Project) Fields() []ent.Field {
 	return []ent.Field{
 		field.UUID("id", uuid.UUID{}).
-			Default(uuid.New).
+			Default(types.NewID).
 			Unique().
 			Immutable(),
 		field.String("title").
@@ -27,6 +29,9 @@ func (Project) Fields() []ent.Field {
 			NotEmpty(),
 		field.Text("description").
 			Optional(),
+		// Standing guidance for agents, inherited by the tasks below
+		field.Text("instructions").
+			Optional(),
 		field.Enum("state").
 			Values("active", "completed", "archived", "deletion-pending").
 			Default("active"),
@@ -46,13 +51,22 @@ func (Project) Fields() []ent.Field {
 			Default(0.0).
 			Min(0.0).
 			Max(100.0),
+		field.String("created_by").
+			Optional(),
+		field.String("updated_by").
+			Optional(),
+		// Long-form project document: goals, conventions, agent instructions
+		field.Text("document").
+			Optional(),
 	}
 }
 
 // Edges of the Project.
 func (Project) Edges() []ent.Edge {
 	return []ent.Edge{
-		edge.To("tasks", Task.Type),
+		// Deleting a project deletes its tasks
+		edge.To("tasks", Task.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)),
 	}
 }
 
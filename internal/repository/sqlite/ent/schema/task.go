@@ -4,9 +4,11 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
+	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
 )
 
@@ -19,7 +21,7 @@ type Task struct {
 func (Task) Fields() []ent.Field {
 	return []ent.Field{
 		field.UUID("id", uuid.UUID{}).
-			Default(uuid.New).
+			Default(types.NewID).
 			Unique().
 			Immutable(),
 		field.UUID("project_id", uuid.UUID{}),
@@ -31,6 +33,9 @@ func (Task) Fields() []ent.Field {
 			NotEmpty(),
 		field.Text("description").
 			Optional(),
+		// Standing guidance for agents, inherited by the tasks below
+		field.Text("instructions").
+			Optional(),
 		field.Enum("state").
 			Values("pending", "in-progress", "completed", "blocked", "cancelled", "deletion-pending").
 			Default("pending"),
@@ -59,6 +64,41 @@ func (Task) Fields() []ent.Field {
 		field.Time("completed_at").
 			Optional().
 			Nillable(),
+		field.JSON("tags", []string{}).
+			Optional().
+			Comment("Free-form labels"),
+		field.JSON("acceptance_criteria", []types.AcceptanceCriterion{}).
+			Optional().
+			Comment("Acceptance criteria with verification state"),
+		field.JSON("effort_log", []types.EffortEntry{}).
+			Optional().
+			Comment("Logged actual effort entries"),
+		field.JSON("review", &types.TaskReview{}).
+			Optional().
+			Comment("Review request and approval state"),
+		field.Bool("keep_complexity").
+			Default(false).
+			Comment("Excluded from automatic complexity reduction"),
+		field.String("created_by").
+			Optional(),
+		field.String("updated_by").
+			Optional(),
+		field.Int("position").
+			Default(0).
+			Comment("Order among siblings, lower comes first"),
+		field.Time("due_date").
+			Optional().
+			Nillable().
+			Comment("Due date of the task"),
+		field.JSON("blocker", &types.TaskBlocker{}).
+			Optional().
+			Comment("External reason the task is blocked"),
+		field.JSON("check", &types.TaskCheck{}).
+			Optional().
+			Comment("Command verifying the task with its latest runs"),
+		field.JSON("handoffs", []types.TaskHandoff{}).
+			Optional().
+			Comment("Handoff notes left for the next assignee"),
 	}
 }
 
@@ -72,8 +112,10 @@ func (Task) Edges() []ent.Edge {
 			Unique().
 			Required(),
 
-		// Self-referencing parent-child relationship
+		// Self-referencing parent-child relationship, deleting a task deletes
+		// its subtree
 		edge.To("children", Task.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
 			From("parent").
 			Field("parent_id").
 			Unique(),
@@ -98,7 +140,9 @@ func (Task) Indexes() []ent.Index {
 		index.Fields("project_id", "priority"),
 		index.Fields("project_id", "assigned_agent"),
 		index.Fields("project_id", "parent_id"),
+		index.Fields("parent_id", "position"),
 		index.Fields("project_id", "depth"),
+		index.Fields("project_id", "created_at", "id"),
 		index.Fields("state", "complexity"),
 		index.Fields("priority", "state"),
 		index.Fields("priority", "complexity"),
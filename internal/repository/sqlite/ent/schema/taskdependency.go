@@ -4,9 +4,11 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
+	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
 )
 
@@ -20,7 +22,7 @@ type TaskDependency struct {
 func (TaskDependency) Fields() []ent.Field {
 	return []ent.Field{
 		field.UUID("id", uuid.UUID{}).
-			Default(uuid.New).
+			Default(types.NewID).
 			Unique().
 			Immutable(),
 		field.UUID("task_id", uuid.UUID{}),
@@ -28,6 +30,13 @@ func (TaskDependency) Fields() []ent.Field {
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
+		field.Enum("dependency_type").
+			Values("finish-to-start", "start-to-start").
+			Default("finish-to-start").
+			Comment("When the dependent task may start: once the dependency is completed or has started"),
+		field.Int64("lag").
+			Default(0).
+			Comment("Wait after the dependency is completed or has started, in minutes"),
 	}
 }
 
@@ -38,13 +47,15 @@ func (TaskDependency) Edges() []ent.Edge {
 		edge.To("task", Task.Type).
 			Field("task_id").
 			Unique().
-			Required(),
+			Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
 
 		// The task that is depended upon
 		edge.To("depends_on_task", Task.Type).
 			Field("depends_on_task_id").
 			Unique().
-			Required(),
+			Required().
+			Annotations(entsql.OnDelete(entsql.Cascade)),
 	}
 }
 
@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/project"
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/task"
+	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
 )
 
@@ -27,6 +29,8 @@ type Task struct {
 	Title string `json:"title,omitempty"`
 	// Description holds the value of the "description" field.
 	Description string `json:"description,omitempty"`
+	// Instructions holds the value of the "instructions" field.
+	Instructions string `json:"instructions,omitempty"`
 	// State holds the value of the "state" field.
 	State task.State `json:"state,omitempty"`
 	// Priority holds the value of the "priority" field.
@@ -45,6 +49,30 @@ type Task struct {
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
 	// CompletedAt holds the value of the "completed_at" field.
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// Free-form labels
+	Tags []string `json:"tags,omitempty"`
+	// Acceptance criteria with verification state
+	AcceptanceCriteria []types.AcceptanceCriterion `json:"acceptance_criteria,omitempty"`
+	// Logged actual effort entries
+	EffortLog []types.EffortEntry `json:"effort_log,omitempty"`
+	// Review request and approval state
+	Review *types.TaskReview `json:"review,omitempty"`
+	// Excluded from automatic complexity reduction
+	KeepComplexity bool `json:"keep_complexity,omitempty"`
+	// CreatedBy holds the value of the "created_by" field.
+	CreatedBy string `json:"created_by,omitempty"`
+	// UpdatedBy holds the value of the "updated_by" field.
+	UpdatedBy string `json:"updated_by,omitempty"`
+	// Order among siblings, lower comes first
+	Position int `json:"position,omitempty"`
+	// Due date of the task
+	DueDate *time.Time `json:"due_date,omitempty"`
+	// External reason the task is blocked
+	Blocker *types.TaskBlocker `json:"blocker,omitempty"`
+	// Command verifying the task with its latest runs
+	Check *types.TaskCheck `json:"check,omitempty"`
+	// Handoff notes left for the next assignee
+	Handoffs []types.TaskHandoff `json:"handoffs,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the TaskQuery when eager-loading is set.
 	Edges        TaskEdges `json:"edges"`
@@ -102,11 +130,15 @@ func (*Task) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case task.FieldParentID, task.FieldAssignedAgent:
 			values[i] = &sql.NullScanner{S: new(uuid.UUID)}
-		case task.FieldComplexity, task.FieldDepth, task.FieldEstimate:
+		case task.FieldTags, task.FieldAcceptanceCriteria, task.FieldEffortLog, task.FieldReview, task.FieldBlocker, task.FieldCheck, task.FieldHandoffs:
+			values[i] = new([]byte)
+		case task.FieldKeepComplexity:
+			values[i] = new(sql.NullBool)
+		case task.FieldComplexity, task.FieldDepth, task.FieldEstimate, task.FieldPosition:
 			values[i] = new(sql.NullInt64)
-		case task.FieldTitle, task.FieldDescription, task.FieldState, task.FieldPriority:
+		case task.FieldTitle, task.FieldDescription, task.FieldInstructions, task.FieldState, task.FieldPriority, task.FieldCreatedBy, task.FieldUpdatedBy:
 			values[i] = new(sql.NullString)
-		case task.FieldCreatedAt, task.FieldUpdatedAt, task.FieldCompletedAt:
+		case task.FieldCreatedAt, task.FieldUpdatedAt, task.FieldCompletedAt, task.FieldDueDate:
 			values[i] = new(sql.NullTime)
 		case task.FieldID, task.FieldProjectID:
 			values[i] = new(uuid.UUID)
@@ -156,6 +188,12 @@ func (_m *Task) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Description = value.String
 			}
+		case task.FieldInstructions:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field instructions", values[i])
+			} else if value.Valid {
+				_m.Instructions = value.String
+			}
 		case task.FieldState:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field state", values[i])
@@ -213,6 +251,93 @@ func (_m *Task) assignValues(columns []string, values []any) error {
 				_m.CompletedAt = new(time.Time)
 				*_m.CompletedAt = value.Time
 			}
+		case task.FieldTags:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field tags", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Tags); err != nil {
+					return fmt.Errorf("unmarshal field tags: %w", err)
+				}
+			}
+		case task.FieldAcceptanceCriteria:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field acceptance_criteria", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.AcceptanceCriteria); err != nil {
+					return fmt.Errorf("unmarshal field acceptance_criteria: %w", err)
+				}
+			}
+		case task.FieldEffortLog:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field effort_log", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.EffortLog); err != nil {
+					return fmt.Errorf("unmarshal field effort_log: %w", err)
+				}
+			}
+		case task.FieldReview:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field review", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Review); err != nil {
+					return fmt.Errorf("unmarshal field review: %w", err)
+				}
+			}
+		case task.FieldKeepComplexity:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field keep_complexity", values[i])
+			} else if value.Valid {
+				_m.KeepComplexity = value.Bool
+			}
+		case task.FieldCreatedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field created_by", values[i])
+			} else if value.Valid {
+				_m.CreatedBy = value.String
+			}
+		case task.FieldUpdatedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_by", values[i])
+			} else if value.Valid {
+				_m.UpdatedBy = value.String
+			}
+		case task.FieldPosition:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field position", values[i])
+			} else if value.Valid {
+				_m.Position = int(value.Int64)
+			}
+		case task.FieldDueDate:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field due_date", values[i])
+			} else if value.Valid {
+				_m.DueDate = new(time.Time)
+				*_m.DueDate = value.Time
+			}
+		case task.FieldBlocker:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field blocker", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Blocker); err != nil {
+					return fmt.Errorf("unmarshal field blocker: %w", err)
+				}
+			}
+		case task.FieldCheck:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field check", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Check); err != nil {
+					return fmt.Errorf("unmarshal field check: %w", err)
+				}
+			}
+		case task.FieldHandoffs:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field handoffs", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Handoffs); err != nil {
+					return fmt.Errorf("unmarshal field handoffs: %w", err)
+				}
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -278,6 +403,9 @@ func (_m *Task) String() string {
 	builder.WriteString("description=")
 	builder.WriteString(_m.Description)
 	builder.WriteString(", ")
+	builder.WriteString("instructions=")
+	builder.WriteString(_m.Instructions)
+	builder.WriteString(", ")
 	builder.WriteString("state=")
 	builder.WriteString(fmt.Sprintf("%v", _m.State))
 	builder.WriteString(", ")
@@ -310,6 +438,44 @@ func (_m *Task) String() string {
 		builder.WriteString("completed_at=")
 		builder.WriteString(v.Format(time.ANSIC))
 	}
+	builder.WriteString(", ")
+	builder.WriteString("tags=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Tags))
+	builder.WriteString(", ")
+	builder.WriteString("acceptance_criteria=")
+	builder.WriteString(fmt.Sprintf("%v", _m.AcceptanceCriteria))
+	builder.WriteString(", ")
+	builder.WriteString("effort_log=")
+	builder.WriteString(fmt.Sprintf("%v", _m.EffortLog))
+	builder.WriteString(", ")
+	builder.WriteString("review=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Review))
+	builder.WriteString(", ")
+	builder.WriteString("keep_complexity=")
+	builder.WriteString(fmt.Sprintf("%v", _m.KeepComplexity))
+	builder.WriteString(", ")
+	builder.WriteString("created_by=")
+	builder.WriteString(_m.CreatedBy)
+	builder.WriteString(", ")
+	builder.WriteString("updated_by=")
+	builder.WriteString(_m.UpdatedBy)
+	builder.WriteString(", ")
+	builder.WriteString("position=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Position))
+	builder.WriteString(", ")
+	if v := _m.DueDate; v != nil {
+		builder.WriteString("due_date=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("blocker=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Blocker))
+	builder.WriteString(", ")
+	builder.WriteString("check=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Check))
+	builder.WriteString(", ")
+	builder.WriteString("handoffs=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Handoffs))
 	builder.WriteByte(')')
 	return builder.String()
 }
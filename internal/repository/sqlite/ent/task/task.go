@@ -24,6 +24,8 @@ const (
 	FieldTitle = "title"
 	// FieldDescription holds the string denoting the description field in the database.
 	FieldDescription = "description"
+	// FieldInstructions holds the string denoting the instructions field in the database.
+	FieldInstructions = "instructions"
 	// FieldState holds the string denoting the state field in the database.
 	FieldState = "state"
 	// FieldPriority holds the string denoting the priority field in the database.
@@ -42,6 +44,30 @@ const (
 	FieldUpdatedAt = "updated_at"
 	// FieldCompletedAt holds the string denoting the completed_at field in the database.
 	FieldCompletedAt = "completed_at"
+	// FieldTags holds the string denoting the tags field in the database.
+	FieldTags = "tags"
+	// FieldAcceptanceCriteria holds the string denoting the acceptance_criteria field in the database.
+	FieldAcceptanceCriteria = "acceptance_criteria"
+	// FieldEffortLog holds the string denoting the effort_log field in the database.
+	FieldEffortLog = "effort_log"
+	// FieldReview holds the string denoting the review field in the database.
+	FieldReview = "review"
+	// FieldKeepComplexity holds the string denoting the keep_complexity field in the database.
+	FieldKeepComplexity = "keep_complexity"
+	// FieldCreatedBy holds the string denoting the created_by field in the database.
+	FieldCreatedBy = "created_by"
+	// FieldUpdatedBy holds the string denoting the updated_by field in the database.
+	FieldUpdatedBy = "updated_by"
+	// FieldPosition holds the string denoting the position field in the database.
+	FieldPosition = "position"
+	// FieldDueDate holds the string denoting the due_date field in the database.
+	FieldDueDate = "due_date"
+	// FieldBlocker holds the string denoting the blocker field in the database.
+	FieldBlocker = "blocker"
+	// FieldCheck holds the string denoting the check field in the database.
+	FieldCheck = "check"
+	// FieldHandoffs holds the string denoting the handoffs field in the database.
+	FieldHandoffs = "handoffs"
 	// EdgeProject holds the string denoting the project edge name in mutations.
 	EdgeProject = "project"
 	// EdgeParent holds the string denoting the parent edge name in mutations.
@@ -74,6 +100,7 @@ var Columns = []string{
 	FieldParentID,
 	FieldTitle,
 	FieldDescription,
+	FieldInstructions,
 	FieldState,
 	FieldPriority,
 	FieldComplexity,
@@ -83,6 +110,18 @@ var Columns = []string{
 	FieldCreatedAt,
 	FieldUpdatedAt,
 	FieldCompletedAt,
+	FieldTags,
+	FieldAcceptanceCriteria,
+	FieldEffortLog,
+	FieldReview,
+	FieldKeepComplexity,
+	FieldCreatedBy,
+	FieldUpdatedBy,
+	FieldPosition,
+	FieldDueDate,
+	FieldBlocker,
+	FieldCheck,
+	FieldHandoffs,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -110,6 +149,10 @@ var (
 	DefaultUpdatedAt func() time.Time
 	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
 	UpdateDefaultUpdatedAt func() time.Time
+	// DefaultKeepComplexity holds the default value on creation for the "keep_complexity" field.
+	DefaultKeepComplexity bool
+	// DefaultPosition holds the default value on creation for the "position" field.
+	DefaultPosition int
 	// DefaultID holds the default value on creation for the "id" field.
 	DefaultID func() uuid.UUID
 )
@@ -199,6 +242,11 @@ func ByDescription(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldDescription, opts...).ToFunc()
 }
 
+// ByInstructions orders the results by the instructions field.
+func ByInstructions(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldInstructions, opts...).ToFunc()
+}
+
 // ByState orders the results by the state field.
 func ByState(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldState, opts...).ToFunc()
@@ -244,6 +292,31 @@ func ByCompletedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCompletedAt, opts...).ToFunc()
 }
 
+// ByKeepComplexity orders the results by the keep_complexity field.
+func ByKeepComplexity(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldKeepComplexity, opts...).ToFunc()
+}
+
+// ByCreatedBy orders the results by the created_by field.
+func ByCreatedBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedBy, opts...).ToFunc()
+}
+
+// ByUpdatedBy orders the results by the updated_by field.
+func ByUpdatedBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdatedBy, opts...).ToFunc()
+}
+
+// ByPosition orders the results by the position field.
+func ByPosition(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPosition, opts...).ToFunc()
+}
+
+// ByDueDate orders the results by the due_date field.
+func ByDueDate(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDueDate, opts...).ToFunc()
+}
+
 // ByProjectField orders the results by project field.
 func ByProjectField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
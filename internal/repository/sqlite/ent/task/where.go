@@ -76,6 +76,11 @@ func Description(v string) predicate.Task {
 	return predicate.Task(sql.FieldEQ(FieldDescription, v))
 }
 
+// Instructions applies equality check predicate on the "instructions" field. It's identical to InstructionsEQ.
+func Instructions(v string) predicate.Task {
+	return predicate.Task(sql.FieldEQ(FieldInstructions, v))
+}
+
 // Complexity applies equality check predicate on the "complexity" field. It's identical to ComplexityEQ.
 func Complexity(v int) predicate.Task {
 	return predicate.Task(sql.FieldEQ(FieldComplexity, v))
@@ -111,6 +116,31 @@ func CompletedAt(v time.Time) predicate.Task {
 	return predicate.Task(sql.FieldEQ(FieldCompletedAt, v))
 }
 
+// KeepComplexity applies equality check predicate on the "keep_complexity" field. It's identical to KeepComplexityEQ.
+func KeepComplexity(v bool) predicate.Task {
+	return predicate.Task(sql.FieldEQ(FieldKeepComplexity, v))
+}
+
+// CreatedBy applies equality check predicate on the "created_by" field. It's identical to CreatedByEQ.
+func CreatedBy(v string) predicate.Task {
+	return predicate.Task(sql.FieldEQ(FieldCreatedBy, v))
+}
+
+// UpdatedBy applies equality check predicate on the "updated_by" field. It's identical to UpdatedByEQ.
+func UpdatedBy(v string) predicate.Task {
+	return predicate.Task(sql.FieldEQ(FieldUpdatedBy, v))
+}
+
+// Position applies equality check predicate on the "position" field. It's identical to PositionEQ.
+func Position(v int) predicate.Task {
+	return predicate.Task(sql.FieldEQ(FieldPosition, v))
+}
+
+// DueDate applies equality check predicate on the "due_date" field. It's identical to DueDateEQ.
+func DueDate(v time.Time) predicate.Task {
+	return predicate.Task(sql.FieldEQ(FieldDueDate, v))
+}
+
 // ProjectIDEQ applies the EQ predicate on the "project_id" field.
 func ProjectIDEQ(v uuid.UUID) predicate.Task {
 	return predicate.Task(sql.FieldEQ(FieldProjectID, v))
@@ -301,6 +331,81 @@ func DescriptionContainsFold(v string) predicate.Task {
 	return predicate.Task(sql.FieldContainsFold(FieldDescription, v))
 }
 
+// InstructionsEQ applies the EQ predicate on the "instructions" field.
+func InstructionsEQ(v string) predicate.Task {
+	return predicate.Task(sql.FieldEQ(FieldInstructions, v))
+}
+
+// InstructionsNEQ applies the NEQ predicate on the "instructions" field.
+func InstructionsNEQ(v string) predicate.Task {
+	return predicate.Task(sql.FieldNEQ(FieldInstructions, v))
+}
+
+// InstructionsIn applies the In predicate on the "instructions" field.
+func InstructionsIn(vs ...string) predicate.Task {
+	return predicate.Task(sql.FieldIn(FieldInstructions, vs...))
+}
+
+// InstructionsNotIn applies the NotIn predicate on the "instructions" field.
+func InstructionsNotIn(vs ...string) predicate.Task {
+	return predicate.Task(sql.FieldNotIn(FieldInstructions, vs...))
+}
+
+// InstructionsGT applies the GT predicate on the "instructions" field.
+func InstructionsGT(v string) predicate.Task {
+	return predicate.Task(sql.FieldGT(FieldInstructions, v))
+}
+
+// InstructionsGTE applies the GTE predicate on the "instructions" field.
+func InstructionsGTE(v string) predicate.Task {
+	return predicate.Task(sql.FieldGTE(FieldInstructions, v))
+}
+
+// InstructionsLT applies the LT predicate on the "instructions" field.
+func InstructionsLT(v string) predicate.Task {
+	return predicate.Task(sql.FieldLT(FieldInstructions, v))
+}
+
+// InstructionsLTE applies the LTE predicate on the "instructions" field.
+func InstructionsLTE(v string) predicate.Task {
+	return predicate.Task(sql.FieldLTE(FieldInstructions, v))
+}
+
+// InstructionsContains applies the Contains predicate on the "instructions" field.
+func InstructionsContains(v string) predicate.Task {
+	return predicate.Task(sql.FieldContains(FieldInstructions, v))
+}
+
+// InstructionsHasPrefix applies the HasPrefix predicate on the "instructions" field.
+func InstructionsHasPrefix(v string) predicate.Task {
+	return predicate.Task(sql.FieldHasPrefix(FieldInstructions, v))
+}
+
+// InstructionsHasSuffix applies the HasSuffix predicate on the "instructions" field.
+func InstructionsHasSuffix(v string) predicate.Task {
+	return predicate.Task(sql.FieldHasSuffix(FieldInstructions, v))
+}
+
+// InstructionsIsNil applies the IsNil predicate on the "instructions" field.
+func InstructionsIsNil() predicate.Task {
+	return predicate.Task(sql.FieldIsNull(FieldInstructions))
+}
+
+// InstructionsNotNil applies the NotNil predicate on the "instructions" field.
+func InstructionsNotNil() predicate.Task {
+	return predicate.Task(sql.FieldNotNull(FieldInstructions))
+}
+
+// InstructionsEqualFold applies the EqualFold predicate on the "instructions" field.
+func InstructionsEqualFold(v string) predicate.Task {
+	return predicate.Task(sql.FieldEqualFold(FieldInstructions, v))
+}
+
+// InstructionsContainsFold applies the ContainsFold predicate on the "instructions" field.
+func InstructionsContainsFold(v string) predicate.Task {
+	return predicate.Task(sql.FieldContainsFold(FieldInstructions, v))
+}
+
 // StateEQ applies the EQ predicate on the "state" field.
 func StateEQ(v State) predicate.Task {
 	return predicate.Task(sql.FieldEQ(FieldState, v))
@@ -651,6 +756,326 @@ func CompletedAtNotNil() predicate.Task {
 	return predicate.Task(sql.FieldNotNull(FieldCompletedAt))
 }
 
+// TagsIsNil applies the IsNil predicate on the "tags" field.
+func TagsIsNil() predicate.Task {
+	return predicate.Task(sql.FieldIsNull(FieldTags))
+}
+
+// TagsNotNil applies the NotNil predicate on the "tags" field.
+func TagsNotNil() predicate.Task {
+	return predicate.Task(sql.FieldNotNull(FieldTags))
+}
+
+// AcceptanceCriteriaIsNil applies the IsNil predicate on the "acceptance_criteria" field.
+func AcceptanceCriteriaIsNil() predicate.Task {
+	return predicate.Task(sql.FieldIsNull(FieldAcceptanceCriteria))
+}
+
+// AcceptanceCriteriaNotNil applies the NotNil predicate on the "acceptance_criteria" field.
+func AcceptanceCriteriaNotNil() predicate.Task {
+	return predicate.Task(sql.FieldNotNull(FieldAcceptanceCriteria))
+}
+
+// EffortLogIsNil applies the IsNil predicate on the "effort_log" field.
+func EffortLogIsNil() predicate.Task {
+	return predicate.Task(sql.FieldIsNull(FieldEffortLog))
+}
+
+// EffortLogNotNil applies the NotNil predicate on the "effort_log" field.
+func EffortLogNotNil() predicate.Task {
+	return predicate.Task(sql.FieldNotNull(FieldEffortLog))
+}
+
+// ReviewIsNil applies the IsNil predicate on the "review" field.
+func ReviewIsNil() predicate.Task {
+	return predicate.Task(sql.FieldIsNull(FieldReview))
+}
+
+// ReviewNotNil applies the NotNil predicate on the "review" field.
+func ReviewNotNil() predicate.Task {
+	return predicate.Task(sql.FieldNotNull(FieldReview))
+}
+
+// KeepComplexityEQ applies the EQ predicate on the "keep_complexity" field.
+func KeepComplexityEQ(v bool) predicate.Task {
+	return predicate.Task(sql.FieldEQ(FieldKeepComplexity, v))
+}
+
+// KeepComplexityNEQ applies the NEQ predicate on the "keep_complexity" field.
+func KeepComplexityNEQ(v bool) predicate.Task {
+	return predicate.Task(sql.FieldNEQ(FieldKeepComplexity, v))
+}
+
+// CreatedByEQ applies the EQ predicate on the "created_by" field.
+func CreatedByEQ(v string) predicate.Task {
+	return predicate.Task(sql.FieldEQ(FieldCreatedBy, v))
+}
+
+// CreatedByNEQ applies the NEQ predicate on the "created_by" field.
+func CreatedByNEQ(v string) predicate.Task {
+	return predicate.Task(sql.FieldNEQ(FieldCreatedBy, v))
+}
+
+// CreatedByIn applies the In predicate on the "created_by" field.
+func CreatedByIn(vs ...string) predicate.Task {
+	return predicate.Task(sql.FieldIn(FieldCreatedBy, vs...))
+}
+
+// CreatedByNotIn applies the NotIn predicate on the "created_by" field.
+func CreatedByNotIn(vs ...string) predicate.Task {
+	return predicate.Task(sql.FieldNotIn(FieldCreatedBy, vs...))
+}
+
+// CreatedByGT applies the GT predicate on the "created_by" field.
+func CreatedByGT(v string) predicate.Task {
+	return predicate.Task(sql.FieldGT(FieldCreatedBy, v))
+}
+
+// CreatedByGTE applies the GTE predicate on the "created_by" field.
+func CreatedByGTE(v string) predicate.Task {
+	return predicate.Task(sql.FieldGTE(FieldCreatedBy, v))
+}
+
+// CreatedByLT applies the LT predicate on the "created_by" field.
+func CreatedByLT(v string) predicate.Task {
+	return predicate.Task(sql.FieldLT(FieldCreatedBy, v))
+}
+
+// CreatedByLTE applies the LTE predicate on the "created_by" field.
+func CreatedByLTE(v string) predicate.Task {
+	return predicate.Task(sql.FieldLTE(FieldCreatedBy, v))
+}
+
+// CreatedByContains applies the Contains predicate on the "created_by" field.
+func CreatedByContains(v string) predicate.Task {
+	return predicate.Task(sql.FieldContains(FieldCreatedBy, v))
+}
+
+// CreatedByHasPrefix applies the HasPrefix predicate on the "created_by" field.
+func CreatedByHasPrefix(v string) predicate.Task {
+	return predicate.Task(sql.FieldHasPrefix(FieldCreatedBy, v))
+}
+
+// CreatedByHasSuffix applies the HasSuffix predicate on the "created_by" field.
+func CreatedByHasSuffix(v string) predicate.Task {
+	return predicate.Task(sql.FieldHasSuffix(FieldCreatedBy, v))
+}
+
+// CreatedByIsNil applies the IsNil predicate on the "created_by" field.
+func CreatedByIsNil() predicate.Task {
+	return predicate.Task(sql.FieldIsNull(FieldCreatedBy))
+}
+
+// CreatedByNotNil applies the NotNil predicate on the "created_by" field.
+func CreatedByNotNil() predicate.Task {
+	return predicate.Task(sql.FieldNotNull(FieldCreatedBy))
+}
+
+// CreatedByEqualFold applies the EqualFold predicate on the "created_by" field.
+func CreatedByEqualFold(v string) predicate.Task {
+	return predicate.Task(sql.FieldEqualFold(FieldCreatedBy, v))
+}
+
+// CreatedByContainsFold applies the ContainsFold predicate on the "created_by" field.
+func CreatedByContainsFold(v string) predicate.Task {
+	return predicate.Task(sql.FieldContainsFold(FieldCreatedBy, v))
+}
+
+// UpdatedByEQ applies the EQ predicate on the "updated_by" field.
+func UpdatedByEQ(v string) predicate.Task {
+	return predicate.Task(sql.FieldEQ(FieldUpdatedBy, v))
+}
+
+// UpdatedByNEQ applies the NEQ predicate on the "updated_by" field.
+func UpdatedByNEQ(v string) predicate.Task {
+	return predicate.Task(sql.FieldNEQ(FieldUpdatedBy, v))
+}
+
+// UpdatedByIn applies the In predicate on the "updated_by" field.
+func UpdatedByIn(vs ...string) predicate.Task {
+	return predicate.Task(sql.FieldIn(FieldUpdatedBy, vs...))
+}
+
+// UpdatedByNotIn applies the NotIn predicate on the "updated_by" field.
+func UpdatedByNotIn(vs ...string) predicate.Task {
+	return predicate.Task(sql.FieldNotIn(FieldUpdatedBy, vs...))
+}
+
+// UpdatedByGT applies the GT predicate on the "updated_by" field.
+func UpdatedByGT(v string) predicate.Task {
+	return predicate.Task(sql.FieldGT(FieldUpdatedBy, v))
+}
+
+// UpdatedByGTE applies the GTE predicate on the "updated_by" field.
+func UpdatedByGTE(v string) predicate.Task {
+	return predicate.Task(sql.FieldGTE(FieldUpdatedBy, v))
+}
+
+// UpdatedByLT applies the LT predicate on the "updated_by" field.
+func UpdatedByLT(v string) predicate.Task {
+	return predicate.Task(sql.FieldLT(FieldUpdatedBy, v))
+}
+
+// UpdatedByLTE applies the LTE predicate on the "updated_by" field.
+func UpdatedByLTE(v string) predicate.Task {
+	return predicate.Task(sql.FieldLTE(FieldUpdatedBy, v))
+}
+
+// UpdatedByContains applies the Contains predicate on the "updated_by" field.
+func UpdatedByContains(v string) predicate.Task {
+	return predicate.Task(sql.FieldContains(FieldUpdatedBy, v))
+}
+
+// UpdatedByHasPrefix applies the HasPrefix predicate on the "updated_by" field.
+func UpdatedByHasPrefix(v string) predicate.Task {
+	return predicate.Task(sql.FieldHasPrefix(FieldUpdatedBy, v))
+}
+
+// UpdatedByHasSuffix applies the HasSuffix predicate on the "updated_by" field.
+func UpdatedByHasSuffix(v string) predicate.Task {
+	return predicate.Task(sql.FieldHasSuffix(FieldUpdatedBy, v))
+}
+
+// UpdatedByIsNil applies the IsNil predicate on the "updated_by" field.
+func UpdatedByIsNil() predicate.Task {
+	return predicate.Task(sql.FieldIsNull(FieldUpdatedBy))
+}
+
+// UpdatedByNotNil applies the NotNil predicate on the "updated_by" field.
+func UpdatedByNotNil() predicate.Task {
+	return predicate.Task(sql.FieldNotNull(FieldUpdatedBy))
+}
+
+// UpdatedByEqualFold applies the EqualFold predicate on the "updated_by" field.
+func UpdatedByEqualFold(v string) predicate.Task {
+	return predicate.Task(sql.FieldEqualFold(FieldUpdatedBy, v))
+}
+
+// UpdatedByContainsFold applies the ContainsFold predicate on the "updated_by" field.
+func UpdatedByContainsFold(v string) predicate.Task {
+	return predicate.Task(sql.FieldContainsFold(FieldUpdatedBy, v))
+}
+
+// PositionEQ applies the EQ predicate on the "position" field.
+func PositionEQ(v int) predicate.Task {
+	return predicate.Task(sql.FieldEQ(FieldPosition, v))
+}
+
+// PositionNEQ applies the NEQ predicate on the "position" field.
+func PositionNEQ(v int) predicate.Task {
+	return predicate.Task(sql.FieldNEQ(FieldPosition, v))
+}
+
+// PositionIn applies the In predicate on the "position" field.
+func PositionIn(vs ...int) predicate.Task {
+	return predicate.Task(sql.FieldIn(FieldPosition, vs...))
+}
+
+// PositionNotIn applies the NotIn predicate on the "position" field.
+func PositionNotIn(vs ...int) predicate.Task {
+	return predicate.Task(sql.FieldNotIn(FieldPosition, vs...))
+}
+
+// PositionGT applies the GT predicate on the "position" field.
+func PositionGT(v int) predicate.Task {
+	return predicate.Task(sql.FieldGT(FieldPosition, v))
+}
+
+// PositionGTE applies the GTE predicate on the "position" field.
+func PositionGTE(v int) predicate.Task {
+	return predicate.Task(sql.FieldGTE(FieldPosition, v))
+}
+
+// PositionLT applies the LT predicate on the "position" field.
+func PositionLT(v int) predicate.Task {
+	return predicate.Task(sql.FieldLT(FieldPosition, v))
+}
+
+// PositionLTE applies the LTE predicate on the "position" field.
+func PositionLTE(v int) predicate.Task {
+	return predicate.Task(sql.FieldLTE(FieldPosition, v))
+}
+
+// DueDateEQ applies the EQ predicate on the "due_date" field.
+func DueDateEQ(v time.Time) predicate.Task {
+	return predicate.Task(sql.FieldEQ(FieldDueDate, v))
+}
+
+// DueDateNEQ applies the NEQ predicate on the "due_date" field.
+func DueDateNEQ(v time.Time) predicate.Task {
+	return predicate.Task(sql.FieldNEQ(FieldDueDate, v))
+}
+
+// DueDateIn applies the In predicate on the "due_date" field.
+func DueDateIn(vs ...time.Time) predicate.Task {
+	return predicate.Task(sql.FieldIn(FieldDueDate, vs...))
+}
+
+// DueDateNotIn applies the NotIn predicate on the "due_date" field.
+func DueDateNotIn(vs ...time.Time) predicate.Task {
+	return predicate.Task(sql.FieldNotIn(FieldDueDate, vs...))
+}
+
+// DueDateGT applies the GT predicate on the "due_date" field.
+func DueDateGT(v time.Time) predicate.Task {
+	return predicate.Task(sql.FieldGT(FieldDueDate, v))
+}
+
+// DueDateGTE applies the GTE predicate on the "due_date" field.
+func DueDateGTE(v time.Time) predicate.Task {
+	return predicate.Task(sql.FieldGTE(FieldDueDate, v))
+}
+
+// DueDateLT applies the LT predicate on the "due_date" field.
+func DueDateLT(v time.Time) predicate.Task {
+	return predicate.Task(sql.FieldLT(FieldDueDate, v))
+}
+
+// DueDateLTE applies the LTE predicate on the "due_date" field.
+func DueDateLTE(v time.Time) predicate.Task {
+	return predicate.Task(sql.FieldLTE(FieldDueDate, v))
+}
+
+// DueDateIsNil applies the IsNil predicate on the "due_date" field.
+func DueDateIsNil() predicate.Task {
+	return predicate.Task(sql.FieldIsNull(FieldDueDate))
+}
+
+// DueDateNotNil applies the NotNil predicate on the "due_date" field.
+func DueDateNotNil() predicate.Task {
+	return predicate.Task(sql.FieldNotNull(FieldDueDate))
+}
+
+// BlockerIsNil applies the IsNil predicate on the "blocker" field.
+func BlockerIsNil() predicate.Task {
+	return predicate.Task(sql.FieldIsNull(FieldBlocker))
+}
+
+// BlockerNotNil applies the NotNil predicate on the "blocker" field.
+func BlockerNotNil() predicate.Task {
+	return predicate.Task(sql.FieldNotNull(FieldBlocker))
+}
+
+// CheckIsNil applies the IsNil predicate on the "check" field.
+func CheckIsNil() predicate.Task {
+	return predicate.Task(sql.FieldIsNull(FieldCheck))
+}
+
+// CheckNotNil applies the NotNil predicate on the "check" field.
+func CheckNotNil() predicate.Task {
+	return predicate.Task(sql.FieldNotNull(FieldCheck))
+}
+
+// HandoffsIsNil applies the IsNil predicate on the "handoffs" field.
+func HandoffsIsNil() predicate.Task {
+	return predicate.Task(sql.FieldIsNull(FieldHandoffs))
+}
+
+// HandoffsNotNil applies the NotNil predicate on the "handoffs" field.
+func HandoffsNotNil() predicate.Task {
+	return predicate.Task(sql.FieldNotNull(FieldHandoffs))
+}
+
 // HasProject applies the HasEdge predicate on the "project" edge.
 func HasProject() predicate.Task {
 	return predicate.Task(func(s *sql.Selector) {
@@ -12,6 +12,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/project"
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/task"
+	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
 )
 
@@ -62,6 +63,20 @@ func (_c *TaskCreate) SetNillableDescription(v *string) *TaskCreate {
 	return _c
 }
 
+// SetInstructions sets the "instructions" field.
+func (_c *TaskCreate) SetInstructions(v string) *TaskCreate {
+	_c.mutation.SetInstructions(v)
+	return _c
+}
+
+// SetNillableInstructions sets the "instructions" field if the given value is not nil.
+func (_c *TaskCreate) SetNillableInstructions(v *string) *TaskCreate {
+	if v != nil {
+		_c.SetInstructions(*v)
+	}
+	return _c
+}
+
 // SetState sets the "state" field.
 func (_c *TaskCreate) SetState(v task.State) *TaskCreate {
 	_c.mutation.SetState(v)
@@ -180,6 +195,118 @@ func (_c *TaskCreate) SetNillableCompletedAt(v *time.Time) *TaskCreate {
 	return _c
 }
 
+// SetTags sets the "tags" field.
+func (_c *TaskCreate) SetTags(v []string) *TaskCreate {
+	_c.mutation.SetTags(v)
+	return _c
+}
+
+// SetAcceptanceCriteria sets the "acceptance_criteria" field.
+func (_c *TaskCreate) SetAcceptanceCriteria(v []types.AcceptanceCriterion) *TaskCreate {
+	_c.mutation.SetAcceptanceCriteria(v)
+	return _c
+}
+
+// SetEffortLog sets the "effort_log" field.
+func (_c *TaskCreate) SetEffortLog(v []types.EffortEntry) *TaskCreate {
+	_c.mutation.SetEffortLog(v)
+	return _c
+}
+
+// SetReview sets the "review" field.
+func (_c *TaskCreate) SetReview(v *types.TaskReview) *TaskCreate {
+	_c.mutation.SetReview(v)
+	return _c
+}
+
+// SetKeepComplexity sets the "keep_complexity" field.
+func (_c *TaskCreate) SetKeepComplexity(v bool) *TaskCreate {
+	_c.mutation.SetKeepComplexity(v)
+	return _c
+}
+
+// SetNillableKeepComplexity sets the "keep_complexity" field if the given value is not nil.
+func (_c *TaskCreate) SetNillableKeepComplexity(v *bool) *TaskCreate {
+	if v != nil {
+		_c.SetKeepComplexity(*v)
+	}
+	return _c
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_c *TaskCreate) SetCreatedBy(v string) *TaskCreate {
+	_c.mutation.SetCreatedBy(v)
+	return _c
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_c *TaskCreate) SetNillableCreatedBy(v *string) *TaskCreate {
+	if v != nil {
+		_c.SetCreatedBy(*v)
+	}
+	return _c
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_c *TaskCreate) SetUpdatedBy(v string) *TaskCreate {
+	_c.mutation.SetUpdatedBy(v)
+	return _c
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_c *TaskCreate) SetNillableUpdatedBy(v *string) *TaskCreate {
+	if v != nil {
+		_c.SetUpdatedBy(*v)
+	}
+	return _c
+}
+
+// SetPosition sets the "position" field.
+func (_c *TaskCreate) SetPosition(v int) *TaskCreate {
+	_c.mutation.SetPosition(v)
+	return _c
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (_c *TaskCreate) SetNillablePosition(v *int) *TaskCreate {
+	if v != nil {
+		_c.SetPosition(*v)
+	}
+	return _c
+}
+
+// SetDueDate sets the "due_date" field.
+func (_c *TaskCreate) SetDueDate(v time.Time) *TaskCreate {
+	_c.mutation.SetDueDate(v)
+	return _c
+}
+
+// SetNillableDueDate sets the "due_date" field if the given value is not nil.
+func (_c *TaskCreate) SetNillableDueDate(v *time.Time) *TaskCreate {
+	if v != nil {
+		_c.SetDueDate(*v)
+	}
+	return _c
+}
+
+// SetBlocker sets the "blocker" field.
+func (_c *TaskCreate) SetBlocker(v *types.TaskBlocker) *TaskCreate {
+	_c.mutation.SetBlocker(v)
+	return _c
+}
+
+// SetCheck sets the "check" field.
+func (_c *TaskCreate) SetCheck(v *types.TaskCheck) *TaskCreate {
+	_c.mutation.SetCheck(v)
+	return _c
+}
+
+// SetHandoffs sets the "handoffs" field.
+func (_c *TaskCreate) SetHandoffs(v []types.TaskHandoff) *TaskCreate {
+	_c.mutation.SetHandoffs(v)
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *TaskCreate) SetID(v uuid.UUID) *TaskCreate {
 	_c.mutation.SetID(v)
@@ -274,6 +401,14 @@ func (_c *TaskCreate) defaults() {
 		v := task.DefaultUpdatedAt()
 		_c.mutation.SetUpdatedAt(v)
 	}
+	if _, ok := _c.mutation.KeepComplexity(); !ok {
+		v := task.DefaultKeepComplexity
+		_c.mutation.SetKeepComplexity(v)
+	}
+	if _, ok := _c.mutation.Position(); !ok {
+		v := task.DefaultPosition
+		_c.mutation.SetPosition(v)
+	}
 	if _, ok := _c.mutation.ID(); !ok {
 		v := task.DefaultID()
 		_c.mutation.SetID(v)
@@ -331,6 +466,12 @@ func (_c *TaskCreate) check() error {
 	if _, ok := _c.mutation.UpdatedAt(); !ok {
 		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Task.updated_at"`)}
 	}
+	if _, ok := _c.mutation.KeepComplexity(); !ok {
+		return &ValidationError{Name: "keep_complexity", err: errors.New(`ent: missing required field "Task.keep_complexity"`)}
+	}
+	if _, ok := _c.mutation.Position(); !ok {
+		return &ValidationError{Name: "position", err: errors.New(`ent: missing required field "Task.position"`)}
+	}
 	if len(_c.mutation.ProjectIDs()) == 0 {
 		return &ValidationError{Name: "project", err: errors.New(`ent: missing required edge "Task.project"`)}
 	}
@@ -377,6 +518,10 @@ func (_c *TaskCreate) createSpec() (*Task, *sqlgraph.CreateSpec) {
 		_spec.SetField(task.FieldDescription, field.TypeString, value)
 		_node.Description = value
 	}
+	if value, ok := _c.mutation.Instructions(); ok {
+		_spec.SetField(task.FieldInstructions, field.TypeString, value)
+		_node.Instructions = value
+	}
 	if value, ok := _c.mutation.State(); ok {
 		_spec.SetField(task.FieldState, field.TypeEnum, value)
 		_node.State = value
@@ -413,6 +558,54 @@ func (_c *TaskCreate) createSpec() (*Task, *sqlgraph.CreateSpec) {
 		_spec.SetField(task.FieldCompletedAt, field.TypeTime, value)
 		_node.CompletedAt = &value
 	}
+	if value, ok := _c.mutation.Tags(); ok {
+		_spec.SetField(task.FieldTags, field.TypeJSON, value)
+		_node.Tags = value
+	}
+	if value, ok := _c.mutation.AcceptanceCriteria(); ok {
+		_spec.SetField(task.FieldAcceptanceCriteria, field.TypeJSON, value)
+		_node.AcceptanceCriteria = value
+	}
+	if value, ok := _c.mutation.EffortLog(); ok {
+		_spec.SetField(task.FieldEffortLog, field.TypeJSON, value)
+		_node.EffortLog = value
+	}
+	if value, ok := _c.mutation.Review(); ok {
+		_spec.SetField(task.FieldReview, field.TypeJSON, value)
+		_node.Review = value
+	}
+	if value, ok := _c.mutation.KeepComplexity(); ok {
+		_spec.SetField(task.FieldKeepComplexity, field.TypeBool, value)
+		_node.KeepComplexity = value
+	}
+	if value, ok := _c.mutation.CreatedBy(); ok {
+		_spec.SetField(task.FieldCreatedBy, field.TypeString, value)
+		_node.CreatedBy = value
+	}
+	if value, ok := _c.mutation.UpdatedBy(); ok {
+		_spec.SetField(task.FieldUpdatedBy, field.TypeString, value)
+		_node.UpdatedBy = value
+	}
+	if value, ok := _c.mutation.Position(); ok {
+		_spec.SetField(task.FieldPosition, field.TypeInt, value)
+		_node.Position = value
+	}
+	if value, ok := _c.mutation.DueDate(); ok {
+		_spec.SetField(task.FieldDueDate, field.TypeTime, value)
+		_node.DueDate = &value
+	}
+	if value, ok := _c.mutation.Blocker(); ok {
+		_spec.SetField(task.FieldBlocker, field.TypeJSON, value)
+		_node.Blocker = value
+	}
+	if value, ok := _c.mutation.Check(); ok {
+		_spec.SetField(task.FieldCheck, field.TypeJSON, value)
+		_node.Check = value
+	}
+	if value, ok := _c.mutation.Handoffs(); ok {
+		_spec.SetField(task.FieldHandoffs, field.TypeJSON, value)
+		_node.Handoffs = value
+	}
 	if nodes := _c.mutation.ProjectIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -10,10 +10,12 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/predicate"
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/project"
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/task"
+	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
 )
 
@@ -98,6 +100,26 @@ func (_u *TaskUpdate) ClearDescription() *TaskUpdate {
 	return _u
 }
 
+// SetInstructions sets the "instructions" field.
+func (_u *TaskUpdate) SetInstructions(v string) *TaskUpdate {
+	_u.mutation.SetInstructions(v)
+	return _u
+}
+
+// SetNillableInstructions sets the "instructions" field if the given value is not nil.
+func (_u *TaskUpdate) SetNillableInstructions(v *string) *TaskUpdate {
+	if v != nil {
+		_u.SetInstructions(*v)
+	}
+	return _u
+}
+
+// ClearInstructions clears the value of the "instructions" field.
+func (_u *TaskUpdate) ClearInstructions() *TaskUpdate {
+	_u.mutation.ClearInstructions()
+	return _u
+}
+
 // SetState sets the "state" field.
 func (_u *TaskUpdate) SetState(v task.State) *TaskUpdate {
 	_u.mutation.SetState(v)
@@ -241,6 +263,209 @@ func (_u *TaskUpdate) ClearCompletedAt() *TaskUpdate {
 	return _u
 }
 
+// SetTags sets the "tags" field.
+func (_u *TaskUpdate) SetTags(v []string) *TaskUpdate {
+	_u.mutation.SetTags(v)
+	return _u
+}
+
+// AppendTags appends value to the "tags" field.
+func (_u *TaskUpdate) AppendTags(v []string) *TaskUpdate {
+	_u.mutation.AppendTags(v)
+	return _u
+}
+
+// ClearTags clears the value of the "tags" field.
+func (_u *TaskUpdate) ClearTags() *TaskUpdate {
+	_u.mutation.ClearTags()
+	return _u
+}
+
+// SetAcceptanceCriteria sets the "acceptance_criteria" field.
+func (_u *TaskUpdate) SetAcceptanceCriteria(v []types.AcceptanceCriterion) *TaskUpdate {
+	_u.mutation.SetAcceptanceCriteria(v)
+	return _u
+}
+
+// AppendAcceptanceCriteria appends value to the "acceptance_criteria" field.
+func (_u *TaskUpdate) AppendAcceptanceCriteria(v []types.AcceptanceCriterion) *TaskUpdate {
+	_u.mutation.AppendAcceptanceCriteria(v)
+	return _u
+}
+
+// ClearAcceptanceCriteria clears the value of the "acceptance_criteria" field.
+func (_u *TaskUpdate) ClearAcceptanceCriteria() *TaskUpdate {
+	_u.mutation.ClearAcceptanceCriteria()
+	return _u
+}
+
+// SetEffortLog sets the "effort_log" field.
+func (_u *TaskUpdate) SetEffortLog(v []types.EffortEntry) *TaskUpdate {
+	_u.mutation.SetEffortLog(v)
+	return _u
+}
+
+// AppendEffortLog appends value to the "effort_log" field.
+func (_u *TaskUpdate) AppendEffortLog(v []types.EffortEntry) *TaskUpdate {
+	_u.mutation.AppendEffortLog(v)
+	return _u
+}
+
+// ClearEffortLog clears the value of the "effort_log" field.
+func (_u *TaskUpdate) ClearEffortLog() *TaskUpdate {
+	_u.mutation.ClearEffortLog()
+	return _u
+}
+
+// SetReview sets the "review" field.
+func (_u *TaskUpdate) SetReview(v *types.TaskReview) *TaskUpdate {
+	_u.mutation.SetReview(v)
+	return _u
+}
+
+// ClearReview clears the value of the "review" field.
+func (_u *TaskUpdate) ClearReview() *TaskUpdate {
+	_u.mutation.ClearReview()
+	return _u
+}
+
+// SetKeepComplexity sets the "keep_complexity" field.
+func (_u *TaskUpdate) SetKeepComplexity(v bool) *TaskUpdate {
+	_u.mutation.SetKeepComplexity(v)
+	return _u
+}
+
+// SetNillableKeepComplexity sets the "keep_complexity" field if the given value is not nil.
+func (_u *TaskUpdate) SetNillableKeepComplexity(v *bool) *TaskUpdate {
+	if v != nil {
+		_u.SetKeepComplexity(*v)
+	}
+	return _u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_u *TaskUpdate) SetCreatedBy(v string) *TaskUpdate {
+	_u.mutation.SetCreatedBy(v)
+	return _u
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_u *TaskUpdate) SetNillableCreatedBy(v *string) *TaskUpdate {
+	if v != nil {
+		_u.SetCreatedBy(*v)
+	}
+	return _u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (_u *TaskUpdate) ClearCreatedBy() *TaskUpdate {
+	_u.mutation.ClearCreatedBy()
+	return _u
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_u *TaskUpdate) SetUpdatedBy(v string) *TaskUpdate {
+	_u.mutation.SetUpdatedBy(v)
+	return _u
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_u *TaskUpdate) SetNillableUpdatedBy(v *string) *TaskUpdate {
+	if v != nil {
+		_u.SetUpdatedBy(*v)
+	}
+	return _u
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (_u *TaskUpdate) ClearUpdatedBy() *TaskUpdate {
+	_u.mutation.ClearUpdatedBy()
+	return _u
+}
+
+// SetPosition sets the "position" field.
+func (_u *TaskUpdate) SetPosition(v int) *TaskUpdate {
+	_u.mutation.ResetPosition()
+	_u.mutation.SetPosition(v)
+	return _u
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (_u *TaskUpdate) SetNillablePosition(v *int) *TaskUpdate {
+	if v != nil {
+		_u.SetPosition(*v)
+	}
+	return _u
+}
+
+// AddPosition adds value to the "position" field.
+func (_u *TaskUpdate) AddPosition(v int) *TaskUpdate {
+	_u.mutation.AddPosition(v)
+	return _u
+}
+
+// SetDueDate sets the "due_date" field.
+func (_u *TaskUpdate) SetDueDate(v time.Time) *TaskUpdate {
+	_u.mutation.SetDueDate(v)
+	return _u
+}
+
+// SetNillableDueDate sets the "due_date" field if the given value is not nil.
+func (_u *TaskUpdate) SetNillableDueDate(v *time.Time) *TaskUpdate {
+	if v != nil {
+		_u.SetDueDate(*v)
+	}
+	return _u
+}
+
+// ClearDueDate clears the value of the "due_date" field.
+func (_u *TaskUpdate) ClearDueDate() *TaskUpdate {
+	_u.mutation.ClearDueDate()
+	return _u
+}
+
+// SetBlocker sets the "blocker" field.
+func (_u *TaskUpdate) SetBlocker(v *types.TaskBlocker) *TaskUpdate {
+	_u.mutation.SetBlocker(v)
+	return _u
+}
+
+// ClearBlocker clears the value of the "blocker" field.
+func (_u *TaskUpdate) ClearBlocker() *TaskUpdate {
+	_u.mutation.ClearBlocker()
+	return _u
+}
+
+// SetCheck sets the "check" field.
+func (_u *TaskUpdate) SetCheck(v *types.TaskCheck) *TaskUpdate {
+	_u.mutation.SetCheck(v)
+	return _u
+}
+
+// ClearCheck clears the value of the "check" field.
+func (_u *TaskUpdate) ClearCheck() *TaskUpdate {
+	_u.mutation.ClearCheck()
+	return _u
+}
+
+// SetHandoffs sets the "handoffs" field.
+func (_u *TaskUpdate) SetHandoffs(v []types.TaskHandoff) *TaskUpdate {
+	_u.mutation.SetHandoffs(v)
+	return _u
+}
+
+// AppendHandoffs appends value to the "handoffs" field.
+func (_u *TaskUpdate) AppendHandoffs(v []types.TaskHandoff) *TaskUpdate {
+	_u.mutation.AppendHandoffs(v)
+	return _u
+}
+
+// ClearHandoffs clears the value of the "handoffs" field.
+func (_u *TaskUpdate) ClearHandoffs() *TaskUpdate {
+	_u.mutation.ClearHandoffs()
+	return _u
+}
+
 // SetProject sets the "project" edge to the Project entity.
 func (_u *TaskUpdate) SetProject(v *Project) *TaskUpdate {
 	return _u.SetProjectID(v.ID)
@@ -394,6 +619,12 @@ func (_u *TaskUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.DescriptionCleared() {
 		_spec.ClearField(task.FieldDescription, field.TypeString)
 	}
+	if value, ok := _u.mutation.Instructions(); ok {
+		_spec.SetField(task.FieldInstructions, field.TypeString, value)
+	}
+	if _u.mutation.InstructionsCleared() {
+		_spec.ClearField(task.FieldInstructions, field.TypeString)
+	}
 	if value, ok := _u.mutation.State(); ok {
 		_spec.SetField(task.FieldState, field.TypeEnum, value)
 	}
@@ -436,6 +667,95 @@ func (_u *TaskUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.CompletedAtCleared() {
 		_spec.ClearField(task.FieldCompletedAt, field.TypeTime)
 	}
+	if value, ok := _u.mutation.Tags(); ok {
+		_spec.SetField(task.FieldTags, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedTags(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, task.FieldTags, value)
+		})
+	}
+	if _u.mutation.TagsCleared() {
+		_spec.ClearField(task.FieldTags, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.AcceptanceCriteria(); ok {
+		_spec.SetField(task.FieldAcceptanceCriteria, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAcceptanceCriteria(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, task.FieldAcceptanceCriteria, value)
+		})
+	}
+	if _u.mutation.AcceptanceCriteriaCleared() {
+		_spec.ClearField(task.FieldAcceptanceCriteria, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.EffortLog(); ok {
+		_spec.SetField(task.FieldEffortLog, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedEffortLog(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, task.FieldEffortLog, value)
+		})
+	}
+	if _u.mutation.EffortLogCleared() {
+		_spec.ClearField(task.FieldEffortLog, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Review(); ok {
+		_spec.SetField(task.FieldReview, field.TypeJSON, value)
+	}
+	if _u.mutation.ReviewCleared() {
+		_spec.ClearField(task.FieldReview, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.KeepComplexity(); ok {
+		_spec.SetField(task.FieldKeepComplexity, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.CreatedBy(); ok {
+		_spec.SetField(task.FieldCreatedBy, field.TypeString, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(task.FieldCreatedBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.UpdatedBy(); ok {
+		_spec.SetField(task.FieldUpdatedBy, field.TypeString, value)
+	}
+	if _u.mutation.UpdatedByCleared() {
+		_spec.ClearField(task.FieldUpdatedBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.Position(); ok {
+		_spec.SetField(task.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedPosition(); ok {
+		_spec.AddField(task.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.DueDate(); ok {
+		_spec.SetField(task.FieldDueDate, field.TypeTime, value)
+	}
+	if _u.mutation.DueDateCleared() {
+		_spec.ClearField(task.FieldDueDate, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Blocker(); ok {
+		_spec.SetField(task.FieldBlocker, field.TypeJSON, value)
+	}
+	if _u.mutation.BlockerCleared() {
+		_spec.ClearField(task.FieldBlocker, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Check(); ok {
+		_spec.SetField(task.FieldCheck, field.TypeJSON, value)
+	}
+	if _u.mutation.CheckCleared() {
+		_spec.ClearField(task.FieldCheck, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Handoffs(); ok {
+		_spec.SetField(task.FieldHandoffs, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedHandoffs(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, task.FieldHandoffs, value)
+		})
+	}
+	if _u.mutation.HandoffsCleared() {
+		_spec.ClearField(task.FieldHandoffs, field.TypeJSON)
+	}
 	if _u.mutation.ProjectCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -627,6 +947,26 @@ func (_u *TaskUpdateOne) ClearDescription() *TaskUpdateOne {
 	return _u
 }
 
+// SetInstructions sets the "instructions" field.
+func (_u *TaskUpdateOne) SetInstructions(v string) *TaskUpdateOne {
+	_u.mutation.SetInstructions(v)
+	return _u
+}
+
+// SetNillableInstructions sets the "instructions" field if the given value is not nil.
+func (_u *TaskUpdateOne) SetNillableInstructions(v *string) *TaskUpdateOne {
+	if v != nil {
+		_u.SetInstructions(*v)
+	}
+	return _u
+}
+
+// ClearInstructions clears the value of the "instructions" field.
+func (_u *TaskUpdateOne) ClearInstructions() *TaskUpdateOne {
+	_u.mutation.ClearInstructions()
+	return _u
+}
+
 // SetState sets the "state" field.
 func (_u *TaskUpdateOne) SetState(v task.State) *TaskUpdateOne {
 	_u.mutation.SetState(v)
@@ -770,6 +1110,209 @@ func (_u *TaskUpdateOne) ClearCompletedAt() *TaskUpdateOne {
 	return _u
 }
 
+// SetTags sets the "tags" field.
+func (_u *TaskUpdateOne) SetTags(v []string) *TaskUpdateOne {
+	_u.mutation.SetTags(v)
+	return _u
+}
+
+// AppendTags appends value to the "tags" field.
+func (_u *TaskUpdateOne) AppendTags(v []string) *TaskUpdateOne {
+	_u.mutation.AppendTags(v)
+	return _u
+}
+
+// ClearTags clears the value of the "tags" field.
+func (_u *TaskUpdateOne) ClearTags() *TaskUpdateOne {
+	_u.mutation.ClearTags()
+	return _u
+}
+
+// SetAcceptanceCriteria sets the "acceptance_criteria" field.
+func (_u *TaskUpdateOne) SetAcceptanceCriteria(v []types.AcceptanceCriterion) *TaskUpdateOne {
+	_u.mutation.SetAcceptanceCriteria(v)
+	return _u
+}
+
+// AppendAcceptanceCriteria appends value to the "acceptance_criteria" field.
+func (_u *TaskUpdateOne) AppendAcceptanceCriteria(v []types.AcceptanceCriterion) *TaskUpdateOne {
+	_u.mutation.AppendAcceptanceCriteria(v)
+	return _u
+}
+
+// ClearAcceptanceCriteria clears the value of the "acceptance_criteria" field.
+func (_u *TaskUpdateOne) ClearAcceptanceCriteria() *TaskUpdateOne {
+	_u.mutation.ClearAcceptanceCriteria()
+	return _u
+}
+
+// SetEffortLog sets the "effort_log" field.
+func (_u *TaskUpdateOne) SetEffortLog(v []types.EffortEntry) *TaskUpdateOne {
+	_u.mutation.SetEffortLog(v)
+	return _u
+}
+
+// AppendEffortLog appends value to the "effort_log" field.
+func (_u *TaskUpdateOne) AppendEffortLog(v []types.EffortEntry) *TaskUpdateOne {
+	_u.mutation.AppendEffortLog(v)
+	return _u
+}
+
+// ClearEffortLog clears the value of the "effort_log" field.
+func (_u *TaskUpdateOne) ClearEffortLog() *TaskUpdateOne {
+	_u.mutation.ClearEffortLog()
+	return _u
+}
+
+// SetReview sets the "review" field.
+func (_u *TaskUpdateOne) SetReview(v *types.TaskReview) *TaskUpdateOne {
+	_u.mutation.SetReview(v)
+	return _u
+}
+
+// ClearReview clears the value of the "review" field.
+func (_u *TaskUpdateOne) ClearReview() *TaskUpdateOne {
+	_u.mutation.ClearReview()
+	return _u
+}
+
+// SetKeepComplexity sets the "keep_complexity" field.
+func (_u *TaskUpdateOne) SetKeepComplexity(v bool) *TaskUpdateOne {
+	_u.mutation.SetKeepComplexity(v)
+	return _u
+}
+
+// SetNillableKeepComplexity sets the "keep_complexity" field if the given value is not nil.
+func (_u *TaskUpdateOne) SetNillableKeepComplexity(v *bool) *TaskUpdateOne {
+	if v != nil {
+		_u.SetKeepComplexity(*v)
+	}
+	return _u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_u *TaskUpdateOne) SetCreatedBy(v string) *TaskUpdateOne {
+	_u.mutation.SetCreatedBy(v)
+	return _u
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_u *TaskUpdateOne) SetNillableCreatedBy(v *string) *TaskUpdateOne {
+	if v != nil {
+		_u.SetCreatedBy(*v)
+	}
+	return _u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (_u *TaskUpdateOne) ClearCreatedBy() *TaskUpdateOne {
+	_u.mutation.ClearCreatedBy()
+	return _u
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (_u *TaskUpdateOne) SetUpdatedBy(v string) *TaskUpdateOne {
+	_u.mutation.SetUpdatedBy(v)
+	return _u
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (_u *TaskUpdateOne) SetNillableUpdatedBy(v *string) *TaskUpdateOne {
+	if v != nil {
+		_u.SetUpdatedBy(*v)
+	}
+	return _u
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (_u *TaskUpdateOne) ClearUpdatedBy() *TaskUpdateOne {
+	_u.mutation.ClearUpdatedBy()
+	return _u
+}
+
+// SetPosition sets the "position" field.
+func (_u *TaskUpdateOne) SetPosition(v int) *TaskUpdateOne {
+	_u.mutation.ResetPosition()
+	_u.mutation.SetPosition(v)
+	return _u
+}
+
+// SetNillablePosition sets the "position" field if the given value is not nil.
+func (_u *TaskUpdateOne) SetNillablePosition(v *int) *TaskUpdateOne {
+	if v != nil {
+		_u.SetPosition(*v)
+	}
+	return _u
+}
+
+// AddPosition adds value to the "position" field.
+func (_u *TaskUpdateOne) AddPosition(v int) *TaskUpdateOne {
+	_u.mutation.AddPosition(v)
+	return _u
+}
+
+// SetDueDate sets the "due_date" field.
+func (_u *TaskUpdateOne) SetDueDate(v time.Time) *TaskUpdateOne {
+	_u.mutation.SetDueDate(v)
+	return _u
+}
+
+// SetNillableDueDate sets the "due_date" field if the given value is not nil.
+func (_u *TaskUpdateOne) SetNillableDueDate(v *time.Time) *TaskUpdateOne {
+	if v != nil {
+		_u.SetDueDate(*v)
+	}
+	return _u
+}
+
+// ClearDueDate clears the value of the "due_date" field.
+func (_u *TaskUpdateOne) ClearDueDate() *TaskUpdateOne {
+	_u.mutation.ClearDueDate()
+	return _u
+}
+
+// SetBlocker sets the "blocker" field.
+func (_u *TaskUpdateOne) SetBlocker(v *types.TaskBlocker) *TaskUpdateOne {
+	_u.mutation.SetBlocker(v)
+	return _u
+}
+
+// ClearBlocker clears the value of the "blocker" field.
+func (_u *TaskUpdateOne) ClearBlocker() *TaskUpdateOne {
+	_u.mutation.ClearBlocker()
+	return _u
+}
+
+// SetCheck sets the "check" field.
+func (_u *TaskUpdateOne) SetCheck(v *types.TaskCheck) *TaskUpdateOne {
+	_u.mutation.SetCheck(v)
+	return _u
+}
+
+// ClearCheck clears the value of the "check" field.
+func (_u *TaskUpdateOne) ClearCheck() *TaskUpdateOne {
+	_u.mutation.ClearCheck()
+	return _u
+}
+
+// SetHandoffs sets the "handoffs" field.
+func (_u *TaskUpdateOne) SetHandoffs(v []types.TaskHandoff) *TaskUpdateOne {
+	_u.mutation.SetHandoffs(v)
+	return _u
+}
+
+// AppendHandoffs appends value to the "handoffs" field.
+func (_u *TaskUpdateOne) AppendHandoffs(v []types.TaskHandoff) *TaskUpdateOne {
+	_u.mutation.AppendHandoffs(v)
+	return _u
+}
+
+// ClearHandoffs clears the value of the "handoffs" field.
+func (_u *TaskUpdateOne) ClearHandoffs() *TaskUpdateOne {
+	_u.mutation.ClearHandoffs()
+	return _u
+}
+
 // SetProject sets the "project" edge to the Project entity.
 func (_u *TaskUpdateOne) SetProject(v *Project) *TaskUpdateOne {
 	return _u.SetProjectID(v.ID)
@@ -953,6 +1496,12 @@ func (_u *TaskUpdateOne) sqlSave(ctx context.Context) (_node *Task, err error) {
 	if _u.mutation.DescriptionCleared() {
 		_spec.ClearField(task.FieldDescription, field.TypeString)
 	}
+	if value, ok := _u.mutation.Instructions(); ok {
+		_spec.SetField(task.FieldInstructions, field.TypeString, value)
+	}
+	if _u.mutation.InstructionsCleared() {
+		_spec.ClearField(task.FieldInstructions, field.TypeString)
+	}
 	if value, ok := _u.mutation.State(); ok {
 		_spec.SetField(task.FieldState, field.TypeEnum, value)
 	}
@@ -995,6 +1544,95 @@ func (_u *TaskUpdateOne) sqlSave(ctx context.Context) (_node *Task, err error) {
 	if _u.mutation.CompletedAtCleared() {
 		_spec.ClearField(task.FieldCompletedAt, field.TypeTime)
 	}
+	if value, ok := _u.mutation.Tags(); ok {
+		_spec.SetField(task.FieldTags, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedTags(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, task.FieldTags, value)
+		})
+	}
+	if _u.mutation.TagsCleared() {
+		_spec.ClearField(task.FieldTags, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.AcceptanceCriteria(); ok {
+		_spec.SetField(task.FieldAcceptanceCriteria, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedAcceptanceCriteria(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, task.FieldAcceptanceCriteria, value)
+		})
+	}
+	if _u.mutation.AcceptanceCriteriaCleared() {
+		_spec.ClearField(task.FieldAcceptanceCriteria, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.EffortLog(); ok {
+		_spec.SetField(task.FieldEffortLog, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedEffortLog(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, task.FieldEffortLog, value)
+		})
+	}
+	if _u.mutation.EffortLogCleared() {
+		_spec.ClearField(task.FieldEffortLog, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Review(); ok {
+		_spec.SetField(task.FieldReview, field.TypeJSON, value)
+	}
+	if _u.mutation.ReviewCleared() {
+		_spec.ClearField(task.FieldReview, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.KeepComplexity(); ok {
+		_spec.SetField(task.FieldKeepComplexity, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.CreatedBy(); ok {
+		_spec.SetField(task.FieldCreatedBy, field.TypeString, value)
+	}
+	if _u.mutation.CreatedByCleared() {
+		_spec.ClearField(task.FieldCreatedBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.UpdatedBy(); ok {
+		_spec.SetField(task.FieldUpdatedBy, field.TypeString, value)
+	}
+	if _u.mutation.UpdatedByCleared() {
+		_spec.ClearField(task.FieldUpdatedBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.Position(); ok {
+		_spec.SetField(task.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedPosition(); ok {
+		_spec.AddField(task.FieldPosition, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.DueDate(); ok {
+		_spec.SetField(task.FieldDueDate, field.TypeTime, value)
+	}
+	if _u.mutation.DueDateCleared() {
+		_spec.ClearField(task.FieldDueDate, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Blocker(); ok {
+		_spec.SetField(task.FieldBlocker, field.TypeJSON, value)
+	}
+	if _u.mutation.BlockerCleared() {
+		_spec.ClearField(task.FieldBlocker, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Check(); ok {
+		_spec.SetField(task.FieldCheck, field.TypeJSON, value)
+	}
+	if _u.mutation.CheckCleared() {
+		_spec.ClearField(task.FieldCheck, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Handoffs(); ok {
+		_spec.SetField(task.FieldHandoffs, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedHandoffs(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, task.FieldHandoffs, value)
+		})
+	}
+	if _u.mutation.HandoffsCleared() {
+		_spec.ClearField(task.FieldHandoffs, field.TypeJSON)
+	}
 	if _u.mutation.ProjectCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -25,6 +25,10 @@ type TaskDependency struct {
 	DependsOnTaskID uuid.UUID `json:"depends_on_task_id,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
+	// When the dependent task may start: once the dependency is completed or has started
+	DependencyType taskdependency.DependencyType `json:"dependency_type,omitempty"`
+	// Wait after the dependency is completed or has started, in minutes
+	Lag int64 `json:"lag,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the TaskDependencyQuery when eager-loading is set.
 	Edges        TaskDependencyEdges `json:"edges"`
@@ -69,6 +73,10 @@ func (*TaskDependency) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
+		case taskdependency.FieldLag:
+			values[i] = new(sql.NullInt64)
+		case taskdependency.FieldDependencyType:
+			values[i] = new(sql.NullString)
 		case taskdependency.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
 		case taskdependency.FieldID, taskdependency.FieldTaskID, taskdependency.FieldDependsOnTaskID:
@@ -112,6 +120,18 @@ func (_m *TaskDependency) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.CreatedAt = value.Time
 			}
+		case taskdependency.FieldDependencyType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field dependency_type", values[i])
+			} else if value.Valid {
+				_m.DependencyType = taskdependency.DependencyType(value.String)
+			}
+		case taskdependency.FieldLag:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field lag", values[i])
+			} else if value.Valid {
+				_m.Lag = value.Int64
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -166,6 +186,12 @@ func (_m *TaskDependency) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("dependency_type=")
+	builder.WriteString(fmt.Sprintf("%v", _m.DependencyType))
+	builder.WriteString(", ")
+	builder.WriteString("lag=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Lag))
 	builder.WriteByte(')')
 	return builder.String()
 }
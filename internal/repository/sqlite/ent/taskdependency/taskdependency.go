@@ -3,6 +3,7 @@
 package taskdependency
 
 import (
+	"fmt"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -21,6 +22,10 @@ const (
 	FieldDependsOnTaskID = "depends_on_task_id"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
+	// FieldDependencyType holds the string denoting the dependency_type field in the database.
+	FieldDependencyType = "dependency_type"
+	// FieldLag holds the string denoting the lag field in the database.
+	FieldLag = "lag"
 	// EdgeTask holds the string denoting the task edge name in mutations.
 	EdgeTask = "task"
 	// EdgeDependsOnTask holds the string denoting the depends_on_task edge name in mutations.
@@ -49,6 +54,8 @@ var Columns = []string{
 	FieldTaskID,
 	FieldDependsOnTaskID,
 	FieldCreatedAt,
+	FieldDependencyType,
+	FieldLag,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -64,10 +71,38 @@ func ValidColumn(column string) bool {
 var (
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
+	// DefaultLag holds the default value on creation for the "lag" field.
+	DefaultLag int64
 	// DefaultID holds the default value on creation for the "id" field.
 	DefaultID func() uuid.UUID
 )
 
+// DependencyType defines the type for the "dependency_type" enum field.
+type DependencyType string
+
+// DependencyTypeFinishToStart is the default value of the DependencyType enum.
+const DefaultDependencyType = DependencyTypeFinishToStart
+
+// DependencyType values.
+const (
+	DependencyTypeFinishToStart DependencyType = "finish-to-start"
+	DependencyTypeStartToStart  DependencyType = "start-to-start"
+)
+
+func (dt DependencyType) String() string {
+	return string(dt)
+}
+
+// DependencyTypeValidator is a validator for the "dependency_type" field enum values. It is called by the builders before save.
+func DependencyTypeValidator(dt DependencyType) error {
+	switch dt {
+	case DependencyTypeFinishToStart, DependencyTypeStartToStart:
+		return nil
+	default:
+		return fmt.Errorf("taskdependency: invalid enum value for dependency_type field: %q", dt)
+	}
+}
+
 // OrderOption defines the ordering options for the TaskDependency queries.
 type OrderOption func(*sql.Selector)
 
@@ -91,6 +126,16 @@ func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
 }
 
+// ByDependencyType orders the results by the dependency_type field.
+func ByDependencyType(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDependencyType, opts...).ToFunc()
+}
+
+// ByLag orders the results by the lag field.
+func ByLag(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLag, opts...).ToFunc()
+}
+
 // ByTaskField orders the results by task field.
 func ByTaskField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -71,6 +71,11 @@ func CreatedAt(v time.Time) predicate.TaskDependency {
 	return predicate.TaskDependency(sql.FieldEQ(FieldCreatedAt, v))
 }
 
+// Lag applies equality check predicate on the "lag" field. It's identical to LagEQ.
+func Lag(v int64) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldEQ(FieldLag, v))
+}
+
 // TaskIDEQ applies the EQ predicate on the "task_id" field.
 func TaskIDEQ(v uuid.UUID) predicate.TaskDependency {
 	return predicate.TaskDependency(sql.FieldEQ(FieldTaskID, v))
@@ -151,6 +156,66 @@ func CreatedAtLTE(v time.Time) predicate.TaskDependency {
 	return predicate.TaskDependency(sql.FieldLTE(FieldCreatedAt, v))
 }
 
+// DependencyTypeEQ applies the EQ predicate on the "dependency_type" field.
+func DependencyTypeEQ(v DependencyType) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldEQ(FieldDependencyType, v))
+}
+
+// DependencyTypeNEQ applies the NEQ predicate on the "dependency_type" field.
+func DependencyTypeNEQ(v DependencyType) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldNEQ(FieldDependencyType, v))
+}
+
+// DependencyTypeIn applies the In predicate on the "dependency_type" field.
+func DependencyTypeIn(vs ...DependencyType) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldIn(FieldDependencyType, vs...))
+}
+
+// DependencyTypeNotIn applies the NotIn predicate on the "dependency_type" field.
+func DependencyTypeNotIn(vs ...DependencyType) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldNotIn(FieldDependencyType, vs...))
+}
+
+// LagEQ applies the EQ predicate on the "lag" field.
+func LagEQ(v int64) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldEQ(FieldLag, v))
+}
+
+// LagNEQ applies the NEQ predicate on the "lag" field.
+func LagNEQ(v int64) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldNEQ(FieldLag, v))
+}
+
+// LagIn applies the In predicate on the "lag" field.
+func LagIn(vs ...int64) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldIn(FieldLag, vs...))
+}
+
+// LagNotIn applies the NotIn predicate on the "lag" field.
+func LagNotIn(vs ...int64) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldNotIn(FieldLag, vs...))
+}
+
+// LagGT applies the GT predicate on the "lag" field.
+func LagGT(v int64) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldGT(FieldLag, v))
+}
+
+// LagGTE applies the GTE predicate on the "lag" field.
+func LagGTE(v int64) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldGTE(FieldLag, v))
+}
+
+// LagLT applies the LT predicate on the "lag" field.
+func LagLT(v int64) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldLT(FieldLag, v))
+}
+
+// LagLTE applies the LTE predicate on the "lag" field.
+func LagLTE(v int64) predicate.TaskDependency {
+	return predicate.TaskDependency(sql.FieldLTE(FieldLag, v))
+}
+
 // HasTask applies the HasEdge predicate on the "task" edge.
 func HasTask() predicate.TaskDependency {
 	return predicate.TaskDependency(func(s *sql.Selector) {
@@ -48,6 +48,34 @@ func (_c *TaskDependencyCreate) SetNillableCreatedAt(v *time.Time) *TaskDependen
 	return _c
 }
 
+// SetDependencyType sets the "dependency_type" field.
+func (_c *TaskDependencyCreate) SetDependencyType(v taskdependency.DependencyType) *TaskDependencyCreate {
+	_c.mutation.SetDependencyType(v)
+	return _c
+}
+
+// SetNillableDependencyType sets the "dependency_type" field if the given value is not nil.
+func (_c *TaskDependencyCreate) SetNillableDependencyType(v *taskdependency.DependencyType) *TaskDependencyCreate {
+	if v != nil {
+		_c.SetDependencyType(*v)
+	}
+	return _c
+}
+
+// SetLag sets the "lag" field.
+func (_c *TaskDependencyCreate) SetLag(v int64) *TaskDependencyCreate {
+	_c.mutation.SetLag(v)
+	return _c
+}
+
+// SetNillableLag sets the "lag" field if the given value is not nil.
+func (_c *TaskDependencyCreate) SetNillableLag(v *int64) *TaskDependencyCreate {
+	if v != nil {
+		_c.SetLag(*v)
+	}
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *TaskDependencyCreate) SetID(v uuid.UUID) *TaskDependencyCreate {
 	_c.mutation.SetID(v)
@@ -111,6 +139,14 @@ func (_c *TaskDependencyCreate) defaults() {
 		v := taskdependency.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
 	}
+	if _, ok := _c.mutation.DependencyType(); !ok {
+		v := taskdependency.DefaultDependencyType
+		_c.mutation.SetDependencyType(v)
+	}
+	if _, ok := _c.mutation.Lag(); !ok {
+		v := taskdependency.DefaultLag
+		_c.mutation.SetLag(v)
+	}
 	if _, ok := _c.mutation.ID(); !ok {
 		v := taskdependency.DefaultID()
 		_c.mutation.SetID(v)
@@ -128,6 +164,17 @@ func (_c *TaskDependencyCreate) check() error {
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "TaskDependency.created_at"`)}
 	}
+	if _, ok := _c.mutation.DependencyType(); !ok {
+		return &ValidationError{Name: "dependency_type", err: errors.New(`ent: missing required field "TaskDependency.dependency_type"`)}
+	}
+	if v, ok := _c.mutation.DependencyType(); ok {
+		if err := taskdependency.DependencyTypeValidator(v); err != nil {
+			return &ValidationError{Name: "dependency_type", err: fmt.Errorf(`ent: validator failed for field "TaskDependency.dependency_type": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Lag(); !ok {
+		return &ValidationError{Name: "lag", err: errors.New(`ent: missing required field "TaskDependency.lag"`)}
+	}
 	if len(_c.mutation.TaskIDs()) == 0 {
 		return &ValidationError{Name: "task", err: errors.New(`ent: missing required edge "TaskDependency.task"`)}
 	}
@@ -173,6 +220,14 @@ func (_c *TaskDependencyCreate) createSpec() (*TaskDependency, *sqlgraph.CreateS
 		_spec.SetField(taskdependency.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
 	}
+	if value, ok := _c.mutation.DependencyType(); ok {
+		_spec.SetField(taskdependency.FieldDependencyType, field.TypeEnum, value)
+		_node.DependencyType = value
+	}
+	if value, ok := _c.mutation.Lag(); ok {
+		_spec.SetField(taskdependency.FieldLag, field.TypeInt64, value)
+		_node.Lag = value
+	}
 	if nodes := _c.mutation.TaskIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
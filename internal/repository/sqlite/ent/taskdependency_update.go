@@ -57,6 +57,41 @@ func (_u *TaskDependencyUpdate) SetNillableDependsOnTaskID(v *uuid.UUID) *TaskDe
 	return _u
 }
 
+// SetDependencyType sets the "dependency_type" field.
+func (_u *TaskDependencyUpdate) SetDependencyType(v taskdependency.DependencyType) *TaskDependencyUpdate {
+	_u.mutation.SetDependencyType(v)
+	return _u
+}
+
+// SetNillableDependencyType sets the "dependency_type" field if the given value is not nil.
+func (_u *TaskDependencyUpdate) SetNillableDependencyType(v *taskdependency.DependencyType) *TaskDependencyUpdate {
+	if v != nil {
+		_u.SetDependencyType(*v)
+	}
+	return _u
+}
+
+// SetLag sets the "lag" field.
+func (_u *TaskDependencyUpdate) SetLag(v int64) *TaskDependencyUpdate {
+	_u.mutation.ResetLag()
+	_u.mutation.SetLag(v)
+	return _u
+}
+
+// SetNillableLag sets the "lag" field if the given value is not nil.
+func (_u *TaskDependencyUpdate) SetNillableLag(v *int64) *TaskDependencyUpdate {
+	if v != nil {
+		_u.SetLag(*v)
+	}
+	return _u
+}
+
+// AddLag adds value to the "lag" field.
+func (_u *TaskDependencyUpdate) AddLag(v int64) *TaskDependencyUpdate {
+	_u.mutation.AddLag(v)
+	return _u
+}
+
 // SetTask sets the "task" edge to the Task entity.
 func (_u *TaskDependencyUpdate) SetTask(v *Task) *TaskDependencyUpdate {
 	return _u.SetTaskID(v.ID)
@@ -113,6 +148,11 @@ func (_u *TaskDependencyUpdate) ExecX(ctx context.Context) {
 
 // check runs all checks and user-defined validators on the builder.
 func (_u *TaskDependencyUpdate) check() error {
+	if v, ok := _u.mutation.DependencyType(); ok {
+		if err := taskdependency.DependencyTypeValidator(v); err != nil {
+			return &ValidationError{Name: "dependency_type", err: fmt.Errorf(`ent: validator failed for field "TaskDependency.dependency_type": %w`, err)}
+		}
+	}
 	if _u.mutation.TaskCleared() && len(_u.mutation.TaskIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "TaskDependency.task"`)
 	}
@@ -134,6 +174,15 @@ func (_u *TaskDependencyUpdate) sqlSave(ctx context.Context) (_node int, err err
 			}
 		}
 	}
+	if value, ok := _u.mutation.DependencyType(); ok {
+		_spec.SetField(taskdependency.FieldDependencyType, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.Lag(); ok {
+		_spec.SetField(taskdependency.FieldLag, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedLag(); ok {
+		_spec.AddField(taskdependency.FieldLag, field.TypeInt64, value)
+	}
 	if _u.mutation.TaskCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -240,6 +289,41 @@ func (_u *TaskDependencyUpdateOne) SetNillableDependsOnTaskID(v *uuid.UUID) *Tas
 	return _u
 }
 
+// SetDependencyType sets the "dependency_type" field.
+func (_u *TaskDependencyUpdateOne) SetDependencyType(v taskdependency.DependencyType) *TaskDependencyUpdateOne {
+	_u.mutation.SetDependencyType(v)
+	return _u
+}
+
+// SetNillableDependencyType sets the "dependency_type" field if the given value is not nil.
+func (_u *TaskDependencyUpdateOne) SetNillableDependencyType(v *taskdependency.DependencyType) *TaskDependencyUpdateOne {
+	if v != nil {
+		_u.SetDependencyType(*v)
+	}
+	return _u
+}
+
+// SetLag sets the "lag" field.
+func (_u *TaskDependencyUpdateOne) SetLag(v int64) *TaskDependencyUpdateOne {
+	_u.mutation.ResetLag()
+	_u.mutation.SetLag(v)
+	return _u
+}
+
+// SetNillableLag sets the "lag" field if the given value is not nil.
+func (_u *TaskDependencyUpdateOne) SetNillableLag(v *int64) *TaskDependencyUpdateOne {
+	if v != nil {
+		_u.SetLag(*v)
+	}
+	return _u
+}
+
+// AddLag adds value to the "lag" field.
+func (_u *TaskDependencyUpdateOne) AddLag(v int64) *TaskDependencyUpdateOne {
+	_u.mutation.AddLag(v)
+	return _u
+}
+
 // SetTask sets the "task" edge to the Task entity.
 func (_u *TaskDependencyUpdateOne) SetTask(v *Task) *TaskDependencyUpdateOne {
 	return _u.SetTaskID(v.ID)
@@ -309,6 +393,11 @@ func (_u *TaskDependencyUpdateOne) ExecX(ctx context.Context) {
 
 // check runs all checks and user-defined validators on the builder.
 func (_u *TaskDependencyUpdateOne) check() error {
+	if v, ok := _u.mutation.DependencyType(); ok {
+		if err := taskdependency.DependencyTypeValidator(v); err != nil {
+			return &ValidationError{Name: "dependency_type", err: fmt.Errorf(`ent: validator failed for field "TaskDependency.dependency_type": %w`, err)}
+		}
+	}
 	if _u.mutation.TaskCleared() && len(_u.mutation.TaskIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "TaskDependency.task"`)
 	}
@@ -347,6 +436,15 @@ func (_u *TaskDependencyUpdateOne) sqlSave(ctx context.Context) (_node *TaskDepe
 			}
 		}
 	}
+	if value, ok := _u.mutation.DependencyType(); ok {
+		_spec.SetField(taskdependency.FieldDependencyType, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.Lag(); ok {
+		_spec.SetField(taskdependency.FieldLag, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedLag(); ok {
+		_spec.AddField(taskdependency.FieldLag, field.TypeInt64, value)
+	}
 	if _u.mutation.TaskCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
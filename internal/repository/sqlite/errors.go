@@ -3,6 +3,8 @@ package sqlite
 import (
 	"errors"
 	"fmt"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
 )
 
 // RepositoryError represents a repository operation error
@@ -127,6 +129,26 @@ func NewValidationError(message string, cause error) *RepositoryError {
 	}
 }
 
+// mapConstraintError converts a constraint failure reported by SQLite into a
+// typed constraint violation error. Other errors are returned unchanged.
+func mapConstraintError(err error) error {
+	var re *RepositoryError
+	if err == nil || errors.As(err, &re) {
+		return err
+	}
+
+	switch {
+	case sqlgraph.IsForeignKeyConstraintError(err):
+		return NewConstraintViolationError("referenced project or task does not exist or is still referenced", err)
+	case sqlgraph.IsUniqueConstraintError(err):
+		return NewConstraintViolationError("entity already exists", err)
+	case sqlgraph.IsConstraintError(err):
+		return NewConstraintViolationError("integrity check failed", err)
+	default:
+		return err
+	}
+}
+
 // Error type check helpers
 
 // IsNotFoundError checks if an error is a not found error
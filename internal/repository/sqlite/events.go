@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// The change feed is kept in a plain table next to the ent schema. It is
+// append-only and only accessed through the functions in this file.
+const createChangeEventsTable = `CREATE TABLE IF NOT EXISTS change_events (
+	seq INTEGER PRIMARY KEY AUTOINCREMENT,
+	kind TEXT NOT NULL,
+	project_id TEXT NOT NULL,
+	task_id TEXT,
+	depends_on_task_id TEXT,
+	actor TEXT NOT NULL DEFAULT '',
+	data TEXT,
+	created_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS change_events_project_seq ON change_events (project_id, seq);`
+
+// ensureChangeEventTable creates the change feed table if it does not exist
+func (r *sqliteRepository) ensureChangeEventTable(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, createChangeEventsTable); err != nil {
+		return NewMigrationError("failed to create change event table", err)
+	}
+	return nil
+}
+
+// recordEvents appends events to the change feed after a committed mutation,
+// or once the transaction of InTransaction the mutation ran in commits.
+// Recording is best effort: a failure is logged but does not fail the mutation.
+func (r *sqliteRepository) recordEvents(ctx context.Context, events ...*types.ChangeEvent) {
+	if tx := r.txFrom(ctx); tx != nil {
+		tx.events = append(tx.events, events...)
+		return
+	}
+	for _, event := range events {
+		_, err := r.db.ExecContext(ctx,
+			`INSERT INTO change_events (kind, project_id, task_id, depends_on_task_id, actor, data, created_at)
+			 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			string(event.Kind),
+			event.ProjectID.String(),
+			nullableUUID(event.TaskID),
+			nullableUUID(event.DependsOnID),
+			event.Actor,
+			nullableData(event.Data),
+			event.CreatedAt.UTC().Format(time.RFC3339Nano),
+		)
+		if err != nil {
+			r.logger.Warn("Failed to record change event",
+				zap.String("kind", string(event.Kind)),
+				zap.Error(err))
+		}
+	}
+}
+
+// ListChangeEvents returns the recorded change events matching the filter
+func (r *sqliteRepository) ListChangeEvents(ctx context.Context, filter types.ChangeEventFilter) ([]*types.ChangeEvent, error) {
+	query := strings.Builder{}
+	query.WriteString(`SELECT seq, kind, project_id, task_id, depends_on_task_id, actor, data, created_at
+		FROM change_events WHERE seq > ?`)
+	args := []any{filter.AfterSeq}
+	if filter.ProjectID != nil {
+		query.WriteString(" AND project_id = ?")
+		args = append(args, filter.ProjectID.String())
+	}
+	if filter.Descending {
+		query.WriteString(" ORDER BY seq DESC")
+	} else {
+		query.WriteString(" ORDER BY seq")
+	}
+	if filter.Limit > 0 {
+		query.WriteString(" LIMIT ?")
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.conn(ctx).QueryContext(ctx, query.String(), args...)
+	if err != nil {
+		return nil, r.mapError("list change events", err)
+	}
+	defer rows.Close()
+
+	events := make([]*types.ChangeEvent, 0)
+	for rows.Next() {
+		var (
+			event               types.ChangeEvent
+			kind, projectID     string
+			taskID, dependsOnID sql.NullString
+			data                sql.NullString
+			createdAt           string
+		)
+		if err := rows.Scan(&event.Seq, &kind, &projectID, &taskID, &dependsOnID, &event.Actor, &data, &createdAt); err != nil {
+			return nil, r.mapError("scan change event", err)
+		}
+
+		event.Kind = types.ChangeEventKind(kind)
+		if event.ProjectID, err = uuid.Parse(projectID); err != nil {
+			return nil, fmt.Errorf("invalid project ID in change event %d: %w", event.Seq, err)
+		}
+		if event.TaskID, err = parseNullableUUID(taskID); err != nil {
+			return nil, fmt.Errorf("invalid task ID in change event %d: %w", event.Seq, err)
+		}
+		if event.DependsOnID, err = parseNullableUUID(dependsOnID); err != nil {
+			return nil, fmt.Errorf("invalid dependency ID in change event %d: %w", event.Seq, err)
+		}
+		if data.Valid {
+			event.Data = []byte(data.String)
+		}
+		if event.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return nil, fmt.Errorf("invalid timestamp in change event %d: %w", event.Seq, err)
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapError("list change events", err)
+	}
+
+	return events, nil
+}
+
+func nullableUUID(id *uuid.UUID) any {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
+
+func nullableData(data []byte) any {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
+
+func parseNullableUUID(value sql.NullString) (*uuid.UUID, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value.String)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestChangeEventLog(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1) // Keep the in-memory database on a single connection
+	defer db.Close()
+
+	repo := &sqliteRepository{db: db, logger: zap.NewNop()}
+	require.NoError(t, repo.ensureChangeEventTable(ctx))
+	require.NoError(t, repo.ensureChangeEventTable(ctx), "table creation must be idempotent")
+
+	projectA := &types.Project{ID: uuid.New(), Title: "A", CreatedBy: "alice"}
+	projectB := &types.Project{ID: uuid.New(), Title: "B"}
+	task := &types.Task{ID: uuid.New(), ProjectID: projectA.ID, Title: "Task", UpdatedBy: "bob"}
+	other := uuid.New()
+
+	repo.recordEvents(ctx,
+		types.NewProjectEvent(types.ChangeProjectCreated, projectA),
+		types.NewProjectEvent(types.ChangeProjectCreated, projectB),
+		types.NewTaskEvent(types.ChangeTaskCreated, task),
+		types.NewDependencyEvent(types.ChangeDependencyAdded, projectA.ID, task.ID, other),
+		types.NewTaskEvent(types.ChangeTaskDeleted, task),
+	)
+
+	all, err := repo.ListChangeEvents(ctx, types.ChangeEventFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 5)
+	for i, event := range all {
+		assert.Equal(t, int64(i+1), event.Seq)
+	}
+	assert.Equal(t, "alice", all[0].Actor)
+	assert.Contains(t, string(all[2].Data), `"title":"Task"`)
+	assert.Equal(t, "bob", all[2].Actor)
+	assert.Equal(t, other, *all[3].DependsOnID)
+	assert.Empty(t, all[4].Data)
+	assert.False(t, all[4].CreatedAt.IsZero())
+
+	forA, err := repo.ListChangeEvents(ctx, types.ChangeEventFilter{ProjectID: &projectA.ID, AfterSeq: 1, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, forA, 2)
+	assert.Equal(t, types.ChangeTaskCreated, forA[0].Kind)
+	assert.Equal(t, task.ID, *forA[0].TaskID)
+	assert.Equal(t, types.ChangeDependencyAdded, forA[1].Kind)
+
+	latest, err := repo.ListChangeEvents(ctx, types.ChangeEventFilter{Limit: 2, Descending: true})
+	require.NoError(t, err)
+	require.Len(t, latest, 2)
+	assert.Equal(t, int64(5), latest[0].Seq)
+	assert.Equal(t, int64(4), latest[1].Seq)
+
+	none, err := repo.ListChangeEvents(ctx, types.ChangeEventFilter{AfterSeq: 5})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
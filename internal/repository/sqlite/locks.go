@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// Project locks are kept in a plain table next to the ent schema, like the
+// change feed. A project has at most one lock.
+const createProjectLocksTable = `CREATE TABLE IF NOT EXISTS project_locks (
+	project_id TEXT PRIMARY KEY,
+	owner TEXT NOT NULL,
+	reason TEXT NOT NULL DEFAULT '',
+	acquired_at TEXT NOT NULL,
+	expires_at TEXT NOT NULL
+);`
+
+// ensureProjectLockTable creates the project lock table if it does not exist
+func (r *sqliteRepository) ensureProjectLockTable(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, createProjectLocksTable); err != nil {
+		return NewMigrationError("failed to create project lock table", err)
+	}
+	return nil
+}
+
+// GetProjectLock returns the lock of a project, or nil if it is not locked
+func (r *sqliteRepository) GetProjectLock(ctx context.Context, projectID uuid.UUID) (*types.ProjectLock, error) {
+	var acquiredAt, expiresAt string
+	lock := &types.ProjectLock{ProjectID: projectID}
+	err := r.conn(ctx).QueryRowContext(ctx,
+		`SELECT owner, reason, acquired_at, expires_at FROM project_locks WHERE project_id = ?`,
+		projectID.String(),
+	).Scan(&lock.Owner, &lock.Reason, &acquiredAt, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.mapError("get project lock", err)
+	}
+
+	if lock.AcquiredAt, err = time.Parse(time.RFC3339Nano, acquiredAt); err != nil {
+		return nil, fmt.Errorf("invalid acquisition time in lock of project %s: %w", projectID, err)
+	}
+	if lock.ExpiresAt, err = time.Parse(time.RFC3339Nano, expiresAt); err != nil {
+		return nil, fmt.Errorf("invalid expiry time in lock of project %s: %w", projectID, err)
+	}
+	return lock, nil
+}
+
+// SaveProjectLock creates or replaces the lock of a project
+func (r *sqliteRepository) SaveProjectLock(ctx context.Context, lock *types.ProjectLock) error {
+	_, err := r.conn(ctx).ExecContext(ctx,
+		`INSERT INTO project_locks (project_id, owner, reason, acquired_at, expires_at)
+		 VALUES (?, ?, ?, ?, ?)
+		 ON CONFLICT (project_id) DO UPDATE SET
+		   owner = excluded.owner,
+		   reason = excluded.reason,
+		   acquired_at = excluded.acquired_at,
+		   expires_at = excluded.expires_at`,
+		lock.ProjectID.String(),
+		lock.Owner,
+		lock.Reason,
+		lock.AcquiredAt.UTC().Format(time.RFC3339Nano),
+		lock.ExpiresAt.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return r.mapError("save project lock", err)
+	}
+	return nil
+}
+
+// DeleteProjectLock removes the lock of a project, if any
+func (r *sqliteRepository) DeleteProjectLock(ctx context.Context, projectID uuid.UUID) error {
+	if _, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM project_locks WHERE project_id = ?`, projectID.String()); err != nil {
+		return r.mapError("delete project lock", err)
+	}
+	return nil
+}
@@ -39,6 +39,9 @@ func entPriorityToDomainPriority(p task.Priority) types.TaskPriority {
 }
 
 // Project entity mapping functions
+//
+// Timestamps are stored and returned in UTC, whatever zone the caller used.
+// Due dates are calendar dates in local time and are kept as they are.
 
 // entProjectToProject converts ent Project entity to domain Project model
 func entProjectToProject(ep *ent.Project) *types.Project {
@@ -46,9 +49,11 @@ func entProjectToProject(ep *ent.Project) *types.Project {
 		ID:             ep.ID,
 		Title:          ep.Title,
 		Description:    ep.Description,
+		Instructions:   ep.Instructions,
+		Document:       ep.Document,
 		State:          entStateToProjectState(string(ep.State)),
-		CreatedAt:      ep.CreatedAt,
-		UpdatedAt:      ep.UpdatedAt,
+		CreatedAt:      ep.CreatedAt.UTC(),
+		UpdatedAt:      ep.UpdatedAt.UTC(),
 		TotalTasks:     ep.TotalTasks,
 		CompletedTasks: ep.CompletedTasks,
 		Progress:       ep.Progress,
@@ -60,16 +65,18 @@ func projectToEntProjectCreate(p *types.Project, client *ent.Client) *ent.Projec
 	create := client.Project.Create().
 		SetTitle(p.Title).
 		SetDescription(p.Description).
+		SetInstructions(p.Instructions).
+		SetDocument(p.Document).
 		SetState(projectStateToEntState(p.State))
 
 	if p.ID != uuid.Nil {
 		create.SetID(p.ID)
 	}
 	if !p.CreatedAt.IsZero() {
-		create.SetCreatedAt(p.CreatedAt)
+		create.SetCreatedAt(p.CreatedAt.UTC())
 	}
 	if !p.UpdatedAt.IsZero() {
-		create.SetUpdatedAt(p.UpdatedAt)
+		create.SetUpdatedAt(p.UpdatedAt.UTC())
 	}
 	if p.TotalTasks > 0 {
 		create.SetTotalTasks(p.TotalTasks)
@@ -89,16 +96,22 @@ func projectToEntProjectCreate(p *types.Project, client *ent.Client) *ent.Projec
 // entTaskToTask converts ent Task entity to domain Task model
 func entTaskToTask(et *ent.Task) *types.Task {
 	domainTask := &types.Task{
-		ID:          et.ID,
-		ProjectID:   et.ProjectID,
-		Title:       et.Title,
-		Description: et.Description,
-		State:       types.TaskState(et.State),
-		Priority:    entPriorityToDomainPriority(et.Priority),
-		Complexity:  et.Complexity,
-		Depth:       et.Depth,
-		CreatedAt:   et.CreatedAt,
-		UpdatedAt:   et.UpdatedAt,
+		ID:           et.ID,
+		ProjectID:    et.ProjectID,
+		Title:        et.Title,
+		Description:  et.Description,
+		Instructions: et.Instructions,
+		State:        types.TaskState(et.State),
+		Priority:     entPriorityToDomainPriority(et.Priority),
+		Complexity:   et.Complexity,
+		Depth:        et.Depth,
+		Position:     et.Position,
+		CreatedAt:    et.CreatedAt.UTC(),
+		UpdatedAt:    et.UpdatedAt.UTC(),
+		CreatedBy:    et.CreatedBy,
+		UpdatedBy:    et.UpdatedBy,
+
+		KeepComplexity: et.KeepComplexity,
 	}
 
 	// Handle optional/nullable fields
@@ -112,7 +125,32 @@ func entTaskToTask(et *ent.Task) *types.Task {
 		domainTask.AssignedAgent = et.AssignedAgent
 	}
 	if et.CompletedAt != nil {
-		domainTask.CompletedAt = et.CompletedAt
+		completedAt := et.CompletedAt.UTC()
+		domainTask.CompletedAt = &completedAt
+	}
+	if et.DueDate != nil {
+		domainTask.DueDate = et.DueDate
+	}
+	if len(et.Tags) > 0 {
+		domainTask.Tags = et.Tags
+	}
+	if len(et.AcceptanceCriteria) > 0 {
+		domainTask.AcceptanceCriteria = et.AcceptanceCriteria
+	}
+	if len(et.EffortLog) > 0 {
+		domainTask.EffortLog = et.EffortLog
+	}
+	if et.Review != nil {
+		domainTask.Review = et.Review
+	}
+	if et.Blocker != nil {
+		domainTask.Blocker = et.Blocker
+	}
+	if et.Check != nil {
+		domainTask.Check = et.Check
+	}
+	if len(et.Handoffs) > 0 {
+		domainTask.Handoffs = et.Handoffs
 	}
 
 	// Initialize slices to avoid nil pointer issues
@@ -128,10 +166,15 @@ func taskToEntTaskCreate(t *types.Task, client *ent.Client) *ent.TaskCreate {
 		SetProjectID(t.ProjectID).
 		SetTitle(t.Title).
 		SetDescription(t.Description).
+		SetInstructions(t.Instructions).
 		SetState(task.State(t.State)).
 		SetPriority(domainPriorityToEntPriority(t.Priority)).
 		SetComplexity(t.Complexity).
-		SetDepth(t.Depth)
+		SetDepth(t.Depth).
+		SetPosition(t.Position).
+		SetKeepComplexity(t.KeepComplexity).
+		SetCreatedBy(t.CreatedBy).
+		SetUpdatedBy(t.UpdatedBy)
 
 	if t.ID != uuid.Nil {
 		create.SetID(t.ID)
@@ -146,13 +189,37 @@ func taskToEntTaskCreate(t *types.Task, client *ent.Client) *ent.TaskCreate {
 		create.SetAssignedAgent(*t.AssignedAgent)
 	}
 	if !t.CreatedAt.IsZero() {
-		create.SetCreatedAt(t.CreatedAt)
+		create.SetCreatedAt(t.CreatedAt.UTC())
 	}
 	if !t.UpdatedAt.IsZero() {
-		create.SetUpdatedAt(t.UpdatedAt)
+		create.SetUpdatedAt(t.UpdatedAt.UTC())
 	}
 	if t.CompletedAt != nil {
-		create.SetCompletedAt(*t.CompletedAt)
+		create.SetCompletedAt(t.CompletedAt.UTC())
+	}
+	if t.DueDate != nil {
+		create.SetDueDate(*t.DueDate)
+	}
+	if len(t.Tags) > 0 {
+		create.SetTags(t.Tags)
+	}
+	if len(t.AcceptanceCriteria) > 0 {
+		create.SetAcceptanceCriteria(t.AcceptanceCriteria)
+	}
+	if len(t.EffortLog) > 0 {
+		create.SetEffortLog(t.EffortLog)
+	}
+	if t.Review != nil {
+		create.SetReview(t.Review)
+	}
+	if t.Blocker != nil {
+		create.SetBlocker(t.Blocker)
+	}
+	if t.Check != nil {
+		create.SetCheck(t.Check)
+	}
+	if len(t.Handoffs) > 0 {
+		create.SetHandoffs(t.Handoffs)
 	}
 
 	return create
@@ -163,10 +230,14 @@ func taskToEntTaskUpdate(t *types.Task, update *ent.TaskUpdateOne) *ent.TaskUpda
 	update = update.
 		SetTitle(t.Title).
 		SetDescription(t.Description).
+		SetInstructions(t.Instructions).
 		SetState(task.State(t.State)).
 		SetPriority(domainPriorityToEntPriority(t.Priority)).
 		SetComplexity(t.Complexity).
-		SetUpdatedAt(t.UpdatedAt)
+		SetPosition(t.Position).
+		SetKeepComplexity(t.KeepComplexity).
+		SetUpdatedBy(t.UpdatedBy).
+		SetUpdatedAt(t.UpdatedAt.UTC())
 
 	if t.Estimate != nil {
 		update.SetEstimate(*t.Estimate)
@@ -181,11 +252,59 @@ func taskToEntTaskUpdate(t *types.Task, update *ent.TaskUpdateOne) *ent.TaskUpda
 	}
 
 	if t.CompletedAt != nil {
-		update.SetCompletedAt(*t.CompletedAt)
+		update.SetCompletedAt(t.CompletedAt.UTC())
 	} else {
 		update.ClearCompletedAt()
 	}
 
+	if t.DueDate != nil {
+		update.SetDueDate(*t.DueDate)
+	} else {
+		update.ClearDueDate()
+	}
+
+	if len(t.Tags) > 0 {
+		update.SetTags(t.Tags)
+	} else {
+		update.ClearTags()
+	}
+
+	if len(t.AcceptanceCriteria) > 0 {
+		update.SetAcceptanceCriteria(t.AcceptanceCriteria)
+	} else {
+		update.ClearAcceptanceCriteria()
+	}
+
+	if len(t.EffortLog) > 0 {
+		update.SetEffortLog(t.EffortLog)
+	} else {
+		update.ClearEffortLog()
+	}
+
+	if t.Review != nil {
+		update.SetReview(t.Review)
+	} else {
+		update.ClearReview()
+	}
+
+	if t.Blocker != nil {
+		update.SetBlocker(t.Blocker)
+	} else {
+		update.ClearBlocker()
+	}
+
+	if t.Check != nil {
+		update.SetCheck(t.Check)
+	} else {
+		update.ClearCheck()
+	}
+
+	if len(t.Handoffs) > 0 {
+		update.SetHandoffs(t.Handoffs)
+	} else {
+		update.ClearHandoffs()
+	}
+
 	return update
 }
 
@@ -200,6 +319,23 @@ func entTaskDependenciesToTaskIDs(dependencies []*ent.TaskDependency) []uuid.UUI
 	return ids
 }
 
+// entTaskDependencyLinks extracts the links of ent TaskDependency entities
+// that are not plain finish-to-start edges without lag
+func entTaskDependencyLinks(dependencies []*ent.TaskDependency) []types.DependencyLink {
+	var links []types.DependencyLink
+	for _, dep := range dependencies {
+		link := types.DependencyLink{
+			DependsOnID: dep.DependsOnTaskID,
+			Type:        types.DependencyType(dep.DependencyType),
+			Lag:         dep.Lag,
+		}
+		if !link.IsDefault() {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
 // entTaskDependentsToTaskIDs extracts dependent task IDs from ent TaskDependency entities
 func entTaskDependentsToTaskIDs(dependents []*ent.TaskDependency) []uuid.UUID {
 	ids := make([]uuid.UUID, len(dependents))
@@ -300,4 +436,3 @@ func entStateToProjectState(state string) types.ProjectState {
 		return types.ProjectStateActive // Default to active for unknown states
 	}
 }
-
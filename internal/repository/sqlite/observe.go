@@ -0,0 +1,86 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"github.com/denkhaus/knot/v2/internal/repository"
+)
+
+// observedDriver reports every statement run through the ent client, also
+// inside transactions, to an observer
+type observedDriver struct {
+	dialect.Driver
+	observer repository.StatementObserver
+}
+
+func (d *observedDriver) Exec(ctx context.Context, query string, args, v any) error {
+	start := time.Now()
+	err := d.Driver.Exec(ctx, query, args, v)
+	d.observer.ObserveStatement(normalizeStatement(query), time.Since(start), err)
+	return err
+}
+
+func (d *observedDriver) Query(ctx context.Context, query string, args, v any) error {
+	start := time.Now()
+	err := d.Driver.Query(ctx, query, args, v)
+	d.observer.ObserveStatement(normalizeStatement(query), time.Since(start), err)
+	return err
+}
+
+func (d *observedDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &observedTx{Tx: tx, observer: d.observer}, nil
+}
+
+// BeginTx starts a transaction with options, as used by ent's Client.BeginTx
+func (d *observedDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("driver.BeginTx is not supported")
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &observedTx{Tx: tx, observer: d.observer}, nil
+}
+
+// observedTx reports the statements of a transaction
+type observedTx struct {
+	dialect.Tx
+	observer repository.StatementObserver
+}
+
+func (t *observedTx) Exec(ctx context.Context, query string, args, v any) error {
+	start := time.Now()
+	err := t.Tx.Exec(ctx, query, args, v)
+	t.observer.ObserveStatement(normalizeStatement(query), time.Since(start), err)
+	return err
+}
+
+func (t *observedTx) Query(ctx context.Context, query string, args, v any) error {
+	start := time.Now()
+	err := t.Tx.Query(ctx, query, args, v)
+	t.observer.ObserveStatement(normalizeStatement(query), time.Since(start), err)
+	return err
+}
+
+// placeholderList matches lists of two or more placeholders, such as the
+// values of an IN clause
+var placeholderList = regexp.MustCompile(`\?(\s*,\s*\?)+`)
+
+// normalizeStatement collapses placeholder lists so statements differing only
+// in the number of arguments are counted together
+func normalizeStatement(query string) string {
+	return placeholderList.ReplaceAllString(query, "?, ...")
+}
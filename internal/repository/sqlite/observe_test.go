@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// recordingObserver keeps the statements it is told about
+type recordingObserver struct {
+	mu         sync.Mutex
+	statements []string
+}
+
+func (o *recordingObserver) ObserveStatement(statement string, duration time.Duration, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.statements = append(o.statements, statement)
+}
+
+func (o *recordingObserver) reset() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	statements := o.statements
+	o.statements = nil
+	return statements
+}
+
+func TestStatementObserver(t *testing.T) {
+	observer := &recordingObserver{}
+	repo, err := NewRepository(filepath.Join(t.TempDir(), "observed.db"),
+		WithAutoMigrate(true),
+		WithLogger(zap.NewNop()),
+		WithStatementObserver(observer),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { repo.(interface{ Close() error }).Close() })
+	ctx := context.Background()
+	observer.reset()
+
+	project := &types.Project{ID: uuid.New(), Title: "Observed", State: types.ProjectStateActive}
+	require.NoError(t, repo.CreateProject(ctx, project))
+	statements := observer.reset()
+	require.NotEmpty(t, statements)
+	assert.Contains(t, statements[len(statements)-1], "INSERT INTO `projects`")
+
+	_, err = repo.GetProject(ctx, project.ID)
+	require.NoError(t, err)
+	statements = observer.reset()
+	require.Len(t, statements, 1)
+	assert.Contains(t, statements[0], "FROM `projects`")
+}
+
+func TestNormalizeStatement(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM `tasks` WHERE `id` IN (?, ...)",
+		normalizeStatement("SELECT * FROM `tasks` WHERE `id` IN (?, ?, ?)"))
+	assert.Equal(t, normalizeStatement("DELETE FROM `tasks` WHERE `id` IN (?,?)"),
+		normalizeStatement("DELETE FROM `tasks` WHERE `id` IN (?, ?, ?, ?)"))
+	assert.Equal(t, "SELECT * FROM `tasks` WHERE `id` = ?", normalizeStatement("SELECT * FROM `tasks` WHERE `id` = ?"))
+}
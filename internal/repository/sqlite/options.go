@@ -3,6 +3,7 @@ package sqlite
 import (
 	"time"
 
+	"github.com/denkhaus/knot/v2/internal/repository"
 	"go.uber.org/zap"
 )
 
@@ -19,6 +20,9 @@ type Config struct {
 	AutoMigrate      bool
 	MigrationTimeout time.Duration
 	Logger           *zap.Logger
+
+	// Observer is told about every statement run through the ent client, if set
+	Observer repository.StatementObserver
 }
 
 // DefaultConfig returns a default configuration optimized for SQLite
@@ -68,6 +72,14 @@ func WithAutoMigrate(enable bool) Option {
 	}
 }
 
+// WithStatementObserver reports every statement run through the ent client
+// to observer, nil to report none
+func WithStatementObserver(observer repository.StatementObserver) Option {
+	return func(r *sqliteRepository) {
+		r.config.Observer = observer
+	}
+}
+
 // WithConnectionPool configures the connection pool
 func WithConnectionPool(maxOpen, maxIdle int) Option {
 	return func(r *sqliteRepository) {
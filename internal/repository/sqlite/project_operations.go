@@ -17,16 +17,17 @@ import (
 // Project CRUD Operations
 
 func (r *sqliteRepository) CreateProject(ctx context.Context, project *types.Project) error {
-	_, err := projectToEntProjectCreate(project, r.client).Save(ctx)
+	_, err := projectToEntProjectCreate(project, r.entClient(ctx)).Save(ctx)
 	if err != nil {
 		return r.mapError("create project", err)
 	}
+	r.recordEvents(ctx, types.NewProjectEvent(types.ChangeProjectCreated, project))
 	return nil
 }
 
 // GetProject retrieves a project by ID using ent
 func (r *sqliteRepository) GetProject(ctx context.Context, id uuid.UUID) (*types.Project, error) {
-	entProject, err := r.client.Project.Get(ctx, id)
+	entProject, err := r.entClient(ctx).Project.Get(ctx, id)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			return nil, NewNotFoundError("project", id.String())
@@ -38,11 +39,13 @@ func (r *sqliteRepository) GetProject(ctx context.Context, id uuid.UUID) (*types
 
 // UpdateProject updates an existing project using ent
 func (r *sqliteRepository) UpdateProject(ctx context.Context, project *types.Project) error {
-	err := r.client.Project.UpdateOneID(project.ID).
+	err := r.entClient(ctx).Project.UpdateOneID(project.ID).
 		SetTitle(project.Title).
 		SetDescription(project.Description).
+		SetInstructions(project.Instructions).
+		SetDocument(project.Document).
 		SetState(projectStateToEntState(project.State)).
-		SetUpdatedAt(project.UpdatedAt).
+		SetUpdatedAt(project.UpdatedAt.UTC()).
 		SetTotalTasks(project.TotalTasks).
 		SetCompletedTasks(project.CompletedTasks).
 		SetProgress(project.Progress).
@@ -54,12 +57,13 @@ func (r *sqliteRepository) UpdateProject(ctx context.Context, project *types.Pro
 		}
 		return r.mapError("update project", err)
 	}
+	r.recordEvents(ctx, types.NewProjectEvent(types.ChangeProjectUpdated, project))
 	return nil
 }
 
 // DeleteProject deletes a project and all its tasks using ent transaction
 func (r *sqliteRepository) DeleteProject(ctx context.Context, id uuid.UUID) error {
-	return r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+	err := r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
 		// First, clear any project context that references this project
 		_, err := tx.ProjectContext.Delete().
 			Where(projectcontext.SelectedProjectIDEQ(id)).
@@ -108,11 +112,28 @@ func (r *sqliteRepository) DeleteProject(ctx context.Context, id uuid.UUID) erro
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// A lock of the deleted project would never be released otherwise
+	if err := r.DeleteProjectLock(ctx, id); err != nil {
+		r.logger.Warn("Failed to remove lock of deleted project", zap.Error(err))
+	}
+	if err := r.clearProjectSessions(ctx, id); err != nil {
+		r.logger.Warn("Failed to clear sessions of deleted project", zap.Error(err))
+	}
+	if err := r.deleteProjectTransitions(ctx, id); err != nil {
+		r.logger.Warn("Failed to remove scheduled transitions of deleted project", zap.Error(err))
+	}
+
+	r.recordEvents(ctx, types.NewProjectEvent(types.ChangeProjectDeleted, &types.Project{ID: id}))
+	return nil
 }
 
 // ListProjects retrieves all projects using ent
 func (r *sqliteRepository) ListProjects(ctx context.Context) ([]*types.Project, error) {
-	entProjects, err := r.client.Project.Query().
+	entProjects, err := r.entClient(ctx).Project.Query().
 		Order(ent.Asc(project.FieldCreatedAt)).
 		All(ctx)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
@@ -19,6 +20,7 @@ import (
 // sqliteRepository implements the Repository interface using ent ORM
 type sqliteRepository struct {
 	client *ent.Client
+	db     *sql.DB // Underlying connection, used for the change feed
 	config *Config
 	logger *zap.Logger
 }
@@ -76,8 +78,12 @@ func (r *sqliteRepository) initialize(dbPath string) error {
 	}
 
 	// Create ent client with SQLite driver
-	drv := entsql.OpenDB(dialect.SQLite, db)
+	var drv dialect.Driver = entsql.OpenDB(dialect.SQLite, db)
+	if r.config.Observer != nil {
+		drv = &observedDriver{Driver: drv, observer: r.config.Observer}
+	}
 	r.client = ent.NewClient(ent.Driver(drv))
+	r.db = db
 
 	// Run auto-migration if enabled
 	if r.config.AutoMigrate {
@@ -95,6 +101,22 @@ func (r *sqliteRepository) initialize(dbPath string) error {
 		r.logger.Info("Database schema migration completed successfully")
 	}
 
+	if err := r.ensureChangeEventTable(context.Background()); err != nil {
+		return err
+	}
+	if err := r.ensureProjectLockTable(context.Background()); err != nil {
+		return err
+	}
+	if err := r.ensureUserTable(context.Background()); err != nil {
+		return err
+	}
+	if err := r.ensureSessionProjectTable(context.Background()); err != nil {
+		return err
+	}
+	if err := r.ensureScheduledTransitionTable(context.Background()); err != nil {
+		return err
+	}
+
 	// Ensure database file has secure permissions
 	if err := r.secureDatabaseFile(dbPath); err != nil {
 		r.config.Logger.Warn("Failed to secure database file permissions", zap.Error(err))
@@ -122,6 +144,10 @@ func (r *sqliteRepository) mapError(operation string, err error) error {
 		return NewNotFoundError("resource", "unknown")
 	}
 
+	if mapped := mapConstraintError(err); mapped != err {
+		return mapped
+	}
+
 	if ent.IsConstraintError(err) {
 		return NewConstraintViolationError("constraint violation", err)
 	}
@@ -215,8 +241,13 @@ func (r *sqliteRepository) getSQLiteConnectionString(dbPath string) (string, err
 		return "", fmt.Errorf("failed to create database directory: %w", err)
 	}
 
-	// SQLite connection string - simple path format
-	return dbPath, nil
+	// Foreign keys are a per-connection setting in SQLite, so enable them in the
+	// connection string to cover every connection of the pool
+	separator := "?"
+	if strings.Contains(dbPath, "?") {
+		separator = "&"
+	}
+	return dbPath + separator + "_pragma=foreign_keys(1)", nil
 }
 
 // secureDatabaseFile ensures the database file has secure permissions (owner read/write only)
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent"
 	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -447,6 +448,92 @@ func TestErrorHandling(t *testing.T) {
 	})
 }
 
+// TestIntegrityConstraints verifies that SQLite itself enforces foreign keys,
+// independent of the checks in the repository operations
+func TestIntegrityConstraints(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	r := repo.(*sqliteRepository)
+
+	newProject := func(t *testing.T) *types.Project {
+		project := &types.Project{ID: uuid.New(), Title: "Integrity", State: types.ProjectStateActive}
+		require.NoError(t, repo.CreateProject(ctx, project))
+		return project
+	}
+	newTask := func(t *testing.T, projectID uuid.UUID, parentID *uuid.UUID) *types.Task {
+		task := &types.Task{
+			ID:         uuid.New(),
+			ProjectID:  projectID,
+			ParentID:   parentID,
+			Title:      "Task",
+			State:      types.TaskStatePending,
+			Priority:   types.TaskPriorityMedium,
+			Complexity: 1,
+		}
+		require.NoError(t, repo.CreateTask(ctx, task))
+		return task
+	}
+
+	t.Run("task in nonexistent project is rejected by the database", func(t *testing.T) {
+		task := &types.Task{
+			ID:         uuid.New(),
+			ProjectID:  uuid.New(),
+			Title:      "Orphan",
+			State:      types.TaskStatePending,
+			Priority:   types.TaskPriorityMedium,
+			Complexity: 1,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}
+
+		// Insert directly, bypassing the existence check of CreateTask
+		err := r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+			_, err := taskToEntTaskCreate(task, tx.Client()).Save(ctx)
+			return err
+		})
+		require.Error(t, err)
+		assert.True(t, IsConstraintViolationError(err), "expected typed constraint violation, got %v", err)
+	})
+
+	t.Run("deleting a project cascades to tasks, dependencies and selection", func(t *testing.T) {
+		project := newProject(t)
+		first := newTask(t, project.ID, nil)
+		second := newTask(t, project.ID, nil)
+		_, err := repo.AddTaskDependency(ctx, second.ID, first.ID)
+		require.NoError(t, err)
+		require.NoError(t, repo.SetSelectedProject(ctx, project.ID, "tester"))
+
+		require.NoError(t, r.client.Project.DeleteOneID(project.ID).Exec(ctx))
+
+		tasks, err := r.client.Task.Query().Count(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, tasks)
+		dependencies, err := r.client.TaskDependency.Query().Count(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, dependencies)
+
+		selected, err := repo.GetSelectedProject(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, selected)
+	})
+
+	t.Run("deleting a parent task cascades to its subtree", func(t *testing.T) {
+		project := newProject(t)
+		parent := newTask(t, project.ID, nil)
+		child := newTask(t, project.ID, &parent.ID)
+		grandchild := newTask(t, project.ID, &child.ID)
+
+		require.NoError(t, r.client.Task.DeleteOneID(parent.ID).Exec(ctx))
+
+		for _, id := range []uuid.UUID{child.ID, grandchild.ID} {
+			_, err := repo.GetTask(ctx, id)
+			assert.True(t, IsNotFoundError(err), "expected subtree task %s to be deleted", id)
+		}
+	})
+}
+
 // TestConcurrency tests repository operations under concurrent access
 func TestConcurrency(t *testing.T) {
 	repo, cleanup := setupTestRepository(t)
@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/project"
+	"github.com/google/uuid"
+)
+
+// Session selections are kept in a plain table next to the ent schema, like
+// project locks. A session selects at most one project.
+const createSessionProjectsTable = `CREATE TABLE IF NOT EXISTS session_projects (
+	session TEXT PRIMARY KEY,
+	project_id TEXT NOT NULL,
+	updated_by TEXT NOT NULL,
+	updated_at TEXT NOT NULL
+);`
+
+// ensureSessionProjectTable creates the session selection table if it does not exist
+func (r *sqliteRepository) ensureSessionProjectTable(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, createSessionProjectsTable); err != nil {
+		return NewMigrationError("failed to create session selection table", err)
+	}
+	return nil
+}
+
+// GetSessionProject returns the project selected in a session, or nil if there is none
+func (r *sqliteRepository) GetSessionProject(ctx context.Context, session string) (*uuid.UUID, error) {
+	var value string
+	err := r.conn(ctx).QueryRowContext(ctx,
+		`SELECT project_id FROM session_projects WHERE session = ?`, session,
+	).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.mapError("get session project", err)
+	}
+
+	projectID, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID in session %s: %w", session, err)
+	}
+	return &projectID, nil
+}
+
+// SetSessionProject selects a project for one session
+func (r *sqliteRepository) SetSessionProject(ctx context.Context, session string, projectID uuid.UUID, actor string) error {
+	exists, err := r.entClient(ctx).Project.Query().
+		Where(project.IDEQ(projectID)).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to verify project exists: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("project with ID %s does not exist", projectID)
+	}
+
+	_, err = r.conn(ctx).ExecContext(ctx,
+		`INSERT INTO session_projects (session, project_id, updated_by, updated_at)
+		 VALUES (?, ?, ?, ?)
+		 ON CONFLICT (session) DO UPDATE SET
+		   project_id = excluded.project_id,
+		   updated_by = excluded.updated_by,
+		   updated_at = excluded.updated_at`,
+		session,
+		projectID.String(),
+		actor,
+		time.Now().UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return r.mapError("set session project", err)
+	}
+	return nil
+}
+
+// ClearSessionProject removes the selection of a session, if any
+func (r *sqliteRepository) ClearSessionProject(ctx context.Context, session string) error {
+	if _, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM session_projects WHERE session = ?`, session); err != nil {
+		return r.mapError("clear session project", err)
+	}
+	return nil
+}
+
+// clearProjectSessions removes the selections of a deleted project from all sessions
+func (r *sqliteRepository) clearProjectSessions(ctx context.Context, projectID uuid.UUID) error {
+	if _, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM session_projects WHERE project_id = ?`, projectID.String()); err != nil {
+		return r.mapError("clear project sessions", err)
+	}
+	return nil
+}
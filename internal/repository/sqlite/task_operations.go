@@ -17,7 +17,7 @@ import (
 
 // CreateTask creates a new task using ent with dependency handling
 func (r *sqliteRepository) CreateTask(ctx context.Context, task *types.Task) error {
-	return r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+	err := r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
 		// Validate project exists
 		exists, err := tx.Project.Query().Where(project.ID(task.ProjectID)).Exist(ctx)
 		if err != nil {
@@ -47,7 +47,7 @@ func (r *sqliteRepository) CreateTask(ctx context.Context, task *types.Task) err
 
 		// Set timestamps if not already set
 		if task.CreatedAt.IsZero() {
-			task.CreatedAt = time.Now()
+			task.CreatedAt = time.Now().UTC()
 		}
 		if task.UpdatedAt.IsZero() {
 			task.UpdatedAt = task.CreatedAt
@@ -103,12 +103,18 @@ func (r *sqliteRepository) CreateTask(ctx context.Context, task *types.Task) err
 		// Update project metrics
 		return r.updateProjectMetricsInTx(ctx, tx, task.ProjectID)
 	})
+	if err != nil {
+		return err
+	}
+
+	r.recordEvents(ctx, types.NewTaskEvent(types.ChangeTaskCreated, task))
+	return nil
 }
 
 // GetTask retrieves a task by ID with dependencies using ent (optimized to reduce database round trips)
 func (r *sqliteRepository) GetTask(ctx context.Context, id uuid.UUID) (*types.Task, error) {
 	// Get the main task
-	entTask, err := r.client.Task.Query().
+	entTask, err := r.entClient(ctx).Task.Query().
 		Where(taskpred.ID(id)).
 		Only(ctx)
 	if err != nil {
@@ -122,16 +128,17 @@ func (r *sqliteRepository) GetTask(ctx context.Context, id uuid.UUID) (*types.Ta
 
 	// Load both dependencies and dependents in a single batch query if possible,
 	// otherwise load them sequentially but more efficiently
-	dependencies, err := r.client.TaskDependency.Query().
+	dependencies, err := r.entClient(ctx).TaskDependency.Query().
 		Where(taskdependency.TaskID(id)).
 		All(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load dependencies: %w", err)
 	}
 	domainTask.Dependencies = entTaskDependenciesToTaskIDs(dependencies)
+	domainTask.DependencyLinks = entTaskDependencyLinks(dependencies)
 
 	// Load dependents (tasks that depend on this task)
-	dependents, err := r.client.TaskDependency.Query().
+	dependents, err := r.entClient(ctx).TaskDependency.Query().
 		Where(taskdependency.DependsOnTaskID(id)).
 		Select(taskdependency.FieldTaskID).
 		All(ctx)
@@ -203,6 +210,7 @@ func (r *sqliteRepository) GetTasksWithDependencies(ctx context.Context, taskIDs
 		for _, task := range tasks {
 			if deps, exists := dependenciesByTask[task.ID]; exists {
 				task.Dependencies = entTaskDependenciesToTaskIDs(deps)
+				task.DependencyLinks = entTaskDependencyLinks(deps)
 			}
 			if deps, exists := dependentsByTask[task.ID]; exists {
 				task.Dependents = entTaskDependentsToTaskIDs(deps)
@@ -221,7 +229,7 @@ func (r *sqliteRepository) GetTasksWithDependencies(ctx context.Context, taskIDs
 
 // UpdateTask updates an existing task using ent
 func (r *sqliteRepository) UpdateTask(ctx context.Context, task *types.Task) error {
-	return r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+	err := r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
 		// Get existing task to preserve certain fields
 		existingTask, err := tx.Task.Get(ctx, task.ID)
 		if err != nil {
@@ -236,11 +244,11 @@ func (r *sqliteRepository) UpdateTask(ctx context.Context, task *types.Task) err
 		task.ProjectID = existingTask.ProjectID
 		task.ParentID = existingTask.ParentID
 		task.Depth = existingTask.Depth
-		task.UpdatedAt = time.Now()
+		task.UpdatedAt = time.Now().UTC()
 
 		// Handle completion timestamp
 		if task.State == types.TaskStateCompleted && string(existingTask.State) != string(types.TaskStateCompleted) {
-			now := time.Now()
+			now := task.UpdatedAt
 			task.CompletedAt = &now
 		} else if task.State != types.TaskStateCompleted {
 			task.CompletedAt = nil
@@ -261,11 +269,18 @@ func (r *sqliteRepository) UpdateTask(ctx context.Context, task *types.Task) err
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	r.recordEvents(ctx, types.NewTaskEvent(types.ChangeTaskUpdated, task))
+	return nil
 }
 
 // DeleteTask deletes a task if it has no children using ent
 func (r *sqliteRepository) DeleteTask(ctx context.Context, id uuid.UUID) error {
-	return r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+	var projectID uuid.UUID
+	err := r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
 		// Get task info
 		task, err := tx.Task.Get(ctx, id)
 		if err != nil {
@@ -302,15 +317,23 @@ func (r *sqliteRepository) DeleteTask(ctx context.Context, id uuid.UUID) error {
 		if err != nil {
 			return fmt.Errorf("failed to delete task: %w", err)
 		}
+		projectID = task.ProjectID
 
 		// Update project metrics
 		return r.updateProjectMetricsInTx(ctx, tx, task.ProjectID)
 	})
+	if err != nil {
+		return err
+	}
+
+	r.recordEvents(ctx, types.NewTaskEvent(types.ChangeTaskDeleted, &types.Task{ID: id, ProjectID: projectID}))
+	return nil
 }
 
 // DeleteTaskSubtree deletes a task and all its descendants using ent with recursive CTE
 func (r *sqliteRepository) DeleteTaskSubtree(ctx context.Context, taskID uuid.UUID) error {
-	return r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+	var deleted []*types.ChangeEvent
+	err := r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
 		// Get the task to ensure it exists and get project ID
 		task, err := tx.Task.Get(ctx, taskID)
 		if err != nil {
@@ -348,9 +371,105 @@ func (r *sqliteRepository) DeleteTaskSubtree(ctx context.Context, taskID uuid.UU
 			return fmt.Errorf("failed to delete tasks: %w", err)
 		}
 
+		deleted = make([]*types.ChangeEvent, 0, len(allTaskIDs))
+		for _, id := range allTaskIDs {
+			deleted = append(deleted, types.NewTaskEvent(types.ChangeTaskDeleted, &types.Task{ID: id, ProjectID: task.ProjectID}))
+		}
+
 		// Update project metrics
 		return r.updateProjectMetricsInTx(ctx, tx, task.ProjectID)
 	})
+	if err != nil {
+		return err
+	}
+
+	r.recordEvents(ctx, deleted...)
+	return nil
+}
+
+// MoveTask moves a task below a new parent, or to the root level if parentID is
+// nil, and recalculates the depth of the task and all its descendants
+func (r *sqliteRepository) MoveTask(ctx context.Context, taskID uuid.UUID, parentID *uuid.UUID) error {
+	var moved []*types.ChangeEvent
+	err := r.withTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+		task, err := tx.Task.Get(ctx, taskID)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return NewNotFoundError("task", taskID.String())
+			}
+			return fmt.Errorf("failed to get task: %w", err)
+		}
+
+		depth := 0
+		if parentID != nil {
+			parentTask, err := tx.Task.Get(ctx, *parentID)
+			if err != nil {
+				if ent.IsNotFound(err) {
+					return NewNotFoundError("parent task", parentID.String())
+				}
+				return fmt.Errorf("failed to get parent task: %w", err)
+			}
+			if parentTask.ProjectID != task.ProjectID {
+				return NewConstraintViolationError("parent task must be in the same project", nil)
+			}
+			depth = parentTask.Depth + 1
+		}
+
+		descendantIDs, err := r.getDescendantTaskIDsInTx(ctx, tx, taskID)
+		if err != nil {
+			return fmt.Errorf("failed to get descendant task IDs: %w", err)
+		}
+		if parentID != nil {
+			for _, id := range append(descendantIDs, taskID) {
+				if id == *parentID {
+					return NewConstraintViolationError("a task cannot be moved below itself or its descendants", nil)
+				}
+			}
+		}
+
+		now := time.Now().UTC()
+		update := tx.Task.UpdateOneID(taskID).
+			SetDepth(depth).
+			SetUpdatedAt(now)
+		if parentID != nil {
+			update.SetParentID(*parentID)
+		} else {
+			update.ClearParentID()
+		}
+		if err := update.Exec(ctx); err != nil {
+			return fmt.Errorf("failed to move task: %w", err)
+		}
+
+		// Descendants keep their position relative to the moved task
+		if shift := depth - task.Depth; shift != 0 && len(descendantIDs) > 0 {
+			_, err = tx.Task.Update().
+				Where(taskpred.IDIn(descendantIDs...)).
+				AddDepth(shift).
+				SetUpdatedAt(now).
+				Save(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to update descendant depths: %w", err)
+			}
+		}
+
+		updated, err := tx.Task.Query().
+			Where(taskpred.IDIn(append(descendantIDs, taskID)...)).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load moved tasks: %w", err)
+		}
+		moved = make([]*types.ChangeEvent, 0, len(updated))
+		for _, t := range updated {
+			moved = append(moved, types.NewTaskEvent(types.ChangeTaskUpdated, entTaskToTask(t)))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.recordEvents(ctx, moved...)
+	return nil
 }
 
 // getDescendantTaskIDsInTx gets all descendant task IDs using recursive approach
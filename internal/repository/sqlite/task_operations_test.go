@@ -533,4 +533,239 @@ func TestGetTasksWithDependenciesEdgeCases(t *testing.T) {
 			strings.Contains(err.Error(), "context"),
 		)
 	})
-}
\ No newline at end of file
+}
+// TestGetDescendants tests walking a subtree with the recursive query
+func TestGetDescendants(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	project := &types.Project{ID: uuid.New(), Title: "Descendants Test Project", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	require.NoError(t, repo.CreateProject(ctx, project))
+
+	newTask := func(title string, parent *types.Task, position int) *types.Task {
+		task := &types.Task{
+			ID:         uuid.New(),
+			ProjectID:  project.ID,
+			Title:      title,
+			State:      types.TaskStatePending,
+			Priority:   types.TaskPriorityMedium,
+			Complexity: 2,
+			Position:   position,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}
+		if parent != nil {
+			task.ParentID = &parent.ID
+			task.Depth = parent.Depth + 1
+		}
+		require.NoError(t, repo.CreateTask(ctx, task))
+		return task
+	}
+
+	root := newTask("root", nil, 1)
+	second := newTask("second", root, 2)
+	first := newTask("first", root, 1)
+	grandchild := newTask("grandchild", second, 1)
+	newTask("great-grandchild", grandchild, 1)
+	newTask("other root", nil, 2)
+
+	titles := func(tasks []*types.Task) []string {
+		result := make([]string, len(tasks))
+		for i, task := range tasks {
+			result[i] = task.Title
+		}
+		return result
+	}
+
+	all, err := repo.GetDescendants(ctx, root.ID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "grandchild", "great-grandchild"}, titles(all))
+
+	limited, err := repo.GetDescendants(ctx, root.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "grandchild"}, titles(limited))
+
+	leaf, err := repo.GetDescendants(ctx, first.ID, 0)
+	require.NoError(t, err)
+	assert.Empty(t, leaf)
+}
+
+func TestTimestampsStoredInUTC(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	zone := time.FixedZone("UTC+5", 5*60*60)
+	created := time.Date(2026, 10, 16, 9, 30, 0, 0, zone)
+
+	project := &types.Project{ID: uuid.New(), Title: "UTC Test Project", CreatedAt: created, UpdatedAt: created}
+	require.NoError(t, repo.CreateProject(ctx, project))
+	completedAt := created.Add(time.Hour)
+	task := &types.Task{
+		ID:          uuid.New(),
+		ProjectID:   project.ID,
+		Title:       "zoned",
+		State:       types.TaskStateCompleted,
+		Priority:    types.TaskPriorityMedium,
+		Complexity:  2,
+		CreatedAt:   created,
+		UpdatedAt:   created,
+		CompletedAt: &completedAt,
+	}
+	require.NoError(t, repo.CreateTask(ctx, task))
+
+	stored, err := repo.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, stored.CreatedAt.Location())
+	assert.True(t, created.Equal(stored.CreatedAt))
+	require.NotNil(t, stored.CompletedAt)
+	assert.Equal(t, time.UTC, stored.CompletedAt.Location())
+	assert.True(t, completedAt.Equal(*stored.CompletedAt))
+
+	storedProject, err := repo.GetProject(ctx, project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, storedProject.CreatedAt.Location())
+	assert.True(t, created.Equal(storedProject.CreatedAt))
+}
+
+func TestListTasksPage(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	project := &types.Project{ID: uuid.New(), Title: "Pagination Test Project", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	require.NoError(t, repo.CreateProject(ctx, project))
+
+	created := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 7; i++ {
+		priority := types.TaskPriorityMedium
+		if i%2 == 0 {
+			priority = types.TaskPriorityHigh
+		}
+		// Tasks created in the same instant are ordered by ID
+		at := created.Add(time.Duration(i/3) * time.Second)
+		require.NoError(t, repo.CreateTask(ctx, &types.Task{
+			ID:         uuid.New(),
+			ProjectID:  project.ID,
+			Title:      fmt.Sprintf("Task %d", i),
+			State:      types.TaskStatePending,
+			Priority:   priority,
+			Complexity: 2,
+			CreatedAt:  at,
+			UpdatedAt:  at,
+		}))
+	}
+
+	filter := types.TaskFilter{ProjectID: &project.ID}
+	walk := func(filter types.TaskFilter, size int, beforeNext func()) []*types.Task {
+		var walked []*types.Task
+		page := types.PageRequest{Limit: size}
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 10, "pagination must terminate")
+			result, err := repo.ListTasksPage(ctx, filter, page)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(result.Tasks), size)
+			walked = append(walked, result.Tasks...)
+			if result.NextCursor == "" {
+				return walked
+			}
+			page.Cursor = result.NextCursor
+			if beforeNext != nil {
+				beforeNext()
+			}
+		}
+	}
+
+	all, err := repo.ListTasks(ctx, filter)
+	require.NoError(t, err)
+	walked := walk(filter, 2, nil)
+	require.Len(t, walked, len(all))
+	for i := 1; i < len(walked); i++ {
+		assert.Negative(t, types.CompareCreation(walked[i-1], walked[i]))
+	}
+
+	t.Run("filters run in the query", func(t *testing.T) {
+		high := types.TaskPriorityHigh
+		highTasks := walk(types.TaskFilter{ProjectID: &project.ID, Priority: &high}, 3, nil)
+		assert.Len(t, highTasks, 4)
+
+		found := walk(types.TaskFilter{ProjectID: &project.ID, Search: "task 5"}, 3, nil)
+		require.Len(t, found, 1)
+		assert.Equal(t, "Task 5", found[0].Title)
+	})
+
+	t.Run("deleting the cursor task neither skips nor repeats tasks", func(t *testing.T) {
+		// The third task in creation order ends the first page of three
+		cursorTask := walked[2]
+		deleted := false
+		paged := walk(filter, 3, func() {
+			if !deleted {
+				require.NoError(t, repo.DeleteTask(ctx, cursorTask.ID))
+				deleted = true
+			}
+		})
+		assert.Equal(t, walked, paged)
+	})
+
+	_, err = repo.ListTasksPage(ctx, filter, types.PageRequest{Cursor: "bogus"})
+	assert.ErrorContains(t, err, "invalid cursor")
+}
+
+func TestSetDependencyLink(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	project := &types.Project{ID: uuid.New(), Title: "Dependency Link Test Project", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	require.NoError(t, repo.CreateProject(ctx, project))
+
+	newTask := func(title string) *types.Task {
+		task := &types.Task{
+			ID:         uuid.New(),
+			ProjectID:  project.ID,
+			Title:      title,
+			State:      types.TaskStatePending,
+			Priority:   types.TaskPriorityMedium,
+			Complexity: 2,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}
+		require.NoError(t, repo.CreateTask(ctx, task))
+		return task
+	}
+
+	design := newTask("design")
+	build := newTask("build")
+	test := newTask("test")
+
+	_, err := repo.AddTaskDependency(ctx, build.ID, design.ID)
+	require.NoError(t, err)
+	_, err = repo.AddTaskDependency(ctx, build.ID, test.ID)
+	require.NoError(t, err)
+
+	link := types.DependencyLink{DependsOnID: design.ID, Type: types.DependencyStartToStart, Lag: 120}
+	updated, err := repo.SetDependencyLink(ctx, build.ID, link)
+	require.NoError(t, err)
+	assert.Equal(t, []types.DependencyLink{link}, updated.DependencyLinks, "only non-default links are listed")
+
+	loaded, err := repo.GetTask(ctx, build.ID)
+	require.NoError(t, err)
+	assert.Equal(t, link, loaded.DependencyLink(design.ID))
+	assert.Equal(t, types.DependencyLink{DependsOnID: test.ID, Type: types.DependencyFinishToStart}, loaded.DependencyLink(test.ID))
+
+	batch, err := repo.GetTasksWithDependencies(ctx, []uuid.UUID{build.ID})
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	assert.Equal(t, []types.DependencyLink{link}, batch[0].DependencyLinks)
+
+	reset, err := repo.SetDependencyLink(ctx, build.ID, types.DependencyLink{DependsOnID: design.ID, Type: types.DependencyFinishToStart})
+	require.NoError(t, err)
+	assert.Empty(t, reset.DependencyLinks)
+
+	_, err = repo.SetDependencyLink(ctx, design.ID, link)
+	assert.Error(t, err, "missing dependencies cannot be changed")
+}
@@ -15,7 +15,60 @@ import (
 
 // ListTasks retrieves tasks with filtering using ent
 func (r *sqliteRepository) ListTasks(ctx context.Context, filter types.TaskFilter) ([]*types.Task, error) {
-	query := r.client.Task.Query()
+	// Execute query
+	entTasks, err := r.filterTasks(ctx, filter).
+		Order(ent.Asc(task.FieldCreatedAt), ent.Asc(task.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, r.mapError("list tasks", err)
+	}
+
+	// Convert to domain models
+	tasks := make([]*types.Task, len(entTasks))
+	for i, entTask := range entTasks {
+		tasks[i] = entTaskToTask(entTask)
+	}
+
+	return tasks, nil
+}
+
+// ListTasksPage retrieves one page of filtered tasks with a keyset query on
+// (created_at, id), so the database skips earlier pages by index instead of
+// loading and slicing them
+func (r *sqliteRepository) ListTasksPage(ctx context.Context, filter types.TaskFilter, page types.PageRequest) (*types.TaskPage, error) {
+	query := r.filterTasks(ctx, filter)
+	if page.Cursor != "" {
+		cursor, err := types.DecodePageCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(task.Or(
+			task.CreatedAtGT(cursor.CreatedAt),
+			task.And(task.CreatedAt(cursor.CreatedAt), task.IDGT(cursor.ID)),
+		))
+	}
+
+	// Fetch one extra task to tell whether another page follows
+	size := page.Size()
+	entTasks, err := query.
+		Order(ent.Asc(task.FieldCreatedAt), ent.Asc(task.FieldID)).
+		Limit(size + 1).
+		All(ctx)
+	if err != nil {
+		return nil, r.mapError("list tasks page", err)
+	}
+
+	tasks := make([]*types.Task, len(entTasks))
+	for i, entTask := range entTasks {
+		tasks[i] = entTaskToTask(entTask)
+	}
+
+	return types.NewTaskPage(tasks, size), nil
+}
+
+// filterTasks builds the task query for the ent predicates of filter
+func (r *sqliteRepository) filterTasks(ctx context.Context, filter types.TaskFilter) *ent.TaskQuery {
+	query := r.entClient(ctx).Task.Query()
 
 	// Apply filters using ent predicates
 	if filter.ProjectID != nil {
@@ -31,6 +84,9 @@ func (r *sqliteRepository) ListTasks(ctx context.Context, filter types.TaskFilte
 	if filter.State != nil {
 		query = query.Where(task.StateEQ(task.State(string(*filter.State))))
 	}
+	if filter.Priority != nil {
+		query = query.Where(task.PriorityEQ(domainPriorityToEntPriority(*filter.Priority)))
+	}
 	if filter.MinDepth != nil {
 		query = query.Where(task.DepthGTE(*filter.MinDepth))
 	}
@@ -43,29 +99,33 @@ func (r *sqliteRepository) ListTasks(ctx context.Context, filter types.TaskFilte
 	if filter.MaxComplexity != nil {
 		query = query.Where(task.ComplexityLTE(*filter.MaxComplexity))
 	}
-
-	// Execute query
-	entTasks, err := query.
-		Order(ent.Asc(task.FieldCreatedAt)).
-		All(ctx)
-	if err != nil {
-		return nil, r.mapError("list tasks", err)
+	if filter.AssignedAgent != nil {
+		query = query.Where(task.AssignedAgent(*filter.AssignedAgent))
 	}
-
-	// Convert to domain models
-	tasks := make([]*types.Task, len(entTasks))
-	for i, entTask := range entTasks {
-		tasks[i] = entTaskToTask(entTask)
+	if filter.Unassigned {
+		query = query.Where(task.AssignedAgentIsNil())
+	}
+	if filter.Search != "" {
+		query = query.Where(task.Or(
+			task.TitleContainsFold(filter.Search),
+			task.DescriptionContainsFold(filter.Search),
+		))
+	}
+	if filter.CreatedBy != "" {
+		query = query.Where(task.CreatedBy(filter.CreatedBy))
+	}
+	if filter.CreatedSince != nil {
+		query = query.Where(task.CreatedAtGTE(*filter.CreatedSince))
 	}
 
-	return tasks, nil
+	return query
 }
 
 // GetTasksByProject retrieves all tasks for a specific project using ent
 func (r *sqliteRepository) GetTasksByProject(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error) {
-	entTasks, err := r.client.Task.Query().
+	entTasks, err := r.entClient(ctx).Task.Query().
 		Where(task.ProjectID(projectID)).
-		Order(ent.Asc(task.FieldCreatedAt)).
+		Order(ent.Asc(task.FieldCreatedAt), ent.Asc(task.FieldID)).
 		All(ctx)
 	if err != nil {
 		return nil, r.mapError("get tasks by project", err)
@@ -82,9 +142,9 @@ func (r *sqliteRepository) GetTasksByProject(ctx context.Context, projectID uuid
 
 // GetTasksByParent retrieves all direct children of a parent task using ent
 func (r *sqliteRepository) GetTasksByParent(ctx context.Context, parentID uuid.UUID) ([]*types.Task, error) {
-	entTasks, err := r.client.Task.Query().
+	entTasks, err := r.entClient(ctx).Task.Query().
 		Where(task.ParentID(parentID)).
-		Order(ent.Asc(task.FieldCreatedAt)).
+		Order(ent.Asc(task.FieldPosition), ent.Asc(task.FieldCreatedAt)).
 		All(ctx)
 	if err != nil {
 		return nil, r.mapError("get tasks by parent", err)
@@ -98,14 +158,68 @@ func (r *sqliteRepository) GetTasksByParent(ctx context.Context, parentID uuid.U
 	return tasks, nil
 }
 
+// selectDescendants walks the subtree of a task in a single recursive query.
+// The second and third arguments limit the levels below the task, 0 means no
+// limit.
+const selectDescendants = `WITH RECURSIVE subtree(id, level) AS (
+		SELECT id, 1 FROM tasks WHERE parent_id = ?
+		UNION ALL
+		SELECT tasks.id, subtree.level + 1 FROM tasks JOIN subtree ON tasks.parent_id = subtree.id
+		WHERE ? = 0 OR subtree.level < ?
+	)
+	SELECT id FROM subtree`
+
+// GetDescendants retrieves the descendants of a task with a recursive CTE
+// instead of a query per level
+func (r *sqliteRepository) GetDescendants(ctx context.Context, taskID uuid.UUID, maxDepth int) ([]*types.Task, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, selectDescendants, taskID.String(), maxDepth, maxDepth)
+	if err != nil {
+		return nil, r.mapError("get descendants", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, r.mapError("scan descendant", err)
+		}
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid descendant ID %q: %w", id, err)
+		}
+		ids = append(ids, parsed)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapError("get descendants", err)
+	}
+	if len(ids) == 0 {
+		return []*types.Task{}, nil
+	}
+
+	entTasks, err := r.entClient(ctx).Task.Query().
+		Where(task.IDIn(ids...)).
+		Order(ent.Asc(task.FieldDepth), ent.Asc(task.FieldPosition), ent.Asc(task.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, r.mapError("get descendants", err)
+	}
+
+	tasks := make([]*types.Task, len(entTasks))
+	for i, entTask := range entTasks {
+		tasks[i] = entTaskToTask(entTask)
+	}
+	return tasks, nil
+}
+
 // GetRootTasks retrieves all root tasks (tasks without parents) for a project using ent
 func (r *sqliteRepository) GetRootTasks(ctx context.Context, projectID uuid.UUID) ([]*types.Task, error) {
-	entTasks, err := r.client.Task.Query().
+	entTasks, err := r.entClient(ctx).Task.Query().
 		Where(
 			task.ProjectID(projectID),
 			task.ParentIDIsNil(),
 		).
-		Order(ent.Asc(task.FieldCreatedAt)).
+		Order(ent.Asc(task.FieldPosition), ent.Asc(task.FieldCreatedAt)).
 		All(ctx)
 	if err != nil {
 		return nil, r.mapError("get root tasks", err)
@@ -122,7 +236,7 @@ func (r *sqliteRepository) GetRootTasks(ctx context.Context, projectID uuid.UUID
 // GetParentTask retrieves the parent task of a given task using ent
 func (r *sqliteRepository) GetParentTask(ctx context.Context, taskID uuid.UUID) (*types.Task, error) {
 	// Get the task first to get parent ID
-	task, err := r.client.Task.Get(ctx, taskID)
+	task, err := r.entClient(ctx).Task.Get(ctx, taskID)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			return nil, NewNotFoundError("task", taskID.String())
@@ -141,7 +255,7 @@ func (r *sqliteRepository) GetParentTask(ctx context.Context, taskID uuid.UUID)
 // GetProjectProgress calculates project progress using ent aggregations
 func (r *sqliteRepository) GetProjectProgress(ctx context.Context, projectID uuid.UUID) (*types.ProjectProgress, error) {
 	// Verify project exists
-	exists, err := r.client.Project.Query().Where(project.ID(projectID)).Exist(ctx)
+	exists, err := r.entClient(ctx).Project.Query().Where(project.ID(projectID)).Exist(ctx)
 	if err != nil {
 		return nil, r.mapError("check project existence", err)
 	}
@@ -162,7 +276,7 @@ func (r *sqliteRepository) GetProjectProgress(ctx context.Context, projectID uui
 
 	totalTasks := 0
 	for _, state := range states {
-		count, err := r.client.Task.Query().
+		count, err := r.entClient(ctx).Task.Query().
 			Where(
 				task.ProjectID(projectID),
 				task.StateEQ(task.State(string(state))),
@@ -179,7 +293,7 @@ func (r *sqliteRepository) GetProjectProgress(ctx context.Context, projectID uui
 	tasksByDepth := make(map[int]int)
 	if totalTasks > 0 {
 		// Get max depth first
-		maxDepthResult, err := r.client.Task.Query().
+		maxDepthResult, err := r.entClient(ctx).Task.Query().
 			Where(task.ProjectID(projectID)).
 			Aggregate(ent.Max(task.FieldDepth)).
 			Int(ctx)
@@ -189,7 +303,7 @@ func (r *sqliteRepository) GetProjectProgress(ctx context.Context, projectID uui
 
 		// Count tasks for each depth level
 		for depth := 0; depth <= maxDepthResult; depth++ {
-			count, err := r.client.Task.Query().
+			count, err := r.entClient(ctx).Task.Query().
 				Where(
 					task.ProjectID(projectID),
 					task.DepthEQ(depth),
@@ -228,7 +342,7 @@ func (r *sqliteRepository) GetTaskCountByDepth(ctx context.Context, projectID uu
 	tasksByDepth := make(map[int]int)
 
 	for depth := 0; depth <= maxDepth; depth++ {
-		count, err := r.client.Task.Query().
+		count, err := r.entClient(ctx).Task.Query().
 			Where(
 				task.ProjectID(projectID),
 				task.DepthEQ(depth),
@@ -242,3 +356,50 @@ func (r *sqliteRepository) GetTaskCountByDepth(ctx context.Context, projectID uu
 
 	return tasksByDepth, nil
 }
+
+// GetSubtreeCounts aggregates the descendants of every task of a project. Only
+// the columns the counts need are loaded.
+func (r *sqliteRepository) GetSubtreeCounts(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]*types.SubtreeCounts, error) {
+	rows, err := r.entClient(ctx).Task.Query().
+		Where(task.ProjectID(projectID)).
+		Select(task.FieldID, task.FieldParentID, task.FieldState, task.FieldComplexity, task.FieldEstimate).
+		All(ctx)
+	if err != nil {
+		return nil, r.mapError("count subtrees", err)
+	}
+
+	tasks := make([]*types.Task, len(rows))
+	for i, row := range rows {
+		tasks[i] = &types.Task{
+			ID:         row.ID,
+			ParentID:   row.ParentID,
+			State:      types.TaskState(row.State),
+			Complexity: row.Complexity,
+			Estimate:   row.Estimate,
+		}
+	}
+	return types.CountSubtrees(tasks), nil
+}
+
+// GetChildCounts counts the children of the tasks of a project in one grouped
+// query on the parent index
+func (r *sqliteRepository) GetChildCounts(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]int, error) {
+	var rows []struct {
+		ParentID uuid.UUID `json:"parent_id"`
+		Count    int       `json:"count"`
+	}
+	err := r.entClient(ctx).Task.Query().
+		Where(task.ProjectID(projectID), task.ParentIDNotNil()).
+		GroupBy(task.FieldParentID).
+		Aggregate(ent.Count()).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, r.mapError("count children", err)
+	}
+
+	counts := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		counts[row.ParentID] = row.Count
+	}
+	return counts, nil
+}
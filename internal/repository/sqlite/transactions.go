@@ -2,8 +2,11 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent"
 	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/task"
 	"github.com/denkhaus/knot/v2/internal/types"
@@ -13,9 +16,114 @@ import (
 // TxFunc represents a function that executes within an ent transaction
 type TxFunc func(ctx context.Context, tx *ent.Tx) error
 
+// queryer runs raw SQL on the database or on a transaction
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// repositoryTx is a transaction started by InTransaction. The operations of
+// the repository run with its context use its ent client and connection.
+type repositoryTx struct {
+	repo   *sqliteRepository
+	sqlTx  *sql.Tx
+	client *ent.Client
+	// events are recorded in the change feed once the transaction commits
+	events []*types.ChangeEvent
+}
+
+type repositoryTxContextKey struct{}
+
+// txFrom returns the transaction of this repository that ctx runs in, or nil
+func (r *sqliteRepository) txFrom(ctx context.Context) *repositoryTx {
+	tx, _ := ctx.Value(repositoryTxContextKey{}).(*repositoryTx)
+	if tx == nil || tx.repo != r {
+		return nil
+	}
+	return tx
+}
+
+// entClient returns the ent client for ctx, which is the client of the
+// transaction started by InTransaction if ctx runs in one
+func (r *sqliteRepository) entClient(ctx context.Context) *ent.Client {
+	if tx := r.txFrom(ctx); tx != nil {
+		return tx.client
+	}
+	return r.client
+}
+
+// conn returns where raw SQL runs for ctx, which is the transaction started by
+// InTransaction if ctx runs in one
+func (r *sqliteRepository) conn(ctx context.Context) queryer {
+	if tx := r.txFrom(ctx); tx != nil {
+		return tx.sqlTx
+	}
+	return r.db
+}
+
+// joinedTxDriver runs ent operations on the transaction of InTransaction.
+// The transactions the repository operations open join it, so they commit or
+// roll back with it.
+type joinedTxDriver struct {
+	entsql.Conn
+}
+
+func (d joinedTxDriver) Tx(context.Context) (dialect.Tx, error) {
+	return dialect.NopTx(d), nil
+}
+
+func (joinedTxDriver) Close() error {
+	return nil
+}
+
+func (joinedTxDriver) Dialect() string {
+	return dialect.SQLite
+}
+
+// InTransaction implements types.Transactor: the operations run with the
+// context passed to fn share one SQLite transaction, which commits if fn
+// returns nil and rolls back otherwise. The change events of the operations
+// are recorded after the commit. A transaction must not be used concurrently,
+// and calls with a context that already runs in one join it.
+func (r *sqliteRepository) InTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if r.txFrom(ctx) != nil {
+		return fn(ctx)
+	}
+
+	sqlTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return NewTransactionError("failed to begin transaction", err)
+	}
+	var drv dialect.Driver = joinedTxDriver{Conn: entsql.Conn{ExecQuerier: sqlTx}}
+	if r.config.Observer != nil {
+		drv = &observedDriver{Driver: drv, observer: r.config.Observer}
+	}
+	tx := &repositoryTx{repo: r, sqlTx: sqlTx, client: ent.NewClient(ent.Driver(drv))}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = sqlTx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, repositoryTxContextKey{}, tx)); err != nil {
+		if rollbackErr := sqlTx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("rollback failed: %v (original error: %w)", rollbackErr, err)
+		}
+		return err
+	}
+	if err := sqlTx.Commit(); err != nil {
+		return NewTransactionError("failed to commit transaction", err)
+	}
+	r.recordEvents(ctx, tx.events...)
+	return nil
+}
+
 // withTx executes a function within an ent transaction
 func (r *sqliteRepository) withTx(ctx context.Context, fn TxFunc) error {
-	tx, err := r.client.Tx(ctx)
+	tx, err := r.entClient(ctx).Tx(ctx)
 	if err != nil {
 		return NewTransactionError("failed to begin transaction", err)
 	}
@@ -41,7 +149,7 @@ func (r *sqliteRepository) withTx(ctx context.Context, fn TxFunc) error {
 		}
 	}()
 
-	err = fn(ctx, tx)
+	err = mapConstraintError(fn(ctx, tx))
 	return err
 }
 
@@ -61,7 +169,7 @@ func (r *sqliteRepository) DuplicateTaskWithDependencies(ctx context.Context, ta
 		}
 
 		// Create new task with new ID and project
-		newTaskID := uuid.New()
+		newTaskID := types.NewID()
 		newTask := entTaskToTask(originalTask)
 		newTask.ID = newTaskID
 		newTask.ProjectID = newProjectID
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInTransaction tests that the operations run in a transaction are
+// committed or rolled back together with their change events
+func TestInTransaction(t *testing.T) {
+	repo, cleanup := setupTestRepository(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	transactor := repo.(types.Transactor)
+	feed := repo.(types.ChangeFeed)
+
+	project := &types.Project{ID: uuid.New(), Title: "Transactions", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	require.NoError(t, repo.CreateProject(ctx, project))
+	newTask := func(ctx context.Context, parentID *uuid.UUID, title string) (*types.Task, error) {
+		task := &types.Task{
+			ID:         uuid.New(),
+			ProjectID:  project.ID,
+			ParentID:   parentID,
+			Title:      title,
+			State:      types.TaskStatePending,
+			Priority:   types.TaskPriorityMedium,
+			Complexity: 3,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}
+		return task, repo.CreateTask(ctx, task)
+	}
+	eventCount := func() int {
+		events, err := feed.ListChangeEvents(ctx, types.ChangeEventFilter{ProjectID: &project.ID})
+		require.NoError(t, err)
+		return len(events)
+	}
+
+	parent, err := newTask(ctx, nil, "Parent")
+	require.NoError(t, err)
+
+	t.Run("rollback", func(t *testing.T) {
+		before := eventCount()
+		var created *types.Task
+		failure := errors.New("step failed")
+		err := transactor.InTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			created, err = newTask(ctx, nil, "Rolled back")
+			if err != nil {
+				return err
+			}
+			if err := repo.MoveTask(ctx, created.ID, &parent.ID); err != nil {
+				return err
+			}
+			if _, err := repo.AddTaskDependency(ctx, created.ID, parent.ID); err != nil {
+				return err
+			}
+			return failure
+		})
+		require.ErrorIs(t, err, failure)
+
+		_, err = repo.GetTask(ctx, created.ID)
+		assert.Error(t, err, "the task created in the transaction must be rolled back")
+		assert.Equal(t, before, eventCount(), "change events of a rolled back transaction must not be recorded")
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		before := eventCount()
+		var created *types.Task
+		err := transactor.InTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			created, err = newTask(ctx, nil, "Committed")
+			if err != nil {
+				return err
+			}
+			// Transactions started with the context of a transaction join it
+			return transactor.InTransaction(ctx, func(ctx context.Context) error {
+				return repo.MoveTask(ctx, created.ID, &parent.ID)
+			})
+		})
+		require.NoError(t, err)
+
+		stored, err := repo.GetTask(ctx, created.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.ParentID)
+		assert.Equal(t, parent.ID, *stored.ParentID)
+		assert.Greater(t, eventCount(), before)
+	})
+}
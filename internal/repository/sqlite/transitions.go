@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/repository/sqlite/ent/task"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// Scheduled transitions are kept in a plain table next to the ent schema,
+// like project locks
+const createScheduledTransitionsTable = `CREATE TABLE IF NOT EXISTS scheduled_transitions (
+	id TEXT PRIMARY KEY,
+	task_id TEXT NOT NULL,
+	project_id TEXT NOT NULL,
+	state TEXT NOT NULL,
+	at TEXT NOT NULL,
+	created_by TEXT NOT NULL,
+	created_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS scheduled_transitions_at ON scheduled_transitions (at);`
+
+// ensureScheduledTransitionTable creates the scheduled transition table if it does not exist
+func (r *sqliteRepository) ensureScheduledTransitionTable(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, createScheduledTransitionsTable); err != nil {
+		return NewMigrationError("failed to create scheduled transition table", err)
+	}
+	return nil
+}
+
+// SaveScheduledTransition creates or replaces a scheduled transition
+func (r *sqliteRepository) SaveScheduledTransition(ctx context.Context, transition *types.ScheduledTransition) error {
+	exists, err := r.entClient(ctx).Task.Query().
+		Where(task.IDEQ(transition.TaskID)).
+		Exist(ctx)
+	if err != nil {
+		return r.mapError("save scheduled transition", err)
+	}
+	if !exists {
+		return NewNotFoundError("task", transition.TaskID.String())
+	}
+
+	_, err = r.conn(ctx).ExecContext(ctx,
+		`INSERT INTO scheduled_transitions (id, task_id, project_id, state, at, created_by, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)
+		 ON CONFLICT (id) DO UPDATE SET
+		   task_id = excluded.task_id,
+		   project_id = excluded.project_id,
+		   state = excluded.state,
+		   at = excluded.at,
+		   created_by = excluded.created_by,
+		   created_at = excluded.created_at`,
+		transition.ID.String(),
+		transition.TaskID.String(),
+		transition.ProjectID.String(),
+		string(transition.State),
+		transition.At.UTC().Format(time.RFC3339Nano),
+		transition.CreatedBy,
+		transition.CreatedAt.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return r.mapError("save scheduled transition", err)
+	}
+	return nil
+}
+
+// ListScheduledTransitions returns the scheduled transitions of a project, or
+// of all projects if projectID is nil, ordered by their time
+func (r *sqliteRepository) ListScheduledTransitions(ctx context.Context, projectID *uuid.UUID) ([]*types.ScheduledTransition, error) {
+	query := `SELECT id, task_id, project_id, state, at, created_by, created_at FROM scheduled_transitions`
+	var args []any
+	if projectID != nil {
+		query += ` WHERE project_id = ?`
+		args = append(args, projectID.String())
+	}
+	query += ` ORDER BY at, id`
+
+	rows, err := r.conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, r.mapError("list scheduled transitions", err)
+	}
+	defer rows.Close()
+
+	transitions := make([]*types.ScheduledTransition, 0)
+	for rows.Next() {
+		var id, taskID, transitionProjectID, state, at, createdAt string
+		transition := &types.ScheduledTransition{}
+		if err := rows.Scan(&id, &taskID, &transitionProjectID, &state, &at, &transition.CreatedBy, &createdAt); err != nil {
+			return nil, r.mapError("list scheduled transitions", err)
+		}
+		if transition.ID, err = uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("invalid scheduled transition ID %q: %w", id, err)
+		}
+		if transition.TaskID, err = uuid.Parse(taskID); err != nil {
+			return nil, fmt.Errorf("invalid task ID in scheduled transition %s: %w", id, err)
+		}
+		if transition.ProjectID, err = uuid.Parse(transitionProjectID); err != nil {
+			return nil, fmt.Errorf("invalid project ID in scheduled transition %s: %w", id, err)
+		}
+		transition.State = types.TaskState(state)
+		if transition.At, err = time.Parse(time.RFC3339Nano, at); err != nil {
+			return nil, fmt.Errorf("invalid time in scheduled transition %s: %w", id, err)
+		}
+		if transition.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return nil, fmt.Errorf("invalid creation time in scheduled transition %s: %w", id, err)
+		}
+		transitions = append(transitions, transition)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapError("list scheduled transitions", err)
+	}
+	return transitions, nil
+}
+
+// DeleteScheduledTransition removes a scheduled transition
+func (r *sqliteRepository) DeleteScheduledTransition(ctx context.Context, id uuid.UUID) error {
+	result, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM scheduled_transitions WHERE id = ?`, id.String())
+	if err != nil {
+		return r.mapError("delete scheduled transition", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return NewNotFoundError("scheduled transition", id.String())
+	}
+	return nil
+}
+
+// deleteProjectTransitions removes the scheduled transitions of a deleted project
+func (r *sqliteRepository) deleteProjectTransitions(ctx context.Context, projectID uuid.UUID) error {
+	if _, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM scheduled_transitions WHERE project_id = ?`, projectID.String()); err != nil {
+		return r.mapError("delete scheduled transitions of project", err)
+	}
+	return nil
+}
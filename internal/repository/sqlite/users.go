@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// Users of a shared deployment are kept in a plain table next to the ent
+// schema, like project locks. Per project roles are stored as JSON.
+const createUsersTable = `CREATE TABLE IF NOT EXISTS users (
+	name TEXT PRIMARY KEY,
+	role TEXT NOT NULL DEFAULT '',
+	project_roles TEXT NOT NULL DEFAULT '{}',
+	token_hash TEXT NOT NULL UNIQUE,
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL
+);`
+
+// ensureUserTable creates the user table if it does not exist
+func (r *sqliteRepository) ensureUserTable(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, createUsersTable); err != nil {
+		return NewMigrationError("failed to create user table", err)
+	}
+	return nil
+}
+
+const selectUsers = `SELECT name, role, project_roles, token_hash, created_at, updated_at FROM users`
+
+// ListUsers returns all users sorted by name
+func (r *sqliteRepository) ListUsers(ctx context.Context) ([]*types.User, error) {
+	rows, err := r.conn(ctx).QueryContext(ctx, selectUsers+` ORDER BY name`)
+	if err != nil {
+		return nil, r.mapError("list users", err)
+	}
+	defer rows.Close()
+
+	var users []*types.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, r.mapError("list users", err)
+	}
+	return users, nil
+}
+
+// GetUser returns the user with the given name, or nil if there is none
+func (r *sqliteRepository) GetUser(ctx context.Context, name string) (*types.User, error) {
+	return r.getUser(ctx, "get user", selectUsers+` WHERE name = ?`, name)
+}
+
+// GetUserByTokenHash returns the user owning a token, or nil if there is none
+func (r *sqliteRepository) GetUserByTokenHash(ctx context.Context, tokenHash string) (*types.User, error) {
+	return r.getUser(ctx, "get user by token", selectUsers+` WHERE token_hash = ?`, tokenHash)
+}
+
+func (r *sqliteRepository) getUser(ctx context.Context, operation, query string, arg string) (*types.User, error) {
+	user, err := scanUser(r.conn(ctx).QueryRowContext(ctx, query, arg))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.mapError(operation, err)
+	}
+	return user, nil
+}
+
+// SaveUser creates or replaces a user
+func (r *sqliteRepository) SaveUser(ctx context.Context, user *types.User) error {
+	projectRoles, err := json.Marshal(user.ProjectRoles)
+	if err != nil {
+		return fmt.Errorf("failed to encode project roles of user %s: %w", user.Name, err)
+	}
+	if user.ProjectRoles == nil {
+		projectRoles = []byte("{}")
+	}
+
+	_, err = r.conn(ctx).ExecContext(ctx,
+		`INSERT INTO users (name, role, project_roles, token_hash, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT (name) DO UPDATE SET
+		   role = excluded.role,
+		   project_roles = excluded.project_roles,
+		   token_hash = excluded.token_hash,
+		   updated_at = excluded.updated_at`,
+		user.Name,
+		string(user.Role),
+		string(projectRoles),
+		user.TokenHash,
+		user.CreatedAt.UTC().Format(time.RFC3339Nano),
+		user.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return r.mapError("save user", err)
+	}
+	return nil
+}
+
+// DeleteUser removes a user, if it exists
+func (r *sqliteRepository) DeleteUser(ctx context.Context, name string) error {
+	if _, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM users WHERE name = ?`, name); err != nil {
+		return r.mapError("delete user", err)
+	}
+	return nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row rowScanner) (*types.User, error) {
+	var role, projectRoles, createdAt, updatedAt string
+	user := &types.User{}
+	if err := row.Scan(&user.Name, &role, &projectRoles, &user.TokenHash, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	user.Role = types.Role(role)
+
+	user.ProjectRoles = make(map[uuid.UUID]types.Role)
+	if err := json.Unmarshal([]byte(projectRoles), &user.ProjectRoles); err != nil {
+		return nil, fmt.Errorf("invalid project roles of user %s: %w", user.Name, err)
+	}
+
+	var err error
+	if user.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return nil, fmt.Errorf("invalid creation time of user %s: %w", user.Name, err)
+	}
+	if user.UpdatedAt, err = time.Parse(time.RFC3339Nano, updatedAt); err != nil {
+		return nil, fmt.Errorf("invalid update time of user %s: %w", user.Name, err)
+	}
+	return user, nil
+}
@@ -0,0 +1,220 @@
+// Package scheduler decides when the schedules of the knot configuration are
+// due. It has no daemon: `knot scheduler run` is invoked by cron or a systemd
+// timer and runs every schedule whose latest occurrence has not run yet.
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed standard five-field cron expression:
+// minute hour day-of-month month day-of-week
+type Cron struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny are set when the field starts with "*"; when both day fields
+	// are restricted a day matching either of them matches
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	min, max int
+	names    []string
+}
+
+var (
+	minuteField = cronField{min: 0, max: 59}
+	hourField   = cronField{min: 0, max: 23}
+	domField    = cronField{min: 1, max: 31}
+	monthField  = cronField{min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	dowField    = cronField{min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// searchLimit bounds the search for occurrences, expressions like "0 0 30 2 *"
+// never match
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+// ParseCron parses a five-field cron expression. Fields accept *, values,
+// ranges (a-b), lists (a,b) and steps (*/n, a-b/n); months and weekdays also
+// accept three-letter names. @yearly, @monthly, @weekly, @daily and @hourly
+// are supported as well.
+func ParseCron(expr string) (*Cron, error) {
+	spec := strings.TrimSpace(expr)
+	if descriptor, ok := descriptors[strings.ToLower(spec)]; ok {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression '%s': expected 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(fields))
+	}
+
+	c := &Cron{
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	for _, f := range []struct {
+		bits  *uint64
+		value string
+		field cronField
+		name  string
+	}{
+		{&c.minute, fields[0], minuteField, "minute"},
+		{&c.hour, fields[1], hourField, "hour"},
+		{&c.dom, fields[2], domField, "day-of-month"},
+		{&c.month, fields[3], monthField, "month"},
+		{&c.dow, fields[4], dowField, "day-of-week"},
+	} {
+		if *f.bits, err = f.field.parse(f.value); err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s': %s: %w", expr, f.name, err)
+		}
+	}
+
+	// 7 is an alias for Sunday
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	return c, nil
+}
+
+func (f cronField) parse(value string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(value, ",") {
+		rangePart, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step in '%s'", item)
+			}
+			rangePart, step = item[:i], n
+		}
+
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = f.min, f.max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range '%s'", rangePart)
+			}
+		default:
+			n, err := f.value(rangePart)
+			if err != nil {
+				return 0, err
+			}
+			lo, hi = n, n
+			// a/n runs from a to the end of the field
+			if step > 1 {
+				hi = f.max
+			}
+		}
+
+		for n := lo; n <= hi; n += step {
+			bits |= 1 << n
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return i + f.min, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("'%s' is not a value between %d and %d", s, f.min, f.max)
+	}
+	return n, nil
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<t.Day()) != 0
+	dow := c.dow&(1<<int(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Prev returns the latest occurrence at or before t, and false if there is
+// none within five years
+func (c *Cron) Prev(t time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute)
+	limit := t.Add(-searchLimit)
+	for t.After(limit) {
+		switch {
+		case c.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		case c.hour&(1<<t.Hour()) == 0:
+			t = startOfHour(t).Add(-time.Minute)
+		case c.minute&(1<<t.Minute()) == 0:
+			t = t.Add(-time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Due returns the latest occurrence at or before now and whether it is after
+// the last run, that is whether it has not run yet. A zero lastRun means the
+// schedule never ran.
+func (c *Cron) Due(lastRun, now time.Time) (time.Time, bool) {
+	occurrence, ok := c.Prev(now)
+	if !ok {
+		return time.Time{}, false
+	}
+	return occurrence, occurrence.After(lastRun)
+}
+
+// Next returns the earliest occurrence after t, and false if there is none
+// within five years
+func (c *Cron) Next(t time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<t.Hour()) == 0:
+			t = startOfHour(t).Add(time.Hour)
+		case c.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// startOfHour truncates in the location of t, unlike time.Truncate
+func startOfHour(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+}
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestParseCron(t *testing.T) {
+	for _, expr := range []string{"* * * * *", "*/15 9-17 * * mon-fri", "0 0 1,15 * *", "30 6 * jan,jul 7", "@weekly", "@DAILY", "5/10 * * * *"} {
+		_, err := ParseCron(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	for expr, msg := range map[string]string{
+		"* * * *":       "expected 5 fields",
+		"60 * * * *":    "minute: '60' is not a value between 0 and 59",
+		"* * 0 * *":     "day-of-month",
+		"* * * foo *":   "month",
+		"*/0 * * * *":   "invalid step",
+		"* 5-2 * * *":   "invalid range",
+		"@fortnightly":  "expected 5 fields",
+		"* * * * mon-x": "day-of-week",
+	} {
+		_, err := ParseCron(expr)
+		assert.ErrorContains(t, err, msg, expr)
+	}
+}
+
+func TestCronPrevNext(t *testing.T) {
+	// 2026-10-16 is a Friday
+	now := at(2026, 10, 16, 14, 7)
+
+	tests := []struct {
+		expr       string
+		prev, next time.Time
+	}{
+		{"*/15 * * * *", at(2026, 10, 16, 14, 0), at(2026, 10, 16, 14, 15)},
+		{"0 9 * * mon-fri", at(2026, 10, 16, 9, 0), at(2026, 10, 19, 9, 0)},
+		{"@weekly", at(2026, 10, 11, 0, 0), at(2026, 10, 18, 0, 0)},
+		{"0 0 1 * *", at(2026, 10, 1, 0, 0), at(2026, 11, 1, 0, 0)},
+		{"0 0 29 2 *", at(2024, 2, 29, 0, 0), at(2028, 2, 29, 0, 0)},
+		// Both day fields restricted: the 1st of the month or any Monday
+		{"0 12 1 * 1", at(2026, 10, 12, 12, 0), at(2026, 10, 19, 12, 0)},
+		{"7 14 * * *", at(2026, 10, 16, 14, 7), at(2026, 10, 17, 14, 7)},
+	}
+	for _, tt := range tests {
+		cron, err := ParseCron(tt.expr)
+		require.NoError(t, err, tt.expr)
+
+		prev, ok := cron.Prev(now)
+		require.True(t, ok, tt.expr)
+		assert.Equal(t, tt.prev, prev, "prev of %s", tt.expr)
+
+		next, ok := cron.Next(now)
+		require.True(t, ok, tt.expr)
+		assert.Equal(t, tt.next, next, "next of %s", tt.expr)
+	}
+
+	never, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+	_, ok := never.Prev(now)
+	assert.False(t, ok)
+	_, ok = never.Next(now)
+	assert.False(t, ok)
+}
+
+func TestCronDue(t *testing.T) {
+	cron, err := ParseCron("0 9 * * *")
+	require.NoError(t, err)
+
+	occurrence, due := cron.Due(time.Time{}, at(2026, 10, 16, 9, 30))
+	assert.True(t, due, "a schedule that never ran is due for its latest occurrence")
+	assert.Equal(t, at(2026, 10, 16, 9, 0), occurrence)
+
+	_, due = cron.Due(occurrence, at(2026, 10, 16, 23, 0))
+	assert.False(t, due, "the occurrence already ran")
+
+	occurrence, due = cron.Due(occurrence, at(2026, 10, 19, 10, 0))
+	assert.True(t, due, "missed occurrences run once, for the latest")
+	assert.Equal(t, at(2026, 10, 19, 9, 0), occurrence)
+}
+
+func TestState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".knot", "scheduler-state.json")
+
+	state, err := LoadState(path)
+	require.NoError(t, err)
+	assert.Empty(t, state.LastRun)
+
+	state.LastRun["standup"] = at(2026, 10, 16, 9, 0)
+	require.NoError(t, state.Save(path))
+
+	loaded, err := LoadState(path)
+	require.NoError(t, err)
+	assert.True(t, loaded.LastRun["standup"].Equal(at(2026, 10, 16, 9, 0)))
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State records the occurrence each schedule last ran for, so that repeated
+// invocations within the same period do not run a schedule twice
+type State struct {
+	LastRun map[string]time.Time `json:"last_run"`
+}
+
+// DefaultStatePath returns the state file of the .knot directory in the
+// current working directory
+func DefaultStatePath() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current working directory: %w", err)
+	}
+	return filepath.Join(cwd, ".knot", "scheduler-state.json"), nil
+}
+
+// LoadState reads the state file, a missing file is an empty state
+func LoadState(path string) (*State, error) {
+	state := &State{LastRun: make(map[string]time.Time)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scheduler state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse scheduler state %s: %w", path, err)
+	}
+	if state.LastRun == nil {
+		state.LastRun = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// Save writes the state file. It is replaced atomically so an interrupted run
+// never leaves a truncated file behind.
+func (s *State) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal scheduler state: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write scheduler state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write scheduler state: %w", err)
+	}
+	return nil
+}
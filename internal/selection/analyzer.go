@@ -59,6 +59,10 @@ func (da *DefaultDependencyAnalyzer) BuildDependencyGraph(tasks []*types.Task) (
 	// Find critical path
 	da.metricsCalculator.FindCriticalPath(graph)
 
+	if da.config.Behavior.PriorityInheritance {
+		graph.EffectivePriorities = EffectivePriorities(tasks)
+	}
+
 	// Count actionable tasks
 	da.actionabilityValidator.CountActionableTasks(graph, tasks)
 
@@ -81,7 +85,7 @@ func (da *DefaultDependencyAnalyzer) computeTaskHash(tasks []*types.Task) string
 
 // computeConfigHash creates a hash for configuration change detection
 func (da *DefaultDependencyAnalyzer) computeConfigHash() string {
-	return fmt.Sprintf("strategy-%d", da.config.Strategy)
+	return fmt.Sprintf("strategy-%d-inheritance-%t", da.config.Strategy, da.config.Behavior.PriorityInheritance)
 }
 
 // CalculateTaskScore computes a detailed score for a task using the metrics calculator
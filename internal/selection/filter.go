@@ -32,13 +32,25 @@ func (tf *DefaultTaskFilter) FilterActionableTasks(tasks []*types.Task) ([]*type
 	}
 
 	actionable := make([]*types.Task, 0, len(candidates))
+	unsuited := 0
 	for _, task := range candidates {
-		if tf.IsTaskActionable(task, tasks) {
-			actionable = append(actionable, task)
+		if !tf.IsTaskActionable(task, tasks) {
+			continue
 		}
+		if !tf.config.Agent.Accepts(task) {
+			unsuited++
+			continue
+		}
+		actionable = append(actionable, task)
 	}
 
 	if len(actionable) == 0 {
+		if unsuited > 0 {
+			return []*types.Task{}, &SelectionError{
+				Type:    ErrorTypeNoActionable,
+				Message: fmt.Sprintf("no actionable tasks suited to the agent: %d actionable task(s) are assigned to other agents or lack the required tags", unsuited),
+			}
+		}
 		if tf.hasPendingTasks(tasks) {
 			return []*types.Task{}, &SelectionError{
 				Type:    ErrorTypeDeadlock,
@@ -18,6 +18,8 @@ type DependencyAnalyzer interface {
 // ScoringStrategy defines how tasks should be scored for selection
 type ScoringStrategy interface {
 	CalculateScore(score *TaskScore, config *Config) float64
+	// ScoreFactors returns the weighted factors that add up to the score
+	ScoreFactors(score *TaskScore, config *Config) []ScoreFactor
 	GetStrategyName() string
 }
 
@@ -210,6 +210,11 @@ func (mc *MetricsCalculator) CalculateTaskScore(task *types.Task, graph *Depende
 		CalculatedAt:       time.Now(),
 	}
 
+	if effective, ok := graph.EffectivePriorities[task.ID]; ok {
+		score.Priority = effective.Priority
+		score.InheritedFrom = effective.InheritedFrom
+	}
+
 	return score, nil
 }
 
@@ -0,0 +1,73 @@
+package selection
+
+import (
+	"sort"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// EffectivePriority is the priority a task is treated with under priority
+// inheritance, together with the task it was inherited from
+type EffectivePriority struct {
+	Priority      types.TaskPriority `json:"priority"`
+	InheritedFrom *uuid.UUID         `json:"inherited_from,omitempty"` // Nil when the task keeps its own priority
+}
+
+// EffectivePriorities applies priority inheritance: every incomplete task
+// passes its priority on to the incomplete tasks it directly or transitively
+// depends on, so prerequisites of urgent work are treated as urgent as well.
+// Each task gets the highest priority of itself and its dependents. Completed
+// dependencies stop the propagation, as nothing below them blocks anymore.
+func EffectivePriorities(tasks []*types.Task) map[uuid.UUID]EffectivePriority {
+	byID := make(map[uuid.UUID]*types.Task, len(tasks))
+	effective := make(map[uuid.UUID]EffectivePriority, len(tasks))
+	var sources []*types.Task
+	for _, task := range tasks {
+		byID[task.ID] = task
+		effective[task.ID] = EffectivePriority{Priority: task.Priority}
+		if isIncomplete(task) {
+			sources = append(sources, task)
+		}
+	}
+
+	// Propagate the highest priorities first so they win ties
+	sort.SliceStable(sources, func(i, j int) bool {
+		if sources[i].Priority != sources[j].Priority {
+			return sources[i].Priority < sources[j].Priority
+		}
+		return types.CompareCreation(sources[i], sources[j]) < 0
+	})
+
+	for _, source := range sources {
+		visited := map[uuid.UUID]bool{source.ID: true}
+		queue := append([]uuid.UUID(nil), source.Dependencies...)
+		for len(queue) > 0 {
+			depID := queue[0]
+			queue = queue[1:]
+			dep, exists := byID[depID]
+			if !exists || visited[depID] || !isIncomplete(dep) {
+				continue
+			}
+			visited[depID] = true
+
+			// Lower numbers are higher priorities
+			if source.Priority < effective[depID].Priority {
+				sourceID := source.ID
+				effective[depID] = EffectivePriority{Priority: source.Priority, InheritedFrom: &sourceID}
+			}
+			queue = append(queue, dep.Dependencies...)
+		}
+	}
+
+	return effective
+}
+
+func isIncomplete(task *types.Task) bool {
+	switch task.State {
+	case types.TaskStateCompleted, types.TaskStateCancelled, types.TaskStateDeletionPending:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,62 @@
+package selection
+
+import (
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectivePriorities(t *testing.T) {
+	task1 := createTestTask("task1", "Schema", types.TaskStatePending, types.TaskPriorityLow, nil, nil)
+	task2 := createTestTask("task2", "Migration", types.TaskStatePending, types.TaskPriorityLow, nil, []uuid.UUID{task1.ID})
+	task3 := createTestTask("task3", "Release", types.TaskStatePending, types.TaskPriorityHigh, nil, []uuid.UUID{task2.ID})
+	task4 := createTestTask("task4", "Docs", types.TaskStatePending, types.TaskPriorityMedium, nil, nil)
+
+	effective := EffectivePriorities([]*types.Task{task1, task2, task3, task4})
+
+	// The whole chain below the high priority task inherits from it
+	for _, task := range []*types.Task{task1, task2} {
+		require.NotNil(t, effective[task.ID].InheritedFrom, task.Title)
+		assert.Equal(t, types.TaskPriorityHigh, effective[task.ID].Priority)
+		assert.Equal(t, task3.ID, *effective[task.ID].InheritedFrom)
+	}
+
+	// Tasks keep their own priority without urgent dependents
+	assert.Nil(t, effective[task3.ID].InheritedFrom)
+	assert.Equal(t, types.TaskPriorityMedium, effective[task4.ID].Priority)
+	assert.Nil(t, effective[task4.ID].InheritedFrom)
+}
+
+func TestEffectivePrioritiesStopsAtCompletedTasks(t *testing.T) {
+	task1 := createTestTask("task1", "Schema", types.TaskStatePending, types.TaskPriorityLow, nil, nil)
+	task2 := createTestTask("task2", "Migration", types.TaskStateCompleted, types.TaskPriorityLow, nil, []uuid.UUID{task1.ID})
+	task3 := createTestTask("task3", "Release", types.TaskStatePending, types.TaskPriorityHigh, nil, []uuid.UUID{task2.ID})
+
+	effective := EffectivePriorities([]*types.Task{task1, task2, task3})
+
+	assert.Equal(t, types.TaskPriorityLow, effective[task1.ID].Priority)
+	assert.Nil(t, effective[task1.ID].InheritedFrom)
+	assert.Nil(t, effective[task2.ID].InheritedFrom)
+}
+
+func TestSelectionWithPriorityInheritance(t *testing.T) {
+	task1 := createTestTask("task1", "Prerequisite", types.TaskStatePending, types.TaskPriorityLow, nil, nil)
+	task2 := createTestTask("task2", "Urgent feature", types.TaskStatePending, types.TaskPriorityHigh, nil, []uuid.UUID{task1.ID})
+	task3 := createTestTask("task3", "Unrelated", types.TaskStatePending, types.TaskPriorityMedium, nil, nil)
+	tasks := []*types.Task{task1, task2, task3}
+
+	config := DefaultConfig()
+	config.Strategy = StrategyPriority
+
+	selected, err := SelectActionableTaskWithConfig(tasks, config)
+	require.NoError(t, err)
+	assert.Equal(t, task3.ID, selected.ID)
+
+	config.Behavior.PriorityInheritance = true
+	selected, err = SelectActionableTaskWithConfig(tasks, config)
+	require.NoError(t, err)
+	assert.Equal(t, task1.ID, selected.ID)
+}
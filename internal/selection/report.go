@@ -0,0 +1,131 @@
+package selection
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// SelectionReport exposes the complete data behind a selection run, so that
+// external tools can reason about why a task was or was not chosen
+type SelectionReport struct {
+	Strategy      string           `json:"strategy"`
+	Config        *Config          `json:"config"`
+	Selected      *TaskScore       `json:"selected,omitempty"`
+	Reason        string           `json:"reason,omitempty"`
+	Alternatives  []*TaskScore     `json:"alternatives"`
+	NotActionable []*BlockedTask   `json:"not_actionable"` // Open tasks that could not be selected
+	Graph         *DependencyGraph `json:"graph"`
+	Error         *SelectionError  `json:"error,omitempty"` // Why no task was selected, if none was
+	GeneratedAt   time.Time        `json:"generated_at"`
+}
+
+// BlockedTask describes an open task that is not actionable
+type BlockedTask struct {
+	TaskID  uuid.UUID       `json:"task_id"`
+	Title   string          `json:"title"`
+	State   types.TaskState `json:"state"`
+	Reasons []string        `json:"reasons"`
+}
+
+// AnalyzeSelection runs a selection over tasks and returns the full analysis.
+// Selection failures such as "no actionable tasks" are reported in the Error
+// field rather than returned, since the analysis is still meaningful.
+func AnalyzeSelection(tasks []*types.Task, config *Config) (*SelectionReport, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	selector, err := NewTaskSelector(config.Strategy, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create selector: %w", err)
+	}
+
+	graph, err := selector.analyzer.BuildDependencyGraph(tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+
+	report := &SelectionReport{
+		Strategy:      config.Strategy.String(),
+		Config:        config,
+		Alternatives:  make([]*TaskScore, 0),
+		NotActionable: findBlockedTasks(tasks, config),
+		Graph:         graph,
+		GeneratedAt:   time.Now(),
+	}
+
+	// The selector reports an exhausted project as a plain filter error
+	if len(tasks) > 0 && !hasOpenTasks(tasks) {
+		report.Error = &SelectionError{
+			Type:    ErrorTypeNoActionable,
+			Message: "no pending or in-progress tasks available",
+		}
+		return report, nil
+	}
+
+	if _, err := selector.SelectNextActionableTask(tasks); err != nil {
+		var selErr *SelectionError
+		if !errors.As(err, &selErr) {
+			return nil, err
+		}
+		report.Error = selErr
+		return report, nil
+	}
+
+	result := selector.GetLastResult()
+	report.Selected = result.Score
+	report.Reason = result.Reason
+	report.Alternatives = append(report.Alternatives, result.Alternatives...)
+
+	return report, nil
+}
+
+// findBlockedTasks lists the open tasks that are not actionable together with
+// the reasons, ordered by creation time
+func findBlockedTasks(tasks []*types.Task, config *Config) []*BlockedTask {
+	validator := NewActionabilityValidator(config)
+	taskMap := NewTaskMap(tasks)
+
+	blocked := make([]*BlockedTask, 0)
+	for _, task := range tasks {
+		switch task.State {
+		case types.TaskStatePending, types.TaskStateInProgress, types.TaskStateBlocked:
+		default:
+			continue
+		}
+
+		actionable, reasons := validator.ValidateAndExplain(task, taskMap)
+		if actionable {
+			continue
+		}
+
+		blocked = append(blocked, &BlockedTask{
+			TaskID:  task.ID,
+			Title:   task.Title,
+			State:   task.State,
+			Reasons: reasons,
+		})
+	}
+
+	sort.SliceStable(blocked, func(i, j int) bool {
+		ti, _ := taskMap.Get(blocked[i].TaskID)
+		tj, _ := taskMap.Get(blocked[j].TaskID)
+		return types.CompareCreation(ti, tj) < 0
+	})
+	return blocked
+}
+
+// hasOpenTasks reports whether any task is pending or in progress
+func hasOpenTasks(tasks []*types.Task) bool {
+	for _, task := range tasks {
+		if task.State == types.TaskStatePending || task.State == types.TaskStateInProgress {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package selection
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeSelection(t *testing.T) {
+	task1 := createTestTask("task1", "Foundation", types.TaskStatePending, types.TaskPriorityMedium, nil, nil)
+	task2 := createTestTask("task2", "Build on foundation", types.TaskStatePending, types.TaskPriorityHigh, nil, []uuid.UUID{task1.ID})
+	task3 := createTestTask("task3", "Done", types.TaskStateCompleted, types.TaskPriorityLow, nil, nil)
+
+	report, err := AnalyzeSelection([]*types.Task{task1, task2, task3}, DefaultConfig())
+	require.NoError(t, err)
+
+	require.NotNil(t, report.Selected)
+	assert.Equal(t, task1.ID, report.Selected.Task.ID)
+	assert.NotEmpty(t, report.Reason)
+	assert.Nil(t, report.Error)
+	assert.Equal(t, 3, report.Graph.TaskCount)
+
+	// The dependent task is reported with its blocking reason, the completed one is not
+	require.Len(t, report.NotActionable, 1)
+	assert.Equal(t, task2.ID, report.NotActionable[0].TaskID)
+	assert.Contains(t, report.NotActionable[0].Reasons[0], "Foundation")
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"not_actionable"`)
+}
+
+func TestAnalyzeSelectionNoActionable(t *testing.T) {
+	task1 := createTestTask("task1", "Done", types.TaskStateCompleted, types.TaskPriorityMedium, nil, nil)
+
+	report, err := AnalyzeSelection([]*types.Task{task1}, nil)
+	require.NoError(t, err)
+
+	assert.Nil(t, report.Selected)
+	require.NotNil(t, report.Error)
+	assert.Equal(t, ErrorTypeNoActionable, report.Error.Type)
+	assert.Empty(t, report.NotActionable)
+}
@@ -38,7 +38,7 @@ import (
 
 // SelectActionableTask is a convenience function for quick task selection with default configuration
 func SelectActionableTask(tasks []*types.Task, strategy Strategy) (*types.Task, error) {
-	selector, err := NewTaskSelector(strategy, DefaultConfig())
+	selector, err := NewTaskSelector(strategy, ConfigForStrategy(strategy))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create selector: %w", err)
 	}
@@ -123,7 +123,7 @@ func GetActionableTasks(tasks []*types.Task, config *Config) ([]*types.Task, err
 // ScoreTasks scores all actionable tasks using the specified strategy
 func ScoreTasks(tasks []*types.Task, strategy Strategy, config *Config) ([]*TaskScore, error) {
 	if config == nil {
-		config = DefaultConfig()
+		config = ConfigForStrategy(strategy)
 	}
 
 	// Override config strategy
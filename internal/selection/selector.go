@@ -2,7 +2,9 @@ package selection
 
 import (
 	"fmt"
+	"math"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/denkhaus/knot/v2/internal/types"
@@ -141,6 +143,7 @@ func (ts *DefaultTaskSelector) scoreActionableTasks(actionableTasks []*types.Tas
 		}
 
 		// Calculate final score using strategy
+		score.Factors = ts.strategy.ScoreFactors(score, ts.config)
 		score.Score = ts.strategy.CalculateScore(score, ts.config)
 
 		// Apply score threshold if configured
@@ -208,6 +211,13 @@ func (ts *DefaultTaskSelector) selectBestTask(scores []*TaskScore) (*TaskScore,
 // sortTaskScores sorts task scores by score and applies tie-breaking rules
 func (ts *DefaultTaskSelector) sortTaskScores(scores []*TaskScore) {
 	sort.Slice(scores, func(i, j int) bool {
+		// Tasks with more of the agent's preferred tags come first
+		preferredI := ts.config.Agent.PreferredCount(scores[i].Task)
+		preferredJ := ts.config.Agent.PreferredCount(scores[j].Task)
+		if preferredI != preferredJ {
+			return preferredI > preferredJ
+		}
+
 		scoreI := scores[i].Score
 		scoreJ := scores[j].Score
 
@@ -219,7 +229,7 @@ func (ts *DefaultTaskSelector) sortTaskScores(scores []*TaskScore) {
 		// Tie-breaking
 		if ts.config.Behavior.BreakTiesByCreation {
 			// Secondary sort by creation time (ascending - older first)
-			return scores[i].Task.CreatedAt.Before(scores[j].Task.CreatedAt)
+			return types.CompareCreation(scores[i].Task, scores[j].Task) < 0
 		}
 
 		// Final tie-breaker by task ID for consistency
@@ -244,6 +254,13 @@ func (ts *DefaultTaskSelector) generateSelectionReason(score *TaskScore, graph *
 		reasons = append(reasons, "high priority")
 	}
 
+	if score.InheritedFrom != nil {
+		if node, exists := graph.Nodes[*score.InheritedFrom]; exists {
+			reasons = append(reasons, fmt.Sprintf("inherits %s priority from dependent task '%s'",
+				score.Priority.ToExternalString(), node.Task.Title))
+		}
+	}
+
 	if score.DependentCount > 0 {
 		reasons = append(reasons, fmt.Sprintf("%d task(s) depend on this", score.DependentCount))
 	}
@@ -256,18 +273,49 @@ func (ts *DefaultTaskSelector) generateSelectionReason(score *TaskScore, graph *
 		reasons = append(reasons, "subtask (completing branch)")
 	}
 
+	if preferred := ts.config.Agent.PreferredCount(score.Task); preferred > 0 {
+		reasons = append(reasons, fmt.Sprintf("matches %d preferred tag(s)", preferred))
+	}
+
 	// Critical path information
 	node := graph.Nodes[score.Task.ID]
 	if node != nil && score.CriticalPathLength > 1 {
 		reasons = append(reasons, fmt.Sprintf("on critical path (length %d)", score.CriticalPathLength))
 	}
 
-	if len(reasons) == 1 {
-		// Only strategy reason, add score information
-		reasons = append(reasons, fmt.Sprintf("score: %.2f", score.Score))
+	reason := strings.Join(reasons, ", ")
+	if len(score.Factors) > 0 {
+		reason += fmt.Sprintf("; score %.2f = %s", score.Score, FormatScoreFactors(score.Factors))
+	} else {
+		reason += fmt.Sprintf("; score %.2f", score.Score)
 	}
+	return reason
+}
 
-	return reasons[0]
+// FormatScoreFactors renders the contribution of each weighted factor to a
+// score, e.g. "unblocked tasks 3 x 0.40 (+1.20) + priority 2 x 0.30 (+0.60)".
+// Factors with a weight of 0 do not contribute and are left out.
+func FormatScoreFactors(factors []ScoreFactor) string {
+	parts := make([]string, 0, len(factors))
+	for _, factor := range factors {
+		if factor.Weight == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %s x %s (%+.2f)",
+			factor.Name, formatFactorNumber(factor.Value), formatFactorNumber(factor.Weight), factor.Contribution))
+	}
+	if len(parts) == 0 {
+		return "no weighted factors"
+	}
+	return strings.Join(parts, " + ")
+}
+
+// formatFactorNumber prints whole numbers without decimals and others with two
+func formatFactorNumber(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.2f", v)
 }
 
 // GetSelectionReason returns the reason for the last selection
@@ -1,6 +1,7 @@
 package selection
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -125,6 +126,18 @@ func TestDefaultTaskSelector_SelectNextActionableTask(t *testing.T) {
 		// Should select the oldest task by creation time
 	})
 
+	t.Run("CreationOrderWithinTheSameInstant", func(t *testing.T) {
+		selector, err := NewTaskSelector(StrategyCreationOrder, DefaultConfig())
+		require.NoError(t, err)
+
+		created := time.Now()
+		first := &types.Task{ID: types.NewID(), Title: "First", State: types.TaskStatePending, Priority: types.TaskPriorityMedium, CreatedAt: created}
+		second := &types.Task{ID: types.NewID(), Title: "Second", State: types.TaskStatePending, Priority: types.TaskPriorityMedium, CreatedAt: created}
+
+		selectedTask, err := selector.SelectNextActionableTask([]*types.Task{second, first})
+		assertTaskSelected(t, "First", selectedTask, err)
+	})
+
 	t.Run("InProgressPreference", func(t *testing.T) {
 		config := DefaultConfig()
 		config.Behavior.PreferInProgress = true
@@ -462,6 +475,21 @@ func TestDefaultTaskSelector_generateSelectionReason(t *testing.T) {
 		reason := selector.generateSelectionReason(score, graph)
 		assert.Contains(t, reason, "dependency-aware strategy")
 	})
+
+	t.Run("FactorContributions", func(t *testing.T) {
+		task := createTestTask("task1", "Test Task", types.TaskStatePending, 2, nil, nil)
+		score := &TaskScore{
+			Task:               task,
+			UnblockedTaskCount: 3,
+			Priority:           2,
+		}
+		score.Factors = selector.strategy.ScoreFactors(score, selector.config)
+		score.Score = selector.strategy.CalculateScore(score, selector.config)
+
+		reason := selector.generateSelectionReason(score, graph)
+		assert.Contains(t, reason, fmt.Sprintf("score %.2f = ", score.Score))
+		assert.Contains(t, reason, "unblocked tasks 3 x 0.40 (+1.20)")
+	})
 }
 
 func BenchmarkTaskSelector_Selection(b *testing.B) {
@@ -558,6 +586,56 @@ func TestDefaultTaskSelector_IntegrationScenarios(t *testing.T) {
 	})
 }
 
+func TestDefaultTaskSelector_AgentPreferences(t *testing.T) {
+	agentID := uuid.New()
+	otherAgent := uuid.New()
+
+	docs := createTestTask("docs", "Write Docs", types.TaskStatePending, types.TaskPriorityHigh, nil, nil)
+	docs.Tags = []string{"docs"}
+	api := createTestTask("api", "API Design", types.TaskStatePending, types.TaskPriorityMedium, nil, nil)
+	api.Tags = []string{"Backend"}
+	schema := createTestTask("database", "Database Setup", types.TaskStatePending, types.TaskPriorityLow, nil, nil)
+	schema.Tags = []string{"backend", "db"}
+	taken := createTestTask("backend", "Backend Feature", types.TaskStateInProgress, types.TaskPriorityHigh, nil, nil)
+	taken.Tags = []string{"backend", "db"}
+	taken.AssignedAgent = &otherAgent
+	tasks := []*types.Task{docs, api, schema, taken}
+
+	selectFor := func(prefs AgentPreferences) (*types.Task, error) {
+		config := ConfigForStrategy(StrategyPriority)
+		config.Agent = prefs
+		selector, err := NewTaskSelector(config.Strategy, config)
+		require.NoError(t, err)
+		return selector.SelectNextActionableTask(tasks)
+	}
+
+	t.Run("RequiredTags", func(t *testing.T) {
+		selected, err := selectFor(AgentPreferences{AgentID: &agentID, RequiredTags: []string{"backend"}})
+		require.NoError(t, err)
+		assert.Equal(t, api.ID, selected.ID, "tag match ignores case; in-progress work of other agents is skipped")
+	})
+
+	t.Run("PreferredTags", func(t *testing.T) {
+		selected, err := selectFor(AgentPreferences{AgentID: &agentID, PreferredTags: []string{"db"}})
+		require.NoError(t, err)
+		assert.Equal(t, schema.ID, selected.ID, "preferred tags outrank the strategy score")
+	})
+
+	t.Run("OwnWorkInProgress", func(t *testing.T) {
+		selected, err := selectFor(AgentPreferences{AgentID: &otherAgent, RequiredTags: []string{"backend"}})
+		require.NoError(t, err)
+		assert.Equal(t, taken.ID, selected.ID)
+	})
+
+	t.Run("NothingSuited", func(t *testing.T) {
+		_, err := selectFor(AgentPreferences{AgentID: &agentID, RequiredTags: []string{"frontend"}})
+		var selErr *SelectionError
+		require.ErrorAs(t, err, &selErr)
+		assert.Equal(t, ErrorTypeNoActionable, selErr.Type)
+		assert.Contains(t, selErr.Message, "suited to the agent")
+	})
+}
+
 // Helper function for integration test
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
 )
 
 // priorityToScore converts priority to scoring value
@@ -13,15 +14,32 @@ func priorityToScore(priority types.TaskPriority) float64 {
 	return float64(4 - priority) // 1->3, 2->2, 3->1
 }
 
+// sumFactors returns the score made up of the contributions of the factors
+func sumFactors(factors []ScoreFactor) float64 {
+	total := 0.0
+	for _, factor := range factors {
+		total += factor.Contribution
+	}
+	return total
+}
+
+// weighted returns a factor contributing value * weight to the score
+func weighted(name string, value, weight float64) ScoreFactor {
+	return ScoreFactor{Name: name, Value: value, Weight: weight, Contribution: value * weight}
+}
+
 // CreationOrderStrategy implements selection by creation time (original behavior)
 type CreationOrderStrategy struct{}
 
 // CalculateScore returns a score based on creation time (lower is better for older tasks)
 func (s *CreationOrderStrategy) CalculateScore(score *TaskScore, config *Config) float64 {
-	// Convert timestamp to score - older tasks get higher scores (inverted)
-	timestamp := score.Task.CreatedAt.Unix()
-	// Use max timestamp - current timestamp to invert (older = higher score)
-	return float64(-timestamp) // Negative so older tasks have higher scores
+	return sumFactors(s.ScoreFactors(score, config))
+}
+
+// ScoreFactors returns the creation time as the only factor
+func (s *CreationOrderStrategy) ScoreFactors(score *TaskScore, config *Config) []ScoreFactor {
+	// Negative timestamp so older tasks have higher scores
+	return []ScoreFactor{weighted(FactorCreationTime, float64(score.Task.CreatedAt.Unix()), -1)}
 }
 
 // GetStrategyName returns the strategy name
@@ -34,20 +52,24 @@ type DependencyAwareStrategy struct{}
 
 // CalculateScore computes a weighted score considering dependencies, priority, and hierarchy
 func (s *DependencyAwareStrategy) CalculateScore(score *TaskScore, config *Config) float64 {
-	// Weighted combination of factors
-	dependentScore := float64(score.UnblockedTaskCount) * config.Weights.DependentCount
-	priorityScore := priorityToScore(score.Priority) * config.Weights.Priority
-	depthScore := float64(score.HierarchyDepth+1) * config.Weights.DepthFirst // Prefer deeper tasks for completing branches
-	criticalScore := float64(score.CriticalPathLength) * config.Weights.CriticalPath
+	return sumFactors(s.ScoreFactors(score, config))
+}
 
-	totalScore := dependentScore + priorityScore + depthScore + criticalScore
+// ScoreFactors returns the weighted dependency, priority, hierarchy and critical path factors
+func (s *DependencyAwareStrategy) ScoreFactors(score *TaskScore, config *Config) []ScoreFactor {
+	factors := []ScoreFactor{
+		weighted(FactorUnblockedTasks, float64(score.UnblockedTaskCount), config.Weights.DependentCount),
+		weighted(FactorPriority, priorityToScore(score.Priority), config.Weights.Priority),
+		weighted(FactorDepth, float64(score.HierarchyDepth+1), config.Weights.DepthFirst), // Prefer deeper tasks for completing branches
+		weighted(FactorCriticalPath, float64(score.CriticalPathLength), config.Weights.CriticalPath),
+	}
 
 	// Apply bonus for in-progress tasks if configured
 	if config.Behavior.PreferInProgress && score.Task.State == types.TaskStateInProgress {
-		totalScore *= 1.2 // 20% bonus
+		factors = append(factors, weighted(FactorInProgressBonus, sumFactors(factors), 0.2)) // 20% bonus
 	}
 
-	return totalScore
+	return factors
 }
 
 // GetStrategyName returns the strategy name
@@ -60,13 +82,15 @@ type PriorityStrategy struct{}
 
 // CalculateScore returns a score based primarily on priority with dependents as tiebreaker
 func (s *PriorityStrategy) CalculateScore(score *TaskScore, config *Config) float64 {
-	// Priority is the main factor (lower number = higher priority = higher score)
-	priorityScore := priorityToScore(score.Priority) * 100
-
-	// Add dependents as secondary factor
-	dependentScore := float64(score.DependentCount)
+	return sumFactors(s.ScoreFactors(score, config))
+}
 
-	return priorityScore + dependentScore
+// ScoreFactors returns priority as the main factor and dependents as secondary factor
+func (s *PriorityStrategy) ScoreFactors(score *TaskScore, config *Config) []ScoreFactor {
+	return []ScoreFactor{
+		weighted(FactorPriority, priorityToScore(score.Priority), 100),
+		weighted(FactorDependents, float64(score.DependentCount), 1),
+	}
 }
 
 // GetStrategyName returns the strategy name
@@ -79,16 +103,17 @@ type DepthFirstStrategy struct{}
 
 // CalculateScore returns a score that heavily favors deeper tasks (subtasks over parents)
 func (s *DepthFirstStrategy) CalculateScore(score *TaskScore, config *Config) float64 {
-	// Heavily weight hierarchy depth to complete branches first
-	depthScore := float64(score.HierarchyDepth * 1000)
-
-	// Add priority as secondary factor
-	priorityScore := priorityToScore(score.Priority)
-
-	// Subtract dependent count to prefer leaf tasks over ones with many dependents
-	dependentPenalty := float64(score.DependentCount * 10)
+	return sumFactors(s.ScoreFactors(score, config))
+}
 
-	return depthScore + priorityScore - dependentPenalty
+// ScoreFactors returns hierarchy depth as the main factor, priority as secondary
+// factor and a penalty for dependents to prefer leaf tasks
+func (s *DepthFirstStrategy) ScoreFactors(score *TaskScore, config *Config) []ScoreFactor {
+	return []ScoreFactor{
+		weighted(FactorDepth, float64(score.HierarchyDepth), 1000),
+		weighted(FactorPriority, priorityToScore(score.Priority), 1),
+		weighted(FactorDependents, float64(score.DependentCount), -10),
+	}
 }
 
 // GetStrategyName returns the strategy name
@@ -101,16 +126,17 @@ type CriticalPathStrategy struct{}
 
 // CalculateScore returns a score that prioritizes tasks on the critical path
 func (s *CriticalPathStrategy) CalculateScore(score *TaskScore, config *Config) float64 {
-	// Primary factor: critical path length
-	criticalScore := float64(score.CriticalPathLength * 100)
-
-	// Secondary factor: number of tasks this would unblock
-	unblockedScore := float64(score.UnblockedTaskCount * 50)
-
-	// Tertiary factor: priority
-	priorityScore := priorityToScore(score.Priority) * 10
+	return sumFactors(s.ScoreFactors(score, config))
+}
 
-	return criticalScore + unblockedScore + priorityScore
+// ScoreFactors returns critical path length, unblocked tasks and priority, in
+// order of importance
+func (s *CriticalPathStrategy) ScoreFactors(score *TaskScore, config *Config) []ScoreFactor {
+	return []ScoreFactor{
+		weighted(FactorCriticalPath, float64(score.CriticalPathLength), 100),
+		weighted(FactorUnblockedTasks, float64(score.UnblockedTaskCount), 50),
+		weighted(FactorPriority, priorityToScore(score.Priority), 10),
+	}
 }
 
 // GetStrategyName returns the strategy name
@@ -118,6 +144,68 @@ func (s *CriticalPathStrategy) GetStrategyName() string {
 	return "critical-path"
 }
 
+// remainingEffort returns the remaining effort of a task in minutes: its
+// estimate minus the logged effort, or one hour per complexity point for
+// tasks without an estimate
+func remainingEffort(task *types.Task) int64 {
+	if task.Estimate == nil {
+		return int64(max(task.Complexity, 1)) * utils.MinutesPerHour
+	}
+	return max(*task.Estimate-task.ActualEffort(), 0)
+}
+
+// ShortestJobFirstStrategy implements selection of quick wins, the tasks with
+// the least remaining effort
+type ShortestJobFirstStrategy struct{}
+
+// CalculateScore returns a score that favors tasks with little remaining effort,
+// with priority and unblocked tasks as secondary factors
+func (s *ShortestJobFirstStrategy) CalculateScore(score *TaskScore, config *Config) float64 {
+	return sumFactors(s.ScoreFactors(score, config))
+}
+
+// ScoreFactors returns the weighted effort, priority and unblocked task factors
+func (s *ShortestJobFirstStrategy) ScoreFactors(score *TaskScore, config *Config) []ScoreFactor {
+	// 10 for tasks without remaining effort, falling with every hour of work
+	hours := float64(remainingEffort(score.Task)) / utils.MinutesPerHour
+	return []ScoreFactor{
+		weighted(FactorEffort, 10/(1+hours), config.Weights.Effort),
+		weighted(FactorPriority, priorityToScore(score.Priority), config.Weights.Priority),
+		weighted(FactorUnblockedTasks, float64(score.UnblockedTaskCount), config.Weights.DependentCount),
+	}
+}
+
+// GetStrategyName returns the strategy name
+func (s *ShortestJobFirstStrategy) GetStrategyName() string {
+	return "shortest-job-first"
+}
+
+// HighestValueStrategy implements selection of the tasks with the best ratio
+// of priority to complexity
+type HighestValueStrategy struct{}
+
+// CalculateScore returns a score based on the priority/complexity ratio with
+// unblocked tasks as secondary factor
+func (s *HighestValueStrategy) CalculateScore(score *TaskScore, config *Config) float64 {
+	return sumFactors(s.ScoreFactors(score, config))
+}
+
+// ScoreFactors returns the weighted value and unblocked task factors
+func (s *HighestValueStrategy) ScoreFactors(score *TaskScore, config *Config) []ScoreFactor {
+	// From 30 for a high priority task of complexity 1 down to 1 for a low
+	// priority task of complexity 10
+	ratio := priorityToScore(score.Priority) / float64(max(score.Task.Complexity, 1))
+	return []ScoreFactor{
+		weighted(FactorValue, ratio*10, config.Weights.Value),
+		weighted(FactorUnblockedTasks, float64(score.UnblockedTaskCount), config.Weights.DependentCount),
+	}
+}
+
+// GetStrategyName returns the strategy name
+func (s *HighestValueStrategy) GetStrategyName() string {
+	return "highest-value"
+}
+
 // StrategyFactory creates scoring strategies
 type StrategyFactory struct{}
 
@@ -134,6 +222,10 @@ func (f *StrategyFactory) NewStrategy(strategy Strategy) (ScoringStrategy, error
 		return &DepthFirstStrategy{}, nil
 	case StrategyCriticalPath:
 		return &CriticalPathStrategy{}, nil
+	case StrategyShortestJobFirst:
+		return &ShortestJobFirstStrategy{}, nil
+	case StrategyHighestValue:
+		return &HighestValueStrategy{}, nil
 	default:
 		return nil, fmt.Errorf("unknown strategy: %v", strategy)
 	}
@@ -147,6 +239,19 @@ func (f *StrategyFactory) GetAvailableStrategies() []Strategy {
 		StrategyPriority,
 		StrategyDepthFirst,
 		StrategyCriticalPath,
+		StrategyShortestJobFirst,
+		StrategyHighestValue,
+	}
+}
+
+// UsesWeights reports whether the strategy scores tasks with the configured
+// weights rather than fixed ones
+func (f *StrategyFactory) UsesWeights(strategy Strategy) bool {
+	switch strategy {
+	case StrategyDependencyAware, StrategyShortestJobFirst, StrategyHighestValue:
+		return true
+	default:
+		return false
 	}
 }
 
@@ -165,6 +270,17 @@ func (f *StrategyFactory) ValidateWeights(strategy Strategy, weights Weights) er
 			return fmt.Errorf("all weights must be non-negative")
 		}
 
+	case StrategyShortestJobFirst, StrategyHighestValue:
+		if weights.DependentCount < 0 || weights.Priority < 0 || weights.Effort < 0 || weights.Value < 0 {
+			return fmt.Errorf("all weights must be non-negative")
+		}
+		if strategy == StrategyShortestJobFirst && weights.Effort == 0 {
+			return fmt.Errorf("shortest-job-first needs a positive effort weight")
+		}
+		if strategy == StrategyHighestValue && weights.Value == 0 {
+			return fmt.Errorf("highest-value needs a positive value weight")
+		}
+
 	case StrategyCreationOrder, StrategyPriority, StrategyDepthFirst, StrategyCriticalPath:
 		// Other strategies don't use weights, but we don't need to error
 		// Just ignore the weights for these strategies
@@ -207,6 +323,17 @@ func (f *StrategyFactory) GetDefaultWeightsForStrategy(strategy Strategy) Weight
 			Priority:       0.1,
 			DepthFirst:     0.0,
 		}
+	case StrategyShortestJobFirst:
+		return Weights{
+			Effort:         0.7,
+			Priority:       0.2,
+			DependentCount: 0.1,
+		}
+	case StrategyHighestValue:
+		return Weights{
+			Value:          0.8,
+			DependentCount: 0.2,
+		}
 	default: // Including StrategyCreationOrder
 		return Weights{
 			DependentCount: 0.0,
@@ -286,6 +286,72 @@ func TestCriticalPathStrategy(t *testing.T) {
 	})
 }
 
+func TestShortestJobFirstStrategy(t *testing.T) {
+	strategy := &ShortestJobFirstStrategy{}
+	config := ConfigForStrategy(StrategyShortestJobFirst)
+
+	t.Run("GetStrategyName", func(t *testing.T) {
+		assert.Equal(t, "shortest-job-first", strategy.GetStrategyName())
+	})
+
+	t.Run("CalculateScore", func(t *testing.T) {
+		quick := createTestTask("quick", "Quick Task", types.TaskStatePending, types.TaskPriorityLow, nil, nil)
+		quick.Estimate = ptrInt64(30)
+		long := createTestTask("long", "Long Task", types.TaskStatePending, types.TaskPriorityHigh, nil, nil)
+		long.Estimate = ptrInt64(16 * 60)
+		started := createTestTask("started", "Started Task", types.TaskStateInProgress, types.TaskPriorityLow, nil, nil)
+		started.Estimate = ptrInt64(16 * 60)
+		started.EffortLog = []types.EffortEntry{{Minutes: 16*60 - 10}}
+		unestimated := createTestTask("unestimated", "Unestimated Task", types.TaskStatePending, types.TaskPriorityLow, nil, nil)
+		unestimated.Complexity = 8
+
+		scoreOf := func(task *types.Task, unblocked int) float64 {
+			return strategy.CalculateScore(&TaskScore{Task: task, Priority: task.Priority, UnblockedTaskCount: unblocked}, config)
+		}
+
+		assert.Greater(t, scoreOf(quick, 0), scoreOf(long, 2), "Quick wins should beat high-priority long tasks")
+		assert.Greater(t, scoreOf(started, 0), scoreOf(quick, 0), "Remaining effort should subtract logged effort")
+		assert.Greater(t, scoreOf(quick, 0), scoreOf(unestimated, 0), "Complexity should stand in for a missing estimate")
+	})
+}
+
+func TestHighestValueStrategy(t *testing.T) {
+	strategy := &HighestValueStrategy{}
+	config := ConfigForStrategy(StrategyHighestValue)
+
+	t.Run("GetStrategyName", func(t *testing.T) {
+		assert.Equal(t, "highest-value", strategy.GetStrategyName())
+	})
+
+	t.Run("CalculateScore", func(t *testing.T) {
+		cheapHigh := createTestTask("cheap", "Cheap High Priority", types.TaskStatePending, types.TaskPriorityHigh, nil, nil)
+		cheapHigh.Complexity = 2
+		costlyHigh := createTestTask("costly", "Costly High Priority", types.TaskStatePending, types.TaskPriorityHigh, nil, nil)
+		costlyHigh.Complexity = 9
+		cheapLow := createTestTask("low", "Cheap Low Priority", types.TaskStatePending, types.TaskPriorityLow, nil, nil)
+		cheapLow.Complexity = 2
+
+		scoreOf := func(task *types.Task) float64 {
+			return strategy.CalculateScore(&TaskScore{Task: task, Priority: task.Priority}, config)
+		}
+
+		assert.Greater(t, scoreOf(cheapHigh), scoreOf(costlyHigh))
+		assert.Greater(t, scoreOf(cheapHigh), scoreOf(cheapLow))
+		assert.Greater(t, scoreOf(cheapLow), scoreOf(costlyHigh), "Low complexity should outweigh priority at a large complexity gap")
+	})
+}
+
+func TestLookupStrategy(t *testing.T) {
+	for _, strategy := range (&StrategyFactory{}).GetAvailableStrategies() {
+		found, ok := LookupStrategy(strategy.String())
+		assert.True(t, ok)
+		assert.Equal(t, strategy, found)
+	}
+
+	_, ok := LookupStrategy("fastest")
+	assert.False(t, ok)
+}
+
 func TestStrategyFactory(t *testing.T) {
 	factory := &StrategyFactory{}
 
@@ -299,6 +365,8 @@ func TestStrategyFactory(t *testing.T) {
 			{StrategyPriority, "priority"},
 			{StrategyDepthFirst, "depth-first"},
 			{StrategyCriticalPath, "critical-path"},
+			{StrategyShortestJobFirst, "shortest-job-first"},
+			{StrategyHighestValue, "highest-value"},
 		}
 
 		for _, test := range tests {
@@ -323,12 +391,14 @@ func TestStrategyFactory(t *testing.T) {
 	t.Run("GetAvailableStrategies", func(t *testing.T) {
 		strategies := factory.GetAvailableStrategies()
 
-		assert.Len(t, strategies, 5)
+		assert.Len(t, strategies, 7)
 		assert.Contains(t, strategies, StrategyCreationOrder)
 		assert.Contains(t, strategies, StrategyDependencyAware)
 		assert.Contains(t, strategies, StrategyPriority)
 		assert.Contains(t, strategies, StrategyDepthFirst)
 		assert.Contains(t, strategies, StrategyCriticalPath)
+		assert.Contains(t, strategies, StrategyShortestJobFirst)
+		assert.Contains(t, strategies, StrategyHighestValue)
 	})
 
 	t.Run("ValidateWeights", func(t *testing.T) {
@@ -367,6 +437,14 @@ func TestStrategyFactory(t *testing.T) {
 		// Other strategies don't require weight validation
 		err = factory.ValidateWeights(StrategyCreationOrder, invalidWeights)
 		assert.NoError(t, err)
+
+		// Effort based strategies need their main weight
+		err = factory.ValidateWeights(StrategyShortestJobFirst, validWeights)
+		assert.ErrorContains(t, err, "effort weight")
+		err = factory.ValidateWeights(StrategyHighestValue, validWeights)
+		assert.ErrorContains(t, err, "value weight")
+		err = factory.ValidateWeights(StrategyShortestJobFirst, factory.GetDefaultWeightsForStrategy(StrategyShortestJobFirst))
+		assert.NoError(t, err)
 	})
 
 	t.Run("GetDefaultWeightsForStrategy", func(t *testing.T) {
@@ -480,3 +558,7 @@ func TestStrategyComparison(t *testing.T) {
 		assert.Equal(t, "Unblocks Many", selectedTask.Title)
 	})
 }
+
+func ptrInt64(v int64) *int64 {
+	return &v
+}
@@ -1,6 +1,7 @@
 package selection
 
 import (
+	"strings"
 	"time"
 
 	"github.com/denkhaus/knot/v2/internal/types"
@@ -16,6 +17,8 @@ const (
 	StrategyDepthFirst
 	StrategyPriority
 	StrategyCriticalPath
+	StrategyShortestJobFirst
+	StrategyHighestValue
 )
 
 // String returns the string representation of a strategy
@@ -31,6 +34,10 @@ func (s Strategy) String() string {
 		return "priority"
 	case StrategyCriticalPath:
 		return "critical-path"
+	case StrategyShortestJobFirst:
+		return "shortest-job-first"
+	case StrategyHighestValue:
+		return "highest-value"
 	default:
 		return "unknown"
 	}
@@ -49,11 +56,27 @@ func ParseStrategy(s string) Strategy {
 		return StrategyPriority
 	case "critical-path":
 		return StrategyCriticalPath
+	case "shortest-job-first":
+		return StrategyShortestJobFirst
+	case "highest-value":
+		return StrategyHighestValue
 	default:
 		return StrategyDependencyAware // default
 	}
 }
 
+// LookupStrategy returns the strategy with the given name, and false if there
+// is no such strategy
+func LookupStrategy(s string) (Strategy, bool) {
+	factory := &StrategyFactory{}
+	for _, strategy := range factory.GetAvailableStrategies() {
+		if strategy.String() == s {
+			return strategy, true
+		}
+	}
+	return StrategyDependencyAware, false
+}
+
 // Config holds configuration for task selection
 type Config struct {
 	// Selection strategy
@@ -67,6 +90,44 @@ type Config struct {
 
 	// Advanced options
 	Advanced AdvancedConfig `json:"advanced"`
+
+	// Agent restricts selection to work suited to one agent
+	Agent AgentPreferences `json:"agent"`
+}
+
+// AgentPreferences restrict and rank tasks for the agent that will work on them
+type AgentPreferences struct {
+	AgentID       *uuid.UUID `json:"agent_id,omitempty"`       // Tasks assigned to other agents are skipped
+	RequiredTags  []string   `json:"required_tags,omitempty"`  // Only tasks with at least one of these tags are selected
+	PreferredTags []string   `json:"preferred_tags,omitempty"` // Tasks with more of these tags are selected first
+}
+
+// Accepts reports whether a task is suited to the agent: not assigned to
+// another agent and tagged with one of the required tags, if any
+func (p AgentPreferences) Accepts(task *types.Task) bool {
+	if p.AgentID != nil && task.AssignedAgent != nil && *task.AssignedAgent != *p.AgentID {
+		return false
+	}
+	return len(p.RequiredTags) == 0 || countTags(task, p.RequiredTags) > 0
+}
+
+// PreferredCount returns the number of preferred tags of a task
+func (p AgentPreferences) PreferredCount(task *types.Task) int {
+	return countTags(task, p.PreferredTags)
+}
+
+// countTags returns how many of the given tags a task has, ignoring case
+func countTags(task *types.Task, tags []string) int {
+	count := 0
+	for _, tag := range tags {
+		for _, taskTag := range task.Tags {
+			if strings.EqualFold(tag, taskTag) {
+				count++
+				break
+			}
+		}
+	}
+	return count
 }
 
 // Weights defines scoring weight factors
@@ -75,6 +136,8 @@ type Weights struct {
 	Priority       float64 `json:"priority"`        // How much to weight explicit priority
 	DepthFirst     float64 `json:"depth_first"`     // How much to prefer completing subtasks first
 	CriticalPath   float64 `json:"critical_path"`   // How much to weight critical path position
+	Effort         float64 `json:"effort"`          // How much to prefer tasks with little remaining effort
+	Value          float64 `json:"value"`           // How much to weight the priority/complexity ratio
 }
 
 // BehaviorConfig defines behavioral options
@@ -83,6 +146,7 @@ type BehaviorConfig struct {
 	PreferInProgress        bool `json:"prefer_in_progress"`         // Whether to prioritize in-progress tasks
 	BreakTiesByCreation     bool `json:"break_ties_by_creation"`     // Use creation time as final tiebreaker
 	StrictDependencies      bool `json:"strict_dependencies"`        // Whether to strictly enforce dependency order
+	PriorityInheritance     bool `json:"priority_inheritance"`       // Whether dependencies inherit the priority of their dependents
 }
 
 // AdvancedConfig defines advanced configuration options
@@ -118,6 +182,15 @@ func DefaultConfig() *Config {
 	}
 }
 
+// ConfigForStrategy returns the default configuration with the given strategy
+// and its default weights
+func ConfigForStrategy(strategy Strategy) *Config {
+	config := DefaultConfig()
+	config.Strategy = strategy
+	config.Weights = (&StrategyFactory{}).GetDefaultWeightsForStrategy(strategy)
+	return config
+}
+
 // TaskScore represents a scored task with selection metrics
 type TaskScore struct {
 	Task               *types.Task        `json:"task"`
@@ -126,12 +199,78 @@ type TaskScore struct {
 	DependencyDepth    int                `json:"dependency_depth"`     // Depth in the dependency chain (0 = no deps)
 	CriticalPathLength int                `json:"critical_path_length"` // Length of longest dependency chain through this task
 	HierarchyDepth     int                `json:"hierarchy_depth"`      // Depth in parent-child hierarchy
-	Priority           types.TaskPriority `json:"priority"`             // Explicit task priority
+	Priority           types.TaskPriority `json:"priority"`             // Task priority, the effective priority with priority inheritance
+	InheritedFrom      *uuid.UUID         `json:"inherited_from"`       // Dependent task the priority was inherited from
 	Score              float64            `json:"score"`                // Calculated selection score
+	Factors            []ScoreFactor      `json:"factors,omitempty"`    // Weighted factors that add up to the score
 	SelectionReason    string             `json:"selection_reason"`     // Why this task was selected
 	CalculatedAt       time.Time          `json:"calculated_at"`        // When the score was calculated
 }
 
+// ScoreFactor is the contribution of one factor to the score of a task
+type ScoreFactor struct {
+	Name         string  `json:"name"`
+	Value        float64 `json:"value"`        // Value of the factor for the task, before weighting
+	Weight       float64 `json:"weight"`       // Weight of the factor in the strategy
+	Contribution float64 `json:"contribution"` // Value * Weight, the part of the score
+}
+
+// Names of the score factors
+const (
+	FactorUnblockedTasks  = "unblocked tasks"
+	FactorPriority        = "priority"
+	FactorDepth           = "depth"
+	FactorCriticalPath    = "critical path"
+	FactorEffort          = "effort"
+	FactorValue           = "value"
+	FactorDependents      = "dependents"
+	FactorCreationTime    = "creation time"
+	FactorInProgressBonus = "in-progress bonus"
+)
+
+// WeightOf returns the configured weight of a score factor, false if the
+// factor has no configurable weight
+func (w Weights) WeightOf(factor string) (float64, bool) {
+	switch factor {
+	case FactorUnblockedTasks:
+		return w.DependentCount, true
+	case FactorPriority:
+		return w.Priority, true
+	case FactorDepth:
+		return w.DepthFirst, true
+	case FactorCriticalPath:
+		return w.CriticalPath, true
+	case FactorEffort:
+		return w.Effort, true
+	case FactorValue:
+		return w.Value, true
+	default:
+		return 0, false
+	}
+}
+
+// SetWeightOf sets the weight of a score factor, false if the factor has no
+// configurable weight
+func (w *Weights) SetWeightOf(factor string, weight float64) bool {
+	switch factor {
+	case FactorUnblockedTasks:
+		w.DependentCount = weight
+	case FactorPriority:
+		w.Priority = weight
+	case FactorDepth:
+		w.DepthFirst = weight
+	case FactorCriticalPath:
+		w.CriticalPath = weight
+	case FactorEffort:
+		w.Effort = weight
+	case FactorValue:
+		w.Value = weight
+	default:
+		return false
+	}
+	return true
+}
+
 // DependencyNode represents a task in the dependency graph
 type DependencyNode struct {
 	TaskID             uuid.UUID   `json:"task_id"`
@@ -160,6 +299,8 @@ type DependencyGraph struct {
 	AnalyzedAt      time.Time                     `json:"analyzed_at"`      // When the graph was built
 	TaskCount       int                           `json:"task_count"`       // Total number of tasks
 	ActionableCount int                           `json:"actionable_count"` // Number of currently actionable tasks
+	// EffectivePriorities holds the inherited priorities, set with priority inheritance only
+	EffectivePriorities map[uuid.UUID]EffectivePriority `json:"effective_priorities,omitempty"`
 }
 
 // SelectionResult contains the result of task selection
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	theme "github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/types"
 )
 
@@ -67,7 +68,7 @@ func (u *TaskSelectionUtils) FormatDependencyGraph(graph *DependencyGraph, showD
 	output.WriteString(fmt.Sprintf("  Leaf tasks: %d\n", len(graph.LeafTasks)))
 
 	if graph.HasCycles {
-		output.WriteString(fmt.Sprintf("  ⚠️  Cycles detected in %d tasks\n", len(graph.CyclicTasks)))
+		output.WriteString(fmt.Sprintf("  %sCycles detected in %d tasks\n", theme.Icon("⚠️"), len(graph.CyclicTasks)))
 	}
 
 	if len(graph.CriticalPath) > 0 {
@@ -77,9 +78,9 @@ func (u *TaskSelectionUtils) FormatDependencyGraph(graph *DependencyGraph, showD
 	if showDetails {
 		output.WriteString("\nTask Details:\n")
 		for _, node := range graph.Nodes {
-			status := "✓"
+			status := theme.Symbol("✓", "+")
 			if !node.IsActionable {
-				status = "✗"
+				status = theme.Symbol("✗", "-")
 			}
 
 			output.WriteString(fmt.Sprintf("  %s %s\n", status, node.Task.Title))
@@ -143,7 +144,7 @@ func (u *TaskSelectionUtils) GenerateSelectionSummary(tasks []*types.Task, graph
 		}
 
 		if graph.HasCycles {
-			output.WriteString("  ⚠️  Circular dependencies detected\n")
+			output.WriteString("  " + theme.Icon("⚠️") + "Circular dependencies detected\n")
 		}
 	}
 
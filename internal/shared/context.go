@@ -1,7 +1,12 @@
 package shared
 
 import (
+	"os"
+
 	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/metrics"
+	"github.com/denkhaus/knot/v2/internal/output"
+	"github.com/denkhaus/knot/v2/internal/types"
 	"go.uber.org/zap"
 )
 
@@ -11,6 +16,13 @@ type AppContext struct {
 	ProjectManager manager.ProjectManager
 	Logger         *zap.Logger
 	Actor          string
+	Metrics        *metrics.Registry
+	// Repository is the storage the ProjectManager works on, for commands
+	// that expose it directly such as 'knot serve'
+	Repository types.Repository
+	// Output is where commands print, chosen by the output mode. Text is
+	// printed to the current os.Stdout if nil.
+	Output output.Writer
 }
 
 // NewAppContext creates a new application context with all dependencies
@@ -18,6 +30,7 @@ func NewAppContext(projectManager manager.ProjectManager, logger *zap.Logger) *A
 	return &AppContext{
 		ProjectManager: projectManager,
 		Logger:         logger,
+		Metrics:        metrics.Default,
 	}
 }
 
@@ -32,3 +45,12 @@ func (p *AppContext) GetActor() string {
 	}
 	return p.Actor
 }
+
+// Out returns the writer commands print to, printing text to stdout if none
+// was set
+func (p *AppContext) Out() output.Writer {
+	if p.Output == nil {
+		return output.NewTextWriter(os.Stdout)
+	}
+	return p.Output
+}
@@ -1,6 +1,14 @@
 package shared
 
-import "github.com/urfave/cli/v2"
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/clipboard"
+	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/urfave/cli/v2"
+)
 
 // NewJSONFlag creates a consistent JSON flag for all commands
 func NewJSONFlag() cli.Flag {
@@ -19,6 +27,44 @@ func NewQuietFlag() cli.Flag {
 	}
 }
 
+// NewQuietIDFlag creates the quiet flag for create commands, which prints only
+// the new ID so the output can be captured with command substitution
+func NewQuietIDFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:    "quiet",
+		Aliases: []string{"q"},
+		Usage:   "Print only the ID of the created item (e.g. ID=$(knot ... --quiet))",
+	}
+}
+
+// NewOutFlag creates the flag writing the JSON output of a command to a file
+// instead of stdout
+func NewOutFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "out",
+		Usage: "Write the JSON output to a file instead of stdout (implies --json)",
+	}
+}
+
+// NewCopyFlag creates the flag putting the result of a command on the system
+// clipboard, the new ID for create commands and the JSON output otherwise
+func NewCopyFlag(usage string) cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "copy",
+		Usage: usage,
+	}
+}
+
+// CopyToClipboard puts text on the system clipboard and reports it on stderr.
+// The command already succeeded, so a missing clipboard is only a warning.
+func CopyToClipboard(text, what string) {
+	if err := clipboard.Copy(text); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Copied %s to the clipboard\n", what)
+}
+
 func NewTaskLimitFlag() cli.Flag {
 	return &cli.IntFlag{
 		Name:    "limit",
@@ -44,3 +90,95 @@ func NewLogLevelFlag() cli.Flag {
 		Value: "off",
 	}
 }
+
+// NewNoColorFlag creates the global flag disabling colored output.
+// The NO_COLOR environment variable is honored by output.Configure.
+func NewNoColorFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "no-color",
+		Usage: "Disable colored output (also disabled by NO_COLOR or when not writing to a terminal)",
+	}
+}
+
+// NewNoEmojiFlag creates the global flag replacing emoji with plain text
+func NewNoEmojiFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:    "no-emoji",
+		Usage:   "Disable emoji in output",
+		EnvVars: []string{"KNOT_NO_EMOJI"},
+	}
+}
+
+// NewMachineFlag creates the global flag suppressing advisory text so that
+// agents can parse the output
+func NewMachineFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:    "machine",
+		Usage:   "Print only results, no reminders or hints; create and update commands print the changed item as JSON (implies --no-color and --no-emoji)",
+		EnvVars: []string{"KNOT_MACHINE"},
+	}
+}
+
+// DefaultTimeout bounds a single command invocation unless --timeout overrides it
+const DefaultTimeout = 30 * time.Second
+
+// NewTimeoutFlag creates the global flag limiting how long a command may run
+func NewTimeoutFlag() cli.Flag {
+	return &cli.DurationFlag{
+		Name:    "timeout",
+		Usage:   "Maximum duration of a command, e.g. 30s or 2m (0 disables the timeout)",
+		Value:   DefaultTimeout,
+		EnvVars: []string{"KNOT_TIMEOUT"},
+	}
+}
+
+// NewUTCFlag creates the global flag displaying timestamps in UTC instead of
+// the configured time zone
+func NewUTCFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:    "utc",
+		Usage:   "Display timestamps in UTC instead of the configured time zone (TimeZone in .knot/config.json, default local time)",
+		EnvVars: []string{"KNOT_UTC"},
+	}
+}
+
+// NewForceFlag creates the global flag overriding project locks held by
+// other actors
+func NewForceFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:    "force",
+		Usage:   "Write to projects locked by another actor (see 'knot project lock')",
+		EnvVars: []string{"KNOT_FORCE"},
+	}
+}
+
+// NewInstructionsFlags creates the flags setting or removing the agent
+// instructions of a project or task
+func NewInstructionsFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "instructions",
+			Aliases: []string{"i"},
+			Usage:   "New instructions, e.g. \"always write tests\"",
+		},
+		&cli.BoolFlag{
+			Name:  "clear",
+			Usage: "Remove the instructions",
+		},
+	}
+}
+
+// InstructionsFromFlags returns the instructions given by the flags of
+// NewInstructionsFlags, empty for --clear
+func InstructionsFromFlags(c *cli.Context) (string, error) {
+	instructions := c.String("instructions")
+	switch {
+	case c.Bool("clear") && instructions != "":
+		return "", errors.NewValidationError("conflicting flags", fmt.Errorf("use either --instructions or --clear"))
+	case c.Bool("clear"):
+		return "", nil
+	case instructions == "":
+		return "", errors.NewValidationError("missing instructions", fmt.Errorf("pass --instructions, or --clear to remove them"))
+	}
+	return instructions, nil
+}
@@ -1,26 +1,88 @@
 package shared
 
 import (
-	"context"
 	"fmt"
+	"os"
 
 	"github.com/denkhaus/knot/v2/internal/errors"
 	"github.com/google/uuid"
 	"github.com/urfave/cli/v2"
 )
 
-// ResolveProjectID resolves the project ID from stored context
+// ProjectEnvVar is the environment variable selecting the project of a single
+// process, e.g. a CI job or an agent sandbox. It takes precedence over the
+// project stored with 'knot project select', which all processes share.
+const ProjectEnvVar = "KNOT_PROJECT_ID"
+
+// SessionEnvVar holds the token of the session started with 'knot session
+// start'. Projects selected with 'knot project select --session' apply only
+// to processes with the same token.
+const SessionEnvVar = "KNOT_SESSION"
+
+// Sources of the selected project, see ResolveSelection
+const (
+	SelectionFromEnv     = "environment"
+	SelectionFromSession = "session"
+	SelectionStored      = "stored"
+)
+
+// Session returns the session token from KNOT_SESSION, or "" outside a session
+func Session() string {
+	return os.Getenv(SessionEnvVar)
+}
+
+// ResolveProjectID resolves the project ID from KNOT_PROJECT_ID, the session or stored context
 func ResolveProjectID(c *cli.Context, appCtx *AppContext) (uuid.UUID, error) {
-	// Get project from database stored context
-	if contextProjectID, err := appCtx.ProjectManager.GetSelectedProject(c.Context); err == nil && contextProjectID != nil {
-		return *contextProjectID, nil
+	projectID, err := SelectedProjectID(c, appCtx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if projectID != nil {
+		return *projectID, nil
 	}
 
 	// No project available
 	return uuid.Nil, errors.NoProjectContextError()
 }
 
-// ShowProjectContext displays the current project context if one is selected
+// SelectedProjectID returns the project set in KNOT_PROJECT_ID, or else the
+// project selected in the session, or else the stored selected project, or nil
+// if there is none
+func SelectedProjectID(c *cli.Context, appCtx *AppContext) (*uuid.UUID, error) {
+	projectID, _, err := ResolveSelection(c, appCtx)
+	return projectID, err
+}
+
+// ResolveSelection is SelectedProjectID that also reports where the project
+// came from: SelectionFromEnv, SelectionFromSession or SelectionStored
+func ResolveSelection(c *cli.Context, appCtx *AppContext) (*uuid.UUID, string, error) {
+	if value := os.Getenv(ProjectEnvVar); value != "" {
+		projectID, err := uuid.Parse(value)
+		if err != nil {
+			return nil, "", errors.InvalidUUIDError(ProjectEnvVar, value)
+		}
+		return &projectID, SelectionFromEnv, nil
+	}
+
+	if session := Session(); session != "" {
+		sessionProjectID, err := appCtx.ProjectManager.GetSessionProject(c.Context, session)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get session project: %w", err)
+		}
+		if sessionProjectID != nil {
+			return sessionProjectID, SelectionFromSession, nil
+		}
+	}
+
+	// Get project from database stored context
+	if contextProjectID, err := appCtx.ProjectManager.GetSelectedProject(c.Context); err == nil && contextProjectID != nil {
+		return contextProjectID, SelectionStored, nil
+	}
+	return nil, "", nil
+}
+
+// ShowProjectContext displays the current project context if one is selected.
+// It is advisory text, so machine mode leaves it out.
 // Returns true if context was shown, false if no project is selected
 func ShowProjectContext(c *cli.Context, appCtx *AppContext) bool {
 	// Skip context display for JSON output or quiet mode
@@ -29,26 +91,26 @@ func ShowProjectContext(c *cli.Context, appCtx *AppContext) bool {
 	}
 
 	// Get selected project
-	selectedProjectID, err := appCtx.ProjectManager.GetSelectedProject(c.Context)
+	selectedProjectID, err := SelectedProjectID(c, appCtx)
 	if err != nil || selectedProjectID == nil {
 		return false
 	}
 
 	// Get project details
-	project, err := appCtx.ProjectManager.GetProject(context.Background(), *selectedProjectID)
+	project, err := appCtx.ProjectManager.GetProject(c.Context, *selectedProjectID)
 	if err != nil {
 		return false
 	}
 
 	// Display context indicator
-	fmt.Printf("[Project: %s]\n", project.Title)
+	appCtx.Out().Advise("[Project: %s]\n", project.Title)
 	return true
 }
 
 // ShowProjectContextWithSeparator displays project context with a separator line
 func ShowProjectContextWithSeparator(c *cli.Context, appCtx *AppContext) bool {
 	if ShowProjectContext(c, appCtx) {
-		fmt.Println()
+		appCtx.Out().Advise("\n")
 		return true
 	}
 	return false
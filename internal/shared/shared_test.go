@@ -87,6 +87,100 @@ func TestResolveProjectID(t *testing.T) {
 	}
 }
 
+func TestResolveProjectIDFromEnvironment(t *testing.T) {
+	repo := inmemory.NewMemoryRepository()
+	projectManager := manager.NewManagerWithRepository(repo, manager.DefaultConfig())
+	appCtx := NewAppContext(projectManager, zap.NewNop())
+
+	selected, err := projectManager.CreateProject(context.Background(), "Selected", "", "test-actor")
+	if err != nil {
+		t.Fatalf("Failed to create test project: %v", err)
+	}
+	if err := projectManager.SetSelectedProject(context.Background(), selected.ID, "test-actor"); err != nil {
+		t.Fatalf("Failed to set selected project: %v", err)
+	}
+	ctx := cli.NewContext(&cli.App{}, nil, nil)
+
+	// The environment takes precedence over the stored selection
+	envProjectID := uuid.New()
+	t.Setenv(ProjectEnvVar, envProjectID.String())
+	resolvedID, err := ResolveProjectID(ctx, appCtx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resolvedID != envProjectID {
+		t.Errorf("Expected project ID %v from %s, but got %v", envProjectID, ProjectEnvVar, resolvedID)
+	}
+
+	t.Setenv(ProjectEnvVar, "not-a-uuid")
+	if _, err := ResolveProjectID(ctx, appCtx); err == nil || !containsString(err.Error(), ProjectEnvVar) {
+		t.Errorf("Expected error mentioning %s, but got: %v", ProjectEnvVar, err)
+	}
+
+	// An empty variable falls back to the stored selection
+	t.Setenv(ProjectEnvVar, "")
+	resolvedID, err = ResolveProjectID(ctx, appCtx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resolvedID != selected.ID {
+		t.Errorf("Expected selected project ID %v, but got %v", selected.ID, resolvedID)
+	}
+}
+
+func TestResolveSelectionInSession(t *testing.T) {
+	repo := inmemory.NewMemoryRepository()
+	projectManager := manager.NewManagerWithRepository(repo, manager.DefaultConfig())
+	appCtx := NewAppContext(projectManager, zap.NewNop())
+	ctx := cli.NewContext(&cli.App{}, nil, nil)
+
+	stored, err := projectManager.CreateProject(context.Background(), "Stored", "", "test-actor")
+	if err != nil {
+		t.Fatalf("Failed to create test project: %v", err)
+	}
+	inSession, err := projectManager.CreateProject(context.Background(), "Session", "", "test-actor")
+	if err != nil {
+		t.Fatalf("Failed to create test project: %v", err)
+	}
+	if err := projectManager.SetSelectedProject(context.Background(), stored.ID, "test-actor"); err != nil {
+		t.Fatalf("Failed to set selected project: %v", err)
+	}
+	if err := projectManager.SetSessionProject(context.Background(), "shell-1", inSession.ID, "test-actor"); err != nil {
+		t.Fatalf("Failed to set session project: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		session        string
+		envProjectID   string
+		expectedID     uuid.UUID
+		expectedSource string
+	}{
+		{"Outside a session", "", "", stored.ID, SelectionStored},
+		{"Session with selection", "shell-1", "", inSession.ID, SelectionFromSession},
+		{"Session without selection", "shell-2", "", stored.ID, SelectionStored},
+		{"Environment overrides session", "shell-1", stored.ID.String(), stored.ID, SelectionFromEnv},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(SessionEnvVar, tt.session)
+			t.Setenv(ProjectEnvVar, tt.envProjectID)
+
+			projectID, source, err := ResolveSelection(ctx, appCtx)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if projectID == nil || *projectID != tt.expectedID {
+				t.Errorf("Expected project ID %v, but got %v", tt.expectedID, projectID)
+			}
+			if source != tt.expectedSource {
+				t.Errorf("Expected source %q, but got %q", tt.expectedSource, source)
+			}
+		})
+	}
+}
+
 func TestValidateProjectID(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,76 @@
+package shared
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+type deadlineContextKey struct{}
+
+// Deadline bounds a command by the global --timeout. Time a user spends on
+// interactive input, such as a confirmation prompt or an editor session, does
+// not count: the command calls RestartTimeout once the input is complete.
+type Deadline struct {
+	Timeout time.Duration
+
+	mu      sync.Mutex
+	base    context.Context
+	current context.Context
+	cancels []context.CancelFunc
+}
+
+// NewDeadline starts a deadline of timeout for a command running with parent
+func NewDeadline(parent context.Context, timeout time.Duration) *Deadline {
+	d := &Deadline{Timeout: timeout}
+	d.base = context.WithValue(parent, deadlineContextKey{}, d)
+	d.Restart()
+	return d
+}
+
+// Context returns the context bounded by the current deadline
+func (d *Deadline) Context() context.Context {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.current
+}
+
+// Restart starts the full timeout anew and returns the context bounded by it.
+// Contexts of earlier deadlines stay valid until Cancel.
+func (d *Deadline) Restart() context.Context {
+	ctx, cancel := context.WithTimeout(d.base, d.Timeout)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.current = ctx
+	d.cancels = append(d.cancels, cancel)
+	return ctx
+}
+
+// TimedOut reports whether the current deadline has passed
+func (d *Deadline) TimedOut() bool {
+	return stderrors.Is(d.Context().Err(), context.DeadlineExceeded)
+}
+
+// Cancel releases the contexts of all deadlines
+func (d *Deadline) Cancel() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, cancel := range d.cancels {
+		cancel()
+	}
+	d.cancels = nil
+}
+
+// RestartTimeout restarts the --timeout deadline of a command after
+// interactive input, so the time the user took does not count against the
+// limit. It replaces c.Context, which the command passes to the operations
+// that follow. Without a timeout it does nothing.
+func RestartTimeout(c *cli.Context) {
+	if d, ok := c.Context.Value(deadlineContextKey{}).(*Deadline); ok {
+		c.Context = d.Restart()
+	}
+}
@@ -0,0 +1,238 @@
+// Package simulation answers "what if" questions about a project by applying
+// changes to an in-memory copy of it. Nothing is written to the repository.
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/selection"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// ProjectState summarizes the progress and the open work of a project
+type ProjectState struct {
+	Completed  int                `json:"completed"`
+	Total      int                `json:"total"`
+	Progress   float64            `json:"progress"` // Percentage (0-100)
+	Actionable []analysis.TaskRef `json:"actionable"`
+	// CriticalPath is the longest chain of open tasks linked by finish-to-start
+	// dependencies, starting with the task that has to be completed first
+	CriticalPath []analysis.TaskRef `json:"critical_path"`
+}
+
+// CompletionResult describes how a project would change if a task was completed
+type CompletionResult struct {
+	Task analysis.TaskRef `json:"task"`
+	// OnCriticalPath is true when the task is part of the current critical path
+	OnCriticalPath bool `json:"on_critical_path"`
+	// AlsoCompleted lists parent tasks that would be completed automatically
+	AlsoCompleted []analysis.TaskRef `json:"also_completed"`
+	// NewlyActionable lists tasks that would become actionable
+	NewlyActionable []analysis.TaskRef `json:"newly_actionable"`
+	Before          ProjectState       `json:"before"`
+	After           ProjectState       `json:"after"`
+}
+
+// SimulateCompletion completes the task in a sandbox copy of its project,
+// applying the same rules as a real completion (acceptance criteria, reviews,
+// automatic completion of parent tasks), and reports the difference. Pending
+// and blocked tasks are started first, as they would have to be in practice.
+func SimulateCompletion(ctx context.Context, pm manager.ProjectManager, projectID, taskID uuid.UUID, actor string) (*CompletionResult, error) {
+	sandbox, err := manager.NewSandbox(ctx, pm, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := sandbox.ListTasksWithDependencies(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *types.Task
+	for _, task := range tasks {
+		if task.ID == taskID {
+			target = task
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("task %s does not belong to the project", taskID)
+	}
+	switch target.State {
+	case types.TaskStateCompleted, types.TaskStateCancelled, types.TaskStateDeletionPending:
+		return nil, fmt.Errorf("task '%s' is already %s", target.Title, target.State)
+	}
+
+	// Summarize before changing anything, the sandbox hands out live tasks
+	result := &CompletionResult{
+		Task:            refOf(target),
+		AlsoCompleted:   make([]analysis.TaskRef, 0),
+		NewlyActionable: make([]analysis.TaskRef, 0),
+		Before:          Summarize(tasks),
+	}
+	for _, ref := range result.Before.CriticalPath {
+		if ref.TaskID == taskID {
+			result.OnCriticalPath = true
+		}
+	}
+	wasCompleted := make(map[uuid.UUID]bool)
+	for _, task := range tasks {
+		wasCompleted[task.ID] = task.State == types.TaskStateCompleted
+	}
+
+	if target.State != types.TaskStateInProgress {
+		if _, err := sandbox.UpdateTaskState(ctx, taskID, types.TaskStateInProgress, actor); err != nil {
+			return nil, fmt.Errorf("cannot start task '%s': %w", result.Task.Title, err)
+		}
+	}
+	if _, err := sandbox.UpdateTaskState(ctx, taskID, types.TaskStateCompleted, actor); err != nil {
+		return nil, err
+	}
+
+	tasks, err = sandbox.ListTasksWithDependencies(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	result.After = Summarize(tasks)
+
+	for _, task := range sortedByTitle(tasks) {
+		if task.ID != taskID && task.State == types.TaskStateCompleted && !wasCompleted[task.ID] {
+			result.AlsoCompleted = append(result.AlsoCompleted, refOf(task))
+		}
+	}
+
+	actionableBefore := make(map[uuid.UUID]bool, len(result.Before.Actionable))
+	for _, ref := range result.Before.Actionable {
+		actionableBefore[ref.TaskID] = true
+	}
+	for _, ref := range result.After.Actionable {
+		if !actionableBefore[ref.TaskID] {
+			result.NewlyActionable = append(result.NewlyActionable, ref)
+		}
+	}
+
+	return result, nil
+}
+
+// Summarize computes progress, actionable tasks and the critical path of the
+// tasks of a project. Actionability follows the default selection rules.
+func Summarize(tasks []*types.Task) ProjectState {
+	state := ProjectState{
+		Total:      len(tasks),
+		Actionable: make([]analysis.TaskRef, 0),
+	}
+
+	validator := selection.NewActionabilityValidator(selection.DefaultConfig())
+	for _, task := range sortedByTitle(tasks) {
+		if task.State == types.TaskStateCompleted {
+			state.Completed++
+		}
+		if validator.ValidateActionability(task, tasks) {
+			state.Actionable = append(state.Actionable, refOf(task))
+		}
+	}
+	if state.Total > 0 {
+		state.Progress = float64(state.Completed) / float64(state.Total) * 100
+	}
+
+	state.CriticalPath = criticalPath(tasks)
+	return state
+}
+
+// criticalPath returns the longest dependency chain of open tasks. Only
+// finish-to-start dependencies chain tasks, start-to-start dependencies let
+// them run in parallel. Ties are broken by the title of the first task.
+func criticalPath(tasks []*types.Task) []analysis.TaskRef {
+	byID := make(map[uuid.UUID]*types.Task, len(tasks))
+	for _, task := range tasks {
+		if isOpen(task) {
+			byID[task.ID] = task
+		}
+	}
+
+	dependents := make(map[uuid.UUID][]*types.Task)
+	for _, task := range sortedByTitle(tasks) {
+		if !isOpen(task) {
+			continue
+		}
+		for _, depID := range task.Dependencies {
+			if task.DependencyLink(depID).Type == types.DependencyStartToStart {
+				continue
+			}
+			if _, open := byID[depID]; open {
+				dependents[depID] = append(dependents[depID], task)
+			}
+		}
+	}
+
+	longest := make(map[uuid.UUID][]*types.Task)
+	visiting := make(map[uuid.UUID]bool)
+	var walk func(task *types.Task) []*types.Task
+	walk = func(task *types.Task) []*types.Task {
+		if path, done := longest[task.ID]; done {
+			return path
+		}
+		if visiting[task.ID] {
+			return nil // Dependency cycle
+		}
+		visiting[task.ID] = true
+		defer delete(visiting, task.ID)
+
+		var tail []*types.Task
+		for _, dependent := range dependents[task.ID] {
+			if path := walk(dependent); len(path) > len(tail) {
+				tail = path
+			}
+		}
+		path := append([]*types.Task{task}, tail...)
+		longest[task.ID] = path
+		return path
+	}
+
+	var best []*types.Task
+	for _, task := range sortedByTitle(tasks) {
+		if !isOpen(task) {
+			continue
+		}
+		if path := walk(task); len(path) > len(best) {
+			best = path
+		}
+	}
+
+	refs := make([]analysis.TaskRef, len(best))
+	for i, task := range best {
+		refs[i] = refOf(task)
+	}
+	return refs
+}
+
+func isOpen(task *types.Task) bool {
+	switch task.State {
+	case types.TaskStatePending, types.TaskStateInProgress, types.TaskStateBlocked:
+		return true
+	default:
+		return false
+	}
+}
+
+func refOf(task *types.Task) analysis.TaskRef {
+	return analysis.TaskRef{TaskID: task.ID, Title: task.Title, State: task.State}
+}
+
+// sortedByTitle returns a copy of tasks ordered by title and ID, so results are stable
+func sortedByTitle(tasks []*types.Task) []*types.Task {
+	sorted := make([]*types.Task, len(tasks))
+	copy(sorted, tasks)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Title != sorted[j].Title {
+			return sorted[i].Title < sorted[j].Title
+		}
+		return sorted[i].ID.String() < sorted[j].ID.String()
+	})
+	return sorted
+}
@@ -0,0 +1,122 @@
+package simulation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/analysis"
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/testutil"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTask(t *testing.T, mgr manager.ProjectManager, project *types.Project, parent *types.Task, title string, dependsOn ...*types.Task) *types.Task {
+	ctx := context.Background()
+	var parentID *uuid.UUID
+	if parent != nil {
+		parentID = &parent.ID
+	}
+	task, err := mgr.CreateTask(ctx, project.ID, parentID, title, "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	for _, dep := range dependsOn {
+		_, err := mgr.AddTaskDependency(ctx, task.ID, dep.ID, "test-user")
+		require.NoError(t, err)
+	}
+	return task
+}
+
+func titles(refs []analysis.TaskRef) []string {
+	result := make([]string, len(refs))
+	for i, ref := range refs {
+		result[i] = ref.Title
+	}
+	return result
+}
+
+func TestSimulateCompletion(t *testing.T) {
+	ctx := context.Background()
+	mgr := testutil.NewTestConfig(t).SetupTestManager(t)
+	project := testutil.CreateTestProject(t, mgr)
+
+	design := createTask(t, mgr, project, nil, "Design")
+	implement := createTask(t, mgr, project, nil, "Implement", design)
+	createTask(t, mgr, project, nil, "Release", implement)
+	docs := createTask(t, mgr, project, nil, "Docs")
+
+	t.Run("reports unblocked tasks, progress and critical path", func(t *testing.T) {
+		result, err := SimulateCompletion(ctx, mgr, project.ID, design.ID, "test-user")
+		require.NoError(t, err)
+
+		assert.True(t, result.OnCriticalPath)
+		assert.Equal(t, []string{"Implement"}, titles(result.NewlyActionable))
+		assert.Empty(t, result.AlsoCompleted)
+
+		assert.Equal(t, 0, result.Before.Completed)
+		assert.Equal(t, 1, result.After.Completed)
+		assert.InDelta(t, 25.0, result.After.Progress, 0.01)
+		assert.Equal(t, []string{"Design", "Docs"}, titles(result.Before.Actionable))
+		assert.Equal(t, []string{"Docs", "Implement"}, titles(result.After.Actionable))
+		assert.Equal(t, []string{"Design", "Implement", "Release"}, titles(result.Before.CriticalPath))
+		assert.Equal(t, []string{"Implement", "Release"}, titles(result.After.CriticalPath))
+	})
+
+	t.Run("does not persist", func(t *testing.T) {
+		result, err := SimulateCompletion(ctx, mgr, project.ID, docs.ID, "test-user")
+		require.NoError(t, err)
+		assert.False(t, result.OnCriticalPath)
+		assert.Empty(t, result.NewlyActionable)
+		assert.Equal(t, result.Before.CriticalPath, result.After.CriticalPath)
+
+		for _, id := range []*types.Task{design, docs} {
+			task, err := mgr.GetTask(ctx, id.ID)
+			require.NoError(t, err)
+			assert.Equal(t, types.TaskStatePending, task.State)
+		}
+	})
+
+	t.Run("reports automatically completed parents", func(t *testing.T) {
+		epic := createTask(t, mgr, project, nil, "Epic")
+		child := createTask(t, mgr, project, epic, "Child")
+
+		result, err := SimulateCompletion(ctx, mgr, project.ID, child.ID, "test-user")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Epic"}, titles(result.AlsoCompleted))
+		assert.Equal(t, result.Before.Completed+2, result.After.Completed)
+	})
+
+	t.Run("rejects closed tasks and foreign projects", func(t *testing.T) {
+		_, err := mgr.UpdateTaskState(ctx, docs.ID, types.TaskStateInProgress, "test-user")
+		require.NoError(t, err)
+		_, err = mgr.UpdateTaskState(ctx, docs.ID, types.TaskStateCompleted, "test-user")
+		require.NoError(t, err)
+
+		_, err = SimulateCompletion(ctx, mgr, project.ID, docs.ID, "test-user")
+		assert.ErrorContains(t, err, "already completed")
+
+		other, err := mgr.CreateProject(ctx, "Other", "", "test-user")
+		require.NoError(t, err)
+		_, err = SimulateCompletion(ctx, mgr, other.ID, design.ID, "test-user")
+		assert.ErrorContains(t, err, "does not belong to the project")
+	})
+
+	t.Run("start-to-start dependencies do not chain the critical path", func(t *testing.T) {
+		a := &types.Task{ID: uuid.New(), Title: "A", State: types.TaskStatePending}
+		b := &types.Task{ID: uuid.New(), Title: "B", State: types.TaskStatePending, Dependencies: []uuid.UUID{a.ID}}
+		c := &types.Task{ID: uuid.New(), Title: "C", State: types.TaskStatePending, Dependencies: []uuid.UUID{b.ID}}
+		assert.Equal(t, []string{"A", "B", "C"}, titles(Summarize([]*types.Task{a, b, c}).CriticalPath))
+
+		b.DependencyLinks = []types.DependencyLink{{DependsOnID: a.ID, Type: types.DependencyStartToStart}}
+		assert.Equal(t, []string{"B", "C"}, titles(Summarize([]*types.Task{a, b, c}).CriticalPath))
+	})
+
+	t.Run("applies completion rules", func(t *testing.T) {
+		_, err := mgr.AddAcceptanceCriterion(ctx, design.ID, "Reviewed by team", "test-user")
+		require.NoError(t, err)
+
+		_, err = SimulateCompletion(ctx, mgr, project.ID, design.ID, "test-user")
+		assert.ErrorContains(t, err, "acceptance criteria")
+	})
+}
@@ -0,0 +1,164 @@
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/denkhaus/knot/v2/internal/utils"
+	"github.com/google/uuid"
+)
+
+// ChangeKind identifies how a task differs between two snapshots
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// TaskChange is a single entry of a snapshot diff
+type TaskChange struct {
+	Kind    ChangeKind `json:"kind"`
+	TaskID  uuid.UUID  `json:"task_id"`
+	Title   string     `json:"title"`
+	Details []string   `json:"details,omitempty"`
+}
+
+// Diff lists the tasks added, removed and changed between two snapshots
+type Diff struct {
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	Added   int          `json:"added"`
+	Removed int          `json:"removed"`
+	Changed int          `json:"changed"`
+	Changes []TaskChange `json:"changes"`
+}
+
+// IsEmpty reports whether the snapshots are equal
+func (d *Diff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// Compare returns the changes from one snapshot to another of the same project.
+// Added and changed tasks are listed in the order of the newer snapshot,
+// followed by the removed tasks.
+func Compare(from, to *Snapshot) (*Diff, error) {
+	if from.Project.ID != to.Project.ID {
+		return nil, fmt.Errorf("snapshots '%s' and '%s' belong to different projects (%s, %s)",
+			from.Label, to.Label, from.Project.Title, to.Project.Title)
+	}
+
+	diff := &Diff{From: from.Label, To: to.Label, Changes: []TaskChange{}}
+	before := indexTasks(from.Tasks)
+	after := indexTasks(to.Tasks)
+
+	for _, task := range to.Tasks {
+		old, existed := before[task.ID]
+		if !existed {
+			diff.Added++
+			diff.Changes = append(diff.Changes, TaskChange{
+				Kind:    ChangeAdded,
+				TaskID:  task.ID,
+				Title:   task.Title,
+				Details: []string{fmt.Sprintf("state: %s, complexity: %d", task.State, task.Complexity)},
+			})
+			continue
+		}
+		if details := compareTasks(old, task, before, after); len(details) > 0 {
+			diff.Changed++
+			diff.Changes = append(diff.Changes, TaskChange{Kind: ChangeChanged, TaskID: task.ID, Title: task.Title, Details: details})
+		}
+	}
+
+	for _, task := range from.Tasks {
+		if _, exists := after[task.ID]; !exists {
+			diff.Removed++
+			diff.Changes = append(diff.Changes, TaskChange{Kind: ChangeRemoved, TaskID: task.ID, Title: task.Title})
+		}
+	}
+
+	return diff, nil
+}
+
+func compareTasks(old, task *types.Task, before, after map[uuid.UUID]*types.Task) []string {
+	var details []string
+	if old.Title != task.Title {
+		details = append(details, fmt.Sprintf("title: %q -> %q", old.Title, task.Title))
+	}
+	if old.Description != task.Description {
+		details = append(details, "description changed")
+	}
+	if old.State != task.State {
+		details = append(details, fmt.Sprintf("state: %s -> %s", old.State, task.State))
+	}
+	if old.Priority != task.Priority {
+		details = append(details, fmt.Sprintf("priority: %s -> %s", old.Priority.ToExternalString(), task.Priority.ToExternalString()))
+	}
+	if old.Complexity != task.Complexity {
+		details = append(details, fmt.Sprintf("complexity: %d -> %d", old.Complexity, task.Complexity))
+	}
+	if oldEstimate, estimate := utils.FormatEstimatePtr(old.Estimate), utils.FormatEstimatePtr(task.Estimate); oldEstimate != estimate {
+		details = append(details, fmt.Sprintf("estimate: %s -> %s", oldEstimate, estimate))
+	}
+	if oldParent, parent := parentTitle(old, before), parentTitle(task, after); oldParent != parent {
+		details = append(details, fmt.Sprintf("parent: %s -> %s", oldParent, parent))
+	}
+
+	removed, added := diffIDs(old.Dependencies, task.Dependencies)
+	for _, id := range added {
+		details = append(details, "+dependency: "+taskTitle(id, after))
+	}
+	for _, id := range removed {
+		details = append(details, "-dependency: "+taskTitle(id, before))
+	}
+
+	if oldTags, tags := strings.Join(old.Tags, ","), strings.Join(task.Tags, ","); oldTags != tags {
+		details = append(details, fmt.Sprintf("tags: [%s] -> [%s]", oldTags, tags))
+	}
+	return details
+}
+
+func indexTasks(tasks []*types.Task) map[uuid.UUID]*types.Task {
+	index := make(map[uuid.UUID]*types.Task, len(tasks))
+	for _, task := range tasks {
+		index[task.ID] = task
+	}
+	return index
+}
+
+func parentTitle(task *types.Task, tasks map[uuid.UUID]*types.Task) string {
+	if task.ParentID == nil {
+		return "(root)"
+	}
+	return taskTitle(*task.ParentID, tasks)
+}
+
+func taskTitle(id uuid.UUID, tasks map[uuid.UUID]*types.Task) string {
+	if task, ok := tasks[id]; ok {
+		return fmt.Sprintf("%q", task.Title)
+	}
+	return id.String()
+}
+
+// diffIDs returns the IDs only in before and the IDs only in after
+func diffIDs(before, after []uuid.UUID) (removed, added []uuid.UUID) {
+	inBefore := make(map[uuid.UUID]bool, len(before))
+	for _, id := range before {
+		inBefore[id] = true
+	}
+	inAfter := make(map[uuid.UUID]bool, len(after))
+	for _, id := range after {
+		inAfter[id] = true
+		if !inBefore[id] {
+			added = append(added, id)
+		}
+	}
+	for _, id := range before {
+		if !inAfter[id] {
+			removed = append(removed, id)
+		}
+	}
+	return removed, added
+}
@@ -0,0 +1,220 @@
+// Package snapshot captures the full state of a project as labeled JSON files
+// below .knot/snapshots and compares snapshots with each other or with the
+// current project state, e.g. to audit what an agent session changed.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+)
+
+// FormatVersion is the version of the snapshot file format
+const FormatVersion = 1
+
+var labelPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Snapshot is the captured state of a project with all its tasks
+type Snapshot struct {
+	Version   int            `json:"version"`
+	Label     string         `json:"label"`
+	CreatedAt time.Time      `json:"created_at"`
+	CreatedBy string         `json:"created_by,omitempty"`
+	Project   *types.Project `json:"project"`
+	Tasks     []*types.Task  `json:"tasks"`
+}
+
+// Info describes a stored snapshot without its tasks
+type Info struct {
+	Label        string    `json:"label"`
+	CreatedAt    time.Time `json:"created_at"`
+	CreatedBy    string    `json:"created_by,omitempty"`
+	ProjectID    uuid.UUID `json:"project_id"`
+	ProjectTitle string    `json:"project_title"`
+	Tasks        int       `json:"tasks"`
+}
+
+// Capture reads the current state of a project. The label is not validated.
+func Capture(ctx context.Context, pm manager.ProjectManager, projectID uuid.UUID, label, actor string) (*Snapshot, error) {
+	project, err := pm.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	current, err := pm.ListTasksWithDependencies(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	// Copy the tasks, repositories may hand out their live instances
+	tasks := make([]*types.Task, len(current))
+	for i, task := range current {
+		copied := *task
+		copied.Dependencies = append([]uuid.UUID(nil), task.Dependencies...)
+		copied.DependencyLinks = append([]types.DependencyLink(nil), task.DependencyLinks...)
+		tasks[i] = &copied
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return types.CompareCreation(tasks[i], tasks[j]) < 0
+	})
+
+	return &Snapshot{
+		Version:   FormatVersion,
+		Label:     label,
+		CreatedAt: pm.GetCurrentTime(),
+		CreatedBy: actor,
+		Project:   project,
+		Tasks:     tasks,
+	}, nil
+}
+
+// Info returns the summary of the snapshot
+func (s *Snapshot) Info() Info {
+	return Info{
+		Label:        s.Label,
+		CreatedAt:    s.CreatedAt,
+		CreatedBy:    s.CreatedBy,
+		ProjectID:    s.Project.ID,
+		ProjectTitle: s.Project.Title,
+		Tasks:        len(s.Tasks),
+	}
+}
+
+// ValidateLabel checks that a label can be used as a snapshot file name
+func ValidateLabel(label string) error {
+	if !labelPattern.MatchString(label) {
+		return fmt.Errorf("invalid snapshot label '%s': use letters, digits, '.', '_' and '-' only", label)
+	}
+	return nil
+}
+
+// DefaultLabel returns a label derived from the time, used when none is given
+func DefaultLabel(now time.Time) string {
+	return now.UTC().Format("20060102-150405")
+}
+
+// Store reads and writes snapshot files in a directory
+type Store struct {
+	Dir string
+}
+
+// DefaultDir returns the snapshot directory of the .knot directory in the
+// current working directory
+func DefaultDir() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current working directory: %w", err)
+	}
+	return filepath.Join(cwd, ".knot", "snapshots"), nil
+}
+
+// NewStore returns a store for the default snapshot directory
+func NewStore() (*Store, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Store{Dir: dir}, nil
+}
+
+func (s *Store) path(label string) string {
+	return filepath.Join(s.Dir, label+".json")
+}
+
+// Exists reports whether a snapshot with the label is stored
+func (s *Store) Exists(label string) bool {
+	_, err := os.Stat(s.path(label))
+	return err == nil
+}
+
+// Save writes the snapshot, replacing a stored snapshot with the same label
+func (s *Store) Save(snap *Snapshot) error {
+	if err := ValidateLabel(snap.Label); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if err := os.WriteFile(s.path(snap.Label), data, 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load reads the snapshot with the label
+func (s *Store) Load(label string) (*Snapshot, error) {
+	if err := ValidateLabel(label); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(s.path(label))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("snapshot '%s' not found", label)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot '%s': %w", label, err)
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot '%s': %w", label, err)
+	}
+	if snap.Version > FormatVersion {
+		return nil, fmt.Errorf("snapshot '%s' has unsupported version %d", label, snap.Version)
+	}
+	if snap.Project == nil {
+		return nil, fmt.Errorf("snapshot '%s' has no project", label)
+	}
+	return &snap, nil
+}
+
+// List returns the stored snapshots, oldest first
+func (s *Store) List() ([]Info, error) {
+	files, err := filepath.Glob(filepath.Join(s.Dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	infos := make([]Info, 0, len(files))
+	for _, file := range files {
+		snap, err := s.Load(strings.TrimSuffix(filepath.Base(file), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, snap.Info())
+	}
+
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].CreatedAt.Before(infos[j].CreatedAt)
+	})
+	return infos, nil
+}
+
+// Delete removes the snapshot with the label
+func (s *Store) Delete(label string) error {
+	if err := ValidateLabel(label); err != nil {
+		return err
+	}
+	if err := os.Remove(s.path(label)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("snapshot '%s' not found", label)
+		}
+		return fmt.Errorf("failed to delete snapshot '%s': %w", label, err)
+	}
+	return nil
+}
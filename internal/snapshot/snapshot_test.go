@@ -0,0 +1,104 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotStore(t *testing.T) {
+	ctx := context.Background()
+	pm := manager.NewManagerWithRepository(inmemory.NewMemoryRepository(), manager.DefaultConfig())
+	project, err := pm.CreateProject(ctx, "Snapshot Project", "", "test-user")
+	require.NoError(t, err)
+	_, err = pm.CreateTask(ctx, project.ID, nil, "Task", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+
+	store := &Store{Dir: t.TempDir()}
+	infos, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, infos)
+
+	snap, err := Capture(ctx, pm, project.ID, "before", "agent")
+	require.NoError(t, err)
+	require.NoError(t, store.Save(snap))
+	assert.True(t, store.Exists("before"))
+
+	loaded, err := store.Load("before")
+	require.NoError(t, err)
+	assert.Equal(t, project.ID, loaded.Project.ID)
+	require.Len(t, loaded.Tasks, 1)
+	assert.Equal(t, "Task", loaded.Tasks[0].Title)
+
+	infos, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, Info{Label: "before", CreatedAt: loaded.CreatedAt, CreatedBy: "agent", ProjectID: project.ID, ProjectTitle: "Snapshot Project", Tasks: 1}, infos[0])
+
+	assert.Error(t, store.Save(&Snapshot{Label: "../escape", Project: project}))
+	_, err = store.Load("missing")
+	assert.Error(t, err)
+
+	require.NoError(t, store.Delete("before"))
+	assert.False(t, store.Exists("before"))
+	assert.Error(t, store.Delete("before"))
+}
+
+func TestCompare(t *testing.T) {
+	ctx := context.Background()
+	pm := manager.NewManagerWithRepository(inmemory.NewMemoryRepository(), manager.DefaultConfig())
+	project, err := pm.CreateProject(ctx, "Diff Project", "", "test-user")
+	require.NoError(t, err)
+	design, err := pm.CreateTask(ctx, project.ID, nil, "Design", "", 3, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	build, err := pm.CreateTask(ctx, project.ID, nil, "Build", "", 5, types.TaskPriorityMedium, "test-user")
+	require.NoError(t, err)
+	obsolete, err := pm.CreateTask(ctx, project.ID, nil, "Obsolete", "", 2, types.TaskPriorityLow, "test-user")
+	require.NoError(t, err)
+
+	before, err := Capture(ctx, pm, project.ID, "before", "")
+	require.NoError(t, err)
+
+	_, err = pm.UpdateTaskState(ctx, design.ID, types.TaskStateInProgress, "agent")
+	require.NoError(t, err)
+	_, err = pm.AddTaskDependency(ctx, build.ID, design.ID, "agent")
+	require.NoError(t, err)
+	require.NoError(t, pm.DeleteTask(ctx, obsolete.ID, "agent"))
+	_, err = pm.CreateTask(ctx, project.ID, nil, "Test", "", 4, types.TaskPriorityMedium, "agent")
+	require.NoError(t, err)
+
+	after, err := Capture(ctx, pm, project.ID, "after", "")
+	require.NoError(t, err)
+
+	diff, err := Compare(before, after)
+	require.NoError(t, err)
+	assert.Equal(t, 1, diff.Added)
+	assert.Equal(t, 1, diff.Removed)
+	assert.Equal(t, 2, diff.Changed)
+
+	changes := map[string]TaskChange{}
+	for _, change := range diff.Changes {
+		changes[change.Title] = change
+	}
+	assert.Equal(t, ChangeAdded, changes["Test"].Kind)
+	assert.Equal(t, ChangeRemoved, changes["Obsolete"].Kind)
+	assert.Equal(t, []string{"state: pending -> in-progress"}, changes["Design"].Details)
+	assert.Equal(t, []string{`+dependency: "Design"`}, changes["Build"].Details)
+	assert.Equal(t, ChangeRemoved, diff.Changes[len(diff.Changes)-1].Kind)
+
+	same, err := Compare(after, after)
+	require.NoError(t, err)
+	assert.True(t, same.IsEmpty())
+
+	other, err := pm.CreateProject(ctx, "Other", "", "test-user")
+	require.NoError(t, err)
+	otherSnap, err := Capture(ctx, pm, other.ID, "other", "")
+	require.NoError(t, err)
+	_, err = Compare(before, otherSnap)
+	assert.Error(t, err)
+}
@@ -73,7 +73,7 @@ func AutoSeedTemplates() error {
 	}
 
 	seededCount := len(metadata.SeededTemplates)
-	fmt.Printf("Auto-seeded %d built-in templates to .knot/templates/\n", seededCount)
+	fmt.Fprintf(os.Stderr, "Auto-seeded %d built-in templates to .knot/templates/\n", seededCount)
 	return nil
 }
 
@@ -189,9 +189,9 @@ func CheckAndSeedIfNeeded() error {
 
 			// Update metadata
 			if err := UpdateSeededTemplate(template); err != nil {
-				fmt.Printf("Warning: Failed to update metadata for template '%s': %v\n", template.Name, err)
+				fmt.Fprintf(os.Stderr, "Warning: Failed to update metadata for template '%s': %v\n", template.Name, err)
 			} else {
-				fmt.Printf("Auto-seeded new template: %s\n", template.Name)
+				fmt.Fprintf(os.Stderr, "Auto-seeded new template: %s\n", template.Name)
 				newTemplatesFound = true
 			}
 		}
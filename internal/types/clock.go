@@ -0,0 +1,18 @@
+package types
+
+import "time"
+
+// Clock tells the current time. Repositories that implement Clock, such as
+// the in-memory repository with a fake clock in tests, also set the time of
+// the manager working on them.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the wall clock
+type SystemClock struct{}
+
+// Now returns the current local time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
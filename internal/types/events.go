@@ -0,0 +1,113 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChangeEventKind identifies the mutation recorded by a change event
+type ChangeEventKind string
+
+const (
+	ChangeProjectCreated    ChangeEventKind = "project.created"
+	ChangeProjectUpdated    ChangeEventKind = "project.updated"
+	ChangeProjectDeleted    ChangeEventKind = "project.deleted"
+	ChangeTaskCreated       ChangeEventKind = "task.created"
+	ChangeTaskUpdated       ChangeEventKind = "task.updated"
+	ChangeTaskDeleted       ChangeEventKind = "task.deleted"
+	ChangeDependencyAdded   ChangeEventKind = "dependency.added"
+	ChangeDependencyRemoved ChangeEventKind = "dependency.removed"
+	ChangeDependencyUpdated ChangeEventKind = "dependency.updated"
+)
+
+// ChangeEvent is one entry of the change feed. Seq increases strictly with
+// every recorded mutation, so consumers can resume after the last seen Seq.
+type ChangeEvent struct {
+	Seq         int64           `json:"seq"`
+	Kind        ChangeEventKind `json:"kind"`
+	ProjectID   uuid.UUID       `json:"project_id"`
+	TaskID      *uuid.UUID      `json:"task_id,omitempty"`
+	DependsOnID *uuid.UUID      `json:"depends_on_task_id,omitempty"`
+	Actor       string          `json:"actor,omitempty"`
+	// Data is the JSON snapshot of the project or task after the change,
+	// empty for deletions and dependency events
+	Data      json.RawMessage `json:"data,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ChangeEventFilter selects events from the change feed
+type ChangeEventFilter struct {
+	// ProjectID restricts the events to one project when set
+	ProjectID *uuid.UUID
+	// AfterSeq returns only events with a greater sequence number
+	AfterSeq int64
+	// Limit caps the number of events returned, 0 means no limit
+	Limit int
+	// Descending returns the newest events first, e.g. to read the latest N
+	Descending bool
+}
+
+// ChangeFeed is implemented by repositories that record a change feed of
+// project and task mutations
+type ChangeFeed interface {
+	ListChangeEvents(ctx context.Context, filter ChangeEventFilter) ([]*ChangeEvent, error)
+}
+
+// NewProjectEvent creates a change event for a project mutation
+func NewProjectEvent(kind ChangeEventKind, project *Project) *ChangeEvent {
+	event := &ChangeEvent{
+		Kind:      kind,
+		ProjectID: project.ID,
+		Actor:     project.UpdatedBy,
+		CreatedAt: time.Now(),
+	}
+	if event.Actor == "" {
+		event.Actor = project.CreatedBy
+	}
+	if kind != ChangeProjectDeleted {
+		event.Data, _ = json.Marshal(project)
+	}
+	return event
+}
+
+// NewTaskEvent creates a change event for a task mutation
+func NewTaskEvent(kind ChangeEventKind, task *Task) *ChangeEvent {
+	taskID := task.ID
+	event := &ChangeEvent{
+		Kind:      kind,
+		ProjectID: task.ProjectID,
+		TaskID:    &taskID,
+		Actor:     task.UpdatedBy,
+		CreatedAt: time.Now(),
+	}
+	if event.Actor == "" {
+		event.Actor = task.CreatedBy
+	}
+	if kind != ChangeTaskDeleted {
+		event.Data, _ = json.Marshal(task)
+	}
+	return event
+}
+
+// NewDependencyEvent creates a change event for an added, updated or removed dependency
+func NewDependencyEvent(kind ChangeEventKind, projectID, taskID, dependsOnID uuid.UUID) *ChangeEvent {
+	return &ChangeEvent{
+		Kind:        kind,
+		ProjectID:   projectID,
+		TaskID:      &taskID,
+		DependsOnID: &dependsOnID,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// MatchesChangeEventFilter reports whether the event is selected by the filter,
+// ignoring the limit
+func MatchesChangeEventFilter(event *ChangeEvent, filter ChangeEventFilter) bool {
+	if event.Seq <= filter.AfterSeq {
+		return false
+	}
+	return filter.ProjectID == nil || event.ProjectID == *filter.ProjectID
+}
@@ -0,0 +1,22 @@
+package types
+
+import "github.com/google/uuid"
+
+// NewID returns a new ID for a project, task or other stored entity. IDs are
+// UUIDv7: they start with the creation time in milliseconds and increase
+// monotonically within a process, also across goroutines, so sorting by ID
+// follows creation order where timestamps tie.
+func NewID() uuid.UUID {
+	return uuid.Must(uuid.NewV7())
+}
+
+// ShortIDLength is the number of characters of a short ID
+const ShortIDLength = 8
+
+// ShortID returns the short form of an ID for dense listings: its last
+// characters. The leading characters of a UUIDv7 are the creation time, which
+// entities created together share, while the tail is random.
+func ShortID(id uuid.UUID) string {
+	s := id.String()
+	return s[len(s)-ShortIDLength:]
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIDFollowsCreationOrder(t *testing.T) {
+	previous := NewID()
+	assert.Equal(t, uuid.Version(7), previous.Version())
+	for i := 0; i < 1000; i++ {
+		id := NewID()
+		require.Positive(t, bytes.Compare(id[:], previous[:]), "IDs must increase")
+		previous = id
+	}
+}
+
+func TestNewIDIsParallelSafe(t *testing.T) {
+	const workers, perWorker = 8, 500
+	ids := make(chan uuid.UUID, workers*perWorker)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				ids <- NewID()
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[uuid.UUID]bool, workers*perWorker)
+	for id := range ids {
+		assert.False(t, seen[id], "duplicate ID %s", id)
+		seen[id] = true
+	}
+	assert.Len(t, seen, workers*perWorker)
+}
+
+func TestShortIDDiffersWithinMillisecond(t *testing.T) {
+	assert.Equal(t, "9c2e41d7", ShortID(uuid.MustParse("01a143c2-5a8b-7000-8000-00009c2e41d7")))
+
+	// IDs created in a burst share the timestamp, but not the random tail
+	seen := make(map[string]uuid.UUID)
+	for i := 0; i < 200; i++ {
+		id := NewID()
+		short := ShortID(id)
+		require.NotContains(t, seen, short, "%s and %s share a short ID", seen[short], id)
+		seen[short] = id
+	}
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectLock is an advisory lock giving one actor exclusive write access to
+// a project until it is released or expires
+type ProjectLock struct {
+	ProjectID  uuid.UUID `json:"project_id"`
+	Owner      string    `json:"owner"`
+	Reason     string    `json:"reason,omitempty"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// Expired reports whether the lock no longer applies at now
+func (l *ProjectLock) Expired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
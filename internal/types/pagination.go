@@ -0,0 +1,135 @@
+package types
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultPageSize is the number of items per page if a request sets no limit
+	DefaultPageSize = 100
+	// MaxPageSize caps the number of items per page
+	MaxPageSize = 1000
+)
+
+// PageRequest selects one page of a keyset-paginated listing
+type PageRequest struct {
+	// Cursor is the next_cursor of the previous page, empty for the first page
+	Cursor string `json:"cursor,omitempty"`
+	// Limit is the maximum number of items on the page
+	Limit int `json:"limit,omitempty"`
+}
+
+// Size returns the page size, DefaultPageSize if no limit is set
+func (p PageRequest) Size() int {
+	if p.Limit <= 0 {
+		return DefaultPageSize
+	}
+	return p.Limit
+}
+
+// TaskPage is one page of tasks in creation order
+type TaskPage struct {
+	Tasks []*Task `json:"tasks"`
+	// NextCursor continues the listing after this page, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PageCursor is the position of a page in creation order: the creation time
+// and ID of the last task of the previous page. Cursors stay valid while
+// tasks are created or deleted, so pages neither skip nor repeat tasks.
+type PageCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// NewPageCursor returns the cursor continuing after task
+func NewPageCursor(task *Task) PageCursor {
+	return PageCursor{CreatedAt: task.CreatedAt.UTC(), ID: task.ID}
+}
+
+// Encode returns the opaque string form of the cursor
+func (c PageCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodePageCursor parses a cursor returned as next_cursor
+func DecodePageCursor(cursor string) (PageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return PageCursor{}, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return PageCursor{}, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	result := PageCursor{}
+	if result.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+		return PageCursor{}, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	if result.ID, err = uuid.Parse(id); err != nil {
+		return PageCursor{}, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return result, nil
+}
+
+// Before reports whether task comes after the cursor position
+func (c PageCursor) Before(task *Task) bool {
+	if !task.CreatedAt.Equal(c.CreatedAt) {
+		return task.CreatedAt.After(c.CreatedAt)
+	}
+	return bytes.Compare(task.ID[:], c.ID[:]) > 0
+}
+
+// CompareCreation orders tasks by creation time and then by ID, the order of
+// paginated listings and creation sorts. IDs from NewID increase with
+// creation, so tasks created within the same instant keep their order too.
+func CompareCreation(a, b *Task) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.ID[:], b.ID[:])
+}
+
+// PaginateTasks returns the page of tasks selected by page, for repositories
+// that hold all matching tasks in memory. The tasks are sorted in place.
+func PaginateTasks(tasks []*Task, page PageRequest) (*TaskPage, error) {
+	slices.SortFunc(tasks, CompareCreation)
+
+	if page.Cursor != "" {
+		cursor, err := DecodePageCursor(page.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		start, _ := slices.BinarySearchFunc(tasks, cursor, func(task *Task, c PageCursor) int {
+			if c.Before(task) {
+				return 1
+			}
+			return -1
+		})
+		tasks = tasks[start:]
+	}
+
+	return NewTaskPage(tasks, page.Size()), nil
+}
+
+// NewTaskPage builds a page from up to size+1 tasks in creation order. The
+// extra task only tells that another page follows.
+func NewTaskPage(tasks []*Task, size int) *TaskPage {
+	result := &TaskPage{Tasks: tasks}
+	if len(tasks) > size {
+		result.Tasks = tasks[:size]
+		result.NextCursor = NewPageCursor(tasks[size-1]).Encode()
+	}
+	if result.Tasks == nil {
+		result.Tasks = []*Task{}
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageCursorRoundTrip(t *testing.T) {
+	cursor := PageCursor{
+		CreatedAt: time.Date(2026, 10, 16, 9, 30, 0, 123456789, time.UTC),
+		ID:        uuid.MustParse("8f72ab6b-1fbf-495f-839c-921ed1c4a0b2"),
+	}
+
+	decoded, err := DecodePageCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+
+	for _, invalid := range []string{"bogus", "bm8tc2VwYXJhdG9y", "eHx5"} {
+		_, err := DecodePageCursor(invalid)
+		assert.ErrorContains(t, err, "invalid cursor", invalid)
+	}
+}
+
+func TestPaginateTasks(t *testing.T) {
+	created := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	var tasks []*Task
+	for i := 0; i < 5; i++ {
+		// Pairs of tasks share a creation time, the ID breaks the tie
+		tasks = append(tasks, &Task{ID: uuid.New(), CreatedAt: created.Add(time.Duration(i/2) * time.Second)})
+	}
+
+	var walked []*Task
+	page := PageRequest{Limit: 2}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5, "pagination must terminate")
+		result, err := PaginateTasks(append([]*Task(nil), tasks...), page)
+		require.NoError(t, err)
+		walked = append(walked, result.Tasks...)
+		if result.NextCursor == "" {
+			break
+		}
+		page.Cursor = result.NextCursor
+	}
+
+	require.Len(t, walked, len(tasks))
+	for i := 1; i < len(walked); i++ {
+		assert.Negative(t, CompareCreation(walked[i-1], walked[i]))
+	}
+
+	empty, err := PaginateTasks(nil, PageRequest{})
+	require.NoError(t, err)
+	assert.NotNil(t, empty.Tasks)
+	assert.Empty(t, empty.NextCursor)
+
+	_, err = PaginateTasks(tasks, PageRequest{Cursor: "bogus"})
+	assert.Error(t, err)
+}
@@ -2,6 +2,11 @@ package types
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -42,6 +47,109 @@ type Variable struct {
 	Required     bool     `json:"required" yaml:"required"`                               // Whether variable is required
 	DefaultValue *string  `json:"default_value,omitempty" yaml:"default_value,omitempty"` // Default value if not provided
 	Options      []string `json:"options,omitempty" yaml:"options,omitempty"`             // Valid options for choice type
+	Pattern      string   `json:"pattern,omitempty" yaml:"pattern,omitempty"`             // Regular expression string values must match
+	Min          *int     `json:"min,omitempty" yaml:"min,omitempty"`                     // Minimum value for int type
+	Max          *int     `json:"max,omitempty" yaml:"max,omitempty"`                     // Maximum value for int type
+}
+
+// boolValues are the accepted values of bool variables
+var boolValues = []string{"true", "false", "yes", "no", "1", "0"}
+
+// Check reports whether the variable definition is consistent: choice
+// variables need options, patterns must compile, ranges must not be empty and
+// a default value must itself be valid
+func (v Variable) Check() error {
+	switch v.Type {
+	case VarTypeString, VarTypeInt, VarTypeBool, VarTypeChoice:
+	default:
+		return fmt.Errorf("variable %s has unknown type %q", v.Name, v.Type)
+	}
+	if v.Type == VarTypeChoice && len(v.Options) == 0 {
+		return fmt.Errorf("choice variable %s must have options", v.Name)
+	}
+	if v.Pattern != "" {
+		if v.Type != VarTypeString {
+			return fmt.Errorf("variable %s: pattern is only supported for string variables", v.Name)
+		}
+		if _, err := regexp.Compile(v.Pattern); err != nil {
+			return fmt.Errorf("variable %s has an invalid pattern: %w", v.Name, err)
+		}
+	}
+	if v.Min != nil || v.Max != nil {
+		if v.Type != VarTypeInt {
+			return fmt.Errorf("variable %s: min and max are only supported for int variables", v.Name)
+		}
+		if v.Min != nil && v.Max != nil && *v.Min > *v.Max {
+			return fmt.Errorf("variable %s: min %d is greater than max %d", v.Name, *v.Min, *v.Max)
+		}
+	}
+	if v.DefaultValue != nil {
+		if err := v.Validate(*v.DefaultValue); err != nil {
+			return fmt.Errorf("invalid default value: %w", err)
+		}
+	}
+	return nil
+}
+
+// Validate checks a value against the variable's type, options, pattern and range
+func (v Variable) Validate(value string) error {
+	switch v.Type {
+	case VarTypeInt:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("variable '%s' must be an integer, got %q", v.Name, value)
+		}
+		if v.Min != nil && n < *v.Min {
+			return fmt.Errorf("variable '%s' must be at least %d, got %d", v.Name, *v.Min, n)
+		}
+		if v.Max != nil && n > *v.Max {
+			return fmt.Errorf("variable '%s' must be at most %d, got %d", v.Name, *v.Max, n)
+		}
+	case VarTypeBool:
+		if !slices.Contains(boolValues, value) {
+			return fmt.Errorf("variable '%s' must be one of: %s", v.Name, strings.Join(boolValues, ", "))
+		}
+	case VarTypeChoice:
+		if !slices.Contains(v.Options, value) {
+			return fmt.Errorf("variable '%s' must be one of: %s", v.Name, strings.Join(v.Options, ", "))
+		}
+	default:
+		if v.Pattern != "" {
+			re, err := regexp.Compile(v.Pattern)
+			if err != nil {
+				return fmt.Errorf("variable '%s' has an invalid pattern: %w", v.Name, err)
+			}
+			if !re.MatchString(value) {
+				return fmt.Errorf("variable '%s' must match %s, got %q", v.Name, v.Pattern, value)
+			}
+		}
+	}
+	return nil
+}
+
+// Hint describes the values the variable accepts, e.g. "Low|Medium|High"
+// or "int, 1-40"
+func (v Variable) Hint() string {
+	switch v.Type {
+	case VarTypeChoice:
+		return strings.Join(v.Options, "|")
+	case VarTypeBool:
+		return "yes|no"
+	case VarTypeInt:
+		switch {
+		case v.Min != nil && v.Max != nil:
+			return fmt.Sprintf("int, %d-%d", *v.Min, *v.Max)
+		case v.Min != nil:
+			return fmt.Sprintf("int, >= %d", *v.Min)
+		case v.Max != nil:
+			return fmt.Sprintf("int, <= %d", *v.Max)
+		}
+		return "int"
+	}
+	if v.Pattern != "" {
+		return "matching " + v.Pattern
+	}
+	return "text"
 }
 
 // VarType represents the type of a template variable
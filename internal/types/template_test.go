@@ -0,0 +1,53 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariableValidate(t *testing.T) {
+	one, ten := 1, 10
+	tests := []struct {
+		name     string
+		variable Variable
+		value    string
+		valid    bool
+	}{
+		{"string", Variable{Name: "v", Type: VarTypeString}, "anything", true},
+		{"pattern match", Variable{Name: "v", Type: VarTypeString, Pattern: `^BUG-\d+$`}, "BUG-42", true},
+		{"pattern mismatch", Variable{Name: "v", Type: VarTypeString, Pattern: `^BUG-\d+$`}, "42", false},
+		{"int", Variable{Name: "v", Type: VarTypeInt}, "7", true},
+		{"not an int", Variable{Name: "v", Type: VarTypeInt}, "seven", false},
+		{"int in range", Variable{Name: "v", Type: VarTypeInt, Min: &one, Max: &ten}, "10", true},
+		{"int below range", Variable{Name: "v", Type: VarTypeInt, Min: &one}, "0", false},
+		{"int above range", Variable{Name: "v", Type: VarTypeInt, Max: &ten}, "11", false},
+		{"bool", Variable{Name: "v", Type: VarTypeBool}, "yes", true},
+		{"not a bool", Variable{Name: "v", Type: VarTypeBool}, "maybe", false},
+		{"choice", Variable{Name: "v", Type: VarTypeChoice, Options: []string{"Low", "High"}}, "High", true},
+		{"not a choice", Variable{Name: "v", Type: VarTypeChoice, Options: []string{"Low", "High"}}, "high", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.variable.Validate(tt.value)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestVariableCheck(t *testing.T) {
+	one, ten := 1, 10
+	bad := "0"
+
+	assert.NoError(t, Variable{Name: "v", Type: VarTypeInt, Min: &one, Max: &ten}.Check())
+	assert.ErrorContains(t, Variable{Name: "v", Type: "date"}.Check(), "unknown type")
+	assert.ErrorContains(t, Variable{Name: "v", Type: VarTypeChoice}.Check(), "must have options")
+	assert.ErrorContains(t, Variable{Name: "v", Type: VarTypeString, Pattern: "("}.Check(), "invalid pattern")
+	assert.ErrorContains(t, Variable{Name: "v", Type: VarTypeString, Min: &one}.Check(), "only supported for int")
+	assert.ErrorContains(t, Variable{Name: "v", Type: VarTypeInt, Min: &ten, Max: &one}.Check(), "greater than max")
+	assert.ErrorContains(t, Variable{Name: "v", Type: VarTypeInt, Min: &one, DefaultValue: &bad}.Check(), "invalid default value")
+}
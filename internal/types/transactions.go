@@ -0,0 +1,19 @@
+package types
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrTransactionsUnsupported is returned by repository wrappers when the
+// wrapped storage backend does not implement Transactor
+var ErrTransactionsUnsupported = errors.New("the storage backend does not support transactions")
+
+// Transactor is implemented by storage backends that can run several
+// operations atomically
+type Transactor interface {
+	// InTransaction runs fn in a transaction: the operations made with the
+	// context passed to fn are committed together if fn returns nil, and
+	// rolled back together with their change events otherwise.
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduledTransition is a state change of a task that is applied
+// automatically once its time has come, e.g. to start work at a sprint
+// boundary
+type ScheduledTransition struct {
+	ID        uuid.UUID `json:"id"`
+	TaskID    uuid.UUID `json:"task_id"`
+	ProjectID uuid.UUID `json:"project_id"`
+	State     TaskState `json:"state"`
+	At        time.Time `json:"at"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Due reports whether the transition is to be applied at now
+func (t *ScheduledTransition) Due(now time.Time) bool {
+	return !now.Before(t.At)
+}
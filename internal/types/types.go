@@ -27,7 +27,10 @@
 package types
 
 import (
+	"cmp"
 	"context"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -115,24 +118,280 @@ func (p TaskPriority) ToExternalString() string {
 //		Dependencies: []uuid.UUID{designTaskID},
 //	}
 type Task struct {
-	ID            uuid.UUID    `json:"id"`
-	ProjectID     uuid.UUID    `json:"project_id"`
-	ParentID      *uuid.UUID   `json:"parent_id,omitempty"` // nil for root tasks
-	Title         string       `json:"title"`
-	Description   string       `json:"description"`
-	State         TaskState    `json:"state"`
-	Priority      TaskPriority `json:"priority"`                 // Task priority level (1=high, 2=medium, 3=low)
-	Complexity    int          `json:"complexity"`               // Used for breakdown decisions
-	Depth         int          `json:"depth"`                    // 0 for root tasks
-	Estimate      *int64       `json:"estimate,omitempty"`       // Time estimate in minutes
-	AssignedAgent *uuid.UUID   `json:"assigned_agent,omitempty"` // Agent assigned to this task
-	Dependencies  []uuid.UUID  `json:"dependencies,omitempty"`   // Tasks this task depends on
-	Dependents    []uuid.UUID  `json:"dependents,omitempty"`     // Tasks that depend on this task
-	CreatedAt     time.Time    `json:"created_at"`
-	UpdatedAt     time.Time    `json:"updated_at"`
-	CreatedBy     string       `json:"created_by,omitempty"` // Actor who created the task
-	UpdatedBy     string       `json:"updated_by,omitempty"` // Actor who last updated the task
-	CompletedAt   *time.Time   `json:"completed_at,omitempty"`
+	ID             uuid.UUID    `json:"id"`
+	ProjectID      uuid.UUID    `json:"project_id"`
+	ParentID       *uuid.UUID   `json:"parent_id,omitempty"` // nil for root tasks
+	Title          string       `json:"title"`
+	Description    string       `json:"description"`
+	Instructions   string       `json:"instructions,omitempty"` // Standing guidance for agents, inherited by subtasks
+	State          TaskState    `json:"state"`
+	Priority       TaskPriority `json:"priority"`                  // Task priority level (1=high, 2=medium, 3=low)
+	Complexity     int          `json:"complexity"`                // Used for breakdown decisions
+	Depth          int          `json:"depth"`                     // 0 for root tasks
+	Position       int          `json:"position"`                  // Order among siblings, lower comes first
+	Estimate       *int64       `json:"estimate,omitempty"`        // Time estimate in minutes
+	AssignedAgent  *uuid.UUID   `json:"assigned_agent,omitempty"`  // Agent assigned to this task
+	Dependencies   []uuid.UUID  `json:"dependencies,omitempty"`    // Tasks this task depends on
+	Dependents     []uuid.UUID  `json:"dependents,omitempty"`      // Tasks that depend on this task
+	Tags           []string     `json:"tags,omitempty"`            // Free-form labels
+	KeepComplexity bool         `json:"keep_complexity,omitempty"` // Excluded from automatic complexity reduction
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	CreatedBy      string       `json:"created_by,omitempty"` // Actor who created the task
+	UpdatedBy      string       `json:"updated_by,omitempty"` // Actor who last updated the task
+	CompletedAt    *time.Time   `json:"completed_at,omitempty"`
+	// DueDate is the date the task must be completed by, nil if it has none
+	DueDate *time.Time `json:"due_date,omitempty"`
+	// AcceptanceCriteria define when the task is done, each with its own verification state
+	AcceptanceCriteria []AcceptanceCriterion `json:"acceptance_criteria,omitempty"`
+	// EffortLog records the actual effort spent on the task
+	EffortLog []EffortEntry `json:"effort_log,omitempty"`
+	// Review is the latest review request of the task, nil if none was requested
+	Review *TaskReview `json:"review,omitempty"`
+	// Blocker is the external reason a blocked task waits for, nil if it is
+	// not blocked or only blocked by its dependencies
+	Blocker *TaskBlocker `json:"blocker,omitempty"`
+	// Check is the command that verifies the task, nil if it has none
+	Check *TaskCheck `json:"check,omitempty"`
+	// DependencyLinks describe the dependencies that are not plain
+	// finish-to-start edges without lag
+	DependencyLinks []DependencyLink `json:"dependency_links,omitempty"`
+	// Handoffs are the notes left when the task passed to another assignee or
+	// went back from in-progress to pending, oldest first
+	Handoffs []TaskHandoff `json:"handoffs,omitempty"`
+}
+
+// CompareSiblings orders sibling tasks by position. Siblings with the same
+// position, such as tasks created before positions existed, keep their
+// creation order.
+func CompareSiblings(a, b *Task) int {
+	if a.Position != b.Position {
+		return cmp.Compare(a.Position, b.Position)
+	}
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID.String(), b.ID.String())
+}
+
+// SortSiblings sorts sibling tasks into their intended order
+func SortSiblings(tasks []*Task) {
+	slices.SortFunc(tasks, CompareSiblings)
+}
+
+// AcceptanceCriterion is one item of a task's definition of done
+type AcceptanceCriterion struct {
+	Text       string     `json:"text"`
+	Verified   bool       `json:"verified"`
+	VerifiedBy string     `json:"verified_by,omitempty"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+}
+
+// UnverifiedCriteria returns the acceptance criteria of the task that are not verified yet
+func (t *Task) UnverifiedCriteria() []AcceptanceCriterion {
+	var unverified []AcceptanceCriterion
+	for _, criterion := range t.AcceptanceCriteria {
+		if !criterion.Verified {
+			unverified = append(unverified, criterion)
+		}
+	}
+	return unverified
+}
+
+// EffortEntry is one logged amount of actual effort spent on a task
+type EffortEntry struct {
+	Minutes  int64     `json:"minutes"`
+	Actor    string    `json:"actor"`
+	LoggedAt time.Time `json:"logged_at"`
+	Note     string    `json:"note,omitempty"`
+}
+
+// ActualEffort returns the total logged effort of the task in minutes
+func (t *Task) ActualEffort() int64 {
+	var total int64
+	for _, entry := range t.EffortLog {
+		total += entry.Minutes
+	}
+	return total
+}
+
+// DependencyType defines when a dependent task may start
+type DependencyType string
+
+const (
+	// DependencyFinishToStart lets the dependent task start once the dependency is completed.
+	DependencyFinishToStart DependencyType = "finish-to-start"
+
+	// DependencyStartToStart lets the dependent task start once the dependency has started.
+	DependencyStartToStart DependencyType = "start-to-start"
+)
+
+// IsValid reports whether the dependency type is known
+func (t DependencyType) IsValid() bool {
+	return t == DependencyFinishToStart || t == DependencyStartToStart
+}
+
+// DependencyLink describes the type and lag of a dependency edge
+type DependencyLink struct {
+	DependsOnID uuid.UUID      `json:"depends_on_id"`
+	Type        DependencyType `json:"type"`
+	Lag         int64          `json:"lag,omitempty"` // Wait after the dependency, in minutes
+}
+
+// IsDefault reports whether the link is a finish-to-start edge without lag
+func (l DependencyLink) IsDefault() bool {
+	return l.Type == DependencyFinishToStart && l.Lag == 0
+}
+
+// DependencyLink returns the link of the task to the given dependency,
+// which is a finish-to-start edge without lag unless recorded otherwise
+func (t *Task) DependencyLink(dependsOnID uuid.UUID) DependencyLink {
+	for _, link := range t.DependencyLinks {
+		if link.DependsOnID == dependsOnID {
+			return link
+		}
+	}
+	return DependencyLink{DependsOnID: dependsOnID, Type: DependencyFinishToStart}
+}
+
+// ReviewStatus represents the state of a task review
+type ReviewStatus string
+
+const (
+	// ReviewStatusRequested indicates the task waits for a reviewer.
+	ReviewStatusRequested ReviewStatus = "requested"
+
+	// ReviewStatusApproved indicates a reviewer approved the task for completion.
+	ReviewStatusApproved ReviewStatus = "approved"
+
+	// ReviewStatusRejected indicates a reviewer sent the task back for more work.
+	ReviewStatusRejected ReviewStatus = "rejected"
+)
+
+// TaskReview records a review request and the reviewer's decision
+type TaskReview struct {
+	Status      ReviewStatus `json:"status"`
+	RequestedBy string       `json:"requested_by"`
+	RequestedAt time.Time    `json:"requested_at"`
+	Reviewer    string       `json:"reviewer,omitempty"` // Actor who approved or rejected the task
+	ReviewedAt  *time.Time   `json:"reviewed_at,omitempty"`
+	Comment     string       `json:"comment,omitempty"`
+}
+
+// TaskBlocker records why a task is blocked by something outside the project,
+// such as a vendor or another team
+type TaskBlocker struct {
+	Reason    string    `json:"reason"`
+	Reference string    `json:"reference,omitempty"` // Ticket, URL or contact of the external blocker
+	BlockedBy string    `json:"blocked_by,omitempty"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
+
+// TaskHandoff is a note on what is done and what is left, left for whoever
+// works on a task next
+type TaskHandoff struct {
+	Note      string     `json:"note"`
+	FromAgent *uuid.UUID `json:"from_agent,omitempty"` // Assignee before the handoff, nil if unassigned
+	ToAgent   *uuid.UUID `json:"to_agent,omitempty"`   // Assignee after the handoff, nil if unassigned
+	FromState TaskState  `json:"from_state"`
+	ToState   TaskState  `json:"to_state"`
+	By        string     `json:"by,omitempty"` // Actor who handed the task off
+	At        time.Time  `json:"at"`
+}
+
+// LatestHandoff returns the most recent handoff note of the task, nil if it has none
+func (t *Task) LatestHandoff() *TaskHandoff {
+	if len(t.Handoffs) == 0 {
+		return nil
+	}
+	return &t.Handoffs[len(t.Handoffs)-1]
+}
+
+// MaxCheckRuns is the number of check runs kept per task
+const MaxCheckRuns = 10
+
+// TaskCheck is a command, such as the task's acceptance tests, that verifies
+// the task is done
+type TaskCheck struct {
+	Command string `json:"command"`
+	// Required blocks completion of the task until the latest run passed
+	Required bool      `json:"required,omitempty"`
+	SetBy    string    `json:"set_by,omitempty"`
+	SetAt    time.Time `json:"set_at"`
+	// Runs are the latest runs of the command, oldest first
+	Runs []CheckRun `json:"runs,omitempty"`
+}
+
+// CheckRun is the outcome of one run of a task's check command
+type CheckRun struct {
+	Passed     bool      `json:"passed"`
+	ExitCode   int       `json:"exit_code"` // -1 if the command did not run to completion
+	DurationMs int64     `json:"duration_ms"`
+	Output     string    `json:"output,omitempty"` // End of the combined output
+	RunBy      string    `json:"run_by,omitempty"`
+	RunAt      time.Time `json:"run_at"`
+}
+
+// LastRun returns the latest run of the check, nil if it never ran
+func (c *TaskCheck) LastRun() *CheckRun {
+	if len(c.Runs) == 0 {
+		return nil
+	}
+	return &c.Runs[len(c.Runs)-1]
+}
+
+// Passed reports whether the latest run of the check passed
+func (c *TaskCheck) Passed() bool {
+	run := c.LastRun()
+	return run != nil && run.Passed
+}
+
+// IsApproved reports whether the task has an approved review
+func (t *Task) IsApproved() bool {
+	return t.Review != nil && t.Review.Status == ReviewStatusApproved
+}
+
+// Scopes of an Instruction
+const (
+	InstructionScopeProject = "project"
+	InstructionScopeTask    = "task"
+)
+
+// Instruction is the standing agent guidance of a project or task
+type Instruction struct {
+	Scope string    `json:"scope"` // "project" or "task"
+	ID    uuid.UUID `json:"id"`
+	Title string    `json:"title"`
+	Text  string    `json:"text"`
+}
+
+// InheritedInstructions returns the instructions that apply to a task, from
+// the most general to the most specific: the project, the ancestors from the
+// root down (as returned by GetAncestors) and the task itself. Any argument
+// may be nil; projects and tasks without instructions are skipped.
+func InheritedInstructions(project *Project, ancestors []*Task, task *Task) []Instruction {
+	var instructions []Instruction
+	if project != nil && project.Instructions != "" {
+		instructions = append(instructions, Instruction{
+			Scope: InstructionScopeProject,
+			ID:    project.ID,
+			Title: project.Title,
+			Text:  project.Instructions,
+		})
+	}
+	chain := make([]*Task, 0, len(ancestors)+1)
+	chain = append(append(chain, ancestors...), task)
+	for _, t := range chain {
+		if t != nil && t.Instructions != "" {
+			instructions = append(instructions, Instruction{
+				Scope: InstructionScopeTask,
+				ID:    t.ID,
+				Title: t.Title,
+				Text:  t.Instructions,
+			})
+		}
+	}
+	return instructions
 }
 
 // ProjectState represents the current state of a project
@@ -147,14 +406,20 @@ const (
 
 // Project represents a project containing hierarchical tasks
 type Project struct {
-	ID          uuid.UUID    `json:"id"`
-	Title       string       `json:"title"`
-	Description string       `json:"description"`
-	State       ProjectState `json:"state"`
-	CreatedAt   time.Time    `json:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at"`
-	CreatedBy   string       `json:"created_by,omitempty"` // Actor who created the project
-	UpdatedBy   string       `json:"updated_by,omitempty"` // Actor who last updated the project
+	ID          uuid.UUID `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	// Instructions is standing guidance for agents working on any task of the
+	// project, e.g. "always write tests"
+	Instructions string       `json:"instructions,omitempty"`
+	State        ProjectState `json:"state"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	CreatedBy    string       `json:"created_by,omitempty"` // Actor who created the project
+	UpdatedBy    string       `json:"updated_by,omitempty"` // Actor who last updated the project
+	// Document is the long-form project document: goals, conventions and
+	// instructions for agents, edited with 'knot project doc edit'
+	Document string `json:"document,omitempty"`
 	// Progress metrics
 	TotalTasks     int     `json:"total_tasks"`
 	CompletedTasks int     `json:"completed_tasks"`
@@ -172,6 +437,78 @@ type ProjectProgress struct {
 	CancelledTasks  int         `json:"cancelled_tasks"`
 	OverallProgress float64     `json:"overall_progress"`
 	TasksByDepth    map[int]int `json:"tasks_by_depth"`
+	// Weighting is how WeightedProgress weights tasks: count, complexity or estimate
+	Weighting string `json:"weighting,omitempty"`
+	// WeightedProgress is the completed share of the total task weight, in percent
+	WeightedProgress float64 `json:"weighted_progress"`
+}
+
+// SubtreeCounts aggregates the descendants of a task for its rollup progress.
+// Complexities count at least 1, estimates are in minutes.
+type SubtreeCounts struct {
+	Descendants         int   `json:"descendants"`
+	Completed           int   `json:"completed"`
+	Complexity          int   `json:"complexity"`
+	CompletedComplexity int   `json:"completed_complexity"`
+	Estimated           int   `json:"estimated"` // Descendants with an estimate
+	Estimate            int64 `json:"estimate"`
+	CompletedEstimated  int   `json:"completed_estimated"`
+	CompletedEstimate   int64 `json:"completed_estimate"`
+}
+
+// Add counts a task
+func (c *SubtreeCounts) Add(task *Task) {
+	completed := task.State == TaskStateCompleted
+	complexity := max(task.Complexity, 1)
+	c.Descendants++
+	c.Complexity += complexity
+	if completed {
+		c.Completed++
+		c.CompletedComplexity += complexity
+	}
+	if task.Estimate != nil && *task.Estimate > 0 {
+		c.Estimated++
+		c.Estimate += *task.Estimate
+		if completed {
+			c.CompletedEstimated++
+			c.CompletedEstimate += *task.Estimate
+		}
+	}
+}
+
+// SubtreeProgress is the rollup progress of a task over its descendants
+type SubtreeProgress struct {
+	Descendants int `json:"descendants"`
+	Completed   int `json:"completed"`
+	// Weighting is how WeightedProgress weights tasks: count, complexity or estimate
+	Weighting string `json:"weighting"`
+	// WeightedProgress is the completed share of the descendants' weight, in percent
+	WeightedProgress float64 `json:"weighted_progress"`
+}
+
+// CountSubtrees aggregates the descendants of every task that has any.
+// Tasks whose parent is not among the tasks are treated as roots.
+func CountSubtrees(tasks []*Task) map[uuid.UUID]*SubtreeCounts {
+	parents := make(map[uuid.UUID]*uuid.UUID, len(tasks))
+	for _, task := range tasks {
+		parents[task.ID] = task.ParentID
+	}
+
+	counts := make(map[uuid.UUID]*SubtreeCounts)
+	for _, task := range tasks {
+		seen := map[uuid.UUID]bool{task.ID: true}
+		for parentID := task.ParentID; parentID != nil && !seen[*parentID]; parentID = parents[*parentID] {
+			if _, ok := parents[*parentID]; !ok {
+				break
+			}
+			seen[*parentID] = true
+			if counts[*parentID] == nil {
+				counts[*parentID] = &SubtreeCounts{}
+			}
+			counts[*parentID].Add(task)
+		}
+	}
+	return counts
 }
 
 // TaskFilter represents filtering options for task queries
@@ -184,6 +521,16 @@ type TaskFilter struct {
 	MaxDepth      *int          `json:"max_depth,omitempty"`
 	MinComplexity *int          `json:"min_complexity,omitempty"`
 	MaxComplexity *int          `json:"max_complexity,omitempty"`
+	// AssignedAgent matches the tasks assigned to the agent
+	AssignedAgent *uuid.UUID `json:"assigned_agent,omitempty"`
+	// Unassigned matches the tasks no agent is assigned to
+	Unassigned bool `json:"unassigned,omitempty"`
+	// Search matches tasks whose title or description contains the text, ignoring case
+	Search string `json:"search,omitempty"`
+	// CreatedBy matches the tasks created by the actor
+	CreatedBy string `json:"created_by,omitempty"`
+	// CreatedSince matches the tasks created at or after the time
+	CreatedSince *time.Time `json:"created_since,omitempty"`
 }
 
 // TaskUpdates represents the fields that can be updated in bulk
@@ -242,16 +589,28 @@ type Repository interface {
 
 	// Task queries
 	ListTasks(ctx context.Context, filter TaskFilter) ([]*Task, error)
+	// ListTasksPage returns one page of the tasks matching filter in creation
+	// order, continuing after page.Cursor with keyset pagination.
+	ListTasksPage(ctx context.Context, filter TaskFilter, page PageRequest) (*TaskPage, error)
 	GetTasksByProject(ctx context.Context, projectID uuid.UUID) ([]*Task, error)
 	GetTasksByParent(ctx context.Context, parentID uuid.UUID) ([]*Task, error)
+	// GetDescendants returns the descendants of a task up to maxDepth levels
+	// below it, or all of them if maxDepth is 0, ordered by depth and then in
+	// sibling order.
+	GetDescendants(ctx context.Context, taskID uuid.UUID, maxDepth int) ([]*Task, error)
 	GetRootTasks(ctx context.Context, projectID uuid.UUID) ([]*Task, error)
 	GetParentTask(ctx context.Context, taskID uuid.UUID) (*Task, error)
 
 	// Hierarchy operations
 	DeleteTaskSubtree(ctx context.Context, taskID uuid.UUID) error
+	// MoveTask moves a task below parentID, or to the root level if parentID is
+	// nil, and recalculates the depth of the task and its descendants.
+	MoveTask(ctx context.Context, taskID uuid.UUID, parentID *uuid.UUID) error
 
 	// Dependency management
 	AddTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID) (*Task, error)
+	// SetDependencyLink changes the type and lag of an existing dependency of a task.
+	SetDependencyLink(ctx context.Context, taskID uuid.UUID, link DependencyLink) (*Task, error)
 	RemoveTaskDependency(ctx context.Context, taskID uuid.UUID, dependsOnTaskID uuid.UUID) (*Task, error)
 	GetTaskDependencies(ctx context.Context, taskID uuid.UUID) ([]*Task, error)
 	GetDependentTasks(ctx context.Context, taskID uuid.UUID) ([]*Task, error)
@@ -259,10 +618,43 @@ type Repository interface {
 	// Metrics and analysis
 	GetProjectProgress(ctx context.Context, projectID uuid.UUID) (*ProjectProgress, error)
 	GetTaskCountByDepth(ctx context.Context, projectID uuid.UUID, maxDepth int) (map[int]int, error)
+	// GetSubtreeCounts aggregates the descendants of every task of a project
+	// that has any, keyed by task ID
+	GetSubtreeCounts(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]*SubtreeCounts, error)
+	// GetChildCounts returns the number of direct children of every task of a
+	// project that has any, keyed by task ID
+	GetChildCounts(ctx context.Context, projectID uuid.UUID) (map[uuid.UUID]int, error)
+
+	// Project locks
+	// GetProjectLock returns the lock of a project, or nil if it is not locked.
+	// Expired locks are returned as well; callers decide how to treat them.
+	GetProjectLock(ctx context.Context, projectID uuid.UUID) (*ProjectLock, error)
+	// SaveProjectLock creates or replaces the lock of a project.
+	SaveProjectLock(ctx context.Context, lock *ProjectLock) error
+	// DeleteProjectLock removes the lock of a project, if any.
+	DeleteProjectLock(ctx context.Context, projectID uuid.UUID) error
+
+	// Scheduled state transitions
+	// SaveScheduledTransition creates or replaces a scheduled transition.
+	SaveScheduledTransition(ctx context.Context, transition *ScheduledTransition) error
+	// ListScheduledTransitions returns the scheduled transitions of a project,
+	// or of all projects if projectID is nil, ordered by their time.
+	ListScheduledTransitions(ctx context.Context, projectID *uuid.UUID) ([]*ScheduledTransition, error)
+	// DeleteScheduledTransition removes a scheduled transition.
+	DeleteScheduledTransition(ctx context.Context, id uuid.UUID) error
 
 	// Project context management
 	GetSelectedProject(ctx context.Context) (*uuid.UUID, error)
 	SetSelectedProject(ctx context.Context, projectID uuid.UUID, actor string) error
 	ClearSelectedProject(ctx context.Context) error
 	HasSelectedProject(ctx context.Context) (bool, error)
+
+	// Session project context
+	// GetSessionProject returns the project selected in a session, or nil if
+	// the session has no selection or its project was deleted.
+	GetSessionProject(ctx context.Context, session string) (*uuid.UUID, error)
+	// SetSessionProject selects a project for one session only.
+	SetSessionProject(ctx context.Context, session string, projectID uuid.UUID, actor string) error
+	// ClearSessionProject removes the selection of a session, if any.
+	ClearSessionProject(ctx context.Context, session string) error
 }
@@ -325,6 +325,23 @@ func TestProjectValidation(t *testing.T) {
 	})
 }
 
+func TestCountSubtrees(t *testing.T) {
+	root := &Task{ID: uuid.New(), State: TaskStateInProgress, Complexity: 5}
+	child := &Task{ID: uuid.New(), ParentID: &root.ID, State: TaskStateCompleted, Complexity: 3, Estimate: int64Ptr(60)}
+	grandchild := &Task{ID: uuid.New(), ParentID: &child.ID, State: TaskStatePending}
+	orphan := &Task{ID: uuid.New(), ParentID: func() *uuid.UUID { id := uuid.New(); return &id }(), State: TaskStateCompleted}
+
+	counts := CountSubtrees([]*Task{root, child, grandchild, orphan})
+	require.Len(t, counts, 2, "only tasks with descendants are counted")
+	assert.Equal(t, SubtreeCounts{
+		Descendants: 2, Completed: 1,
+		Complexity: 4, CompletedComplexity: 3,
+		Estimated: 1, Estimate: 60, CompletedEstimated: 1, CompletedEstimate: 60,
+	}, *counts[root.ID], "complexity 0 counts as 1")
+	assert.Equal(t, 1, counts[child.ID].Descendants)
+	assert.Zero(t, counts[child.ID].Completed)
+}
+
 // Helper functions
 func intPtr(i int) *int {
 	return &i
@@ -333,3 +350,21 @@ func intPtr(i int) *int {
 func int64Ptr(i int64) *int64 {
 	return &i
 }
+
+// TestInheritedInstructions tests the order of inherited agent instructions
+func TestInheritedInstructions(t *testing.T) {
+	project := &Project{ID: uuid.New(), Title: "Project", Instructions: "Always write tests"}
+	root := &Task{ID: uuid.New(), Title: "Root", Instructions: "Use library X"}
+	middle := &Task{ID: uuid.New(), Title: "Middle"}
+	task := &Task{ID: uuid.New(), Title: "Task", Instructions: "Keep it short"}
+
+	instructions := InheritedInstructions(project, []*Task{root, middle}, task)
+	require.Len(t, instructions, 3)
+	assert.Equal(t, Instruction{Scope: InstructionScopeProject, ID: project.ID, Title: "Project", Text: "Always write tests"}, instructions[0])
+	assert.Equal(t, root.ID, instructions[1].ID)
+	assert.Equal(t, InstructionScopeTask, instructions[2].Scope)
+	assert.Equal(t, "Keep it short", instructions[2].Text)
+
+	assert.Empty(t, InheritedInstructions(nil, nil, nil))
+	assert.Len(t, InheritedInstructions(project, nil, nil), 1)
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role grants a user access to the projects served by 'knot serve'
+type Role string
+
+const (
+	// RoleNone grants no access
+	RoleNone Role = ""
+	// RoleViewer may read projects and tasks
+	RoleViewer Role = "viewer"
+	// RoleEditor may additionally create and change projects and tasks
+	RoleEditor Role = "editor"
+	// RoleAdmin may additionally delete projects and tasks
+	RoleAdmin Role = "admin"
+)
+
+// ParseRole parses a role name; "none" parses to RoleNone
+func ParseRole(name string) (Role, error) {
+	switch role := Role(name); role {
+	case RoleViewer, RoleEditor, RoleAdmin:
+		return role, nil
+	case "none":
+		return RoleNone, nil
+	default:
+		return RoleNone, fmt.Errorf("invalid role %q, must be one of: viewer, editor, admin, none", name)
+	}
+}
+
+// Allows reports whether r includes the permissions of required
+func (r Role) Allows(required Role) bool {
+	return r.rank() >= required.rank()
+}
+
+func (r Role) rank() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleEditor:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// User is a member of a shared knot deployment who authenticates with a
+// token. Role applies to all projects unless ProjectRoles overrides it.
+type User struct {
+	Name         string             `json:"name"`
+	Role         Role               `json:"role,omitempty"`
+	ProjectRoles map[uuid.UUID]Role `json:"project_roles,omitempty"`
+	// TokenHash is the SHA-256 hash of the user's token; the token itself is
+	// only shown once when it is created
+	TokenHash string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RoleFor returns the role of the user on a project
+func (u *User) RoleFor(projectID uuid.UUID) Role {
+	if role, ok := u.ProjectRoles[projectID]; ok {
+		return role
+	}
+	return u.Role
+}
+
+// ErrUsersUnsupported is returned by repository wrappers when the wrapped
+// storage backend does not implement UserStore
+var ErrUsersUnsupported = errors.New("the storage backend does not manage users")
+
+// UserStore is implemented by storage backends that manage the users of a
+// shared deployment
+type UserStore interface {
+	ListUsers(ctx context.Context) ([]*User, error)
+	// GetUser returns the user with the given name, or nil if there is none
+	GetUser(ctx context.Context, name string) (*User, error)
+	// GetUserByTokenHash returns the user owning a token, or nil if there is none
+	GetUserByTokenHash(ctx context.Context, tokenHash string) (*User, error)
+	// SaveUser creates or replaces a user
+	SaveUser(ctx context.Context, user *User) error
+	DeleteUser(ctx context.Context, name string) error
+}
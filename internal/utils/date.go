@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDueDate parses a due date given as YYYY-MM-DD in local time
+func ParseDueDate(s string) (time.Time, error) {
+	input := strings.TrimSpace(s)
+	due, err := time.ParseInLocation(time.DateOnly, input, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", input)
+	}
+	return due, nil
+}
+
+// FormatDueDate formats a due date as YYYY-MM-DD, or "-" if it is nil
+func FormatDueDate(due *time.Time) string {
+	if due == nil {
+		return "-"
+	}
+	return due.Format(time.DateOnly)
+}
+
+// ParseTimestamp parses a point in time given as RFC 3339, as
+// "YYYY-MM-DD HH:MM" or YYYY-MM-DDTHH:MM in local time or as a date
+// YYYY-MM-DD (midnight, local time)
+func ParseTimestamp(s string) (time.Time, error) {
+	input := strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, input); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, input, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (expected RFC 3339, \"YYYY-MM-DD HH:MM\" or YYYY-MM-DD)", input)
+}
+
+// ParseSince parses the start of a reporting period relative to now: "today",
+// "yesterday", a date as YYYY-MM-DD, or a duration back from now such as 36h
+// or 2d
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	input := strings.ToLower(strings.TrimSpace(s))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch input {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+	if day, err := time.ParseInLocation(time.DateOnly, input, now.Location()); err == nil {
+		return day, nil
+	}
+	if days, ok := strings.CutSuffix(input, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(input); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use today, yesterday, YYYY-MM-DD or a duration such as 36h or 2d)", strings.TrimSpace(s))
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDueDate(t *testing.T) {
+	due, err := ParseDueDate(" 2026-10-23 ")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 23, 0, 0, 0, 0, time.Local), due)
+	assert.Equal(t, "2026-10-23", FormatDueDate(&due))
+	assert.Equal(t, "-", FormatDueDate(nil))
+
+	for _, input := range []string{"", "tomorrow", "23.10.2026", "2026-13-01"} {
+		_, err := ParseDueDate(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	cases := map[string]time.Time{
+		"today":      time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+		" Yesterday": time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC),
+		"2026-10-12": time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+		"2d":         time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC),
+		"90m":        time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC),
+	}
+	for input, want := range cases {
+		since, err := ParseSince(input, now)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, since, input)
+	}
+
+	for _, input := range []string{"", "last week", "-2d", "2026-13-01"} {
+		_, err := ParseSince(input, now)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	cases := map[string]time.Time{
+		"2024-07-01T09:00:00Z": time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC),
+		"2024-07-01 09:00":     time.Date(2024, 7, 1, 9, 0, 0, 0, time.Local),
+		"2024-07-01T09:00":     time.Date(2024, 7, 1, 9, 0, 0, 0, time.Local),
+		" 2024-07-01 ":         time.Date(2024, 7, 1, 0, 0, 0, 0, time.Local),
+	}
+	for input, want := range cases {
+		got, err := ParseTimestamp(input)
+		require.NoError(t, err, input)
+		assert.True(t, want.Equal(got), "%s: want %s, got %s", input, want, got)
+	}
+
+	for _, input := range []string{"", "tomorrow", "2024-07-01T25:00"} {
+		_, err := ParseTimestamp(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// Estimates are stored in minutes. Days and weeks are working days and weeks,
+// which is how effort estimates are usually meant ("3d" of work, not 72 hours).
+const (
+	MinutesPerHour = 60
+	MinutesPerDay  = 8 * MinutesPerHour
+	MinutesPerWeek = 5 * MinutesPerDay
+)
+
+var (
+	durationPartPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([a-z]+)`)
+	durationUnits       = map[string]int64{
+		"m": 1, "min": 1, "mins": 1, "minute": 1, "minutes": 1,
+		"h": MinutesPerHour, "hr": MinutesPerHour, "hrs": MinutesPerHour, "hour": MinutesPerHour, "hours": MinutesPerHour,
+		"d": MinutesPerDay, "day": MinutesPerDay, "days": MinutesPerDay,
+		"w": MinutesPerWeek, "wk": MinutesPerWeek, "week": MinutesPerWeek, "weeks": MinutesPerWeek,
+	}
+)
+
+// ParseEstimate parses a human-friendly duration such as "2h30m", "3d", "1.5h"
+// or "1w 2d" into minutes. A bare number is interpreted as minutes.
+func ParseEstimate(s string) (int64, error) {
+	input := strings.ToLower(strings.TrimSpace(s))
+	if input == "" {
+		return 0, fmt.Errorf("duration is empty")
+	}
+
+	if minutes, err := strconv.ParseInt(input, 10, 64); err == nil {
+		if minutes < 0 {
+			return 0, fmt.Errorf("duration must be non-negative, got %q", s)
+		}
+		return minutes, nil
+	}
+
+	matches := durationPartPattern.FindAllStringSubmatchIndex(input, -1)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid duration %q (examples: 45m, 2h30m, 3d, 1w)", s)
+	}
+
+	var total float64
+	consumed := 0
+	for _, match := range matches {
+		// Only whitespace may appear between duration parts
+		if strings.TrimSpace(input[consumed:match[0]]) != "" {
+			return 0, fmt.Errorf("invalid duration %q (examples: 45m, 2h30m, 3d, 1w)", s)
+		}
+		consumed = match[1]
+
+		value, err := strconv.ParseFloat(input[match[2]:match[3]], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number in duration %q: %w", s, err)
+		}
+		unit, ok := durationUnits[input[match[4]:match[5]]]
+		if !ok {
+			return 0, fmt.Errorf("unknown unit %q in duration %q (use m, h, d or w)", input[match[4]:match[5]], s)
+		}
+		total += value * float64(unit)
+	}
+	if strings.TrimSpace(input[consumed:]) != "" {
+		return 0, fmt.Errorf("invalid duration %q (examples: 45m, 2h30m, 3d, 1w)", s)
+	}
+
+	return int64(total + 0.5), nil
+}
+
+// FormatEstimate renders minutes as a compact human-friendly duration such as
+// "1w2d", "2h30m" or "0m", using working days and weeks. The output can be fed
+// back into ParseEstimate.
+func FormatEstimate(minutes int64) string {
+	if minutes <= 0 {
+		return "0m"
+	}
+
+	var b strings.Builder
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{
+		{"w", MinutesPerWeek},
+		{"d", MinutesPerDay},
+		{"h", MinutesPerHour},
+		{"m", 1},
+	} {
+		if minutes >= unit.size {
+			fmt.Fprintf(&b, "%d%s", minutes/unit.size, unit.suffix)
+			minutes %= unit.size
+		}
+	}
+	return b.String()
+}
+
+// FormatEstimatePtr renders an optional estimate, returning "-" when unset
+func FormatEstimatePtr(minutes *int64) string {
+	if minutes == nil {
+		return "-"
+	}
+	return FormatEstimate(*minutes)
+}
+
+// EstimateSuffix returns " | Estimate: <duration>" for tasks with an estimate,
+// for appending to single-line task summaries, or "" otherwise.
+func EstimateSuffix(task *types.Task) string {
+	if task.Estimate == nil {
+		return ""
+	}
+	return " | Estimate: " + FormatEstimate(*task.Estimate)
+}
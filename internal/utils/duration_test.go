@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"90", 90},
+		{"45m", 45},
+		{"2h30m", 150},
+		{"1.5h", 90},
+		{"3d", 3 * MinutesPerDay},
+		{"1w 2d", MinutesPerWeek + 2*MinutesPerDay},
+		{"2 hours 15 mins", 135},
+		{" 1H ", 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			minutes, err := ParseEstimate(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, minutes)
+		})
+	}
+}
+
+func TestParseEstimateInvalid(t *testing.T) {
+	for _, input := range []string{"", "-5", "abc", "2x", "2h and 3m", "h"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseEstimate(input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFormatEstimate(t *testing.T) {
+	assert.Equal(t, "0m", FormatEstimate(0))
+	assert.Equal(t, "45m", FormatEstimate(45))
+	assert.Equal(t, "2h30m", FormatEstimate(150))
+	assert.Equal(t, "3d", FormatEstimate(3*MinutesPerDay))
+	assert.Equal(t, "1w2d1h", FormatEstimate(MinutesPerWeek+2*MinutesPerDay+60))
+	assert.Equal(t, "-", FormatEstimatePtr(nil))
+
+	for _, minutes := range []int64{1, 59, 61, 480, 2401, 10000} {
+		parsed, err := ParseEstimate(FormatEstimate(minutes))
+		require.NoError(t, err)
+		assert.Equal(t, minutes, parsed)
+	}
+}
@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// ellipsis marks text shortened by Truncate and TruncateWidth
+const ellipsis = "…"
+
+// Characters splits s into user-perceived characters: a base character
+// together with the combining marks, variation selectors, emoji modifiers and
+// zero width joiner sequences that follow it, and pairs of regional indicators
+// (flags). This covers the scripts and emoji that appear in task titles
+// without implementing the full Unicode segmentation rules.
+func Characters(s string) []string {
+	var chars []string
+	start := -1
+	var prev rune
+	regional := 0
+	for i, r := range s {
+		if start >= 0 && extendsCharacter(prev, r, regional) {
+			if isRegionalIndicator(r) {
+				regional++
+			}
+			prev = r
+			continue
+		}
+		if start >= 0 {
+			chars = append(chars, s[start:i])
+		}
+		start = i
+		prev = r
+		regional = 0
+		if isRegionalIndicator(r) {
+			regional = 1
+		}
+	}
+	if start >= 0 {
+		chars = append(chars, s[start:])
+	}
+	return chars
+}
+
+// TextLength returns the number of user-perceived characters in s
+func TextLength(s string) int {
+	return len(Characters(s))
+}
+
+// DisplayWidth returns the number of terminal columns needed to print s: wide
+// East Asian characters and emoji take two columns, control characters none
+func DisplayWidth(s string) int {
+	total := 0
+	for _, char := range Characters(s) {
+		total += characterWidth(char)
+	}
+	return total
+}
+
+// Truncate shortens s to at most limit characters, ending it with "…" when
+// text was cut. Characters are never split.
+func Truncate(s string, limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	chars := Characters(s)
+	if len(chars) <= limit {
+		return s
+	}
+	return strings.Join(chars[:limit-1], "") + ellipsis
+}
+
+// TruncateWidth shortens s to at most columns terminal columns, ending it
+// with "…" when text was cut
+func TruncateWidth(s string, columns int) string {
+	if columns <= 0 {
+		return ""
+	}
+	if DisplayWidth(s) <= columns {
+		return s
+	}
+
+	var b strings.Builder
+	used := 0
+	for _, char := range Characters(s) {
+		w := characterWidth(char)
+		if used+w > columns-1 {
+			break
+		}
+		b.WriteString(char)
+		used += w
+	}
+	return b.String() + ellipsis
+}
+
+func extendsCharacter(prev, r rune, regional int) bool {
+	switch {
+	case prev == '\r' && r == '\n':
+		return true
+	case prev == '\u200d':
+		// The character after a zero width joiner is part of the sequence
+		return true
+	case r == '\u200d':
+		return true
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+		return true
+	case r >= 0xFE00 && r <= 0xFE0F, r >= 0xE0100 && r <= 0xE01EF:
+		// Variation selectors
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF:
+		// Emoji skin tone modifiers
+		return true
+	case r >= 0xE0020 && r <= 0xE007F:
+		// Tag characters of subdivision flags
+		return true
+	case isRegionalIndicator(r):
+		return regional == 1
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func characterWidth(char string) int {
+	first := []rune(char)[0]
+	switch {
+	case unicode.IsControl(first):
+		return 0
+	case isRegionalIndicator(first), strings.ContainsRune(char, '\ufe0f'):
+		// Flags and characters with emoji presentation
+		return 2
+	}
+	switch width.LookupRune(first).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
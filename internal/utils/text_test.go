@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextLength(t *testing.T) {
+	tests := []struct {
+		input         string
+		length, width int
+	}{
+		{"", 0, 0},
+		{"Fix login", 9, 9},
+		{"修复登录错误", 6, 12},
+		{"café", 4, 4},
+		{"cafe\u0301", 4, 4},
+		{"ship 🚀", 6, 7},
+		{"👍🏽 ok", 4, 5},
+		{"\U0001F469\u200d\U0001F4BB review", 8, 9},
+		{"🇩🇪🇫🇷", 2, 4},
+		{"\u2764\ufe0f", 1, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.length, TextLength(tt.input), "length")
+			assert.Equal(t, tt.width, DisplayWidth(tt.input), "width")
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "Fix login", Truncate("Fix login", 9))
+	assert.Equal(t, "Fix lo…", Truncate("Fix login", 7))
+	assert.Equal(t, "修复登…", Truncate("修复登录错误", 4))
+	assert.Equal(t, "\U0001F469\u200d\U0001F4BB…", Truncate(strings.Repeat("\U0001F469\u200d\U0001F4BB", 3), 2), "sequences are not split")
+	assert.Equal(t, "", Truncate("Fix login", 0))
+
+	long := strings.Repeat("界", 300)
+	assert.Equal(t, 200, TextLength(Truncate(long, 200)))
+}
+
+func TestTruncateWidth(t *testing.T) {
+	assert.Equal(t, "Fix login", TruncateWidth("Fix login", 9))
+	assert.Equal(t, "Fix lo…", TruncateWidth("Fix login", 7))
+	assert.Equal(t, "修复登…", TruncateWidth("修复登录错误", 8))
+	assert.Equal(t, "修复…", TruncateWidth("修复登录错误", 6), "wide characters are not split across the limit")
+	assert.LessOrEqual(t, DisplayWidth(TruncateWidth("🚀🚀🚀🚀", 5)), 5)
+}
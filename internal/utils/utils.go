@@ -1,9 +1,6 @@
 package utils
 
 import (
-	"encoding/json"
-	"fmt"
-
 	"github.com/denkhaus/knot/v2/internal/types"
 	"github.com/google/uuid"
 )
@@ -34,26 +31,6 @@ func IsTaskReady(task *types.Task, taskMap map[uuid.UUID]*types.Task) bool {
 	return true
 }
 
-// outputTasksAsJSON outputs tasks in JSON format
-func OutputTasksAsJSON(tasks []*types.Task) error {
-	jsonData, err := json.MarshalIndent(tasks, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal tasks to JSON: %w", err)
-	}
-	fmt.Println(string(jsonData))
-	return nil
-}
-
-// OutputTaskAsJSON outputs a single task in JSON format
-func OutputTaskAsJSON(task *types.Task) error {
-	jsonData, err := json.MarshalIndent(task, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal task to JSON: %w", err)
-	}
-	fmt.Println(string(jsonData))
-	return nil
-}
-
 // ParsePriority converts string priority to TaskPriority int
 func ParsePriority(priority string) types.TaskPriority {
 	switch priority {
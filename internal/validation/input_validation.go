@@ -5,7 +5,8 @@ import (
 	"html"
 	"regexp"
 	"strings"
-	"unicode/utf8"
+
+	"github.com/denkhaus/knot/v2/internal/utils"
 )
 
 // InputValidator provides validation for user inputs
@@ -13,6 +14,27 @@ type InputValidator struct {
 	MaxTitleLength       int
 	MaxDescriptionLength int
 	AllowHTML            bool
+	// BannedPatterns reject titles and descriptions matching any of them
+	BannedPatterns []*regexp.Regexp
+}
+
+// Markup modes of Rules
+const (
+	// MarkupReject rejects HTML tags and script-like content (default)
+	MarkupReject = "reject"
+	// MarkupAllow accepts any markup
+	MarkupAllow = "allow"
+)
+
+// Rules configures an InputValidator. Zero values use the defaults of
+// NewInputValidator.
+type Rules struct {
+	MaxTitleLength       int
+	MaxDescriptionLength int
+	// Markup is MarkupReject or MarkupAllow
+	Markup string
+	// BannedPatterns are regular expressions titles and descriptions must not match
+	BannedPatterns []string
 }
 
 // NewInputValidator creates a new input validator with default limits
@@ -24,6 +46,40 @@ func NewInputValidator() *InputValidator {
 	}
 }
 
+// NewInputValidatorWithRules creates an input validator with configured rules
+func NewInputValidatorWithRules(rules Rules) (*InputValidator, error) {
+	v := NewInputValidator()
+	if rules.MaxTitleLength < 0 {
+		return nil, fmt.Errorf("max title length must not be negative, got %d", rules.MaxTitleLength)
+	}
+	if rules.MaxTitleLength > 0 {
+		v.MaxTitleLength = rules.MaxTitleLength
+	}
+	if rules.MaxDescriptionLength < 0 {
+		return nil, fmt.Errorf("max description length must not be negative, got %d", rules.MaxDescriptionLength)
+	}
+	if rules.MaxDescriptionLength > 0 {
+		v.MaxDescriptionLength = rules.MaxDescriptionLength
+	}
+
+	switch rules.Markup {
+	case "", MarkupReject:
+	case MarkupAllow:
+		v.AllowHTML = true
+	default:
+		return nil, fmt.Errorf("markup must be %q or %q, got %q", MarkupReject, MarkupAllow, rules.Markup)
+	}
+
+	for _, pattern := range rules.BannedPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid banned pattern %q: %w", pattern, err)
+		}
+		v.BannedPatterns = append(v.BannedPatterns, re)
+	}
+	return v, nil
+}
+
 // ValidateTaskTitle validates a task title
 func (v *InputValidator) ValidateTaskTitle(title string) error {
 	if title == "" {
@@ -31,15 +87,18 @@ func (v *InputValidator) ValidateTaskTitle(title string) error {
 	}
 
 	// Check length
-	if utf8.RuneCountInString(title) > v.MaxTitleLength {
+	if utils.TextLength(title) > v.MaxTitleLength {
 		return fmt.Errorf("title too long: %d characters (max: %d)",
-			utf8.RuneCountInString(title), v.MaxTitleLength)
+			utils.TextLength(title), v.MaxTitleLength)
 	}
 
 	// Check for dangerous content
 	if err := v.validateContent(title, "title"); err != nil {
 		return err
 	}
+	if err := v.checkBanned(title, "title"); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -52,15 +111,41 @@ func (v *InputValidator) ValidateTaskDescription(description string) error {
 	}
 
 	// Check length
-	if utf8.RuneCountInString(description) > v.MaxDescriptionLength {
+	if utils.TextLength(description) > v.MaxDescriptionLength {
 		return fmt.Errorf("description too long: %d characters (max: %d)",
-			utf8.RuneCountInString(description), v.MaxDescriptionLength)
+			utils.TextLength(description), v.MaxDescriptionLength)
 	}
 
 	// Check for dangerous content
 	if err := v.validateContent(description, "description"); err != nil {
 		return err
 	}
+	if err := v.checkBanned(description, "description"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateInstructions validates the agent instructions of a project or task,
+// which share the description limits
+func (v *InputValidator) ValidateInstructions(instructions string) error {
+	// Empty instructions are allowed, they remove the instructions
+	if instructions == "" {
+		return nil
+	}
+
+	if utils.TextLength(instructions) > v.MaxDescriptionLength {
+		return fmt.Errorf("instructions too long: %d characters (max: %d)",
+			utils.TextLength(instructions), v.MaxDescriptionLength)
+	}
+
+	if err := v.validateContent(instructions, "instructions"); err != nil {
+		return err
+	}
+	if err := v.checkBanned(instructions, "instructions"); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -72,15 +157,18 @@ func (v *InputValidator) ValidateProjectTitle(title string) error {
 	}
 
 	// Use same limits as task title
-	if utf8.RuneCountInString(title) > v.MaxTitleLength {
+	if utils.TextLength(title) > v.MaxTitleLength {
 		return fmt.Errorf("project title too long: %d characters (max: %d)",
-			utf8.RuneCountInString(title), v.MaxTitleLength)
+			utils.TextLength(title), v.MaxTitleLength)
 	}
 
 	// Check for dangerous content
 	if err := v.validateContent(title, "project title"); err != nil {
 		return err
 	}
+	if err := v.checkBanned(title, "project title"); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -93,15 +181,44 @@ func (v *InputValidator) ValidateProjectDescription(description string) error {
 	}
 
 	// Use same limits as task description
-	if utf8.RuneCountInString(description) > v.MaxDescriptionLength {
+	if utils.TextLength(description) > v.MaxDescriptionLength {
 		return fmt.Errorf("project description too long: %d characters (max: %d)",
-			utf8.RuneCountInString(description), v.MaxDescriptionLength)
+			utils.TextLength(description), v.MaxDescriptionLength)
 	}
 
 	// Check for dangerous content
 	if err := v.validateContent(description, "project description"); err != nil {
 		return err
 	}
+	if err := v.checkBanned(description, "project description"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MaxProjectDocumentLength caps project documents, which are long-form text
+// beyond the description limit
+const MaxProjectDocumentLength = 100000
+
+// ValidateProjectDocument validates a project document
+func (v *InputValidator) ValidateProjectDocument(document string) error {
+	// Empty document is allowed, it removes the document
+	if document == "" {
+		return nil
+	}
+
+	if utils.TextLength(document) > MaxProjectDocumentLength {
+		return fmt.Errorf("project document too long: %d characters (max: %d)",
+			utils.TextLength(document), MaxProjectDocumentLength)
+	}
+
+	if err := v.validateContent(document, "project document"); err != nil {
+		return err
+	}
+	if err := v.checkBanned(document, "project document"); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -130,6 +247,16 @@ func (v *InputValidator) validateContent(content, fieldName string) error {
 	return nil
 }
 
+// checkBanned rejects content matching one of the banned patterns
+func (v *InputValidator) checkBanned(content, fieldName string) error {
+	for _, pattern := range v.BannedPatterns {
+		if pattern.MatchString(content) {
+			return fmt.Errorf("%s matches the banned pattern %q", fieldName, pattern.String())
+		}
+	}
+	return nil
+}
+
 // checkForHTML detects and blocks HTML-like content
 func (v *InputValidator) checkForHTML(content, fieldName string) error {
 	// Simple HTML tag detection
@@ -194,9 +321,9 @@ func (v *InputValidator) ValidateActor(actor string) error {
 	}
 
 	// Reasonable limit for actor names
-	if utf8.RuneCountInString(actor) > 100 {
+	if utils.TextLength(actor) > 100 {
 		return fmt.Errorf("actor name too long: %d characters (max: 100)",
-			utf8.RuneCountInString(actor))
+			utils.TextLength(actor))
 	}
 
 	// Check for dangerous content
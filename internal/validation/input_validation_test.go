@@ -628,3 +628,36 @@ func BenchmarkValidateComplexity(b *testing.B) {
 		_ = validator.ValidateComplexity(5)
 	}
 }
+
+func TestNewInputValidatorWithRules(t *testing.T) {
+	validator, err := NewInputValidatorWithRules(Rules{})
+	assert.NoError(t, err)
+	assert.Equal(t, NewInputValidator(), validator, "zero rules use the defaults")
+
+	validator, err = NewInputValidatorWithRules(Rules{
+		MaxTitleLength:       20,
+		MaxDescriptionLength: 50,
+		Markup:               MarkupAllow,
+		BannedPatterns:       []string{`(?i)secret`, `^WIP`},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 20, validator.MaxTitleLength)
+	assert.Equal(t, 50, validator.MaxDescriptionLength)
+	assert.True(t, validator.AllowHTML)
+
+	assert.NoError(t, validator.ValidateTaskTitle("<b>Ship</b>"))
+	assert.ErrorContains(t, validator.ValidateTaskTitle("WIP: ship"), `title matches the banned pattern "^WIP"`)
+	assert.ErrorContains(t, validator.ValidateTaskDescription("the SECRET key"), "description matches the banned pattern")
+	assert.ErrorContains(t, validator.ValidateProjectTitle("Secret project"), "project title matches")
+	assert.ErrorContains(t, validator.ValidateProjectDescription("secret"), "project description matches")
+	assert.NoError(t, validator.ValidateActor("secret-agent"), "actor names are not checked against banned patterns")
+
+	for _, rules := range []Rules{
+		{MaxTitleLength: -1},
+		{Markup: "markdown"},
+		{BannedPatterns: []string{"("}},
+	} {
+		_, err := NewInputValidatorWithRules(rules)
+		assert.Error(t, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/denkhaus/knot/v2/internal/errors"
+	"github.com/denkhaus/knot/v2/internal/output"
 	"github.com/denkhaus/knot/v2/internal/types"
 )
 
@@ -109,14 +110,14 @@ func (sv *StateValidator) addValidationRules() {
 		},
 		{
 			Name:        "blocked_requires_dependencies",
-			Description: "Tasks should only be blocked if they have unmet dependencies",
+			Description: "Tasks should only be blocked if they have unmet dependencies or an external reason",
 			Validate: func(from, to types.TaskState, task *types.Task) error {
-				if to == types.TaskStateBlocked && len(task.Dependencies) == 0 {
+				if to == types.TaskStateBlocked && len(task.Dependencies) == 0 && task.Blocker == nil {
 					return &errors.EnhancedError{
 						Operation:   "validating state transition",
 						Cause:       fmt.Errorf("cannot block task without dependencies"),
-						Suggestion:  "Add dependencies first, or use a different state like pending",
-						Example:     "knot dependency add --task-id " + task.ID.String() + " --depends-on <dependency-id>",
+						Suggestion:  "Add dependencies first, give an external reason with --reason, or use a different state like pending",
+						Example:     "knot task update-state --id " + task.ID.String() + " --state blocked --reason \"waiting for vendor\"",
 						HelpCommand: "knot dependency --help",
 					}
 				}
@@ -216,9 +217,9 @@ func (sv *StateValidator) ValidateTransitionLenient(from, to types.TaskState, ta
 		if err := rule.Validate(from, to, task); err != nil {
 			// Convert errors to warnings in lenient mode
 			if enhancedErr, ok := err.(*errors.EnhancedError); ok {
-				warnings = append(warnings, fmt.Sprintf("⚠️  %s: %s", rule.Name, enhancedErr.Suggestion))
+				warnings = append(warnings, fmt.Sprintf("%s%s: %s", output.Icon("⚠️"), rule.Name, enhancedErr.Suggestion))
 			} else {
-				warnings = append(warnings, fmt.Sprintf("⚠️  %s: %s", rule.Name, err.Error()))
+				warnings = append(warnings, fmt.Sprintf("%s%s: %s", output.Icon("⚠️"), rule.Name, err.Error()))
 			}
 		}
 	}
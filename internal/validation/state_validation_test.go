@@ -27,6 +27,8 @@ func TestValidationErrorHandling(t *testing.T) {
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
+	externallyBlocked := *task
+	externallyBlocked.Blocker = &types.TaskBlocker{Reason: "waiting for vendor"}
 
 	tests := []struct {
 		name          string
@@ -74,6 +76,22 @@ func TestValidationErrorHandling(t *testing.T) {
 			task:        task,
 			expectError: false,
 		},
+		{
+			name:          "Invalid state transition - blocked without dependencies",
+			fromState:     types.TaskStatePending,
+			toState:       types.TaskStateBlocked,
+			task:          task,
+			expectError:   true,
+			errorType:     "*errors.EnhancedError",
+			errorContains: []string{"without dependencies", "--reason"},
+		},
+		{
+			name:        "Valid state transition - blocked for an external reason",
+			fromState:   types.TaskStatePending,
+			toState:     types.TaskStateBlocked,
+			task:        &externallyBlocked,
+			expectError: false,
+		},
 	}
 
 	for _, tt := range tests {
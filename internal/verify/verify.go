@@ -0,0 +1,106 @@
+// Package verify runs the check commands that verify a task is done, such as
+// its acceptance tests, and captures their outcome for the task's history.
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// DefaultTimeout bounds a check run unless another timeout is given
+const DefaultTimeout = 10 * time.Minute
+
+// MaxOutput is the number of bytes kept of the end of a check's output
+const MaxOutput = 4096
+
+// Run runs command with the system shell in dir and returns its outcome. The
+// combined output is copied to w, if not nil, and its end is kept in the run.
+// Commands that cannot be started or exceed the timeout fail with exit code -1.
+func Run(ctx context.Context, command, dir string, timeout time.Duration, w io.Writer) types.CheckRun {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.CommandContext(ctx, shell, flag, command)
+	cmd.Dir = dir
+	// Background processes of the command must not keep the run alive
+	cmd.WaitDelay = time.Second
+
+	tail := &tailBuffer{max: MaxOutput}
+	var out io.Writer = tail
+	if w != nil {
+		out = io.MultiWriter(tail, w)
+	}
+	cmd.Stdout, cmd.Stderr = out, out
+
+	start := time.Now()
+	err := cmd.Run()
+	run := types.CheckRun{DurationMs: time.Since(start).Milliseconds()}
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		run.ExitCode = -1
+		fmt.Fprintf(tail, "\ncheck timed out after %s", timeout)
+	case errors.As(err, &exitErr):
+		run.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		run.ExitCode = -1
+		fmt.Fprintf(tail, "\n%v", err)
+	}
+	run.Passed = err == nil
+	run.Output = tail.String()
+	return run
+}
+
+// tailBuffer keeps the last max bytes written to it
+type tailBuffer struct {
+	max       int
+	data      []byte
+	truncated bool
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.data = append(b.data, p...)
+	if len(b.data) > b.max {
+		b.data = b.data[len(b.data)-b.max:]
+		b.truncated = true
+	}
+	return len(p), nil
+}
+
+// String returns the kept output, marking a cut off beginning with "..."
+func (b *tailBuffer) String() string {
+	s := strings.TrimSpace(strings.ToValidUTF8(string(b.data), ""))
+	if b.truncated {
+		return "..." + s
+	}
+	return s
+}
+
+// FailedError reports a check run that did not pass. Commands return it to
+// exit non-zero; the run is bounded by its own timeout, not the command's.
+type FailedError struct {
+	Run types.CheckRun
+}
+
+func (e *FailedError) Error() string {
+	if e.Run.ExitCode < 0 {
+		return "task check did not run to completion"
+	}
+	return fmt.Sprintf("task check failed with exit code %d", e.Run.ExitCode)
+}
@@ -0,0 +1,48 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands need a POSIX shell")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	var streamed bytes.Buffer
+	run := Run(ctx, "echo ok; pwd", dir, time.Minute, &streamed)
+	assert.True(t, run.Passed)
+	assert.Equal(t, 0, run.ExitCode)
+	assert.Contains(t, run.Output, "ok")
+	assert.Contains(t, run.Output, dir)
+	assert.Contains(t, streamed.String(), "ok")
+
+	run = Run(ctx, "echo broken >&2; exit 3", dir, time.Minute, nil)
+	assert.False(t, run.Passed)
+	assert.Equal(t, 3, run.ExitCode)
+	assert.Equal(t, "broken", run.Output)
+
+	run = Run(ctx, "sleep 5", dir, 100*time.Millisecond, nil)
+	assert.False(t, run.Passed)
+	assert.Equal(t, -1, run.ExitCode)
+	assert.Contains(t, run.Output, "check timed out after 100ms")
+	assert.Less(t, run.DurationMs, int64(5000))
+}
+
+func TestTailBuffer(t *testing.T) {
+	b := &tailBuffer{max: 10}
+	b.Write([]byte("hello "))
+	assert.Equal(t, "hello", b.String())
+
+	b.Write([]byte(strings.Repeat("x", 8) + "end"))
+	assert.Equal(t, "...xxxxxxxend", b.String())
+}
@@ -72,6 +72,8 @@ func (Actionable) TestStrategies() error {
 		{"depth-first", "Completes subtasks first", "hierarchy"},
 		{"creation-order", "Oldest tasks first", "creation"},
 		{"critical-path", "Project timeline focus", "critical"},
+		{"shortest-job-first", "Quick wins first", "shortest"},
+		{"highest-value", "Best priority to complexity ratio", "value"},
 	}
 
 	var successCount, totalCount int
@@ -0,0 +1,39 @@
+// Package knot is the public Go API for embedding knot project and task
+// management in other programs, without shelling out to the knot CLI.
+//
+// A Client wraps the same business logic the CLI uses: input validation,
+// state transition rules, dependency cycle detection, hierarchy limits and
+// automatic complexity reduction. Storage is selected with a DSN, exactly like
+// the KNOT_DATABASE environment variable of the CLI:
+//
+//	client, err := knot.Open(knot.WithDSN("sqlite:///var/lib/myapp/knot.db"))
+//	if err != nil {
+//		return err
+//	}
+//	defer client.Close()
+//
+//	project, err := client.CreateProject(ctx, "Release 2.0", "Everything for the release")
+//	task, err := client.CreateTask(ctx, project.ID, knot.NewTask{
+//		Title:      "Write changelog",
+//		Complexity: 3,
+//	})
+//
+// # Stability
+//
+// Package knot follows semantic versioning together with the module
+// (github.com/denkhaus/knot/v2):
+//
+//   - Exported functions, methods, types and constants of this package are not
+//     removed or changed incompatibly within a major version.
+//   - Request structs such as NewTask may gain new optional fields; their zero
+//     value always keeps the previous behavior. Use keyed struct literals.
+//   - Domain types (Project, Task, ...) are aliases of knot's internal model.
+//     Their existing fields are covered by the same guarantee; new fields may be
+//     added.
+//   - Error messages are not part of the API and may be reworded.
+//   - Repository, the interface of storage drivers registered with Register,
+//     may gain methods as knot gains features. Drivers that embed a
+//     Repository opened with OpenRepository keep compiling.
+//
+// Everything under internal/ may change at any time and is not covered.
+package knot
@@ -0,0 +1,50 @@
+package knot
+
+import (
+	"github.com/denkhaus/knot/v2/internal/repository"
+	"github.com/denkhaus/knot/v2/internal/types"
+)
+
+// Storage drivers
+type (
+	// Repository is the interface a storage backend implements
+	Repository = types.Repository
+	// DriverOptions are the driver independent settings passed to every driver
+	DriverOptions = repository.Options
+	// OpenFunc opens a repository. location is the part of the DSN after the
+	// driver prefix and may be empty to request the driver's default location.
+	OpenFunc = repository.OpenFunc
+	// StatementObserver is told about the statements of drivers that run SQL
+	StatementObserver = repository.StatementObserver
+)
+
+// Types in the method signatures of Repository
+type (
+	DependencyLink      = types.DependencyLink
+	PageRequest         = types.PageRequest
+	ProjectLock         = types.ProjectLock
+	ScheduledTransition = types.ScheduledTransition
+	SubtreeCounts       = types.SubtreeCounts
+	TaskFilter          = types.TaskFilter
+	TaskPage            = types.TaskPage
+)
+
+// Register makes a storage driver available under the given name, for
+// WithDSN as well as for the KNOT_DATABASE variable of a knot CLI built with
+// the driver. It is meant to be called from an init function, like
+// database/sql drivers, and panics if open is nil or the name is already
+// registered.
+func Register(name string, open OpenFunc) {
+	repository.Register(name, open)
+}
+
+// Drivers returns the sorted names of the registered storage drivers
+func Drivers() []string {
+	return repository.Drivers()
+}
+
+// OpenRepository opens the repository described by dsn with the registered
+// driver. Custom drivers can use it to build on one of the built-in backends.
+func OpenRepository(dsn string, opts DriverOptions) (Repository, error) {
+	return repository.Open(dsn, opts)
+}
@@ -0,0 +1,119 @@
+package knot_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/denkhaus/knot/v2/pkg/knot"
+)
+
+func Example() {
+	ctx := context.Background()
+
+	client, err := knot.Open(knot.WithDSN("inmemory://"), knot.WithActor("release-bot"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	project, err := client.CreateProject(ctx, "Release 2.0", "Everything needed for the release")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	changelog, err := client.CreateTask(ctx, project.ID, knot.NewTask{Title: "Write changelog", Complexity: 2})
+	if err != nil {
+		log.Fatal(err)
+	}
+	publish, err := client.CreateTask(ctx, project.ID, knot.NewTask{Title: "Publish release", Priority: knot.TaskPriorityHigh})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Publishing has to wait for the changelog
+	if _, err := client.AddDependency(ctx, publish.ID, changelog.ID); err != nil {
+		log.Fatal(err)
+	}
+
+	next, err := client.NextTask(ctx, project.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("next:", next.Title)
+
+	if _, err := client.UpdateTaskState(ctx, changelog.ID, knot.TaskStateInProgress); err != nil {
+		log.Fatal(err)
+	}
+	if _, err := client.UpdateTaskState(ctx, changelog.ID, knot.TaskStateCompleted); err != nil {
+		log.Fatal(err)
+	}
+
+	next, err = client.NextTask(ctx, project.ID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("next:", next.Title)
+	// Output:
+	// next: Write changelog
+	// next: Publish release
+}
+
+func ExampleClient_CreateTask_subtasks() {
+	ctx := context.Background()
+
+	client, err := knot.Open(knot.WithDSN("inmemory://"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	project, _ := client.CreateProject(ctx, "Website", "")
+	parent, _ := client.CreateTask(ctx, project.ID, knot.NewTask{Title: "Redesign", Complexity: 8})
+	for _, title := range []string{"Wireframes", "Implement layout"} {
+		if _, err := client.CreateTask(ctx, project.ID, knot.NewTask{ParentID: &parent.ID, Title: title}); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	subtasks, _ := client.ListSubtasks(ctx, parent.ID)
+	fmt.Println("subtasks:", len(subtasks))
+	// Output:
+	// subtasks: 2
+}
+
+// auditRepository is a custom storage backend that reports every task it
+// stores and keeps the tasks in the in-memory backend
+type auditRepository struct {
+	knot.Repository
+}
+
+func (r *auditRepository) CreateTask(ctx context.Context, task *knot.Task) error {
+	fmt.Println("storing:", task.Title)
+	return r.Repository.CreateTask(ctx, task)
+}
+
+func ExampleRegister() {
+	// Custom drivers usually register themselves in an init function
+	knot.Register("audit", func(location string, opts knot.DriverOptions) (knot.Repository, error) {
+		repo, err := knot.OpenRepository("inmemory://", opts)
+		if err != nil {
+			return nil, err
+		}
+		return &auditRepository{Repository: repo}, nil
+	})
+
+	ctx := context.Background()
+	client, err := knot.Open(knot.WithDSN("audit://"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	project, _ := client.CreateProject(ctx, "Audited", "")
+	if _, err := client.CreateTask(ctx, project.ID, knot.NewTask{Title: "Review access"}); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// storing: Review access
+}
@@ -0,0 +1,247 @@
+package knot
+
+import (
+	"context"
+	"io"
+
+	"github.com/denkhaus/knot/v2/internal/manager"
+	"github.com/denkhaus/knot/v2/internal/repository"
+	"github.com/denkhaus/knot/v2/internal/types"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	// Built-in storage drivers
+	_ "github.com/denkhaus/knot/v2/internal/repository/inmemory"
+	_ "github.com/denkhaus/knot/v2/internal/repository/sqlite"
+)
+
+// Domain types shared with the knot CLI
+type (
+	Project         = types.Project
+	ProjectState    = types.ProjectState
+	ProjectProgress = types.ProjectProgress
+	Task            = types.Task
+	TaskState       = types.TaskState
+	TaskPriority    = types.TaskPriority
+	Config          = manager.Config
+	Clock           = types.Clock
+)
+
+// Task states
+const (
+	TaskStatePending         = types.TaskStatePending
+	TaskStateInProgress      = types.TaskStateInProgress
+	TaskStateCompleted       = types.TaskStateCompleted
+	TaskStateBlocked         = types.TaskStateBlocked
+	TaskStateCancelled       = types.TaskStateCancelled
+	TaskStateDeletionPending = types.TaskStateDeletionPending
+)
+
+// Task priorities
+const (
+	TaskPriorityHigh   = types.TaskPriorityHigh
+	TaskPriorityMedium = types.TaskPriorityMedium
+	TaskPriorityLow    = types.TaskPriorityLow
+)
+
+// DefaultComplexity is used for new tasks that do not set a complexity
+const DefaultComplexity = 5
+
+// DefaultActor is recorded in the audit trail unless WithActor is used
+const DefaultActor = "knot-sdk"
+
+// DefaultConfig returns the default hierarchy and complexity limits
+func DefaultConfig() *Config {
+	return manager.DefaultConfig()
+}
+
+// Client provides project and task management on top of a storage backend.
+// A Client is safe for concurrent use.
+type Client struct {
+	manager manager.ProjectManager
+	repo    types.Repository
+	actor   string
+}
+
+type options struct {
+	dsn    string
+	logger *zap.Logger
+	config *Config
+	actor  string
+	clock  Clock
+}
+
+// Option configures a Client
+type Option func(*options)
+
+// WithDSN selects the storage backend, e.g. "sqlite:///path/to/knot.db" or
+// "inmemory://". The default is SQLite in the .knot directory of the current
+// working directory, the same database the CLI uses.
+func WithDSN(dsn string) Option {
+	return func(o *options) {
+		o.dsn = dsn
+	}
+}
+
+// WithLogger sets the logger used by the storage backend
+func WithLogger(logger *zap.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithConfig sets hierarchy and complexity limits, see DefaultConfig
+func WithConfig(config *Config) Option {
+	return func(o *options) {
+		o.config = config
+	}
+}
+
+// WithActor sets the actor recorded in the audit trail for changes
+func WithActor(actor string) Option {
+	return func(o *options) {
+		o.actor = actor
+	}
+}
+
+// WithClock sets the clock of the in-memory storage, which then also
+// timestamps the changes and expires the project locks of the Client. Other
+// storage backends use the system clock. See package knottest for a fake clock.
+func WithClock(clock Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// Open creates a Client for the configured storage backend
+func Open(opts ...Option) (*Client, error) {
+	o := &options{
+		logger: zap.NewNop(),
+		config: DefaultConfig(),
+		actor:  DefaultActor,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	repo, err := repository.Open(o.dsn, repository.Options{
+		Logger:      o.logger,
+		AutoMigrate: true,
+		Clock:       o.clock,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		manager: manager.NewManagerWithRepository(repo, o.config),
+		repo:    repo,
+		actor:   o.actor,
+	}, nil
+}
+
+// Close releases the storage backend
+func (c *Client) Close() error {
+	if closer, ok := c.repo.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// writer attaches the client actor to ctx, so writes to projects locked by
+// another actor are rejected
+func (c *Client) writer(ctx context.Context) context.Context {
+	return manager.WithWriter(ctx, c.actor, false)
+}
+
+// CreateProject creates a new project
+func (c *Client) CreateProject(ctx context.Context, title, description string) (*Project, error) {
+	return c.manager.CreateProject(ctx, title, description, c.actor)
+}
+
+// GetProject returns the project with the given ID
+func (c *Client) GetProject(ctx context.Context, projectID uuid.UUID) (*Project, error) {
+	return c.manager.GetProject(ctx, projectID)
+}
+
+// ListProjects returns all projects
+func (c *Client) ListProjects(ctx context.Context) ([]*Project, error) {
+	return c.manager.ListProjects(ctx)
+}
+
+// DeleteProject deletes a project and all of its tasks
+func (c *Client) DeleteProject(ctx context.Context, projectID uuid.UUID) error {
+	return c.manager.DeleteProject(c.writer(ctx), projectID)
+}
+
+// GetProjectProgress returns task counts and completion of a project
+func (c *Client) GetProjectProgress(ctx context.Context, projectID uuid.UUID) (*ProjectProgress, error) {
+	return c.manager.GetProjectProgress(ctx, projectID)
+}
+
+// NewTask describes a task to create. Fields may be added in future versions;
+// their zero value keeps the current behavior.
+type NewTask struct {
+	// ParentID creates the task as a subtask, nil creates a root task
+	ParentID    *uuid.UUID
+	Title       string
+	Description string
+	// Complexity from 1 to 10, 0 means DefaultComplexity
+	Complexity int
+	// Priority, 0 means TaskPriorityMedium
+	Priority TaskPriority
+}
+
+// CreateTask creates a task in the given project
+func (c *Client) CreateTask(ctx context.Context, projectID uuid.UUID, task NewTask) (*Task, error) {
+	complexity := task.Complexity
+	if complexity == 0 {
+		complexity = DefaultComplexity
+	}
+	priority := task.Priority
+	if priority == 0 {
+		priority = TaskPriorityMedium
+	}
+	return c.manager.CreateTask(c.writer(ctx), projectID, task.ParentID, task.Title, task.Description, complexity, priority, c.actor)
+}
+
+// GetTask returns the task with the given ID
+func (c *Client) GetTask(ctx context.Context, taskID uuid.UUID) (*Task, error) {
+	return c.manager.GetTask(ctx, taskID)
+}
+
+// ListTasks returns all tasks of a project
+func (c *Client) ListTasks(ctx context.Context, projectID uuid.UUID) ([]*Task, error) {
+	return c.manager.ListTasksForProject(ctx, projectID)
+}
+
+// ListSubtasks returns the direct subtasks of a task
+func (c *Client) ListSubtasks(ctx context.Context, taskID uuid.UUID) ([]*Task, error) {
+	return c.manager.GetChildTasks(ctx, taskID)
+}
+
+// UpdateTaskState moves a task to a new state, enforcing the transition rules
+func (c *Client) UpdateTaskState(ctx context.Context, taskID uuid.UUID, state TaskState) (*Task, error) {
+	return c.manager.UpdateTaskState(c.writer(ctx), taskID, state, c.actor)
+}
+
+// DeleteTask deletes a task without subtasks
+func (c *Client) DeleteTask(ctx context.Context, taskID uuid.UUID) error {
+	return c.manager.DeleteTask(c.writer(ctx), taskID, c.actor)
+}
+
+// AddDependency makes taskID depend on dependsOnID. Cycles are rejected.
+func (c *Client) AddDependency(ctx context.Context, taskID, dependsOnID uuid.UUID) (*Task, error) {
+	return c.manager.AddTaskDependency(c.writer(ctx), taskID, dependsOnID, c.actor)
+}
+
+// RemoveDependency removes the dependency of taskID on dependsOnID
+func (c *Client) RemoveDependency(ctx context.Context, taskID, dependsOnID uuid.UUID) (*Task, error) {
+	return c.manager.RemoveTaskDependency(c.writer(ctx), taskID, dependsOnID, c.actor)
+}
+
+// NextTask returns the next task to work on: an in-progress task or a pending
+// task whose dependencies are all completed
+func (c *Client) NextTask(ctx context.Context, projectID uuid.UUID) (*Task, error) {
+	return c.manager.FindNextActionableTask(ctx, projectID)
+}
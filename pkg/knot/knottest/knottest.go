@@ -0,0 +1,77 @@
+// Package knottest provides helpers for testing code built on package knot
+// without a database.
+//
+// NewClient returns a Client backed by in-memory storage that behaves like
+// the SQLite storage of the knot CLI, including its validation and errors.
+// Time is controlled by a FakeClock, so due dates, lock expiry and
+// timestamps are deterministic:
+//
+//	func TestRelease(t *testing.T) {
+//		clock := knottest.NewFakeClock(time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC))
+//		client := knottest.NewClient(t, clock)
+//
+//		project, err := client.CreateProject(ctx, "Release", "")
+//		...
+//		clock.Advance(24 * time.Hour)
+//	}
+package knottest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/pkg/knot"
+)
+
+// FakeClock is a knot.Clock that only moves when told to. A FakeClock is
+// safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a FakeClock standing at start
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the current time of the clock
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to t
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// NewClient opens a Client on fresh in-memory storage, which is closed when
+// the test ends. The client uses clock if it is not nil, the system clock
+// otherwise. Further options such as knot.WithActor are applied after the
+// storage options.
+func NewClient(t testing.TB, clock *FakeClock, opts ...knot.Option) *knot.Client {
+	t.Helper()
+
+	options := []knot.Option{knot.WithDSN("inmemory://")}
+	if clock != nil {
+		options = append(options, knot.WithClock(clock))
+	}
+	client, err := knot.Open(append(options, opts...)...)
+	if err != nil {
+		t.Fatalf("knottest: failed to open client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
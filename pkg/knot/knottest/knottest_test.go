@@ -0,0 +1,58 @@
+package knottest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/denkhaus/knot/v2/pkg/knot"
+	"github.com/denkhaus/knot/v2/pkg/knot/knottest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	clock := knottest.NewFakeClock(start)
+	client := knottest.NewClient(t, clock, knot.WithActor("tester"))
+
+	project, err := client.CreateProject(ctx, "Release", "")
+	require.NoError(t, err)
+	task, err := client.CreateTask(ctx, project.ID, knot.NewTask{Title: "Write changelog"})
+	require.NoError(t, err)
+	assert.True(t, task.CreatedAt.Equal(start))
+
+	clock.Advance(90 * time.Minute)
+	_, err = client.UpdateTaskState(ctx, task.ID, knot.TaskStateInProgress)
+	require.NoError(t, err)
+	done, err := client.UpdateTaskState(ctx, task.ID, knot.TaskStateCompleted)
+	require.NoError(t, err)
+
+	assert.True(t, done.UpdatedAt.Equal(start.Add(90*time.Minute)))
+	require.NotNil(t, done.CompletedAt)
+	assert.True(t, done.CompletedAt.Equal(start.Add(90*time.Minute)))
+
+	t.Run("storage is not shared between clients", func(t *testing.T) {
+		projects, err := knottest.NewClient(t, nil).ListProjects(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, projects)
+	})
+
+	t.Run("storage validates like SQLite", func(t *testing.T) {
+		_, err := client.AddDependency(ctx, task.ID, task.ID)
+		assert.Error(t, err)
+	})
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	clock := knottest.NewFakeClock(start)
+	assert.Equal(t, start, clock.Now())
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour), clock.Now())
+
+	clock.Set(start)
+	assert.Equal(t, start, clock.Now())
+}